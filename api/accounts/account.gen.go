@@ -24,6 +24,7 @@ type Account struct {
 	AuthMethodId      string                 `json:"auth_method_id,omitempty"`
 	Attributes        map[string]interface{} `json:"attributes,omitempty"`
 	ManagedGroupIds   []string               `json:"managed_group_ids,omitempty"`
+	Disabled          bool                   `json:"disabled,omitempty"`
 	AuthorizedActions []string               `json:"authorized_actions,omitempty"`
 
 	response *api.Response
@@ -1,7 +1,15 @@
 // Code generated by "make api"; DO NOT EDIT.
 package accounts
 
+import (
+	"time"
+)
+
 type PasswordAccountAttributes struct {
-	LoginName string `json:"login_name,omitempty"`
-	Password  string `json:"password,omitempty"`
+	LoginName          string    `json:"login_name,omitempty"`
+	Password           string    `json:"password,omitempty"`
+	FailedLoginCount   uint32    `json:"failed_login_count,omitempty"`
+	LockExpirationTime time.Time `json:"lock_expiration_time,omitempty"`
+	IsLocked           bool      `json:"is_locked,omitempty"`
+	IsTotpEnrolled     bool      `json:"is_totp_enrolled,omitempty"`
 }
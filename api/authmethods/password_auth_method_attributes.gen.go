@@ -2,6 +2,17 @@
 package authmethods
 
 type PasswordAuthMethodAttributes struct {
-	MinLoginNameLength uint32 `json:"min_login_name_length,omitempty"`
-	MinPasswordLength  uint32 `json:"min_password_length,omitempty"`
+	MinLoginNameLength       uint32 `json:"min_login_name_length,omitempty"`
+	MinPasswordLength        uint32 `json:"min_password_length,omitempty"`
+	PasswordRequireLowercase bool   `json:"password_require_lowercase,omitempty"`
+	PasswordRequireUppercase bool   `json:"password_require_uppercase,omitempty"`
+	PasswordRequireDigit     bool   `json:"password_require_digit,omitempty"`
+	PasswordRequireSymbol    bool   `json:"password_require_symbol,omitempty"`
+	DenyCommonPasswords      bool   `json:"deny_common_passwords,omitempty"`
+	LockoutThreshold         uint32 `json:"lockout_threshold,omitempty"`
+	LockoutWindowSeconds     uint32 `json:"lockout_window_seconds,omitempty"`
+	LockoutDurationSeconds   uint32 `json:"lockout_duration_seconds,omitempty"`
+	PasswordHistoryCount     uint32 `json:"password_history_count,omitempty"`
+	MaxPasswordAgeDays       uint32 `json:"max_password_age_days,omitempty"`
+	TotpMfa                  string `json:"totp_mfa,omitempty"`
 }
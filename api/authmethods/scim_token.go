@@ -0,0 +1,103 @@
+package authmethods
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// RotateScimTokenResult is the result of rotating the SCIM token of an auth method.
+type RotateScimTokenResult struct {
+	ScimToken string `json:"scim_token,omitempty"`
+	ScimPath  string `json:"scim_path,omitempty"`
+	response  *api.Response
+}
+
+func (n RotateScimTokenResult) GetItem() interface{} {
+	return n
+}
+
+func (n RotateScimTokenResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// DeleteScimTokenResult is the result of deleting the SCIM token of an auth method.
+type DeleteScimTokenResult struct {
+	response *api.Response
+}
+
+func (n DeleteScimTokenResult) GetItem() interface{} {
+	return nil
+}
+
+func (n DeleteScimTokenResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// RotateScimToken generates a new SCIM provisioning token for an auth method.
+// Any existing token of the auth method stops working. The token is only
+// returned by this call.
+func (c *Client) RotateScimToken(ctx context.Context, authMethodId string, opt ...Option) (*RotateScimTokenResult, error) {
+	if authMethodId == "" {
+		return nil, fmt.Errorf("empty authMethodId value passed into RotateScimToken request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in RotateScimToken request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("auth-methods/%s:rotate-scim-token", authMethodId), map[string]interface{}{}, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RotateScimToken request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during RotateScimToken call: %w", err)
+	}
+
+	target := new(RotateScimTokenResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RotateScimToken response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+// DeleteScimToken deletes the SCIM provisioning token of an auth method,
+// disabling SCIM provisioning through it.
+func (c *Client) DeleteScimToken(ctx context.Context, authMethodId string, opt ...Option) (*DeleteScimTokenResult, error) {
+	if authMethodId == "" {
+		return nil, fmt.Errorf("empty authMethodId value passed into DeleteScimToken request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in DeleteScimToken request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("auth-methods/%s:delete-scim-token", authMethodId), map[string]interface{}{}, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating DeleteScimToken request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during DeleteScimToken call: %w", err)
+	}
+
+	apiErr, err := resp.Decode(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding DeleteScimToken response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return &DeleteScimTokenResult{response: resp}, nil
+}
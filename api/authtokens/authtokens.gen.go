@@ -23,6 +23,12 @@ type AuthToken struct {
 	UpdatedTime             time.Time         `json:"updated_time,omitempty"`
 	ApproximateLastUsedTime time.Time         `json:"approximate_last_used_time,omitempty"`
 	ExpirationTime          time.Time         `json:"expiration_time,omitempty"`
+	Description             string            `json:"description,omitempty"`
+	ParentId                string            `json:"parent_id,omitempty"`
+	GrantStrings            []string          `json:"grant_strings,omitempty"`
+	GrantScopeIds           []string          `json:"grant_scope_ids,omitempty"`
+	TimeToLiveSeconds       uint32            `json:"time_to_live_seconds,omitempty"`
+	ServiceAccountId        string            `json:"service_account_id,omitempty"`
 	AuthorizedActions       []string          `json:"authorized_actions,omitempty"`
 
 	response *api.Response
@@ -90,6 +96,50 @@ func (c *Client) ApiClient() *api.Client {
 	return c.client
 }
 
+func (c *Client) Create(ctx context.Context, scopeId string, opt ...Option) (*AuthTokenCreateResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into Create request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts.postMap["scope_id"] = scopeId
+
+	req, err := c.client.NewRequest(ctx, "POST", "auth-tokens", opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Create request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Create call: %w", err)
+	}
+
+	target := new(AuthTokenCreateResult)
+	target.Item = new(AuthToken)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Create response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
 func (c *Client) Read(ctx context.Context, id string, opt ...Option) (*AuthTokenReadResult, error) {
 	if id == "" {
 		return nil, fmt.Errorf("empty id value passed into Read request")
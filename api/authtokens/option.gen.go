@@ -86,3 +86,51 @@ func WithRecursive(recurse bool) Option {
 		o.withRecursive = true
 	}
 }
+
+func WithDescription(inDescription string) Option {
+	return func(o *options) {
+		o.postMap["description"] = inDescription
+	}
+}
+
+func DefaultDescription() Option {
+	return func(o *options) {
+		o.postMap["description"] = nil
+	}
+}
+
+func WithGrantScopeIds(inGrantScopeIds []string) Option {
+	return func(o *options) {
+		o.postMap["grant_scope_ids"] = inGrantScopeIds
+	}
+}
+
+func DefaultGrantScopeIds() Option {
+	return func(o *options) {
+		o.postMap["grant_scope_ids"] = nil
+	}
+}
+
+func WithGrantStrings(inGrantStrings []string) Option {
+	return func(o *options) {
+		o.postMap["grant_strings"] = inGrantStrings
+	}
+}
+
+func DefaultGrantStrings() Option {
+	return func(o *options) {
+		o.postMap["grant_strings"] = nil
+	}
+}
+
+func WithTimeToLiveSeconds(inTimeToLiveSeconds uint32) Option {
+	return func(o *options) {
+		o.postMap["time_to_live_seconds"] = inTimeToLiveSeconds
+	}
+}
+
+func DefaultTimeToLiveSeconds() Option {
+	return func(o *options) {
+		o.postMap["time_to_live_seconds"] = nil
+	}
+}
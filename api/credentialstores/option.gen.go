@@ -99,6 +99,54 @@ func WithVaultCredentialStoreAddress(inAddress string) Option {
 	}
 }
 
+func WithVaultCredentialStoreApproleRoleId(inApproleRoleId string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["approle_role_id"] = inApproleRoleId
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultVaultCredentialStoreApproleRoleId() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["approle_role_id"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithVaultCredentialStoreApproleSecretId(inApproleSecretId string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["approle_secret_id"] = inApproleSecretId
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultVaultCredentialStoreApproleSecretId() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["approle_secret_id"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithAttributes(inAttributes map[string]interface{}) Option {
 	return func(o *options) {
 		o.postMap["attributes"] = inAttributes
@@ -111,6 +159,54 @@ func DefaultAttributes() Option {
 	}
 }
 
+func WithVaultCredentialStoreAuthMethod(inAuthMethod string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["auth_method"] = inAuthMethod
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultVaultCredentialStoreAuthMethod() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["auth_method"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithVaultCredentialStoreAuthMountPath(inAuthMountPath string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["auth_mount_path"] = inAuthMountPath
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultVaultCredentialStoreAuthMountPath() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["auth_mount_path"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithVaultCredentialStoreCaCert(inCaCert string) Option {
 	return func(o *options) {
 		raw, ok := o.postMap["attributes"]
@@ -195,6 +291,30 @@ func DefaultDescription() Option {
 	}
 }
 
+func WithVaultCredentialStoreKubernetesRole(inKubernetesRole string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["kubernetes_role"] = inKubernetesRole
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultVaultCredentialStoreKubernetesRole() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["kubernetes_role"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithName(inName string) Option {
 	return func(o *options) {
 		o.postMap["name"] = inName
@@ -1,15 +1,32 @@
 // Code generated by "make api"; DO NOT EDIT.
 package credentialstores
 
+import (
+	"time"
+)
+
 type VaultCredentialStoreAttributes struct {
-	Address                  string `json:"address,omitempty"`
-	Namespace                string `json:"namespace,omitempty"`
-	CaCert                   string `json:"ca_cert,omitempty"`
-	TlsServerName            string `json:"tls_server_name,omitempty"`
-	TlsSkipVerify            bool   `json:"tls_skip_verify,omitempty"`
-	Token                    string `json:"token,omitempty"`
-	TokenHmac                string `json:"token_hmac,omitempty"`
-	ClientCertificate        string `json:"client_certificate,omitempty"`
-	ClientCertificateKey     string `json:"client_certificate_key,omitempty"`
-	ClientCertificateKeyHmac string `json:"client_certificate_key_hmac,omitempty"`
+	Address                  string    `json:"address,omitempty"`
+	Namespace                string    `json:"namespace,omitempty"`
+	CaCert                   string    `json:"ca_cert,omitempty"`
+	TlsServerName            string    `json:"tls_server_name,omitempty"`
+	TlsSkipVerify            bool      `json:"tls_skip_verify,omitempty"`
+	Token                    string    `json:"token,omitempty"`
+	TokenHmac                string    `json:"token_hmac,omitempty"`
+	ClientCertificate        string    `json:"client_certificate,omitempty"`
+	ClientCertificateKey     string    `json:"client_certificate_key,omitempty"`
+	ClientCertificateKeyHmac string    `json:"client_certificate_key_hmac,omitempty"`
+	AuthMethod               string    `json:"auth_method,omitempty"`
+	AuthMountPath            string    `json:"auth_mount_path,omitempty"`
+	ApproleRoleId            string    `json:"approle_role_id,omitempty"`
+	ApproleSecretId          string    `json:"approle_secret_id,omitempty"`
+	ApproleSecretIdHmac      string    `json:"approle_secret_id_hmac,omitempty"`
+	KubernetesRole           string    `json:"kubernetes_role,omitempty"`
+	OperationalState         string    `json:"operational_state,omitempty"`
+	HealthStatus             string    `json:"health_status,omitempty"`
+	HealthCheckTime          time.Time `json:"health_check_time,omitempty"`
+	HealthFailedCheck        string    `json:"health_failed_check,omitempty"`
+	HealthMessage            string    `json:"health_message,omitempty"`
+	TokenExpirationTime      time.Time `json:"token_expiration_time,omitempty"`
+	CaCertExpirationTime     time.Time `json:"ca_cert_expiration_time,omitempty"`
 }
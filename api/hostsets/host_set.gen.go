@@ -27,6 +27,7 @@ type HostSet struct {
 	HostIds             []string               `json:"host_ids,omitempty"`
 	PreferredEndpoints  []string               `json:"preferred_endpoints,omitempty"`
 	SyncIntervalSeconds int32                  `json:"sync_interval_seconds,omitempty"`
+	Labels              map[string]string      `json:"labels,omitempty"`
 	Attributes          map[string]interface{} `json:"attributes,omitempty"`
 	AuthorizedActions   []string               `json:"authorized_actions,omitempty"`
 
@@ -98,6 +98,18 @@ func DefaultDescription() Option {
 	}
 }
 
+func WithLabels(inLabels map[string]string) Option {
+	return func(o *options) {
+		o.postMap["labels"] = inLabels
+	}
+}
+
+func DefaultLabels() Option {
+	return func(o *options) {
+		o.postMap["labels"] = nil
+	}
+}
+
 func WithName(inName string) Option {
 	return func(o *options) {
 		o.postMap["name"] = inName
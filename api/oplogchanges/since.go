@@ -0,0 +1,21 @@
+package oplogchanges
+
+import (
+	"strconv"
+)
+
+// WithSinceId tells the API to only return the oplog changes with an id
+// greater than the provided id. The id of the last change of a list result can
+// be used to retrieve the changes that happened after it.
+func WithSinceId(id uint32) Option {
+	return func(o *options) {
+		o.queryMap["since_id"] = strconv.FormatUint(uint64(id), 10)
+	}
+}
+
+// WithLimit tells the API the maximum number of oplog changes to return.
+func WithLimit(limit uint32) Option {
+	return func(o *options) {
+		o.queryMap["limit"] = strconv.FormatUint(uint64(limit), 10)
+	}
+}
@@ -0,0 +1,95 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+type ReportRunResult struct {
+	Item     *ReportResult
+	response *api.Response
+}
+
+func (n ReportRunResult) GetItem() interface{} {
+	return n.Item
+}
+
+func (n ReportRunResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// WithStartTime tells the API to only include warehouse data recorded at or
+// after the provided time when running a report.
+func WithStartTime(t time.Time) Option {
+	return func(o *options) {
+		o.queryMap["start_time"] = t.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+// WithEndTime tells the API to only include warehouse data recorded before
+// the provided time when running a report.
+func WithEndTime(t time.Time) Option {
+	return func(o *options) {
+		o.queryMap["end_time"] = t.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+// WithLimit tells the API the maximum number of rows to return when running a
+// report.
+func WithLimit(limit uint32) Option {
+	return func(o *options) {
+		o.queryMap["limit"] = strconv.FormatUint(uint64(limit), 10)
+	}
+}
+
+// Run runs the report with the given id over the warehouse data of the given
+// scope and its child scopes.
+func (c *Client) Run(ctx context.Context, reportId, scopeId string, opt ...Option) (*ReportRunResult, error) {
+	if reportId == "" {
+		return nil, fmt.Errorf("empty reportId value passed into Run request")
+	}
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into Run request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.queryMap["scope_id"] = scopeId
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("reports/%s:run", url.PathEscape(reportId)), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Run request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Run call: %w", err)
+	}
+
+	target := new(ReportRunResult)
+	target.Item = new(ReportResult)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Run response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
@@ -0,0 +1,65 @@
+package scopes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// CancelSessionsResult is the result of canceling the sessions in a scope.
+type CancelSessionsResult struct {
+	CanceledSessionCount uint32   `json:"canceled_session_count,omitempty"`
+	CanceledSessionIds   []string `json:"canceled_session_ids,omitempty"`
+	response             *api.Response
+}
+
+func (n CancelSessionsResult) GetItem() interface{} {
+	return n
+}
+
+func (n CancelSessionsResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// CancelSessions cancels all non-terminated sessions in a scope and its child scopes. If filter is not
+// empty only the sessions matching it are canceled. The reason is optional.
+func (c *Client) CancelSessions(ctx context.Context, scopeId string, filter, reason string, opt ...Option) (*CancelSessionsResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into CancelSessions request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in CancelSessions request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]interface{}{}
+	if filter != "" {
+		reqBody["filter"] = filter
+	}
+	if reason != "" {
+		reqBody["reason"] = reason
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("scopes/%s:cancel-sessions", scopeId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating CancelSessions request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during CancelSessions call: %w", err)
+	}
+
+	target := new(CancelSessionsResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding CancelSessions response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
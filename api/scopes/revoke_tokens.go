@@ -0,0 +1,61 @@
+package scopes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// RevokeTokensResult is the result of revoking the auth tokens of the users of a scope.
+type RevokeTokensResult struct {
+	RevokedTokenCount  uint32   `json:"revoked_token_count,omitempty"`
+	CanceledSessionIds []string `json:"canceled_session_ids,omitempty"`
+	response           *api.Response
+}
+
+func (n RevokeTokensResult) GetItem() interface{} {
+	return n
+}
+
+func (n RevokeTokensResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// RevokeTokens revokes all auth tokens of the users of a scope. If cancelSessions is true the
+// sessions of the affected users are canceled as well.
+func (c *Client) RevokeTokens(ctx context.Context, scopeId string, cancelSessions bool, opt ...Option) (*RevokeTokensResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into RevokeTokens request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in RevokeTokens request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]interface{}{
+		"cancel_sessions": cancelSessions,
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("scopes/%s:revoke-tokens", scopeId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RevokeTokens request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during RevokeTokens call: %w", err)
+	}
+
+	target := new(RevokeTokensResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RevokeTokens response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
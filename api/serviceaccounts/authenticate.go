@@ -0,0 +1,86 @@
+package serviceaccounts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/authtokens"
+)
+
+// AuthenticateResult is the result of authenticating as a service account.
+// Item holds the issued auth token, including its token value.
+type AuthenticateResult struct {
+	Item     *authtokens.AuthToken
+	response *api.Response
+}
+
+func (n AuthenticateResult) GetItem() interface{} {
+	return n.Item
+}
+
+func (n AuthenticateResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// AuthenticateWithSecret authenticates as the service account with the id
+// and secret of one of its client-secret credentials.
+func (c *Client) AuthenticateWithSecret(ctx context.Context, serviceAccountId, clientId, clientSecret string, opt ...Option) (*AuthenticateResult, error) {
+	if clientId == "" {
+		return nil, fmt.Errorf("empty clientId value passed into AuthenticateWithSecret request")
+	}
+	if clientSecret == "" {
+		return nil, fmt.Errorf("empty clientSecret value passed into AuthenticateWithSecret request")
+	}
+	return c.authenticate(ctx, serviceAccountId, map[string]interface{}{
+		"client_id":     clientId,
+		"client_secret": clientSecret,
+	}, opt...)
+}
+
+// AuthenticateWithJwt authenticates as the service account with a JWT signed
+// by the private key of one of its jwt-public-key credentials. The subject of
+// the JWT must be the service account id and its audience must include
+// "/v1/service-accounts/<id>:authenticate". The JWT must also have a unique
+// "jti" claim, as each JWT can only be used once.
+func (c *Client) AuthenticateWithJwt(ctx context.Context, serviceAccountId, jwt string, opt ...Option) (*AuthenticateResult, error) {
+	if jwt == "" {
+		return nil, fmt.Errorf("empty jwt value passed into AuthenticateWithJwt request")
+	}
+	return c.authenticate(ctx, serviceAccountId, map[string]interface{}{
+		"jwt": jwt,
+	}, opt...)
+}
+
+func (c *Client) authenticate(ctx context.Context, serviceAccountId string, reqBody map[string]interface{}, opt ...Option) (*AuthenticateResult, error) {
+	if serviceAccountId == "" {
+		return nil, fmt.Errorf("empty serviceAccountId value passed into Authenticate request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in Authenticate request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("service-accounts/%s:authenticate", serviceAccountId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Authenticate request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Authenticate call: %w", err)
+	}
+
+	target := new(AuthenticateResult)
+	target.Item = new(authtokens.AuthToken)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Authenticate response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
@@ -0,0 +1,15 @@
+// Code generated by "make api"; DO NOT EDIT.
+package serviceaccounts
+
+import (
+	"time"
+)
+
+type Credential struct {
+	Id             string    `json:"id,omitempty"`
+	Type           string    `json:"type,omitempty"`
+	PublicKey      string    `json:"public_key,omitempty"`
+	CreatedTime    time.Time `json:"created_time,omitempty"`
+	ExpirationTime time.Time `json:"expiration_time,omitempty"`
+	ClientSecret   string    `json:"client_secret,omitempty"`
+}
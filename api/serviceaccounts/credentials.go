@@ -0,0 +1,162 @@
+package serviceaccounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+const (
+	// JwtPublicKeyCredentialType is the type of credential which holds a PEM
+	// encoded public key verifying JWTs signed by the service account.
+	JwtPublicKeyCredentialType = "jwt-public-key"
+
+	// ClientSecretCredentialType is the type of credential which holds a
+	// generated client secret.
+	ClientSecretCredentialType = "client-secret"
+)
+
+// WithPublicKey sets the PEM encoded public key of a jwt-public-key
+// credential.
+func WithPublicKey(publicKey string) Option {
+	return func(o *options) {
+		o.postMap["public_key"] = publicKey
+	}
+}
+
+// WithCredentialExpirationTime sets the time after which the credential can
+// no longer be used to authenticate.
+func WithCredentialExpirationTime(t time.Time) Option {
+	return func(o *options) {
+		o.postMap["expiration_time"] = t.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+// CredentialAddResult is the result of adding a credential to a service
+// account. The client secret of a client-secret credential is only returned
+// in this result and can not be retrieved later.
+type CredentialAddResult struct {
+	Item       *ServiceAccount `json:"item,omitempty"`
+	Credential *Credential     `json:"credential,omitempty"`
+	response   *api.Response
+}
+
+func (n CredentialAddResult) GetItem() interface{} {
+	return n.Item
+}
+
+func (n CredentialAddResult) GetResponse() *api.Response {
+	return n.response
+}
+
+func (c *Client) AddCredential(ctx context.Context, serviceAccountId string, version uint32, credentialType string, opt ...Option) (*CredentialAddResult, error) {
+	if serviceAccountId == "" {
+		return nil, fmt.Errorf("empty serviceAccountId value passed into AddCredential request")
+	}
+	if credentialType == "" {
+		return nil, fmt.Errorf("empty credentialType value passed into AddCredential request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in AddCredential request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	version, err := c.checkAndSetVersion(ctx, serviceAccountId, version, opts, opt...)
+	if err != nil {
+		return nil, err
+	}
+	opts.postMap["version"] = version
+	opts.postMap["type"] = credentialType
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("service-accounts/%s:add-credential", serviceAccountId), opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating AddCredential request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during AddCredential call: %w", err)
+	}
+
+	target := new(CredentialAddResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding AddCredential response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+func (c *Client) RemoveCredential(ctx context.Context, serviceAccountId string, version uint32, credentialId string, opt ...Option) (*ServiceAccountUpdateResult, error) {
+	if serviceAccountId == "" {
+		return nil, fmt.Errorf("empty serviceAccountId value passed into RemoveCredential request")
+	}
+	if credentialId == "" {
+		return nil, fmt.Errorf("empty credentialId value passed into RemoveCredential request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in RemoveCredential request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	version, err := c.checkAndSetVersion(ctx, serviceAccountId, version, opts, opt...)
+	if err != nil {
+		return nil, err
+	}
+	reqBody := map[string]interface{}{
+		"version":       version,
+		"credential_id": credentialId,
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("service-accounts/%s:remove-credential", serviceAccountId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RemoveCredential request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during RemoveCredential call: %w", err)
+	}
+
+	target := new(ServiceAccountUpdateResult)
+	target.Item = new(ServiceAccount)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RemoveCredential response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+// checkAndSetVersion returns version, or the current version of the service
+// account if version is zero and automatic versioning is enabled.
+func (c *Client) checkAndSetVersion(ctx context.Context, serviceAccountId string, version uint32, opts options, opt ...Option) (uint32, error) {
+	if version != 0 {
+		return version, nil
+	}
+	if !opts.withAutomaticVersioning {
+		return 0, errors.New("zero version number passed into request and automatic versioning not specified")
+	}
+	existingTarget, existingErr := c.Read(ctx, serviceAccountId, append([]Option{WithSkipCurlOutput(true)}, opt...)...)
+	if existingErr != nil {
+		if api.AsServerError(existingErr) != nil {
+			return 0, fmt.Errorf("error from controller when performing initial check-and-set read: %w", existingErr)
+		}
+		return 0, fmt.Errorf("error performing initial check-and-set read: %w", existingErr)
+	}
+	if existingTarget == nil || existingTarget.Item == nil {
+		return 0, errors.New("nil resource found when performing initial check-and-set read")
+	}
+	return existingTarget.Item.Version, nil
+}
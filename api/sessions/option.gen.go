@@ -86,3 +86,9 @@ func WithRecursive(recurse bool) Option {
 		o.withRecursive = true
 	}
 }
+
+func WithCancelReason(inCancelReason string) Option {
+	return func(o *options) {
+		o.postMap["reason"] = inCancelReason
+	}
+}
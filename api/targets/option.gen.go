@@ -1,6 +1,7 @@
 package targets
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -123,6 +124,48 @@ func DefaultAttributes() Option {
 	}
 }
 
+func WithAuthorized(inAuthorized bool) Option {
+	return func(o *options) {
+		o.queryMap["authorized"] = fmt.Sprintf("%v", inAuthorized)
+	}
+}
+
+func WithConnectionIdleTimeoutSeconds(inConnectionIdleTimeoutSeconds uint32) Option {
+	return func(o *options) {
+		o.postMap["connection_idle_timeout_seconds"] = inConnectionIdleTimeoutSeconds
+	}
+}
+
+func DefaultConnectionIdleTimeoutSeconds() Option {
+	return func(o *options) {
+		o.postMap["connection_idle_timeout_seconds"] = nil
+	}
+}
+
+func WithKubernetesTargetDefaultPort(inDefaultPort uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["default_port"] = inDefaultPort
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultKubernetesTargetDefaultPort() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["default_port"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithTcpTargetDefaultPort(inDefaultPort uint32) Option {
 	return func(o *options) {
 		raw, ok := o.postMap["attributes"]
@@ -177,6 +220,48 @@ func WithHostId(inHostId string) Option {
 	}
 }
 
+func WithLabelSelector(inLabelSelector string) Option {
+	return func(o *options) {
+		o.queryMap["label_selector"] = fmt.Sprintf("%v", inLabelSelector)
+	}
+}
+
+func WithLabels(inLabels map[string]string) Option {
+	return func(o *options) {
+		o.postMap["labels"] = inLabels
+	}
+}
+
+func DefaultLabels() Option {
+	return func(o *options) {
+		o.postMap["labels"] = nil
+	}
+}
+
+func WithMaxBytesPerSession(inMaxBytesPerSession uint64) Option {
+	return func(o *options) {
+		o.postMap["max_bytes_per_session"] = inMaxBytesPerSession
+	}
+}
+
+func DefaultMaxBytesPerSession() Option {
+	return func(o *options) {
+		o.postMap["max_bytes_per_session"] = nil
+	}
+}
+
+func WithMaxConcurrentConnections(inMaxConcurrentConnections uint32) Option {
+	return func(o *options) {
+		o.postMap["max_concurrent_connections"] = inMaxConcurrentConnections
+	}
+}
+
+func DefaultMaxConcurrentConnections() Option {
+	return func(o *options) {
+		o.postMap["max_concurrent_connections"] = nil
+	}
+}
+
 func WithName(inName string) Option {
 	return func(o *options) {
 		o.postMap["name"] = inName
@@ -236,3 +321,15 @@ func DefaultWorkerFilter() Option {
 		o.postMap["worker_filter"] = nil
 	}
 }
+
+func WithWorkerSelectionStrategy(inWorkerSelectionStrategy string) Option {
+	return func(o *options) {
+		o.postMap["worker_selection_strategy"] = inWorkerSelectionStrategy
+	}
+}
+
+func DefaultWorkerSelectionStrategy() Option {
+	return func(o *options) {
+		o.postMap["worker_selection_strategy"] = nil
+	}
+}
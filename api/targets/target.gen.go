@@ -29,6 +29,11 @@ type Target struct {
 	SessionMaxSeconds               uint32                 `json:"session_max_seconds,omitempty"`
 	SessionConnectionLimit          int32                  `json:"session_connection_limit,omitempty"`
 	WorkerFilter                    string                 `json:"worker_filter,omitempty"`
+	ConnectionIdleTimeoutSeconds    uint32                 `json:"connection_idle_timeout_seconds,omitempty"`
+	MaxConcurrentConnections        uint32                 `json:"max_concurrent_connections,omitempty"`
+	MaxBytesPerSession              uint64                 `json:"max_bytes_per_session,omitempty,string"`
+	Labels                          map[string]string      `json:"labels,omitempty"`
+	WorkerSelectionStrategy         string                 `json:"worker_selection_strategy,omitempty"`
 	ApplicationCredentialLibraryIds []string               `json:"application_credential_library_ids,omitempty"`
 	ApplicationCredentialLibraries  []*CredentialLibrary   `json:"application_credential_libraries,omitempty"`
 	ApplicationCredentialSourceIds  []string               `json:"application_credential_source_ids,omitempty"`
@@ -0,0 +1,113 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// UserActivity is an entry in the authentication and session authorization
+// history of a user.
+type UserActivity struct {
+	Id           uint64    `json:"id,omitempty"`
+	Type         string    `json:"type,omitempty"`
+	ScopeId      string    `json:"scope_id,omitempty"`
+	AuthMethodId string    `json:"auth_method_id,omitempty"`
+	AccountId    string    `json:"account_id,omitempty"`
+	TargetId     string    `json:"target_id,omitempty"`
+	SessionId    string    `json:"session_id,omitempty"`
+	Detail       string    `json:"detail,omitempty"`
+	CreatedTime  time.Time `json:"created_time,omitempty"`
+}
+
+type UserActivityReadResult struct {
+	Items    []*UserActivity
+	response *api.Response
+}
+
+func (n UserActivityReadResult) GetItems() interface{} {
+	return n.Items
+}
+
+func (n UserActivityReadResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// WithActivityScopeId tells the API to only include the activities which
+// happened in the provided scope and its child scopes when reading the
+// activity of a user.
+func WithActivityScopeId(scopeId string) Option {
+	return func(o *options) {
+		o.queryMap["scope_id"] = scopeId
+	}
+}
+
+// WithStartTime tells the API to only include the activities at or after the
+// provided time when reading the activity of a user.
+func WithStartTime(t time.Time) Option {
+	return func(o *options) {
+		o.queryMap["start_time"] = t.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+// WithEndTime tells the API to only include the activities before the
+// provided time when reading the activity of a user.
+func WithEndTime(t time.Time) Option {
+	return func(o *options) {
+		o.queryMap["end_time"] = t.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+// WithLimit tells the API the maximum number of activities to return when
+// reading the activity of a user.
+func WithLimit(limit uint32) Option {
+	return func(o *options) {
+		o.queryMap["limit"] = strconv.FormatUint(uint64(limit), 10)
+	}
+}
+
+// ReadActivity returns the authentication and session authorization history
+// of the user with the given id, most recent first.
+func (c *Client) ReadActivity(ctx context.Context, userId string, opt ...Option) (*UserActivityReadResult, error) {
+	if userId == "" {
+		return nil, fmt.Errorf("empty userId value passed into ReadActivity request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in ReadActivity request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("users/%s:read-activity", url.PathEscape(userId)), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ReadActivity request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ReadActivity call: %w", err)
+	}
+
+	target := new(UserActivityReadResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ReadActivity response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
@@ -1,6 +1,10 @@
 package main
 
 import (
+	// Enable kubernetes target support.
+	_ "github.com/hashicorp/boundary/internal/servers/controller/handlers/targets/kubernetes"
+	_ "github.com/hashicorp/boundary/internal/target/kubernetes"
+
 	// Enable tcp target support.
 	_ "github.com/hashicorp/boundary/internal/servers/controller/handlers/targets/tcp"
 	_ "github.com/hashicorp/boundary/internal/target/tcp"
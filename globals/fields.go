@@ -70,4 +70,31 @@ const (
 	EgressCredentialSourceIdsField       = "egress_credential_source_ids"
 	EgressCredentialSourcesField         = "egress_credential_sources"
 	ConnectionsField                     = "connections"
+	ConnectionIdleTimeoutSecondsField    = "connection_idle_timeout_seconds"
+	MaxConcurrentConnectionsField        = "max_concurrent_connections"
+	MaxBytesPerSessionField              = "max_bytes_per_session"
+	WorkerSelectionStrategyField         = "worker_selection_strategy"
+	AggregateNameField                   = "aggregate_name"
+	MetadataField                        = "metadata"
+	OperationsField                      = "operations"
+	ColumnsField                         = "columns"
+	RowsField                            = "rows"
+	ParentIdField                        = "parent_id"
+	GrantScopeIdsField                   = "grant_scope_ids"
+	TimeToLiveSecondsField               = "time_to_live_seconds"
+	ServiceAccountIdField                = "service_account_id"
+	CredentialsField                     = "credentials"
+	UrlField                             = "url"
+	EventTypesField                      = "event_types"
+	EventFilterField                     = "event_filter"
+	SecretField                          = "secret"
+	DeliveryStatusField                  = "delivery_status"
+	DisabledField                        = "disabled"
+	CredentialTypeField                  = "credential_type"
+	CredentialMappingOverridesField      = "credential_mapping_overrides"
+	ValueField                           = "value"
+	DestinationIdField                   = "destination_id"
+	LabelsField                          = "labels"
+	LabelSelectorField                   = "label_selector"
+	AuthorizedField                      = "authorized"
 )
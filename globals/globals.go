@@ -5,6 +5,11 @@ import "time"
 const (
 	TcpProxyV1     = "boundary-tcp-proxy-v1"
 	ServiceTokenV1 = "s1"
+
+	// SessionTerminatedWsCloseCode is the websocket close code a worker uses
+	// when closing a client connection because its session was canceled. The
+	// close reason holds the reason given for the cancellation.
+	SessionTerminatedWsCloseCode = 4000
 )
 
 type (
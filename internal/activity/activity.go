@@ -0,0 +1,85 @@
+// Package activity records the authentication and session authorization
+// history of users. Authentications are recorded by the auth methods through
+// RecordAccountActivity; session authorizations are recorded by a database
+// trigger when the session is created, and denied session authorizations by
+// the targets service through Repository.RecordSessionAuthorizationDenied.
+package activity
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// Type is the type of an activity.
+type Type string
+
+const (
+	UnknownType                Type = "unknown"
+	AuthenticationSucceeded    Type = "authentication-succeeded"
+	AuthenticationFailed       Type = "authentication-failed"
+	SessionAuthorized          Type = "session-authorized"
+	SessionAuthorizationDenied Type = "session-authorization-denied"
+)
+
+func (t Type) String() string {
+	return string(t)
+}
+
+// An Activity is an entry in the activity history of a user. ScopeId is the
+// scope the activity happened in: the scope of the auth method for
+// authentications and the project of the target for session authorizations.
+type Activity struct {
+	Id           int64
+	UserId       string
+	ScopeId      string
+	ActivityType string
+	AuthMethodId string
+	AccountId    string
+	TargetId     string
+	SessionId    string
+	Detail       string
+	CreateTime   time.Time
+}
+
+// GetType returns the type of the activity.
+func (a *Activity) GetType() Type {
+	switch t := Type(a.ActivityType); t {
+	case AuthenticationSucceeded, AuthenticationFailed, SessionAuthorized, SessionAuthorizationDenied:
+		return t
+	default:
+		return UnknownType
+	}
+}
+
+// RecordAccountActivity records an activity of type t in the history of the
+// user associated with the account accountId. Nothing is recorded if the
+// account is not associated with a user. w can be the writer of a
+// transaction. WithDetail is the only valid option.
+func RecordAccountActivity(ctx context.Context, w db.Writer, accountId string, t Type, opt ...Option) error {
+	const op = "activity.RecordAccountActivity"
+	switch {
+	case w == nil:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing db.Writer")
+	case accountId == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing account id")
+	}
+	switch t {
+	case AuthenticationSucceeded, AuthenticationFailed:
+	default:
+		return errors.New(ctx, errors.InvalidParameter, op, "invalid account activity type: "+t.String())
+	}
+	opts := getOpts(opt...)
+	args := []interface{}{
+		sql.Named("account_id", accountId),
+		sql.Named("activity_type", t.String()),
+		sql.Named("detail", opts.withDetail),
+	}
+	if _, err := w.Exec(ctx, insertAccountActivityQuery, args); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
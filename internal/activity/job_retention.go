@@ -0,0 +1,94 @@
+package activity
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	ua "go.uber.org/atomic"
+)
+
+const (
+	retentionJobName         = "user_activity_retention"
+	defaultRetention         = 90 * 24 * time.Hour
+	defaultRetentionInterval = time.Hour
+)
+
+// retentionJob is the recurring job which deletes the user activities which
+// are older than the retention period. The retentionJob is not thread safe,
+// an attempt to Run the job concurrently will result in a JobAlreadyRunning
+// error.
+type retentionJob struct {
+	writer    db.Writer
+	retention time.Duration
+	interval  time.Duration
+
+	running    ua.Bool
+	numDeleted int
+}
+
+// newRetentionJob creates a new in-memory retentionJob.
+//
+// WithRetention and WithInterval are the only supported options.
+func newRetentionJob(ctx context.Context, w db.Writer, opt ...Option) (*retentionJob, error) {
+	const op = "activity.newRetentionJob"
+	if w == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Writer")
+	}
+	opts := getOpts(opt...)
+	return &retentionJob{
+		writer:    w,
+		retention: opts.withRetention,
+		interval:  opts.withInterval,
+	}, nil
+}
+
+// Status returns the current status of the retention job. Total and
+// Completed are the number of activities deleted by the last run.
+func (j *retentionJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: j.numDeleted,
+		Total:     j.numDeleted,
+	}
+}
+
+// Run deletes the user activities which are older than the retention
+// period. Can not be run in parallel, if Run is invoked while already
+// running an error with code JobAlreadyRunning will be returned.
+func (j *retentionJob) Run(ctx context.Context) error {
+	const op = "activity.(retentionJob).Run"
+	if !j.running.CAS(j.running.Load(), true) {
+		return errors.New(ctx, errors.JobAlreadyRunning, op, "job already running")
+	}
+	defer j.running.Store(false)
+
+	// Verify context is not done before running
+	if err := ctx.Err(); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	n, err := j.writer.Exec(ctx, deleteActivityBeforeQuery, []interface{}{sql.Named("retention_seconds", j.retention.Seconds())})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	j.numDeleted = n
+	return nil
+}
+
+// NextRunIn returns the configured retention job interval.
+func (j *retentionJob) NextRunIn() (time.Duration, error) {
+	return j.interval, nil
+}
+
+// Name is the unique name of the job.
+func (j *retentionJob) Name() string {
+	return retentionJobName
+}
+
+// Description is the human readable description of the job.
+func (j *retentionJob) Description() string {
+	return "Deletes user activities which are older than the retention period."
+}
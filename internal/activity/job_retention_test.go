@@ -0,0 +1,57 @@
+package activity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRetentionJob(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+
+	_, err := newRetentionJob(ctx, nil)
+	assert.Error(t, err)
+
+	j, err := newRetentionJob(ctx, rw, WithRetention(0))
+	require.NoError(t, err)
+	assert.Equal(t, defaultRetention, j.retention)
+	assert.Equal(t, defaultRetentionInterval, j.interval)
+	assert.Equal(t, retentionJobName, j.Name())
+	next, err := j.NextRunIn()
+	require.NoError(t, err)
+	assert.Equal(t, defaultRetentionInterval, next)
+}
+
+func TestRetentionJob_Run(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	u := iam.TestUser(t, iamRepo, org.GetPublicId())
+
+	_, err := rw.Exec(ctx, "insert into iam_user_activity (user_id, scope_id, activity_type) values (?, ?, ?)",
+		[]interface{}{u.GetPublicId(), org.GetPublicId(), AuthenticationSucceeded.String()})
+	require.NoError(t, err)
+
+	j, err := newRetentionJob(ctx, rw)
+	require.NoError(t, err)
+	require.NoError(t, j.Run(ctx))
+	assert.Equal(t, 0, j.Status().Completed)
+
+	time.Sleep(10 * time.Millisecond)
+	j.retention = time.Millisecond
+	require.NoError(t, j.Run(ctx))
+	assert.Equal(t, 1, j.Status().Completed)
+	assert.Equal(t, 1, j.Status().Total)
+}
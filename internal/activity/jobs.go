@@ -0,0 +1,25 @@
+package activity
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/scheduler"
+)
+
+// RegisterJobs registers the user activity retention job with the provided
+// scheduler.
+//
+// WithRetention and WithInterval are the only supported options.
+func RegisterJobs(ctx context.Context, scheduler *scheduler.Scheduler, w db.Writer, opt ...Option) error {
+	const op = "activity.RegisterJobs"
+	retention, err := newRetentionJob(ctx, w, opt...)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err = scheduler.RegisterJob(ctx, retention); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("retention job"))
+	}
+	return nil
+}
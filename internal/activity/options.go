@@ -0,0 +1,96 @@
+package activity
+
+import "time"
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withLimit     int
+	withScopeIds  []string
+	withStartTime time.Time
+	withEndTime   time.Time
+	withDetail    string
+	withRetention time.Duration
+	withInterval  time.Duration
+}
+
+func getDefaultOptions() options {
+	return options{
+		withLimit:     0,
+		withRetention: defaultRetention,
+		withInterval:  defaultRetentionInterval,
+	}
+}
+
+// WithLimit provides an option to provide a limit on the number of activities
+// returned. Intentionally allowing negative integers. If WithLimit < 0, then
+// unlimited results are returned. If WithLimit == 0, then default limits are
+// used for results.
+func WithLimit(l int) Option {
+	return func(o *options) {
+		o.withLimit = l
+	}
+}
+
+// WithScopeIds provides an option to only include activities which happened
+// in the given scopes. No filtering is done if no scope ids are provided.
+func WithScopeIds(ids []string) Option {
+	return func(o *options) {
+		o.withScopeIds = ids
+	}
+}
+
+// WithStartTime provides an option to only include activities at or after
+// the given time.
+func WithStartTime(t time.Time) Option {
+	return func(o *options) {
+		o.withStartTime = t
+	}
+}
+
+// WithEndTime provides an option to only include activities before the given
+// time.
+func WithEndTime(t time.Time) Option {
+	return func(o *options) {
+		o.withEndTime = t
+	}
+}
+
+// WithDetail provides an optional detail of a recorded activity, such as the
+// reason an authentication failed.
+func WithDetail(d string) Option {
+	return func(o *options) {
+		o.withDetail = d
+	}
+}
+
+// WithRetention provides how long activities are kept before the retention
+// job deletes them. Zero or negative values use the default of 90 days.
+func WithRetention(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.withRetention = d
+		}
+	}
+}
+
+// WithInterval provides the time between runs of the retention job. Zero or
+// negative values use the default of one hour.
+func WithInterval(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.withInterval = d
+		}
+	}
+}
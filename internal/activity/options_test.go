@@ -0,0 +1,70 @@
+package activity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_GetOpts provides unit tests for GetOpts and all the options
+func Test_GetOpts(t *testing.T) {
+	t.Parallel()
+	t.Run("WithLimit", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithLimit(5))
+		testOpts := getDefaultOptions()
+		testOpts.withLimit = 5
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithScopeIds", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithScopeIds([]string{"o_1234567890", "p_1234567890"}))
+		testOpts := getDefaultOptions()
+		testOpts.withScopeIds = []string{"o_1234567890", "p_1234567890"}
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithStartTime", func(t *testing.T) {
+		assert := assert.New(t)
+		now := time.Now()
+		opts := getOpts(WithStartTime(now))
+		testOpts := getDefaultOptions()
+		testOpts.withStartTime = now
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithEndTime", func(t *testing.T) {
+		assert := assert.New(t)
+		now := time.Now()
+		opts := getOpts(WithEndTime(now))
+		testOpts := getDefaultOptions()
+		testOpts.withEndTime = now
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithDetail", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithDetail("invalid password"))
+		testOpts := getDefaultOptions()
+		testOpts.withDetail = "invalid password"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithRetention", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithRetention(time.Hour))
+		testOpts := getDefaultOptions()
+		testOpts.withRetention = time.Hour
+		assert.Equal(opts, testOpts)
+
+		opts = getOpts(WithRetention(0))
+		assert.Equal(defaultRetention, opts.withRetention)
+	})
+	t.Run("WithInterval", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithInterval(time.Minute))
+		testOpts := getDefaultOptions()
+		testOpts.withInterval = time.Minute
+		assert.Equal(opts, testOpts)
+
+		opts = getOpts(WithInterval(-time.Minute))
+		assert.Equal(defaultRetentionInterval, opts.withInterval)
+	})
+}
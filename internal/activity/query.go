@@ -0,0 +1,49 @@
+package activity
+
+const (
+	// insertAccountActivityQuery records an activity of the user associated
+	// with an account. Nothing is inserted if the account is not associated
+	// with a user.
+	insertAccountActivityQuery = `
+insert into iam_user_activity
+  (user_id, scope_id, activity_type, auth_method_id, account_id, detail)
+select acct.iam_user_id,
+       acct.scope_id,
+       @activity_type,
+       acct.auth_method_id,
+       acct.public_id,
+       nullif(@detail, '')
+  from auth_account acct
+ where acct.public_id = @account_id
+   and acct.iam_user_id is not null;
+`
+
+	insertSessionAuthorizationDeniedQuery = `
+insert into iam_user_activity
+  (user_id, scope_id, activity_type, target_id, detail)
+values
+  (@user_id, @scope_id, 'session-authorization-denied', @target_id, nullif(@detail, ''));
+`
+
+	listUserActivityQuery = `
+select id,
+       user_id,
+       scope_id,
+       activity_type,
+       coalesce(auth_method_id, '') as auth_method_id,
+       coalesce(account_id, '')     as account_id,
+       coalesce(target_id, '')      as target_id,
+       coalesce(session_id, '')     as session_id,
+       coalesce(detail, '')         as detail,
+       create_time
+  from iam_user_activity
+ where %s
+ order by create_time desc, id desc
+ %s;
+`
+
+	deleteActivityBeforeQuery = `
+delete from iam_user_activity
+ where create_time < now() - make_interval(secs => @retention_seconds);
+`
+)
@@ -0,0 +1,128 @@
+package activity
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// A Repository reads the activity history of users and records denied
+// session authorizations. It is not safe to use a repository concurrently.
+type Repository struct {
+	reader db.Reader
+	writer db.Writer
+
+	// defaultLimit provides a default for limiting the number of activities
+	// returned from the repo
+	defaultLimit int
+}
+
+// NewRepository creates a new Repository. The returned repository should
+// only be used for one transaction and it is not safe for concurrent go
+// routines to access it. Supports the WithLimit option.
+func NewRepository(r db.Reader, w db.Writer, opt ...Option) (*Repository, error) {
+	const op = "activity.NewRepository"
+	switch {
+	case r == nil:
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "nil reader")
+	case w == nil:
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "nil writer")
+	}
+	opts := getOpts(opt...)
+	if opts.withLimit == 0 {
+		// zero signals the boundary defaults should be used.
+		opts.withLimit = db.DefaultLimit
+	}
+	return &Repository{
+		reader:       r,
+		writer:       w,
+		defaultLimit: opts.withLimit,
+	}, nil
+}
+
+// ListUserActivity returns the activity history of the user userId, most
+// recent first. Supports the WithLimit, WithScopeIds, WithStartTime and
+// WithEndTime options.
+func (r *Repository) ListUserActivity(ctx context.Context, userId string, opt ...Option) ([]*Activity, error) {
+	const op = "activity.(Repository).ListUserActivity"
+	if userId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	}
+	opts := getOpts(opt...)
+	if !opts.withStartTime.IsZero() && !opts.withEndTime.IsZero() && !opts.withStartTime.Before(opts.withEndTime) {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "start time must be before end time")
+	}
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+
+	conds := []string{"user_id = ?"}
+	args := []interface{}{userId}
+	if len(opts.withScopeIds) > 0 {
+		conds = append(conds, "scope_id in (?)")
+		args = append(args, opts.withScopeIds)
+	}
+	if !opts.withStartTime.IsZero() {
+		conds = append(conds, "create_time >= ?")
+		args = append(args, opts.withStartTime)
+	}
+	if !opts.withEndTime.IsZero() {
+		conds = append(conds, "create_time < ?")
+		args = append(args, opts.withEndTime)
+	}
+	var limitClause string
+	if limit > 0 {
+		limitClause = fmt.Sprintf("limit %d", limit)
+	}
+	query := fmt.Sprintf(listUserActivityQuery, strings.Join(conds, " and "), limitClause)
+
+	rows, err := r.reader.Query(ctx, query, args)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var activities []*Activity
+	for rows.Next() {
+		var a Activity
+		if err := r.reader.ScanRows(rows, &a); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		activities = append(activities, &a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return activities, nil
+}
+
+// RecordSessionAuthorizationDenied records a denied request by userId to
+// authorize a session for the target targetId in the project projectId in
+// the activity history of the user. reason is recorded as the detail of the
+// activity.
+func (r *Repository) RecordSessionAuthorizationDenied(ctx context.Context, userId, targetId, projectId, reason string) error {
+	const op = "activity.(Repository).RecordSessionAuthorizationDenied"
+	switch {
+	case userId == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	case targetId == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target id")
+	case projectId == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+	}
+	args := []interface{}{
+		sql.Named("user_id", userId),
+		sql.Named("scope_id", projectId),
+		sql.Named("target_id", targetId),
+		sql.Named("detail", reason),
+	}
+	if _, err := r.writer.Exec(ctx, insertSessionAuthorizationDeniedQuery, args); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package activity_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/activity"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRepository(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+
+	t.Run("valid", func(t *testing.T) {
+		got, err := activity.NewRepository(rw, rw, activity.WithLimit(5))
+		require.NoError(t, err)
+		assert.NotNil(t, got)
+	})
+	t.Run("nil-reader", func(t *testing.T) {
+		_, err := activity.NewRepository(nil, rw)
+		require.Error(t, err)
+		assert.Equal(t, "activity.NewRepository: nil reader: parameter violation: error #100", err.Error())
+	})
+	t.Run("nil-writer", func(t *testing.T) {
+		_, err := activity.NewRepository(rw, nil)
+		require.Error(t, err)
+		assert.Equal(t, "activity.NewRepository: nil writer: parameter violation: error #100", err.Error())
+	})
+}
+
+func TestRecordAccountActivity(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	am := password.TestAuthMethods(t, conn, org.GetPublicId(), 1)[0]
+	acct := password.TestAccount(t, conn, am.GetPublicId(), "alice")
+	unassociated := password.TestAccount(t, conn, am.GetPublicId(), "bob")
+	u := iam.TestUser(t, iamRepo, org.GetPublicId(), iam.WithAccountIds(acct.GetPublicId()))
+
+	repo, err := activity.NewRepository(rw, rw)
+	require.NoError(t, err)
+
+	t.Run("invalid", func(t *testing.T) {
+		err := activity.RecordAccountActivity(ctx, nil, acct.GetPublicId(), activity.AuthenticationSucceeded)
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		err = activity.RecordAccountActivity(ctx, rw, "", activity.AuthenticationSucceeded)
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		err = activity.RecordAccountActivity(ctx, rw, acct.GetPublicId(), activity.SessionAuthorized)
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+	})
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		require.NoError(activity.RecordAccountActivity(ctx, rw, acct.GetPublicId(), activity.AuthenticationFailed, activity.WithDetail("invalid password")))
+		require.NoError(activity.RecordAccountActivity(ctx, rw, unassociated.GetPublicId(), activity.AuthenticationFailed))
+
+		got, err := repo.ListUserActivity(ctx, u.GetPublicId())
+		require.NoError(err)
+		require.Len(got, 1)
+		assert.Equal(activity.AuthenticationFailed, got[0].GetType())
+		assert.Equal(u.GetPublicId(), got[0].UserId)
+		assert.Equal(org.GetPublicId(), got[0].ScopeId)
+		assert.Equal(am.GetPublicId(), got[0].AuthMethodId)
+		assert.Equal(acct.GetPublicId(), got[0].AccountId)
+		assert.Equal("invalid password", got[0].Detail)
+		assert.False(got[0].CreateTime.IsZero())
+	})
+}
+
+func TestRepository_ListUserActivity(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	s := session.TestDefaultSession(t, conn, wrapper, iamRepo)
+
+	repo, err := activity.NewRepository(rw, rw)
+	require.NoError(t, err)
+
+	t.Run("session-authorized", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ListUserActivity(ctx, s.UserId)
+		require.NoError(err)
+		require.Len(got, 1)
+		assert.Equal(activity.SessionAuthorized, got[0].GetType())
+		assert.Equal(s.ScopeId, got[0].ScopeId)
+		assert.Equal(s.TargetId, got[0].TargetId)
+		assert.Equal(s.PublicId, got[0].SessionId)
+	})
+	t.Run("in-scope", func(t *testing.T) {
+		got, err := repo.ListUserActivity(ctx, s.UserId, activity.WithScopeIds([]string{s.ScopeId}))
+		require.NoError(t, err)
+		assert.Len(t, got, 1)
+	})
+	t.Run("other-scope", func(t *testing.T) {
+		org, _ := iam.TestScopes(t, iamRepo)
+		got, err := repo.ListUserActivity(ctx, s.UserId, activity.WithScopeIds([]string{org.GetPublicId()}))
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+	t.Run("time-range", func(t *testing.T) {
+		got, err := repo.ListUserActivity(ctx, s.UserId, activity.WithStartTime(time.Now().Add(-time.Hour)), activity.WithEndTime(time.Now().Add(time.Hour)))
+		require.NoError(t, err)
+		assert.Len(t, got, 1)
+
+		got, err = repo.ListUserActivity(ctx, s.UserId, activity.WithStartTime(time.Now().Add(time.Hour)))
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+	t.Run("invalid-time-range", func(t *testing.T) {
+		now := time.Now()
+		_, err := repo.ListUserActivity(ctx, s.UserId, activity.WithStartTime(now), activity.WithEndTime(now))
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+	})
+	t.Run("missing-user-id", func(t *testing.T) {
+		_, err := repo.ListUserActivity(ctx, "")
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+	})
+}
+
+func TestRepository_RecordSessionAuthorizationDenied(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	s := session.TestDefaultSession(t, conn, wrapper, iamRepo)
+
+	repo, err := activity.NewRepository(rw, rw)
+	require.NoError(t, err)
+
+	t.Run("invalid", func(t *testing.T) {
+		err := repo.RecordSessionAuthorizationDenied(ctx, "", s.TargetId, s.ScopeId, "forbidden")
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		err = repo.RecordSessionAuthorizationDenied(ctx, s.UserId, "", s.ScopeId, "forbidden")
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		err = repo.RecordSessionAuthorizationDenied(ctx, s.UserId, s.TargetId, "", "forbidden")
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+	})
+	t.Run("denied", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		require.NoError(repo.RecordSessionAuthorizationDenied(ctx, s.UserId, s.TargetId, s.ScopeId, "forbidden"))
+		got, err := repo.ListUserActivity(ctx, s.UserId)
+		require.NoError(err)
+		require.Len(got, 2)
+		assert.Equal(activity.SessionAuthorizationDenied, got[0].GetType())
+		assert.Equal(s.ScopeId, got[0].ScopeId)
+		assert.Equal(s.TargetId, got[0].TargetId)
+		assert.Empty(got[0].SessionId)
+		assert.Equal("forbidden", got[0].Detail)
+		assert.Equal(activity.SessionAuthorized, got[1].GetType())
+	})
+}
@@ -0,0 +1,141 @@
+// Package alias provides aliases, globally unique DNS-style names such as
+// prod-db.payments which resolve to a target and optionally one of the
+// target's hosts. An alias can be used in place of a target id when
+// authorizing a session.
+package alias
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/boundary/internal/alias/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	aliasTableName = "alias"
+
+	// maxValueLength is the maximum length of an alias value, the same as
+	// the maximum length of a DNS name.
+	maxValueLength = 253
+)
+
+// valueRe matches DNS-style names made of dot separated lowercase labels.
+// It is kept in sync with the value_must_be_dns_like constraint of the alias
+// table.
+var valueRe = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$`)
+
+// An Alias is a globally unique Value which resolves to the target
+// DestinationId and, if set, the host HostId. Aliases are owned by a scope
+// which only determines who can manage them.
+type Alias struct {
+	*store.Alias
+	tableName string `gorm:"-"`
+}
+
+// NewAlias creates a new in memory Alias in scopeId which resolves value to
+// the target destinationId. The value must be a valid alias value.
+//
+// WithName, WithDescription and WithHostId are the only valid options.
+func NewAlias(ctx context.Context, scopeId, value, destinationId string, opt ...Option) (*Alias, error) {
+	const op = "alias.NewAlias"
+	switch {
+	case scopeId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	case destinationId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing destination id")
+	}
+	if err := ValidateValue(ctx, value); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	opts := getOpts(opt...)
+	return &Alias{
+		Alias: &store.Alias{
+			ScopeId:       scopeId,
+			Name:          opts.withName,
+			Description:   opts.withDescription,
+			Value:         value,
+			DestinationId: destinationId,
+			HostId:        opts.withHostId,
+		},
+	}, nil
+}
+
+// ValidateValue returns an error unless v is a valid alias value: dot
+// separated labels of lowercase letters, digits and hyphens which neither
+// start nor end with a hyphen.
+func ValidateValue(ctx context.Context, v string) error {
+	const op = "alias.ValidateValue"
+	switch {
+	case v == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing value")
+	case len(v) > maxValueLength:
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("value is longer than %d characters", maxValueLength))
+	case !valueRe.MatchString(v):
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q is not a DNS-style name of lowercase letters, digits and hyphens", v))
+	}
+	return nil
+}
+
+// IsValue reports whether s is a valid alias value. Public ids are never
+// valid alias values, so it can be used to tell an alias from an id.
+func IsValue(s string) bool {
+	return len(s) <= maxValueLength && valueRe.MatchString(s)
+}
+
+func allocAlias() *Alias {
+	return &Alias{
+		Alias: &store.Alias{},
+	}
+}
+
+var _ db.VetForWriter = (*Alias)(nil)
+
+func (a *Alias) clone() *Alias {
+	cp := proto.Clone(a.Alias)
+	return &Alias{
+		Alias: cp.(*store.Alias),
+	}
+}
+
+// TableName returns the table name.
+func (a *Alias) TableName() string {
+	if a.tableName != "" {
+		return a.tableName
+	}
+	return aliasTableName
+}
+
+// SetTableName sets the table name.
+func (a *Alias) SetTableName(name string) {
+	a.tableName = name
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the alias
+// before it's written.
+func (a *Alias) VetForWrite(ctx context.Context, _ db.Reader, opType db.OpType, _ ...db.Option) error {
+	const op = "alias.(Alias).VetForWrite"
+	if a.PublicId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	if opType == db.CreateOp && a.ScopeId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	return nil
+}
+
+func (a *Alias) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{a.PublicId},
+		"resource-type":      []string{"alias"},
+		"op-type":            []string{op.String()},
+	}
+	if a.ScopeId != "" {
+		metadata["scope-id"] = []string{a.ScopeId}
+	}
+	return metadata
+}
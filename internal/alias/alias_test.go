@@ -0,0 +1,79 @@
+package alias
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateValue(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{value: "prod-db.payments"},
+		{value: "db"},
+		{value: "a1.b2.c3"},
+		{value: strings.Repeat("a", 63)},
+		{value: "", wantErr: true},
+		{value: "Prod-db", wantErr: true},
+		{value: "-db", wantErr: true},
+		{value: "db-", wantErr: true},
+		{value: "prod..db", wantErr: true},
+		{value: ".db", wantErr: true},
+		{value: "db.", wantErr: true},
+		{value: "ttcp_1234567890", wantErr: true},
+		{value: "prod db", wantErr: true},
+		{value: strings.Repeat("a", 64), wantErr: true},
+		{value: strings.Repeat("a.", 127) + "a", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.value, func(t *testing.T) {
+			err := ValidateValue(ctx, tt.value)
+			if tt.wantErr {
+				assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+				assert.False(t, IsValue(tt.value))
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, IsValue(tt.value))
+		})
+	}
+}
+
+func TestNewAlias(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		a, err := NewAlias(ctx, "o_1234567890", "prod-db.payments", "ttcp_1234567890",
+			WithName("name"), WithDescription("desc"), WithHostId("hst_1234567890"))
+		require.NoError(err)
+		assert.Equal("o_1234567890", a.ScopeId)
+		assert.Equal("prod-db.payments", a.Value)
+		assert.Equal("ttcp_1234567890", a.DestinationId)
+		assert.Equal("hst_1234567890", a.HostId)
+		assert.Equal("name", a.Name)
+		assert.Equal("desc", a.Description)
+		assert.Empty(a.PublicId)
+	})
+	t.Run("missing-scope", func(t *testing.T) {
+		_, err := NewAlias(ctx, "", "prod-db", "ttcp_1234567890")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("missing-destination", func(t *testing.T) {
+		_, err := NewAlias(ctx, "o_1234567890", "prod-db", "")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("invalid-value", func(t *testing.T) {
+		_, err := NewAlias(ctx, "o_1234567890", "Prod_DB", "ttcp_1234567890")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
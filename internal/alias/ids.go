@@ -0,0 +1,17 @@
+package alias
+
+import (
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// AliasPrefix is the prefix for Alias public ids.
+const AliasPrefix = "alt"
+
+func newAliasId() (string, error) {
+	id, err := db.NewPublicId(AliasPrefix)
+	if err != nil {
+		return "", errors.WrapDeprecated(err, "alias.newAliasId")
+	}
+	return id, nil
+}
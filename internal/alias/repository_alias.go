@@ -0,0 +1,262 @@
+package alias
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreateAlias inserts a into the repository and returns a new Alias
+// containing the alias's PublicId. a is not changed. a must contain a valid
+// ScopeId, Value and DestinationId. a must not contain a PublicId. The
+// PublicId is generated and assigned by this method.
+//
+// The Value must not be used by any other alias. If a.Name is set, it must
+// be unique within a.ScopeId.
+func (r *Repository) CreateAlias(ctx context.Context, a *Alias, _ ...Option) (*Alias, error) {
+	const op = "alias.(Repository).CreateAlias"
+	switch {
+	case a == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil Alias")
+	case a.Alias == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil embedded Alias")
+	case a.ScopeId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	case a.DestinationId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no destination id")
+	case a.PublicId != "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	}
+	if err := ValidateValue(ctx, a.Value); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	a = a.clone()
+	id, err := newAliasId()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	a.PublicId = id
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, a.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var newAlias *Alias
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newAlias = a.clone()
+			if err := w.Create(ctx, newAlias, db.WithOplog(oplogWrapper, a.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("in scope: %s: value %s or name %s already exists", a.ScopeId, a.Value, a.Name))
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in scope: %s", a.ScopeId)))
+	}
+	return newAlias, nil
+}
+
+// UpdateAlias updates the repository entry for a.PublicId with the values
+// in a for the fields listed in fieldMaskPaths. It returns a new Alias
+// containing the updated values and a count of the number of records
+// updated. a is not changed.
+//
+// a must contain a valid PublicId. Name, Description, Value, DestinationId
+// and HostId can be updated. Value and DestinationId can not be unset.
+//
+// An attribute of a will be set to NULL in the database if the attribute in
+// a is the zero value and it is included in fieldMaskPaths.
+func (r *Repository) UpdateAlias(ctx context.Context, a *Alias, version uint32, fieldMaskPaths []string, _ ...Option) (*Alias, int, error) {
+	const op = "alias.(Repository).UpdateAlias"
+	switch {
+	case a == nil:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "nil Alias")
+	case a.Alias == nil:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "nil embedded Alias")
+	case a.PublicId == "":
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	case version == 0:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no version")
+	case len(fieldMaskPaths) == 0:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
+	}
+
+	var dbMask, nullFields []string
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("name", f) && a.Name == "":
+			nullFields = append(nullFields, "name")
+		case strings.EqualFold("name", f) && a.Name != "":
+			dbMask = append(dbMask, "name")
+		case strings.EqualFold("description", f) && a.Description == "":
+			nullFields = append(nullFields, "description")
+		case strings.EqualFold("description", f) && a.Description != "":
+			dbMask = append(dbMask, "description")
+		case strings.EqualFold("value", f):
+			if err := ValidateValue(ctx, a.Value); err != nil {
+				return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+			}
+			dbMask = append(dbMask, "value")
+		case strings.EqualFold("destinationid", f), strings.EqualFold("destination_id", f):
+			if a.DestinationId == "" {
+				return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "destination id can not be unset")
+			}
+			dbMask = append(dbMask, "DestinationId")
+		case (strings.EqualFold("hostid", f) || strings.EqualFold("host_id", f)) && a.HostId == "":
+			nullFields = append(nullFields, "HostId")
+		case strings.EqualFold("hostid", f), strings.EqualFold("host_id", f):
+			dbMask = append(dbMask, "HostId")
+		default:
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
+		}
+	}
+
+	current, err := r.LookupAlias(ctx, a.PublicId)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	if current == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("alias %s not found", a.PublicId))
+	}
+
+	a = a.clone()
+	a.ScopeId = current.ScopeId
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, a.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsUpdated int
+	var returnedAlias *Alias
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedAlias = a.clone()
+			var err error
+			rowsUpdated, err = w.Update(ctx, returnedAlias, dbMask, nullFields,
+				db.WithOplog(oplogWrapper, a.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+				db.WithVersion(&version))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("in %s: value %s or name %s already exists", a.PublicId, a.Value, a.Name))
+		}
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in %s", a.PublicId)))
+	}
+	return returnedAlias, rowsUpdated, nil
+}
+
+// LookupAlias returns the Alias for publicId. Returns nil, nil if no Alias
+// is found for publicId.
+func (r *Repository) LookupAlias(ctx context.Context, publicId string, _ ...Option) (*Alias, error) {
+	const op = "alias.(Repository).LookupAlias"
+	if publicId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+	a := allocAlias()
+	a.PublicId = publicId
+	if err := r.reader.LookupByPublicId(ctx, a); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", publicId)))
+	}
+	return a, nil
+}
+
+// LookupAliasByValue returns the Alias with the value. Returns nil, nil if
+// no Alias has the value.
+func (r *Repository) LookupAliasByValue(ctx context.Context, value string, _ ...Option) (*Alias, error) {
+	const op = "alias.(Repository).LookupAliasByValue"
+	if value == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no value")
+	}
+	a := allocAlias()
+	if err := r.reader.LookupWhere(ctx, a, "value = ?", []interface{}{value}); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", value)))
+	}
+	return a, nil
+}
+
+// ListAliases returns a slice of Aliases for the scopeIds. WithLimit is the
+// only option supported.
+func (r *Repository) ListAliases(ctx context.Context, scopeIds []string, opt ...Option) ([]*Alias, error) {
+	const op = "alias.(Repository).ListAliases"
+	if len(scopeIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var aliases []*Alias
+	if err := r.reader.SearchWhere(ctx, &aliases, "scope_id in (?)", []interface{}{scopeIds}, db.WithLimit(limit)); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return aliases, nil
+}
+
+// DeleteAlias deletes publicId from the repository and returns the number
+// of records deleted.
+func (r *Repository) DeleteAlias(ctx context.Context, publicId string, _ ...Option) (int, error) {
+	const op = "alias.(Repository).DeleteAlias"
+	if publicId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+	a, err := r.LookupAlias(ctx, publicId)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	if a == nil {
+		return db.NoRowsAffected, nil
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, a.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			da := a.clone()
+			var err error
+			rowsDeleted, err = w.Delete(ctx, da, db.WithOplog(oplogWrapper, a.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsDeleted > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("delete failed for %s", a.PublicId)))
+	}
+	return rowsDeleted, nil
+}
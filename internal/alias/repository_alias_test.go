@@ -0,0 +1,107 @@
+package alias
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_AliasCrud(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	tar := tcp.TestTarget(ctx, t, conn, proj.PublicId, "target")
+	other := tcp.TestTarget(ctx, t, conn, proj.PublicId, "other")
+	cat := static.TestCatalogs(t, conn, proj.PublicId, 1)[0]
+	host := static.TestHosts(t, conn, cat.PublicId, 1)[0]
+
+	t.Run("create", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		a, err := NewAlias(ctx, org.PublicId, "prod-db.payments", tar.GetPublicId(), WithName("created"), WithHostId(host.PublicId))
+		require.NoError(err)
+		got, err := repo.CreateAlias(ctx, a)
+		require.NoError(err)
+		assert.NotEmpty(got.PublicId)
+		assert.Equal(uint32(1), got.Version)
+		assert.Equal(host.PublicId, got.HostId)
+
+		// The value is unique across all scopes.
+		a.Name = "duplicate"
+		a.ScopeId = proj.PublicId
+		_, err = repo.CreateAlias(ctx, a)
+		assert.True(errors.Match(errors.T(errors.NotUnique), err))
+	})
+	t.Run("unknown-destination", func(t *testing.T) {
+		a, err := NewAlias(ctx, org.PublicId, "unknown", "ttcp_1234567890")
+		require.NoError(t, err)
+		_, err = repo.CreateAlias(ctx, a)
+		assert.Error(t, err)
+	})
+	t.Run("update-lookup-list-delete", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		a := TestAlias(t, conn, proj.PublicId, "web", tar.GetPublicId(), WithHostId(host.PublicId))
+
+		byValue, err := repo.LookupAliasByValue(ctx, "web")
+		require.NoError(err)
+		require.NotNil(byValue)
+		assert.Equal(a.PublicId, byValue.PublicId)
+
+		a.Value = "web.prod"
+		a.DestinationId = other.GetPublicId()
+		a.HostId = ""
+		updated, n, err := repo.UpdateAlias(ctx, a, 1, []string{"Value", "DestinationId", "HostId"})
+		require.NoError(err)
+		assert.Equal(1, n)
+		assert.Equal("web.prod", updated.Value)
+		assert.Equal(other.GetPublicId(), updated.DestinationId)
+		assert.Empty(updated.HostId)
+		assert.Equal(uint32(2), updated.Version)
+
+		a.Value = "Not Valid"
+		_, _, err = repo.UpdateAlias(ctx, a, 2, []string{"Value"})
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		a.DestinationId = ""
+		_, _, err = repo.UpdateAlias(ctx, a, 2, []string{"DestinationId"})
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+
+		byValue, err = repo.LookupAliasByValue(ctx, "web")
+		require.NoError(err)
+		assert.Nil(byValue)
+
+		list, err := repo.ListAliases(ctx, []string{proj.PublicId})
+		require.NoError(err)
+		assert.Len(list, 1)
+
+		deleted, err := repo.DeleteAlias(ctx, a.PublicId)
+		require.NoError(err)
+		assert.Equal(1, deleted)
+		got, err := repo.LookupAlias(ctx, a.PublicId)
+		require.NoError(err)
+		assert.Nil(got)
+	})
+	t.Run("deleted-with-target", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		doomed := tcp.TestTarget(ctx, t, conn, proj.PublicId, "doomed")
+		a := TestAlias(t, conn, proj.PublicId, "doomed", doomed.GetPublicId())
+		_, err := rw.Exec(ctx, "delete from target where public_id = ?", []interface{}{doomed.GetPublicId()})
+		require.NoError(err)
+		got, err := repo.LookupAlias(ctx, a.PublicId)
+		require.NoError(err)
+		assert.Nil(got)
+	})
+}
@@ -0,0 +1,24 @@
+package alias
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAlias creates an alias in scopeId which resolves value to the target
+// destinationId. WithName, WithDescription and WithHostId are supported.
+func TestAlias(t *testing.T, conn *db.DB, scopeId, value, destinationId string, opt ...Option) *Alias {
+	t.Helper()
+	ctx := context.Background()
+	require := require.New(t)
+	a, err := NewAlias(ctx, scopeId, value, destinationId, opt...)
+	require.NoError(err)
+	id, err := newAliasId()
+	require.NoError(err)
+	a.PublicId = id
+	require.NoError(db.New(conn).Create(ctx, a))
+	return a
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/gen/controller/api"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/accounts"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/aliases"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authmethods"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authtokens"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/credentiallibraries"
@@ -14,9 +15,13 @@ import (
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hosts"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hostsets"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/managedgroups"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/notifications"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/oplogchanges"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/plugins"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/reports"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/roles"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/serviceaccounts"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/sessions"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/users"
@@ -42,6 +47,9 @@ type fieldInfo struct {
 	SubtypeNames      []string
 	Query             bool
 	SkipDefault       bool
+	// JsonString is set for 64-bit integer fields, which protojson encodes as
+	// JSON strings.
+	JsonString bool
 }
 
 type structInfo struct {
@@ -230,6 +238,67 @@ var inputStructs = []*structInfo{
 		createResponseTypes: true,
 		recursiveListing:    true,
 	},
+	// Service account related resources
+	{
+		inProto:     &serviceaccounts.Credential{},
+		outFile:     "serviceaccounts/credential.gen.go",
+		skipOptions: true,
+	},
+	{
+		inProto: &serviceaccounts.ServiceAccount{},
+		outFile: "serviceaccounts/service_account.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			createTemplate,
+			readTemplate,
+			updateTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		pluralResourceName:  "service-accounts",
+		versionEnabled:      true,
+		createResponseTypes: true,
+		recursiveListing:    true,
+	},
+	// Notification related resources
+	{
+		inProto:     &notifications.DeliveryStatus{},
+		outFile:     "notifications/delivery_status.gen.go",
+		skipOptions: true,
+	},
+	{
+		inProto: &notifications.Notification{},
+		outFile: "notifications/notification.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			createTemplate,
+			readTemplate,
+			updateTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		pluralResourceName:  "notifications",
+		versionEnabled:      true,
+		createResponseTypes: true,
+		recursiveListing:    true,
+	},
+	// Alias related resources
+	{
+		inProto: &aliases.Alias{},
+		outFile: "aliases/alias.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			createTemplate,
+			readTemplate,
+			updateTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		pluralResourceName:  "aliases",
+		versionEnabled:      true,
+		createResponseTypes: true,
+		recursiveListing:    true,
+	},
 	// Role related resources
 	{
 		inProto:     &roles.Grant{},
@@ -283,11 +352,21 @@ var inputStructs = []*structInfo{
 		outFile:     "authmethods/oidc_auth_method_attributes.gen.go",
 		subtypeName: "OidcAuthMethod",
 	},
+	{
+		inProto:     &authmethods.CertAuthMethodAttributes{},
+		outFile:     "authmethods/cert_auth_method_attributes.gen.go",
+		subtypeName: "CertAuthMethod",
+	},
 	{
 		inProto:     &authmethods.OidcAuthMethodAuthenticateStartResponse{},
 		outFile:     "authmethods/oidc_auth_method_authenticate_start_response.gen.go",
 		subtypeName: "OidcAuthMethod",
 	},
+	{
+		inProto:     &authmethods.PasswordAuthMethodAuthenticateMfaChallengeResponse{},
+		outFile:     "authmethods/password_auth_method_authenticate_mfa_challenge_response.gen.go",
+		subtypeName: "PasswordAuthMethod",
+	},
 	{
 		inProto: &authmethods.AuthMethod{},
 		outFile: "authmethods/authmethods.gen.go",
@@ -316,6 +395,11 @@ var inputStructs = []*structInfo{
 		outFile:     "accounts/oidc_account_attributes.gen.go",
 		subtypeName: "OidcAccount",
 	},
+	{
+		inProto:     &accounts.CertAccountAttributes{},
+		outFile:     "accounts/cert_account_attributes.gen.go",
+		subtypeName: "CertAccount",
+	},
 	{
 		inProto: &accounts.Account{},
 		outFile: "accounts/account.gen.go",
@@ -366,6 +450,7 @@ var inputStructs = []*structInfo{
 		outFile: "authtokens/authtokens.gen.go",
 		templates: []*template.Template{
 			clientTemplate,
+			createTemplate,
 			readTemplate,
 			deleteTemplate,
 			listTemplate,
@@ -419,6 +504,17 @@ var inputStructs = []*structInfo{
 			},
 		},
 	},
+	{
+		inProto:     &credentiallibraries.VaultSshSignedCertCredentialLibraryAttributes{},
+		outFile:     "credentiallibraries/vault_ssh_signed_cert_credential_library_attributes.gen.go",
+		subtypeName: "VaultSshSignedCertCredentialLibrary",
+		fieldOverrides: []fieldInfo{
+			{
+				Name:        "Path",
+				SkipDefault: true,
+			},
+		},
+	},
 	{
 		inProto: &credentiallibraries.CredentialLibrary{},
 		outFile: "credentiallibraries/credential_library.gen.go",
@@ -432,6 +528,7 @@ var inputStructs = []*structInfo{
 		},
 		pluralResourceName:  "credential-libraries",
 		parentTypeName:      "credential-store",
+		typeOnCreate:        true,
 		versionEnabled:      true,
 		createResponseTypes: true,
 	},
@@ -480,6 +577,17 @@ var inputStructs = []*structInfo{
 			},
 		},
 	},
+	{
+		inProto:     &credentiallibraries.VaultSshSignedCertCredentialLibraryAttributes{},
+		outFile:     "credentiallibraries/vault_ssh_signed_cert_credential_library_attributes.gen.go",
+		subtypeName: "VaultSshSignedCertCredentialLibrary",
+		fieldOverrides: []fieldInfo{
+			{
+				Name:        "Path",
+				SkipDefault: true,
+			},
+		},
+	},
 	{
 		inProto: &credentiallibraries.CredentialLibrary{},
 		outFile: "credentiallibraries/credential_library.gen.go",
@@ -493,6 +601,7 @@ var inputStructs = []*structInfo{
 		},
 		pluralResourceName:  "credential-libraries",
 		parentTypeName:      "credential-store",
+		typeOnCreate:        true,
 		versionEnabled:      true,
 		createResponseTypes: true,
 	},
@@ -617,6 +726,11 @@ var inputStructs = []*structInfo{
 		outFile:     "targets/tcp_target_attributes.gen.go",
 		subtypeName: "TcpTarget",
 	},
+	{
+		inProto:     &targets.KubernetesTargetAttributes{},
+		outFile:     "targets/kubernetes_target_attributes.gen.go",
+		subtypeName: "KubernetesTarget",
+	},
 	{
 		inProto: &targets.Target{},
 		outFile: "targets/target.gen.go",
@@ -675,6 +789,20 @@ var inputStructs = []*structInfo{
 				ProtoName: "egress_credential_source_ids",
 				FieldType: "[]string",
 			},
+			{
+				Name:        "LabelSelector",
+				ProtoName:   "label_selector",
+				FieldType:   "string",
+				SkipDefault: true,
+				Query:       true,
+			},
+			{
+				Name:        "Authorized",
+				ProtoName:   "authorized",
+				FieldType:   "bool",
+				SkipDefault: true,
+				Query:       true,
+			},
 		},
 		versionEnabled:      true,
 		typeOnCreate:        true,
@@ -701,9 +829,48 @@ var inputStructs = []*structInfo{
 			readTemplate,
 			listTemplate,
 		},
+		extraFields: []fieldInfo{
+			{
+				Name:        "CancelReason",
+				ProtoName:   "reason",
+				FieldType:   "string",
+				SkipDefault: true,
+			},
+		},
 		pluralResourceName:  "sessions",
 		createResponseTypes: true,
 		fieldFilter:         []string{"private_key"},
 		recursiveListing:    true,
 	},
+	// Oplog Changes
+	{
+		inProto: &oplogchanges.OplogOperation{},
+		outFile: "oplogchanges/oplog_operation.gen.go",
+	},
+	{
+		inProto: &oplogchanges.OplogChange{},
+		outFile: "oplogchanges/oplog_change.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			listTemplate,
+		},
+		pluralResourceName:  "oplog-changes",
+		createResponseTypes: true,
+	},
+
+	// Reports
+	{
+		inProto: &reports.ReportResult{},
+		outFile: "reports/report_result.gen.go",
+	},
+	{
+		inProto: &reports.Report{},
+		outFile: "reports/report.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			listTemplate,
+		},
+		pluralResourceName:  "reports",
+		createResponseTypes: true,
+	},
 }
@@ -62,12 +62,17 @@ func parsePBs() {
 			switch k := fd.Kind(); k {
 			case protoreflect.MessageKind:
 				ptr, pkg, name := messageKind(fd)
+				if fd.Message().FullName() == uInt64ValueName {
+					fi.JsonString = true
+				}
 				if pkg != "" && pkg != in.generatedStructure.pkg {
 					name = fmt.Sprintf("%s.%s", pkg, name)
 				}
 				switch name {
 				case "v1.AuthorizedCollectionActionsEntry":
 					fi.FieldType = "map[string][]string"
+				case "v1.LabelsEntry":
+					fi.FieldType = "map[string]string"
 				default:
 					fi.FieldType = sliceText + ptr + name
 				}
@@ -96,10 +101,12 @@ var (
 	stringValueName = (&wrapperspb.StringValue{}).ProtoReflect().Descriptor().FullName()
 	boolValueName   = (&wrapperspb.BoolValue{}).ProtoReflect().Descriptor().FullName()
 	uInt32ValueName = (&wrapperspb.UInt32Value{}).ProtoReflect().Descriptor().FullName()
+	uInt64ValueName = (&wrapperspb.UInt64Value{}).ProtoReflect().Descriptor().FullName()
 	int32ValueName  = (&wrapperspb.Int32Value{}).ProtoReflect().Descriptor().FullName()
 	structValueName = (&_struct.Struct{}).ProtoReflect().Descriptor().FullName()
 	timestampName   = (&timestamppb.Timestamp{}).ProtoReflect().Descriptor().FullName()
 	valueName       = (&_struct.Value{}).ProtoReflect().Descriptor().FullName()
+	listValueName   = (&_struct.ListValue{}).ProtoReflect().Descriptor().FullName()
 )
 
 func messageKind(fd protoreflect.FieldDescriptor) (ptr, pkg, name string) {
@@ -110,12 +117,16 @@ func messageKind(fd protoreflect.FieldDescriptor) (ptr, pkg, name string) {
 		return "", "", "bool"
 	case uInt32ValueName:
 		return "", "", "uint32"
+	case uInt64ValueName:
+		return "", "", "uint64"
 	case int32ValueName:
 		return "", "", "int32"
 	case structValueName:
 		return "", "", "map[string]interface{}"
 	case valueName:
 		return "", "", "interface{}"
+	case listValueName:
+		return "", "", "[]interface{}"
 	case timestampName:
 		return "", "time", "Time"
 	default:
@@ -214,8 +214,12 @@ func TestSelfReadingDifferentOutputFields(t *testing.T) {
 	s, err := authmethodsservice.NewService(tc.Kms(),
 		tc.Controller().PasswordAuthRepoFn,
 		tc.Controller().OidcRepoFn,
+		tc.Controller().CertAuthRepoFn,
 		tc.Controller().IamRepoFn,
-		tc.Controller().AuthTokenRepoFn)
+		tc.Controller().AuthTokenRepoFn,
+		nil,
+		nil,
+		nil)
 	require.NoError(t, err)
 
 	// Create two auth tokens belonging to different users in the org. Each will
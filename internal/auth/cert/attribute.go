@@ -0,0 +1,119 @@
+package cert
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// An Attribute is an attribute of a client certificate which can be mapped to
+// a field of an account.
+type Attribute string
+
+const (
+	// CommonNameAttribute is the common name of the certificate subject.
+	CommonNameAttribute Attribute = "cn"
+	// DnsSanAttribute is the first DNS name subject alternative name.
+	DnsSanAttribute Attribute = "dns_san"
+	// EmailSanAttribute is the first email address subject alternative name.
+	EmailSanAttribute Attribute = "email_san"
+	// UriSanAttribute is the first URI subject alternative name, e.g. a
+	// SPIFFE ID.
+	UriSanAttribute Attribute = "uri_san"
+)
+
+// validAttribute reports whether a is a known certificate attribute.
+func validAttribute(a Attribute) bool {
+	switch a {
+	case CommonNameAttribute, DnsSanAttribute, EmailSanAttribute, UriSanAttribute:
+		return true
+	}
+	return false
+}
+
+// value returns the value of the attribute in c or an empty string if c does
+// not have the attribute.
+func (a Attribute) value(c *x509.Certificate) string {
+	switch a {
+	case CommonNameAttribute:
+		return c.Subject.CommonName
+	case DnsSanAttribute:
+		if len(c.DNSNames) > 0 {
+			return c.DNSNames[0]
+		}
+	case EmailSanAttribute:
+		if len(c.EmailAddresses) > 0 {
+			return c.EmailAddresses[0]
+		}
+	case UriSanAttribute:
+		if len(c.URIs) > 0 {
+			return c.URIs[0].String()
+		}
+	}
+	return ""
+}
+
+// An AccountField is a field of an account which a certificate attribute can
+// be mapped to.
+type AccountField string
+
+const (
+	ToSubjectField AccountField = "subject"
+	ToNameField    AccountField = "name"
+	ToEmailField   AccountField = "email"
+)
+
+// AttributeMap maps the certificate attribute From to the account field To.
+type AttributeMap struct {
+	From Attribute
+	To   AccountField
+}
+
+// String returns the attribute map in the from=to form used by the API.
+func (m AttributeMap) String() string {
+	return fmt.Sprintf("%s=%s", m.From, m.To)
+}
+
+// ParseAttributeMaps parses attribute maps represented as from=to, for
+// example "email_san=email". An account field may only be mapped once.
+func ParseAttributeMaps(ctx context.Context, ms ...string) ([]AttributeMap, error) {
+	const op = "cert.ParseAttributeMaps"
+	var maps []AttributeMap
+	seen := make(map[AccountField]bool, len(ms))
+	for _, m := range ms {
+		from, to, ok := splitAttributeMap(m)
+		if !ok {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q is not in the format from=to", m))
+		}
+		am := AttributeMap{From: Attribute(from), To: AccountField(to)}
+		if !validAttribute(am.From) {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q is not a valid certificate attribute", from))
+		}
+		switch am.To {
+		case ToSubjectField, ToNameField, ToEmailField:
+		default:
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q is not a valid account field", to))
+		}
+		if seen[am.To] {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q is mapped more than once", to))
+		}
+		seen[am.To] = true
+		maps = append(maps, am)
+	}
+	return maps, nil
+}
+
+func splitAttributeMap(m string) (string, string, bool) {
+	parts := strings.SplitN(m, "=", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	from, to := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+	if from == "" || to == "" {
+		return "", "", false
+	}
+	return from, to, true
+}
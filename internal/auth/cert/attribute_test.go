@@ -0,0 +1,146 @@
+package cert
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAttributeMaps(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		maps      []string
+		want      []AttributeMap
+		wantErr   bool
+		wantIsErr errors.Code
+	}{
+		{
+			name: "valid",
+			maps: []string{"uri_san=subject", "cn = name", "email_san=email"},
+			want: []AttributeMap{
+				{From: UriSanAttribute, To: ToSubjectField},
+				{From: CommonNameAttribute, To: ToNameField},
+				{From: EmailSanAttribute, To: ToEmailField},
+			},
+		},
+		{
+			name: "none",
+		},
+		{
+			name:      "missing-separator",
+			maps:      []string{"cn"},
+			wantErr:   true,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-to",
+			maps:      []string{"cn="},
+			wantErr:   true,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "invalid-attribute",
+			maps:      []string{"ou=name"},
+			wantErr:   true,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "invalid-field",
+			maps:      []string{"cn=login"},
+			wantErr:   true,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "duplicate-field",
+			maps:      []string{"cn=name", "dns_san=name"},
+			wantErr:   true,
+			wantIsErr: errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			got, err := ParseAttributeMaps(ctx, tt.maps...)
+			if tt.wantErr {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err code: %q got: %q", tt.wantIsErr, err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestAuthMethod_accountFields(t *testing.T) {
+	t.Parallel()
+	spiffe, err := url.Parse("spiffe://example.com/ns/default/sa/ci")
+	require.NoError(t, err)
+	c := &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "Alice Doe"},
+		DNSNames:       []string{"alice.example.com"},
+		EmailAddresses: []string{"alice@example.com"},
+		URIs:           []*url.URL{spiffe},
+	}
+	tests := []struct {
+		name         string
+		maps         []AttributeMap
+		cert         *x509.Certificate
+		wantSubject  string
+		wantFullName string
+		wantEmail    string
+	}{
+		{
+			name:        "default-uri-san",
+			cert:        c,
+			wantSubject: "spiffe://example.com/ns/default/sa/ci",
+		},
+		{
+			name:        "default-cn",
+			cert:        &x509.Certificate{Subject: pkix.Name{CommonName: "Alice Doe"}},
+			wantSubject: "Alice Doe",
+		},
+		{
+			name: "mapped",
+			maps: []AttributeMap{
+				{From: DnsSanAttribute, To: ToSubjectField},
+				{From: CommonNameAttribute, To: ToNameField},
+				{From: EmailSanAttribute, To: ToEmailField},
+			},
+			cert:         c,
+			wantSubject:  "alice.example.com",
+			wantFullName: "Alice Doe",
+			wantEmail:    "alice@example.com",
+		},
+		{
+			name:        "mapped-missing",
+			maps:        []AttributeMap{{From: EmailSanAttribute, To: ToSubjectField}},
+			cert:        &x509.Certificate{Subject: pkix.Name{CommonName: "Alice Doe"}},
+			wantSubject: "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert := assert.New(t)
+			am := AllocAuthMethod()
+			am.setAttributeMaps(tt.maps)
+			assert.Equal(tt.maps, am.AttributeMaps())
+			subject, fullName, email := am.accountFields(tt.cert)
+			assert.Equal(tt.wantSubject, subject)
+			assert.Equal(tt.wantFullName, fullName)
+			assert.Equal(tt.wantEmail, email)
+		})
+	}
+}
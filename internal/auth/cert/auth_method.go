@@ -0,0 +1,189 @@
+package cert
+
+import (
+	"context"
+	"crypto/x509"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/auth/cert/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultAuthMethodTableName defines the default table name for an AuthMethod
+const defaultAuthMethodTableName = "auth_cert_method"
+
+// AuthMethod contains a client certificate auth method. It is owned by a
+// scope. A client certificate authenticates with the auth method if it chains
+// to one of the CA certificates of the auth method.
+type AuthMethod struct {
+	*store.AuthMethod
+	tableName string
+}
+
+// NewAuthMethod creates a new in memory AuthMethod assigned to scopeId which
+// trusts the client certificates issued by caCerts. caCerts may only be empty
+// for an AuthMethod used to update other fields of an existing auth method.
+// WithName, WithDescription and WithAttributeMaps are the only valid options.
+// All other options are ignored.
+func NewAuthMethod(ctx context.Context, scopeId string, caCerts []*x509.Certificate, opt ...Option) (*AuthMethod, error) {
+	const op = "cert.NewAuthMethod"
+	opts := getOpts(opt...)
+	a := &AuthMethod{
+		AuthMethod: &store.AuthMethod{
+			ScopeId:     scopeId,
+			Name:        opts.withName,
+			Description: opts.withDescription,
+		},
+	}
+	if len(caCerts) > 0 {
+		pems, err := EncodeCertificates(ctx, caCerts...)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		a.CaCertificates = strings.Join(pems, "")
+	}
+	a.setAttributeMaps(opts.withAttributeMaps)
+	if err := a.validate(ctx, op); err != nil {
+		return nil, err // intentionally not wrapped.
+	}
+	return a, nil
+}
+
+// validate the AuthMethod. On success, it will return nil.
+func (a *AuthMethod) validate(ctx context.Context, caller errors.Op) error {
+	if a.ScopeId == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing scope id")
+	}
+	if a.CaCertificates != "" {
+		if _, err := ParseCertificates(ctx, a.CaCertificates); err != nil {
+			return errors.Wrap(ctx, err, caller)
+		}
+	}
+	for _, attr := range []string{a.SubjectAttribute, a.NameAttribute, a.EmailAttribute} {
+		if attr != "" && !validAttribute(Attribute(attr)) {
+			return errors.New(ctx, errors.InvalidParameter, caller, "invalid certificate attribute "+attr)
+		}
+	}
+	return nil
+}
+
+// AllocAuthMethod makes an empty one in memory
+func AllocAuthMethod() *AuthMethod {
+	return &AuthMethod{
+		AuthMethod: &store.AuthMethod{},
+	}
+}
+
+// Clone an AuthMethod.
+func (a *AuthMethod) Clone() *AuthMethod {
+	cp := proto.Clone(a.AuthMethod)
+	return &AuthMethod{
+		AuthMethod: cp.(*store.AuthMethod),
+	}
+}
+
+// TableName returns the table name.
+func (a *AuthMethod) TableName() string {
+	if a.tableName != "" {
+		return a.tableName
+	}
+	return defaultAuthMethodTableName
+}
+
+// SetTableName sets the table name.
+func (a *AuthMethod) SetTableName(n string) {
+	a.tableName = n
+}
+
+// Certificates returns the CA certificates of the auth method.
+func (a *AuthMethod) Certificates(ctx context.Context) ([]*x509.Certificate, error) {
+	const op = "cert.(AuthMethod).Certificates"
+	certs, err := ParseCertificates(ctx, a.CaCertificates)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return certs, nil
+}
+
+// AttributeMaps returns the maps from certificate attributes to account
+// fields of the auth method.
+func (a *AuthMethod) AttributeMaps() []AttributeMap {
+	var maps []AttributeMap
+	if a.SubjectAttribute != "" {
+		maps = append(maps, AttributeMap{From: Attribute(a.SubjectAttribute), To: ToSubjectField})
+	}
+	if a.NameAttribute != "" {
+		maps = append(maps, AttributeMap{From: Attribute(a.NameAttribute), To: ToNameField})
+	}
+	if a.EmailAttribute != "" {
+		maps = append(maps, AttributeMap{From: Attribute(a.EmailAttribute), To: ToEmailField})
+	}
+	return maps
+}
+
+func (a *AuthMethod) setAttributeMaps(maps []AttributeMap) {
+	a.SubjectAttribute, a.NameAttribute, a.EmailAttribute = "", "", ""
+	for _, m := range maps {
+		switch m.To {
+		case ToSubjectField:
+			a.SubjectAttribute = string(m.From)
+		case ToNameField:
+			a.NameAttribute = string(m.From)
+		case ToEmailField:
+			a.EmailAttribute = string(m.From)
+		}
+	}
+}
+
+// accountFields returns the subject, full name and email of the account of
+// the client certificate c. If the auth method does not map an attribute to
+// the subject, the first URI SAN of c is used, or its common name if it has
+// no URI SAN.
+func (a *AuthMethod) accountFields(c *x509.Certificate) (subject, fullName, email string) {
+	switch {
+	case a.SubjectAttribute != "":
+		subject = Attribute(a.SubjectAttribute).value(c)
+	default:
+		if subject = UriSanAttribute.value(c); subject == "" {
+			subject = CommonNameAttribute.value(c)
+		}
+	}
+	if a.NameAttribute != "" {
+		fullName = Attribute(a.NameAttribute).value(c)
+	}
+	if a.EmailAttribute != "" {
+		email = Attribute(a.EmailAttribute).value(c)
+	}
+	return subject, fullName, email
+}
+
+// oplog will create oplog metadata for the AuthMethod.
+func (a *AuthMethod) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{a.GetPublicId()},
+		"resource-type":      []string{"cert auth method"},
+		"op-type":            []string{op.String()},
+	}
+	if a.ScopeId != "" {
+		metadata["scope-id"] = []string{a.ScopeId}
+	}
+	return metadata
+}
+
+// authMethodView provides a simple way to read an AuthMethod with its
+// IsPrimaryAuthMethod field set. By definition, it's used only for reading
+// AuthMethods.
+type authMethodView struct {
+	*store.AuthMethod
+	tableName string
+}
+
+// TableName returns the view name.
+func (a *authMethodView) TableName() string {
+	if a.tableName != "" {
+		return a.tableName
+	}
+	return "auth_cert_method_with_is_primary"
+}
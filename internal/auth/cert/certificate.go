@@ -0,0 +1,112 @@
+package cert
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// ParseCertificates parses PEM encoded x509 certificates. Each PEM may
+// contain a bundle of certificates.
+func ParseCertificates(ctx context.Context, pems ...string) ([]*x509.Certificate, error) {
+	const op = "cert.ParseCertificates"
+	if len(pems) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no PEMs provided")
+	}
+	var certs []*x509.Certificate
+	for _, p := range pems {
+		if strings.TrimSpace(p) == "" {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "empty certificate PEM")
+		}
+		var found bool
+		for rest := []byte(p); len(rest) > 0; {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+			c, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, errors.New(ctx, errors.InvalidParameter, op, "failed to parse certificate: "+err.Error(), errors.WithWrap(err))
+			}
+			certs, found = append(certs, c), true
+		}
+		if !found {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "failed to parse certificate PEM")
+		}
+	}
+	return certs, nil
+}
+
+// EncodeCertificates encodes x509 certificates to PEMs.
+func EncodeCertificates(ctx context.Context, certs ...*x509.Certificate) ([]string, error) {
+	const op = "cert.EncodeCertificates"
+	if len(certs) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no certs provided")
+	}
+	pems := make([]string, 0, len(certs))
+	for _, c := range certs {
+		if c == nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "nil cert")
+		}
+		var buf bytes.Buffer
+		if err := pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: c.Raw}); err != nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "failed to encode cert: "+err.Error(), errors.WithWrap(err))
+		}
+		pems = append(pems, buf.String())
+	}
+	return pems, nil
+}
+
+// parseChain parses the DER encoded certificate chain presented by a client,
+// leaf first.
+func parseChain(ctx context.Context, chain [][]byte) ([]*x509.Certificate, error) {
+	const op = "cert.parseChain"
+	if len(chain) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing client certificate")
+	}
+	certs := make([]*x509.Certificate, 0, len(chain))
+	for _, der := range chain {
+		c, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "failed to parse client certificate", errors.WithWrap(err))
+		}
+		certs = append(certs, c)
+	}
+	return certs, nil
+}
+
+// verifyChain verifies that the leaf of chain is a client certificate issued
+// by one of caCerts, using the rest of chain as intermediates.
+func verifyChain(ctx context.Context, caCerts []*x509.Certificate, chain []*x509.Certificate, now time.Time) error {
+	const op = "cert.verifyChain"
+	if len(chain) == 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing client certificate")
+	}
+	roots := x509.NewCertPool()
+	for _, c := range caCerts {
+		roots.AddCert(c)
+	}
+	intermediates := x509.NewCertPool()
+	for _, c := range chain[1:] {
+		intermediates.AddCert(c)
+	}
+	_, err := chain[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return errors.New(ctx, errors.Unauthorized, op, "client certificate not trusted by auth method", errors.WithWrap(err))
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package cert
+
+import (
+	"context"
+	"crypto/x509"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCertificates(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	ca1, ca2 := NewTestCa(t, "ca1"), NewTestCa(t, "ca2")
+	pems, err := EncodeCertificates(ctx, ca1.Cert, ca2.Cert)
+	require.NoError(t, err)
+	require.Len(t, pems, 2)
+
+	tests := []struct {
+		name      string
+		pems      []string
+		want      []*x509.Certificate
+		wantIsErr errors.Code
+	}{
+		{
+			name: "separate",
+			pems: pems,
+			want: []*x509.Certificate{ca1.Cert, ca2.Cert},
+		},
+		{
+			name: "bundle",
+			pems: []string{strings.Join(pems, "\n")},
+			want: []*x509.Certificate{ca1.Cert, ca2.Cert},
+		},
+		{
+			name:      "none",
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "empty",
+			pems:      []string{" "},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "not-pem",
+			pems:      []string{"not a certificate"},
+			wantIsErr: errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			got, err := ParseCertificates(ctx, tt.pems...)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err code: %q got: %q", tt.wantIsErr, err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func Test_verifyChain(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	trusted, untrusted := NewTestCa(t, "trusted"), NewTestCa(t, "untrusted")
+
+	parse := func(chain [][]byte) []*x509.Certificate {
+		certs, err := parseChain(ctx, chain)
+		require.NoError(t, err)
+		return certs
+	}
+	tests := []struct {
+		name      string
+		chain     []*x509.Certificate
+		now       time.Time
+		wantIsErr errors.Code
+	}{
+		{
+			name:  "trusted",
+			chain: parse(trusted.Issue(t, TestClientCertificate{CommonName: "alice"})),
+			now:   time.Now(),
+		},
+		{
+			name:      "untrusted",
+			chain:     parse(untrusted.Issue(t, TestClientCertificate{CommonName: "alice"})),
+			now:       time.Now(),
+			wantIsErr: errors.Unauthorized,
+		},
+		{
+			name:      "expired",
+			chain:     parse(trusted.Issue(t, TestClientCertificate{CommonName: "alice"})),
+			now:       time.Now().Add(2 * time.Hour),
+			wantIsErr: errors.Unauthorized,
+		},
+		{
+			name:      "missing",
+			now:       time.Now(),
+			wantIsErr: errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			err := verifyChain(ctx, []*x509.Certificate{trusted.Cert}, tt.chain, tt.now)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err code: %q got: %q", tt.wantIsErr, err)
+				return
+			}
+			require.NoError(err)
+		})
+	}
+}
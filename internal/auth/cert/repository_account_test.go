@@ -0,0 +1,321 @@
+package cert
+
+import (
+	"context"
+	"crypto/x509"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/cert/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateAccount(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+	am := TestAuthMethod(t, conn, org.PublicId, []*x509.Certificate{NewTestCa(t, "ca").Cert})
+
+	newAccount := func(t *testing.T, subject string, opt ...Option) *Account {
+		t.Helper()
+		a, err := NewAccount(ctx, am.PublicId, subject, opt...)
+		require.NoError(t, err)
+		return a
+	}
+
+	tests := []struct {
+		name      string
+		scopeId   string
+		a         *Account
+		opt       []Option
+		wantIsErr errors.Code
+	}{
+		{
+			name:    "valid",
+			scopeId: org.PublicId,
+			a: newAccount(t, "spiffe://example.com/alice", WithName("alice"), WithDescription("desc"),
+				WithFullName("Alice"), WithEmail("alice@example.com")),
+		},
+		{
+			name:    "valid-with-public-id",
+			scopeId: org.PublicId,
+			a:       newAccount(t, "spiffe://example.com/bob"),
+			opt:     []Option{WithPublicId(AccountPrefix + "_1234567890")},
+		},
+		{
+			name:      "invalid-public-id-prefix",
+			scopeId:   org.PublicId,
+			a:         newAccount(t, "spiffe://example.com/carol"),
+			opt:       []Option{WithPublicId("acctpw_1234567890")},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "nil",
+			scopeId:   org.PublicId,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "nil-embedded",
+			scopeId:   org.PublicId,
+			a:         &Account{},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-scope-id",
+			a:         newAccount(t, "spiffe://example.com/carol"),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:    "missing-subject",
+			scopeId: org.PublicId,
+			a: &Account{Account: &store.Account{
+				AuthMethodId: am.PublicId,
+			}},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:    "subject-too-long",
+			scopeId: org.PublicId,
+			a: &Account{Account: &store.Account{
+				AuthMethodId: am.PublicId,
+				Subject:      strings.Repeat("a", 1024),
+			}},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "duplicate-subject",
+			scopeId:   org.PublicId,
+			a:         newAccount(t, "spiffe://example.com/alice"),
+			wantIsErr: errors.NotUnique,
+		},
+		{
+			name:      "duplicate-name",
+			scopeId:   org.PublicId,
+			a:         newAccount(t, "spiffe://example.com/dave", WithName("alice")),
+			wantIsErr: errors.NotUnique,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.CreateAccount(ctx, tt.scopeId, tt.a, tt.opt...)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err code: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.True(strings.HasPrefix(got.PublicId, AccountPrefix+"_"))
+			assert.Empty(tt.a.PublicId, "the account passed in must not be changed")
+			assert.Equal(tt.a.Subject, got.Subject)
+			assert.Equal(tt.a.Name, got.Name)
+			assert.Equal(tt.a.FullName, got.FullName)
+			assert.Equal(tt.a.Email, got.Email)
+
+			found, err := repo.LookupAccount(ctx, got.PublicId)
+			require.NoError(err)
+			assert.Equal(got.Subject, found.Subject)
+		})
+	}
+}
+
+func TestRepository_LookupListDeleteAccount(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+	ca := NewTestCa(t, "ca")
+	am := TestAuthMethod(t, conn, org.PublicId, []*x509.Certificate{ca.Cert})
+	otherAm := TestAuthMethod(t, conn, org.PublicId, []*x509.Certificate{ca.Cert})
+	a1 := TestAccount(t, conn, am.PublicId, "spiffe://example.com/alice", WithFullName("Alice"))
+	a2 := TestAccount(t, conn, am.PublicId, "spiffe://example.com/bob")
+	TestAccount(t, conn, otherAm.PublicId, "spiffe://example.com/alice")
+
+	t.Run("lookup", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.LookupAccount(ctx, a1.PublicId)
+		require.NoError(err)
+		assert.Equal(a1.Subject, got.Subject)
+		assert.Equal("Alice", got.FullName)
+
+		got, err = repo.LookupAccount(ctx, AccountPrefix+"_1234567890")
+		require.NoError(err)
+		assert.Nil(got)
+
+		_, err = repo.LookupAccount(ctx, "")
+		assert.True(errors.Match(errors.T(errors.InvalidPublicId), err))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ListAccounts(ctx, am.PublicId)
+		require.NoError(err)
+		var ids []string
+		for _, a := range got {
+			ids = append(ids, a.PublicId)
+		}
+		assert.ElementsMatch([]string{a1.PublicId, a2.PublicId}, ids)
+
+		got, err = repo.ListAccounts(ctx, am.PublicId, WithLimit(1))
+		require.NoError(err)
+		assert.Len(got, 1)
+
+		_, err = repo.ListAccounts(ctx, "")
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := repo.DeleteAccount(ctx, org.PublicId, "")
+		assert.True(errors.Match(errors.T(errors.InvalidPublicId), err))
+		_, err = repo.DeleteAccount(ctx, "", a2.PublicId)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+
+		n, err := repo.DeleteAccount(ctx, org.PublicId, a2.PublicId)
+		require.NoError(err)
+		assert.Equal(1, n)
+		got, err := repo.LookupAccount(ctx, a2.PublicId)
+		require.NoError(err)
+		assert.Nil(got)
+
+		n, err = repo.DeleteAccount(ctx, org.PublicId, a2.PublicId)
+		require.NoError(err)
+		assert.Zero(n)
+	})
+}
+
+func TestRepository_UpdateAccount(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+	am := TestAuthMethod(t, conn, org.PublicId, []*x509.Certificate{NewTestCa(t, "ca").Cert})
+	TestAccount(t, conn, am.PublicId, "spiffe://example.com/taken", WithName("taken"))
+
+	tests := []struct {
+		name      string
+		update    func(a *Account)
+		paths     []string
+		want      func(t *testing.T, orig, got *Account)
+		wantIsErr errors.Code
+	}{
+		{
+			name: "name-and-description",
+			update: func(a *Account) {
+				a.Name = "updated"
+				a.Description = "updated desc"
+			},
+			paths: []string{NameField, DescriptionField},
+			want: func(t *testing.T, orig, got *Account) {
+				assert.Equal(t, "updated", got.Name)
+				assert.Equal(t, "updated desc", got.Description)
+				assert.Equal(t, orig.Subject, got.Subject)
+			},
+		},
+		{
+			name: "unset-description",
+			update: func(a *Account) {
+				a.Description = ""
+			},
+			paths: []string{DescriptionField},
+			want: func(t *testing.T, orig, got *Account) {
+				assert.Empty(t, got.Description)
+				assert.Equal(t, orig.Name, got.Name)
+			},
+		},
+		{
+			name: "disable",
+			update: func(a *Account) {
+				a.Disabled = true
+			},
+			paths: []string{DisabledField},
+			want: func(t *testing.T, _, got *Account) {
+				assert.True(t, got.Disabled)
+			},
+		},
+		{
+			name: "subject-is-not-updatable",
+			update: func(a *Account) {
+				a.Subject = "spiffe://example.com/other"
+			},
+			paths:     []string{"Subject"},
+			wantIsErr: errors.InvalidFieldMask,
+		},
+		{
+			name:      "empty-field-mask",
+			wantIsErr: errors.EmptyFieldMask,
+		},
+		{
+			name: "duplicate-name",
+			update: func(a *Account) {
+				a.Name = "taken"
+			},
+			paths:     []string{NameField},
+			wantIsErr: errors.NotUnique,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			orig := TestAccount(t, conn, am.PublicId, "spiffe://example.com/"+tt.name, WithName(tt.name), WithDescription("desc"))
+			a := orig.Clone()
+			if tt.update != nil {
+				tt.update(a)
+			}
+			got, n, err := repo.UpdateAccount(ctx, org.PublicId, a, orig.Version, tt.paths)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err code: %q got: %q", tt.wantIsErr, err)
+				assert.Zero(n)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(1, n)
+			tt.want(t, orig, got)
+
+			found, err := repo.LookupAccount(ctx, orig.PublicId)
+			require.NoError(err)
+			assert.Equal(orig.Version+1, found.Version)
+			tt.want(t, orig, found)
+		})
+	}
+
+	t.Run("missing-parameters", func(t *testing.T) {
+		assert := assert.New(t)
+		a := TestAccount(t, conn, am.PublicId, "spiffe://example.com/params")
+		_, _, err := repo.UpdateAccount(ctx, "", a, a.Version, []string{NameField})
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		_, _, err = repo.UpdateAccount(ctx, org.PublicId, a, 0, []string{NameField})
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		_, _, err = repo.UpdateAccount(ctx, org.PublicId, AllocAccount(), a.Version, []string{NameField})
+		assert.True(errors.Match(errors.T(errors.InvalidPublicId), err))
+	})
+}
@@ -0,0 +1,298 @@
+package cert
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+const (
+	NameField             = "Name"
+	DescriptionField      = "Description"
+	CaCertificatesField   = "CaCertificates"
+	AttributeMapsField    = "AttributeMaps"
+	SubjectAttributeField = "SubjectAttribute"
+	NameAttributeField    = "NameAttribute"
+	EmailAttributeField   = "EmailAttribute"
+	FullNameField         = "FullName"
+	EmailField            = "Email"
+	DisabledField         = "Disabled"
+)
+
+// CreateAuthMethod inserts am into the repository and returns a new
+// AuthMethod containing the auth method's PublicId. am is not changed. am
+// must contain a valid ScopeId and CaCertificates. am must not contain a
+// PublicId. The PublicId is generated and assigned by this method.
+//
+// WithPublicId is the only valid option. All other options are ignored.
+//
+// Both am.Name and am.Description are optional. If am.Name is set, it must
+// be unique within am.ScopeId.
+func (r *Repository) CreateAuthMethod(ctx context.Context, am *AuthMethod, opt ...Option) (*AuthMethod, error) {
+	const op = "cert.(Repository).CreateAuthMethod"
+	if am == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method")
+	}
+	if am.AuthMethod == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing embedded auth method")
+	}
+	if am.PublicId != "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	}
+	if am.CaCertificates == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing ca certificates")
+	}
+	if err := am.validate(ctx, op); err != nil {
+		return nil, err
+	}
+	am = am.Clone()
+
+	opts := getOpts(opt...)
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, AuthMethodPrefix+"_") {
+			return nil, errors.New(ctx, errors.InvalidPublicId, op, fmt.Sprintf("passed-in public ID %q has wrong prefix, should be %q", opts.withPublicId, AuthMethodPrefix))
+		}
+		am.PublicId = opts.withPublicId
+	} else {
+		id, err := newAuthMethodId(ctx)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		am.PublicId = id
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, am.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var newAuthMethod *AuthMethod
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			newAuthMethod = am.Clone()
+			if err := w.Create(ctx, newAuthMethod, db.WithOplog(oplogWrapper, am.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			txRepo := &Repository{reader: reader, writer: w, kms: r.kms}
+			var err error
+			if newAuthMethod, err = txRepo.lookupAuthMethod(ctx, am.PublicId); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to lookup auth method after create"))
+			}
+			if newAuthMethod == nil {
+				return errors.New(ctx, errors.RecordNotFound, op, "unable to lookup auth method after create")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("in scope: %s: name %s already exists", am.ScopeId, am.Name))
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(am.ScopeId))
+	}
+	return newAuthMethod, nil
+}
+
+// LookupAuthMethod will look up an auth method in the repository. If the auth
+// method is not found, it will return nil, nil. All options are ignored.
+func (r *Repository) LookupAuthMethod(ctx context.Context, publicId string, _ ...Option) (*AuthMethod, error) {
+	const op = "cert.(Repository).LookupAuthMethod"
+	if publicId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	am, err := r.lookupAuthMethod(ctx, publicId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return am, nil
+}
+
+// ListAuthMethods returns a slice of AuthMethods for the scopeIds. WithLimit
+// is the only option supported.
+func (r *Repository) ListAuthMethods(ctx context.Context, scopeIds []string, opt ...Option) ([]*AuthMethod, error) {
+	const op = "cert.(Repository).ListAuthMethods"
+	if len(scopeIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var views []*authMethodView
+	if err := r.reader.SearchWhere(ctx, &views, "scope_id in (?)", []interface{}{scopeIds}, db.WithLimit(limit)); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	authMethods := make([]*AuthMethod, 0, len(views))
+	for _, v := range views {
+		authMethods = append(authMethods, &AuthMethod{AuthMethod: v.AuthMethod})
+	}
+	return authMethods, nil
+}
+
+// DeleteAuthMethod deletes the auth method for the provided id from the
+// repository returning a count of the number of records deleted. All options
+// are ignored.
+func (r *Repository) DeleteAuthMethod(ctx context.Context, scopeId, publicId string, _ ...Option) (int, error) {
+	const op = "cert.(Repository).DeleteAuthMethod"
+	if publicId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	if scopeId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	am := AllocAuthMethod()
+	am.PublicId = publicId
+	am.ScopeId = scopeId
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			var err error
+			rowsDeleted, err = w.Delete(ctx, am.Clone(), db.WithOplog(oplogWrapper, am.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsDeleted > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(publicId))
+	}
+	return rowsDeleted, nil
+}
+
+// UpdateAuthMethod updates the repository entry for am.PublicId with the
+// values in am for the fields listed in fieldMaskPaths. It returns a new
+// AuthMethod containing the updated values and a count of the number of
+// records updated. am is not changed.
+//
+// Name, Description, CaCertificates and AttributeMaps are the only updatable
+// fields. AttributeMaps updates the SubjectAttribute, NameAttribute and
+// EmailAttribute of am as a set. A field will be set to NULL if it is the
+// zero value and included in fieldMaskPaths, except CaCertificates which must
+// not be empty.
+func (r *Repository) UpdateAuthMethod(ctx context.Context, am *AuthMethod, version uint32, fieldMaskPaths []string, _ ...Option) (*AuthMethod, int, error) {
+	const op = "cert.(Repository).UpdateAuthMethod"
+	if am == nil || am.AuthMethod == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing auth method")
+	}
+	if am.PublicId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	if am.ScopeId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	if version == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	}
+
+	var paths []string
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold(NameField, f):
+			paths = append(paths, NameField)
+		case strings.EqualFold(DescriptionField, f):
+			paths = append(paths, DescriptionField)
+		case strings.EqualFold(CaCertificatesField, f):
+			if am.CaCertificates == "" {
+				return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing ca certificates")
+			}
+			if _, err := ParseCertificates(ctx, am.CaCertificates); err != nil {
+				return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+			}
+			paths = append(paths, CaCertificatesField)
+		case strings.EqualFold(AttributeMapsField, f):
+			paths = append(paths, SubjectAttributeField, NameAttributeField, EmailAttributeField)
+		default:
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
+		}
+	}
+	for _, attr := range []string{am.SubjectAttribute, am.NameAttribute, am.EmailAttribute} {
+		if attr != "" && !validAttribute(Attribute(attr)) {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "invalid certificate attribute "+attr)
+		}
+	}
+	dbMask, nullFields := dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			NameField:             am.Name,
+			DescriptionField:      am.Description,
+			CaCertificatesField:   am.CaCertificates,
+			SubjectAttributeField: am.SubjectAttribute,
+			NameAttributeField:    am.NameAttribute,
+			EmailAttributeField:   am.EmailAttribute,
+		},
+		paths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "field mask must not be empty")
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, am.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	upAuthMethod := am.Clone()
+	var rowsUpdated int
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			var err error
+			rowsUpdated, err = w.Update(ctx, upAuthMethod, dbMask, nullFields,
+				db.WithOplog(oplogWrapper, upAuthMethod.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+				db.WithVersion(&version),
+			)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			txRepo := &Repository{reader: reader, writer: w, kms: r.kms}
+			if upAuthMethod, err = txRepo.lookupAuthMethod(ctx, am.PublicId); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to lookup auth method after update"))
+			}
+			if upAuthMethod == nil {
+				return errors.New(ctx, errors.RecordNotFound, op, "unable to lookup auth method after update")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("auth method %s already exists in scope %s", am.Name, am.ScopeId))
+		}
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(am.PublicId))
+	}
+	return upAuthMethod, rowsUpdated, nil
+}
+
+// lookupAuthMethod will lookup a single auth method with its
+// IsPrimaryAuthMethod field set.
+func (r *Repository) lookupAuthMethod(ctx context.Context, authMethodId string) (*AuthMethod, error) {
+	const op = "cert.(Repository).lookupAuthMethod"
+	v := &authMethodView{AuthMethod: AllocAuthMethod().AuthMethod}
+	if err := r.reader.LookupWhere(ctx, v, "public_id = ?", authMethodId); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(authMethodId))
+	}
+	return &AuthMethod{AuthMethod: v.AuthMethod}, nil
+}
@@ -0,0 +1,374 @@
+package cert
+
+import (
+	"context"
+	"crypto/x509"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/cert/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateAuthMethod(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+	ca := NewTestCa(t, "ca")
+
+	newAuthMethod := func(t *testing.T, opt ...Option) *AuthMethod {
+		t.Helper()
+		am, err := NewAuthMethod(ctx, org.PublicId, []*x509.Certificate{ca.Cert}, opt...)
+		require.NoError(t, err)
+		return am
+	}
+
+	tests := []struct {
+		name      string
+		am        *AuthMethod
+		opt       []Option
+		wantIsErr errors.Code
+	}{
+		{
+			name: "valid",
+			am: newAuthMethod(t, WithName("valid"), WithDescription("desc"),
+				WithAttributeMaps(AttributeMap{From: CommonNameAttribute, To: ToNameField})),
+		},
+		{
+			name: "valid-with-public-id",
+			am:   newAuthMethod(t, WithName("with-id")),
+			opt:  []Option{WithPublicId(AuthMethodPrefix + "_1234567890")},
+		},
+		{
+			name:      "invalid-public-id-prefix",
+			am:        newAuthMethod(t),
+			opt:       []Option{WithPublicId("ampw_1234567890")},
+			wantIsErr: errors.InvalidPublicId,
+		},
+		{
+			name:      "nil",
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "nil-embedded",
+			am:        &AuthMethod{},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "public-id-set",
+			am: func() *AuthMethod {
+				am := newAuthMethod(t)
+				am.PublicId = AuthMethodPrefix + "_0987654321"
+				return am
+			}(),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "missing-ca-certificates",
+			am: &AuthMethod{AuthMethod: &store.AuthMethod{
+				ScopeId: org.PublicId,
+			}},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "invalid-ca-certificates",
+			am: &AuthMethod{AuthMethod: &store.AuthMethod{
+				ScopeId:        org.PublicId,
+				CaCertificates: "not a certificate",
+			}},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "invalid-attribute",
+			am: func() *AuthMethod {
+				am := newAuthMethod(t)
+				am.NameAttribute = "unknown"
+				return am
+			}(),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "duplicate-name",
+			am:        newAuthMethod(t, WithName("valid")),
+			wantIsErr: errors.NotUnique,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.CreateAuthMethod(ctx, tt.am, tt.opt...)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err code: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.True(strings.HasPrefix(got.PublicId, AuthMethodPrefix+"_"))
+			assert.Empty(tt.am.PublicId, "the auth method passed in must not be changed")
+			assert.Equal(tt.am.Name, got.Name)
+			assert.Equal(tt.am.Description, got.Description)
+			assert.Equal(tt.am.CaCertificates, got.CaCertificates)
+			assert.Equal(tt.am.NameAttribute, got.NameAttribute)
+			assert.Equal(uint32(1), got.Version)
+
+			found, err := repo.LookupAuthMethod(ctx, got.PublicId)
+			require.NoError(err)
+			assert.Equal(got.PublicId, found.PublicId)
+		})
+	}
+}
+
+func TestRepository_LookupListDeleteAuthMethod(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, proj := iam.TestScopes(t, iamRepo)
+	org2, _ := iam.TestScopes(t, iamRepo)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+	ca := NewTestCa(t, "ca")
+	am1 := TestAuthMethod(t, conn, org.PublicId, []*x509.Certificate{ca.Cert}, WithName("one"))
+	am2 := TestAuthMethod(t, conn, org.PublicId, []*x509.Certificate{ca.Cert}, WithName("two"))
+	am3 := TestAuthMethod(t, conn, org2.PublicId, []*x509.Certificate{ca.Cert})
+
+	t.Run("lookup", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.LookupAuthMethod(ctx, am1.PublicId)
+		require.NoError(err)
+		assert.Equal(am1.Name, got.Name)
+		assert.Equal(am1.CaCertificates, got.CaCertificates)
+
+		got, err = repo.LookupAuthMethod(ctx, AuthMethodPrefix+"_1234567890")
+		require.NoError(err)
+		assert.Nil(got)
+
+		_, err = repo.LookupAuthMethod(ctx, "")
+		assert.True(errors.Match(errors.T(errors.InvalidPublicId), err))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ListAuthMethods(ctx, []string{org.PublicId})
+		require.NoError(err)
+		var ids []string
+		for _, am := range got {
+			ids = append(ids, am.PublicId)
+		}
+		assert.ElementsMatch([]string{am1.PublicId, am2.PublicId}, ids)
+
+		got, err = repo.ListAuthMethods(ctx, []string{org.PublicId, org2.PublicId}, WithLimit(1))
+		require.NoError(err)
+		assert.Len(got, 1)
+
+		got, err = repo.ListAuthMethods(ctx, []string{proj.PublicId})
+		require.NoError(err)
+		assert.Empty(got)
+
+		_, err = repo.ListAuthMethods(ctx, nil)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct := TestAccount(t, conn, am3.PublicId, "spiffe://example.com/alice")
+
+		_, err := repo.DeleteAuthMethod(ctx, org2.PublicId, "")
+		assert.True(errors.Match(errors.T(errors.InvalidPublicId), err))
+		_, err = repo.DeleteAuthMethod(ctx, "", am3.PublicId)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+
+		n, err := repo.DeleteAuthMethod(ctx, org2.PublicId, am3.PublicId)
+		require.NoError(err)
+		assert.Equal(1, n)
+		got, err := repo.LookupAuthMethod(ctx, am3.PublicId)
+		require.NoError(err)
+		assert.Nil(got)
+
+		// The accounts of the auth method are deleted with it.
+		found, err := repo.LookupAccount(ctx, acct.PublicId)
+		require.NoError(err)
+		assert.Nil(found)
+
+		n, err = repo.DeleteAuthMethod(ctx, org2.PublicId, am3.PublicId)
+		require.NoError(err)
+		assert.Zero(n)
+	})
+}
+
+func TestRepository_UpdateAuthMethod(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+	ca, other := NewTestCa(t, "ca"), NewTestCa(t, "other")
+	otherPems, err := EncodeCertificates(ctx, other.Cert)
+	require.NoError(t, err)
+	TestAuthMethod(t, conn, org.PublicId, []*x509.Certificate{ca.Cert}, WithName("taken"))
+
+	tests := []struct {
+		name      string
+		update    func(am *AuthMethod)
+		paths     []string
+		version   func(am *AuthMethod) uint32
+		want      func(t *testing.T, orig, got *AuthMethod)
+		wantNone  bool
+		wantIsErr errors.Code
+	}{
+		{
+			name: "name-and-description",
+			update: func(am *AuthMethod) {
+				am.Name = "updated"
+				am.Description = "updated desc"
+			},
+			paths: []string{NameField, DescriptionField},
+			want: func(t *testing.T, orig, got *AuthMethod) {
+				assert.Equal(t, "updated", got.Name)
+				assert.Equal(t, "updated desc", got.Description)
+				assert.Equal(t, orig.CaCertificates, got.CaCertificates)
+			},
+		},
+		{
+			name: "unset-name",
+			update: func(am *AuthMethod) {
+				am.Name = ""
+			},
+			paths: []string{NameField},
+			want: func(t *testing.T, orig, got *AuthMethod) {
+				assert.Empty(t, got.Name)
+				assert.Equal(t, orig.Description, got.Description)
+			},
+		},
+		{
+			name: "ca-certificates",
+			update: func(am *AuthMethod) {
+				am.CaCertificates = strings.Join(otherPems, "")
+			},
+			paths: []string{CaCertificatesField},
+			want: func(t *testing.T, _, got *AuthMethod) {
+				certs, err := got.Certificates(ctx)
+				require.NoError(t, err)
+				require.Len(t, certs, 1)
+				assert.True(t, other.Cert.Equal(certs[0]))
+			},
+		},
+		{
+			name: "attribute-maps",
+			update: func(am *AuthMethod) {
+				am.setAttributeMaps([]AttributeMap{{From: EmailSanAttribute, To: ToEmailField}})
+			},
+			paths: []string{AttributeMapsField},
+			want: func(t *testing.T, _, got *AuthMethod) {
+				assert.Equal(t, []AttributeMap{{From: EmailSanAttribute, To: ToEmailField}}, got.AttributeMaps())
+			},
+		},
+		{
+			name: "unset-ca-certificates",
+			update: func(am *AuthMethod) {
+				am.CaCertificates = ""
+			},
+			paths:     []string{CaCertificatesField},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "invalid-ca-certificates",
+			update: func(am *AuthMethod) {
+				am.CaCertificates = "not a certificate"
+			},
+			paths:     []string{CaCertificatesField},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "invalid-attribute",
+			update: func(am *AuthMethod) {
+				am.NameAttribute = "unknown"
+			},
+			paths:     []string{AttributeMapsField},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "invalid-field-mask",
+			paths:     []string{"ScopeId"},
+			wantIsErr: errors.InvalidFieldMask,
+		},
+		{
+			name:      "empty-field-mask",
+			wantIsErr: errors.EmptyFieldMask,
+		},
+		{
+			name: "duplicate-name",
+			update: func(am *AuthMethod) {
+				am.Name = "taken"
+			},
+			paths:     []string{NameField},
+			wantIsErr: errors.NotUnique,
+		},
+		{
+			name: "wrong-version",
+			update: func(am *AuthMethod) {
+				am.Name = "wrong version"
+			},
+			paths:    []string{NameField},
+			version:  func(am *AuthMethod) uint32 { return am.Version + 1 },
+			wantNone: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			orig := TestAuthMethod(t, conn, org.PublicId, []*x509.Certificate{ca.Cert},
+				WithName(tt.name), WithDescription("desc"))
+			am := orig.Clone()
+			if tt.update != nil {
+				tt.update(am)
+			}
+			version := orig.Version
+			if tt.version != nil {
+				version = tt.version(orig)
+			}
+			got, n, err := repo.UpdateAuthMethod(ctx, am, version, tt.paths)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err code: %q got: %q", tt.wantIsErr, err)
+				assert.Zero(n)
+				return
+			}
+			require.NoError(err)
+			if tt.wantNone {
+				// A stale version updates nothing.
+				assert.Zero(n)
+				assert.Equal(orig.Name, got.Name)
+				assert.Equal(orig.Version, got.Version)
+				return
+			}
+			assert.Equal(1, n)
+			assert.Equal(orig.Version+1, got.Version)
+			tt.want(t, orig, got)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package cert
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// Authenticate authenticates the client certificate chain presented to an
+// api listener against the auth method authMethodId. chain is DER encoded,
+// leaf first. It returns the account of the leaf certificate, creating the
+// account the first time its subject authenticates. The full name and email
+// of an existing account are updated if the mapped attributes of the
+// certificate have changed.
+//
+// An errors.Unauthorized error is returned if the chain is not trusted by
+// the auth method or the certificate has no subject, and an
+// errors.AccountDisabled error is returned if the account is disabled.
+func (r *Repository) Authenticate(ctx context.Context, authMethodId string, chain [][]byte) (*Account, error) {
+	const op = "cert.(Repository).Authenticate"
+	if authMethodId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+	}
+	certs, err := parseChain(ctx, chain)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	am, err := r.lookupAuthMethod(ctx, authMethodId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if am == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, "auth method not found: "+authMethodId)
+	}
+	caCerts, err := am.Certificates(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if err := verifyChain(ctx, caCerts, certs, time.Now()); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	subject, fullName, email := am.accountFields(certs[0])
+	if subject == "" {
+		return nil, errors.New(ctx, errors.Unauthorized, op, "client certificate has no subject")
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, am.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var acct *Account
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			acct = AllocAccount()
+			err := reader.LookupWhere(ctx, acct, "auth_method_id = ? and subject = ?", am.PublicId, subject)
+			switch {
+			case errors.IsNotFoundError(err):
+				id, err := newAccountId(ctx, am.PublicId, subject)
+				if err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+				acct = AllocAccount()
+				acct.PublicId = id
+				acct.AuthMethodId = am.PublicId
+				acct.Subject = subject
+				acct.FullName = fullName
+				acct.Email = email
+				if err := acct.validate(ctx, op); err != nil {
+					return err
+				}
+				if err := w.Create(ctx, acct, db.WithOplog(oplogWrapper, acct.oplog(oplog.OpType_OP_TYPE_CREATE, am.ScopeId))); err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+				return nil
+			case err != nil:
+				return errors.Wrap(ctx, err, op)
+			}
+
+			var dbMask, nullFields []string
+			if acct.FullName != fullName {
+				acct.FullName = fullName
+				if fullName == "" {
+					nullFields = append(nullFields, FullNameField)
+				} else {
+					dbMask = append(dbMask, FullNameField)
+				}
+			}
+			if acct.Email != email {
+				acct.Email = email
+				if email == "" {
+					nullFields = append(nullFields, EmailField)
+				} else {
+					dbMask = append(dbMask, EmailField)
+				}
+			}
+			if len(dbMask) == 0 && len(nullFields) == 0 {
+				return nil
+			}
+			if err := acct.validate(ctx, op); err != nil {
+				return err
+			}
+			rowsUpdated, err := w.Update(ctx, acct, dbMask, nullFields, db.WithOplog(oplogWrapper, acct.oplog(oplog.OpType_OP_TYPE_UPDATE, am.ScopeId)))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated != 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if acct.Disabled {
+		return nil, errors.New(ctx, errors.AccountDisabled, op, "account is disabled")
+	}
+	return acct, nil
+}
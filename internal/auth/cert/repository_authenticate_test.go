@@ -0,0 +1,114 @@
+package cert
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Authenticate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	ca, other := NewTestCa(t, "ca"), NewTestCa(t, "other")
+	am := TestAuthMethod(t, conn, org.PublicId, []*x509.Certificate{ca.Cert},
+		WithAttributeMaps(AttributeMap{From: CommonNameAttribute, To: ToNameField}, AttributeMap{From: EmailSanAttribute, To: ToEmailField}))
+	disabled := TestAccount(t, conn, am.PublicId, "spiffe://example.com/disabled")
+	disabled.Disabled = true
+	_, _, err = repo.UpdateAccount(ctx, org.PublicId, disabled, disabled.Version, []string{DisabledField})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		authMethodId string
+		chain        [][]byte
+		wantSubject  string
+		wantFullName string
+		wantEmail    string
+		wantIsErr    errors.Code
+	}{
+		{
+			name:         "new-account",
+			authMethodId: am.PublicId,
+			chain:        ca.Issue(t, TestClientCertificate{CommonName: "Alice", Emails: []string{"alice@example.com"}, Uris: []string{"spiffe://example.com/alice"}}),
+			wantSubject:  "spiffe://example.com/alice",
+			wantFullName: "Alice",
+			wantEmail:    "alice@example.com",
+		},
+		{
+			name:         "existing-account-updated",
+			authMethodId: am.PublicId,
+			chain:        ca.Issue(t, TestClientCertificate{CommonName: "Alice Doe", Uris: []string{"spiffe://example.com/alice"}}),
+			wantSubject:  "spiffe://example.com/alice",
+			wantFullName: "Alice Doe",
+		},
+		{
+			name:         "untrusted",
+			authMethodId: am.PublicId,
+			chain:        other.Issue(t, TestClientCertificate{CommonName: "Mallory"}),
+			wantIsErr:    errors.Unauthorized,
+		},
+		{
+			name:         "disabled",
+			authMethodId: am.PublicId,
+			chain:        ca.Issue(t, TestClientCertificate{Uris: []string{"spiffe://example.com/disabled"}}),
+			wantIsErr:    errors.AccountDisabled,
+		},
+		{
+			name:         "no-subject",
+			authMethodId: am.PublicId,
+			chain:        ca.Issue(t, TestClientCertificate{}),
+			wantIsErr:    errors.Unauthorized,
+		},
+		{
+			name:         "unknown-auth-method",
+			authMethodId: AuthMethodPrefix + "_1234567890",
+			chain:        ca.Issue(t, TestClientCertificate{CommonName: "Alice"}),
+			wantIsErr:    errors.RecordNotFound,
+		},
+		{
+			name:         "missing-chain",
+			authMethodId: am.PublicId,
+			wantIsErr:    errors.InvalidParameter,
+		},
+	}
+	// The tests are run in order since the second test updates the account
+	// created by the first.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.Authenticate(ctx, tt.authMethodId, tt.chain)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err code: %q got: %q", tt.wantIsErr, err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(am.PublicId, got.AuthMethodId)
+			assert.Equal(tt.wantSubject, got.Subject)
+			assert.Equal(tt.wantFullName, got.FullName)
+			assert.Equal(tt.wantEmail, got.Email)
+
+			found, err := repo.LookupAccount(ctx, got.PublicId)
+			require.NoError(err)
+			assert.Equal(tt.wantFullName, found.FullName)
+			assert.Equal(tt.wantEmail, found.Email)
+		})
+	}
+}
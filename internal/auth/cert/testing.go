@@ -0,0 +1,131 @@
+package cert
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCa is a test certificate authority which issues client certificates.
+type TestCa struct {
+	Cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// NewTestCa creates a self-signed certificate authority with the common name
+// cn. If any errors are encountered, the test will fail.
+func NewTestCa(t testing.TB, cn string) *TestCa {
+	t.Helper()
+	require := require.New(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(err)
+	return &TestCa{Cert: cert, key: key}
+}
+
+// TestClientCertificate describes a client certificate issued by a TestCa.
+type TestClientCertificate struct {
+	CommonName string
+	Emails     []string
+	DnsNames   []string
+	Uris       []string
+	// NotAfter is when the certificate expires. If zero, the certificate
+	// expires in an hour.
+	NotAfter time.Time
+}
+
+// Issue issues a client certificate for c and returns its DER encoded chain,
+// leaf first. If any errors are encountered, the test will fail.
+func (ca *TestCa) Issue(t testing.TB, c TestClientCertificate) [][]byte {
+	t.Helper()
+	require := require.New(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	var uris []*url.URL
+	for _, u := range c.Uris {
+		pu, err := url.Parse(u)
+		require.NoError(err)
+		uris = append(uris, pu)
+	}
+	notAfter := c.NotAfter
+	if notAfter.IsZero() {
+		notAfter = time.Now().Add(time.Hour)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:   big.NewInt(time.Now().UnixNano()),
+		Subject:        pkix.Name{CommonName: c.CommonName},
+		EmailAddresses: c.Emails,
+		DNSNames:       c.DnsNames,
+		URIs:           uris,
+		NotBefore:      notAfter.Add(-2 * time.Hour),
+		NotAfter:       notAfter,
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.Cert, &key.PublicKey, ca.key)
+	require.NoError(err)
+	return [][]byte{der}
+}
+
+// TestAuthMethod creates a cert auth method in the provided DB with the
+// provided scope id which trusts the certificates of caCerts. See
+// NewAuthMethod(...) for a list of supported options. If any errors are
+// encountered during the creation of the auth method, the test will fail.
+func TestAuthMethod(t testing.TB, conn *db.DB, scopeId string, caCerts []*x509.Certificate, opt ...Option) *AuthMethod {
+	t.Helper()
+	ctx := context.Background()
+	require := require.New(t)
+	rw := db.New(conn)
+
+	am, err := NewAuthMethod(ctx, scopeId, caCerts, opt...)
+	require.NoError(err)
+	opts := getOpts(opt...)
+	am.PublicId = opts.withPublicId
+	if am.PublicId == "" {
+		am.PublicId, err = newAuthMethodId(ctx)
+		require.NoError(err)
+	}
+	require.NoError(rw.Create(ctx, am))
+	return am
+}
+
+// TestAccount creates a cert account in the provided DB with the provided
+// auth method id and subject. The auth method must have been created
+// previously. See NewAccount(...) for a list of supported options. If any
+// errors are encountered during the creation of the account, the test will
+// fail.
+func TestAccount(t testing.TB, conn *db.DB, authMethodId, subject string, opt ...Option) *Account {
+	t.Helper()
+	ctx := context.Background()
+	require := require.New(t)
+	rw := db.New(conn)
+
+	a, err := NewAccount(ctx, authMethodId, subject, opt...)
+	require.NoError(err)
+	a.PublicId, err = newAccountId(ctx, authMethodId, subject)
+	require.NoError(err)
+	require.NoError(rw.Create(ctx, a))
+	return a
+}
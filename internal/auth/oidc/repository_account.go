@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
 )
 
 // CreateAccount inserts an Account, a, into the repository and returns a
@@ -28,23 +29,65 @@ import (
 // WithPublicId is currently the only valid option.
 func (r *Repository) CreateAccount(ctx context.Context, scopeId string, a *Account, opt ...Option) (*Account, error) {
 	const op = "oidc.(Repository).CreateAccount"
+	a, oplogWrapper, err := r.prepareAccount(ctx, op, scopeId, a, opt...)
+	if err != nil {
+		return nil, err
+	}
+
+	var newAccount *Account
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			var err error
+			newAccount, err = createAccount(ctx, op, w, oplogWrapper, scopeId, a)
+			return err
+		},
+	)
+
+	if err != nil {
+		return nil, createAccountError(ctx, op, scopeId, a, err)
+	}
+	return newAccount, nil
+}
+
+// CreateAccountTx inserts an Account, a, into the repository like
+// CreateAccount, but with w, the writer of a transaction started by the
+// caller, instead of in a transaction of its own.
+func (r *Repository) CreateAccountTx(ctx context.Context, w db.Writer, scopeId string, a *Account, opt ...Option) (*Account, error) {
+	const op = "oidc.(Repository).CreateAccountTx"
+	if w == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing writer")
+	}
+	a, oplogWrapper, err := r.prepareAccount(ctx, op, scopeId, a, opt...)
+	if err != nil {
+		return nil, err
+	}
+	newAccount, err := createAccount(ctx, op, w, oplogWrapper, scopeId, a)
+	if err != nil {
+		return nil, createAccountError(ctx, op, scopeId, a, err)
+	}
+	return newAccount, nil
+}
+
+// prepareAccount validates a and returns a clone of it with its PublicId
+// and Issuer set, along with the oplog wrapper of scopeId.
+func (r *Repository) prepareAccount(ctx context.Context, op errors.Op, scopeId string, a *Account, opt ...Option) (*Account, wrapping.Wrapper, error) {
 	if a == nil {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing Account")
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing Account")
 	}
 	if a.Account == nil {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing embedded Account")
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing embedded Account")
 	}
 	if a.AuthMethodId == "" {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
 	}
 	if a.Subject == "" {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing subject")
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing subject")
 	}
 	if a.PublicId != "" {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id must be empty")
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "public id must be empty")
 	}
 	if scopeId == "" {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
 	}
 
 	a = a.Clone()
@@ -59,58 +102,55 @@ func (r *Repository) CreateAccount(ctx context.Context, scopeId string, a *Accou
 	if a.Issuer == "" {
 		am, err := r.LookupAuthMethod(ctx, a.AuthMethodId)
 		if err != nil {
-			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get auth method"))
+			return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get auth method"))
 		}
 		if am.GetIssuer() == "" {
-			return nil, errors.New(ctx, errors.InvalidParameter, op, "no issuer on auth method")
+			return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "no issuer on auth method")
 		}
 		a.Issuer = am.GetIssuer()
 	}
 	if a.Issuer == "" {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "no issuer provided or defined in auth method")
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "no issuer provided or defined in auth method")
 	}
 
 	opts := getOpts(opt...)
 	if opts.withPublicId != "" {
 		if !strings.HasPrefix(opts.withPublicId, AccountPrefix+"_") {
-			return nil, errors.New(ctx, errors.InvalidParameter, op, "chosen account id does not have a valid prefix")
+			return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "chosen account id does not have a valid prefix")
 		}
 		a.PublicId = opts.withPublicId
 	} else {
 		id, err := newAccountId(ctx, a.AuthMethodId, a.Issuer, a.Subject)
 		if err != nil {
-			return nil, errors.Wrap(ctx, err, op)
+			return nil, nil, errors.Wrap(ctx, err, op)
 		}
 		a.PublicId = id
 	}
 
 	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
 	if err != nil {
-		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"), errors.WithCode(errors.Encrypt))
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"), errors.WithCode(errors.Encrypt))
 	}
+	return a, oplogWrapper, nil
+}
 
-	var newAccount *Account
-	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
-		func(_ db.Reader, w db.Writer) error {
-			newAccount = a.Clone()
-			if err := w.Create(ctx, newAccount, db.WithOplog(oplogWrapper, a.oplog(oplog.OpType_OP_TYPE_CREATE, scopeId))); err != nil {
-				return errors.Wrap(ctx, err, op)
-			}
-			return nil
-		},
-	)
-
-	if err != nil {
-		if errors.IsUniqueError(err) {
-			return nil, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf(
-				"in auth method %s: name %q already exists or subject %q already exists for issuer %q in scope %s",
-				a.AuthMethodId, a.Name, a.Subject, a.Issuer, scopeId))
-		}
-		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(a.AuthMethodId))
+func createAccount(ctx context.Context, op errors.Op, w db.Writer, oplogWrapper wrapping.Wrapper, scopeId string, a *Account) (*Account, error) {
+	newAccount := a.Clone()
+	if err := w.Create(ctx, newAccount, db.WithOplog(oplogWrapper, a.oplog(oplog.OpType_OP_TYPE_CREATE, scopeId))); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
 	}
 	return newAccount, nil
 }
 
+func createAccountError(ctx context.Context, op errors.Op, scopeId string, a *Account, err error) error {
+	if errors.IsUniqueError(err) {
+		return errors.New(ctx, errors.NotUnique, op, fmt.Sprintf(
+			"in auth method %s: name %q already exists or subject %q already exists for issuer %q in scope %s",
+			a.AuthMethodId, a.Name, a.Subject, a.Issuer, scopeId))
+	}
+	return errors.Wrap(ctx, err, op, errors.WithMsg(a.AuthMethodId))
+}
+
 // LookupAccount will look up an account in the repository.  If the account is not
 // found, it will return nil, nil.  All options are ignored.
 func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, opt ...Option) (*Account, error) {
@@ -159,8 +199,6 @@ func (r *Repository) DeleteAccount(ctx context.Context, scopeId, withPublicId st
 	if scopeId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
 	}
-	ac := AllocAccount()
-	ac.PublicId = withPublicId
 
 	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
 	if err != nil {
@@ -173,16 +211,8 @@ func (r *Repository) DeleteAccount(ctx context.Context, scopeId, withPublicId st
 		db.StdRetryCnt,
 		db.ExpBackoff{},
 		func(_ db.Reader, w db.Writer) (err error) {
-			metadata := ac.oplog(oplog.OpType_OP_TYPE_DELETE, scopeId)
-			dAc := ac.Clone()
-			rowsDeleted, err = w.Delete(ctx, dAc, db.WithOplog(oplogWrapper, metadata))
-			if err != nil {
-				return errors.Wrap(ctx, err, op)
-			}
-			if rowsDeleted > 1 {
-				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
-			}
-			return nil
+			rowsDeleted, err = deleteAccount(ctx, op, w, oplogWrapper, scopeId, withPublicId)
+			return err
 		},
 	)
 
@@ -193,17 +223,59 @@ func (r *Repository) DeleteAccount(ctx context.Context, scopeId, withPublicId st
 	return rowsDeleted, nil
 }
 
+// DeleteAccountTx deletes the account for the provided id like
+// DeleteAccount, but with w, the writer of a transaction started by the
+// caller, instead of in a transaction of its own.
+func (r *Repository) DeleteAccountTx(ctx context.Context, w db.Writer, scopeId, withPublicId string) (int, error) {
+	const op = "oidc.(Repository).DeleteAccountTx"
+	if w == nil {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing writer")
+	}
+	if withPublicId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	if scopeId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get oplog wrapper"))
+	}
+	rowsDeleted, err := deleteAccount(ctx, op, w, oplogWrapper, scopeId, withPublicId)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(withPublicId))
+	}
+	return rowsDeleted, nil
+}
+
+func deleteAccount(ctx context.Context, op errors.Op, w db.Writer, oplogWrapper wrapping.Wrapper, scopeId, withPublicId string) (int, error) {
+	ac := AllocAccount()
+	ac.PublicId = withPublicId
+	metadata := ac.oplog(oplog.OpType_OP_TYPE_DELETE, scopeId)
+	dAc := ac.Clone()
+	rowsDeleted, err := w.Delete(ctx, dAc, db.WithOplog(oplogWrapper, metadata))
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	if rowsDeleted > 1 {
+		return db.NoRowsAffected, errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+	}
+	return rowsDeleted, nil
+}
+
 // UpdateAccount updates the repository entry for a.PublicId with the
 // values in a for the fields listed in fieldMaskPaths. It returns a new
 // Account containing the updated values and a count of the number of
 // records updated. a is not changed.
 //
-// a must contain a valid PublicId. Only a.Name and a.Description can be
-// updated. If a.Name is set to a non-empty string, it must be unique within
-// a.AuthMethodId.
+// a must contain a valid PublicId. Only a.Name, a.Description and
+// a.Disabled can be updated. If a.Name is set to a non-empty string, it must
+// be unique within a.AuthMethodId.
 //
 // An attribute of a will be set to NULL in the database if the attribute
-// in a is the zero value and it is included in fieldMaskPaths.
+// in a is the zero value and it is included in fieldMaskPaths. a.Disabled is
+// set to false instead.
 func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Account, version uint32, fieldMaskPaths []string, opt ...Option) (*Account, int, error) {
 	const op = "oidc.(Repository).UpdateAccount"
 	if a == nil {
@@ -226,6 +298,7 @@ func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Accou
 		switch {
 		case strings.EqualFold(NameField, f):
 		case strings.EqualFold(DescriptionField, f):
+		case strings.EqualFold(DisabledField, f):
 		default:
 			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
 		}
@@ -235,9 +308,10 @@ func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Accou
 		map[string]interface{}{
 			NameField:        a.Name,
 			DescriptionField: a.Description,
+			DisabledField:    a.Disabled,
 		},
 		fieldMaskPaths,
-		nil,
+		[]string{DisabledField},
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "missing field mask")
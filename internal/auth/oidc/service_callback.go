@@ -7,9 +7,11 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hashicorp/boundary/internal/activity"
 	"github.com/hashicorp/boundary/internal/auth/oidc/request"
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/cap/oidc"
 	"github.com/hashicorp/go-bexpr"
 	"github.com/mitchellh/pointerstructure"
@@ -39,7 +41,8 @@ import (
 // for the account.
 //
 // * Use iam.(Repository).LookupUserWithLogin(...) look up the iam.User matching
-// the Account.
+// the Account. If either the Account or the iam.User is disabled, an error
+// with code AccountDisabled is returned.
 //
 // * Use the authtoken.(Repository).CreateAuthToken(...) to create a pending
 // auth token for the authenticated user.
@@ -238,6 +241,10 @@ func Callback(
 	if err != nil {
 		return "", errors.Wrap(ctx, err, op)
 	}
+	if acct.GetDisabled() || user.GetDisabled() {
+		r.recordActivity(ctx, acct.PublicId, activity.AuthenticationFailed, "account disabled")
+		return "", errors.New(ctx, errors.AccountDisabled, op, "account is disabled", errors.WithoutEvent())
+	}
 
 	// Now we need to check filters and assign managed groups by filter.
 
@@ -254,6 +261,17 @@ func Callback(
 		}
 		return "", errors.Wrap(ctx, err, op)
 	}
+	r.recordActivity(ctx, acct.PublicId, activity.AuthenticationSucceeded, "")
 	// tada!  we can return a final redirect URL for the successful authentication.
 	return reqState.FinalRedirectUrl, nil
 }
+
+// recordActivity records an authentication of the account accountId in the
+// activity history of its user. Failures are written as events and do not
+// fail the authentication.
+func (r *Repository) recordActivity(ctx context.Context, accountId string, t activity.Type, detail string) {
+	const op = "oidc.(Repository).recordActivity"
+	if err := activity.RecordAccountActivity(ctx, r.writer, accountId, t, activity.WithDetail(detail)); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to record user activity", "account id", accountId))
+	}
+}
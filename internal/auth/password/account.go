@@ -76,3 +76,19 @@ func (a *Account) oplog(op oplog.OpType) oplog.Metadata {
 	}
 	return metadata
 }
+
+// accountView provides a simple way to read an Account with its
+// FailedLoginCount, LockExpirationTime and IsLocked fields set. By definition,
+// it's used only for reading Accounts.
+type accountView struct {
+	*store.Account
+	tableName string
+}
+
+// TableName returns the view name.
+func (a *accountView) TableName() string {
+	if a.tableName != "" {
+		return a.tableName
+	}
+	return "auth_password_account_with_lockout"
+}
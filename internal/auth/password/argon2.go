@@ -213,3 +213,37 @@ func (c *Argon2Credential) oplog(op oplog.OpType) oplog.Metadata {
 	}
 	return metadata
 }
+
+// argon2CredentialHistory is a previous Argon2Credential of an Account. It
+// keeps the encrypted salt and derived key of the credential it replaced so
+// reuse of the password can be detected.
+type argon2CredentialHistory struct {
+	*store.Argon2CredentialHistory
+	tableName string
+}
+
+func newArgon2CredentialHistory(c *Argon2Credential) *argon2CredentialHistory {
+	return &argon2CredentialHistory{
+		Argon2CredentialHistory: &store.Argon2CredentialHistory{
+			PrivateId:         c.PrivateId,
+			PasswordAccountId: c.PasswordAccountId,
+			PasswordConfId:    c.PasswordConfId,
+			CtSalt:            c.CtSalt,
+			DerivedKey:        c.DerivedKey,
+			KeyId:             c.KeyId,
+		},
+	}
+}
+
+// TableName returns the table name.
+func (h *argon2CredentialHistory) TableName() string {
+	if h != nil && h.tableName != "" {
+		return h.tableName
+	}
+	return "auth_password_argon2_cred_history"
+}
+
+// SetTableName sets the table name.
+func (h *argon2CredentialHistory) SetTableName(n string) {
+	h.tableName = n
+}
@@ -24,6 +24,7 @@ type options struct {
 	withPassword          bool
 	withOrderByCreateTime bool
 	ascending             bool
+	withNewPassword       string
 }
 
 func getDefaultOptions() options {
@@ -92,3 +93,11 @@ func WithOrderByCreateTime(ascending bool) Option {
 		o.ascending = ascending
 	}
 }
+
+// WithNewPassword provides an optional new password which replaces an
+// expired password during authentication.
+func WithNewPassword(password string) Option {
+	return func(o *options) {
+		o.withNewPassword = password
+	}
+}
@@ -46,6 +46,12 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withPassword = true
 		assert.Equal(t, opts, testOpts)
 	})
+	t.Run("WithNewPassword", func(t *testing.T) {
+		opts := getOpts(WithNewPassword("test new password"))
+		testOpts := getDefaultOptions()
+		testOpts.withNewPassword = "test new password"
+		assert.Equal(t, opts, testOpts)
+	})
 	t.Run("WithConfiguration", func(t *testing.T) {
 		conf := NewArgon2Configuration()
 		conf.KeyLength = conf.KeyLength * 2
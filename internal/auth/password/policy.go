@@ -0,0 +1,83 @@
+package password
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// commonPasswords is a list of frequently used passwords which are rejected
+// by auth methods with DenyCommonPasswords set. Passwords are compared case
+// insensitively.
+var commonPasswords = map[string]struct{}{}
+
+func init() {
+	for _, p := range []string{
+		"123456", "123456789", "12345678", "1234567890", "12345", "1234567",
+		"password", "password1", "password12", "password123", "password1234",
+		"passw0rd", "p@ssw0rd", "p@ssword", "pa$$word", "qwerty", "qwerty123",
+		"qwertyuiop", "1q2w3e4r", "1q2w3e4r5t", "1qaz2wsx", "zaq12wsx",
+		"abc123", "abcd1234", "abcdef", "a1b2c3d4", "111111", "000000",
+		"11111111", "00000000", "12341234", "123123", "123123123", "654321",
+		"987654321", "666666", "696969", "888888", "121212", "112233",
+		"iloveyou", "iloveyou1", "letmein", "letmein1", "welcome", "welcome1",
+		"welcome123", "admin", "admin123", "administrator", "root", "toor",
+		"changeme", "changeit", "default", "secret", "master", "login",
+		"monkey", "dragon", "football", "baseball", "basketball", "soccer",
+		"hockey", "princess", "sunshine", "shadow", "superman", "batman",
+		"trustno1", "starwars", "whatever", "freedom", "michael", "jennifer",
+		"jordan23", "hunter2", "mustang", "access", "flower", "cheese",
+		"computer", "internet", "summer", "winter", "spring", "autumn",
+		"charlie", "liverpool", "chelsea", "arsenal", "pokemon", "killer",
+		"ginger", "hello123", "test", "test123", "testing", "guest",
+		"boundary", "hashicorp",
+	} {
+		commonPasswords[p] = struct{}{}
+	}
+}
+
+// checkPasswordPolicy returns an error with code PasswordTooWeak if password
+// does not satisfy the character class and common password settings of c. The
+// minimum password length is checked by the callers.
+func (c *currentConfig) checkPasswordPolicy(ctx context.Context, password string) error {
+	const op = "password.(currentConfig).checkPasswordPolicy"
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsLetter(r):
+			hasSymbol = true
+		}
+	}
+
+	var missing []string
+	if c.PasswordRequireLowercase && !hasLower {
+		missing = append(missing, "a lowercase letter")
+	}
+	if c.PasswordRequireUppercase && !hasUpper {
+		missing = append(missing, "an uppercase letter")
+	}
+	if c.PasswordRequireDigit && !hasDigit {
+		missing = append(missing, "a digit")
+	}
+	if c.PasswordRequireSymbol && !hasSymbol {
+		missing = append(missing, "a symbol")
+	}
+	if len(missing) > 0 {
+		return errors.New(ctx, errors.PasswordTooWeak, op, "must contain "+strings.Join(missing, ", "))
+	}
+
+	if c.DenyCommonPasswords {
+		if _, ok := commonPasswords[strings.ToLower(password)]; ok {
+			return errors.New(ctx, errors.PasswordTooWeak, op, "must not be a commonly used password")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package password
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrentConfig_checkPasswordPolicy(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		conf     *currentConfig
+		password string
+		wantErr  bool
+	}{
+		{
+			name:     "no-policy",
+			conf:     &currentConfig{},
+			password: "password",
+		},
+		{
+			name:     "missing-lowercase",
+			conf:     &currentConfig{PasswordRequireLowercase: true},
+			password: "PASSWORD",
+			wantErr:  true,
+		},
+		{
+			name:     "missing-uppercase",
+			conf:     &currentConfig{PasswordRequireUppercase: true},
+			password: "password",
+			wantErr:  true,
+		},
+		{
+			name:     "missing-digit",
+			conf:     &currentConfig{PasswordRequireDigit: true},
+			password: "password",
+			wantErr:  true,
+		},
+		{
+			name:     "missing-symbol",
+			conf:     &currentConfig{PasswordRequireSymbol: true},
+			password: "passw0rd",
+			wantErr:  true,
+		},
+		{
+			name: "all-classes",
+			conf: &currentConfig{
+				PasswordRequireLowercase: true,
+				PasswordRequireUppercase: true,
+				PasswordRequireDigit:     true,
+				PasswordRequireSymbol:    true,
+			},
+			password: "Pa55-word",
+		},
+		{
+			name:     "common-password",
+			conf:     &currentConfig{DenyCommonPasswords: true},
+			password: "PassW0rd",
+			wantErr:  true,
+		},
+		{
+			name:     "uncommon-password",
+			conf:     &currentConfig{DenyCommonPasswords: true},
+			password: "correct horse battery staple",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert := assert.New(t)
+			err := tt.conf.checkPasswordPolicy(ctx, tt.password)
+			if tt.wantErr {
+				assert.Truef(errors.Match(errors.T(errors.PasswordTooWeak), err), "want err code: %q got: %q", errors.PasswordTooWeak, err)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
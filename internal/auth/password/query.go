@@ -20,11 +20,28 @@ select acct.name,                        -- Account.Name
        conf.iterations,                  -- Argon2Configuration.Iterations
        conf.memory,                      -- Argon2Configuration.Memory
        conf.threads,                     -- Argon2Configuration.Threads
-       meth.password_conf_id = cred.password_conf_id as is_current_conf
+       meth.password_conf_id = cred.password_conf_id as is_current_conf,
+       meth.lockout_threshold,           -- authAccount.LockoutThreshold
+       meth.lockout_window_seconds,      -- authAccount.LockoutWindowSeconds
+       meth.lockout_duration_seconds,    -- authAccount.LockoutDurationSeconds
+       coalesce(lo.failed_login_count, 0) as failed_login_count,                 -- Account.FailedLoginCount
+       lo.lock_expiration_time,                                                  -- Account.LockExpirationTime
+       coalesce(lo.lock_expiration_time > current_timestamp, false) as is_locked, -- Account.IsLocked
+       meth.max_password_age_days > 0
+         and cred.create_time + make_interval(days => meth.max_password_age_days) <= current_timestamp
+         as is_password_expired,
+       acct.disabled,                                                            -- Account.Disabled
+       coalesce(usr.disabled, false) as is_user_disabled                         -- authAccount.IsUserDisabled
   from auth_password_argon2_cred cred,
        auth_password_argon2_conf conf,
-       auth_password_account acct,
-       auth_password_method meth
+       auth_password_method meth,
+       auth_password_account acct
+  left join auth_password_account_lockout lo
+         on acct.public_id = lo.password_account_id
+  left join auth_account aa
+         on acct.public_id = aa.public_id
+  left join iam_user usr
+         on aa.iam_user_id = usr.public_id
  where acct.auth_method_id = @auth_method_id
    and acct.login_name = @login_name
    and cred.password_conf_id = conf.private_id
@@ -40,5 +57,133 @@ select *
          from auth_password_account
         where public_id = @public_id
     );
+`
+	// recordFailedLoginQuery counts a failed authentication attempt for an
+	// account. The count restarts when the lockout window has passed or a
+	// previous lock has expired, and the account is locked for
+	// @duration_seconds once the count reaches @threshold.
+	recordFailedLoginQuery = `
+insert into auth_password_account_lockout as lo
+       (password_account_id, failed_login_count, window_start_time, lock_expiration_time)
+values (@account_id, 1, current_timestamp,
+        case when @threshold <= 1 then current_timestamp + make_interval(secs => @duration_seconds) end)
+    on conflict (password_account_id) do update
+   set failed_login_count = case
+         when lo.lock_expiration_time <= current_timestamp
+           or (@window_seconds > 0 and lo.window_start_time + make_interval(secs => @window_seconds) <= current_timestamp)
+         then 1
+         else lo.failed_login_count + 1
+       end,
+       window_start_time = case
+         when lo.lock_expiration_time <= current_timestamp
+           or (@window_seconds > 0 and lo.window_start_time + make_interval(secs => @window_seconds) <= current_timestamp)
+         then current_timestamp
+         else lo.window_start_time
+       end,
+       lock_expiration_time = case
+         when lo.lock_expiration_time <= current_timestamp
+           or (@window_seconds > 0 and lo.window_start_time + make_interval(secs => @window_seconds) <= current_timestamp)
+         then case when @threshold <= 1 then current_timestamp + make_interval(secs => @duration_seconds) end
+         when lo.failed_login_count + 1 >= @threshold
+         then current_timestamp + make_interval(secs => @duration_seconds)
+         else lo.lock_expiration_time
+       end;
+`
+	clearFailedLoginsQuery = `
+delete from auth_password_account_lockout
+ where password_account_id = @account_id;
+`
+	// previousCredentialsQuery returns the current credential of an account
+	// followed by its @limit most recent previous credentials.
+	previousCredentialsQuery = `
+select cred.salt,
+       cred.derived_key,
+       cred.key_id,
+       conf.iterations,
+       conf.memory,
+       conf.threads,
+       conf.key_length
+  from auth_password_argon2_cred cred
+  join auth_password_argon2_conf conf
+    on cred.password_conf_id = conf.private_id
+ where cred.password_account_id = @account_id
+ union all
+(select hist.salt,
+       hist.derived_key,
+       hist.key_id,
+       conf.iterations,
+       conf.memory,
+       conf.threads,
+       conf.key_length
+  from auth_password_argon2_cred_history hist
+  join auth_password_argon2_conf conf
+    on hist.password_conf_id = conf.private_id
+ where hist.password_account_id = @account_id
+ order by hist.create_time desc
+ limit @limit);
+`
+	// pruneCredentialHistoryQuery deletes all but the @limit most recent
+	// previous credentials of an account.
+	pruneCredentialHistoryQuery = `
+delete from auth_password_argon2_cred_history
+ where password_account_id = @account_id
+   and private_id not in (
+       select private_id
+         from auth_password_argon2_cred_history
+        where password_account_id = @account_id
+        order by create_time desc
+        limit @limit
+   );
+`
+
+	// totpStateQuery returns the TOTP MFA setting and lockout policy of the
+	// auth method of an account, whether the account has a TOTP enrollment
+	// and whether it is locked.
+	totpStateQuery = `
+select meth.totp_mfa,
+       totp.password_account_id is not null as is_enrolled,
+       coalesce(totp.confirmed, false) as is_confirmed,
+       meth.lockout_threshold,
+       meth.lockout_window_seconds,
+       meth.lockout_duration_seconds,
+       coalesce(lo.failed_login_count, 0) as failed_login_count,
+       coalesce(lo.lock_expiration_time > current_timestamp, false) as is_locked
+  from auth_password_account acct
+  join auth_password_method meth
+    on acct.auth_method_id = meth.public_id
+  left join auth_password_account_totp totp
+    on acct.public_id = totp.password_account_id
+  left join auth_password_account_lockout lo
+    on acct.public_id = lo.password_account_id
+ where acct.public_id = @account_id;
+`
+	deleteTotpQuery = `
+delete from auth_password_account_totp
+ where password_account_id = @account_id;
+`
+	// useTotpStepQuery confirms the TOTP enrollment of an account and records
+	// the time step of an accepted code. It does not update the enrollment if
+	// a code of the same or a later time step was accepted concurrently.
+	useTotpStepQuery = `
+update auth_password_account_totp
+   set confirmed = true,
+       last_used_step = @step
+ where password_account_id = @account_id
+   and last_used_step < @step;
+`
+	useRecoveryCodeQuery = `
+delete from auth_password_totp_recovery_code
+ where password_account_id = @account_id
+   and code_hash = @code_hash;
+`
+	failedMfaAttemptQuery = `
+update auth_password_mfa_challenge
+   set failed_attempts = failed_attempts + 1
+ where public_id = @public_id;
+`
+	deleteExpiredMfaChallengesQuery = `
+delete from auth_password_mfa_challenge
+ where password_account_id = @account_id
+   and expiration_time < current_timestamp;
 `
 )
@@ -6,12 +6,14 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/hashicorp/boundary/internal/auth/password/store"
 	"github.com/hashicorp/boundary/internal/db"
 	dbcommon "github.com/hashicorp/boundary/internal/db/common"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/intglobals"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
 )
 
 // CreateAccount inserts a into the repository and returns a new Account
@@ -29,32 +31,75 @@ import (
 // unique within a.AuthMethodId.
 func (r *Repository) CreateAccount(ctx context.Context, scopeId string, a *Account, opt ...Option) (*Account, error) {
 	const op = "password.(Repository).CreateAccount"
+	a, cred, oplogWrapper, databaseWrapper, err := r.prepareAccount(ctx, op, scopeId, a, opt...)
+	if err != nil {
+		return nil, err
+	}
+
+	var newAccount *Account
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			var err error
+			newAccount, err = createAccount(ctx, op, w, oplogWrapper, databaseWrapper, a, cred)
+			return err
+		},
+	)
+
+	if err != nil {
+		return nil, createAccountError(ctx, op, a, err)
+	}
+	return newAccount, nil
+}
+
+// CreateAccountTx inserts a into the repository like CreateAccount, but
+// with w, the writer of a transaction started by the caller, instead of in
+// a transaction of its own.
+func (r *Repository) CreateAccountTx(ctx context.Context, w db.Writer, scopeId string, a *Account, opt ...Option) (*Account, error) {
+	const op = "password.(Repository).CreateAccountTx"
+	if w == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing writer")
+	}
+	a, cred, oplogWrapper, databaseWrapper, err := r.prepareAccount(ctx, op, scopeId, a, opt...)
+	if err != nil {
+		return nil, err
+	}
+	newAccount, err := createAccount(ctx, op, w, oplogWrapper, databaseWrapper, a, cred)
+	if err != nil {
+		return nil, createAccountError(ctx, op, a, err)
+	}
+	return newAccount, nil
+}
+
+// prepareAccount validates a and returns a clone of it with its PublicId
+// set, the credential of the password set by WithPassword, if any, and the
+// oplog and database wrappers of scopeId.
+func (r *Repository) prepareAccount(ctx context.Context, op errors.Op, scopeId string, a *Account, opt ...Option) (*Account, *Argon2Credential, wrapping.Wrapper, wrapping.Wrapper, error) {
 	if a == nil {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing Account")
+		return nil, nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing Account")
 	}
 	if a.Account == nil {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing embedded Account")
+		return nil, nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing embedded Account")
 	}
 	if a.AuthMethodId == "" {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+		return nil, nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
 	}
 	if a.PublicId != "" {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id must be empty")
+		return nil, nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, "public id must be empty")
 	}
 	if scopeId == "" {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+		return nil, nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
 	}
 	if !validLoginName(a.LoginName) {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("login name must be all-lowercase alphanumeric, period or hyphen. got: %s", a.LoginName))
+		return nil, nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("login name must be all-lowercase alphanumeric, period or hyphen. got: %s", a.LoginName))
 	}
 
 	cc, err := r.currentConfig(ctx, a.AuthMethodId)
 	if err != nil {
-		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("retrieve current configuration"))
+		return nil, nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("retrieve current configuration"))
 	}
 
 	if cc.MinLoginNameLength > len(a.LoginName) {
-		return nil, errors.New(ctx, errors.TooShort, op, fmt.Sprintf("username: %s, must be longer than %d", a.LoginName, cc.MinLoginNameLength))
+		return nil, nil, nil, nil, errors.New(ctx, errors.TooShort, op, fmt.Sprintf("username: %s, must be longer than %d", a.LoginName, cc.MinLoginNameLength))
 	}
 
 	opts := getOpts(opt...)
@@ -62,13 +107,13 @@ func (r *Repository) CreateAccount(ctx context.Context, scopeId string, a *Accou
 	a = a.clone()
 	if opts.withPublicId != "" {
 		if !strings.HasPrefix(opts.withPublicId, intglobals.NewPasswordAccountPrefix+"_") {
-			return nil, errors.New(ctx, errors.InvalidParameter, op, "chosen account id does not have a valid prefix")
+			return nil, nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, "chosen account id does not have a valid prefix")
 		}
 		a.PublicId = opts.withPublicId
 	} else {
 		id, err := newAccountId()
 		if err != nil {
-			return nil, errors.Wrap(ctx, err, op)
+			return nil, nil, nil, nil, errors.Wrap(ctx, err, op)
 		}
 		a.PublicId = id
 	}
@@ -76,54 +121,53 @@ func (r *Repository) CreateAccount(ctx context.Context, scopeId string, a *Accou
 	var cred *Argon2Credential
 	if opts.withPassword {
 		if cc.MinPasswordLength > len(opts.password) {
-			return nil, errors.New(ctx, errors.PasswordTooShort, op, fmt.Sprintf("must be longer than %v", cc.MinPasswordLength))
+			return nil, nil, nil, nil, errors.New(ctx, errors.PasswordTooShort, op, fmt.Sprintf("must be longer than %v", cc.MinPasswordLength))
+		}
+		if err := cc.checkPasswordPolicy(ctx, opts.password); err != nil {
+			return nil, nil, nil, nil, errors.Wrap(ctx, err, op)
 		}
 		if cred, err = newArgon2Credential(a.PublicId, opts.password, cc.argon2()); err != nil {
-			return nil, errors.Wrap(ctx, err, op)
+			return nil, nil, nil, nil, errors.Wrap(ctx, err, op)
 		}
 	}
 
 	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
 	if err != nil {
-		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"), errors.WithCode(errors.Encrypt))
+		return nil, nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"), errors.WithCode(errors.Encrypt))
 	}
 	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
 	if err != nil {
-		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"), errors.WithCode(errors.Encrypt))
+		return nil, nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"), errors.WithCode(errors.Encrypt))
 	}
+	return a, cred, oplogWrapper, databaseWrapper, nil
+}
 
-	var newCred *Argon2Credential
-	var newAccount *Account
-	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
-		func(_ db.Reader, w db.Writer) error {
-			newAccount = a.clone()
-			if err := w.Create(ctx, newAccount, db.WithOplog(oplogWrapper, a.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
-				return errors.Wrap(ctx, err, op)
-			}
-
-			if cred != nil {
-				newCred = cred.clone()
-				if err := newCred.encrypt(ctx, databaseWrapper); err != nil {
-					return errors.Wrap(ctx, err, op)
-				}
-				if err := w.Create(ctx, newCred, db.WithOplog(oplogWrapper, cred.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
-					return errors.Wrap(ctx, err, op)
-				}
-			}
-			return nil
-		},
-	)
+func createAccount(ctx context.Context, op errors.Op, w db.Writer, oplogWrapper, databaseWrapper wrapping.Wrapper, a *Account, cred *Argon2Credential) (*Account, error) {
+	newAccount := a.clone()
+	if err := w.Create(ctx, newAccount, db.WithOplog(oplogWrapper, a.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
 
-	if err != nil {
-		if errors.IsUniqueError(err) {
-			return nil, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("in auth method %s: name %q or loginName %q already exists",
-				a.AuthMethodId, a.Name, a.LoginName))
+	if cred != nil {
+		newCred := cred.clone()
+		if err := newCred.encrypt(ctx, databaseWrapper); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if err := w.Create(ctx, newCred, db.WithOplog(oplogWrapper, cred.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
 		}
-		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(a.AuthMethodId))
 	}
 	return newAccount, nil
 }
 
+func createAccountError(ctx context.Context, op errors.Op, a *Account, err error) error {
+	if errors.IsUniqueError(err) {
+		return errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("in auth method %s: name %q or loginName %q already exists",
+			a.AuthMethodId, a.Name, a.LoginName))
+	}
+	return errors.Wrap(ctx, err, op, errors.WithMsg(a.AuthMethodId))
+}
+
 // LookupAccount will look up an account in the repository.  If the account is not
 // found, it will return nil, nil.  All options are ignored.
 func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, opt ...Option) (*Account, error) {
@@ -131,7 +175,7 @@ func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, opt
 	if withPublicId == "" {
 		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
 	}
-	a := allocAccount()
+	a := &accountView{Account: &store.Account{}}
 	a.PublicId = withPublicId
 	if err := r.reader.LookupByPublicId(ctx, a); err != nil {
 		if errors.IsNotFoundError(err) {
@@ -139,7 +183,7 @@ func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, opt
 		}
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", withPublicId)))
 	}
-	return a, nil
+	return &Account{Account: a.Account}, nil
 }
 
 // ListAccounts in an auth method and supports WithLimit option.
@@ -154,11 +198,15 @@ func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string,
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
-	var accts []*Account
-	err := r.reader.SearchWhere(ctx, &accts, "auth_method_id = ?", []interface{}{withAuthMethodId}, db.WithLimit(limit))
+	var views []*accountView
+	err := r.reader.SearchWhere(ctx, &views, "auth_method_id = ?", []interface{}{withAuthMethodId}, db.WithLimit(limit))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
+	var accts []*Account
+	for _, v := range views {
+		accts = append(accts, &Account{Account: v.Account})
+	}
 	return accts, nil
 }
 
@@ -172,8 +220,6 @@ func (r *Repository) DeleteAccount(ctx context.Context, scopeId, withPublicId st
 	if scopeId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
 	}
-	ac := allocAccount()
-	ac.PublicId = withPublicId
 
 	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
 	if err != nil {
@@ -186,16 +232,8 @@ func (r *Repository) DeleteAccount(ctx context.Context, scopeId, withPublicId st
 		db.StdRetryCnt,
 		db.ExpBackoff{},
 		func(_ db.Reader, w db.Writer) (err error) {
-			metadata := ac.oplog(oplog.OpType_OP_TYPE_DELETE)
-			dAc := ac.clone()
-			rowsDeleted, err = w.Delete(ctx, dAc, db.WithOplog(oplogWrapper, metadata))
-			if err != nil {
-				return errors.Wrap(ctx, err, op)
-			}
-			if rowsDeleted > 1 {
-				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
-			}
-			return nil
+			rowsDeleted, err = deleteAccount(ctx, op, w, oplogWrapper, withPublicId)
+			return err
 		},
 	)
 
@@ -206,6 +244,47 @@ func (r *Repository) DeleteAccount(ctx context.Context, scopeId, withPublicId st
 	return rowsDeleted, nil
 }
 
+// DeleteAccountTx deletes the account for the provided id like
+// DeleteAccount, but with w, the writer of a transaction started by the
+// caller, instead of in a transaction of its own.
+func (r *Repository) DeleteAccountTx(ctx context.Context, w db.Writer, scopeId, withPublicId string) (int, error) {
+	const op = "password.(Repository).DeleteAccountTx"
+	if w == nil {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing writer")
+	}
+	if withPublicId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	if scopeId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get oplog wrapper"))
+	}
+	rowsDeleted, err := deleteAccount(ctx, op, w, oplogWrapper, withPublicId)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(withPublicId))
+	}
+	return rowsDeleted, nil
+}
+
+func deleteAccount(ctx context.Context, op errors.Op, w db.Writer, oplogWrapper wrapping.Wrapper, withPublicId string) (int, error) {
+	ac := allocAccount()
+	ac.PublicId = withPublicId
+	metadata := ac.oplog(oplog.OpType_OP_TYPE_DELETE)
+	dAc := ac.clone()
+	rowsDeleted, err := w.Delete(ctx, dAc, db.WithOplog(oplogWrapper, metadata))
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	if rowsDeleted > 1 {
+		return db.NoRowsAffected, errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+	}
+	return rowsDeleted, nil
+}
+
 var reInvalidLoginName = regexp.MustCompile("[^a-z0-9.-]")
 
 func validLoginName(u string) bool {
@@ -220,14 +299,14 @@ func validLoginName(u string) bool {
 // Account containing the updated values and a count of the number of
 // records updated. a is not changed.
 //
-// a must contain a valid PublicId. Only a.Name, a.Description and
-// a.LoginName can be updated. If a.Name is set to a non-empty string, it
+// a must contain a valid PublicId. Only a.Name, a.Description, a.LoginName
+// and a.Disabled can be updated. If a.Name is set to a non-empty string, it
 // must be unique within a.AuthMethodId. If a.LoginName is set to a
 // non-empty string, it must be unique within a.AuthMethodId.
 //
 // An attribute of a will be set to NULL in the database if the attribute
 // in a is the zero value and it is included in fieldMaskPaths. a.LoginName
-// cannot be set to NULL.
+// cannot be set to NULL and a.Disabled is set to false.
 func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Account, version uint32, fieldMaskPaths []string, opt ...Option) (*Account, int, error) {
 	const op = "password.(Repository).UpdateAccount"
 	if a == nil {
@@ -257,6 +336,7 @@ func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Accou
 					fmt.Sprintf("invalid username: must be all-lowercase alphanumeric, period or hyphen, got %s", a.LoginName))
 			}
 			changeLoginName = true
+		case strings.EqualFold("Disabled", f):
 		default:
 			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
 		}
@@ -267,9 +347,10 @@ func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Accou
 			"Name":        a.Name,
 			"Description": a.Description,
 			"LoginName":   a.LoginName,
+			"Disabled":    a.Disabled,
 		},
 		fieldMaskPaths,
-		nil,
+		[]string{"Disabled"},
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "missing field mask")
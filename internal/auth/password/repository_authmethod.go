@@ -36,6 +36,9 @@ func (r *Repository) CreateAuthMethod(ctx context.Context, m *AuthMethod, opt ..
 	if m.PublicId != "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
 	}
+	if m.TotpMfa != "" && !validTotpMfa(m.TotpMfa) {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unknown totp mfa setting %q", m.TotpMfa))
+	}
 	m = m.Clone()
 
 	opts := getOpts(opt...)
@@ -174,8 +177,14 @@ func (r *Repository) DeleteAuthMethod(ctx context.Context, scopeId, publicId str
 // NewAuthMethod.  fieldMaskPaths provides field_mask.proto paths for fields
 // that should be updated.  Fields will be set to NULL if the field is a zero
 // value and included in fieldMask. Name, Description, MinPasswordLength,
-// and MinLoginNameLength are the only updatable fields, If no updatable fields
-// are included in the fieldMaskPaths, then an error is returned.
+// MinLoginNameLength and the password policy fields PasswordRequireLowercase,
+// PasswordRequireUppercase, PasswordRequireDigit, PasswordRequireSymbol,
+// DenyCommonPasswords, LockoutThreshold, LockoutWindowSeconds,
+// LockoutDurationSeconds, PasswordHistoryCount, MaxPasswordAgeDays and
+// TotpMfa are the only updatable fields. The password policy fields are set
+// to their zero value rather than NULL and an empty TotpMfa disables TOTP MFA.
+// If no updatable fields are included in the fieldMaskPaths, then an error is
+// returned.
 func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMethod, version uint32, fieldMaskPaths []string, opt ...Option) (*AuthMethod, int, error) {
 	const op = "password.(Repository).UpdateAuthMethod"
 	if authMethod == nil {
@@ -193,20 +202,60 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMetho
 		case strings.EqualFold("Description", f):
 		case strings.EqualFold("MinLoginNameLength", f):
 		case strings.EqualFold("MinPasswordLength", f):
+		case strings.EqualFold("PasswordRequireLowercase", f):
+		case strings.EqualFold("PasswordRequireUppercase", f):
+		case strings.EqualFold("PasswordRequireDigit", f):
+		case strings.EqualFold("PasswordRequireSymbol", f):
+		case strings.EqualFold("DenyCommonPasswords", f):
+		case strings.EqualFold("LockoutThreshold", f):
+		case strings.EqualFold("LockoutWindowSeconds", f):
+		case strings.EqualFold("LockoutDurationSeconds", f):
+		case strings.EqualFold("PasswordHistoryCount", f):
+		case strings.EqualFold("MaxPasswordAgeDays", f):
+		case strings.EqualFold("TotpMfa", f):
 		default:
 			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
 		}
 	}
+	totpMfa := authMethod.TotpMfa
+	if totpMfa == "" {
+		totpMfa = TotpMfaDisabled
+	}
+	if !validTotpMfa(totpMfa) {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unknown totp mfa setting %q", totpMfa))
+	}
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbcommon.BuildUpdatePaths(
 		map[string]interface{}{
-			"Name":               authMethod.Name,
-			"Description":        authMethod.Description,
-			"MinPasswordLength":  authMethod.MinPasswordLength,
-			"MinLoginNameLength": authMethod.MinLoginNameLength,
+			"Name":                     authMethod.Name,
+			"Description":              authMethod.Description,
+			"MinPasswordLength":        authMethod.MinPasswordLength,
+			"MinLoginNameLength":       authMethod.MinLoginNameLength,
+			"PasswordRequireLowercase": authMethod.PasswordRequireLowercase,
+			"PasswordRequireUppercase": authMethod.PasswordRequireUppercase,
+			"PasswordRequireDigit":     authMethod.PasswordRequireDigit,
+			"PasswordRequireSymbol":    authMethod.PasswordRequireSymbol,
+			"DenyCommonPasswords":      authMethod.DenyCommonPasswords,
+			"LockoutThreshold":         authMethod.LockoutThreshold,
+			"LockoutWindowSeconds":     authMethod.LockoutWindowSeconds,
+			"LockoutDurationSeconds":   authMethod.LockoutDurationSeconds,
+			"PasswordHistoryCount":     authMethod.PasswordHistoryCount,
+			"MaxPasswordAgeDays":       authMethod.MaxPasswordAgeDays,
+			"TotpMfa":                  totpMfa,
 		},
 		fieldMaskPaths,
-		nil,
+		[]string{
+			"PasswordRequireLowercase",
+			"PasswordRequireUppercase",
+			"PasswordRequireDigit",
+			"PasswordRequireSymbol",
+			"DenyCommonPasswords",
+			"LockoutThreshold",
+			"LockoutWindowSeconds",
+			"LockoutDurationSeconds",
+			"PasswordHistoryCount",
+			"MaxPasswordAgeDays",
+		},
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "field mask must not be empty")
@@ -219,6 +268,7 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMetho
 	}
 
 	upAuthMethod := authMethod.Clone()
+	upAuthMethod.TotpMfa = totpMfa
 	var rowsUpdated int
 	_, err = r.writer.DoTx(
 		ctx,
@@ -124,6 +124,13 @@ type currentConfig struct {
 	MinLoginNameLength int
 	MinPasswordLength  int
 
+	PasswordRequireLowercase bool
+	PasswordRequireUppercase bool
+	PasswordRequireDigit     bool
+	PasswordRequireSymbol    bool
+	DenyCommonPasswords      bool
+	PasswordHistoryCount     int
+
 	*Argon2Configuration
 }
 
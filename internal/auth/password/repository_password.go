@@ -6,10 +6,15 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/hashicorp/boundary/internal/activity"
+	"github.com/hashicorp/boundary/internal/auth/password/store"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
 
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/oplog"
 	"golang.org/x/crypto/argon2"
 )
@@ -19,6 +24,25 @@ type authAccount struct {
 	*Argon2Credential
 	*Argon2Configuration
 	IsCurrentConf bool
+
+	LockoutThreshold       uint32
+	LockoutWindowSeconds   uint32
+	LockoutDurationSeconds uint32
+	IsPasswordExpired      bool
+	IsUserDisabled         bool
+}
+
+// previousCredential holds the key derivation parameters of a current or
+// previous credential of an account.
+type previousCredential struct {
+	CtSalt     []byte `gorm:"column:salt" wrapping:"ct,entry_salt"`
+	Salt       []byte `gorm:"-" wrapping:"pt,entry_salt"`
+	DerivedKey []byte
+	KeyId      string
+	Iterations uint32
+	Memory     uint32
+	Threads    uint32
+	KeyLength  uint32
 }
 
 // Authenticate authenticates loginName and password match for loginName in
@@ -32,7 +56,15 @@ type authAccount struct {
 // Authenticate will update the stored values for password to the current
 // password settings for authMethodId if authentication is successful and
 // the stored values are not using the current password settings.
-func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, loginName, password string) (*Account, error) {
+//
+// If the account is locked because of too many failed authentication
+// attempts, an error with code AccountLocked is returned. If the account or
+// its user is disabled, an error with code AccountDisabled is returned once
+// the password is verified. If the password is older than the maximum
+// password age of authMethodId, it must be replaced by the password provided
+// with WithNewPassword; without it an error with code PasswordExpired is
+// returned. WithNewPassword is the only valid option.
+func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, loginName, password string, opt ...Option) (*Account, error) {
 	const op = "password.(Repository).Authenticate"
 	if authMethodId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing authMethodId", errors.WithoutEvent())
@@ -60,6 +92,26 @@ func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, lo
 		return nil, nil
 	}
 
+	if acct.IsPasswordExpired {
+		opts := getOpts(opt...)
+		if opts.withNewPassword == "" {
+			r.recordActivity(ctx, acct.PublicId, activity.AuthenticationFailed, "password expired")
+			return nil, errors.New(ctx, errors.PasswordExpired, op, "password must be changed", errors.WithoutEvent())
+		}
+		updated, err := r.ChangePassword(ctx, scopeId, acct.PublicId, password, opts.withNewPassword, acct.Version)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("change expired password"))
+		}
+		if updated == nil {
+			return nil, nil
+		}
+		acct.Account.Version = updated.Version
+		acct.Account.CredentialId = updated.CredentialId
+		r.recordActivity(ctx, acct.PublicId, activity.AuthenticationSucceeded, "")
+		return acct.Account, nil
+	}
+
+	r.recordActivity(ctx, acct.PublicId, activity.AuthenticationSucceeded, "")
 	if !acct.IsCurrentConf {
 		cc, err := r.currentConfig(ctx, authMethodId)
 		if err != nil {
@@ -110,6 +162,10 @@ func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, lo
 // Returns nil, db.ErrorRecordNotFound if the account doesn't exist.
 // Returns nil, nil if old does not match the stored password for accountId.
 // Returns nil, error with code PasswordsEqual if old and new are equal.
+// Returns nil, error with code PasswordTooWeak if new does not satisfy the
+// password policy of the auth method.
+// Returns nil, error with code PasswordReused if new matches one of the
+// passwords kept in the account's password history.
 func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old, new string, version uint32) (*Account, error) {
 	const op = "password.(Repository).ChangePassword"
 	if accountId == "" {
@@ -163,6 +219,12 @@ func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old
 	if cc.MinPasswordLength > len(new) {
 		return nil, errors.New(ctx, errors.PasswordTooShort, op, fmt.Sprintf("must be at least %d", cc.MinPasswordLength))
 	}
+	if err := cc.checkPasswordPolicy(ctx, new); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if err := r.checkPasswordReuse(ctx, scopeId, accountId, new, cc.PasswordHistoryCount); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
 	newCred, err := newArgon2Credential(accountId, new, cc.argon2())
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
@@ -195,6 +257,9 @@ func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old
 			if rowsDeleted > 1 {
 				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
 			}
+			if err := archiveCredential(ctx, w, oldCred, cc.PasswordHistoryCount); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
 			if err = w.Create(ctx, newCred, db.WithOplog(oplogWrapper, newCred.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create new credential"))
 			}
@@ -238,6 +303,11 @@ func (r *Repository) authenticate(ctx context.Context, scopeId, authMethodId, lo
 		acct = accts[0]
 	}
 
+	if acct.IsLocked {
+		r.recordActivity(ctx, acct.PublicId, activity.AuthenticationFailed, "account locked")
+		return nil, errors.New(ctx, errors.AccountLocked, op, "too many failed authentication attempts", errors.WithoutEvent())
+	}
+
 	// We don't pass a wrapper in here because for ecryption we want to indicate the expected key ID
 	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(acct.GetKeyId()))
 	if err != nil {
@@ -251,94 +321,281 @@ func (r *Repository) authenticate(ctx context.Context, scopeId, authMethodId, lo
 	inputKey := argon2.IDKey([]byte(password), acct.Salt, acct.Iterations, acct.Memory, uint8(acct.Threads), acct.KeyLength)
 	if subtle.ConstantTimeCompare(inputKey, acct.DerivedKey) == 0 {
 		// authentication failed, password does not match
+		if acct.LockoutThreshold > 0 {
+			if err := recordFailedLogin(ctx, r.writer, acct.PublicId, acct.LockoutThreshold, acct.LockoutWindowSeconds, acct.LockoutDurationSeconds); err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+		}
+		r.recordActivity(ctx, acct.PublicId, activity.AuthenticationFailed, "invalid password")
 		return nil, nil
 	}
+	if acct.FailedLoginCount > 0 || acct.LockExpirationTime != nil {
+		// Accounts which must still answer an MFA challenge keep their failed
+		// attempts until the challenge is answered, so that guessing codes
+		// after each password authentication still locks the account.
+		mfaRequired, err := r.MfaRequired(ctx, acct.PublicId)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if !mfaRequired {
+			if err := clearFailedLogins(ctx, r.writer, acct.PublicId); err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+			acct.FailedLoginCount = 0
+			acct.LockExpirationTime = nil
+		}
+	}
+	if acct.Disabled || acct.IsUserDisabled {
+		r.recordActivity(ctx, acct.PublicId, activity.AuthenticationFailed, "account disabled")
+		return nil, errors.New(ctx, errors.AccountDisabled, op, "account is disabled", errors.WithoutEvent())
+	}
 	return &acct, nil
 }
 
+// recordActivity records an authentication of the account accountId in the
+// activity history of its user. Failures are written as events and do not
+// fail the authentication.
+func (r *Repository) recordActivity(ctx context.Context, accountId string, t activity.Type, detail string) {
+	const op = "password.(Repository).recordActivity"
+	if err := activity.RecordAccountActivity(ctx, r.writer, accountId, t, activity.WithDetail(detail)); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to record user activity", "account id", accountId))
+	}
+}
+
+// clearFailedLogins resets the failed authentication attempts of accountId
+// and unlocks it.
+func clearFailedLogins(ctx context.Context, w db.Writer, accountId string) error {
+	const op = "password.clearFailedLogins"
+	if _, err := w.Exec(ctx, clearFailedLoginsQuery, []interface{}{sql.Named("account_id", accountId)}); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to clear failed authentication attempts"))
+	}
+	return nil
+}
+
+// recordFailedLogin counts a failed authentication attempt, either a wrong
+// password or a wrong MFA code, for accountId and locks the account once
+// threshold attempts were made within windowSeconds. The lock lasts
+// durationSeconds.
+func recordFailedLogin(ctx context.Context, w db.Writer, accountId string, threshold, windowSeconds, durationSeconds uint32) error {
+	const op = "password.recordFailedLogin"
+	_, err := w.Exec(ctx, recordFailedLoginQuery, []interface{}{
+		sql.Named("account_id", accountId),
+		sql.Named("threshold", threshold),
+		sql.Named("window_seconds", windowSeconds),
+		sql.Named("duration_seconds", durationSeconds),
+	})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// checkPasswordReuse returns an error with code PasswordReused if password
+// matches the current password of accountId or one of its historyCount-1
+// most recent previous passwords. No passwords are checked if historyCount
+// is 0.
+func (r *Repository) checkPasswordReuse(ctx context.Context, scopeId, accountId, password string, historyCount int) error {
+	const op = "password.(Repository).checkPasswordReuse"
+	if historyCount == 0 {
+		return nil
+	}
+	rows, err := r.reader.Query(ctx, previousCredentialsQuery, []interface{}{sql.Named("account_id", accountId), sql.Named("limit", historyCount-1)})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var creds []*previousCredential
+	for rows.Next() {
+		var c previousCredential
+		if err := r.reader.ScanRows(rows, &c); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		creds = append(creds, &c)
+	}
+
+	for _, c := range creds {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(c.KeyId))
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
+		}
+		if err := structwrapping.UnwrapStruct(ctx, databaseWrapper, c, nil); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt), errors.WithMsg("unable to decrypt previous credential"))
+		}
+		inputKey := argon2.IDKey([]byte(password), c.Salt, c.Iterations, c.Memory, uint8(c.Threads), c.KeyLength)
+		if subtle.ConstantTimeCompare(inputKey, c.DerivedKey) == 1 {
+			return errors.New(ctx, errors.PasswordReused, op, fmt.Sprintf("must not match any of the last %d passwords", historyCount))
+		}
+	}
+	return nil
+}
+
+// archiveCredential adds c, which is being replaced, to the password history
+// of its account and removes all but the historyCount-1 most recent previous
+// credentials, as the replacing credential counts towards historyCount.
+func archiveCredential(ctx context.Context, w db.Writer, c *Argon2Credential, historyCount int) error {
+	const op = "password.archiveCredential"
+	limit := historyCount - 1
+	if limit > 0 {
+		if err := w.Create(ctx, newArgon2CredentialHistory(c)); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add credential to history"))
+		}
+	} else {
+		limit = 0
+	}
+	if _, err := w.Exec(ctx, pruneCredentialHistoryQuery, []interface{}{sql.Named("account_id", c.PasswordAccountId), sql.Named("limit", limit)}); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to prune credential history"))
+	}
+	return nil
+}
+
 // SetPassword sets the password for accountId to password. If password
 // contains an empty string, the password for accountId will be deleted.
+// Setting the password unlocks the account. password must satisfy the
+// password policy of the auth method and must not match one of the
+// passwords kept in the account's password history.
 func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, password string, version uint32) (*Account, error) {
 	const op = "password.(Repository).SetPassword"
+	newCred, historyCount, oplogWrapper, err := r.prepareSetPassword(ctx, op, scopeId, accountId, password, version)
+	if err != nil {
+		return nil, err
+	}
+
+	var acct *Account
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(rr db.Reader, w db.Writer) error {
+			var err error
+			acct, err = setPassword(ctx, op, rr, w, oplogWrapper, accountId, version, newCred, historyCount)
+			return err
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return acct, nil
+}
+
+// SetPasswordTx sets the password for accountId to password like
+// SetPassword, but with reader and w, the reader and writer of a
+// transaction started by the caller, instead of in a transaction of its
+// own.
+func (r *Repository) SetPasswordTx(ctx context.Context, reader db.Reader, w db.Writer, scopeId, accountId, password string, version uint32) (*Account, error) {
+	const op = "password.(Repository).SetPasswordTx"
+	if reader == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing reader")
+	}
+	if w == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing writer")
+	}
+	newCred, historyCount, oplogWrapper, err := r.prepareSetPassword(ctx, op, scopeId, accountId, password, version)
+	if err != nil {
+		return nil, err
+	}
+	acct, err := setPassword(ctx, op, reader, w, oplogWrapper, accountId, version, newCred, historyCount)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return acct, nil
+}
+
+// prepareSetPassword validates the arguments of SetPassword and returns
+// the encrypted credential of password, or nil if password is empty, the
+// number of passwords to keep in the history of accountId and the oplog
+// wrapper of scopeId.
+func (r *Repository) prepareSetPassword(ctx context.Context, op errors.Op, scopeId, accountId, password string, version uint32) (*Argon2Credential, int, wrapping.Wrapper, error) {
 	if accountId == "" {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing accountId")
+		return nil, 0, nil, errors.New(ctx, errors.InvalidParameter, op, "missing accountId")
 	}
 	if version == 0 {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+		return nil, 0, nil, errors.New(ctx, errors.InvalidParameter, op, "missing version")
 	}
 	if scopeId == "" {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scopeId")
+		return nil, 0, nil, errors.New(ctx, errors.InvalidParameter, op, "missing scopeId")
 	}
 
 	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
 	if err != nil {
-		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get oplog wrapper"))
+		return nil, 0, nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get oplog wrapper"))
 	}
 	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
 	if err != nil {
-		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
+		return nil, 0, nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
 	}
 
 	var newCred *Argon2Credential
+	var historyCount int
 	if password != "" {
 		cc, err := r.currentConfigForAccount(ctx, accountId)
 		if err != nil {
-			return nil, errors.Wrap(ctx, err, op)
+			return nil, 0, nil, errors.Wrap(ctx, err, op)
 		}
 		if cc == nil {
-			return nil, errors.New(ctx, errors.RecordNotFound, op, "unable to retrieve current configuration")
+			return nil, 0, nil, errors.New(ctx, errors.RecordNotFound, op, "unable to retrieve current configuration")
 		}
 		if cc.MinPasswordLength > len(password) {
-			return nil, errors.New(ctx, errors.PasswordTooShort, op, fmt.Sprintf("password must be at least %v", cc.MinPasswordLength))
+			return nil, 0, nil, errors.New(ctx, errors.PasswordTooShort, op, fmt.Sprintf("password must be at least %v", cc.MinPasswordLength))
+		}
+		if err := cc.checkPasswordPolicy(ctx, password); err != nil {
+			return nil, 0, nil, errors.Wrap(ctx, err, op)
 		}
+		if err := r.checkPasswordReuse(ctx, scopeId, accountId, password, cc.PasswordHistoryCount); err != nil {
+			return nil, 0, nil, errors.Wrap(ctx, err, op)
+		}
+		historyCount = cc.PasswordHistoryCount
 		newCred, err = newArgon2Credential(accountId, password, cc.argon2())
 		if err != nil {
-			return nil, errors.Wrap(ctx, err, op)
+			return nil, 0, nil, errors.Wrap(ctx, err, op)
 		}
 		if err := newCred.encrypt(ctx, databaseWrapper); err != nil {
-			return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+			return nil, 0, nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
 		}
 	}
+	return newCred, historyCount, oplogWrapper, nil
+}
 
-	var acct *Account
-	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
-		func(rr db.Reader, w db.Writer) error {
-			updatedAccount := allocAccount()
-			updatedAccount.PublicId = accountId
-			updatedAccount.Version = version + 1
-			rowsUpdated, err := w.Update(ctx, updatedAccount, []string{"Version"}, nil, db.WithOplog(oplogWrapper, updatedAccount.oplog(oplog.OpType_OP_TYPE_UPDATE)), db.WithVersion(&version))
-			if err != nil {
-				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update account version"))
-			}
-			if rowsUpdated != 1 {
-				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated account and %d rows updated", rowsUpdated))
-			}
-			acct = updatedAccount
+func setPassword(ctx context.Context, op errors.Op, rr db.Reader, w db.Writer, oplogWrapper wrapping.Wrapper, accountId string, version uint32, newCred *Argon2Credential, historyCount int) (*Account, error) {
+	updatedAccount := allocAccount()
+	updatedAccount.PublicId = accountId
+	updatedAccount.Version = version + 1
+	rowsUpdated, err := w.Update(ctx, updatedAccount, []string{"Version"}, nil, db.WithOplog(oplogWrapper, updatedAccount.oplog(oplog.OpType_OP_TYPE_UPDATE)), db.WithVersion(&version))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update account version"))
+	}
+	if rowsUpdated != 1 {
+		return nil, errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated account and %d rows updated", rowsUpdated))
+	}
 
-			oldCred := allocCredential()
-			if err := rr.LookupWhere(ctx, &oldCred, "password_account_id = ?", accountId); err != nil {
-				if !errors.IsNotFoundError(err) {
-					return errors.Wrap(ctx, err, op)
-				}
-			}
-			if oldCred.PrivateId != "" {
-				dCred := oldCred.clone()
-				rowsDeleted, err := w.Delete(ctx, dCred, db.WithOplog(oplogWrapper, oldCred.oplog(oplog.OpType_OP_TYPE_DELETE)))
-				if err != nil {
-					return errors.Wrap(ctx, err, op)
-				}
-				if rowsDeleted > 1 {
-					return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
-				}
+	oldCred := allocCredential()
+	if err := rr.LookupWhere(ctx, &oldCred, "password_account_id = ?", accountId); err != nil {
+		if !errors.IsNotFoundError(err) {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	if oldCred.PrivateId != "" {
+		if newCred != nil {
+			archived := &Argon2Credential{Argon2Credential: &store.Argon2Credential{}}
+			if err := rr.LookupWhere(ctx, archived, "private_id = ?", oldCred.PrivateId); err != nil {
+				return nil, errors.Wrap(ctx, err, op)
 			}
-			if newCred != nil {
-				return w.Create(ctx, newCred, db.WithOplog(oplogWrapper, newCred.oplog(oplog.OpType_OP_TYPE_CREATE)))
+			if err := archiveCredential(ctx, w, archived, historyCount); err != nil {
+				return nil, errors.Wrap(ctx, err, op)
 			}
-			return nil
-		},
-	)
-	if err != nil {
-		return nil, errors.Wrap(ctx, err, op)
+		}
+		dCred := oldCred.clone()
+		rowsDeleted, err := w.Delete(ctx, dCred, db.WithOplog(oplogWrapper, oldCred.oplog(oplog.OpType_OP_TYPE_DELETE)))
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if rowsDeleted > 1 {
+			return nil, errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+		}
 	}
-	return acct, nil
+	if _, err := w.Exec(ctx, clearFailedLoginsQuery, []interface{}{sql.Named("account_id", accountId)}); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to unlock account"))
+	}
+	if newCred != nil {
+		if err := w.Create(ctx, newCred, db.WithOplog(oplogWrapper, newCred.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+			return nil, err
+		}
+	}
+	return updatedAccount, nil
 }
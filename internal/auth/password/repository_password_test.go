@@ -128,6 +128,63 @@ func TestRepository_Authenticate(t *testing.T) {
 	}
 }
 
+func TestRepository_Authenticate_Disabled(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iamRepo)
+	authMethod := TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	passwd := "12345678"
+	acct, err := repo.CreateAccount(ctx, o.GetPublicId(), &Account{
+		Account: &store.Account{
+			AuthMethodId: authMethod.PublicId,
+			LoginName:    "kazmierczak",
+		},
+	}, WithPassword(passwd))
+	require.NoError(t, err)
+	user := iam.TestUser(t, iamRepo, o.GetPublicId(), iam.WithAccountIds(acct.PublicId))
+
+	setAccountDisabled := func(t *testing.T, disabled bool) {
+		t.Helper()
+		acct.Disabled = disabled
+		updated, n, err := repo.UpdateAccount(ctx, o.GetPublicId(), acct, acct.Version, []string{"Disabled"})
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		assert.Equal(t, disabled, updated.Disabled)
+		acct.Version = updated.Version
+	}
+
+	t.Run("disabled-account", func(t *testing.T) {
+		setAccountDisabled(t, true)
+		_, err := repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, acct.LoginName, passwd)
+		assert.Truef(t, errors.Match(errors.T(errors.AccountDisabled), err), "Unexpected error %s", err)
+
+		got, err := repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, acct.LoginName, "wrong-password")
+		assert.NoError(t, err, "a wrong password does not reveal the account is disabled")
+		assert.Nil(t, got)
+
+		setAccountDisabled(t, false)
+		got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, acct.LoginName, passwd)
+		require.NoError(t, err)
+		assert.NotNil(t, got)
+	})
+	t.Run("disabled-user", func(t *testing.T) {
+		user.Disabled = true
+		updated, _, n, err := iamRepo.UpdateUser(ctx, user, user.Version, []string{"disabled"})
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		assert.True(t, updated.Disabled)
+		_, err = repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, acct.LoginName, passwd)
+		assert.Truef(t, errors.Match(errors.T(errors.AccountDisabled), err), "Unexpected error %s", err)
+	})
+}
+
 func TestRepository_AuthenticateRehash(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	rw := db.New(conn)
@@ -0,0 +1,403 @@
+package password
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+// TOTP enrollments and MFA challenges, like auth tokens, are not replicated,
+// so they don't need oplog entries.
+
+type totpState struct {
+	TotpMfa     string
+	IsEnrolled  bool
+	IsConfirmed bool
+
+	LockoutThreshold       uint32
+	LockoutWindowSeconds   uint32
+	LockoutDurationSeconds uint32
+	FailedLoginCount       uint32
+	IsLocked               bool
+}
+
+// mfaLockoutPolicy returns the lockout policy applied to failed MFA attempts.
+// It is the lockout policy of the auth method, or a default one if the auth
+// method does not lock accounts, as TOTP codes are much easier to guess than
+// passwords.
+func (s *totpState) mfaLockoutPolicy() (threshold, windowSeconds, durationSeconds uint32) {
+	if s.LockoutThreshold > 0 {
+		return s.LockoutThreshold, s.LockoutWindowSeconds, s.LockoutDurationSeconds
+	}
+	return mfaLockoutThreshold, 0, mfaLockoutDurationSeconds
+}
+
+func (r *Repository) lookupTotpState(ctx context.Context, accountId string) (*totpState, error) {
+	const op = "password.(Repository).lookupTotpState"
+	rows, err := r.reader.Query(ctx, totpStateQuery, []interface{}{sql.Named("account_id", accountId)})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var states []totpState
+	for rows.Next() {
+		var s totpState
+		if err := r.reader.ScanRows(rows, &s); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		states = append(states, s)
+	}
+	if len(states) == 0 {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("account %s not found", accountId))
+	}
+	return &states[0], nil
+}
+
+// EnrollTotp enrolls accountId in TOTP and returns the account with the
+// enrollment. The enrollment is returned only once and must be handed to the
+// owner of the account. Enrollments created by EnrollTotp are confirmed
+// immediately. Returns an error with code TotpAlreadyEnrolled if the account
+// already has a confirmed enrollment and TotpMfaDisabled if TOTP MFA is
+// disabled for the auth method of the account.
+func (r *Repository) EnrollTotp(ctx context.Context, scopeId, accountId string) (*Account, *TotpEnrollment, error) {
+	const op = "password.(Repository).EnrollTotp"
+	switch {
+	case scopeId == "":
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	case accountId == "":
+		return nil, nil, errors.New(ctx, errors.InvalidPublicId, op, "missing account id")
+	}
+	acct, err := r.LookupAccount(ctx, accountId)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	if acct == nil {
+		return nil, nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("account %s not found", accountId))
+	}
+	state, err := r.lookupTotpState(ctx, accountId)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	switch {
+	case state.TotpMfa == TotpMfaDisabled:
+		return nil, nil, errors.New(ctx, errors.TotpMfaDisabled, op, "totp mfa is disabled for the auth method")
+	case state.IsConfirmed:
+		return nil, nil, errors.New(ctx, errors.TotpAlreadyEnrolled, op, "account is already enrolled in totp")
+	}
+
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	totp, codes, enrollment, err := newAccountTotp(accountId, acct.GetLoginName())
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	totp.Confirmed = true
+	if err := totp.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			// Replace an unconfirmed enrollment from an authentication which
+			// was never answered.
+			if _, err := w.Exec(ctx, deleteTotpQuery, []interface{}{sql.Named("account_id", accountId)}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete unconfirmed enrollment"))
+			}
+			return createTotp(ctx, w, totp, codes)
+		},
+	)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	acct.IsTotpEnrolled = true
+	return acct, enrollment, nil
+}
+
+// RemoveTotp removes the TOTP enrollment of accountId and returns the
+// account.
+func (r *Repository) RemoveTotp(ctx context.Context, scopeId, accountId string) (*Account, error) {
+	const op = "password.(Repository).RemoveTotp"
+	switch {
+	case scopeId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	case accountId == "":
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing account id")
+	}
+	acct, err := r.LookupAccount(ctx, accountId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if acct == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("account %s not found", accountId))
+	}
+	if _, err := r.writer.Exec(ctx, deleteTotpQuery, []interface{}{sql.Named("account_id", accountId)}); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	acct.IsTotpEnrolled = false
+	return acct, nil
+}
+
+// MfaRequired reports whether accountId must answer an MFA challenge after
+// authenticating with its password.
+func (r *Repository) MfaRequired(ctx context.Context, accountId string) (bool, error) {
+	const op = "password.(Repository).MfaRequired"
+	if accountId == "" {
+		return false, errors.New(ctx, errors.InvalidPublicId, op, "missing account id")
+	}
+	state, err := r.lookupTotpState(ctx, accountId)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	switch state.TotpMfa {
+	case TotpMfaRequired:
+		return true, nil
+	case TotpMfaOptional:
+		return state.IsConfirmed, nil
+	}
+	return false, nil
+}
+
+// StartMfa creates an MFA challenge for accountId which, once answered by
+// VerifyMfa, issues the pending auth token authTokenId. It returns the
+// challenge token to hand to the client. If the account has no confirmed
+// TOTP enrollment, a new unconfirmed enrollment is created and returned. It is
+// confirmed once the challenge is answered with a code generated from it.
+func (r *Repository) StartMfa(ctx context.Context, scopeId, accountId, authTokenId string) (string, *TotpEnrollment, error) {
+	const op = "password.(Repository).StartMfa"
+	switch {
+	case scopeId == "":
+		return "", nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	case accountId == "":
+		return "", nil, errors.New(ctx, errors.InvalidPublicId, op, "missing account id")
+	case authTokenId == "":
+		return "", nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth token id")
+	}
+	state, err := r.lookupTotpState(ctx, accountId)
+	if err != nil {
+		return "", nil, errors.Wrap(ctx, err, op)
+	}
+	if state.TotpMfa == TotpMfaDisabled {
+		return "", nil, errors.New(ctx, errors.TotpMfaDisabled, op, "totp mfa is disabled for the auth method")
+	}
+
+	var totp *accountTotp
+	var codes []*totpRecoveryCode
+	var enrollment *TotpEnrollment
+	if !state.IsConfirmed {
+		acct, err := r.LookupAccount(ctx, accountId)
+		if err != nil {
+			return "", nil, errors.Wrap(ctx, err, op)
+		}
+		if acct == nil {
+			return "", nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("account %s not found", accountId))
+		}
+		databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return "", nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+		}
+		if totp, codes, enrollment, err = newAccountTotp(accountId, acct.GetLoginName()); err != nil {
+			return "", nil, errors.Wrap(ctx, err, op)
+		}
+		if err := totp.encrypt(ctx, databaseWrapper); err != nil {
+			return "", nil, errors.Wrap(ctx, err, op)
+		}
+	}
+
+	id, token, secretHash, err := newMfaChallengeToken()
+	if err != nil {
+		return "", nil, errors.Wrap(ctx, err, op)
+	}
+	challenge := allocMfaChallenge()
+	challenge.PublicId = id
+	challenge.PasswordAccountId = accountId
+	challenge.AuthTokenId = authTokenId
+	challenge.SecretHash = secretHash
+	challenge.ExpirationTime = timestamp.New(time.Now().Add(mfaChallengeTTL).Truncate(time.Second))
+
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			if _, err := w.Exec(ctx, deleteExpiredMfaChallengesQuery, []interface{}{sql.Named("account_id", accountId)}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete expired challenges"))
+			}
+			if totp != nil {
+				// Replace an unconfirmed enrollment from an earlier
+				// authentication which was never answered.
+				if _, err := w.Exec(ctx, deleteTotpQuery, []interface{}{sql.Named("account_id", accountId)}); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete unconfirmed enrollment"))
+				}
+				if err := createTotp(ctx, w, totp, codes); err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+			}
+			if err := w.Create(ctx, challenge); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create challenge"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return "", nil, errors.Wrap(ctx, err, op)
+	}
+	return token, enrollment, nil
+}
+
+// VerifyMfa answers the MFA challenge of challengeToken with code, which is
+// either a TOTP code or an unused recovery code of the account. It returns the
+// id of the pending auth token to issue. If the challenge does not exist, does
+// not belong to authMethodId or code is not valid, an empty id is returned
+// with a nil error. A challenge is deleted once it has been answered or after
+// too many failed attempts. Failed attempts count towards the lockout of the
+// account, as wrong passwords do. Returns an error with code
+// AuthAttemptExpired if the challenge has expired and AccountLocked if the
+// account is locked.
+func (r *Repository) VerifyMfa(ctx context.Context, scopeId, authMethodId, challengeToken, code string) (string, error) {
+	const op = "password.(Repository).VerifyMfa"
+	switch {
+	case scopeId == "":
+		return "", errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	case authMethodId == "":
+		return "", errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+	case challengeToken == "":
+		return "", errors.New(ctx, errors.InvalidParameter, op, "missing challenge token")
+	case code == "":
+		return "", errors.New(ctx, errors.InvalidParameter, op, "missing code")
+	}
+	id, secretHash, ok := parseMfaChallengeToken(challengeToken)
+	if !ok {
+		return "", nil
+	}
+	challenge := allocMfaChallenge()
+	challenge.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, challenge); err != nil {
+		if errors.IsNotFoundError(err) {
+			return "", nil
+		}
+		return "", errors.Wrap(ctx, err, op)
+	}
+	if subtle.ConstantTimeCompare(challenge.GetSecretHash(), secretHash) != 1 {
+		return "", nil
+	}
+	acct, err := r.LookupAccount(ctx, challenge.GetPasswordAccountId())
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	if acct == nil || acct.GetAuthMethodId() != authMethodId {
+		return "", nil
+	}
+	if challenge.GetExpirationTime().AsTime().Before(time.Now()) {
+		if _, err := r.writer.Delete(ctx, challenge); err != nil {
+			return "", errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete expired challenge"))
+		}
+		return "", errors.New(ctx, errors.AuthAttemptExpired, op, "mfa challenge has expired")
+	}
+
+	state, err := r.lookupTotpState(ctx, challenge.GetPasswordAccountId())
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	if state.IsLocked {
+		if _, err := r.writer.Delete(ctx, challenge); err != nil {
+			return "", errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete challenge of locked account"))
+		}
+		return "", errors.New(ctx, errors.AccountLocked, op, "too many failed authentication attempts", errors.WithoutEvent())
+	}
+
+	totp := allocAccountTotp()
+	if err := r.reader.LookupWhere(ctx, totp, "password_account_id = ?", challenge.GetPasswordAccountId()); err != nil {
+		if errors.IsNotFoundError(err) {
+			return "", nil
+		}
+		return "", errors.Wrap(ctx, err, op)
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(totp.GetKeyId()))
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := totp.decrypt(ctx, databaseWrapper); err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+
+	var verified bool
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			verified = false
+			accountArg := sql.Named("account_id", totp.GetPasswordAccountId())
+			if step, ok := validateTotpCode(totp.GetSeed(), code, time.Now(), totp.GetLastUsedStep()); ok {
+				rowsUpdated, err := w.Exec(ctx, useTotpStepQuery, []interface{}{accountArg, sql.Named("step", step)})
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to record totp code"))
+				}
+				verified = rowsUpdated == 1
+			} else if totp.GetConfirmed() {
+				// Recovery codes of an unconfirmed enrollment can not be
+				// used since the enrollment may never have been seen.
+				rowsDeleted, err := w.Exec(ctx, useRecoveryCodeQuery, []interface{}{accountArg, sql.Named("code_hash", hashRecoveryCode(code))})
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to use recovery code"))
+				}
+				verified = rowsDeleted == 1
+			}
+
+			if verified {
+				if _, err := w.Delete(ctx, challenge); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete challenge"))
+				}
+				if state.FailedLoginCount > 0 {
+					if err := clearFailedLogins(ctx, w, totp.GetPasswordAccountId()); err != nil {
+						return errors.Wrap(ctx, err, op)
+					}
+				}
+				return nil
+			}
+
+			// Failed attempts count towards the lockout of the account, so
+			// opening new challenges does not allow guessing more codes.
+			threshold, windowSeconds, durationSeconds := state.mfaLockoutPolicy()
+			if err := recordFailedLogin(ctx, w, totp.GetPasswordAccountId(), threshold, windowSeconds, durationSeconds); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if challenge.GetFailedAttempts()+1 >= mfaChallengeMaxAttempts {
+				if _, err := w.Delete(ctx, challenge); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete challenge"))
+				}
+				return nil
+			}
+			if _, err := w.Exec(ctx, failedMfaAttemptQuery, []interface{}{sql.Named("public_id", challenge.GetPublicId())}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to record failed attempt"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	if !verified {
+		return "", nil
+	}
+	return challenge.GetAuthTokenId(), nil
+}
+
+// createTotp creates totp and its recovery codes.
+func createTotp(ctx context.Context, w db.Writer, totp *accountTotp, codes []*totpRecoveryCode) error {
+	const op = "password.createTotp"
+	if err := w.Create(ctx, totp); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create totp enrollment"))
+	}
+	items := make([]interface{}, 0, len(codes))
+	for _, c := range codes {
+		items = append(items, c)
+	}
+	if err := w.CreateItems(ctx, items); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create recovery codes"))
+	}
+	return nil
+}
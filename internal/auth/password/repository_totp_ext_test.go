@@ -0,0 +1,121 @@
+package password_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testTotpCode returns the current TOTP code of the base32 encoded secret of
+// an enrollment.
+func testTotpCode(t *testing.T, secret string) string {
+	t.Helper()
+	seed, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	require.NoError(t, err)
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(time.Now().Unix()/30))
+	mac := hmac.New(sha1.New, seed)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0xf
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", code%1000000)
+}
+
+func TestRepository_VerifyMfa_Lockout(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	repo, err := password.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	atRepo, err := authtoken.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	authMethod := password.TestAuthMethods(t, conn, org.GetPublicId(), 1)[0]
+	const threshold = 3
+	authMethod.TotpMfa = password.TotpMfaRequired
+	authMethod.LockoutThreshold = threshold
+	authMethod.LockoutDurationSeconds = 600
+	_, _, err = repo.UpdateAuthMethod(ctx, authMethod, authMethod.GetVersion(), []string{"TotpMfa", "LockoutThreshold", "LockoutDurationSeconds"})
+	require.NoError(t, err)
+
+	const passwd = "12345678"
+	newAccount := func(t *testing.T, loginName string) (*password.Account, *iam.User) {
+		acct, err := repo.CreateAccount(ctx, org.GetPublicId(), &password.Account{
+			Account: &store.Account{
+				AuthMethodId: authMethod.GetPublicId(),
+				LoginName:    loginName,
+			},
+		}, password.WithPassword(passwd))
+		require.NoError(t, err)
+		return acct, iam.TestUser(t, iamRepo, org.GetPublicId(), iam.WithAccountIds(acct.GetPublicId()))
+	}
+	// startMfa authenticates with the password and opens a challenge.
+	startMfa := func(t *testing.T, acct *password.Account, u *iam.User) string {
+		_, err := repo.Authenticate(ctx, org.GetPublicId(), authMethod.GetPublicId(), acct.GetLoginName(), passwd)
+		require.NoError(t, err)
+		at, err := atRepo.CreateAuthToken(ctx, u, acct.GetPublicId(), authtoken.WithStatus(authtoken.PendingStatus))
+		require.NoError(t, err)
+		token, _, err := repo.StartMfa(ctx, org.GetPublicId(), acct.GetPublicId(), at.GetPublicId())
+		require.NoError(t, err)
+		return token
+	}
+
+	t.Run("failed attempts across challenges lock the account", func(t *testing.T) {
+		acct, u := newAccount(t, "locked")
+		open := startMfa(t, acct, u)
+		// Each failed attempt is made on a new challenge, after a successful
+		// password authentication.
+		for i := 0; i < threshold; i++ {
+			tokenId, err := repo.VerifyMfa(ctx, org.GetPublicId(), authMethod.GetPublicId(), startMfa(t, acct, u), "invalid")
+			require.NoError(t, err)
+			assert.Empty(t, tokenId)
+		}
+
+		_, err := repo.Authenticate(ctx, org.GetPublicId(), authMethod.GetPublicId(), acct.GetLoginName(), passwd)
+		assert.True(t, errors.Match(errors.T(errors.AccountLocked), err))
+		_, err = repo.VerifyMfa(ctx, org.GetPublicId(), authMethod.GetPublicId(), open, "invalid")
+		assert.True(t, errors.Match(errors.T(errors.AccountLocked), err))
+	})
+
+	t.Run("answering a challenge clears failed attempts", func(t *testing.T) {
+		acct, u := newAccount(t, "unlocked")
+		_, enrollment, err := repo.EnrollTotp(ctx, org.GetPublicId(), acct.GetPublicId())
+		require.NoError(t, err)
+
+		for i := 0; i < threshold-1; i++ {
+			tokenId, err := repo.VerifyMfa(ctx, org.GetPublicId(), authMethod.GetPublicId(), startMfa(t, acct, u), "invalid")
+			require.NoError(t, err)
+			assert.Empty(t, tokenId)
+		}
+		found, err := repo.LookupAccount(ctx, acct.GetPublicId())
+		require.NoError(t, err)
+		assert.Equal(t, uint32(threshold-1), found.GetFailedLoginCount())
+
+		tokenId, err := repo.VerifyMfa(ctx, org.GetPublicId(), authMethod.GetPublicId(), startMfa(t, acct, u), testTotpCode(t, enrollment.Secret))
+		require.NoError(t, err)
+		assert.NotEmpty(t, tokenId)
+		found, err = repo.LookupAccount(ctx, acct.GetPublicId())
+		require.NoError(t, err)
+		assert.Zero(t, found.GetFailedLoginCount())
+	})
+}
@@ -0,0 +1,314 @@
+package password
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"github.com/hashicorp/go-secure-stdlib/base62"
+)
+
+// TOTP MFA settings of an AuthMethod.
+const (
+	// TotpMfaDisabled does not require TOTP codes from any account.
+	TotpMfaDisabled = "disabled"
+
+	// TotpMfaOptional requires TOTP codes from accounts enrolled in TOTP.
+	TotpMfaOptional = "optional"
+
+	// TotpMfaRequired requires TOTP codes from all accounts. Accounts not
+	// enrolled in TOTP are enrolled during their next authentication.
+	TotpMfaRequired = "required"
+)
+
+// validTotpMfa reports whether s is a valid TOTP MFA setting.
+func validTotpMfa(s string) bool {
+	switch s {
+	case TotpMfaDisabled, TotpMfaOptional, TotpMfaRequired:
+		return true
+	}
+	return false
+}
+
+// The TOTP parameters follow the defaults of RFC 6238 which are supported by
+// all common authenticator apps.
+const (
+	totpIssuer     = "Boundary"
+	totpPeriod     = 30
+	totpDigits     = 6
+	totpSkew       = 1
+	totpSeedLength = 20
+
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+
+	mfaChallengePrefix      = "pwmfa"
+	mfaChallengeSecretLen   = 32
+	mfaChallengeTTL         = 5 * time.Minute
+	mfaChallengeMaxAttempts = 5
+
+	// The lockout policy applied to failed MFA attempts of accounts of auth
+	// methods which do not lock accounts.
+	mfaLockoutThreshold       = 10
+	mfaLockoutDurationSeconds = 15 * 60
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TotpEnrollment contains what an account needs to set up an authenticator
+// app. It is only returned when an enrollment is created.
+type TotpEnrollment struct {
+	// Url is an otpauth URL which can be encoded in a QR code.
+	Url string
+
+	// Secret is the base32 encoded seed.
+	Secret string
+
+	// RecoveryCodes can each be used once in place of a TOTP code.
+	RecoveryCodes []string
+}
+
+// accountTotp is the TOTP enrollment of an Account.
+type accountTotp struct {
+	*store.AccountTotp
+	tableName string
+}
+
+func allocAccountTotp() *accountTotp {
+	return &accountTotp{
+		AccountTotp: &store.AccountTotp{},
+	}
+}
+
+// newAccountTotp creates a new in memory accountTotp with a random seed and
+// returns it with the enrollment to hand to the account.
+func newAccountTotp(accountId, loginName string) (*accountTotp, []*totpRecoveryCode, *TotpEnrollment, error) {
+	const op = "password.newAccountTotp"
+	if accountId == "" {
+		return nil, nil, nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing account id")
+	}
+	seed := make([]byte, totpSeedLength)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, nil, nil, errors.WrapDeprecated(err, op, errors.WithCode(errors.Io))
+	}
+
+	enrollment := &TotpEnrollment{
+		Url:    totpUrl(loginName, seed),
+		Secret: totpEncoding.EncodeToString(seed),
+	}
+	codes := make([]*totpRecoveryCode, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return nil, nil, nil, errors.WrapDeprecated(err, op)
+		}
+		enrollment.RecoveryCodes = append(enrollment.RecoveryCodes, code)
+		codes = append(codes, &totpRecoveryCode{
+			TotpRecoveryCode: &store.TotpRecoveryCode{
+				PasswordAccountId: accountId,
+				CodeHash:          hashRecoveryCode(code),
+			},
+		})
+	}
+
+	t := &accountTotp{
+		AccountTotp: &store.AccountTotp{
+			PasswordAccountId: accountId,
+			Seed:              seed,
+		},
+	}
+	return t, codes, enrollment, nil
+}
+
+// TableName returns the table name.
+func (t *accountTotp) TableName() string {
+	if t != nil && t.tableName != "" {
+		return t.tableName
+	}
+	return "auth_password_account_totp"
+}
+
+// SetTableName sets the table name.
+func (t *accountTotp) SetTableName(n string) {
+	t.tableName = n
+}
+
+func (t *accountTotp) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "password.(accountTotp).encrypt"
+	if err := structwrapping.WrapStruct(ctx, cipher, t.AccountTotp, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	t.KeyId = cipher.KeyID()
+	return nil
+}
+
+func (t *accountTotp) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "password.(accountTotp).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, t.AccountTotp, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
+
+// totpRecoveryCode is the hash of an unused recovery code.
+type totpRecoveryCode struct {
+	*store.TotpRecoveryCode
+	tableName string
+}
+
+// TableName returns the table name.
+func (c *totpRecoveryCode) TableName() string {
+	if c != nil && c.tableName != "" {
+		return c.tableName
+	}
+	return "auth_password_totp_recovery_code"
+}
+
+// SetTableName sets the table name.
+func (c *totpRecoveryCode) SetTableName(n string) {
+	c.tableName = n
+}
+
+// mfaChallenge is a pending second authentication step of an Account.
+type mfaChallenge struct {
+	*store.MfaChallenge
+	tableName string
+}
+
+func allocMfaChallenge() *mfaChallenge {
+	return &mfaChallenge{
+		MfaChallenge: &store.MfaChallenge{},
+	}
+}
+
+// TableName returns the table name.
+func (c *mfaChallenge) TableName() string {
+	if c != nil && c.tableName != "" {
+		return c.tableName
+	}
+	return "auth_password_mfa_challenge"
+}
+
+// SetTableName sets the table name.
+func (c *mfaChallenge) SetTableName(n string) {
+	c.tableName = n
+}
+
+// newMfaChallengeToken returns a new challenge id and the token handed to the
+// client for it. The token is the id and a secret joined by a period. Only a
+// hash of the secret is stored.
+func newMfaChallengeToken() (id, token string, secretHash []byte, err error) {
+	const op = "password.newMfaChallengeToken"
+	id, err = db.NewPublicId(mfaChallengePrefix)
+	if err != nil {
+		return "", "", nil, errors.WrapDeprecated(err, op)
+	}
+	secret, err := base62.Random(mfaChallengeSecretLen)
+	if err != nil {
+		return "", "", nil, errors.WrapDeprecated(err, op, errors.WithCode(errors.Io))
+	}
+	h := sha256.Sum256([]byte(secret))
+	return id, id + "." + secret, h[:], nil
+}
+
+// parseMfaChallengeToken splits token into the challenge id and the hash of
+// the secret.
+func parseMfaChallengeToken(token string) (id string, secretHash []byte, ok bool) {
+	i := strings.LastIndex(token, ".")
+	if i <= 0 || i == len(token)-1 {
+		return "", nil, false
+	}
+	h := sha256.Sum256([]byte(token[i+1:]))
+	return token[:i], h[:], true
+}
+
+// totpStep returns the TOTP time step of t.
+func totpStep(t time.Time) int64 {
+	return t.Unix() / totpPeriod
+}
+
+// totpCode returns the TOTP code of seed for the time step as specified in
+// RFC 6238 using HMAC-SHA1.
+func totpCode(seed []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, seed)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	v := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, v%1000000)
+}
+
+// validateTotpCode returns the time step of code if it is a valid code of
+// seed at now. Codes of the adjacent time steps are accepted to allow for
+// clock skew. Codes of time steps up to and including lastUsedStep are
+// rejected so a code can not be replayed.
+func validateTotpCode(seed []byte, code string, now time.Time, lastUsedStep int64) (int64, bool) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	cur := totpStep(now)
+	for step := cur - totpSkew; step <= cur+totpSkew; step++ {
+		if step <= lastUsedStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(totpCode(seed, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// totpUrl returns the otpauth URL of seed for the account with loginName.
+func totpUrl(loginName string, seed []byte) string {
+	v := url.Values{}
+	v.Set("secret", totpEncoding.EncodeToString(seed))
+	v.Set("issuer", totpIssuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(totpDigits))
+	v.Set("period", fmt.Sprint(totpPeriod))
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + totpIssuer + ":" + loginName,
+		RawQuery: v.Encode(),
+	}
+	return u.String()
+}
+
+// newRecoveryCode returns a random recovery code formatted as two groups of
+// characters separated by a dash.
+func newRecoveryCode() (string, error) {
+	const op = "password.newRecoveryCode"
+	b := make([]byte, recoveryCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.WrapDeprecated(err, op, errors.WithCode(errors.Io))
+	}
+	code := strings.ToLower(totpEncoding.EncodeToString(b))[:recoveryCodeLength]
+	return code[:recoveryCodeLength/2] + "-" + code[recoveryCodeLength/2:], nil
+}
+
+// hashRecoveryCode returns the hash of code stored in the database. Case,
+// dashes and spaces in code are ignored.
+func hashRecoveryCode(code string) []byte {
+	code = strings.ToLower(code)
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+	h := sha256.Sum256([]byte(code))
+	return h[:]
+}
@@ -0,0 +1,118 @@
+package password
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTotpCode(t *testing.T) {
+	t.Parallel()
+	// Test vectors from RFC 6238 Appendix B for SHA1, truncated to 6 digits.
+	seed := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+	}
+	for _, tt := range tests {
+		got := totpCode(seed, totpStep(time.Unix(tt.unix, 0)))
+		assert.Equalf(t, tt.want, got, "time %d", tt.unix)
+	}
+}
+
+func TestValidateTotpCode(t *testing.T) {
+	t.Parallel()
+	seed := []byte("12345678901234567890")
+	now := time.Unix(1234567890, 0)
+	step := totpStep(now)
+
+	t.Run("current-step", func(t *testing.T) {
+		got, ok := validateTotpCode(seed, "005924", now, 0)
+		assert.True(t, ok)
+		assert.Equal(t, step, got)
+	})
+	t.Run("with-spaces", func(t *testing.T) {
+		got, ok := validateTotpCode(seed, " 005 924 ", now, 0)
+		assert.True(t, ok)
+		assert.Equal(t, step, got)
+	})
+	t.Run("previous-step", func(t *testing.T) {
+		got, ok := validateTotpCode(seed, totpCode(seed, step-1), now, 0)
+		assert.True(t, ok)
+		assert.Equal(t, step-1, got)
+	})
+	t.Run("outside-skew", func(t *testing.T) {
+		_, ok := validateTotpCode(seed, totpCode(seed, step-2), now, 0)
+		assert.False(t, ok)
+	})
+	t.Run("replayed", func(t *testing.T) {
+		_, ok := validateTotpCode(seed, "005924", now, step)
+		assert.False(t, ok)
+	})
+	t.Run("wrong-length", func(t *testing.T) {
+		_, ok := validateTotpCode(seed, "05924", now, 0)
+		assert.False(t, ok)
+	})
+}
+
+func TestNewAccountTotp(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+
+	_, _, _, err := newAccountTotp("", "alice")
+	assert.Error(err)
+
+	totp, codes, enrollment, err := newAccountTotp("acctpw_1234567890", "alice")
+	require.NoError(err)
+	assert.Len(totp.GetSeed(), totpSeedLength)
+	assert.Equal("acctpw_1234567890", totp.GetPasswordAccountId())
+	assert.False(totp.GetConfirmed())
+
+	require.Len(enrollment.RecoveryCodes, recoveryCodeCount)
+	require.Len(codes, recoveryCodeCount)
+	for i, c := range enrollment.RecoveryCodes {
+		assert.Len(c, recoveryCodeLength+1)
+		assert.Equal(hashRecoveryCode(c), codes[i].GetCodeHash())
+	}
+
+	u, err := url.Parse(enrollment.Url)
+	require.NoError(err)
+	assert.Equal("otpauth", u.Scheme)
+	assert.Equal("totp", u.Host)
+	assert.Equal("/Boundary:alice", u.Path)
+	assert.Equal(enrollment.Secret, u.Query().Get("secret"))
+	assert.Equal(totpEncoding.EncodeToString(totp.GetSeed()), enrollment.Secret)
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, hashRecoveryCode("abcde-fghij"), hashRecoveryCode("ABCDE FGHIJ"))
+	assert.Equal(t, hashRecoveryCode("abcde-fghij"), hashRecoveryCode("abcdefghij"))
+	assert.NotEqual(t, hashRecoveryCode("abcde-fghij"), hashRecoveryCode("abcde-fghik"))
+}
+
+func TestMfaChallengeToken(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	id, token, secretHash, err := newMfaChallengeToken()
+	require.NoError(err)
+
+	gotId, gotHash, ok := parseMfaChallengeToken(token)
+	require.True(ok)
+	assert.Equal(id, gotId)
+	assert.Equal(secretHash, gotHash)
+
+	for _, bad := range []string{"", ".", id, id + ".", ".secret"} {
+		_, _, ok := parseMfaChallengeToken(bad)
+		assert.Falsef(ok, "token %q", bad)
+	}
+}
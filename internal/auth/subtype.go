@@ -36,6 +36,7 @@ type AuthMethod interface {
 type Account interface {
 	boundary.Resource
 	GetAuthMethodId() string
+	GetDisabled() bool
 }
 
 type ManagedGroup interface {
@@ -0,0 +1,67 @@
+package authtoken
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/authtoken/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/perms"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultAuthTokenGrantTableName is the table where the grants of derived auth
+// tokens are stored.
+const defaultAuthTokenGrantTableName = "auth_token_grant"
+
+// A Grant restricts a derived auth token to a grant within a scope. A derived
+// auth token is only authorized to perform an action if both its grants and
+// the grants of its user allow it.
+type Grant struct {
+	*store.AuthTokenGrant
+	tableName string `gorm:"-"`
+}
+
+// NewGrant creates a new in memory grant for the scope. The auth token id is
+// set when the grant is stored along with its derived auth token. All options
+// are ignored.
+func NewGrant(ctx context.Context, scopeId, grant string, _ ...Option) (*Grant, error) {
+	const op = "authtoken.NewGrant"
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	if grant == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing grant")
+	}
+	perm, err := perms.Parse(scopeId, grant)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("parsing grant string"))
+	}
+	return &Grant{
+		AuthTokenGrant: &store.AuthTokenGrant{
+			ScopeId:        scopeId,
+			RawGrant:       grant,
+			CanonicalGrant: perm.CanonicalString(),
+		},
+	}, nil
+}
+
+func (g *Grant) clone() *Grant {
+	cp := proto.Clone(g.AuthTokenGrant)
+	return &Grant{
+		AuthTokenGrant: cp.(*store.AuthTokenGrant),
+	}
+}
+
+// TableName returns the table name for the grant.
+func (g *Grant) TableName() string {
+	if g.tableName != "" {
+		return g.tableName
+	}
+	return defaultAuthTokenGrantTableName
+}
+
+// SetTableName sets the table name. If the caller attempts to set the name to
+// "" the name will be reset to the default name.
+func (g *Grant) SetTableName(n string) {
+	g.tableName = n
+}
@@ -31,6 +31,7 @@ type options struct {
 	withLimit                    int
 	withStatus                   Status
 	withPublicId                 string
+	withDescription              string
 }
 
 func getDefaultOptions() options {
@@ -91,3 +92,10 @@ func WithPublicId(id string) Option {
 		o.withPublicId = id
 	}
 }
+
+// WithDescription allows setting the description of a derived auth token.
+func WithDescription(desc string) Option {
+	return func(o *options) {
+		o.withDescription = desc
+	}
+}
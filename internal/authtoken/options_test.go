@@ -50,4 +50,12 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withPublicId = "test-id"
 		assert.Equal(opts, testOpts)
 	})
+
+	t.Run("WithDescription", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithDescription("ci pipeline"))
+		testOpts := getDefaultOptions()
+		testOpts.withDescription = "ci pipeline"
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -443,3 +443,102 @@ func (r *Repository) CloseExpiredPendingTokens(ctx context.Context) (int, error)
 	}
 	return tokensClosed, nil
 }
+
+// RevokeAuthTokensForUser deletes all auth tokens of the user and returns the
+// deleted tokens without their token values. Sessions created with a deleted
+// token are canceled by the database.
+func (r *Repository) RevokeAuthTokensForUser(ctx context.Context, userId string) ([]*AuthToken, error) {
+	const op = "authtoken.(Repository).RevokeAuthTokensForUser"
+	if userId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	}
+	ats, err := r.revokeAuthTokens(ctx, "iam_user_id = ?", []interface{}{userId})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(userId))
+	}
+	return ats, nil
+}
+
+// RevokeAuthTokensForAccount deletes all auth tokens issued for the account
+// and returns the deleted tokens without their token values. Sessions created
+// with a deleted token are canceled by the database.
+func (r *Repository) RevokeAuthTokensForAccount(ctx context.Context, accountId string) ([]*AuthToken, error) {
+	const op = "authtoken.(Repository).RevokeAuthTokensForAccount"
+	if accountId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing account id")
+	}
+	ats, err := r.revokeAuthTokens(ctx, "auth_account_id = ?", []interface{}{accountId})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(accountId))
+	}
+	return ats, nil
+}
+
+// RevokeAuthTokensForAuthMethod deletes all auth tokens issued for accounts of
+// the auth method and returns the deleted tokens without their token values.
+// Sessions created with a deleted token are canceled by the database.
+func (r *Repository) RevokeAuthTokensForAuthMethod(ctx context.Context, authMethodId string) ([]*AuthToken, error) {
+	const op = "authtoken.(Repository).RevokeAuthTokensForAuthMethod"
+	if authMethodId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+	}
+	ats, err := r.revokeAuthTokens(ctx, "auth_method_id = ?", []interface{}{authMethodId})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(authMethodId))
+	}
+	return ats, nil
+}
+
+// RevokeAuthTokensForScope deletes all auth tokens of the users in the scope
+// and returns the deleted tokens without their token values. Sessions created
+// with a deleted token are canceled by the database.
+func (r *Repository) RevokeAuthTokensForScope(ctx context.Context, scopeId string) ([]*AuthToken, error) {
+	const op = "authtoken.(Repository).RevokeAuthTokensForScope"
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	ats, err := r.revokeAuthTokens(ctx, "iam_user_id in (select public_id from iam_user where scope_id = ?)", []interface{}{scopeId})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(scopeId))
+	}
+	return ats, nil
+}
+
+// revokeAuthTokens deletes the auth tokens matching the where clause, which
+// is evaluated against the auth_token_account view, and returns them.
+func (r *Repository) revokeAuthTokens(ctx context.Context, where string, args []interface{}) ([]*AuthToken, error) {
+	const op = "authtoken.(Repository).revokeAuthTokens"
+	var authTokens []*AuthToken
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			authTokens = nil
+			var atvs []*authTokenView
+			if err := reader.SearchWhere(ctx, &atvs, where, args, db.WithLimit(-1)); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if len(atvs) == 0 {
+				return nil
+			}
+			ids := make([]string, 0, len(atvs))
+			for _, atv := range atvs {
+				atv.Token = ""
+				atv.CtToken = nil
+				atv.KeyId = ""
+				authTokens = append(authTokens, atv.toAuthToken())
+				ids = append(ids, atv.PublicId)
+			}
+			// tokens are not replicated, so they don't need oplog entries.
+			if _, err := w.Exec(ctx, "delete from auth_token where public_id in (?)", []interface{}{ids}); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err // error already wrapped when raised from r.DoTx(...)
+	}
+	return authTokens, nil
+}
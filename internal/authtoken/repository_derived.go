@@ -0,0 +1,140 @@
+package authtoken
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+// CreateDerivedAuthToken creates an auth token derived from the issued auth
+// token parentId and returns it including its token value. The derived auth
+// token belongs to the same auth account or service account as its parent and
+// is restricted to the provided grants, of which there must be at least one.
+// It expires after the duration set with WithTokenTimeToLiveDuration but never
+// after its parent and it is deleted along with its parent. Derived auth tokens can not be used to
+// create further derived auth tokens. The WithTokenTimeToLiveDuration,
+// WithDescription and WithPublicId options are supported and all other options
+// are ignored.
+func (r *Repository) CreateDerivedAuthToken(ctx context.Context, parentId string, grants []*Grant, opt ...Option) (*AuthToken, error) {
+	const op = "authtoken.(Repository).CreateDerivedAuthToken"
+	if parentId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing parent id")
+	}
+	if len(grants) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing grants")
+	}
+	for _, g := range grants {
+		if g == nil || g.AuthTokenGrant == nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "missing grant")
+		}
+	}
+
+	parent, err := r.LookupAuthToken(ctx, parentId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	switch {
+	case parent == nil:
+		return nil, errors.New(ctx, errors.RecordNotFound, op, "parent auth token not found")
+	case parent.GetParentId() != "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "derived auth tokens can not be used to create auth tokens")
+	case parent.GetStatus() != string(IssuedStatus):
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "parent auth token has not been issued")
+	}
+
+	opts := getOpts(opt...)
+	at, err := newAuthToken()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if opts.withPublicId == "" {
+		id, err := NewAuthTokenId()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		opts.withPublicId = id
+	}
+	at.PublicId = opts.withPublicId
+	at.AuthAccountId = parent.GetAuthAccountId()
+	at.ServiceAccountId = parent.GetServiceAccountId()
+	at.ParentId = parent.GetPublicId()
+	at.Description = opts.withDescription
+	at.Status = string(IssuedStatus)
+
+	parentExpiration, err := ptypes.Timestamp(parent.GetExpirationTime().GetTimestamp())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("parent expiration time"), errors.WithCode(errors.InvalidTimeStamp))
+	}
+	// We truncate the expiration time to the nearest second to make testing in
+	// different platforms with different time resolutions easier.
+	exp := time.Now().Add(opts.withTokenTimeToLiveDuration).Truncate(time.Second)
+	if exp.After(parentExpiration) {
+		exp = parentExpiration
+	}
+	expiration, err := ptypes.TimestampProto(exp)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidTimeStamp))
+	}
+	at.ExpirationTime = &timestamp.Timestamp{Timestamp: expiration}
+
+	databaseWrapper, err := r.kms.GetWrapper(ctx, parent.GetScopeId(), kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+
+	var newAuthToken *AuthToken
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newAuthToken = at.clone()
+			if err := newAuthToken.encrypt(ctx, databaseWrapper); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			// tokens are not replicated, so they don't need oplog entries.
+			if err := w.Create(ctx, newAuthToken); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			newGrants := make([]interface{}, 0, len(grants))
+			for _, g := range grants {
+				ng := g.clone()
+				ng.AuthTokenId = newAuthToken.GetPublicId()
+				newGrants = append(newGrants, ng)
+			}
+			if err := w.CreateItems(ctx, newGrants); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create grants"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	newAuthToken.CtToken = nil
+	newAuthToken.KeyId = ""
+	newAuthToken.ScopeId = parent.GetScopeId()
+	newAuthToken.AuthMethodId = parent.GetAuthMethodId()
+	newAuthToken.IamUserId = parent.GetIamUserId()
+	return newAuthToken, nil
+}
+
+// ListAuthTokenGrants returns the grants of the derived auth token
+// authTokenId. Auth tokens which are not derived have no grants. All options
+// are ignored.
+func (r *Repository) ListAuthTokenGrants(ctx context.Context, authTokenId string, _ ...Option) ([]*Grant, error) {
+	const op = "authtoken.(Repository).ListAuthTokenGrants"
+	if authTokenId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth token id")
+	}
+	var grants []*Grant
+	if err := r.reader.SearchWhere(ctx, &grants, "auth_token_id = ?", []interface{}{authTokenId}, db.WithLimit(-1)); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return grants, nil
+}
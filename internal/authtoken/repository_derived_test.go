@@ -0,0 +1,185 @@
+package authtoken
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateDerivedAuthToken(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, proj := iam.TestScopes(t, iamRepo)
+
+	newGrants := func(t *testing.T) []*Grant {
+		t.Helper()
+		g1, err := NewGrant(ctx, proj.GetPublicId(), "id=*;type=session;actions=list,read")
+		require.NoError(t, err)
+		g2, err := NewGrant(ctx, org.GetPublicId(), "id=*;type=target;actions=list")
+		require.NoError(t, err)
+		return []*Grant{g1, g2}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		parent := TestAuthToken(t, conn, kms, org.GetPublicId())
+		got, err := repo.CreateDerivedAuthToken(ctx, parent.GetPublicId(), newGrants(t),
+			WithDescription("automation"), WithTokenTimeToLiveDuration(time.Hour))
+		require.NoError(err)
+		require.NotNil(got)
+		assert.NotEmpty(got.GetToken())
+		assert.Empty(got.GetCtToken())
+		assert.Equal(parent.GetPublicId(), got.GetParentId())
+		assert.Equal(parent.GetAuthAccountId(), got.GetAuthAccountId())
+		assert.Equal(parent.GetIamUserId(), got.GetIamUserId())
+		assert.Equal(parent.GetScopeId(), got.GetScopeId())
+		assert.Equal("automation", got.GetDescription())
+		assert.Equal(string(IssuedStatus), got.GetStatus())
+		exp := got.GetExpirationTime().AsTime()
+		assert.WithinDuration(time.Now().Add(time.Hour), exp, time.Minute)
+
+		found, err := repo.LookupAuthToken(ctx, got.GetPublicId())
+		require.NoError(err)
+		require.NotNil(found)
+		assert.Equal(parent.GetPublicId(), found.GetParentId())
+		assert.Equal("automation", found.GetDescription())
+
+		validated, err := repo.ValidateToken(ctx, got.GetPublicId(), got.GetToken())
+		require.NoError(err)
+		require.NotNil(validated)
+
+		grants, err := repo.ListAuthTokenGrants(ctx, got.GetPublicId())
+		require.NoError(err)
+		assert.Len(grants, 2)
+		for _, g := range grants {
+			assert.Equal(got.GetPublicId(), g.GetAuthTokenId())
+			assert.NotEmpty(g.GetCanonicalGrant())
+		}
+
+		// derived tokens are deleted along with their parent
+		_, err = repo.DeleteAuthToken(ctx, parent.GetPublicId())
+		require.NoError(err)
+		found, err = repo.LookupAuthToken(ctx, got.GetPublicId())
+		require.NoError(err)
+		assert.Nil(found)
+		grants, err = repo.ListAuthTokenGrants(ctx, got.GetPublicId())
+		require.NoError(err)
+		assert.Empty(grants)
+	})
+	t.Run("never-outlives-parent", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		parent := TestAuthToken(t, conn, kms, org.GetPublicId(), WithTokenTimeToLiveDuration(time.Hour))
+		got, err := repo.CreateDerivedAuthToken(ctx, parent.GetPublicId(), newGrants(t), WithTokenTimeToLiveDuration(48*time.Hour))
+		require.NoError(err)
+		assert.True(got.GetExpirationTime().AsTime().Equal(parent.GetExpirationTime().AsTime()))
+	})
+	t.Run("derived-parent", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		parent := TestAuthToken(t, conn, kms, org.GetPublicId())
+		derived, err := repo.CreateDerivedAuthToken(ctx, parent.GetPublicId(), newGrants(t))
+		require.NoError(err)
+		got, err := repo.CreateDerivedAuthToken(ctx, derived.GetPublicId(), newGrants(t))
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %v", err)
+		assert.Nil(got)
+	})
+	t.Run("pending-parent", func(t *testing.T) {
+		assert := assert.New(t)
+		parent := TestAuthToken(t, conn, kms, org.GetPublicId(), WithStatus(PendingStatus))
+		got, err := repo.CreateDerivedAuthToken(ctx, parent.GetPublicId(), newGrants(t))
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %v", err)
+		assert.Nil(got)
+	})
+	t.Run("unknown-parent", func(t *testing.T) {
+		assert := assert.New(t)
+		id, err := NewAuthTokenId()
+		require.NoError(t, err)
+		got, err := repo.CreateDerivedAuthToken(ctx, id, newGrants(t))
+		assert.Truef(errors.Match(errors.T(errors.RecordNotFound), err), "unexpected error %v", err)
+		assert.Nil(got)
+	})
+	t.Run("missing-parameters", func(t *testing.T) {
+		assert := assert.New(t)
+		parent := TestAuthToken(t, conn, kms, org.GetPublicId())
+		_, err := repo.CreateDerivedAuthToken(ctx, "", newGrants(t))
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		_, err = repo.CreateDerivedAuthToken(ctx, parent.GetPublicId(), nil)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		_, err = repo.CreateDerivedAuthToken(ctx, parent.GetPublicId(), []*Grant{nil})
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		_, err = repo.ListAuthTokenGrants(ctx, "")
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
+
+func TestNewGrant(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := []struct {
+		name          string
+		scopeId       string
+		grant         string
+		wantCanonical string
+		wantErr       bool
+	}{
+		{
+			name:          "valid",
+			scopeId:       scope.Global.String(),
+			grant:         "type=host-catalog;actions=list;id=*",
+			wantCanonical: "id=*;type=host-catalog;actions=list",
+		},
+		{
+			name:          "templated",
+			scopeId:       "o_1234567890",
+			grant:         "id={{user.id}};actions=read",
+			wantCanonical: "id={{user.id}};actions=read",
+		},
+		{
+			name:    "missing-scope",
+			grant:   "id=*;type=*;actions=*",
+			wantErr: true,
+		},
+		{
+			name:    "missing-grant",
+			scopeId: scope.Global.String(),
+			wantErr: true,
+		},
+		{
+			name:    "invalid-grant",
+			scopeId: scope.Global.String(),
+			grant:   "id=*;type=nothing;actions=read",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewGrant(ctx, tt.scopeId, tt.grant)
+			if tt.wantErr {
+				assert.Error(err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.scopeId, got.GetScopeId())
+			assert.Equal(tt.grant, got.GetRawGrant())
+			assert.Equal(tt.wantCanonical, got.GetCanonicalGrant())
+			assert.Empty(got.GetAuthTokenId())
+		})
+	}
+}
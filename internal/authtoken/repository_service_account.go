@@ -0,0 +1,83 @@
+package authtoken
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+// CreateServiceAccountAuthToken creates an issued auth token for the service
+// account withServiceAccount and returns it including its token value. The
+// service account must already be authenticated by the caller. WithPublicId
+// is the only supported option.
+func (r *Repository) CreateServiceAccountAuthToken(ctx context.Context, withServiceAccount *iam.ServiceAccount, opt ...Option) (*AuthToken, error) {
+	const op = "authtoken.(Repository).CreateServiceAccountAuthToken"
+	if withServiceAccount == nil || withServiceAccount.ServiceAccount == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing service account")
+	}
+	if withServiceAccount.GetPublicId() == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing service account id")
+	}
+	if withServiceAccount.GetScopeId() == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	at, err := newAuthToken()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	opts := getOpts(opt...)
+	if opts.withPublicId == "" {
+		id, err := NewAuthTokenId()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		opts.withPublicId = id
+	}
+	at.PublicId = opts.withPublicId
+	at.ServiceAccountId = withServiceAccount.GetPublicId()
+	at.Status = string(IssuedStatus)
+
+	// We truncate the expiration time to the nearest second to make testing in
+	// different platforms with different time resolutions easier.
+	expiration, err := ptypes.TimestampProto(time.Now().Add(r.timeToLiveDuration).Truncate(time.Second))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidTimeStamp))
+	}
+	at.ExpirationTime = &timestamp.Timestamp{Timestamp: expiration}
+
+	databaseWrapper, err := r.kms.GetWrapper(ctx, withServiceAccount.GetScopeId(), kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+
+	var newAuthToken *AuthToken
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newAuthToken = at.clone()
+			if err := newAuthToken.encrypt(ctx, databaseWrapper); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			// tokens are not replicated, so they don't need oplog entries.
+			if err := w.Create(ctx, newAuthToken); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	newAuthToken.CtToken = nil
+	newAuthToken.KeyId = ""
+	newAuthToken.ScopeId = withServiceAccount.GetScopeId()
+	return newAuthToken, nil
+}
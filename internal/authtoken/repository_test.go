@@ -868,3 +868,90 @@ func Test_CloseExpiredPendingTokens(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_RevokeAuthTokens(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+
+	lookup := func(t *testing.T, id string) *AuthToken {
+		t.Helper()
+		got, err := repo.LookupAuthToken(ctx, id)
+		require.NoError(t, err)
+		return got
+	}
+
+	t.Run("user", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		org, _ := iam.TestScopes(t, iamRepo)
+		at := TestAuthToken(t, conn, kms, org.GetPublicId())
+		other := TestAuthToken(t, conn, kms, org.GetPublicId())
+
+		got, err := repo.RevokeAuthTokensForUser(ctx, at.GetIamUserId())
+		require.NoError(err)
+		require.Len(got, 1)
+		assert.Equal(at.GetPublicId(), got[0].GetPublicId())
+		assert.Empty(got[0].GetToken())
+		assert.Nil(lookup(t, at.GetPublicId()))
+		assert.NotNil(lookup(t, other.GetPublicId()))
+
+		got, err = repo.RevokeAuthTokensForUser(ctx, at.GetIamUserId())
+		require.NoError(err)
+		assert.Empty(got)
+	})
+	t.Run("account", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		org, _ := iam.TestScopes(t, iamRepo)
+		at := TestAuthToken(t, conn, kms, org.GetPublicId())
+		other := TestAuthToken(t, conn, kms, org.GetPublicId())
+
+		got, err := repo.RevokeAuthTokensForAccount(ctx, at.GetAuthAccountId())
+		require.NoError(err)
+		require.Len(got, 1)
+		assert.Nil(lookup(t, at.GetPublicId()))
+		assert.NotNil(lookup(t, other.GetPublicId()))
+	})
+	t.Run("auth-method", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		org, _ := iam.TestScopes(t, iamRepo)
+		at := TestAuthToken(t, conn, kms, org.GetPublicId())
+		other := TestAuthToken(t, conn, kms, org.GetPublicId())
+
+		got, err := repo.RevokeAuthTokensForAuthMethod(ctx, at.GetAuthMethodId())
+		require.NoError(err)
+		require.Len(got, 1)
+		assert.Nil(lookup(t, at.GetPublicId()))
+		assert.NotNil(lookup(t, other.GetPublicId()))
+	})
+	t.Run("scope", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		org, _ := iam.TestScopes(t, iamRepo)
+		otherOrg, _ := iam.TestScopes(t, iamRepo)
+		at1 := TestAuthToken(t, conn, kms, org.GetPublicId())
+		at2 := TestAuthToken(t, conn, kms, org.GetPublicId())
+		other := TestAuthToken(t, conn, kms, otherOrg.GetPublicId())
+
+		got, err := repo.RevokeAuthTokensForScope(ctx, org.GetPublicId())
+		require.NoError(err)
+		assert.Len(got, 2)
+		assert.Nil(lookup(t, at1.GetPublicId()))
+		assert.Nil(lookup(t, at2.GetPublicId()))
+		assert.NotNil(lookup(t, other.GetPublicId()))
+	})
+	t.Run("missing-ids", func(t *testing.T) {
+		_, err := repo.RevokeAuthTokensForUser(ctx, "")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		_, err = repo.RevokeAuthTokensForAccount(ctx, "")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		_, err = repo.RevokeAuthTokensForAuthMethod(ctx, "")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		_, err = repo.RevokeAuthTokensForScope(ctx, "")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
@@ -106,6 +106,7 @@ type Command struct {
 	FlagVersion           int
 	FlagRecursive         bool
 	FlagFilter            string
+	FlagLabels            []string
 
 	// Attribute values
 	FlagAttributes string
@@ -254,8 +254,6 @@ func (c *Command) PrintApiError(in *api.Error, contextStr string, opt ...Option)
 // PrintCliError prints the given CLI error to the UI in the appropriate format
 func (c *Command) PrintCliError(err error) {
 	switch Format(c.UI) {
-	case "table":
-		c.UI.Error(err.Error())
 	case "json":
 		output := struct {
 			Error string `json:"error"`
@@ -264,6 +262,8 @@ func (c *Command) PrintCliError(err error) {
 		}
 		b, _ := JsonFormatter{}.Format(output)
 		c.UI.Error(string(b))
+	default:
+		c.UI.Error(err.Error())
 	}
 }
 
@@ -64,6 +64,7 @@ func (b *Server) CreateInitialLoginRole(ctx context.Context) (*iam.Role, error)
 	if _, err := iamRepo.AddRoleGrants(cancelCtx, role.PublicId, role.Version, []string{
 		"id=*;type=scope;actions=list,no-op",
 		"id=*;type=auth-method;actions=authenticate,list",
+		"id=*;type=service-account;actions=authenticate",
 		"id={{account.id}};actions=read,change-password",
 		"id=*;type=auth-token;actions=list,read:self,delete:self",
 	}); err != nil {
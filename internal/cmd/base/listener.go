@@ -14,6 +14,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/libs/alpnmux"
 	"github.com/hashicorp/go-secure-stdlib/listenerutil"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
 	"github.com/hashicorp/go-secure-stdlib/reloadutil"
 	"github.com/mitchellh/cli"
 	"github.com/pires/go-proxyproto"
@@ -99,8 +100,13 @@ func NewListener(l *listenerutil.ListenerConfig, ui cli.Ui) (*alpnmux.ALPNMux, m
 	}
 
 	// Don't request a client cert unless they've explicitly configured it to do
-	// so
-	if !l.TLSRequireAndVerifyClientCert {
+	// so. API listeners can opt in to requesting (but not requiring) a client
+	// cert so that it can be used with a cert auth method.
+	requestClientCert, err := listenerRequestsClientCert(purpose, l)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !l.TLSRequireAndVerifyClientCert && !requestClientCert {
 		l.TLSDisableClientCerts = true
 	}
 	tlsConfig, reloadFunc, err := listenerutil.TLSConfig(l, props, ui)
@@ -121,6 +127,24 @@ func NewListener(l *listenerutil.ListenerConfig, ui cli.Ui) (*alpnmux.ALPNMux, m
 	return alpnMux, props, reloadFunc, nil
 }
 
+// listenerRequestsClientCert returns whether tls_request_client_cert is set
+// on an api listener. The key is not known to listenerutil so it is read from
+// the raw config.
+func listenerRequestsClientCert(purpose string, l *listenerutil.ListenerConfig) (bool, error) {
+	raw, ok := l.RawConfig["tls_request_client_cert"]
+	if !ok {
+		return false, nil
+	}
+	if purpose != "api" {
+		return false, fmt.Errorf("tls_request_client_cert is only valid for api listeners")
+	}
+	v, err := parseutil.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("invalid value for tls_request_client_cert: %w", err)
+	}
+	return v, nil
+}
+
 func tcpListenerFactory(purpose string, l *listenerutil.ListenerConfig, ui cli.Ui) (string, net.Listener, error) {
 	if l.Address == "" {
 		switch purpose {
@@ -0,0 +1,175 @@
+package base
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-secure-stdlib/listenerutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_listenerRequestsClientCert(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		purpose string
+		raw     map[string]interface{}
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "not-configured",
+			purpose: "api",
+		},
+		{
+			name:    "enabled",
+			purpose: "api",
+			raw:     map[string]interface{}{"tls_request_client_cert": true},
+			want:    true,
+		},
+		{
+			name:    "enabled-string",
+			purpose: "api",
+			raw:     map[string]interface{}{"tls_request_client_cert": "true"},
+			want:    true,
+		},
+		{
+			name:    "disabled",
+			purpose: "api",
+			raw:     map[string]interface{}{"tls_request_client_cert": false},
+		},
+		{
+			name:    "invalid-value",
+			purpose: "api",
+			raw:     map[string]interface{}{"tls_request_client_cert": "sometimes"},
+			wantErr: true,
+		},
+		{
+			name:    "non-api-listener",
+			purpose: "cluster",
+			raw:     map[string]interface{}{"tls_request_client_cert": true},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := listenerRequestsClientCert(tt.purpose, &listenerutil.ListenerConfig{RawConfig: tt.raw})
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestNewListener_ClientCertRequest(t *testing.T) {
+	t.Parallel()
+	certFile, keyFile := testListenerCertFiles(t)
+
+	tests := []struct {
+		name        string
+		raw         map[string]interface{}
+		wantRequest bool
+	}{
+		{
+			name: "not-configured",
+		},
+		{
+			name:        "configured",
+			raw:         map[string]interface{}{"tls_request_client_cert": true},
+			wantRequest: true,
+		},
+		{
+			name: "disabled",
+			raw:  map[string]interface{}{"tls_request_client_cert": false},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			l := &listenerutil.ListenerConfig{
+				Type:        "tcp",
+				Purpose:     []string{"api"},
+				Address:     "127.0.0.1:0",
+				TLSCertFile: certFile,
+				TLSKeyFile:  keyFile,
+				RawConfig:   tt.raw,
+			}
+			mux, _, _, err := NewListener(l, cli.NewMockUi())
+			require.NoError(err)
+			t.Cleanup(func() { mux.Close() })
+			assert.Equal(!tt.wantRequest, l.TLSDisableClientCerts)
+
+			ln := mux.GetListener("")
+			require.NotNil(ln)
+			go func() {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+				if tc, ok := conn.(*tls.Conn); ok {
+					_ = tc.Handshake()
+				}
+			}()
+
+			var requested bool
+			conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 5 * time.Second}, "tcp", mux.Addr().String(), &tls.Config{
+				InsecureSkipVerify: true,
+				GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+					requested = true
+					return &tls.Certificate{}, nil
+				},
+			})
+			require.NoError(err)
+			require.NoError(conn.Close())
+			assert.Equal(tt.wantRequest, requested)
+		})
+	}
+}
+
+// testListenerCertFiles writes a self-signed server certificate and its key
+// to a temporary directory and returns their paths.
+func testListenerCertFiles(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+	return certFile, keyFile
+}
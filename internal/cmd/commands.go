@@ -3,6 +3,7 @@ package cmd
 import (
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/cmd/commands/accountscmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/aliasescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authenticate"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authmethodscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authtokenscmd"
@@ -18,9 +19,13 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/commands/hostsetscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/logout"
 	"github.com/hashicorp/boundary/internal/cmd/commands/managedgroupscmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/notificationscmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/oplogcmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/reportscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/rolescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/scopescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/server"
+	"github.com/hashicorp/boundary/internal/cmd/commands/serviceaccountscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/sessionscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/targetscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/userscmd"
@@ -54,6 +59,42 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"aliases": func() (cli.Command, error) {
+			return &aliasescmd.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"aliases create": func() (cli.Command, error) {
+			return &aliasescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"aliases update": func() (cli.Command, error) {
+			return &aliasescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"aliases read": func() (cli.Command, error) {
+			return &aliasescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"aliases delete": func() (cli.Command, error) {
+			return &aliasescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "delete",
+			}, nil
+		},
+		"aliases list": func() (cli.Command, error) {
+			return &aliasescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+
 		"authenticate": func() (cli.Command, error) {
 			return &authenticate.Command{
 				Command: base.NewCommand(ui),
@@ -69,6 +110,11 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Command: base.NewCommand(ui),
 			}, nil
 		},
+		"authenticate cert": func() (cli.Command, error) {
+			return &authenticate.CertCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 
 		"accounts": func() (cli.Command, error) {
 			return &accountscmd.Command{
@@ -105,6 +151,24 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "change-password",
 			}, nil
 		},
+		"accounts enroll-totp": func() (cli.Command, error) {
+			return &accountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "enroll-totp",
+			}, nil
+		},
+		"accounts remove-totp": func() (cli.Command, error) {
+			return &accountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "remove-totp",
+			}, nil
+		},
+		"accounts revoke-tokens": func() (cli.Command, error) {
+			return &accountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "revoke-tokens",
+			}, nil
+		},
 		"accounts create": func() (cli.Command, error) {
 			return &accountscmd.Command{
 				Command: base.NewCommand(ui),
@@ -123,6 +187,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"accounts create cert": func() (cli.Command, error) {
+			return &accountscmd.CertCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"accounts update": func() (cli.Command, error) {
 			return &accountscmd.Command{
 				Command: base.NewCommand(ui),
@@ -141,6 +211,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"accounts update cert": func() (cli.Command, error) {
+			return &accountscmd.CertCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
 
 		"auth-methods": func() (cli.Command, error) {
 			return &authmethodscmd.Command{
@@ -165,6 +241,24 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "list",
 			}, nil
 		},
+		"auth-methods revoke-tokens": func() (cli.Command, error) {
+			return &authmethodscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "revoke-tokens",
+			}, nil
+		},
+		"auth-methods rotate-scim-token": func() (cli.Command, error) {
+			return &authmethodscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "rotate-scim-token",
+			}, nil
+		},
+		"auth-methods delete-scim-token": func() (cli.Command, error) {
+			return &authmethodscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "delete-scim-token",
+			}, nil
+		},
 		"auth-methods create": func() (cli.Command, error) {
 			return &authmethodscmd.Command{
 				Command: base.NewCommand(ui),
@@ -183,6 +277,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"auth-methods create cert": func() (cli.Command, error) {
+			return &authmethodscmd.CertCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"auth-methods update": func() (cli.Command, error) {
 			return &authmethodscmd.Command{
 				Command: base.NewCommand(ui),
@@ -201,6 +301,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"auth-methods update cert": func() (cli.Command, error) {
+			return &authmethodscmd.CertCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
 		"auth-methods change-state oidc": func() (cli.Command, error) {
 			return &authmethodscmd.OidcCommand{
 				Command: base.NewCommand(ui),
@@ -213,6 +319,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Command: base.NewCommand(ui),
 			}, nil
 		},
+		"auth-tokens create": func() (cli.Command, error) {
+			return &authtokenscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"auth-tokens read": func() (cli.Command, error) {
 			return &authtokenscmd.Command{
 				Command: base.NewCommand(ui),
@@ -362,6 +474,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"credential-libraries create vault-ssh-signed-cert": func() (cli.Command, error) {
+			return &credentiallibrariescmd.VaultSshSignedCertCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"credential-libraries update": func() (cli.Command, error) {
 			return &credentiallibrariescmd.Command{
 				Command: base.NewCommand(ui),
@@ -374,6 +492,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"credential-libraries update vault-ssh-signed-cert": func() (cli.Command, error) {
+			return &credentiallibrariescmd.VaultSshSignedCertCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
 
 		"credential-stores": func() (cli.Command, error) {
 			return &credentialstorescmd.Command{
@@ -717,6 +841,69 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"oplog": func() (cli.Command, error) {
+			return &oplogcmd.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"oplog tail": func() (cli.Command, error) {
+			return &oplogcmd.TailCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+
+		"reports": func() (cli.Command, error) {
+			return &reportscmd.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"reports list": func() (cli.Command, error) {
+			return &reportscmd.ListCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"reports run": func() (cli.Command, error) {
+			return &reportscmd.RunCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+
+		"notifications": func() (cli.Command, error) {
+			return &notificationscmd.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"notifications create": func() (cli.Command, error) {
+			return &notificationscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"notifications update": func() (cli.Command, error) {
+			return &notificationscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"notifications read": func() (cli.Command, error) {
+			return &notificationscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"notifications delete": func() (cli.Command, error) {
+			return &notificationscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "delete",
+			}, nil
+		},
+		"notifications list": func() (cli.Command, error) {
+			return &notificationscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+
 		"roles": func() (cli.Command, error) {
 			return &rolescmd.Command{
 				Command: base.NewCommand(ui),
@@ -824,7 +1011,72 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "list",
 			}, nil
 		},
+		"scopes revoke-tokens": func() (cli.Command, error) {
+			return &scopescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "revoke-tokens",
+			}, nil
+		},
+		"scopes cancel-sessions": func() (cli.Command, error) {
+			return &scopescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "cancel-sessions",
+			}, nil
+		},
 
+		"service-accounts": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"service-accounts create": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"service-accounts update": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"service-accounts read": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"service-accounts delete": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "delete",
+			}, nil
+		},
+		"service-accounts list": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+		"service-accounts add-credential": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "add-credential",
+			}, nil
+		},
+		"service-accounts remove-credential": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "remove-credential",
+			}, nil
+		},
+		"service-accounts authenticate": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "authenticate",
+			}, nil
+		},
 		"sessions": func() (cli.Command, error) {
 			return &sessionscmd.Command{
 				Command: base.NewCommand(ui),
@@ -878,12 +1130,30 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "list",
 			}, nil
 		},
+		"targets list-authorized": func() (cli.Command, error) {
+			return &targetscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list-authorized",
+			}, nil
+		},
+		"targets cancel-sessions": func() (cli.Command, error) {
+			return &targetscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "cancel-sessions",
+			}, nil
+		},
 		"targets create": func() (cli.Command, error) {
 			return &targetscmd.Command{
 				Command: base.NewCommand(ui),
 				Func:    "create",
 			}, nil
 		},
+		"targets create kubernetes": func() (cli.Command, error) {
+			return &targetscmd.KubernetesCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"targets create tcp": func() (cli.Command, error) {
 			return &targetscmd.TcpCommand{
 				Command: base.NewCommand(ui),
@@ -896,6 +1166,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"targets update kubernetes": func() (cli.Command, error) {
+			return &targetscmd.KubernetesCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
 		"targets update tcp": func() (cli.Command, error) {
 			return &targetscmd.TcpCommand{
 				Command: base.NewCommand(ui),
@@ -1028,5 +1304,23 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "remove-accounts",
 			}, nil
 		},
+		"users revoke-tokens": func() (cli.Command, error) {
+			return &userscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "revoke-tokens",
+			}, nil
+		},
+		"users read-activity": func() (cli.Command, error) {
+			return &userscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read-activity",
+			}, nil
+		},
+		"users cancel-sessions": func() (cli.Command, error) {
+			return &userscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "cancel-sessions",
+			}, nil
+		},
 	}
 }
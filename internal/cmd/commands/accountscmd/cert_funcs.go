@@ -0,0 +1,87 @@
+package accountscmd
+
+import (
+	"github.com/hashicorp/boundary/api/accounts"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+)
+
+func init() {
+	extraCertActionsFlagsMapFunc = extraCertActionsFlagsMapFuncImpl
+	extraCertFlagsFunc = extraCertFlagsFuncImpl
+	extraCertFlagsHandlingFunc = extraCertFlagsHandlingFuncImpl
+}
+
+func extraCertActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {subjectFlagName, common.DisabledFlagName},
+		"update": {common.DisabledFlagName},
+	}
+}
+
+type extraCertCmdVars struct {
+	flagSubject  string
+	flagDisabled string
+}
+
+func (c *CertCommand) extraCertHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts create cert [options] [args]",
+			"",
+			"  Create a cert-type account. Accounts are also created when a client certificate is first used to authenticate. Example:",
+			"",
+			`    $ boundary accounts create cert -subject "spiffe://example.com/prodops" -description "Cert account for ProdOps"`,
+			"",
+			"",
+		})
+
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts update cert [options] [args]",
+			"",
+			"  Update a cert-type account given its ID. Example:",
+			"",
+			`    $ boundary accounts update cert -id acctcert_1234567890 -name "devops" -description "Cert account for DevOps"`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraCertFlagsFuncImpl(c *CertCommand, set *base.FlagSets, f *base.FlagSet) {
+	f = set.NewFlagSet("Cert Account Options")
+
+	for _, name := range flagsCertMap[c.Func] {
+		switch name {
+		case subjectFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   subjectFlagName,
+				Target: &c.flagSubject,
+				Usage:  "The subject for this account, as mapped from the client certificate by the auth method.",
+			})
+		case common.DisabledFlagName:
+			common.PopulateDisabledFlag(f, &c.flagDisabled, "account")
+		}
+	}
+}
+
+func extraCertFlagsHandlingFuncImpl(c *CertCommand, _ *base.FlagSets, opts *[]accounts.Option) bool {
+	switch c.flagSubject {
+	case "null", "":
+		if c.Func == "create" {
+			c.UI.Error("Subject must be passed in via -subject")
+			return false
+		}
+	default:
+		if c.Func != "create" {
+			c.UI.Error("-subject can only be set when creating a cert account")
+			return false
+		}
+		*opts = append(*opts, accounts.WithCertAccountSubject(c.flagSubject))
+	}
+	return appendDisabledOption(c.UI, c.flagDisabled, opts)
+}
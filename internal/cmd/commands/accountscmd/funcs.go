@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/accounts"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
 	"github.com/hashicorp/go-secure-stdlib/password"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
 )
@@ -19,18 +20,26 @@ func init() {
 	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
 	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
 }
 
 type extraCmdVars struct {
 	flagPassword        string
 	flagCurrentPassword string
 	flagNewPassword     string
+	flagCancelSessions  bool
+
+	totpEnrollment     *accounts.TotpEnrollmentResult
+	revokeTokensResult *accounts.RevokeTokensResult
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
 		"change-password": {"id", "current-password", "new-password", "version"},
 		"set-password":    {"id", "password", "version"},
+		"enroll-totp":     {"id"},
+		"remove-totp":     {"id"},
+		"revoke-tokens":   {"id", common.RevokeTokensFlagName},
 	}
 }
 
@@ -42,6 +51,15 @@ func extraSynopsisFuncImpl(c *Command) string {
 	case "set-password":
 		return "Directly set the password on an account"
 
+	case "enroll-totp":
+		return "Enroll an account in TOTP multi-factor authentication"
+
+	case "remove-totp":
+		return "Remove the TOTP enrollment of an account"
+
+	case "revoke-tokens":
+		return "Revoke all auth tokens issued to an account"
+
 	default:
 		return ""
 	}
@@ -86,6 +104,42 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			"",
 		})
+	case "enroll-totp":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts enroll-totp [options] [args]",
+			"",
+			"  This command enrolls a password-type account in TOTP multi-factor authentication. The TOTP secret and recovery codes are only shown once and must be handed to the owner of the account. Example:",
+			"",
+			"    Enroll a password-type account in TOTP:",
+			"",
+			`      $ boundary accounts enroll-totp -id acctpw_1234567890`,
+			"",
+			"",
+		})
+	case "remove-totp":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts remove-totp [options] [args]",
+			"",
+			"  This command removes the TOTP enrollment of a password-type account. If the auth method of the account requires TOTP, the account is enrolled again during its next authentication. Example:",
+			"",
+			"    Remove the TOTP enrollment of a password-type account:",
+			"",
+			`      $ boundary accounts remove-totp -id acctpw_1234567890`,
+			"",
+			"",
+		})
+	case "revoke-tokens":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts revoke-tokens [options] [args]",
+			"",
+			"  This command revokes all auth tokens issued to an account. If -cancel-sessions is set, the sessions of the user of the account are canceled as well. Example:",
+			"",
+			"    Revoke the auth tokens of an account:",
+			"",
+			`      $ boundary accounts revoke-tokens -id acctpw_1234567890`,
+			"",
+			"",
+		})
 	}
 	return helpStr + c.Flags().Help()
 }
@@ -111,6 +165,8 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagNewPassword,
 				Usage:  "The new password for the account. If not specified, the command will prompt for the password to be entered in a non-echoing way.",
 			})
+		case common.RevokeTokensFlagName:
+			common.PopulateRevokeTokensFlags(f, &c.flagCancelSessions)
 		}
 	}
 }
@@ -180,10 +236,89 @@ func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError
 		return accountClient.SetPassword(c.Context, c.FlagId, c.flagPassword, version, opts...)
 	case "change-password":
 		return accountClient.ChangePassword(c.Context, c.FlagId, c.flagCurrentPassword, c.flagNewPassword, version, opts...)
+	case "enroll-totp":
+		var err error
+		c.totpEnrollment, err = accountClient.EnrollTotp(c.Context, c.FlagId, opts...)
+		return c.totpEnrollment, err
+	case "remove-totp":
+		return accountClient.RemoveTotp(c.Context, c.FlagId, opts...)
+	case "revoke-tokens":
+		var err error
+		c.revokeTokensResult, err = accountClient.RevokeTokens(c.Context, c.FlagId, c.flagCancelSessions, opts...)
+		return c.revokeTokensResult, err
 	}
 	return origResult, origError
 }
 
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "enroll-totp":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printItemTable(c.totpEnrollment))
+			c.UI.Output(printTotpEnrollment(c.totpEnrollment.TotpUrl, c.totpEnrollment.TotpSecret, c.totpEnrollment.RecoveryCodes))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.totpEnrollment); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+		}
+	case "revoke-tokens":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(common.RevokeTokensTable(c.revokeTokensResult.RevokedTokenCount, c.revokeTokensResult.CanceledSessionIds))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.revokeTokensResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// printTotpEnrollment formats the parts of a TOTP enrollment which are only
+// shown once.
+func printTotpEnrollment(url, secret string, recoveryCodes []string) string {
+	ret := []string{
+		"",
+		"TOTP enrollment:",
+		base.WrapMap(2, len("Secret")+2, map[string]interface{}{
+			"URL":    url,
+			"Secret": secret,
+		}),
+		"",
+		"  Recovery Codes:",
+		base.WrapSlice(4, recoveryCodes),
+		"",
+		"  The secret and recovery codes will not be shown again. Add the secret to",
+		"  an authenticator app and store the recovery codes in a safe place.",
+	}
+	return base.WrapForHelpText(ret)
+}
+
+// appendDisabledOption appends the option for the value of the disabled flag
+// of the create and update subcommands to opts. It reports an invalid value
+// to ui and returns false.
+func appendDisabledOption(ui interface{ Error(string) }, flag string, opts *[]accounts.Option) bool {
+	disabled, set, err := common.ParseDisabledFlag(flag)
+	switch {
+	case err != nil:
+		ui.Error(err.Error())
+		return false
+	case !set:
+	case disabled == nil:
+		*opts = append(*opts, accounts.DefaultDisabled())
+	default:
+		*opts = append(*opts, accounts.WithDisabled(*disabled))
+	}
+	return true
+}
+
 func (c *Command) printListTable(items []*accounts.Account) string {
 	if len(items) == 0 {
 		return "No accounts found"
@@ -226,6 +361,11 @@ func (c *Command) printListTable(items []*accounts.Account) string {
 				fmt.Sprintf("    Description:         %s", item.Description),
 			)
 		}
+		if item.Disabled {
+			output = append(output,
+				fmt.Sprintf("    Disabled:            %t", item.Disabled),
+			)
+		}
 		if len(item.AuthorizedActions) > 0 {
 			output = append(output,
 				"    Authorized Actions:",
@@ -264,6 +404,7 @@ func printItemTable(result api.GenericResult) string {
 	if item.Description != "" {
 		nonAttributeMap["Description"] = item.Description
 	}
+	nonAttributeMap["Disabled"] = item.Disabled
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, item.Attributes, keySubstMap)
 
@@ -309,5 +450,9 @@ func printItemTable(result api.GenericResult) string {
 }
 
 var keySubstMap = map[string]string{
-	"login_name": "Login Name",
+	"login_name":           "Login Name",
+	"failed_login_count":   "Failed Login Count",
+	"lock_expiration_time": "Lock Expiration Time",
+	"is_locked":            "Is Locked",
+	"is_totp_enrolled":     "Is TOTP Enrolled",
 }
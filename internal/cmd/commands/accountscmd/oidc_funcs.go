@@ -3,6 +3,7 @@ package accountscmd
 import (
 	"github.com/hashicorp/boundary/api/accounts"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
 )
 
 const (
@@ -18,13 +19,15 @@ func init() {
 
 func extraOidcActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {subjectFlagName, issuerFlagName},
+		"create": {subjectFlagName, issuerFlagName, common.DisabledFlagName},
+		"update": {common.DisabledFlagName},
 	}
 }
 
 type extraOidcCmdVars struct {
-	flagIssuer  string
-	flagSubject string
+	flagIssuer   string
+	flagSubject  string
+	flagDisabled string
 }
 
 func (c *OidcCommand) extraOidcHelpFunc(helpMap map[string]func() string) string {
@@ -72,6 +75,8 @@ func extraOidcFlagsFuncImpl(c *OidcCommand, set *base.FlagSets, f *base.FlagSet)
 				Target: &c.flagSubject,
 				Usage:  "The subject for this account on the OIDC provider.",
 			})
+		case common.DisabledFlagName:
+			common.PopulateDisabledFlag(f, &c.flagDisabled, "account")
 		}
 	}
 }
@@ -101,5 +106,5 @@ func extraOidcFlagsHandlingFuncImpl(c *OidcCommand, _ *base.FlagSets, opts *[]ac
 		}
 		*opts = append(*opts, accounts.WithOidcAccountIssuer(c.flagIssuer))
 	}
-	return true
+	return appendDisabledOption(c.UI, c.flagDisabled, opts)
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/boundary/api/accounts"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
 	"github.com/hashicorp/go-secure-stdlib/password"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
 )
@@ -19,14 +20,15 @@ func init() {
 
 func extraPasswordActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {"login-name", "password"},
-		"update": {"login-name"},
+		"create": {"login-name", "password", common.DisabledFlagName},
+		"update": {"login-name", common.DisabledFlagName},
 	}
 }
 
 type extraPasswordCmdVars struct {
 	flagLoginName string
 	flagPassword  string
+	flagDisabled  string
 }
 
 func (c *PasswordCommand) extraPasswordHelpFunc(helpMap map[string]func() string) string {
@@ -74,6 +76,8 @@ func extraPasswordFlagsFuncImpl(c *PasswordCommand, set *base.FlagSets, f *base.
 				Target: &c.flagPassword,
 				Usage:  "The password for the account. If not specified, the command will prompt for the password to be entered in a non-echoing way.",
 			})
+		case common.DisabledFlagName:
+			common.PopulateDisabledFlag(f, &c.flagDisabled, "account")
 		}
 	}
 }
@@ -119,5 +123,5 @@ func extraPasswordFlagsHandlingFuncImpl(c *PasswordCommand, _ *base.FlagSets, op
 		}
 	}
 
-	return true
+	return appendDisabledOption(c.UI, c.flagDisabled, opts)
 }
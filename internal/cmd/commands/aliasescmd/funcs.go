@@ -0,0 +1,249 @@
+package aliasescmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/aliases"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
+	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	printCustomActionOutput = printCustomActionOutputImpl
+}
+
+type extraCmdVars struct {
+	flagValue         string
+	flagDestinationId string
+	flagHostId        string
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {"value", "destination-id", "host-id"},
+		"update": {"value", "destination-id", "host-id"},
+	}
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary aliases [sub command] [options] [args]",
+			"",
+			"  This command allows operations on Boundary alias resources. An alias is a globally unique DNS-style name which resolves to a target and optionally one of its hosts, and can be used in place of the target ID when connecting. Example:",
+			"",
+			"    Create an alias:",
+			"",
+			`      $ boundary aliases create -scope-id global -value prod-db.payments -destination-id ttcp_1234567890`,
+			"",
+			"    Connect through an alias:",
+			"",
+			`      $ boundary connect prod-db.payments`,
+			"",
+			"  Please see the aliases subcommand help for detailed usage information.",
+		})
+
+	default:
+		helpStr = helpMap[c.Func]()
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case "value":
+			f.StringVar(&base.StringVar{
+				Name:   "value",
+				Target: &c.flagValue,
+				Usage:  "The DNS-style name of the alias, e.g. prod-db.payments. It must not be used by any other alias.",
+			})
+		case "destination-id":
+			f.StringVar(&base.StringVar{
+				Name:   "destination-id",
+				Target: &c.flagDestinationId,
+				Usage:  "The ID of the target the alias resolves to.",
+			})
+		case "host-id":
+			f.StringVar(&base.StringVar{
+				Name:   "host-id",
+				Target: &c.flagHostId,
+				Usage:  "The ID of a host of the target used when connecting through the alias without a host ID.",
+			})
+		}
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]aliases.Option) bool {
+	switch c.Func {
+	case "create", "update":
+	default:
+		return true
+	}
+
+	switch c.flagValue {
+	case "":
+		if c.Func == "create" {
+			c.UI.Error("No value supplied via -value")
+			return false
+		}
+	default:
+		*opts = append(*opts, aliases.WithValue(c.flagValue))
+	}
+
+	switch c.flagDestinationId {
+	case "":
+		if c.Func == "create" {
+			c.UI.Error("No destination ID supplied via -destination-id")
+			return false
+		}
+	default:
+		*opts = append(*opts, aliases.WithDestinationId(c.flagDestinationId))
+	}
+
+	switch c.flagHostId {
+	case "":
+	case "null":
+		*opts = append(*opts, aliases.DefaultHostId())
+	default:
+		*opts = append(*opts, aliases.WithHostId(c.flagHostId))
+	}
+
+	return true
+}
+
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	return false, nil
+}
+
+func (c *Command) printListTable(items []*aliases.Alias) string {
+	if len(items) == 0 {
+		return "No aliases found"
+	}
+	var output []string
+	output = []string{
+		"",
+		"Alias information:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		if item.Id != "" {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", item.Id),
+			)
+		} else {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", "(not available)"),
+			)
+		}
+		if c.FlagRecursive && item.ScopeId != "" {
+			output = append(output,
+				fmt.Sprintf("    Scope ID:            %s", item.ScopeId),
+			)
+		}
+		if item.Version > 0 {
+			output = append(output,
+				fmt.Sprintf("    Version:             %d", item.Version),
+			)
+		}
+		if item.Name != "" {
+			output = append(output,
+				fmt.Sprintf("    Name:                %s", item.Name),
+			)
+		}
+		if item.Description != "" {
+			output = append(output,
+				fmt.Sprintf("    Description:         %s", item.Description),
+			)
+		}
+		if item.Value != "" {
+			output = append(output,
+				fmt.Sprintf("    Value:               %s", item.Value),
+			)
+		}
+		if item.DestinationId != "" {
+			output = append(output,
+				fmt.Sprintf("    Destination ID:      %s", item.DestinationId),
+			)
+		}
+		if item.HostId != "" {
+			output = append(output,
+				fmt.Sprintf("    Host ID:             %s", item.HostId),
+			)
+		}
+		if len(item.AuthorizedActions) > 0 {
+			output = append(output,
+				"    Authorized Actions:",
+				base.WrapSlice(6, item.AuthorizedActions),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+func printItemTable(result api.GenericResult) string {
+	item := result.GetItem().(*aliases.Alias)
+	nonAttributeMap := map[string]interface{}{}
+	if item.Id != "" {
+		nonAttributeMap["ID"] = item.Id
+	}
+	if item.Version != 0 {
+		nonAttributeMap["Version"] = item.Version
+	}
+	if !item.CreatedTime.IsZero() {
+		nonAttributeMap["Created Time"] = item.CreatedTime.Local().Format(time.RFC1123)
+	}
+	if !item.UpdatedTime.IsZero() {
+		nonAttributeMap["Updated Time"] = item.UpdatedTime.Local().Format(time.RFC1123)
+	}
+	if item.Name != "" {
+		nonAttributeMap["Name"] = item.Name
+	}
+	if item.Description != "" {
+		nonAttributeMap["Description"] = item.Description
+	}
+	if item.Value != "" {
+		nonAttributeMap["Value"] = item.Value
+	}
+	if item.DestinationId != "" {
+		nonAttributeMap["Destination ID"] = item.DestinationId
+	}
+	if item.HostId != "" {
+		nonAttributeMap["Host ID"] = item.HostId
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Alias information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if item.Scope != nil {
+		ret = append(ret,
+			"",
+			"  Scope:",
+			base.ScopeInfoForOutput(item.Scope, maxLength),
+		)
+	}
+
+	if len(item.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, item.AuthorizedActions),
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
@@ -26,6 +26,10 @@ func (c *Command) Help() string {
 		"",
 		"      $ boundary authenticate password -auth-method-id ampw_1234567890 -login-name foo -password \"bar\"",
 		"",
+		"    Authenticate with a TLS client certificate:",
+		"",
+		"      $ boundary authenticate cert -auth-method-id amcert_1234567890 -client-cert client.pem -client-key client-key.pem",
+		"",
 		"  Please see the auth method subcommand help for detailed usage information.",
 	})
 }
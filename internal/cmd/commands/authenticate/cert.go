@@ -0,0 +1,95 @@
+package authenticate
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/authmethods"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*CertCommand)(nil)
+	_ cli.CommandAutocomplete = (*CertCommand)(nil)
+)
+
+type CertCommand struct {
+	*base.Command
+}
+
+func (c *CertCommand) Synopsis() string {
+	return wordwrap.WrapString("Invoke the cert auth method to authenticate with Boundary", base.TermWidth)
+}
+
+func (c *CertCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary authenticate cert [options] [args]",
+		"",
+		"  Invoke the cert auth method to authenticate the Boundary CLI using a TLS client certificate:",
+		"",
+		`    $ boundary authenticate cert -auth-method-id amcert_1234567890 -client-cert client.pem -client-key client-key.pem`,
+		"",
+		"  The API listener of the controller must be configured to request client certificates.",
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *CertCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "auth-method-id",
+		EnvVar: "BOUNDARY_AUTH_METHOD_ID",
+		Target: &c.FlagAuthMethodId,
+		Usage:  "The auth-method resource to use for the operation",
+	})
+
+	return set
+}
+
+func (c *CertCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *CertCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *CertCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	if c.FlagAuthMethodId == "" {
+		c.PrintCliError(errors.New("Auth method ID must be provided via -auth-method-id"))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client(base.WithNoTokenScope(), base.WithNoTokenValue())
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	amClient := authmethods.NewClient(client)
+	result, err := amClient.Authenticate(c.Context, c.FlagAuthMethodId, "login", nil)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when performing authentication")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to perform authentication: %w", err))
+		return base.CommandCliError
+	}
+
+	return saveAndOrPrintToken(c.Command, result)
+}
@@ -1,6 +1,7 @@
 package authenticate
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -21,15 +22,19 @@ var (
 )
 
 var (
-	envPassword  = "BOUNDARY_AUTHENTICATE_PASSWORD_PASSWORD"
-	envLoginName = "BOUNDARY_AUTHENTICATE_PASSWORD_LOGIN_NAME"
+	envPassword    = "BOUNDARY_AUTHENTICATE_PASSWORD_PASSWORD"
+	envNewPassword = "BOUNDARY_AUTHENTICATE_PASSWORD_NEW_PASSWORD"
+	envLoginName   = "BOUNDARY_AUTHENTICATE_PASSWORD_LOGIN_NAME"
+	envTotpCode    = "BOUNDARY_AUTHENTICATE_PASSWORD_TOTP_CODE"
 )
 
 type PasswordCommand struct {
 	*base.Command
 
-	flagLoginName string
-	flagPassword  string
+	flagLoginName   string
+	flagPassword    string
+	flagNewPassword string
+	flagTotpCode    string
 }
 
 func (c *PasswordCommand) Synopsis() string {
@@ -66,6 +71,20 @@ func (c *PasswordCommand) Flags() *base.FlagSets {
 		Usage:  "The password associated with the login name",
 	})
 
+	f.StringVar(&base.StringVar{
+		Name:   "new-password",
+		Target: &c.flagNewPassword,
+		EnvVar: envNewPassword,
+		Usage:  "A new password replacing the password associated with the login name if it has expired",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "totp-code",
+		Target: &c.flagTotpCode,
+		EnvVar: envTotpCode,
+		Usage:  "The TOTP code, or a recovery code, if the account must provide one. If not specified and a code is required, the command will prompt for the code to be entered",
+	})
+
 	f.StringVar(&base.StringVar{
 		Name:   "auth-method-id",
 		EnvVar: "BOUNDARY_AUTH_METHOD_ID",
@@ -133,11 +152,15 @@ func (c *PasswordCommand) Run(args []string) int {
 		return base.CommandCliError
 	}
 
-	result, err := authmethods.NewClient(client).Authenticate(c.Context, c.FlagAuthMethodId, "login",
-		map[string]interface{}{
-			"login_name": c.flagLoginName,
-			"password":   c.flagPassword,
-		})
+	attrs := map[string]interface{}{
+		"login_name": c.flagLoginName,
+		"password":   c.flagPassword,
+	}
+	if c.flagNewPassword != "" {
+		attrs["new_password"] = c.flagNewPassword
+	}
+	amClient := authmethods.NewClient(client)
+	result, err := amClient.Authenticate(c.Context, c.FlagAuthMethodId, "login", attrs)
 	if err != nil {
 		if apiErr := api.AsServerError(err); apiErr != nil {
 			c.PrintApiError(apiErr, "Error from controller when performing authentication")
@@ -147,5 +170,68 @@ func (c *PasswordCommand) Run(args []string) int {
 		return base.CommandCliError
 	}
 
+	if _, ok := result.Attributes["challenge_token"]; ok {
+		challenge := new(authmethods.PasswordAuthMethodAuthenticateMfaChallengeResponse)
+		if err := json.Unmarshal(result.GetRawAttributes(), challenge); err != nil {
+			c.PrintCliError(fmt.Errorf("Error trying to decode authenticate challenge response: %w", err))
+			return base.CommandCliError
+		}
+		if ret := c.answerTotpChallenge(challenge); ret != base.CommandSuccess {
+			return ret
+		}
+		result, err = amClient.Authenticate(c.Context, c.FlagAuthMethodId, "totp", map[string]interface{}{
+			"challenge_token": challenge.ChallengeToken,
+			"code":            c.flagTotpCode,
+		})
+		if err != nil {
+			if apiErr := api.AsServerError(err); apiErr != nil {
+				c.PrintApiError(apiErr, "Error from controller when performing authentication")
+				return base.CommandApiError
+			}
+			c.PrintCliError(fmt.Errorf("Error trying to perform authentication: %w", err))
+			return base.CommandCliError
+		}
+	}
+
 	return saveAndOrPrintToken(c.Command, result)
 }
+
+// answerTotpChallenge shows the TOTP enrollment of challenge, if any, and
+// reads the TOTP code if it was not provided as a flag.
+func (c *PasswordCommand) answerTotpChallenge(challenge *authmethods.PasswordAuthMethodAuthenticateMfaChallengeResponse) int {
+	if challenge.TotpSecret != "" {
+		enrollment := base.WrapForHelpText([]string{
+			"",
+			"The account has been enrolled in TOTP multi-factor authentication. Add",
+			"the secret to an authenticator app and store the recovery codes in a safe",
+			"place. They will not be shown again.",
+			"",
+			fmt.Sprintf("  URL:     %s", challenge.TotpUrl),
+			fmt.Sprintf("  Secret:  %s", challenge.TotpSecret),
+			"",
+			"  Recovery Codes:",
+			base.WrapSlice(4, challenge.RecoveryCodes),
+			"",
+		})
+		// Keep JSON output parseable by writing the enrollment to stderr.
+		if base.Format(c.UI) == "table" {
+			c.UI.Output(enrollment)
+		} else {
+			c.UI.Warn(enrollment)
+		}
+		// A code generated before the enrollment existed can not be valid.
+		c.flagTotpCode = ""
+	}
+
+	if c.flagTotpCode == "" {
+		fmt.Print("TOTP code is not set as flag or in env, please enter it now (will be hidden): ")
+		value, err := password.Read(os.Stdin)
+		fmt.Print("\n")
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("An error occurred attempting to read the TOTP code. The raw error message is shown below but usually this is because you attempted to pipe a value into the command or you are executing outside of a terminal (TTY). The raw error was:\n\n%s", err.Error()))
+			return base.CommandUserError
+		}
+		c.flagTotpCode = strings.TrimSpace(value)
+	}
+	return base.CommandSuccess
+}
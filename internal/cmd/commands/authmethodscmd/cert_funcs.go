@@ -0,0 +1,101 @@
+package authmethodscmd
+
+import (
+	"github.com/hashicorp/boundary/api/authmethods"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func init() {
+	extraCertActionsFlagsMapFunc = extraCertActionsFlagsMapFuncImpl
+	extraCertFlagsFunc = extraCertFlagsFuncImpl
+	extraCertFlagsHandlingFunc = extraCertFlagHandlingFuncImpl
+}
+
+type extraCertCmdVars struct {
+	flagCaCertificates       []string
+	flagAccountAttributeMaps []string
+}
+
+const (
+	caCertificateFlagName       = "ca-certificate"
+	accountAttributeMapFlagName = "account-attribute-map"
+)
+
+func extraCertActionsFlagsMapFuncImpl() map[string][]string {
+	flags := []string{
+		caCertificateFlagName,
+		accountAttributeMapFlagName,
+	}
+	return map[string][]string{
+		"create": flags,
+		"update": flags,
+	}
+}
+
+func (c *CertCommand) extraCertHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary auth-methods create cert [options] [args]",
+			"",
+			"  Create a cert-type auth method. Example:",
+			"",
+			`    $ boundary auth-methods create cert -name prodops -ca-certificate "$(cat ca.pem)" -account-attribute-map "uri_san=subject"`,
+			"",
+			"",
+		})
+
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary auth-methods update cert [options] [args]",
+			"",
+			"  Update a cert-type auth method given its ID. Example:",
+			"",
+			`    $ boundary auth-methods update cert -id amcert_1234567890 -name "devops" -description "Cert auth-method for DevOps"`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraCertFlagsFuncImpl(c *CertCommand, set *base.FlagSets, f *base.FlagSet) {
+	f = set.NewFlagSet("Cert Auth Method Options")
+
+	for _, name := range flagsCertMap[c.Func] {
+		switch name {
+		case caCertificateFlagName:
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   caCertificateFlagName,
+				Target: &c.flagCaCertificates,
+				Usage:  "PEM-encoded X.509 CA certificate trusted to issue client certificates. May be specified multiple times.",
+			})
+		case accountAttributeMapFlagName:
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   accountAttributeMapFlagName,
+				Target: &c.flagAccountAttributeMaps,
+				Usage:  `The optional maps from certificate attributes to the account fields subject, name and email. These maps are represented as key=value where the key is one of "cn", "dns_san", "email_san" or "uri_san" and the value is the account field. For example "email_san=subject". May be specified multiple times for different account fields.`,
+			})
+		}
+	}
+}
+
+func extraCertFlagHandlingFuncImpl(c *CertCommand, _ *base.FlagSets, opts *[]authmethods.Option) bool {
+	switch {
+	case len(c.flagCaCertificates) == 0:
+	case len(c.flagCaCertificates) == 1 && c.flagCaCertificates[0] == "null":
+		*opts = append(*opts, authmethods.DefaultCertAuthMethodCaCertificates())
+	default:
+		*opts = append(*opts, authmethods.WithCertAuthMethodCaCertificates(c.flagCaCertificates))
+	}
+	switch {
+	case len(c.flagAccountAttributeMaps) == 0:
+	case len(c.flagAccountAttributeMaps) == 1 && c.flagAccountAttributeMaps[0] == "null":
+		*opts = append(*opts, authmethods.DefaultCertAuthMethodAccountAttributeMaps())
+	default:
+		*opts = append(*opts, authmethods.WithCertAuthMethodAccountAttributeMaps(c.flagAccountAttributeMaps))
+	}
+
+	return true
+}
@@ -9,10 +9,46 @@ import (
 	"github.com/hashicorp/boundary/api/authmethods"
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
 )
 
 func init() {
-	extraOidcSynopsisFunc = extraSynopsisFuncImpl
+	extraOidcSynopsisFunc = extraOidcSynopsisFuncImpl
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
+}
+
+type extraCmdVars struct {
+	flagCancelSessions bool
+
+	revokeTokensResult    *authmethods.RevokeTokensResult
+	rotateScimTokenResult *authmethods.RotateScimTokenResult
+	deleteScimTokenResult *authmethods.DeleteScimTokenResult
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"revoke-tokens":     {"id", common.RevokeTokensFlagName},
+		"rotate-scim-token": {"id"},
+		"delete-scim-token": {"id"},
+	}
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "revoke-tokens":
+		return "Revoke all auth tokens issued by an auth method"
+	case "rotate-scim-token":
+		return "Generate a new SCIM provisioning token for an auth method"
+	case "delete-scim-token":
+		return "Delete the SCIM provisioning token of an auth method"
+
+	default:
+		return ""
+	}
 }
 
 func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
@@ -54,11 +90,127 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
+	case "revoke-tokens":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary auth-methods revoke-tokens [options] [args]",
+			"",
+			"  This command revokes all auth tokens issued by an auth method. If -cancel-sessions is set, the sessions of the users who authenticated with the auth method are canceled as well. Example:",
+			"",
+			"    Revoke the auth tokens of an auth method:",
+			"",
+			`      $ boundary auth-methods revoke-tokens -id ampw_1234567890`,
+			"",
+			"",
+		})
+	case "rotate-scim-token":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary auth-methods rotate-scim-token [options] [args]",
+			"",
+			"  This command generates a new SCIM provisioning token for an auth method. Identity providers use the token to provision users and groups through the SCIM endpoint of the controller. Any previous token of the auth method stops working, and the new token is only shown once. Example:",
+			"",
+			"    Rotate the SCIM token of an auth method:",
+			"",
+			`      $ boundary auth-methods rotate-scim-token -id amoidc_1234567890`,
+			"",
+			"",
+		})
+	case "delete-scim-token":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary auth-methods delete-scim-token [options] [args]",
+			"",
+			"  This command deletes the SCIM provisioning token of an auth method, disabling SCIM provisioning through it. Users and groups already provisioned are kept. Example:",
+			"",
+			"    Delete the SCIM token of an auth method:",
+			"",
+			`      $ boundary auth-methods delete-scim-token -id amoidc_1234567890`,
+			"",
+			"",
+		})
 	}
 	return helpStr + c.Flags().Help()
 }
 
-func extraSynopsisFuncImpl(c *OidcCommand) string {
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case common.RevokeTokensFlagName:
+			common.PopulateRevokeTokensFlags(f, &c.flagCancelSessions)
+		}
+	}
+}
+
+func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError error, authmethodClient *authmethods.Client, version uint32, opts []authmethods.Option) (api.GenericResult, error) {
+	switch c.Func {
+	case "revoke-tokens":
+		var err error
+		c.revokeTokensResult, err = authmethodClient.RevokeTokens(c.Context, c.FlagId, c.flagCancelSessions, opts...)
+		return c.revokeTokensResult, err
+	case "rotate-scim-token":
+		var err error
+		c.rotateScimTokenResult, err = authmethodClient.RotateScimToken(c.Context, c.FlagId, opts...)
+		return c.rotateScimTokenResult, err
+	case "delete-scim-token":
+		var err error
+		c.deleteScimTokenResult, err = authmethodClient.DeleteScimToken(c.Context, c.FlagId, opts...)
+		return c.deleteScimTokenResult, err
+	}
+	return origResult, origError
+}
+
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "revoke-tokens":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(common.RevokeTokensTable(c.revokeTokensResult.RevokedTokenCount, c.revokeTokensResult.CanceledSessionIds))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.revokeTokensResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+		}
+
+	case "rotate-scim-token":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(base.WrapForHelpText([]string{
+				"",
+				"SCIM token information:",
+				base.WrapMap(2, 0, map[string]interface{}{
+					"SCIM Token": c.rotateScimTokenResult.ScimToken,
+					"SCIM Path":  c.rotateScimTokenResult.ScimPath,
+				}),
+				"",
+				"  Store the token now, it cannot be retrieved again.",
+			}))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.rotateScimTokenResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+		}
+
+	case "delete-scim-token":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output("The SCIM token was deleted successfully.")
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.deleteScimTokenResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func extraOidcSynopsisFuncImpl(c *OidcCommand) string {
 	switch c.Func {
 	case "change-state":
 		return "Change the active state of an auth method"
@@ -217,6 +369,17 @@ func printItemTable(result api.GenericResult) string {
 }
 
 var keySubstMap = map[string]string{
-	"min_login_name_length": "Minimum Login Name Length",
-	"min_password_length":   "Minimum Password Length",
+	"min_login_name_length":      "Minimum Login Name Length",
+	"min_password_length":        "Minimum Password Length",
+	"password_require_lowercase": "Password Requires Lowercase",
+	"password_require_uppercase": "Password Requires Uppercase",
+	"password_require_digit":     "Password Requires Digit",
+	"password_require_symbol":    "Password Requires Symbol",
+	"deny_common_passwords":      "Deny Common Passwords",
+	"lockout_threshold":          "Lockout Threshold",
+	"lockout_window_seconds":     "Lockout Window Seconds",
+	"lockout_duration_seconds":   "Lockout Duration Seconds",
+	"password_history_count":     "Password History Count",
+	"max_password_age_days":      "Maximum Password Age Days",
+	"totp_mfa":                   "TOTP MFA",
 }
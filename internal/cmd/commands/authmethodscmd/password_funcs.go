@@ -15,14 +15,40 @@ func init() {
 }
 
 type extraPasswordCmdVars struct {
-	flagMinLoginNameLength string
-	flagMinPasswordLength  string
+	flagMinLoginNameLength       string
+	flagMinPasswordLength        string
+	flagPasswordRequireLowercase string
+	flagPasswordRequireUppercase string
+	flagPasswordRequireDigit     string
+	flagPasswordRequireSymbol    string
+	flagDenyCommonPasswords      string
+	flagLockoutThreshold         string
+	flagLockoutWindowSeconds     string
+	flagLockoutDurationSeconds   string
+	flagPasswordHistoryCount     string
+	flagMaxPasswordAgeDays       string
+	flagTotpMfa                  string
 }
 
 func extraPasswordActionsFlagsMapFuncImpl() map[string][]string {
+	flags := []string{
+		"min-login-name-length",
+		"min-password-length",
+		"password-require-lowercase",
+		"password-require-uppercase",
+		"password-require-digit",
+		"password-require-symbol",
+		"deny-common-passwords",
+		"lockout-threshold",
+		"lockout-window-seconds",
+		"lockout-duration-seconds",
+		"password-history-count",
+		"max-password-age-days",
+		"totp-mfa",
+	}
 	return map[string][]string{
-		"create": {"min-login-name-length", "min-password-length"},
-		"update": {"min-login-name-length", "min-password-length"},
+		"create": flags,
+		"update": flags,
 	}
 }
 
@@ -71,6 +97,72 @@ func extraPasswordFlagsFuncImpl(c *PasswordCommand, set *base.FlagSets, f *base.
 				Target: &c.flagMinPasswordLength,
 				Usage:  "The minimum length of passwords",
 			})
+		case "password-require-lowercase":
+			f.StringVar(&base.StringVar{
+				Name:   "password-require-lowercase",
+				Target: &c.flagPasswordRequireLowercase,
+				Usage:  "If true, passwords must contain a lowercase letter",
+			})
+		case "password-require-uppercase":
+			f.StringVar(&base.StringVar{
+				Name:   "password-require-uppercase",
+				Target: &c.flagPasswordRequireUppercase,
+				Usage:  "If true, passwords must contain an uppercase letter",
+			})
+		case "password-require-digit":
+			f.StringVar(&base.StringVar{
+				Name:   "password-require-digit",
+				Target: &c.flagPasswordRequireDigit,
+				Usage:  "If true, passwords must contain a digit",
+			})
+		case "password-require-symbol":
+			f.StringVar(&base.StringVar{
+				Name:   "password-require-symbol",
+				Target: &c.flagPasswordRequireSymbol,
+				Usage:  "If true, passwords must contain a character which is not a letter or a digit",
+			})
+		case "deny-common-passwords":
+			f.StringVar(&base.StringVar{
+				Name:   "deny-common-passwords",
+				Target: &c.flagDenyCommonPasswords,
+				Usage:  "If true, commonly used passwords are rejected",
+			})
+		case "lockout-threshold":
+			f.StringVar(&base.StringVar{
+				Name:   "lockout-threshold",
+				Target: &c.flagLockoutThreshold,
+				Usage:  "The number of failed authentication attempts after which an account is locked. If 0, accounts are never locked",
+			})
+		case "lockout-window-seconds":
+			f.StringVar(&base.StringVar{
+				Name:   "lockout-window-seconds",
+				Target: &c.flagLockoutWindowSeconds,
+				Usage:  "The period in seconds in which failed authentication attempts are counted. If 0, they are counted until the next successful authentication",
+			})
+		case "lockout-duration-seconds":
+			f.StringVar(&base.StringVar{
+				Name:   "lockout-duration-seconds",
+				Target: &c.flagLockoutDurationSeconds,
+				Usage:  "The number of seconds a locked account can not authenticate",
+			})
+		case "password-history-count":
+			f.StringVar(&base.StringVar{
+				Name:   "password-history-count",
+				Target: &c.flagPasswordHistoryCount,
+				Usage:  "The number of most recent passwords, including the current one, which may not be reused",
+			})
+		case "max-password-age-days":
+			f.StringVar(&base.StringVar{
+				Name:   "max-password-age-days",
+				Target: &c.flagMaxPasswordAgeDays,
+				Usage:  "The number of days after which a password must be changed when authenticating. If 0, passwords do not expire",
+			})
+		case "totp-mfa":
+			f.StringVar(&base.StringVar{
+				Name:   "totp-mfa",
+				Target: &c.flagTotpMfa,
+				Usage:  `When accounts must provide a TOTP code in addition to their password. One of "disabled", "optional" (only accounts enrolled in TOTP) or "required" (accounts not enrolled are enrolled during their next authentication)`,
+			})
 		}
 	}
 }
@@ -109,6 +201,62 @@ func extraPasswordFlagHandlingFuncImpl(c *PasswordCommand, _ *base.FlagSets, opt
 		addAttribute("min_password_length", uint32(length))
 	}
 
+	for _, b := range []struct {
+		name string
+		val  string
+	}{
+		{"password_require_lowercase", c.flagPasswordRequireLowercase},
+		{"password_require_uppercase", c.flagPasswordRequireUppercase},
+		{"password_require_digit", c.flagPasswordRequireDigit},
+		{"password_require_symbol", c.flagPasswordRequireSymbol},
+		{"deny_common_passwords", c.flagDenyCommonPasswords},
+	} {
+		switch b.val {
+		case "":
+		case "null":
+			addAttribute(b.name, nil)
+		default:
+			v, err := strconv.ParseBool(b.val)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", b.val, err))
+				return false
+			}
+			addAttribute(b.name, v)
+		}
+	}
+
+	for _, u := range []struct {
+		name string
+		val  string
+	}{
+		{"lockout_threshold", c.flagLockoutThreshold},
+		{"lockout_window_seconds", c.flagLockoutWindowSeconds},
+		{"lockout_duration_seconds", c.flagLockoutDurationSeconds},
+		{"password_history_count", c.flagPasswordHistoryCount},
+		{"max_password_age_days", c.flagMaxPasswordAgeDays},
+	} {
+		switch u.val {
+		case "":
+		case "null":
+			addAttribute(u.name, nil)
+		default:
+			v, err := strconv.ParseUint(u.val, 10, 32)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", u.val, err))
+				return false
+			}
+			addAttribute(u.name, uint32(v))
+		}
+	}
+
+	switch c.flagTotpMfa {
+	case "":
+	case "null":
+		addAttribute("totp_mfa", nil)
+	default:
+		addAttribute("totp_mfa", c.flagTotpMfa)
+	}
+
 	if attributes != nil {
 		*opts = append(*opts, authmethods.WithAttributes(attributes))
 	}
@@ -35,6 +35,8 @@ type Command struct {
 	Func string
 
 	plural string
+
+	extraCmdVars
 }
 
 func (c *Command) AutocompleteArgs() complete.Predictor {
@@ -65,6 +67,9 @@ func (c *Command) Help() string {
 
 	switch c.Func {
 
+	case "create":
+		helpStr = helpMap[c.Func]() + c.Flags().Help()
+
 	case "read":
 		helpStr = helpMap[c.Func]() + c.Flags().Help()
 
@@ -87,6 +92,8 @@ func (c *Command) Help() string {
 
 var flagsMap = map[string][]string{
 
+	"create": {"scope-id", "description"},
+
 	"read": {"id"},
 
 	"delete": {"id"},
@@ -133,11 +140,19 @@ func (c *Command) Run(args []string) int {
 
 	if strutil.StrListContains(flagsMap[c.Func], "scope-id") {
 		switch c.Func {
+
+		case "create":
+			if c.FlagScopeId == "" {
+				c.PrintCliError(errors.New("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID"))
+				return base.CommandUserError
+			}
+
 		case "list":
 			if c.FlagScopeId == "" {
 				c.PrintCliError(errors.New("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID"))
 				return base.CommandUserError
 			}
+
 		}
 	}
 
@@ -148,6 +163,14 @@ func (c *Command) Run(args []string) int {
 	}
 	authtokensClient := authtokens.NewClient(client)
 
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, authtokens.DefaultDescription())
+	default:
+		opts = append(opts, authtokens.WithDescription(c.FlagDescription))
+	}
+
 	switch c.FlagRecursive {
 	case true:
 		opts = append(opts, authtokens.WithRecursive(true))
@@ -169,6 +192,9 @@ func (c *Command) Run(args []string) int {
 
 	switch c.Func {
 
+	case "create":
+		result, err = authtokensClient.Create(c.Context, c.FlagScopeId, opts...)
+
 	case "read":
 		result, err = authtokensClient.Read(c.Context, c.FlagId, opts...)
 
@@ -9,15 +9,75 @@ import (
 	"github.com/hashicorp/boundary/api/authtokens"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
+	"github.com/posener/complete"
 )
 
 const selfFlag = "self"
 
 func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
 }
 
-func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, _ *[]authtokens.Option) bool {
+type extraCmdVars struct {
+	flagGrants        []string
+	flagGrantScopeIds []string
+	flagTtl           time.Duration
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {"grant", "grant-scope-id", "ttl"},
+	}
+}
+
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case "grant":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "grant",
+				Target: &c.flagGrants,
+				Usage:  `The grants the auth token is restricted to. May be specified multiple times. Defaults to "id=*;type=*;actions=*", which restricts the auth token only to the grant scopes.`,
+			})
+		case "grant-scope-id":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "grant-scope-id",
+				Target: &c.flagGrantScopeIds,
+				Usage:  "The scope IDs in which the grants apply. Must be specified at least once and may be specified multiple times.",
+			})
+		case "ttl":
+			f.DurationVar(&base.DurationVar{
+				Name:       "ttl",
+				Target:     &c.flagTtl,
+				Completion: complete.PredictAnything,
+				Usage:      "The time to live of the auth token. It never outlives the auth token used to create it.",
+			})
+		}
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]authtokens.Option) bool {
+	if c.Func == "create" {
+		if len(c.flagGrantScopeIds) == 0 {
+			c.PrintCliError(errors.New("No grant scope IDs supplied via -grant-scope-id"))
+			return false
+		}
+		*opts = append(*opts, authtokens.WithGrantScopeIds(c.flagGrantScopeIds))
+		if len(c.flagGrants) > 0 {
+			*opts = append(*opts, authtokens.WithGrantStrings(c.flagGrants))
+		}
+		switch {
+		case c.flagTtl < 0:
+			c.PrintCliError(errors.New("The time to live supplied via -ttl must not be negative"))
+			return false
+		case c.flagTtl > 0:
+			*opts = append(*opts, authtokens.WithTimeToLiveSeconds(uint32(c.flagTtl.Seconds())))
+		}
+		return true
+	}
+
 	if c.Func != "delete" && c.Func != "read" {
 		if strutil.StrListContains(flagsMap[c.Func], "id") && c.FlagId == "" {
 			c.PrintCliError(errors.New("ID is required but not passed in via -id"))
@@ -110,6 +170,16 @@ func (c *Command) printListTable(items []*authtokens.AuthToken) string {
 				fmt.Sprintf("    User ID:                     %s", t.UserId),
 			)
 		}
+		if t.ParentId != "" {
+			output = append(output,
+				fmt.Sprintf("    Parent ID:                   %s", t.ParentId),
+			)
+		}
+		if t.Description != "" {
+			output = append(output,
+				fmt.Sprintf("    Description:                 %s", t.Description),
+			)
+		}
 		if len(t.AuthorizedActions) > 0 {
 			output = append(output,
 				"    Authorized Actions:",
@@ -132,6 +202,15 @@ func printItemTable(result api.GenericResult) string {
 		"Expiration Time":            item.ExpirationTime.Local().Format(time.RFC1123),
 		"Approximate Last Used Time": item.ApproximateLastUsedTime.Local().Format(time.RFC1123),
 	}
+	if item.Description != "" {
+		nonAttributeMap["Description"] = item.Description
+	}
+	if item.ParentId != "" {
+		nonAttributeMap["Parent ID"] = item.ParentId
+	}
+	if item.Token != "" {
+		nonAttributeMap["Token"] = item.Token
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
 
@@ -144,6 +223,22 @@ func printItemTable(result api.GenericResult) string {
 		base.ScopeInfoForOutput(item.Scope, maxLength),
 	}
 
+	if len(item.GrantScopeIds) > 0 {
+		ret = append(ret,
+			"",
+			"  Grant Scope IDs:",
+			base.WrapSlice(4, item.GrantScopeIds),
+		)
+	}
+
+	if len(item.GrantStrings) > 0 {
+		ret = append(ret,
+			"",
+			"  Grants:",
+			base.WrapSlice(4, item.GrantStrings),
+		)
+	}
+
 	if len(item.AuthorizedActions) > 0 {
 		ret = append(ret,
 			"",
@@ -20,10 +20,12 @@ import (
 	"time"
 
 	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/aliases"
 	"github.com/hashicorp/boundary/api/targets"
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/proxy"
+	"github.com/hashicorp/boundary/internal/types/scope"
 	targetspb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
 	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/go-secure-stdlib/base62"
@@ -37,7 +39,16 @@ import (
 	"nhooyr.io/websocket/wspb"
 )
 
-const sessionCancelTimeout = 10 * time.Second
+const (
+	sessionCancelTimeout = 10 * time.Second
+
+	// aliasCompletionTimeout bounds listing aliases for shell completion so
+	// an unreachable controller doesn't hang the shell.
+	aliasCompletionTimeout = 2 * time.Second
+)
+
+// errSessionUnauthorized is returned when a worker rejects the session.
+var errSessionUnauthorized = errors.New("Session credentials were not accepted, or session is unauthorized")
 
 type SessionInfo struct {
 	Address         string                       `json:"address"`
@@ -101,8 +112,10 @@ type Command struct {
 	listenerAddr       *net.TCPAddr
 	connsLeftCh        chan int32
 	connectionsLeft    *atomic.Int32
+	workerIdx          *atomic.Int32
 	expiration         time.Time
 	execCmdReturnValue *atomic.Int32
+	terminationReason  *atomic.String
 	proxyCtx           context.Context
 	proxyCancel        context.CancelFunc
 	outputJsonErrors   bool
@@ -133,7 +146,7 @@ func (c *Command) Help() string {
 	switch c.Func {
 	case "connect":
 		return base.WrapForHelpText([]string{
-			"Usage: boundary connect [options] [args]",
+			"Usage: boundary connect [options] [target]",
 			"",
 			`  This command performs a target authorization (or consumes an existing authorization token) and launches a proxied connection.`,
 			"",
@@ -141,12 +154,16 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary connect -target-id ttcp_1234567890"`,
 			"",
+			"  The target can also be given as an argument, either by its ID or by one of its aliases:",
+			"",
+			`      $ boundary connect prod-db.payments`,
+			"",
 			"",
 		}) + c.Flags().Help()
 
 	default:
 		return base.WrapForHelpText([]string{
-			fmt.Sprintf("Usage: boundary connect %s [options] [args]", c.Func),
+			fmt.Sprintf("Usage: boundary connect %s [options] [target]", c.Func),
 			"",
 			fmt.Sprintf(`  This command performs a target authorization (or consumes an existing authorization token) and launches a proxied %s connection.`, c.Func),
 			"",
@@ -174,7 +191,7 @@ func (c *Command) Flags() *base.FlagSets {
 	f.StringVar(&base.StringVar{
 		Name:   "target-id",
 		Target: &c.flagTargetId,
-		Usage:  "The ID of the target to authorize against. Cannot be used with -authz-token.",
+		Usage:  "The ID or alias of the target to authorize against. Cannot be used with -authz-token. The target can also be given as the only argument.",
 	})
 
 	f.StringVar(&base.StringVar{
@@ -251,7 +268,29 @@ func (c *Command) Flags() *base.FlagSets {
 }
 
 func (c *Command) AutocompleteArgs() complete.Predictor {
-	return complete.PredictAnything
+	return complete.PredictFunc(c.predictAliases)
+}
+
+// predictAliases returns the values of the aliases the user can list in any
+// scope, so they can be completed as the target argument.
+func (c *Command) predictAliases(complete.Args) []string {
+	client, err := c.Client()
+	if err != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(c.Context, aliasCompletionTimeout)
+	defer cancel()
+	result, err := aliases.NewClient(client).List(ctx, scope.Global.String(), aliases.WithRecursive(true))
+	if err != nil {
+		return nil
+	}
+	values := make([]string, 0, len(result.Items))
+	for _, a := range result.Items {
+		if a.Value != "" {
+			values = append(values, a.Value)
+		}
+	}
+	return values
 }
 
 func (c *Command) AutocompleteFlags() complete.Flags {
@@ -274,6 +313,19 @@ func (c *Command) Run(args []string) (retCode int) {
 		return base.CommandUserError
 	}
 
+	switch targetArgs := f.Args(); len(targetArgs) {
+	case 0:
+	case 1:
+		if c.flagTargetId != "" {
+			c.PrintCliError(errors.New("Cannot specify a target as an argument and also via -target-id"))
+			return base.CommandUserError
+		}
+		c.flagTargetId = targetArgs[0]
+	default:
+		c.PrintCliError(fmt.Errorf("Only one target can be given as an argument, got %d", len(targetArgs)))
+		return base.CommandUserError
+	}
+
 	switch {
 	case c.flagAuthzToken != "":
 		switch {
@@ -320,6 +372,8 @@ func (c *Command) Run(args []string) (retCode int) {
 	}
 
 	c.connectionsLeft = atomic.NewInt32(0)
+	c.workerIdx = atomic.NewInt32(0)
+	c.terminationReason = atomic.NewString("")
 	c.connsLeftCh = make(chan int32)
 
 	if c.flagListenAddr == "" {
@@ -418,7 +472,6 @@ func (c *Command) Run(args []string) (retCode int) {
 	}
 
 	c.connectionsLeft.Store(c.sessionAuthzData.ConnectionLimit)
-	workerAddr := c.sessionAuthzData.GetWorkerInfo()[0].GetAddress()
 
 	parsedCert, err := x509.ParseCertificate(c.sessionAuthzData.Certificate)
 	if err != nil {
@@ -568,10 +621,7 @@ func (c *Command) Run(args []string) (retCode int) {
 			go func() {
 				defer listeningConn.Close()
 				defer c.connWg.Done()
-				wsConn, err := c.getWsConn(
-					c.proxyCtx,
-					workerAddr,
-					transport)
+				wsConn, err := c.dialWorker(c.proxyCtx, transport)
 				if err != nil {
 					c.PrintCliError(err)
 				} else {
@@ -622,27 +672,32 @@ func (c *Command) Run(args []string) (retCode int) {
 
 	termInfo := TerminationInfo{Reason: "Unknown"}
 	sendSessionCancel := false
-	select {
-	case <-c.Context.Done():
-		termInfo.Reason = "Received shutdown signal"
-		sendSessionCancel = true
-	case <-timer.C:
-		termInfo.Reason = "Session has expired"
-	default:
-		if c.execCmdReturnValue != nil {
-			// Don't print out in this case, so ensure we clear it
-			termInfo.Reason = ""
+	if reason := c.terminationReason.Load(); reason != "" {
+		// The worker closed the connection because the session was canceled
+		termInfo.Reason = reason
+	} else {
+		select {
+		case <-c.Context.Done():
+			termInfo.Reason = "Received shutdown signal"
 			sendSessionCancel = true
-		} else {
-			if c.connectionsLeft.Load() == 0 {
-				termInfo.Reason = "No connections left in session"
+		case <-timer.C:
+			termInfo.Reason = "Session has expired"
+		default:
+			if c.execCmdReturnValue != nil {
+				// Don't print out in this case, so ensure we clear it
+				termInfo.Reason = ""
+				sendSessionCancel = true
+			} else {
+				if c.connectionsLeft.Load() == 0 {
+					termInfo.Reason = "No connections left in session"
+				}
 			}
 		}
 	}
 
 	if sendSessionCancel {
 		ctx, cancel := context.WithTimeout(context.Background(), sessionCancelTimeout)
-		wsConn, err := c.getWsConn(ctx, workerAddr, transport)
+		wsConn, err := c.dialWorker(ctx, transport)
 		if err != nil {
 			c.PrintCliError(fmt.Errorf("error fetching connection to send session teardown request to worker: %w", err))
 		} else {
@@ -676,6 +731,32 @@ func (c *Command) Run(args []string) (retCode int) {
 	return
 }
 
+// dialWorker connects to a worker of the session. The workers are tried in
+// the order the controller returned them, starting with the last one a
+// connection was made to, so that a worker which is down is only skipped once.
+func (c *Command) dialWorker(
+	ctx context.Context,
+	transport *http.Transport) (*websocket.Conn, error) {
+	workers := c.sessionAuthzData.GetWorkerInfo()
+	start := int(c.workerIdx.Load())
+	var err error
+	for i := range workers {
+		idx := (start + i) % len(workers)
+		var conn *websocket.Conn
+		conn, err = c.getWsConn(ctx, workers[idx].GetAddress(), transport)
+		if err == nil {
+			c.workerIdx.Store(int32(idx))
+			return conn, nil
+		}
+		// Other workers reject the session the same way, and there is no point
+		// trying them once the context is done
+		if errors.Is(err, errSessionUnauthorized) || ctx.Err() != nil {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
 func (c *Command) getWsConn(
 	ctx context.Context,
 	workerAddr string,
@@ -693,7 +774,7 @@ func (c *Command) getWsConn(
 	if err != nil {
 		switch {
 		case strings.Contains(err.Error(), "tls: internal error"):
-			return nil, errors.New("Session credentials were not accepted, or session is unauthorized")
+			return nil, errSessionUnauthorized
 		case strings.Contains(err.Error(), "connect: connection refused"):
 			return nil, fmt.Errorf("Unable to connect to worker at %s", workerAddr)
 		default:
@@ -774,7 +855,14 @@ func (c *Command) runTcpProxyV1(
 	}()
 	go func() {
 		defer localWg.Done()
-		io.Copy(listeningConn, netConn)
+		_, err := io.Copy(listeningConn, netConn)
+		var closeErr websocket.CloseError
+		if errors.As(err, &closeErr) && closeErr.Code == globals.SessionTerminatedWsCloseCode {
+			// The session was canceled; no further connections will be
+			// authorized, so stop proxying
+			c.terminationReason.Store(closeErr.Reason)
+			c.proxyCancel()
+		}
 		listeningConn.Close()
 		netConn.Close()
 	}()
@@ -839,7 +927,12 @@ func (c *Command) handleExec(passthroughArgs []string) {
 		args = append(args, c.rdpFlags.buildArgs(c, port, ip, addr)...)
 
 	case "ssh":
-		args = append(args, c.sshFlags.buildArgs(c, port, ip, addr)...)
+		sshArgs, sshErr := c.sshFlags.buildArgs(c, port, ip, addr)
+		if sshErr != nil {
+			argsErr = sshErr
+			break
+		}
+		args = append(args, sshArgs...)
 
 	case "kube":
 		kubeArgs, err := c.kubeFlags.buildArgs(c, port, ip, addr)
@@ -58,6 +58,9 @@ func generateCredentialTableOutputSlice(prefixIndent int, creds []*targets.Sessi
 		if crd.CredentialLibrary.Description != "" {
 			libMap["Credential Library Description"] = crd.CredentialLibrary.Description
 		}
+		if crd.CredentialSource != nil && crd.CredentialSource.CredentialType != "" {
+			libMap["Credential Type"] = crd.CredentialSource.CredentialType
+		}
 		maxLength := base.MaxAttributesLength(libMap, nil, nil)
 		ret = append(ret,
 			fmt.Sprintf("%sCredentials:", prefixString),
@@ -39,7 +39,7 @@ func kubeOptions(c *Command, set *base.FlagSets) {
 		Default:    "https",
 		EnvVar:     fmt.Sprintf("BOUNDARY_CONNECT_%s_SCHEME", strings.ToUpper(c.Func)),
 		Completion: complete.PredictNothing,
-		Usage:      `Specifies the scheme to use.`,
+		Usage:      `Specifies the scheme to use. Ignored for kubernetes-type targets, for which the worker connects to the API server.`,
 	})
 }
 
@@ -64,13 +64,19 @@ func (f *kubeFlags) buildArgs(c *Command, port, ip, addr string) ([]string, erro
 		}
 		host = u.Hostname()
 	}
+	scheme := f.flagKubeScheme
+	if c.sessionAuthzData.GetType() == "kubernetes" {
+		// The worker terminates TLS to the API server for kubernetes targets
+		// and serves plain HTTP over the proxied connection.
+		scheme = "http"
+	}
 	switch f.flagKubeStyle {
 	case "kubectl":
-		if host != "" && f.flagKubeScheme == "https" {
+		if host != "" && scheme == "https" {
 			host = strings.TrimSuffix(host, "/")
 			args = append(args, "--tls-server-name", host)
 		}
-		args = append(args, "--server", fmt.Sprintf("%s://%s", f.flagKubeScheme, addr))
+		args = append(args, "--server", fmt.Sprintf("%s://%s", scheme, addr))
 	}
 	return args, nil
 }
@@ -61,12 +61,18 @@ func (p *postgresFlags) buildArgs(c *Command, port, ip, addr string) (args, envs
 	var creds postgresCredentials
 	if c.sessionAuthz != nil && len(c.sessionAuthz.Credentials) > 0 {
 		for _, cred := range c.sessionAuthz.Credentials {
-			if cred.Secret == nil || cred.Secret.Decoded == nil {
+			if cred.Credential == nil && (cred.Secret == nil || cred.Secret.Decoded == nil) {
 				continue
 			}
 			// TODO: Could allow switching on library ID or name
-			switch cred.CredentialLibrary.Type {
-			case "vault":
+			switch {
+			case cred.CredentialSource != nil && cred.CredentialSource.CredentialType == "username_password":
+				// The controller already decoded the secret using the
+				// attribute mapping of the library
+				if err := mapstructure.Decode(cred.Credential, &creds); err != nil {
+					return nil, nil, fmt.Errorf("Error interpreting username_password credential: %w", err)
+				}
+			case cred.CredentialLibrary.Type == "vault":
 				// Attempt unmarshaling into creds
 				if err := mapstructure.Decode(cred.Secret.Decoded, &creds); err != nil {
 					return nil, nil, fmt.Errorf("Error interpreting Vault secret: %w", err)
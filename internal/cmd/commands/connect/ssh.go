@@ -2,9 +2,12 @@ package connect
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"strings"
 
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/mapstructure"
 	"github.com/posener/complete"
 )
 
@@ -29,7 +32,7 @@ func sshOptions(c *Command, set *base.FlagSets) {
 		Target:     &c.flagUsername,
 		EnvVar:     "BOUNDARY_CONNECT_USERNAME",
 		Completion: complete.PredictNothing,
-		Usage:      `Specifies the username to pass through to the client`,
+		Usage:      `Specifies the username to pass through to the client. May be overridden by credentials sourced from a credential store.`,
 	})
 }
 
@@ -41,18 +44,87 @@ func (s *sshFlags) defaultExec() string {
 	return strings.ToLower(s.flagSshStyle)
 }
 
-func (s *sshFlags) buildArgs(c *Command, port, ip, addr string) []string {
+type sshCredentials struct {
+	Username    string `mapstructure:"username"`
+	PrivateKey  string `mapstructure:"private_key"`
+	Certificate string `mapstructure:"certificate"`
+}
+
+func (s *sshFlags) buildArgs(c *Command, port, ip, addr string) (args []string, retErr error) {
+	var creds sshCredentials
+	if c.sessionAuthz != nil {
+		for _, cred := range c.sessionAuthz.Credentials {
+			if cred.Credential == nil || cred.CredentialSource == nil {
+				continue
+			}
+			switch cred.CredentialSource.CredentialType {
+			case "ssh_private_key", "ssh_certificate", "username_password":
+				if err := mapstructure.Decode(cred.Credential, &creds); err != nil {
+					return nil, fmt.Errorf("Error interpreting %s credential: %w", cred.CredentialSource.CredentialType, err)
+				}
+			}
+			if creds.Username != "" && creds.PrivateKey != "" {
+				break
+			}
+		}
+	}
+
 	// Might want -t for ssh or -tt but seems fine without it for now...
-	var args []string
 	switch s.flagSshStyle {
 	case "ssh":
 		args = append(args, "-p", port, ip)
 		args = append(args, "-o", fmt.Sprintf("HostKeyAlias=%s", c.sessionAuthzData.HostId))
+
+		if creds.PrivateKey != "" {
+			keyfile, err := ioutil.TempFile("", "*")
+			if err != nil {
+				return nil, fmt.Errorf("Error saving ssh private key to tmp file: %w", err)
+			}
+			c.cleanupFuncs = append(c.cleanupFuncs, func() error {
+				if err := os.Remove(keyfile.Name()); err != nil {
+					return fmt.Errorf("Error removing temporary ssh private key file; consider removing %s manually: %w", keyfile.Name(), err)
+				}
+				return nil
+			})
+			if _, err := keyfile.WriteString(creds.PrivateKey); err != nil {
+				return nil, fmt.Errorf("Error writing ssh private key file to %s: %w", keyfile.Name(), err)
+			}
+			if err := keyfile.Close(); err != nil {
+				return nil, fmt.Errorf("Error closing ssh private key file after writing to %s: %w", keyfile.Name(), err)
+			}
+			args = append(args, "-i", keyfile.Name())
+
+			if creds.Certificate != "" {
+				// ssh would pick up a certificate next to the key file on its
+				// own, but being explicit avoids depending on its naming rules.
+				certfile := keyfile.Name() + "-cert.pub"
+				c.cleanupFuncs = append(c.cleanupFuncs, func() error {
+					if err := os.Remove(certfile); err != nil && !os.IsNotExist(err) {
+						return fmt.Errorf("Error removing temporary ssh certificate file; consider removing %s manually: %w", certfile, err)
+					}
+					return nil
+				})
+				if err := ioutil.WriteFile(certfile, []byte(creds.Certificate), 0o600); err != nil {
+					return nil, fmt.Errorf("Error writing ssh certificate file to %s: %w", certfile, err)
+				}
+				args = append(args, "-o", fmt.Sprintf("CertificateFile=%s", certfile))
+			}
+		}
 	case "putty":
 		args = append(args, "-P", port, ip)
+		if creds.PrivateKey != "" {
+			c.UI.Warn("An ssh private key is being brokered but cannot be passed to putty; it will not be used.")
+		}
+		if creds.Certificate != "" {
+			c.UI.Warn("An ssh certificate is being brokered but cannot be passed to putty; it will not be used.")
+		}
 	}
-	if c.flagUsername != "" {
+
+	switch {
+	case creds.Username != "":
+		args = append(args, "-l", creds.Username)
+	case c.flagUsername != "":
 		args = append(args, "-l", c.flagUsername)
 	}
-	return args
+	return args, nil
 }
@@ -135,8 +135,14 @@ func printItemTable(result api.GenericResult) string {
 	if item.Type != "" {
 		nonAttributeMap["Type"] = item.Type
 	}
+	if item.CredentialType != "" {
+		nonAttributeMap["Credential Type"] = item.CredentialType
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, item.Attributes, keySubstMap)
+	if l := base.MaxAttributesLength(nonAttributeMap, item.CredentialMappingOverrides, keySubstMap); l > maxLength {
+		maxLength = l
+	}
 
 	ret := []string{
 		"",
@@ -168,6 +174,14 @@ func printItemTable(result api.GenericResult) string {
 		)
 	}
 
+	if len(item.CredentialMappingOverrides) > 0 {
+		ret = append(ret,
+			"",
+			"  Credential Mapping Overrides:",
+			base.WrapMap(4, maxLength, item.CredentialMappingOverrides),
+		)
+	}
+
 	return base.WrapForHelpText(ret)
 }
 
@@ -175,4 +189,8 @@ var keySubstMap = map[string]string{
 	"path":              "Path",
 	"http_method":       "HTTP Method",
 	"http_request_body": "HTTP Request Body",
+
+	"username_attribute":    "Username Attribute",
+	"password_attribute":    "Password Attribute",
+	"private_key_attribute": "Private Key Attribute",
 }
@@ -184,7 +184,7 @@ func (c *VaultCommand) Run(args []string) int {
 	switch c.Func {
 
 	case "create":
-		result, err = credentiallibrariesClient.Create(c.Context, c.FlagCredentialStoreId, opts...)
+		result, err = credentiallibrariesClient.Create(c.Context, "vault", c.FlagCredentialStoreId, opts...)
 
 	case "update":
 		result, err = credentiallibrariesClient.Update(c.Context, c.FlagId, version, opts...)
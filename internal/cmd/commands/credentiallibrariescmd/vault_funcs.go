@@ -16,23 +16,36 @@ const (
 	pathFlagName            = "vault-path"
 	httpMethodFlagName      = "vault-http-method"
 	httpRequestBodyFlagName = "vault-http-request-body"
+
+	credentialTypeFlagName      = "credential-type"
+	usernameAttributeFlagName   = "username-attribute"
+	passwordAttributeFlagName   = "password-attribute"
+	privateKeyAttributeFlagName = "private-key-attribute"
 )
 
 type extraVaultCmdVars struct {
 	flagPath            string
 	flagHttpMethod      string
 	flagHttpRequestBody string
+
+	flagCredentialType      string
+	flagUsernameAttribute   string
+	flagPasswordAttribute   string
+	flagPrivateKeyAttribute string
 }
 
 func extraVaultActionsFlagsMapFuncImpl() map[string][]string {
 	flags := map[string][]string{
-		"create": {
+		"update": {
 			pathFlagName,
 			httpMethodFlagName,
 			httpRequestBodyFlagName,
+			usernameAttributeFlagName,
+			passwordAttributeFlagName,
+			privateKeyAttributeFlagName,
 		},
 	}
-	flags["update"] = flags["create"]
+	flags["create"] = append([]string{credentialTypeFlagName}, flags["update"]...)
 	return flags
 }
 
@@ -59,6 +72,30 @@ func extraVaultFlagsFuncImpl(c *VaultCommand, set *base.FlagSets, _ *base.FlagSe
 				Target: &c.flagHttpRequestBody,
 				Usage:  "The http request body the library uses to communicate with vault. This can be the value itself, refer to a file on disk (file://) from which the value will be read, or an env var (env://) from which the value will be read.",
 			})
+		case credentialTypeFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   credentialTypeFlagName,
+				Target: &c.flagCredentialType,
+				Usage:  `The type of credential the library provides. Either "username_password" or "ssh_private_key". Cannot be changed after creation.`,
+			})
+		case usernameAttributeFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   usernameAttributeFlagName,
+				Target: &c.flagUsernameAttribute,
+				Usage:  `The name of the attribute in the vault secret holding the username. Defaults to "username".`,
+			})
+		case passwordAttributeFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   passwordAttributeFlagName,
+				Target: &c.flagPasswordAttribute,
+				Usage:  `The name of the attribute in the vault secret holding the password of a username_password credential. Defaults to "password".`,
+			})
+		case privateKeyAttributeFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   privateKeyAttributeFlagName,
+				Target: &c.flagPrivateKeyAttribute,
+				Usage:  `The name of the attribute in the vault secret holding the private key of an ssh_private_key credential. Defaults to "private_key".`,
+			})
 		}
 	}
 }
@@ -84,6 +121,27 @@ func extraVaultFlagHandlingFuncImpl(c *VaultCommand, f *base.FlagSets, opts *[]c
 		rb, _ := parseutil.ParsePath(c.flagHttpRequestBody)
 		*opts = append(*opts, credentiallibraries.WithVaultCredentialLibraryHttpRequestBody(rb))
 	}
+	if c.flagCredentialType != "" {
+		*opts = append(*opts, credentiallibraries.WithCredentialType(c.flagCredentialType))
+	}
+
+	overrides := map[string]interface{}{}
+	for key, val := range map[string]string{
+		"username_attribute":    c.flagUsernameAttribute,
+		"password_attribute":    c.flagPasswordAttribute,
+		"private_key_attribute": c.flagPrivateKeyAttribute,
+	} {
+		switch val {
+		case "":
+		case "null":
+			overrides[key] = nil
+		default:
+			overrides[key] = val
+		}
+	}
+	if len(overrides) > 0 {
+		*opts = append(*opts, credentiallibraries.WithCredentialMappingOverrides(overrides))
+	}
 
 	return true
 }
@@ -99,6 +157,10 @@ func (c *VaultCommand) extraVaultHelpFunc(helpMap map[string]func() string) stri
 			"",
 			`    $ boundary credential-libraries create vault -credential-store-id csvlt_1234567890 -vault-path "/some/path"`,
 			"",
+			"  Create a vault-type credential library providing username_password credentials from a secret with the \"user\" and \"pass\" attributes. Example:",
+			"",
+			`    $ boundary credential-libraries create vault -credential-store-id csvlt_1234567890 -vault-path "/some/path" -credential-type username_password -username-attribute user -password-attribute pass`,
+			"",
 			"",
 		})
 
@@ -0,0 +1,148 @@
+package credentiallibrariescmd
+
+import (
+	"strings"
+
+	"github.com/hashicorp/boundary/api/credentiallibraries"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func init() {
+	extraVaultSshSignedCertFlagsFunc = extraVaultSshSignedCertFlagsFuncImpl
+	extraVaultSshSignedCertActionsFlagsMapFunc = extraVaultSshSignedCertActionsFlagsMapFuncImpl
+	extraVaultSshSignedCertFlagsHandlingFunc = extraVaultSshSignedCertFlagHandlingFuncImpl
+}
+
+const (
+	validPrincipalFlagName = "valid-principal"
+	ttlFlagName            = "ttl"
+	extensionFlagName      = "extension"
+)
+
+type extraVaultSshSignedCertCmdVars struct {
+	flagPath            string
+	flagValidPrincipals []string
+	flagTtl             string
+	flagExtensions      []string
+}
+
+func extraVaultSshSignedCertActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {
+			pathFlagName,
+			validPrincipalFlagName,
+			ttlFlagName,
+			extensionFlagName,
+		},
+		"update": {
+			pathFlagName,
+			validPrincipalFlagName,
+			ttlFlagName,
+			extensionFlagName,
+		},
+	}
+}
+
+func extraVaultSshSignedCertFlagsFuncImpl(c *VaultSshSignedCertCommand, set *base.FlagSets, _ *base.FlagSet) {
+	f := set.NewFlagSet("Vault SSH Signed Certificate Credential Library Options")
+
+	for _, name := range flagsVaultSshSignedCertMap[c.Func] {
+		switch name {
+		case pathFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   pathFlagName,
+				Target: &c.flagPath,
+				Usage:  `The path of the sign endpoint of a vault SSH secrets engine role, e.g. "ssh-client-signer/sign/my-role".`,
+			})
+		case validPrincipalFlagName:
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   validPrincipalFlagName,
+				Target: &c.flagValidPrincipals,
+				Usage:  "A principal the certificate is signed for. May be specified multiple times. The first principal is used as the username of the credential.",
+			})
+		case ttlFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   ttlFlagName,
+				Target: &c.flagTtl,
+				Usage:  `The requested time to live of the certificate, e.g. "10m". Defaults to the TTL of the vault role.`,
+			})
+		case extensionFlagName:
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   extensionFlagName,
+				Target: &c.flagExtensions,
+				Usage:  `An extension requested for the certificate, in the form of "name" or "name=value". May be specified multiple times. Defaults to the extensions of the vault role.`,
+			})
+		}
+	}
+}
+
+func extraVaultSshSignedCertFlagHandlingFuncImpl(c *VaultSshSignedCertCommand, f *base.FlagSets, opts *[]credentiallibraries.Option) bool {
+	switch c.flagPath {
+	case "":
+	default:
+		*opts = append(*opts, credentiallibraries.WithVaultSshSignedCertCredentialLibraryPath(c.flagPath))
+	}
+	switch {
+	case len(c.flagValidPrincipals) == 0:
+	case len(c.flagValidPrincipals) == 1 && c.flagValidPrincipals[0] == "null":
+		*opts = append(*opts, credentiallibraries.DefaultVaultSshSignedCertCredentialLibraryValidPrincipals())
+	default:
+		*opts = append(*opts, credentiallibraries.WithVaultSshSignedCertCredentialLibraryValidPrincipals(c.flagValidPrincipals))
+	}
+	switch c.flagTtl {
+	case "":
+	case "null":
+		*opts = append(*opts, credentiallibraries.DefaultVaultSshSignedCertCredentialLibraryTtl())
+	default:
+		*opts = append(*opts, credentiallibraries.WithVaultSshSignedCertCredentialLibraryTtl(c.flagTtl))
+	}
+	switch {
+	case len(c.flagExtensions) == 0:
+	case len(c.flagExtensions) == 1 && c.flagExtensions[0] == "null":
+		*opts = append(*opts, credentiallibraries.DefaultVaultSshSignedCertCredentialLibraryExtensions())
+	default:
+		extensions := make(map[string]interface{}, len(c.flagExtensions))
+		for _, e := range c.flagExtensions {
+			name, value := e, ""
+			if idx := strings.Index(e, "="); idx != -1 {
+				name, value = e[:idx], e[idx+1:]
+			}
+			if name == "" {
+				c.UI.Error(`Extensions must be in the form of "name" or "name=value".`)
+				return false
+			}
+			extensions[name] = value
+		}
+		*opts = append(*opts, credentiallibraries.WithVaultSshSignedCertCredentialLibraryExtensions(extensions))
+	}
+
+	return true
+}
+
+func (c *VaultSshSignedCertCommand) extraVaultSshSignedCertHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary credential-libraries create vault-ssh-signed-cert -credential-store-id [options] [args]",
+			"",
+			"  Create a vault-ssh-signed-cert-type credential library. Each credential issued by the library is a freshly generated key pair with a certificate signed by vault. Example:",
+			"",
+			`    $ boundary credential-libraries create vault-ssh-signed-cert -credential-store-id csvlt_1234567890 -vault-path "ssh-client-signer/sign/my-role" -valid-principal ubuntu -ttl 10m -extension permit-pty`,
+			"",
+			"",
+		})
+
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary credential-libraries update vault-ssh-signed-cert [options] [args]",
+			"",
+			"  Update a vault-ssh-signed-cert-type credential library given its ID. Example:",
+			"",
+			`    $ boundary credential-libraries update vault-ssh-signed-cert -id clvsc_1234567890 -ttl 5m`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
@@ -200,4 +200,16 @@ var keySubstMap = map[string]string{
 	"token_hmac":                  "Token HMAC",
 	"client_certificate":          "Client Certificate",
 	"client_certificate_key_hmac": "Client Certificate Key HMAC",
+	"auth_method":                 "Auth Method",
+	"auth_mount_path":             "Auth Mount Path",
+	"approle_role_id":             "AppRole Role ID",
+	"approle_secret_id_hmac":      "AppRole Secret ID HMAC",
+	"kubernetes_role":             "Kubernetes Role",
+	"operational_state":           "Operational State",
+	"health_status":               "Health Status",
+	"health_check_time":           "Health Check Time",
+	"health_failed_check":         "Health Failed Check",
+	"health_message":              "Health Message",
+	"token_expiration_time":       "Token Expiration Time",
+	"ca_cert_expiration_time":     "CA Cert Expiration Time",
 }
@@ -1,6 +1,9 @@
 package credentialstorescmd
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/hashicorp/boundary/api/credentialstores"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/go-secure-stdlib/parseutil"
@@ -21,6 +24,11 @@ const (
 	vaultTokenFlagName           = "vault-token"
 	clientCertificateFlagName    = "vault-client-certificate"
 	clientCertificateKeyFlagName = "vault-client-certificate-key"
+	authMethodFlagName           = "vault-auth-method"
+	authMountPathFlagName        = "vault-auth-mount-path"
+	approleRoleIdFlagName        = "vault-approle-role-id"
+	approleSecretIdFlagName      = "vault-approle-secret-id"
+	kubernetesRoleFlagName       = "vault-kubernetes-role"
 )
 
 type extraVaultCmdVars struct {
@@ -32,6 +40,12 @@ type extraVaultCmdVars struct {
 	flagClientCertKey string
 	flagTlsServerName string
 	flagTlsSkipVerify bool
+
+	flagAuthMethod      string
+	flagAuthMountPath   string
+	flagApproleRoleId   string
+	flagApproleSecretId string
+	flagKubernetesRole  string
 }
 
 func extraVaultActionsFlagsMapFuncImpl() map[string][]string {
@@ -45,6 +59,11 @@ func extraVaultActionsFlagsMapFuncImpl() map[string][]string {
 			vaultTokenFlagName,
 			clientCertificateFlagName,
 			clientCertificateKeyFlagName,
+			authMethodFlagName,
+			authMountPathFlagName,
+			approleRoleIdFlagName,
+			approleSecretIdFlagName,
+			kubernetesRoleFlagName,
 		},
 	}
 	flags["update"] = flags["create"]
@@ -104,6 +123,36 @@ func extraVaultFlagsFuncImpl(c *VaultCommand, set *base.FlagSets, _ *base.FlagSe
 				Target: &c.flagClientCertKey,
 				Usage:  `The client certificate's private key to use when boundary connects to vault for this store. This can be the value itself, refer to a file on disk (file://) from which the value will be read, or an env var (env://) from which the value will be read.`,
 			})
+		case authMethodFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   authMethodFlagName,
+				Target: &c.flagAuthMethod,
+				Usage:  `How boundary obtains its vault token for this store. Can be "token" (the token set with -vault-token), "approle", or "kubernetes". Defaults to "token".`,
+			})
+		case authMountPathFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   authMountPathFlagName,
+				Target: &c.flagAuthMountPath,
+				Usage:  "The path the vault auth method is mounted at. Defaults to the name of the auth method.",
+			})
+		case approleRoleIdFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   approleRoleIdFlagName,
+				Target: &c.flagApproleRoleId,
+				Usage:  "The role ID to log in to vault with when using the approle auth method.",
+			})
+		case approleSecretIdFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   approleSecretIdFlagName,
+				Target: &c.flagApproleSecretId,
+				Usage:  "The secret ID to log in to vault with when using the approle auth method. This can be the value itself, refer to a file on disk (file://) from which the value will be read, or an env var (env://) from which the value will be read.",
+			})
+		case kubernetesRoleFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   kubernetesRoleFlagName,
+				Target: &c.flagKubernetesRole,
+				Usage:  "The vault role to log in as with the service account token of the controller when using the kubernetes auth method.",
+			})
 		}
 	}
 }
@@ -153,6 +202,40 @@ func extraVaultFlagHandlingFuncImpl(c *VaultCommand, f *base.FlagSets, opts *[]c
 	if c.flagTlsSkipVerify {
 		*opts = append(*opts, credentialstores.WithVaultCredentialStoreTlsSkipVerify(c.flagTlsSkipVerify))
 	}
+	switch c.flagAuthMethod {
+	case "":
+	case "null":
+		*opts = append(*opts, credentialstores.DefaultVaultCredentialStoreAuthMethod())
+	default:
+		*opts = append(*opts, credentialstores.WithVaultCredentialStoreAuthMethod(c.flagAuthMethod))
+	}
+	switch c.flagAuthMountPath {
+	case "":
+	case "null":
+		*opts = append(*opts, credentialstores.DefaultVaultCredentialStoreAuthMountPath())
+	default:
+		*opts = append(*opts, credentialstores.WithVaultCredentialStoreAuthMountPath(c.flagAuthMountPath))
+	}
+	switch c.flagApproleRoleId {
+	case "":
+	default:
+		*opts = append(*opts, credentialstores.WithVaultCredentialStoreApproleRoleId(c.flagApproleRoleId))
+	}
+	switch c.flagApproleSecretId {
+	case "":
+	default:
+		secretId, err := parseutil.ParsePath(c.flagApproleSecretId)
+		if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+			c.UI.Error(fmt.Sprintf("Error parsing approle secret id: %v", err))
+			return false
+		}
+		*opts = append(*opts, credentialstores.WithVaultCredentialStoreApproleSecretId(secretId))
+	}
+	switch c.flagKubernetesRole {
+	case "":
+	default:
+		*opts = append(*opts, credentialstores.WithVaultCredentialStoreKubernetesRole(c.flagKubernetesRole))
+	}
 
 	return true
 }
@@ -168,6 +251,10 @@ func (c *VaultCommand) extraVaultHelpFunc(helpMap map[string]func() string) stri
 			"",
 			`    $ boundary credential-stores create vault -vault-address "http://localhost:8200" -vault-token "s.s0m3t0k3n"`,
 			"",
+			"  Create a vault-type credential store which logs in to vault with an approle. Example:",
+			"",
+			`    $ boundary credential-stores create vault -vault-address "http://localhost:8200" -vault-auth-method approle -vault-approle-role-id "db02de05-fa39-4855-059b-67221c5c2f63" -vault-approle-secret-id "env://BOUNDARY_APPROLE_SECRET_ID"`,
+			"",
 			"",
 		})
 
@@ -1,8 +1,11 @@
 package hostscmd
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/boundary/api/hosts"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
 )
 
 func init() {
@@ -17,8 +20,8 @@ type extraStaticCmdVars struct {
 
 func extraStaticActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {"address"},
-		"update": {"address"},
+		"create": {"address", "label"},
+		"update": {"address", "label"},
 	}
 }
 
@@ -82,5 +85,14 @@ func extraStaticFlagsHandlingFuncImpl(c *StaticCommand, _ *base.FlagSets, opts *
 		*opts = append(*opts, hosts.WithStaticHostAddress(c.flagAddress))
 	}
 
+	if err := common.HandleLabelFlags(
+		c.FlagLabels,
+		func() { *opts = append(*opts, hosts.DefaultLabels()) },
+		func(in map[string]string) { *opts = append(*opts, hosts.WithLabels(in)) },
+	); err != nil {
+		c.UI.Error(fmt.Sprintf("Error parsing labels: %s", err))
+		return false
+	}
+
 	return true
 }
@@ -305,6 +305,18 @@ func printItemTable(result api.GenericResult) string {
 		)
 	}
 
+	if len(item.Labels) > 0 {
+		labels := make(map[string]interface{}, len(item.Labels))
+		for k, v := range item.Labels {
+			labels[k] = v
+		}
+		ret = append(ret,
+			"",
+			"  Labels:",
+			base.WrapMap(4, 0, labels),
+		)
+	}
+
 	if len(item.HostIds) > 0 {
 		ret = append(ret,
 			"",
@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/boundary/api/hostsets"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
 	"github.com/hashicorp/boundary/internal/libs/endpoint"
 	"github.com/hashicorp/go-secure-stdlib/parseutil"
 )
@@ -22,8 +23,8 @@ type extraPluginCmdVars struct {
 
 func extraPluginActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {"preferred-endpoint", "sync-interval"},
-		"update": {"preferred-endpoint", "sync-interval"},
+		"create": {"preferred-endpoint", "sync-interval", "label"},
+		"update": {"preferred-endpoint", "sync-interval", "label"},
 	}
 }
 
@@ -120,5 +121,14 @@ func extraPluginFlagsHandlingFuncImpl(c *PluginCommand, _ *base.FlagSets, opts *
 		*opts = append(*opts, hostsets.WithSyncIntervalSeconds(int32(interval.Seconds())))
 	}
 
+	if err := common.HandleLabelFlags(
+		c.FlagLabels,
+		func() { *opts = append(*opts, hostsets.DefaultLabels()) },
+		func(in map[string]string) { *opts = append(*opts, hostsets.WithLabels(in)) },
+	); err != nil {
+		c.UI.Error(fmt.Sprintf("Error parsing labels: %s", err))
+		return false
+	}
+
 	return true
 }
@@ -1,9 +1,25 @@
 package hostsetscmd
 
 import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/api/hostsets"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
 )
 
+func init() {
+	extraStaticActionsFlagsMapFunc = extraStaticActionsFlagsMapFuncImpl
+	extraStaticFlagsHandlingFunc = extraStaticFlagsHandlingFuncImpl
+}
+
+func extraStaticActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {"label"},
+		"update": {"label"},
+	}
+}
+
 func (c *StaticCommand) extraStaticHelpFunc(helpMap map[string]func() string) string {
 	var helpStr string
 	switch c.Func {
@@ -33,3 +49,16 @@ func (c *StaticCommand) extraStaticHelpFunc(helpMap map[string]func() string) st
 	}
 	return helpStr + c.Flags().Help()
 }
+
+func extraStaticFlagsHandlingFuncImpl(c *StaticCommand, _ *base.FlagSets, opts *[]hostsets.Option) bool {
+	if err := common.HandleLabelFlags(
+		c.FlagLabels,
+		func() { *opts = append(*opts, hostsets.DefaultLabels()) },
+		func(in map[string]string) { *opts = append(*opts, hostsets.WithLabels(in)) },
+	); err != nil {
+		c.UI.Error(fmt.Sprintf("Error parsing labels: %s", err))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,314 @@
+package notificationscmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/notifications"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/go-bexpr"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
+)
+
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
+	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	printCustomActionOutput = printCustomActionOutputImpl
+}
+
+type extraCmdVars struct {
+	flagUrl         string
+	flagEventTypes  []string
+	flagEventFilter string
+	flagSecret      string
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {"url", "event-type", "event-filter", "secret"},
+		"update": {"url", "event-type", "event-filter", "secret"},
+	}
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary notifications [sub command] [options] [args]",
+			"",
+			"  This command allows operations on Boundary notification resources. A notification delivers events about sessions, connections and roles to a webhook as signed HTTP requests. Example:",
+			"",
+			"    Read a notification:",
+			"",
+			`      $ boundary notifications read -id ntf_1234567890`,
+			"",
+			"  Please see the notifications subcommand help for detailed usage information.",
+		})
+
+	default:
+		helpStr = helpMap[c.Func]()
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case "url":
+			f.StringVar(&base.StringVar{
+				Name:   "url",
+				Target: &c.flagUrl,
+				Usage:  "The http or https URL of the webhook the events are delivered to.",
+			})
+		case "event-type":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "event-type",
+				Target: &c.flagEventTypes,
+				Usage:  `The type of the events to deliver, e.g. "session.authorized" or "role.grant-added". May be specified multiple times.`,
+			})
+		case "event-filter":
+			f.StringVar(&base.StringVar{
+				Name:   "event-filter",
+				Target: &c.flagEventFilter,
+				Usage:  "A boolean expression the events must match to be delivered.",
+			})
+		case "secret":
+			f.StringVar(&base.StringVar{
+				Name:   "secret",
+				Target: &c.flagSecret,
+				Usage:  "The secret the deliveries are signed with. May be a file:// or env:// reference. If not supplied on creation a secret is generated.",
+			})
+		}
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]notifications.Option) bool {
+	switch c.Func {
+	case "create", "update":
+	default:
+		return true
+	}
+
+	switch c.flagUrl {
+	case "":
+		if c.Func == "create" {
+			c.UI.Error("No URL supplied via -url")
+			return false
+		}
+	default:
+		u, err := url.Parse(c.flagUrl)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			c.UI.Error("The -url must be an absolute http or https URL")
+			return false
+		}
+		*opts = append(*opts, notifications.WithUrl(c.flagUrl))
+	}
+
+	switch len(c.flagEventTypes) {
+	case 0:
+		if c.Func == "create" {
+			c.UI.Error("No event types supplied via -event-type")
+			return false
+		}
+	default:
+		*opts = append(*opts, notifications.WithEventTypes(c.flagEventTypes))
+	}
+
+	switch c.flagEventFilter {
+	case "":
+	case "null":
+		*opts = append(*opts, notifications.DefaultEventFilter())
+	default:
+		if _, err := bexpr.CreateEvaluator(c.flagEventFilter); err != nil {
+			c.UI.Error(fmt.Sprintf("Unable to successfully parse filter expression: %s", err))
+			return false
+		}
+		*opts = append(*opts, notifications.WithEventFilter(c.flagEventFilter))
+	}
+
+	if c.flagSecret != "" {
+		secret, err := parseutil.ParsePath(c.flagSecret)
+		if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+			c.UI.Error(fmt.Sprintf("Error parsing secret: %s", err))
+			return false
+		}
+		*opts = append(*opts, notifications.WithSecret(secret))
+	}
+
+	return true
+}
+
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	return false, nil
+}
+
+func (c *Command) printListTable(items []*notifications.Notification) string {
+	if len(items) == 0 {
+		return "No notifications found"
+	}
+	var output []string
+	output = []string{
+		"",
+		"Notification information:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		if item.Id != "" {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", item.Id),
+			)
+		} else {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", "(not available)"),
+			)
+		}
+		if c.FlagRecursive && item.ScopeId != "" {
+			output = append(output,
+				fmt.Sprintf("    Scope ID:            %s", item.ScopeId),
+			)
+		}
+		if item.Version > 0 {
+			output = append(output,
+				fmt.Sprintf("    Version:             %d", item.Version),
+			)
+		}
+		if item.Name != "" {
+			output = append(output,
+				fmt.Sprintf("    Name:                %s", item.Name),
+			)
+		}
+		if item.Description != "" {
+			output = append(output,
+				fmt.Sprintf("    Description:         %s", item.Description),
+			)
+		}
+		if item.Url != "" {
+			output = append(output,
+				fmt.Sprintf("    URL:                 %s", item.Url),
+			)
+		}
+		if item.DeliveryStatus != nil && item.DeliveryStatus.LastStatus != "" {
+			output = append(output,
+				fmt.Sprintf("    Last Delivery:       %s", item.DeliveryStatus.LastStatus),
+			)
+		}
+		if len(item.AuthorizedActions) > 0 {
+			output = append(output,
+				"    Authorized Actions:",
+				base.WrapSlice(6, item.AuthorizedActions),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+func printItemTable(result api.GenericResult) string {
+	item := result.GetItem().(*notifications.Notification)
+	nonAttributeMap := map[string]interface{}{}
+	if item.Id != "" {
+		nonAttributeMap["ID"] = item.Id
+	}
+	if item.Version != 0 {
+		nonAttributeMap["Version"] = item.Version
+	}
+	if !item.CreatedTime.IsZero() {
+		nonAttributeMap["Created Time"] = item.CreatedTime.Local().Format(time.RFC1123)
+	}
+	if !item.UpdatedTime.IsZero() {
+		nonAttributeMap["Updated Time"] = item.UpdatedTime.Local().Format(time.RFC1123)
+	}
+	if item.Name != "" {
+		nonAttributeMap["Name"] = item.Name
+	}
+	if item.Description != "" {
+		nonAttributeMap["Description"] = item.Description
+	}
+	if item.Url != "" {
+		nonAttributeMap["URL"] = item.Url
+	}
+	if item.EventFilter != "" {
+		nonAttributeMap["Event Filter"] = item.EventFilter
+	}
+	if item.Secret != "" {
+		nonAttributeMap["Secret"] = item.Secret
+	}
+
+	var deliveryMap map[string]interface{}
+	if ds := item.DeliveryStatus; ds != nil {
+		deliveryMap = map[string]interface{}{
+			"Pending":   ds.PendingCount,
+			"Delivered": ds.DeliveredCount,
+			"Failed":    ds.FailedCount,
+		}
+		if !ds.LastAttemptTime.IsZero() {
+			deliveryMap["Last Attempt Time"] = ds.LastAttemptTime.Local().Format(time.RFC1123)
+		}
+		if ds.LastStatus != "" {
+			deliveryMap["Last Status"] = ds.LastStatus
+		}
+		if ds.LastResponseCode != 0 {
+			deliveryMap["Last Response Code"] = ds.LastResponseCode
+		}
+		if ds.LastError != "" {
+			deliveryMap["Last Error"] = ds.LastError
+		}
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, deliveryMap, nil)
+
+	ret := []string{
+		"",
+		"Notification information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if item.Scope != nil {
+		ret = append(ret,
+			"",
+			"  Scope:",
+			base.ScopeInfoForOutput(item.Scope, maxLength),
+		)
+	}
+
+	if len(item.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, item.AuthorizedActions),
+		)
+	}
+
+	if len(item.EventTypes) > 0 {
+		ret = append(ret,
+			"",
+			"  Event Types:",
+			base.WrapSlice(4, item.EventTypes),
+		)
+	}
+
+	if len(deliveryMap) > 0 {
+		ret = append(ret,
+			"",
+			"  Delivery Status:",
+			base.WrapMap(4, maxLength, deliveryMap),
+		)
+	}
+
+	if item.Secret != "" {
+		ret = append(ret,
+			"",
+			"  The secret will not be shown again. Store it in a safe place; it is",
+			"  needed to verify the signature of the deliveries.",
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
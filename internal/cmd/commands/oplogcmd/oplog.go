@@ -0,0 +1,35 @@
+package oplogcmd
+
+import (
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+)
+
+var _ cli.Command = (*Command)(nil)
+
+type Command struct {
+	*base.Command
+}
+
+func (c *Command) Synopsis() string {
+	return wordwrap.WrapString("Read the Boundary operations log", base.TermWidth)
+}
+
+func (c *Command) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary oplog [sub command] [options] [args]",
+		"",
+		"  This command allows reading the decrypted operations log (oplog) of Boundary. Example:",
+		"",
+		"    Follow the changes written to the oplog:",
+		"",
+		"      $ boundary oplog tail -follow",
+		"",
+		"  Please see the oplog subcommand help for detailed usage information.",
+	})
+}
+
+func (c *Command) Run(args []string) int {
+	return cli.RunResultHelp
+}
@@ -0,0 +1,192 @@
+package oplogcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/oplogchanges"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*TailCommand)(nil)
+	_ cli.CommandAutocomplete = (*TailCommand)(nil)
+)
+
+const defaultTailLimit = 100
+
+type TailCommand struct {
+	*base.Command
+
+	flagSinceId      uint64
+	flagLimit        uint64
+	flagFollow       bool
+	flagPollInterval time.Duration
+}
+
+func (c *TailCommand) Synopsis() string {
+	return "Print the changes written to the Boundary operations log"
+}
+
+func (c *TailCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary oplog tail [options]",
+		"",
+		"  Print the decrypted changes written to the operations log after the entry with the given ID. Secret values are removed from the printed changes. Example:",
+		"",
+		`    $ boundary oplog tail -since-id 1500 -follow`,
+		"",
+		"  When the output format is JSON, each change is printed as a single line of JSON, which allows the output to be streamed into other systems.",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *TailCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+
+	f.Uint64Var(&base.Uint64Var{
+		Name:   "since-id",
+		Target: &c.flagSinceId,
+		Usage:  "Only print the changes with an ID greater than the given oplog entry ID. Defaults to printing all changes.",
+	})
+	f.Uint64Var(&base.Uint64Var{
+		Name:    "limit",
+		Target:  &c.flagLimit,
+		Default: defaultTailLimit,
+		Usage:   "The maximum number of changes to fetch with each request.",
+	})
+	f.BoolVar(&base.BoolVar{
+		Name:   "follow",
+		Target: &c.flagFollow,
+		Usage:  "If set, keep polling for new changes until interrupted.",
+	})
+	f.DurationVar(&base.DurationVar{
+		Name:       "poll-interval",
+		Target:     &c.flagPollInterval,
+		Default:    5 * time.Second,
+		Completion: complete.PredictAnything,
+		Usage:      "The time to wait between requests for new changes when -follow is set.",
+	})
+
+	return set
+}
+
+func (c *TailCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *TailCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *TailCommand) Run(args []string) int {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+	switch {
+	case c.flagSinceId > uint64(^uint32(0)):
+		c.PrintCliError(fmt.Errorf("-since-id must be less than or equal to %d", ^uint32(0)))
+		return base.CommandUserError
+	case c.flagLimit == 0 || c.flagLimit > uint64(^uint32(0)):
+		c.PrintCliError(fmt.Errorf("-limit must be greater than zero and less than or equal to %d", ^uint32(0)))
+		return base.CommandUserError
+	case c.flagFollow && c.flagPollInterval <= 0:
+		c.PrintCliError(fmt.Errorf("-poll-interval must be greater than zero"))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+	ocClient := oplogchanges.NewClient(client)
+
+	sinceId := uint32(c.flagSinceId)
+	limit := uint32(c.flagLimit)
+	for {
+		result, err := ocClient.List(c.Context, scope.Global.String(), oplogchanges.WithSinceId(sinceId), oplogchanges.WithLimit(limit))
+		if err != nil {
+			if c.Context.Err() != nil {
+				return base.CommandSuccess
+			}
+			if apiErr := api.AsServerError(err); apiErr != nil {
+				c.PrintApiError(apiErr, "Error from controller when performing list on oplog changes")
+				return base.CommandApiError
+			}
+			c.PrintCliError(fmt.Errorf("Error trying to list oplog changes: %w", err))
+			return base.CommandCliError
+		}
+
+		for _, item := range result.Items {
+			if !c.printChange(item) {
+				return base.CommandCliError
+			}
+			sinceId = item.Id
+		}
+		if uint32(len(result.Items)) >= limit {
+			// There may be more changes waiting, so don't wait before asking
+			// for them
+			continue
+		}
+		if !c.flagFollow {
+			return base.CommandSuccess
+		}
+
+		select {
+		case <-c.Context.Done():
+			return base.CommandSuccess
+		case <-time.After(c.flagPollInterval):
+		}
+	}
+}
+
+func (c *TailCommand) printChange(item *oplogchanges.OplogChange) bool {
+	switch base.Format(c.UI) {
+	case "json":
+		b, err := json.Marshal(item)
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("Error formatting as JSON: %w", err))
+			return false
+		}
+		c.UI.Output(string(b))
+
+	default:
+		c.UI.Output(printChangeTable(item))
+	}
+	return true
+}
+
+func printChangeTable(item *oplogchanges.OplogChange) string {
+	var ret []string
+	ret = append(ret, fmt.Sprintf("%d  %s  %s  %s",
+		item.Id,
+		item.CreatedTime.Local().Format(time.RFC3339),
+		item.ScopeId,
+		item.AggregateName,
+	))
+	for _, o := range item.Operations {
+		line := fmt.Sprintf("    %s %s", o.OpType, o.TypeName)
+		if id, ok := o.Value["public_id"].(string); ok && id != "" {
+			line = fmt.Sprintf("%s %s", line, id)
+		}
+		if len(o.FieldMaskPaths) > 0 {
+			line = fmt.Sprintf("%s  fields: %s", line, strings.Join(o.FieldMaskPaths, ","))
+		}
+		if len(o.SetToNullPaths) > 0 {
+			line = fmt.Sprintf("%s  set to null: %s", line, strings.Join(o.SetToNullPaths, ","))
+		}
+		ret = append(ret, line)
+	}
+	return strings.Join(ret, "\n")
+}
@@ -0,0 +1,121 @@
+package reportscmd
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/reports"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*ListCommand)(nil)
+	_ cli.CommandAutocomplete = (*ListCommand)(nil)
+)
+
+type ListCommand struct {
+	*base.Command
+}
+
+func (c *ListCommand) Synopsis() string {
+	return "List the available reports"
+}
+
+func (c *ListCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary reports list [options] [args]",
+		"",
+		"  List the reports which can be run in the given scope. Example:",
+		"",
+		`    $ boundary reports list -scope-id o_1234567890`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *ListCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "report", flagsMap, "list")
+	return set
+}
+
+func (c *ListCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ListCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ListCommand) Run(args []string) int {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+	if c.FlagScopeId == "" {
+		c.PrintCliError(fmt.Errorf("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID"))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	var opts []reports.Option
+	if c.FlagFilter != "" {
+		opts = append(opts, reports.WithFilter(c.FlagFilter))
+	}
+	result, err := reports.NewClient(client).List(c.Context, c.FlagScopeId, opts...)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when performing list on reports")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to list reports: %w", err))
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		if !c.PrintJsonItems(result) {
+			return base.CommandCliError
+		}
+	default:
+		c.UI.Output(printItemsTable(result.Items))
+	}
+	return base.CommandSuccess
+}
+
+func printItemsTable(items []*reports.Report) string {
+	if len(items) == 0 {
+		return "No reports found"
+	}
+	var output []string
+	output = []string{
+		"",
+		"Report information:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  ID:                    %s", item.Id),
+			fmt.Sprintf("    Description:         %s", item.Description),
+		)
+		if len(item.AuthorizedActions) > 0 {
+			output = append(output,
+				"    Authorized Actions:",
+				base.WrapSlice(6, item.AuthorizedActions),
+			)
+		}
+	}
+	return base.WrapForHelpText(output)
+}
@@ -0,0 +1,40 @@
+package reportscmd
+
+import (
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+)
+
+var _ cli.Command = (*Command)(nil)
+
+type Command struct {
+	*base.Command
+}
+
+func (c *Command) Synopsis() string {
+	return wordwrap.WrapString("Run reports over the Boundary session data warehouse", base.TermWidth)
+}
+
+func (c *Command) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary reports [sub command] [options] [args]",
+		"",
+		"  This command allows listing and running the reports over the session data warehouse of Boundary. Example:",
+		"",
+		"    Run the sessions-per-user report for an org:",
+		"",
+		`      $ boundary reports run -id sessions-per-user -scope-id o_1234567890`,
+		"",
+		"  Please see the reports subcommand help for detailed usage information.",
+	})
+}
+
+func (c *Command) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+var flagsMap = map[string][]string{
+	"list": {"scope-id", "filter"},
+	"run":  {"id", "scope-id"},
+}
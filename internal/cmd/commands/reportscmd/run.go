@@ -0,0 +1,206 @@
+package reportscmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/reports"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*RunCommand)(nil)
+	_ cli.CommandAutocomplete = (*RunCommand)(nil)
+)
+
+type RunCommand struct {
+	*base.Command
+
+	flagStartTime string
+	flagEndTime   string
+	flagLimit     uint64
+}
+
+func (c *RunCommand) Synopsis() string {
+	return "Run a report over the session data warehouse"
+}
+
+func (c *RunCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary reports run [options] [args]",
+		"",
+		"  Run the report with the given ID over the warehouse data of the given scope and its child scopes. The IDs of the available reports can be found with \"boundary reports list\". Example:",
+		"",
+		`    $ boundary reports run -id sessions-per-day -scope-id p_1234567890 -start-time 2021-10-01T00:00:00Z -format csv`,
+		"",
+		"  In addition to \"table\" and \"json\", the \"csv\" output format is supported. It prints a header line with the column names followed by one line for each row of the report.",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *RunCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "report", flagsMap, "run")
+
+	f.StringVar(&base.StringVar{
+		Name:       "start-time",
+		Target:     &c.flagStartTime,
+		Completion: complete.PredictAnything,
+		Usage:      "Only include warehouse data recorded at or after the given RFC 3339 time.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:       "end-time",
+		Target:     &c.flagEndTime,
+		Completion: complete.PredictAnything,
+		Usage:      "Only include warehouse data recorded before the given RFC 3339 time.",
+	})
+	f.Uint64Var(&base.Uint64Var{
+		Name:   "limit",
+		Target: &c.flagLimit,
+		Usage:  "The maximum number of rows to return. Defaults to the controller's default limit.",
+	})
+
+	return set
+}
+
+func (c *RunCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *RunCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *RunCommand) Run(args []string) int {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+	switch {
+	case c.FlagId == "":
+		c.PrintCliError(fmt.Errorf("ID is required but not passed in via -id"))
+		return base.CommandUserError
+	case c.FlagScopeId == "":
+		c.PrintCliError(fmt.Errorf("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID"))
+		return base.CommandUserError
+	case c.flagLimit > uint64(^uint32(0)):
+		c.PrintCliError(fmt.Errorf("-limit must be less than or equal to %d", ^uint32(0)))
+		return base.CommandUserError
+	}
+
+	var opts []reports.Option
+	if c.flagStartTime != "" {
+		t, err := time.Parse(time.RFC3339, c.flagStartTime)
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("Error parsing -start-time: %w", err))
+			return base.CommandUserError
+		}
+		opts = append(opts, reports.WithStartTime(t))
+	}
+	if c.flagEndTime != "" {
+		t, err := time.Parse(time.RFC3339, c.flagEndTime)
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("Error parsing -end-time: %w", err))
+			return base.CommandUserError
+		}
+		opts = append(opts, reports.WithEndTime(t))
+	}
+	if c.flagLimit > 0 {
+		opts = append(opts, reports.WithLimit(uint32(c.flagLimit)))
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	result, err := reports.NewClient(client).Run(c.Context, c.FlagId, c.FlagScopeId, opts...)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when performing run on report")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to run report: %w", err))
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		if !c.PrintJsonItem(result) {
+			return base.CommandCliError
+		}
+	case "csv":
+		out, err := printResultCsv(result.Item)
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("Error formatting as CSV: %w", err))
+			return base.CommandCliError
+		}
+		c.UI.Output(out)
+	default:
+		c.UI.Output(printResultTable(result.Item))
+	}
+	return base.CommandSuccess
+}
+
+func printResultCsv(item *reports.ReportResult) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(item.Columns); err != nil {
+		return "", err
+	}
+	for _, row := range item.Rows {
+		if err := w.Write(formatRow(row)); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func printResultTable(item *reports.ReportResult) string {
+	if len(item.Rows) == 0 {
+		return "No rows found"
+	}
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(item.Columns, "\t"))
+	for _, row := range item.Rows {
+		fmt.Fprintln(w, strings.Join(formatRow(row), "\t"))
+	}
+	w.Flush()
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// formatRow returns the text form of each value of a report row. Numbers are
+// decoded from JSON as float64s, so whole numbers are printed without a
+// fractional part.
+func formatRow(row []interface{}) []string {
+	ret := make([]string, 0, len(row))
+	for _, v := range row {
+		switch t := v.(type) {
+		case nil:
+			ret = append(ret, "")
+		case float64:
+			ret = append(ret, strconv.FormatFloat(t, 'f', -1, 64))
+		default:
+			ret = append(ret, fmt.Sprint(t))
+		}
+	}
+	return ret
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/scopes"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
 )
 
 const (
@@ -18,14 +19,19 @@ const (
 
 func init() {
 	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
 	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {flagSkipAdminRoleCreationName, flagSkipDefaultRoleCreationName},
-		"update": {flagPrimaryAuthMethodIdName},
+		"create":          {flagSkipAdminRoleCreationName, flagSkipDefaultRoleCreationName},
+		"update":          {flagPrimaryAuthMethodIdName},
+		"revoke-tokens":   {"id", common.RevokeTokensFlagName},
+		"cancel-sessions": {"id", common.CancelSessionsFilterFlagName, common.CancelSessionsReasonFlagName},
 	}
 }
 
@@ -33,9 +39,63 @@ type extraCmdVars struct {
 	flagSkipAdminRoleCreation   bool
 	flagSkipDefaultRoleCreation bool
 	flagPrimaryAuthMethodId     string
+	flagCancelSessions          bool
+	flagReason                  string
+
+	revokeTokensResult   *scopes.RevokeTokensResult
+	cancelSessionsResult *scopes.CancelSessionsResult
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "revoke-tokens":
+		return "Revoke all auth tokens of the users in a scope"
+
+	case "cancel-sessions":
+		return "Cancel the sessions in a scope and its child scopes"
+
+	default:
+		return ""
+	}
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "revoke-tokens":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary scopes revoke-tokens [options] [args]",
+			"",
+			"  This command revokes all auth tokens of the users in the global scope or an org. If -cancel-sessions is set, the sessions of these users are canceled as well. Example:",
+			"",
+			"    Revoke the auth tokens of the users in an org:",
+			"",
+			`      $ boundary scopes revoke-tokens -id o_1234567890`,
+			"",
+			"",
+		})
+
+	case "cancel-sessions":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary scopes cancel-sessions [options] [args]",
+			"",
+			`  This command cancels all non-terminated sessions in a scope and its child scopes. If "filter" is set, only the sessions matching it are canceled. Example:`,
+			"",
+			"    Cancel the sessions of a user in an org:",
+			"",
+			`      $ boundary scopes cancel-sessions -id o_1234567890 -filter '"/item/user_id" == "u_1234567890"'`,
+			"",
+			"",
+		})
+
+	default:
+		return helpMap["base"]()
+	}
+	return helpStr + c.Flags().Help()
 }
 
 func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
+	common.PopulateCancelSessionsFlags(c.Command, f, flagsMap[c.Func], &c.flagReason)
 	for _, name := range flagsMap[c.Func] {
 		switch name {
 		case flagSkipAdminRoleCreationName:
@@ -56,6 +116,8 @@ func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagPrimaryAuthMethodId,
 				Usage:  "If set, the primary auth method id for the scope.  A primary auth method is allowed to create users on first login and is also used as a source for account full name and email for a scope's users",
 			})
+		case common.RevokeTokensFlagName:
+			common.PopulateRevokeTokensFlags(f, &c.flagCancelSessions)
 		}
 	}
 }
@@ -74,6 +136,51 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]scopes.Opt
 	return true
 }
 
+func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError error, scopeClient *scopes.Client, version uint32, opts []scopes.Option) (api.GenericResult, error) {
+	switch c.Func {
+	case "revoke-tokens":
+		var err error
+		c.revokeTokensResult, err = scopeClient.RevokeTokens(c.Context, c.FlagId, c.flagCancelSessions, opts...)
+		return c.revokeTokensResult, err
+	case "cancel-sessions":
+		var err error
+		c.cancelSessionsResult, err = scopeClient.CancelSessions(c.Context, c.FlagId, c.FlagFilter, c.flagReason, opts...)
+		return c.cancelSessionsResult, err
+	}
+	return origResult, origError
+}
+
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "revoke-tokens":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(common.RevokeTokensTable(c.revokeTokensResult.RevokedTokenCount, c.revokeTokensResult.CanceledSessionIds))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.revokeTokensResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+		}
+
+	case "cancel-sessions":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(common.CancelSessionsTable(c.cancelSessionsResult.CanceledSessionCount, c.cancelSessionsResult.CanceledSessionIds))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.cancelSessionsResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (c *Command) printListTable(items []*scopes.Scope) string {
 	if len(items) == 0 {
 		return "No child scopes found"
@@ -0,0 +1,428 @@
+package serviceaccountscmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/serviceaccounts"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
+)
+
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
+	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
+}
+
+type extraCmdVars struct {
+	flagType           string
+	flagPublicKey      string
+	flagExpirationTime string
+	flagCredentialId   string
+	flagClientId       string
+	flagClientSecret   string
+	flagJwt            string
+
+	addCredentialResult *serviceaccounts.CredentialAddResult
+	authenticateResult  *serviceaccounts.AuthenticateResult
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"add-credential":    {"id", "type", "public-key", "expiration-time", "version"},
+		"remove-credential": {"id", "credential-id", "version"},
+		"authenticate":      {"id", "client-id", "client-secret", "jwt"},
+	}
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "add-credential":
+		return "Add a public key or client secret credential to a service account"
+
+	case "remove-credential":
+		return "Remove a credential from a service account"
+
+	case "authenticate":
+		return "Authenticate as a service account to receive an auth token"
+
+	default:
+		return ""
+	}
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "":
+		return helpMap["base"]()
+
+	case "add-credential":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary service-accounts add-credential [options] [args]",
+			"",
+			`  Adds a credential to a service account given its ID. A "jwt-public-key" credential verifies JWTs signed with the matching private key; a "client-secret" credential generates a secret which is only shown once. Examples:`,
+			"",
+			`    $ boundary service-accounts add-credential -id sa_1234567890 -type jwt-public-key -public-key file:///path/to/key.pub`,
+			"",
+			`    $ boundary service-accounts add-credential -id sa_1234567890 -type client-secret -expiration-time 2030-01-01T00:00:00Z`,
+			"",
+			"",
+		})
+
+	case "remove-credential":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary service-accounts remove-credential [options] [args]",
+			"",
+			"  Removes a credential from a service account given its ID. Example:",
+			"",
+			`    $ boundary service-accounts remove-credential -id sa_1234567890 -credential-id sacred_1234567890`,
+			"",
+			"",
+		})
+
+	case "authenticate":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary service-accounts authenticate [options] [args]",
+			"",
+			`  Authenticates as a service account given its ID, using either a client id and secret or a JWT whose subject is the service account ID, whose audience includes "/v1/service-accounts/<id>:authenticate" and which has a unique "jti" claim; each JWT can only be used once. The issued token can be used by setting the BOUNDARY_TOKEN environment variable. Examples:`,
+			"",
+			`    $ boundary service-accounts authenticate -id sa_1234567890 -client-id sacred_1234567890 -client-secret env://SA_SECRET`,
+			"",
+			`    $ boundary service-accounts authenticate -id sa_1234567890 -jwt file:///path/to/token.jwt`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case "type":
+			f.StringVar(&base.StringVar{
+				Name:   "type",
+				Target: &c.flagType,
+				Usage:  `The type of the credential, either "jwt-public-key" or "client-secret".`,
+			})
+		case "public-key":
+			f.StringVar(&base.StringVar{
+				Name:   "public-key",
+				Target: &c.flagPublicKey,
+				Usage:  "The PEM encoded public key of a jwt-public-key credential. May be a file:// or env:// reference.",
+			})
+		case "expiration-time":
+			f.StringVar(&base.StringVar{
+				Name:   "expiration-time",
+				Target: &c.flagExpirationTime,
+				Usage:  "The time, in RFC3339 format, after which the credential can no longer be used.",
+			})
+		case "credential-id":
+			f.StringVar(&base.StringVar{
+				Name:   "credential-id",
+				Target: &c.flagCredentialId,
+				Usage:  "The ID of the credential to remove.",
+			})
+		case "client-id":
+			f.StringVar(&base.StringVar{
+				Name:   "client-id",
+				Target: &c.flagClientId,
+				Usage:  "The ID of the client-secret credential to authenticate with.",
+			})
+		case "client-secret":
+			f.StringVar(&base.StringVar{
+				Name:   "client-secret",
+				Target: &c.flagClientSecret,
+				Usage:  "The client secret to authenticate with. May be a file:// or env:// reference.",
+			})
+		case "jwt":
+			f.StringVar(&base.StringVar{
+				Name:   "jwt",
+				Target: &c.flagJwt,
+				Usage:  "The signed JWT to authenticate with. May be a file:// or env:// reference.",
+			})
+		}
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]serviceaccounts.Option) bool {
+	switch c.Func {
+	case "add-credential":
+		if c.flagType == "" {
+			c.UI.Error("No credential type supplied via -type")
+			return false
+		}
+		if c.flagPublicKey != "" {
+			pk, err := parseutil.ParsePath(c.flagPublicKey)
+			if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+				c.UI.Error(fmt.Sprintf("Error parsing public key: %s", err))
+				return false
+			}
+			*opts = append(*opts, serviceaccounts.WithPublicKey(pk))
+		}
+		if c.flagExpirationTime != "" {
+			t, err := time.Parse(time.RFC3339, c.flagExpirationTime)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing -expiration-time: %s", err))
+				return false
+			}
+			*opts = append(*opts, serviceaccounts.WithCredentialExpirationTime(t))
+		}
+
+	case "remove-credential":
+		if c.flagCredentialId == "" {
+			c.UI.Error("No credential supplied via -credential-id")
+			return false
+		}
+
+	case "authenticate":
+		switch {
+		case c.flagJwt != "" && (c.flagClientId != "" || c.flagClientSecret != ""):
+			c.UI.Error("Only one of -jwt or -client-id and -client-secret can be supplied")
+			return false
+		case c.flagJwt != "":
+			jwt, err := parseutil.ParsePath(c.flagJwt)
+			if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+				c.UI.Error(fmt.Sprintf("Error parsing jwt: %s", err))
+				return false
+			}
+			c.flagJwt = jwt
+		case c.flagClientId == "" || c.flagClientSecret == "":
+			c.UI.Error("Either -jwt or both -client-id and -client-secret must be supplied")
+			return false
+		default:
+			secret, err := parseutil.ParsePath(c.flagClientSecret)
+			if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+				c.UI.Error(fmt.Sprintf("Error parsing client secret: %s", err))
+				return false
+			}
+			c.flagClientSecret = secret
+		}
+	}
+
+	return true
+}
+
+func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError error, serviceAccountClient *serviceaccounts.Client, version uint32, opts []serviceaccounts.Option) (api.GenericResult, error) {
+	switch c.Func {
+	case "add-credential":
+		var err error
+		c.addCredentialResult, err = serviceAccountClient.AddCredential(c.Context, c.FlagId, version, c.flagType, opts...)
+		return c.addCredentialResult, err
+	case "remove-credential":
+		return serviceAccountClient.RemoveCredential(c.Context, c.FlagId, version, c.flagCredentialId, opts...)
+	case "authenticate":
+		var err error
+		if c.flagJwt != "" {
+			c.authenticateResult, err = serviceAccountClient.AuthenticateWithJwt(c.Context, c.FlagId, c.flagJwt, opts...)
+		} else {
+			c.authenticateResult, err = serviceAccountClient.AuthenticateWithSecret(c.Context, c.FlagId, c.flagClientId, c.flagClientSecret, opts...)
+		}
+		return c.authenticateResult, err
+	}
+	return origResult, origError
+}
+
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "add-credential":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printItemTable(c.addCredentialResult))
+			c.UI.Output(printAddedCredential(c.addCredentialResult.Credential))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.addCredentialResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+		}
+	case "authenticate":
+		tok := c.authenticateResult.Item
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(base.WrapForHelpText([]string{
+				"",
+				"Authentication information:",
+				fmt.Sprintf("  Auth Token ID:      %s", tok.Id),
+				fmt.Sprintf("  Expiration Time:    %s", tok.ExpirationTime.Local().Format(time.RFC1123)),
+				fmt.Sprintf("  Service Account ID: %s", tok.ServiceAccountId),
+				fmt.Sprintf("  Token:              %s", tok.Token),
+			}))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.authenticateResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// printAddedCredential formats a newly added credential, including the client
+// secret which is only shown once.
+func printAddedCredential(cred *serviceaccounts.Credential) string {
+	if cred == nil {
+		return ""
+	}
+	ret := []string{
+		"",
+		"Added credential:",
+		base.WrapMap(2, len("Client Secret")+2, credentialMap(cred)),
+	}
+	if cred.ClientSecret != "" {
+		ret = append(ret,
+			"",
+			"  The client secret will not be shown again. Store it in a safe place; the",
+			"  credential ID is used as the client ID.",
+		)
+	}
+	return base.WrapForHelpText(ret)
+}
+
+func credentialMap(cred *serviceaccounts.Credential) map[string]interface{} {
+	m := map[string]interface{}{
+		"ID":   cred.Id,
+		"Type": cred.Type,
+	}
+	if !cred.CreatedTime.IsZero() {
+		m["Created Time"] = cred.CreatedTime.Local().Format(time.RFC1123)
+	}
+	if !cred.ExpirationTime.IsZero() {
+		m["Expiration Time"] = cred.ExpirationTime.Local().Format(time.RFC1123)
+	}
+	if cred.ClientSecret != "" {
+		m["Client Secret"] = cred.ClientSecret
+	}
+	return m
+}
+
+func (c *Command) printListTable(items []*serviceaccounts.ServiceAccount) string {
+	if len(items) == 0 {
+		return "No service accounts found"
+	}
+	var output []string
+	output = []string{
+		"",
+		"Service Account information:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		if item.Id != "" {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", item.Id),
+			)
+		} else {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", "(not available)"),
+			)
+		}
+		if c.FlagRecursive && item.ScopeId != "" {
+			output = append(output,
+				fmt.Sprintf("    Scope ID:            %s", item.ScopeId),
+			)
+		}
+		if item.Version > 0 {
+			output = append(output,
+				fmt.Sprintf("    Version:             %d", item.Version),
+			)
+		}
+		if item.Name != "" {
+			output = append(output,
+				fmt.Sprintf("    Name:                %s", item.Name),
+			)
+		}
+		if item.Description != "" {
+			output = append(output,
+				fmt.Sprintf("    Description:         %s", item.Description),
+			)
+		}
+		if len(item.AuthorizedActions) > 0 {
+			output = append(output,
+				"    Authorized Actions:",
+				base.WrapSlice(6, item.AuthorizedActions),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+func printItemTable(result api.GenericResult) string {
+	item := result.GetItem().(*serviceaccounts.ServiceAccount)
+	nonAttributeMap := map[string]interface{}{}
+	if item.Id != "" {
+		nonAttributeMap["ID"] = item.Id
+	}
+	if item.Version != 0 {
+		nonAttributeMap["Version"] = item.Version
+	}
+	if !item.CreatedTime.IsZero() {
+		nonAttributeMap["Created Time"] = item.CreatedTime.Local().Format(time.RFC1123)
+	}
+	if !item.UpdatedTime.IsZero() {
+		nonAttributeMap["Updated Time"] = item.UpdatedTime.Local().Format(time.RFC1123)
+	}
+	if item.Name != "" {
+		nonAttributeMap["Name"] = item.Name
+	}
+	if item.Description != "" {
+		nonAttributeMap["Description"] = item.Description
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+	if len(item.Credentials) > 0 {
+		if l := len("Expiration Time"); l > maxLength {
+			maxLength = l
+		}
+	}
+
+	ret := []string{
+		"",
+		"Service Account information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if item.Scope != nil {
+		ret = append(ret,
+			"",
+			"  Scope:",
+			base.ScopeInfoForOutput(item.Scope, maxLength),
+		)
+	}
+
+	if len(item.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, item.AuthorizedActions),
+		)
+	}
+
+	if len(item.Credentials) > 0 {
+		ret = append(ret,
+			"",
+			"  Credentials:",
+		)
+		for _, cred := range item.Credentials {
+			ret = append(ret,
+				base.WrapMap(4, maxLength, credentialMap(cred)),
+				"",
+			)
+		}
+	}
+
+	return base.WrapForHelpText(ret)
+}
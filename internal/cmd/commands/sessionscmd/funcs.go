@@ -6,21 +6,62 @@ import (
 	"time"
 
 	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/scopes"
 	"github.com/hashicorp/boundary/api/sessions"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
 )
 
 func init() {
 	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
+	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
 	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
+}
+
+type extraCmdVars struct {
+	flagReason string
+
+	cancelSessionsResult *scopes.CancelSessionsResult
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"cancel": {"id"},
+		// The id flag is registered in extraFlagsFuncImpl as it is not
+		// required when canceling the sessions matching a filter.
+		"cancel": {"scope-id", common.CancelSessionsFilterFlagName, common.CancelSessionsReasonFlagName},
 	}
 }
 
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	common.PopulateCancelSessionsFlags(c.Command, f, flagsMap[c.Func], &c.flagReason)
+	if c.Func == "cancel" {
+		f.StringVar(&base.StringVar{
+			Name:   "id",
+			Target: &c.FlagId,
+			Usage:  "ID of the session to cancel. Mutually exclusive with -filter.",
+		})
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]sessions.Option) bool {
+	if c.Func == "cancel" {
+		switch {
+		case c.FlagId == "" && c.FlagFilter == "":
+			c.UI.Error("Either an ID must be passed in via -id or a filter via -filter")
+			return false
+		case c.FlagId != "" && c.FlagFilter != "":
+			c.UI.Error("Cannot specify both an ID and a filter")
+			return false
+		}
+	}
+	if c.flagReason != "" {
+		*opts = append(*opts, sessions.WithCancelReason(c.flagReason))
+	}
+	return true
+}
+
 func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 	var helpStr string
 	switch c.Func {
@@ -43,7 +84,11 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			"  Cancel the session specified by ID. If the session is already canceled, this command succeeds with no effect. Example:",
 			"",
-			`    $ boundary sessions cancel -id s_1234567890`,
+			`    $ boundary sessions cancel -id s_1234567890 -reason "Scheduled maintenance"`,
+			"",
+			`  If "filter" is set instead of an ID, all non-terminated sessions in the scope given by "scope-id" and its child scopes which match the filter are canceled. Example:`,
+			"",
+			`    $ boundary sessions cancel -scope-id o_1234567890 -filter '"/item/target_id" == "ttcp_1234567890"'`,
 			"",
 			"",
 		})
@@ -58,11 +103,34 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError error, sessionClient *sessions.Client, version uint32, opts []sessions.Option) (api.GenericResult, error) {
 	switch c.Func {
 	case "cancel":
+		if c.FlagFilter != "" {
+			var err error
+			c.cancelSessionsResult, err = scopes.NewClient(sessionClient.ApiClient()).CancelSessions(c.Context, c.FlagScopeId, c.FlagFilter, c.flagReason)
+			return c.cancelSessionsResult, err
+		}
 		return sessionClient.Cancel(c.Context, c.FlagId, version, opts...)
 	}
 	return origResult, origError
 }
 
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	if c.cancelSessionsResult == nil {
+		return false, nil
+	}
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(common.CancelSessionsTable(c.cancelSessionsResult.CanceledSessionCount, c.cancelSessionsResult.CanceledSessionIds))
+		return true, nil
+
+	case "json":
+		if ok := c.PrintJsonItem(c.cancelSessionsResult); !ok {
+			return false, fmt.Errorf("Error formatting as JSON")
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
 func (c *Command) printListTable(items []*sessions.Session) string {
 	if len(items) == 0 {
 		return "No sessions found"
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/boundary/api/targets"
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
 	"github.com/hashicorp/boundary/internal/credential"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
@@ -36,12 +37,18 @@ type extraCmdVars struct {
 	flagApplicationCredentialSources   []string
 	flagEgressCredentialSources        []string
 	flagHostId                         string
+	flagReason                         string
 	sar                                *targets.SessionAuthorizationResult
+	listResult                         *targets.TargetListResult
+	cancelSessionsResult               *targets.CancelSessionsResult
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
+		"list":                        {"label"},
+		"list-authorized":             {"filter", "label"},
 		"authorize-session":           {"id", "host-id"},
+		"cancel-sessions":             {"id", common.CancelSessionsFilterFlagName, common.CancelSessionsReasonFlagName},
 		"add-host-sets":               {"id", "host-set", "version"},
 		"remove-host-sets":            {"id", "host-set", "version"},
 		"set-host-sets":               {"id", "host-set", "version"},
@@ -110,6 +117,12 @@ func extraSynopsisFuncImpl(c *Command) string {
 	case "authorize-session":
 		return "Request session authorization against the target"
 
+	case "list-authorized":
+		return "List the targets in all scopes against which sessions can be authorized"
+
+	case "cancel-sessions":
+		return "Cancel the sessions of a target"
+
 	default:
 		return ""
 	}
@@ -140,6 +153,10 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			`      $ boundary targets create tcp -name prodops -description "For ProdOps usage"`,
 			"",
+			"    Create a kubernetes-type target:",
+			"",
+			`      $ boundary targets create kubernetes -name prodops-k8s -description "For ProdOps usage"`,
+			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
 	case "update":
@@ -310,6 +327,18 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			"",
 		})
+	case "list-authorized":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary targets list-authorized [options] [args]",
+			"",
+			"  This command lists the targets in any scope against which the caller can request session authorization. Example:",
+			"",
+			"    List the authorized targets labeled env=prod:",
+			"",
+			`      $ boundary targets list-authorized -label env=prod`,
+			"",
+			"",
+		})
 	case "authorize-session":
 		helpStr = base.WrapForHelpText([]string{
 			"Usage: boundary target authorize-session [options] [args]",
@@ -326,11 +355,24 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			"",
 		})
+	case "cancel-sessions":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary targets cancel-sessions [options] [args]",
+			"",
+			`  This command cancels all non-terminated sessions of a target. If "filter" is set, only the sessions matching it are canceled. Example:`,
+			"",
+			"    Cancel the sessions of a user on a target:",
+			"",
+			`      $ boundary targets cancel-sessions -id ttcp_1234567890 -filter '"/item/user_id" == "u_1234567890"' -reason "Maintenance"`,
+			"",
+			"",
+		})
 	}
 	return helpStr + c.Flags().Help()
 }
 
 func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	common.PopulateCancelSessionsFlags(c.Command, f, flagsMap[c.Func], &c.flagReason)
 	for _, name := range flagsMap[c.Func] {
 		switch name {
 		case "host-set":
@@ -539,6 +581,11 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]targets.Op
 		if len(c.flagHostId) != 0 {
 			*opts = append(*opts, targets.WithHostId(c.flagHostId))
 		}
+
+	case "list", "list-authorized":
+		if len(c.FlagLabels) > 0 {
+			*opts = append(*opts, targets.WithLabelSelector(strings.Join(c.FlagLabels, ",")))
+		}
 	}
 
 	return true
@@ -575,6 +622,16 @@ func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError
 		c.plural = "a session against target"
 		c.sar, err = targetClient.AuthorizeSession(c.Context, c.FlagId, opts...)
 		return nil, err
+	case "cancel-sessions":
+		var err error
+		c.cancelSessionsResult, err = targetClient.CancelSessions(c.Context, c.FlagId, c.FlagFilter, c.flagReason, opts...)
+		return c.cancelSessionsResult, err
+	case "list-authorized":
+		var err error
+		c.plural = "authorized targets"
+		opts = append(opts, targets.WithAuthorized(true))
+		c.listResult, err = targetClient.List(c.Context, scope.Global.String(), opts...)
+		return nil, err
 	}
 	return origResult, origError
 }
@@ -601,7 +658,7 @@ func (c *Command) printListTable(items []*targets.Target) string {
 				fmt.Sprintf("  ID:                    %s", "(not available)"),
 			)
 		}
-		if c.FlagRecursive && item.ScopeId != "" {
+		if (c.FlagRecursive || c.Func == "list-authorized") && item.ScopeId != "" {
 			output = append(output,
 				fmt.Sprintf("    Scope ID:            %s", item.ScopeId),
 			)
@@ -664,6 +721,18 @@ func printItemTable(result api.GenericResult) string {
 	if item.WorkerFilter != "" {
 		nonAttributeMap["Worker Filter"] = item.WorkerFilter
 	}
+	if item.ConnectionIdleTimeoutSeconds != 0 {
+		nonAttributeMap["Connection Idle Timeout Seconds"] = item.ConnectionIdleTimeoutSeconds
+	}
+	if item.MaxConcurrentConnections != 0 {
+		nonAttributeMap["Max Concurrent Connections"] = item.MaxConcurrentConnections
+	}
+	if item.MaxBytesPerSession != 0 {
+		nonAttributeMap["Max Bytes Per Session"] = item.MaxBytesPerSession
+	}
+	if item.WorkerSelectionStrategy != "" {
+		nonAttributeMap["Worker Selection Strategy"] = item.WorkerSelectionStrategy
+	}
 	if result.GetResponse() != nil && result.GetResponse().Map != nil {
 		if result.GetResponse().Map[globals.SessionConnectionLimitField] != nil {
 			nonAttributeMap["Session Connection Limit"] = item.SessionConnectionLimit
@@ -737,6 +806,24 @@ func printItemTable(result api.GenericResult) string {
 		}
 	}
 
+	if len(item.EgressCredentialSources) > 0 {
+		if credentialSourceMaps == nil {
+			credentialSourceMaps = make(map[credential.Purpose][]map[string]interface{})
+		}
+		var egressCredentialSourceMaps []map[string]interface{}
+		for _, lib := range item.EgressCredentialSources {
+			m := map[string]interface{}{
+				"ID":                  lib.Id,
+				"Credential Store ID": lib.CredentialStoreId,
+			}
+			egressCredentialSourceMaps = append(egressCredentialSourceMaps, m)
+		}
+		credentialSourceMaps[credential.EgressPurpose] = egressCredentialSourceMaps
+		if l := len("Credential Store ID"); l > maxLength {
+			maxLength = l
+		}
+	}
+
 	ret := []string{
 		"",
 		"Target information:",
@@ -759,6 +846,18 @@ func printItemTable(result api.GenericResult) string {
 		)
 	}
 
+	if len(item.Labels) > 0 {
+		labels := make(map[string]interface{}, len(item.Labels))
+		for k, v := range item.Labels {
+			labels[k] = v
+		}
+		ret = append(ret,
+			"",
+			"  Labels:",
+			base.WrapMap(4, 0, labels),
+		)
+	}
+
 	ret = append(ret,
 		"",
 	)
@@ -787,6 +886,17 @@ func printItemTable(result api.GenericResult) string {
 				)
 			}
 		}
+		if egressMap := credentialSourceMaps[credential.EgressPurpose]; len(egressMap) > 0 {
+			ret = append(ret,
+				"  Egress Credential Sources:",
+			)
+			for _, m := range egressMap {
+				ret = append(ret,
+					base.WrapMap(4, maxLength, m),
+					"",
+				)
+			}
+		}
 	}
 
 	if len(item.Attributes) > 0 {
@@ -801,6 +911,30 @@ func printItemTable(result api.GenericResult) string {
 
 func printCustomActionOutputImpl(c *Command) (bool, error) {
 	switch c.Func {
+	case "cancel-sessions":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(common.CancelSessionsTable(c.cancelSessionsResult.CanceledSessionCount, c.cancelSessionsResult.CanceledSessionIds))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.cancelSessionsResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+		}
+
+	case "list-authorized":
+		switch base.Format(c.UI) {
+		case "json":
+			if ok := c.PrintJsonItems(c.listResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+		case "table":
+			c.UI.Output(c.printListTable(c.listResult.Items))
+		}
+		return true, nil
+
 	case "authorize-session":
 		item := c.sar.GetItem().(*targets.SessionAuthorization)
 
@@ -0,0 +1,247 @@
+package targetscmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/go-bexpr"
+)
+
+func init() {
+	extraKubernetesActionsFlagsMapFunc = extraKubernetesActionsFlagsMapFuncImpl
+	extraKubernetesFlagsFunc = extraKubernetesFlagsFuncImpl
+	extraKubernetesFlagsHandlingFunc = extraKubernetesFlagsHandlingFuncImpl
+}
+
+func extraKubernetesActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {"default-port", "session-max-seconds", "session-connection-limit", "worker-filter", "connection-idle-timeout", "max-concurrent-connections", "max-bytes-per-session", "worker-selection-strategy", "label"},
+		"update": {"default-port", "session-max-seconds", "session-connection-limit", "worker-filter", "connection-idle-timeout", "max-concurrent-connections", "max-bytes-per-session", "worker-selection-strategy", "label"},
+	}
+}
+
+type extraKubernetesCmdVars struct {
+	flagDefaultPort            string
+	flagSessionMaxSeconds      string
+	flagSessionConnectionLimit string
+	flagWorkerFilter           string
+
+	flagConnectionIdleTimeout    string
+	flagMaxConcurrentConnections string
+	flagMaxBytesPerSession       string
+	flagWorkerSelectionStrategy  string
+}
+
+func (c *KubernetesCommand) extraKubernetesHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary targets create kubernetes [options] [args]",
+			"",
+			"  Create a kubernetes-type target. Example:",
+			"",
+			`    $ boundary targets create kubernetes -name prodops-k8s -description "Kubernetes API server for ProdOps"`,
+			"",
+			"",
+		})
+
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary targets update kubernetes [options] [args]",
+			"",
+			"  Update a kubernetes-type target given its ID. Example:",
+			"",
+			`    $ boundary targets update kubernetes -id tkube_1234567890 -name "devops-k8s" -description "Kubernetes API server for DevOps"`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraKubernetesFlagsFuncImpl(c *KubernetesCommand, set *base.FlagSets, f *base.FlagSet) {
+	fs := set.NewFlagSet("Kubernetes Target Options")
+
+	for _, name := range flagsKubernetesMap[c.Func] {
+		switch name {
+		case "default-port":
+			fs.StringVar(&base.StringVar{
+				Name:   "default-port",
+				Target: &c.flagDefaultPort,
+				Usage:  "The default port to set on the target.",
+			})
+		case "session-max-seconds":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-max-seconds",
+				Target: &c.flagSessionMaxSeconds,
+				Usage:  `The maximum lifetime of the session, including all connections. Can be specified as an integer number of seconds or a duration string.`,
+			})
+		case "session-connection-limit":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-connection-limit",
+				Target: &c.flagSessionConnectionLimit,
+				Usage:  "The maximum number of connections allowed for a session. -1 means unlimited.",
+			})
+		case "worker-filter":
+			fs.StringVar(&base.StringVar{
+				Name:   "worker-filter",
+				Target: &c.flagWorkerFilter,
+				Usage:  "A boolean expression to filter which workers can handle sessions for this target.",
+			})
+		case "connection-idle-timeout":
+			fs.StringVar(&base.StringVar{
+				Name:   "connection-idle-timeout",
+				Target: &c.flagConnectionIdleTimeout,
+				Usage:  "The time a connection may go without transferring data before it is closed. Can be specified as an integer number of seconds or a duration string. 0 means no timeout.",
+			})
+		case "max-concurrent-connections":
+			fs.StringVar(&base.StringVar{
+				Name:   "max-concurrent-connections",
+				Target: &c.flagMaxConcurrentConnections,
+				Usage:  "The maximum number of connections of a session which may be open at the same time. 0 means unlimited.",
+			})
+		case "max-bytes-per-session":
+			fs.StringVar(&base.StringVar{
+				Name:   "max-bytes-per-session",
+				Target: &c.flagMaxBytesPerSession,
+				Usage:  "The maximum number of bytes which may be transferred over all connections of a session. 0 means unlimited.",
+			})
+		case "worker-selection-strategy":
+			fs.StringVar(&base.StringVar{
+				Name:   "worker-selection-strategy",
+				Target: &c.flagWorkerSelectionStrategy,
+				Usage:  `The strategy used to order the workers which can handle sessions for this target: "random", "least-connections", "lowest-latency" or "user-affinity". "lowest-latency" uses the "latency" tag of the workers.`,
+			})
+		}
+	}
+}
+
+func extraKubernetesFlagsHandlingFuncImpl(c *KubernetesCommand, _ *base.FlagSets, opts *[]targets.Option) bool {
+	switch c.flagDefaultPort {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultKubernetesTargetDefaultPort())
+	default:
+		port, err := strconv.ParseUint(c.flagDefaultPort, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagDefaultPort, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithKubernetesTargetDefaultPort(uint32(port)))
+	}
+
+	switch c.flagSessionMaxSeconds {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionMaxSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagSessionMaxSeconds, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagSessionMaxSeconds)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionMaxSeconds, err))
+				return false
+			}
+			final = uint32(dur.Seconds())
+		}
+		*opts = append(*opts, targets.WithSessionMaxSeconds(final))
+	}
+
+	switch c.flagSessionConnectionLimit {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionConnectionLimit())
+	default:
+		limit, err := strconv.ParseInt(c.flagSessionConnectionLimit, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionConnectionLimit, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithSessionConnectionLimit(int32(limit)))
+	}
+
+	switch c.flagWorkerFilter {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultWorkerFilter())
+	default:
+		if _, err := bexpr.CreateEvaluator(c.flagWorkerFilter); err != nil {
+			c.UI.Error(fmt.Sprintf("Unable to successfully parse filter expression: %s", err))
+			return false
+		}
+		*opts = append(*opts, targets.WithWorkerFilter(c.flagWorkerFilter))
+	}
+
+	switch c.flagConnectionIdleTimeout {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultConnectionIdleTimeoutSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagConnectionIdleTimeout, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagConnectionIdleTimeout)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagConnectionIdleTimeout, err))
+				return false
+			}
+			final = uint32(dur.Seconds())
+		}
+		*opts = append(*opts, targets.WithConnectionIdleTimeoutSeconds(final))
+	}
+
+	switch c.flagMaxConcurrentConnections {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultMaxConcurrentConnections())
+	default:
+		limit, err := strconv.ParseUint(c.flagMaxConcurrentConnections, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMaxConcurrentConnections, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithMaxConcurrentConnections(uint32(limit)))
+	}
+
+	switch c.flagMaxBytesPerSession {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultMaxBytesPerSession())
+	default:
+		limit, err := strconv.ParseUint(c.flagMaxBytesPerSession, 10, 64)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMaxBytesPerSession, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithMaxBytesPerSession(limit))
+	}
+
+	switch c.flagWorkerSelectionStrategy {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultWorkerSelectionStrategy())
+	default:
+		*opts = append(*opts, targets.WithWorkerSelectionStrategy(c.flagWorkerSelectionStrategy))
+	}
+
+	if err := common.HandleLabelFlags(
+		c.FlagLabels,
+		func() { *opts = append(*opts, targets.DefaultLabels()) },
+		func(in map[string]string) { *opts = append(*opts, targets.WithLabels(in)) },
+	); err != nil {
+		c.UI.Error(fmt.Sprintf("Error parsing labels: %s", err))
+		return false
+	}
+
+	return true
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/boundary/api/targets"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
 	"github.com/hashicorp/go-bexpr"
 )
 
@@ -18,8 +19,8 @@ func init() {
 
 func extraTcpActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {"default-port", "session-max-seconds", "session-connection-limit", "worker-filter"},
-		"update": {"default-port", "session-max-seconds", "session-connection-limit", "worker-filter"},
+		"create": {"default-port", "session-max-seconds", "session-connection-limit", "worker-filter", "connection-idle-timeout", "max-concurrent-connections", "max-bytes-per-session", "worker-selection-strategy", "label"},
+		"update": {"default-port", "session-max-seconds", "session-connection-limit", "worker-filter", "connection-idle-timeout", "max-concurrent-connections", "max-bytes-per-session", "worker-selection-strategy", "label"},
 	}
 }
 
@@ -28,6 +29,11 @@ type extraTcpCmdVars struct {
 	flagSessionMaxSeconds      string
 	flagSessionConnectionLimit string
 	flagWorkerFilter           string
+
+	flagConnectionIdleTimeout    string
+	flagMaxConcurrentConnections string
+	flagMaxBytesPerSession       string
+	flagWorkerSelectionStrategy  string
 }
 
 func (c *TcpCommand) extraTcpHelpFunc(helpMap map[string]func() string) string {
@@ -87,6 +93,30 @@ func extraTcpFlagsFuncImpl(c *TcpCommand, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagWorkerFilter,
 				Usage:  "A boolean expression to filter which workers can handle sessions for this target.",
 			})
+		case "connection-idle-timeout":
+			fs.StringVar(&base.StringVar{
+				Name:   "connection-idle-timeout",
+				Target: &c.flagConnectionIdleTimeout,
+				Usage:  "The time a connection may go without transferring data before it is closed. Can be specified as an integer number of seconds or a duration string. 0 means no timeout.",
+			})
+		case "max-concurrent-connections":
+			fs.StringVar(&base.StringVar{
+				Name:   "max-concurrent-connections",
+				Target: &c.flagMaxConcurrentConnections,
+				Usage:  "The maximum number of connections of a session which may be open at the same time. 0 means unlimited.",
+			})
+		case "max-bytes-per-session":
+			fs.StringVar(&base.StringVar{
+				Name:   "max-bytes-per-session",
+				Target: &c.flagMaxBytesPerSession,
+				Usage:  "The maximum number of bytes which may be transferred over all connections of a session. 0 means unlimited.",
+			})
+		case "worker-selection-strategy":
+			fs.StringVar(&base.StringVar{
+				Name:   "worker-selection-strategy",
+				Target: &c.flagWorkerSelectionStrategy,
+				Usage:  `The strategy used to order the workers which can handle sessions for this target: "random", "least-connections", "lowest-latency" or "user-affinity". "lowest-latency" uses the "latency" tag of the workers.`,
+			})
 		}
 	}
 }
@@ -150,5 +180,68 @@ func extraTcpFlagsHandlingFuncImpl(c *TcpCommand, _ *base.FlagSets, opts *[]targ
 		*opts = append(*opts, targets.WithWorkerFilter(c.flagWorkerFilter))
 	}
 
+	switch c.flagConnectionIdleTimeout {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultConnectionIdleTimeoutSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagConnectionIdleTimeout, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagConnectionIdleTimeout)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagConnectionIdleTimeout, err))
+				return false
+			}
+			final = uint32(dur.Seconds())
+		}
+		*opts = append(*opts, targets.WithConnectionIdleTimeoutSeconds(final))
+	}
+
+	switch c.flagMaxConcurrentConnections {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultMaxConcurrentConnections())
+	default:
+		limit, err := strconv.ParseUint(c.flagMaxConcurrentConnections, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMaxConcurrentConnections, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithMaxConcurrentConnections(uint32(limit)))
+	}
+
+	switch c.flagMaxBytesPerSession {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultMaxBytesPerSession())
+	default:
+		limit, err := strconv.ParseUint(c.flagMaxBytesPerSession, 10, 64)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMaxBytesPerSession, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithMaxBytesPerSession(limit))
+	}
+
+	switch c.flagWorkerSelectionStrategy {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultWorkerSelectionStrategy())
+	default:
+		*opts = append(*opts, targets.WithWorkerSelectionStrategy(c.flagWorkerSelectionStrategy))
+	}
+
+	if err := common.HandleLabelFlags(
+		c.FlagLabels,
+		func() { *opts = append(*opts, targets.DefaultLabels()) },
+		func(in map[string]string) { *opts = append(*opts, targets.WithLabels(in)) },
+	); err != nil {
+		c.UI.Error(fmt.Sprintf("Error parsing labels: %s", err))
+		return false
+	}
+
 	return true
 }
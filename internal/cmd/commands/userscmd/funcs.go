@@ -8,7 +8,9 @@ import (
 	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/users"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
 	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
 )
 
 func init() {
@@ -17,10 +19,21 @@ func init() {
 	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
 	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
 }
 
 type extraCmdVars struct {
-	flagAccounts []string
+	flagAccounts       []string
+	flagCancelSessions bool
+	flagDisabled       string
+	flagStartTime      string
+	flagEndTime        string
+	flagLimit          uint64
+	flagReason         string
+
+	revokeTokensResult   *users.RevokeTokensResult
+	readActivityResult   *users.UserActivityReadResult
+	cancelSessionsResult *users.CancelSessionsResult
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
@@ -28,6 +41,11 @@ func extraActionsFlagsMapFuncImpl() map[string][]string {
 		"add-accounts":    {"id", "account", "version"},
 		"set-accounts":    {"id", "account", "version"},
 		"remove-accounts": {"id", "account", "version"},
+		"revoke-tokens":   {"id", common.RevokeTokensFlagName},
+		"read-activity":   {"id", "scope-id", "start-time", "end-time", "limit"},
+		"cancel-sessions": {"id", common.CancelSessionsFilterFlagName, common.CancelSessionsReasonFlagName},
+		"create":          {common.DisabledFlagName},
+		"update":          {common.DisabledFlagName},
 	}
 }
 
@@ -44,6 +62,15 @@ func extraSynopsisFuncImpl(c *Command) string {
 			in = "Remove accounts from"
 		}
 		return wordwrap.WrapString(fmt.Sprintf("%s a user within Boundary", in), base.TermWidth)
+
+	case "revoke-tokens":
+		return "Revoke all auth tokens of a user"
+
+	case "read-activity":
+		return "Read the authentication and session authorization history of a user"
+
+	case "cancel-sessions":
+		return "Cancel the sessions of a user"
 	}
 
 	return ""
@@ -85,6 +112,39 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 		})
 
+	case "revoke-tokens":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary users revoke-tokens [options] [args]",
+			"",
+			`  Revokes all auth tokens of a user given its ID. If "cancel-sessions" is set, the sessions of the user are canceled as well. Example:`,
+			"",
+			`    $ boundary users revoke-tokens -id u_1234567890 -cancel-sessions`,
+			"",
+			"",
+		})
+
+	case "read-activity":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary users read-activity [options] [args]",
+			"",
+			`  Reads the authentication and session authorization history of a user given its ID, most recent first. If "scope-id" is set, only the activities which happened in that scope and its child scopes are returned. Example:`,
+			"",
+			`    $ boundary users read-activity -id u_1234567890 -scope-id o_1234567890 -start-time 2021-10-01T00:00:00Z`,
+			"",
+			"",
+		})
+
+	case "cancel-sessions":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary users cancel-sessions [options] [args]",
+			"",
+			`  Cancels all non-terminated sessions of a user given its ID. If "filter" is set, only the sessions matching it are canceled. Example:`,
+			"",
+			`    $ boundary users cancel-sessions -id u_1234567890 -filter '"/item/target_id" == "ttcp_1234567890"' -reason "Compromised credentials"`,
+			"",
+			"",
+		})
+
 	default:
 		helpStr = helpMap["base"]()
 	}
@@ -92,6 +152,7 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 }
 
 func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	common.PopulateCancelSessionsFlags(c.Command, f, flagsMap[c.Func], &c.flagReason)
 	for _, name := range flagsMap[c.Func] {
 		switch name {
 		case "account":
@@ -100,6 +161,30 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagAccounts,
 				Usage:  "The accounts to add, remove, or set. May be specified multiple times.",
 			})
+		case common.RevokeTokensFlagName:
+			common.PopulateRevokeTokensFlags(f, &c.flagCancelSessions)
+		case common.DisabledFlagName:
+			common.PopulateDisabledFlag(f, &c.flagDisabled, "user")
+		case "start-time":
+			f.StringVar(&base.StringVar{
+				Name:       "start-time",
+				Target:     &c.flagStartTime,
+				Completion: complete.PredictAnything,
+				Usage:      "Only include activities at or after the given RFC 3339 time.",
+			})
+		case "end-time":
+			f.StringVar(&base.StringVar{
+				Name:       "end-time",
+				Target:     &c.flagEndTime,
+				Completion: complete.PredictAnything,
+				Usage:      "Only include activities before the given RFC 3339 time.",
+			})
+		case "limit":
+			f.Uint64Var(&base.Uint64Var{
+				Name:   "limit",
+				Target: &c.flagLimit,
+				Usage:  "The maximum number of activities to return. Defaults to the controller's default limit.",
+			})
 		}
 	}
 }
@@ -122,6 +207,47 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]users.Opti
 				c.flagAccounts = nil
 			}
 		}
+
+	case "create", "update":
+		disabled, set, err := common.ParseDisabledFlag(c.flagDisabled)
+		switch {
+		case err != nil:
+			c.UI.Error(err.Error())
+			return false
+		case !set:
+		case disabled == nil:
+			*opts = append(*opts, users.DefaultDisabled())
+		default:
+			*opts = append(*opts, users.WithDisabled(*disabled))
+		}
+
+	case "read-activity":
+		if c.flagLimit > uint64(^uint32(0)) {
+			c.UI.Error(fmt.Sprintf("-limit must be less than or equal to %d", ^uint32(0)))
+			return false
+		}
+		if c.FlagScopeId != "" {
+			*opts = append(*opts, users.WithActivityScopeId(c.FlagScopeId))
+		}
+		if c.flagStartTime != "" {
+			t, err := time.Parse(time.RFC3339, c.flagStartTime)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing -start-time: %s", err))
+				return false
+			}
+			*opts = append(*opts, users.WithStartTime(t))
+		}
+		if c.flagEndTime != "" {
+			t, err := time.Parse(time.RFC3339, c.flagEndTime)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing -end-time: %s", err))
+				return false
+			}
+			*opts = append(*opts, users.WithEndTime(t))
+		}
+		if c.flagLimit > 0 {
+			*opts = append(*opts, users.WithLimit(uint32(c.flagLimit)))
+		}
 	}
 
 	return true
@@ -135,10 +261,66 @@ func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError
 		return userClient.SetAccounts(c.Context, c.FlagId, version, c.flagAccounts, opts...)
 	case "remove-accounts":
 		return userClient.RemoveAccounts(c.Context, c.FlagId, version, c.flagAccounts, opts...)
+	case "revoke-tokens":
+		var err error
+		c.revokeTokensResult, err = userClient.RevokeTokens(c.Context, c.FlagId, c.flagCancelSessions, opts...)
+		return c.revokeTokensResult, err
+	case "read-activity":
+		var err error
+		c.readActivityResult, err = userClient.ReadActivity(c.Context, c.FlagId, opts...)
+		return origResult, err
+	case "cancel-sessions":
+		var err error
+		c.cancelSessionsResult, err = userClient.CancelSessions(c.Context, c.FlagId, c.FlagFilter, c.flagReason, opts...)
+		return c.cancelSessionsResult, err
 	}
 	return origResult, origError
 }
 
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "revoke-tokens":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(common.RevokeTokensTable(c.revokeTokensResult.RevokedTokenCount, c.revokeTokensResult.CanceledSessionIds))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.revokeTokensResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+		}
+
+	case "read-activity":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printActivityTable(c.readActivityResult.Items))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItems(c.readActivityResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+		}
+
+	case "cancel-sessions":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(common.CancelSessionsTable(c.cancelSessionsResult.CanceledSessionCount, c.cancelSessionsResult.CanceledSessionIds))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.cancelSessionsResult); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (c *Command) printListTable(items []*users.User) string {
 	if len(items) == 0 {
 		return "No users found"
@@ -203,6 +385,11 @@ func (c *Command) printListTable(items []*users.User) string {
 				fmt.Sprintf("    Email:               %s", item.Email),
 			)
 		}
+		if item.Disabled {
+			output = append(output,
+				fmt.Sprintf("    Disabled:            %t", item.Disabled),
+			)
+		}
 
 		if len(item.AuthorizedActions) > 0 {
 			output = append(output,
@@ -248,6 +435,7 @@ func printItemTable(result api.GenericResult) string {
 	if item.Email != "" {
 		nonAttributeMap["Email"] = item.Email
 	}
+	nonAttributeMap["Disabled"] = item.Disabled
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
 
@@ -302,3 +490,51 @@ func printItemTable(result api.GenericResult) string {
 
 	return base.WrapForHelpText(ret)
 }
+
+func printActivityTable(items []*users.UserActivity) string {
+	if len(items) == 0 {
+		return "No activities found"
+	}
+
+	output := []string{
+		"",
+		"User activity:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  Type:                  %s", item.Type),
+			fmt.Sprintf("    Time:                %s", item.CreatedTime.Local().Format(time.RFC1123)),
+			fmt.Sprintf("    Scope ID:            %s", item.ScopeId),
+		)
+		if item.AuthMethodId != "" {
+			output = append(output,
+				fmt.Sprintf("    Auth Method ID:      %s", item.AuthMethodId),
+			)
+		}
+		if item.AccountId != "" {
+			output = append(output,
+				fmt.Sprintf("    Account ID:          %s", item.AccountId),
+			)
+		}
+		if item.TargetId != "" {
+			output = append(output,
+				fmt.Sprintf("    Target ID:           %s", item.TargetId),
+			)
+		}
+		if item.SessionId != "" {
+			output = append(output,
+				fmt.Sprintf("    Session ID:          %s", item.SessionId),
+			)
+		}
+		if item.Detail != "" {
+			output = append(output,
+				fmt.Sprintf("    Detail:              %s", item.Detail),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
@@ -0,0 +1,54 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+const (
+	// CancelSessionsFilterFlagName is the name of the flag which restricts
+	// the sessions canceled by a cancel-sessions subcommand.
+	CancelSessionsFilterFlagName = "filter"
+
+	// CancelSessionsReasonFlagName is the name of the flag which sets the
+	// reason the sessions are canceled.
+	CancelSessionsReasonFlagName = "reason"
+)
+
+// PopulateCancelSessionsFlags adds the flags of the cancel-sessions
+// subcommands which are in flagNames.
+func PopulateCancelSessionsFlags(c *base.Command, f *base.FlagSet, flagNames []string, reason *string) {
+	for _, name := range flagNames {
+		switch name {
+		case CancelSessionsFilterFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   CancelSessionsFilterFlagName,
+				Target: &c.FlagFilter,
+				Usage:  "If set, only the sessions matching the filter are canceled. The filter operates against each session. Using single quotes is recommended as filters contain double quotes. See https://www.boundaryproject.io/docs/concepts/filtering/resource-listing for details.",
+			})
+		case CancelSessionsReasonFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   CancelSessionsReasonFlagName,
+				Target: reason,
+				Usage:  "The reason the sessions are being canceled. It is shown to the users of the sessions when their connections are closed.",
+			})
+		}
+	}
+}
+
+// CancelSessionsTable formats the result of a cancel-sessions subcommand.
+func CancelSessionsTable(canceledSessionCount uint32, canceledSessionIds []string) string {
+	ret := []string{
+		"",
+		"Session cancellation:",
+		fmt.Sprintf("  Canceled Sessions:     %d", canceledSessionCount),
+	}
+	if len(canceledSessionIds) > 0 {
+		ret = append(ret,
+			"  Canceled Session IDs:",
+			base.WrapSlice(4, canceledSessionIds),
+		)
+	}
+	return base.WrapForHelpText(ret)
+}
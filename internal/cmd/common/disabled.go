@@ -0,0 +1,38 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+// DisabledFlagName is the name of the flag which disables or enables a user
+// or an account.
+const DisabledFlagName = "disabled"
+
+// PopulateDisabledFlag adds the flag which disables or enables a user or an
+// account.
+func PopulateDisabledFlag(f *base.FlagSet, target *string, resourceType string) {
+	f.StringVar(&base.StringVar{
+		Name:   DisabledFlagName,
+		Target: target,
+		Usage:  fmt.Sprintf(`Whether the %s is disabled. A disabled %s cannot authenticate, and its auth tokens are revoked and its sessions canceled. May be "true", "false" or "null".`, resourceType, resourceType),
+	})
+}
+
+// ParseDisabledFlag parses the value of the disabled flag. set is false if
+// the flag was not given; value is nil if it was set to "null".
+func ParseDisabledFlag(in string) (value *bool, set bool, err error) {
+	switch in {
+	case "":
+		return nil, false, nil
+	case "null":
+		return nil, true, nil
+	}
+	b, err := strconv.ParseBool(in)
+	if err != nil {
+		return nil, false, fmt.Errorf("Invalid value for -%s: %q", DisabledFlagName, in)
+	}
+	return &b, true, nil
+}
@@ -102,7 +102,7 @@ func PopulateCommonFlags(c *base.Command, f *base.FlagSet, resourceType string,
 			})
 		}
 	}
-	if command == "list" {
+	if strings.HasPrefix(command, "list") {
 		for _, name := range flagNames[command] {
 			switch name {
 			case "filter":
@@ -111,11 +111,54 @@ func PopulateCommonFlags(c *base.Command, f *base.FlagSet, resourceType string,
 					Target: &c.FlagFilter,
 					Usage:  "If set, the list operation will be filtered before being returned. The filter operates against each item in the list. Using single quotes is recommended as filters contain double quotes. See https://www.boundaryproject.io/docs/concepts/filtering/resource-listing for details.",
 				})
+			case "label":
+				f.StringSliceVar(&base.StringSliceVar{
+					Name:   "label",
+					Target: &c.FlagLabels,
+					Usage:  fmt.Sprintf("A key=value label which each listed %s must carry. Can be specified multiple times.", resourceType),
+				})
+			}
+		}
+	} else {
+		for _, name := range flagNames[command] {
+			switch name {
+			case "label":
+				f.StringSliceVar(&base.StringSliceVar{
+					Name:   "label",
+					Target: &c.FlagLabels,
+					Usage:  fmt.Sprintf(`A key=value label to set on the %s. Can be specified multiple times; the given labels replace all existing ones. Use "null" to remove all labels.`, resourceType),
+				})
 			}
 		}
 	}
 }
 
+// HandleLabelFlags parses the key=value labels given via -label and passes
+// them to setFunc. A single "null" label calls defaultFunc instead, clearing
+// the labels.
+func HandleLabelFlags(labels []string, defaultFunc func(), setFunc func(map[string]string)) error {
+	switch {
+	case len(labels) == 0:
+		return nil
+	case len(labels) == 1 && labels[0] == "null":
+		defaultFunc()
+		return nil
+	}
+	setMap := make(map[string]string, len(labels))
+	for _, l := range labels {
+		kv := strings.SplitN(l, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return fmt.Errorf("label %q is not of the form key=value", l)
+		}
+		if _, ok := setMap[kv[0]]; ok {
+			return fmt.Errorf("label key %q specified more than once", kv[0])
+		}
+		setMap[kv[0]] = kv[1]
+	}
+	setFunc(setMap)
+	return nil
+}
+
 func PopulateAttributeFlags(c *base.Command, f *base.FlagSet, flagNames map[string][]string, command string) {
 	keyDelimiter := "."
 	for _, name := range flagNames[command] {
@@ -411,3 +411,69 @@ func TestHandleAttributeFlags(t *testing.T) {
 		}
 	}
 }
+
+func TestHandleLabelFlags(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		expectedMap   map[string]string
+		expectDefault bool
+		expectedErr   string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name: "labels",
+			args: []string{"env=prod", "team=payments", "empty="},
+			expectedMap: map[string]string{
+				"env":   "prod",
+				"team":  "payments",
+				"empty": "",
+			},
+		},
+		{
+			name: "value-with-equals",
+			args: []string{"expr=a=b"},
+			expectedMap: map[string]string{
+				"expr": "a=b",
+			},
+		},
+		{
+			name:          "null",
+			args:          []string{"null"},
+			expectDefault: true,
+		},
+		{
+			name:        "missing-value",
+			args:        []string{"env"},
+			expectedErr: `label "env" is not of the form key=value`,
+		},
+		{
+			name:        "missing-key",
+			args:        []string{"=prod"},
+			expectedErr: `label "=prod" is not of the form key=value`,
+		},
+		{
+			name:        "duplicate-key",
+			args:        []string{"env=prod", "env=dev"},
+			expectedErr: `label key "env" specified more than once`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			var outMap map[string]string
+			var defaulted bool
+			err := HandleLabelFlags(tt.args, func() { defaulted = true }, func(in map[string]string) { outMap = in })
+			if tt.expectedErr != "" {
+				require.Error(err)
+				assert.Equal(tt.expectedErr, err.Error())
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.expectDefault, defaulted)
+			assert.Equal(tt.expectedMap, outMap)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+// RevokeTokensFlagName is the name of the flag which requests that the
+// sessions of the affected users are canceled when revoking auth tokens.
+const RevokeTokensFlagName = "cancel-sessions"
+
+// PopulateRevokeTokensFlags adds the flags of the revoke-tokens subcommands.
+func PopulateRevokeTokensFlags(f *base.FlagSet, target *bool) {
+	f.BoolVar(&base.BoolVar{
+		Name:   RevokeTokensFlagName,
+		Target: target,
+		Usage:  "If set, the sessions of the users whose auth tokens are revoked are canceled as well.",
+	})
+}
+
+// RevokeTokensTable formats the result of a revoke-tokens subcommand.
+func RevokeTokensTable(revokedTokenCount uint32, canceledSessionIds []string) string {
+	ret := []string{
+		"",
+		"Auth token revocation:",
+		fmt.Sprintf("  Revoked Tokens:        %d", revokedTokenCount),
+	}
+	if len(canceledSessionIds) > 0 {
+		ret = append(ret,
+			"  Canceled Sessions:",
+			base.WrapSlice(4, canceledSessionIds),
+		)
+	}
+	return base.WrapForHelpText(ret)
+}
@@ -140,6 +140,49 @@ type Controller struct {
 	//
 	// TODO: This field is currently internal.
 	SchedulerRunJobInterval time.Duration `hcl:"-"`
+
+	// WarehouseExport configures the periodic export of the session data
+	// warehouse to files. The export is disabled if it is not set.
+	WarehouseExport *WarehouseExport `hcl:"warehouse_export"`
+
+	// UserActivityRetention is how long the authentication and session
+	// authorization history of users is kept, denoted by time.Duration. It
+	// defaults to 90 days.
+	UserActivityRetention         interface{} `hcl:"user_activity_retention"`
+	UserActivityRetentionDuration time.Duration
+}
+
+// WarehouseExport configures the warehouse export job of the controller.
+// Exactly one of LocalDirectory and S3 must be set.
+type WarehouseExport struct {
+	// Format is the format of the exported files, either "parquet" (the
+	// default) or "csv".
+	Format string `hcl:"format"`
+
+	// Interval is the time between exports, denoted by time.Duration. It
+	// defaults to one hour.
+	Interval         interface{} `hcl:"interval"`
+	IntervalDuration time.Duration
+
+	// LocalDirectory is the directory to write the exported files to.
+	LocalDirectory string `hcl:"local_directory"`
+
+	// S3 configures an AWS S3 or S3 compatible bucket to write the exported
+	// files to.
+	S3 *WarehouseExportS3 `hcl:"s3"`
+}
+
+// WarehouseExportS3 configures the bucket of a warehouse export. The access
+// key id and secret access key can be env:// or file:// paths. If they are not
+// set, the default AWS credential chain is used.
+type WarehouseExportS3 struct {
+	Endpoint        string `hcl:"endpoint"`
+	Region          string `hcl:"region"`
+	Bucket          string `hcl:"bucket"`
+	Prefix          string `hcl:"prefix"`
+	AccessKeyId     string `hcl:"access_key_id"`
+	SecretAccessKey string `hcl:"secret_access_key"`
+	ForcePathStyle  bool   `hcl:"force_path_style"`
 }
 
 func (c *Controller) InitNameIfEmpty() (string, error) {
@@ -341,6 +384,17 @@ func Parse(d string) (*Config, error) {
 			result.Controller.AuthTokenTimeToStaleDuration = t
 		}
 
+		if result.Controller.UserActivityRetention != nil {
+			t, err := parseutil.ParseDurationSecond(result.Controller.UserActivityRetention)
+			if err != nil {
+				return result, err
+			}
+			if t <= 0 {
+				return result, errors.New("Controller user activity retention must be positive")
+			}
+			result.Controller.UserActivityRetentionDuration = t
+		}
+
 		if result.Controller.Database != nil {
 			if result.Controller.Database.MaxOpenConnectionsRaw != nil {
 				switch t := result.Controller.Database.MaxOpenConnectionsRaw.(type) {
@@ -361,6 +415,12 @@ func Parse(d string) (*Config, error) {
 				}
 			}
 		}
+
+		if wx := result.Controller.WarehouseExport; wx != nil {
+			if err := parseWarehouseExport(wx); err != nil {
+				return nil, fmt.Errorf("Error parsing warehouse export: %w", err)
+			}
+		}
 	}
 
 	// Parse worker tags
@@ -576,6 +636,49 @@ func parseWorkerControllers(c *Config) ([]string, error) {
 	}
 }
 
+// parseWarehouseExport validates the warehouse export block and resolves its
+// interval and S3 credentials.
+func parseWarehouseExport(wx *WarehouseExport) error {
+	switch strings.ToLower(wx.Format) {
+	case "", "parquet", "csv":
+	default:
+		return fmt.Errorf("unknown format %q, must be parquet or csv", wx.Format)
+	}
+
+	wx.IntervalDuration = time.Hour
+	if wx.Interval != nil {
+		t, err := parseutil.ParseDurationSecond(wx.Interval)
+		if err != nil {
+			return fmt.Errorf("invalid interval: %w", err)
+		}
+		if t <= 0 {
+			return errors.New("interval must be greater than zero")
+		}
+		wx.IntervalDuration = t
+	}
+
+	switch {
+	case wx.LocalDirectory == "" && wx.S3 == nil:
+		return errors.New("one of local_directory or s3 must be set")
+	case wx.LocalDirectory != "" && wx.S3 != nil:
+		return errors.New("only one of local_directory or s3 can be set")
+	case wx.S3 != nil:
+		if wx.S3.Bucket == "" {
+			return errors.New("s3 bucket must be set")
+		}
+		var err error
+		wx.S3.AccessKeyId, err = parseutil.ParsePath(wx.S3.AccessKeyId)
+		if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+			return fmt.Errorf("error parsing s3 access key id: %w", err)
+		}
+		wx.S3.SecretAccessKey, err = parseutil.ParsePath(wx.S3.SecretAccessKey)
+		if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+			return fmt.Errorf("error parsing s3 secret access key: %w", err)
+		}
+	}
+	return nil
+}
+
 func parseEventing(eventObj *ast.ObjectItem) (*event.EventerConfig, error) {
 	// Decode the outside struct
 	var result event.EventerConfig
@@ -1145,3 +1145,133 @@ func TestDatabaseMaxConnections(t *testing.T) {
 		})
 	}
 }
+
+func TestWarehouseExport(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		exp       *WarehouseExport
+		expErrStr string
+	}{
+		{
+			name: "Not set",
+			in: `
+			controller {
+				name = "example-controller"
+			}`,
+		},
+		{
+			name: "Local directory with defaults",
+			in: `
+			controller {
+				name = "example-controller"
+				warehouse_export {
+					local_directory = "/var/lib/boundary/warehouse"
+				}
+			}`,
+			exp: &WarehouseExport{
+				LocalDirectory:   "/var/lib/boundary/warehouse",
+				IntervalDuration: time.Hour,
+			},
+		},
+		{
+			name: "S3 with env credentials",
+			in: `
+			controller {
+				name = "example-controller"
+				warehouse_export {
+					format = "csv"
+					interval = "15m"
+					s3 {
+						endpoint = "http://127.0.0.1:9000"
+						bucket = "warehouse"
+						prefix = "boundary"
+						access_key_id = "env://WH_ACCESS_KEY_ID"
+						secret_access_key = "env://WH_SECRET_ACCESS_KEY"
+						force_path_style = true
+					}
+				}
+			}`,
+			exp: &WarehouseExport{
+				Format:           "csv",
+				Interval:         "15m",
+				IntervalDuration: 15 * time.Minute,
+				S3: &WarehouseExportS3{
+					Endpoint:        "http://127.0.0.1:9000",
+					Bucket:          "warehouse",
+					Prefix:          "boundary",
+					AccessKeyId:     "access-key-id",
+					SecretAccessKey: "secret-access-key",
+					ForcePathStyle:  true,
+				},
+			},
+		},
+		{
+			name: "No destination",
+			in: `
+			controller {
+				name = "example-controller"
+				warehouse_export {
+					format = "csv"
+				}
+			}`,
+			expErrStr: "Error parsing warehouse export: one of local_directory or s3 must be set",
+		},
+		{
+			name: "Two destinations",
+			in: `
+			controller {
+				name = "example-controller"
+				warehouse_export {
+					local_directory = "/tmp"
+					s3 {
+						bucket = "warehouse"
+					}
+				}
+			}`,
+			expErrStr: "Error parsing warehouse export: only one of local_directory or s3 can be set",
+		},
+		{
+			name: "Missing bucket",
+			in: `
+			controller {
+				name = "example-controller"
+				warehouse_export {
+					s3 {
+						region = "us-west-2"
+					}
+				}
+			}`,
+			expErrStr: "Error parsing warehouse export: s3 bucket must be set",
+		},
+		{
+			name: "Unknown format",
+			in: `
+			controller {
+				name = "example-controller"
+				warehouse_export {
+					format = "json"
+					local_directory = "/tmp"
+				}
+			}`,
+			expErrStr: "Error parsing warehouse export: unknown format \"json\", must be parquet or csv",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("WH_ACCESS_KEY_ID", "access-key-id")
+			t.Setenv("WH_SECRET_ACCESS_KEY", "secret-access-key")
+			c, err := Parse(tt.in)
+			if tt.expErrStr != "" {
+				require.EqualError(t, err, tt.expErrStr)
+				require.Nil(t, c)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, c)
+			require.NotNil(t, c.Controller)
+			require.Equal(t, tt.exp, c.Controller.WarehouseExport)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
 
 	"github.com/hashicorp/go-secure-stdlib/strutil"
@@ -63,18 +64,28 @@ func lowerSpaceCase(in string) string {
 	return strcase.ToDelimited(in, ' ')
 }
 
+// lowerSpacePlural returns the plural of lowerSpaceCase(in).
+func lowerSpacePlural(in string) string {
+	out := lowerSpaceCase(in)
+	if strings.HasSuffix(out, "s") {
+		return out + "es"
+	}
+	return out + "s"
+}
+
 func hasAction(in []string, action string) bool {
 	return strutil.StrListContains(in, action)
 }
 
 var cmdTemplate = template.Must(template.New("").Funcs(
 	template.FuncMap{
-		"camelCase":      camelCase,
-		"envCase":        envCase,
-		"snakeCase":      snakeCase,
-		"kebabCase":      kebabCase,
-		"lowerSpaceCase": lowerSpaceCase,
-		"hasAction":      hasAction,
+		"camelCase":        camelCase,
+		"envCase":          envCase,
+		"snakeCase":        snakeCase,
+		"kebabCase":        kebabCase,
+		"lowerSpaceCase":   lowerSpaceCase,
+		"lowerSpacePlural": lowerSpacePlural,
+		"hasAction":        hasAction,
 	},
 ).Parse(`{{ $input := . }}
 // Code generated by "make cli"; DO NOT EDIT.
@@ -173,7 +184,7 @@ var flags{{ camelCase .SubActionPrefix }}Map = map[string][]string{
 	{{ with $secretFlags := ", \"secrets\", \"secret\", \"string-secret\", \"bool-secret\", \"num-secret\"" }}
 	{{ range $i, $action := $input.StdActions }}
 	{{ if eq $action "create" }}
-	"create": { "{{ kebabCase $input.Container }}-id", "name", "description" {{ if $input.IsPluginType }} , "plugin-id", "plugin-name" {{ end }} {{ if $input.HasGenericAttributes }} {{ $attrFlags }} {{ end }} {{ if $input.HasGenericSecrets }} {{ $secretFlags }} {{ end }} },
+	"create": { "{{ kebabCase $input.Container }}-id", {{ if $input.HasName }} "name", {{ end }} "description" {{ if $input.IsPluginType }} , "plugin-id", "plugin-name" {{ end }} {{ if $input.HasGenericAttributes }} {{ $attrFlags }} {{ end }} {{ if $input.HasGenericSecrets }} {{ $secretFlags }} {{ end }} },
 	{{ end }}
 	{{ if eq $action "read" }}
 	"read": {"id"},
@@ -238,7 +249,7 @@ func (c *{{ camelCase .SubActionPrefix }}Command) Run(args []string) int {
 	c.plural = "{{ if .SubActionPrefix }}{{ .SubActionPrefix }}-type {{ end }}{{ lowerSpaceCase .ResourceType }}"
 	switch c.Func {
 	case "list":
-		c.plural = "{{ if .SubActionPrefix }}{{ .SubActionPrefix }}-type {{ end }}{{ lowerSpaceCase .ResourceType }}s"		
+		c.plural = "{{ if .SubActionPrefix }}{{ .SubActionPrefix }}-type {{ end }}{{ lowerSpacePlural .ResourceType }}"		
 	}
 
 	f := c.Flags()
@@ -23,6 +23,41 @@ type Store interface {
 type Library interface {
 	boundary.Resource
 	GetStoreId() string
+	CredentialType() Type
+}
+
+// Type is the type of credential provided by a library.
+type Type string
+
+func (t Type) String() string {
+	return string(t)
+}
+
+// Credential type values.
+const (
+	// UnspecifiedType is a credential of no specific type. The secret data
+	// is returned as is and is not decoded into a typed credential.
+	UnspecifiedType Type = "unspecified"
+
+	// UsernamePasswordType is a credential containing a username and a
+	// password.
+	UsernamePasswordType Type = "username_password"
+
+	// SshPrivateKeyType is a credential containing a username and an SSH
+	// private key.
+	SshPrivateKeyType Type = "ssh_private_key"
+
+	// SshCertificateType is a credential containing a username, an SSH
+	// private key, and an SSH certificate signed for the private key.
+	SshCertificateType Type = "ssh_certificate"
+)
+
+// ValidTypes are the set of all credential Types.
+var ValidTypes = []Type{
+	UnspecifiedType,
+	UsernamePasswordType,
+	SshPrivateKeyType,
+	SshCertificateType,
 }
 
 // Purpose is the purpose of the credential.
@@ -126,3 +161,12 @@ type Certificate interface {
 	Certificate() []byte
 	Private() PrivateKey
 }
+
+// SshCertificate is a credential containing a username, an SSH private key,
+// and an SSH certificate signed for the private key.
+type SshCertificate interface {
+	Credential
+	Username() string
+	Private() PrivateKey
+	Certificate() []byte
+}
@@ -0,0 +1,344 @@
+package vault
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/credential/vault/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/libs/crypto"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// An AuthMethodType is a Vault auth method a credential store can use to
+// log in to Vault.
+type AuthMethodType string
+
+const (
+	// TokenAuthMethod is used by a credential store which uses the token
+	// provided by a user. It is never stored; a credential store using it
+	// has no AuthMethod.
+	TokenAuthMethod AuthMethodType = "token"
+
+	// AppRoleAuthMethod logs in to Vault with a role ID and a secret ID.
+	AppRoleAuthMethod AuthMethodType = "approle"
+
+	// KubernetesAuthMethod logs in to Vault with the service account token
+	// of the controller.
+	KubernetesAuthMethod AuthMethodType = "kubernetes"
+)
+
+// kubernetesServiceAccountTokenPath is the path of the service account
+// token kubernetes mounts into a pod.
+var kubernetesServiceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// AuthMethod contains the configuration of the Vault auth method a
+// credential store uses to obtain its Vault token. It is owned by a
+// credential store.
+type AuthMethod struct {
+	*store.AuthMethod
+	tableName string `gorm:"-"`
+}
+
+// NewAppRoleAuthMethod creates a new in memory AuthMethod which logs in to
+// Vault with roleId and secretId. WithMountPath is the only valid option.
+// The mount path defaults to "approle".
+func NewAppRoleAuthMethod(roleId string, secretId SecretIdSecret, opt ...Option) (*AuthMethod, error) {
+	const op = "vault.NewAppRoleAuthMethod"
+	if roleId == "" {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "no role id")
+	}
+	if len(secretId) == 0 {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "no secret id")
+	}
+	opts := getOpts(opt...)
+
+	secretIdCopy := make(SecretIdSecret, len(secretId))
+	copy(secretIdCopy, secretId)
+
+	am := &AuthMethod{
+		AuthMethod: &store.AuthMethod{
+			AuthMethod: string(AppRoleAuthMethod),
+			MountPath:  opts.withMountPath,
+			RoleId:     roleId,
+			SecretId:   secretIdCopy,
+		},
+	}
+	am.setDefaults()
+	return am, nil
+}
+
+// NewKubernetesAuthMethod creates a new in memory AuthMethod which logs in
+// to Vault as role with the service account token of the controller.
+// WithMountPath is the only valid option. The mount path defaults to
+// "kubernetes".
+func NewKubernetesAuthMethod(role string, opt ...Option) (*AuthMethod, error) {
+	const op = "vault.NewKubernetesAuthMethod"
+	if role == "" {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "no role")
+	}
+	opts := getOpts(opt...)
+
+	am := &AuthMethod{
+		AuthMethod: &store.AuthMethod{
+			AuthMethod:     string(KubernetesAuthMethod),
+			MountPath:      opts.withMountPath,
+			KubernetesRole: role,
+		},
+	}
+	am.setDefaults()
+	return am, nil
+}
+
+func allocAuthMethod() *AuthMethod {
+	return &AuthMethod{
+		AuthMethod: &store.AuthMethod{},
+	}
+}
+
+func (am *AuthMethod) clone() *AuthMethod {
+	cp := proto.Clone(am.AuthMethod)
+	return &AuthMethod{
+		AuthMethod: cp.(*store.AuthMethod),
+	}
+}
+
+func (am *AuthMethod) setDefaults() {
+	if am.MountPath == "" {
+		am.MountPath = am.AuthMethod.AuthMethod
+	}
+	am.MountPath = strings.Trim(am.MountPath, "/")
+}
+
+// applyUpdate returns a new AuthMethod with the new values applied to am
+// based on the passed in fieldMaskPaths. am and new can be nil. It returns
+// nil if the auth method is removed. Changing the type of the auth method
+// discards all values of the previous auth method.
+func (am *AuthMethod) applyUpdate(new *AuthMethod, fieldMaskPaths []string) *AuthMethod {
+	if new == nil {
+		new = allocAuthMethod()
+	}
+	cp := allocAuthMethod()
+	if am != nil {
+		cp = am.clone()
+	}
+	for _, f := range fieldMaskPaths {
+		if !strings.EqualFold(authMethodField, f) {
+			continue
+		}
+		switch new.GetAuthMethod() {
+		case "", string(TokenAuthMethod):
+			return nil
+		case cp.GetAuthMethod():
+		default:
+			cp = allocAuthMethod()
+			cp.AuthMethod.AuthMethod = new.GetAuthMethod()
+			if am != nil {
+				cp.StoreId = am.StoreId
+			}
+		}
+	}
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold(mountPathField, f):
+			cp.MountPath = new.GetMountPath()
+		case strings.EqualFold(roleIdField, f):
+			cp.RoleId = new.GetRoleId()
+		case strings.EqualFold(secretIdField, f):
+			cp.SecretId = new.GetSecretId()
+		case strings.EqualFold(kubernetesRoleField, f):
+			cp.KubernetesRole = new.GetKubernetesRole()
+		}
+	}
+	cp.setDefaults()
+	return cp
+}
+
+// validate checks that am contains the values required by its type and no
+// values of other types.
+func (am *AuthMethod) validate(ctx context.Context, op errors.Op) error {
+	switch AuthMethodType(am.GetAuthMethod()) {
+	case AppRoleAuthMethod:
+		switch {
+		case am.GetRoleId() == "":
+			return errors.New(ctx, errors.InvalidParameter, op, "approle auth method: no role id")
+		case len(am.GetSecretId()) == 0:
+			return errors.New(ctx, errors.InvalidParameter, op, "approle auth method: no secret id")
+		case am.GetKubernetesRole() != "":
+			return errors.New(ctx, errors.InvalidParameter, op, "approle auth method: kubernetes role not allowed")
+		}
+	case KubernetesAuthMethod:
+		switch {
+		case am.GetKubernetesRole() == "":
+			return errors.New(ctx, errors.InvalidParameter, op, "kubernetes auth method: no role")
+		case am.GetRoleId() != "" || len(am.GetSecretId()) > 0:
+			return errors.New(ctx, errors.InvalidParameter, op, "kubernetes auth method: approle role id and secret id not allowed")
+		}
+	case "":
+		return errors.New(ctx, errors.InvalidParameter, op, "no auth method")
+	default:
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unsupported auth method: %s", am.GetAuthMethod()))
+	}
+	return nil
+}
+
+// login logs in to Vault with c and returns a new Token for the
+// credential store owning am. The token of c is replaced with the new
+// token.
+func (am *AuthMethod) login(ctx context.Context, c *client) (*Token, error) {
+	const op = "vault.(AuthMethod).login"
+	var data map[string]interface{}
+	switch AuthMethodType(am.GetAuthMethod()) {
+	case AppRoleAuthMethod:
+		data = map[string]interface{}{
+			"role_id":   am.GetRoleId(),
+			"secret_id": string(am.GetSecretId()),
+		}
+	case KubernetesAuthMethod:
+		jwt, err := os.ReadFile(kubernetesServiceAccountTokenPath)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to read kubernetes service account token"))
+		}
+		data = map[string]interface{}{
+			"role": am.GetKubernetesRole(),
+			"jwt":  strings.TrimSpace(string(jwt)),
+		}
+	default:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unsupported auth method: %s", am.GetAuthMethod()))
+	}
+
+	// Vault rejects requests carrying an invalid token, even to login
+	// endpoints, so the current token must not be sent.
+	c.swapToken(nil)
+	s, err := c.login(am.GetMountPath(), data)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to log in with %s auth method", am.GetAuthMethod())))
+	}
+	tokenSecret := TokenSecret(s.Auth.ClientToken)
+	c.swapToken(tokenSecret)
+
+	token, err := newToken(am.GetStoreId(), tokenSecret, []byte(s.Auth.Accessor), time.Duration(s.Auth.LeaseDuration)*time.Second)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return token, nil
+}
+
+// TableName returns the table name.
+func (am *AuthMethod) TableName() string {
+	if am.tableName != "" {
+		return am.tableName
+	}
+	return "credential_vault_auth_method"
+}
+
+// SetTableName sets the table name.
+func (am *AuthMethod) SetTableName(n string) {
+	am.tableName = n
+}
+
+func (am *AuthMethod) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "vault.(AuthMethod).encrypt"
+	if len(am.SecretId) == 0 {
+		// Only the approle auth method has a secret.
+		return nil
+	}
+	if err := structwrapping.WrapStruct(ctx, cipher, am.AuthMethod, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	am.KeyId = cipher.KeyID()
+	hm, err := crypto.HmacSha256(ctx, am.SecretId, cipher, []byte(am.StoreId), nil, crypto.WithEd25519())
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	am.SecretIdHmac = []byte(hm)
+	return nil
+}
+
+func (am *AuthMethod) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "vault.(AuthMethod).decrypt"
+	if len(am.CtSecretId) == 0 {
+		return nil
+	}
+	if err := structwrapping.UnwrapStruct(ctx, cipher, am.AuthMethod, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
+
+func (am *AuthMethod) insertQuery() (query string, queryValues []interface{}) {
+	query = upsertAuthMethodQuery
+	queryValues = []interface{}{
+		sql.Named("store_id", am.StoreId),
+		sql.Named("auth_method", am.AuthMethod.AuthMethod),
+		sql.Named("mount_path", am.MountPath),
+		sql.Named("role_id", nullString(am.RoleId)),
+		sql.Named("secret_id", nullBytes(am.CtSecretId)),
+		sql.Named("secret_id_hmac", nullBytes(am.SecretIdHmac)),
+		sql.Named("kubernetes_role", nullString(am.KubernetesRole)),
+		sql.Named("key_id", nullString(am.KeyId)),
+	}
+	return
+}
+
+func (am *AuthMethod) deleteQuery() (query string, queryValues []interface{}) {
+	query = deleteAuthMethodQuery
+	queryValues = []interface{}{
+		am.StoreId,
+	}
+	return
+}
+
+func (am *AuthMethod) oplogMessage(opType db.OpType) *oplog.Message {
+	msg := oplog.Message{
+		Message:  am.clone(),
+		TypeName: am.TableName(),
+	}
+	switch opType {
+	case db.CreateOp, db.UpdateOp:
+		msg.OpType = oplog.OpType_OP_TYPE_CREATE
+	case db.DeleteOp:
+		msg.OpType = oplog.OpType_OP_TYPE_DELETE
+	}
+	return &msg
+}
+
+// lookupAuthMethod returns the decrypted AuthMethod of the credential
+// store storeId. It returns nil, nil if the credential store has no auth
+// method.
+func lookupAuthMethod(ctx context.Context, r db.Reader, storeId string, cipher wrapping.Wrapper) (*AuthMethod, error) {
+	const op = "vault.lookupAuthMethod"
+	am := allocAuthMethod()
+	if err := r.LookupWhere(ctx, am, "store_id = ?", storeId); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", storeId)))
+	}
+	if err := am.decrypt(ctx, cipher); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return am, nil
+}
+
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func nullBytes(b []byte) interface{} {
+	if len(b) == 0 {
+		return nil
+	}
+	return b
+}
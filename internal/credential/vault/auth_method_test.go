@@ -0,0 +1,141 @@
+package vault
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential/vault/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthMethod_New(t *testing.T) {
+	t.Parallel()
+
+	t.Run("approle", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := NewAppRoleAuthMethod("role-id", SecretIdSecret("secret-id"))
+		require.NoError(err)
+		assert.Equal(string(AppRoleAuthMethod), got.GetAuthMethod())
+		assert.Equal("approle", got.GetMountPath())
+		assert.Equal("role-id", got.GetRoleId())
+		assert.Equal([]byte("secret-id"), got.GetSecretId())
+
+		got, err = NewAppRoleAuthMethod("role-id", SecretIdSecret("secret-id"), WithMountPath("/my-approle/"))
+		require.NoError(err)
+		assert.Equal("my-approle", got.GetMountPath())
+
+		_, err = NewAppRoleAuthMethod("", SecretIdSecret("secret-id"))
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "want err code: %q got: %q", errors.InvalidParameter, err)
+		_, err = NewAppRoleAuthMethod("role-id", nil)
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "want err code: %q got: %q", errors.InvalidParameter, err)
+	})
+
+	t.Run("kubernetes", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := NewKubernetesAuthMethod("boundary")
+		require.NoError(err)
+		assert.Equal(string(KubernetesAuthMethod), got.GetAuthMethod())
+		assert.Equal("kubernetes", got.GetMountPath())
+		assert.Equal("boundary", got.GetKubernetesRole())
+
+		_, err = NewKubernetesAuthMethod("")
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "want err code: %q got: %q", errors.InvalidParameter, err)
+	})
+}
+
+func TestAuthMethod_validate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		in      *store.AuthMethod
+		wantErr bool
+	}{
+		{
+			name: "valid-approle",
+			in:   &store.AuthMethod{AuthMethod: "approle", RoleId: "role-id", SecretId: []byte("secret-id")},
+		},
+		{
+			name:    "approle-no-secret-id",
+			in:      &store.AuthMethod{AuthMethod: "approle", RoleId: "role-id"},
+			wantErr: true,
+		},
+		{
+			name:    "approle-with-kubernetes-role",
+			in:      &store.AuthMethod{AuthMethod: "approle", RoleId: "role-id", SecretId: []byte("secret-id"), KubernetesRole: "boundary"},
+			wantErr: true,
+		},
+		{
+			name: "valid-kubernetes",
+			in:   &store.AuthMethod{AuthMethod: "kubernetes", KubernetesRole: "boundary"},
+		},
+		{
+			name:    "kubernetes-with-role-id",
+			in:      &store.AuthMethod{AuthMethod: "kubernetes", KubernetesRole: "boundary", RoleId: "role-id"},
+			wantErr: true,
+		},
+		{
+			name:    "no-auth-method",
+			in:      &store.AuthMethod{RoleId: "role-id"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown-auth-method",
+			in:      &store.AuthMethod{AuthMethod: "userpass"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := (&AuthMethod{AuthMethod: tt.in}).validate(ctx, "test")
+			if tt.wantErr {
+				assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "want err code: %q got: %q", errors.InvalidParameter, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAuthMethod_applyUpdate(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+
+	orig, err := NewAppRoleAuthMethod("role-id", SecretIdSecret("secret-id"))
+	require.NoError(err)
+	orig.StoreId = "csvlt_1234567890"
+
+	// updating a field keeps the others
+	got := orig.applyUpdate(&AuthMethod{AuthMethod: &store.AuthMethod{SecretId: []byte("new-secret-id")}}, []string{secretIdField})
+	require.NotNil(got)
+	assert.Equal("role-id", got.GetRoleId())
+	assert.Equal([]byte("new-secret-id"), got.GetSecretId())
+	assert.Equal([]byte("secret-id"), orig.GetSecretId(), "the original must not be modified")
+
+	// changing the type discards the values of the previous type
+	got = orig.applyUpdate(&AuthMethod{AuthMethod: &store.AuthMethod{AuthMethod: "kubernetes", KubernetesRole: "boundary"}},
+		[]string{authMethodField, kubernetesRoleField})
+	require.NotNil(got)
+	assert.Equal(orig.StoreId, got.StoreId)
+	assert.Equal("kubernetes", got.GetMountPath())
+	assert.Empty(got.GetRoleId())
+	assert.Empty(got.GetSecretId())
+	assert.Equal("boundary", got.GetKubernetesRole())
+
+	// switching to the token auth method removes the auth method
+	assert.Nil(orig.applyUpdate(&AuthMethod{AuthMethod: &store.AuthMethod{AuthMethod: "token"}}, []string{authMethodField}))
+	assert.Nil(orig.applyUpdate(nil, []string{authMethodField}))
+
+	// adding an auth method to a credential store without one
+	var none *AuthMethod
+	got = none.applyUpdate(&AuthMethod{AuthMethod: &store.AuthMethod{AuthMethod: "kubernetes", MountPath: "k8s", KubernetesRole: "boundary"}},
+		[]string{authMethodField, mountPathField, kubernetesRoleField})
+	require.NotNil(got)
+	assert.Equal("k8s", got.GetMountPath())
+	assert.NoError(got.validate(context.Background(), "test"))
+}
@@ -1,8 +1,12 @@
 package vault
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/hashicorp/boundary/internal/credential"
 	"github.com/hashicorp/boundary/internal/credential/vault/store"
+	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/oplog"
 	"google.golang.org/protobuf/proto"
 )
@@ -25,8 +29,9 @@ type CredentialLibrary struct {
 
 // NewCredentialLibrary creates a new in memory CredentialLibrary
 // for a Vault backend at vaultPath assigned to storeId.
-// Name, description, method, and request body are the only valid options.
-// All other options are ignored.
+// Name, description, method, request body, credential type, and the
+// username, password, and private key attributes are the only valid
+// options. All other options are ignored.
 func NewCredentialLibrary(storeId string, vaultPath string, opt ...Option) (*CredentialLibrary, error) {
 	const op = "vault.NewCredentialLibrary"
 	opts := getOpts(opt...)
@@ -39,6 +44,11 @@ func NewCredentialLibrary(storeId string, vaultPath string, opt ...Option) (*Cre
 			VaultPath:       vaultPath,
 			HttpRequestBody: opts.withRequestBody,
 			HttpMethod:      string(opts.withMethod),
+
+			CredentialType:      string(opts.withCredentialType),
+			UsernameAttribute:   opts.withUsernameAttribute,
+			PasswordAttribute:   opts.withPasswordAttribute,
+			PrivateKeyAttribute: opts.withPrivateKeyAttribute,
 		},
 	}
 
@@ -83,4 +93,55 @@ func (l *CredentialLibrary) oplog(op oplog.OpType) oplog.Metadata {
 	return metadata
 }
 
+// CredentialType returns the type of credential the library provides.
+func (l *CredentialLibrary) CredentialType() credential.Type {
+	if ct := l.GetCredentialType(); ct != "" {
+		return credential.Type(ct)
+	}
+	return credential.UnspecifiedType
+}
+
+// validateMapping checks that the credential type of the library is known,
+// that only the attributes of that type are overridden, and that no two
+// fields of the type are mapped to the same attribute.
+func (l *CredentialLibrary) validateMapping(ctx context.Context) error {
+	const op = "vault.(CredentialLibrary).validateMapping"
+	var attrs []string
+	switch ct := l.CredentialType(); ct {
+	case credential.UnspecifiedType:
+		if l.UsernameAttribute != "" || l.PasswordAttribute != "" || l.PrivateKeyAttribute != "" {
+			return errors.New(ctx, errors.InvalidParameter, op, "attribute overrides require a credential type")
+		}
+		return nil
+	case credential.UsernamePasswordType:
+		if l.PrivateKeyAttribute != "" {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("private key attribute not allowed for credential type %s", ct))
+		}
+		attrs = []string{l.usernameAttribute(), l.passwordAttribute()}
+	case credential.SshPrivateKeyType:
+		if l.PasswordAttribute != "" {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("password attribute not allowed for credential type %s", ct))
+		}
+		attrs = []string{l.usernameAttribute(), l.privateKeyAttribute()}
+	default:
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unknown credential type %q", ct))
+	}
+	if attrs[0] == attrs[1] {
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("attribute %q mapped more than once", attrs[0]))
+	}
+	return nil
+}
+
+func (l *CredentialLibrary) usernameAttribute() string {
+	return attributeOrDefault(l.UsernameAttribute, defaultUsernameAttribute)
+}
+
+func (l *CredentialLibrary) passwordAttribute() string {
+	return attributeOrDefault(l.PasswordAttribute, defaultPasswordAttribute)
+}
+
+func (l *CredentialLibrary) privateKeyAttribute() string {
+	return attributeOrDefault(l.PrivateKeyAttribute, defaultPrivateKeyAttribute)
+}
+
 var _ credential.Library = (*CredentialLibrary)(nil)
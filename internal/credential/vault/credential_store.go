@@ -10,28 +10,48 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+// An OperationalState is the operational state of a credential store.
+type OperationalState string
+
+const (
+	// ActiveState represents a credential store with a current Vault
+	// token which can be used for retrieving credentials.
+	ActiveState OperationalState = "active"
+
+	// AuthFailedState represents a credential store without a current
+	// Vault token. Its token expired or was revoked, or its auth method
+	// failed to log in to Vault. Credentials cannot be retrieved until a
+	// new token is provided or the auth method logs in successfully.
+	AuthFailedState OperationalState = "auth-failed"
+)
+
 // A CredentialStore contains credential libraries. It is owned by a scope.
 type CredentialStore struct {
 	*store.CredentialStore
 	tableName string `gorm:"-"`
 
-	clientCert  *ClientCertificate `gorm:"-"`
-	inputToken  TokenSecret        `gorm:"-"`
-	outputToken *Token             `gorm:"-"`
+	clientCert       *ClientCertificate `gorm:"-"`
+	authMethod       *AuthMethod        `gorm:"-"`
+	inputToken       TokenSecret        `gorm:"-"`
+	outputToken      *Token             `gorm:"-"`
+	operationalState OperationalState   `gorm:"-"`
+	healthCheck      *HealthCheck       `gorm:"-"`
 
 	privateClientCert *ClientCertificate `gorm:"-"`
 	privateToken      *Token             `gorm:"-"`
 }
 
 // NewCredentialStore creates a new in memory CredentialStore for a Vault
-// server at vaultAddress assigned to scopeId. Name, description, CA cert,
-// client cert, namespace, TLS server name, and TLS skip verify are the
-// only valid options. All other options are ignored.
+// server at vaultAddress assigned to scopeId. token can be empty if an
+// auth method is provided. Name, description, CA cert, client cert, auth
+// method, namespace, TLS server name, and TLS skip verify are the only
+// valid options. All other options are ignored.
 func NewCredentialStore(scopeId string, vaultAddress string, token TokenSecret, opt ...Option) (*CredentialStore, error) {
 	opts := getOpts(opt...)
 	cs := &CredentialStore{
 		inputToken: token,
 		clientCert: opts.withClientCert,
+		authMethod: opts.withAuthMethod,
 		CredentialStore: &store.CredentialStore{
 			ScopeId:       scopeId,
 			Name:          opts.withName,
@@ -59,11 +79,17 @@ func (cs *CredentialStore) clone() *CredentialStore {
 	if cs.clientCert != nil {
 		clientCertCopy = cs.clientCert.clone()
 	}
+	var authMethodCopy *AuthMethod
+	if cs.authMethod != nil {
+		authMethodCopy = cs.authMethod.clone()
+	}
 	cp := proto.Clone(cs.CredentialStore)
 	return &CredentialStore{
-		inputToken:      tokenCopy,
-		clientCert:      clientCertCopy,
-		CredentialStore: cp.(*store.CredentialStore),
+		inputToken:       tokenCopy,
+		clientCert:       clientCertCopy,
+		authMethod:       authMethodCopy,
+		operationalState: cs.operationalState,
+		CredentialStore:  cp.(*store.CredentialStore),
 	}
 }
 
@@ -71,6 +97,7 @@ func (cs *CredentialStore) clone() *CredentialStore {
 // this based on the passed in fieldMaskPaths.
 func (cs *CredentialStore) applyUpdate(new *CredentialStore, fieldMaskPaths []string) *CredentialStore {
 	cp := cs.clone()
+	var authMethodMask []string
 	for _, f := range fieldMaskPaths {
 		switch {
 		case strings.EqualFold(nameField, f):
@@ -109,6 +136,18 @@ func (cs *CredentialStore) applyUpdate(new *CredentialStore, fieldMaskPaths []st
 			cp.TlsSkipVerify = new.TlsSkipVerify
 		case strings.EqualFold(tokenField, f):
 			cp.inputToken = new.inputToken
+		case strings.EqualFold(authMethodField, f),
+			strings.EqualFold(mountPathField, f),
+			strings.EqualFold(roleIdField, f),
+			strings.EqualFold(secretIdField, f),
+			strings.EqualFold(kubernetesRoleField, f):
+			authMethodMask = append(authMethodMask, f)
+		}
+	}
+	if len(authMethodMask) > 0 {
+		cp.authMethod = cp.authMethod.applyUpdate(new.authMethod, authMethodMask)
+		if cp.authMethod != nil {
+			cp.authMethod.StoreId = cs.GetPublicId()
 		}
 	}
 	return cp
@@ -165,6 +204,26 @@ func (cs *CredentialStore) ClientCertificate() *ClientCertificate {
 	return cs.clientCert
 }
 
+// AuthMethod returns the auth method the credential store uses to log in
+// to Vault. It returns nil if the credential store uses the token provided
+// by a user.
+func (cs *CredentialStore) AuthMethod() *AuthMethod {
+	return cs.authMethod
+}
+
+// OperationalState returns the operational state of the credential store
+// if available.
+func (cs *CredentialStore) OperationalState() OperationalState {
+	return cs.operationalState
+}
+
+// HealthCheck returns the result of the last health check of the
+// credential store. It returns nil if the credential store has not been
+// checked.
+func (cs *CredentialStore) HealthCheck() *HealthCheck {
+	return cs.healthCheck
+}
+
 func (cs *CredentialStore) client() (*client, error) {
 	const op = "vault.(CredentialStore).client"
 	clientConfig := &clientConfig{
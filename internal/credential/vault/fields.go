@@ -9,6 +9,14 @@ const (
 	httpMethodField      = "HttpMethod"
 	httpRequestBodyField = "HttpRequestBody"
 
+	usernameAttributeField   = "UsernameAttribute"
+	passwordAttributeField   = "PasswordAttribute"
+	privateKeyAttributeField = "PrivateKeyAttribute"
+
+	validPrincipalsField = "ValidPrincipals"
+	ttlField             = "Ttl"
+	extensionsField      = "Extensions"
+
 	certificateField    = "Certificate"
 	certificateKeyField = "CertificateKey"
 	vaultAddressField   = "VaultAddress"
@@ -17,4 +25,10 @@ const (
 	tlsServerNameField  = "TlsServerName"
 	tlsSkipVerifyField  = "TlsSkipVerify"
 	tokenField          = "Token"
+
+	authMethodField     = "AuthMethod"
+	mountPathField      = "MountPath"
+	roleIdField         = "RoleId"
+	secretIdField       = "SecretId"
+	kubernetesRoleField = "KubernetesRole"
 )
@@ -0,0 +1,253 @@
+package vault
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// A HealthStatus is the result of the last health check of a credential
+// store.
+type HealthStatus string
+
+const (
+	HealthyStatus   HealthStatus = "healthy"
+	UnhealthyStatus HealthStatus = "unhealthy"
+)
+
+// A HealthCheckName is a check run against a credential store by the
+// health check job. The checks are run in the order listed and the health
+// check stops at the first check that fails.
+type HealthCheckName string
+
+const (
+	// CaCertificateCheck fails if a CA certificate of the credential
+	// store has expired or is not yet valid.
+	CaCertificateCheck HealthCheckName = "ca_certificate"
+
+	// ConnectivityCheck fails if Vault is unreachable, sealed, or not
+	// initialized.
+	ConnectivityCheck HealthCheckName = "connectivity"
+
+	// TokenCheck fails if the credential store has no current token, the
+	// token is rejected by Vault, or the token expires before the next
+	// health check.
+	TokenCheck HealthCheckName = "token"
+
+	// CapabilitiesCheck fails if the token is missing a capability
+	// required by Boundary or by a credential library of the credential
+	// store.
+	CapabilitiesCheck HealthCheckName = "capabilities"
+)
+
+// healthCheckInterval is how often the health check job checks each
+// credential store.
+const healthCheckInterval = 5 * time.Minute
+
+// HealthCheck is the result of the last health check of a credential
+// store.
+type HealthCheck struct {
+	StoreId              string
+	Status               HealthStatus
+	CheckTime            *timestamp.Timestamp
+	FailedCheck          HealthCheckName
+	Message              string
+	TokenExpirationTime  *timestamp.Timestamp
+	CaCertExpirationTime *timestamp.Timestamp
+}
+
+// Healthy reports whether all checks passed.
+func (h *HealthCheck) Healthy() bool {
+	return h.Status == HealthyStatus
+}
+
+// Error returns a description of the failed check or an empty string if
+// the credential store is healthy.
+func (h *HealthCheck) Error() string {
+	if h.Healthy() {
+		return ""
+	}
+	return fmt.Sprintf("credential store %s failed the %s health check: %s", h.StoreId, h.FailedCheck, h.Message)
+}
+
+func (h *HealthCheck) fail(check HealthCheckName, msg string) *HealthCheck {
+	h.Status = UnhealthyStatus
+	h.FailedCheck = check
+	h.Message = msg
+	return h
+}
+
+func (h *HealthCheck) upsertQuery() (query string, queryValues []interface{}) {
+	var tokenExp, caExp interface{}
+	if h.TokenExpirationTime != nil {
+		tokenExp = h.TokenExpirationTime.AsTime()
+	}
+	if h.CaCertExpirationTime != nil {
+		caExp = h.CaCertExpirationTime.AsTime()
+	}
+	queryValues = []interface{}{
+		h.StoreId,
+		string(h.Status),
+		nullString(string(h.FailedCheck)),
+		nullString(h.Message),
+		tokenExp,
+		caExp,
+	}
+	return upsertHealthCheckQuery, queryValues
+}
+
+// libraryPath is a Vault path a credential library of a credential store
+// requests credentials from.
+type libraryPath struct {
+	PublicId   string
+	VaultPath  string
+	HttpMethod string
+}
+
+// libraryCapabilities returns the capabilities the token of a credential
+// store needs for its libraries.
+func libraryCapabilities(libs []*libraryPath) pathCapabilities {
+	pc := make(pathCapabilities, len(libs))
+	for _, l := range libs {
+		switch Method(l.HttpMethod) {
+		case MethodPost:
+			pc[l.VaultPath] |= updateCapability
+		default:
+			pc[l.VaultPath] |= readCapability
+		}
+	}
+	return pc
+}
+
+// checkCaCert parses the PEM encoded certificates in caCert and returns
+// the earliest expiration time. It returns an error if a certificate is
+// not valid at now.
+func checkCaCert(caCert []byte, now time.Time) (time.Time, error) {
+	var expiration time.Time
+	for rest := caCert; len(rest) > 0; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return expiration, fmt.Errorf("unable to parse CA certificate: %w", err)
+		}
+		if expiration.IsZero() || cert.NotAfter.Before(expiration) {
+			expiration = cert.NotAfter
+		}
+		switch {
+		case now.After(cert.NotAfter):
+			return expiration, fmt.Errorf("CA certificate %q expired at %s", cert.Subject.CommonName, cert.NotAfter.UTC().Format(time.RFC3339))
+		case now.Before(cert.NotBefore):
+			return expiration, fmt.Errorf("CA certificate %q is not valid before %s", cert.Subject.CommonName, cert.NotBefore.UTC().Format(time.RFC3339))
+		}
+	}
+	return expiration, nil
+}
+
+// checkHealth runs the health checks against the credential store ps
+// whose libraries request credentials from libs. ps must be decrypted.
+func checkHealth(ctx context.Context, ps *privateStore, libs []*libraryPath) *HealthCheck {
+	h := &HealthCheck{
+		StoreId: ps.PublicId,
+		Status:  HealthyStatus,
+	}
+
+	if len(ps.CaCert) > 0 {
+		expiration, err := checkCaCert(ps.CaCert, time.Now())
+		if !expiration.IsZero() {
+			h.CaCertExpirationTime = timestamp.New(expiration)
+		}
+		if err != nil {
+			return h.fail(CaCertificateCheck, err.Error())
+		}
+	}
+
+	client, err := ps.client()
+	if err != nil {
+		return h.fail(ConnectivityCheck, fmt.Sprintf("unable to create vault client: %s", err))
+	}
+	if err := client.ping(); err != nil {
+		return h.fail(ConnectivityCheck, err.Error())
+	}
+
+	if ps.TokenStatus != string(CurrentToken) {
+		return h.fail(TokenCheck, "no current vault token")
+	}
+	tokenLookup, err := client.lookupToken()
+	if err != nil {
+		return h.fail(TokenCheck, fmt.Sprintf("unable to lookup vault token: %s", err))
+	}
+	ttl, err := tokenLookup.TokenTTL()
+	if err != nil {
+		return h.fail(TokenCheck, fmt.Sprintf("unable to read vault token ttl: %s", err))
+	}
+	if ttl > 0 {
+		h.TokenExpirationTime = timestamp.New(time.Now().Add(ttl))
+		if ttl < healthCheckInterval {
+			return h.fail(TokenCheck, fmt.Sprintf("vault token expires in %s", ttl.Round(time.Second)))
+		}
+	}
+
+	required := requiredCapabilities.union(libraryCapabilities(libs))
+	available, err := client.capabilities(required.paths())
+	if err != nil {
+		return h.fail(CapabilitiesCheck, fmt.Sprintf("unable to lookup vault token capabilities: %s", err))
+	}
+	if missing := available.missing(required); len(missing) > 0 {
+		return h.fail(CapabilitiesCheck, fmt.Sprintf("vault token is missing capabilities: %s", missing))
+	}
+
+	return h
+}
+
+// lookupLibraryPaths returns the Vault paths of the credential libraries
+// of the credential store storeId.
+func lookupLibraryPaths(ctx context.Context, r db.Reader, storeId string) ([]*libraryPath, error) {
+	const op = "vault.lookupLibraryPaths"
+	rows, err := r.Query(ctx, selectLibraryPathsQuery, []interface{}{storeId})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+
+	var libs []*libraryPath
+	for rows.Next() {
+		var l libraryPath
+		if err := r.ScanRows(rows, &l); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		libs = append(libs, &l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return libs, nil
+}
+
+// lookupHealthCheck returns the result of the last health check of the
+// credential store storeId. It returns nil, nil if the credential store
+// has not been checked.
+func lookupHealthCheck(ctx context.Context, r db.Reader, storeId string) (*HealthCheck, error) {
+	const op = "vault.lookupHealthCheck"
+	agg := allocPublicStore()
+	agg.PublicId = storeId
+	if err := r.LookupByPublicId(ctx, agg); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return agg.healthCheck(), nil
+}
@@ -0,0 +1,116 @@
+package vault
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCaCertPem(t *testing.T, notBefore, notAfter time.Time) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestCheckCaCert(t *testing.T) {
+	t.Parallel()
+	now := time.Now().Truncate(time.Second)
+	valid := testCaCertPem(t, now.Add(-time.Hour), now.Add(24*time.Hour))
+	soonest := testCaCertPem(t, now.Add(-time.Hour), now.Add(2*time.Hour))
+	expired := testCaCertPem(t, now.Add(-2*time.Hour), now.Add(-time.Hour))
+	notYetValid := testCaCertPem(t, now.Add(time.Hour), now.Add(2*time.Hour))
+
+	tests := []struct {
+		name           string
+		caCert         []byte
+		wantExpiration time.Time
+		wantErr        bool
+	}{
+		{
+			name:           "valid",
+			caCert:         valid,
+			wantExpiration: now.Add(24 * time.Hour),
+		},
+		{
+			name:           "earliest-expiration",
+			caCert:         append(append([]byte{}, valid...), soonest...),
+			wantExpiration: now.Add(2 * time.Hour),
+		},
+		{
+			name:           "expired",
+			caCert:         append(append([]byte{}, valid...), expired...),
+			wantExpiration: now.Add(-time.Hour),
+			wantErr:        true,
+		},
+		{
+			name:           "not-yet-valid",
+			caCert:         notYetValid,
+			wantExpiration: now.Add(2 * time.Hour),
+			wantErr:        true,
+		},
+		{
+			name:   "no-certificates",
+			caCert: []byte("not a certificate"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert := assert.New(t)
+			got, err := checkCaCert(tt.caCert, now)
+			if tt.wantErr {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+			}
+			assert.True(tt.wantExpiration.Equal(got), "want expiration %s, got %s", tt.wantExpiration, got)
+		})
+	}
+}
+
+func TestLibraryCapabilities(t *testing.T) {
+	t.Parallel()
+	got := libraryCapabilities([]*libraryPath{
+		{PublicId: "clvlt_1", VaultPath: "database/creds/opened", HttpMethod: "GET"},
+		{PublicId: "clvlt_2", VaultPath: "pki/issue/boundary", HttpMethod: "POST"},
+		{PublicId: "clvsc_1", VaultPath: "ssh/sign/boundary", HttpMethod: "POST"},
+	})
+	assert.Equal(t, pathCapabilities{
+		"database/creds/opened": readCapability,
+		"pki/issue/boundary":    updateCapability,
+		"ssh/sign/boundary":     updateCapability,
+	}, got)
+}
+
+func TestHealthCheck_Error(t *testing.T) {
+	t.Parallel()
+	h := &HealthCheck{StoreId: "csvlt_1234567890", Status: HealthyStatus}
+	assert.True(t, h.Healthy())
+	assert.Empty(t, h.Error())
+
+	h.fail(TokenCheck, "no current vault token")
+	assert.False(t, h.Healthy())
+	assert.Equal(t, "credential store csvlt_1234567890 failed the token health check: no current vault token", h.Error())
+}
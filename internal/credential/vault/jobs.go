@@ -22,6 +22,7 @@ const (
 	credentialRevocationJobName   = "vault_credential_revocation"
 	credentialStoreCleanupJobName = "vault_credential_store_cleanup"
 	credentialCleanupJobName      = "vault_credential_cleanup"
+	healthCheckJobName            = "vault_credential_store_health_check"
 
 	defaultNextRunIn = 5 * time.Minute
 	renewalWindow    = 10 * time.Minute
@@ -71,12 +72,22 @@ func RegisterJobs(ctx context.Context, scheduler *scheduler.Scheduler, r db.Read
 	if err = scheduler.RegisterJob(ctx, credCleanup); err != nil {
 		return errors.Wrap(ctx, err, op, errors.WithMsg("credential cleanup job"))
 	}
+	healthCheck, err := newHealthCheckJob(r, w, kms)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err = scheduler.RegisterJob(ctx, healthCheck); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("credential store health check job"))
+	}
 	return nil
 }
 
 // TokenRenewalJob is the recurring job that renews credential store Vault tokens that
-// are in the `current` and `maintaining` state.  The TokenRenewalJob is not thread safe,
-// an attempt to Run the job concurrently will result in an JobAlreadyRunning error.
+// are in the `current` and `maintaining` state. For credential stores with an auth
+// method, it logs in to Vault again when the current token cannot be renewed and
+// retries the login for credential stores left without a current token. The
+// TokenRenewalJob is not thread safe, an attempt to Run the job concurrently will
+// result in an JobAlreadyRunning error.
 type TokenRenewalJob struct {
 	reader db.Reader
 	writer db.Writer
@@ -162,6 +173,30 @@ func (r *TokenRenewalJob) Run(ctx context.Context) error {
 		r.numProcessed++
 	}
 
+	// Retry the login of credential stores with an auth method which are
+	// left without a current token.
+	var failed []*privateStore
+	err = r.reader.SearchWhere(ctx, &failed, storesNeedingLoginWhere, nil, db.WithLimit(r.limit))
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	seen := make(map[string]bool, len(failed))
+	for _, s := range failed {
+		if seen[s.PublicId] {
+			// the view returns a row for each maintaining token
+			continue
+		}
+		seen[s.PublicId] = true
+		r.numTokens++
+		if err := ctx.Err(); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := r.loginStore(ctx, s); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error logging in to vault", "credential store id", s.StoreId))
+		}
+		r.numProcessed++
+	}
+
 	return nil
 }
 
@@ -210,9 +245,21 @@ func (r *TokenRenewalJob) renewToken(ctx context.Context, s *privateStore) error
 			return errors.Wrap(ctx, err, op, errors.WithMsg("error updating credentials to revoked after revoking token"))
 		}
 
+		if s.TokenStatus == string(CurrentToken) {
+			if _, err := r.login(ctx, s); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+		}
 		return nil
 	}
 	if err != nil {
+		if s.TokenStatus == string(CurrentToken) {
+			// The token may still be valid, so it is kept as a
+			// maintaining token if the login succeeds.
+			if ok, loginErr := r.login(ctx, s); ok && loginErr == nil {
+				return nil
+			}
+		}
 		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to renew vault token"))
 	}
 
@@ -231,9 +278,77 @@ func (r *TokenRenewalJob) renewToken(ctx context.Context, s *privateStore) error
 		return errors.New(ctx, errors.Unknown, op, "token renewed but failed to update repo")
 	}
 
+	if s.TokenStatus == string(CurrentToken) && tokenExpires < renewalWindow {
+		// The token reached its max TTL and will expire before the next
+		// renewal, replace it while it is still valid.
+		if _, err := r.login(ctx, s); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+	}
+
+	return nil
+}
+
+// loginStore decrypts the credential store s and logs in to Vault with
+// its auth method.
+func (r *TokenRenewalJob) loginStore(ctx context.Context, s *privateStore) error {
+	const op = "vault.(TokenRenewalJob).loginStore"
+	databaseWrapper, err := r.kms.GetWrapper(ctx, s.ScopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err = s.decrypt(ctx, databaseWrapper); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if _, err := r.login(ctx, s); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
 	return nil
 }
 
+// login logs in to Vault with the auth method of the credential store s
+// and stores the new token as the current token of s. It returns false if
+// s has no auth method. s must be decrypted.
+func (r *TokenRenewalJob) login(ctx context.Context, s *privateStore) (bool, error) {
+	const op = "vault.(TokenRenewalJob).login"
+	databaseWrapper, err := r.kms.GetWrapper(ctx, s.ScopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	am, err := lookupAuthMethod(ctx, r.reader, s.PublicId, databaseWrapper)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	if am == nil {
+		return false, nil
+	}
+
+	vc, err := s.client()
+	if err != nil {
+		return true, errors.Wrap(ctx, err, op)
+	}
+	token, err := am.login(ctx, vc)
+	if err != nil {
+		event.WriteSysEvent(ctx, op, "Vault credential store failed to log in to vault", "credential store id", s.PublicId, "auth method", am.GetAuthMethod())
+		return true, errors.Wrap(ctx, err, op)
+	}
+	if err := token.encrypt(ctx, databaseWrapper); err != nil {
+		return true, errors.Wrap(ctx, err, op)
+	}
+	// The insert trigger changes the status of the previous current token
+	// to maintaining.
+	query, values := token.insertQuery()
+	numRows, err := r.writer.Exec(ctx, query, values)
+	if err != nil {
+		return true, errors.Wrap(ctx, err, op)
+	}
+	if numRows != 1 {
+		return true, errors.New(ctx, errors.Unknown, op, "logged in but failed to insert token")
+	}
+	event.WriteSysEvent(ctx, op, "Vault credential store logged in to vault", "credential store id", s.PublicId, "auth method", am.GetAuthMethod())
+	return true, nil
+}
+
 // NextRunIn queries the vault credential repo to determine when the next token renewal job should run.
 func (r *TokenRenewalJob) NextRunIn() (time.Duration, error) {
 	const op = "vault.(TokenRenewalJob).NextRunIn"
@@ -242,6 +357,18 @@ func (r *TokenRenewalJob) NextRunIn() (time.Duration, error) {
 		return defaultNextRunIn, errors.WrapDeprecated(err, op)
 	}
 
+	if next > defaultNextRunIn {
+		// Retry the login of credential stores without a current token
+		// at least every defaultNextRunIn.
+		var failed []*privateStore
+		if err := r.reader.SearchWhere(context.Background(), &failed, storesNeedingLoginWhere, nil, db.WithLimit(1)); err != nil {
+			return defaultNextRunIn, errors.WrapDeprecated(err, op)
+		}
+		if len(failed) > 0 {
+			return defaultNextRunIn, nil
+		}
+	}
+
 	return next, nil
 }
 
@@ -953,3 +1080,156 @@ func (r *CredentialCleanupJob) Name() string {
 func (r *CredentialCleanupJob) Description() string {
 	return "Periodically deletes Vault credentials that are no longer attached to a session (have a null session_id) and are not active in Vault."
 }
+
+// HealthCheckJob is the recurring job that checks the connectivity to
+// Vault, the TTL of the current token, and the capabilities of the token
+// for each Vault credential store and stores the result on the credential
+// store. The HealthCheckJob is not thread safe, an attempt to Run the job
+// concurrently will result in an JobAlreadyRunning error.
+type HealthCheckJob struct {
+	reader db.Reader
+	writer db.Writer
+	kms    *kms.Kms
+
+	limit        int
+	running      ua.Bool
+	numProcessed int
+	numStores    int
+}
+
+// newHealthCheckJob creates a new in-memory HealthCheckJob.
+//
+// WithLimit is the only supported option.
+func newHealthCheckJob(r db.Reader, w db.Writer, kms *kms.Kms, opt ...Option) (*HealthCheckJob, error) {
+	const op = "vault.newHealthCheckJob"
+	switch {
+	case r == nil:
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing db.Reader")
+	case w == nil:
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing db.Writer")
+	case kms == nil:
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing kms")
+	}
+
+	opts := getOpts(opt...)
+	if opts.withLimit == 0 {
+		// zero signals the boundary defaults should be used.
+		opts.withLimit = db.DefaultLimit
+	}
+	return &HealthCheckJob{
+		reader: r,
+		writer: w,
+		kms:    kms,
+		limit:  opts.withLimit,
+	}, nil
+}
+
+// Status returns the current status of the credential store health check job.
+func (r *HealthCheckJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: r.numProcessed,
+		Total:     r.numStores,
+	}
+}
+
+// Run checks the health of the vault credential stores which have not been
+// checked within the last half of the health check interval. Can not be run
+// in parallel, if Run is invoked while already running an error with code
+// JobAlreadyRunning will be returned.
+func (r *HealthCheckJob) Run(ctx context.Context) error {
+	const op = "vault.(HealthCheckJob).Run"
+	if !r.running.CAS(r.running.Load(), true) {
+		return errors.New(ctx, errors.JobAlreadyRunning, op, "job already running")
+	}
+	defer r.running.Store(false)
+
+	// Verify context is not done before running
+	if err := ctx.Err(); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	var rows []*privateStore
+	values := []interface{}{int((healthCheckInterval / 2).Seconds())}
+	err := r.reader.SearchWhere(ctx, &rows, storesNeedingHealthCheckWhere, values, db.WithLimit(r.limit))
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	// The view returns a row for each active token of a store, only the
+	// current token is checked.
+	var stores []*privateStore
+	byId := make(map[string]*privateStore, len(rows))
+	for _, s := range rows {
+		ps, ok := byId[s.PublicId]
+		switch {
+		case !ok:
+			byId[s.PublicId] = s
+			stores = append(stores, s)
+		case s.TokenStatus == string(CurrentToken) && ps.TokenStatus != string(CurrentToken):
+			*ps = *s
+		}
+	}
+
+	// Set numProcessed and numStores for status report
+	r.numProcessed, r.numStores = 0, len(stores)
+	for _, s := range stores {
+		// Verify context is not done before checking next store
+		if err := ctx.Err(); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := r.checkStore(ctx, s); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error checking credential store health", "credential store id", s.PublicId))
+		}
+		r.numProcessed++
+	}
+
+	return nil
+}
+
+func (r *HealthCheckJob) checkStore(ctx context.Context, s *privateStore) error {
+	const op = "vault.(HealthCheckJob).checkStore"
+	if s.TokenStatus != string(CurrentToken) {
+		s.clearToken()
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, s.ScopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err = s.decrypt(ctx, databaseWrapper); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	libs, err := lookupLibraryPaths(ctx, r.reader, s.PublicId)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	h := checkHealth(ctx, s, libs)
+	if !h.Healthy() {
+		event.WriteSysEvent(ctx, op, "Vault credential store failed health check", "credential store id", s.PublicId, "check", h.FailedCheck, "message", h.Message)
+	}
+
+	query, values := h.upsertQuery()
+	numRows, err := r.writer.Exec(ctx, query, values)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if numRows != 1 {
+		return errors.New(ctx, errors.Unknown, op, "health check completed but failed to update repo")
+	}
+	return nil
+}
+
+// NextRunIn determine when the next credential store health check job should run.
+func (r *HealthCheckJob) NextRunIn() (time.Duration, error) {
+	return healthCheckInterval, nil
+}
+
+// Name is the unique name of the job.
+func (r *HealthCheckJob) Name() string {
+	return healthCheckJobName
+}
+
+// Description is the human readable description of the job.
+func (r *HealthCheckJob) Description() string {
+	return "Periodically checks the connectivity to Vault, the Vault token TTL, and the Vault token capabilities of Vault credential stores."
+}
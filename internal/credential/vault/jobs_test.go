@@ -468,6 +468,109 @@ func TestTokenRenewalJob_RunExpired(t *testing.T) {
 	assert.Equal(string(ExpiredToken), token.Status)
 }
 
+func TestTokenRenewalJob_Login(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	v := NewTestVaultServer(t)
+	roleId, secretId := v.MountAppRole(t)
+
+	r, err := newTokenRenewalJob(rw, rw, kmsCache)
+	require.NoError(err)
+	err = sche.RegisterJob(ctx, r)
+	require.NoError(err)
+
+	repo, err := NewRepository(rw, rw, kmsCache, sche)
+	require.NoError(err)
+	am, err := NewAppRoleAuthMethod(roleId, SecretIdSecret(secretId))
+	require.NoError(err)
+	in, err := NewCredentialStore(prj.GetPublicId(), v.Addr, nil, WithAuthMethod(am))
+	require.NoError(err)
+	amStore, err := repo.CreateCredentialStore(ctx, in)
+	require.NoError(err)
+	_, token := v.CreateToken(t)
+	in, err = NewCredentialStore(prj.GetPublicId(), v.Addr, []byte(token))
+	require.NoError(err)
+	tokenStore, err := repo.CreateCredentialStore(ctx, in)
+	require.NoError(err)
+
+	expireCurrent := func(storeId string) {
+		t.Helper()
+		_, err := rw.Exec(ctx, "update credential_vault_token set status = ? where store_id = ? and status = ?",
+			[]interface{}{ExpiredToken, storeId, CurrentToken})
+		require.NoError(err)
+	}
+	storesNeedingLogin := func() map[string]*privateStore {
+		t.Helper()
+		var ps []*privateStore
+		require.NoError(rw.SearchWhere(ctx, &ps, storesNeedingLoginWhere, nil))
+		stores := make(map[string]*privateStore, len(ps))
+		for _, s := range ps {
+			stores[s.PublicId] = s
+		}
+		return stores
+	}
+	operationalState := func(storeId string) OperationalState {
+		t.Helper()
+		cs, err := repo.LookupCredentialStore(ctx, storeId)
+		require.NoError(err)
+		require.NotNil(cs)
+		return cs.OperationalState()
+	}
+
+	// Stores with a current token do not need to log in
+	assert.Empty(storesNeedingLogin())
+	assert.Equal(ActiveState, operationalState(amStore.GetPublicId()))
+
+	// Only stores with an auth method are selected once their current
+	// token expired
+	expireCurrent(amStore.GetPublicId())
+	expireCurrent(tokenStore.GetPublicId())
+	stores := storesNeedingLogin()
+	require.Len(stores, 1)
+	ps := stores[amStore.GetPublicId()]
+	require.NotNil(ps)
+	assert.Equal(AuthFailedState, operationalState(amStore.GetPublicId()))
+	assert.Equal(AuthFailedState, operationalState(tokenStore.GetPublicId()))
+
+	// login does nothing for stores without an auth method
+	var tokenPs []*privateStore
+	require.NoError(rw.SearchWhere(ctx, &tokenPs, "public_id = ?", []interface{}{tokenStore.GetPublicId()}))
+	require.NotEmpty(tokenPs)
+	ok, err := r.login(ctx, tokenPs[0])
+	require.NoError(err)
+	assert.False(ok)
+
+	// A successful login stores a new current token
+	require.NoError(r.loginStore(ctx, ps))
+	assert.Empty(storesNeedingLogin())
+	assert.Equal(ActiveState, operationalState(amStore.GetPublicId()))
+
+	current := allocToken()
+	require.NoError(rw.LookupWhere(ctx, &current, "store_id = ? and status = ?", []interface{}{amStore.GetPublicId(), CurrentToken}))
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, amStore.GetScopeId(), kms.KeyPurposeDatabase)
+	require.NoError(err)
+	require.NoError(current.decrypt(ctx, databaseWrapper))
+	v.LookupToken(t, string(current.GetToken()))
+
+	// A failed login leaves the store without a current token
+	vc := v.client(t).cl
+	_, err = vc.Logical().Write("auth/approle/role/boundary/secret-id/destroy", map[string]interface{}{"secret_id": secretId})
+	require.NoError(err)
+	expireCurrent(amStore.GetPublicId())
+	require.NoError(r.Run(ctx))
+	assert.Contains(storesNeedingLogin(), amStore.GetPublicId())
+	assert.Equal(AuthFailedState, operationalState(amStore.GetPublicId()))
+	assert.Equal(r.numTokens, r.numProcessed)
+}
+
 func TestTokenRenewalJob_NextRunIn(t *testing.T) {
 	t.Parallel()
 
@@ -1,5 +1,7 @@
 package vault
 
+import "github.com/hashicorp/boundary/internal/credential"
+
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
 	opts := getDefaultOptions()
@@ -22,8 +24,18 @@ type options struct {
 	withTlsServerName string
 	withTlsSkipVerify bool
 	withClientCert    *ClientCertificate
+	withAuthMethod    *AuthMethod
+	withMountPath     string
 	withMethod        Method
 	withRequestBody   []byte
+
+	withCredentialType      credential.Type
+	withUsernameAttribute   string
+	withPasswordAttribute   string
+	withPrivateKeyAttribute string
+
+	withTtl        string
+	withExtensions map[string]string
 }
 
 func getDefaultOptions() options {
@@ -94,6 +106,22 @@ func WithClientCert(clientCert *ClientCertificate) Option {
 	}
 }
 
+// WithAuthMethod provides an optional AuthMethod the credential store uses
+// to log in to a Vault server instead of using a token.
+func WithAuthMethod(am *AuthMethod) Option {
+	return func(o *options) {
+		o.withAuthMethod = am
+	}
+}
+
+// WithMountPath provides an optional path a Vault auth method is mounted
+// at.
+func WithMountPath(p string) Option {
+	return func(o *options) {
+		o.withMountPath = p
+	}
+}
+
 // WithMethod provides an optional Method to use for communicating with
 // Vault.
 func WithMethod(m Method) Option {
@@ -109,3 +137,52 @@ func WithRequestBody(b []byte) Option {
 		o.withRequestBody = b
 	}
 }
+
+// WithCredentialType provides an optional credential type to associate
+// with a credential library.
+func WithCredentialType(t credential.Type) Option {
+	return func(o *options) {
+		o.withCredentialType = t
+	}
+}
+
+// WithUsernameAttribute provides an optional name of the attribute in a
+// Vault secret which holds the username of a typed credential.
+func WithUsernameAttribute(name string) Option {
+	return func(o *options) {
+		o.withUsernameAttribute = name
+	}
+}
+
+// WithPasswordAttribute provides an optional name of the attribute in a
+// Vault secret which holds the password of a username_password credential.
+func WithPasswordAttribute(name string) Option {
+	return func(o *options) {
+		o.withPasswordAttribute = name
+	}
+}
+
+// WithPrivateKeyAttribute provides an optional name of the attribute in a
+// Vault secret which holds the private key of an ssh_private_key
+// credential.
+func WithPrivateKeyAttribute(name string) Option {
+	return func(o *options) {
+		o.withPrivateKeyAttribute = name
+	}
+}
+
+// WithTtl provides an optional time to live to request for a signed SSH
+// certificate.
+func WithTtl(ttl string) Option {
+	return func(o *options) {
+		o.withTtl = ttl
+	}
+}
+
+// WithExtensions provides optional extensions to request for a signed SSH
+// certificate.
+func WithExtensions(e map[string]string) Option {
+	return func(o *options) {
+		o.withExtensions = e
+	}
+}
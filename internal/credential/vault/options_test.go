@@ -83,4 +83,29 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withRequestBody = []byte("body")
 		assert.Equal(t, opts, testOpts)
 	})
+	t.Run("WithTtl", func(t *testing.T) {
+		opts := getOpts(WithTtl("1h"))
+		testOpts := getDefaultOptions()
+		testOpts.withTtl = "1h"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithExtensions", func(t *testing.T) {
+		opts := getOpts(WithExtensions(map[string]string{"permit-pty": ""}))
+		testOpts := getDefaultOptions()
+		testOpts.withExtensions = map[string]string{"permit-pty": ""}
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithAuthMethod", func(t *testing.T) {
+		am := &AuthMethod{}
+		opts := getOpts(WithAuthMethod(am))
+		testOpts := getDefaultOptions()
+		testOpts.withAuthMethod = am
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithMountPath", func(t *testing.T) {
+		opts := getOpts(WithMountPath("my-approle"))
+		testOpts := getDefaultOptions()
+		testOpts.withMountPath = "my-approle"
+		assert.Equal(t, opts, testOpts)
+	})
 }
@@ -31,65 +31,194 @@ func (ac *actualCredential) Secret() credential.SecretData { return ac.secretDat
 func (ac *actualCredential) Library() credential.Library   { return ac.lib }
 func (ac *actualCredential) Purpose() credential.Purpose   { return ac.purpose }
 
+// The default names of the attributes in a Vault secret which hold the
+// fields of a typed credential.
+const (
+	defaultUsernameAttribute   = "username"
+	defaultPasswordAttribute   = "password"
+	defaultPrivateKeyAttribute = "private_key"
+)
+
+func attributeOrDefault(attr, def string) string {
+	if attr != "" {
+		return attr
+	}
+	return def
+}
+
+// secretValue returns the string value of attr in data. If data does not
+// contain attr, it is looked up in the nested "data" map used by version 2
+// of the Vault KV secrets engine.
+func secretValue(data map[string]interface{}, attr string) (string, bool) {
+	if v, ok := data[attr].(string); ok {
+		return v, true
+	}
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if v, ok := nested[attr].(string); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+var _ credential.UserPassword = (*usrPassCred)(nil)
+
+type usrPassCred struct {
+	*actualCredential
+	username string
+	password credential.Password
+}
+
+func (c *usrPassCred) Username() string              { return c.username }
+func (c *usrPassCred) Password() credential.Password { return c.password }
+
+var _ credential.KeyPair = (*sshPrivateKeyCred)(nil)
+
+type sshPrivateKeyCred struct {
+	*actualCredential
+	username   string
+	privateKey credential.PrivateKey
+}
+
+func (c *sshPrivateKeyCred) Username() string               { return c.username }
+func (c *sshPrivateKeyCred) Private() credential.PrivateKey { return c.privateKey }
+
+var _ credential.SshCertificate = (*sshCertCred)(nil)
+
+type sshCertCred struct {
+	*actualCredential
+	username    string
+	privateKey  credential.PrivateKey
+	certificate []byte
+}
+
+func (c *sshCertCred) Username() string               { return c.username }
+func (c *sshCertCred) Private() credential.PrivateKey { return c.privateKey }
+func (c *sshCertCred) Certificate() []byte            { return c.certificate }
+
+// typed decodes the secret data of ac into a credential of the credential
+// type of its library. It returns ac if the library has no credential
+// type.
+func (ac *actualCredential) typed(ctx context.Context) (credential.Dynamic, error) {
+	const op = "vault.(actualCredential).typed"
+	lib := ac.lib
+	value := func(attr, def string) (string, error) {
+		attr = attributeOrDefault(attr, def)
+		v, ok := secretValue(ac.secretData, attr)
+		if !ok {
+			return "", errors.New(ctx, errors.VaultInvalidCredentialMapping, op,
+				fmt.Sprintf("library %s: secret has no string attribute %q for credential type %s", lib.PublicId, attr, lib.CredentialType()))
+		}
+		return v, nil
+	}
+
+	switch lib.CredentialType() {
+	case credential.UsernamePasswordType:
+		username, err := value(lib.UsernameAttribute, defaultUsernameAttribute)
+		if err != nil {
+			return nil, err
+		}
+		password, err := value(lib.PasswordAttribute, defaultPasswordAttribute)
+		if err != nil {
+			return nil, err
+		}
+		return &usrPassCred{
+			actualCredential: ac,
+			username:         username,
+			password:         credential.Password(password),
+		}, nil
+	case credential.SshPrivateKeyType:
+		username, err := value(lib.UsernameAttribute, defaultUsernameAttribute)
+		if err != nil {
+			return nil, err
+		}
+		pk, err := value(lib.PrivateKeyAttribute, defaultPrivateKeyAttribute)
+		if err != nil {
+			return nil, err
+		}
+		return &sshPrivateKeyCred{
+			actualCredential: ac,
+			username:         username,
+			privateKey:       credential.PrivateKey(pk),
+		}, nil
+	default:
+		return ac, nil
+	}
+}
+
 var _ credential.Library = (*privateLibrary)(nil)
 
 type privateLibrary struct {
-	PublicId        string `gorm:"primary_key"`
-	StoreId         string
-	Name            string
-	Description     string
-	CreateTime      *timestamp.Timestamp
-	UpdateTime      *timestamp.Timestamp
-	Version         uint32
-	ScopeId         string
-	VaultPath       string
-	HttpMethod      string
-	HttpRequestBody []byte
-	VaultAddress    string
-	Namespace       string
-	CaCert          []byte
-	TlsServerName   string
-	TlsSkipVerify   bool
-	TokenHmac       []byte
-	Token           TokenSecret
-	CtToken         []byte
-	TokenKeyId      string
-	ClientCert      []byte
-	ClientKey       KeySecret
-	CtClientKey     []byte
-	ClientKeyId     string
-	Purpose         credential.Purpose `gorm:"-"`
+	PublicId            string `gorm:"primary_key"`
+	StoreId             string
+	Name                string
+	Description         string
+	CreateTime          *timestamp.Timestamp
+	UpdateTime          *timestamp.Timestamp
+	Version             uint32
+	ScopeId             string
+	VaultPath           string
+	HttpMethod          string
+	HttpRequestBody     []byte
+	CredType            string `gorm:"column:credential_type"`
+	UsernameAttribute   string
+	PasswordAttribute   string
+	PrivateKeyAttribute string
+	ValidPrincipals     string
+	Ttl                 string
+	Extensions          string
+	VaultAddress        string
+	Namespace           string
+	CaCert              []byte
+	TlsServerName       string
+	TlsSkipVerify       bool
+	TokenHmac           []byte
+	Token               TokenSecret
+	CtToken             []byte
+	TokenKeyId          string
+	ClientCert          []byte
+	ClientKey           KeySecret
+	CtClientKey         []byte
+	ClientKeyId         string
+	Purpose             credential.Purpose `gorm:"-"`
 }
 
 func (pl *privateLibrary) clone() *privateLibrary {
 	// The 'append(a[:0:0], a...)' comes from
 	// https://github.com/go101/go101/wiki/How-to-perfectly-clone-a-slice%3F
 	return &privateLibrary{
-		PublicId:        pl.PublicId,
-		StoreId:         pl.StoreId,
-		Name:            pl.Name,
-		Description:     pl.Description,
-		CreateTime:      proto.Clone(pl.CreateTime).(*timestamp.Timestamp),
-		UpdateTime:      proto.Clone(pl.UpdateTime).(*timestamp.Timestamp),
-		Version:         pl.Version,
-		ScopeId:         pl.ScopeId,
-		VaultPath:       pl.VaultPath,
-		HttpMethod:      pl.HttpMethod,
-		HttpRequestBody: append(pl.HttpRequestBody[:0:0], pl.HttpRequestBody...),
-		VaultAddress:    pl.VaultAddress,
-		Namespace:       pl.Namespace,
-		CaCert:          append(pl.CaCert[:0:0], pl.CaCert...),
-		TlsServerName:   pl.TlsServerName,
-		TlsSkipVerify:   pl.TlsSkipVerify,
-		TokenHmac:       append(pl.TokenHmac[:0:0], pl.TokenHmac...),
-		Token:           append(pl.Token[:0:0], pl.Token...),
-		CtToken:         append(pl.CtToken[:0:0], pl.CtToken...),
-		TokenKeyId:      pl.TokenKeyId,
-		ClientCert:      append(pl.ClientCert[:0:0], pl.ClientCert...),
-		ClientKey:       append(pl.ClientKey[:0:0], pl.ClientKey...),
-		CtClientKey:     append(pl.CtClientKey[:0:0], pl.CtClientKey...),
-		ClientKeyId:     pl.ClientKeyId,
-		Purpose:         pl.Purpose,
+		PublicId:            pl.PublicId,
+		StoreId:             pl.StoreId,
+		Name:                pl.Name,
+		Description:         pl.Description,
+		CreateTime:          proto.Clone(pl.CreateTime).(*timestamp.Timestamp),
+		UpdateTime:          proto.Clone(pl.UpdateTime).(*timestamp.Timestamp),
+		Version:             pl.Version,
+		ScopeId:             pl.ScopeId,
+		VaultPath:           pl.VaultPath,
+		HttpMethod:          pl.HttpMethod,
+		HttpRequestBody:     append(pl.HttpRequestBody[:0:0], pl.HttpRequestBody...),
+		CredType:            pl.CredType,
+		UsernameAttribute:   pl.UsernameAttribute,
+		PasswordAttribute:   pl.PasswordAttribute,
+		PrivateKeyAttribute: pl.PrivateKeyAttribute,
+		ValidPrincipals:     pl.ValidPrincipals,
+		Ttl:                 pl.Ttl,
+		Extensions:          pl.Extensions,
+		VaultAddress:        pl.VaultAddress,
+		Namespace:           pl.Namespace,
+		CaCert:              append(pl.CaCert[:0:0], pl.CaCert...),
+		TlsServerName:       pl.TlsServerName,
+		TlsSkipVerify:       pl.TlsSkipVerify,
+		TokenHmac:           append(pl.TokenHmac[:0:0], pl.TokenHmac...),
+		Token:               append(pl.Token[:0:0], pl.Token...),
+		CtToken:             append(pl.CtToken[:0:0], pl.CtToken...),
+		TokenKeyId:          pl.TokenKeyId,
+		ClientCert:          append(pl.ClientCert[:0:0], pl.ClientCert...),
+		ClientKey:           append(pl.ClientKey[:0:0], pl.ClientKey...),
+		CtClientKey:         append(pl.CtClientKey[:0:0], pl.CtClientKey...),
+		ClientKeyId:         pl.ClientKeyId,
+		Purpose:             pl.Purpose,
 	}
 }
 
@@ -101,6 +230,13 @@ func (pl *privateLibrary) GetVersion() uint32                  { return pl.Versi
 func (pl *privateLibrary) GetCreateTime() *timestamp.Timestamp { return pl.CreateTime }
 func (pl *privateLibrary) GetUpdateTime() *timestamp.Timestamp { return pl.UpdateTime }
 
+func (pl *privateLibrary) CredentialType() credential.Type {
+	if pl.CredType != "" {
+		return credential.Type(pl.CredType)
+	}
+	return credential.UnspecifiedType
+}
+
 func (pl *privateLibrary) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
 	const op = "vault.(privateLibrary).decrypt"
 
@@ -170,14 +306,49 @@ func (r *Repository) getPrivateLibraries(ctx context.Context, requests []credent
 		return nil, errors.Wrap(ctx, err, op)
 	}
 
-	libIds := mapper.libIds()
+	// Each library subtype has its own private view.
+	idsByQuery := make(map[string][]string)
+	for _, id := range mapper.libIds() {
+		switch credential.SubtypeFromId(id) {
+		case SshSignedCertSubtype:
+			idsByQuery[selectPrivateSshSignedCertLibrariesQuery] = append(idsByQuery[selectPrivateSshSignedCertLibrariesQuery], id)
+		default:
+			idsByQuery[selectPrivateLibrariesQuery] = append(idsByQuery[selectPrivateLibrariesQuery], id)
+		}
+	}
+
+	var libs []*privateLibrary
+	for q, libIds := range idsByQuery {
+		ql, err := r.queryPrivateLibraries(ctx, q, libIds, mapper)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		libs = append(libs, ql...)
+	}
+
+	for _, pl := range libs {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, pl.ScopeId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+		}
+
+		if err := pl.decrypt(ctx, databaseWrapper); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+
+	return libs, nil
+}
+
+func (r *Repository) queryPrivateLibraries(ctx context.Context, q string, libIds []string, mapper *requestMap) ([]*privateLibrary, error) {
+	const op = "vault.(Repository).queryPrivateLibraries"
 	var inClauseSpots []string
 	for i := 1; i < len(libIds)+1; i++ {
 		inClauseSpots = append(inClauseSpots, fmt.Sprintf("@%d", i))
 	}
 	inClause := strings.Join(inClauseSpots, ",")
 
-	query := fmt.Sprintf(selectPrivateLibrariesQuery, inClause)
+	query := fmt.Sprintf(q, inClause)
 
 	var params []interface{}
 	for idx, v := range libIds {
@@ -205,18 +376,6 @@ func (r *Repository) getPrivateLibraries(ctx context.Context, requests []credent
 			libs = append(libs, cp)
 		}
 	}
-
-	for _, pl := range libs {
-		databaseWrapper, err := r.kms.GetWrapper(ctx, pl.ScopeId, kms.KeyPurposeDatabase)
-		if err != nil {
-			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
-		}
-
-		if err := pl.decrypt(ctx, databaseWrapper); err != nil {
-			return nil, errors.Wrap(ctx, err, op)
-		}
-	}
-
 	return libs, nil
 }
 
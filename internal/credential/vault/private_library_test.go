@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/credential"
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/scheduler"
@@ -112,6 +113,18 @@ func TestRepository_getPrivateLibraries(t *testing.T) {
 				req := credential.Request{SourceId: lib.GetPublicId(), Purpose: credential.ApplicationPurpose}
 				requests = append(requests, req)
 			}
+			{
+				libIn, err := NewCredentialLibrary(origStore.GetPublicId(), "/vault/path",
+					WithCredentialType(credential.UsernamePasswordType), WithUsernameAttribute("user"), WithPasswordAttribute("pass"))
+				assert.NoError(err)
+				require.NotNil(libIn)
+				lib, err := repo.CreateCredentialLibrary(ctx, prj.GetPublicId(), libIn)
+				assert.NoError(err)
+				require.NotNil(lib)
+				libs[lib.GetPublicId()] = lib
+				req := credential.Request{SourceId: lib.GetPublicId(), Purpose: credential.ApplicationPurpose}
+				requests = append(requests, req)
+			}
 
 			gotLibs, err := repo.getPrivateLibraries(ctx, requests)
 			assert.NoError(err)
@@ -131,6 +144,125 @@ func TestRepository_getPrivateLibraries(t *testing.T) {
 				assert.Equal(want.VaultPath, got.VaultPath)
 				assert.Equal(want.HttpMethod, got.HttpMethod)
 				assert.Equal(want.HttpRequestBody, got.HttpRequestBody)
+				assert.Equal(want.CredentialType(), got.CredentialType())
+				assert.Equal(want.UsernameAttribute, got.UsernameAttribute)
+				assert.Equal(want.PasswordAttribute, got.PasswordAttribute)
+				assert.Equal(want.PrivateKeyAttribute, got.PrivateKeyAttribute)
+			}
+		})
+	}
+}
+
+func TestActualCredential_typed(t *testing.T) {
+	tests := []struct {
+		name         string
+		lib          *privateLibrary
+		secretData   map[string]interface{}
+		wantUsername string
+		wantPassword credential.Password
+		wantKey      credential.PrivateKey
+		wantUntyped  bool
+		wantErr      errors.Code
+	}{
+		{
+			name:        "unspecified",
+			lib:         &privateLibrary{},
+			secretData:  map[string]interface{}{"username": "user"},
+			wantUntyped: true,
+		},
+		{
+			name:         "username-password-defaults",
+			lib:          &privateLibrary{CredType: string(credential.UsernamePasswordType)},
+			secretData:   map[string]interface{}{"username": "user", "password": "pass"},
+			wantUsername: "user",
+			wantPassword: "pass",
+		},
+		{
+			name: "username-password-overrides",
+			lib: &privateLibrary{
+				CredType:          string(credential.UsernamePasswordType),
+				UsernameAttribute: "login",
+				PasswordAttribute: "secret",
+			},
+			secretData:   map[string]interface{}{"login": "user", "secret": "pass"},
+			wantUsername: "user",
+			wantPassword: "pass",
+		},
+		{
+			name: "username-password-kv2",
+			lib:  &privateLibrary{CredType: string(credential.UsernamePasswordType)},
+			secretData: map[string]interface{}{
+				"data":     map[string]interface{}{"username": "user", "password": "pass"},
+				"metadata": map[string]interface{}{"version": 1},
+			},
+			wantUsername: "user",
+			wantPassword: "pass",
+		},
+		{
+			name:       "username-password-missing-password",
+			lib:        &privateLibrary{CredType: string(credential.UsernamePasswordType)},
+			secretData: map[string]interface{}{"username": "user"},
+			wantErr:    errors.VaultInvalidCredentialMapping,
+		},
+		{
+			name:       "username-password-not-a-string",
+			lib:        &privateLibrary{CredType: string(credential.UsernamePasswordType)},
+			secretData: map[string]interface{}{"username": "user", "password": 1234},
+			wantErr:    errors.VaultInvalidCredentialMapping,
+		},
+		{
+			name:         "ssh-private-key-defaults",
+			lib:          &privateLibrary{CredType: string(credential.SshPrivateKeyType)},
+			secretData:   map[string]interface{}{"username": "user", "private_key": "key"},
+			wantUsername: "user",
+			wantKey:      credential.PrivateKey("key"),
+		},
+		{
+			name: "ssh-private-key-overrides",
+			lib: &privateLibrary{
+				CredType:            string(credential.SshPrivateKeyType),
+				PrivateKeyAttribute: "pk",
+			},
+			secretData:   map[string]interface{}{"username": "user", "pk": "key"},
+			wantUsername: "user",
+			wantKey:      credential.PrivateKey("key"),
+		},
+		{
+			name:       "ssh-private-key-missing-username",
+			lib:        &privateLibrary{CredType: string(credential.SshPrivateKeyType)},
+			secretData: map[string]interface{}{"private_key": "key"},
+			wantErr:    errors.VaultInvalidCredentialMapping,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ac := &actualCredential{
+				id:         "cred_1234567890",
+				lib:        tt.lib,
+				secretData: tt.secretData,
+				purpose:    credential.ApplicationPurpose,
+			}
+			got, err := ac.typed(context.Background())
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.Equal(ac.GetPublicId(), got.GetPublicId())
+			assert.Equal(tt.secretData, got.Secret())
+			switch c := got.(type) {
+			case credential.UserPassword:
+				assert.Equal(tt.wantUsername, c.Username())
+				assert.Equal(tt.wantPassword, c.Password())
+			case credential.KeyPair:
+				assert.Equal(tt.wantUsername, c.Username())
+				assert.Equal(tt.wantKey, c.Private())
+			default:
+				assert.True(tt.wantUntyped)
 			}
 		})
 	}
@@ -44,13 +44,27 @@ func (r *Repository) lookupPrivateStore(ctx context.Context, publicId string) (*
 	if publicId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
 	}
-	ps := allocPrivateStore()
-	if err := r.reader.LookupWhere(ctx, &ps, "public_id = ? and token_status = ?", publicId, CurrentToken); err != nil {
-		if errors.IsNotFoundError(err) {
-			return nil, nil
-		}
+	// The view returns a row for each active token of the store, or a
+	// single row without a token if the store has no active tokens. A
+	// store without a current token is returned without a token so it can
+	// still be updated with a new token or auth method.
+	var stores []*privateStore
+	if err := r.reader.SearchWhere(ctx, &stores, "public_id = ? and delete_time is null", []interface{}{publicId}); err != nil {
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", publicId)))
 	}
+	if len(stores) == 0 {
+		return nil, nil
+	}
+	ps := stores[0]
+	for _, s := range stores {
+		if s.TokenStatus == string(CurrentToken) {
+			ps = s
+			break
+		}
+	}
+	if ps.TokenStatus != string(CurrentToken) {
+		ps.clearToken()
+	}
 
 	databaseWrapper, err := r.kms.GetWrapper(ctx, ps.ScopeId, kms.KeyPurposeDatabase)
 	if err != nil {
@@ -128,6 +142,19 @@ func (ps *privateStore) toCredentialStore() *CredentialStore {
 	return cs
 }
 
+func (ps *privateStore) clearToken() {
+	ps.TokenHmac = nil
+	ps.Token = nil
+	ps.CtToken = nil
+	ps.TokenCreateTime = nil
+	ps.TokenUpdateTime = nil
+	ps.TokenLastRenewalTime = nil
+	ps.TokenExpirationTime = nil
+	ps.TokenRenewalTime = nil
+	ps.TokenKeyId = ""
+	ps.TokenStatus = ""
+}
+
 func (ps *privateStore) token() *Token {
 	if ps.TokenHmac != nil {
 		tk := allocToken()
@@ -11,6 +11,9 @@ func init() {
 	if err := credential.Register(Subtype, CredentialStorePrefix, CredentialLibraryPrefix, DynamicCredentialPrefix); err != nil {
 		panic(err)
 	}
+	if err := credential.Register(SshSignedCertSubtype, SshSignedCertCredentialLibraryPrefix); err != nil {
+		panic(err)
+	}
 }
 
 // PublicId prefixes for the resources in the vault package.
@@ -19,7 +22,10 @@ const (
 	CredentialLibraryPrefix = "clvlt"
 	DynamicCredentialPrefix = "cdvlt"
 
-	Subtype = subtypes.Subtype("vault")
+	SshSignedCertCredentialLibraryPrefix = "clvsc"
+
+	Subtype              = subtypes.Subtype("vault")
+	SshSignedCertSubtype = subtypes.Subtype("vault-ssh-signed-cert")
 )
 
 func newCredentialStoreId() (string, error) {
@@ -45,3 +51,11 @@ func newCredentialLibraryId() (string, error) {
 	}
 	return id, nil
 }
+
+func newSshSignedCertCredentialLibraryId() (string, error) {
+	id, err := db.NewPublicId(SshSignedCertCredentialLibraryPrefix)
+	if err != nil {
+		return "", errors.WrapDeprecated(err, "vault.newSshSignedCertCredentialLibraryId")
+	}
+	return id, nil
+}
@@ -87,12 +87,39 @@ delete from credential_vault_client_certificate
  where store_id = ?;
 `
 
+	upsertAuthMethodQuery = `
+insert into credential_vault_auth_method
+  (store_id, auth_method, mount_path, role_id, secret_id, secret_id_hmac, kubernetes_role, key_id)
+values
+  (@store_id, @auth_method, @mount_path, @role_id, @secret_id, @secret_id_hmac, @kubernetes_role, @key_id)
+on conflict (store_id) do update
+  set auth_method     = excluded.auth_method,
+      mount_path      = excluded.mount_path,
+      role_id         = excluded.role_id,
+      secret_id       = excluded.secret_id,
+      secret_id_hmac  = excluded.secret_id_hmac,
+      kubernetes_role = excluded.kubernetes_role,
+      key_id          = excluded.key_id
+returning *;
+`
+
+	deleteAuthMethodQuery = `
+delete from credential_vault_auth_method
+ where store_id = ?;
+`
+
 	selectPrivateLibrariesQuery = `
 select *
   from credential_vault_library_private
  where public_id in (%s);
 `
 
+	selectPrivateSshSignedCertLibrariesQuery = `
+select *
+  from credential_vault_ssh_signed_cert_library_private
+ where public_id in (%s);
+`
+
 	updateSessionCredentialQuery = `
 update session_credential_dynamic
    set credential_id = @public_id
@@ -126,6 +153,54 @@ select extract(epoch from (last_renewal_time + (expiration_time - last_renewal_t
        );
 `
 
+	upsertHealthCheckQuery = `
+insert into credential_vault_store_health
+  (store_id, status, failed_check, message, token_expiration_time, ca_cert_expiration_time)
+values
+  ($1, $2, $3, $4, $5, $6)
+on conflict (store_id) do update
+  set status                  = excluded.status,
+      failed_check            = excluded.failed_check,
+      message                 = excluded.message,
+      token_expiration_time   = excluded.token_expiration_time,
+      ca_cert_expiration_time = excluded.ca_cert_expiration_time,
+      check_time              = now();
+`
+
+	deleteHealthCheckQuery = `
+delete from credential_vault_store_health
+ where store_id = $1;
+`
+
+	selectLibraryPathsQuery = `
+select public_id, vault_path, http_method
+  from credential_vault_library
+ where store_id = $1
+ union
+select public_id, vault_path, 'POST' as http_method
+  from credential_vault_ssh_signed_cert_library
+ where store_id = $1;
+`
+
+	storesNeedingHealthCheckWhere = `
+delete_time is null
+and public_id not in (
+  select store_id from credential_vault_store_health
+   where check_time > now() - ? * interval '1 second'
+)
+`
+
+	storesNeedingLoginWhere = `
+delete_time is null
+and public_id in (
+  select store_id from credential_vault_auth_method
+)
+and public_id not in (
+  select store_id from credential_vault_token
+   where status = 'current'
+)
+`
+
 	revokeCredentialsQuery = `
 update credential_vault_credential
    set status = 'revoke'
@@ -133,6 +208,26 @@ update credential_vault_credential
    and status = 'active';
 `
 
+	revokeSessionPurposeCredentialsQuery = `
+update credential_vault_credential
+   set status = 'revoke'
+ where status = 'active'
+   and public_id in (
+     select credential_id
+       from session_credential_dynamic
+      where session_id = @session_id
+        and credential_purpose = @purpose
+   );
+`
+
+	releaseSessionPurposeCredentialsQuery = `
+update session_credential_dynamic
+   set credential_id = null
+ where session_id = @session_id
+   and credential_purpose = @purpose
+   and credential_id is not null;
+`
+
 	updateCredentialStatusByTokenQuery = `
 update credential_vault_credential
    set status = ?
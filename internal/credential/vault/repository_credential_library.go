@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/boundary/internal/credential"
 	"github.com/hashicorp/boundary/internal/db"
 	dbcommon "github.com/hashicorp/boundary/internal/db/common"
 	"github.com/hashicorp/boundary/internal/errors"
@@ -21,6 +22,10 @@ import (
 // Both l.Name and l.Description are optional. If l.Name is set, it must be
 // unique within l.StoreId.
 //
+// If l.CredentialType is not set, the library has the unspecified
+// credential type. The username, password, and private key attributes of
+// l can only be set for the credential types which have those fields.
+//
 // Both l.CreateTime and l.UpdateTime are ignored.
 func (r *Repository) CreateCredentialLibrary(ctx context.Context, scopeId string, l *CredentialLibrary, _ ...Option) (*CredentialLibrary, error) {
 	const op = "vault.(Repository).CreateCredentialLibrary"
@@ -47,6 +52,12 @@ func (r *Repository) CreateCredentialLibrary(ctx context.Context, scopeId string
 	if l.HttpMethod == "" {
 		l.HttpMethod = string(MethodGet)
 	}
+	if l.CredentialLibrary.CredentialType == "" {
+		l.CredentialLibrary.CredentialType = string(credential.UnspecifiedType)
+	}
+	if err := l.validateMapping(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
 
 	id, err := newCredentialLibraryId()
 	if err != nil {
@@ -86,8 +97,11 @@ func (r *Repository) CreateCredentialLibrary(ctx context.Context, scopeId string
 // number of records updated. l is not changed.
 //
 // l must contain a valid PublicId. Only Name, Description, VaultPath,
-// HttpMethod, and HttpRequestBody can be updated. If l.Name is set to a
-// non-empty string, it must be unique within l.StoreId.
+// HttpMethod, HttpRequestBody, UsernameAttribute, PasswordAttribute, and
+// PrivateKeyAttribute can be updated. If l.Name is set to a non-empty
+// string, it must be unique within l.StoreId. The CredentialType of a
+// library cannot be updated and the update will fail if an attribute is
+// set which is not allowed for the CredentialType in storage.
 //
 // An attribute of l will be set to NULL in the database if the attribute
 // in l is the zero value and it is included in fieldMaskPaths except for
@@ -120,6 +134,9 @@ func (r *Repository) UpdateCredentialLibrary(ctx context.Context, scopeId string
 		case strings.EqualFold(vaultPathField, f):
 		case strings.EqualFold(httpMethodField, f):
 		case strings.EqualFold(httpRequestBodyField, f):
+		case strings.EqualFold(usernameAttributeField, f):
+		case strings.EqualFold(passwordAttributeField, f):
+		case strings.EqualFold(privateKeyAttributeField, f):
 		default:
 			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
 		}
@@ -132,6 +149,10 @@ func (r *Repository) UpdateCredentialLibrary(ctx context.Context, scopeId string
 			vaultPathField:       l.VaultPath,
 			httpMethodField:      l.HttpMethod,
 			httpRequestBodyField: l.HttpRequestBody,
+
+			usernameAttributeField:   l.UsernameAttribute,
+			passwordAttributeField:   l.PasswordAttribute,
+			privateKeyAttributeField: l.PrivateKeyAttribute,
 		},
 		fieldMaskPaths,
 		nil,
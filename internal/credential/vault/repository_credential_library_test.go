@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/boundary/internal/credential"
 	"github.com/hashicorp/boundary/internal/credential/vault/store"
 	"github.com/hashicorp/boundary/internal/db"
 	dbassert "github.com/hashicorp/boundary/internal/db/assert"
@@ -166,6 +167,132 @@ func TestRepository_CreateCredentialLibrary(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid-username-password-credential-type",
+			in: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:        cs.GetPublicId(),
+					HttpMethod:     "GET",
+					VaultPath:      "/some/path",
+					CredentialType: string(credential.UsernamePasswordType),
+				},
+			},
+			want: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:        cs.GetPublicId(),
+					HttpMethod:     "GET",
+					VaultPath:      "/some/path",
+					CredentialType: string(credential.UsernamePasswordType),
+				},
+			},
+		},
+		{
+			name: "valid-username-password-attributes",
+			in: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:           cs.GetPublicId(),
+					HttpMethod:        "GET",
+					VaultPath:         "/some/path",
+					CredentialType:    string(credential.UsernamePasswordType),
+					UsernameAttribute: "user",
+					PasswordAttribute: "pass",
+				},
+			},
+			want: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:           cs.GetPublicId(),
+					HttpMethod:        "GET",
+					VaultPath:         "/some/path",
+					CredentialType:    string(credential.UsernamePasswordType),
+					UsernameAttribute: "user",
+					PasswordAttribute: "pass",
+				},
+			},
+		},
+		{
+			name: "valid-ssh-private-key-attributes",
+			in: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:             cs.GetPublicId(),
+					HttpMethod:          "GET",
+					VaultPath:           "/some/path",
+					CredentialType:      string(credential.SshPrivateKeyType),
+					PrivateKeyAttribute: "key",
+				},
+			},
+			want: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:             cs.GetPublicId(),
+					HttpMethod:          "GET",
+					VaultPath:           "/some/path",
+					CredentialType:      string(credential.SshPrivateKeyType),
+					PrivateKeyAttribute: "key",
+				},
+			},
+		},
+		{
+			name: "invalid-unknown-credential-type",
+			in: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:        cs.GetPublicId(),
+					HttpMethod:     "GET",
+					VaultPath:      "/some/path",
+					CredentialType: "unknown",
+				},
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "invalid-attribute-without-credential-type",
+			in: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:           cs.GetPublicId(),
+					HttpMethod:        "GET",
+					VaultPath:         "/some/path",
+					UsernameAttribute: "user",
+				},
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "invalid-private-key-attribute-username-password-type",
+			in: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:             cs.GetPublicId(),
+					HttpMethod:          "GET",
+					VaultPath:           "/some/path",
+					CredentialType:      string(credential.UsernamePasswordType),
+					PrivateKeyAttribute: "key",
+				},
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "invalid-password-attribute-ssh-private-key-type",
+			in: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:           cs.GetPublicId(),
+					HttpMethod:        "GET",
+					VaultPath:         "/some/path",
+					CredentialType:    string(credential.SshPrivateKeyType),
+					PasswordAttribute: "pass",
+				},
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "invalid-same-attribute-for-username-and-password",
+			in: &CredentialLibrary{
+				CredentialLibrary: &store.CredentialLibrary{
+					StoreId:           cs.GetPublicId(),
+					HttpMethod:        "GET",
+					VaultPath:         "/some/path",
+					CredentialType:    string(credential.UsernamePasswordType),
+					UsernameAttribute: "password",
+				},
+			},
+			wantErr: errors.InvalidParameter,
+		},
 	}
 
 	for _, tt := range tests {
@@ -191,6 +318,10 @@ func TestRepository_CreateCredentialLibrary(t *testing.T) {
 			assert.NotSame(tt.in, got)
 			assert.Equal(tt.want.Name, got.Name)
 			assert.Equal(tt.want.Description, got.Description)
+			assert.Equal(tt.want.CredentialType(), got.CredentialType())
+			assert.Equal(tt.want.UsernameAttribute, got.UsernameAttribute)
+			assert.Equal(tt.want.PasswordAttribute, got.PasswordAttribute)
+			assert.Equal(tt.want.PrivateKeyAttribute, got.PrivateKeyAttribute)
 			assert.Equal(got.CreateTime, got.UpdateTime)
 			assert.NoError(db.TestVerifyOplog(t, rw, got.GetPublicId(), db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second)))
 		})
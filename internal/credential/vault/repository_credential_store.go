@@ -19,9 +19,14 @@ import (
 // CredentialStore containing the credential store's PublicId. cs is not
 // changed. cs must not contain a PublicId. The PublicId is generated and
 // assigned by this method. cs must contain a valid ScopeId, VaultAddress,
-// and Vault token. The Vault token must be renewable, periodic, and
-// orphan. CreateCredentialStore calls the /auth/token/renew-self and
-// /auth/token/lookup-self Vault endpoints.
+// and either a Vault token or an AuthMethod. The Vault token must be
+// renewable, periodic, and orphan. CreateCredentialStore calls the
+// /auth/token/renew-self and /auth/token/lookup-self Vault endpoints.
+//
+// If cs contains an AuthMethod, CreateCredentialStore logs in to Vault
+// with the auth method to obtain the token. The token does not need to
+// be periodic or orphan since the credential store logs in again when the
+// token can no longer be renewed.
 //
 // Both cs.Name and cs.Description are optional. If cs.Name is set, it must
 // be unique within cs.ScopeId. Both cs.CreateTime and cs.UpdateTime are
@@ -49,8 +54,16 @@ func (r *Repository) CreateCredentialStore(ctx context.Context, cs *CredentialSt
 	if cs.ScopeId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
 	}
-	if len(cs.inputToken) == 0 {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "no vault token")
+	if len(cs.inputToken) == 0 && cs.authMethod == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no vault token or auth method")
+	}
+	if len(cs.inputToken) != 0 && cs.authMethod != nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "vault token and auth method are mutually exclusive")
+	}
+	if cs.authMethod != nil {
+		if err := cs.authMethod.validate(ctx, op); err != nil {
+			return nil, err
+		}
 	}
 	if cs.VaultAddress == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "no vault address")
@@ -72,17 +85,29 @@ func (r *Repository) CreateCredentialStore(ctx context.Context, cs *CredentialSt
 	if cs.clientCert != nil {
 		cs.clientCert.StoreId = id
 	}
+	if cs.authMethod != nil {
+		cs.authMethod.StoreId = id
+		cs.authMethod.setDefaults()
+	}
 
 	client, err := cs.client()
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create vault client"))
 	}
-	tokenLookup, err := client.lookupToken()
-	if err != nil {
-		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to lookup vault token"))
-	}
-	if err := validateTokenLookup(op, tokenLookup); err != nil {
-		return nil, err
+
+	var token *Token
+	if cs.authMethod != nil {
+		if token, err = cs.authMethod.login(ctx, client); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	} else {
+		tokenLookup, err := client.lookupToken()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to lookup vault token"))
+		}
+		if err := validateTokenLookup(op, tokenLookup); err != nil {
+			return nil, err
+		}
 	}
 
 	available, err := client.capabilities(requiredCapabilities.paths())
@@ -95,24 +120,25 @@ func (r *Repository) CreateCredentialStore(ctx context.Context, cs *CredentialSt
 			errors.New(ctx, errors.VaultTokenMissingCapabilities, op, fmt.Sprintf("missing capabilites: %v", missing))
 	}
 
-	renewedToken, err := client.renewToken()
-	if err != nil {
-		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to renew vault token"))
-	}
+	if token == nil {
+		renewedToken, err := client.renewToken()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to renew vault token"))
+		}
 
-	tokenExpires, err := renewedToken.TokenTTL()
-	if err != nil {
-		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get vault token expiration"))
-	}
+		tokenExpires, err := renewedToken.TokenTTL()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get vault token expiration"))
+		}
 
-	accessor, err := renewedToken.TokenAccessor()
-	if err != nil {
-		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get vault token accessor"))
-	}
+		accessor, err := renewedToken.TokenAccessor()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get vault token accessor"))
+		}
 
-	token, err := newToken(id, cs.inputToken, []byte(accessor), tokenExpires)
-	if err != nil {
-		return nil, err
+		if token, err = newToken(id, cs.inputToken, []byte(accessor), tokenExpires); err != nil {
+			return nil, err
+		}
 	}
 
 	oplogWrapper, err := r.kms.GetWrapper(ctx, cs.ScopeId, kms.KeyPurposeOplog)
@@ -133,13 +159,18 @@ func (r *Repository) CreateCredentialStore(ctx context.Context, cs *CredentialSt
 			return nil, errors.Wrap(ctx, err, op)
 		}
 	}
+	if cs.authMethod != nil {
+		if err := cs.authMethod.encrypt(ctx, databaseWrapper); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
 
 	var newToken *Token
 	var newClientCertificate *ClientCertificate
 	var newCredentialStore *CredentialStore
 	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
 		func(_ db.Reader, w db.Writer) error {
-			msgs := make([]*oplog.Message, 0, 3)
+			msgs := make([]*oplog.Message, 0, 4)
 			ticket, err := w.GetTicket(cs)
 			if err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
@@ -184,6 +215,26 @@ func (r *Repository) CreateCredentialStore(ctx context.Context, cs *CredentialSt
 				newCredentialStore.clientCert = newClientCertificate
 
 			}
+
+			// insert auth method (if exists)
+			if cs.authMethod != nil {
+				newAuthMethod := cs.authMethod.clone()
+				query, values := newAuthMethod.insertQuery()
+				rows, err := w.Exec(ctx, query, values)
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to insert auth method"))
+				}
+				if rows > 1 {
+					return errors.New(ctx, errors.MultipleRecords, op, "more than 1 auth method would have been created")
+				}
+				msgs = append(msgs, newAuthMethod.oplogMessage(db.CreateOp))
+
+				newAuthMethod.SecretId = nil
+				newAuthMethod.CtSecretId = nil
+				newCredentialStore.authMethod = newAuthMethod
+			}
+			newCredentialStore.operationalState = ActiveState
+
 			metadata := cs.oplog(oplog.OpType_OP_TYPE_CREATE)
 			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, msgs); err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
@@ -203,6 +254,7 @@ func (r *Repository) CreateCredentialStore(ctx context.Context, cs *CredentialSt
 	// cause update to fail.
 	// TODO (lcr 05/2021): log error once repo has logger
 	_ = r.scheduler.UpdateJobNextRunInAtLeast(ctx, tokenRenewalJobName, token.renewalIn())
+	_ = r.scheduler.UpdateJobNextRunInAtLeast(ctx, healthCheckJobName, 0)
 
 	return newCredentialStore, nil
 }
@@ -279,6 +331,19 @@ type publicStore struct {
 	TokenExpirationTime  *timestamp.Timestamp
 	ClientCert           []byte
 	ClientCertKeyHmac    []byte
+	AuthMethod           string
+	AuthMountPath        string
+	AuthRoleId           string
+	AuthSecretIdHmac     []byte
+	AuthKubernetesRole   string
+	OperationalState     string
+
+	HealthStatus               string
+	HealthCheckTime            *timestamp.Timestamp
+	HealthFailedCheck          string
+	HealthMessage              string
+	HealthTokenExpirationTime  *timestamp.Timestamp
+	HealthCaCertExpirationTime *timestamp.Timestamp
 }
 
 func allocPublicStore() *publicStore {
@@ -316,9 +381,37 @@ func (ps *publicStore) toCredentialStore() *CredentialStore {
 		cert.CertificateKeyHmac = ps.ClientCertKeyHmac
 		cs.clientCert = cert
 	}
+
+	if ps.AuthMethod != "" {
+		am := allocAuthMethod()
+		am.StoreId = ps.PublicId
+		am.AuthMethod.AuthMethod = ps.AuthMethod
+		am.MountPath = ps.AuthMountPath
+		am.RoleId = ps.AuthRoleId
+		am.SecretIdHmac = ps.AuthSecretIdHmac
+		am.KubernetesRole = ps.AuthKubernetesRole
+		cs.authMethod = am
+	}
+	cs.operationalState = OperationalState(ps.OperationalState)
+	cs.healthCheck = ps.healthCheck()
 	return cs
 }
 
+func (ps *publicStore) healthCheck() *HealthCheck {
+	if ps.HealthStatus == "" {
+		return nil
+	}
+	return &HealthCheck{
+		StoreId:              ps.PublicId,
+		Status:               HealthStatus(ps.HealthStatus),
+		CheckTime:            ps.HealthCheckTime,
+		FailedCheck:          HealthCheckName(ps.HealthFailedCheck),
+		Message:              ps.HealthMessage,
+		TokenExpirationTime:  ps.HealthTokenExpirationTime,
+		CaCertExpirationTime: ps.HealthCaCertExpirationTime,
+	}
+}
+
 // TableName returns the table name for gorm.
 func (_ *publicStore) TableName() string { return "credential_vault_store_public" }
 
@@ -332,12 +425,20 @@ func (ps *publicStore) GetPublicId() string { return ps.PublicId }
 //
 // cs must contain a valid PublicId. Only Name, Description, Namespace,
 // TlsServerName, TlsSkipVerify, CaCert, VaultAddress, ClientCertificate,
-// ClientCertificateKey, and Token can be changed. If cs.Name is set to a
-// non-empty string, it must be unique within cs.ScopeId. If Token is changed,
-// the new token must have the same properties defined in CreateCredentialStore
-// and UpdateCredentialStore calls the same Vault endpoints described in
+// ClientCertificateKey, Token, AuthMethod, MountPath, RoleId, SecretId, and
+// KubernetesRole can be changed. If cs.Name is set to a non-empty string,
+// it must be unique within cs.ScopeId. If Token is changed, the new token
+// must have the same properties defined in CreateCredentialStore and
+// UpdateCredentialStore calls the same Vault endpoints described in
 // CreateCredentialStore.
 //
+// If the credential store has an auth method after the update and a field
+// of the auth method or VaultAddress is changed, UpdateCredentialStore
+// logs in to Vault with the auth method to obtain a new token. Token
+// cannot be changed for a credential store with an auth method. Removing
+// the auth method, by setting AuthMethod to an empty string, requires a
+// new Token.
+//
 // An attribute of cs will be set to NULL in the database if the attribute
 // in cs is the zero value and it is included in fieldMaskPaths.
 func (r *Repository) UpdateCredentialStore(ctx context.Context, cs *CredentialStore, version uint32, fieldMaskPaths []string, _ ...Option) (*CredentialStore, int, error) {
@@ -359,8 +460,12 @@ func (r *Repository) UpdateCredentialStore(ctx context.Context, cs *CredentialSt
 	}
 	cs = cs.clone()
 
-	var validateToken, updateToken bool
+	var validateToken, updateToken, updateAuthMethod, recheckHealth bool
 	for _, f := range fieldMaskPaths {
+		if !strings.EqualFold(nameField, f) && !strings.EqualFold(descriptionField, f) {
+			// Any other change can change the result of the health check.
+			recheckHealth = true
+		}
 		switch {
 		case strings.EqualFold(nameField, f):
 		case strings.EqualFold(descriptionField, f):
@@ -377,6 +482,12 @@ func (r *Repository) UpdateCredentialStore(ctx context.Context, cs *CredentialSt
 				updateToken = true
 				validateToken = true
 			}
+		case strings.EqualFold(authMethodField, f),
+			strings.EqualFold(mountPathField, f),
+			strings.EqualFold(roleIdField, f),
+			strings.EqualFold(secretIdField, f),
+			strings.EqualFold(kubernetesRoleField, f):
+			updateAuthMethod = true
 		default:
 			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
 		}
@@ -412,7 +523,7 @@ func (r *Repository) UpdateCredentialStore(ctx context.Context, cs *CredentialSt
 	if len(certNullFields) != 0 && len(certNullFields) != 2 {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "attempting to unset a required field on a client cert")
 	}
-	if len(append(dbMask, certDbMask...)) == 0 && len(append(nullFields, certNullFields...)) == 0 {
+	if len(append(dbMask, certDbMask...)) == 0 && len(append(nullFields, certNullFields...)) == 0 && !updateAuthMethod {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "missing field mask")
 	}
 
@@ -459,8 +570,35 @@ func (r *Repository) UpdateCredentialStore(ctx context.Context, cs *CredentialSt
 	if err != nil {
 		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("can't recreate client certificate for vault client creation"))
 	}
+	if origStore.authMethod, err = lookupAuthMethod(ctx, r.reader, ps.PublicId, databaseWrapper); err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to lookup auth method"))
+	}
 	updatedStore := origStore.applyUpdate(cs, fieldMaskPaths)
 
+	// A credential store with an auth method logs in to obtain a new token
+	// whenever the token of the previous configuration may not be valid
+	// for the new one.
+	var login bool
+	switch {
+	case updatedStore.authMethod != nil:
+		if updateToken {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "vault token and auth method are mutually exclusive")
+		}
+		if updateAuthMethod {
+			if err := updatedStore.authMethod.validate(ctx, op); err != nil {
+				return nil, db.NoRowsAffected, err
+			}
+			if err := updatedStore.authMethod.encrypt(ctx, databaseWrapper); err != nil {
+				return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+			}
+		}
+		login = updateAuthMethod || validateToken
+		validateToken = false
+		updatedStore.inputToken = nil
+	case updateAuthMethod && origStore.authMethod != nil && !updateToken:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "a vault token is required when removing the auth method")
+	}
+
 	if len(certDbMask) > 0 && updatedStore.clientCert != nil {
 		if err := updatedStore.clientCert.encrypt(ctx, databaseWrapper); err != nil {
 			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
@@ -472,6 +610,11 @@ func (r *Repository) UpdateCredentialStore(ctx context.Context, cs *CredentialSt
 	if err != nil {
 		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get client for updated store"))
 	}
+	if login {
+		if token, err = updatedStore.authMethod.login(ctx, client); err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+		}
+	}
 	if validateToken {
 		tokenLookup, err := client.lookupToken()
 		if err != nil {
@@ -480,7 +623,8 @@ func (r *Repository) UpdateCredentialStore(ctx context.Context, cs *CredentialSt
 		if err := validateTokenLookup(op, tokenLookup); err != nil {
 			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
 		}
-
+	}
+	if validateToken || login {
 		available, err := client.capabilities(requiredCapabilities.paths())
 		if err != nil {
 			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get vault capabilities"))
@@ -508,6 +652,8 @@ func (r *Repository) UpdateCredentialStore(ctx context.Context, cs *CredentialSt
 		if token, err = newToken(cs.GetPublicId(), cs.inputToken, []byte(accessor), tokenExpires); err != nil {
 			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
 		}
+	}
+	if token != nil {
 		// encrypt token
 		if err := token.encrypt(ctx, databaseWrapper); err != nil {
 			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
@@ -518,7 +664,7 @@ func (r *Repository) UpdateCredentialStore(ctx context.Context, cs *CredentialSt
 	var returnedCredentialStore *CredentialStore
 	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
 		func(reader db.Reader, w db.Writer) error {
-			msgs := make([]*oplog.Message, 0, 3)
+			msgs := make([]*oplog.Message, 0, 4)
 			ticket, err := w.GetTicket(cs)
 			if err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
@@ -591,7 +737,32 @@ func (r *Repository) UpdateCredentialStore(ctx context.Context, cs *CredentialSt
 				}
 			}
 
-			if updateToken {
+			if updateAuthMethod {
+				switch {
+				case updatedStore.authMethod != nil:
+					query, values := updatedStore.authMethod.insertQuery()
+					rows, err := w.Exec(ctx, query, values)
+					if err != nil {
+						return errors.Wrap(ctx, err, op, errors.WithMsg("unable to upsert auth method"))
+					}
+					if rows > 1 {
+						return errors.New(ctx, errors.MultipleRecords, op, "more than 1 auth method would have been upserted")
+					}
+					msgs = append(msgs, updatedStore.authMethod.oplogMessage(db.UpdateOp))
+				case origStore.authMethod != nil:
+					query, values := origStore.authMethod.deleteQuery()
+					rows, err := w.Exec(ctx, query, values)
+					if err != nil {
+						return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete auth method"))
+					}
+					if rows > 1 {
+						return errors.New(ctx, errors.MultipleRecords, op, "more than 1 auth method would have been deleted")
+					}
+					msgs = append(msgs, origStore.authMethod.oplogMessage(db.DeleteOp))
+				}
+			}
+
+			if token != nil {
 				query, values := token.insertQuery()
 				rows, err := w.Exec(ctx, query, values)
 				if err != nil {
@@ -607,6 +778,12 @@ func (r *Repository) UpdateCredentialStore(ctx context.Context, cs *CredentialSt
 				msgs = append(msgs, token.oplogMessage(db.CreateOp))
 			}
 
+			if recheckHealth {
+				if _, err := w.Exec(ctx, deleteHealthCheckQuery, []interface{}{cs.PublicId}); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete health check"))
+				}
+			}
+
 			publicId := cs.PublicId
 			agg := allocPublicStore()
 			agg.PublicId = publicId
@@ -627,12 +804,15 @@ func (r *Repository) UpdateCredentialStore(ctx context.Context, cs *CredentialSt
 		return nil, db.NoRowsAffected, err
 	}
 
-	if updateToken && token != nil {
+	if token != nil {
 		// Best effort update next run time of token renewal job, but an error should not
 		// cause update to fail.
 		// TODO (lcr 05/2021): log error once repo has logger
 		_ = r.scheduler.UpdateJobNextRunInAtLeast(ctx, tokenRenewalJobName, token.renewalIn())
 	}
+	if recheckHealth && rowsUpdated > 0 {
+		_ = r.scheduler.UpdateJobNextRunInAtLeast(ctx, healthCheckJobName, 0)
+	}
 
 	return returnedCredentialStore, rowsUpdated, nil
 }
@@ -2,6 +2,7 @@ package vault
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -36,6 +37,21 @@ func (r *Repository) Issue(ctx context.Context, sessionId string, requests []cre
 	var creds []credential.Dynamic
 	var minLease time.Duration
 	for _, lib := range libs {
+		if lib.CredentialType() == credential.SshCertificateType {
+			// Signed SSH certificates have no Vault lease so they are not
+			// stored for renewal or revocation.
+			client, err := lib.client()
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+			dc, err := lib.signSshCertificate(ctx, client, sessionId)
+			if err != nil {
+				return nil, r.wrapHealthCheckError(ctx, err, op, lib.StoreId)
+			}
+			creds = append(creds, dc)
+			continue
+		}
+
 		// Get the credential ID early. No need to get a secret from Vault
 		// if there is no way to save it in the database.
 		credId, err := newCredentialId()
@@ -59,9 +75,7 @@ func (r *Repository) Issue(ctx context.Context, sessionId string, requests []cre
 		}
 
 		if err != nil {
-			// TODO(mgaffney) 05/2021: detect if the error is because of an
-			// expired or invalid token
-			return nil, errors.Wrap(ctx, err, op)
+			return nil, r.wrapHealthCheckError(ctx, err, op, lib.StoreId)
 		}
 
 		leaseDuration := time.Duration(secret.LeaseDuration) * time.Second
@@ -102,13 +116,17 @@ func (r *Repository) Issue(ctx context.Context, sessionId string, requests []cre
 			return nil, errors.Wrap(ctx, err, op)
 		}
 
-		creds = append(creds, &actualCredential{
+		dc, err := (&actualCredential{
 			id:         cred.PublicId,
 			sessionId:  cred.SessionId,
 			lib:        lib,
 			secretData: secret.Data,
 			purpose:    lib.Purpose,
-		})
+		}).typed(ctx)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		creds = append(creds, dc)
 	}
 
 	// Best effort update next run time of credential renewal job, but an error should not
@@ -119,6 +137,19 @@ func (r *Repository) Issue(ctx context.Context, sessionId string, requests []cre
 	return creds, nil
 }
 
+// wrapHealthCheckError wraps err, an error returned by the Vault server of
+// the credential store storeId, with the failed check of the last health
+// check of the credential store. The failed check is more likely to
+// explain the error, e.g. an expired token or missing capabilities, than
+// the error returned by Vault.
+func (r *Repository) wrapHealthCheckError(ctx context.Context, err error, op errors.Op, storeId string) error {
+	h, lookupErr := lookupHealthCheck(ctx, r.reader, storeId)
+	if lookupErr != nil || h == nil || h.Healthy() {
+		return errors.Wrap(ctx, err, op)
+	}
+	return errors.Wrap(ctx, err, op, errors.WithMsg(h.Error()))
+}
+
 var _ credential.Revoker = (*Repository)(nil)
 
 // Revoke revokes all dynamic credentials issued from Vault for sessionId.
@@ -138,3 +169,34 @@ func (r *Repository) Revoke(ctx context.Context, sessionId string) error {
 	)
 	return err
 }
+
+// Release revokes the dynamic credentials issued from Vault for sessionId
+// with purpose and detaches them from the session, so new credentials can be
+// issued for the session by Issue. It is used when credentials were issued
+// but could not be handed to the session.
+func (r *Repository) Release(ctx context.Context, sessionId string, purpose credential.Purpose) error {
+	const op = "vault.(Repository).Release"
+	switch {
+	case sessionId == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "no session id")
+	case purpose == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "no purpose")
+	}
+
+	values := []interface{}{
+		sql.Named("session_id", sessionId),
+		sql.Named("purpose", string(purpose)),
+	}
+	_, err := r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			if _, err := w.Exec(ctx, revokeSessionPurposeCredentialsQuery, values); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if _, err := w.Exec(ctx, releaseSessionPurposeCredentialsQuery, values); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	return err
+}
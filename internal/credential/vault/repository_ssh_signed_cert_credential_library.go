@@ -0,0 +1,272 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreateSshSignedCertCredentialLibrary inserts l into the repository and
+// returns a new SshSignedCertCredentialLibrary containing the credential
+// library's PublicId. l is not changed. l must contain a valid StoreId,
+// VaultPath, and at least one principal in ValidPrincipals. l must not
+// contain a PublicId. The PublicId is generated and assigned by this
+// method.
+//
+// Both l.Name and l.Description are optional. If l.Name is set, it must be
+// unique within l.StoreId. If l.Extensions is set, it must be a JSON object
+// of strings.
+//
+// Both l.CreateTime and l.UpdateTime are ignored.
+func (r *Repository) CreateSshSignedCertCredentialLibrary(ctx context.Context, scopeId string, l *SshSignedCertCredentialLibrary, _ ...Option) (*SshSignedCertCredentialLibrary, error) {
+	const op = "vault.(Repository).CreateSshSignedCertCredentialLibrary"
+	if l == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil SshSignedCertCredentialLibrary")
+	}
+	if l.SshSignedCertCredentialLibrary == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil embedded l")
+	}
+	if l.StoreId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no store id")
+	}
+	if l.VaultPath == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no vault path")
+	}
+	if len(l.Principals()) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no valid principals")
+	}
+	if l.PublicId != "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	}
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+	if _, err := l.ExtensionsMap(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	l = l.clone()
+	l.SshSignedCertCredentialLibrary.CredentialType = string(credential.SshCertificateType)
+
+	id, err := newSshSignedCertCredentialLibraryId()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	l.PublicId = id
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var newLibrary *SshSignedCertCredentialLibrary
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newLibrary = l.clone()
+			err := w.Create(ctx, newLibrary, db.WithOplog(oplogWrapper, l.oplog(oplog.OpType_OP_TYPE_CREATE)))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in credential store: %s: name %s already exists", l.StoreId, l.Name)))
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in credential store: %s", l.StoreId)))
+	}
+	return newLibrary, nil
+}
+
+// UpdateSshSignedCertCredentialLibrary updates the repository entry for
+// l.PublicId with the values in l for the fields listed in fieldMaskPaths.
+// It returns a new SshSignedCertCredentialLibrary containing the updated
+// values and a count of the number of records updated. l is not changed.
+//
+// l must contain a valid PublicId. Only Name, Description, VaultPath,
+// ValidPrincipals, Ttl, and Extensions can be updated. If l.Name is set to
+// a non-empty string, it must be unique within l.StoreId. VaultPath and
+// ValidPrincipals cannot be set to NULL.
+//
+// An attribute of l will be set to NULL in the database if the attribute
+// in l is the zero value and it is included in fieldMaskPaths.
+func (r *Repository) UpdateSshSignedCertCredentialLibrary(ctx context.Context, scopeId string, l *SshSignedCertCredentialLibrary, version uint32, fieldMaskPaths []string, _ ...Option) (*SshSignedCertCredentialLibrary, int, error) {
+	const op = "vault.(Repository).UpdateSshSignedCertCredentialLibrary"
+	if l == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing SshSignedCertCredentialLibrary")
+	}
+	if l.SshSignedCertCredentialLibrary == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing embedded SshSignedCertCredentialLibrary")
+	}
+	if l.PublicId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	if version == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	}
+	if scopeId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	if _, err := l.ExtensionsMap(ctx); err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	l = l.clone()
+
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold(nameField, f):
+		case strings.EqualFold(descriptionField, f):
+		case strings.EqualFold(vaultPathField, f):
+		case strings.EqualFold(validPrincipalsField, f):
+		case strings.EqualFold(ttlField, f):
+		case strings.EqualFold(extensionsField, f):
+		default:
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
+		}
+	}
+	var dbMask, nullFields []string
+	dbMask, nullFields = dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			nameField:            l.Name,
+			descriptionField:     l.Description,
+			vaultPathField:       l.VaultPath,
+			validPrincipalsField: l.ValidPrincipals,
+			ttlField:             l.Ttl,
+			extensionsField:      l.Extensions,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	for _, f := range nullFields {
+		switch {
+		case strings.EqualFold(vaultPathField, f):
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no vault path")
+		case strings.EqualFold(validPrincipalsField, f):
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no valid principals")
+		}
+	}
+
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "missing field mask")
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt),
+			errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsUpdated int
+	var returnedLibrary *SshSignedCertCredentialLibrary
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedLibrary = l.clone()
+			var err error
+			rowsUpdated, err = w.Update(ctx, returnedLibrary, dbMask, nullFields,
+				db.WithOplog(oplogWrapper, l.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+				db.WithVersion(&version))
+			if err == nil && rowsUpdated > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.NotUnique, op,
+				fmt.Sprintf("name %s already exists: %s", l.Name, l.PublicId))
+		}
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(l.PublicId))
+	}
+
+	return returnedLibrary, rowsUpdated, nil
+}
+
+// LookupSshSignedCertCredentialLibrary returns the
+// SshSignedCertCredentialLibrary for publicId. Returns nil, nil if no
+// SshSignedCertCredentialLibrary is found for publicId.
+func (r *Repository) LookupSshSignedCertCredentialLibrary(ctx context.Context, publicId string, _ ...Option) (*SshSignedCertCredentialLibrary, error) {
+	const op = "vault.(Repository).LookupSshSignedCertCredentialLibrary"
+	if publicId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+	l := allocSshSignedCertCredentialLibrary()
+	l.PublicId = publicId
+	if err := r.reader.LookupByPublicId(ctx, l); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", publicId)))
+	}
+	return l, nil
+}
+
+// DeleteSshSignedCertCredentialLibrary deletes publicId from the
+// repository and returns the number of records deleted.
+func (r *Repository) DeleteSshSignedCertCredentialLibrary(ctx context.Context, scopeId string, publicId string, _ ...Option) (int, error) {
+	const op = "vault.(Repository).DeleteSshSignedCertCredentialLibrary"
+	if publicId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+	if scopeId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+
+	l := allocSshSignedCertCredentialLibrary()
+	l.PublicId = publicId
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) (err error) {
+			dl := l.clone()
+			rowsDeleted, err = w.Delete(ctx, dl, db.WithOplog(oplogWrapper, l.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err == nil && rowsDeleted > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 SshSignedCertCredentialLibrary would have been deleted")
+			}
+			return err
+		},
+	)
+
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("delete failed for %s", l.PublicId)))
+	}
+
+	return rowsDeleted, nil
+}
+
+// ListSshSignedCertCredentialLibraries returns a slice of
+// SshSignedCertCredentialLibraries for the storeId. WithLimit is the only
+// option supported.
+func (r *Repository) ListSshSignedCertCredentialLibraries(ctx context.Context, storeId string, opt ...Option) ([]*SshSignedCertCredentialLibrary, error) {
+	const op = "vault.(Repository).ListSshSignedCertCredentialLibraries"
+	if storeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no storeId")
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var libs []*SshSignedCertCredentialLibrary
+	err := r.reader.SearchWhere(ctx, &libs, "store_id = ?", []interface{}{storeId}, db.WithLimit(limit))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return libs, nil
+}
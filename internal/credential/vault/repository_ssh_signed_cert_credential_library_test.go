@@ -0,0 +1,796 @@
+package vault
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/vault/store"
+	"github.com/hashicorp/boundary/internal/db"
+	dbassert "github.com/hashicorp/boundary/internal/db/assert"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestRepository_CreateSshSignedCertCredentialLibrary(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 1)[0]
+
+	tests := []struct {
+		name    string
+		scopeId string
+		in      *SshSignedCertCredentialLibrary
+		want    *SshSignedCertCredentialLibrary
+		wantErr errors.Code
+	}{
+		{
+			name:    "nil-SshSignedCertCredentialLibrary",
+			scopeId: prj.GetPublicId(),
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "nil-embedded-SshSignedCertCredentialLibrary",
+			scopeId: prj.GetPublicId(),
+			in:      &SshSignedCertCredentialLibrary{},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "invalid-no-store-id",
+			scopeId: prj.GetPublicId(),
+			in: &SshSignedCertCredentialLibrary{
+				SshSignedCertCredentialLibrary: &store.SshSignedCertCredentialLibrary{
+					VaultPath:       "ssh/sign/role",
+					ValidPrincipals: "ubuntu",
+				},
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "invalid-no-vault-path",
+			scopeId: prj.GetPublicId(),
+			in: &SshSignedCertCredentialLibrary{
+				SshSignedCertCredentialLibrary: &store.SshSignedCertCredentialLibrary{
+					StoreId:         cs.GetPublicId(),
+					ValidPrincipals: "ubuntu",
+				},
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "invalid-no-valid-principals",
+			scopeId: prj.GetPublicId(),
+			in: &SshSignedCertCredentialLibrary{
+				SshSignedCertCredentialLibrary: &store.SshSignedCertCredentialLibrary{
+					StoreId:         cs.GetPublicId(),
+					VaultPath:       "ssh/sign/role",
+					ValidPrincipals: " , ",
+				},
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "invalid-public-id-set",
+			scopeId: prj.GetPublicId(),
+			in: &SshSignedCertCredentialLibrary{
+				SshSignedCertCredentialLibrary: &store.SshSignedCertCredentialLibrary{
+					StoreId:         cs.GetPublicId(),
+					PublicId:        "abcd_OOOOOOOOOO",
+					VaultPath:       "ssh/sign/role",
+					ValidPrincipals: "ubuntu",
+				},
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "invalid-no-scope-id",
+			in: &SshSignedCertCredentialLibrary{
+				SshSignedCertCredentialLibrary: &store.SshSignedCertCredentialLibrary{
+					StoreId:         cs.GetPublicId(),
+					VaultPath:       "ssh/sign/role",
+					ValidPrincipals: "ubuntu",
+				},
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "invalid-extensions",
+			scopeId: prj.GetPublicId(),
+			in: &SshSignedCertCredentialLibrary{
+				SshSignedCertCredentialLibrary: &store.SshSignedCertCredentialLibrary{
+					StoreId:         cs.GetPublicId(),
+					VaultPath:       "ssh/sign/role",
+					ValidPrincipals: "ubuntu",
+					Extensions:      "not json",
+				},
+			},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "valid-no-options",
+			scopeId: prj.GetPublicId(),
+			in: &SshSignedCertCredentialLibrary{
+				SshSignedCertCredentialLibrary: &store.SshSignedCertCredentialLibrary{
+					StoreId:         cs.GetPublicId(),
+					VaultPath:       "ssh/sign/role",
+					ValidPrincipals: "ubuntu",
+				},
+			},
+			want: &SshSignedCertCredentialLibrary{
+				SshSignedCertCredentialLibrary: &store.SshSignedCertCredentialLibrary{
+					StoreId:         cs.GetPublicId(),
+					VaultPath:       "ssh/sign/role",
+					ValidPrincipals: "ubuntu",
+				},
+			},
+		},
+		{
+			name:    "valid-with-name-and-description",
+			scopeId: prj.GetPublicId(),
+			in: &SshSignedCertCredentialLibrary{
+				SshSignedCertCredentialLibrary: &store.SshSignedCertCredentialLibrary{
+					StoreId:         cs.GetPublicId(),
+					Name:            "test-name-repo",
+					Description:     "test-description-repo",
+					VaultPath:       "ssh/sign/role",
+					ValidPrincipals: "ubuntu",
+				},
+			},
+			want: &SshSignedCertCredentialLibrary{
+				SshSignedCertCredentialLibrary: &store.SshSignedCertCredentialLibrary{
+					StoreId:         cs.GetPublicId(),
+					Name:            "test-name-repo",
+					Description:     "test-description-repo",
+					VaultPath:       "ssh/sign/role",
+					ValidPrincipals: "ubuntu",
+				},
+			},
+		},
+		{
+			name:    "valid-with-ttl-and-extensions",
+			scopeId: prj.GetPublicId(),
+			in: &SshSignedCertCredentialLibrary{
+				SshSignedCertCredentialLibrary: &store.SshSignedCertCredentialLibrary{
+					StoreId:         cs.GetPublicId(),
+					VaultPath:       "ssh/sign/role",
+					ValidPrincipals: "ubuntu,admin",
+					Ttl:             "10m",
+					Extensions:      `{"permit-pty":""}`,
+				},
+			},
+			want: &SshSignedCertCredentialLibrary{
+				SshSignedCertCredentialLibrary: &store.SshSignedCertCredentialLibrary{
+					StoreId:         cs.GetPublicId(),
+					VaultPath:       "ssh/sign/role",
+					ValidPrincipals: "ubuntu,admin",
+					Ttl:             "10m",
+					Extensions:      `{"permit-pty":""}`,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			kms := kms.TestKms(t, conn, wrapper)
+			sche := scheduler.TestScheduler(t, conn, wrapper)
+			repo, err := NewRepository(rw, rw, kms, sche)
+			require.NoError(err)
+			require.NotNil(repo)
+			got, err := repo.CreateSshSignedCertCredentialLibrary(ctx, tt.scopeId, tt.in)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Empty(tt.in.PublicId)
+			require.NotNil(got)
+			assertPublicId(t, SshSignedCertCredentialLibraryPrefix, got.GetPublicId())
+			assert.NotSame(tt.in, got)
+			assert.Equal(tt.want.Name, got.Name)
+			assert.Equal(tt.want.Description, got.Description)
+			assert.Equal(tt.want.VaultPath, got.VaultPath)
+			assert.Equal(tt.want.ValidPrincipals, got.ValidPrincipals)
+			assert.Equal(tt.want.Ttl, got.Ttl)
+			assert.Equal(tt.want.Extensions, got.Extensions)
+			assert.Equal(credential.SshCertificateType, got.CredentialType())
+			assert.Equal(got.CreateTime, got.UpdateTime)
+			assert.NoError(db.TestVerifyOplog(t, rw, got.GetPublicId(), db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second)))
+		})
+	}
+
+	t.Run("invalid-duplicate-names", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ctx := context.Background()
+		kms := kms.TestKms(t, conn, wrapper)
+		sche := scheduler.TestScheduler(t, conn, wrapper)
+		repo, err := NewRepository(rw, rw, kms, sche)
+		require.NoError(err)
+		require.NotNil(repo)
+
+		in := &SshSignedCertCredentialLibrary{
+			SshSignedCertCredentialLibrary: &store.SshSignedCertCredentialLibrary{
+				StoreId:         cs.GetPublicId(),
+				VaultPath:       "ssh/sign/role",
+				ValidPrincipals: "ubuntu",
+				Name:            "test-dup-name-repo",
+			},
+		}
+
+		got, err := repo.CreateSshSignedCertCredentialLibrary(ctx, prj.GetPublicId(), in)
+		require.NoError(err)
+		require.NotNil(got)
+
+		got2, err := repo.CreateSshSignedCertCredentialLibrary(ctx, prj.GetPublicId(), in)
+		assert.Truef(errors.Match(errors.T(errors.NotUnique), err), "want err: %q got: %q", errors.NotUnique, err)
+		assert.Nil(got2)
+	})
+}
+
+func TestRepository_UpdateSshSignedCertCredentialLibrary(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	changeName := func(n string) func(*SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+		return func(l *SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+			l.Name = n
+			return l
+		}
+	}
+
+	changeDescription := func(d string) func(*SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+		return func(l *SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+			l.Description = d
+			return l
+		}
+	}
+
+	changeVaultPath := func(p string) func(*SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+		return func(l *SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+			l.VaultPath = p
+			return l
+		}
+	}
+
+	changeValidPrincipals := func(p string) func(*SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+		return func(l *SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+			l.ValidPrincipals = p
+			return l
+		}
+	}
+
+	changeTtl := func(ttl string) func(*SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+		return func(l *SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+			l.Ttl = ttl
+			return l
+		}
+	}
+
+	changeExtensions := func(e string) func(*SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+		return func(l *SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+			l.Extensions = e
+			return l
+		}
+	}
+
+	makeNil := func() func(*SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+		return func(l *SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+			return nil
+		}
+	}
+
+	makeEmbeddedNil := func() func(*SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+		return func(l *SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+			return &SshSignedCertCredentialLibrary{}
+		}
+	}
+
+	deletePublicId := func() func(*SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+		return func(l *SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+			l.PublicId = ""
+			return l
+		}
+	}
+
+	nonExistentPublicId := func() func(*SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+		return func(l *SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+			l.PublicId = "abcd_OOOOOOOOOO"
+			return l
+		}
+	}
+
+	combine := func(fns ...func(l *SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary) func(*SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+		return func(l *SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary {
+			for _, fn := range fns {
+				l = fn(l)
+			}
+			return l
+		}
+	}
+
+	newOrig := func() *SshSignedCertCredentialLibrary {
+		return &SshSignedCertCredentialLibrary{
+			SshSignedCertCredentialLibrary: &store.SshSignedCertCredentialLibrary{
+				Name:            "test-name-repo",
+				Description:     "test-description-repo",
+				VaultPath:       "ssh/sign/role",
+				ValidPrincipals: "ubuntu",
+				Ttl:             "10m",
+				Extensions:      `{"permit-pty":""}`,
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		chgFn     func(*SshSignedCertCredentialLibrary) *SshSignedCertCredentialLibrary
+		masks     []string
+		want      *store.SshSignedCertCredentialLibrary
+		wantNull  []string
+		wantCount int
+		wantErr   errors.Code
+	}{
+		{
+			name:    "nil-credential-library",
+			chgFn:   makeNil(),
+			masks:   []string{nameField},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "nil-embedded-credential-library",
+			chgFn:   makeEmbeddedNil(),
+			masks:   []string{nameField},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "no-public-id",
+			chgFn:   deletePublicId(),
+			masks:   []string{nameField},
+			wantErr: errors.InvalidPublicId,
+		},
+		{
+			name:    "updating-non-existent-credential-library",
+			chgFn:   combine(nonExistentPublicId(), changeName("test-update-name-repo")),
+			masks:   []string{nameField},
+			wantErr: errors.RecordNotFound,
+		},
+		{
+			name:    "empty-field-mask",
+			chgFn:   changeName("test-update-name-repo"),
+			wantErr: errors.EmptyFieldMask,
+		},
+		{
+			name:    "read-only-fields-in-field-mask",
+			chgFn:   changeName("test-update-name-repo"),
+			masks:   []string{"PublicId", "CreateTime", "UpdateTime", "StoreId"},
+			wantErr: errors.InvalidFieldMask,
+		},
+		{
+			name:    "unknown-field-in-field-mask",
+			chgFn:   changeName("test-update-name-repo"),
+			masks:   []string{"Bilbo"},
+			wantErr: errors.InvalidFieldMask,
+		},
+		{
+			name:    "delete-vault-path",
+			chgFn:   changeVaultPath(""),
+			masks:   []string{vaultPathField},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "delete-valid-principals",
+			chgFn:   changeValidPrincipals(""),
+			masks:   []string{validPrincipalsField},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "invalid-extensions",
+			chgFn:   changeExtensions("not json"),
+			masks:   []string{extensionsField},
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:  "change-name-and-description",
+			chgFn: combine(changeName("test-update-name-repo"), changeDescription("test-update-description-repo")),
+			masks: []string{nameField, descriptionField},
+			want: &store.SshSignedCertCredentialLibrary{
+				Name:            "test-update-name-repo",
+				Description:     "test-update-description-repo",
+				VaultPath:       "ssh/sign/role",
+				ValidPrincipals: "ubuntu",
+				Ttl:             "10m",
+				Extensions:      `{"permit-pty":""}`,
+			},
+			wantCount: 1,
+		},
+		{
+			name:  "change-name-not-in-mask",
+			chgFn: combine(changeName("test-update-name-repo"), changeDescription("test-update-description-repo")),
+			masks: []string{descriptionField},
+			want: &store.SshSignedCertCredentialLibrary{
+				Name:            "test-name-repo",
+				Description:     "test-update-description-repo",
+				VaultPath:       "ssh/sign/role",
+				ValidPrincipals: "ubuntu",
+				Ttl:             "10m",
+				Extensions:      `{"permit-pty":""}`,
+			},
+			wantCount: 1,
+		},
+		{
+			name:  "change-vault-path-and-principals",
+			chgFn: combine(changeVaultPath("ssh/sign/other"), changeValidPrincipals("admin,ubuntu")),
+			masks: []string{vaultPathField, validPrincipalsField},
+			want: &store.SshSignedCertCredentialLibrary{
+				Name:            "test-name-repo",
+				Description:     "test-description-repo",
+				VaultPath:       "ssh/sign/other",
+				ValidPrincipals: "admin,ubuntu",
+				Ttl:             "10m",
+				Extensions:      `{"permit-pty":""}`,
+			},
+			wantCount: 1,
+		},
+		{
+			name:  "change-ttl-and-extensions",
+			chgFn: combine(changeTtl("1h"), changeExtensions(`{"permit-port-forwarding":""}`)),
+			masks: []string{ttlField, extensionsField},
+			want: &store.SshSignedCertCredentialLibrary{
+				Name:            "test-name-repo",
+				Description:     "test-description-repo",
+				VaultPath:       "ssh/sign/role",
+				ValidPrincipals: "ubuntu",
+				Ttl:             "1h",
+				Extensions:      `{"permit-port-forwarding":""}`,
+			},
+			wantCount: 1,
+		},
+		{
+			name:  "delete-name-description-ttl-and-extensions",
+			chgFn: combine(changeName(""), changeDescription(""), changeTtl(""), changeExtensions("")),
+			masks: []string{nameField, descriptionField, ttlField, extensionsField},
+			want: &store.SshSignedCertCredentialLibrary{
+				VaultPath:       "ssh/sign/role",
+				ValidPrincipals: "ubuntu",
+			},
+			wantNull:  []string{"name", "description", "ttl", "extensions"},
+			wantCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			kms := kms.TestKms(t, conn, wrapper)
+			sche := scheduler.TestScheduler(t, conn, wrapper)
+			repo, err := NewRepository(rw, rw, kms, sche)
+			assert.NoError(err)
+			require.NotNil(repo)
+
+			_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+			cs := TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 1)[0]
+
+			in := newOrig()
+			in.StoreId = cs.GetPublicId()
+			orig, err := repo.CreateSshSignedCertCredentialLibrary(ctx, prj.GetPublicId(), in)
+			assert.NoError(err)
+			require.NotNil(orig)
+
+			if tt.chgFn != nil {
+				orig = tt.chgFn(orig)
+			}
+			got, gotCount, err := repo.UpdateSshSignedCertCredentialLibrary(ctx, prj.GetPublicId(), orig, 1, tt.masks)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				assert.Equal(tt.wantCount, gotCount, "row count")
+				assert.Nil(got)
+				return
+			}
+			assert.NoError(err)
+			require.NotNil(got)
+			assertPublicId(t, SshSignedCertCredentialLibraryPrefix, got.GetPublicId())
+			assert.Equal(tt.wantCount, gotCount, "row count")
+			assert.NotSame(orig, got)
+			assert.Equal(cs.GetPublicId(), got.StoreId)
+
+			found, err := repo.LookupSshSignedCertCredentialLibrary(ctx, got.GetPublicId())
+			require.NoError(err)
+			require.NotNil(found)
+			assert.Equal(tt.want.Name, found.Name)
+			assert.Equal(tt.want.Description, found.Description)
+			assert.Equal(tt.want.VaultPath, found.VaultPath)
+			assert.Equal(tt.want.ValidPrincipals, found.ValidPrincipals)
+			assert.Equal(tt.want.Ttl, found.Ttl)
+			assert.Equal(tt.want.Extensions, found.Extensions)
+			assert.Equal(uint32(2), found.Version)
+
+			underlyingDB, err := conn.SqlDB(ctx)
+			require.NoError(err)
+			dbassert := dbassert.New(t, underlyingDB)
+			for _, f := range tt.wantNull {
+				dbassert.IsNull(found, f)
+			}
+			assert.NoError(db.TestVerifyOplog(t, rw, got.GetPublicId(), db.WithOperation(oplog.OpType_OP_TYPE_UPDATE), db.WithCreateNotBefore(10*time.Second)))
+		})
+	}
+
+	t.Run("invalid-duplicate-names", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ctx := context.Background()
+		kms := kms.TestKms(t, conn, wrapper)
+		sche := scheduler.TestScheduler(t, conn, wrapper)
+		repo, err := NewRepository(rw, rw, kms, sche)
+		assert.NoError(err)
+		require.NotNil(repo)
+
+		name := "test-dup-name"
+		_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+		cs := TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 1)[0]
+		libs := TestSshSignedCertCredentialLibraries(t, conn, wrapper, cs.GetPublicId(), 2)
+
+		lA, lB := libs[0], libs[1]
+
+		lA.Name = name
+		got1, gotCount1, err := repo.UpdateSshSignedCertCredentialLibrary(ctx, prj.GetPublicId(), lA, 1, []string{nameField})
+		assert.NoError(err)
+		require.NotNil(got1)
+		assert.Equal(name, got1.Name)
+		assert.Equal(1, gotCount1, "row count")
+
+		lB.Name = name
+		got2, gotCount2, err := repo.UpdateSshSignedCertCredentialLibrary(ctx, prj.GetPublicId(), lB, 1, []string{nameField})
+		assert.Truef(errors.Match(errors.T(errors.NotUnique), err), "want err code: %v got err: %v", errors.NotUnique, err)
+		assert.Nil(got2)
+		assert.Equal(db.NoRowsAffected, gotCount2, "row count")
+	})
+
+	t.Run("version-and-scope-id", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ctx := context.Background()
+		kms := kms.TestKms(t, conn, wrapper)
+		sche := scheduler.TestScheduler(t, conn, wrapper)
+		repo, err := NewRepository(rw, rw, kms, sche)
+		assert.NoError(err)
+		require.NotNil(repo)
+
+		_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+		cs := TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 1)[0]
+		l := TestSshSignedCertCredentialLibraries(t, conn, wrapper, cs.GetPublicId(), 1)[0]
+
+		l.Name = "test-wrong-version"
+		_, gotCount, err := repo.UpdateSshSignedCertCredentialLibrary(ctx, prj.GetPublicId(), l, 2, []string{nameField})
+		assert.NoError(err)
+		assert.Equal(db.NoRowsAffected, gotCount, "row count")
+
+		_, _, err = repo.UpdateSshSignedCertCredentialLibrary(ctx, prj.GetPublicId(), l, 0, []string{nameField})
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "want err: %q got: %q", errors.InvalidParameter, err)
+
+		_, _, err = repo.UpdateSshSignedCertCredentialLibrary(ctx, "", l, 1, []string{nameField})
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "want err: %q got: %q", errors.InvalidParameter, err)
+	})
+}
+
+func TestRepository_LookupSshSignedCertCredentialLibrary(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 1)[0]
+	l := TestSshSignedCertCredentialLibraries(t, conn, wrapper, cs.GetPublicId(), 1)[0]
+
+	badId, err := newSshSignedCertCredentialLibraryId()
+	require.NoError(t, err)
+	require.NotNil(t, badId)
+
+	tests := []struct {
+		name    string
+		in      string
+		want    *SshSignedCertCredentialLibrary
+		wantErr errors.Code
+	}{
+		{
+			name: "valid",
+			in:   l.GetPublicId(),
+			want: l,
+		},
+		{
+			name:    "empty-public-id",
+			in:      "",
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "not-found",
+			in:   badId,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			kms := kms.TestKms(t, conn, wrapper)
+			sche := scheduler.TestScheduler(t, conn, wrapper)
+			repo, err := NewRepository(rw, rw, kms, sche)
+			assert.NoError(err)
+			require.NotNil(repo)
+
+			got, err := repo.LookupSshSignedCertCredentialLibrary(ctx, tt.in)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+
+			switch {
+			case tt.want == nil:
+				assert.Nil(got)
+			case tt.want != nil:
+				assert.NotNil(got)
+				assert.Equal(got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepository_DeleteSshSignedCertCredentialLibrary(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	cs := TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 1)[0]
+	l := TestSshSignedCertCredentialLibraries(t, conn, wrapper, cs.GetPublicId(), 1)[0]
+
+	badId, err := newSshSignedCertCredentialLibraryId()
+	require.NoError(t, err)
+	require.NotNil(t, badId)
+
+	tests := []struct {
+		name    string
+		scopeId string
+		in      string
+		want    int
+		wantErr errors.Code
+	}{
+		{
+			name:    "found",
+			scopeId: prj.GetPublicId(),
+			in:      l.GetPublicId(),
+			want:    1,
+		},
+		{
+			name:    "not-found",
+			scopeId: prj.GetPublicId(),
+			in:      badId,
+		},
+		{
+			name:    "empty-public-id",
+			scopeId: prj.GetPublicId(),
+			in:      "",
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "empty-scope-id",
+			in:      l.GetPublicId(),
+			wantErr: errors.InvalidParameter,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			kms := kms.TestKms(t, conn, wrapper)
+			sche := scheduler.TestScheduler(t, conn, wrapper)
+			repo, err := NewRepository(rw, rw, kms, sche)
+			assert.NoError(err)
+			require.NotNil(repo)
+
+			got, err := repo.DeleteSshSignedCertCredentialLibrary(ctx, tt.scopeId, tt.in)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.want, got, "row count")
+		})
+	}
+}
+
+func TestRepository_ListSshSignedCertCredentialLibraries(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	css := TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 2)
+	csA, csB := css[0], css[1]
+
+	libs := TestSshSignedCertCredentialLibraries(t, conn, wrapper, csA.GetPublicId(), 3)
+	TestCredentialLibraries(t, conn, wrapper, csB.GetPublicId(), 1)
+
+	tests := []struct {
+		name    string
+		in      string
+		opts    []Option
+		want    []*SshSignedCertCredentialLibrary
+		wantErr errors.Code
+	}{
+		{
+			name:    "with-no-credential-store-id",
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name: "CredentialStore-with-no-libraries",
+			in:   csB.GetPublicId(),
+			want: []*SshSignedCertCredentialLibrary{},
+		},
+		{
+			name: "CredentialStore-with-libraries",
+			in:   csA.GetPublicId(),
+			want: libs,
+		},
+		{
+			name: "with-limit",
+			in:   csA.GetPublicId(),
+			opts: []Option{WithLimit(1)},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			kms := kms.TestKms(t, conn, wrapper)
+			sche := scheduler.TestScheduler(t, conn, wrapper)
+			repo, err := NewRepository(rw, rw, kms, sche)
+			assert.NoError(err)
+			require.NotNil(repo)
+			got, err := repo.ListSshSignedCertCredentialLibraries(ctx, tt.in, tt.opts...)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			if tt.want == nil {
+				assert.Len(got, 1)
+				return
+			}
+			opts := []cmp.Option{
+				cmpopts.SortSlices(func(x, y *SshSignedCertCredentialLibrary) bool { return x.PublicId < y.PublicId }),
+				protocmp.Transform(),
+			}
+			assert.Empty(cmp.Diff(tt.want, got, opts...))
+		})
+	}
+}
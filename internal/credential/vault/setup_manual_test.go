@@ -72,7 +72,7 @@ func TestSetupSleepyDevEnvironment(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, store)
 	libClient := credentiallibraries.NewClient(client)
-	lib, err := libClient.Create(ctx, store.Item.Id, credentiallibraries.WithVaultCredentialLibraryPath(path.Join("database", "creds", "opened")),
+	lib, err := libClient.Create(ctx, "vault", store.Item.Id, credentiallibraries.WithVaultCredentialLibraryPath(path.Join("database", "creds", "opened")),
 		credentiallibraries.WithVaultCredentialLibraryHttpMethod("GET"),
 	)
 	require.NoError(t, err)
@@ -0,0 +1,102 @@
+package vault
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshSignRequest is the body of a request to the sign endpoint of the
+// Vault SSH secrets engine.
+type sshSignRequest struct {
+	PublicKey       string            `json:"public_key"`
+	CertType        string            `json:"cert_type"`
+	ValidPrincipals string            `json:"valid_principals"`
+	Ttl             string            `json:"ttl,omitempty"`
+	Extensions      map[string]string `json:"extensions,omitempty"`
+}
+
+// newSshKeyPair generates an ephemeral ECDSA key pair. It returns the PEM
+// encoded private key and the public key in the OpenSSH authorized_keys
+// format.
+func newSshKeyPair(ctx context.Context) (credential.PrivateKey, []byte, error) {
+	const op = "vault.newSshKeyPair"
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to generate key"))
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to marshal private key"))
+	}
+	pub, err := ssh.NewPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create ssh public key"))
+	}
+	priv := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+	return credential.PrivateKey(priv), ssh.MarshalAuthorizedKey(pub), nil
+}
+
+// signSshCertificate generates an ephemeral key pair and has the public key
+// signed by the Vault SSH secrets engine at the path of pl. The first valid
+// principal of pl is used as the username of the returned credential.
+func (pl *privateLibrary) signSshCertificate(ctx context.Context, c *client, sessionId string) (*sshCertCred, error) {
+	const op = "vault.(privateLibrary).signSshCertificate"
+	principals := splitPrincipals(pl.ValidPrincipals)
+	if len(principals) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("library %s: no valid principals", pl.PublicId))
+	}
+	extensions, err := decodeExtensions(pl.Extensions)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter), errors.WithMsg(fmt.Sprintf("library %s: extensions", pl.PublicId)))
+	}
+
+	priv, pub, err := newSshKeyPair(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	body, err := json.Marshal(&sshSignRequest{
+		PublicKey:       string(pub),
+		CertType:        "user",
+		ValidPrincipals: pl.ValidPrincipals,
+		Ttl:             pl.Ttl,
+		Extensions:      extensions,
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to marshal sign request"))
+	}
+
+	secret, err := c.post(pl.VaultPath, body)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if secret == nil {
+		return nil, errors.New(ctx, errors.VaultCredentialRequest, op, fmt.Sprintf("library %s: empty response", pl.PublicId))
+	}
+	signed, ok := secret.Data["signed_key"].(string)
+	if !ok || signed == "" {
+		return nil, errors.New(ctx, errors.VaultInvalidCredentialMapping, op, fmt.Sprintf("library %s: response has no signed_key", pl.PublicId))
+	}
+
+	return &sshCertCred{
+		actualCredential: &actualCredential{
+			sessionId:  sessionId,
+			lib:        pl,
+			secretData: secret.Data,
+			purpose:    pl.Purpose,
+		},
+		username:    principals[0],
+		privateKey:  priv,
+		certificate: []byte(signed),
+	}, nil
+}
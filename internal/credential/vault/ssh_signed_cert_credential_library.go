@@ -0,0 +1,139 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/vault/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+// A SshSignedCertCredentialLibrary contains the path of a Vault SSH
+// secrets engine sign endpoint and is owned by a credential store. Each
+// credential issued by the library is an ephemeral SSH key pair and a
+// certificate for the public key signed by Vault.
+type SshSignedCertCredentialLibrary struct {
+	*store.SshSignedCertCredentialLibrary
+	tableName string `gorm:"-"`
+}
+
+// NewSshSignedCertCredentialLibrary creates a new in memory
+// SshSignedCertCredentialLibrary for the Vault SSH sign endpoint at
+// vaultPath assigned to storeId. The certificates issued by the library
+// are signed for validPrincipals. The first principal is used as the
+// username of the credential. Name, description, ttl, and extensions are
+// the only valid options. All other options are ignored.
+func NewSshSignedCertCredentialLibrary(storeId string, vaultPath string, validPrincipals []string, opt ...Option) (*SshSignedCertCredentialLibrary, error) {
+	const op = "vault.NewSshSignedCertCredentialLibrary"
+	opts := getOpts(opt...)
+
+	var extensions string
+	if len(opts.withExtensions) > 0 {
+		b, err := json.Marshal(opts.withExtensions)
+		if err != nil {
+			return nil, errors.WrapDeprecated(err, op, errors.WithCode(errors.InvalidParameter))
+		}
+		extensions = string(b)
+	}
+
+	l := &SshSignedCertCredentialLibrary{
+		SshSignedCertCredentialLibrary: &store.SshSignedCertCredentialLibrary{
+			StoreId:         storeId,
+			Name:            opts.withName,
+			Description:     opts.withDescription,
+			VaultPath:       vaultPath,
+			ValidPrincipals: strings.Join(validPrincipals, ","),
+			Ttl:             opts.withTtl,
+			Extensions:      extensions,
+			CredentialType:  string(credential.SshCertificateType),
+		},
+	}
+	return l, nil
+}
+
+func allocSshSignedCertCredentialLibrary() *SshSignedCertCredentialLibrary {
+	return &SshSignedCertCredentialLibrary{
+		SshSignedCertCredentialLibrary: &store.SshSignedCertCredentialLibrary{},
+	}
+}
+
+func (l *SshSignedCertCredentialLibrary) clone() *SshSignedCertCredentialLibrary {
+	cp := proto.Clone(l.SshSignedCertCredentialLibrary)
+	return &SshSignedCertCredentialLibrary{
+		SshSignedCertCredentialLibrary: cp.(*store.SshSignedCertCredentialLibrary),
+	}
+}
+
+// TableName returns the table name.
+func (l *SshSignedCertCredentialLibrary) TableName() string {
+	if l.tableName != "" {
+		return l.tableName
+	}
+	return "credential_vault_ssh_signed_cert_library"
+}
+
+// SetTableName sets the table name.
+func (l *SshSignedCertCredentialLibrary) SetTableName(n string) {
+	l.tableName = n
+}
+
+func (l *SshSignedCertCredentialLibrary) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{l.PublicId},
+		"resource-type":      []string{"credential-vault-ssh-signed-cert-library"},
+		"op-type":            []string{op.String()},
+	}
+	if l.StoreId != "" {
+		metadata["store-id"] = []string{l.StoreId}
+	}
+	return metadata
+}
+
+// CredentialType returns the type of credential the library provides.
+func (l *SshSignedCertCredentialLibrary) CredentialType() credential.Type {
+	return credential.SshCertificateType
+}
+
+// Principals returns the principals the certificates issued by the
+// library are signed for.
+func (l *SshSignedCertCredentialLibrary) Principals() []string {
+	return splitPrincipals(l.GetValidPrincipals())
+}
+
+// ExtensionsMap returns the extensions requested for the certificates
+// issued by the library.
+func (l *SshSignedCertCredentialLibrary) ExtensionsMap(ctx context.Context) (map[string]string, error) {
+	const op = "vault.(SshSignedCertCredentialLibrary).ExtensionsMap"
+	e, err := decodeExtensions(l.GetExtensions())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter))
+	}
+	return e, nil
+}
+
+func splitPrincipals(s string) []string {
+	var principals []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			principals = append(principals, p)
+		}
+	}
+	return principals
+}
+
+func decodeExtensions(s string) (map[string]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var e map[string]string
+	if err := json.Unmarshal([]byte(s), &e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+var _ credential.Library = (*SshSignedCertCredentialLibrary)(nil)
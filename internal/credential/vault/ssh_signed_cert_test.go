@@ -0,0 +1,81 @@
+package vault
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestNewSshKeyPair(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+
+	priv, pub, err := newSshKeyPair(ctx)
+	require.NoError(err)
+	require.NotEmpty(priv)
+	require.NotEmpty(pub)
+
+	signer, err := ssh.ParsePrivateKey(priv)
+	require.NoError(err)
+	parsed, _, _, _, err := ssh.ParseAuthorizedKey(pub)
+	require.NoError(err)
+	assert.Equal(signer.PublicKey().Marshal(), parsed.Marshal())
+
+	priv2, _, err := newSshKeyPair(ctx)
+	require.NoError(err)
+	assert.NotEqual(priv, priv2, "key pairs must not be reused")
+}
+
+func TestSshSignedCertCredentialLibrary_New(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		principals     []string
+		opts           []Option
+		wantPrincipals []string
+		wantTtl        string
+		wantExtensions map[string]string
+	}{
+		{
+			name:           "single-principal",
+			principals:     []string{"ubuntu"},
+			wantPrincipals: []string{"ubuntu"},
+		},
+		{
+			name:           "multiple-principals",
+			principals:     []string{"ubuntu", " admin ", ""},
+			wantPrincipals: []string{"ubuntu", "admin"},
+		},
+		{
+			name:           "ttl-and-extensions",
+			principals:     []string{"ubuntu"},
+			opts:           []Option{WithTtl("10m"), WithExtensions(map[string]string{"permit-pty": ""})},
+			wantPrincipals: []string{"ubuntu"},
+			wantTtl:        "10m",
+			wantExtensions: map[string]string{"permit-pty": ""},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewSshSignedCertCredentialLibrary("csvlt_1234567890", "ssh/sign/role", tt.principals, tt.opts...)
+			require.NoError(err)
+			require.NotNil(got)
+			assert.Equal(credential.SshCertificateType, got.CredentialType())
+			assert.Equal(tt.wantPrincipals, got.Principals())
+			assert.Equal(tt.wantTtl, got.GetTtl())
+			ext, err := got.ExtensionsMap(ctx)
+			require.NoError(err)
+			assert.Equal(tt.wantExtensions, ext)
+		})
+	}
+}
@@ -141,6 +141,38 @@ func TestCredentialLibraries(t *testing.T, conn *db.DB, _ wrapping.Wrapper, stor
 	return libs
 }
 
+// TestSshSignedCertCredentialLibraries creates count number of vault ssh
+// signed cert credential libraries in the provided DB with the provided
+// store id. If any errors are encountered during the creation of the
+// credential libraries, the test will fail.
+func TestSshSignedCertCredentialLibraries(t *testing.T, conn *db.DB, _ wrapping.Wrapper, storeId string, count int) []*SshSignedCertCredentialLibrary {
+	t.Helper()
+	assert, require := assert.New(t), require.New(t)
+	w := db.New(conn)
+	var libs []*SshSignedCertCredentialLibrary
+
+	for i := 0; i < count; i++ {
+		lib, err := NewSshSignedCertCredentialLibrary(storeId, fmt.Sprintf("ssh/sign/role%d", i), []string{"ubuntu"})
+		assert.NoError(err)
+		require.NotNil(lib)
+		id, err := newSshSignedCertCredentialLibraryId()
+		assert.NoError(err)
+		require.NotEmpty(id)
+		lib.PublicId = id
+
+		ctx := context.Background()
+		_, err2 := w.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+			func(_ db.Reader, iw db.Writer) error {
+				return iw.Create(ctx, lib)
+			},
+		)
+
+		require.NoError(err2)
+		libs = append(libs, lib)
+	}
+	return libs
+}
+
 // TestCredentials creates count number of vault credentials in the provided DB with
 // the provided library id and session id. If any errors are encountered
 // during the creation of the credentials, the test will fail.
@@ -789,6 +821,49 @@ func (v *TestVaultServer) MountPKI(t *testing.T, opt ...TestOption) *vault.Secre
 	return s
 }
 
+// MountAppRole enables the Vault AppRole auth method and creates a role on
+// the mount. Tokens issued for the role are periodic and have the standard
+// set of policies attached to tokens created with v.CreateToken. It
+// returns the role id of the role and a new secret id.
+//
+// The default mount path is approle and the default role name is
+// boundary. WithTestMountPath, WithTestRoleName, WithTokenPeriod and
+// WithPolicies are the only test options supported.
+func (v *TestVaultServer) MountAppRole(t *testing.T, opt ...TestOption) (string, string) {
+	t.Helper()
+	require := require.New(t)
+	opts := getTestOpts(t, opt...)
+	vc := v.client(t).cl
+
+	mountPath := opts.mountPath
+	if mountPath == "" {
+		mountPath = "approle/"
+	}
+	require.NoError(vc.Sys().EnableAuthWithOptions(mountPath, &vault.EnableAuthOptions{Type: "approle"}))
+
+	rolePath := path.Join("auth", mountPath, "role", opts.roleName)
+	roleOptions := map[string]interface{}{
+		"token_policies": opts.policies,
+		"token_period":   opts.tokenPeriod.String(),
+	}
+	_, err := vc.Logical().Write(rolePath, roleOptions)
+	require.NoError(err)
+
+	s, err := vc.Logical().Read(path.Join(rolePath, "role-id"))
+	require.NoError(err)
+	require.NotNil(s)
+	roleId, ok := s.Data["role_id"].(string)
+	require.True(ok, "role_id")
+
+	s, err = vc.Logical().Write(path.Join(rolePath, "secret-id"), nil)
+	require.NoError(err)
+	require.NotNil(s)
+	secretId, ok := s.Data["secret_id"].(string)
+	require.True(ok, "secret_id")
+
+	return roleId, secretId
+}
+
 // TestVaultServer is a vault server running in a docker container suitable
 // for testing.
 type TestVaultServer struct {
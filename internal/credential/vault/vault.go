@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net/http"
+	"path"
 	"strings"
 	"time"
 
@@ -170,6 +171,23 @@ func (c *client) lookupToken() (*vault.Secret, error) {
 	return t, nil
 }
 
+// login calls the /auth/<mountPath>/login Vault endpoint of an auth
+// method with data and returns the vault.Secret response. The token of the
+// client is not changed. See
+// https://www.vaultproject.io/api-docs/auth/approle#login-with-approle and
+// https://www.vaultproject.io/api-docs/auth/kubernetes#login.
+func (c *client) login(mountPath string, data map[string]interface{}) (*vault.Secret, error) {
+	const op = "vault.(client).login"
+	s, err := c.cl.Logical().Write(path.Join("auth", mountPath, "login"), data)
+	if err != nil {
+		return nil, errors.WrapDeprecated(err, op, errors.WithCode(errors.Unknown), errors.WithMsg(fmt.Sprintf("vault: %s", c.cl.Address())))
+	}
+	if s == nil || s.Auth == nil || s.Auth.ClientToken == "" {
+		return nil, errors.NewDeprecated(errors.Unknown, op, fmt.Sprintf("no token in login response: vault: %s", c.cl.Address()))
+	}
+	return s, nil
+}
+
 // swapToken replaces the token in the Vault client with t and returns the
 // token that was replaced.
 func (c *client) swapToken(new TokenSecret) (old TokenSecret) {
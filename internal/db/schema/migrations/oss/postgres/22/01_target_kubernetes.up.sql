@@ -0,0 +1,94 @@
+begin;
+
+  -- target_kubernetes is a target subtype for a Kubernetes API server. The
+  -- worker terminates the client's HTTP connection and forwards requests to
+  -- the API server using the session's egress credentials, adding
+  -- impersonation headers for the Boundary user.
+  create table target_kubernetes (
+    public_id wt_public_id primary key
+      references target(public_id)
+      on delete cascade
+      on update cascade,
+    scope_id wt_scope_id not null
+      references iam_scope(public_id)
+      on delete cascade
+      on update cascade,
+    name text not null, -- name is not optional for a target subtype
+    description text,
+    default_port int, -- default_port can be null
+    -- max duration of the session in seconds.
+    -- default is 8 hours
+    session_max_seconds int not null default 28800
+      constraint session_max_seconds_must_be_greater_than_0
+      check(session_max_seconds > 0),
+    -- limit on number of session connections allowed. -1 equals no limit
+    session_connection_limit int not null default 1
+      constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+      check(session_connection_limit > 0 or session_connection_limit = -1),
+    worker_filter wt_bexprfilter,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    constraint target_kubernetes_scope_id_name_uq
+      unique(scope_id, name) -- name must be unique within a scope
+  );
+
+  create trigger insert_target_subtype before insert on target_kubernetes
+    for each row execute procedure insert_target_subtype();
+
+  create trigger delete_target_subtype after delete on target_kubernetes
+    for each row execute procedure delete_target_subtype();
+
+  create trigger immutable_columns before update on target_kubernetes
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger update_version_column after update on target_kubernetes
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on target_kubernetes
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on target_kubernetes
+    for each row execute procedure default_create_time();
+
+  create trigger target_scope_valid before insert on target_kubernetes
+    for each row execute procedure target_scope_valid();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('target_kubernetes', 1);
+
+  -- Replaces the view created in 1/01_server_tags_migrations.up.sql to include
+  -- kubernetes targets.
+  drop view target_all_subtypes;
+  create view target_all_subtypes
+  as
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'tcp' as type
+    from target_tcp
+   union
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'kubernetes' as type
+    from target_kubernetes;
+
+commit;
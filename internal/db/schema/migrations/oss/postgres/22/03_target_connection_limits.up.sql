@@ -0,0 +1,110 @@
+begin;
+
+  -- connection_idle_timeout_seconds is the number of seconds a session
+  -- connection may go without transferring data before the worker closes it.
+  -- max_concurrent_connections is the number of connections of a session which
+  -- may be open at the same time. max_bytes_per_session is the number of bytes
+  -- which may be transferred over all connections of a session. For all three
+  -- columns, 0 means no limit.
+  alter table target_tcp
+    add column connection_idle_timeout_seconds int not null default 0
+      constraint connection_idle_timeout_seconds_must_not_be_negative
+      check(connection_idle_timeout_seconds >= 0),
+    add column max_concurrent_connections int not null default 0
+      constraint max_concurrent_connections_must_not_be_negative
+      check(max_concurrent_connections >= 0),
+    add column max_bytes_per_session bigint not null default 0
+      constraint max_bytes_per_session_must_not_be_negative
+      check(max_bytes_per_session >= 0);
+
+  alter table target_kubernetes
+    add column connection_idle_timeout_seconds int not null default 0
+      constraint connection_idle_timeout_seconds_must_not_be_negative
+      check(connection_idle_timeout_seconds >= 0),
+    add column max_concurrent_connections int not null default 0
+      constraint max_concurrent_connections_must_not_be_negative
+      check(max_concurrent_connections >= 0),
+    add column max_bytes_per_session bigint not null default 0
+      constraint max_bytes_per_session_must_not_be_negative
+      check(max_bytes_per_session >= 0);
+
+  alter table session
+    add column connection_idle_timeout_seconds int not null default 0
+      constraint connection_idle_timeout_seconds_must_not_be_negative
+      check(connection_idle_timeout_seconds >= 0),
+    add column max_concurrent_connections int not null default 0
+      constraint max_concurrent_connections_must_not_be_negative
+      check(max_concurrent_connections >= 0),
+    add column max_bytes_per_session bigint not null default 0
+      constraint max_bytes_per_session_must_not_be_negative
+      check(max_bytes_per_session >= 0);
+
+  -- Replaces the trigger from 1/01_server_tags_migrations.up.sql to add the
+  -- connection limit columns.
+  drop trigger immutable_columns on session;
+  create trigger immutable_columns before update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit', 'create_time', 'endpoint', 'worker_filter',
+      'connection_idle_timeout_seconds', 'max_concurrent_connections', 'max_bytes_per_session');
+
+  -- 'limit reached' is used when a worker closes a connection because one of
+  -- the session's connection limits was exceeded.
+  alter table session_connection_closed_reason_enm
+    drop constraint only_predefined_session_connection_closed_reasons_allowed;
+  alter table session_connection_closed_reason_enm
+    add constraint only_predefined_session_connection_closed_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'canceled',
+          'network error',
+          'system error',
+          'limit reached'
+        )
+      );
+
+  insert into session_connection_closed_reason_enm (name)
+  values
+    ('limit reached');
+
+  -- Replaces the view created in 22/01_target_kubernetes.up.sql to include the
+  -- connection limit columns. The warehouse source views depend on this view,
+  -- so it is replaced in place and the new columns are appended after type.
+  create or replace view target_all_subtypes
+  as
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'tcp' as type,
+         connection_idle_timeout_seconds,
+         max_concurrent_connections,
+         max_bytes_per_session
+    from target_tcp
+   union
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'kubernetes' as type,
+         connection_idle_timeout_seconds,
+         max_concurrent_connections,
+         max_bytes_per_session
+    from target_kubernetes;
+
+commit;
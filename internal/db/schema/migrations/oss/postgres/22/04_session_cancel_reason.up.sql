@@ -0,0 +1,11 @@
+begin;
+
+  -- cancel_reason is an optional message supplied when a session is canceled.
+  -- It is sent to the worker proxying the session's connections, which passes
+  -- it on to the client when the connections are closed.
+  alter table session
+    add column cancel_reason text
+      constraint cancel_reason_must_not_be_empty
+      check(length(trim(cancel_reason)) > 0);
+
+commit;
@@ -0,0 +1,162 @@
+begin;
+
+  -- Password policy settings for password auth methods. The character class
+  -- columns require at least one character of the class in a password.
+  -- deny_common_passwords rejects passwords found in a list of commonly used
+  -- passwords. For all integer columns, 0 disables the setting.
+  alter table auth_password_method
+    add column password_require_lowercase bool not null default false,
+    add column password_require_uppercase bool not null default false,
+    add column password_require_digit bool not null default false,
+    add column password_require_symbol bool not null default false,
+    add column deny_common_passwords bool not null default false,
+    -- lockout_threshold is the number of failed authentication attempts within
+    -- lockout_window_seconds after which an account is locked for
+    -- lockout_duration_seconds. A lockout_window_seconds of 0 counts failed
+    -- attempts until the next successful authentication.
+    add column lockout_threshold int not null default 0
+      constraint lockout_threshold_must_not_be_negative
+      check(lockout_threshold >= 0),
+    add column lockout_window_seconds int not null default 0
+      constraint lockout_window_seconds_must_not_be_negative
+      check(lockout_window_seconds >= 0),
+    add column lockout_duration_seconds int not null default 0
+      constraint lockout_duration_seconds_must_not_be_negative
+      check(lockout_duration_seconds >= 0),
+    -- password_history_count is the number of most recent passwords of an
+    -- account, including the current password, which may not be reused.
+    add column password_history_count int not null default 0
+      constraint password_history_count_must_be_between_0_and_24
+      check(password_history_count between 0 and 24),
+    -- max_password_age_days is the number of days after which a password must
+    -- be changed at the next authentication.
+    add column max_password_age_days int not null default 0
+      constraint max_password_age_days_must_not_be_negative
+      check(max_password_age_days >= 0),
+    add constraint lockout_duration_required_with_lockout_threshold
+      check(lockout_threshold = 0 or lockout_duration_seconds > 0);
+
+  -- auth_password_account_lockout holds the failed authentication attempts of
+  -- a password account. It is kept out of auth_password_account so that failed
+  -- attempts do not change the version of the account.
+  create table auth_password_account_lockout (
+    password_account_id wt_public_id primary key
+      references auth_password_account (public_id)
+      on delete cascade
+      on update cascade,
+    failed_login_count int not null default 0
+      constraint failed_login_count_must_not_be_negative
+      check(failed_login_count >= 0),
+    window_start_time timestamp with time zone not null default current_timestamp,
+    lock_expiration_time timestamp with time zone
+  );
+  comment on table auth_password_account_lockout is
+    'auth_password_account_lockout holds the failed authentication attempts of a password account';
+
+  -- auth_password_argon2_cred_history holds the previous credentials of a
+  -- password account. Entries are added when a credential is replaced and
+  -- retain the private_id of the replaced credential.
+  create table auth_password_argon2_cred_history (
+    private_id wt_private_id primary key,
+    password_account_id wt_public_id not null
+      references auth_password_account (public_id)
+      on delete cascade
+      on update cascade,
+    password_conf_id wt_private_id not null
+      references auth_password_argon2_conf (private_id)
+      on delete cascade
+      on update cascade,
+    create_time wt_timestamp,
+    salt bytea not null
+      constraint salt_must_not_be_empty
+      check(length(salt) > 0),
+    derived_key bytea not null
+      constraint derived_key_must_not_be_empty
+      check(length(derived_key) > 0),
+    key_id text not null
+      constraint key_id_must_not_be_empty
+      check(length(trim(key_id)) > 0)
+  );
+  comment on table auth_password_argon2_cred_history is
+    'auth_password_argon2_cred_history holds the previous credentials of a password account';
+
+  create trigger
+    immutable_columns
+  before
+  update on auth_password_argon2_cred_history
+    for each row execute procedure immutable_columns('private_id', 'password_account_id', 'password_conf_id', 'create_time', 'salt', 'derived_key', 'key_id');
+
+  create trigger
+    default_create_time_column
+  before
+  insert on auth_password_argon2_cred_history
+    for each row execute procedure default_create_time();
+
+  -- auth_password_account_with_lockout is used for reading a password account
+  -- with its lockout state.
+  create view auth_password_account_with_lockout as
+  select acct.public_id,
+         acct.auth_method_id,
+         acct.scope_id,
+         acct.name,
+         acct.description,
+         acct.create_time,
+         acct.update_time,
+         acct.login_name,
+         acct.version,
+         coalesce(lo.failed_login_count, 0) as failed_login_count,
+         lo.lock_expiration_time,
+         coalesce(lo.lock_expiration_time > current_timestamp, false) as is_locked
+    from auth_password_account acct
+    left join auth_password_account_lockout lo
+      on acct.public_id = lo.password_account_id;
+  comment on view auth_password_account_with_lockout is
+    'password account with its lockout state';
+
+  -- Replaces the view created in 2/20_pass.up.sql to add the password policy
+  -- columns.
+  create or replace view auth_password_method_with_is_primary as
+  select
+    case when s.primary_auth_method_id is not null then
+      true
+    else false end
+    as is_primary_auth_method,
+    am.public_id,
+    am.scope_id,
+    am.password_conf_id,
+    am.name,
+    am.description,
+    am.create_time,
+    am.update_time,
+    am.version,
+    am.min_login_name_length,
+    am.min_password_length,
+    am.password_require_lowercase,
+    am.password_require_uppercase,
+    am.password_require_digit,
+    am.password_require_symbol,
+    am.deny_common_passwords,
+    am.lockout_threshold,
+    am.lockout_window_seconds,
+    am.lockout_duration_seconds,
+    am.password_history_count,
+    am.max_password_age_days
+  from
+    auth_password_method am
+    left outer join iam_scope s on am.public_id = s.primary_auth_method_id;
+
+  -- Replaces the view created in 0/14_auth_password_views.up.sql to add the
+  -- password policy columns.
+  create or replace view auth_password_current_conf as
+      select pm.min_login_name_length, pm.min_password_length, c.*,
+             pm.password_require_lowercase,
+             pm.password_require_uppercase,
+             pm.password_require_digit,
+             pm.password_require_symbol,
+             pm.deny_common_passwords,
+             pm.password_history_count
+        from auth_password_method pm
+  inner join auth_password_conf_union c
+          on pm.password_conf_id = c.password_conf_id;
+
+commit;
@@ -0,0 +1,176 @@
+begin;
+
+  -- auth_password_totp_mfa_enm entries define when password accounts must
+  -- provide a TOTP code in addition to their password. With 'optional', only
+  -- accounts enrolled in TOTP must provide a code. With 'required', accounts
+  -- not enrolled are enrolled during their next authentication.
+  create table auth_password_totp_mfa_enm (
+    name text primary key
+      constraint only_predefined_totp_mfa_settings_allowed
+      check (
+        name in ('disabled', 'optional', 'required')
+      )
+  );
+
+  insert into auth_password_totp_mfa_enm(name)
+    values
+      ('disabled'),
+      ('optional'),
+      ('required');
+
+  create trigger
+    immutable_columns
+  before
+  update on auth_password_totp_mfa_enm
+    for each row execute procedure immutable_columns('name');
+
+  alter table auth_password_method
+    add column totp_mfa text not null default 'disabled'
+      references auth_password_totp_mfa_enm(name)
+      on delete restrict
+      on update cascade;
+
+  -- auth_password_account_totp holds the TOTP enrollment of a password
+  -- account. The seed is encrypted with the database key of the scope of the
+  -- account. Enrollments created during authentication are unconfirmed until a
+  -- code generated from the seed has been provided. last_used_step is the time
+  -- step of the last code accepted and prevents codes from being replayed.
+  create table auth_password_account_totp (
+    password_account_id wt_public_id primary key
+      references auth_password_account (public_id)
+      on delete cascade
+      on update cascade,
+    create_time wt_timestamp,
+    seed bytea not null
+      constraint seed_must_not_be_empty
+      check(length(seed) > 0),
+    key_id text not null
+      constraint key_id_must_not_be_empty
+      check(length(trim(key_id)) > 0),
+    confirmed bool not null default false,
+    last_used_step bigint not null default 0
+  );
+  comment on table auth_password_account_totp is
+    'auth_password_account_totp holds the TOTP enrollment of a password account';
+
+  create trigger
+    immutable_columns
+  before
+  update on auth_password_account_totp
+    for each row execute procedure immutable_columns('password_account_id', 'create_time', 'seed', 'key_id');
+
+  create trigger
+    default_create_time_column
+  before
+  insert on auth_password_account_totp
+    for each row execute procedure default_create_time();
+
+  -- auth_password_totp_recovery_code holds the hashes of the unused recovery
+  -- codes of a TOTP enrollment. A recovery code can be used once in place of a
+  -- TOTP code.
+  create table auth_password_totp_recovery_code (
+    password_account_id wt_public_id not null
+      references auth_password_account_totp (password_account_id)
+      on delete cascade
+      on update cascade,
+    code_hash bytea not null
+      constraint code_hash_must_not_be_empty
+      check(length(code_hash) > 0),
+    primary key(password_account_id, code_hash)
+  );
+  comment on table auth_password_totp_recovery_code is
+    'auth_password_totp_recovery_code holds the hashes of the unused recovery codes of a TOTP enrollment';
+
+  -- auth_password_mfa_challenge holds the pending second authentication step
+  -- of a password account. The auth token is created in the 'auth token
+  -- pending' status and issued once the challenge is answered.
+  create table auth_password_mfa_challenge (
+    public_id wt_public_id primary key,
+    password_account_id wt_public_id not null
+      references auth_password_account (public_id)
+      on delete cascade
+      on update cascade,
+    auth_token_id wt_public_id not null
+      references auth_token (public_id)
+      on delete cascade
+      on update cascade,
+    secret_hash bytea not null
+      constraint secret_hash_must_not_be_empty
+      check(length(secret_hash) > 0),
+    create_time wt_timestamp,
+    expiration_time timestamp with time zone not null,
+    failed_attempts int not null default 0
+      constraint failed_attempts_must_not_be_negative
+      check(failed_attempts >= 0)
+  );
+  comment on table auth_password_mfa_challenge is
+    'auth_password_mfa_challenge holds the pending second authentication step of a password account';
+
+  create trigger
+    immutable_columns
+  before
+  update on auth_password_mfa_challenge
+    for each row execute procedure immutable_columns('public_id', 'password_account_id', 'auth_token_id', 'secret_hash', 'create_time', 'expiration_time');
+
+  create trigger
+    default_create_time_column
+  before
+  insert on auth_password_mfa_challenge
+    for each row execute procedure default_create_time();
+
+  -- Replaces the view created in 22/05_auth_password_policy.up.sql to add
+  -- is_totp_enrolled.
+  create or replace view auth_password_account_with_lockout as
+  select acct.public_id,
+         acct.auth_method_id,
+         acct.scope_id,
+         acct.name,
+         acct.description,
+         acct.create_time,
+         acct.update_time,
+         acct.login_name,
+         acct.version,
+         coalesce(lo.failed_login_count, 0) as failed_login_count,
+         lo.lock_expiration_time,
+         coalesce(lo.lock_expiration_time > current_timestamp, false) as is_locked,
+         coalesce(totp.confirmed, false) as is_totp_enrolled
+    from auth_password_account acct
+    left join auth_password_account_lockout lo
+      on acct.public_id = lo.password_account_id
+    left join auth_password_account_totp totp
+      on acct.public_id = totp.password_account_id;
+
+  -- Replaces the view created in 22/05_auth_password_policy.up.sql to add
+  -- totp_mfa.
+  create or replace view auth_password_method_with_is_primary as
+  select
+    case when s.primary_auth_method_id is not null then
+      true
+    else false end
+    as is_primary_auth_method,
+    am.public_id,
+    am.scope_id,
+    am.password_conf_id,
+    am.name,
+    am.description,
+    am.create_time,
+    am.update_time,
+    am.version,
+    am.min_login_name_length,
+    am.min_password_length,
+    am.password_require_lowercase,
+    am.password_require_uppercase,
+    am.password_require_digit,
+    am.password_require_symbol,
+    am.deny_common_passwords,
+    am.lockout_threshold,
+    am.lockout_window_seconds,
+    am.lockout_duration_seconds,
+    am.password_history_count,
+    am.max_password_age_days,
+    am.totp_mfa
+  from
+    auth_password_method am
+    left outer join iam_scope s on am.public_id = s.primary_auth_method_id;
+
+commit;
@@ -0,0 +1,38 @@
+begin;
+
+  -- The wh_authorization_failure_fact table is a transaction fact table. The
+  -- grain of the fact table is one row per request to authorize a session
+  -- which was denied. Rows are inserted by the controller since failed
+  -- authorizations do not leave any other trace in the database.
+  --
+  -- user_id, target_id, project_id and organization_id are degenerate
+  -- dimensions. They hold the public ids of the resources at the time of the
+  -- failure and are not foreign keys since the resources can be deleted.
+  create table wh_authorization_failure_fact (
+    user_id         text           not null,
+    target_id       wt_public_id   not null,
+    project_id      wt_scope_id    not null,
+    organization_id wt_scope_id    not null,
+
+    reason          wh_dim_text,
+
+    -- date and time foreign keys and timestamp
+    failure_date_key integer default wh_date_key(current_timestamp) not null
+      references wh_date_dimension (key)
+      on delete restrict
+      on update cascade,
+    failure_time_key integer default wh_time_key(current_timestamp) not null
+      references wh_time_of_day_dimension (key)
+      on delete restrict
+      on update cascade,
+    failure_time wh_timestamp default current_timestamp
+  );
+
+  comment on table wh_authorization_failure_fact is
+    'The Wh Authorization Failure Fact table is a transaction fact table. '
+    'The grain of the fact table is one row per denied session authorization.';
+
+  create index on wh_authorization_failure_fact(failure_time);
+  create index on wh_authorization_failure_fact(project_id);
+
+commit;
@@ -0,0 +1,53 @@
+begin;
+
+  -- The warehouse tables are updated in place by the wh_* triggers, so they
+  -- get an update_time column which allows the warehouse export job to find
+  -- the rows which are new or changed since its previous run. Existing rows
+  -- get the time of the migration and are included in the first export.
+  alter table wh_session_accumulating_fact
+    add column update_time wh_timestamp default current_timestamp;
+  alter table wh_session_connection_accumulating_fact
+    add column update_time wh_timestamp default current_timestamp;
+  alter table wh_host_dimension
+    add column update_time wh_timestamp default current_timestamp;
+  alter table wh_user_dimension
+    add column update_time wh_timestamp default current_timestamp;
+  alter table wh_credential_dimension
+    add column update_time wh_timestamp default current_timestamp;
+  alter table wh_credential_group_membership
+    add column update_time wh_timestamp default current_timestamp;
+
+  create trigger update_time_column before update on wh_session_accumulating_fact
+    for each row execute procedure update_time_column();
+  create trigger update_time_column before update on wh_session_connection_accumulating_fact
+    for each row execute procedure update_time_column();
+  create trigger update_time_column before update on wh_host_dimension
+    for each row execute procedure update_time_column();
+  create trigger update_time_column before update on wh_user_dimension
+    for each row execute procedure update_time_column();
+  create trigger update_time_column before update on wh_credential_dimension
+    for each row execute procedure update_time_column();
+  create trigger update_time_column before update on wh_credential_group_membership
+    for each row execute procedure update_time_column();
+
+  create index on wh_session_accumulating_fact(update_time);
+  create index on wh_session_connection_accumulating_fact(update_time);
+  create index on wh_host_dimension(update_time);
+  create index on wh_user_dimension(update_time);
+  create index on wh_credential_dimension(update_time);
+  create index on wh_credential_group_membership(update_time);
+
+  -- wh_export_watermark holds, for each exported warehouse table, the
+  -- update_time up to which rows have been exported.
+  create table wh_export_watermark (
+    table_name text primary key
+      constraint table_name_must_not_be_empty
+      check(length(trim(table_name)) > 0),
+    high_watermark wh_timestamp,
+    update_time wt_timestamp
+  );
+
+  create trigger update_time_column before update on wh_export_watermark
+    for each row execute procedure update_time_column();
+
+commit;
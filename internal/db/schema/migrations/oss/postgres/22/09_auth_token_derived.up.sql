@@ -0,0 +1,84 @@
+begin;
+
+  -- A derived auth token is created by a user from the auth token of one of
+  -- their own sessions. It belongs to the same auth account as its parent and
+  -- is deleted along with its parent.
+  alter table auth_token
+    add column parent_id wt_public_id null
+      constraint auth_token_fkey
+        references auth_token(public_id)
+        on delete cascade
+        on update cascade
+      constraint parent_id_must_not_be_public_id
+        check(parent_id <> public_id),
+    add column description text;
+
+  drop trigger immutable_columns on auth_token;
+  create trigger
+    immutable_columns
+  before
+  update on auth_token
+    for each row execute procedure immutable_columns('public_id', 'auth_account_id', 'create_time', 'parent_id');
+
+  create index auth_token_parent_id_ix
+    on auth_token (parent_id);
+
+  -- auth_token_grant restricts what a derived auth token may be used for. The
+  -- permissions of a derived auth token are the intersection of the grants of
+  -- its user and the grants of the auth token. Grants are immutable.
+  create table auth_token_grant (
+    create_time wt_timestamp,
+    auth_token_id wt_public_id -- pk
+      constraint auth_token_fkey
+        references auth_token(public_id)
+        on delete cascade
+        on update cascade,
+    scope_id wt_scope_id -- pk
+      constraint iam_scope_fkey
+        references iam_scope(public_id)
+        on delete cascade
+        on update cascade,
+    canonical_grant text -- pk
+      constraint canonical_grant_must_not_be_empty
+      check(
+        length(trim(canonical_grant)) > 0
+      ),
+    raw_grant text not null
+      constraint raw_grant_must_not_be_empty
+      check(
+        length(trim(raw_grant)) > 0
+      ),
+    primary key(auth_token_id, scope_id, canonical_grant)
+  );
+
+  create trigger
+    immutable_columns
+  before
+  update on auth_token_grant
+    for each row execute procedure immutable_columns('create_time', 'auth_token_id', 'scope_id', 'canonical_grant', 'raw_grant');
+
+  create trigger
+    default_create_time_column
+  before
+  insert on auth_token_grant
+    for each row execute procedure default_create_time();
+
+  create or replace view auth_token_account as
+        select at.public_id,
+               at.token,
+               at.auth_account_id,
+               at.create_time,
+               at.update_time,
+               at.approximate_last_access_time,
+               at.expiration_time,
+               aa.scope_id,
+               aa.iam_user_id,
+               aa.auth_method_id,
+               at.status,
+               at.parent_id,
+               at.description
+          from auth_token as at
+    inner join auth_account as aa
+            on at.auth_account_id = aa.public_id;
+
+commit;
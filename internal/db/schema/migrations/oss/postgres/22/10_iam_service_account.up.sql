@@ -0,0 +1,259 @@
+begin;
+
+  -- iam_service_account is a principal for automation. Like users, service
+  -- accounts can be assigned to roles, but they authenticate with credentials
+  -- registered directly on the service account instead of with an auth
+  -- method.
+  create table iam_service_account (
+    public_id wt_public_id
+      primary key,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    name text,
+    description text,
+    scope_id wt_scope_id
+      not null
+      constraint iam_scope_fkey
+        references iam_scope(public_id)
+        on delete cascade
+        on update cascade,
+    version wt_version,
+    constraint iam_service_account_name_scope_id_uq
+      unique(name, scope_id),
+    constraint iam_service_account_scope_id_public_id_uq
+      unique(scope_id, public_id)
+  );
+
+  create trigger
+    update_version_column
+  after update on iam_service_account
+    for each row execute procedure update_version_column();
+
+  create trigger
+    update_time_column
+  before update on iam_service_account
+    for each row execute procedure update_time_column();
+
+  create trigger
+    default_create_time_column
+  before
+  insert on iam_service_account
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on iam_service_account
+    for each row execute procedure immutable_columns('public_id', 'create_time', 'scope_id');
+
+  -- service accounts live in the same scopes as users.
+  create trigger
+    ensure_service_account_scope_id_valid
+  before
+  insert or update on iam_service_account
+    for each row execute procedure user_scope_id_valid();
+
+  create table iam_service_account_credential_type_enm (
+    string text primary key
+      constraint only_predefined_service_account_credential_types_allowed
+      check (
+        string in ('jwt-public-key', 'client-secret')
+      )
+  );
+
+  insert into iam_service_account_credential_type_enm (string)
+  values
+    ('jwt-public-key'),
+    ('client-secret');
+
+  -- iam_service_account_credential contains the credentials a service account
+  -- authenticates with. A jwt-public-key credential holds a PEM encoded public
+  -- key which verifies JWTs signed by the service account. A client-secret
+  -- credential holds the SHA-256 hash of a generated secret; its public_id is
+  -- the client id. Credentials are immutable: keys are rotated by adding a new
+  -- credential and removing the old one.
+  create table iam_service_account_credential (
+    public_id wt_public_id
+      primary key,
+    service_account_id wt_public_id not null
+      constraint iam_service_account_fkey
+        references iam_service_account(public_id)
+        on delete cascade
+        on update cascade,
+    type text not null
+      constraint iam_service_account_credential_type_enm_fkey
+        references iam_service_account_credential_type_enm(string)
+        on delete restrict
+        on update cascade,
+    public_key text,
+    secret_hash bytea,
+    create_time wt_timestamp,
+    expiration_time timestamp with time zone,
+    constraint credential_must_match_type
+      check (
+        (type = 'jwt-public-key' and length(trim(public_key)) > 0 and secret_hash is null)
+        or
+        (type = 'client-secret' and length(secret_hash) > 0 and public_key is null)
+      )
+  );
+
+  create trigger
+    immutable_columns
+  before
+  update on iam_service_account_credential
+    for each row execute procedure immutable_columns('public_id', 'service_account_id', 'type', 'public_key', 'secret_hash', 'create_time', 'expiration_time');
+
+  create trigger
+    default_create_time_column
+  before
+  insert on iam_service_account_credential
+    for each row execute procedure default_create_time();
+
+  create index iam_service_account_credential_service_account_id_ix
+    on iam_service_account_credential (service_account_id);
+
+  -- iam_service_account_role contains roles that have been assigned to
+  -- service accounts. The rows in this table must be immutable after insert,
+  -- which will be ensured with a before update trigger using
+  -- iam_immutable_role_principal().
+  create table iam_service_account_role (
+    create_time wt_timestamp,
+    role_id wt_role_id
+      references iam_role(public_id)
+      on delete cascade
+      on update cascade,
+    principal_id wt_public_id
+      references iam_service_account(public_id)
+      on delete cascade
+      on update cascade,
+    primary key (role_id, principal_id)
+  );
+
+  create trigger immutable_role_principal
+  before update on iam_service_account_role
+    for each row execute procedure iam_immutable_role_principal();
+
+  create trigger default_create_time_column
+  before insert on iam_service_account_role
+    for each row execute procedure default_create_time();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('iam_service_account', 1),
+    ('iam_service_account_credential', 1),
+    ('iam_service_account_role', 1);
+
+  -- replaces view from 9/04_oidc_managed_group_principal_role.up.sql
+  create or replace view iam_principal_role as
+  select
+    ur.create_time,
+    ur.principal_id,
+    ur.role_id,
+    u.scope_id as principal_scope_id,
+    r.scope_id as role_scope_id,
+    get_scoped_principal_id(r.scope_id, u.scope_id, ur.principal_id) as scoped_principal_id,
+    'user' as type
+  from
+    iam_user_role ur,
+    iam_role r,
+    iam_user u
+  where
+    ur.role_id = r.public_id and
+    u.public_id = ur.principal_id
+  union
+  select
+    gr.create_time,
+    gr.principal_id,
+    gr.role_id,
+    g.scope_id as principal_scope_id,
+    r.scope_id as role_scope_id,
+    get_scoped_principal_id(r.scope_id, g.scope_id, gr.principal_id) as scoped_principal_id,
+    'group' as type
+  from
+    iam_group_role gr,
+    iam_role r,
+    iam_group g
+  where
+    gr.role_id = r.public_id and
+    g.public_id = gr.principal_id
+  union
+  select
+    mgr.create_time,
+    mgr.principal_id,
+    mgr.role_id,
+    (select scope_id from auth_method am where am.public_id = amg.auth_method_id) as principal_scope_id,
+    r.scope_id as role_scope_id,
+    get_scoped_principal_id(r.scope_id, (select scope_id from auth_method am where am.public_id = amg.auth_method_id), mgr.principal_id) as scoped_principal_id,
+    'managed group' as type
+  from
+    iam_managed_group_role mgr,
+    iam_role r,
+    auth_managed_group amg
+  where
+    mgr.role_id = r.public_id and
+    amg.public_id = mgr.principal_id
+  union
+  select
+    sar.create_time,
+    sar.principal_id,
+    sar.role_id,
+    sa.scope_id as principal_scope_id,
+    r.scope_id as role_scope_id,
+    get_scoped_principal_id(r.scope_id, sa.scope_id, sar.principal_id) as scoped_principal_id,
+    'service account' as type
+  from
+    iam_service_account_role sar,
+    iam_role r,
+    iam_service_account sa
+  where
+    sar.role_id = r.public_id and
+    sa.public_id = sar.principal_id;
+
+  -- Auth tokens are issued either to an auth account or to a service account.
+  alter table auth_token
+    alter column auth_account_id drop not null,
+    add column service_account_id wt_public_id null
+      constraint iam_service_account_fkey
+        references iam_service_account(public_id)
+        on delete cascade
+        on update cascade,
+    add constraint auth_token_must_have_one_principal
+      check (
+        (auth_account_id is not null and service_account_id is null)
+        or
+        (auth_account_id is null and service_account_id is not null)
+      );
+
+  drop trigger immutable_columns on auth_token;
+  create trigger
+    immutable_columns
+  before
+  update on auth_token
+    for each row execute procedure immutable_columns('public_id', 'auth_account_id', 'service_account_id', 'create_time', 'parent_id');
+
+  create index auth_token_service_account_id_ix
+    on auth_token (service_account_id);
+
+  -- replaces view from 22/09_auth_token_derived.up.sql
+  create or replace view auth_token_account as
+        select at.public_id,
+               at.token,
+               at.auth_account_id,
+               at.create_time,
+               at.update_time,
+               at.approximate_last_access_time,
+               at.expiration_time,
+               coalesce(aa.scope_id, sa.scope_id) as scope_id,
+               aa.iam_user_id,
+               aa.auth_method_id,
+               at.status,
+               at.parent_id,
+               at.description,
+               at.service_account_id
+          from auth_token as at
+     left join auth_account as aa
+            on at.auth_account_id = aa.public_id
+     left join iam_service_account as sa
+            on at.service_account_id = sa.public_id;
+
+commit;
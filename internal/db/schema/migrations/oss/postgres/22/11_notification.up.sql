@@ -0,0 +1,560 @@
+begin;
+
+  -- notification is a subscription to events of the given event types which
+  -- delivers the events that match its filter to a webhook url. Deliveries
+  -- are signed with the notification's secret. Like users, notifications
+  -- live in the global scope or an org and receive the events of their scope
+  -- and of its child scopes.
+  create table notification (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      constraint iam_scope_fkey
+        references iam_scope(public_id)
+        on delete cascade
+        on update cascade,
+    name text,
+    description text,
+    url text not null
+      constraint url_must_not_be_empty
+      check(length(trim(url)) > 0),
+    filter wt_bexprfilter,
+    -- the secret is encrypted with the database key of the notification's
+    -- scope.
+    secret bytea not null
+      constraint secret_must_not_be_empty
+      check(length(secret) > 0),
+    key_id text not null
+      constraint kms_database_key_version_fkey
+        references kms_database_key_version (private_id)
+        on delete restrict
+        on update cascade,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    constraint notification_scope_id_name_uq
+      unique(scope_id, name)
+  );
+
+  create trigger
+    update_version_column
+  after update on notification
+    for each row execute procedure update_version_column();
+
+  create trigger
+    update_time_column
+  before update on notification
+    for each row execute procedure update_time_column();
+
+  create trigger
+    default_create_time_column
+  before
+  insert on notification
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on notification
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  -- notifications live in the same scopes as users.
+  create trigger
+    ensure_notification_scope_id_valid
+  before
+  insert or update on notification
+    for each row execute procedure user_scope_id_valid();
+
+  create table notification_event_type_enm (
+    string text primary key
+      constraint only_predefined_notification_event_types_allowed
+      check (
+        string in (
+          'session.authorized',
+          'session.activated',
+          'session.canceled',
+          'session.terminated',
+          'connection.connected',
+          'connection.closed',
+          'role.created',
+          'role.updated',
+          'role.deleted',
+          'role.grant-added',
+          'role.grant-removed',
+          'role.principal-added',
+          'role.principal-removed'
+        )
+      )
+  );
+
+  insert into notification_event_type_enm (string)
+  values
+    ('session.authorized'),
+    ('session.activated'),
+    ('session.canceled'),
+    ('session.terminated'),
+    ('connection.connected'),
+    ('connection.closed'),
+    ('role.created'),
+    ('role.updated'),
+    ('role.deleted'),
+    ('role.grant-added'),
+    ('role.grant-removed'),
+    ('role.principal-added'),
+    ('role.principal-removed');
+
+  -- notification_event_type contains the event types a notification is
+  -- subscribed to.
+  create table notification_event_type (
+    notification_id wt_public_id not null
+      constraint notification_fkey
+        references notification(public_id)
+        on delete cascade
+        on update cascade,
+    event_type text not null
+      constraint notification_event_type_enm_fkey
+        references notification_event_type_enm(string)
+        on delete restrict
+        on update cascade,
+    create_time wt_timestamp,
+    primary key (notification_id, event_type)
+  );
+
+  create trigger
+    immutable_columns
+  before
+  update on notification_event_type
+    for each row execute procedure immutable_columns('notification_id', 'event_type', 'create_time');
+
+  create trigger
+    default_create_time_column
+  before
+  insert on notification_event_type
+    for each row execute procedure default_create_time();
+
+  create index notification_event_type_event_type_ix
+    on notification_event_type (event_type);
+
+  insert into oplog_ticket (name, version)
+  values
+    ('notification', 1),
+    ('notification_event_type', 1);
+
+  -- notification_event is the queue of events written by the triggers below.
+  -- Events are only written when at least one notification is subscribed to
+  -- their type. The notification dispatch job matches undispatched events
+  -- against the notifications and their filters and creates a delivery for
+  -- each match.
+  create table notification_event (
+    id bigint generated always as identity primary key,
+    type text not null
+      constraint notification_event_type_enm_fkey
+        references notification_event_type_enm(string)
+        on delete restrict
+        on update cascade,
+    -- scope_id is the scope of the resource the event is about. It is not a
+    -- foreign key so events about deleted scopes are still delivered.
+    scope_id text not null,
+    data jsonb not null,
+    create_time wt_timestamp,
+    dispatched boolean not null default false
+  );
+
+  create trigger
+    default_create_time_column
+  before
+  insert on notification_event
+    for each row execute procedure default_create_time();
+
+  create index notification_event_undispatched_ix
+    on notification_event (id)
+    where dispatched = false;
+
+  create index notification_event_create_time_ix
+    on notification_event (create_time);
+
+  create table notification_delivery_status_enm (
+    string text primary key
+      constraint only_predefined_notification_delivery_statuses_allowed
+      check (
+        string in ('pending', 'delivered', 'failed')
+      )
+  );
+
+  insert into notification_delivery_status_enm (string)
+  values
+    ('pending'),
+    ('delivered'),
+    ('failed');
+
+  -- notification_delivery tracks the delivery of an event to a notification.
+  -- Failed attempts are retried by the notification delivery job until
+  -- next_attempt_time; a delivery is failed once it runs out of attempts.
+  create table notification_delivery (
+    private_id wt_private_id
+      primary key,
+    notification_id wt_public_id not null
+      constraint notification_fkey
+        references notification(public_id)
+        on delete cascade
+        on update cascade,
+    event_id bigint not null
+      constraint notification_event_fkey
+        references notification_event(id)
+        on delete cascade
+        on update cascade,
+    status text not null default 'pending'
+      constraint notification_delivery_status_enm_fkey
+        references notification_delivery_status_enm(string)
+        on delete restrict
+        on update cascade,
+    attempts int not null default 0
+      constraint attempts_must_not_be_negative
+      check(attempts >= 0),
+    next_attempt_time timestamp with time zone not null default current_timestamp,
+    last_attempt_time timestamp with time zone,
+    response_code int,
+    last_error text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    constraint notification_delivery_notification_id_event_id_uq
+      unique(notification_id, event_id)
+  );
+
+  create trigger
+    update_time_column
+  before update on notification_delivery
+    for each row execute procedure update_time_column();
+
+  create trigger
+    default_create_time_column
+  before
+  insert on notification_delivery
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on notification_delivery
+    for each row execute procedure immutable_columns('private_id', 'notification_id', 'event_id', 'create_time');
+
+  create index notification_delivery_pending_ix
+    on notification_delivery (next_attempt_time)
+    where status = 'pending';
+
+  create index notification_delivery_notification_id_ix
+    on notification_delivery (notification_id, update_time);
+
+  -- notification_agg adds the subscribed event types and the status of the
+  -- retained deliveries to each notification.
+  create view notification_agg as
+  select n.public_id,
+         n.scope_id,
+         n.name,
+         n.description,
+         n.url,
+         n.filter,
+         n.key_id,
+         n.create_time,
+         n.update_time,
+         n.version,
+         et.event_types,
+         coalesce(ds.pending_count, 0)   as pending_count,
+         coalesce(ds.delivered_count, 0) as delivered_count,
+         coalesce(ds.failed_count, 0)    as failed_count,
+         ld.last_attempt_time            as last_delivery_time,
+         ld.status                       as last_delivery_status,
+         ld.response_code                as last_delivery_response_code,
+         ld.last_error                   as last_delivery_error
+    from notification n
+    left join (
+         select notification_id,
+                string_agg(distinct event_type, '|') as event_types
+           from notification_event_type
+       group by notification_id
+         ) et
+      on et.notification_id = n.public_id
+    left join (
+         select notification_id,
+                count(*) filter (where status = 'pending')   as pending_count,
+                count(*) filter (where status = 'delivered') as delivered_count,
+                count(*) filter (where status = 'failed')    as failed_count
+           from notification_delivery
+       group by notification_id
+         ) ds
+      on ds.notification_id = n.public_id
+    left join lateral (
+         select d.last_attempt_time,
+                d.status,
+                d.response_code,
+                d.last_error
+           from notification_delivery d
+          where d.notification_id = n.public_id
+            and d.last_attempt_time is not null
+       order by d.last_attempt_time desc
+          limit 1
+         ) ld
+      on true;
+
+  -- insert_notification_event writes an event of type p_type for scope
+  -- p_scope_id, unless no notification is subscribed to the type.
+  create function insert_notification_event(p_type text, p_scope_id text, p_data jsonb)
+    returns void
+  as $$
+  begin
+    if p_scope_id is null then
+      return;
+    end if;
+    perform from notification_event_type where event_type = p_type limit 1;
+    if not found then
+      return;
+    end if;
+    insert into notification_event (type, scope_id, data)
+    values (p_type, p_scope_id, p_data);
+  end;
+  $$ language plpgsql;
+
+  create function session_state_notification_event()
+    returns trigger
+  as $$
+  declare
+    s record;
+  begin
+    select ss.public_id,
+           ss.scope_id,
+           ss.target_id,
+           t.name as target_name,
+           ss.user_id,
+           u.name as user_name,
+           ss.host_id,
+           ss.host_set_id,
+           ss.endpoint,
+           ss.termination_reason,
+           ss.cancel_reason
+      into s
+      from session ss
+      left join target_all_subtypes t
+        on t.public_id = ss.target_id
+      left join iam_user u
+        on u.public_id = ss.user_id
+     where ss.public_id = new.session_id;
+    perform insert_notification_event(
+      case new.state
+        when 'pending'    then 'session.authorized'
+        when 'active'     then 'session.activated'
+        when 'canceling'  then 'session.canceled'
+        when 'terminated' then 'session.terminated'
+      end,
+      s.scope_id,
+      jsonb_build_object(
+        'session_id',         s.public_id,
+        'state',              new.state,
+        'target_id',          s.target_id,
+        'target_name',        s.target_name,
+        'user_id',            s.user_id,
+        'user_name',          s.user_name,
+        'host_id',            s.host_id,
+        'host_set_id',        s.host_set_id,
+        'endpoint',           s.endpoint,
+        'termination_reason', s.termination_reason,
+        'cancel_reason',      s.cancel_reason
+      )
+    );
+    return null;
+  end;
+  $$ language plpgsql;
+
+  create trigger
+    notification_event
+  after
+  insert on session_state
+    for each row execute procedure session_state_notification_event();
+
+  create function session_connection_state_notification_event()
+    returns trigger
+  as $$
+  declare
+    c record;
+  begin
+    if new.state not in ('connected', 'closed') then
+      return null;
+    end if;
+    select sc.public_id,
+           sc.session_id,
+           host(sc.client_tcp_address) as client_tcp_address,
+           sc.client_tcp_port,
+           host(sc.endpoint_tcp_address) as endpoint_tcp_address,
+           sc.endpoint_tcp_port,
+           sc.closed_reason,
+           ss.scope_id,
+           ss.target_id,
+           t.name as target_name,
+           ss.user_id,
+           u.name as user_name,
+           ss.host_id
+      into c
+      from session_connection sc
+      join session ss
+        on ss.public_id = sc.session_id
+      left join target_all_subtypes t
+        on t.public_id = ss.target_id
+      left join iam_user u
+        on u.public_id = ss.user_id
+     where sc.public_id = new.connection_id;
+    perform insert_notification_event(
+      'connection.' || new.state,
+      c.scope_id,
+      jsonb_build_object(
+        'connection_id',        c.public_id,
+        'session_id',           c.session_id,
+        'target_id',            c.target_id,
+        'target_name',          c.target_name,
+        'user_id',              c.user_id,
+        'user_name',            c.user_name,
+        'host_id',              c.host_id,
+        'client_tcp_address',   c.client_tcp_address,
+        'client_tcp_port',      c.client_tcp_port,
+        'endpoint_tcp_address', c.endpoint_tcp_address,
+        'endpoint_tcp_port',    c.endpoint_tcp_port,
+        'closed_reason',        c.closed_reason
+      )
+    );
+    return null;
+  end;
+  $$ language plpgsql;
+
+  create trigger
+    notification_event
+  after
+  insert on session_connection_state
+    for each row execute procedure session_connection_state_notification_event();
+
+  create function iam_role_notification_event()
+    returns trigger
+  as $$
+  declare
+    r record;
+  begin
+    if tg_op = 'DELETE' then
+      r = old;
+    else
+      r = new;
+    end if;
+    perform insert_notification_event(
+      case tg_op
+        when 'INSERT' then 'role.created'
+        when 'UPDATE' then 'role.updated'
+        when 'DELETE' then 'role.deleted'
+      end,
+      r.scope_id,
+      jsonb_build_object(
+        'role_id',        r.public_id,
+        'role_name',      r.name,
+        'grant_scope_id', r.grant_scope_id
+      )
+    );
+    return null;
+  end;
+  $$ language plpgsql;
+
+  create trigger
+    notification_event
+  after
+  insert or update or delete on iam_role
+    for each row execute procedure iam_role_notification_event();
+
+  create function iam_role_grant_notification_event()
+    returns trigger
+  as $$
+  declare
+    g record;
+  begin
+    if tg_op = 'DELETE' then
+      g = old;
+    else
+      g = new;
+    end if;
+    -- the role is already gone when its grants are deleted along with it,
+    -- in which case the role.deleted event covers the change.
+    perform insert_notification_event(
+      case tg_op
+        when 'INSERT' then 'role.grant-added'
+        when 'DELETE' then 'role.grant-removed'
+      end,
+      r.scope_id,
+      jsonb_build_object(
+        'role_id',   r.public_id,
+        'role_name', r.name,
+        'grant',     g.canonical_grant
+      )
+    )
+    from iam_role r
+   where r.public_id = g.role_id;
+    return null;
+  end;
+  $$ language plpgsql;
+
+  create trigger
+    notification_event
+  after
+  insert or delete on iam_role_grant
+    for each row execute procedure iam_role_grant_notification_event();
+
+  -- iam_principal_role_notification_event is attached to the principal role
+  -- tables. Its argument is the type of the principal.
+  create function iam_principal_role_notification_event()
+    returns trigger
+  as $$
+  declare
+    pr record;
+  begin
+    if tg_op = 'DELETE' then
+      pr = old;
+    else
+      pr = new;
+    end if;
+    perform insert_notification_event(
+      case tg_op
+        when 'INSERT' then 'role.principal-added'
+        when 'DELETE' then 'role.principal-removed'
+      end,
+      r.scope_id,
+      jsonb_build_object(
+        'role_id',        r.public_id,
+        'role_name',      r.name,
+        'principal_id',   pr.principal_id,
+        'principal_type', tg_argv[0]
+      )
+    )
+    from iam_role r
+   where r.public_id = pr.role_id;
+    return null;
+  end;
+  $$ language plpgsql;
+
+  create trigger
+    notification_event
+  after
+  insert or delete on iam_user_role
+    for each row execute procedure iam_principal_role_notification_event('user');
+
+  create trigger
+    notification_event
+  after
+  insert or delete on iam_group_role
+    for each row execute procedure iam_principal_role_notification_event('group');
+
+  create trigger
+    notification_event
+  after
+  insert or delete on iam_managed_group_role
+    for each row execute procedure iam_principal_role_notification_event('managed group');
+
+  create trigger
+    notification_event
+  after
+  insert or delete on iam_service_account_role
+    for each row execute procedure iam_principal_role_notification_event('service account');
+
+commit;
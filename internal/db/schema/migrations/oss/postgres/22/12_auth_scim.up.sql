@@ -0,0 +1,144 @@
+begin;
+
+  -- auth_scim_token binds a SCIM 2.0 bearer token to an auth method. An auth
+  -- method has at most one token. Only the sha256 hash of the token is
+  -- stored, the token itself is returned once when it is rotated.
+  create table auth_scim_token (
+    auth_method_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null,
+    token_hash bytea not null
+      constraint token_hash_must_not_be_empty
+      check(length(token_hash) > 0)
+      constraint auth_scim_token_token_hash_uq
+        unique,
+    create_time wt_timestamp,
+    constraint auth_method_fkey
+      foreign key (scope_id, auth_method_id)
+        references auth_method (scope_id, public_id)
+        on delete cascade
+        on update cascade
+  );
+  comment on table auth_scim_token is
+    'auth_scim_token is a table where each row is the SCIM bearer token of an auth method.';
+
+  create trigger
+    default_create_time_column
+  before
+  insert on auth_scim_token
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on auth_scim_token
+    for each row execute procedure immutable_columns('auth_method_id', 'scope_id');
+
+  -- auth_scim_user records the iam_users provisioned through the SCIM
+  -- endpoint of an auth method together with the SCIM attributes which have
+  -- no equivalent on the iam_user or the auth_account. The account is only
+  -- set while the user is active, deactivating a user deletes its account.
+  create table auth_scim_user (
+    user_id wt_user_id
+      primary key,
+    auth_method_id wt_public_id
+      not null,
+    scope_id wt_scope_id
+      not null,
+    account_id wt_public_id
+      constraint auth_account_fkey
+        references auth_account (public_id)
+        on delete set null
+        on update cascade,
+    user_name text not null
+      constraint user_name_must_not_be_empty
+      check(length(trim(user_name)) > 0),
+    external_id text,
+    active boolean not null default true,
+    display_name text,
+    given_name text,
+    family_name text,
+    email text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    constraint iam_user_fkey
+      foreign key (scope_id, user_id)
+        references iam_user (scope_id, public_id)
+        on delete cascade
+        on update cascade,
+    constraint auth_method_fkey
+      foreign key (scope_id, auth_method_id)
+        references auth_method (scope_id, public_id)
+        on delete cascade
+        on update cascade
+  );
+  comment on table auth_scim_user is
+    'auth_scim_user is a table where each row is a user provisioned through the SCIM endpoint of an auth method.';
+
+  -- SCIM user names are not case sensitive.
+  create unique index auth_scim_user_auth_method_id_user_name_uq
+    on auth_scim_user (auth_method_id, lower(user_name));
+
+  create trigger
+    update_time_column
+  before update on auth_scim_user
+    for each row execute procedure update_time_column();
+
+  create trigger
+    default_create_time_column
+  before
+  insert on auth_scim_user
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on auth_scim_user
+    for each row execute procedure immutable_columns('user_id', 'auth_method_id', 'scope_id', 'create_time');
+
+  -- auth_scim_group records the iam_groups provisioned through the SCIM
+  -- endpoint of an auth method. The display name of the SCIM group is the
+  -- name of the iam_group and its members are the iam_group's members.
+  create table auth_scim_group (
+    group_id wt_public_id
+      primary key,
+    auth_method_id wt_public_id
+      not null,
+    scope_id wt_scope_id
+      not null,
+    external_id text,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    constraint iam_group_fkey
+      foreign key (scope_id, group_id)
+        references iam_group (scope_id, public_id)
+        on delete cascade
+        on update cascade,
+    constraint auth_method_fkey
+      foreign key (scope_id, auth_method_id)
+        references auth_method (scope_id, public_id)
+        on delete cascade
+        on update cascade
+  );
+  comment on table auth_scim_group is
+    'auth_scim_group is a table where each row is a group provisioned through the SCIM endpoint of an auth method.';
+
+  create trigger
+    update_time_column
+  before update on auth_scim_group
+    for each row execute procedure update_time_column();
+
+  create trigger
+    default_create_time_column
+  before
+  insert on auth_scim_group
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on auth_scim_group
+    for each row execute procedure immutable_columns('group_id', 'auth_method_id', 'scope_id', 'create_time');
+
+commit;
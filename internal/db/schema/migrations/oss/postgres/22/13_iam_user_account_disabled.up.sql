@@ -0,0 +1,122 @@
+begin;
+
+  -- A disabled user or account cannot authenticate, and the auth tokens of a
+  -- disabled user or account are not accepted. Disabling keeps the user and
+  -- its accounts, so their history in the warehouse dimensions is kept as
+  -- well.
+  alter table iam_user
+    add column disabled bool not null default false;
+
+  -- The disabled column of the account subtypes is synchronized to the base
+  -- auth_account table so the auth tokens of disabled accounts can be found
+  -- without knowing the subtype of the account.
+  alter table auth_account
+    add column disabled bool not null default false;
+
+  alter table auth_password_account
+    add column disabled bool not null default false;
+
+  alter table auth_oidc_account
+    add column disabled bool not null default false;
+
+  -- update_auth_account_subtype_disabled() is a before insert and update
+  -- trigger function for auth_account subtypes. It synchronizes the disabled
+  -- column of the sub type to the base auth_account table. Before triggers fire
+  -- in name order, so on insert it runs after the insert trigger of the sub
+  -- type has created the base auth_account row.
+  create function
+    update_auth_account_subtype_disabled()
+    returns trigger
+  as $$
+  begin
+    update auth_account
+       set disabled = new.disabled
+     where public_id = new.public_id
+       and disabled != new.disabled;
+    return new;
+  end;
+  $$ language plpgsql;
+  comment on function update_auth_account_subtype_disabled() is
+    'update_auth_account_subtype_disabled() updates the disabled column of the base auth_account table with the value of the sub type';
+
+  create trigger
+    update_auth_account_subtype_disabled
+  before
+  insert or update of disabled on auth_password_account
+    for each row execute procedure update_auth_account_subtype_disabled();
+
+  create trigger
+    update_auth_account_subtype_disabled
+  before
+  insert or update of disabled on auth_oidc_account
+    for each row execute procedure update_auth_account_subtype_disabled();
+
+  -- Replaces the view created in 4/01_iam.up.sql to add disabled.
+  create or replace view iam_user_acct_info as
+  select u.public_id,
+         u.scope_id,
+         u.name,
+         u.description,
+         u.create_time,
+         u.update_time,
+         u.version,
+         i.primary_account_id,
+         i.login_name,
+         i.full_name,
+         i.email,
+         u.disabled
+    from iam_user u
+    left outer join iam_acct_info i
+      on u.public_id = i.iam_user_id;
+
+  -- Replaces the view created in 22/06_auth_password_totp.up.sql to add
+  -- disabled.
+  create or replace view auth_password_account_with_lockout as
+  select acct.public_id,
+         acct.auth_method_id,
+         acct.scope_id,
+         acct.name,
+         acct.description,
+         acct.create_time,
+         acct.update_time,
+         acct.login_name,
+         acct.version,
+         coalesce(lo.failed_login_count, 0) as failed_login_count,
+         lo.lock_expiration_time,
+         coalesce(lo.lock_expiration_time > current_timestamp, false) as is_locked,
+         coalesce(totp.confirmed, false) as is_totp_enrolled,
+         acct.disabled
+    from auth_password_account acct
+    left join auth_password_account_lockout lo
+      on acct.public_id = lo.password_account_id
+    left join auth_password_account_totp totp
+      on acct.public_id = totp.password_account_id;
+
+  -- Replaces the view created in 22/10_iam_service_account.up.sql to add
+  -- disabled, which is true if the account or the user of the token is
+  -- disabled.
+  create or replace view auth_token_account as
+        select at.public_id,
+               at.token,
+               at.auth_account_id,
+               at.create_time,
+               at.update_time,
+               at.approximate_last_access_time,
+               at.expiration_time,
+               coalesce(aa.scope_id, sa.scope_id) as scope_id,
+               aa.iam_user_id,
+               aa.auth_method_id,
+               at.status,
+               at.parent_id,
+               at.description,
+               at.service_account_id,
+               coalesce(aa.disabled, false) or coalesce(u.disabled, false) as disabled
+          from auth_token as at
+     left join auth_account as aa
+            on at.auth_account_id = aa.public_id
+     left join iam_user as u
+            on aa.iam_user_id = u.public_id
+     left join iam_service_account as sa
+            on at.service_account_id = sa.public_id;
+
+commit;
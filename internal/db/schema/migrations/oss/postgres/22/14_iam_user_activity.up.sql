@@ -0,0 +1,96 @@
+begin;
+
+  create table iam_user_activity_type_enm (
+    string text primary key
+      constraint only_predefined_user_activity_types_allowed
+      check (
+        string in (
+          'authentication-succeeded',
+          'authentication-failed',
+          'session-authorized'
+        )
+      )
+  );
+
+  insert into iam_user_activity_type_enm (string)
+  values
+    ('authentication-succeeded'),
+    ('authentication-failed'),
+    ('session-authorized');
+
+  -- iam_user_activity is the append-only history of the authentications and
+  -- session authorizations of a user. scope_id is the scope the activity
+  -- happened in: the scope of the auth method for authentications and the
+  -- project of the target for session authorizations. The history of a user
+  -- is deleted with the user, and rows older than the configured retention
+  -- period are deleted by the user activity retention job.
+  create table iam_user_activity (
+    id bigint generated always as identity
+      primary key,
+    user_id wt_user_id
+      constraint iam_user_fkey
+        references iam_user(public_id)
+        on delete cascade
+        on update cascade,
+    scope_id wt_scope_id
+      constraint iam_scope_fkey
+        references iam_scope(public_id)
+        on delete cascade
+        on update cascade,
+    activity_type text not null
+      constraint iam_user_activity_type_enm_fkey
+        references iam_user_activity_type_enm(string)
+        on delete restrict
+        on update cascade,
+    auth_method_id text,
+    account_id text,
+    target_id text,
+    session_id text,
+    detail text,
+    create_time wt_timestamp
+  );
+
+  create trigger
+    default_create_time_column
+  before
+  insert on iam_user_activity
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on iam_user_activity
+    for each row execute procedure immutable_columns('id', 'user_id', 'scope_id', 'activity_type',
+      'auth_method_id', 'account_id', 'target_id', 'session_id', 'detail', 'create_time');
+
+  create index iam_user_activity_user_id_create_time_ix
+    on iam_user_activity (user_id, create_time desc);
+
+  create index iam_user_activity_create_time_ix
+    on iam_user_activity (create_time);
+
+  -- insert_session_user_activity() is an after insert trigger function for
+  -- the session table. It records the authorization of the session in the
+  -- activity history of its user.
+  create function
+    insert_session_user_activity()
+    returns trigger
+  as $$
+  begin
+    insert into iam_user_activity
+      (user_id, scope_id, activity_type, target_id, session_id)
+    values
+      (new.user_id, new.scope_id, 'session-authorized', new.target_id, new.public_id);
+    return null;
+  end;
+  $$ language plpgsql;
+  comment on function insert_session_user_activity() is
+    'insert_session_user_activity() records the authorization of a new session in the iam_user_activity table';
+
+  create trigger
+    insert_session_user_activity
+  after
+  insert on session
+    for each row execute procedure insert_session_user_activity();
+
+commit;
@@ -0,0 +1,217 @@
+begin;
+
+  -- Replaces the check constraint created in
+  -- 22/15_credential_vault_library_credential_type.up.sql to add the
+  -- ssh_certificate credential type.
+  alter table credential_type_enm
+    drop constraint only_predefined_credential_types_allowed;
+  alter table credential_type_enm
+    add constraint only_predefined_credential_types_allowed
+      check (
+        name in ('unspecified', 'username_password', 'ssh_private_key', 'ssh_certificate')
+      );
+
+  insert into credential_type_enm (name)
+  values
+    ('ssh_certificate');
+
+  -- SSH certificates are only issued by credential_vault_ssh_signed_cert_library.
+  alter table credential_vault_library
+    add constraint ssh_certificate_credential_type_not_allowed
+      check(credential_type != 'ssh_certificate');
+
+  create table credential_vault_ssh_signed_cert_library (
+    public_id wt_public_id primary key,
+    store_id wt_public_id not null
+      constraint credential_vault_store_fkey
+        references credential_vault_store (public_id)
+        on delete cascade
+        on update cascade,
+    name wt_name,
+    description wt_description,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    vault_path text not null
+      constraint vault_path_must_not_be_empty
+        check(length(trim(vault_path)) > 0),
+    valid_principals text not null
+      constraint valid_principals_must_not_be_empty
+        check(length(trim(valid_principals)) > 0),
+    ttl text
+      constraint ttl_must_not_be_empty
+        check(length(trim(ttl)) > 0),
+    extensions text
+      constraint extensions_must_not_be_empty
+        check(length(trim(extensions)) > 0),
+    credential_type text not null default 'ssh_certificate'
+      constraint credential_type_enm_fkey
+        references credential_type_enm (name)
+        on delete restrict
+        on update cascade
+      constraint credential_type_must_be_ssh_certificate
+        check(credential_type = 'ssh_certificate'),
+    constraint credential_vault_ssh_signed_cert_library_store_id_name_uq
+      unique(store_id, name),
+    constraint credential_library_fkey
+      foreign key (store_id, public_id)
+      references credential_library (store_id, public_id)
+      on delete cascade
+      on update cascade,
+    constraint credential_vault_ssh_signed_cert_library_store_id_public_id_uq
+      unique(store_id, public_id)
+  );
+  comment on table credential_vault_ssh_signed_cert_library is
+    'credential_vault_ssh_signed_cert_library is a table where each row is a resource that represents a vault ssh certificate-signing credential library. '
+    'It is a credential_library subtype and a child table of credential_vault_store.';
+
+  create trigger update_version_column after update on credential_vault_ssh_signed_cert_library
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on credential_vault_ssh_signed_cert_library
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on credential_vault_ssh_signed_cert_library
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on credential_vault_ssh_signed_cert_library
+    for each row execute procedure immutable_columns('public_id', 'store_id', 'create_time', 'credential_type');
+
+  create trigger insert_credential_library_subtype before insert on credential_vault_ssh_signed_cert_library
+    for each row execute procedure insert_credential_library_subtype();
+
+  create trigger delete_credential_library_subtype after delete on credential_vault_ssh_signed_cert_library
+    for each row execute procedure delete_credential_library_subtype();
+
+  create trigger before_insert_credential_vault_library before insert on credential_vault_ssh_signed_cert_library
+    for each row execute procedure before_insert_credential_vault_library();
+
+  insert into oplog_ticket (name, version)
+  values
+    ('credential_vault_ssh_signed_cert_library', 1);
+
+  -- Replaces the function created in 10/04_vault_credential.up.sql to also
+  -- delete the store's ssh certificate-signing libraries.
+  create or replace function after_soft_delete_credential_vault_store()
+    returns trigger
+  as $$
+  begin
+    if new.delete_time is distinct from old.delete_time then
+      if old.delete_time is null then
+
+        -- mark current and maintaining tokens as revoke
+        update credential_vault_token
+           set status   = 'revoke'
+         where store_id = new.public_id
+           and status in ('current', 'maintaining');
+
+        -- delete the store's libraries
+        delete
+          from credential_vault_library
+         where store_id = new.public_id;
+
+        delete
+          from credential_vault_ssh_signed_cert_library
+         where store_id = new.public_id;
+
+      end if;
+    end if;
+    return null;
+  end;
+  $$ language plpgsql;
+
+     create view credential_vault_ssh_signed_cert_library_private as
+     select library.public_id         as public_id,
+            library.store_id          as store_id,
+            library.name              as name,
+            library.description       as description,
+            library.create_time       as create_time,
+            library.update_time       as update_time,
+            library.version           as version,
+            library.vault_path        as vault_path,
+            library.valid_principals  as valid_principals,
+            library.ttl               as ttl,
+            library.extensions        as extensions,
+            library.credential_type   as credential_type,
+            store.scope_id            as scope_id,
+            store.vault_address       as vault_address,
+            store.namespace           as namespace,
+            store.ca_cert             as ca_cert,
+            store.tls_server_name     as tls_server_name,
+            store.tls_skip_verify     as tls_skip_verify,
+            store.token_hmac          as token_hmac,
+            store.ct_token            as ct_token, -- encrypted
+            store.token_key_id        as token_key_id,
+            store.client_cert         as client_cert,
+            store.ct_client_key       as ct_client_key, -- encrypted
+            store.client_key_id       as client_key_id
+       from credential_vault_ssh_signed_cert_library library
+       join credential_vault_store_private store
+         on library.store_id = store.public_id
+        and store.token_status = 'current';
+  comment on view credential_vault_ssh_signed_cert_library_private is
+    'credential_vault_ssh_signed_cert_library_private is a view where each row contains an ssh certificate-signing credential library and the credential library''s data needed to connect to Vault. '
+    'Each row may contain encrypted data. This view should not be used to retrieve data which will be returned external to boundary.';
+
+  -- replaces view from 22/02_wh_target_subtypes.up.sql
+  drop view whx_credential_dimension_source;
+  create view whx_credential_dimension_source as
+       select -- id is the first column in the target view
+              s.public_id                              as session_id,
+              coalesce(scd.credential_purpose, 'None') as credential_purpose,
+              cl.public_id                             as credential_library_id,
+              case
+                when vcl is not null then 'vault credential library'
+                when vscl is not null then 'vault ssh signed cert credential library'
+                else 'None'
+                end                                    as credential_library_type,
+              coalesce(vcl.name, vscl.name, 'None')    as credential_library_name,
+              coalesce(vcl.description, vscl.description, 'None')
+                                                       as credential_library_description,
+              coalesce(vcl.vault_path, vscl.vault_path, 'None')
+                                                       as credential_library_vault_path,
+              case
+                when vscl is not null then 'POST'
+                else coalesce(vcl.http_method, 'None')
+                end                                    as credential_library_vault_http_method,
+              coalesce(vcl.http_request_body, 'None')  as credential_library_vault_http_request_body,
+              cs.public_id                             as credential_store_id,
+              case
+                when vcs is null then 'None'
+                else 'vault credential store'
+                end                                    as credential_store_type,
+              coalesce(vcs.name, 'None')               as credential_store_name,
+              coalesce(vcs.description, 'None')        as credential_store_description,
+              coalesce(vcs.namespace, 'None')          as credential_store_vault_namespace,
+              coalesce(vcs.vault_address, 'None')      as credential_store_vault_address,
+              t.public_id                              as target_id,
+              tt.type || ' target'                     as target_type,
+              coalesce(tt.name, 'None')                as target_name,
+              coalesce(tt.description, 'None')         as target_description,
+              coalesce(tt.default_port, 0)             as target_default_port_number,
+              tt.session_max_seconds                   as target_session_max_seconds,
+              tt.session_connection_limit              as target_session_connection_limit,
+              p.public_id                              as project_id,
+              coalesce(p.name, 'None')                 as project_name,
+              coalesce(p.description, 'None')          as project_description,
+              o.public_id                              as organization_id,
+              coalesce(o.name, 'None')                 as organization_name,
+              coalesce(o.description, 'None')          as organization_description
+         from session_credential_dynamic as scd
+         join session as s                          on s.public_id = scd.session_id
+         join credential_library as cl              on scd.library_id = cl.public_id
+         join credential_store as cs                on cl.store_id = cs.public_id
+         join credential_vault_store as vcs         on vcs.public_id = cs.public_id
+         join target as t                           on s.target_id = t.public_id
+         join target_all_subtypes as tt             on t.public_id = tt.public_id
+         join iam_scope as p                        on p.public_id = t.scope_id and p.type = 'project'
+         join iam_scope as o                        on o.public_id = p.parent_id and o.type = 'org'
+
+         left join credential_vault_library as vcl
+           on vcl.public_id = cl.public_id
+         left join credential_vault_ssh_signed_cert_library as vscl
+           on vscl.public_id = cl.public_id
+        where vcl is not null
+           or vscl is not null;
+
+commit;
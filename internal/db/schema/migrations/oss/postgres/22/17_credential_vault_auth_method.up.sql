@@ -0,0 +1,120 @@
+begin;
+
+  -- credential_vault_auth_method_enm entries define the Vault auth methods a
+  -- credential store can use to log in to Vault instead of using a token
+  -- provided by a user.
+  create table credential_vault_auth_method_enm (
+    name text primary key
+      constraint only_predefined_auth_methods_allowed
+      check (
+        name in ('approle', 'kubernetes')
+      )
+  );
+  comment on table credential_vault_auth_method_enm is
+    'credential_vault_auth_method_enm is an enumeration table for the Vault auth methods a credential store can use.';
+
+  insert into credential_vault_auth_method_enm (name)
+  values
+    ('approle'),
+    ('kubernetes');
+
+  create trigger immutable_columns before update on credential_vault_auth_method_enm
+    for each row execute procedure immutable_columns('name');
+
+  create table credential_vault_auth_method (
+    store_id wt_public_id primary key
+      constraint credential_vault_store_fkey
+        references credential_vault_store (public_id)
+        on delete cascade
+        on update cascade,
+    auth_method text not null
+      constraint credential_vault_auth_method_enm_fkey
+        references credential_vault_auth_method_enm (name)
+        on delete restrict
+        on update cascade,
+    mount_path text not null
+      constraint mount_path_must_not_be_empty
+        check(length(trim(mount_path)) > 0),
+    role_id text
+      constraint role_id_must_not_be_empty
+        check(length(trim(role_id)) > 0),
+    secret_id bytea -- encrypted
+      constraint secret_id_must_not_be_empty
+        check(length(secret_id) > 0),
+    secret_id_hmac bytea
+      constraint secret_id_hmac_must_not_be_empty
+        check(length(secret_id_hmac) > 0),
+    kubernetes_role text
+      constraint kubernetes_role_must_not_be_empty
+        check(length(trim(kubernetes_role)) > 0),
+    key_id text
+      constraint kms_database_key_version_fkey
+        references kms_database_key_version (private_id)
+        on delete restrict
+        on update cascade,
+    constraint approle_fields_required
+      check(
+        auth_method != 'approle'
+        or (role_id is not null and secret_id is not null and secret_id_hmac is not null and key_id is not null)
+      ),
+    constraint kubernetes_fields_required
+      check(
+        auth_method != 'kubernetes'
+        or kubernetes_role is not null
+      )
+  );
+  comment on table credential_vault_auth_method is
+    'credential_vault_auth_method is a table where each row contains the configuration of the Vault auth method a credential_vault_store uses to obtain its Vault token. '
+    'A credential_vault_store can have 0 or 1 auth methods. A credential_vault_store without an auth method uses the token provided by a user.';
+
+  create trigger immutable_columns before update on credential_vault_auth_method
+    for each row execute procedure immutable_columns('store_id');
+
+  -- The public view no longer requires a store to have a current token so a
+  -- store whose token expired, or whose auth method failed to log in, is
+  -- still visible. Such a store has an operational_state of 'auth-failed'.
+  drop view credential_vault_store_public;
+
+     create view credential_vault_store_public as
+     select store.public_id            as public_id,
+            store.scope_id             as scope_id,
+            store.name                 as name,
+            store.description          as description,
+            store.create_time          as create_time,
+            store.update_time          as update_time,
+            store.version              as version,
+            store.vault_address        as vault_address,
+            store.namespace            as namespace,
+            store.ca_cert              as ca_cert,
+            store.tls_server_name      as tls_server_name,
+            store.tls_skip_verify      as tls_skip_verify,
+            token.token_hmac           as token_hmac,
+            token.create_time          as token_create_time,
+            token.update_time          as token_update_time,
+            token.last_renewal_time    as token_last_renewal_time,
+            token.expiration_time      as token_expiration_time,
+            cert.certificate           as client_cert,
+            cert.certificate_key_hmac  as client_cert_key_hmac,
+            auth.auth_method           as auth_method,
+            auth.mount_path            as auth_mount_path,
+            auth.role_id               as auth_role_id,
+            auth.secret_id_hmac        as auth_secret_id_hmac,
+            auth.kubernetes_role       as auth_kubernetes_role,
+            case
+              when token.token_hmac is null then 'auth-failed'
+              else 'active'
+            end                        as operational_state
+       from credential_vault_store store
+  left join credential_vault_token token
+         on store.public_id = token.store_id
+        and token.status = 'current'
+  left join credential_vault_client_certificate cert
+         on store.public_id = cert.store_id
+  left join credential_vault_auth_method auth
+         on store.public_id = auth.store_id
+      where store.delete_time is null;
+  comment on view credential_vault_store_public is
+    'credential_vault_store_public is a view where each row contains a credential store. '
+    'No encrypted data is returned. This view can be used to retrieve data which will be returned external to boundary.';
+
+commit;
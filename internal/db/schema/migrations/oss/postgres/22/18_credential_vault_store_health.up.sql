@@ -0,0 +1,103 @@
+begin;
+
+  -- credential_vault_store_health_check_enm entries define the checks run
+  -- against a credential_vault_store by the health check job.
+  create table credential_vault_store_health_check_enm (
+    name text primary key
+      constraint only_predefined_health_checks_allowed
+      check (
+        name in ('ca_certificate', 'connectivity', 'token', 'capabilities')
+      )
+  );
+  comment on table credential_vault_store_health_check_enm is
+    'credential_vault_store_health_check_enm is an enumeration table for the health checks run against a Vault credential store.';
+
+  insert into credential_vault_store_health_check_enm (name)
+  values
+    ('ca_certificate'),
+    ('connectivity'),
+    ('token'),
+    ('capabilities');
+
+  create trigger immutable_columns before update on credential_vault_store_health_check_enm
+    for each row execute procedure immutable_columns('name');
+
+  create table credential_vault_store_health (
+    store_id wt_public_id primary key
+      constraint credential_vault_store_fkey
+        references credential_vault_store (public_id)
+        on delete cascade
+        on update cascade,
+    check_time wt_timestamp,
+    status text not null
+      constraint health_status_valid
+        check (status in ('healthy', 'unhealthy')),
+    failed_check text
+      constraint credential_vault_store_health_check_enm_fkey
+        references credential_vault_store_health_check_enm (name)
+        on delete restrict
+        on update cascade,
+    message text,
+    token_expiration_time timestamp with time zone,
+    ca_cert_expiration_time timestamp with time zone,
+    constraint failed_check_set_only_when_unhealthy
+      check ((status = 'unhealthy') = (failed_check is not null))
+  );
+  comment on table credential_vault_store_health is
+    'credential_vault_store_health is a table where each row contains the result of the last health check of a credential_vault_store. '
+    'A credential_vault_store has no row until its first health check completes.';
+
+  -- The public view is recreated to add the result of the last health check.
+  drop view credential_vault_store_public;
+
+     create view credential_vault_store_public as
+     select store.public_id                 as public_id,
+            store.scope_id                  as scope_id,
+            store.name                      as name,
+            store.description               as description,
+            store.create_time               as create_time,
+            store.update_time               as update_time,
+            store.version                   as version,
+            store.vault_address             as vault_address,
+            store.namespace                 as namespace,
+            store.ca_cert                   as ca_cert,
+            store.tls_server_name           as tls_server_name,
+            store.tls_skip_verify           as tls_skip_verify,
+            token.token_hmac                as token_hmac,
+            token.create_time               as token_create_time,
+            token.update_time               as token_update_time,
+            token.last_renewal_time         as token_last_renewal_time,
+            token.expiration_time           as token_expiration_time,
+            cert.certificate                as client_cert,
+            cert.certificate_key_hmac       as client_cert_key_hmac,
+            auth.auth_method                as auth_method,
+            auth.mount_path                 as auth_mount_path,
+            auth.role_id                    as auth_role_id,
+            auth.secret_id_hmac             as auth_secret_id_hmac,
+            auth.kubernetes_role            as auth_kubernetes_role,
+            case
+              when token.token_hmac is null then 'auth-failed'
+              else 'active'
+            end                             as operational_state,
+            health.status                   as health_status,
+            health.check_time               as health_check_time,
+            health.failed_check             as health_failed_check,
+            health.message                  as health_message,
+            health.token_expiration_time    as health_token_expiration_time,
+            health.ca_cert_expiration_time  as health_ca_cert_expiration_time
+       from credential_vault_store store
+  left join credential_vault_token token
+         on store.public_id = token.store_id
+        and token.status = 'current'
+  left join credential_vault_client_certificate cert
+         on store.public_id = cert.store_id
+  left join credential_vault_auth_method auth
+         on store.public_id = auth.store_id
+  left join credential_vault_store_health health
+         on store.public_id = health.store_id
+      where store.delete_time is null;
+  comment on view credential_vault_store_public is
+    'credential_vault_store_public is a view where each row contains a credential store. '
+    'No encrypted data is returned. This view can be used to retrieve data which will be returned external to boundary.';
+
+commit;
@@ -0,0 +1,202 @@
+begin;
+
+  -- auth_cert_method is an auth_method subtype that authenticates clients with
+  -- the x509 certificate presented on a mutual TLS connection to an api
+  -- listener. A certificate is accepted if it chains to one of the CA
+  -- certificates of the auth method.
+  --
+  -- The *_attribute columns map an attribute of the client certificate to a
+  -- field of the account. A null subject_attribute uses the first URI SAN of
+  -- the certificate, or its common name if it has no URI SAN. A null
+  -- name_attribute or email_attribute leaves the field of the account empty.
+  create table auth_cert_method (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null,
+    name wt_name,
+    description wt_description,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    ca_certificates text not null
+      constraint ca_certificates_must_not_be_empty
+        check(length(trim(ca_certificates)) > 0),
+    subject_attribute text
+      constraint subject_attribute_valid
+        check(subject_attribute in ('cn', 'dns_san', 'email_san', 'uri_san')),
+    name_attribute text
+      constraint name_attribute_valid
+        check(name_attribute in ('cn', 'dns_san', 'email_san', 'uri_san')),
+    email_attribute text
+      constraint email_attribute_valid
+        check(email_attribute in ('cn', 'dns_san', 'email_san', 'uri_san')),
+    constraint auth_method_fkey
+      foreign key (scope_id, public_id)
+        references auth_method (scope_id, public_id)
+        on delete cascade
+        on update cascade,
+    constraint auth_cert_method_scope_id_name_uq
+      unique(scope_id, name),
+    constraint auth_cert_method_scope_id_public_id_uq
+      unique(scope_id, public_id)
+  );
+  comment on table auth_cert_method is
+    'auth_cert_method entries are the client certificate auth methods configured for existing scopes.';
+
+  create trigger
+    insert_auth_method_subtype
+  before insert on auth_cert_method
+    for each row execute procedure insert_auth_method_subtype();
+
+  create trigger
+    update_auth_method_subtype
+  before update on auth_cert_method
+    for each row execute procedure update_auth_method_subtype();
+
+  create trigger
+    delete_auth_method_subtype
+  after delete on auth_cert_method
+    for each row execute procedure delete_auth_method_subtype();
+
+  create trigger
+    update_time_column
+  before update on auth_cert_method
+    for each row execute procedure update_time_column();
+
+  create trigger
+    immutable_columns
+  before update on auth_cert_method
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger
+    default_create_time_column
+  before insert on auth_cert_method
+    for each row execute procedure default_create_time();
+
+  create trigger
+    update_version_column
+  after update on auth_cert_method
+    for each row execute procedure update_version_column();
+
+  -- auth_cert_account entries are created the first time a client certificate
+  -- authenticates with an auth_cert_method, or ahead of time to link the
+  -- account to an existing user.
+  create table auth_cert_account (
+    public_id wt_public_id
+      primary key,
+    auth_method_id wt_public_id
+      not null,
+    -- scope_id is set by the insert_auth_cert_account_subtype trigger.
+    scope_id text not null,
+    name wt_name,
+    description wt_description,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    subject text not null
+      constraint subject_must_not_be_empty
+        check(length(trim(subject)) > 0)
+      constraint subject_must_be_less_than_1024_chars
+        check(length(trim(subject)) < 1024),
+    full_name wt_full_name,
+    email wt_email,
+    disabled bool not null default false,
+    constraint auth_cert_method_fkey
+      foreign key (scope_id, auth_method_id)
+        references auth_cert_method (scope_id, public_id)
+        on delete cascade
+        on update cascade,
+    constraint auth_account_fkey
+      foreign key (scope_id, auth_method_id, public_id)
+        references auth_account (scope_id, auth_method_id, public_id)
+        on delete cascade
+        on update cascade,
+    constraint auth_cert_account_auth_method_id_name_uq
+      unique(auth_method_id, name),
+    constraint auth_cert_account_auth_method_id_subject_uq
+      unique(auth_method_id, subject),
+    constraint auth_cert_account_auth_method_id_public_id_uq
+      unique(auth_method_id, public_id)
+  );
+  comment on table auth_cert_account is
+    'auth_cert_account entries are subtypes of auth_account and represent the accounts of client certificate subjects.';
+
+  create function
+    insert_auth_cert_account_subtype()
+    returns trigger
+  as $$
+  begin
+    select auth_method.scope_id
+      into new.scope_id
+      from auth_method
+     where auth_method.public_id = new.auth_method_id;
+
+    insert into auth_account
+      (public_id, auth_method_id, scope_id)
+    values
+      (new.public_id, new.auth_method_id, new.scope_id)
+    on conflict do nothing;
+
+    return new;
+  end;
+  $$ language plpgsql;
+  comment on function insert_auth_cert_account_subtype() is
+    'insert_auth_cert_account_subtype() is a before insert trigger function that inserts the base auth_account of an auth_cert_account';
+
+  create trigger
+    insert_auth_cert_account_subtype
+  before insert on auth_cert_account
+    for each row execute procedure insert_auth_cert_account_subtype();
+
+  create trigger
+    update_auth_account_subtype_disabled
+  before
+  insert or update of disabled on auth_cert_account
+    for each row execute procedure update_auth_account_subtype_disabled();
+
+  create trigger
+    update_time_column
+  before update on auth_cert_account
+    for each row execute procedure update_time_column();
+
+  create trigger
+    immutable_columns
+  before update on auth_cert_account
+    for each row execute procedure immutable_columns('public_id', 'auth_method_id', 'scope_id', 'create_time', 'subject');
+
+  create trigger
+    default_create_time_column
+  before insert on auth_cert_account
+    for each row execute procedure default_create_time();
+
+  create trigger
+    update_version_column
+  after update on auth_cert_account
+    for each row execute procedure update_version_column();
+
+  -- auth_cert_method_with_is_primary is used for reading a cert auth method
+  -- with a bool to determine if it's the scope's primary auth method.
+  create view auth_cert_method_with_is_primary as
+  select am.public_id,
+         am.scope_id,
+         am.name,
+         am.description,
+         am.create_time,
+         am.update_time,
+         am.version,
+         am.ca_certificates,
+         am.subject_attribute,
+         am.name_attribute,
+         am.email_attribute,
+         case when s.primary_auth_method_id is not null then
+           true
+         else false end
+           as is_primary_auth_method
+    from auth_cert_method am
+    left outer join iam_scope s
+      on am.public_id = s.primary_auth_method_id;
+  comment on view auth_cert_method_with_is_primary is
+    'auth_cert_method_with_is_primary is a view for reading a cert auth method with a bool to determine if it is the primary auth method of its scope';
+
+commit;
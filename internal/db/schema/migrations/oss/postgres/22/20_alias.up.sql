@@ -0,0 +1,75 @@
+begin;
+
+  -- alias maps a globally unique, DNS-style value such as prod-db.payments
+  -- to a target and optionally to one of the target's hosts. The value can
+  -- be used instead of the target's id when authorizing a session. Aliases
+  -- live in any scope; the scope only determines who can manage the alias.
+  create table alias (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      constraint iam_scope_fkey
+        references iam_scope(public_id)
+        on delete cascade
+        on update cascade,
+    name text,
+    description text,
+    value text not null
+      constraint alias_value_uq
+        unique
+      constraint value_must_be_dns_like
+        check(
+          length(value) <= 253
+          and
+          value ~ '^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$'
+        ),
+    destination_id wt_public_id not null
+      constraint target_fkey
+        references target(public_id)
+        on delete cascade
+        on update cascade,
+    host_id wt_public_id
+      constraint host_fkey
+        references host(public_id)
+        on delete set null
+        on update cascade,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    constraint alias_scope_id_name_uq
+      unique(scope_id, name)
+  );
+  comment on table alias is
+    'alias is a globally unique DNS-style name for a target and optionally one of its hosts.';
+
+  create index alias_destination_id_ix
+    on alias (destination_id);
+
+  create trigger
+    update_version_column
+  after update on alias
+    for each row execute procedure update_version_column();
+
+  create trigger
+    update_time_column
+  before update on alias
+    for each row execute procedure update_time_column();
+
+  create trigger
+    default_create_time_column
+  before
+  insert on alias
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on alias
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  insert into oplog_ticket (name, version)
+  values
+    ('alias', 1);
+
+commit;
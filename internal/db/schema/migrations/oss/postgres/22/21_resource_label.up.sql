@@ -0,0 +1,55 @@
+begin;
+
+  -- Labels are key/value pairs used to find targets, host sets and hosts
+  -- without knowing their ids. A resource has at most one value per key.
+  -- Like server tags, keys and values use the wt_tagpair domain so they are
+  -- always trimmed and lowercase.
+  create table target_label (
+    target_id wt_public_id not null
+      constraint target_fkey
+        references target(public_id)
+        on delete cascade
+        on update cascade,
+    key wt_tagpair,
+    value wt_tagpair,
+    primary key(target_id, key)
+  );
+  comment on table target_label is
+    'target_label is a key/value pair attached to a target.';
+
+  create index target_label_key_value_ix
+    on target_label (key, value);
+
+  create table host_set_label (
+    host_set_id wt_public_id not null
+      constraint host_set_fkey
+        references host_set(public_id)
+        on delete cascade
+        on update cascade,
+    key wt_tagpair,
+    value wt_tagpair,
+    primary key(host_set_id, key)
+  );
+  comment on table host_set_label is
+    'host_set_label is a key/value pair attached to a host set.';
+
+  create index host_set_label_key_value_ix
+    on host_set_label (key, value);
+
+  create table host_label (
+    host_id wt_public_id not null
+      constraint host_fkey
+        references host(public_id)
+        on delete cascade
+        on update cascade,
+    key wt_tagpair,
+    value wt_tagpair,
+    primary key(host_id, key)
+  );
+  comment on table host_label is
+    'host_label is a key/value pair attached to a host.';
+
+  create index host_label_key_value_ix
+    on host_label (key, value);
+
+commit;
@@ -0,0 +1,63 @@
+begin;
+
+  -- worker_selection_strategy is the strategy the controller uses to order the
+  -- workers which can handle a session of the target. Null leaves the workers
+  -- unordered.
+  alter table target_tcp
+    add column worker_selection_strategy text
+      constraint worker_selection_strategy_must_be_valid
+      check(worker_selection_strategy in ('random', 'least-connections', 'lowest-latency', 'user-affinity'));
+
+  alter table target_kubernetes
+    add column worker_selection_strategy text
+      constraint worker_selection_strategy_must_be_valid
+      check(worker_selection_strategy in ('random', 'least-connections', 'lowest-latency', 'user-affinity'));
+
+  -- active_connection_count is the number of open connections a worker
+  -- reported in its last status request. It is only set for workers.
+  alter table server
+    add column active_connection_count int not null default 0
+      constraint active_connection_count_must_not_be_negative
+      check(active_connection_count >= 0);
+
+  -- Replaces the view created in 22/03_target_connection_limits.up.sql to
+  -- include the worker_selection_strategy column.
+  create or replace view target_all_subtypes
+  as
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'tcp' as type,
+         connection_idle_timeout_seconds,
+         max_concurrent_connections,
+         max_bytes_per_session,
+         worker_selection_strategy
+    from target_tcp
+   union
+  select public_id,
+         scope_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         'kubernetes' as type,
+         connection_idle_timeout_seconds,
+         max_concurrent_connections,
+         max_bytes_per_session,
+         worker_selection_strategy
+    from target_kubernetes;
+
+commit;
@@ -0,0 +1,47 @@
+begin;
+
+  -- session_egress_credential holds the egress credentials issued for a
+  -- session. Dynamic credentials are only issued once per session, so they are
+  -- kept to hand to every worker which looks up the session. The credentials
+  -- are encrypted with the database key of the scope of the session and are
+  -- deleted with the session.
+  create table session_egress_credential (
+    session_id wt_public_id primary key
+      constraint session_fkey
+        references session (public_id)
+        on delete cascade
+        on update cascade,
+    create_time wt_timestamp,
+    credentials bytea not null
+      constraint credentials_must_not_be_empty
+      check(length(credentials) > 0),
+    key_id text not null
+      constraint key_id_must_not_be_empty
+      check(length(trim(key_id)) > 0)
+  );
+  comment on table session_egress_credential is
+    'session_egress_credential holds the encrypted egress credentials issued for a session';
+
+  create trigger default_create_time_column before insert on session_egress_credential
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on session_egress_credential
+    for each row execute procedure immutable_columns('session_id', 'create_time', 'credentials', 'key_id');
+
+  -- The credentials are not needed once the session is over.
+  create function delete_session_egress_credential()
+    returns trigger
+  as $$
+  begin
+    if new.state = 'terminated' then
+      delete from session_egress_credential
+       where session_id = new.session_id;
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger delete_session_egress_credential after insert on session_state
+    for each row execute procedure delete_session_egress_credential();
+
+commit;
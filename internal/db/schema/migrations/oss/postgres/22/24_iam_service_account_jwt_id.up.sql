@@ -0,0 +1,32 @@
+begin;
+
+  -- iam_service_account_jwt_id holds the "jti" (JWT ID) claims of the JWTs a
+  -- service account has authenticated with, so a JWT can only be used once.
+  -- Rows are kept until the JWT can no longer be presented and are deleted
+  -- with the service account.
+  create table iam_service_account_jwt_id (
+    service_account_id wt_public_id not null
+      constraint iam_service_account_fkey
+        references iam_service_account (public_id)
+        on delete cascade
+        on update cascade,
+    jwt_id text not null
+      constraint jwt_id_must_not_be_empty
+      check(length(trim(jwt_id)) > 0),
+    create_time wt_timestamp,
+    expiration_time timestamp with time zone not null,
+    primary key (service_account_id, jwt_id)
+  );
+  comment on table iam_service_account_jwt_id is
+    'iam_service_account_jwt_id holds the ids of the JWTs a service account has authenticated with';
+
+  create trigger default_create_time_column before insert on iam_service_account_jwt_id
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on iam_service_account_jwt_id
+    for each row execute procedure immutable_columns('service_account_id', 'jwt_id', 'create_time', 'expiration_time');
+
+  create index iam_service_account_jwt_id_expiration_time_ix
+    on iam_service_account_jwt_id (expiration_time);
+
+commit;
@@ -0,0 +1,9 @@
+begin;
+
+  -- Controllers poll for sessions which were recently canceled so they can
+  -- push the terminations to the workers connected to them.
+  create index session_state_canceling_start_time_ix
+    on session_state (start_time)
+    where state = 'canceling';
+
+commit;
@@ -0,0 +1,23 @@
+begin;
+
+  -- Service accounts authenticate as the anonymous user, so the
+  -- authenticate action must be granted to u_anon. New installs get the
+  -- grant in the generated "Login and Default Grants" role. This adds it to
+  -- the same role in existing installs: any global role assigned to u_anon
+  -- that grants authenticate on all auth methods.
+  insert into iam_role_grant
+    (role_id, canonical_grant, raw_grant)
+  select distinct r.public_id,
+         'id=*;type=service-account;actions=authenticate',
+         'id=*;type=service-account;actions=authenticate'
+    from iam_role r
+    join iam_role_grant g
+      on g.role_id = r.public_id
+    join iam_user_role ur
+      on ur.role_id = r.public_id
+   where r.scope_id = 'global'
+     and ur.principal_id = 'u_anon'
+     and g.canonical_grant = 'id=*;type=auth-method;actions=authenticate,list'
+  on conflict do nothing;
+
+commit;
@@ -0,0 +1,24 @@
+begin;
+
+  -- Denied authorize-session requests are recorded in the activity history
+  -- of the user by the controller, next to the sessions it authorized which
+  -- are recorded by the insert_session_user_activity trigger.
+  alter table iam_user_activity_type_enm
+    drop constraint only_predefined_user_activity_types_allowed;
+
+  alter table iam_user_activity_type_enm
+    add constraint only_predefined_user_activity_types_allowed
+      check (
+        string in (
+          'authentication-succeeded',
+          'authentication-failed',
+          'session-authorized',
+          'session-authorization-denied'
+        )
+      );
+
+  insert into iam_user_activity_type_enm (string)
+  values
+    ('session-authorization-denied');
+
+commit;
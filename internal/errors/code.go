@@ -41,6 +41,7 @@ const (
 	JobAlreadyRunning        Code = 117 // JobAlreadyRunning represents that a Job is already running when an attempt to run again was made
 	SubtypeAlreadyRegistered Code = 118 // SubtypeAlreadyRegistered represents that a value has already been registered in the subtype registry system.
 
+	AccountDisabled    Code = 197 // AccountDisabled represents an error that means the account or its user is disabled.
 	AuthAttemptExpired Code = 198 // AuthAttemptExpired represents an expired authentication attempt
 	AuthMethodInactive Code = 199 // AuthMethodInactive represents an error that means the auth method is not active.
 
@@ -59,6 +60,31 @@ const (
 	// new passwords are equal.
 	PasswordsEqual Code = 203
 
+	// PasswordTooWeak results from attempting to set a password which does not
+	// satisfy the password policy of the auth method.
+	PasswordTooWeak Code = 204
+
+	// PasswordReused results from attempting to set a password which matches
+	// one of the account's previous passwords.
+	PasswordReused Code = 205
+
+	// PasswordExpired is returned from Authenticate when the account's
+	// password is older than the maximum password age of the auth method and
+	// no new password was provided.
+	PasswordExpired Code = 206
+
+	// AccountLocked is returned when authenticating with an account which is
+	// locked because of too many failed authentication attempts.
+	AccountLocked Code = 207
+
+	// TotpMfaDisabled is returned when enrolling an account in TOTP in an
+	// auth method with TOTP MFA disabled.
+	TotpMfaDisabled Code = 208
+
+	// TotpAlreadyEnrolled is returned when enrolling an account in TOTP which
+	// already has a confirmed TOTP enrollment.
+	TotpAlreadyEnrolled Code = 209
+
 	Encrypt Code = 300 // Encrypt represents an error occurred during the underlying encryption process
 	Decrypt Code = 301 // Decrypt represents an error occurred during the underlying decryption process
 	Encode  Code = 302 // Encode represents an error occurred during the underlying encoding/marshaling process
@@ -107,6 +133,7 @@ const (
 	VaultTokenNotRenewable        Code = 3012 // VaultTokenNotRenewable represents an error for a Vault token that is not renewable
 	VaultTokenMissingCapabilities Code = 3013 // VaultTokenMissingCapabilities represents an error for a Vault token that is missing capabilities
 	VaultCredentialRequest        Code = 3014 // VaultCredentialRequest represents an error returned from Vault when retrieving a credential
+	VaultInvalidCredentialMapping Code = 3015 // VaultInvalidCredentialMapping represents an error for a Vault secret that does not match the credential mapping of the library
 
 	// OIDC authentication provided errors
 	OidcProviderCallbackError Code = 4000 // OidcProviderCallbackError represents an error that is passed by the OIDC provider to the callback endpoint
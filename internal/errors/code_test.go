@@ -137,6 +137,11 @@ func TestCode_Both_String_Info(t *testing.T) {
 			c:    AuthMethodInactive,
 			want: AuthMethodInactive,
 		},
+		{
+			name: "AccountDisabled",
+			c:    AccountDisabled,
+			want: AccountDisabled,
+		},
 		{
 			name: "AuthAttemptExpired",
 			c:    AuthAttemptExpired,
@@ -162,6 +167,36 @@ func TestCode_Both_String_Info(t *testing.T) {
 			c:    PasswordsEqual,
 			want: PasswordsEqual,
 		},
+		{
+			name: "PasswordTooWeak",
+			c:    PasswordTooWeak,
+			want: PasswordTooWeak,
+		},
+		{
+			name: "PasswordReused",
+			c:    PasswordReused,
+			want: PasswordReused,
+		},
+		{
+			name: "PasswordExpired",
+			c:    PasswordExpired,
+			want: PasswordExpired,
+		},
+		{
+			name: "AccountLocked",
+			c:    AccountLocked,
+			want: AccountLocked,
+		},
+		{
+			name: "TotpMfaDisabled",
+			c:    TotpMfaDisabled,
+			want: TotpMfaDisabled,
+		},
+		{
+			name: "TotpAlreadyEnrolled",
+			c:    TotpAlreadyEnrolled,
+			want: TotpAlreadyEnrolled,
+		},
 		{
 			name: "Encrypt",
 			c:    Encrypt,
@@ -282,6 +317,11 @@ func TestCode_Both_String_Info(t *testing.T) {
 			c:    VaultCredentialRequest,
 			want: VaultCredentialRequest,
 		},
+		{
+			name: "VaultInvalidCredentialMapping",
+			c:    VaultInvalidCredentialMapping,
+			want: VaultInvalidCredentialMapping,
+		},
 		{
 			name: "OidcProviderCallbackError",
 			c:    OidcProviderCallbackError,
@@ -76,6 +76,10 @@ var errorCodeInfo = map[Code]Info{
 		Message: "authentication method is inactive",
 		Kind:    State,
 	},
+	AccountDisabled: {
+		Message: "account is disabled",
+		Kind:    State,
+	},
 	AuthAttemptExpired: {
 		Message: "authentication attempt has expired",
 		Kind:    State,
@@ -116,6 +120,30 @@ var errorCodeInfo = map[Code]Info{
 		Message: "old and new password are equal",
 		Kind:    Password,
 	},
+	PasswordTooWeak: {
+		Message: "does not satisfy the password policy",
+		Kind:    Password,
+	},
+	PasswordReused: {
+		Message: "matches a previous password",
+		Kind:    Password,
+	},
+	PasswordExpired: {
+		Message: "password has expired",
+		Kind:    Password,
+	},
+	AccountLocked: {
+		Message: "account is locked",
+		Kind:    Password,
+	},
+	TotpMfaDisabled: {
+		Message: "totp mfa is disabled",
+		Kind:    Password,
+	},
+	TotpAlreadyEnrolled: {
+		Message: "account is already enrolled in totp",
+		Kind:    Password,
+	},
 	Encrypt: {
 		Message: "error occurred during encrypt",
 		Kind:    Encryption,
@@ -228,6 +256,10 @@ var errorCodeInfo = map[Code]Info{
 		Message: "request for a new credential from vault failed",
 		Kind:    External,
 	},
+	VaultInvalidCredentialMapping: {
+		Message: "vault secret does not match the credential mapping of the library",
+		Kind:    External,
+	},
 	OidcProviderCallbackError: {
 		Message: "oidc provider callback error",
 		Kind:    External,
@@ -48,6 +48,20 @@ type AuthMethodServiceClient interface {
 	ChangeState(ctx context.Context, in *ChangeStateRequest, opts ...grpc.CallOption) (*ChangeStateResponse, error)
 	// Authenticate validates credentials provided and returns an Auth Token.
 	Authenticate(ctx context.Context, in *AuthenticateRequest, opts ...grpc.CallOption) (*AuthenticateResponse, error)
+	// RevokeAuthMethodTokens deletes all Auth Tokens issued for Accounts of the
+	// specified Auth Method. Sessions created with a revoked Auth Token are
+	// canceled. If cancel_sessions is set, all other non-terminated Sessions of
+	// the affected Users are canceled as well.
+	RevokeAuthMethodTokens(ctx context.Context, in *RevokeAuthMethodTokensRequest, opts ...grpc.CallOption) (*RevokeAuthMethodTokensResponse, error)
+	// RotateScimToken generates a new SCIM token for the specified Auth Method,
+	// replacing its previous token. The SCIM endpoint at /scim/v2 accepts the
+	// token as a bearer token to provision the Users and Groups of the Auth
+	// Method's scope. The token is only returned by this request.
+	RotateScimToken(ctx context.Context, in *RotateScimTokenRequest, opts ...grpc.CallOption) (*RotateScimTokenResponse, error)
+	// DeleteScimToken deletes the SCIM token of the specified Auth Method,
+	// which disables its SCIM endpoint. Users and Groups provisioned through
+	// the endpoint are kept.
+	DeleteScimToken(ctx context.Context, in *DeleteScimTokenRequest, opts ...grpc.CallOption) (*DeleteScimTokenResponse, error)
 }
 
 type authMethodServiceClient struct {
@@ -121,6 +135,33 @@ func (c *authMethodServiceClient) Authenticate(ctx context.Context, in *Authenti
 	return out, nil
 }
 
+func (c *authMethodServiceClient) RevokeAuthMethodTokens(ctx context.Context, in *RevokeAuthMethodTokensRequest, opts ...grpc.CallOption) (*RevokeAuthMethodTokensResponse, error) {
+	out := new(RevokeAuthMethodTokensResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.AuthMethodService/RevokeAuthMethodTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authMethodServiceClient) RotateScimToken(ctx context.Context, in *RotateScimTokenRequest, opts ...grpc.CallOption) (*RotateScimTokenResponse, error) {
+	out := new(RotateScimTokenResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.AuthMethodService/RotateScimToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authMethodServiceClient) DeleteScimToken(ctx context.Context, in *DeleteScimTokenRequest, opts ...grpc.CallOption) (*DeleteScimTokenResponse, error) {
+	out := new(DeleteScimTokenResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.AuthMethodService/DeleteScimToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthMethodServiceServer is the server API for AuthMethodService service.
 // All implementations must embed UnimplementedAuthMethodServiceServer
 // for forward compatibility
@@ -155,6 +196,20 @@ type AuthMethodServiceServer interface {
 	ChangeState(context.Context, *ChangeStateRequest) (*ChangeStateResponse, error)
 	// Authenticate validates credentials provided and returns an Auth Token.
 	Authenticate(context.Context, *AuthenticateRequest) (*AuthenticateResponse, error)
+	// RevokeAuthMethodTokens deletes all Auth Tokens issued for Accounts of the
+	// specified Auth Method. Sessions created with a revoked Auth Token are
+	// canceled. If cancel_sessions is set, all other non-terminated Sessions of
+	// the affected Users are canceled as well.
+	RevokeAuthMethodTokens(context.Context, *RevokeAuthMethodTokensRequest) (*RevokeAuthMethodTokensResponse, error)
+	// RotateScimToken generates a new SCIM token for the specified Auth Method,
+	// replacing its previous token. The SCIM endpoint at /scim/v2 accepts the
+	// token as a bearer token to provision the Users and Groups of the Auth
+	// Method's scope. The token is only returned by this request.
+	RotateScimToken(context.Context, *RotateScimTokenRequest) (*RotateScimTokenResponse, error)
+	// DeleteScimToken deletes the SCIM token of the specified Auth Method,
+	// which disables its SCIM endpoint. Users and Groups provisioned through
+	// the endpoint are kept.
+	DeleteScimToken(context.Context, *DeleteScimTokenRequest) (*DeleteScimTokenResponse, error)
 	mustEmbedUnimplementedAuthMethodServiceServer()
 }
 
@@ -183,6 +238,15 @@ func (UnimplementedAuthMethodServiceServer) ChangeState(context.Context, *Change
 func (UnimplementedAuthMethodServiceServer) Authenticate(context.Context, *AuthenticateRequest) (*AuthenticateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Authenticate not implemented")
 }
+func (UnimplementedAuthMethodServiceServer) RevokeAuthMethodTokens(context.Context, *RevokeAuthMethodTokensRequest) (*RevokeAuthMethodTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAuthMethodTokens not implemented")
+}
+func (UnimplementedAuthMethodServiceServer) RotateScimToken(context.Context, *RotateScimTokenRequest) (*RotateScimTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateScimToken not implemented")
+}
+func (UnimplementedAuthMethodServiceServer) DeleteScimToken(context.Context, *DeleteScimTokenRequest) (*DeleteScimTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteScimToken not implemented")
+}
 func (UnimplementedAuthMethodServiceServer) mustEmbedUnimplementedAuthMethodServiceServer() {}
 
 // UnsafeAuthMethodServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -322,6 +386,60 @@ func _AuthMethodService_Authenticate_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthMethodService_RevokeAuthMethodTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAuthMethodTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthMethodServiceServer).RevokeAuthMethodTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.AuthMethodService/RevokeAuthMethodTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthMethodServiceServer).RevokeAuthMethodTokens(ctx, req.(*RevokeAuthMethodTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthMethodService_RotateScimToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateScimTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthMethodServiceServer).RotateScimToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.AuthMethodService/RotateScimToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthMethodServiceServer).RotateScimToken(ctx, req.(*RotateScimTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthMethodService_DeleteScimToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteScimTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthMethodServiceServer).DeleteScimToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.AuthMethodService/DeleteScimToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthMethodServiceServer).DeleteScimToken(ctx, req.(*DeleteScimTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AuthMethodService_ServiceDesc is the grpc.ServiceDesc for AuthMethodService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -357,6 +475,18 @@ var AuthMethodService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Authenticate",
 			Handler:    _AuthMethodService_Authenticate_Handler,
 		},
+		{
+			MethodName: "RevokeAuthMethodTokens",
+			Handler:    _AuthMethodService_RevokeAuthMethodTokens_Handler,
+		},
+		{
+			MethodName: "RotateScimToken",
+			Handler:    _AuthMethodService_RotateScimToken_Handler,
+		},
+		{
+			MethodName: "DeleteScimToken",
+			Handler:    _AuthMethodService_DeleteScimToken_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/auth_method_service.proto",
@@ -27,6 +27,11 @@ type AuthTokenServiceClient interface {
 	// the Auth Tokens being listed.  If the scope id is missing, malformed, or
 	// referencing a non existing resource, an error is returned.
 	ListAuthTokens(ctx context.Context, in *ListAuthTokensRequest, opts ...grpc.CallOption) (*ListAuthTokensResponse, error)
+	// CreateAuthToken creates a derived Auth Token from the Auth Token used for
+	// the request. The derived Auth Token belongs to the same account and is
+	// restricted to the provided grants and scopes. Derived Auth Tokens can not
+	// be used to create further Auth Tokens.
+	CreateAuthToken(ctx context.Context, in *CreateAuthTokenRequest, opts ...grpc.CallOption) (*CreateAuthTokenResponse, error)
 	// DeleteAuthToken removes a Auth Token from Boundary. If the provided
 	// Auth Token id is malformed or not provided an error is returned.
 	DeleteAuthToken(ctx context.Context, in *DeleteAuthTokenRequest, opts ...grpc.CallOption) (*DeleteAuthTokenResponse, error)
@@ -58,6 +63,15 @@ func (c *authTokenServiceClient) ListAuthTokens(ctx context.Context, in *ListAut
 	return out, nil
 }
 
+func (c *authTokenServiceClient) CreateAuthToken(ctx context.Context, in *CreateAuthTokenRequest, opts ...grpc.CallOption) (*CreateAuthTokenResponse, error) {
+	out := new(CreateAuthTokenResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.AuthTokenService/CreateAuthToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authTokenServiceClient) DeleteAuthToken(ctx context.Context, in *DeleteAuthTokenRequest, opts ...grpc.CallOption) (*DeleteAuthTokenResponse, error) {
 	out := new(DeleteAuthTokenResponse)
 	err := c.cc.Invoke(ctx, "/controller.api.services.v1.AuthTokenService/DeleteAuthToken", in, out, opts...)
@@ -80,6 +94,11 @@ type AuthTokenServiceServer interface {
 	// the Auth Tokens being listed.  If the scope id is missing, malformed, or
 	// referencing a non existing resource, an error is returned.
 	ListAuthTokens(context.Context, *ListAuthTokensRequest) (*ListAuthTokensResponse, error)
+	// CreateAuthToken creates a derived Auth Token from the Auth Token used for
+	// the request. The derived Auth Token belongs to the same account and is
+	// restricted to the provided grants and scopes. Derived Auth Tokens can not
+	// be used to create further Auth Tokens.
+	CreateAuthToken(context.Context, *CreateAuthTokenRequest) (*CreateAuthTokenResponse, error)
 	// DeleteAuthToken removes a Auth Token from Boundary. If the provided
 	// Auth Token id is malformed or not provided an error is returned.
 	DeleteAuthToken(context.Context, *DeleteAuthTokenRequest) (*DeleteAuthTokenResponse, error)
@@ -96,6 +115,9 @@ func (UnimplementedAuthTokenServiceServer) GetAuthToken(context.Context, *GetAut
 func (UnimplementedAuthTokenServiceServer) ListAuthTokens(context.Context, *ListAuthTokensRequest) (*ListAuthTokensResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAuthTokens not implemented")
 }
+func (UnimplementedAuthTokenServiceServer) CreateAuthToken(context.Context, *CreateAuthTokenRequest) (*CreateAuthTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAuthToken not implemented")
+}
 func (UnimplementedAuthTokenServiceServer) DeleteAuthToken(context.Context, *DeleteAuthTokenRequest) (*DeleteAuthTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteAuthToken not implemented")
 }
@@ -148,6 +170,24 @@ func _AuthTokenService_ListAuthTokens_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthTokenService_CreateAuthToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAuthTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthTokenServiceServer).CreateAuthToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.AuthTokenService/CreateAuthToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthTokenServiceServer).CreateAuthToken(ctx, req.(*CreateAuthTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AuthTokenService_DeleteAuthToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteAuthTokenRequest)
 	if err := dec(in); err != nil {
@@ -181,6 +221,10 @@ var AuthTokenService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListAuthTokens",
 			Handler:    _AuthTokenService_ListAuthTokens_Handler,
 		},
+		{
+			MethodName: "CreateAuthToken",
+			Handler:    _AuthTokenService_CreateAuthToken_Handler,
+		},
 		{
 			MethodName: "DeleteAuthToken",
 			Handler:    _AuthTokenService_DeleteAuthToken_Handler,
@@ -44,6 +44,15 @@ type ScopeServiceClient interface {
 	// DeleteScope remotes a Scope and all child resources from Boundary. If the
 	// provided Scope IDs are malformed or not provided an error is returned.
 	DeleteScope(ctx context.Context, in *DeleteScopeRequest, opts ...grpc.CallOption) (*DeleteScopeResponse, error)
+	// RevokeScopeTokens deletes all Auth Tokens of the Users in the specified
+	// Scope. Sessions created with a revoked Auth Token are canceled. If
+	// cancel_sessions is set, all other non-terminated Sessions of the affected
+	// Users are canceled as well.
+	RevokeScopeTokens(ctx context.Context, in *RevokeScopeTokensRequest, opts ...grpc.CallOption) (*RevokeScopeTokensResponse, error)
+	// CancelScopeSessions cancels all non-terminated Sessions in the specified
+	// Scope and its child Scopes, optionally restricted to the Sessions matching
+	// a filter. The Sessions are canceled in a single transaction.
+	CancelScopeSessions(ctx context.Context, in *CancelScopeSessionsRequest, opts ...grpc.CallOption) (*CancelScopeSessionsResponse, error)
 }
 
 type scopeServiceClient struct {
@@ -99,6 +108,24 @@ func (c *scopeServiceClient) DeleteScope(ctx context.Context, in *DeleteScopeReq
 	return out, nil
 }
 
+func (c *scopeServiceClient) RevokeScopeTokens(ctx context.Context, in *RevokeScopeTokensRequest, opts ...grpc.CallOption) (*RevokeScopeTokensResponse, error) {
+	out := new(RevokeScopeTokensResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/RevokeScopeTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scopeServiceClient) CancelScopeSessions(ctx context.Context, in *CancelScopeSessionsRequest, opts ...grpc.CallOption) (*CancelScopeSessionsResponse, error) {
+	out := new(CancelScopeSessionsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/CancelScopeSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ScopeServiceServer is the server API for ScopeService service.
 // All implementations must embed UnimplementedScopeServiceServer
 // for forward compatibility
@@ -129,6 +156,15 @@ type ScopeServiceServer interface {
 	// DeleteScope remotes a Scope and all child resources from Boundary. If the
 	// provided Scope IDs are malformed or not provided an error is returned.
 	DeleteScope(context.Context, *DeleteScopeRequest) (*DeleteScopeResponse, error)
+	// RevokeScopeTokens deletes all Auth Tokens of the Users in the specified
+	// Scope. Sessions created with a revoked Auth Token are canceled. If
+	// cancel_sessions is set, all other non-terminated Sessions of the affected
+	// Users are canceled as well.
+	RevokeScopeTokens(context.Context, *RevokeScopeTokensRequest) (*RevokeScopeTokensResponse, error)
+	// CancelScopeSessions cancels all non-terminated Sessions in the specified
+	// Scope and its child Scopes, optionally restricted to the Sessions matching
+	// a filter. The Sessions are canceled in a single transaction.
+	CancelScopeSessions(context.Context, *CancelScopeSessionsRequest) (*CancelScopeSessionsResponse, error)
 	mustEmbedUnimplementedScopeServiceServer()
 }
 
@@ -151,6 +187,12 @@ func (UnimplementedScopeServiceServer) UpdateScope(context.Context, *UpdateScope
 func (UnimplementedScopeServiceServer) DeleteScope(context.Context, *DeleteScopeRequest) (*DeleteScopeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteScope not implemented")
 }
+func (UnimplementedScopeServiceServer) RevokeScopeTokens(context.Context, *RevokeScopeTokensRequest) (*RevokeScopeTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeScopeTokens not implemented")
+}
+func (UnimplementedScopeServiceServer) CancelScopeSessions(context.Context, *CancelScopeSessionsRequest) (*CancelScopeSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScopeSessions not implemented")
+}
 func (UnimplementedScopeServiceServer) mustEmbedUnimplementedScopeServiceServer() {}
 
 // UnsafeScopeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -254,6 +296,42 @@ func _ScopeService_DeleteScope_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ScopeService_RevokeScopeTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeScopeTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).RevokeScopeTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/RevokeScopeTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).RevokeScopeTokens(ctx, req.(*RevokeScopeTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScopeService_CancelScopeSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScopeSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).CancelScopeSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/CancelScopeSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).CancelScopeSessions(ctx, req.(*CancelScopeSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ScopeService_ServiceDesc is the grpc.ServiceDesc for ScopeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -281,6 +359,14 @@ var ScopeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteScope",
 			Handler:    _ScopeService_DeleteScope_Handler,
 		},
+		{
+			MethodName: "RevokeScopeTokens",
+			Handler:    _ScopeService_RevokeScopeTokens_Handler,
+		},
+		{
+			MethodName: "CancelScopeSessions",
+			Handler:    _ScopeService_CancelScopeSessions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/scope_service.proto",
@@ -145,6 +145,10 @@ type TargetServiceClient interface {
 	// Credential Source is attempted to be removed from the Target when the
 	// Target does not have the Credential Source.
 	RemoveTargetCredentialSources(ctx context.Context, in *RemoveTargetCredentialSourcesRequest, opts ...grpc.CallOption) (*RemoveTargetCredentialSourcesResponse, error)
+	// CancelTargetSessions cancels all non-terminated Sessions of the specified
+	// Target, optionally restricted to the Sessions matching a filter. The
+	// Sessions are canceled in a single transaction.
+	CancelTargetSessions(ctx context.Context, in *CancelTargetSessionsRequest, opts ...grpc.CallOption) (*CancelTargetSessionsResponse, error)
 }
 
 type targetServiceClient struct {
@@ -320,6 +324,15 @@ func (c *targetServiceClient) RemoveTargetCredentialSources(ctx context.Context,
 	return out, nil
 }
 
+func (c *targetServiceClient) CancelTargetSessions(ctx context.Context, in *CancelTargetSessionsRequest, opts ...grpc.CallOption) (*CancelTargetSessionsResponse, error) {
+	out := new(CancelTargetSessionsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.TargetService/CancelTargetSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TargetServiceServer is the server API for TargetService service.
 // All implementations must embed UnimplementedTargetServiceServer
 // for forward compatibility
@@ -451,6 +464,10 @@ type TargetServiceServer interface {
 	// Credential Source is attempted to be removed from the Target when the
 	// Target does not have the Credential Source.
 	RemoveTargetCredentialSources(context.Context, *RemoveTargetCredentialSourcesRequest) (*RemoveTargetCredentialSourcesResponse, error)
+	// CancelTargetSessions cancels all non-terminated Sessions of the specified
+	// Target, optionally restricted to the Sessions matching a filter. The
+	// Sessions are canceled in a single transaction.
+	CancelTargetSessions(context.Context, *CancelTargetSessionsRequest) (*CancelTargetSessionsResponse, error)
 	mustEmbedUnimplementedTargetServiceServer()
 }
 
@@ -512,6 +529,9 @@ func (UnimplementedTargetServiceServer) SetTargetCredentialSources(context.Conte
 func (UnimplementedTargetServiceServer) RemoveTargetCredentialSources(context.Context, *RemoveTargetCredentialSourcesRequest) (*RemoveTargetCredentialSourcesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveTargetCredentialSources not implemented")
 }
+func (UnimplementedTargetServiceServer) CancelTargetSessions(context.Context, *CancelTargetSessionsRequest) (*CancelTargetSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTargetSessions not implemented")
+}
 func (UnimplementedTargetServiceServer) mustEmbedUnimplementedTargetServiceServer() {}
 
 // UnsafeTargetServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -849,6 +869,24 @@ func _TargetService_RemoveTargetCredentialSources_Handler(srv interface{}, ctx c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TargetService_CancelTargetSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTargetSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TargetServiceServer).CancelTargetSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.TargetService/CancelTargetSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TargetServiceServer).CancelTargetSessions(ctx, req.(*CancelTargetSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TargetService_ServiceDesc is the grpc.ServiceDesc for TargetService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -928,6 +966,10 @@ var TargetService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveTargetCredentialSources",
 			Handler:    _TargetService_RemoveTargetCredentialSources_Handler,
 		},
+		{
+			MethodName: "CancelTargetSessions",
+			Handler:    _TargetService_CancelTargetSessions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/target_service.proto",
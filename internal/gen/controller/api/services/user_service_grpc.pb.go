@@ -66,6 +66,20 @@ type UserServiceClient interface {
 	// will be removed from. If the provided Account ids is not associated with the
 	// provided User, an error is returned.
 	RemoveUserAccounts(ctx context.Context, in *RemoveUserAccountsRequest, opts ...grpc.CallOption) (*RemoveUserAccountsResponse, error)
+	// RevokeUserTokens deletes all Auth Tokens of the specified User. Sessions
+	// created with a revoked Auth Token are canceled. If cancel_sessions is set,
+	// all other non-terminated Sessions of the User are canceled as well.
+	RevokeUserTokens(ctx context.Context, in *RevokeUserTokensRequest, opts ...grpc.CallOption) (*RevokeUserTokensResponse, error)
+	// ReadUserActivity returns the authentication and session authorization
+	// history of the specified User, most recent first. The history can be
+	// restricted to a time range and to the activities which happened in a
+	// Scope and its child Scopes. If the User does not exist or the time range
+	// is invalid an error is returned.
+	ReadUserActivity(ctx context.Context, in *ReadUserActivityRequest, opts ...grpc.CallOption) (*ReadUserActivityResponse, error)
+	// CancelUserSessions cancels all non-terminated Sessions of the specified
+	// User, optionally restricted to the Sessions matching a filter. The
+	// Sessions are canceled in a single transaction.
+	CancelUserSessions(ctx context.Context, in *CancelUserSessionsRequest, opts ...grpc.CallOption) (*CancelUserSessionsResponse, error)
 }
 
 type userServiceClient struct {
@@ -148,6 +162,33 @@ func (c *userServiceClient) RemoveUserAccounts(ctx context.Context, in *RemoveUs
 	return out, nil
 }
 
+func (c *userServiceClient) RevokeUserTokens(ctx context.Context, in *RevokeUserTokensRequest, opts ...grpc.CallOption) (*RevokeUserTokensResponse, error) {
+	out := new(RevokeUserTokensResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.UserService/RevokeUserTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ReadUserActivity(ctx context.Context, in *ReadUserActivityRequest, opts ...grpc.CallOption) (*ReadUserActivityResponse, error) {
+	out := new(ReadUserActivityResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.UserService/ReadUserActivity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CancelUserSessions(ctx context.Context, in *CancelUserSessionsRequest, opts ...grpc.CallOption) (*CancelUserSessionsResponse, error) {
+	out := new(CancelUserSessionsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.UserService/CancelUserSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility
@@ -200,6 +241,20 @@ type UserServiceServer interface {
 	// will be removed from. If the provided Account ids is not associated with the
 	// provided User, an error is returned.
 	RemoveUserAccounts(context.Context, *RemoveUserAccountsRequest) (*RemoveUserAccountsResponse, error)
+	// RevokeUserTokens deletes all Auth Tokens of the specified User. Sessions
+	// created with a revoked Auth Token are canceled. If cancel_sessions is set,
+	// all other non-terminated Sessions of the User are canceled as well.
+	RevokeUserTokens(context.Context, *RevokeUserTokensRequest) (*RevokeUserTokensResponse, error)
+	// ReadUserActivity returns the authentication and session authorization
+	// history of the specified User, most recent first. The history can be
+	// restricted to a time range and to the activities which happened in a
+	// Scope and its child Scopes. If the User does not exist or the time range
+	// is invalid an error is returned.
+	ReadUserActivity(context.Context, *ReadUserActivityRequest) (*ReadUserActivityResponse, error)
+	// CancelUserSessions cancels all non-terminated Sessions of the specified
+	// User, optionally restricted to the Sessions matching a filter. The
+	// Sessions are canceled in a single transaction.
+	CancelUserSessions(context.Context, *CancelUserSessionsRequest) (*CancelUserSessionsResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -231,6 +286,15 @@ func (UnimplementedUserServiceServer) SetUserAccounts(context.Context, *SetUserA
 func (UnimplementedUserServiceServer) RemoveUserAccounts(context.Context, *RemoveUserAccountsRequest) (*RemoveUserAccountsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveUserAccounts not implemented")
 }
+func (UnimplementedUserServiceServer) RevokeUserTokens(context.Context, *RevokeUserTokensRequest) (*RevokeUserTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeUserTokens not implemented")
+}
+func (UnimplementedUserServiceServer) ReadUserActivity(context.Context, *ReadUserActivityRequest) (*ReadUserActivityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadUserActivity not implemented")
+}
+func (UnimplementedUserServiceServer) CancelUserSessions(context.Context, *CancelUserSessionsRequest) (*CancelUserSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelUserSessions not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 
 // UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -388,6 +452,60 @@ func _UserService_RemoveUserAccounts_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_RevokeUserTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeUserTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RevokeUserTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.UserService/RevokeUserTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RevokeUserTokens(ctx, req.(*RevokeUserTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ReadUserActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadUserActivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ReadUserActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.UserService/ReadUserActivity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ReadUserActivity(ctx, req.(*ReadUserActivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CancelUserSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelUserSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CancelUserSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.UserService/CancelUserSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CancelUserSessions(ctx, req.(*CancelUserSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -427,6 +545,18 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveUserAccounts",
 			Handler:    _UserService_RemoveUserAccounts_Handler,
 		},
+		{
+			MethodName: "RevokeUserTokens",
+			Handler:    _UserService_RevokeUserTokens_Handler,
+		},
+		{
+			MethodName: "ReadUserActivity",
+			Handler:    _UserService_ReadUserActivity_Handler,
+		},
+		{
+			MethodName: "CancelUserSessions",
+			Handler:    _UserService_CancelUserSessions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/user_service.proto",
@@ -22,6 +22,10 @@ type ServerCoordinationServiceClient interface {
 	// returns the status response which includes the changes the controller would like to make to
 	// jobs as well as provide a list of the controllers in the system.
 	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// SessionTerminations streams the sessions canceled on the controller to the
+	// worker so that it can close their connections immediately instead of
+	// waiting for its next status request.
+	SessionTerminations(ctx context.Context, in *SessionTerminationsRequest, opts ...grpc.CallOption) (ServerCoordinationService_SessionTerminationsClient, error)
 }
 
 type serverCoordinationServiceClient struct {
@@ -41,6 +45,38 @@ func (c *serverCoordinationServiceClient) Status(ctx context.Context, in *Status
 	return out, nil
 }
 
+func (c *serverCoordinationServiceClient) SessionTerminations(ctx context.Context, in *SessionTerminationsRequest, opts ...grpc.CallOption) (ServerCoordinationService_SessionTerminationsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ServerCoordinationService_ServiceDesc.Streams[0], "/controller.servers.services.v1.ServerCoordinationService/SessionTerminations", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serverCoordinationServiceSessionTerminationsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ServerCoordinationService_SessionTerminationsClient interface {
+	Recv() (*SessionTerminationsResponse, error)
+	grpc.ClientStream
+}
+
+type serverCoordinationServiceSessionTerminationsClient struct {
+	grpc.ClientStream
+}
+
+func (x *serverCoordinationServiceSessionTerminationsClient) Recv() (*SessionTerminationsResponse, error) {
+	m := new(SessionTerminationsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ServerCoordinationServiceServer is the server API for ServerCoordinationService service.
 // All implementations must embed UnimplementedServerCoordinationServiceServer
 // for forward compatibility
@@ -49,6 +85,10 @@ type ServerCoordinationServiceServer interface {
 	// returns the status response which includes the changes the controller would like to make to
 	// jobs as well as provide a list of the controllers in the system.
 	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+	// SessionTerminations streams the sessions canceled on the controller to the
+	// worker so that it can close their connections immediately instead of
+	// waiting for its next status request.
+	SessionTerminations(*SessionTerminationsRequest, ServerCoordinationService_SessionTerminationsServer) error
 	mustEmbedUnimplementedServerCoordinationServiceServer()
 }
 
@@ -59,6 +99,9 @@ type UnimplementedServerCoordinationServiceServer struct {
 func (UnimplementedServerCoordinationServiceServer) Status(context.Context, *StatusRequest) (*StatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
 }
+func (UnimplementedServerCoordinationServiceServer) SessionTerminations(*SessionTerminationsRequest, ServerCoordinationService_SessionTerminationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SessionTerminations not implemented")
+}
 func (UnimplementedServerCoordinationServiceServer) mustEmbedUnimplementedServerCoordinationServiceServer() {
 }
 
@@ -91,6 +134,27 @@ func _ServerCoordinationService_Status_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServerCoordinationService_SessionTerminations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SessionTerminationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServerCoordinationServiceServer).SessionTerminations(m, &serverCoordinationServiceSessionTerminationsServer{stream})
+}
+
+type ServerCoordinationService_SessionTerminationsServer interface {
+	Send(*SessionTerminationsResponse) error
+	grpc.ServerStream
+}
+
+type serverCoordinationServiceSessionTerminationsServer struct {
+	grpc.ServerStream
+}
+
+func (x *serverCoordinationServiceSessionTerminationsServer) Send(m *SessionTerminationsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // ServerCoordinationService_ServiceDesc is the grpc.ServiceDesc for ServerCoordinationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -103,6 +167,12 @@ var ServerCoordinationService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _ServerCoordinationService_Status_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SessionTerminations",
+			Handler:       _ServerCoordinationService_SessionTerminations_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "controller/servers/services/v1/server_coordination_service.proto",
 }
@@ -13,6 +13,9 @@ const (
 	GroupPrefix     = "g"
 	RolePrefix      = "r"
 	RoleGrantPrefix = "rg"
+
+	ServiceAccountPrefix           = "sa"
+	ServiceAccountCredentialPrefix = "sacred"
 )
 
 func newRoleId() (string, error) {
@@ -39,6 +42,22 @@ func newGroupId() (string, error) {
 	return id, nil
 }
 
+func newServiceAccountId() (string, error) {
+	id, err := db.NewPublicId(ServiceAccountPrefix)
+	if err != nil {
+		return "", errors.WrapDeprecated(err, "iam.newServiceAccountId")
+	}
+	return id, nil
+}
+
+func newServiceAccountCredentialId() (string, error) {
+	id, err := db.NewPublicId(ServiceAccountCredentialPrefix)
+	if err != nil {
+		return "", errors.WrapDeprecated(err, "iam.newServiceAccountCredentialId")
+	}
+	return id, nil
+}
+
 func newScopeId(scopeType scope.Type) (string, error) {
 	const op = "iam.newScopeId"
 	if scopeType == scope.Unknown {
@@ -27,6 +27,15 @@ func Test_PublicIds(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, strings.HasPrefix(id, GroupPrefix+"_"))
 	})
+	t.Run("service account", func(t *testing.T) {
+		id, err := newServiceAccountId()
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id, ServiceAccountPrefix+"_"))
+
+		id, err = newServiceAccountCredentialId()
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id, ServiceAccountCredentialPrefix+"_"))
+	})
 	t.Run("oidc managed group", func(t *testing.T) {
 		assert.True(t, strings.HasPrefix("mgoidc_1234567890", intglobals.OidcManagedGroupPrefix+"_"))
 	})
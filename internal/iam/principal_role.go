@@ -13,10 +13,11 @@ import (
 type RoleType uint32
 
 const (
-	UnknownRoleType      RoleType = 0
-	UserRoleType         RoleType = 1
-	GroupRoleType        RoleType = 2
-	ManagedGroupRoleType RoleType = 3
+	UnknownRoleType        RoleType = 0
+	UserRoleType           RoleType = 1
+	GroupRoleType          RoleType = 2
+	ManagedGroupRoleType   RoleType = 3
+	ServiceAccountRoleType RoleType = 4
 )
 
 // String returns a string representation of the role type.
@@ -26,14 +27,16 @@ func (r RoleType) String() string {
 		"user",
 		"group",
 		"managed group",
+		"service account",
 	}[r]
 }
 
 const (
-	principalRoleViewDefaultTable = "iam_principal_role"
-	userRoleDefaultTable          = "iam_user_role"
-	groupRoleDefaultTable         = "iam_group_role"
-	managedGroupRoleDefaultTable  = "iam_managed_group_role"
+	principalRoleViewDefaultTable  = "iam_principal_role"
+	userRoleDefaultTable           = "iam_user_role"
+	groupRoleDefaultTable          = "iam_group_role"
+	managedGroupRoleDefaultTable   = "iam_managed_group_role"
+	serviceAccountRoleDefaultTable = "iam_service_account_role"
 )
 
 // PrincipalRole provides a common way to return roles regardless of their
@@ -294,3 +297,74 @@ func (r *ManagedGroupRole) SetTableName(n string) {
 		r.tableName = n
 	}
 }
+
+// ServiceAccountRole is a service account assigned to a role
+type ServiceAccountRole struct {
+	*store.ServiceAccountRole
+	tableName string `gorm:"-"`
+}
+
+// ensure that ServiceAccountRole implements the interfaces of: Cloneable and
+// db.VetForWriter
+var (
+	_ Cloneable       = (*ServiceAccountRole)(nil)
+	_ db.VetForWriter = (*ServiceAccountRole)(nil)
+)
+
+// NewServiceAccountRole creates a new service account role in memory. No
+// options are supported currently.
+func NewServiceAccountRole(roleId, serviceAccountId string, _ ...Option) (*ServiceAccountRole, error) {
+	const op = "iam.NewServiceAccountRole"
+	if roleId == "" {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing role id")
+	}
+	if serviceAccountId == "" {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing service account id")
+	}
+	return &ServiceAccountRole{
+		ServiceAccountRole: &store.ServiceAccountRole{
+			PrincipalId: serviceAccountId,
+			RoleId:      roleId,
+		},
+	}, nil
+}
+
+// Clone creates a clone of the ServiceAccountRole.
+func (r *ServiceAccountRole) Clone() interface{} {
+	cp := proto.Clone(r.ServiceAccountRole)
+	return &ServiceAccountRole{
+		ServiceAccountRole: cp.(*store.ServiceAccountRole),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface for service account roles.
+func (role *ServiceAccountRole) VetForWrite(ctx context.Context, _ db.Reader, _ db.OpType, _ ...db.Option) error {
+	const op = "iam.(ServiceAccountRole).VetForWrite"
+	if role.RoleId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing role id")
+	}
+	if role.PrincipalId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing service account id")
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name for
+// service account roles.
+func (r *ServiceAccountRole) TableName() string {
+	if r.tableName != "" {
+		return r.tableName
+	}
+	return serviceAccountRoleDefaultTable
+}
+
+// SetTableName sets the table name for the resource. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (r *ServiceAccountRole) SetTableName(n string) {
+	switch n {
+	case "":
+		r.tableName = serviceAccountRoleDefaultTable
+	default:
+		r.tableName = n
+	}
+}
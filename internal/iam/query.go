@@ -14,6 +14,16 @@ const (
 		iam_user_acct_info.scope_id = auth_account.scope_id and
 		auth_account.public_id = ?`
 
+	// userManagedGroupsQuery - given a user id, return the distinct ids of the
+	// managed groups the user's accounts are members of.
+	userManagedGroupsQuery = `
+	select distinct mg.managed_group_id
+	  from auth_managed_group_member_account mg
+	  join auth_account aa
+	    on mg.member_id = aa.public_id
+	 where aa.iam_user_id = ?
+	 order by mg.managed_group_id`
+
 	// whereValidAuthMethod - determine if an auth method public_id within a scope_id
 	// is valid by returning a count of matching rows.
 	whereValidAuthMethod = `select count(*) from auth_method where public_id = $1 and scope_id = $2` // raw query
@@ -22,6 +32,14 @@ const (
 	// should be replaced with calls to the auth method repo).
 	insertAuthMethod = `insert into auth_method (public_id, scope_id) values (?, ?)`
 
+	// deleteExpiredServiceAccountJwtIds - delete the JWT ids which are no
+	// longer needed to detect a reused JWT.
+	deleteExpiredServiceAccountJwtIds = `delete from iam_service_account_jwt_id where expiration_time < ?`
+
+	// insertServiceAccountJwtId - record the id of a JWT a service account
+	// authenticated with.
+	insertServiceAccountJwtId = `insert into iam_service_account_jwt_id (service_account_id, jwt_id, expiration_time) values (?, ?, ?)`
+
 	accountChangesQuery = `
 	with
 	final_accounts (account_id) as (
@@ -12,8 +12,8 @@ import (
 	"github.com/hashicorp/boundary/internal/oplog"
 )
 
-// AddPrincipalRoles provides the ability to add principals (userIds and
-// groupIds) to a role (roleId).  The role's current db version must match the
+// AddPrincipalRoles provides the ability to add principals (userIds,
+// groupIds, managedGroupIds and serviceAccountIds) to a role (roleId).  The role's current db version must match the
 // roleVersion or an error will be returned.  The list of current PrincipalRoles
 // after the adds will be returned on success. Zero is not a valid value for
 // the WithVersion option and will return an error.
@@ -25,12 +25,12 @@ func (r *Repository) AddPrincipalRoles(ctx context.Context, roleId string, roleV
 	if roleVersion == 0 {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing version")
 	}
-	userIds, groupIds, managedGroupIds, err := splitPrincipals(ctx, principalIds)
+	userIds, groupIds, managedGroupIds, serviceAccountIds, err := splitPrincipals(ctx, principalIds)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	if len(userIds) == 0 && len(groupIds) == 0 && len(managedGroupIds) == 0 {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing any of users, groups, or managed groups to add")
+	if len(userIds) == 0 && len(groupIds) == 0 && len(managedGroupIds) == 0 && len(serviceAccountIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing any of users, groups, managed groups, or service accounts to add")
 	}
 
 	newUserRoles := make([]interface{}, 0, len(userIds))
@@ -57,6 +57,14 @@ func (r *Repository) AddPrincipalRoles(ctx context.Context, roleId string, roleV
 		}
 		newManagedGrpRoles = append(newManagedGrpRoles, managedGrpRole)
 	}
+	newServiceAccountRoles := make([]interface{}, 0, len(serviceAccountIds))
+	for _, id := range serviceAccountIds {
+		saRole, err := NewServiceAccountRole(roleId, id)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory service account role"))
+		}
+		newServiceAccountRoles = append(newServiceAccountRoles, saRole)
+	}
 
 	role := allocRole()
 	role.PublicId = roleId
@@ -114,6 +122,13 @@ func (r *Repository) AddPrincipalRoles(ctx context.Context, roleId string, roleV
 				}
 				msgs = append(msgs, managedGrpOplogMsgs...)
 			}
+			if len(newServiceAccountRoles) > 0 {
+				saOplogMsgs := make([]*oplog.Message, 0, len(newServiceAccountRoles))
+				if err := w.CreateItems(ctx, newServiceAccountRoles, db.NewOplogMsgs(&saOplogMsgs)); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add service accounts"))
+				}
+				msgs = append(msgs, saOplogMsgs...)
+			}
 			metadata := oplog.Metadata{
 				"op-type":            []string{oplog.OpType_OP_TYPE_CREATE.String()},
 				"scope-id":           []string{scope.PublicId},
@@ -163,11 +178,11 @@ func (r *Repository) SetPrincipalRoles(ctx context.Context, roleId string, roleV
 	// it's "safe" to do this lookup outside the DoTx transaction because we
 	// have a roleVersion so the principals can’t change without the version
 	// changing.
-	userIds, groupIds, managedGroupIds, err := splitPrincipals(ctx, principalIds)
+	userIds, groupIds, managedGroupIds, serviceAccountIds, err := splitPrincipals(ctx, principalIds)
 	if err != nil {
 		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
 	}
-	toSet, err := r.PrincipalsToSet(ctx, &role, userIds, groupIds, managedGroupIds)
+	toSet, err := r.PrincipalsToSet(ctx, &role, userIds, groupIds, managedGroupIds, serviceAccountIds)
 	if err != nil {
 		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
 	}
@@ -222,7 +237,8 @@ func (r *Repository) SetPrincipalRoles(ctx context.Context, roleId string, roleV
 
 			if len(toSet.DeleteUserRoles) > 0 ||
 				len(toSet.DeleteGroupRoles) > 0 ||
-				len(toSet.DeleteManagedGroupRoles) > 0 {
+				len(toSet.DeleteManagedGroupRoles) > 0 ||
+				len(toSet.DeleteServiceAccountRoles) > 0 {
 				metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
 				if len(toSet.DeleteUserRoles) > 0 {
 					userOplogMsgs := make([]*oplog.Message, 0, len(toSet.DeleteUserRoles))
@@ -260,10 +276,23 @@ func (r *Repository) SetPrincipalRoles(ctx context.Context, roleId string, roleV
 					totalRowsAffected += rowsDeleted
 					msgs = append(msgs, managedGrpOplogMsgs...)
 				}
+				if len(toSet.DeleteServiceAccountRoles) > 0 {
+					saOplogMsgs := make([]*oplog.Message, 0, len(toSet.DeleteServiceAccountRoles))
+					rowsDeleted, err := w.DeleteItems(ctx, toSet.DeleteServiceAccountRoles, db.NewOplogMsgs(&saOplogMsgs))
+					if err != nil {
+						return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete service accounts"))
+					}
+					if rowsDeleted != len(toSet.DeleteServiceAccountRoles) {
+						return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("service account roles deleted %d did not match request for %d", rowsDeleted, len(toSet.DeleteServiceAccountRoles)))
+					}
+					totalRowsAffected += rowsDeleted
+					msgs = append(msgs, saOplogMsgs...)
+				}
 			}
 			if len(toSet.AddUserRoles) > 0 ||
 				len(toSet.AddGroupRoles) > 0 ||
-				len(toSet.AddManagedGroupRoles) > 0 {
+				len(toSet.AddManagedGroupRoles) > 0 ||
+				len(toSet.AddServiceAccountRoles) > 0 {
 				metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
 				if len(toSet.AddUserRoles) > 0 {
 					userOplogMsgs := make([]*oplog.Message, 0, len(toSet.AddUserRoles))
@@ -289,6 +318,14 @@ func (r *Repository) SetPrincipalRoles(ctx context.Context, roleId string, roleV
 					totalRowsAffected += len(toSet.AddManagedGroupRoles)
 					msgs = append(msgs, managedGrpOplogMsgs...)
 				}
+				if len(toSet.AddServiceAccountRoles) > 0 {
+					saOplogMsgs := make([]*oplog.Message, 0, len(toSet.AddServiceAccountRoles))
+					if err := w.CreateItems(ctx, toSet.AddServiceAccountRoles, db.NewOplogMsgs(&saOplogMsgs)); err != nil {
+						return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add service accounts"))
+					}
+					totalRowsAffected += len(toSet.AddServiceAccountRoles)
+					msgs = append(msgs, saOplogMsgs...)
+				}
 			}
 			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, roleTicket, metadata, msgs); err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
@@ -322,12 +359,12 @@ func (r *Repository) DeletePrincipalRoles(ctx context.Context, roleId string, ro
 	if roleId == "" {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing role id")
 	}
-	userIds, groupIds, managedGroupIds, err := splitPrincipals(ctx, principalIds)
+	userIds, groupIds, managedGroupIds, serviceAccountIds, err := splitPrincipals(ctx, principalIds)
 	if err != nil {
 		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
 	}
-	if len(userIds) == 0 && len(groupIds) == 0 && len(managedGroupIds) == 0 {
-		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing any of users, groups, or managed groups to delete")
+	if len(userIds) == 0 && len(groupIds) == 0 && len(managedGroupIds) == 0 && len(serviceAccountIds) == 0 {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing any of users, groups, managed groups, or service accounts to delete")
 	}
 	if roleVersion == 0 {
 		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing version")
@@ -359,6 +396,14 @@ func (r *Repository) DeletePrincipalRoles(ctx context.Context, roleId string, ro
 		}
 		deleteManagedGrpRoles = append(deleteManagedGrpRoles, managedGrpRole)
 	}
+	deleteServiceAccountRoles := make([]interface{}, 0, len(serviceAccountIds))
+	for _, id := range serviceAccountIds {
+		saRole, err := NewServiceAccountRole(roleId, id)
+		if err != nil {
+			return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory service account role"))
+		}
+		deleteServiceAccountRoles = append(deleteServiceAccountRoles, saRole)
+	}
 
 	scope, err := role.GetScope(ctx, r.reader)
 	if err != nil {
@@ -428,6 +473,18 @@ func (r *Repository) DeletePrincipalRoles(ctx context.Context, roleId string, ro
 				totalRowsDeleted += rowsDeleted
 				msgs = append(msgs, managedGrpOplogMsgs...)
 			}
+			if len(deleteServiceAccountRoles) > 0 {
+				saOplogMsgs := make([]*oplog.Message, 0, len(deleteServiceAccountRoles))
+				rowsDeleted, err := w.DeleteItems(ctx, deleteServiceAccountRoles, db.NewOplogMsgs(&saOplogMsgs))
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete service accounts"))
+				}
+				if rowsDeleted != len(deleteServiceAccountRoles) {
+					return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("service account roles deleted %d did not match request for %d", rowsDeleted, len(deleteServiceAccountRoles)))
+				}
+				totalRowsDeleted += rowsDeleted
+				msgs = append(msgs, saOplogMsgs...)
+			}
 			metadata := oplog.Metadata{
 				"op-type":            []string{oplog.OpType_OP_TYPE_DELETE.String()},
 				"scope-id":           []string{scope.PublicId},
@@ -462,12 +519,14 @@ func (r *Repository) ListPrincipalRoles(ctx context.Context, roleId string, opt
 }
 
 type PrincipalSet struct {
-	AddUserRoles            []interface{}
-	AddGroupRoles           []interface{}
-	AddManagedGroupRoles    []interface{}
-	DeleteUserRoles         []interface{}
-	DeleteGroupRoles        []interface{}
-	DeleteManagedGroupRoles []interface{}
+	AddUserRoles              []interface{}
+	AddGroupRoles             []interface{}
+	AddManagedGroupRoles      []interface{}
+	DeleteUserRoles           []interface{}
+	DeleteGroupRoles          []interface{}
+	DeleteManagedGroupRoles   []interface{}
+	AddServiceAccountRoles    []interface{}
+	DeleteServiceAccountRoles []interface{}
 	// unchangedPrincipalRoles is set iff there are no changes, that is, the
 	// length of all other members is zero
 	UnchangedPrincipalRoles []PrincipalRole
@@ -475,7 +534,7 @@ type PrincipalSet struct {
 
 // TODO: Should this be moved inside the transaction, at this point?
 // PrincipalsToSet sets principals on a role from the given lists.
-func (r *Repository) PrincipalsToSet(ctx context.Context, role *Role, userIds, groupIds, managedGroupIds []string) (*PrincipalSet, error) {
+func (r *Repository) PrincipalsToSet(ctx context.Context, role *Role, userIds, groupIds, managedGroupIds, serviceAccountIds []string) (*PrincipalSet, error) {
 	const op = "iam.(Repository).PrincipalsToSet"
 	// TODO(mgaffney) 08/2020: Use SQL to calculate changes.
 	if role == nil {
@@ -488,6 +547,7 @@ func (r *Repository) PrincipalsToSet(ctx context.Context, role *Role, userIds, g
 	existingUsers := map[string]PrincipalRole{}
 	existingGroups := map[string]PrincipalRole{}
 	existingManagedGroups := map[string]PrincipalRole{}
+	existingServiceAccounts := map[string]PrincipalRole{}
 	for _, p := range existing {
 		switch p.GetType() {
 		case UserRoleType.String():
@@ -496,6 +556,8 @@ func (r *Repository) PrincipalsToSet(ctx context.Context, role *Role, userIds, g
 			existingGroups[p.PrincipalId] = p
 		case ManagedGroupRoleType.String():
 			existingManagedGroups[p.PrincipalId] = p
+		case ServiceAccountRoleType.String():
+			existingServiceAccounts[p.PrincipalId] = p
 		default:
 			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%s is unknown principal type %s", p.PrincipalId, p.GetType()))
 		}
@@ -536,6 +598,18 @@ func (r *Repository) PrincipalsToSet(ctx context.Context, role *Role, userIds, g
 			newManagedGrpRoles = append(newManagedGrpRoles, managedGrpRole)
 		}
 	}
+	var newServiceAccountRoles []interface{}
+	serviceAccountIdsMap := map[string]struct{}{}
+	for _, id := range serviceAccountIds {
+		serviceAccountIdsMap[id] = struct{}{}
+		if _, ok := existingServiceAccounts[id]; !ok {
+			saRole, err := NewServiceAccountRole(role.PublicId, id)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory service account role for add"))
+			}
+			newServiceAccountRoles = append(newServiceAccountRoles, saRole)
+		}
+	}
 	var deleteUserRoles []interface{}
 	for _, p := range existingUsers {
 		if _, ok := userIdsMap[p.PrincipalId]; !ok {
@@ -566,14 +640,26 @@ func (r *Repository) PrincipalsToSet(ctx context.Context, role *Role, userIds, g
 			deleteManagedGrpRoles = append(deleteManagedGrpRoles, managedGrpRole)
 		}
 	}
+	var deleteServiceAccountRoles []interface{}
+	for _, p := range existingServiceAccounts {
+		if _, ok := serviceAccountIdsMap[p.PrincipalId]; !ok {
+			saRole, err := NewServiceAccountRole(p.GetRoleId(), p.GetPrincipalId())
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory service account role for delete"))
+			}
+			deleteServiceAccountRoles = append(deleteServiceAccountRoles, saRole)
+		}
+	}
 
 	toSet := &PrincipalSet{
-		AddUserRoles:            newUserRoles,
-		AddGroupRoles:           newGrpRoles,
-		AddManagedGroupRoles:    newManagedGrpRoles,
-		DeleteUserRoles:         deleteUserRoles,
-		DeleteGroupRoles:        deleteGrpRoles,
-		DeleteManagedGroupRoles: deleteManagedGrpRoles,
+		AddUserRoles:              newUserRoles,
+		AddGroupRoles:             newGrpRoles,
+		AddManagedGroupRoles:      newManagedGrpRoles,
+		DeleteUserRoles:           deleteUserRoles,
+		DeleteGroupRoles:          deleteGrpRoles,
+		DeleteManagedGroupRoles:   deleteManagedGrpRoles,
+		AddServiceAccountRoles:    newServiceAccountRoles,
+		DeleteServiceAccountRoles: deleteServiceAccountRoles,
 	}
 
 	if len(toSet.AddUserRoles) == 0 &&
@@ -581,17 +667,24 @@ func (r *Repository) PrincipalsToSet(ctx context.Context, role *Role, userIds, g
 		len(toSet.AddManagedGroupRoles) == 0 &&
 		len(toSet.DeleteUserRoles) == 0 &&
 		len(toSet.DeleteGroupRoles) == 0 &&
-		len(toSet.DeleteManagedGroupRoles) == 0 {
+		len(toSet.DeleteManagedGroupRoles) == 0 &&
+		len(toSet.AddServiceAccountRoles) == 0 &&
+		len(toSet.DeleteServiceAccountRoles) == 0 {
 		toSet.UnchangedPrincipalRoles = existing
 	}
 
 	return toSet, nil
 }
 
-func splitPrincipals(ctx context.Context, principals []string) (users, groups, managedGroups []string, retErr error) {
+func splitPrincipals(ctx context.Context, principals []string) (users, groups, managedGroups, serviceAccounts []string, retErr error) {
 	const op = "iam.splitPrincipals"
 	for _, principal := range principals {
 		switch {
+		case strings.HasPrefix(principal, ServiceAccountPrefix+"_"):
+			if serviceAccounts == nil {
+				serviceAccounts = make([]string, 0, len(principals))
+			}
+			serviceAccounts = append(serviceAccounts, principal)
 		case strings.HasPrefix(principal, UserPrefix):
 			if users == nil {
 				users = make([]string, 0, len(principals))
@@ -608,7 +701,7 @@ func splitPrincipals(ctx context.Context, principals []string) (users, groups, m
 			}
 			managedGroups = append(managedGroups, principal)
 		default:
-			return nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("invalid principal ID %q", principal))
+			return nil, nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("invalid principal ID %q", principal))
 		}
 	}
 
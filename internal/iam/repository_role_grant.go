@@ -385,6 +385,9 @@ func (r *Repository) ListRoleGrants(ctx context.Context, roleId string, opt ...O
 	return roleGrants, nil
 }
 
+// GrantsForUser returns the grants of userId, which is either a user or a
+// service account id, including the grants of the anonymous and authenticated
+// users.
 func (r *Repository) GrantsForUser(ctx context.Context, userId string, _ ...Option) ([]perms.GrantTuple, error) {
 	const op = "iam.(Repository).GrantsForUser"
 	if userId == "" {
@@ -437,6 +440,11 @@ user_roles (role_id) as (
          users
    where principal_id in (users.id)
 ),
+service_account_roles (role_id) as (
+  select role_id
+    from iam_service_account_role
+   where principal_id = ?
+),
 user_group_roles (role_id) as (
   select role_id
     from group_roles
@@ -446,6 +454,9 @@ user_group_roles (role_id) as (
    union
   select role_id
     from managed_group_roles
+   union
+  select role_id
+    from service_account_roles
 ),
 roles (role_id, grant_scope_id) as (
   select iam_role.public_id,
@@ -476,7 +487,7 @@ select role_id as role_id, role_scope as scope_id, role_grant as grant from fina
 	}
 
 	var grants []perms.GrantTuple
-	rows, err := r.reader.Query(ctx, query, []interface{}{userId})
+	rows, err := r.reader.Query(ctx, query, []interface{}{userId, userId})
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
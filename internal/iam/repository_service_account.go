@@ -0,0 +1,458 @@
+package iam
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/cap/jwt"
+)
+
+// MaxServiceAccountJwtLifetime is the longest a JWT presented by a service
+// account may be valid for, measured from the time it is presented.
+const MaxServiceAccountJwtLifetime = time.Hour
+
+// ServiceAccountJwtAudience returns the "aud" (audience) claim a JWT must
+// contain to authenticate as the service account serviceAccountId: the path
+// of the service account's authenticate endpoint.
+func ServiceAccountJwtAudience(serviceAccountId string) string {
+	return fmt.Sprintf("/v1/service-accounts/%s:authenticate", serviceAccountId)
+}
+
+// CreateServiceAccount will create a service account in the repository and
+// return the written service account. No options are currently supported.
+func (r *Repository) CreateServiceAccount(ctx context.Context, sa *ServiceAccount, _ ...Option) (*ServiceAccount, error) {
+	const op = "iam.(Repository).CreateServiceAccount"
+	if sa == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing service account")
+	}
+	if sa.ServiceAccount == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing service account store")
+	}
+	if sa.PublicId != "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	}
+	if sa.ScopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	id, err := newServiceAccountId()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	s := sa.Clone().(*ServiceAccount)
+	s.PublicId = id
+	resource, err := r.create(ctx, s)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("service account %s already exists in scope %s", sa.Name, sa.ScopeId))
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for service account %s", s.PublicId)))
+	}
+	return resource.(*ServiceAccount), nil
+}
+
+// UpdateServiceAccount will update a service account in the repository and
+// return the written service account and its credentials. fieldMaskPaths
+// provides field_mask.proto paths for fields that should be updated. Fields
+// will be set to NULL if the field is a zero value and included in
+// fieldMask. Name and Description are the only updatable fields. If no
+// updatable fields are included in the fieldMaskPaths, then an error is
+// returned.
+func (r *Repository) UpdateServiceAccount(ctx context.Context, sa *ServiceAccount, version uint32, fieldMaskPaths []string, _ ...Option) (*ServiceAccount, []*ServiceAccountCredential, int, error) {
+	const op = "iam.(Repository).UpdateServiceAccount"
+	if sa == nil {
+		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing service account")
+	}
+	if sa.ServiceAccount == nil {
+		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing service account store")
+	}
+	if sa.PublicId == "" {
+		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("name", f):
+		case strings.EqualFold("description", f):
+		default:
+			return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
+		}
+	}
+	dbMask, nullFields := dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			"name":        sa.Name,
+			"description": sa.Description,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
+	}
+	var resource Resource
+	var rowsUpdated int
+	var creds []*ServiceAccountCredential
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(read db.Reader, w db.Writer) error {
+			var err error
+			s := sa.Clone().(*ServiceAccount)
+			resource, rowsUpdated, err = r.update(ctx, s, version, dbMask, nullFields)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			repo, err := NewRepository(read, w, r.kms)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			creds, err = repo.ListServiceAccountCredentials(ctx, sa.PublicId)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("service account %s already exists in scope %s", sa.Name, sa.ScopeId))
+		}
+		return nil, nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for service account %s", sa.PublicId)))
+	}
+	return resource.(*ServiceAccount), creds, rowsUpdated, nil
+}
+
+// LookupServiceAccount will look up a service account and its credentials in
+// the repository. If the service account is not found, it will return nil,
+// nil, nil.
+func (r *Repository) LookupServiceAccount(ctx context.Context, withPublicId string, _ ...Option) (*ServiceAccount, []*ServiceAccountCredential, error) {
+	const op = "iam.(Repository).LookupServiceAccount"
+	if withPublicId == "" {
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	sa := allocServiceAccount()
+	sa.PublicId = withPublicId
+	if err := r.reader.LookupByPublicId(ctx, &sa); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for service account %s", withPublicId)))
+	}
+	creds, err := r.ListServiceAccountCredentials(ctx, withPublicId)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	return &sa, creds, nil
+}
+
+// DeleteServiceAccount will delete a service account from the repository.
+// Its credentials, role assignments and auth tokens are deleted with it.
+func (r *Repository) DeleteServiceAccount(ctx context.Context, withPublicId string, _ ...Option) (int, error) {
+	const op = "iam.(Repository).DeleteServiceAccount"
+	if withPublicId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	sa := allocServiceAccount()
+	sa.PublicId = withPublicId
+	if err := r.reader.LookupByPublicId(ctx, &sa); err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for service account %s", withPublicId)))
+	}
+	rowsDeleted, err := r.delete(ctx, &sa)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for service account %s", withPublicId)))
+	}
+	return rowsDeleted, nil
+}
+
+// ListServiceAccounts lists service accounts in the given scopes and supports
+// the WithLimit option.
+func (r *Repository) ListServiceAccounts(ctx context.Context, withScopeIds []string, opt ...Option) ([]*ServiceAccount, error) {
+	const op = "iam.(Repository).ListServiceAccounts"
+	if len(withScopeIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	var sas []*ServiceAccount
+	if err := r.list(ctx, &sas, "scope_id in (?)", []interface{}{withScopeIds}, opt...); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return sas, nil
+}
+
+// ListServiceAccountCredentials lists the credentials of a service account
+// and supports the WithLimit option.
+func (r *Repository) ListServiceAccountCredentials(ctx context.Context, withServiceAccountId string, opt ...Option) ([]*ServiceAccountCredential, error) {
+	const op = "iam.(Repository).ListServiceAccountCredentials"
+	if withServiceAccountId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing service account id")
+	}
+	creds := []*ServiceAccountCredential{}
+	if err := r.list(ctx, &creds, "service_account_id = ?", []interface{}{withServiceAccountId}, opt...); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return creds, nil
+}
+
+// AddServiceAccountCredential adds the credential cred to its service
+// account. The service account's current db version must match
+// serviceAccountVersion or an error will be returned. The written credential
+// is returned.
+func (r *Repository) AddServiceAccountCredential(ctx context.Context, cred *ServiceAccountCredential, serviceAccountVersion uint32, _ ...Option) (*ServiceAccountCredential, error) {
+	const op = "iam.(Repository).AddServiceAccountCredential"
+	if cred == nil || cred.ServiceAccountCredential == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing credential")
+	}
+	if cred.PublicId != "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	}
+	if cred.ServiceAccountId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing service account id")
+	}
+	if serviceAccountVersion == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	}
+	id, err := newServiceAccountCredentialId()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	newCred := cred.Clone().(*ServiceAccountCredential)
+	newCred.PublicId = id
+
+	var returnedCred *ServiceAccountCredential
+	err = r.writeServiceAccountCredential(ctx, op, cred.ServiceAccountId, serviceAccountVersion, oplog.OpType_OP_TYPE_CREATE,
+		func(w db.Writer) (*oplog.Message, error) {
+			returnedCred = newCred.Clone().(*ServiceAccountCredential)
+			var msg oplog.Message
+			if err := w.Create(ctx, returnedCred, db.NewOplogMsg(&msg)); err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to add credential"))
+			}
+			return &msg, nil
+		})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return returnedCred, nil
+}
+
+// RemoveServiceAccountCredential removes the credential credentialId from the
+// service account serviceAccountId. The service account's current db version
+// must match serviceAccountVersion or an error will be returned.
+func (r *Repository) RemoveServiceAccountCredential(ctx context.Context, serviceAccountId string, serviceAccountVersion uint32, credentialId string, _ ...Option) (int, error) {
+	const op = "iam.(Repository).RemoveServiceAccountCredential"
+	if serviceAccountId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing service account id")
+	}
+	if credentialId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing credential id")
+	}
+	if serviceAccountVersion == 0 {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	}
+	cred := allocServiceAccountCredential()
+	cred.PublicId = credentialId
+	var rowsDeleted int
+	err := r.writeServiceAccountCredential(ctx, op, serviceAccountId, serviceAccountVersion, oplog.OpType_OP_TYPE_DELETE,
+		func(w db.Writer) (*oplog.Message, error) {
+			var msg oplog.Message
+			var err error
+			rowsDeleted, err = w.Delete(ctx, &cred, db.NewOplogMsg(&msg), db.WithWhere("service_account_id = ?", serviceAccountId))
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to remove credential"))
+			}
+			if rowsDeleted != 1 {
+				return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("credential %s not found for service account %s", credentialId, serviceAccountId))
+			}
+			return &msg, nil
+		})
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	return rowsDeleted, nil
+}
+
+// writeServiceAccountCredential bumps the version of the service account
+// serviceAccountId and runs write within the same transaction, writing a
+// single oplog entry for both.
+func (r *Repository) writeServiceAccountCredential(ctx context.Context, op errors.Op, serviceAccountId string, serviceAccountVersion uint32, opType oplog.OpType, write func(w db.Writer) (*oplog.Message, error)) error {
+	sa := allocServiceAccount()
+	sa.PublicId = serviceAccountId
+	scope, err := sa.GetScope(ctx, r.reader)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to get service account %s scope", serviceAccountId)))
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scope.GetPublicId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			ticket, err := w.GetTicket(&sa)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+			}
+			updatedSa := allocServiceAccount()
+			updatedSa.PublicId = serviceAccountId
+			updatedSa.Version = serviceAccountVersion + 1
+			var saOplogMsg oplog.Message
+			rowsUpdated, err := w.Update(ctx, &updatedSa, []string{"Version"}, nil, db.NewOplogMsg(&saOplogMsg), db.WithVersion(&serviceAccountVersion))
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update service account version"))
+			}
+			if rowsUpdated != 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated service account and %d rows updated", rowsUpdated))
+			}
+			credOplogMsg, err := write(w)
+			if err != nil {
+				return err
+			}
+			metadata := oplog.Metadata{
+				"op-type":            []string{opType.String()},
+				"scope-id":           []string{scope.PublicId},
+				"scope-type":         []string{scope.Type},
+				"resource-public-id": []string{serviceAccountId},
+			}
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, []*oplog.Message{&saOplogMsg, credOplogMsg}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+			}
+			return nil
+		},
+	)
+	return err
+}
+
+// AuthenticateServiceAccountSecret returns the service account
+// serviceAccountId if clientId is the id of one of its unexpired client-secret
+// credentials and secret is that credential's secret. An Unauthorized error is
+// returned otherwise.
+func (r *Repository) AuthenticateServiceAccountSecret(ctx context.Context, serviceAccountId, clientId, secret string, _ ...Option) (*ServiceAccount, error) {
+	const op = "iam.(Repository).AuthenticateServiceAccountSecret"
+	switch {
+	case serviceAccountId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing service account id")
+	case clientId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing client id")
+	case secret == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing client secret")
+	}
+	sa, creds, err := r.LookupServiceAccount(ctx, serviceAccountId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if sa == nil {
+		return nil, errors.New(ctx, errors.Unauthorized, op, "authentication failed")
+	}
+	for _, c := range creds {
+		if c.GetPublicId() == clientId && !c.Expired() && c.matchesSecret(secret) {
+			return sa, nil
+		}
+	}
+	return nil, errors.New(ctx, errors.Unauthorized, op, "authentication failed")
+}
+
+// AuthenticateServiceAccountJwt returns the service account serviceAccountId
+// if token is a JWT signed with the private key of one of its unexpired
+// jwt-public-key credentials. The subject of the JWT must be the service
+// account id, its audience must include ServiceAccountJwtAudience and it must
+// expire no more than MaxServiceAccountJwtLifetime from now. The JWT must have
+// a "jti" claim and each JWT can only be used once. An Unauthorized error is
+// returned otherwise.
+func (r *Repository) AuthenticateServiceAccountJwt(ctx context.Context, serviceAccountId, token string, _ ...Option) (*ServiceAccount, error) {
+	const op = "iam.(Repository).AuthenticateServiceAccountJwt"
+	switch {
+	case serviceAccountId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing service account id")
+	case token == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing jwt")
+	}
+	sa, creds, err := r.LookupServiceAccount(ctx, serviceAccountId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if sa == nil {
+		return nil, errors.New(ctx, errors.Unauthorized, op, "authentication failed")
+	}
+	expected := jwt.Expected{
+		Subject:   serviceAccountId,
+		Audiences: []string{ServiceAccountJwtAudience(serviceAccountId)},
+		SigningAlgorithms: []jwt.Alg{
+			jwt.RS256, jwt.RS384, jwt.RS512,
+			jwt.ES256, jwt.ES384, jwt.ES512,
+			jwt.PS256, jwt.PS384, jwt.PS512,
+		},
+	}
+	for _, c := range creds {
+		if c.GetType() != string(JwtPublicKeyCredentialType) || c.Expired() {
+			continue
+		}
+		key, err := jwt.ParsePublicKeyPEM([]byte(c.GetPublicKey()))
+		if err != nil {
+			continue
+		}
+		keySet, err := jwt.NewStaticKeySet([]crypto.PublicKey{key})
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		validator, err := jwt.NewValidator(keySet)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		claims, err := validator.Validate(ctx, token, expected)
+		if err != nil {
+			continue
+		}
+		exp, ok := claims["exp"].(float64)
+		if !ok {
+			return nil, errors.New(ctx, errors.Unauthorized, op, "jwt is missing an expiration time")
+		}
+		expTime := time.Unix(int64(exp), 0)
+		if expTime.After(time.Now().Add(MaxServiceAccountJwtLifetime)) {
+			return nil, errors.New(ctx, errors.Unauthorized, op, fmt.Sprintf("jwt must expire within %s", MaxServiceAccountJwtLifetime))
+		}
+		jti, _ := claims["jti"].(string)
+		if strings.TrimSpace(jti) == "" {
+			return nil, errors.New(ctx, errors.Unauthorized, op, "jwt is missing an id")
+		}
+		if err := r.useServiceAccountJwtId(ctx, serviceAccountId, jti, expTime); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		return sa, nil
+	}
+	return nil, errors.New(ctx, errors.Unauthorized, op, "authentication failed")
+}
+
+// useServiceAccountJwtId records that the service account serviceAccountId
+// authenticated with the JWT with the id jti, which expires at exp. An
+// Unauthorized error is returned if the JWT has already been used. Ids are
+// kept for MaxServiceAccountJwtLifetime past their expiration, well beyond
+// the leeway given when validating the expiration of a JWT, and expired ids
+// are deleted as new ones are recorded.
+func (r *Repository) useServiceAccountJwtId(ctx context.Context, serviceAccountId, jti string, exp time.Time) error {
+	const op = "iam.(Repository).useServiceAccountJwtId"
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			if _, err := w.Exec(ctx, deleteExpiredServiceAccountJwtIds, []interface{}{time.Now().Add(-MaxServiceAccountJwtLifetime)}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete expired jwt ids"))
+			}
+			if _, err := w.Exec(ctx, insertServiceAccountJwtId, []interface{}{serviceAccountId, jti, exp}); err != nil {
+				if errors.IsUniqueError(err) {
+					return errors.New(ctx, errors.Unauthorized, op, "jwt has already been used")
+				}
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to record jwt id"))
+			}
+			return nil
+		},
+	)
+	return err
+}
@@ -0,0 +1,232 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/cap/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ServiceAccountCrud(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+
+	t.Run("create", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		sa, err := NewServiceAccount(org.PublicId, WithName("create"), WithDescription("desc"))
+		require.NoError(err)
+		got, err := repo.CreateServiceAccount(ctx, sa)
+		require.NoError(err)
+		assert.NotEmpty(got.PublicId)
+		assert.Equal("create", got.Name)
+		assert.Equal(uint32(1), got.Version)
+		assert.NotNil(got.CreateTime)
+
+		_, err = repo.CreateServiceAccount(ctx, sa)
+		assert.True(errors.Match(errors.T(errors.NotUnique), err))
+	})
+	t.Run("create-in-project", func(t *testing.T) {
+		sa, err := NewServiceAccount(proj.PublicId)
+		require.NoError(t, err)
+		_, err = repo.CreateServiceAccount(ctx, sa)
+		assert.Error(t, err)
+	})
+	t.Run("update-lookup-delete", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		sa := TestServiceAccount(t, conn, org.PublicId, WithName("before"))
+		sa.Name = "after"
+		got, creds, rowsUpdated, err := repo.UpdateServiceAccount(ctx, sa, sa.Version, []string{"Name"})
+		require.NoError(err)
+		assert.Equal(1, rowsUpdated)
+		assert.Equal("after", got.Name)
+		assert.Empty(creds)
+
+		found, _, err := repo.LookupServiceAccount(ctx, sa.PublicId)
+		require.NoError(err)
+		assert.Equal("after", found.Name)
+		assert.Equal(uint32(2), found.Version)
+
+		list, err := repo.ListServiceAccounts(ctx, []string{org.PublicId})
+		require.NoError(err)
+		var ids []string
+		for _, s := range list {
+			ids = append(ids, s.PublicId)
+		}
+		assert.Contains(ids, sa.PublicId)
+
+		rowsDeleted, err := repo.DeleteServiceAccount(ctx, sa.PublicId)
+		require.NoError(err)
+		assert.Equal(1, rowsDeleted)
+		found, _, err = repo.LookupServiceAccount(ctx, sa.PublicId)
+		require.NoError(err)
+		assert.Nil(found)
+	})
+}
+
+func TestRepository_ServiceAccountCredentials(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+	sa := TestServiceAccount(t, conn, org.PublicId)
+	_, pubPem := testPublicKeyPem(t)
+
+	assert, require := assert.New(t), require.New(t)
+	keyCred, err := NewServiceAccountPublicKey(sa.PublicId, pubPem)
+	require.NoError(err)
+	gotKey, err := repo.AddServiceAccountCredential(ctx, keyCred, sa.Version)
+	require.NoError(err)
+	assert.NotEmpty(gotKey.PublicId)
+
+	secretCred, _, err := NewServiceAccountClientSecret(sa.PublicId)
+	require.NoError(err)
+	_, err = repo.AddServiceAccountCredential(ctx, secretCred, sa.Version)
+	assert.Error(err, "stale version must be rejected")
+	gotSecret, err := repo.AddServiceAccountCredential(ctx, secretCred, sa.Version+1)
+	require.NoError(err)
+
+	found, creds, err := repo.LookupServiceAccount(ctx, sa.PublicId)
+	require.NoError(err)
+	assert.Equal(sa.Version+2, found.Version)
+	assert.Len(creds, 2)
+
+	rows, err := repo.RemoveServiceAccountCredential(ctx, sa.PublicId, found.Version, gotKey.PublicId)
+	require.NoError(err)
+	assert.Equal(1, rows)
+	_, err = repo.RemoveServiceAccountCredential(ctx, sa.PublicId, found.Version+1, gotKey.PublicId)
+	assert.True(errors.IsNotFoundError(err))
+
+	creds, err = repo.ListServiceAccountCredentials(ctx, sa.PublicId)
+	require.NoError(err)
+	require.Len(creds, 1)
+	assert.Equal(gotSecret.PublicId, creds[0].PublicId)
+}
+
+func TestRepository_AuthenticateServiceAccount(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+	sa := TestServiceAccount(t, conn, org.PublicId)
+
+	priv, pubPem := testPublicKeyPem(t)
+	keyCred, err := NewServiceAccountPublicKey(sa.PublicId, pubPem)
+	require.NoError(t, err)
+	_, err = repo.AddServiceAccountCredential(ctx, keyCred, sa.Version)
+	require.NoError(t, err)
+
+	secretCred, secret, err := NewServiceAccountClientSecret(sa.PublicId)
+	require.NoError(t, err)
+	gotSecret, err := repo.AddServiceAccountCredential(ctx, secretCred, sa.Version+1)
+	require.NoError(t, err)
+
+	expiredCred, expiredSecret, err := NewServiceAccountClientSecret(sa.PublicId, WithExpirationTime(time.Now().Add(-time.Minute)))
+	require.NoError(t, err)
+	gotExpired, err := repo.AddServiceAccountCredential(ctx, expiredCred, sa.Version+2)
+	require.NoError(t, err)
+
+	t.Run("secret", func(t *testing.T) {
+		assert := assert.New(t)
+		got, err := repo.AuthenticateServiceAccountSecret(ctx, sa.PublicId, gotSecret.PublicId, secret)
+		assert.NoError(err)
+		assert.Equal(sa.PublicId, got.GetPublicId())
+
+		_, err = repo.AuthenticateServiceAccountSecret(ctx, sa.PublicId, gotSecret.PublicId, secret+"x")
+		assert.True(errors.Match(errors.T(errors.Unauthorized), err))
+
+		_, err = repo.AuthenticateServiceAccountSecret(ctx, sa.PublicId, gotExpired.PublicId, expiredSecret)
+		assert.True(errors.Match(errors.T(errors.Unauthorized), err))
+
+		_, err = repo.AuthenticateServiceAccountSecret(ctx, "sa_doesnotexist", gotSecret.PublicId, secret)
+		assert.True(errors.Match(errors.T(errors.Unauthorized), err))
+	})
+
+	t.Run("jwt", func(t *testing.T) {
+		assert := assert.New(t)
+		var jtis int
+		claims := func(sub string, exp time.Time) map[string]interface{} {
+			jtis++
+			return map[string]interface{}{
+				"sub": sub,
+				"aud": []string{ServiceAccountJwtAudience(sub)},
+				"jti": fmt.Sprintf("jti-%d", jtis),
+				"iat": time.Now().Unix(),
+				"exp": exp.Unix(),
+			}
+		}
+		sign := func(c map[string]interface{}) string {
+			return oidc.TestSignJWT(t, priv, string(oidc.ES256), c, nil)
+		}
+		token := sign(claims(sa.PublicId, time.Now().Add(5*time.Minute)))
+		got, err := repo.AuthenticateServiceAccountJwt(ctx, sa.PublicId, token)
+		assert.NoError(err)
+		assert.Equal(sa.PublicId, got.GetPublicId())
+
+		_, err = repo.AuthenticateServiceAccountJwt(ctx, sa.PublicId, token)
+		assert.True(errors.Match(errors.T(errors.Unauthorized), err), "reused jwt")
+
+		_, err = repo.AuthenticateServiceAccountJwt(ctx, sa.PublicId, sign(claims("sa_other", time.Now().Add(5*time.Minute))))
+		assert.True(errors.Match(errors.T(errors.Unauthorized), err), "wrong subject")
+
+		_, err = repo.AuthenticateServiceAccountJwt(ctx, sa.PublicId, sign(claims(sa.PublicId, time.Now().Add(-5*time.Minute))))
+		assert.True(errors.Match(errors.T(errors.Unauthorized), err), "expired")
+
+		_, err = repo.AuthenticateServiceAccountJwt(ctx, sa.PublicId, sign(claims(sa.PublicId, time.Now().Add(2*MaxServiceAccountJwtLifetime))))
+		assert.True(errors.Match(errors.T(errors.Unauthorized), err), "lifetime too long")
+
+		c := claims(sa.PublicId, time.Now().Add(5*time.Minute))
+		delete(c, "aud")
+		_, err = repo.AuthenticateServiceAccountJwt(ctx, sa.PublicId, sign(c))
+		assert.True(errors.Match(errors.T(errors.Unauthorized), err), "missing audience")
+
+		c = claims(sa.PublicId, time.Now().Add(5*time.Minute))
+		c["aud"] = []string{ServiceAccountJwtAudience("sa_other")}
+		_, err = repo.AuthenticateServiceAccountJwt(ctx, sa.PublicId, sign(c))
+		assert.True(errors.Match(errors.T(errors.Unauthorized), err), "wrong audience")
+
+		c = claims(sa.PublicId, time.Now().Add(5*time.Minute))
+		delete(c, "jti")
+		_, err = repo.AuthenticateServiceAccountJwt(ctx, sa.PublicId, sign(c))
+		assert.True(errors.Match(errors.T(errors.Unauthorized), err), "missing id")
+
+		otherPriv, _ := testPublicKeyPem(t)
+		_, err = repo.AuthenticateServiceAccountJwt(ctx, sa.PublicId, oidc.TestSignJWT(t, otherPriv, string(oidc.ES256), claims(sa.PublicId, time.Now().Add(5*time.Minute)), nil))
+		assert.True(errors.Match(errors.T(errors.Unauthorized), err), "wrong key")
+	})
+}
+
+func TestRepository_GrantsForServiceAccount(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+	sa := TestServiceAccount(t, conn, org.PublicId)
+
+	role := TestRole(t, conn, org.PublicId)
+	TestRoleGrant(t, conn, role.PublicId, "id=*;type=host-catalog;actions=read")
+	TestServiceAccountRole(t, conn, role.PublicId, sa.PublicId)
+
+	grants, err := repo.GrantsForUser(ctx, sa.PublicId)
+	require.NoError(t, err)
+	var raw []string
+	for _, g := range grants {
+		raw = append(raw, g.Grant)
+	}
+	assert.Contains(t, raw, "id=*;type=host-catalog;actions=read")
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
 	"github.com/hashicorp/boundary/internal/types/scope"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
 )
 
 // CreateUser will create a user in the repository and return the written user
@@ -62,23 +63,75 @@ func (r *Repository) CreateUser(ctx context.Context, user *User, opt ...Option)
 // UpdateUser will update a user in the repository and return the written user
 // plus its associated account ids. fieldMaskPaths provides field_mask.proto
 // paths for fields that should be updated.  Fields will be set to NULL if the
-// field is a zero value and included in fieldMask. Name and Description are the
-// only updatable fields, if no updatable fields are included in the
-// fieldMaskPaths, then an error is returned.
+// field is a zero value and included in fieldMask, except for Disabled which is
+// set to false. Name, Description and Disabled are the only updatable fields,
+// if no updatable fields are included in the fieldMaskPaths, then an error is
+// returned.
 func (r *Repository) UpdateUser(ctx context.Context, user *User, version uint32, fieldMaskPaths []string, opt ...Option) (*User, []string, int, error) {
 	const op = "iam.(Repository).UpdateUser"
+	u, dbMask, nullFields, dbOpts, err := r.prepareUserUpdate(ctx, op, user, version, fieldMaskPaths, opt...)
+	if err != nil {
+		return nil, nil, db.NoRowsAffected, err
+	}
+
+	var rowsUpdated int
+	var returnedUser *User
+	var currentAccountIds []string
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			var err error
+			returnedUser, currentAccountIds, rowsUpdated, err = r.updateUser(ctx, op, reader, w, u, dbMask, nullFields, dbOpts)
+			return err
+		},
+	)
+	if err != nil {
+		return nil, nil, db.NoRowsAffected, updateUserError(ctx, op, user, err)
+	}
+	return returnedUser, currentAccountIds, rowsUpdated, nil
+}
+
+// UpdateUserTx updates a user like UpdateUser, but with reader and w, the
+// reader and writer of a transaction started by the caller, instead of in a
+// transaction of its own.
+func (r *Repository) UpdateUserTx(ctx context.Context, reader db.Reader, w db.Writer, user *User, version uint32, fieldMaskPaths []string, opt ...Option) (*User, []string, int, error) {
+	const op = "iam.(Repository).UpdateUserTx"
+	if reader == nil {
+		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing reader")
+	}
+	if w == nil {
+		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing writer")
+	}
+	u, dbMask, nullFields, dbOpts, err := r.prepareUserUpdate(ctx, op, user, version, fieldMaskPaths, opt...)
+	if err != nil {
+		return nil, nil, db.NoRowsAffected, err
+	}
+	returnedUser, currentAccountIds, rowsUpdated, err := r.updateUser(ctx, op, reader, w, u, dbMask, nullFields, dbOpts)
+	if err != nil {
+		return nil, nil, db.NoRowsAffected, updateUserError(ctx, op, user, err)
+	}
+	return returnedUser, currentAccountIds, rowsUpdated, nil
+}
+
+// prepareUserUpdate validates the arguments of UpdateUser and returns a
+// clone of user along with the fields to update, the fields to set to null
+// and the options of the update.
+func (r *Repository) prepareUserUpdate(ctx context.Context, op errors.Op, user *User, version uint32, fieldMaskPaths []string, opt ...Option) (*User, []string, []string, []db.Option, error) {
 	if user == nil {
-		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing user")
+		return nil, nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing user")
 	}
 	if user.PublicId == "" {
-		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+		return nil, nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
 	}
 	for _, f := range fieldMaskPaths {
 		switch {
 		case strings.EqualFold("name", f):
 		case strings.EqualFold("description", f):
+		case strings.EqualFold("disabled", f):
 		default:
-			return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
+			return nil, nil, nil, nil, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
 		}
 	}
 	var dbMask, nullFields []string
@@ -86,18 +139,19 @@ func (r *Repository) UpdateUser(ctx context.Context, user *User, version uint32,
 		map[string]interface{}{
 			"name":        user.Name,
 			"description": user.Description,
+			"disabled":    user.Disabled,
 		},
 		fieldMaskPaths,
-		nil,
+		[]string{"disabled"},
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
-		return nil, nil, db.NoRowsAffected, errors.E(ctx, errors.WithCode(errors.EmptyFieldMask), errors.WithOp(op))
+		return nil, nil, nil, nil, errors.E(ctx, errors.WithCode(errors.EmptyFieldMask), errors.WithOp(op))
 	}
 
 	u := user.Clone().(*User)
 	metadata, err := r.stdMetadata(ctx, u)
 	if err != nil {
-		return nil, nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+		return nil, nil, nil, nil, errors.Wrap(ctx, err, op)
 	}
 	metadata["op-type"] = []string{oplog.OpType_OP_TYPE_UPDATE.String()}
 
@@ -111,64 +165,57 @@ func (r *Repository) UpdateUser(ctx context.Context, user *User, version uint32,
 
 	scope, err := u.GetScope(ctx, r.reader)
 	if err != nil {
-		return nil, nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get scope"))
+		return nil, nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get scope"))
 	}
 
 	oplogWrapper, err := r.kms.GetWrapper(ctx, scope.GetPublicId(), kms.KeyPurposeOplog)
 	if err != nil {
-		return nil, nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+		return nil, nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
 	}
 	dbOpts = append(dbOpts, db.WithOplog(oplogWrapper, metadata))
+	return u, dbMask, nullFields, dbOpts, nil
+}
 
-	var rowsUpdated int
-	var returnedUser *User
-	var currentAccountIds []string
-	_, err = r.writer.DoTx(
+func (r *Repository) updateUser(ctx context.Context, op errors.Op, reader db.Reader, w db.Writer, u *User, dbMask, nullFields []string, dbOpts []db.Option) (*User, []string, int, error) {
+	returnedUser := u.Clone().(*User)
+	rowsUpdated, err := w.Update(
 		ctx,
-		db.StdRetryCnt,
-		db.ExpBackoff{},
-		func(reader db.Reader, w db.Writer) error {
-			returnedUser = u.Clone().(*User)
-			rowsUpdated, err = w.Update(
-				ctx,
-				returnedUser,
-				dbMask,
-				nullFields,
-				dbOpts...,
-			)
-			if err != nil {
-				return errors.Wrap(ctx, err, op)
-			}
-			if rowsUpdated > 1 {
-				// return err, which will result in a rollback of the update
-				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
-			}
-			txRepo := &Repository{
-				reader: reader,
-				writer: w,
-				kms:    r.kms,
-				// intentionally not setting the defaultLimit
-			}
-			returnedUser, err = txRepo.lookupUser(ctx, user.PublicId)
-			if err != nil {
-				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to retrieve current user after update"))
-			}
-			currentAccountIds, err = txRepo.ListUserAccounts(ctx, user.PublicId)
-			if err != nil {
-				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to retrieve current account ids after update"))
-			}
-			return nil
-		},
+		returnedUser,
+		dbMask,
+		nullFields,
+		dbOpts...,
 	)
 	if err != nil {
-		if errors.IsUniqueError(err) {
-			return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("user %s already exists in org %s", user.Name, user.ScopeId))
-		}
-		return nil, nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for %s", user.PublicId)))
+		return nil, nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	if rowsUpdated > 1 {
+		// return err, which will result in a rollback of the update
+		return nil, nil, db.NoRowsAffected, errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+	}
+	txRepo := &Repository{
+		reader: reader,
+		writer: w,
+		kms:    r.kms,
+		// intentionally not setting the defaultLimit
+	}
+	returnedUser, err = txRepo.lookupUser(ctx, u.PublicId)
+	if err != nil {
+		return nil, nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to retrieve current user after update"))
+	}
+	currentAccountIds, err := txRepo.ListUserAccounts(ctx, u.PublicId)
+	if err != nil {
+		return nil, nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to retrieve current account ids after update"))
 	}
 	return returnedUser, currentAccountIds, rowsUpdated, nil
 }
 
+func updateUserError(ctx context.Context, op errors.Op, user *User, err error) error {
+	if errors.IsUniqueError(err) {
+		return errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("user %s already exists in org %s", user.Name, user.ScopeId))
+	}
+	return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for %s", user.PublicId)))
+}
+
 // LookupUser will look up a user and its associated account ids in the
 // repository.  If the user is not found, it will return nil, nil, nil.
 func (r *Repository) LookupUser(ctx context.Context, userId string, _ ...Option) (*User, []string, error) {
@@ -205,6 +252,44 @@ func (r *Repository) DeleteUser(ctx context.Context, withPublicId string, _ ...O
 	return rowsDeleted, nil
 }
 
+// DeleteUserTx deletes a user like DeleteUser, but with reader and w, the
+// reader and writer of a transaction started by the caller, instead of in a
+// transaction of its own.
+func (r *Repository) DeleteUserTx(ctx context.Context, reader db.Reader, w db.Writer, withPublicId string) (int, error) {
+	const op = "iam.(Repository).DeleteUserTx"
+	if reader == nil {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing reader")
+	}
+	if w == nil {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing writer")
+	}
+	if withPublicId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	user := AllocUser()
+	user.PublicId = withPublicId
+	if err := reader.LookupByPublicId(ctx, &user); err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for %s", withPublicId)))
+	}
+	metadata, err := r.stdMetadata(ctx, &user)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("error getting metadata"))
+	}
+	metadata["op-type"] = []string{oplog.OpType_OP_TYPE_DELETE.String()}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, user.GetScopeId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+	rowsDeleted, err := w.Delete(ctx, user.Clone(), db.WithOplog(oplogWrapper, metadata))
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for %s", withPublicId)))
+	}
+	if rowsDeleted > 1 {
+		return db.NoRowsAffected, errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+	}
+	return rowsDeleted, nil
+}
+
 // ListUsers lists users in the given scopes and supports the WithLimit option.
 func (r *Repository) ListUsers(ctx context.Context, withScopeIds []string, opt ...Option) ([]*User, error) {
 	const op = "iam.(Repository).ListUsers"
@@ -388,82 +473,153 @@ func (r *Repository) ListUserAccounts(ctx context.Context, userId string, opt ..
 	return ids, nil
 }
 
+// ListUserManagedGroups returns the ids of the managed groups that any of the
+// user's accounts are members of. Returns nil, nil when no managed groups are
+// found. No options are currently supported.
+func (r *Repository) ListUserManagedGroups(ctx context.Context, userId string, _ ...Option) ([]string, error) {
+	const op = "iam.(Repository).ListUserManagedGroups"
+	if userId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	}
+	rows, err := r.reader.Query(ctx, userManagedGroupsQuery, []interface{}{userId})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to query managed groups for user %s", userId)))
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to scan managed group id"))
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get next managed group id"))
+	}
+	return ids, nil
+}
+
 // AddUserAccounts will associate a user with existing accounts and
 // return a list of all associated account ids for the user. The accounts must
 // not already be associated with different users.  No options are currently
 // supported.
 func (r *Repository) AddUserAccounts(ctx context.Context, userId string, userVersion uint32, accountIds []string, _ ...Option) ([]string, error) {
 	const op = "iam.(Repository).AddUserAccounts"
+	user, oplogWrapper, err := r.prepareAddUserAccounts(ctx, op, userId, userVersion, accountIds)
+	if err != nil {
+		return nil, err
+	}
+
+	var currentAccountIds []string
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			var err error
+			currentAccountIds, err = r.addUserAccounts(ctx, op, reader, w, oplogWrapper, user, userVersion, accountIds)
+			return err
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return currentAccountIds, nil
+}
+
+// AddUserAccountsTx associates a user with existing accounts like
+// AddUserAccounts, but with reader and w, the reader and writer of a
+// transaction started by the caller, instead of in a transaction of its
+// own.
+func (r *Repository) AddUserAccountsTx(ctx context.Context, reader db.Reader, w db.Writer, userId string, userVersion uint32, accountIds []string) ([]string, error) {
+	const op = "iam.(Repository).AddUserAccountsTx"
+	if reader == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing reader")
+	}
+	if w == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing writer")
+	}
+	txRepo := &Repository{
+		reader: reader,
+		writer: w,
+		kms:    r.kms,
+	}
+	user, oplogWrapper, err := txRepo.prepareAddUserAccounts(ctx, op, userId, userVersion, accountIds)
+	if err != nil {
+		return nil, err
+	}
+	currentAccountIds, err := r.addUserAccounts(ctx, op, reader, w, oplogWrapper, user, userVersion, accountIds)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return currentAccountIds, nil
+}
+
+// prepareAddUserAccounts validates the arguments of AddUserAccounts and
+// returns the user along with the oplog wrapper of its scope.
+func (r *Repository) prepareAddUserAccounts(ctx context.Context, op errors.Op, userId string, userVersion uint32, accountIds []string) (*User, wrapping.Wrapper, error) {
 	if userId == "" {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
 	}
 	if userVersion == 0 {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user version")
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing user version")
 	}
 	if len(accountIds) == 0 {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing account ids")
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing account ids")
 	}
 
 	user, err := r.lookupUser(ctx, userId)
 	if err != nil {
-		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to lookup user %s", userId)))
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to lookup user %s", userId)))
 	}
 	oplogWrapper, err := r.kms.GetWrapper(ctx, user.ScopeId, kms.KeyPurposeOplog)
 	if err != nil {
-		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
 	}
+	return user, oplogWrapper, nil
+}
 
-	var currentAccountIds []string
-	_, err = r.writer.DoTx(
-		ctx,
-		db.StdRetryCnt,
-		db.ExpBackoff{},
-		func(reader db.Reader, w db.Writer) error {
-			userTicket, err := w.GetTicket(user)
-			if err != nil {
-				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
-			}
-			updatedUser := AllocUser()
-			updatedUser.PublicId = userId
-			updatedUser.Version = userVersion + 1
-			var userOplogMsg oplog.Message
-			rowsUpdated, err := w.Update(ctx, &updatedUser, []string{"Version"}, nil, db.NewOplogMsg(&userOplogMsg), db.WithVersion(&userVersion))
-			if err != nil {
-				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get user version"))
-			}
-			if rowsUpdated != 1 {
-				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated user and %d rows updated", rowsUpdated))
-			}
-			if err := associateUserWithAccounts(ctx, r.kms, reader, w, user.PublicId, accountIds); err != nil {
-				return errors.Wrap(ctx, err, op)
-			}
-			metadata := oplog.Metadata{
-				"op-type":            []string{oplog.OpType_OP_TYPE_UPDATE.String()},
-				"scope-id":           []string{user.ScopeId},
-				"scope-type":         []string{scope.Org.String()},
-				"resource-public-id": []string{user.PublicId},
-			}
-			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, userTicket, metadata, []*oplog.Message{&userOplogMsg}); err != nil {
-				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
-			}
-			// we need a new repo, that's using the same reader/writer as this TxHandler
-			txRepo := &Repository{
-				reader: reader,
-				writer: w,
-				kms:    r.kms,
-				// intentionally not setting the defaultLimit, so we'll get all
-				// the account ids without a limit
-			}
-			currentAccountIds, err = txRepo.ListUserAccounts(ctx, user.PublicId)
-			if err != nil {
-				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to retrieve current account ids after adds"))
-			}
-			return nil
-		},
-	)
+func (r *Repository) addUserAccounts(ctx context.Context, op errors.Op, reader db.Reader, w db.Writer, oplogWrapper wrapping.Wrapper, user *User, userVersion uint32, accountIds []string) ([]string, error) {
+	userTicket, err := w.GetTicket(user)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+	}
+	updatedUser := AllocUser()
+	updatedUser.PublicId = user.PublicId
+	updatedUser.Version = userVersion + 1
+	var userOplogMsg oplog.Message
+	rowsUpdated, err := w.Update(ctx, &updatedUser, []string{"Version"}, nil, db.NewOplogMsg(&userOplogMsg), db.WithVersion(&userVersion))
 	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get user version"))
+	}
+	if rowsUpdated != 1 {
+		return nil, errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated user and %d rows updated", rowsUpdated))
+	}
+	if err := associateUserWithAccounts(ctx, r.kms, reader, w, user.PublicId, accountIds); err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
+	metadata := oplog.Metadata{
+		"op-type":            []string{oplog.OpType_OP_TYPE_UPDATE.String()},
+		"scope-id":           []string{user.ScopeId},
+		"scope-type":         []string{scope.Org.String()},
+		"resource-public-id": []string{user.PublicId},
+	}
+	if err := w.WriteOplogEntryWith(ctx, oplogWrapper, userTicket, metadata, []*oplog.Message{&userOplogMsg}); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+	}
+	// we need a new repo, that's using the same reader/writer as this TxHandler
+	txRepo := &Repository{
+		reader: reader,
+		writer: w,
+		kms:    r.kms,
+		// intentionally not setting the defaultLimit, so we'll get all
+		// the account ids without a limit
+	}
+	currentAccountIds, err := txRepo.ListUserAccounts(ctx, user.PublicId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to retrieve current account ids after adds"))
+	}
 	return currentAccountIds, nil
 }
 
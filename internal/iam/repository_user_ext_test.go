@@ -0,0 +1,80 @@
+package iam_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ListUserManagedGroups(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	repo := iam.TestRepo(t, conn, wrap)
+	org, _ := iam.TestScopes(t, repo)
+
+	kmsCache := kms.TestKms(t, conn, wrap)
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	authMethod := oidc.TestAuthMethod(
+		t, conn, databaseWrapper, org.GetPublicId(), oidc.ActivePrivateState,
+		"alice-rp", "fido",
+		oidc.WithSigningAlgs(oidc.RS256),
+		oidc.WithIssuer(oidc.TestConvertToUrls(t, "https://www.alice.com")[0]),
+		oidc.WithApiUrl(oidc.TestConvertToUrls(t, "https://www.alice.com/callback")[0]),
+	)
+
+	acct := oidc.TestAccount(t, conn, authMethod, "sub-1")
+	user := iam.TestUser(t, repo, org.PublicId, iam.WithAccountIds(acct.PublicId))
+	noGroupsUser := iam.TestUser(t, repo, org.PublicId)
+
+	mg1 := oidc.TestManagedGroup(t, conn, authMethod, oidc.TestFakeManagedGroupFilter)
+	mg2 := oidc.TestManagedGroup(t, conn, authMethod, oidc.TestFakeManagedGroupFilter)
+	_ = oidc.TestManagedGroup(t, conn, authMethod, oidc.TestFakeManagedGroupFilter)
+	oidc.TestManagedGroupMember(t, conn, mg1.PublicId, acct.PublicId)
+	oidc.TestManagedGroupMember(t, conn, mg2.PublicId, acct.PublicId)
+
+	tests := []struct {
+		name      string
+		userId    string
+		want      []string
+		wantIsErr errors.Code
+	}{
+		{
+			name:      "missing-user-id",
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:   "no-groups",
+			userId: noGroupsUser.PublicId,
+		},
+		{
+			name:   "valid",
+			userId: user.PublicId,
+			want:   []string{mg1.PublicId, mg2.PublicId},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.ListUserManagedGroups(ctx, tt.userId)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.True(errors.Match(errors.T(tt.wantIsErr), err))
+				return
+			}
+			require.NoError(err)
+			assert.ElementsMatch(tt.want, got)
+		})
+	}
+}
@@ -214,6 +214,7 @@ func TestRepository_UpdateUser(t *testing.T) {
 	type args struct {
 		name           string
 		description    string
+		disabled       bool
 		fieldMaskPaths []string
 		opt            []iam.Option
 		ScopeId        string
@@ -285,6 +286,16 @@ func TestRepository_UpdateUser(t *testing.T) {
 			wantErr:        false,
 			wantRowsUpdate: 1,
 		},
+		{
+			name: "disabled",
+			args: args{
+				disabled:       true,
+				fieldMaskPaths: []string{"Disabled"},
+				ScopeId:        org.PublicId,
+			},
+			wantErr:        false,
+			wantRowsUpdate: 1,
+		},
 		{
 			name: "empty-field-mask",
 			args: args{
@@ -439,6 +450,7 @@ func TestRepository_UpdateUser(t *testing.T) {
 			updateUser.ScopeId = tt.args.ScopeId
 			updateUser.Name = tt.args.name
 			updateUser.Description = tt.args.description
+			updateUser.Disabled = tt.args.disabled
 
 			var userAfterUpdate *iam.User
 			var acctIdsAfterUpdate []string
@@ -470,6 +482,7 @@ func TestRepository_UpdateUser(t *testing.T) {
 			assert.Equal(wantFullName, userAfterUpdate.FullName)
 			assert.Equal(wantEmail, userAfterUpdate.Email)
 			assert.Equal(wantPrimaryAccountId, userAfterUpdate.PrimaryAccountId)
+			assert.Equal(tt.args.disabled, userAfterUpdate.Disabled)
 
 			foundUser, foundAccountIds, err := repo.LookupUser(context.Background(), u.PublicId)
 			require.NoError(err)
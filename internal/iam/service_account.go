@@ -0,0 +1,273 @@
+package iam
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/cap/jwt"
+	"github.com/hashicorp/go-secure-stdlib/base62"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultServiceAccountTableName           = "iam_service_account"
+	defaultServiceAccountCredentialTableName = "iam_service_account_credential"
+
+	clientSecretLength = 32
+)
+
+// ServiceAccountCredentialType defines the possible types of service account
+// credentials.
+type ServiceAccountCredentialType string
+
+const (
+	// JwtPublicKeyCredentialType is a PEM encoded public key which verifies
+	// JWTs signed by the service account.
+	JwtPublicKeyCredentialType ServiceAccountCredentialType = "jwt-public-key"
+
+	// ClientSecretCredentialType is a generated secret which is presented
+	// along with the credential's id as the client id.
+	ClientSecretCredentialType ServiceAccountCredentialType = "client-secret"
+)
+
+// ServiceAccount is a principal for automation. Service accounts can be
+// assigned to roles and authenticate with the credentials registered on them
+// instead of with an auth method. They are scoped to global or an org.
+type ServiceAccount struct {
+	*store.ServiceAccount
+	tableName string `gorm:"-"`
+}
+
+// ensure that ServiceAccount implements the interfaces of: Resource,
+// Cloneable, and db.VetForWriter.
+var (
+	_ Resource        = (*ServiceAccount)(nil)
+	_ Cloneable       = (*ServiceAccount)(nil)
+	_ db.VetForWriter = (*ServiceAccount)(nil)
+)
+
+// NewServiceAccount creates a new in memory service account with a scope
+// (global/org) and allowed options include: WithDescription, WithName.
+func NewServiceAccount(scopeId string, opt ...Option) (*ServiceAccount, error) {
+	const op = "iam.NewServiceAccount"
+	if scopeId == "" {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing scope id")
+	}
+	opts := getOpts(opt...)
+	return &ServiceAccount{
+		ServiceAccount: &store.ServiceAccount{
+			Name:        opts.withName,
+			Description: opts.withDescription,
+			ScopeId:     scopeId,
+		},
+	}, nil
+}
+
+func allocServiceAccount() ServiceAccount {
+	return ServiceAccount{
+		ServiceAccount: &store.ServiceAccount{},
+	}
+}
+
+// Clone creates a clone of the ServiceAccount.
+func (s *ServiceAccount) Clone() interface{} {
+	cp := proto.Clone(s.ServiceAccount)
+	return &ServiceAccount{
+		ServiceAccount: cp.(*store.ServiceAccount),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the service
+// account before it's written.
+func (s *ServiceAccount) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	const op = "iam.(ServiceAccount).VetForWrite"
+	if s.PublicId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	if err := validateScopeForWrite(ctx, r, s, opType, opt...); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+func (*ServiceAccount) validScopeTypes() []scope.Type {
+	return []scope.Type{scope.Global, scope.Org}
+}
+
+// GetScope returns the scope for the ServiceAccount.
+func (s *ServiceAccount) GetScope(ctx context.Context, r db.Reader) (*Scope, error) {
+	return LookupScope(ctx, r, s)
+}
+
+// ResourceType returns the type of the ServiceAccount.
+func (*ServiceAccount) ResourceType() resource.Type { return resource.ServiceAccount }
+
+// Actions returns the available actions for ServiceAccount
+func (*ServiceAccount) Actions() map[string]action.Type {
+	ret := CrudActions()
+	ret[action.AddCredential.String()] = action.AddCredential
+	ret[action.RemoveCredential.String()] = action.RemoveCredential
+	ret[action.Authenticate.String()] = action.Authenticate
+	return ret
+}
+
+// TableName returns the tablename to override the default gorm table name.
+func (s *ServiceAccount) TableName() string {
+	if s.tableName != "" {
+		return s.tableName
+	}
+	return defaultServiceAccountTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (s *ServiceAccount) SetTableName(n string) {
+	s.tableName = n
+}
+
+// ServiceAccountCredential is a credential a service account authenticates
+// with. Credentials are immutable; they are rotated by adding a new
+// credential and removing the old one.
+type ServiceAccountCredential struct {
+	*store.ServiceAccountCredential
+	tableName string `gorm:"-"`
+}
+
+// ensure that ServiceAccountCredential implements the interfaces of:
+// Cloneable and db.VetForWriter.
+var (
+	_ Cloneable       = (*ServiceAccountCredential)(nil)
+	_ db.VetForWriter = (*ServiceAccountCredential)(nil)
+)
+
+// NewServiceAccountPublicKey creates a new in memory jwt-public-key
+// credential for the service account serviceAccountId. The publicKeyPem must
+// be a PEM encoded RSA or ECDSA public key. WithExpirationTime is
+// the only supported option.
+func NewServiceAccountPublicKey(serviceAccountId, publicKeyPem string, opt ...Option) (*ServiceAccountCredential, error) {
+	const op = "iam.NewServiceAccountPublicKey"
+	if serviceAccountId == "" {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing service account id")
+	}
+	publicKeyPem = strings.TrimSpace(publicKeyPem)
+	if publicKeyPem == "" {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing public key")
+	}
+	if _, err := jwt.ParsePublicKeyPEM([]byte(publicKeyPem)); err != nil {
+		return nil, errors.WrapDeprecated(err, op, errors.WithCode(errors.InvalidParameter), errors.WithMsg("unable to parse public key"))
+	}
+	c := &ServiceAccountCredential{
+		ServiceAccountCredential: &store.ServiceAccountCredential{
+			ServiceAccountId: serviceAccountId,
+			Type:             string(JwtPublicKeyCredentialType),
+			PublicKey:        publicKeyPem,
+		},
+	}
+	c.setExpirationTime(getOpts(opt...))
+	return c, nil
+}
+
+// NewServiceAccountClientSecret creates a new in memory client-secret
+// credential for the service account serviceAccountId and returns it along
+// with its generated secret. Only the hash of the secret is stored, so the
+// secret can not be retrieved later. WithExpirationTime is the only supported
+// option.
+func NewServiceAccountClientSecret(serviceAccountId string, opt ...Option) (*ServiceAccountCredential, string, error) {
+	const op = "iam.NewServiceAccountClientSecret"
+	if serviceAccountId == "" {
+		return nil, "", errors.NewDeprecated(errors.InvalidParameter, op, "missing service account id")
+	}
+	secret, err := base62.Random(clientSecretLength)
+	if err != nil {
+		return nil, "", errors.WrapDeprecated(err, op, errors.WithCode(errors.Io))
+	}
+	h := sha256.Sum256([]byte(secret))
+	c := &ServiceAccountCredential{
+		ServiceAccountCredential: &store.ServiceAccountCredential{
+			ServiceAccountId: serviceAccountId,
+			Type:             string(ClientSecretCredentialType),
+			SecretHash:       h[:],
+		},
+	}
+	c.setExpirationTime(getOpts(opt...))
+	return c, secret, nil
+}
+
+func (c *ServiceAccountCredential) setExpirationTime(opts options) {
+	if !opts.withExpirationTime.IsZero() {
+		c.ExpirationTime = timestamp.New(opts.withExpirationTime)
+	}
+}
+
+func allocServiceAccountCredential() ServiceAccountCredential {
+	return ServiceAccountCredential{
+		ServiceAccountCredential: &store.ServiceAccountCredential{},
+	}
+}
+
+// Clone creates a clone of the ServiceAccountCredential.
+func (c *ServiceAccountCredential) Clone() interface{} {
+	cp := proto.Clone(c.ServiceAccountCredential)
+	return &ServiceAccountCredential{
+		ServiceAccountCredential: cp.(*store.ServiceAccountCredential),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface for service account
+// credentials.
+func (c *ServiceAccountCredential) VetForWrite(ctx context.Context, _ db.Reader, opType db.OpType, _ ...db.Option) error {
+	const op = "iam.(ServiceAccountCredential).VetForWrite"
+	if c.PublicId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	if opType == db.UpdateOp {
+		return errors.New(ctx, errors.InvalidParameter, op, "service account credentials are immutable")
+	}
+	if c.ServiceAccountId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing service account id")
+	}
+	return nil
+}
+
+// Expired reports whether the credential has an expiration time which has
+// passed.
+func (c *ServiceAccountCredential) Expired() bool {
+	exp := c.GetExpirationTime()
+	return exp.GetTimestamp() != nil && !exp.AsTime().After(time.Now())
+}
+
+// matchesSecret reports whether secret is the secret of a client-secret
+// credential.
+func (c *ServiceAccountCredential) matchesSecret(secret string) bool {
+	if c.GetType() != string(ClientSecretCredentialType) {
+		return false
+	}
+	h := sha256.Sum256([]byte(secret))
+	return subtle.ConstantTimeCompare(h[:], c.GetSecretHash()) == 1
+}
+
+// TableName returns the tablename to override the default gorm table name.
+func (c *ServiceAccountCredential) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return defaultServiceAccountCredentialTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (c *ServiceAccountCredential) SetTableName(n string) {
+	c.tableName = n
+}
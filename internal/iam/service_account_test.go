@@ -0,0 +1,145 @@
+package iam
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPublicKeyPem returns a new ECDSA P-256 private key and its PEM encoded
+// public key.
+func testPublicKeyPem(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(priv.Public())
+	require.NoError(t, err)
+	return priv, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestNewServiceAccount(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name            string
+		scopeId         string
+		opt             []Option
+		wantErr         bool
+		wantIsErr       errors.Code
+		wantName        string
+		wantDescription string
+	}{
+		{
+			name:            "valid",
+			scopeId:         "o_1234567890",
+			opt:             []Option{WithName("name"), WithDescription("description")},
+			wantName:        "name",
+			wantDescription: "description",
+		},
+		{
+			name:    "valid-no-options",
+			scopeId: "global",
+		},
+		{
+			name:      "no-scope",
+			opt:       []Option{WithName("name")},
+			wantErr:   true,
+			wantIsErr: errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewServiceAccount(tt.scopeId, tt.opt...)
+			if tt.wantErr {
+				require.Error(err)
+				assert.True(errors.Match(errors.T(tt.wantIsErr), err))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.scopeId, got.ScopeId)
+			assert.Equal(tt.wantName, got.Name)
+			assert.Equal(tt.wantDescription, got.Description)
+			assert.Empty(got.PublicId)
+		})
+	}
+}
+
+func TestServiceAccount_Actions(t *testing.T) {
+	assert := assert.New(t)
+	sa := allocServiceAccount()
+	assert.Equal(resource.ServiceAccount, sa.ResourceType())
+	a := sa.Actions()
+	for _, want := range []action.Type{action.Create, action.Read, action.Update, action.Delete, action.AddCredential, action.RemoveCredential, action.Authenticate} {
+		assert.Equal(want, a[want.String()])
+	}
+}
+
+func TestNewServiceAccountPublicKey(t *testing.T) {
+	t.Parallel()
+	_, pubPem := testPublicKeyPem(t)
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := NewServiceAccountPublicKey("sa_1234567890", pubPem, WithExpirationTime(exp))
+		require.NoError(err)
+		assert.Equal("sa_1234567890", got.ServiceAccountId)
+		assert.Equal(string(JwtPublicKeyCredentialType), got.Type)
+		assert.Contains(got.PublicKey, "PUBLIC KEY")
+		assert.Empty(got.SecretHash)
+		assert.True(exp.Equal(got.ExpirationTime.AsTime()))
+		assert.False(got.Expired())
+	})
+	t.Run("missing-service-account", func(t *testing.T) {
+		_, err := NewServiceAccountPublicKey("", pubPem)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("missing-key", func(t *testing.T) {
+		_, err := NewServiceAccountPublicKey("sa_1234567890", "  ")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("invalid-key", func(t *testing.T) {
+		_, err := NewServiceAccountPublicKey("sa_1234567890", "not a key")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
+
+func TestNewServiceAccountClientSecret(t *testing.T) {
+	t.Parallel()
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, secret, err := NewServiceAccountClientSecret("sa_1234567890")
+		require.NoError(err)
+		assert.Equal(string(ClientSecretCredentialType), got.Type)
+		assert.Len(secret, clientSecretLength)
+		assert.Empty(got.PublicKey)
+		assert.NotEqual([]byte(secret), got.SecretHash)
+		assert.True(got.matchesSecret(secret))
+		assert.False(got.matchesSecret(secret + "x"))
+		assert.Nil(got.ExpirationTime)
+		assert.False(got.Expired())
+
+		_, secret2, err := NewServiceAccountClientSecret("sa_1234567890")
+		require.NoError(err)
+		assert.NotEqual(secret, secret2)
+	})
+	t.Run("expired", func(t *testing.T) {
+		got, _, err := NewServiceAccountClientSecret("sa_1234567890", WithExpirationTime(time.Now().Add(-time.Minute)))
+		require.NoError(t, err)
+		assert.True(t, got.Expired())
+	})
+	t.Run("missing-service-account", func(t *testing.T) {
+		_, _, err := NewServiceAccountClientSecret("")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
@@ -253,6 +253,35 @@ func TestManagedGroupRole(t *testing.T, conn *db.DB, roleId, managedGrpId string
 	return r
 }
 
+// TestServiceAccount creates a service account suitable for testing.
+func TestServiceAccount(t *testing.T, conn *db.DB, scopeId string, opt ...Option) *ServiceAccount {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+
+	sa, err := NewServiceAccount(scopeId, opt...)
+	require.NoError(err)
+	id, err := newServiceAccountId()
+	require.NoError(err)
+	sa.PublicId = id
+	err = rw.Create(context.Background(), sa)
+	require.NoError(err)
+	require.NotEmpty(sa.PublicId)
+	return sa
+}
+
+func TestServiceAccountRole(t *testing.T, conn *db.DB, roleId, serviceAccountId string, opt ...Option) *ServiceAccountRole {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+	r, err := NewServiceAccountRole(roleId, serviceAccountId, opt...)
+	require.NoError(err)
+
+	err = rw.Create(context.Background(), r)
+	require.NoError(err)
+	return r
+}
+
 // testAccount is a temporary test function.  TODO - replace with an auth
 // subsystem testAccount function.  If userId is zero value, then an auth
 // account will be created with a null IamUserId
@@ -0,0 +1,120 @@
+// Package label stores the key/value labels attached to targets, host sets
+// and hosts and builds the conditions used to find those resources by their
+// labels.
+package label
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/resource"
+)
+
+// maxLength matches the wt_tagpair domain used for keys and values.
+const maxLength = 512
+
+// table names the label table of a resource type and the column holding the
+// labeled resource's id.
+type table struct {
+	name   string
+	column string
+}
+
+var tables = map[resource.Type]table{
+	resource.Target:  {name: "target_label", column: "target_id"},
+	resource.HostSet: {name: "host_set_label", column: "host_set_id"},
+	resource.Host:    {name: "host_label", column: "host_id"},
+}
+
+func tableFor(ctx context.Context, op errors.Op, typ resource.Type) (table, error) {
+	t, ok := tables[typ]
+	if !ok {
+		return table{}, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%s resources cannot be labeled", typ))
+	}
+	return t, nil
+}
+
+// Validate returns an error unless every key and value of labels is a non
+// empty, lowercase string of at most 512 characters without leading or
+// trailing whitespace. Keys must not contain '=' and neither keys nor values
+// may contain ',' so labels can always be selected with a selector.
+func Validate(ctx context.Context, labels map[string]string) error {
+	const op = "label.Validate"
+	for k, v := range labels {
+		if strings.Contains(k, "=") {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("label key %q contains '='", k))
+		}
+		for _, s := range []string{k, v} {
+			switch {
+			case s == "":
+				return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("label %q has an empty key or value", k))
+			case len(s) > maxLength:
+				return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("label %q is longer than %d characters", k, maxLength))
+			case strings.Contains(s, ","):
+				return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("label %q contains ','", k))
+			case strings.TrimSpace(s) != s, strings.ToLower(s) != s:
+				return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("label %q must be lowercase without surrounding whitespace", k))
+			}
+		}
+	}
+	return nil
+}
+
+// ParseSelector parses a selector of comma separated key=value pairs, e.g.
+// env=prod,team=payments, into labels. A key may only be given once.
+func ParseSelector(ctx context.Context, selector string) (map[string]string, error) {
+	const op = "label.ParseSelector"
+	if selector == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing selector")
+	}
+	pairs := strings.Split(selector, ",")
+	labels := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) != 2 {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q is not of the form key=value", p))
+		}
+		k, v := kv[0], kv[1]
+		if _, dup := labels[k]; dup {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("label %q given more than once", k))
+		}
+		labels[k] = v
+	}
+	if err := Validate(ctx, labels); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return labels, nil
+}
+
+// Where returns a condition, and its arguments, which matches resources of
+// type typ carrying all of labels. The condition applies to the public_id
+// column of the resource's table or view.
+func Where(ctx context.Context, typ resource.Type, labels map[string]string) (string, []interface{}, error) {
+	const op = "label.Where"
+	t, err := tableFor(ctx, op, typ)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(labels) == 0 {
+		return "", nil, errors.New(ctx, errors.InvalidParameter, op, "missing labels")
+	}
+	var where []string
+	var args []interface{}
+	for _, k := range sortedKeys(labels) {
+		where = append(where, fmt.Sprintf(hasLabelCondition, t.column, t.name))
+		args = append(args, k, labels[k])
+	}
+	return strings.Join(where, " and "), args, nil
+}
+
+func sortedKeys(labels map[string]string) []string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,72 @@
+package label
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		labels  map[string]string
+		wantErr bool
+	}{
+		{name: "nil"},
+		{name: "valid", labels: map[string]string{"env": "prod", "team": "payments"}},
+		{name: "empty-key", labels: map[string]string{"": "prod"}, wantErr: true},
+		{name: "empty-value", labels: map[string]string{"env": ""}, wantErr: true},
+		{name: "uppercase", labels: map[string]string{"env": "Prod"}, wantErr: true},
+		{name: "whitespace", labels: map[string]string{" env": "prod"}, wantErr: true},
+		{name: "comma", labels: map[string]string{"env": "prod,dev"}, wantErr: true},
+		{name: "equals-in-key", labels: map[string]string{"env=x": "prod"}, wantErr: true},
+		{name: "too-long", labels: map[string]string{"env": strings.Repeat("a", maxLength+1)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(ctx, tt.labels)
+			if tt.wantErr {
+				assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParseSelector(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	got, err := ParseSelector(ctx, "env=prod,owner=a=b")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod", "owner": "a=b"}, got)
+
+	for _, selector := range []string{"", "env", "env=prod,", "env=prod,env=dev", "env=Prod"} {
+		_, err := ParseSelector(ctx, selector)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err), "selector: %q", selector)
+	}
+}
+
+func TestWhere(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	where, args, err := Where(ctx, resource.Target, map[string]string{"team": "payments", "env": "prod"})
+	require.NoError(t, err)
+	assert.Equal(t,
+		"public_id in (select target_id from target_label where key = ? and value = ?) and "+
+			"public_id in (select target_id from target_label where key = ? and value = ?)", where)
+	assert.Equal(t, []interface{}{"env", "prod", "team", "payments"}, args)
+
+	_, _, err = Where(ctx, resource.Target, nil)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	_, _, err = Where(ctx, resource.User, map[string]string{"env": "prod"})
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+}
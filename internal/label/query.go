@@ -0,0 +1,28 @@
+package label
+
+// The queries below are formatted with the label table name and the column
+// holding the labeled resource's id.
+const (
+	hasLabelCondition = `public_id in (select %s from %s where key = ? and value = ?)`
+
+	deleteLabelsQuery = `
+delete from %s
+ where %s = ?;
+`
+
+	insertLabelQuery = `
+insert into %s
+  (%s, key, value)
+values
+  (?, ?, ?);
+`
+
+	listLabelsQuery = `
+select %s as resource_id,
+       key,
+       value
+  from %s
+ where %s in (?)
+ order by key;
+`
+)
@@ -0,0 +1,109 @@
+package label
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/resource"
+)
+
+// A Repository stores and retrieves the labels of targets, host sets and
+// hosts. It is not safe to use a repository concurrently.
+type Repository struct {
+	reader db.Reader
+	writer db.Writer
+}
+
+// NewRepository creates a new Repository. The returned repository should
+// only be used for one transaction and it is not safe for concurrent go
+// routines to access it.
+func NewRepository(r db.Reader, w db.Writer) (*Repository, error) {
+	const op = "label.NewRepository"
+	switch {
+	case r == nil:
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "nil reader")
+	case w == nil:
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "nil writer")
+	}
+	return &Repository{
+		reader: r,
+		writer: w,
+	}, nil
+}
+
+// SetLabels replaces the labels of the resource of type typ identified by
+// id with labels. An empty labels removes all of the resource's labels.
+func (r *Repository) SetLabels(ctx context.Context, typ resource.Type, id string, labels map[string]string) error {
+	const op = "label.(Repository).SetLabels"
+	if id == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing id")
+	}
+	t, err := tableFor(ctx, op, typ)
+	if err != nil {
+		return err
+	}
+	if err := Validate(ctx, labels); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			if _, err := w.Exec(ctx, fmt.Sprintf(deleteLabelsQuery, t.name, t.column), []interface{}{id}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete labels"))
+			}
+			for _, k := range sortedKeys(labels) {
+				if _, err := w.Exec(ctx, fmt.Sprintf(insertLabelQuery, t.name, t.column), []interface{}{id, k, labels[k]}); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to insert label %q", k)))
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg(id))
+	}
+	return nil
+}
+
+// ListLabels returns the labels of the resources of type typ identified by
+// ids, keyed by resource id. Resources without labels are not included.
+func (r *Repository) ListLabels(ctx context.Context, typ resource.Type, ids []string) (map[string]map[string]string, error) {
+	const op = "label.(Repository).ListLabels"
+	t, err := tableFor(ctx, op, typ)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(listLabelsQuery, t.column, t.name, t.column), []interface{}{ids})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	labels := make(map[string]map[string]string)
+	for rows.Next() {
+		var l struct {
+			ResourceId string
+			Key        string
+			Value      string
+		}
+		if err := r.reader.ScanRows(rows, &l); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if labels[l.ResourceId] == nil {
+			labels[l.ResourceId] = make(map[string]string)
+		}
+		labels[l.ResourceId][l.Key] = l.Value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return labels, nil
+}
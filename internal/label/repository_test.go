@@ -0,0 +1,80 @@
+package label_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/label"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Labels(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	repo, err := label.NewRepository(rw, rw)
+	require.NoError(t, err)
+
+	tar := tcp.TestTarget(ctx, t, conn, proj.PublicId, "target")
+	other := tcp.TestTarget(ctx, t, conn, proj.PublicId, "other")
+	cat := static.TestCatalogs(t, conn, proj.PublicId, 1)[0]
+	h := static.TestHosts(t, conn, cat.PublicId, 1)[0]
+	set := static.TestSets(t, conn, cat.PublicId, 1)[0]
+
+	t.Run("targets", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		require.NoError(repo.SetLabels(ctx, resource.Target, tar.GetPublicId(), map[string]string{"env": "prod", "team": "payments"}))
+		require.NoError(repo.SetLabels(ctx, resource.Target, other.GetPublicId(), map[string]string{"env": "dev"}))
+
+		got, err := repo.ListLabels(ctx, resource.Target, []string{tar.GetPublicId(), other.GetPublicId()})
+		require.NoError(err)
+		assert.Equal(map[string]map[string]string{
+			tar.GetPublicId():   {"env": "prod", "team": "payments"},
+			other.GetPublicId(): {"env": "dev"},
+		}, got)
+
+		// Setting replaces all labels.
+		require.NoError(repo.SetLabels(ctx, resource.Target, tar.GetPublicId(), map[string]string{"env": "staging"}))
+		got, err = repo.ListLabels(ctx, resource.Target, []string{tar.GetPublicId()})
+		require.NoError(err)
+		assert.Equal(map[string]map[string]string{tar.GetPublicId(): {"env": "staging"}}, got)
+
+		require.NoError(repo.SetLabels(ctx, resource.Target, tar.GetPublicId(), nil))
+		got, err = repo.ListLabels(ctx, resource.Target, []string{tar.GetPublicId()})
+		require.NoError(err)
+		assert.Empty(got)
+	})
+	t.Run("hosts-and-sets", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		require.NoError(repo.SetLabels(ctx, resource.Host, h.PublicId, map[string]string{"rack": "r1"}))
+		require.NoError(repo.SetLabels(ctx, resource.HostSet, set.PublicId, map[string]string{"env": "prod"}))
+
+		got, err := repo.ListLabels(ctx, resource.Host, []string{h.PublicId})
+		require.NoError(err)
+		assert.Equal(map[string]map[string]string{h.PublicId: {"rack": "r1"}}, got)
+		got, err = repo.ListLabels(ctx, resource.HostSet, []string{set.PublicId})
+		require.NoError(err)
+		assert.Equal(map[string]map[string]string{set.PublicId: {"env": "prod"}}, got)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert := assert.New(t)
+		err := repo.SetLabels(ctx, resource.Target, "", map[string]string{"env": "prod"})
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		err = repo.SetLabels(ctx, resource.User, tar.GetPublicId(), map[string]string{"env": "prod"})
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		err = repo.SetLabels(ctx, resource.Target, tar.GetPublicId(), map[string]string{"env": "Prod"})
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		err = repo.SetLabels(ctx, resource.Target, "ttcp_1234567890", map[string]string{"env": "prod"})
+		assert.Error(err)
+	})
+}
@@ -0,0 +1,152 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// DeliveryStatusType is the status of the delivery of an event to a
+// notification.
+type DeliveryStatusType string
+
+const (
+	// DeliveryPending is the status of a delivery which has not succeeded
+	// yet and will be attempted again.
+	DeliveryPending DeliveryStatusType = "pending"
+	// DeliveryDelivered is the status of a delivery the webhook responded
+	// to with a 2xx status code.
+	DeliveryDelivered DeliveryStatusType = "delivered"
+	// DeliveryFailed is the status of a delivery which ran out of
+	// attempts.
+	DeliveryFailed DeliveryStatusType = "failed"
+)
+
+// The headers sent with each delivery.
+const (
+	// EventTypeHeader holds the type of the delivered event.
+	EventTypeHeader = "X-Boundary-Event"
+	// DeliveryIdHeader holds the id of the delivery, which is the same for
+	// each attempt and can be used to deduplicate retried deliveries.
+	DeliveryIdHeader = "X-Boundary-Delivery"
+	// TimestampHeader holds the time the request was signed as seconds
+	// since the Unix epoch.
+	TimestampHeader = "X-Boundary-Timestamp"
+	// SignatureHeader holds the signature of the request, see Sign.
+	SignatureHeader = "X-Boundary-Signature"
+
+	signatureVersion = "v1"
+)
+
+const (
+	// maxDeliveryAttempts is the number of attempts after which a delivery
+	// is failed.
+	maxDeliveryAttempts = 8
+	// initialRetryDelay is the delay before the second attempt of a
+	// delivery. The delay doubles with each further attempt, up to
+	// maxRetryDelay.
+	initialRetryDelay = 30 * time.Second
+	maxRetryDelay     = time.Hour
+
+	deliveryTimeout = 10 * time.Second
+
+	// maxErrorLength is the longest error message stored for a delivery.
+	maxErrorLength = 1024
+	// maxResponseBodyLength is the most of a failed response's body which
+	// is included in the error of the delivery.
+	maxResponseBodyLength = 256
+)
+
+// Sign returns the signature of a delivery's body at timestamp using the
+// notification's secret. The signature is "v1=" followed by the hex encoded
+// HMAC-SHA256 of the timestamp, a period, and the body. Receivers should
+// compute the signature with the value of the TimestampHeader and compare it
+// in constant time with the value of the SignatureHeader, and reject
+// requests with timestamps too far in the past.
+func Sign(secret []byte, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryDelay returns the delay before the next attempt of a delivery which
+// has been attempted attempts times.
+func retryDelay(attempts int) time.Duration {
+	d := initialRetryDelay
+	for i := 1; i < attempts && d < maxRetryDelay; i++ {
+		d *= 2
+	}
+	if d > maxRetryDelay {
+		d = maxRetryDelay
+	}
+	return d
+}
+
+// deliveryResult is the outcome of a single delivery attempt.
+type deliveryResult struct {
+	responseCode int
+	err          error
+}
+
+// send posts the event to webhookUrl, signed with secret. It returns the
+// response code, if any, and an error unless the webhook responded with a
+// 2xx status code.
+func send(ctx context.Context, client *http.Client, webhookUrl string, secret []byte, e *Event, now time.Time) deliveryResult {
+	const op = "notification.send"
+	body, err := json.Marshal(e)
+	if err != nil {
+		return deliveryResult{err: errors.New(ctx, errors.Encode, op, "unable to marshal event", errors.WithWrap(err))}
+	}
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookUrl, bytes.NewReader(body))
+	if err != nil {
+		return deliveryResult{err: errors.New(ctx, errors.InvalidParameter, op, "unable to create request", errors.WithWrap(err))}
+	}
+	ts := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Boundary")
+	req.Header.Set(EventTypeHeader, string(e.Type))
+	req.Header.Set(DeliveryIdHeader, e.Id)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(ts, 10))
+	req.Header.Set(SignatureHeader, Sign(secret, ts, body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return deliveryResult{err: errors.New(ctx, errors.Unknown, op, "request failed", errors.WithWrap(err))}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		return deliveryResult{responseCode: resp.StatusCode}
+	}
+	respBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseBodyLength))
+	return deliveryResult{
+		responseCode: resp.StatusCode,
+		err:          errors.New(ctx, errors.Unknown, op, fmt.Sprintf("unexpected response status %d: %s", resp.StatusCode, bytes.TrimSpace(respBody))),
+	}
+}
+
+// truncateError returns the message of err limited to maxErrorLength bytes.
+func truncateError(err error) string {
+	if err == nil {
+		return ""
+	}
+	msg := err.Error()
+	if len(msg) > maxErrorLength {
+		msg = msg[:maxErrorLength]
+	}
+	return msg
+}
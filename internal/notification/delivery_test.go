@@ -0,0 +1,112 @@
+package notification
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+	secret := []byte("secret")
+	body := []byte(`{"type":"session.authorized"}`)
+	got := Sign(secret, 1600000000, body)
+	assert.True(t, strings.HasPrefix(got, "v1="))
+	assert.Len(t, got, len("v1=")+64)
+	assert.Equal(t, got, Sign(secret, 1600000000, body))
+	assert.NotEqual(t, got, Sign(secret, 1600000001, body))
+	assert.NotEqual(t, got, Sign([]byte("other"), 1600000000, body))
+}
+
+func TestRetryDelay(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, initialRetryDelay, retryDelay(1))
+	assert.Equal(t, 2*initialRetryDelay, retryDelay(2))
+	assert.Equal(t, 4*initialRetryDelay, retryDelay(3))
+	assert.Equal(t, maxRetryDelay, retryDelay(maxDeliveryAttempts))
+	assert.Equal(t, maxRetryDelay, retryDelay(100))
+}
+
+func TestSend(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	secret := []byte("secret")
+	now := time.Now()
+	e := &Event{
+		Id:      "ntfd_1234567890",
+		Type:    SessionTerminated,
+		Time:    now,
+		ScopeId: "p_1234567890",
+		Data:    map[string]interface{}{"session_id": "s_1234567890"},
+	}
+
+	t.Run("delivered", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		var gotHeader http.Header
+		var gotBody []byte
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotHeader = r.Header.Clone()
+			gotBody, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer ts.Close()
+
+		got := send(ctx, ts.Client(), ts.URL, secret, e, now)
+		require.NoError(got.err)
+		assert.Equal(http.StatusNoContent, got.responseCode)
+
+		assert.Equal("application/json", gotHeader.Get("Content-Type"))
+		assert.Equal(string(SessionTerminated), gotHeader.Get(EventTypeHeader))
+		assert.Equal(e.Id, gotHeader.Get(DeliveryIdHeader))
+		assert.Equal(strconv.FormatInt(now.Unix(), 10), gotHeader.Get(TimestampHeader))
+		assert.True(hmac.Equal([]byte(Sign(secret, now.Unix(), gotBody)), []byte(gotHeader.Get(SignatureHeader))))
+
+		var gotEvent Event
+		require.NoError(json.Unmarshal(gotBody, &gotEvent))
+		assert.Equal(e.Id, gotEvent.Id)
+		assert.Equal(e.Data, gotEvent.Data)
+	})
+	t.Run("error-status", func(t *testing.T) {
+		assert := assert.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("try later"))
+		}))
+		defer ts.Close()
+
+		got := send(ctx, ts.Client(), ts.URL, secret, e, now)
+		assert.Error(got.err)
+		assert.Contains(got.err.Error(), "try later")
+		assert.Equal(http.StatusServiceUnavailable, got.responseCode)
+	})
+	t.Run("unreachable", func(t *testing.T) {
+		assert := assert.New(t)
+		ts := httptest.NewServer(http.NotFoundHandler())
+		ts.Close()
+
+		got := send(ctx, ts.Client(), ts.URL, secret, e, now)
+		assert.Error(got.err)
+		assert.Zero(got.responseCode)
+	})
+}
+
+func TestTruncateError(t *testing.T) {
+	t.Parallel()
+	assert.Empty(t, truncateError(nil))
+	long := strings.Repeat("x", maxErrorLength+10)
+	assert.Len(t, truncateError(errString(long)), maxErrorLength)
+}
+
+type errString string
+
+func (e errString) Error() string { return string(e) }
@@ -0,0 +1,181 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/go-bexpr"
+	"github.com/mitchellh/pointerstructure"
+)
+
+// EventType is the type of an event notifications can subscribe to.
+type EventType string
+
+// The event types notifications can subscribe to. The events are written by
+// database triggers, so they are recorded regardless of which controller or
+// API call caused them.
+const (
+	SessionAuthorized EventType = "session.authorized"
+	SessionActivated  EventType = "session.activated"
+	SessionCanceled   EventType = "session.canceled"
+	SessionTerminated EventType = "session.terminated"
+
+	ConnectionConnected EventType = "connection.connected"
+	ConnectionClosed    EventType = "connection.closed"
+
+	RoleCreated          EventType = "role.created"
+	RoleUpdated          EventType = "role.updated"
+	RoleDeleted          EventType = "role.deleted"
+	RoleGrantAdded       EventType = "role.grant-added"
+	RoleGrantRemoved     EventType = "role.grant-removed"
+	RolePrincipalAdded   EventType = "role.principal-added"
+	RolePrincipalRemoved EventType = "role.principal-removed"
+)
+
+var supportedEventTypes = map[EventType]bool{
+	SessionAuthorized:    true,
+	SessionActivated:     true,
+	SessionCanceled:      true,
+	SessionTerminated:    true,
+	ConnectionConnected:  true,
+	ConnectionClosed:     true,
+	RoleCreated:          true,
+	RoleUpdated:          true,
+	RoleDeleted:          true,
+	RoleGrantAdded:       true,
+	RoleGrantRemoved:     true,
+	RolePrincipalAdded:   true,
+	RolePrincipalRemoved: true,
+}
+
+// SupportedEventTypes returns the supported event types in lexical order.
+func SupportedEventTypes() []EventType {
+	ret := make([]EventType, 0, len(supportedEventTypes))
+	for t := range supportedEventTypes {
+		ret = append(ret, t)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+// ValidateEventTypes returns an error if eventTypes is empty, contains an
+// unsupported event type or contains an event type more than once.
+func ValidateEventTypes(ctx context.Context, eventTypes []string) error {
+	const op = "notification.ValidateEventTypes"
+	if len(eventTypes) == 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing event types")
+	}
+	seen := make(map[string]bool, len(eventTypes))
+	for _, t := range eventTypes {
+		if !supportedEventTypes[EventType(t)] {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unsupported event type %q", t))
+		}
+		if seen[t] {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("duplicate event type %q", t))
+		}
+		seen[t] = true
+	}
+	return nil
+}
+
+// ValidateFilter returns an error if filter is not a valid go-bexpr
+// expression. An empty filter is valid and matches all events.
+func ValidateFilter(ctx context.Context, filter string) error {
+	const op = "notification.ValidateFilter"
+	if filter == "" {
+		return nil
+	}
+	if _, err := bexpr.CreateEvaluator(filter); err != nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "invalid filter", errors.WithWrap(err))
+	}
+	return nil
+}
+
+// Event is an event delivered to a notification. It is marshaled to JSON as
+// the body of the webhook request, and filters are evaluated against the
+// same structure, for example:
+//
+//	"/type" == "connection.connected" and "/data/target_name" matches "^prod-"
+type Event struct {
+	// Id is the id of the delivery. It is the same for every attempt to
+	// deliver the event to the notification.
+	Id string `json:"id"`
+	// Type is the type of the event.
+	Type EventType `json:"type"`
+	// Time is the time the event occurred.
+	Time time.Time `json:"time"`
+	// ScopeId is the id of the scope of the resource the event is about.
+	ScopeId string `json:"scope_id"`
+	// NotificationId is the id of the notification the event is delivered
+	// to.
+	NotificationId string `json:"notification_id"`
+	// Text is a human readable summary of the event, which allows chat
+	// tools which accept incoming webhooks to display it as is.
+	Text string `json:"text"`
+	// Data holds the event type specific attributes of the event.
+	Data map[string]interface{} `json:"data"`
+}
+
+// summary returns a human readable summary of the event.
+func (e *Event) summary() string {
+	str := func(k string) string {
+		if v, ok := e.Data[k].(string); ok {
+			return v
+		}
+		return ""
+	}
+	named := func(idKey, nameKey string) string {
+		id, name := str(idKey), str(nameKey)
+		if name == "" || name == id {
+			return id
+		}
+		return fmt.Sprintf("%s (%s)", name, id)
+	}
+	var msg string
+	switch {
+	case strings.HasPrefix(string(e.Type), "session."):
+		msg = fmt.Sprintf("session %s for user %s to target %s", str("session_id"), named("user_id", "user_name"), named("target_id", "target_name"))
+	case strings.HasPrefix(string(e.Type), "connection."):
+		msg = fmt.Sprintf("connection %s of session %s for user %s to target %s", str("connection_id"), str("session_id"), named("user_id", "user_name"), named("target_id", "target_name"))
+	case e.Type == RoleGrantAdded || e.Type == RoleGrantRemoved:
+		msg = fmt.Sprintf("grant %q on role %s", str("grant"), named("role_id", "role_name"))
+	case e.Type == RolePrincipalAdded || e.Type == RolePrincipalRemoved:
+		msg = fmt.Sprintf("%s %s on role %s", str("principal_type"), str("principal_id"), named("role_id", "role_name"))
+	default:
+		msg = fmt.Sprintf("role %s", named("role_id", "role_name"))
+	}
+	return fmt.Sprintf("[Boundary] %s: %s in scope %s", e.Type, msg, e.ScopeId)
+}
+
+// matches reports whether the event matches the go-bexpr filter. An empty
+// filter matches all events.
+func (e *Event) matches(ctx context.Context, filter string) (bool, error) {
+	const op = "notification.(Event).matches"
+	if filter == "" {
+		return true, nil
+	}
+	eval, err := bexpr.CreateEvaluator(filter)
+	if err != nil {
+		return false, errors.New(ctx, errors.InvalidParameter, op, "invalid filter", errors.WithWrap(err))
+	}
+	// Round trip the event through JSON so the filter sees the same
+	// field names and values as the webhook receiver.
+	b, err := json.Marshal(e)
+	if err != nil {
+		return false, errors.New(ctx, errors.Encode, op, "unable to marshal event", errors.WithWrap(err))
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return false, errors.New(ctx, errors.Decode, op, "unable to unmarshal event", errors.WithWrap(err))
+	}
+	match, err := eval.Evaluate(m)
+	if err != nil && !errors.Is(err, pointerstructure.ErrNotFound) {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	return match, nil
+}
@@ -0,0 +1,183 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEventTypes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		eventTypes []string
+		wantErr    bool
+	}{
+		{
+			name:       "valid",
+			eventTypes: []string{string(SessionAuthorized), string(RoleGrantAdded)},
+		},
+		{
+			name:    "empty",
+			wantErr: true,
+		},
+		{
+			name:       "unsupported",
+			eventTypes: []string{"session.created"},
+			wantErr:    true,
+		},
+		{
+			name:       "duplicate",
+			eventTypes: []string{string(SessionAuthorized), string(SessionAuthorized)},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEventTypes(context.Background(), tt.eventTypes)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSupportedEventTypes(t *testing.T) {
+	t.Parallel()
+	got := SupportedEventTypes()
+	assert.Len(t, got, len(supportedEventTypes))
+	assert.IsIncreasing(t, got)
+}
+
+func TestEvent_matches(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	e := &Event{
+		Type:    ConnectionConnected,
+		Time:    time.Now(),
+		ScopeId: "p_1234567890",
+		Data: map[string]interface{}{
+			"session_id":  "s_1234567890",
+			"target_name": "prod-db",
+		},
+	}
+	tests := []struct {
+		name    string
+		filter  string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			want: true,
+		},
+		{
+			name:   "type",
+			filter: `"/type" == "connection.connected"`,
+			want:   true,
+		},
+		{
+			name:   "data",
+			filter: `"/data/target_name" matches "^prod-"`,
+			want:   true,
+		},
+		{
+			name:   "no-match",
+			filter: `"/scope_id" == "p_0987654321"`,
+		},
+		{
+			name:   "missing-field",
+			filter: `"/data/role_id" == "r_1234567890"`,
+		},
+		{
+			name:    "invalid",
+			filter:  `"/type" ==`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := e.matches(ctx, tt.filter)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEvent_summary(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		e    *Event
+		want string
+	}{
+		{
+			name: "session",
+			e: &Event{
+				Type:    SessionAuthorized,
+				ScopeId: "p_1234567890",
+				Data: map[string]interface{}{
+					"session_id":  "s_1234567890",
+					"user_id":     "u_1234567890",
+					"user_name":   "alice",
+					"target_id":   "ttcp_1234567890",
+					"target_name": "ttcp_1234567890",
+				},
+			},
+			want: "[Boundary] session.authorized: session s_1234567890 for user alice (u_1234567890) to target ttcp_1234567890 in scope p_1234567890",
+		},
+		{
+			name: "grant",
+			e: &Event{
+				Type:    RoleGrantAdded,
+				ScopeId: "global",
+				Data: map[string]interface{}{
+					"role_id": "r_1234567890",
+					"grant":   "id=*;type=*;actions=*",
+				},
+			},
+			want: `[Boundary] role.grant-added: grant "id=*;type=*;actions=*" on role r_1234567890 in scope global`,
+		},
+		{
+			name: "principal",
+			e: &Event{
+				Type:    RolePrincipalRemoved,
+				ScopeId: "global",
+				Data: map[string]interface{}{
+					"role_id":        "r_1234567890",
+					"role_name":      "admins",
+					"principal_type": "user",
+					"principal_id":   "u_1234567890",
+				},
+			},
+			want: "[Boundary] role.principal-removed: user u_1234567890 on role admins (r_1234567890) in scope global",
+		},
+		{
+			name: "role",
+			e: &Event{
+				Type:    RoleDeleted,
+				ScopeId: "o_1234567890",
+				Data: map[string]interface{}{
+					"role_id": "r_1234567890",
+				},
+			},
+			want: "[Boundary] role.deleted: role r_1234567890 in scope o_1234567890",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.e.summary())
+		})
+	}
+}
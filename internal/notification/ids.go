@@ -0,0 +1,28 @@
+package notification
+
+import (
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// Id prefixes for the resources in the notification package.
+const (
+	NotificationPrefix = "ntf"
+	deliveryPrefix     = "ntfd"
+)
+
+func newNotificationId() (string, error) {
+	id, err := db.NewPublicId(NotificationPrefix)
+	if err != nil {
+		return "", errors.WrapDeprecated(err, "notification.newNotificationId")
+	}
+	return id, nil
+}
+
+func newDeliveryId() (string, error) {
+	id, err := db.NewPrivateId(deliveryPrefix)
+	if err != nil {
+		return "", errors.WrapDeprecated(err, "notification.newDeliveryId")
+	}
+	return id, nil
+}
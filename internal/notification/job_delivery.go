@@ -0,0 +1,235 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	ua "go.uber.org/atomic"
+)
+
+const (
+	deliveryJobName = "notification_delivery"
+
+	defaultDeliveryInterval = 15 * time.Second
+	deliveryBatchSize       = 50
+)
+
+// deliveryJob is the recurring job which sends the pending deliveries to
+// their notification's webhook. Failed deliveries are retried with an
+// exponential backoff until they run out of attempts. The deliveryJob is not
+// thread safe, an attempt to Run the job concurrently will result in a
+// JobAlreadyRunning error.
+type deliveryJob struct {
+	reader db.Reader
+	writer db.Writer
+	kms    *kms.Kms
+	client *http.Client
+	limit  int
+
+	running       ua.Bool
+	numDeliveries int
+	numProcessed  int
+}
+
+// newDeliveryJob creates a new in-memory deliveryJob.
+func newDeliveryJob(ctx context.Context, r db.Reader, w db.Writer, kms *kms.Kms) (*deliveryJob, error) {
+	const op = "notification.newDeliveryJob"
+	switch {
+	case r == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Reader")
+	case w == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Writer")
+	case kms == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing kms")
+	}
+	return &deliveryJob{
+		reader: r,
+		writer: w,
+		kms:    kms,
+		client: &http.Client{},
+		limit:  deliveryBatchSize,
+	}, nil
+}
+
+// Status returns the current status of the delivery job. Total is the
+// number of deliveries in the current batch. Completed is the number of
+// those deliveries already attempted.
+func (j *deliveryJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: j.numProcessed,
+		Total:     j.numDeliveries,
+	}
+}
+
+// pendingDelivery is a row returned by pendingDeliveriesQuery.
+type pendingDelivery struct {
+	PrivateId           string
+	Attempts            int
+	Type                string
+	ScopeId             string
+	Data                string
+	CreateTime          time.Time
+	NotificationId      string
+	NotificationScopeId string
+	Url                 string
+	Secret              []byte
+	KeyId               string
+}
+
+// Run attempts the deliveries which are due. Can not be run in parallel, if
+// Run is invoked while already running an error with code JobAlreadyRunning
+// will be returned.
+func (j *deliveryJob) Run(ctx context.Context) error {
+	const op = "notification.(deliveryJob).Run"
+	if !j.running.CAS(j.running.Load(), true) {
+		return errors.New(ctx, errors.JobAlreadyRunning, op, "job already running")
+	}
+	defer j.running.Store(false)
+
+	// Verify context is not done before running
+	if err := ctx.Err(); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	deliveries, err := j.pendingDeliveries(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	// Set numProcessed and numDeliveries for status report
+	j.numProcessed, j.numDeliveries = 0, len(deliveries)
+
+	for _, d := range deliveries {
+		// Verify context is not done before attempting next delivery
+		if err := ctx.Err(); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := j.deliver(ctx, d); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error delivering notification", "notification id", d.NotificationId, "delivery id", d.PrivateId))
+		}
+		j.numProcessed++
+	}
+	return nil
+}
+
+func (j *deliveryJob) pendingDeliveries(ctx context.Context) ([]*pendingDelivery, error) {
+	const op = "notification.(deliveryJob).pendingDeliveries"
+	rows, err := j.reader.Query(ctx, pendingDeliveriesQuery, []interface{}{j.limit})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var deliveries []*pendingDelivery
+	for rows.Next() {
+		var d pendingDelivery
+		if err := j.reader.ScanRows(rows, &d); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		deliveries = append(deliveries, &d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deliveries, nil
+}
+
+// deliver attempts the delivery d and records its outcome. An error is only
+// returned if the outcome could not be recorded or the delivery could not be
+// attempted at all; a failed attempt is recorded on the delivery.
+func (j *deliveryJob) deliver(ctx context.Context, d *pendingDelivery) error {
+	const op = "notification.(deliveryJob).deliver"
+	var result deliveryResult
+	secret, err := j.decryptSecret(ctx, d)
+	if err != nil {
+		result.err = err
+	} else {
+		e := &Event{
+			Id:             d.PrivateId,
+			Type:           EventType(d.Type),
+			Time:           d.CreateTime.UTC(),
+			ScopeId:        d.ScopeId,
+			NotificationId: d.NotificationId,
+		}
+		if err := json.Unmarshal([]byte(d.Data), &e.Data); err != nil {
+			result.err = errors.New(ctx, errors.Decode, op, "unable to unmarshal event data", errors.WithWrap(err))
+		} else {
+			e.Text = e.summary()
+			result = send(ctx, j.client, d.Url, secret, e, time.Now())
+		}
+	}
+
+	status := DeliveryDelivered
+	var nextAttemptIn time.Duration
+	if result.err != nil {
+		status = DeliveryPending
+		nextAttemptIn = retryDelay(d.Attempts + 1)
+		if d.Attempts+1 >= maxDeliveryAttempts {
+			status = DeliveryFailed
+		}
+	}
+	var responseCode, lastError interface{}
+	if result.responseCode != 0 {
+		responseCode = result.responseCode
+	}
+	if result.err != nil {
+		lastError = truncateError(result.err)
+	}
+	rows, err := j.writer.Exec(ctx, updateDeliveryQuery, []interface{}{
+		string(status),
+		int(nextAttemptIn.Seconds()),
+		responseCode,
+		lastError,
+		d.PrivateId,
+	})
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update delivery"))
+	}
+	if rows != 1 {
+		return errors.New(ctx, errors.MultipleRecords, op, "more than 1 delivery would have been updated")
+	}
+	return nil
+}
+
+// decryptSecret returns the plain-text secret of the delivery's
+// notification.
+func (j *deliveryJob) decryptSecret(ctx context.Context, d *pendingDelivery) ([]byte, error) {
+	const op = "notification.(deliveryJob).decryptSecret"
+	databaseWrapper, err := j.kms.GetWrapper(ctx, d.NotificationScopeId, kms.KeyPurposeDatabase, kms.WithKeyId(d.KeyId))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	n := allocNotification()
+	n.CtSecret = d.Secret
+	n.KeyId = d.KeyId
+	if err := n.decrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return n.Secret, nil
+}
+
+// NextRunIn returns zero if the last run attempted a full batch of
+// deliveries, so the remaining deliveries are attempted right away, and the
+// default interval otherwise.
+func (j *deliveryJob) NextRunIn() (time.Duration, error) {
+	if j.numDeliveries >= j.limit {
+		return 0, nil
+	}
+	return defaultDeliveryInterval, nil
+}
+
+// Name is the unique name of the job.
+func (j *deliveryJob) Name() string {
+	return deliveryJobName
+}
+
+// Description is the human readable description of the job.
+func (j *deliveryJob) Description() string {
+	return "Delivers notification events to their webhooks and retries failed deliveries."
+}
@@ -0,0 +1,226 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	ua "go.uber.org/atomic"
+)
+
+const (
+	dispatchJobName = "notification_dispatch"
+
+	defaultDispatchInterval = 15 * time.Second
+	dispatchBatchSize       = 500
+
+	// eventRetention is how long events, and their deliveries, are kept
+	// after they have been dispatched.
+	eventRetention = 7 * 24 * time.Hour
+)
+
+// dispatchJob is the recurring job which matches the events recorded by the
+// notification triggers against the notifications subscribed to them and
+// creates a delivery for each notification whose filter matches the event.
+// The dispatchJob is not thread safe, an attempt to Run the job concurrently
+// will result in a JobAlreadyRunning error.
+type dispatchJob struct {
+	reader db.Reader
+	writer db.Writer
+	limit  int
+
+	running      ua.Bool
+	numEvents    int
+	numProcessed int
+}
+
+// newDispatchJob creates a new in-memory dispatchJob.
+func newDispatchJob(ctx context.Context, r db.Reader, w db.Writer) (*dispatchJob, error) {
+	const op = "notification.newDispatchJob"
+	switch {
+	case r == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Reader")
+	case w == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Writer")
+	}
+	return &dispatchJob{
+		reader: r,
+		writer: w,
+		limit:  dispatchBatchSize,
+	}, nil
+}
+
+// Status returns the current status of the dispatch job. Total is the number
+// of events in the current batch. Completed is the number of those events
+// already matched against the notifications.
+func (j *dispatchJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: j.numProcessed,
+		Total:     j.numEvents,
+	}
+}
+
+// pendingEvent is an undispatched event and the notifications subscribed to
+// its type.
+type pendingEvent struct {
+	id            int64
+	event         *Event
+	subscriptions []subscription
+}
+
+// subscription is a notification subscribed to an event's type.
+type subscription struct {
+	notificationId string
+	filter         string
+}
+
+// Run matches a batch of undispatched events against the notifications and
+// creates their deliveries. The events are marked dispatched in the same
+// transaction, so if Run fails the events are dispatched again by the next
+// run. Run also deletes the events which are past the retention period. Can
+// not be run in parallel, if Run is invoked while already running an error
+// with code JobAlreadyRunning will be returned.
+func (j *dispatchJob) Run(ctx context.Context) error {
+	const op = "notification.(dispatchJob).Run"
+	if !j.running.CAS(j.running.Load(), true) {
+		return errors.New(ctx, errors.JobAlreadyRunning, op, "job already running")
+	}
+	defer j.running.Store(false)
+
+	// Verify context is not done before running
+	if err := ctx.Err(); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	events, err := j.undispatchedEvents(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	j.numProcessed, j.numEvents = 0, len(events)
+
+	if len(events) > 0 {
+		type delivery struct {
+			id, notificationId string
+			eventId            int64
+		}
+		var deliveries []delivery
+		ids := make([]int64, 0, len(events))
+		for _, pe := range events {
+			ids = append(ids, pe.id)
+			for _, s := range pe.subscriptions {
+				e := *pe.event
+				e.NotificationId = s.notificationId
+				match, err := e.matches(ctx, s.filter)
+				if err != nil {
+					// Filters are validated when they are written, so this
+					// should never happen.
+					event.WriteError(ctx, op, err, event.WithInfoMsg("error evaluating notification filter", "notification id", s.notificationId))
+					continue
+				}
+				if !match {
+					continue
+				}
+				id, err := newDeliveryId()
+				if err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+				deliveries = append(deliveries, delivery{id: id, notificationId: s.notificationId, eventId: pe.id})
+			}
+			j.numProcessed++
+		}
+
+		_, err = j.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+			func(_ db.Reader, w db.Writer) error {
+				for _, d := range deliveries {
+					if _, err := w.Exec(ctx, insertDeliveryQuery, []interface{}{d.id, d.notificationId, d.eventId}); err != nil {
+						return errors.Wrap(ctx, err, op, errors.WithMsg("unable to insert delivery"))
+					}
+				}
+				if _, err := w.Exec(ctx, markEventsDispatchedQuery, []interface{}{ids}); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to mark events dispatched"))
+				}
+				return nil
+			},
+		)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+	}
+
+	if _, err := j.writer.Exec(ctx, deleteExpiredEventsQuery, []interface{}{-int(eventRetention.Seconds())}); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete expired events"))
+	}
+	return nil
+}
+
+// undispatchedEvents returns the oldest undispatched events in id order.
+func (j *dispatchJob) undispatchedEvents(ctx context.Context) ([]*pendingEvent, error) {
+	const op = "notification.(dispatchJob).undispatchedEvents"
+	rows, err := j.reader.Query(ctx, undispatchedEventsQuery, []interface{}{j.limit})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+
+	var events []*pendingEvent
+	var current *pendingEvent
+	for rows.Next() {
+		var (
+			id                     int64
+			typ, scopeId, data     string
+			createTime             time.Time
+			notificationId, filter sql.NullString
+		)
+		if err := rows.Scan(&id, &typ, &scopeId, &data, &createTime, &notificationId, &filter); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if current == nil || current.id != id {
+			e := &Event{
+				Type:    EventType(typ),
+				Time:    createTime.UTC(),
+				ScopeId: scopeId,
+			}
+			if err := json.Unmarshal([]byte(data), &e.Data); err != nil {
+				return nil, errors.New(ctx, errors.Decode, op, "unable to unmarshal event data", errors.WithWrap(err))
+			}
+			e.Text = e.summary()
+			current = &pendingEvent{id: id, event: e}
+			events = append(events, current)
+		}
+		if notificationId.Valid {
+			current.subscriptions = append(current.subscriptions, subscription{
+				notificationId: notificationId.String,
+				filter:         filter.String,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return events, nil
+}
+
+// NextRunIn returns zero if the last run dispatched a full batch of events,
+// so the remaining events are dispatched right away, and the default
+// interval otherwise.
+func (j *dispatchJob) NextRunIn() (time.Duration, error) {
+	if j.numEvents >= j.limit {
+		return 0, nil
+	}
+	return defaultDispatchInterval, nil
+}
+
+// Name is the unique name of the job.
+func (j *dispatchJob) Name() string {
+	return dispatchJobName
+}
+
+// Description is the human readable description of the job.
+func (j *dispatchJob) Description() string {
+	return "Matches recorded events against the notifications subscribed to them and queues their deliveries."
+}
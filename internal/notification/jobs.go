@@ -0,0 +1,31 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+)
+
+// RegisterJobs registers the notification dispatch and delivery jobs with
+// the provided scheduler.
+func RegisterJobs(ctx context.Context, scheduler *scheduler.Scheduler, r db.Reader, w db.Writer, kms *kms.Kms) error {
+	const op = "notification.RegisterJobs"
+	dispatch, err := newDispatchJob(ctx, r, w)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err = scheduler.RegisterJob(ctx, dispatch); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("dispatch job"))
+	}
+	delivery, err := newDeliveryJob(ctx, r, w, kms)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err = scheduler.RegisterJob(ctx, delivery); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("delivery job"))
+	}
+	return nil
+}
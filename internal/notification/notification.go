@@ -0,0 +1,287 @@
+// Package notification delivers events about sessions, connections and roles
+// to webhooks. A Notification subscribes to event types and optionally
+// filters the events with a go-bexpr expression. Events are recorded by
+// database triggers; the notification dispatch job matches them against the
+// notifications and the notification delivery job sends each match as a
+// signed HTTP request, retrying failed deliveries with a backoff.
+package notification
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/notification/store"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	notificationTableName          = "notification"
+	notificationEventTypeTableName = "notification_event_type"
+
+	// secretLength is the number of random bytes in a generated secret.
+	secretLength = 32
+)
+
+// A Notification subscribes to events of its EventTypes which match its
+// Filter and delivers them to its Url. Notifications are owned by the global
+// scope or an org and receive the events of their scope and its descendants.
+type Notification struct {
+	*store.Notification
+	tableName string `gorm:"-"`
+
+	// DeliveryStatus summarizes the retained deliveries of the
+	// notification. It is only set on notifications returned by the
+	// Repository's lookup and list methods.
+	DeliveryStatus *DeliveryStatus `gorm:"-"`
+}
+
+// DeliveryStatus summarizes the deliveries of a notification.
+type DeliveryStatus struct {
+	// PendingCount is the number of deliveries which have not succeeded
+	// yet but will be retried.
+	PendingCount int
+	// DeliveredCount is the number of successful deliveries.
+	DeliveredCount int
+	// FailedCount is the number of deliveries which failed and will not be
+	// retried.
+	FailedCount int
+	// LastAttemptTime is the time of the most recent delivery attempt.
+	LastAttemptTime *timestamp.Timestamp
+	// LastStatus is the status of the delivery attempted most recently.
+	LastStatus DeliveryStatusType
+	// LastResponseCode is the HTTP status code returned by the most recent
+	// delivery attempt, or zero if no response was received.
+	LastResponseCode int
+	// LastError is the error of the most recent delivery attempt, if it
+	// failed.
+	LastError string
+}
+
+// NewNotification creates a new in memory Notification for scopeId which
+// delivers the events of eventTypes to webhookUrl. The url must be an
+// absolute http or https url.
+//
+// WithName, WithDescription, WithFilter and WithSecret are the only valid
+// options.
+func NewNotification(scopeId, webhookUrl string, eventTypes []string, opt ...Option) (*Notification, error) {
+	const op = "notification.NewNotification"
+	if scopeId == "" {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing scope id")
+	}
+	if err := validateUrl(context.TODO(), webhookUrl); err != nil {
+		return nil, errors.WrapDeprecated(err, op)
+	}
+	if err := ValidateEventTypes(context.TODO(), eventTypes); err != nil {
+		return nil, errors.WrapDeprecated(err, op)
+	}
+	opts := getOpts(opt...)
+	if err := ValidateFilter(context.TODO(), opts.withFilter); err != nil {
+		return nil, errors.WrapDeprecated(err, op)
+	}
+	n := &Notification{
+		Notification: &store.Notification{
+			ScopeId:     scopeId,
+			Name:        opts.withName,
+			Description: opts.withDescription,
+			Url:         webhookUrl,
+			Filter:      opts.withFilter,
+			EventTypes:  append([]string(nil), eventTypes...),
+		},
+	}
+	if len(opts.withSecret) > 0 {
+		n.Secret = append([]byte(nil), opts.withSecret...)
+	}
+	return n, nil
+}
+
+// validateUrl returns an error unless u is an absolute http or https url.
+func validateUrl(ctx context.Context, u string) error {
+	const op = "notification.validateUrl"
+	if strings.TrimSpace(u) == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing url")
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "unable to parse url", errors.WithWrap(err))
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "url must be an absolute http or https url")
+	}
+	return nil
+}
+
+// generateSecret returns a random secret encoded with unpadded base64url.
+func generateSecret(ctx context.Context) ([]byte, error) {
+	const op = "notification.generateSecret"
+	b := make([]byte, secretLength)
+	if _, err := rand.Read(b); err != nil {
+		return nil, errors.New(ctx, errors.Io, op, "unable to generate secret", errors.WithWrap(err))
+	}
+	return []byte(base64.RawURLEncoding.EncodeToString(b)), nil
+}
+
+func allocNotification() *Notification {
+	return &Notification{
+		Notification: &store.Notification{},
+	}
+}
+
+var _ db.VetForWriter = (*Notification)(nil)
+
+func (n *Notification) clone() *Notification {
+	cp := proto.Clone(n.Notification)
+	return &Notification{
+		Notification: cp.(*store.Notification),
+	}
+}
+
+// TableName returns the table name.
+func (n *Notification) TableName() string {
+	if n.tableName != "" {
+		return n.tableName
+	}
+	return notificationTableName
+}
+
+// SetTableName sets the table name.
+func (n *Notification) SetTableName(name string) {
+	n.tableName = name
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the
+// notification before it's written.
+func (n *Notification) VetForWrite(ctx context.Context, _ db.Reader, opType db.OpType, _ ...db.Option) error {
+	const op = "notification.(Notification).VetForWrite"
+	if n.PublicId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	if opType == db.CreateOp && n.ScopeId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	return nil
+}
+
+func (n *Notification) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "notification.(Notification).encrypt"
+	if len(n.Secret) == 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "no secret defined")
+	}
+	if err := structwrapping.WrapStruct(ctx, cipher, n.Notification, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	n.KeyId = cipher.KeyID()
+	return nil
+}
+
+func (n *Notification) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "notification.(Notification).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, n.Notification, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
+
+func (n *Notification) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{n.PublicId},
+		"resource-type":      []string{"notification"},
+		"op-type":            []string{op.String()},
+	}
+	if n.ScopeId != "" {
+		metadata["scope-id"] = []string{n.ScopeId}
+	}
+	return metadata
+}
+
+// eventType is a row of the notification_event_type table.
+type eventType struct {
+	*store.NotificationEventType
+	tableName string `gorm:"-"`
+}
+
+func newEventType(notificationId, t string) *eventType {
+	return &eventType{
+		NotificationEventType: &store.NotificationEventType{
+			NotificationId: notificationId,
+			EventType:      t,
+		},
+	}
+}
+
+// TableName returns the table name.
+func (et *eventType) TableName() string {
+	if et.tableName != "" {
+		return et.tableName
+	}
+	return notificationEventTypeTableName
+}
+
+// SetTableName sets the table name.
+func (et *eventType) SetTableName(name string) {
+	et.tableName = name
+}
+
+// notificationAgg is a row of the notification_agg view.
+type notificationAgg struct {
+	PublicId                 string `gorm:"primary_key"`
+	ScopeId                  string
+	Name                     string
+	Description              string
+	Url                      string
+	Filter                   string
+	KeyId                    string
+	CreateTime               *timestamp.Timestamp
+	UpdateTime               *timestamp.Timestamp
+	Version                  uint32
+	EventTypes               string
+	PendingCount             int
+	DeliveredCount           int
+	FailedCount              int
+	LastDeliveryTime         *timestamp.Timestamp
+	LastDeliveryStatus       string
+	LastDeliveryResponseCode int
+	LastDeliveryError        string
+}
+
+// TableName returns the table name.
+func (agg *notificationAgg) TableName() string { return "notification_agg" }
+
+// GetPublicId returns the public id.
+func (agg *notificationAgg) GetPublicId() string { return agg.PublicId }
+
+func (agg *notificationAgg) toNotification() *Notification {
+	const aggregateDelimiter = "|"
+	n := allocNotification()
+	n.PublicId = agg.PublicId
+	n.ScopeId = agg.ScopeId
+	n.Name = agg.Name
+	n.Description = agg.Description
+	n.Url = agg.Url
+	n.Filter = agg.Filter
+	n.KeyId = agg.KeyId
+	n.CreateTime = agg.CreateTime
+	n.UpdateTime = agg.UpdateTime
+	n.Version = agg.Version
+	if agg.EventTypes != "" {
+		n.EventTypes = strings.Split(agg.EventTypes, aggregateDelimiter)
+	}
+	n.DeliveryStatus = &DeliveryStatus{
+		PendingCount:     agg.PendingCount,
+		DeliveredCount:   agg.DeliveredCount,
+		FailedCount:      agg.FailedCount,
+		LastAttemptTime:  agg.LastDeliveryTime,
+		LastStatus:       DeliveryStatusType(agg.LastDeliveryStatus),
+		LastResponseCode: agg.LastDeliveryResponseCode,
+		LastError:        agg.LastDeliveryError,
+	}
+	return n
+}
@@ -0,0 +1,107 @@
+package notification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNotification(t *testing.T) {
+	t.Parallel()
+	eventTypes := []string{string(SessionAuthorized)}
+	tests := []struct {
+		name       string
+		scopeId    string
+		url        string
+		eventTypes []string
+		opt        []Option
+		wantErr    bool
+	}{
+		{
+			name:       "valid",
+			scopeId:    "o_1234567890",
+			url:        "https://hooks.example.com/boundary",
+			eventTypes: eventTypes,
+			opt: []Option{
+				WithName("name"),
+				WithDescription("description"),
+				WithFilter(`"/data/target_name" == "prod"`),
+				WithSecret([]byte("secret")),
+			},
+		},
+		{
+			name:       "no-scope",
+			url:        "https://hooks.example.com/boundary",
+			eventTypes: eventTypes,
+			wantErr:    true,
+		},
+		{
+			name:       "no-url",
+			scopeId:    "global",
+			eventTypes: eventTypes,
+			wantErr:    true,
+		},
+		{
+			name:       "relative-url",
+			scopeId:    "global",
+			url:        "/boundary",
+			eventTypes: eventTypes,
+			wantErr:    true,
+		},
+		{
+			name:       "unsupported-scheme",
+			scopeId:    "global",
+			url:        "ftp://hooks.example.com/boundary",
+			eventTypes: eventTypes,
+			wantErr:    true,
+		},
+		{
+			name:    "no-event-types",
+			scopeId: "global",
+			url:     "http://hooks.example.com/boundary",
+			wantErr: true,
+		},
+		{
+			name:       "invalid-filter",
+			scopeId:    "global",
+			url:        "http://hooks.example.com/boundary",
+			eventTypes: eventTypes,
+			opt:        []Option{WithFilter(`"/type" ==`)},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewNotification(tt.scopeId, tt.url, tt.eventTypes, tt.opt...)
+			if tt.wantErr {
+				require.Error(err)
+				assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+				return
+			}
+			require.NoError(err)
+			opts := getOpts(tt.opt...)
+			assert.Equal(tt.scopeId, got.ScopeId)
+			assert.Equal(tt.url, got.Url)
+			assert.Equal(tt.eventTypes, got.EventTypes)
+			assert.Equal(opts.withName, got.Name)
+			assert.Equal(opts.withDescription, got.Description)
+			assert.Equal(opts.withFilter, got.Filter)
+			assert.Equal(opts.withSecret, got.Secret)
+			assert.Empty(got.PublicId)
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	t.Parallel()
+	a, err := generateSecret(context.Background())
+	require.NoError(t, err)
+	b, err := generateSecret(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, a, 43)
+	assert.NotEqual(t, a, b)
+}
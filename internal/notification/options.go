@@ -0,0 +1,65 @@
+package notification
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withName        string
+	withDescription string
+	withFilter      string
+	withSecret      []byte
+	withLimit       int
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithDescription provides an optional description.
+func WithDescription(desc string) Option {
+	return func(o *options) {
+		o.withDescription = desc
+	}
+}
+
+// WithName provides an optional name.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.withName = name
+	}
+}
+
+// WithFilter provides an optional boolean expression in go-bexpr format
+// which events must match to be delivered.
+func WithFilter(filter string) Option {
+	return func(o *options) {
+		o.withFilter = filter
+	}
+}
+
+// WithSecret provides the secret deliveries are signed with. A random secret
+// is generated when creating a notification without one.
+func WithSecret(secret []byte) Option {
+	return func(o *options) {
+		o.withSecret = secret
+	}
+}
+
+// WithLimit provides an option to provide a limit. Intentionally allowing
+// negative integers. If WithLimit < 0, then unlimited results are
+// returned. If WithLimit == 0, then default limits are used for results.
+func WithLimit(l int) Option {
+	return func(o *options) {
+		o.withLimit = l
+	}
+}
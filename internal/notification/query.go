@@ -0,0 +1,99 @@
+package notification
+
+const (
+	// undispatchedEventsQuery returns the oldest undispatched events along
+	// with the notifications subscribed to them. Notifications receive the
+	// events of their own scope and of its descendants. Events without a
+	// subscribed notification are returned once with a null notification.
+	undispatchedEventsQuery = `
+with event as (
+  select id,
+         type,
+         scope_id,
+         data,
+         create_time
+    from notification_event
+   where dispatched = false
+order by id
+   limit ?
+)
+   select e.id,
+          e.type,
+          e.scope_id,
+          e.data::text as data,
+          e.create_time,
+          n.public_id as notification_id,
+          n.filter
+     from event e
+left join iam_scope s
+       on s.public_id = e.scope_id
+left join notification_event_type et
+       on et.event_type = e.type
+left join notification n
+       on n.public_id = et.notification_id
+      and (n.scope_id = 'global' or n.scope_id = e.scope_id or n.scope_id = s.parent_id)
+ order by e.id;
+`
+
+	insertDeliveryQuery = `
+insert into notification_delivery
+  (private_id, notification_id, event_id)
+values
+  (?, ?, ?)
+on conflict do nothing;
+`
+
+	markEventsDispatchedQuery = `
+update notification_event
+   set dispatched = true
+ where id in (?);
+`
+
+	// deleteExpiredEventsQuery deletes the dispatched events, and with them
+	// their deliveries, which are older than the retention period and have
+	// no pending delivery.
+	deleteExpiredEventsQuery = `
+delete from notification_event e
+ where e.dispatched = true
+   and e.create_time < wt_add_seconds_to_now(?)
+   and not exists (
+         select from notification_delivery d
+          where d.event_id = e.id
+            and d.status = 'pending'
+       );
+`
+
+	pendingDeliveriesQuery = `
+  select d.private_id,
+         d.attempts,
+         e.type,
+         e.scope_id,
+         e.data::text as data,
+         e.create_time,
+         n.public_id as notification_id,
+         n.scope_id as notification_scope_id,
+         n.url,
+         n.secret,
+         n.key_id
+    from notification_delivery d
+    join notification_event e
+      on e.id = d.event_id
+    join notification n
+      on n.public_id = d.notification_id
+   where d.status = 'pending'
+     and d.next_attempt_time <= current_timestamp
+order by d.next_attempt_time
+   limit ?;
+`
+
+	updateDeliveryQuery = `
+update notification_delivery
+   set status            = ?,
+       attempts          = attempts + 1,
+       last_attempt_time = current_timestamp,
+       next_attempt_time = wt_add_seconds_to_now(?),
+       response_code     = ?,
+       last_error        = ?
+ where private_id = ?;
+`
+)
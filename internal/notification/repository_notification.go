@@ -0,0 +1,363 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreateNotification inserts n into the repository and returns a new
+// Notification containing the notification's PublicId. n is not changed. n
+// must contain a valid ScopeId, Url and EventTypes. n must not contain a
+// PublicId. The PublicId is generated and assigned by this method. If n
+// does not contain a Secret a random one is generated.
+//
+// Unlike the notifications returned by other methods, the returned
+// notification contains the plain-text Secret, so it can be shown to the
+// user once.
+func (r *Repository) CreateNotification(ctx context.Context, n *Notification, _ ...Option) (*Notification, error) {
+	const op = "notification.(Repository).CreateNotification"
+	if n == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil Notification")
+	}
+	if n.Notification == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil embedded Notification")
+	}
+	if n.ScopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+	if n.PublicId != "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	}
+	if err := validateUrl(ctx, n.Url); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if err := ValidateEventTypes(ctx, n.EventTypes); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if err := ValidateFilter(ctx, n.Filter); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	n = n.clone()
+	id, err := newNotificationId()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	n.PublicId = id
+	if len(n.Secret) == 0 {
+		if n.Secret, err = generateSecret(ctx); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	secret := n.Secret
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, n.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, n.ScopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := n.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	eventTypes := make([]interface{}, 0, len(n.EventTypes))
+	for _, t := range n.EventTypes {
+		eventTypes = append(eventTypes, newEventType(id, t))
+	}
+
+	var newNotification *Notification
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			msgs := make([]*oplog.Message, 0, len(eventTypes)+1)
+			ticket, err := w.GetTicket(n)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+			}
+
+			newNotification = n.clone()
+			var nOplogMsg oplog.Message
+			if err := w.Create(ctx, newNotification, db.NewOplogMsg(&nOplogMsg)); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			msgs = append(msgs, &nOplogMsg)
+
+			etOplogMsgs := make([]*oplog.Message, 0, len(eventTypes))
+			if err := w.CreateItems(ctx, eventTypes, db.NewOplogMsgs(&etOplogMsgs)); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			msgs = append(msgs, etOplogMsgs...)
+
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, n.oplog(oplog.OpType_OP_TYPE_CREATE), msgs); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("in scope: %s: name %s already exists", n.ScopeId, n.Name))
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in scope: %s", n.ScopeId)))
+	}
+	newNotification.CtSecret = nil
+	newNotification.Secret = secret
+	return newNotification, nil
+}
+
+// UpdateNotification updates the repository entry for n.PublicId with the
+// values in n for the fields listed in fieldMaskPaths. It returns a new
+// Notification containing the updated values and a count of the number of
+// records updated. n is not changed.
+//
+// n must contain a valid PublicId. Name, Description, Url, Filter, Secret
+// and EventTypes can be updated. If n.Name is set to a non-empty string, it
+// must be unique within n.ScopeId. Url, Secret and EventTypes can not be
+// unset.
+//
+// An attribute of n will be set to NULL in the database if the attribute in
+// n is the zero value and it is included in fieldMaskPaths.
+func (r *Repository) UpdateNotification(ctx context.Context, n *Notification, version uint32, fieldMaskPaths []string, _ ...Option) (*Notification, int, error) {
+	const op = "notification.(Repository).UpdateNotification"
+	if n == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "nil Notification")
+	}
+	if n.Notification == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "nil embedded Notification")
+	}
+	if n.PublicId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+	if version == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no version")
+	}
+	if len(fieldMaskPaths) == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
+	}
+
+	var dbMask, nullFields []string
+	var updateSecret, updateEventTypes bool
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("name", f) && n.Name == "":
+			nullFields = append(nullFields, "name")
+		case strings.EqualFold("name", f) && n.Name != "":
+			dbMask = append(dbMask, "name")
+		case strings.EqualFold("description", f) && n.Description == "":
+			nullFields = append(nullFields, "description")
+		case strings.EqualFold("description", f) && n.Description != "":
+			dbMask = append(dbMask, "description")
+		case strings.EqualFold("filter", f) && n.Filter == "":
+			nullFields = append(nullFields, "filter")
+		case strings.EqualFold("filter", f) && n.Filter != "":
+			if err := ValidateFilter(ctx, n.Filter); err != nil {
+				return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+			}
+			dbMask = append(dbMask, "filter")
+		case strings.EqualFold("url", f):
+			if err := validateUrl(ctx, n.Url); err != nil {
+				return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+			}
+			dbMask = append(dbMask, "url")
+		case strings.EqualFold("secret", f):
+			if len(n.Secret) == 0 {
+				return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "secret can not be unset")
+			}
+			updateSecret = true
+			dbMask = append(dbMask, "CtSecret", "KeyId")
+		case strings.EqualFold("eventtypes", f), strings.EqualFold("event_types", f):
+			if err := ValidateEventTypes(ctx, n.EventTypes); err != nil {
+				return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+			}
+			updateEventTypes = true
+		default:
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
+		}
+	}
+
+	current, err := r.LookupNotification(ctx, n.PublicId)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	if current == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("notification %s not found", n.PublicId))
+	}
+
+	n = n.clone()
+	n.ScopeId = current.ScopeId
+	if updateSecret {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, n.ScopeId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+		}
+		if err := n.encrypt(ctx, databaseWrapper); err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+		}
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, n.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsUpdated int
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			var msgs []*oplog.Message
+			ticket, err := w.GetTicket(n)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+			}
+
+			updated := n.clone()
+			if len(dbMask) == 0 && len(nullFields) == 0 {
+				// Only the event types are changing, so the version is bumped
+				// explicitly.
+				updated.Version = version + 1
+				dbMask = []string{"Version"}
+			}
+			var nOplogMsg oplog.Message
+			rowsUpdated, err = w.Update(ctx, updated, dbMask, nullFields, db.NewOplogMsg(&nOplogMsg), db.WithVersion(&version))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated != 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated notification and %d rows updated", rowsUpdated))
+			}
+			msgs = append(msgs, &nOplogMsg)
+
+			if updateEventTypes {
+				deleteEventTypes := make([]interface{}, 0, len(current.EventTypes))
+				for _, t := range current.EventTypes {
+					deleteEventTypes = append(deleteEventTypes, newEventType(n.PublicId, t))
+				}
+				if len(deleteEventTypes) > 0 {
+					deleteOplogMsgs := make([]*oplog.Message, 0, len(deleteEventTypes))
+					if _, err := w.DeleteItems(ctx, deleteEventTypes, db.NewOplogMsgs(&deleteOplogMsgs)); err != nil {
+						return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete event types"))
+					}
+					msgs = append(msgs, deleteOplogMsgs...)
+				}
+				createEventTypes := make([]interface{}, 0, len(n.EventTypes))
+				for _, t := range n.EventTypes {
+					createEventTypes = append(createEventTypes, newEventType(n.PublicId, t))
+				}
+				createOplogMsgs := make([]*oplog.Message, 0, len(createEventTypes))
+				if err := w.CreateItems(ctx, createEventTypes, db.NewOplogMsgs(&createOplogMsgs)); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add event types"))
+				}
+				msgs = append(msgs, createOplogMsgs...)
+			}
+
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, n.oplog(oplog.OpType_OP_TYPE_UPDATE), msgs); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("in %s: name %s already exists", n.PublicId, n.Name))
+		}
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in %s", n.PublicId)))
+	}
+
+	returned, err := r.LookupNotification(ctx, n.PublicId)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	return returned, rowsUpdated, nil
+}
+
+// LookupNotification returns the Notification for publicId, including its
+// event types and delivery status. Returns nil, nil if no Notification is
+// found for publicId.
+func (r *Repository) LookupNotification(ctx context.Context, publicId string, _ ...Option) (*Notification, error) {
+	const op = "notification.(Repository).LookupNotification"
+	if publicId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+	agg := &notificationAgg{PublicId: publicId}
+	if err := r.reader.LookupByPublicId(ctx, agg); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", publicId)))
+	}
+	return agg.toNotification(), nil
+}
+
+// ListNotifications returns a slice of Notifications for the scopeIds.
+// WithLimit is the only option supported.
+func (r *Repository) ListNotifications(ctx context.Context, scopeIds []string, opt ...Option) ([]*Notification, error) {
+	const op = "notification.(Repository).ListNotifications"
+	if len(scopeIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var aggs []*notificationAgg
+	if err := r.reader.SearchWhere(ctx, &aggs, "scope_id in (?)", []interface{}{scopeIds}, db.WithLimit(limit)); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	notifications := make([]*Notification, 0, len(aggs))
+	for _, agg := range aggs {
+		notifications = append(notifications, agg.toNotification())
+	}
+	return notifications, nil
+}
+
+// DeleteNotification deletes publicId from the repository and returns the
+// number of records deleted. Its event types and deliveries are deleted with
+// it.
+func (r *Repository) DeleteNotification(ctx context.Context, publicId string, _ ...Option) (int, error) {
+	const op = "notification.(Repository).DeleteNotification"
+	if publicId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+	n := allocNotification()
+	n.PublicId = publicId
+	if err := r.reader.LookupByPublicId(ctx, n); err != nil {
+		if errors.IsNotFoundError(err) {
+			return db.NoRowsAffected, nil
+		}
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", publicId)))
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, n.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			dn := n.clone()
+			var err error
+			rowsDeleted, err = w.Delete(ctx, dn, db.WithOplog(oplogWrapper, n.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsDeleted > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("delete failed for %s", n.PublicId)))
+	}
+	return rowsDeleted, nil
+}
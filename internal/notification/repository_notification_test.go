@@ -0,0 +1,174 @@
+package notification
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_NotificationCrud(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	eventTypes := []string{string(RoleCreated), string(RoleDeleted)}
+
+	t.Run("create-generates-secret", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		n, err := NewNotification(org.PublicId, "https://hooks.example.com/a", eventTypes, WithName("generated"))
+		require.NoError(err)
+		got, err := repo.CreateNotification(ctx, n)
+		require.NoError(err)
+		assert.NotEmpty(got.PublicId)
+		assert.NotEmpty(got.Secret)
+		assert.Empty(got.CtSecret)
+		assert.Equal(uint32(1), got.Version)
+
+		_, err = repo.CreateNotification(ctx, n)
+		assert.True(errors.Match(errors.T(errors.NotUnique), err))
+	})
+	t.Run("create-with-secret", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		n, err := NewNotification(org.PublicId, "https://hooks.example.com/b", eventTypes, WithSecret([]byte("secret")))
+		require.NoError(err)
+		got, err := repo.CreateNotification(ctx, n)
+		require.NoError(err)
+		assert.Equal([]byte("secret"), got.Secret)
+	})
+	t.Run("create-in-project", func(t *testing.T) {
+		n, err := NewNotification(proj.PublicId, "https://hooks.example.com/c", eventTypes)
+		require.NoError(t, err)
+		_, err = repo.CreateNotification(ctx, n)
+		assert.Error(t, err)
+	})
+	t.Run("update-lookup-list-delete", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		n, err := NewNotification(org.PublicId, "https://hooks.example.com/d", eventTypes, WithName("before"))
+		require.NoError(err)
+		n, err = repo.CreateNotification(ctx, n)
+		require.NoError(err)
+
+		upd := n.clone()
+		upd.Name = "after"
+		upd.Url = "https://hooks.example.com/e"
+		upd.Filter = `"/data/role_name" == "admins"`
+		upd.EventTypes = []string{string(RoleGrantAdded)}
+		got, rowsUpdated, err := repo.UpdateNotification(ctx, upd, n.Version, []string{"Name", "Url", "Filter", "EventTypes"})
+		require.NoError(err)
+		assert.Equal(1, rowsUpdated)
+		assert.Equal("after", got.Name)
+		assert.Equal("https://hooks.example.com/e", got.Url)
+		assert.Equal(upd.Filter, got.Filter)
+		assert.Equal([]string{string(RoleGrantAdded)}, got.EventTypes)
+		assert.Equal(uint32(2), got.Version)
+
+		upd = got.clone()
+		upd.EventTypes = []string{string(RoleGrantAdded), string(RoleGrantRemoved)}
+		got, rowsUpdated, err = repo.UpdateNotification(ctx, upd, got.Version, []string{"EventTypes"})
+		require.NoError(err)
+		assert.Equal(1, rowsUpdated)
+		assert.ElementsMatch(upd.EventTypes, got.EventTypes)
+		assert.Equal(uint32(3), got.Version)
+
+		_, _, err = repo.UpdateNotification(ctx, upd, got.Version, []string{"ScopeId"})
+		assert.True(errors.Match(errors.T(errors.InvalidFieldMask), err))
+
+		found, err := repo.LookupNotification(ctx, n.PublicId)
+		require.NoError(err)
+		require.NotNil(found)
+		assert.Equal("after", found.Name)
+		assert.Empty(found.Secret)
+		require.NotNil(found.DeliveryStatus)
+		assert.Zero(found.DeliveryStatus.PendingCount)
+
+		list, err := repo.ListNotifications(ctx, []string{org.PublicId})
+		require.NoError(err)
+		var ids []string
+		for _, l := range list {
+			ids = append(ids, l.PublicId)
+		}
+		assert.Contains(ids, n.PublicId)
+
+		deleted, err := repo.DeleteNotification(ctx, n.PublicId)
+		require.NoError(err)
+		assert.Equal(1, deleted)
+		found, err = repo.LookupNotification(ctx, n.PublicId)
+		require.NoError(err)
+		assert.Nil(found)
+	})
+}
+
+func TestNotification_Delivery(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+
+	var mu sync.Mutex
+	var bodies [][]byte
+	var signatures []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, b)
+		signatures = append(signatures, r.Header.Get(SignatureHeader))
+	}))
+	defer ts.Close()
+
+	n, err := NewNotification(org.PublicId, ts.URL, []string{string(RoleCreated)},
+		WithFilter(`"/data/role_name" == "notify"`), WithSecret([]byte("secret")))
+	require.NoError(err)
+	n, err = repo.CreateNotification(ctx, n)
+	require.NoError(err)
+
+	// Only the role matching the filter is delivered.
+	iam.TestRole(t, conn, org.PublicId, iam.WithName("notify"))
+	iam.TestRole(t, conn, org.PublicId, iam.WithName("ignore"))
+
+	dispatch, err := newDispatchJob(ctx, rw, rw)
+	require.NoError(err)
+	require.NoError(dispatch.Run(ctx))
+	assert.Equal(2, dispatch.Status().Total)
+
+	delivery, err := newDeliveryJob(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+	delivery.client = ts.Client()
+	require.NoError(delivery.Run(ctx))
+	assert.Equal(1, delivery.Status().Total)
+
+	mu.Lock()
+	require.Len(bodies, 1)
+	assert.Contains(string(bodies[0]), `"role_name":"notify"`)
+	assert.NotEmpty(signatures[0])
+	mu.Unlock()
+
+	found, err := repo.LookupNotification(ctx, n.PublicId)
+	require.NoError(err)
+	require.NotNil(found.DeliveryStatus)
+	assert.Equal(1, found.DeliveryStatus.DeliveredCount)
+	assert.Equal(DeliveryDelivered, found.DeliveryStatus.LastStatus)
+	assert.Equal(http.StatusOK, found.DeliveryStatus.LastResponseCode)
+}
@@ -0,0 +1,165 @@
+// Package changefeed provides a read-only view of the oplog. It decrypts oplog
+// entries with the oplog key of the scope that wrote them and decodes their
+// operations into typed change events, which allows external systems to
+// mirror configuration changes made in Boundary.
+package changefeed
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	oidcstore "github.com/hashicorp/boundary/internal/auth/oidc/store"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	pwstore "github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/credential/vault"
+	vaultstore "github.com/hashicorp/boundary/internal/credential/vault/store"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/plugin"
+	pluginstore "github.com/hashicorp/boundary/internal/host/plugin/store"
+	"github.com/hashicorp/boundary/internal/host/static"
+	staticstore "github.com/hashicorp/boundary/internal/host/static/store"
+	"github.com/hashicorp/boundary/internal/iam"
+	iamstore "github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/kubernetes"
+	k8sstore "github.com/hashicorp/boundary/internal/target/kubernetes/store"
+	targetstore "github.com/hashicorp/boundary/internal/target/store"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	tcpstore "github.com/hashicorp/boundary/internal/target/tcp/store"
+	"google.golang.org/protobuf/proto"
+)
+
+// Change is a single decrypted oplog entry.
+type Change struct {
+	// EntryId is the id of the oplog entry. Entry ids are monotonically
+	// increasing and can be used as a cursor for subsequent requests.
+	EntryId uint32
+	// CreateTime is the time the oplog entry was written.
+	CreateTime *timestamp.Timestamp
+	// AggregateName is the name of the aggregate the entry was written for,
+	// which is usually the name of the table that was modified.
+	AggregateName string
+	// ScopeId is the id of the scope whose oplog key encrypted the entry.
+	ScopeId string
+	// Metadata is the metadata that was written with the entry.
+	Metadata oplog.Metadata
+	// Operations are the operations contained in the entry, in the order
+	// they were applied.
+	Operations []*Operation
+}
+
+// Operation is a single create, update or delete contained in an oplog entry.
+type Operation struct {
+	// TypeName is the name of the type of the operation's message, which is
+	// the name of the table that was modified.
+	TypeName string
+	// OpType is the type of the operation.
+	OpType oplog.OpType
+	// FieldMaskPaths are the fields that were set by an update operation.
+	FieldMaskPaths []string
+	// SetToNullPaths are the fields that were set to null by an update
+	// operation.
+	SetToNullPaths []string
+	// Message is the decoded message of the operation with all secret fields
+	// cleared. It is nil if the TypeName is not part of the change feed's
+	// type catalog.
+	Message proto.Message
+}
+
+// newTypeCatalog returns the catalog of the types which are decoded by the
+// change feed. The names are taken from the domain types so they match the
+// type names written to the oplog.
+func newTypeCatalog() (*oplog.TypeCatalog, error) {
+	const op = "changefeed.newTypeCatalog"
+	types, err := oplog.NewTypeCatalog(
+		oplog.Type{Interface: new(iamstore.Scope), Name: (&iam.Scope{}).TableName()},
+		oplog.Type{Interface: new(iamstore.User), Name: (&iam.User{}).TableName()},
+		oplog.Type{Interface: new(iamstore.Group), Name: (&iam.Group{}).TableName()},
+		oplog.Type{Interface: new(iamstore.GroupMemberUser), Name: (&iam.GroupMemberUser{}).TableName()},
+		oplog.Type{Interface: new(iamstore.Role), Name: (&iam.Role{}).TableName()},
+		oplog.Type{Interface: new(iamstore.RoleGrant), Name: (&iam.RoleGrant{}).TableName()},
+		oplog.Type{Interface: new(iamstore.UserRole), Name: (&iam.UserRole{}).TableName()},
+		oplog.Type{Interface: new(iamstore.GroupRole), Name: (&iam.GroupRole{}).TableName()},
+		oplog.Type{Interface: new(iamstore.ManagedGroupRole), Name: (&iam.ManagedGroupRole{}).TableName()},
+		oplog.Type{Interface: new(iamstore.ServiceAccount), Name: (&iam.ServiceAccount{}).TableName()},
+		oplog.Type{Interface: new(iamstore.ServiceAccountCredential), Name: (&iam.ServiceAccountCredential{}).TableName()},
+		oplog.Type{Interface: new(iamstore.ServiceAccountRole), Name: (&iam.ServiceAccountRole{}).TableName()},
+		oplog.Type{Interface: new(pwstore.AuthMethod), Name: (&password.AuthMethod{}).TableName()},
+		oplog.Type{Interface: new(pwstore.Account), Name: (&password.Account{}).TableName()},
+		oplog.Type{Interface: new(oidcstore.AuthMethod), Name: (&oidc.AuthMethod{}).TableName()},
+		oplog.Type{Interface: new(oidcstore.Account), Name: (&oidc.Account{}).TableName()},
+		oplog.Type{Interface: new(oidcstore.ManagedGroup), Name: (&oidc.ManagedGroup{}).TableName()},
+		oplog.Type{Interface: new(staticstore.HostCatalog), Name: (&static.HostCatalog{}).TableName()},
+		oplog.Type{Interface: new(staticstore.Host), Name: (&static.Host{}).TableName()},
+		oplog.Type{Interface: new(staticstore.HostSet), Name: (&static.HostSet{}).TableName()},
+		oplog.Type{Interface: new(staticstore.HostSetMember), Name: (&static.HostSetMember{}).TableName()},
+		oplog.Type{Interface: new(pluginstore.HostCatalog), Name: (&plugin.HostCatalog{}).TableName()},
+		oplog.Type{Interface: new(pluginstore.HostSet), Name: (&plugin.HostSet{}).TableName()},
+		oplog.Type{Interface: new(tcpstore.Target), Name: (&tcp.Target{}).TableName()},
+		oplog.Type{Interface: new(k8sstore.Target), Name: (&kubernetes.Target{}).TableName()},
+		oplog.Type{Interface: new(targetstore.TargetHostSet), Name: (&target.TargetHostSet{}).TableName()},
+		oplog.Type{Interface: new(targetstore.CredentialLibrary), Name: (&target.CredentialLibrary{}).TableName()},
+		oplog.Type{Interface: new(vaultstore.CredentialStore), Name: (&vault.CredentialStore{}).TableName()},
+		oplog.Type{Interface: new(vaultstore.CredentialLibrary), Name: (&vault.CredentialLibrary{}).TableName()},
+	)
+	if err != nil {
+		return nil, errors.WrapDeprecated(err, op)
+	}
+	return types, nil
+}
+
+// decodeOperation converts the AnyOperation into an Operation. The operation's
+// value is only decoded when its type name is in the catalog.
+func decodeOperation(types *oplog.TypeCatalog, anyOp *oplog.AnyOperation) (*Operation, error) {
+	const op = "changefeed.decodeOperation"
+	o := &Operation{
+		TypeName:       anyOp.GetTypeName(),
+		OpType:         anyOp.GetOperationType(),
+		FieldMaskPaths: anyOp.GetFieldMask().GetPaths(),
+		SetToNullPaths: anyOp.GetNullMask().GetPaths(),
+	}
+	if _, ok := (*types)[o.TypeName]; !ok || anyOp.GetValue() == nil {
+		return o, nil
+	}
+	i, err := types.Get(o.TypeName)
+	if err != nil {
+		return nil, errors.WrapDeprecated(err, op)
+	}
+	m := i.(proto.Message)
+	if err := proto.Unmarshal(anyOp.GetValue(), m); err != nil {
+		return nil, errors.NewDeprecated(errors.Decode, op, "error unmarshaling value", errors.WithWrap(err))
+	}
+	redact(m)
+	o.Message = m
+	return o, nil
+}
+
+// redact clears the fields of the message which hold secrets. These are the
+// fields which are encrypted by structwrapping, the hmacs of those values and
+// the hashes of secrets.
+func redact(m proto.Message) {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			// unexported field
+			continue
+		}
+		_, wrapped := f.Tag.Lookup("wrapping")
+		if !wrapped && !strings.HasSuffix(f.Name, "Hmac") && !strings.HasSuffix(f.Name, "Hash") {
+			continue
+		}
+		v.Field(i).Set(reflect.Zero(f.Type))
+	}
+}
@@ -0,0 +1,104 @@
+package changefeed
+
+import (
+	"testing"
+
+	oidcstore "github.com/hashicorp/boundary/internal/auth/oidc/store"
+	iamstore "github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_newTypeCatalog(t *testing.T) {
+	t.Parallel()
+	types, err := newTypeCatalog()
+	require.NoError(t, err)
+	for _, name := range []string{"iam_scope", "iam_user", "auth_password_account", "auth_oidc_method", "static_host", "target_tcp", "target_kubernetes", "credential_vault_library", "iam_service_account", "iam_service_account_credential"} {
+		_, ok := (*types)[name]
+		assert.Truef(t, ok, "missing type %s", name)
+	}
+}
+
+func Test_decodeOperation(t *testing.T) {
+	t.Parallel()
+	types, err := newTypeCatalog()
+	require.NoError(t, err)
+
+	t.Run("known-type", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		scope := &iamstore.Scope{PublicId: "o_1234567890", Name: "org"}
+		value, err := proto.Marshal(scope)
+		require.NoError(err)
+		got, err := decodeOperation(types, &oplog.AnyOperation{
+			TypeName:      "iam_scope",
+			Value:         value,
+			OperationType: oplog.OpType_OP_TYPE_UPDATE,
+			FieldMask:     &field_mask.FieldMask{Paths: []string{"Name"}},
+			NullMask:      &field_mask.FieldMask{Paths: []string{"Description"}},
+		})
+		require.NoError(err)
+		assert.Equal("iam_scope", got.TypeName)
+		assert.Equal(oplog.OpType_OP_TYPE_UPDATE, got.OpType)
+		assert.Equal([]string{"Name"}, got.FieldMaskPaths)
+		assert.Equal([]string{"Description"}, got.SetToNullPaths)
+		assert.True(proto.Equal(scope, got.Message))
+	})
+	t.Run("unknown-type", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := decodeOperation(types, &oplog.AnyOperation{
+			TypeName:      "not_in_catalog",
+			Value:         []byte("opaque"),
+			OperationType: oplog.OpType_OP_TYPE_DELETE,
+		})
+		require.NoError(err)
+		assert.Equal("not_in_catalog", got.TypeName)
+		assert.Equal(oplog.OpType_OP_TYPE_DELETE, got.OpType)
+		assert.Nil(got.Message)
+	})
+	t.Run("redacts-secrets", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		am := &oidcstore.AuthMethod{
+			PublicId:         "amoidc_1234567890",
+			ClientId:         "client-id",
+			ClientSecret:     "client-secret",
+			CtClientSecret:   []byte("ciphertext"),
+			ClientSecretHmac: "hmac",
+		}
+		value, err := proto.Marshal(am)
+		require.NoError(err)
+		got, err := decodeOperation(types, &oplog.AnyOperation{
+			TypeName:      "auth_oidc_method",
+			Value:         value,
+			OperationType: oplog.OpType_OP_TYPE_CREATE,
+		})
+		require.NoError(err)
+		gotAm, ok := got.Message.(*oidcstore.AuthMethod)
+		require.True(ok)
+		assert.Equal("amoidc_1234567890", gotAm.PublicId)
+		assert.Equal("client-id", gotAm.ClientId)
+		assert.Empty(gotAm.ClientSecret)
+		assert.Empty(gotAm.CtClientSecret)
+		assert.Empty(gotAm.ClientSecretHmac)
+
+		cred := &iamstore.ServiceAccountCredential{
+			PublicId:   "sacred_1234567890",
+			Type:       "client-secret",
+			SecretHash: []byte("hash"),
+		}
+		value, err = proto.Marshal(cred)
+		require.NoError(err)
+		got, err = decodeOperation(types, &oplog.AnyOperation{
+			TypeName:      "iam_service_account_credential",
+			Value:         value,
+			OperationType: oplog.OpType_OP_TYPE_CREATE,
+		})
+		require.NoError(err)
+		gotCred, ok := got.Message.(*iamstore.ServiceAccountCredential)
+		require.True(ok)
+		assert.Equal("sacred_1234567890", gotCred.PublicId)
+		assert.Empty(gotCred.SecretHash)
+	})
+}
@@ -0,0 +1,34 @@
+package changefeed
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withLimit int
+}
+
+func getDefaultOptions() options {
+	return options{
+		withLimit: 0,
+	}
+}
+
+// WithLimit provides an option to provide a limit on the number of oplog
+// entries returned. Intentionally allowing negative integers. If WithLimit <
+// 0, then unlimited results are returned. If WithLimit == 0, then default
+// limits are used for results.
+func WithLimit(l int) Option {
+	return func(o *options) {
+		o.withLimit = l
+	}
+}
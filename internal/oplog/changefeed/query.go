@@ -0,0 +1,13 @@
+package changefeed
+
+const (
+	oplogKeyScopeQuery = `
+select rk.scope_id
+  from kms_oplog_key_version kv
+  join kms_oplog_key k
+    on k.private_id = kv.oplog_key_id
+  join kms_root_key rk
+    on rk.private_id = k.root_key_id
+ where kv.private_id = ?;
+`
+)
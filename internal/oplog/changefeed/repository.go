@@ -0,0 +1,186 @@
+package changefeed
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/store"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// A Repository reads and decrypts oplog entries.
+type Repository struct {
+	reader db.Reader
+	kms    *kms.Kms
+	types  *oplog.TypeCatalog
+
+	// defaultLimit provides a default for limiting the number of results
+	// returned from the repo
+	defaultLimit int
+}
+
+// NewRepository creates a new Repository. The returned repository is not
+// safe for concurrent go routines to access it. Supports the WithLimit
+// option.
+func NewRepository(r db.Reader, kms *kms.Kms, opt ...Option) (*Repository, error) {
+	const op = "changefeed.NewRepository"
+	switch {
+	case r == nil:
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "nil reader")
+	case kms == nil:
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "nil kms")
+	}
+	types, err := newTypeCatalog()
+	if err != nil {
+		return nil, errors.WrapDeprecated(err, op)
+	}
+	opts := getOpts(opt...)
+	if opts.withLimit == 0 {
+		// zero signals the boundary defaults should be used.
+		opts.withLimit = db.DefaultLimit
+	}
+	return &Repository{
+		reader:       r,
+		kms:          kms,
+		types:        types,
+		defaultLimit: opts.withLimit,
+	}, nil
+}
+
+// ListChanges returns the decrypted oplog entries with an id greater than
+// sinceId in ascending id order. Supports the WithLimit option.
+func (r *Repository) ListChanges(ctx context.Context, sinceId uint32, opt ...Option) ([]*Change, error) {
+	const op = "changefeed.(Repository).ListChanges"
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+
+	var entries []*store.Entry
+	if err := r.reader.SearchWhere(ctx, &entries, "id > ?", []interface{}{sinceId}, db.WithLimit(limit), db.WithOrder("id asc")); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint32, 0, len(entries))
+	for _, e := range entries {
+		ids = append(ids, e.GetId())
+	}
+	var metadata []*store.Metadata
+	if err := r.reader.SearchWhere(ctx, &metadata, "entry_id in (?)", []interface{}{ids}, db.WithLimit(-1)); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to search oplog metadata"))
+	}
+	metadataByEntry := make(map[uint32]oplog.Metadata, len(entries))
+	for _, m := range metadata {
+		md, ok := metadataByEntry[m.GetEntryId()]
+		if !ok {
+			md = oplog.Metadata{}
+			metadataByEntry[m.GetEntryId()] = md
+		}
+		md[m.GetKey()] = append(md[m.GetKey()], m.GetValue())
+	}
+
+	scopeByKeyId := make(map[string]string)
+	changes := make([]*Change, 0, len(entries))
+	for _, e := range entries {
+		c, err := r.decryptEntry(ctx, e, scopeByKeyId)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to decrypt oplog entry %d", e.GetId())))
+		}
+		c.Metadata = metadataByEntry[e.GetId()]
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+// decryptEntry decrypts the entry with the oplog key that encrypted it and
+// decodes the entry's operations. scopeByKeyId caches the scope of each
+// oplog key version that has been looked up.
+func (r *Repository) decryptEntry(ctx context.Context, e *store.Entry, scopeByKeyId map[string]string) (*Change, error) {
+	const op = "changefeed.(Repository).decryptEntry"
+	blobInfo := new(wrapping.EncryptedBlobInfo)
+	if err := proto.Unmarshal(e.GetCtData(), blobInfo); err != nil {
+		return nil, errors.New(ctx, errors.Decode, op, "unable to unmarshal encrypted entry data", errors.WithWrap(err))
+	}
+	keyId := blobInfo.GetKeyInfo().GetKeyID()
+	if keyId == "" {
+		return nil, errors.New(ctx, errors.KeyNotFound, op, "missing key id in encrypted entry data")
+	}
+	scopeId, ok := scopeByKeyId[keyId]
+	if !ok {
+		var err error
+		if scopeId, err = r.lookupOplogKeyScope(ctx, keyId); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		scopeByKeyId[keyId] = scopeId
+	}
+	wrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog, kms.WithKeyId(keyId))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	entry := &oplog.Entry{
+		Entry:    proto.Clone(e).(*store.Entry),
+		Cipherer: wrapper,
+	}
+	if err := entry.DecryptData(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	c := &Change{
+		EntryId:       e.GetId(),
+		CreateTime:    e.GetCreateTime(),
+		AggregateName: e.GetAggregateName(),
+		ScopeId:       scopeId,
+	}
+	queue := oplog.Queue{
+		Buffer: *bytes.NewBuffer(entry.Data),
+	}
+	for {
+		anyOp, err := queue.RemoveOperation()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		o, err := decodeOperation(r.types, anyOp)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		c.Operations = append(c.Operations, o)
+	}
+	return c, nil
+}
+
+// lookupOplogKeyScope returns the id of the scope which owns the oplog key
+// version.
+func (r *Repository) lookupOplogKeyScope(ctx context.Context, keyVersionId string) (string, error) {
+	const op = "changefeed.(Repository).lookupOplogKeyScope"
+	rows, err := r.reader.Query(ctx, oplogKeyScopeQuery, []interface{}{keyVersionId})
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var scopeId string
+	for rows.Next() {
+		if err := rows.Scan(&scopeId); err != nil {
+			return "", errors.Wrap(ctx, err, op)
+		}
+	}
+	if scopeId == "" {
+		return "", errors.New(ctx, errors.KeyNotFound, op, fmt.Sprintf("no scope found for oplog key version %s", keyVersionId))
+	}
+	return scopeId, nil
+}
@@ -0,0 +1,95 @@
+package changefeed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	iamstore "github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRepository(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := NewRepository(rw, testKms, WithLimit(5))
+		require.NoError(err)
+		assert.Equal(rw, got.reader)
+		assert.Equal(testKms, got.kms)
+		assert.Equal(5, got.defaultLimit)
+		assert.NotNil(got.types)
+	})
+	t.Run("nil-reader", func(t *testing.T) {
+		_, err := NewRepository(nil, testKms)
+		require.Error(t, err)
+		assert.Equal(t, "changefeed.NewRepository: nil reader: parameter violation: error #100", err.Error())
+	})
+	t.Run("nil-kms", func(t *testing.T) {
+		_, err := NewRepository(rw, nil)
+		require.Error(t, err)
+		assert.Equal(t, "changefeed.NewRepository: nil kms: parameter violation: error #100", err.Error())
+	})
+}
+
+func TestRepository_ListChanges(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	repo, err := NewRepository(rw, testKms)
+	require.NoError(t, err)
+
+	t.Run("all", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		changes, err := repo.ListChanges(ctx, 0, WithLimit(-1))
+		require.NoError(err)
+		require.NotEmpty(changes)
+
+		var found bool
+		var lastId uint32
+		for _, c := range changes {
+			assert.Greater(c.EntryId, lastId)
+			lastId = c.EntryId
+			for _, o := range c.Operations {
+				s, ok := o.Message.(*iamstore.Scope)
+				if !ok || s.GetPublicId() != org.GetPublicId() || o.OpType != oplog.OpType_OP_TYPE_CREATE {
+					continue
+				}
+				found = true
+				assert.Equal("iam_scope", o.TypeName)
+				assert.NotEmpty(c.ScopeId)
+			}
+		}
+		assert.True(found, "missing create of org scope")
+
+		got, err := repo.ListChanges(ctx, lastId)
+		require.NoError(err)
+		assert.Empty(got)
+	})
+	t.Run("limit", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		changes, err := repo.ListChanges(ctx, 0, WithLimit(1))
+		require.NoError(err)
+		require.Len(changes, 1)
+
+		next, err := repo.ListChanges(ctx, changes[0].EntryId, WithLimit(1))
+		require.NoError(err)
+		require.Len(next, 1)
+		assert.Greater(next[0].EntryId, changes[0].EntryId)
+	})
+}
@@ -92,29 +92,44 @@ func (q *Queue) Add(m proto.Message, typeName string, t OpType, opt ...Option) e
 	return nil
 }
 
-// Remove pb message from the queue and EOF if empty. It also returns the OpType
-// for the msg and if it's OpType_OP_TYPE_UPDATE, the it will also return the
-// fieldMask and setToNullPaths for the update operation.
-func (q *Queue) Remove() (proto.Message, OpType, []string, []string, error) {
-	const op = "oplog.(Queue).Remove"
-	if q.Catalog == nil {
-		return nil, OpType_OP_TYPE_UNSPECIFIED, nil, nil, errors.NewDeprecated(errors.InvalidParameter, op, "nil catalog")
-	}
+// RemoveOperation removes the next AnyOperation from the queue without
+// resolving its TypeName through the Catalog, and returns EOF if empty. This
+// allows callers to inspect operations whose types are not in the Catalog.
+func (q *Queue) RemoveOperation() (*AnyOperation, error) {
+	const op = "oplog.(Queue).RemoveOperation"
 	q.mx.Lock()
 	defer q.mx.Unlock()
 	var n uint32
 	err := binary.Read(q, binary.LittleEndian, &n)
 	if err == io.EOF {
-		return nil, 0, nil, nil, err // intentionally not wrapping error, return io.EOF so client can handle it correctly
+		return nil, err // intentionally not wrapping error, return io.EOF so client can handle it correctly
 	}
 	if err != nil {
-		return nil, 0, nil, nil, errors.NewDeprecated(errors.Io, op, "binary read error", errors.WithWrap(err))
+		return nil, errors.NewDeprecated(errors.Io, op, "binary read error", errors.WithWrap(err))
 	}
 	data := q.Next(int(n))
 	msg := new(AnyOperation)
 	err = proto.Unmarshal(data, msg)
 	if err != nil {
-		return nil, 0, nil, nil, errors.NewDeprecated(errors.Decode, op, "error unmarshaling message", errors.WithWrap(err))
+		return nil, errors.NewDeprecated(errors.Decode, op, "error unmarshaling message", errors.WithWrap(err))
+	}
+	return msg, nil
+}
+
+// Remove pb message from the queue and EOF if empty. It also returns the OpType
+// for the msg and if it's OpType_OP_TYPE_UPDATE, the it will also return the
+// fieldMask and setToNullPaths for the update operation.
+func (q *Queue) Remove() (proto.Message, OpType, []string, []string, error) {
+	const op = "oplog.(Queue).Remove"
+	if q.Catalog == nil {
+		return nil, OpType_OP_TYPE_UNSPECIFIED, nil, nil, errors.NewDeprecated(errors.InvalidParameter, op, "nil catalog")
+	}
+	msg, err := q.RemoveOperation()
+	if err == io.EOF {
+		return nil, 0, nil, nil, err // intentionally not wrapping error, return io.EOF so client can handle it correctly
+	}
+	if err != nil {
+		return nil, 0, nil, nil, errors.WrapDeprecated(err, op)
 	}
 	if msg.Value == nil {
 		return nil, 0, nil, nil, nil
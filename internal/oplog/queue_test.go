@@ -1,6 +1,7 @@
 package oplog
 
 import (
+	"io"
 	"testing"
 
 	"github.com/hashicorp/boundary/internal/oplog/oplog_test"
@@ -106,6 +107,24 @@ func Test_Queue(t *testing.T) {
 		require.Error(err)
 		assert.Equal("oplog.(Queue).Remove: nil catalog: parameter violation: error #100", err.Error())
 	})
+	t.Run("remove operation without catalog", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		queue := Queue{}
+		require.NoError(queue.Add(userUpdate, "user", OpType_OP_TYPE_UPDATE, WithFieldMaskPaths([]string{"Name"}), WithSetToNullPaths([]string{"Email"})))
+
+		anyOp, err := queue.RemoveOperation()
+		require.NoError(err)
+		assert.Equal("user", anyOp.TypeName)
+		assert.Equal(OpType_OP_TYPE_UPDATE, anyOp.OperationType)
+		assert.Equal([]string{"Name"}, anyOp.FieldMask.GetPaths())
+		assert.Equal([]string{"Email"}, anyOp.NullMask.GetPaths())
+		got := new(oplog_test.TestUser)
+		require.NoError(proto.Unmarshal(anyOp.Value, got))
+		assert.True(proto.Equal(userUpdate, got))
+
+		_, err = queue.RemoveOperation()
+		assert.Equal(io.EOF, err)
+	})
 	t.Run("not replayable", func(t *testing.T) {
 		assert, require := assert.New(t), require.New(t)
 		u := &oplog_test.TestNonReplayableUser{
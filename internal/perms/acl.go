@@ -14,6 +14,7 @@ construction is thus synthesizing something reasonable from a set of Grants.
 */
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -26,6 +27,10 @@ const AnonymousUserId = "u_anon"
 // action is allowed on a resource based on a principal's (user or group) grants.
 type ACL struct {
 	scopeMap map[string][]Grant
+
+	// restrictions, if set, is an ACL which must also allow an action for it
+	// to be authorized.
+	restrictions *ACL
 }
 
 // ACLResults provides a type for the permission's engine results so that we can
@@ -70,8 +75,29 @@ func NewACL(grants ...Grant) ACL {
 	return ret
 }
 
+// Restrict returns a copy of the ACL which only authorizes actions that are
+// also allowed by the provided grants. Output fields are still determined by the
+// grants of the original ACL. Restricting an already restricted ACL only
+// authorizes actions allowed by all of the sets of grants.
+func (a ACL) Restrict(grants ...Grant) ACL {
+	restrictions := NewACL(grants...)
+	if a.restrictions != nil {
+		restrictions = a.restrictions.Restrict(grants...)
+	}
+	a.restrictions = &restrictions
+	return a
+}
+
 // Allowed determines if the grants for an ACL allow an action for a resource.
 func (a ACL) Allowed(r Resource, aType action.Type) (results ACLResults) {
+	results = a.allowed(r, aType)
+	if results.Authorized && a.restrictions != nil && !a.restrictions.Allowed(r, aType).Authorized {
+		results.Authorized = false
+	}
+	return
+}
+
+func (a ACL) allowed(r Resource, aType action.Type) (results ACLResults) {
 	// First, get the grants within the specified scope
 	grants := a.scopeMap[r.ScopeId]
 	results.scopeMap = a.scopeMap
@@ -176,15 +202,86 @@ func (a ACL) Allowed(r Resource, aType action.Type) (results ACLResults) {
 	return
 }
 
+// Permission describes the resources of one type within a scope on which an
+// ACL allows an action.
+type Permission struct {
+	ScopeId string
+
+	// All is true if the action is allowed on every resource of the type in
+	// the scope.
+	All bool
+
+	// ResourceIds are the resources the action is allowed on if All is
+	// false. Grants on specific ids do not carry a type, so the ids may
+	// belong to resources of other types.
+	ResourceIds []string
+}
+
+// ListPermissions returns, for each scope with a matching grant, the
+// resources of type typ on which the ACL allows aType, ordered by scope id.
+// It lets callers find every resource they may act on without walking the
+// scopes. Pinned grants only apply to resources which are not top level and
+// restrictions are not taken into account, so each resource found should
+// still be checked with Allowed.
+func (a ACL) ListPermissions(typ resource.Type, aType action.Type) []Permission {
+	var parentAction action.Type
+	split := strings.Split(aType.String(), ":")
+	if len(split) == 2 {
+		parentAction = action.Map[split[0]]
+	}
+
+	scopeIds := make([]string, 0, len(a.scopeMap))
+	for scopeId := range a.scopeMap {
+		scopeIds = append(scopeIds, scopeId)
+	}
+	sort.Strings(scopeIds)
+
+	var ret []Permission
+	for _, scopeId := range scopeIds {
+		p := Permission{ScopeId: scopeId}
+		for _, grant := range a.scopeMap[scopeId] {
+			switch {
+			case grant.actions[aType],
+				grant.actions[parentAction],
+				grant.actions[action.All]:
+			default:
+				continue
+			}
+			switch {
+			case grant.id == "*" &&
+				(grant.typ == typ || grant.typ == resource.All):
+				p.All = true
+			case grant.id != "" &&
+				grant.id != "*" &&
+				grant.typ == resource.Unknown:
+				p.ResourceIds = append(p.ResourceIds, grant.id)
+			}
+		}
+		switch {
+		case p.All:
+			p.ResourceIds = nil
+		case len(p.ResourceIds) == 0:
+			continue
+		}
+		ret = append(ret, p)
+	}
+	return ret
+}
+
 func topLevelType(typ resource.Type) bool {
 	switch typ {
-	case resource.AuthMethod,
+	case resource.Alias,
+		resource.AuthMethod,
 		resource.AuthToken,
 		resource.CredentialStore,
 		resource.Group,
 		resource.HostCatalog,
+		resource.Notification,
+		resource.OplogChange,
+		resource.Report,
 		resource.Role,
 		resource.Scope,
+		resource.ServiceAccount,
 		resource.Session,
 		resource.Target,
 		resource.User:
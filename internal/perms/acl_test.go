@@ -337,6 +337,166 @@ func Test_ACLAllowed(t *testing.T) {
 	}
 }
 
+func Test_ACLRestrict(t *testing.T) {
+	t.Parallel()
+
+	parse := func(scopeId string, grants ...string) []Grant {
+		ret := make([]Grant, 0, len(grants))
+		for _, g := range grants {
+			grant, err := Parse(scopeId, g)
+			require.NoError(t, err)
+			ret = append(ret, grant)
+		}
+		return ret
+	}
+
+	acl := NewACL(append(
+		parse("o_a", "id=*;type=*;actions=*;output_fields=id,name"),
+		parse("o_b", "id=*;type=target;actions=read,authorize-session")...,
+	)...)
+
+	tests := []struct {
+		name         string
+		restrictions [][]Grant
+		resource     Resource
+		action       action.Type
+		authorized   bool
+	}{
+		{
+			name:       "unrestricted",
+			resource:   Resource{ScopeId: "o_a", Id: "ttcp_1", Type: resource.Target},
+			action:     action.Delete,
+			authorized: true,
+		},
+		{
+			name:         "allowed by both",
+			restrictions: [][]Grant{parse("o_a", "id=*;type=target;actions=read")},
+			resource:     Resource{ScopeId: "o_a", Id: "ttcp_1", Type: resource.Target},
+			action:       action.Read,
+			authorized:   true,
+		},
+		{
+			name:         "not allowed by restriction",
+			restrictions: [][]Grant{parse("o_a", "id=*;type=target;actions=read")},
+			resource:     Resource{ScopeId: "o_a", Id: "ttcp_1", Type: resource.Target},
+			action:       action.Delete,
+		},
+		{
+			name:         "restriction in another scope",
+			restrictions: [][]Grant{parse("o_b", "id=*;type=*;actions=*")},
+			resource:     Resource{ScopeId: "o_a", Id: "ttcp_1", Type: resource.Target},
+			action:       action.Read,
+		},
+		{
+			name:         "not allowed by acl",
+			restrictions: [][]Grant{parse("o_b", "id=*;type=*;actions=*")},
+			resource:     Resource{ScopeId: "o_b", Id: "ttcp_1", Type: resource.Target},
+			action:       action.Delete,
+		},
+		{
+			name: "nested restrictions",
+			restrictions: [][]Grant{
+				parse("o_a", "id=*;type=target;actions=read,delete"),
+				parse("o_a", "id=*;type=target;actions=read"),
+			},
+			resource: Resource{ScopeId: "o_a", Id: "ttcp_1", Type: resource.Target},
+			action:   action.Delete,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			restricted := acl
+			for _, r := range tt.restrictions {
+				restricted = restricted.Restrict(r...)
+			}
+			result := restricted.Allowed(tt.resource, tt.action)
+			assert.Equal(tt.authorized, result.Authorized)
+			if tt.authorized && tt.resource.ScopeId == "o_a" {
+				assert.ElementsMatch([]string{"id", "name"}, result.OutputFields.Fields())
+			}
+			// Restricting must not change the original ACL
+			assert.Nil(acl.restrictions)
+		})
+	}
+}
+
+func Test_ACLListPermissions(t *testing.T) {
+	t.Parallel()
+
+	parse := func(scopeId string, grants ...string) []Grant {
+		ret := make([]Grant, 0, len(grants))
+		for _, g := range grants {
+			grant, err := Parse(scopeId, g)
+			require.NoError(t, err)
+			ret = append(ret, grant)
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name   string
+		grants []Grant
+		want   []Permission
+	}{
+		{
+			name: "no grants",
+		},
+		{
+			name:   "other action",
+			grants: parse("p_a", "id=*;type=target;actions=read"),
+		},
+		{
+			name:   "other type",
+			grants: parse("p_a", "id=*;type=host-set;actions=*"),
+		},
+		{
+			name: "all targets",
+			grants: append(
+				parse("p_a", "id=*;type=target;actions=authorize-session"),
+				parse("p_b", "id=*;type=*;actions=*")...,
+			),
+			want: []Permission{
+				{ScopeId: "p_a", All: true},
+				{ScopeId: "p_b", All: true},
+			},
+		},
+		{
+			name: "specific ids",
+			grants: parse("p_a",
+				"id=ttcp_1;actions=authorize-session",
+				"id=ttcp_2;actions=read,authorize-session",
+				"id=ttcp_3;actions=read",
+			),
+			want: []Permission{
+				{ScopeId: "p_a", ResourceIds: []string{"ttcp_1", "ttcp_2"}},
+			},
+		},
+		{
+			name: "all overrides ids",
+			grants: parse("p_a",
+				"id=ttcp_1;actions=authorize-session",
+				"id=*;type=target;actions=authorize-session",
+			),
+			want: []Permission{
+				{ScopeId: "p_a", All: true},
+			},
+		},
+		{
+			name:   "type grant without id",
+			grants: parse("p_a", "type=target;actions=list"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			acl := NewACL(tt.grants...)
+			assert.Equal(t, tt.want, acl.ListPermissions(resource.Target, action.AuthorizeSession))
+		})
+	}
+}
+
 func TestJsonMarshal(t *testing.T) {
 	res := &Resource{
 		ScopeId: "scope",
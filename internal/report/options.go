@@ -0,0 +1,64 @@
+package report
+
+import "time"
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withLimit     int
+	withScopeIds  []string
+	withStartTime time.Time
+	withEndTime   time.Time
+}
+
+func getDefaultOptions() options {
+	return options{
+		withLimit: 0,
+	}
+}
+
+// WithLimit provides an option to provide a limit on the number of rows
+// returned. Intentionally allowing negative integers. If WithLimit < 0, then
+// unlimited results are returned. If WithLimit == 0, then default limits are
+// used for results.
+func WithLimit(l int) Option {
+	return func(o *options) {
+		o.withLimit = l
+	}
+}
+
+// WithScopeIds provides an option to only include warehouse rows belonging to
+// the given organization or project scopes. No filtering is done if no scope
+// ids are provided.
+func WithScopeIds(ids []string) Option {
+	return func(o *options) {
+		o.withScopeIds = ids
+	}
+}
+
+// WithStartTime provides an option to only include warehouse rows at or after
+// the given time.
+func WithStartTime(t time.Time) Option {
+	return func(o *options) {
+		o.withStartTime = t
+	}
+}
+
+// WithEndTime provides an option to only include warehouse rows before the
+// given time.
+func WithEndTime(t time.Time) Option {
+	return func(o *options) {
+		o.withEndTime = t
+	}
+}
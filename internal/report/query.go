@@ -0,0 +1,131 @@
+package report
+
+const (
+	sessionsPerUserQuery = `
+select ud.user_id,
+       max(ud.user_name)                    as user_name,
+       count(*)::bigint                     as session_count,
+       sum(f.total_connection_count)::bigint as connection_count,
+       sum(f.total_bytes_up)::bigint        as bytes_up,
+       sum(f.total_bytes_down)::bigint      as bytes_down
+  from wh_session_accumulating_fact f
+  join wh_user_dimension ud
+    on ud.key = f.user_key
+  join wh_host_dimension hd
+    on hd.key = f.host_key
+ where %s
+ group by ud.user_id
+ order by session_count desc, ud.user_id
+ limit ?;
+`
+
+	sessionsPerTargetQuery = `
+select hd.target_id,
+       max(hd.target_name)                  as target_name,
+       max(hd.target_type)                  as target_type,
+       max(hd.project_id)                   as project_id,
+       count(*)::bigint                     as session_count,
+       count(distinct ud.user_id)::bigint   as user_count,
+       sum(f.total_bytes_up)::bigint        as bytes_up,
+       sum(f.total_bytes_down)::bigint      as bytes_down
+  from wh_session_accumulating_fact f
+  join wh_user_dimension ud
+    on ud.key = f.user_key
+  join wh_host_dimension hd
+    on hd.key = f.host_key
+ where %s
+ group by hd.target_id
+ order by session_count desc, hd.target_id
+ limit ?;
+`
+
+	sessionsPerDayQuery = `
+select dd.date::text                        as date,
+       count(*)::bigint                     as session_count,
+       count(distinct ud.user_id)::bigint   as user_count
+  from wh_session_accumulating_fact f
+  join wh_date_dimension dd
+    on dd.key = f.session_pending_date_key
+  join wh_user_dimension ud
+    on ud.key = f.user_key
+  join wh_host_dimension hd
+    on hd.key = f.host_key
+ where %s
+ group by dd.date
+ order by dd.date
+ limit ?;
+`
+
+	connectionDurationsQuery = `
+select hd.target_id,
+       max(hd.target_name)                  as target_name,
+       count(*)::bigint                     as connection_count,
+       avg(extract(epoch from cf.connection_closed_time - cf.connection_connected_time))::float8 as avg_duration_seconds,
+       max(extract(epoch from cf.connection_closed_time - cf.connection_connected_time))::float8 as max_duration_seconds
+  from wh_session_connection_accumulating_fact cf
+  join wh_host_dimension hd
+    on hd.key = cf.host_key
+ where cf.connection_connected_time <> 'infinity'::timestamptz
+   and cf.connection_closed_time <> 'infinity'::timestamptz
+   and %s
+ group by hd.target_id
+ order by connection_count desc, hd.target_id
+ limit ?;
+`
+
+	topHostsQuery = `
+select hd.host_id,
+       max(hd.host_name)                    as host_name,
+       max(hd.host_address)                 as host_address,
+       max(hd.host_type)                    as host_type,
+       count(*)::bigint                     as session_count,
+       sum(f.total_bytes_up)::bigint        as bytes_up,
+       sum(f.total_bytes_down)::bigint      as bytes_down
+  from wh_session_accumulating_fact f
+  join wh_host_dimension hd
+    on hd.key = f.host_key
+ where %s
+ group by hd.host_id
+ order by session_count desc, hd.host_id
+ limit ?;
+`
+
+	credentialUsageQuery = `
+select cd.credential_library_id,
+       max(cd.credential_library_name)      as credential_library_name,
+       max(cd.credential_store_id)          as credential_store_id,
+       count(distinct f.session_id)::bigint as session_count,
+       count(distinct cd.target_id)::bigint as target_count
+  from wh_session_accumulating_fact f
+  join wh_credential_group_membership cgm
+    on cgm.credential_group_key = f.credential_group_key
+  join wh_credential_dimension cd
+    on cd.key = cgm.credential_key
+ where cd.credential_library_id not in ('None', 'Unknown')
+   and %s
+ group by cd.credential_library_id
+ order by session_count desc, cd.credential_library_id
+ limit ?;
+`
+
+	failedAuthorizationsQuery = `
+select af.user_id,
+       af.target_id,
+       max(af.project_id)                   as project_id,
+       count(*)::bigint                     as failure_count,
+       max(af.failure_time)                 as last_failure_time
+  from wh_authorization_failure_fact af
+ where %s
+ group by af.user_id, af.target_id
+ order by failure_count desc, af.user_id, af.target_id
+ limit ?;
+`
+
+	insertAuthorizationFailureQuery = `
+insert into wh_authorization_failure_fact
+  (user_id, target_id, project_id, organization_id, reason)
+select ?, ?, s.public_id, s.parent_id, ?
+  from iam_scope s
+ where s.public_id = ?;
+`
+)
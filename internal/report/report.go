@@ -0,0 +1,148 @@
+// Package report provides canned aggregations over the session data
+// warehouse. Each report is a fixed query over the wh_* star schema which can
+// be narrowed to a set of scopes and a time range.
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	SessionsPerUser      = "sessions-per-user"
+	SessionsPerTarget    = "sessions-per-target"
+	SessionsPerDay       = "sessions-per-day"
+	ConnectionDurations  = "connection-durations"
+	TopHosts             = "top-hosts"
+	CredentialUsage      = "credential-usage"
+	FailedAuthorizations = "failed-authorizations"
+)
+
+// A Report describes one of the canned reports.
+type Report struct {
+	Name        string
+	Description string
+
+	// query is the report's SQL. It must contain a single %s verb where the
+	// filter conditions are placed and must end with a limit clause taking a
+	// single parameter.
+	query string
+	// timeColumn is the column compared with the start and end times.
+	timeColumn string
+	// projectColumn and orgColumn are the columns compared with the scope
+	// ids.
+	projectColumn string
+	orgColumn     string
+}
+
+var reports = map[string]*Report{
+	SessionsPerUser: {
+		Name:          SessionsPerUser,
+		Description:   "The number of sessions and the bytes transferred for each user.",
+		query:         sessionsPerUserQuery,
+		timeColumn:    "f.session_pending_time",
+		projectColumn: "hd.project_id",
+		orgColumn:     "hd.organization_id",
+	},
+	SessionsPerTarget: {
+		Name:          SessionsPerTarget,
+		Description:   "The number of sessions and the bytes transferred for each target.",
+		query:         sessionsPerTargetQuery,
+		timeColumn:    "f.session_pending_time",
+		projectColumn: "hd.project_id",
+		orgColumn:     "hd.organization_id",
+	},
+	SessionsPerDay: {
+		Name:          SessionsPerDay,
+		Description:   "The number of sessions and distinct users for each day.",
+		query:         sessionsPerDayQuery,
+		timeColumn:    "f.session_pending_time",
+		projectColumn: "hd.project_id",
+		orgColumn:     "hd.organization_id",
+	},
+	ConnectionDurations: {
+		Name:          ConnectionDurations,
+		Description:   "The number of closed connections and their average and maximum duration in seconds for each target.",
+		query:         connectionDurationsQuery,
+		timeColumn:    "cf.connection_authorized_time",
+		projectColumn: "hd.project_id",
+		orgColumn:     "hd.organization_id",
+	},
+	TopHosts: {
+		Name:          TopHosts,
+		Description:   "The hosts with the most sessions.",
+		query:         topHostsQuery,
+		timeColumn:    "f.session_pending_time",
+		projectColumn: "hd.project_id",
+		orgColumn:     "hd.organization_id",
+	},
+	CredentialUsage: {
+		Name:          CredentialUsage,
+		Description:   "The number of sessions brokering credentials from each credential library.",
+		query:         credentialUsageQuery,
+		timeColumn:    "f.session_pending_time",
+		projectColumn: "cd.project_id",
+		orgColumn:     "cd.organization_id",
+	},
+	FailedAuthorizations: {
+		Name:          FailedAuthorizations,
+		Description:   "The number of denied session authorizations for each user and target.",
+		query:         failedAuthorizationsQuery,
+		timeColumn:    "af.failure_time",
+		projectColumn: "af.project_id",
+		orgColumn:     "af.organization_id",
+	},
+}
+
+// List returns all of the reports ordered by name.
+func List() []*Report {
+	ret := make([]*Report, 0, len(reports))
+	for _, r := range reports {
+		ret = append(ret, r)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
+	return ret
+}
+
+// Lookup returns the report with the given name or nil if no report has that
+// name.
+func Lookup(name string) *Report {
+	return reports[name]
+}
+
+// build returns the report's query and its parameters for the given
+// options.
+func (r *Report) build(opts options, limit int) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	if len(opts.withScopeIds) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(opts.withScopeIds)), ", ")
+		conds = append(conds, fmt.Sprintf("(%s in (%s) or %s in (%s))", r.projectColumn, placeholders, r.orgColumn, placeholders))
+		for i := 0; i < 2; i++ {
+			for _, id := range opts.withScopeIds {
+				args = append(args, id)
+			}
+		}
+	}
+	if !opts.withStartTime.IsZero() {
+		conds = append(conds, fmt.Sprintf("%s >= ?", r.timeColumn))
+		args = append(args, opts.withStartTime)
+	}
+	if !opts.withEndTime.IsZero() {
+		conds = append(conds, fmt.Sprintf("%s < ?", r.timeColumn))
+		args = append(args, opts.withEndTime)
+	}
+	where := "true"
+	if len(conds) > 0 {
+		where = strings.Join(conds, "\n   and ")
+	}
+
+	// A null limit returns all rows
+	var l interface{}
+	if limit > 0 {
+		l = limit
+	}
+	args = append(args, l)
+	return fmt.Sprintf(r.query, where), args
+}
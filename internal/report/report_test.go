@@ -0,0 +1,76 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestList(t *testing.T) {
+	t.Parallel()
+	got := List()
+	require.Len(t, got, len(reports))
+	for i := 1; i < len(got); i++ {
+		assert.Less(t, got[i-1].Name, got[i].Name)
+	}
+	for _, r := range got {
+		assert.NotEmpty(t, r.Description, r.Name)
+		assert.Same(t, r, Lookup(r.Name))
+	}
+	assert.Nil(t, Lookup("not-a-report"))
+}
+
+func TestReport_build(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	rpt := Lookup(SessionsPerUser)
+	require.NotNil(t, rpt)
+
+	tests := []struct {
+		name      string
+		opts      options
+		limit     int
+		wantWhere string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "no-filters",
+			limit:     10,
+			wantWhere: "where true",
+			wantArgs:  []interface{}{10},
+		},
+		{
+			name:      "unlimited",
+			limit:     -1,
+			wantWhere: "where true",
+			wantArgs:  []interface{}{nil},
+		},
+		{
+			name:      "scopes",
+			opts:      options{withScopeIds: []string{"o_1", "p_1"}},
+			limit:     10,
+			wantWhere: "where (hd.project_id in (?, ?) or hd.organization_id in (?, ?))",
+			wantArgs:  []interface{}{"o_1", "p_1", "o_1", "p_1", 10},
+		},
+		{
+			name:      "times",
+			opts:      options{withStartTime: start, withEndTime: end},
+			limit:     10,
+			wantWhere: "where f.session_pending_time >= ?\n   and f.session_pending_time < ?",
+			wantArgs:  []interface{}{start, end, 10},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			query, args := rpt.build(tt.opts, tt.limit)
+			assert.True(strings.Contains(query, tt.wantWhere), query)
+			assert.Equal(tt.wantArgs, args)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// A Result is the output of running a report. Each row holds one value for
+// each column. Values are strings, int64s, float64s or nil. Timestamps are
+// returned as RFC 3339 strings in UTC.
+type Result struct {
+	Name    string
+	Columns []string
+	Rows    [][]interface{}
+}
+
+// A Repository runs reports over the session data warehouse and records
+// warehouse facts which are not captured by database triggers.
+type Repository struct {
+	reader db.Reader
+	writer db.Writer
+
+	// defaultLimit provides a default for limiting the number of rows
+	// returned from the repo
+	defaultLimit int
+}
+
+// NewRepository creates a new Repository. The returned repository should
+// only be used for one transaction and it is not safe for concurrent go
+// routines to access it. Supports the WithLimit option.
+func NewRepository(r db.Reader, w db.Writer, opt ...Option) (*Repository, error) {
+	const op = "report.NewRepository"
+	switch {
+	case r == nil:
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "nil reader")
+	case w == nil:
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "nil writer")
+	}
+	opts := getOpts(opt...)
+	if opts.withLimit == 0 {
+		// zero signals the boundary defaults should be used.
+		opts.withLimit = db.DefaultLimit
+	}
+	return &Repository{
+		reader:       r,
+		writer:       w,
+		defaultLimit: opts.withLimit,
+	}, nil
+}
+
+// RunReport runs the report with the given name. Supports the WithLimit,
+// WithScopeIds, WithStartTime and WithEndTime options.
+func (r *Repository) RunReport(ctx context.Context, name string, opt ...Option) (*Result, error) {
+	const op = "report.(Repository).RunReport"
+	rpt := Lookup(name)
+	if rpt == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("unknown report %q", name))
+	}
+	opts := getOpts(opt...)
+	if !opts.withStartTime.IsZero() && !opts.withEndTime.IsZero() && !opts.withStartTime.Before(opts.withEndTime) {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "start time must be before end time")
+	}
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+
+	query, args := rpt.build(opts, limit)
+	rows, err := r.reader.Query(ctx, query, args)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to run report %q", name)))
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	res := &Result{Name: name, Columns: cols}
+	for rows.Next() {
+		vals := make([]interface{}, len(cols))
+		ptrs := make([]interface{}, len(cols))
+		for i := range vals {
+			ptrs[i] = &vals[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		for i, v := range vals {
+			vals[i] = normalize(v)
+		}
+		res.Rows = append(res.Rows, vals)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return res, nil
+}
+
+// RecordAuthorizationFailure records a denied request by userId to authorize
+// a session for the target targetId in the project projectId.
+func (r *Repository) RecordAuthorizationFailure(ctx context.Context, userId, targetId, projectId, reason string) error {
+	const op = "report.(Repository).RecordAuthorizationFailure"
+	switch {
+	case userId == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	case targetId == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target id")
+	case projectId == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+	case reason == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing reason")
+	}
+	n, err := r.writer.Exec(ctx, insertAuthorizationFailureQuery, []interface{}{userId, targetId, reason, projectId})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if n != 1 {
+		return errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("project %s not found", projectId))
+	}
+	return nil
+}
+
+// normalize converts the values returned by the database driver into the
+// types documented on Result.
+func normalize(v interface{}) interface{} {
+	switch t := v.(type) {
+	case []byte:
+		return string(t)
+	case time.Time:
+		return t.UTC().Format(time.RFC3339)
+	case int32:
+		return int64(t)
+	case float32:
+		return float64(t)
+	default:
+		return v
+	}
+}
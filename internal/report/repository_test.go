@@ -0,0 +1,128 @@
+package report
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRepository(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := NewRepository(rw, rw, WithLimit(5))
+		require.NoError(err)
+		assert.Equal(rw, got.reader)
+		assert.Equal(rw, got.writer)
+		assert.Equal(5, got.defaultLimit)
+	})
+	t.Run("nil-reader", func(t *testing.T) {
+		_, err := NewRepository(nil, rw)
+		require.Error(t, err)
+		assert.Equal(t, "report.NewRepository: nil reader: parameter violation: error #100", err.Error())
+	})
+	t.Run("nil-writer", func(t *testing.T) {
+		_, err := NewRepository(rw, nil)
+		require.Error(t, err)
+		assert.Equal(t, "report.NewRepository: nil writer: parameter violation: error #100", err.Error())
+	})
+}
+
+func TestRepository_RunReport(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	s := session.TestDefaultSession(t, conn, wrapper, iamRepo)
+
+	repo, err := NewRepository(rw, rw)
+	require.NoError(t, err)
+
+	t.Run("all-reports", func(t *testing.T) {
+		for _, rpt := range List() {
+			got, err := repo.RunReport(ctx, rpt.Name, WithScopeIds([]string{s.ScopeId}), WithStartTime(time.Now().Add(-time.Hour)), WithEndTime(time.Now().Add(time.Hour)))
+			require.NoError(t, err, rpt.Name)
+			assert.NotEmpty(t, got.Columns, rpt.Name)
+			for _, row := range got.Rows {
+				assert.Len(t, row, len(got.Columns), rpt.Name)
+			}
+		}
+	})
+	t.Run("sessions-per-user", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.RunReport(ctx, SessionsPerUser, WithScopeIds([]string{s.ScopeId}))
+		require.NoError(err)
+		assert.Equal([]string{"user_id", "user_name", "session_count", "connection_count", "bytes_up", "bytes_down"}, got.Columns)
+		require.Len(got.Rows, 1)
+		assert.Equal(s.UserId, got.Rows[0][0])
+		assert.Equal(int64(1), got.Rows[0][2])
+	})
+	t.Run("other-scope", func(t *testing.T) {
+		org, _ := iam.TestScopes(t, iamRepo)
+		got, err := repo.RunReport(ctx, SessionsPerUser, WithScopeIds([]string{org.GetPublicId()}))
+		require.NoError(t, err)
+		assert.Empty(t, got.Rows)
+	})
+	t.Run("unknown-report", func(t *testing.T) {
+		_, err := repo.RunReport(ctx, "not-a-report")
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.RecordNotFound), err))
+	})
+	t.Run("bad-time-range", func(t *testing.T) {
+		now := time.Now()
+		_, err := repo.RunReport(ctx, SessionsPerDay, WithStartTime(now), WithEndTime(now.Add(-time.Hour)))
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
+
+func TestRepository_RecordAuthorizationFailure(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, proj := iam.TestScopes(t, iamRepo)
+
+	repo, err := NewRepository(rw, rw)
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		for i := 0; i < 2; i++ {
+			require.NoError(repo.RecordAuthorizationFailure(ctx, "u_1234567890", "ttcp_1234567890", proj.GetPublicId(), "forbidden"))
+		}
+		got, err := repo.RunReport(ctx, FailedAuthorizations, WithScopeIds([]string{org.GetPublicId()}))
+		require.NoError(err)
+		require.Len(got.Rows, 1)
+		assert.Equal("u_1234567890", got.Rows[0][0])
+		assert.Equal("ttcp_1234567890", got.Rows[0][1])
+		assert.Equal(proj.GetPublicId(), got.Rows[0][2])
+		assert.Equal(int64(2), got.Rows[0][3])
+		_, err = time.Parse(time.RFC3339, got.Rows[0][4].(string))
+		assert.NoError(err)
+	})
+	t.Run("unknown-project", func(t *testing.T) {
+		err := repo.RecordAuthorizationFailure(ctx, "u_1234567890", "ttcp_1234567890", "p_doesntexist", "forbidden")
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.RecordNotFound), err))
+	})
+	t.Run("missing-reason", func(t *testing.T) {
+		err := repo.RecordAuthorizationFailure(ctx, "u_1234567890", "ttcp_1234567890", proj.GetPublicId(), "")
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
@@ -0,0 +1,122 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// Condition is an equality comparison of a filter.
+type Condition struct {
+	// Attribute is the attribute name as it appears in the schema, e.g.
+	// userName.
+	Attribute string
+	Value     string
+}
+
+// Filter is a parsed SCIM filter. Only equality comparisons joined with
+// "and" are supported, which covers the lookups identity providers issue
+// before they create a user or group, e.g. userName eq "alice".
+type Filter []Condition
+
+// filterAttributes maps the lower case names of the attributes which can be
+// filtered on to their names in the schema. Attribute names are case
+// insensitive.
+var filterAttributes = map[string]string{
+	"id":          "id",
+	"externalid":  "externalId",
+	"username":    "userName",
+	"displayname": "displayName",
+	"active":      "active",
+}
+
+// ParseFilter parses the SCIM filter expression s. An empty s results in an
+// empty Filter.
+func ParseFilter(ctx context.Context, s string) (Filter, error) {
+	const op = "scim.ParseFilter"
+	tokens, err := tokenizeFilter(ctx, s)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	var f Filter
+	for len(tokens) > 0 {
+		if len(tokens) < 3 {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "incomplete comparison in filter")
+		}
+		attr, ok := filterAttributes[strings.ToLower(tokens[0])]
+		if !ok {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "unsupported filter attribute "+tokens[0])
+		}
+		if !strings.EqualFold(tokens[1], "eq") {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "unsupported filter operator "+tokens[1])
+		}
+		value := tokens[2]
+		switch {
+		case strings.HasPrefix(value, `"`):
+			if err := json.Unmarshal([]byte(value), &value); err != nil {
+				return nil, errors.New(ctx, errors.InvalidParameter, op, "invalid string in filter", errors.WithWrap(err))
+			}
+		case strings.EqualFold(value, "true"), strings.EqualFold(value, "false"):
+			value = strings.ToLower(value)
+		default:
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "unsupported filter value "+value)
+		}
+		f = append(f, Condition{Attribute: attr, Value: value})
+
+		tokens = tokens[3:]
+		if len(tokens) > 0 {
+			if !strings.EqualFold(tokens[0], "and") {
+				return nil, errors.New(ctx, errors.InvalidParameter, op, "unsupported logical operator "+tokens[0])
+			}
+			tokens = tokens[1:]
+			if len(tokens) == 0 {
+				return nil, errors.New(ctx, errors.InvalidParameter, op, "missing comparison after and")
+			}
+		}
+	}
+	return f, nil
+}
+
+// tokenizeFilter splits s at whitespace outside of quoted strings. Quoted
+// strings are returned with their quotes.
+func tokenizeFilter(ctx context.Context, s string) ([]string, error) {
+	const op = "scim.tokenizeFilter"
+	var tokens []string
+	var cur strings.Builder
+	inString, escaped := false, false
+	for _, r := range s {
+		switch {
+		case inString:
+			cur.WriteRune(r)
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == '"':
+				inString = false
+			}
+		case r == '"':
+			cur.WriteRune(r)
+			inString = true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if cur.Len() > 0 {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+			}
+		case r == '(' || r == ')' || r == '[' || r == ']':
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "grouping is not supported in filters")
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if inString {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "unterminated string in filter")
+	}
+	if cur.Len() > 0 {
+		tokens = append(tokens, cur.String())
+	}
+	return tokens, nil
+}
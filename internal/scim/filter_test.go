@@ -0,0 +1,102 @@
+package scim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		filter  string
+		want    Filter
+		wantErr string
+	}{
+		{
+			name:   "empty",
+			filter: "",
+		},
+		{
+			name:   "user-name",
+			filter: `userName eq "alice"`,
+			want:   Filter{{Attribute: "userName", Value: "alice"}},
+		},
+		{
+			name:   "case-insensitive-attribute-and-operator",
+			filter: `USERNAME EQ "alice"`,
+			want:   Filter{{Attribute: "userName", Value: "alice"}},
+		},
+		{
+			name:   "quoted-whitespace-and-escapes",
+			filter: `displayName eq "Alice \"Al\" Smith"`,
+			want:   Filter{{Attribute: "displayName", Value: `Alice "Al" Smith`}},
+		},
+		{
+			name:   "and",
+			filter: `externalId eq "00u1" and active eq True`,
+			want: Filter{
+				{Attribute: "externalId", Value: "00u1"},
+				{Attribute: "active", Value: "true"},
+			},
+		},
+		{
+			name:    "unsupported-attribute",
+			filter:  `emails eq "alice@example.com"`,
+			wantErr: "unsupported filter attribute emails",
+		},
+		{
+			name:    "unsupported-operator",
+			filter:  `userName sw "al"`,
+			wantErr: "unsupported filter operator sw",
+		},
+		{
+			name:    "unsupported-logical-operator",
+			filter:  `userName eq "alice" or userName eq "bob"`,
+			wantErr: "unsupported logical operator or",
+		},
+		{
+			name:    "unquoted-value",
+			filter:  `userName eq alice`,
+			wantErr: "unsupported filter value alice",
+		},
+		{
+			name:    "incomplete",
+			filter:  `userName eq`,
+			wantErr: "incomplete comparison in filter",
+		},
+		{
+			name:    "dangling-and",
+			filter:  `userName eq "alice" and`,
+			wantErr: "missing comparison after and",
+		},
+		{
+			name:    "grouping",
+			filter:  `(userName eq "alice")`,
+			wantErr: "grouping is not supported in filters",
+		},
+		{
+			name:    "unterminated-string",
+			filter:  `userName eq "alice`,
+			wantErr: "unterminated string in filter",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseFilter(ctx, tt.filter)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetOpts(t *testing.T) {
+	t.Parallel()
+	t.Run("WithLimit", func(t *testing.T) {
+		opts := getOpts(WithLimit(5))
+		testOpts := getDefaultOptions()
+		testOpts.withLimit = 5
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithOffset", func(t *testing.T) {
+		opts := getOpts(WithOffset(10))
+		testOpts := getDefaultOptions()
+		testOpts.withOffset = 10
+		assert.Equal(t, opts, testOpts)
+	})
+}
@@ -0,0 +1,349 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// The operations of a PATCH request.
+const (
+	opAdd     = "add"
+	opReplace = "replace"
+	opRemove  = "remove"
+)
+
+// ApplyUserPatch applies the operations of a PATCH request to u. Operations
+// on attributes Boundary does not store, e.g. addresses or the enterprise
+// extension, are ignored so identity providers which push every attribute
+// they know of can still provision users.
+func ApplyUserPatch(ctx context.Context, u *User, ops []PatchOperation) error {
+	const op = "scim.ApplyUserPatch"
+	for _, o := range ops {
+		pop, err := patchOp(ctx, o)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if o.Path == "" {
+			var attrs map[string]json.RawMessage
+			if err := json.Unmarshal(o.Value, &attrs); err != nil {
+				return errors.New(ctx, errors.InvalidParameter, op, "value of an operation without path must be an object", errors.WithWrap(err))
+			}
+			for name, v := range attrs {
+				if err := setUserAttribute(ctx, u, pop, name, v); err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+			}
+			continue
+		}
+		if err := setUserAttribute(ctx, u, pop, o.Path, o.Value); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+	}
+	return nil
+}
+
+func setUserAttribute(ctx context.Context, u *User, pop, path string, v json.RawMessage) error {
+	const op = "scim.setUserAttribute"
+	path = strings.TrimPrefix(path, UserSchema+":")
+	attr, valueFilter, subAttr := splitPath(path)
+	remove := pop == opRemove
+	var err error
+	switch strings.ToLower(attr) {
+	case "active":
+		if remove {
+			u.Active = nil
+			return nil
+		}
+		var active bool
+		if active, err = boolValue(v); err == nil {
+			u.Active = &active
+		}
+	case "username":
+		err = stringValue(v, remove, &u.UserName)
+	case "externalid":
+		err = stringValue(v, remove, &u.ExternalId)
+	case "displayname":
+		err = stringValue(v, remove, &u.DisplayName)
+	case "password":
+		err = stringValue(v, remove, &u.Password)
+	case "name":
+		if u.Name == nil {
+			u.Name = &Name{}
+		}
+		switch strings.ToLower(subAttr) {
+		case "":
+			if remove {
+				u.Name = nil
+				return nil
+			}
+			var n Name
+			if err = json.Unmarshal(v, &n); err == nil {
+				u.Name = &n
+			}
+		case "givenname":
+			err = stringValue(v, remove, &u.Name.GivenName)
+		case "familyname":
+			err = stringValue(v, remove, &u.Name.FamilyName)
+		case "formatted":
+			err = stringValue(v, remove, &u.Name.Formatted)
+		}
+	case "emails":
+		err = setEmails(ctx, u, pop, valueFilter, subAttr, v)
+	}
+	if err != nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "invalid value for "+path, errors.WithWrap(err))
+	}
+	return nil
+}
+
+// setEmails applies an operation on the emails of u. The only supported
+// value filter is on the type of the email, e.g. emails[type eq "work"].value,
+// which is how Azure AD addresses the work email of a user.
+func setEmails(ctx context.Context, u *User, pop, valueFilter, subAttr string, v json.RawMessage) error {
+	const op = "scim.setEmails"
+	if valueFilter == "" {
+		switch pop {
+		case opRemove:
+			u.Emails = nil
+			return nil
+		case opAdd:
+			var emails []Email
+			if err := json.Unmarshal(v, &emails); err != nil {
+				return err
+			}
+			u.Emails = append(u.Emails, emails...)
+			return nil
+		default:
+			return json.Unmarshal(v, &u.Emails)
+		}
+	}
+
+	emailType, ok := parseValueFilter(ctx, valueFilter, "type")
+	if !ok {
+		return errors.New(ctx, errors.InvalidParameter, op, "unsupported email filter "+valueFilter)
+	}
+	idx := -1
+	for i, e := range u.Emails {
+		if strings.EqualFold(e.Type, emailType) {
+			idx = i
+			break
+		}
+	}
+	if pop == opRemove {
+		if idx >= 0 {
+			u.Emails = append(u.Emails[:idx], u.Emails[idx+1:]...)
+		}
+		return nil
+	}
+	if idx < 0 {
+		u.Emails = append(u.Emails, Email{Type: emailType})
+		idx = len(u.Emails) - 1
+	}
+	switch strings.ToLower(subAttr) {
+	case "value":
+		return stringValue(v, false, &u.Emails[idx].Value)
+	case "primary":
+		primary, err := boolValue(v)
+		if err != nil {
+			return err
+		}
+		u.Emails[idx].Primary = primary
+		return nil
+	default:
+		e := Email{Type: emailType}
+		if err := json.Unmarshal(v, &e); err != nil {
+			return err
+		}
+		u.Emails[idx] = e
+		return nil
+	}
+}
+
+// parseValueFilter parses a value filter of the form attr eq "value" and
+// returns the value. It reports false if s is not an equality comparison of
+// attr.
+func parseValueFilter(ctx context.Context, s, attr string) (string, bool) {
+	tokens, err := tokenizeFilter(ctx, s)
+	if err != nil || len(tokens) != 3 || !strings.EqualFold(tokens[0], attr) || !strings.EqualFold(tokens[1], "eq") {
+		return "", false
+	}
+	var value string
+	if err := json.Unmarshal([]byte(tokens[2]), &value); err != nil {
+		return "", false
+	}
+	return value, true
+}
+
+// ApplyGroupPatch applies the operations of a PATCH request to g.
+// Operations on attributes Boundary does not store are ignored.
+func ApplyGroupPatch(ctx context.Context, g *Group, ops []PatchOperation) error {
+	const op = "scim.ApplyGroupPatch"
+	for _, o := range ops {
+		pop, err := patchOp(ctx, o)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if o.Path == "" {
+			var attrs map[string]json.RawMessage
+			if err := json.Unmarshal(o.Value, &attrs); err != nil {
+				return errors.New(ctx, errors.InvalidParameter, op, "value of an operation without path must be an object", errors.WithWrap(err))
+			}
+			for name, v := range attrs {
+				if err := setGroupAttribute(ctx, g, pop, name, v); err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+			}
+			continue
+		}
+		if err := setGroupAttribute(ctx, g, pop, o.Path, o.Value); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+	}
+	return nil
+}
+
+func setGroupAttribute(ctx context.Context, g *Group, pop, path string, v json.RawMessage) error {
+	const op = "scim.setGroupAttribute"
+	path = strings.TrimPrefix(path, GroupSchema+":")
+	attr, valueFilter, _ := splitPath(path)
+	remove := pop == opRemove
+	var err error
+	switch strings.ToLower(attr) {
+	case "displayname":
+		err = stringValue(v, remove, &g.DisplayName)
+	case "externalid":
+		err = stringValue(v, remove, &g.ExternalId)
+	case "members":
+		err = setMembers(ctx, g, pop, valueFilter, v)
+	}
+	if err != nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "invalid value for "+path, errors.WithWrap(err))
+	}
+	return nil
+}
+
+// setMembers applies an operation on the members of g. Members are removed
+// either with a value filter, e.g. members[value eq "u_1234567890"], or by
+// listing them in the value of the operation.
+func setMembers(ctx context.Context, g *Group, pop, valueFilter string, v json.RawMessage) error {
+	const op = "scim.setMembers"
+	var members []Member
+	if len(v) > 0 && string(v) != "null" {
+		if err := json.Unmarshal(v, &members); err != nil {
+			// Some identity providers send a single member as an object.
+			var m Member
+			if err := json.Unmarshal(v, &m); err != nil {
+				return err
+			}
+			members = []Member{m}
+		}
+	}
+	if valueFilter != "" {
+		id, ok := parseValueFilter(ctx, valueFilter, "value")
+		if !ok {
+			return errors.New(ctx, errors.InvalidParameter, op, "unsupported members filter "+valueFilter)
+		}
+		if pop != opRemove {
+			return errors.New(ctx, errors.InvalidParameter, op, "members filter is only supported when removing members")
+		}
+		members = []Member{{Value: id}}
+	}
+
+	switch pop {
+	case opReplace:
+		g.Members = members
+	case opAdd:
+		for _, m := range members {
+			if !hasMember(g.Members, m.Value) {
+				g.Members = append(g.Members, m)
+			}
+		}
+	case opRemove:
+		if valueFilter == "" && len(members) == 0 {
+			g.Members = nil
+			return nil
+		}
+		kept := g.Members[:0]
+		for _, m := range g.Members {
+			if !hasMember(members, m.Value) {
+				kept = append(kept, m)
+			}
+		}
+		g.Members = kept
+	}
+	return nil
+}
+
+func hasMember(members []Member, id string) bool {
+	for _, m := range members {
+		if m.Value == id {
+			return true
+		}
+	}
+	return false
+}
+
+// patchOp returns the normalized operation of o. Operation names are case
+// insensitive; Azure AD sends them capitalized.
+func patchOp(ctx context.Context, o PatchOperation) (string, error) {
+	const op = "scim.patchOp"
+	pop := strings.ToLower(o.Op)
+	switch pop {
+	case opAdd, opReplace:
+		if o.Path == "" && len(o.Value) == 0 {
+			return "", errors.New(ctx, errors.InvalidParameter, op, "missing value")
+		}
+	case opRemove:
+		if o.Path == "" {
+			return "", errors.New(ctx, errors.InvalidParameter, op, "missing path of remove operation")
+		}
+	default:
+		return "", errors.New(ctx, errors.InvalidParameter, op, "unsupported operation "+o.Op)
+	}
+	return pop, nil
+}
+
+// splitPath splits an attribute path like emails[type eq "work"].value into
+// its attribute, value filter and sub-attribute.
+func splitPath(path string) (attr, valueFilter, subAttr string) {
+	attr = path
+	if i := strings.Index(path, "["); i >= 0 {
+		if j := strings.LastIndex(path, "]"); j > i {
+			attr, valueFilter = path[:i], path[i+1:j]
+			subAttr = strings.TrimPrefix(path[j+1:], ".")
+			return attr, valueFilter, subAttr
+		}
+	}
+	if i := strings.Index(path, "."); i >= 0 {
+		attr, subAttr = path[:i], path[i+1:]
+	}
+	return attr, valueFilter, subAttr
+}
+
+// stringValue sets s to the string v, or to the empty string if remove is
+// true.
+func stringValue(v json.RawMessage, remove bool, s *string) error {
+	if remove || len(v) == 0 || string(v) == "null" {
+		*s = ""
+		return nil
+	}
+	return json.Unmarshal(v, s)
+}
+
+// boolValue returns the boolean v. Azure AD sends booleans as the strings
+// "True" and "False".
+func boolValue(v json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(v, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(v, &s); err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(strings.ToLower(s))
+}
@@ -0,0 +1,225 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyUserPatch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	active := true
+	newUser := func() *User {
+		return &User{
+			UserName:    "alice",
+			DisplayName: "Alice",
+			Active:      &active,
+			Name:        &Name{GivenName: "Alice", FamilyName: "Smith"},
+			Emails:      []Email{{Value: "alice@example.com", Type: "work", Primary: true}},
+		}
+	}
+	tests := []struct {
+		name    string
+		ops     string
+		want    func(*User)
+		wantErr string
+	}{
+		{
+			name: "deactivate-azure",
+			ops:  `[{"op":"Replace","path":"active","value":"False"}]`,
+			want: func(u *User) {
+				inactive := false
+				u.Active = &inactive
+			},
+		},
+		{
+			name: "replace-without-path",
+			ops:  `[{"op":"replace","value":{"active":false,"userName":"alice2","name.givenName":"Al"}}]`,
+			want: func(u *User) {
+				inactive := false
+				u.Active = &inactive
+				u.UserName = "alice2"
+				u.Name.GivenName = "Al"
+			},
+		},
+		{
+			name: "sub-attribute",
+			ops:  `[{"op":"replace","path":"name.familyName","value":"Jones"}]`,
+			want: func(u *User) {
+				u.Name.FamilyName = "Jones"
+			},
+		},
+		{
+			name: "schema-prefixed-path",
+			ops:  `[{"op":"replace","path":"urn:ietf:params:scim:schemas:core:2.0:User:displayName","value":"Al"}]`,
+			want: func(u *User) {
+				u.DisplayName = "Al"
+			},
+		},
+		{
+			name: "email-value-filter",
+			ops:  `[{"op":"replace","path":"emails[type eq \"work\"].value","value":"al@example.com"}]`,
+			want: func(u *User) {
+				u.Emails[0].Value = "al@example.com"
+			},
+		},
+		{
+			name: "email-value-filter-adds-missing-type",
+			ops:  `[{"op":"add","path":"emails[type eq \"home\"].value","value":"al@example.net"}]`,
+			want: func(u *User) {
+				u.Emails = append(u.Emails, Email{Value: "al@example.net", Type: "home"})
+			},
+		},
+		{
+			name: "remove-attribute",
+			ops:  `[{"op":"remove","path":"displayName"}]`,
+			want: func(u *User) {
+				u.DisplayName = ""
+			},
+		},
+		{
+			name: "unknown-attribute-ignored",
+			ops:  `[{"op":"add","path":"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department","value":"Eng"}]`,
+			want: func(u *User) {},
+		},
+		{
+			name:    "unsupported-operation",
+			ops:     `[{"op":"move","path":"displayName","value":"Al"}]`,
+			wantErr: "unsupported operation move",
+		},
+		{
+			name:    "remove-without-path",
+			ops:     `[{"op":"remove"}]`,
+			wantErr: "missing path of remove operation",
+		},
+		{
+			name:    "invalid-active",
+			ops:     `[{"op":"replace","path":"active","value":"maybe"}]`,
+			wantErr: "invalid value for active",
+		},
+		{
+			name:    "unsupported-email-filter",
+			ops:     `[{"op":"replace","path":"emails[value eq \"alice@example.com\"].type","value":"home"}]`,
+			wantErr: "unsupported email filter",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var ops []PatchOperation
+			require.NoError(t, json.Unmarshal([]byte(tt.ops), &ops))
+			u := newUser()
+			err := ApplyUserPatch(ctx, u, ops)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			want := newUser()
+			tt.want(want)
+			assert.Equal(t, want, u)
+		})
+	}
+}
+
+func TestApplyGroupPatch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	newGroup := func() *Group {
+		return &Group{
+			DisplayName: "eng",
+			Members:     []Member{{Value: "u_1"}, {Value: "u_2"}},
+		}
+	}
+	tests := []struct {
+		name    string
+		ops     string
+		want    func(*Group)
+		wantErr string
+	}{
+		{
+			name: "add-members",
+			ops:  `[{"op":"add","path":"members","value":[{"value":"u_2"},{"value":"u_3"}]}]`,
+			want: func(g *Group) {
+				g.Members = append(g.Members, Member{Value: "u_3"})
+			},
+		},
+		{
+			name: "add-single-member-object",
+			ops:  `[{"op":"add","path":"members","value":{"value":"u_3"}}]`,
+			want: func(g *Group) {
+				g.Members = append(g.Members, Member{Value: "u_3"})
+			},
+		},
+		{
+			name: "replace-members",
+			ops:  `[{"op":"replace","path":"members","value":[{"value":"u_3"}]}]`,
+			want: func(g *Group) {
+				g.Members = []Member{{Value: "u_3"}}
+			},
+		},
+		{
+			name: "remove-member-by-filter",
+			ops:  `[{"op":"remove","path":"members[value eq \"u_1\"]"}]`,
+			want: func(g *Group) {
+				g.Members = []Member{{Value: "u_2"}}
+			},
+		},
+		{
+			name: "remove-member-by-value",
+			ops:  `[{"op":"remove","path":"members","value":[{"value":"u_2"}]}]`,
+			want: func(g *Group) {
+				g.Members = []Member{{Value: "u_1"}}
+			},
+		},
+		{
+			name: "remove-all-members",
+			ops:  `[{"op":"remove","path":"members"}]`,
+			want: func(g *Group) {
+				g.Members = nil
+			},
+		},
+		{
+			name: "replace-display-name-without-path",
+			ops:  `[{"op":"replace","value":{"id":"g_1","displayName":"platform"}}]`,
+			want: func(g *Group) {
+				g.DisplayName = "platform"
+			},
+		},
+		{
+			name:    "members-filter-on-add",
+			ops:     `[{"op":"add","path":"members[value eq \"u_1\"]","value":[{"value":"u_1"}]}]`,
+			wantErr: "members filter is only supported when removing members",
+		},
+		{
+			name:    "invalid-members",
+			ops:     `[{"op":"add","path":"members","value":"u_1"}]`,
+			wantErr: "invalid value for members",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var ops []PatchOperation
+			require.NoError(t, json.Unmarshal([]byte(tt.ops), &ops))
+			g := newGroup()
+			err := ApplyGroupPatch(ctx, g, ops)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			want := newGroup()
+			tt.want(want)
+			assert.Equal(t, want, g)
+		})
+	}
+}
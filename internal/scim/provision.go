@@ -0,0 +1,516 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/observability/event"
+)
+
+// A Provisioner creates, updates and deletes the iam.Users, accounts and
+// iam.Groups represented by SCIM users and groups. Users are replaced and
+// deleted in a single transaction. Creating a user creates its iam.User
+// first and its account and SCIM attributes in a single transaction; the
+// iam.User is deleted again if that transaction fails. Groups are not
+// changed in a shared transaction; if a step fails, the resources created
+// by the previous steps are deleted again.
+type Provisioner struct {
+	repoFn     func() (*Repository, error)
+	iamRepoFn  func() (*iam.Repository, error)
+	oidcRepoFn func() (*oidc.Repository, error)
+	pwRepoFn   func() (*password.Repository, error)
+	revokeFn   RevokeUserFn
+}
+
+// RevokeUserFn revokes the auth tokens of a user and cancels its sessions.
+// It is called after a user is deactivated or deleted.
+type RevokeUserFn func(ctx context.Context, userId string) error
+
+// NewProvisioner creates a new Provisioner.
+func NewProvisioner(ctx context.Context,
+	repoFn func() (*Repository, error),
+	iamRepoFn func() (*iam.Repository, error),
+	oidcRepoFn func() (*oidc.Repository, error),
+	pwRepoFn func() (*password.Repository, error),
+	revokeFn RevokeUserFn,
+) (*Provisioner, error) {
+	const op = "scim.NewProvisioner"
+	switch {
+	case repoFn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scim repository")
+	case iamRepoFn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing iam repository")
+	case oidcRepoFn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing oidc repository")
+	case pwRepoFn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing password repository")
+	case revokeFn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing revoke function")
+	}
+	return &Provisioner{
+		repoFn:     repoFn,
+		iamRepoFn:  iamRepoFn,
+		oidcRepoFn: oidcRepoFn,
+		pwRepoFn:   pwRepoFn,
+		revokeFn:   revokeFn,
+	}, nil
+}
+
+// doTx calls fn with the reader and writer of a new transaction. fn may be
+// called more than once if the transaction is retried.
+func (p *Provisioner) doTx(ctx context.Context, fn db.TxHandler) error {
+	const op = "scim.(Provisioner).doTx"
+	repo, err := p.repoFn()
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if _, err := repo.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{}, fn); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// Authenticate returns the auth method binding of a SCIM token. Returns
+// nil, nil if the token is not bound to an auth method.
+func (p *Provisioner) Authenticate(ctx context.Context, token string) (*Token, error) {
+	const op = "scim.(Provisioner).Authenticate"
+	repo, err := p.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	t, err := repo.LookupToken(ctx, token)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return t, nil
+}
+
+// ListUsers returns the users of the auth method matching f along with the
+// total number of matching users.
+func (p *Provisioner) ListUsers(ctx context.Context, t *Token, f Filter, opt ...Option) ([]*User, int, error) {
+	const op = "scim.(Provisioner).ListUsers"
+	repo, err := p.repoFn()
+	if err != nil {
+		return nil, 0, errors.Wrap(ctx, err, op)
+	}
+	recs, total, err := repo.ListUsers(ctx, t.AuthMethodId, f, opt...)
+	if err != nil {
+		return nil, 0, errors.Wrap(ctx, err, op)
+	}
+	users := make([]*User, 0, len(recs))
+	for _, rec := range recs {
+		users = append(users, toUser(rec))
+	}
+	return users, total, nil
+}
+
+// GetUser returns the user of the auth method.
+func (p *Provisioner) GetUser(ctx context.Context, t *Token, id string) (*User, error) {
+	const op = "scim.(Provisioner).GetUser"
+	rec, err := p.lookupUser(ctx, t, id)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return toUser(rec), nil
+}
+
+// CreateUser provisions the iam.User of u and, if u is active, its account.
+func (p *Provisioner) CreateUser(ctx context.Context, t *Token, u *User) (*User, error) {
+	const op = "scim.(Provisioner).CreateUser"
+	if strings.TrimSpace(u.UserName) == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing userName")
+	}
+	iamRepo, err := p.iamRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	user, err := iam.NewUser(t.ScopeId, iam.WithName(u.UserName), iam.WithDescription(u.DisplayName))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	user, err = iamRepo.CreateUser(ctx, user)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	var rec *ProvisionedUser
+	err = p.doTx(ctx, func(r db.Reader, w db.Writer) error {
+		rec = newProvisionedUser(t, user.GetPublicId(), u)
+		if rec.Active {
+			var err error
+			if rec.AccountId, err = p.createAccount(ctx, w, t, u); err != nil {
+				return err
+			}
+			if _, err := iamRepo.AddUserAccountsTx(ctx, r, w, rec.UserId, user.GetVersion(), []string{rec.AccountId}); err != nil {
+				return err
+			}
+		}
+		repo, err := NewRepository(r, w)
+		if err != nil {
+			return err
+		}
+		rec, err = repo.CreateUser(ctx, rec)
+		return err
+	})
+	if err != nil {
+		if _, err := iamRepo.DeleteUser(ctx, user.GetPublicId()); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("unable to delete failed user creation", "user id", user.GetPublicId()))
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return toUser(rec), nil
+}
+
+// ReplaceUser replaces the attributes of the user of the auth method with
+// the ones of u. Deactivating the user deletes its account and revokes its
+// auth tokens, activating it creates a new account. The account is
+// recreated as well if the attribute it is identified by changes. The
+// changes are made in a single transaction and the auth tokens are revoked
+// once it is committed.
+func (p *Provisioner) ReplaceUser(ctx context.Context, t *Token, id string, u *User) (*User, error) {
+	const op = "scim.(Provisioner).ReplaceUser"
+	if strings.TrimSpace(u.UserName) == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing userName")
+	}
+	var rec *ProvisionedUser
+	var revoke bool
+	err := p.doTx(ctx, func(r db.Reader, w db.Writer) error {
+		var err error
+		rec, revoke, err = p.replaceUser(ctx, r, w, t, id, u)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if revoke {
+		if err := p.revokeFn(ctx, id); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	return toUser(rec), nil
+}
+
+// replaceUser makes the changes of ReplaceUser with the reader and writer
+// of a transaction and reports whether the account of the user was
+// deleted.
+func (p *Provisioner) replaceUser(ctx context.Context, r db.Reader, w db.Writer, t *Token, id string, u *User) (*ProvisionedUser, bool, error) {
+	const op = "scim.(Provisioner).replaceUser"
+	repo, err := NewRepository(r, w)
+	if err != nil {
+		return nil, false, errors.Wrap(ctx, err, op)
+	}
+	rec, err := lookupUser(ctx, repo, t, id)
+	if err != nil {
+		return nil, false, errors.Wrap(ctx, err, op)
+	}
+	iamRepo, err := p.iamRepoFn()
+	if err != nil {
+		return nil, false, errors.Wrap(ctx, err, op)
+	}
+
+	user, _, err := iamRepo.LookupUser(ctx, id)
+	if err != nil {
+		return nil, false, errors.Wrap(ctx, err, op)
+	}
+	if user == nil {
+		return nil, false, errors.New(ctx, errors.RecordNotFound, op, "user not found: "+id)
+	}
+	var fieldMask []string
+	if user.GetName() != u.UserName {
+		user.Name = u.UserName
+		fieldMask = append(fieldMask, "Name")
+	}
+	if user.GetDescription() != u.DisplayName {
+		user.Description = u.DisplayName
+		fieldMask = append(fieldMask, "Description")
+	}
+	if len(fieldMask) > 0 {
+		if user, _, _, err = iamRepo.UpdateUserTx(ctx, r, w, user, user.GetVersion(), fieldMask); err != nil {
+			return nil, false, errors.Wrap(ctx, err, op)
+		}
+	}
+
+	var deleted bool
+	updated := newProvisionedUser(t, id, u)
+	updated.AccountId = rec.AccountId
+	if rec.AccountId != "" && (!updated.Active || accountIdentity(t, rec.ExternalId, rec.UserName) != accountIdentity(t, u.ExternalId, u.UserName)) {
+		if err := p.deleteAccount(ctx, w, t, rec.AccountId); err != nil {
+			return nil, false, errors.Wrap(ctx, err, op)
+		}
+		updated.AccountId = ""
+		deleted = true
+	}
+	switch {
+	case updated.Active && updated.AccountId == "":
+		if updated.AccountId, err = p.createAccount(ctx, w, t, u); err != nil {
+			return nil, false, errors.Wrap(ctx, err, op)
+		}
+		if _, err := iamRepo.AddUserAccountsTx(ctx, r, w, id, user.GetVersion(), []string{updated.AccountId}); err != nil {
+			return nil, false, errors.Wrap(ctx, err, op)
+		}
+	case updated.Active && u.Password != "":
+		if err := p.setPassword(ctx, r, w, t, updated.AccountId, u.Password); err != nil {
+			return nil, false, errors.Wrap(ctx, err, op)
+		}
+	}
+
+	if rec, err = repo.UpdateUser(ctx, updated); err != nil {
+		return nil, false, errors.Wrap(ctx, err, op)
+	}
+	return rec, deleted, nil
+}
+
+// PatchUser applies the operations of a PATCH request to the user of the
+// auth method.
+func (p *Provisioner) PatchUser(ctx context.Context, t *Token, id string, ops []PatchOperation) (*User, error) {
+	const op = "scim.(Provisioner).PatchUser"
+	rec, err := p.lookupUser(ctx, t, id)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	u := toUser(rec)
+	if err := ApplyUserPatch(ctx, u, ops); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	u, err = p.ReplaceUser(ctx, t, id, u)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return u, nil
+}
+
+// DeleteUser deletes the user of the auth method and its account in a
+// single transaction and then revokes the user's auth tokens.
+func (p *Provisioner) DeleteUser(ctx context.Context, t *Token, id string) error {
+	const op = "scim.(Provisioner).DeleteUser"
+	iamRepo, err := p.iamRepoFn()
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	err = p.doTx(ctx, func(r db.Reader, w db.Writer) error {
+		repo, err := NewRepository(r, w)
+		if err != nil {
+			return err
+		}
+		rec, err := lookupUser(ctx, repo, t, id)
+		if err != nil {
+			return err
+		}
+		if rec.AccountId != "" {
+			if err := p.deleteAccount(ctx, w, t, rec.AccountId); err != nil {
+				return err
+			}
+		}
+		_, err = iamRepo.DeleteUserTx(ctx, r, w, id)
+		return err
+	})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err := p.revokeFn(ctx, id); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+func (p *Provisioner) lookupUser(ctx context.Context, t *Token, id string) (*ProvisionedUser, error) {
+	const op = "scim.(Provisioner).lookupUser"
+	repo, err := p.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	rec, err := lookupUser(ctx, repo, t, id)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return rec, nil
+}
+
+// lookupUser returns the user of the auth method or a RecordNotFound error
+// if there is none.
+func lookupUser(ctx context.Context, repo *Repository, t *Token, id string) (*ProvisionedUser, error) {
+	const op = "scim.lookupUser"
+	rec, err := repo.LookupUser(ctx, t.AuthMethodId, id)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if rec == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, "user not found: "+id)
+	}
+	return rec, nil
+}
+
+// createAccount creates the account of u in the auth method with the writer
+// of a transaction and returns its id.
+func (p *Provisioner) createAccount(ctx context.Context, w db.Writer, t *Token, u *User) (string, error) {
+	const op = "scim.(Provisioner).createAccount"
+	switch auth.SubtypeFromId(t.AuthMethodId) {
+	case oidc.Subtype:
+		repo, err := p.oidcRepoFn()
+		if err != nil {
+			return "", errors.Wrap(ctx, err, op)
+		}
+		fullName := u.DisplayName
+		if u.Name != nil && u.Name.Formatted != "" {
+			fullName = u.Name.Formatted
+		}
+		acct, err := oidc.NewAccount(ctx, t.AuthMethodId, accountIdentity(t, u.ExternalId, u.UserName),
+			oidc.WithEmail(u.PrimaryEmail()), oidc.WithFullName(fullName))
+		if err != nil {
+			return "", errors.Wrap(ctx, err, op)
+		}
+		if acct, err = repo.CreateAccountTx(ctx, w, t.ScopeId, acct); err != nil {
+			return "", errors.Wrap(ctx, err, op)
+		}
+		return acct.GetPublicId(), nil
+
+	case password.Subtype:
+		repo, err := p.pwRepoFn()
+		if err != nil {
+			return "", errors.Wrap(ctx, err, op)
+		}
+		acct, err := password.NewAccount(t.AuthMethodId, password.WithLoginName(accountIdentity(t, u.ExternalId, u.UserName)))
+		if err != nil {
+			return "", errors.Wrap(ctx, err, op)
+		}
+		var opts []password.Option
+		if u.Password != "" {
+			opts = append(opts, password.WithPassword(u.Password))
+		}
+		if acct, err = repo.CreateAccountTx(ctx, w, t.ScopeId, acct, opts...); err != nil {
+			return "", errors.Wrap(ctx, err, op)
+		}
+		return acct.GetPublicId(), nil
+
+	default:
+		return "", errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("auth method %s does not support provisioning accounts", t.AuthMethodId))
+	}
+}
+
+// deleteAccount deletes an account of the auth method with the writer of a
+// transaction.
+func (p *Provisioner) deleteAccount(ctx context.Context, w db.Writer, t *Token, accountId string) error {
+	const op = "scim.(Provisioner).deleteAccount"
+	var err error
+	switch auth.SubtypeFromId(t.AuthMethodId) {
+	case oidc.Subtype:
+		var repo *oidc.Repository
+		if repo, err = p.oidcRepoFn(); err == nil {
+			_, err = repo.DeleteAccountTx(ctx, w, t.ScopeId, accountId)
+		}
+	case password.Subtype:
+		var repo *password.Repository
+		if repo, err = p.pwRepoFn(); err == nil {
+			_, err = repo.DeleteAccountTx(ctx, w, t.ScopeId, accountId)
+		}
+	default:
+		err = errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("auth method %s does not support provisioning accounts", t.AuthMethodId))
+	}
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// setPassword sets the password of a password account with the reader and
+// writer of a transaction. Passwords are ignored for the accounts of other
+// auth methods.
+func (p *Provisioner) setPassword(ctx context.Context, r db.Reader, w db.Writer, t *Token, accountId, pw string) error {
+	const op = "scim.(Provisioner).setPassword"
+	if auth.SubtypeFromId(t.AuthMethodId) != password.Subtype {
+		return nil
+	}
+	repo, err := p.pwRepoFn()
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	acct, err := repo.LookupAccount(ctx, accountId)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if acct == nil {
+		return errors.New(ctx, errors.RecordNotFound, op, "account not found: "+accountId)
+	}
+	if _, err := repo.SetPasswordTx(ctx, r, w, t.ScopeId, accountId, pw, acct.GetVersion()); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// accountIdentity returns the attribute the account of a user is identified
+// by in the auth method: the subject of an OIDC account, which is the
+// externalId of the user or its userName if it has none, or the login name
+// of a password account. Login names only allow lower case alphanumeric
+// characters, periods and hyphens, all other characters of the userName are
+// replaced with periods, e.g. alice@example.com becomes alice.example.com.
+func accountIdentity(t *Token, externalId, userName string) string {
+	if auth.SubtypeFromId(t.AuthMethodId) == oidc.Subtype {
+		if externalId != "" {
+			return externalId
+		}
+		return userName
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		default:
+			return '.'
+		}
+	}, strings.ToLower(userName))
+}
+
+func newProvisionedUser(t *Token, userId string, u *User) *ProvisionedUser {
+	rec := &ProvisionedUser{
+		UserId:       userId,
+		AuthMethodId: t.AuthMethodId,
+		ScopeId:      t.ScopeId,
+		UserName:     u.UserName,
+		ExternalId:   u.ExternalId,
+		Active:       u.IsActive(),
+		DisplayName:  u.DisplayName,
+		Email:        u.PrimaryEmail(),
+	}
+	if u.Name != nil {
+		rec.GivenName = u.Name.GivenName
+		rec.FamilyName = u.Name.FamilyName
+	}
+	return rec
+}
+
+func toUser(rec *ProvisionedUser) *User {
+	active := rec.Active
+	u := &User{
+		Schemas:     []string{UserSchema},
+		Id:          rec.UserId,
+		ExternalId:  rec.ExternalId,
+		UserName:    rec.UserName,
+		DisplayName: rec.DisplayName,
+		Active:      &active,
+		Meta: &Meta{
+			ResourceType: "User",
+			Created:      &rec.CreateTime,
+			LastModified: &rec.UpdateTime,
+			Location:     BasePath + "Users/" + rec.UserId,
+		},
+	}
+	if rec.GivenName != "" || rec.FamilyName != "" {
+		u.Name = &Name{
+			Formatted:  strings.TrimSpace(rec.GivenName + " " + rec.FamilyName),
+			GivenName:  rec.GivenName,
+			FamilyName: rec.FamilyName,
+		}
+	}
+	if rec.Email != "" {
+		u.Emails = []Email{{Value: rec.Email, Type: "work", Primary: true}}
+	}
+	return u
+}
@@ -0,0 +1,263 @@
+package scim
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/observability/event"
+)
+
+// ListGroups returns the groups of the auth method matching f along with
+// the total number of matching groups.
+func (p *Provisioner) ListGroups(ctx context.Context, t *Token, f Filter, opt ...Option) ([]*Group, int, error) {
+	const op = "scim.(Provisioner).ListGroups"
+	repo, err := p.repoFn()
+	if err != nil {
+		return nil, 0, errors.Wrap(ctx, err, op)
+	}
+	recs, total, err := repo.ListGroups(ctx, t.AuthMethodId, f, opt...)
+	if err != nil {
+		return nil, 0, errors.Wrap(ctx, err, op)
+	}
+	groups := make([]*Group, 0, len(recs))
+	for _, rec := range recs {
+		g, err := p.toGroup(ctx, rec)
+		if err != nil {
+			return nil, 0, errors.Wrap(ctx, err, op)
+		}
+		groups = append(groups, g)
+	}
+	return groups, total, nil
+}
+
+// GetGroup returns the group of the auth method.
+func (p *Provisioner) GetGroup(ctx context.Context, t *Token, id string) (*Group, error) {
+	const op = "scim.(Provisioner).GetGroup"
+	rec, err := p.lookupGroup(ctx, t, id)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	g, err := p.toGroup(ctx, rec)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return g, nil
+}
+
+// CreateGroup provisions the iam.Group of g. The members of g must be users
+// provisioned through the same auth method.
+func (p *Provisioner) CreateGroup(ctx context.Context, t *Token, g *Group) (_ *Group, retErr error) {
+	const op = "scim.(Provisioner).CreateGroup"
+	if strings.TrimSpace(g.DisplayName) == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing displayName")
+	}
+	memberIds, err := p.memberIds(ctx, t, g.Members)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	repo, err := p.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	iamRepo, err := p.iamRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	group, err := iam.NewGroup(t.ScopeId, iam.WithName(g.DisplayName))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if group, err = iamRepo.CreateGroup(ctx, group); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer func() {
+		if retErr == nil {
+			return
+		}
+		if _, err := iamRepo.DeleteGroup(ctx, group.GetPublicId()); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("unable to delete failed group creation", "group id", group.GetPublicId()))
+		}
+	}()
+
+	if len(memberIds) > 0 {
+		if _, _, err := iamRepo.SetGroupMembers(ctx, group.GetPublicId(), group.GetVersion(), memberIds); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	rec, err := repo.CreateGroup(ctx, &ProvisionedGroup{
+		GroupId:      group.GetPublicId(),
+		AuthMethodId: t.AuthMethodId,
+		ScopeId:      t.ScopeId,
+		ExternalId:   g.ExternalId,
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	created, err := p.toGroup(ctx, rec)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return created, nil
+}
+
+// ReplaceGroup replaces the display name, external id and members of the
+// group of the auth method with the ones of g.
+func (p *Provisioner) ReplaceGroup(ctx context.Context, t *Token, id string, g *Group) (*Group, error) {
+	const op = "scim.(Provisioner).ReplaceGroup"
+	if strings.TrimSpace(g.DisplayName) == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing displayName")
+	}
+	if _, err := p.lookupGroup(ctx, t, id); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	memberIds, err := p.memberIds(ctx, t, g.Members)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	repo, err := p.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	iamRepo, err := p.iamRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	group, _, err := iamRepo.LookupGroup(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if group == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, "group not found: "+id)
+	}
+	if group.GetName() != g.DisplayName {
+		group.Name = g.DisplayName
+		if group, _, _, err = iamRepo.UpdateGroup(ctx, group, group.GetVersion(), []string{"Name"}); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	if _, _, err := iamRepo.SetGroupMembers(ctx, id, group.GetVersion(), memberIds); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	rec, err := repo.UpdateGroup(ctx, &ProvisionedGroup{
+		GroupId:      id,
+		AuthMethodId: t.AuthMethodId,
+		ExternalId:   g.ExternalId,
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	updated, err := p.toGroup(ctx, rec)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return updated, nil
+}
+
+// PatchGroup applies the operations of a PATCH request to the group of the
+// auth method.
+func (p *Provisioner) PatchGroup(ctx context.Context, t *Token, id string, ops []PatchOperation) (*Group, error) {
+	const op = "scim.(Provisioner).PatchGroup"
+	g, err := p.GetGroup(ctx, t, id)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if err := ApplyGroupPatch(ctx, g, ops); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	g, err = p.ReplaceGroup(ctx, t, id, g)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return g, nil
+}
+
+// DeleteGroup deletes the group of the auth method.
+func (p *Provisioner) DeleteGroup(ctx context.Context, t *Token, id string) error {
+	const op = "scim.(Provisioner).DeleteGroup"
+	if _, err := p.lookupGroup(ctx, t, id); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	iamRepo, err := p.iamRepoFn()
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if _, err := iamRepo.DeleteGroup(ctx, id); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+func (p *Provisioner) lookupGroup(ctx context.Context, t *Token, id string) (*ProvisionedGroup, error) {
+	const op = "scim.(Provisioner).lookupGroup"
+	repo, err := p.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	rec, err := repo.LookupGroup(ctx, t.AuthMethodId, id)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if rec == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, "group not found: "+id)
+	}
+	return rec, nil
+}
+
+// memberIds returns the user ids of members after verifying they are users
+// provisioned through the auth method.
+func (p *Provisioner) memberIds(ctx context.Context, t *Token, members []Member) ([]string, error) {
+	const op = "scim.(Provisioner).memberIds"
+	repo, err := p.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	ids := make([]string, 0, len(members))
+	seen := make(map[string]bool, len(members))
+	for _, m := range members {
+		if seen[m.Value] {
+			continue
+		}
+		seen[m.Value] = true
+		u, err := repo.LookupUser(ctx, t.AuthMethodId, m.Value)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if u == nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "member is not a user provisioned through this auth method: "+m.Value)
+		}
+		ids = append(ids, m.Value)
+	}
+	return ids, nil
+}
+
+func (p *Provisioner) toGroup(ctx context.Context, rec *ProvisionedGroup) (*Group, error) {
+	const op = "scim.(Provisioner).toGroup"
+	repo, err := p.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	members, err := repo.ListGroupMembers(ctx, rec.GroupId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	for i := range members {
+		members[i].Ref = BasePath + "Users/" + members[i].Value
+	}
+	return &Group{
+		Schemas:     []string{GroupSchema},
+		Id:          rec.GroupId,
+		ExternalId:  rec.ExternalId,
+		DisplayName: rec.DisplayName,
+		Members:     members,
+		Meta: &Meta{
+			ResourceType: "Group",
+			Created:      &rec.CreateTime,
+			LastModified: &rec.UpdateTime,
+			Location:     BasePath + "Groups/" + rec.GroupId,
+		},
+	}, nil
+}
@@ -0,0 +1,81 @@
+package scim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvisioner_ReplaceUser_Rollback(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	am := password.TestAuthMethod(t, conn, org.PublicId)
+	pwRepo, err := password.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	var revoked []string
+	revokeFn := func(_ context.Context, userId string) error {
+		revoked = append(revoked, userId)
+		return nil
+	}
+	repoFn := func() (*Repository, error) { return NewRepository(rw, rw) }
+	iamRepoFn := func() (*iam.Repository, error) { return iam.NewRepository(rw, rw, kmsCache) }
+	oidcRepoFn := func() (*oidc.Repository, error) { return oidc.NewRepository(ctx, rw, rw, kmsCache) }
+	pwRepoFn := func() (*password.Repository, error) { return password.NewRepository(rw, rw, kmsCache) }
+	p, err := NewProvisioner(ctx, repoFn, iamRepoFn, oidcRepoFn, pwRepoFn, revokeFn)
+	require.NoError(t, err)
+	tok := &Token{AuthMethodId: am.PublicId, ScopeId: org.PublicId}
+
+	_, err = p.CreateUser(ctx, tok, &User{UserName: "alice"})
+	require.NoError(t, err)
+	bob, err := p.CreateUser(ctx, tok, &User{UserName: "bob"})
+	require.NoError(t, err)
+
+	// Renaming bob recreates its account, whose login name is already used by
+	// alice's account. None of the changes made before that are kept.
+	_, err = p.ReplaceUser(ctx, tok, bob.Id, &User{UserName: "ALICE"})
+	require.Error(t, err)
+	assert.Empty(t, revoked)
+
+	got, err := p.GetUser(ctx, tok, bob.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "bob", got.UserName)
+	user, accountIds, err := iamRepo.LookupUser(ctx, bob.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "bob", user.GetName())
+	require.Len(t, accountIds, 1)
+	acct, err := pwRepo.LookupAccount(ctx, accountIds[0])
+	require.NoError(t, err)
+	require.NotNil(t, acct)
+	assert.Equal(t, "bob", acct.GetLoginName())
+
+	// Deactivating bob deletes its account and revokes its tokens once the
+	// transaction is committed.
+	active := false
+	got, err = p.ReplaceUser(ctx, tok, bob.Id, &User{UserName: "bob", Active: &active})
+	require.NoError(t, err)
+	assert.False(t, got.IsActive())
+	assert.Equal(t, []string{bob.Id}, revoked)
+	_, accountIds, err = iamRepo.LookupUser(ctx, bob.Id)
+	require.NoError(t, err)
+	assert.Empty(t, accountIds)
+
+	require.NoError(t, p.DeleteUser(ctx, tok, bob.Id))
+	assert.Equal(t, []string{bob.Id, bob.Id}, revoked)
+	user, _, err = iamRepo.LookupUser(ctx, bob.Id)
+	require.NoError(t, err)
+	assert.Nil(t, user)
+}
@@ -0,0 +1,130 @@
+package scim
+
+const (
+	// upsertTokenQuery sets the token hash of an auth method, replacing its
+	// previous token. It inserts no row if the auth method does not exist.
+	upsertTokenQuery = `
+insert into auth_scim_token
+  (auth_method_id, scope_id, token_hash)
+select public_id, scope_id, ?
+  from auth_method
+ where public_id = ?
+on conflict (auth_method_id) do update
+   set token_hash  = excluded.token_hash,
+       create_time = now();
+`
+
+	deleteTokenQuery = `
+delete from auth_scim_token
+ where auth_method_id = ?;
+`
+
+	lookupTokenQuery = `
+select auth_method_id,
+       scope_id,
+       create_time
+  from auth_scim_token
+ where token_hash = ?;
+`
+
+	insertUserQuery = `
+insert into auth_scim_user
+  (user_id, auth_method_id, scope_id, account_id, user_name, external_id,
+   active, display_name, given_name, family_name, email)
+values
+  (?, ?, ?, nullif(?, ''), ?, nullif(?, ''),
+   ?, nullif(?, ''), nullif(?, ''), nullif(?, ''), nullif(?, ''));
+`
+
+	updateUserQuery = `
+update auth_scim_user
+   set account_id   = nullif(?, ''),
+       user_name    = ?,
+       external_id  = nullif(?, ''),
+       active       = ?,
+       display_name = nullif(?, ''),
+       given_name   = nullif(?, ''),
+       family_name  = nullif(?, ''),
+       email        = nullif(?, '')
+ where user_id        = ?
+   and auth_method_id = ?;
+`
+
+	// selectUsersQuery is completed with the conditions of the filter, if
+	// any, followed by the order, limit and offset clauses.
+	selectUsersQuery = `
+select u.user_id,
+       u.auth_method_id,
+       u.scope_id,
+       coalesce(u.account_id, '')   as account_id,
+       u.user_name,
+       coalesce(u.external_id, '')  as external_id,
+       u.active,
+       coalesce(u.display_name, '') as display_name,
+       coalesce(u.given_name, '')   as given_name,
+       coalesce(u.family_name, '')  as family_name,
+       coalesce(u.email, '')        as email,
+       u.create_time,
+       u.update_time
+  from auth_scim_user u
+ where u.auth_method_id = ?
+`
+
+	countUsersQuery = `
+select count(*)
+  from auth_scim_user u
+ where u.auth_method_id = ?
+`
+
+	insertGroupQuery = `
+insert into auth_scim_group
+  (group_id, auth_method_id, scope_id, external_id)
+values
+  (?, ?, ?, nullif(?, ''));
+`
+
+	updateGroupQuery = `
+update auth_scim_group
+   set external_id = nullif(?, '')
+ where group_id       = ?
+   and auth_method_id = ?;
+`
+
+	// selectGroupsQuery is completed with the conditions of the filter, if
+	// any, followed by the order, limit and offset clauses. The display name
+	// of a group is the name of its iam_group.
+	selectGroupsQuery = `
+select g.group_id,
+       g.auth_method_id,
+       g.scope_id,
+       coalesce(g.external_id, '') as external_id,
+       coalesce(ig.name, '')       as display_name,
+       g.create_time,
+       greatest(g.update_time, ig.update_time) as update_time
+  from auth_scim_group g
+  join iam_group ig
+    on ig.public_id = g.group_id
+ where g.auth_method_id = ?
+`
+
+	countGroupsQuery = `
+select count(*)
+  from auth_scim_group g
+  join iam_group ig
+    on ig.public_id = g.group_id
+ where g.auth_method_id = ?
+`
+
+	// selectGroupMembersQuery returns the members of a group along with
+	// their SCIM user names. Members added in Boundary directly have no
+	// user name.
+	selectGroupMembersQuery = `
+   select m.member_id                as value,
+          coalesce(u.user_name, '') as display
+     from iam_group_member_user m
+left join auth_scim_user u
+       on u.user_id = m.member_id
+    where m.group_id = ?
+ order by m.member_id;
+`
+)
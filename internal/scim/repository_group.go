@@ -0,0 +1,167 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// ProvisionedGroup is an iam.Group provisioned through the SCIM endpoint of
+// an auth method. DisplayName is the name of the iam.Group and is read
+// only.
+type ProvisionedGroup struct {
+	GroupId      string
+	AuthMethodId string
+	ScopeId      string
+	ExternalId   string
+	DisplayName  string
+	CreateTime   time.Time
+	UpdateTime   time.Time
+}
+
+// groupFilterColumns maps the attributes a group can be filtered on to their
+// conditions.
+var groupFilterColumns = map[string]string{
+	"id":          "g.group_id = ?",
+	"externalId":  "g.external_id = ?",
+	"displayName": "ig.name = ?",
+}
+
+// CreateGroup records that g.GroupId was provisioned through the SCIM
+// endpoint of g.AuthMethodId and returns the recorded group.
+func (r *Repository) CreateGroup(ctx context.Context, g *ProvisionedGroup) (*ProvisionedGroup, error) {
+	const op = "scim.(Repository).CreateGroup"
+	switch {
+	case g == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing group")
+	case g.GroupId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing group id")
+	case g.AuthMethodId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+	case g.ScopeId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	if _, err := r.writer.Exec(ctx, insertGroupQuery, []interface{}{g.GroupId, g.AuthMethodId, g.ScopeId, g.ExternalId}); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to create group %s", g.GroupId)))
+	}
+	created, err := r.LookupGroup(ctx, g.AuthMethodId, g.GroupId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return created, nil
+}
+
+// UpdateGroup replaces the external id of g.GroupId and returns the updated
+// group.
+func (r *Repository) UpdateGroup(ctx context.Context, g *ProvisionedGroup) (*ProvisionedGroup, error) {
+	const op = "scim.(Repository).UpdateGroup"
+	switch {
+	case g == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing group")
+	case g.GroupId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing group id")
+	case g.AuthMethodId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+	}
+	rows, err := r.writer.Exec(ctx, updateGroupQuery, []interface{}{g.ExternalId, g.GroupId, g.AuthMethodId})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to update group %s", g.GroupId)))
+	}
+	if rows == 0 {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, "group not found: "+g.GroupId)
+	}
+	updated, err := r.LookupGroup(ctx, g.AuthMethodId, g.GroupId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return updated, nil
+}
+
+// LookupGroup returns the group provisioned through the SCIM endpoint of the
+// auth method. Returns nil, nil if no such group is found.
+func (r *Repository) LookupGroup(ctx context.Context, authMethodId, groupId string) (*ProvisionedGroup, error) {
+	const op = "scim.(Repository).LookupGroup"
+	if authMethodId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+	}
+	if groupId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing group id")
+	}
+	groups, _, err := r.ListGroups(ctx, authMethodId, Filter{{Attribute: "id", Value: groupId}})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	return groups[0], nil
+}
+
+// ListGroups returns the groups provisioned through the SCIM endpoint of
+// the auth method which match f, ordered by creation, along with the total
+// number of matching groups. WithLimit and WithOffset are used to page
+// through the groups.
+func (r *Repository) ListGroups(ctx context.Context, authMethodId string, f Filter, opt ...Option) ([]*ProvisionedGroup, int, error) {
+	const op = "scim.(Repository).ListGroups"
+	if authMethodId == "" {
+		return nil, 0, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+	}
+	where, args, err := filterConditions(ctx, f, groupFilterColumns)
+	if err != nil {
+		return nil, 0, errors.Wrap(ctx, err, op)
+	}
+	args = append([]interface{}{authMethodId}, args...)
+
+	total, err := r.count(ctx, countGroupsQuery+where, args)
+	if err != nil {
+		return nil, 0, errors.Wrap(ctx, err, op)
+	}
+
+	limit, offset := r.page(opt...)
+	query := selectGroupsQuery + where + " order by g.create_time, g.group_id limit ? offset ?;"
+	rows, err := r.reader.Query(ctx, query, append(args, limit, offset))
+	if err != nil {
+		return nil, 0, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var groups []*ProvisionedGroup
+	for rows.Next() {
+		var g ProvisionedGroup
+		if err := r.reader.ScanRows(rows, &g); err != nil {
+			return nil, 0, errors.Wrap(ctx, err, op)
+		}
+		groups = append(groups, &g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, errors.Wrap(ctx, err, op)
+	}
+	return groups, total, nil
+}
+
+// ListGroupMembers returns the members of the group with the SCIM user
+// names of the members as their display values.
+func (r *Repository) ListGroupMembers(ctx context.Context, groupId string) ([]Member, error) {
+	const op = "scim.(Repository).ListGroupMembers"
+	if groupId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing group id")
+	}
+	rows, err := r.reader.Query(ctx, selectGroupMembersQuery, []interface{}{groupId})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var members []Member
+	for rows.Next() {
+		var m Member
+		if err := r.reader.ScanRows(rows, &m); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		members = append(members, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return members, nil
+}
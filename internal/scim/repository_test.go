@@ -0,0 +1,201 @@
+package scim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Token(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	am := password.TestAuthMethod(t, conn, org.PublicId)
+	repo, err := NewRepository(rw, rw)
+	require.NoError(t, err)
+
+	t.Run("missing-auth-method", func(t *testing.T) {
+		_, err := repo.RotateToken(ctx, "ampw_1234567890")
+		assert.True(t, errors.IsNotFoundError(err))
+	})
+	t.Run("rotate-lookup-delete", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		first, err := repo.RotateToken(ctx, am.PublicId)
+		require.NoError(err)
+		assert.Len(first, tokenLength)
+
+		got, err := repo.LookupToken(ctx, first)
+		require.NoError(err)
+		require.NotNil(got)
+		assert.Equal(am.PublicId, got.AuthMethodId)
+		assert.Equal(org.PublicId, got.ScopeId)
+
+		second, err := repo.RotateToken(ctx, am.PublicId)
+		require.NoError(err)
+		assert.NotEqual(first, second)
+		got, err = repo.LookupToken(ctx, first)
+		require.NoError(err)
+		assert.Nil(got)
+		got, err = repo.LookupToken(ctx, second)
+		require.NoError(err)
+		assert.NotNil(got)
+
+		n, err := repo.DeleteToken(ctx, am.PublicId)
+		require.NoError(err)
+		assert.Equal(1, n)
+		got, err = repo.LookupToken(ctx, second)
+		require.NoError(err)
+		assert.Nil(got)
+		n, err = repo.DeleteToken(ctx, am.PublicId)
+		require.NoError(err)
+		assert.Equal(0, n)
+	})
+}
+
+func TestRepository_Users(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	am := password.TestAuthMethod(t, conn, org.PublicId)
+	repo, err := NewRepository(rw, rw)
+	require.NoError(t, err)
+
+	alice := iam.TestUser(t, iamRepo, org.PublicId)
+	bob := iam.TestUser(t, iamRepo, org.PublicId)
+
+	assert, require := assert.New(t), require.New(t)
+	created, err := repo.CreateUser(ctx, &ProvisionedUser{
+		UserId:       alice.PublicId,
+		AuthMethodId: am.PublicId,
+		ScopeId:      org.PublicId,
+		UserName:     "Alice",
+		ExternalId:   "00u1",
+		Active:       true,
+		Email:        "alice@example.com",
+	})
+	require.NoError(err)
+	assert.Equal("Alice", created.UserName)
+	assert.Empty(created.AccountId)
+	assert.True(created.Active)
+
+	_, err = repo.CreateUser(ctx, &ProvisionedUser{
+		UserId:       bob.PublicId,
+		AuthMethodId: am.PublicId,
+		ScopeId:      org.PublicId,
+		UserName:     "alice",
+	})
+	assert.True(errors.IsUniqueError(err), "user names are unique case insensitively")
+
+	_, err = repo.CreateUser(ctx, &ProvisionedUser{
+		UserId:       bob.PublicId,
+		AuthMethodId: am.PublicId,
+		ScopeId:      org.PublicId,
+		UserName:     "bob",
+	})
+	require.NoError(err)
+
+	users, total, err := repo.ListUsers(ctx, am.PublicId, Filter{{Attribute: "userName", Value: "ALICE"}})
+	require.NoError(err)
+	assert.Equal(1, total)
+	require.Len(users, 1)
+	assert.Equal(alice.PublicId, users[0].UserId)
+
+	users, total, err = repo.ListUsers(ctx, am.PublicId, nil, WithLimit(1), WithOffset(1))
+	require.NoError(err)
+	assert.Equal(2, total)
+	require.Len(users, 1)
+	assert.Equal(bob.PublicId, users[0].UserId)
+
+	created.Active = false
+	created.DisplayName = "Alice Smith"
+	updated, err := repo.UpdateUser(ctx, created)
+	require.NoError(err)
+	assert.False(updated.Active)
+	assert.Equal("Alice Smith", updated.DisplayName)
+
+	users, total, err = repo.ListUsers(ctx, am.PublicId, Filter{{Attribute: "active", Value: "false"}})
+	require.NoError(err)
+	assert.Equal(1, total)
+	require.Len(users, 1)
+	assert.Equal(alice.PublicId, users[0].UserId)
+
+	_, _, err = repo.ListUsers(ctx, am.PublicId, Filter{{Attribute: "members", Value: "x"}})
+	assert.Error(err)
+
+	got, err := repo.LookupUser(ctx, am.PublicId, "u_1234567890")
+	require.NoError(err)
+	assert.Nil(got)
+
+	_, err = iamRepo.DeleteUser(ctx, alice.PublicId)
+	require.NoError(err)
+	got, err = repo.LookupUser(ctx, am.PublicId, alice.PublicId)
+	require.NoError(err)
+	assert.Nil(got, "deleting the iam user deletes the scim user")
+}
+
+func TestRepository_Groups(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	am := password.TestAuthMethod(t, conn, org.PublicId)
+	repo, err := NewRepository(rw, rw)
+	require.NoError(t, err)
+
+	assert, require := assert.New(t), require.New(t)
+	user := iam.TestUser(t, iamRepo, org.PublicId)
+	_, err = repo.CreateUser(ctx, &ProvisionedUser{
+		UserId:       user.PublicId,
+		AuthMethodId: am.PublicId,
+		ScopeId:      org.PublicId,
+		UserName:     "alice",
+	})
+	require.NoError(err)
+
+	group := iam.TestGroup(t, conn, org.PublicId, iam.WithName("eng"))
+	_, _, err = iamRepo.SetGroupMembers(ctx, group.PublicId, group.Version, []string{user.PublicId})
+	require.NoError(err)
+
+	created, err := repo.CreateGroup(ctx, &ProvisionedGroup{
+		GroupId:      group.PublicId,
+		AuthMethodId: am.PublicId,
+		ScopeId:      org.PublicId,
+		ExternalId:   "00g1",
+	})
+	require.NoError(err)
+	assert.Equal("eng", created.DisplayName)
+	assert.Equal("00g1", created.ExternalId)
+
+	groups, total, err := repo.ListGroups(ctx, am.PublicId, Filter{{Attribute: "displayName", Value: "eng"}})
+	require.NoError(err)
+	assert.Equal(1, total)
+	require.Len(groups, 1)
+
+	created.ExternalId = "00g2"
+	updated, err := repo.UpdateGroup(ctx, created)
+	require.NoError(err)
+	assert.Equal("00g2", updated.ExternalId)
+
+	members, err := repo.ListGroupMembers(ctx, group.PublicId)
+	require.NoError(err)
+	assert.Equal([]Member{{Value: user.PublicId, Display: "alice"}}, members)
+
+	_, err = repo.UpdateGroup(ctx, &ProvisionedGroup{GroupId: "g_1234567890", AuthMethodId: am.PublicId})
+	assert.True(errors.IsNotFoundError(err))
+}
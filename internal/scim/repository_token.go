@@ -0,0 +1,86 @@
+package scim
+
+import (
+	"context"
+	"crypto/sha256"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/go-secure-stdlib/base62"
+)
+
+// tokenLength is the length of a generated SCIM token.
+const tokenLength = 40
+
+// Token is the binding of a SCIM token to an auth method. The token itself is
+// not stored.
+type Token struct {
+	AuthMethodId string
+	ScopeId      string
+	CreateTime   time.Time
+}
+
+// RotateToken generates a new SCIM token for the auth method, which
+// replaces its previous token, and returns it. The token is only returned
+// by RotateToken, only its hash is stored.
+func (r *Repository) RotateToken(ctx context.Context, authMethodId string) (string, error) {
+	const op = "scim.(Repository).RotateToken"
+	if authMethodId == "" {
+		return "", errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+	}
+	token, err := base62.Random(tokenLength)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithMsg("unable to generate token"))
+	}
+	h := sha256.Sum256([]byte(token))
+	rows, err := r.writer.Exec(ctx, upsertTokenQuery, []interface{}{h[:], authMethodId})
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	if rows == 0 {
+		return "", errors.New(ctx, errors.RecordNotFound, op, "auth method not found: "+authMethodId)
+	}
+	return token, nil
+}
+
+// DeleteToken deletes the SCIM token of the auth method, which disables its
+// SCIM endpoint. The users and groups provisioned through the endpoint are
+// kept.
+func (r *Repository) DeleteToken(ctx context.Context, authMethodId string) (int, error) {
+	const op = "scim.(Repository).DeleteToken"
+	if authMethodId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+	}
+	rows, err := r.writer.Exec(ctx, deleteTokenQuery, []interface{}{authMethodId})
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	return rows, nil
+}
+
+// LookupToken returns the binding of token to its auth method. Returns nil,
+// nil if no auth method has the token.
+func (r *Repository) LookupToken(ctx context.Context, token string) (*Token, error) {
+	const op = "scim.(Repository).LookupToken"
+	if token == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing token")
+	}
+	h := sha256.Sum256([]byte(token))
+	rows, err := r.reader.Query(ctx, lookupTokenQuery, []interface{}{h[:]})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var t *Token
+	for rows.Next() {
+		t = &Token{}
+		if err := r.reader.ScanRows(rows, t); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return t, nil
+}
@@ -0,0 +1,196 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// ProvisionedUser is an iam.User provisioned through the SCIM endpoint of an
+// auth method together with its SCIM attributes. AccountId is empty while
+// the user is deactivated.
+type ProvisionedUser struct {
+	UserId       string
+	AuthMethodId string
+	ScopeId      string
+	AccountId    string
+	UserName     string
+	ExternalId   string
+	Active       bool
+	DisplayName  string
+	GivenName    string
+	FamilyName   string
+	Email        string
+	CreateTime   time.Time
+	UpdateTime   time.Time
+}
+
+// userFilterColumns maps the attributes a user can be filtered on to their
+// conditions. User names are compared case insensitively.
+var userFilterColumns = map[string]string{
+	"id":          "u.user_id = ?",
+	"externalId":  "u.external_id = ?",
+	"userName":    "lower(u.user_name) = lower(?)",
+	"displayName": "u.display_name = ?",
+	"active":      "u.active = ?::boolean",
+}
+
+// CreateUser records that u.UserId was provisioned through the SCIM
+// endpoint of u.AuthMethodId and returns the recorded user.
+func (r *Repository) CreateUser(ctx context.Context, u *ProvisionedUser) (*ProvisionedUser, error) {
+	const op = "scim.(Repository).CreateUser"
+	switch {
+	case u == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user")
+	case u.UserId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	case u.AuthMethodId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+	case u.ScopeId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	case strings.TrimSpace(u.UserName) == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user name")
+	}
+	if _, err := r.writer.Exec(ctx, insertUserQuery, []interface{}{
+		u.UserId, u.AuthMethodId, u.ScopeId, u.AccountId, u.UserName, u.ExternalId,
+		u.Active, u.DisplayName, u.GivenName, u.FamilyName, u.Email,
+	}); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to create user %s", u.UserName)))
+	}
+	created, err := r.LookupUser(ctx, u.AuthMethodId, u.UserId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return created, nil
+}
+
+// UpdateUser replaces the SCIM attributes and the account of u.UserId and
+// returns the updated user.
+func (r *Repository) UpdateUser(ctx context.Context, u *ProvisionedUser) (*ProvisionedUser, error) {
+	const op = "scim.(Repository).UpdateUser"
+	switch {
+	case u == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user")
+	case u.UserId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	case u.AuthMethodId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+	case strings.TrimSpace(u.UserName) == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user name")
+	}
+	rows, err := r.writer.Exec(ctx, updateUserQuery, []interface{}{
+		u.AccountId, u.UserName, u.ExternalId, u.Active,
+		u.DisplayName, u.GivenName, u.FamilyName, u.Email,
+		u.UserId, u.AuthMethodId,
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to update user %s", u.UserId)))
+	}
+	if rows == 0 {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, "user not found: "+u.UserId)
+	}
+	updated, err := r.LookupUser(ctx, u.AuthMethodId, u.UserId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return updated, nil
+}
+
+// LookupUser returns the user provisioned through the SCIM endpoint of the
+// auth method. Returns nil, nil if no such user is found.
+func (r *Repository) LookupUser(ctx context.Context, authMethodId, userId string) (*ProvisionedUser, error) {
+	const op = "scim.(Repository).LookupUser"
+	if authMethodId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+	}
+	if userId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	}
+	users, _, err := r.ListUsers(ctx, authMethodId, Filter{{Attribute: "id", Value: userId}})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if len(users) == 0 {
+		return nil, nil
+	}
+	return users[0], nil
+}
+
+// ListUsers returns the users provisioned through the SCIM endpoint of the
+// auth method which match f, ordered by creation, along with the total
+// number of matching users. WithLimit and WithOffset are used to page
+// through the users.
+func (r *Repository) ListUsers(ctx context.Context, authMethodId string, f Filter, opt ...Option) ([]*ProvisionedUser, int, error) {
+	const op = "scim.(Repository).ListUsers"
+	if authMethodId == "" {
+		return nil, 0, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+	}
+	where, args, err := filterConditions(ctx, f, userFilterColumns)
+	if err != nil {
+		return nil, 0, errors.Wrap(ctx, err, op)
+	}
+	args = append([]interface{}{authMethodId}, args...)
+
+	total, err := r.count(ctx, countUsersQuery+where, args)
+	if err != nil {
+		return nil, 0, errors.Wrap(ctx, err, op)
+	}
+
+	limit, offset := r.page(opt...)
+	query := selectUsersQuery + where + " order by u.create_time, u.user_id limit ? offset ?;"
+	rows, err := r.reader.Query(ctx, query, append(args, limit, offset))
+	if err != nil {
+		return nil, 0, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var users []*ProvisionedUser
+	for rows.Next() {
+		var u ProvisionedUser
+		if err := r.reader.ScanRows(rows, &u); err != nil {
+			return nil, 0, errors.Wrap(ctx, err, op)
+		}
+		users = append(users, &u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, errors.Wrap(ctx, err, op)
+	}
+	return users, total, nil
+}
+
+// page returns the limit and offset of a list. A negative limit is
+// unlimited, which postgres expresses as a null limit.
+func (r *Repository) page(opt ...Option) (interface{}, int) {
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		limit = opts.withLimit
+	}
+	offset := opts.withOffset
+	if offset < 0 {
+		offset = 0
+	}
+	if limit < 0 {
+		return nil, offset
+	}
+	return limit, offset
+}
+
+// filterConditions returns the where conditions, prefixed by "and", and
+// their arguments for f using the conditions of the attributes in columns.
+func filterConditions(ctx context.Context, f Filter, columns map[string]string) (string, []interface{}, error) {
+	const op = "scim.filterConditions"
+	var where strings.Builder
+	var args []interface{}
+	for _, c := range f {
+		cond, ok := columns[c.Attribute]
+		if !ok {
+			return "", nil, errors.New(ctx, errors.InvalidParameter, op, "unsupported filter attribute "+c.Attribute)
+		}
+		where.WriteString(" and " + cond)
+		args = append(args, c.Value)
+	}
+	return where.String(), args, nil
+}
@@ -0,0 +1,337 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/observability/event"
+)
+
+// BasePath is the path the SCIM endpoints are served under.
+const BasePath = "/scim/v2/"
+
+// NewHandler returns the http.Handler serving the SCIM endpoints under
+// BasePath. Requests are authenticated with the SCIM token of an auth
+// method sent as a bearer token and operate on the users and groups of that
+// auth method.
+func NewHandler(ctx context.Context, p *Provisioner) (http.Handler, error) {
+	const op = "scim.NewHandler"
+	if p == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing provisioner")
+	}
+	return &server{p: p}, nil
+}
+
+type server struct {
+	p *Provisioner
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	t, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, BasePath), "/")
+	resource, id := path, ""
+	if i := strings.Index(path, "/"); i >= 0 {
+		resource, id = path[:i], path[i+1:]
+	}
+	switch {
+	case resource == "ServiceProviderConfig" && id == "" && r.Method == http.MethodGet:
+		writeResponse(w, http.StatusOK, ServiceProviderConfig())
+	case resource == "Users":
+		s.serveUsers(ctx, w, r, t, id)
+	case resource == "Groups":
+		s.serveGroups(ctx, w, r, t, id)
+	default:
+		writeError(ctx, w, errors.New(ctx, errors.RecordNotFound, "scim.(server).ServeHTTP", "unknown endpoint "+r.URL.Path))
+	}
+}
+
+// authenticate returns the auth method binding of the bearer token of r. It
+// writes an error response and reports false if the request is not
+// authenticated.
+func (s *server) authenticate(w http.ResponseWriter, r *http.Request) (*Token, bool) {
+	const op = "scim.(server).authenticate"
+	ctx := r.Context()
+	token := r.Header.Get("Authorization")
+	if len(token) > 7 && strings.EqualFold(token[:7], "bearer ") {
+		token = strings.TrimSpace(token[7:])
+	} else {
+		token = ""
+	}
+	if token != "" {
+		t, err := s.p.Authenticate(ctx, token)
+		if err != nil {
+			writeError(ctx, w, errors.Wrap(ctx, err, op))
+			return nil, false
+		}
+		if t != nil {
+			return t, true
+		}
+	}
+	w.Header().Set("WWW-Authenticate", `Bearer realm="scim"`)
+	writeError(ctx, w, errors.New(ctx, errors.Unauthorized, op, "missing or invalid SCIM token"))
+	return nil, false
+}
+
+func (s *server) serveUsers(ctx context.Context, w http.ResponseWriter, r *http.Request, t *Token, id string) {
+	var result interface{}
+	var err error
+	status := http.StatusOK
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		result, err = s.listUsers(ctx, r, t)
+	case id == "" && r.Method == http.MethodPost:
+		var u User
+		if err = decodeBody(ctx, r, &u); err == nil {
+			result, err = s.p.CreateUser(ctx, t, &u)
+			status = http.StatusCreated
+		}
+	case id != "" && r.Method == http.MethodGet:
+		result, err = s.p.GetUser(ctx, t, id)
+	case id != "" && r.Method == http.MethodPut:
+		var u User
+		if err = decodeBody(ctx, r, &u); err == nil {
+			result, err = s.p.ReplaceUser(ctx, t, id, &u)
+		}
+	case id != "" && r.Method == http.MethodPatch:
+		var req PatchRequest
+		if err = decodeBody(ctx, r, &req); err == nil {
+			result, err = s.p.PatchUser(ctx, t, id, req.Operations)
+		}
+	case id != "" && r.Method == http.MethodDelete:
+		err = s.p.DeleteUser(ctx, t, id)
+		status = http.StatusNoContent
+	default:
+		writeMethodNotAllowed(ctx, w, r)
+		return
+	}
+	if err != nil {
+		writeError(ctx, w, err)
+		return
+	}
+	writeResponse(w, status, result)
+}
+
+func (s *server) serveGroups(ctx context.Context, w http.ResponseWriter, r *http.Request, t *Token, id string) {
+	var result interface{}
+	var err error
+	status := http.StatusOK
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		result, err = s.listGroups(ctx, r, t)
+	case id == "" && r.Method == http.MethodPost:
+		var g Group
+		if err = decodeBody(ctx, r, &g); err == nil {
+			result, err = s.p.CreateGroup(ctx, t, &g)
+			status = http.StatusCreated
+		}
+	case id != "" && r.Method == http.MethodGet:
+		result, err = s.p.GetGroup(ctx, t, id)
+	case id != "" && r.Method == http.MethodPut:
+		var g Group
+		if err = decodeBody(ctx, r, &g); err == nil {
+			result, err = s.p.ReplaceGroup(ctx, t, id, &g)
+		}
+	case id != "" && r.Method == http.MethodPatch:
+		var req PatchRequest
+		if err = decodeBody(ctx, r, &req); err == nil {
+			result, err = s.p.PatchGroup(ctx, t, id, req.Operations)
+		}
+	case id != "" && r.Method == http.MethodDelete:
+		err = s.p.DeleteGroup(ctx, t, id)
+		status = http.StatusNoContent
+	default:
+		writeMethodNotAllowed(ctx, w, r)
+		return
+	}
+	if err != nil {
+		writeError(ctx, w, err)
+		return
+	}
+	writeResponse(w, status, result)
+}
+
+func (s *server) listUsers(ctx context.Context, r *http.Request, t *Token) (*ListResponse, error) {
+	const op = "scim.(server).listUsers"
+	f, startIndex, count, err := listParams(ctx, r)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	users, total, err := s.p.ListUsers(ctx, t, f, WithOffset(startIndex-1), WithLimit(pageLimit(count)))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	resp := newListResponse(total, startIndex)
+	for _, u := range users[:min(count, len(users))] {
+		resp.Resources = append(resp.Resources, u)
+	}
+	resp.ItemsPerPage = len(resp.Resources)
+	return resp, nil
+}
+
+func (s *server) listGroups(ctx context.Context, r *http.Request, t *Token) (*ListResponse, error) {
+	const op = "scim.(server).listGroups"
+	f, startIndex, count, err := listParams(ctx, r)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	groups, total, err := s.p.ListGroups(ctx, t, f, WithOffset(startIndex-1), WithLimit(pageLimit(count)))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	resp := newListResponse(total, startIndex)
+	for _, g := range groups[:min(count, len(groups))] {
+		resp.Resources = append(resp.Resources, g)
+	}
+	resp.ItemsPerPage = len(resp.Resources)
+	return resp, nil
+}
+
+func newListResponse(total, startIndex int) *ListResponse {
+	return &ListResponse{
+		Schemas:      []string{ListResponseSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		Resources:    []interface{}{},
+	}
+}
+
+// listParams returns the filter, the 1-based start index and the page size
+// of a query.
+func listParams(ctx context.Context, r *http.Request) (Filter, int, int, error) {
+	const op = "scim.listParams"
+	q := r.URL.Query()
+	f, err := ParseFilter(ctx, q.Get("filter"))
+	if err != nil {
+		return nil, 0, 0, &filterError{err: errors.Wrap(ctx, err, op)}
+	}
+	startIndex, count := 1, maxResults
+	if v := q.Get("startIndex"); v != "" {
+		if startIndex, err = strconv.Atoi(v); err != nil {
+			return nil, 0, 0, errors.New(ctx, errors.InvalidParameter, op, "invalid startIndex")
+		}
+		// A start index less than 1 is interpreted as 1.
+		if startIndex < 1 {
+			startIndex = 1
+		}
+	}
+	if v := q.Get("count"); v != "" {
+		if count, err = strconv.Atoi(v); err != nil {
+			return nil, 0, 0, errors.New(ctx, errors.InvalidParameter, op, "invalid count")
+		}
+		switch {
+		case count < 0:
+			count = 0
+		case count > maxResults:
+			count = maxResults
+		}
+	}
+	return f, startIndex, count, nil
+}
+
+// pageLimit returns the limit to list a page of count results with. A count
+// of zero only returns the total number of results, the repository is still
+// queried for a single result since a limit of zero means the default limit.
+func pageLimit(count int) int {
+	if count == 0 {
+		return 1
+	}
+	return count
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// decodeBody decodes the JSON body of r into v.
+func decodeBody(ctx context.Context, r *http.Request, v interface{}) error {
+	const op = "scim.decodeBody"
+	maxSize := globals.DefaultMaxRequestSize
+	if size, ok := ctx.Value(globals.ContextMaxRequestSizeTypeKey).(int64); ok && size > 0 {
+		maxSize = size
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxSize+1))
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to read request body"))
+	}
+	if int64(len(body)) > maxSize {
+		return errors.New(ctx, errors.InvalidParameter, op, "request body too large")
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "invalid request body", errors.WithWrap(err))
+	}
+	return nil
+}
+
+// filterError is an error caused by the filter of a query.
+type filterError struct {
+	err error
+}
+
+func (e *filterError) Error() string { return e.err.Error() }
+func (e *filterError) Unwrap() error { return e.err }
+
+func writeResponse(w http.ResponseWriter, status int, v interface{}) {
+	if status == http.StatusNoContent {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeMethodNotAllowed(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, http.StatusMethodNotAllowed, &ErrorResponse{
+		Schemas: []string{ErrorSchema},
+		Status:  strconv.Itoa(http.StatusMethodNotAllowed),
+		Detail:  "method " + r.Method + " is not allowed on " + r.URL.Path,
+	})
+}
+
+// writeError writes the SCIM error response for err. Errors which are not
+// caused by the request are logged and reported without details.
+func writeError(ctx context.Context, w http.ResponseWriter, err error) {
+	const op = "scim.writeError"
+	resp := &ErrorResponse{
+		Schemas: []string{ErrorSchema},
+		Detail:  err.Error(),
+	}
+	status := http.StatusBadRequest
+	switch {
+	case errors.Match(errors.T(errors.Unauthorized), err):
+		status = http.StatusUnauthorized
+	case errors.IsNotFoundError(err):
+		status = http.StatusNotFound
+	case errors.IsUniqueError(err):
+		status = http.StatusConflict
+		resp.ScimType = "uniqueness"
+	case errors.As(err, new(*filterError)):
+		resp.ScimType = "invalidFilter"
+	case errors.Match(errors.T(errors.InvalidParameter), err),
+		errors.Match(errors.T(errors.TooShort), err),
+		errors.Match(errors.T(errors.PasswordTooShort), err),
+		errors.IsCheckConstraintError(err),
+		errors.IsNotNullError(err):
+		resp.ScimType = "invalidValue"
+	default:
+		status = http.StatusInternalServerError
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error handling scim request"))
+		resp.Detail = "internal error"
+	}
+	resp.Status = strconv.Itoa(status)
+	writeResponse(w, status, resp)
+}
@@ -0,0 +1,148 @@
+package scim
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	am := password.TestAuthMethod(t, conn, org.PublicId)
+
+	repoFn := func() (*Repository, error) { return NewRepository(rw, rw) }
+	iamRepoFn := func() (*iam.Repository, error) { return iam.NewRepository(rw, rw, kmsCache) }
+	oidcRepoFn := func() (*oidc.Repository, error) { return oidc.NewRepository(ctx, rw, rw, kmsCache) }
+	pwRepoFn := func() (*password.Repository, error) { return password.NewRepository(rw, rw, kmsCache) }
+	var revokedMu sync.Mutex
+	var revoked []string
+	revokeFn := func(_ context.Context, userId string) error {
+		revokedMu.Lock()
+		defer revokedMu.Unlock()
+		revoked = append(revoked, userId)
+		return nil
+	}
+	p, err := NewProvisioner(ctx, repoFn, iamRepoFn, oidcRepoFn, pwRepoFn, revokeFn)
+	require.NoError(t, err)
+	h, err := NewHandler(ctx, p)
+	require.NoError(t, err)
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	repo, err := repoFn()
+	require.NoError(t, err)
+	token, err := repo.RotateToken(ctx, am.PublicId)
+	require.NoError(t, err)
+
+	do := func(t *testing.T, method, path, token, body string, want interface{}) int {
+		t.Helper()
+		req, err := http.NewRequest(method, srv.URL+BasePath+path, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		req.Header.Set("Content-Type", ContentType)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if want != nil {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(want))
+		}
+		return resp.StatusCode
+	}
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, do(t, http.MethodGet, "Users", "", "", nil))
+		assert.Equal(t, http.StatusUnauthorized, do(t, http.MethodGet, "Users", "invalid", "", nil))
+	})
+
+	t.Run("service-provider-config", func(t *testing.T) {
+		var cfg map[string]interface{}
+		assert.Equal(t, http.StatusOK, do(t, http.MethodGet, "ServiceProviderConfig", token, "", &cfg))
+		assert.Equal(t, []interface{}{ServiceProviderConfigSchema}, cfg["schemas"])
+	})
+
+	t.Run("user-lifecycle", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		var u User
+		status := do(t, http.MethodPost, "Users", token,
+			`{"schemas":["`+UserSchema+`"],"userName":"alice","password":"passw0rd-alice","emails":[{"value":"alice@example.com","primary":true}]}`, &u)
+		require.Equal(http.StatusCreated, status)
+		require.NotEmpty(u.Id)
+		assert.True(u.IsActive())
+		assert.Equal("alice@example.com", u.PrimaryEmail())
+
+		var conflict ErrorResponse
+		assert.Equal(http.StatusConflict, do(t, http.MethodPost, "Users", token, `{"userName":"ALICE"}`, &conflict))
+		assert.Equal("uniqueness", conflict.ScimType)
+
+		var list ListResponse
+		require.Equal(http.StatusOK, do(t, http.MethodGet, `Users?filter=userName+eq+"alice"`, token, "", &list))
+		assert.Equal(1, list.TotalResults)
+
+		var badFilter ErrorResponse
+		assert.Equal(http.StatusBadRequest, do(t, http.MethodGet, `Users?filter=userName+sw+"a"`, token, "", &badFilter))
+		assert.Equal("invalidFilter", badFilter.ScimType)
+
+		pwRepo, err := pwRepoFn()
+		require.NoError(err)
+		accts, err := pwRepo.ListAccounts(ctx, am.PublicId)
+		require.NoError(err)
+		require.Len(accts, 1)
+		assert.Equal("alice", accts[0].GetLoginName())
+
+		var patched User
+		require.Equal(http.StatusOK, do(t, http.MethodPatch, "Users/"+u.Id, token,
+			`{"schemas":["`+PatchOpSchema+`"],"Operations":[{"op":"Replace","path":"active","value":"False"}]}`, &patched))
+		assert.False(patched.IsActive())
+		accts, err = pwRepo.ListAccounts(ctx, am.PublicId)
+		require.NoError(err)
+		assert.Empty(accts, "deactivating a user deletes its account")
+		revokedMu.Lock()
+		assert.Contains(revoked, u.Id)
+		revokedMu.Unlock()
+
+		var g Group
+		require.Equal(http.StatusCreated, do(t, http.MethodPost, "Groups", token,
+			`{"schemas":["`+GroupSchema+`"],"displayName":"eng","members":[{"value":"`+u.Id+`"}]}`, &g))
+		require.Len(g.Members, 1)
+		assert.Equal(u.Id, g.Members[0].Value)
+		assert.Equal(BasePath+"Users/"+u.Id, g.Members[0].Ref)
+
+		assert.Equal(http.StatusBadRequest, do(t, http.MethodPost, "Groups", token,
+			`{"displayName":"ops","members":[{"value":"u_1234567890"}]}`, nil))
+
+		require.Equal(http.StatusOK, do(t, http.MethodPatch, "Groups/"+g.Id, token,
+			`{"Operations":[{"op":"remove","path":"members[value eq \"`+u.Id+`\"]"}]}`, &g))
+		assert.Empty(g.Members)
+
+		assert.Equal(http.StatusNoContent, do(t, http.MethodDelete, "Groups/"+g.Id, token, "", nil))
+		assert.Equal(http.StatusNoContent, do(t, http.MethodDelete, "Users/"+u.Id, token, "", nil))
+		assert.Equal(http.StatusNotFound, do(t, http.MethodGet, "Users/"+u.Id, token, "", nil))
+		users, err := iamRepo.ListUsers(ctx, []string{org.PublicId})
+		require.NoError(err)
+		for _, user := range users {
+			assert.False(strings.EqualFold(user.GetName(), "alice"))
+		}
+	})
+}
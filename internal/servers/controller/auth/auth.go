@@ -10,10 +10,12 @@ import (
 
 	"github.com/hashicorp/boundary/api/recovery"
 	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/errors"
 	authpb "github.com/hashicorp/boundary/internal/gen/controller/auth"
 	"github.com/hashicorp/boundary/internal/gen/controller/tokens"
 
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/perms"
@@ -447,6 +449,9 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 	_ = retErr
 	scopeInfo = new(scopes.ScopeInfo)
 	userId = AnonymousUserId
+	// Set when the request uses a derived auth token, whose own grants restrict
+	// the grants of its user
+	var tokenGrants []*authtoken.Grant
 
 	// Validate the token and fetch the corresponding user ID
 	switch v.requestInfo.TokenFormat {
@@ -475,13 +480,39 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 			event.WriteError(ctx, op, err, event.WithInfoMsg("error validating token; continuing as anonymous user"))
 			break
 		}
+		if at != nil && at.GetDisabled() {
+			// The tokens of a disabled user or account are revoked when it is
+			// disabled; this covers tokens issued while the change was racing.
+			event.WriteError(ctx, op, stderrors.New("perform auth check: valid token belongs to a disabled user or account; continuing as u_anon"), event.WithInfo("token_id", at.GetPublicId()))
+			break
+		}
 		if at != nil {
 			accountId = at.GetAuthAccountId()
 			userId = at.GetIamUserId()
+			if at.GetServiceAccountId() != "" {
+				// Service accounts take the place of the user, so their grants
+				// are looked up the same way.
+				userId = at.GetServiceAccountId()
+			}
 			if userId == "" {
 				event.WriteError(ctx, op, stderrors.New("perform auth check: valid token did not map to a user, likely because no account is associated with the user any longer; continuing as u_anon"), event.WithInfo("token_id", at.GetPublicId()))
 				userId = AnonymousUserId
 				accountId = ""
+				break
+			}
+			if at.GetParentId() != "" {
+				tokenGrants, err = tokenRepo.ListAuthTokenGrants(v.ctx, at.GetPublicId())
+				if err != nil {
+					retErr = errors.Wrap(ctx, err, op, errors.WithMsg("failed to list auth token grants"))
+					return
+				}
+				if len(tokenGrants) == 0 {
+					// A derived token without grants must not fall back to
+					// the grants of its user
+					event.WriteError(ctx, op, stderrors.New("perform auth check: derived token has no grants; continuing as u_anon"), event.WithInfo("token_id", at.GetPublicId()))
+					userId = AnonymousUserId
+					accountId = ""
+				}
 			}
 		}
 	}
@@ -492,13 +523,25 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 		return
 	}
 
-	u, _, err := iamRepo.LookupUser(ctx, userId)
-	if err != nil {
-		retErr = errors.Wrap(ctx, err, op, errors.WithMsg("failed to lookup user"))
-		return
+	switch {
+	case strings.HasPrefix(userId, iam.ServiceAccountPrefix+"_"):
+		sa, _, err := iamRepo.LookupServiceAccount(ctx, userId)
+		if err != nil {
+			retErr = errors.Wrap(ctx, err, op, errors.WithMsg("failed to lookup service account"))
+			return
+		}
+		if sa != nil {
+			userName = sa.GetName()
+		}
+	default:
+		u, _, err := iamRepo.LookupUser(ctx, userId)
+		if err != nil {
+			retErr = errors.Wrap(ctx, err, op, errors.WithMsg("failed to lookup user"))
+			return
+		}
+		userEmail = u.Email
+		userName = u.FullName
 	}
-	userEmail = u.Email
-	userName = u.FullName
 
 	// Look up scope details to return. We can skip a lookup when using the
 	// global scope
@@ -567,6 +610,23 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 	}
 
 	retAcl = perms.NewACL(parsedGrants...)
+	if userId != AnonymousUserId && len(tokenGrants) > 0 {
+		restrictGrants := make([]perms.Grant, 0, len(tokenGrants))
+		for _, g := range tokenGrants {
+			parsed, err := perms.Parse(
+				g.GetScopeId(),
+				g.GetCanonicalGrant(),
+				perms.WithUserId(userId),
+				perms.WithAccountId(accountId),
+				perms.WithSkipFinalValidation(true))
+			if err != nil {
+				retErr = errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed to parse auth token grant %#v", g.GetCanonicalGrant())))
+				return
+			}
+			restrictGrants = append(restrictGrants, parsed)
+		}
+		retAcl = retAcl.Restrict(restrictGrants...)
+	}
 	aclResults = retAcl.Allowed(*v.res, v.act)
 	// We don't set authenticated above because setting this but not authorized
 	// is used for further permissions checks, such as during recursive listing.
@@ -627,6 +687,29 @@ func (r *VerifyResults) fetchActions(id string, typ resource.Type, availableActi
 	return ret
 }
 
+// ListPermissions returns the resources of type typ on which the current set
+// of ACLs allows act, per scope. If unrestricted is true every resource is
+// allowed, e.g. because auth is disabled, and the returned permissions are
+// nil.
+func (r *VerifyResults) ListPermissions(typ resource.Type, act action.Type) (permissions []perms.Permission, unrestricted bool) {
+	switch {
+	case r.v.requestInfo.DisableAuthEntirely,
+		r.v.requestInfo.TokenFormat == uint32(AuthTokenTypeRecoveryKms):
+		return nil, true
+	}
+	return r.v.acl.ListPermissions(typ, act), false
+}
+
+// ClientCertificates returns the DER encoded certificate chain presented by
+// the client on a mutual TLS connection, leaf first, or nil if the client did
+// not present a certificate.
+func (r *VerifyResults) ClientCertificates() [][]byte {
+	if r.v == nil || r.v.requestInfo == nil {
+		return nil
+	}
+	return r.v.requestInfo.GetClientCertificates()
+}
+
 func (r *VerifyResults) FetchOutputFields(res perms.Resource, act action.Type) perms.OutputFieldsMap {
 	switch {
 	case r.v.requestInfo.TokenFormat == uint32(AuthTokenTypeRecoveryKms):
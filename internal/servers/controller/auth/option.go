@@ -30,6 +30,7 @@ type options struct {
 	withRecoveryTokenNotAllowed bool
 	withAnonymousUserNotAllowed bool
 	withResource                *perms.Resource
+	withClientCertificates      [][]byte
 }
 
 func getDefaultOptions() options {
@@ -96,3 +97,11 @@ func WithResource(resource *perms.Resource) Option {
 		o.withResource = resource
 	}
 }
+
+// WithClientCertificates specifies the DER encoded certificate chain presented
+// by the client, leaf first. It is used by DisabledAuthTestContext.
+func WithClientCertificates(chain [][]byte) Option {
+	return func(o *options) {
+		o.withClientCertificates = chain
+	}
+}
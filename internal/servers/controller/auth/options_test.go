@@ -28,6 +28,7 @@ func Test_GetOpts(t *testing.T) {
 		WithRecoveryTokenNotAllowed(true),
 		WithAnonymousUserNotAllowed(true),
 		WithResource(res),
+		WithClientCertificates([][]byte{[]byte("cert")}),
 	)
 	exp := options{
 		withScopeId:                 "foo",
@@ -40,6 +41,7 @@ func Test_GetOpts(t *testing.T) {
 		withRecoveryTokenNotAllowed: true,
 		withAnonymousUserNotAllowed: true,
 		withResource:                res,
+		withClientCertificates:      [][]byte{[]byte("cert")},
 	}
 	assert.Equal(t, exp, opts)
 }
@@ -9,8 +9,9 @@ import (
 )
 
 // DisabledAuthTestContext is meant for testing, and uses a context that has
-// auth checking entirely disabled. Supported options: WithScopeId an WithUserId
-// are used directly; WithKms is passed through into the verifier context.
+// auth checking entirely disabled. Supported options: WithScopeId, WithUserId
+// and WithClientCertificates are used directly; WithKms is passed through into
+// the verifier context.
 func DisabledAuthTestContext(iamRepoFn common.IamRepoFactory, scopeId string, opt ...Option) context.Context {
 	reqInfo := authpb.RequestInfo{DisableAuthEntirely: true}
 	opts := getOpts(opt...)
@@ -18,6 +19,7 @@ func DisabledAuthTestContext(iamRepoFn common.IamRepoFactory, scopeId string, op
 	if reqInfo.ScopeIdOverride == "" {
 		reqInfo.ScopeIdOverride = scopeId
 	}
+	reqInfo.ClientCertificates = opts.withClientCertificates
 	reqInfo.UserIdOverride = opts.withUserId
 	if reqInfo.UserIdOverride == "" {
 		reqInfo.UserIdOverride = "u_auth"
@@ -1,13 +1,21 @@
 package common
 
 import (
+	"github.com/hashicorp/boundary/internal/activity"
+	"github.com/hashicorp/boundary/internal/alias"
+	"github.com/hashicorp/boundary/internal/auth/cert"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/credential/vault"
 	pluginhost "github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/label"
+	"github.com/hashicorp/boundary/internal/notification"
+	"github.com/hashicorp/boundary/internal/oplog/changefeed"
 	hostplugin "github.com/hashicorp/boundary/internal/plugin/host"
+	"github.com/hashicorp/boundary/internal/report"
+	"github.com/hashicorp/boundary/internal/scim"
 	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/target"
@@ -19,10 +27,18 @@ type (
 	IamRepoFactory             func() (*iam.Repository, error)
 	OidcAuthRepoFactory        = oidc.OidcRepoFactory
 	PasswordAuthRepoFactory    func() (*password.Repository, error)
+	CertAuthRepoFactory        func() (*cert.Repository, error)
 	ServersRepoFactory         func() (*servers.Repository, error)
 	StaticRepoFactory          func() (*static.Repository, error)
 	PluginHostRepoFactory      func() (*pluginhost.Repository, error)
 	HostPluginRepoFactory      func() (*hostplugin.Repository, error)
 	SessionRepoFactory         func() (*session.Repository, error)
 	TargetRepoFactory          func() (*target.Repository, error)
+	OplogChangeRepoFactory     func() (*changefeed.Repository, error)
+	ReportRepoFactory          func() (*report.Repository, error)
+	NotificationRepoFactory    func() (*notification.Repository, error)
+	ScimRepoFactory            func() (*scim.Repository, error)
+	ActivityRepoFactory        func() (*activity.Repository, error)
+	AliasRepoFactory           func() (*alias.Repository, error)
+	LabelRepoFactory           func() (*label.Repository, error)
 )
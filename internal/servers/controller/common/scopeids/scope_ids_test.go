@@ -47,7 +47,7 @@ func TestListingScopeIds(t *testing.T) {
 	sessionsRepoFn := func() (*session.Repository, error) {
 		return session.NewRepository(rw, rw, kms)
 	}
-	sess, err := sessions.NewService(sessionsRepoFn, iamRepoFn)
+	sess, err := sessions.NewService(sessionsRepoFn, iamRepoFn, nil)
 	require.NoError(t, err)
 
 	tcs := []struct {
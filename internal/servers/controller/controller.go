@@ -9,6 +9,9 @@ import (
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 
+	"github.com/hashicorp/boundary/internal/activity"
+	"github.com/hashicorp/boundary/internal/alias"
+	"github.com/hashicorp/boundary/internal/auth/cert"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/authtoken"
@@ -20,16 +23,22 @@ import (
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/label"
+	"github.com/hashicorp/boundary/internal/notification"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/oplog/changefeed"
 	"github.com/hashicorp/boundary/internal/plugin/host"
 	hostplugin "github.com/hashicorp/boundary/internal/plugin/host"
+	"github.com/hashicorp/boundary/internal/report"
 	"github.com/hashicorp/boundary/internal/scheduler"
 	"github.com/hashicorp/boundary/internal/scheduler/job"
+	"github.com/hashicorp/boundary/internal/scim"
 	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/target"
 	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/internal/warehouse"
 	host_plugin_assets "github.com/hashicorp/boundary/plugins/host"
 	"github.com/hashicorp/boundary/sdk/pbs/plugin"
 	external_host_plugins "github.com/hashicorp/boundary/sdk/plugins/host"
@@ -55,6 +64,9 @@ type Controller struct {
 	// Used for testing and tracking worker health
 	workerStatusUpdateTimes *sync.Map
 
+	// Used to push session terminations to connected workers
+	sessionTerminations *session.TerminationBroadcaster
+
 	// grpc gateway server
 	gatewayServer   *grpc.Server
 	gatewayTicket   string
@@ -67,12 +79,20 @@ type Controller struct {
 	IamRepoFn             common.IamRepoFactory
 	OidcRepoFn            common.OidcAuthRepoFactory
 	PasswordAuthRepoFn    common.PasswordAuthRepoFactory
+	CertAuthRepoFn        common.CertAuthRepoFactory
 	ServersRepoFn         common.ServersRepoFactory
 	SessionRepoFn         common.SessionRepoFactory
 	StaticHostRepoFn      common.StaticRepoFactory
 	PluginHostRepoFn      common.PluginHostRepoFactory
 	HostPluginRepoFn      common.HostPluginRepoFactory
 	TargetRepoFn          common.TargetRepoFactory
+	OplogChangeRepoFn     common.OplogChangeRepoFactory
+	ReportRepoFn          common.ReportRepoFactory
+	NotificationRepoFn    common.NotificationRepoFactory
+	ScimRepoFn            common.ScimRepoFactory
+	ActivityRepoFn        common.ActivityRepoFactory
+	AliasRepoFn           common.AliasRepoFactory
+	LabelRepoFn           common.LabelRepoFactory
 
 	scheduler *scheduler.Scheduler
 
@@ -90,6 +110,7 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 		schedulerWg:             new(sync.WaitGroup),
 		workerAuthCache:         new(sync.Map),
 		workerStatusUpdateTimes: new(sync.Map),
+		sessionTerminations:     session.NewTerminationBroadcaster(),
 		enabledPlugins:          conf.Server.EnabledPlugins,
 	}
 
@@ -230,12 +251,36 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 	c.PasswordAuthRepoFn = func() (*password.Repository, error) {
 		return password.NewRepository(dbase, dbase, c.kms)
 	}
+	c.CertAuthRepoFn = func() (*cert.Repository, error) {
+		return cert.NewRepository(ctx, dbase, dbase, c.kms)
+	}
 	c.TargetRepoFn = func() (*target.Repository, error) {
 		return target.NewRepository(dbase, dbase, c.kms)
 	}
 	c.SessionRepoFn = func() (*session.Repository, error) {
 		return session.NewRepository(dbase, dbase, c.kms)
 	}
+	c.OplogChangeRepoFn = func() (*changefeed.Repository, error) {
+		return changefeed.NewRepository(dbase, c.kms)
+	}
+	c.ReportRepoFn = func() (*report.Repository, error) {
+		return report.NewRepository(dbase, dbase)
+	}
+	c.NotificationRepoFn = func() (*notification.Repository, error) {
+		return notification.NewRepository(dbase, dbase, c.kms)
+	}
+	c.ScimRepoFn = func() (*scim.Repository, error) {
+		return scim.NewRepository(dbase, dbase)
+	}
+	c.ActivityRepoFn = func() (*activity.Repository, error) {
+		return activity.NewRepository(dbase, dbase)
+	}
+	c.AliasRepoFn = func() (*alias.Repository, error) {
+		return alias.NewRepository(dbase, dbase, c.kms)
+	}
+	c.LabelRepoFn = func() (*label.Repository, error) {
+		return label.NewRepository(dbase, dbase)
+	}
 
 	return c, nil
 }
@@ -257,7 +302,7 @@ func (c *Controller) Start() error {
 		return fmt.Errorf("error starting controller listeners: %w", err)
 	}
 
-	c.tickerWg.Add(5)
+	c.tickerWg.Add(6)
 	go func() {
 		defer c.tickerWg.Done()
 		c.startStatusTicking(c.baseContext)
@@ -274,6 +319,10 @@ func (c *Controller) Start() error {
 		defer c.tickerWg.Done()
 		c.startCloseExpiredPendingTokens(c.baseContext)
 	}()
+	go func() {
+		defer c.tickerWg.Done()
+		c.startSessionTerminationsPolling(c.baseContext)
+	}()
 	go func() {
 		defer c.tickerWg.Done()
 		c.started.Store(true)
@@ -290,14 +339,56 @@ func (c *Controller) registerJobs() error {
 	if err := pluginhost.RegisterJobs(c.baseContext, c.scheduler, rw, rw, c.kms, c.conf.HostPlugins); err != nil {
 		return err
 	}
+	if err := notification.RegisterJobs(c.baseContext, c.scheduler, rw, rw, c.kms); err != nil {
+		return err
+	}
+	if err := activity.RegisterJobs(c.baseContext, c.scheduler, rw, activity.WithRetention(c.conf.RawConfig.Controller.UserActivityRetentionDuration)); err != nil {
+		return err
+	}
 
 	if err := c.registerSessionCleanupJob(); err != nil {
 		return err
 	}
+	if err := c.registerWarehouseExportJob(rw); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// registerWarehouseExportJob registers the warehouse export job if it is
+// configured.
+func (c *Controller) registerWarehouseExportJob(rw *db.Db) error {
+	wx := c.conf.RawConfig.Controller.WarehouseExport
+	if wx == nil {
+		return nil
+	}
+	format, err := warehouse.ParseFormat(c.baseContext, wx.Format)
+	if err != nil {
+		return err
+	}
+	var dest warehouse.Destination
+	switch {
+	case wx.S3 != nil:
+		dest, err = warehouse.NewS3Bucket(c.baseContext, warehouse.S3Config{
+			Endpoint:        wx.S3.Endpoint,
+			Region:          wx.S3.Region,
+			Bucket:          wx.S3.Bucket,
+			Prefix:          wx.S3.Prefix,
+			AccessKeyId:     wx.S3.AccessKeyId,
+			SecretAccessKey: wx.S3.SecretAccessKey,
+			ForcePathStyle:  wx.S3.ForcePathStyle,
+		})
+	default:
+		dest, err = warehouse.NewLocalDirectory(c.baseContext, wx.LocalDirectory)
+	}
+	if err != nil {
+		return err
+	}
+	return warehouse.RegisterJobs(c.baseContext, c.scheduler, rw, rw, dest,
+		warehouse.WithFormat(format), warehouse.WithInterval(wx.IntervalDuration))
+}
+
 // registerSessionCleanupJob is a helper method to abstract
 // registering the session cleanup job specifically.
 func (c *Controller) registerSessionCleanupJob() error {
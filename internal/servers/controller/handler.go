@@ -18,9 +18,11 @@ import (
 	"github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	authpb "github.com/hashicorp/boundary/internal/gen/controller/auth"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/scim"
 	"github.com/hashicorp/boundary/internal/servers/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/auth"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/accounts"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/aliases"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authmethods"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authtokens"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/credentiallibraries"
@@ -30,8 +32,12 @@ import (
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/host_sets"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/hosts"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/managed_groups"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/notifications"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/oplogchanges"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/reports"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/roles"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/scopes"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/serviceaccounts"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/sessions"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/targets"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/users"
@@ -61,6 +67,11 @@ func (c *Controller) handler(props HandlerProperties) (http.Handler, error) {
 		return nil, err
 	}
 	mux.Handle("/v1/", h)
+	sh, err := handleScim(c)
+	if err != nil {
+		return nil, err
+	}
+	mux.Handle(scim.BasePath, sh)
 	mux.Handle("/", handleUi(c))
 
 	corsWrappedHandler := wrapHandlerWithCors(mux, props)
@@ -93,7 +104,7 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 		}
 	}
 	if _, ok := currentServices[services.HostSetService_ServiceDesc.ServiceName]; !ok {
-		hss, err := host_sets.NewService(c.StaticHostRepoFn, c.PluginHostRepoFn)
+		hss, err := host_sets.NewService(c.StaticHostRepoFn, c.PluginHostRepoFn, c.LabelRepoFn)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create host set handler service: %w", err)
 		}
@@ -103,7 +114,7 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 		}
 	}
 	if _, ok := currentServices[services.HostService_ServiceDesc.ServiceName]; !ok {
-		hs, err := hosts.NewService(c.StaticHostRepoFn, c.PluginHostRepoFn)
+		hs, err := hosts.NewService(c.StaticHostRepoFn, c.PluginHostRepoFn, c.LabelRepoFn)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create host handler service: %w", err)
 		}
@@ -113,7 +124,7 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 		}
 	}
 	if _, ok := currentServices[services.AccountService_ServiceDesc.ServiceName]; !ok {
-		accts, err := accounts.NewService(c.PasswordAuthRepoFn, c.OidcRepoFn)
+		accts, err := accounts.NewService(c.PasswordAuthRepoFn, c.OidcRepoFn, c.CertAuthRepoFn, c.AuthTokenRepoFn, c.SessionRepoFn, c.sessionTerminations)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create account handler service: %w", err)
 		}
@@ -123,7 +134,7 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 		}
 	}
 	if _, ok := currentServices[services.AuthMethodService_ServiceDesc.ServiceName]; !ok {
-		authMethods, err := authmethods.NewService(c.kms, c.PasswordAuthRepoFn, c.OidcRepoFn, c.IamRepoFn, c.AuthTokenRepoFn)
+		authMethods, err := authmethods.NewService(c.kms, c.PasswordAuthRepoFn, c.OidcRepoFn, c.CertAuthRepoFn, c.IamRepoFn, c.AuthTokenRepoFn, c.SessionRepoFn, c.sessionTerminations, c.ScimRepoFn)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create auth method handler service: %w", err)
 		}
@@ -133,7 +144,7 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 		}
 	}
 	if _, ok := currentServices[services.AuthTokenService_ServiceDesc.ServiceName]; !ok {
-		authtoks, err := authtokens.NewService(c.AuthTokenRepoFn, c.IamRepoFn)
+		authtoks, err := authtokens.NewService(c.kms, c.AuthTokenRepoFn, c.IamRepoFn)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create auth token handler service: %w", err)
 		}
@@ -143,7 +154,7 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 		}
 	}
 	if _, ok := currentServices[services.ScopeService_ServiceDesc.ServiceName]; !ok {
-		os, err := scopes.NewService(c.IamRepoFn)
+		os, err := scopes.NewService(c.IamRepoFn, c.AuthTokenRepoFn, c.SessionRepoFn, c.sessionTerminations)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create scope handler service: %w", err)
 		}
@@ -153,7 +164,7 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 		}
 	}
 	if _, ok := currentServices[services.UserService_ServiceDesc.ServiceName]; !ok {
-		us, err := users.NewService(c.IamRepoFn)
+		us, err := users.NewService(c.IamRepoFn, c.AuthTokenRepoFn, c.SessionRepoFn, c.sessionTerminations, c.ActivityRepoFn)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create user handler service: %w", err)
 		}
@@ -172,7 +183,12 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 			c.SessionRepoFn,
 			c.PluginHostRepoFn,
 			c.StaticHostRepoFn,
-			c.VaultCredentialRepoFn)
+			c.VaultCredentialRepoFn,
+			c.ReportRepoFn,
+			c.AliasRepoFn,
+			c.LabelRepoFn,
+			c.ActivityRepoFn,
+			c.sessionTerminations)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create target handler service: %w", err)
 		}
@@ -191,6 +207,36 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 			return nil, fmt.Errorf("failed to register group service handler: %w", err)
 		}
 	}
+	if _, ok := currentServices[services.ServiceAccountService_ServiceDesc.ServiceName]; !ok {
+		sas, err := serviceaccounts.NewService(c.kms, c.IamRepoFn, c.AuthTokenRepoFn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create service account handler service: %w", err)
+		}
+		services.RegisterServiceAccountServiceServer(c.gatewayServer, sas)
+		if err := services.RegisterServiceAccountServiceHandlerFromEndpoint(ctx, c.gatewayMux, gatewayTarget, dialOptions); err != nil {
+			return nil, fmt.Errorf("failed to register service account service handler: %w", err)
+		}
+	}
+	if _, ok := currentServices[services.NotificationService_ServiceDesc.ServiceName]; !ok {
+		ns, err := notifications.NewService(c.NotificationRepoFn, c.IamRepoFn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create notification handler service: %w", err)
+		}
+		services.RegisterNotificationServiceServer(c.gatewayServer, ns)
+		if err := services.RegisterNotificationServiceHandlerFromEndpoint(ctx, c.gatewayMux, gatewayTarget, dialOptions); err != nil {
+			return nil, fmt.Errorf("failed to register notification service handler: %w", err)
+		}
+	}
+	if _, ok := currentServices[services.AliasService_ServiceDesc.ServiceName]; !ok {
+		as, err := aliases.NewService(c.AliasRepoFn, c.IamRepoFn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create alias handler service: %w", err)
+		}
+		services.RegisterAliasServiceServer(c.gatewayServer, as)
+		if err := services.RegisterAliasServiceHandlerFromEndpoint(ctx, c.gatewayMux, gatewayTarget, dialOptions); err != nil {
+			return nil, fmt.Errorf("failed to register alias service handler: %w", err)
+		}
+	}
 	if _, ok := currentServices[services.RoleService_ServiceDesc.ServiceName]; !ok {
 		rs, err := roles.NewService(c.IamRepoFn)
 		if err != nil {
@@ -202,7 +248,7 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 		}
 	}
 	if _, ok := currentServices[services.SessionService_ServiceDesc.ServiceName]; !ok {
-		ss, err := sessions.NewService(c.SessionRepoFn, c.IamRepoFn)
+		ss, err := sessions.NewService(c.SessionRepoFn, c.IamRepoFn, c.sessionTerminations)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create session handler service: %w", err)
 		}
@@ -241,6 +287,26 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 			return nil, fmt.Errorf("failed to register credential library service handler: %w", err)
 		}
 	}
+	if _, ok := currentServices[services.OplogChangeService_ServiceDesc.ServiceName]; !ok {
+		oc, err := oplogchanges.NewService(c.OplogChangeRepoFn, c.IamRepoFn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create oplog change handler service: %w", err)
+		}
+		services.RegisterOplogChangeServiceServer(c.gatewayServer, oc)
+		if err := services.RegisterOplogChangeServiceHandlerFromEndpoint(ctx, c.gatewayMux, gatewayTarget, dialOptions); err != nil {
+			return nil, fmt.Errorf("failed to register oplog change service handler: %w", err)
+		}
+	}
+	if _, ok := currentServices[services.ReportService_ServiceDesc.ServiceName]; !ok {
+		rs, err := reports.NewService(c.ReportRepoFn, c.IamRepoFn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create report handler service: %w", err)
+		}
+		services.RegisterReportServiceServer(c.gatewayServer, rs)
+		if err := services.RegisterReportServiceHandlerFromEndpoint(ctx, c.gatewayMux, gatewayTarget, dialOptions); err != nil {
+			return nil, fmt.Errorf("failed to register report service handler: %w", err)
+		}
+	}
 
 	return c.gatewayMux, nil
 }
@@ -286,7 +352,19 @@ func wrapHandlerWithCommonFuncs(h http.Handler, c *Controller, props HandlerProp
 			DisableAuthzFailures: disableAuthzFailures,
 		}
 
-		requestInfo.PublicId, requestInfo.EncryptedToken, requestInfo.TokenFormat = auth.GetTokenFromRequest(ctx, c.kms, r)
+		// SCIM requests carry the SCIM token of an auth method instead of an
+		// auth token, it is verified by the SCIM handler.
+		if !strings.HasPrefix(r.URL.Path, scim.BasePath) {
+			requestInfo.PublicId, requestInfo.EncryptedToken, requestInfo.TokenFormat = auth.GetTokenFromRequest(ctx, c.kms, r)
+		}
+
+		// The client certificate chain, if one was presented on a mutual TLS
+		// connection, is used by cert auth methods to authenticate the client.
+		if r.TLS != nil {
+			for _, c := range r.TLS.PeerCertificates {
+				requestInfo.ClientCertificates = append(requestInfo.ClientCertificates, c.Raw)
+			}
+		}
 
 		if info, ok := event.RequestInfoFromContext(ctx); ok {
 			// piggyback some eventing fields with the auth info proto message
@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/scim"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authtokens"
+)
+
+// handleScim returns the handler of the SCIM endpoints of the auth methods.
+// Deactivating or deleting a user through a SCIM endpoint revokes the
+// user's auth tokens and cancels its sessions. This happens after the
+// user's account is deleted, which already deletes the tokens issued for
+// it, so the sessions are canceled whether or not any token is left.
+func handleScim(c *Controller) (http.Handler, error) {
+	revokeFn := func(ctx context.Context, userId string) error {
+		_, _, err := authtokens.RevokeDisabled(ctx, c.AuthTokenRepoFn, c.SessionRepoFn, c.sessionTerminations, []string{userId},
+			func(ctx context.Context, repo *authtoken.Repository) ([]*authtoken.AuthToken, error) {
+				return repo.RevokeAuthTokensForUser(ctx, userId)
+			})
+		return err
+	}
+	p, err := scim.NewProvisioner(c.baseContext, c.ScimRepoFn, c.IamRepoFn, c.OidcRepoFn, c.PasswordAuthRepoFn, revokeFn)
+	if err != nil {
+		return nil, err
+	}
+	return scim.NewHandler(c.baseContext, p)
+}
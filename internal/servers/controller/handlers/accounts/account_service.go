@@ -9,18 +9,24 @@ import (
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/auth/cert"
+	certstore "github.com/hashicorp/boundary/internal/auth/cert/store"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	oidcstore "github.com/hashicorp/boundary/internal/auth/oidc/store"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	pwstore "github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/intglobals"
+	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	requestauth "github.com/hashicorp/boundary/internal/servers/controller/auth"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authtokens"
+	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/subtypes"
@@ -43,6 +49,11 @@ const (
 	loginNameKey         = "login_name"
 	newPasswordField     = "new_password"
 	currentPasswordField = "current_password"
+	passwordAttrField    = "attributes.password"
+	failedLoginCountKey  = "attributes.failed_login_count"
+	lockExpirationKey    = "attributes.lock_expiration_time"
+	isLockedKey          = "attributes.is_locked"
+	isTotpEnrolledKey    = "attributes.is_totp_enrolled"
 
 	// oidc field names
 	issuerField     = "attributes.issuer"
@@ -54,6 +65,7 @@ const (
 var (
 	pwMaskManager   handlers.MaskManager
 	oidcMaskManager handlers.MaskManager
+	certMaskManager handlers.MaskManager
 
 	// IdActions contains the set of actions that can be performed on
 	// individual resources
@@ -65,12 +77,23 @@ var (
 			action.Delete,
 			action.SetPassword,
 			action.ChangePassword,
+			action.EnrollTotp,
+			action.RemoveTotp,
+			action.RevokeTokens,
 		},
 		oidc.Subtype: {
 			action.NoOp,
 			action.Read,
 			action.Update,
 			action.Delete,
+			action.RevokeTokens,
+		},
+		cert.Subtype: {
+			action.NoOp,
+			action.Read,
+			action.Update,
+			action.Delete,
+			action.RevokeTokens,
 		},
 	}
 
@@ -90,18 +113,27 @@ func init() {
 	if oidcMaskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&oidcstore.Account{}}, handlers.MaskSource{&pb.Account{}, &pb.OidcAccountAttributes{}}); err != nil {
 		panic(err)
 	}
+	if certMaskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&certstore.Account{}}, handlers.MaskSource{&pb.Account{}, &pb.CertAccountAttributes{}}); err != nil {
+		panic(err)
+	}
 }
 
 // Service handles request as described by the pbs.AccountServiceServer interface.
 type Service struct {
 	pbs.UnimplementedAccountServiceServer
 
-	pwRepoFn   common.PasswordAuthRepoFactory
-	oidcRepoFn common.OidcAuthRepoFactory
+	pwRepoFn      common.PasswordAuthRepoFactory
+	oidcRepoFn    common.OidcAuthRepoFactory
+	certRepoFn    common.CertAuthRepoFactory
+	atRepoFn      common.AuthTokenRepoFactory
+	sessionRepoFn common.SessionRepoFactory
+	terminations  *session.TerminationBroadcaster
 }
 
-// NewService returns a account service which handles account related requests to boundary.
-func NewService(pwRepo common.PasswordAuthRepoFactory, oidcRepo common.OidcAuthRepoFactory) (Service, error) {
+// NewService returns a account service which handles account related requests
+// to boundary. The auth token and session repositories and the termination
+// broadcaster are only used to revoke the auth tokens of an account.
+func NewService(pwRepo common.PasswordAuthRepoFactory, oidcRepo common.OidcAuthRepoFactory, certRepo common.CertAuthRepoFactory, atRepoFn common.AuthTokenRepoFactory, sessionRepoFn common.SessionRepoFactory, terminations *session.TerminationBroadcaster) (Service, error) {
 	const op = "accounts.NewService"
 	if pwRepo == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing password repository")
@@ -109,7 +141,17 @@ func NewService(pwRepo common.PasswordAuthRepoFactory, oidcRepo common.OidcAuthR
 	if oidcRepo == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing oidc repository provided")
 	}
-	return Service{pwRepoFn: pwRepo, oidcRepoFn: oidcRepo}, nil
+	if certRepo == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing cert repository")
+	}
+	return Service{
+		pwRepoFn:      pwRepo,
+		oidcRepoFn:    oidcRepo,
+		certRepoFn:    certRepo,
+		atRepoFn:      atRepoFn,
+		sessionRepoFn: sessionRepoFn,
+		terminations:  terminations,
+	}, nil
 }
 
 var _ pbs.AccountServiceServer = Service{}
@@ -270,6 +312,11 @@ func (s Service) UpdateAccount(ctx context.Context, req *pbs.UpdateAccountReques
 	if err != nil {
 		return nil, err
 	}
+	if acct.GetDisabled() && handlers.MaskContains(req.GetUpdateMask().GetPaths(), globals.DisabledField) {
+		if err := s.revokeDisabled(ctx, acct.GetPublicId()); err != nil {
+			return nil, err
+		}
+	}
 
 	outputFields, ok := requests.OutputFields(ctx)
 	if !ok {
@@ -387,6 +434,123 @@ func (s Service) SetPassword(ctx context.Context, req *pbs.SetPasswordRequest) (
 	return &pbs.SetPasswordResponse{Item: item}, nil
 }
 
+// EnrollTotp implements the interface pbs.AccountServiceServer.
+func (s Service) EnrollTotp(ctx context.Context, req *pbs.EnrollTotpRequest) (*pbs.EnrollTotpResponse, error) {
+	const op = "accounts.(Service).EnrollTotp"
+
+	if err := validateTotpRequest(req); err != nil {
+		return nil, err
+	}
+
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.EnrollTotp)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	acct, enrollment, err := s.enrollTotpInRepo(ctx, authResults.Scope.GetId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, acct.GetPublicId(), IdActions[auth.SubtypeFromId(acct.GetPublicId())]).Strings()))
+	}
+
+	item, err := toProto(ctx, acct, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.EnrollTotpResponse{
+		Item:          item,
+		TotpUrl:       enrollment.Url,
+		TotpSecret:    enrollment.Secret,
+		RecoveryCodes: enrollment.RecoveryCodes,
+	}, nil
+}
+
+// RemoveTotp implements the interface pbs.AccountServiceServer.
+func (s Service) RemoveTotp(ctx context.Context, req *pbs.RemoveTotpRequest) (*pbs.RemoveTotpResponse, error) {
+	const op = "accounts.(Service).RemoveTotp"
+
+	if err := validateTotpRequest(req); err != nil {
+		return nil, err
+	}
+
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.RemoveTotp)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	acct, err := s.removeTotpInRepo(ctx, authResults.Scope.GetId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, acct.GetPublicId(), IdActions[auth.SubtypeFromId(acct.GetPublicId())]).Strings()))
+	}
+
+	item, err := toProto(ctx, acct, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.RemoveTotpResponse{Item: item}, nil
+}
+
+// RevokeAccountTokens implements the interface pbs.AccountServiceServer.
+func (s Service) RevokeAccountTokens(ctx context.Context, req *pbs.RevokeAccountTokensRequest) (*pbs.RevokeAccountTokensResponse, error) {
+	if err := validateRevokeAccountTokensRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.RevokeTokens)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	count, sessionIds, err := authtokens.Revoke(ctx, s.atRepoFn, s.sessionRepoFn, s.terminations, req.GetCancelSessions(),
+		func(ctx context.Context, repo *authtoken.Repository) ([]*authtoken.AuthToken, error) {
+			return repo.RevokeAuthTokensForAccount(ctx, req.GetId())
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.RevokeAccountTokensResponse{RevokedTokenCount: count, CanceledSessionIds: sessionIds}, nil
+}
+
+// revokeDisabled revokes the auth tokens of the disabled account acctId and
+// cancels the sessions of the users the tokens were issued to.
+func (s Service) revokeDisabled(ctx context.Context, acctId string) error {
+	const op = "accounts.(Service).revokeDisabled"
+	count, sessionIds, err := authtokens.Revoke(ctx, s.atRepoFn, s.sessionRepoFn, s.terminations, true,
+		func(ctx context.Context, repo *authtoken.Repository) ([]*authtoken.AuthToken, error) {
+			return repo.RevokeAuthTokensForAccount(ctx, acctId)
+		})
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("account was disabled but its auth tokens could not be revoked"))
+	}
+	event.WriteSysEvent(ctx, op, "account disabled", "account_id", acctId, "revoked_token_count", count, "canceled_session_ids", sessionIds)
+	return nil
+}
+
 // getFromRepo returns the account and, if available, managed groups the account
 // belongs to within the auth method
 func (s Service) getFromRepo(ctx context.Context, id string) (auth.Account, []string, error) {
@@ -426,6 +590,19 @@ func (s Service) getFromRepo(ctx context.Context, id string) (auth.Account, []st
 			mgIds = append(mgIds, mg.GetManagedGroupId())
 		}
 		acct = a
+	case cert.Subtype:
+		repo, err := s.certRepoFn()
+		if err != nil {
+			return nil, nil, err
+		}
+		a, err := repo.LookupAccount(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		if a == nil {
+			return nil, nil, handlers.NotFoundErrorf("Account %q doesn't exist.", id)
+		}
+		acct = a
 	default:
 		return nil, nil, handlers.NotFoundErrorf("Unrecognized id.")
 	}
@@ -453,6 +630,7 @@ func (s Service) createPwInRepo(ctx context.Context, am auth.AuthMethod, item *p
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build account for creation: %v.", err)
 	}
+	a.Disabled = item.GetDisabled()
 	repo, err := s.pwRepoFn()
 	if err != nil {
 		return nil, err
@@ -464,6 +642,11 @@ func (s Service) createPwInRepo(ctx context.Context, am auth.AuthMethod, item *p
 	}
 	out, err := repo.CreateAccount(ctx, am.GetScopeId(), a, createOpts...)
 	if err != nil {
+		switch {
+		case errors.Match(errors.T(errors.PasswordTooWeak), err):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{passwordAttrField: "Password does not meet the password policy of the auth method."})
+		}
 		return nil, errors.Wrap(ctx, err, op)
 	}
 	if out == nil {
@@ -500,6 +683,7 @@ func (s Service) createOidcInRepo(ctx context.Context, am auth.AuthMethod, item
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build account for creation: %v.", err)
 	}
+	a.Disabled = item.GetDisabled()
 	repo, err := s.oidcRepoFn()
 	if err != nil {
 		return nil, err
@@ -515,6 +699,43 @@ func (s Service) createOidcInRepo(ctx context.Context, am auth.AuthMethod, item
 	return out, nil
 }
 
+func (s Service) createCertInRepo(ctx context.Context, am auth.AuthMethod, item *pb.Account) (*cert.Account, error) {
+	const op = "accounts.(Service).createCertInRepo"
+	if item == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing item")
+	}
+	var opts []cert.Option
+	if item.GetName() != nil {
+		opts = append(opts, cert.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, cert.WithDescription(item.GetDescription().GetValue()))
+	}
+	attrs := &pb.CertAccountAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), attrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	a, err := cert.NewAccount(ctx, am.GetPublicId(), attrs.GetSubject(), opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build account for creation: %v.", err)
+	}
+	a.Disabled = item.GetDisabled()
+	repo, err := s.certRepoFn()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := repo.CreateAccount(ctx, am.GetScopeId(), a)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create account"))
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create account but no error returned from repository.")
+	}
+	return out, nil
+}
+
 func (s Service) createInRepo(ctx context.Context, am auth.AuthMethod, item *pb.Account) (auth.Account, error) {
 	const op = "accounts.(Service).createInRepo"
 	if item == nil {
@@ -540,6 +761,15 @@ func (s Service) createInRepo(ctx context.Context, am auth.AuthMethod, item *pb.
 			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create account but no error returned from repository.")
 		}
 		out = am
+	case cert.Subtype:
+		am, err := s.createCertInRepo(ctx, am, item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if am == nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create account but no error returned from repository.")
+		}
+		out = am
 	}
 	return out, nil
 }
@@ -590,6 +820,7 @@ func (s Service) updateOidcInRepo(ctx context.Context, scopeId, amId, id string,
 	if item.GetDescription() != nil {
 		u.Description = item.GetDescription().GetValue()
 	}
+	u.Disabled = item.GetDisabled()
 
 	version := item.GetVersion()
 
@@ -611,6 +842,41 @@ func (s Service) updateOidcInRepo(ctx context.Context, scopeId, amId, id string,
 	return out, nil
 }
 
+func (s Service) updateCertInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Account) (*cert.Account, error) {
+	const op = "accounts.(Service).updateCertInRepo"
+	if item == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil account.")
+	}
+	u := cert.AllocAccount()
+	u.PublicId = id
+	if item.GetName() != nil {
+		u.Name = item.GetName().GetValue()
+	}
+	if item.GetDescription() != nil {
+		u.Description = item.GetDescription().GetValue()
+	}
+	u.Disabled = item.GetDisabled()
+
+	version := item.GetVersion()
+
+	dbMask := certMaskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.certRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, rowsUpdated, err := repo.UpdateAccount(ctx, scopeId, u, version, dbMask)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update account"))
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Account %q doesn't exist or incorrect version provided.", id)
+	}
+	return out, nil
+}
+
 func (s Service) updateInRepo(ctx context.Context, scopeId, authMethodId string, req *pbs.UpdateAccountRequest) (auth.Account, error) {
 	const op = "accounts.(Service).updateInRepo"
 	var out auth.Account
@@ -633,6 +899,15 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, authMethodId string,
 			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to update account but no error returned from repository.")
 		}
 		out = a
+	case cert.Subtype:
+		a, err := s.updateCertInRepo(ctx, scopeId, req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if a == nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to update account but no error returned from repository.")
+		}
+		out = a
 	}
 	return out, nil
 }
@@ -654,6 +929,12 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool,
 			return false, iErr
 		}
 		rows, err = repo.DeleteAccount(ctx, scopeId, id)
+	case cert.Subtype:
+		repo, iErr := s.certRepoFn()
+		if iErr != nil {
+			return false, iErr
+		}
+		rows, err = repo.DeleteAccount(ctx, scopeId, id)
 	}
 	if err != nil {
 		if errors.IsNotFoundError(err) {
@@ -693,6 +974,18 @@ func (s Service) listFromRepo(ctx context.Context, authMethodId string) ([]auth.
 		for _, a := range oidcl {
 			outUl = append(outUl, a)
 		}
+	case cert.Subtype:
+		certRepo, err := s.certRepoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		certl, err := certRepo.ListAccounts(ctx, authMethodId)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		for _, a := range certl {
+			outUl = append(outUl, a)
+		}
 	}
 	return outUl, nil
 }
@@ -714,6 +1007,16 @@ func (s Service) changePasswordInRepo(ctx context.Context, scopeId, id string, v
 		case errors.Match(errors.T(errors.PasswordsEqual), err):
 			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
 				map[string]string{"new_password": "New password equal to current password."})
+		case errors.Match(errors.T(errors.PasswordTooWeak), err):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"new_password": "Password does not meet the password policy of the auth method."})
+		case errors.Match(errors.T(errors.PasswordReused), err):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"new_password": "Password was used recently."})
+		case errors.Match(errors.T(errors.AccountLocked), err):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "Account is locked.")
+		case errors.Match(errors.T(errors.AccountDisabled), err):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "Account is disabled.")
 		}
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -738,6 +1041,51 @@ func (s Service) setPasswordInRepo(ctx context.Context, scopeId, id string, vers
 		case errors.Match(errors.T(errors.PasswordTooShort), err):
 			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
 				map[string]string{"password": "Password is too short."})
+		case errors.Match(errors.T(errors.PasswordTooWeak), err):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"password": "Password does not meet the password policy of the auth method."})
+		case errors.Match(errors.T(errors.PasswordReused), err):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"password": "Password was used recently."})
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return out, nil
+}
+
+func (s Service) enrollTotpInRepo(ctx context.Context, scopeId, id string) (auth.Account, *password.TotpEnrollment, error) {
+	const op = "accounts.(Service).enrollTotpInRepo"
+
+	repo, err := s.pwRepoFn()
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	out, enrollment, err := repo.EnrollTotp(ctx, scopeId, id)
+	if err != nil {
+		switch {
+		case errors.IsNotFoundError(err):
+			return nil, nil, handlers.NotFoundErrorf("Account not found.")
+		case errors.Match(errors.T(errors.TotpMfaDisabled), err):
+			return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "TOTP is disabled for the auth method of the account.")
+		case errors.Match(errors.T(errors.TotpAlreadyEnrolled), err):
+			return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "Account is already enrolled in TOTP.")
+		}
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	return out, enrollment, nil
+}
+
+func (s Service) removeTotpInRepo(ctx context.Context, scopeId, id string) (auth.Account, error) {
+	const op = "accounts.(Service).removeTotpInRepo"
+
+	repo, err := s.pwRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, err := repo.RemoveTotp(ctx, scopeId, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("Account not found.")
 		}
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -756,6 +1104,11 @@ func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Ty
 		res.Error = err
 		return nil, res
 	}
+	certRepo, err := s.certRepoFn()
+	if err != nil {
+		res.Error = err
+		return nil, res
+	}
 
 	var parentId string
 	opts := []requestauth.Option{requestauth.WithType(resource.Account), requestauth.WithAction(a)}
@@ -786,6 +1139,17 @@ func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Ty
 				return nil, res
 			}
 			parentId = acct.GetAuthMethodId()
+		case cert.Subtype:
+			acct, err := certRepo.LookupAccount(ctx, id)
+			if err != nil {
+				res.Error = err
+				return nil, res
+			}
+			if acct == nil {
+				res.Error = handlers.NotFoundError()
+				return nil, res
+			}
+			parentId = acct.GetAuthMethodId()
 		}
 		opts = append(opts, requestauth.WithId(id))
 	}
@@ -814,6 +1178,17 @@ func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Ty
 			return nil, res
 		}
 		authMeth = am
+	case cert.Subtype:
+		am, err := certRepo.LookupAuthMethod(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return nil, res
+		}
+		if am == nil {
+			res.Error = handlers.NotFoundError()
+			return nil, res
+		}
+		authMeth = am
 	}
 	opts = append(opts, requestauth.WithScopeId(authMeth.GetScopeId()), requestauth.WithPin(parentId))
 	return authMeth, requestauth.Verify(ctx, opts...)
@@ -859,6 +1234,9 @@ func toProto(ctx context.Context, in auth.Account, opt ...handlers.Option) (*pb.
 	if outputFields.Has(globals.ManagedGroupIdsField) {
 		out.ManagedGroupIds = opts.WithManagedGroupIds
 	}
+	if outputFields.Has(globals.DisabledField) {
+		out.Disabled = in.GetDisabled()
+	}
 	switch i := in.(type) {
 	case *password.Account:
 		if outputFields.Has(globals.TypeField) {
@@ -867,7 +1245,13 @@ func toProto(ctx context.Context, in auth.Account, opt ...handlers.Option) (*pb.
 		if !outputFields.Has(globals.AttributesField) {
 			break
 		}
-		st, err := handlers.ProtoToStruct(&pb.PasswordAccountAttributes{LoginName: i.GetLoginName()})
+		st, err := handlers.ProtoToStruct(&pb.PasswordAccountAttributes{
+			LoginName:          i.GetLoginName(),
+			FailedLoginCount:   i.GetFailedLoginCount(),
+			LockExpirationTime: i.GetLockExpirationTime().GetTimestamp(),
+			IsLocked:           i.GetIsLocked(),
+			IsTotpEnrolled:     i.GetIsTotpEnrolled(),
+		})
 		if err != nil {
 			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building password attribute struct: %v", err)
 		}
@@ -910,6 +1294,22 @@ func toProto(ctx context.Context, in auth.Account, opt ...handlers.Option) (*pb.
 			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building oidc attribute struct: %v", err)
 		}
 		out.Attributes = st
+	case *cert.Account:
+		if outputFields.Has(globals.TypeField) {
+			out.Type = cert.Subtype.String()
+		}
+		if !outputFields.Has(globals.AttributesField) {
+			break
+		}
+		st, err := handlers.ProtoToStruct(&pb.CertAccountAttributes{
+			Subject:  i.GetSubject(),
+			FullName: i.GetFullName(),
+			Email:    i.GetEmail(),
+		})
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building cert attribute struct: %v", err)
+		}
+		out.Attributes = st
 	}
 	return &out, nil
 }
@@ -930,6 +1330,7 @@ func toStoragePwAccount(amId string, item *pb.Account) (*password.Account, error
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build account for creation: %v.", err)
 	}
+	u.Disabled = item.GetDisabled()
 
 	attrs := &pb.PasswordAccountAttributes{}
 	if err := handlers.StructToProto(item.GetAttributes(), attrs); err != nil {
@@ -953,7 +1354,7 @@ func validateGetRequest(req *pbs.GetAccountRequest) error {
 	if req == nil {
 		return errors.NewDeprecated(errors.InvalidParameter, op, "nil request")
 	}
-	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix, oidc.AccountPrefix)
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix, oidc.AccountPrefix, cert.AccountPrefix)
 }
 
 func validateCreateRequest(req *pbs.CreateAccountRequest) error {
@@ -978,6 +1379,18 @@ func validateCreateRequest(req *pbs.CreateAccountRequest) error {
 			if attrs.GetLoginName() == "" {
 				badFields[loginNameKey] = "This is a required field for this type."
 			}
+			if attrs.GetFailedLoginCount() != 0 {
+				badFields[failedLoginCountKey] = "This is a read only field."
+			}
+			if attrs.GetLockExpirationTime() != nil {
+				badFields[lockExpirationKey] = "This is a read only field."
+			}
+			if attrs.GetIsLocked() {
+				badFields[isLockedKey] = "This is a read only field."
+			}
+			if attrs.GetIsTotpEnrolled() {
+				badFields[isTotpEnrolledKey] = "This is a read only field."
+			}
 		case oidc.Subtype:
 			if req.GetItem().GetType() != "" && req.GetItem().GetType() != oidc.Subtype.String() {
 				badFields[typeField] = "Doesn't match the parent resource's type."
@@ -1004,6 +1417,23 @@ func validateCreateRequest(req *pbs.CreateAccountRequest) error {
 			if attrs.GetEmail() != "" {
 				badFields[emailClaimField] = "This is a read only field."
 			}
+		case cert.Subtype:
+			if req.GetItem().GetType() != "" && req.GetItem().GetType() != cert.Subtype.String() {
+				badFields[typeField] = "Doesn't match the parent resource's type."
+			}
+			attrs := &pb.CertAccountAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+				badFields[attributesField] = "Attribute fields do not match the expected format."
+			}
+			if attrs.GetSubject() == "" {
+				badFields[subjectField] = "This is a required field for this type."
+			}
+			if attrs.GetFullName() != "" {
+				badFields[nameClaimField] = "This is a read only field."
+			}
+			if attrs.GetEmail() != "" {
+				badFields[emailClaimField] = "This is a read only field."
+			}
 		default:
 			badFields[authMethodIdField] = "Unknown auth method type from ID."
 		}
@@ -1027,6 +1457,11 @@ func validateUpdateRequest(req *pbs.UpdateAccountRequest) error {
 			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
 				badFields[attributesField] = "Attribute fields do not match the expected format."
 			}
+			for _, f := range []string{failedLoginCountKey, lockExpirationKey, isLockedKey, isTotpEnrolledKey} {
+				if handlers.MaskContains(req.GetUpdateMask().GetPaths(), f) {
+					badFields[f] = "Field is read only."
+				}
+			}
 		case oidc.Subtype:
 			if req.GetItem().GetType() != "" && req.GetItem().GetType() != oidc.Subtype.String() {
 				badFields[typeField] = "Cannot modify the resource type."
@@ -1047,9 +1482,22 @@ func validateUpdateRequest(req *pbs.UpdateAccountRequest) error {
 			if handlers.MaskContains(req.GetUpdateMask().GetPaths(), nameClaimField) {
 				badFields[nameClaimField] = "Field is read only."
 			}
+		case cert.Subtype:
+			if req.GetItem().GetType() != "" && req.GetItem().GetType() != cert.Subtype.String() {
+				badFields[typeField] = "Cannot modify the resource type."
+			}
+			attrs := &pb.CertAccountAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+				badFields[attributesField] = "Attribute fields do not match the expected format."
+			}
+			for _, f := range []string{subjectField, emailClaimField, nameClaimField} {
+				if handlers.MaskContains(req.GetUpdateMask().GetPaths(), f) {
+					badFields[f] = "Field is read only."
+				}
+			}
 		}
 		return badFields
-	}, intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix, oidc.AccountPrefix)
+	}, intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix, oidc.AccountPrefix, cert.AccountPrefix)
 }
 
 func validateDeleteRequest(req *pbs.DeleteAccountRequest) error {
@@ -1057,7 +1505,7 @@ func validateDeleteRequest(req *pbs.DeleteAccountRequest) error {
 	if req == nil {
 		return errors.NewDeprecated(errors.InvalidParameter, op, "nil request")
 	}
-	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix, oidc.AccountPrefix)
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix, oidc.AccountPrefix, cert.AccountPrefix)
 }
 
 func validateListRequest(req *pbs.ListAccountsRequest) error {
@@ -1066,7 +1514,7 @@ func validateListRequest(req *pbs.ListAccountsRequest) error {
 		return errors.NewDeprecated(errors.InvalidParameter, op, "nil request")
 	}
 	badFields := map[string]string{}
-	if !handlers.ValidId(handlers.Id(req.GetAuthMethodId()), password.AuthMethodPrefix, oidc.AuthMethodPrefix) {
+	if !handlers.ValidId(handlers.Id(req.GetAuthMethodId()), password.AuthMethodPrefix, oidc.AuthMethodPrefix, cert.AuthMethodPrefix) {
 		badFields[authMethodIdField] = "Invalid formatted identifier."
 	}
 	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
@@ -1119,3 +1567,29 @@ func validateSetPasswordRequest(req *pbs.SetPasswordRequest) error {
 	}
 	return nil
 }
+
+type totpRequest interface {
+	GetId() string
+}
+
+func validateRevokeAccountTokensRequest(req *pbs.RevokeAccountTokensRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix, oidc.AccountPrefix, cert.AccountPrefix) {
+		badFields[idField] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateTotpRequest(req totpRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix) {
+		badFields[idField] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
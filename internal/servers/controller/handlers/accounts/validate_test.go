@@ -88,6 +88,42 @@ func TestValidateCreateRequest(t *testing.T) {
 			},
 			errContains: fieldError(loginNameKey, "This is a required field for this type."),
 		},
+		{
+			name: "read only is locked field",
+			item: &pb.Account{
+				Type:         password.Subtype.String(),
+				AuthMethodId: password.AuthMethodPrefix + "_1234567890",
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					loginNameKey: structpb.NewStringValue("something"),
+					"is_locked":  structpb.NewBoolValue(true),
+				}},
+			},
+			errContains: fieldError(isLockedKey, "This is a read only field."),
+		},
+		{
+			name: "read only is totp enrolled field",
+			item: &pb.Account{
+				Type:         password.Subtype.String(),
+				AuthMethodId: password.AuthMethodPrefix + "_1234567890",
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					loginNameKey:       structpb.NewStringValue("something"),
+					"is_totp_enrolled": structpb.NewBoolValue(true),
+				}},
+			},
+			errContains: fieldError(isTotpEnrolledKey, "This is a read only field."),
+		},
+		{
+			name: "read only failed login count field",
+			item: &pb.Account{
+				Type:         password.Subtype.String(),
+				AuthMethodId: password.AuthMethodPrefix + "_1234567890",
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					loginNameKey:         structpb.NewStringValue("something"),
+					"failed_login_count": structpb.NewNumberValue(3),
+				}},
+			},
+			errContains: fieldError(failedLoginCountKey, "This is a read only field."),
+		},
 		{
 			name: "bad pw attributes",
 			item: &pb.Account{
@@ -278,4 +314,65 @@ func TestValidateUpdateRequest(t *testing.T) {
 				"%q wasn't contained in %q", expected, err.Error())
 		}
 	})
+	t.Run("password read only fields", func(t *testing.T) {
+		t.Parallel()
+		readOnlyFields := []string{
+			failedLoginCountKey,
+			lockExpirationKey,
+			isLockedKey,
+			isTotpEnrolledKey,
+		}
+		err := validateUpdateRequest(&pbs.UpdateAccountRequest{
+			Id:         intglobals.NewPasswordAccountPrefix + "_1234567890",
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: readOnlyFields},
+		})
+
+		for _, f := range readOnlyFields {
+			expected := fieldError(f, "Field is read only.")
+			assert.True(t, strings.Contains(err.Error(), expected),
+				"%q wasn't contained in %q", expected, err.Error())
+		}
+	})
+}
+
+func TestValidateTotpRequest(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		req         totpRequest
+		errContains string
+	}{
+		{
+			name:        "oidc account",
+			req:         &pbs.EnrollTotpRequest{Id: oidc.AccountPrefix + "_1234567890"},
+			errContains: fieldError(idField, "Improperly formatted identifier."),
+		},
+		{
+			name:        "missing id",
+			req:         &pbs.RemoveTotpRequest{},
+			errContains: fieldError(idField, "Improperly formatted identifier."),
+		},
+		{
+			name: "old prefix",
+			req:  &pbs.EnrollTotpRequest{Id: intglobals.OldPasswordAccountPrefix + "_1234567890"},
+		},
+		{
+			name: "new prefix",
+			req:  &pbs.RemoveTotpRequest{Id: intglobals.NewPasswordAccountPrefix + "_1234567890"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateTotpRequest(tc.req)
+			if tc.errContains == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, strings.Contains(err.Error(), tc.errContains),
+				"%q wasn't contained in %q", tc.errContains, err.Error())
+		})
+	}
 }
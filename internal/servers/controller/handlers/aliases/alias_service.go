@@ -0,0 +1,550 @@
+package aliases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/alias"
+	"github.com/hashicorp/boundary/internal/alias/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/host/plugin"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/common/scopeids"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/aliases"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	maskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.NoOp,
+		action.Read,
+		action.Update,
+		action.Delete,
+	}
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.ActionSet{
+		action.Create,
+		action.List,
+	}
+)
+
+func init() {
+	var err error
+	if maskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&store.Alias{}}, handlers.MaskSource{&pb.Alias{}}); err != nil {
+		panic(err)
+	}
+}
+
+// Service handles request as described by the pbs.AliasServiceServer interface.
+type Service struct {
+	pbs.UnimplementedAliasServiceServer
+
+	repoFn    common.AliasRepoFactory
+	iamRepoFn common.IamRepoFactory
+}
+
+// NewService returns an alias service which handles alias related requests
+// to boundary.
+func NewService(repoFn common.AliasRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+	const op = "aliases.NewService"
+	if repoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing alias repository")
+	}
+	if iamRepoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
+	}
+	return Service{repoFn: repoFn, iamRepoFn: iamRepoFn}, nil
+}
+
+var _ pbs.AliasServiceServer = Service{}
+
+// ListAliases implements the interface pbs.AliasServiceServer.
+func (s Service) ListAliases(ctx context.Context, req *pbs.ListAliasesRequest) (*pbs.ListAliasesResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		// If it's forbidden, and it's a recursive request, and they're
+		// successfully authenticated but just not authorized, keep going as we
+		// may have authorization on downstream scopes. Or, if they've not
+		// authenticated, still process in case u_anon has permissions.
+		if (authResults.Error == handlers.ForbiddenError() || authResults.Error == handlers.UnauthenticatedError()) &&
+			req.GetRecursive() &&
+			authResults.AuthenticationFinished {
+		} else {
+			return nil, authResults.Error
+		}
+	}
+
+	scopeIds, scopeInfoMap, err := scopeids.GetListingScopeIds(
+		ctx, s.iamRepoFn, authResults, req.GetScopeId(), resource.Alias, req.GetRecursive(), false)
+	if err != nil {
+		return nil, err
+	}
+	// If no scopes match, return an empty response
+	if len(scopeIds) == 0 {
+		return &pbs.ListAliasesResponse{}, nil
+	}
+
+	al, err := s.listFromRepo(ctx, scopeIds)
+	if err != nil {
+		return nil, err
+	}
+	if len(al) == 0 {
+		return &pbs.ListAliasesResponse{}, nil
+	}
+
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.Alias, 0, len(al))
+	res := perms.Resource{
+		Type: resource.Alias,
+	}
+	for _, item := range al {
+		res.Id = item.GetPublicId()
+		res.ScopeId = item.GetScopeId()
+		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+		if len(authorizedActions) == 0 {
+			continue
+		}
+
+		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+		outputOpts := make([]handlers.Option, 0, 3)
+		outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+		if outputFields.Has(globals.ScopeField) {
+			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetScopeId()]))
+		}
+		if outputFields.Has(globals.AuthorizedActionsField) {
+			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+		}
+
+		item, err := toProto(ctx, item, outputOpts...)
+		if err != nil {
+			return nil, err
+		}
+
+		if filter.Match(item) {
+			finalItems = append(finalItems, item)
+		}
+	}
+	return &pbs.ListAliasesResponse{Items: finalItems}, nil
+}
+
+// GetAlias implements the interface pbs.AliasServiceServer.
+func (s Service) GetAlias(ctx context.Context, req *pbs.GetAliasRequest) (*pbs.GetAliasResponse, error) {
+	const op = "aliases.(Service).GetAlias"
+
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	a, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputOpts, err := s.outputOpts(ctx, op, &authResults, a.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	item, err := toProto(ctx, a, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.GetAliasResponse{Item: item}, nil
+}
+
+// CreateAlias implements the interface pbs.AliasServiceServer.
+func (s Service) CreateAlias(ctx context.Context, req *pbs.CreateAliasRequest) (*pbs.CreateAliasResponse, error) {
+	const op = "aliases.(Service).CreateAlias"
+
+	if err := validateCreateRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetScopeId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	a, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputOpts, err := s.outputOpts(ctx, op, &authResults, a.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	item, err := toProto(ctx, a, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.CreateAliasResponse{Item: item, Uri: fmt.Sprintf("aliases/%s", item.GetId())}, nil
+}
+
+// UpdateAlias implements the interface pbs.AliasServiceServer.
+func (s Service) UpdateAlias(ctx context.Context, req *pbs.UpdateAliasRequest) (*pbs.UpdateAliasResponse, error) {
+	const op = "aliases.(Service).UpdateAlias"
+
+	if err := validateUpdateRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Update)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	a, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputOpts, err := s.outputOpts(ctx, op, &authResults, a.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	item, err := toProto(ctx, a, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.UpdateAliasResponse{Item: item}, nil
+}
+
+// DeleteAlias implements the interface pbs.AliasServiceServer.
+func (s Service) DeleteAlias(ctx context.Context, req *pbs.DeleteAliasRequest) (*pbs.DeleteAliasResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	_, err := s.deleteFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s Service) outputOpts(ctx context.Context, op errors.Op, authResults *auth.VerifyResults, id string) ([]handlers.Option, error) {
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, id, IdActions).Strings()))
+	}
+	return outputOpts, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*alias.Alias, error) {
+	const op = "aliases.(Service).getFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	a, err := repo.LookupAlias(ctx, id)
+	if err != nil && !errors.IsNotFoundError(err) {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if a == nil {
+		return nil, handlers.NotFoundErrorf("Alias %q doesn't exist.", id)
+	}
+	return a, nil
+}
+
+func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Alias) (*alias.Alias, error) {
+	const op = "aliases.(Service).createInRepo"
+	var opts []alias.Option
+	if item.GetName() != nil {
+		opts = append(opts, alias.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, alias.WithDescription(item.GetDescription().GetValue()))
+	}
+	if item.GetHostId() != nil {
+		opts = append(opts, alias.WithHostId(item.GetHostId().GetValue()))
+	}
+	a, err := alias.NewAlias(ctx, scopeId, item.GetValue().GetValue(), item.GetDestinationId().GetValue(), opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build alias for creation: %v.", err)
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, err := repo.CreateAlias(ctx, a)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create alias"))
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create alias but no error returned from repository.")
+	}
+	return out, nil
+}
+
+func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Alias) (*alias.Alias, error) {
+	const op = "aliases.(Service).updateInRepo"
+	a := &alias.Alias{
+		Alias: &store.Alias{
+			PublicId:      id,
+			ScopeId:       scopeId,
+			Name:          item.GetName().GetValue(),
+			Description:   item.GetDescription().GetValue(),
+			Value:         item.GetValue().GetValue(),
+			DestinationId: item.GetDestinationId().GetValue(),
+			HostId:        item.GetHostId().GetValue(),
+		},
+	}
+	version := item.GetVersion()
+	dbMask := maskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, rowsUpdated, err := repo.UpdateAlias(ctx, a, version, dbMask)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update alias"))
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Alias %q doesn't exist or incorrect version provided.", id)
+	}
+	return out, nil
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
+	const op = "aliases.(Service).deleteFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return false, err
+	}
+	rows, err := repo.DeleteAlias(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete alias"))
+	}
+	return rows > 0, nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*alias.Alias, error) {
+	const op = "aliases.(Service).listFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	al, err := repo.ListAliases(ctx, scopeIds)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return al, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.Alias), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+		iamRepo, err := s.iamRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		scp, err := iamRepo.LookupScope(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if scp == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	default:
+		repo, err := s.repoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		al, err := repo.LookupAlias(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if al == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = al.GetScopeId()
+		opts = append(opts, auth.WithId(id))
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(ctx context.Context, in *alias.Alias, opt ...handlers.Option) (*pb.Alias, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building alias proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.Alias{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has(globals.ScopeIdField) {
+		out.ScopeId = in.GetScopeId()
+	}
+	if outputFields.Has(globals.DescriptionField) && in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if outputFields.Has(globals.NameField) && in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	if outputFields.Has(globals.CreatedTimeField) {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.UpdatedTimeField) {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.VersionField) {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has(globals.ValueField) {
+		out.Value = wrapperspb.String(in.GetValue())
+	}
+	if outputFields.Has(globals.DestinationIdField) {
+		out.DestinationId = wrapperspb.String(in.GetDestinationId())
+	}
+	if outputFields.Has(globals.HostIdField) && in.GetHostId() != "" {
+		out.HostId = wrapperspb.String(in.GetHostId())
+	}
+	if outputFields.Has(globals.ScopeField) {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	return &out, nil
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//  * The path passed in is correctly formatted
+//  * All required parameters are set
+//  * There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetAliasRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, alias.AliasPrefix)
+}
+
+func validateCreateRequest(ctx context.Context, req *pbs.CreateAliasRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		item := req.GetItem()
+		if !validScopeId(item.GetScopeId()) {
+			badFields[globals.ScopeIdField] = "This field must be 'global' or a valid org or project scope id."
+		}
+		if item.GetValue() == nil {
+			badFields[globals.ValueField] = "This field is required."
+		}
+		if item.GetDestinationId() == nil {
+			badFields[globals.DestinationIdField] = "This field is required."
+		}
+		validateFields(ctx, item, badFields)
+		return badFields
+	})
+}
+
+func validateUpdateRequest(ctx context.Context, req *pbs.UpdateAliasRequest) error {
+	return handlers.ValidateUpdateRequest(req, req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		item := req.GetItem()
+		if handlers.MaskContains(req.GetUpdateMask().GetPaths(), globals.ValueField) && item.GetValue() == nil {
+			badFields[globals.ValueField] = "This field cannot be unset."
+		}
+		if handlers.MaskContains(req.GetUpdateMask().GetPaths(), globals.DestinationIdField) && item.GetDestinationId() == nil {
+			badFields[globals.DestinationIdField] = "This field cannot be unset."
+		}
+		validateFields(ctx, item, badFields)
+		return badFields
+	}, alias.AliasPrefix)
+}
+
+// validateFields adds an entry to badFields for each of the value,
+// destination id and host id of item which is set but not valid.
+func validateFields(ctx context.Context, item *pb.Alias, badFields map[string]string) {
+	if item.GetValue() != nil {
+		if err := alias.ValidateValue(ctx, item.GetValue().GetValue()); err != nil {
+			badFields[globals.ValueField] = "Must be a DNS-style name of dot separated labels of lowercase letters, digits and hyphens."
+		}
+	}
+	if item.GetDestinationId() != nil && !handlers.ValidId(handlers.Id(item.GetDestinationId().GetValue()), target.Prefixes()...) {
+		badFields[globals.DestinationIdField] = "Incorrectly formatted identifier."
+	}
+	if item.GetHostId() != nil && !handlers.ValidId(handlers.Id(item.GetHostId().GetValue()), static.HostPrefix, plugin.HostPrefix) {
+		badFields[globals.HostIdField] = "Incorrectly formatted identifier."
+	}
+}
+
+func validateDeleteRequest(req *pbs.DeleteAliasRequest) error {
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, alias.AliasPrefix)
+}
+
+func validateListRequest(req *pbs.ListAliasesRequest) error {
+	badFields := map[string]string{}
+	if !validScopeId(req.GetScopeId()) {
+		badFields[globals.ScopeIdField] = "Incorrectly formatted identifier."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
+
+// validScopeId reports whether id can own aliases, which can live in any
+// scope.
+func validScopeId(id string) bool {
+	return id == scope.Global.String() ||
+		handlers.ValidId(handlers.Id(id), scope.Org.Prefix(), scope.Project.Prefix())
+}
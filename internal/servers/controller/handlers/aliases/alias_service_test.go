@@ -0,0 +1,475 @@
+package aliases_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/alias"
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/aliases"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/aliases"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete"}
+
+type testEnv struct {
+	conn      *db.DB
+	iamRepoFn func() (*iam.Repository, error)
+	service   aliases.Service
+	org       *iam.Scope
+	proj      *iam.Scope
+	targetId  string
+	hostId    string
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*alias.Repository, error) {
+		return alias.NewRepository(rw, rw, kms)
+	}
+	s, err := aliases.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	org, proj := iam.TestScopes(t, iamRepo)
+	tar := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "test target")
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+	return &testEnv{
+		conn:      conn,
+		iamRepoFn: iamRepoFn,
+		service:   s,
+		org:       org,
+		proj:      proj,
+		targetId:  tar.GetPublicId(),
+		hostId:    h.GetPublicId(),
+	}
+}
+
+func (e *testEnv) scopeInfo(scopeId string) *scopes.ScopeInfo {
+	switch scopeId {
+	case scope.Global.String():
+		return &scopes.ScopeInfo{Id: scope.Global.String(), Type: scope.Global.String(), Name: scope.Global.String(), Description: "Global Scope"}
+	case e.org.GetPublicId():
+		return &scopes.ScopeInfo{Id: e.org.GetPublicId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()}
+	default:
+		return &scopes.ScopeInfo{Id: e.proj.GetPublicId(), Type: scope.Project.String(), ParentScopeId: e.org.GetPublicId()}
+	}
+}
+
+func TestNewService(t *testing.T) {
+	_, err := aliases.NewService(nil, nil)
+	assert.Error(t, err)
+}
+
+func TestGet(t *testing.T) {
+	e := newTestEnv(t)
+	a := alias.TestAlias(t, e.conn, e.org.GetPublicId(), "db.prod", e.targetId,
+		alias.WithName("name"), alias.WithDescription("desc"), alias.WithHostId(e.hostId))
+
+	cases := []struct {
+		name string
+		req  *pbs.GetAliasRequest
+		res  *pbs.GetAliasResponse
+		err  error
+	}{
+		{
+			name: "Get an existing alias",
+			req:  &pbs.GetAliasRequest{Id: a.GetPublicId()},
+			res: &pbs.GetAliasResponse{Item: &pb.Alias{
+				Id:                a.GetPublicId(),
+				ScopeId:           e.org.GetPublicId(),
+				Scope:             e.scopeInfo(e.org.GetPublicId()),
+				Name:              wrapperspb.String("name"),
+				Description:       wrapperspb.String("desc"),
+				CreatedTime:       a.GetCreateTime().GetTimestamp(),
+				UpdatedTime:       a.GetUpdateTime().GetTimestamp(),
+				Version:           1,
+				Value:             wrapperspb.String("db.prod"),
+				DestinationId:     wrapperspb.String(e.targetId),
+				HostId:            wrapperspb.String(e.hostId),
+				AuthorizedActions: testAuthorizedActions,
+			}},
+		},
+		{
+			name: "Get a non existing alias",
+			req:  &pbs.GetAliasRequest{Id: alias.AliasPrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Wrong id prefix",
+			req:  &pbs.GetAliasRequest{Id: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := e.service.GetAlias(auth.DisabledAuthTestContext(e.iamRepoFn, e.org.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "GetAlias(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()))
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	e := newTestEnv(t)
+	orgAlias := alias.TestAlias(t, e.conn, e.org.GetPublicId(), "org.alias", e.targetId)
+	projAlias := alias.TestAlias(t, e.conn, e.proj.GetPublicId(), "proj.alias", e.targetId)
+
+	cases := []struct {
+		name    string
+		req     *pbs.ListAliasesRequest
+		wantIds []string
+		err     error
+	}{
+		{
+			name:    "List org aliases",
+			req:     &pbs.ListAliasesRequest{ScopeId: e.org.GetPublicId()},
+			wantIds: []string{orgAlias.GetPublicId()},
+		},
+		{
+			name:    "List recursively",
+			req:     &pbs.ListAliasesRequest{ScopeId: scope.Global.String(), Recursive: true},
+			wantIds: []string{orgAlias.GetPublicId(), projAlias.GetPublicId()},
+		},
+		{
+			name:    "Filter aliases",
+			req:     &pbs.ListAliasesRequest{ScopeId: scope.Global.String(), Recursive: true, Filter: `"/item/value"=="proj.alias"`},
+			wantIds: []string{projAlias.GetPublicId()},
+		},
+		{
+			name: "Empty global scope",
+			req:  &pbs.ListAliasesRequest{ScopeId: scope.Global.String()},
+		},
+		{
+			name: "Invalid filter",
+			req:  &pbs.ListAliasesRequest{ScopeId: e.org.GetPublicId(), Filter: `"/item/value"=="proj.alias`},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Invalid scope",
+			req:  &pbs.ListAliasesRequest{ScopeId: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := e.service.ListAliases(auth.DisabledAuthTestContext(e.iamRepoFn, tc.req.GetScopeId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "ListAliases(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			var gotIds []string
+			for _, item := range got.GetItems() {
+				gotIds = append(gotIds, item.GetId())
+			}
+			assert.ElementsMatch(tc.wantIds, gotIds)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	e := newTestEnv(t)
+	existing := alias.TestAlias(t, e.conn, e.org.GetPublicId(), "taken.alias", e.targetId)
+
+	cases := []struct {
+		name string
+		req  *pbs.CreateAliasRequest
+		res  *pbs.CreateAliasResponse
+		err  error
+	}{
+		{
+			name: "Create a valid alias",
+			req: &pbs.CreateAliasRequest{Item: &pb.Alias{
+				ScopeId:       e.proj.GetPublicId(),
+				Name:          wrapperspb.String("name"),
+				Description:   wrapperspb.String("desc"),
+				Value:         wrapperspb.String("web.prod"),
+				DestinationId: wrapperspb.String(e.targetId),
+				HostId:        wrapperspb.String(e.hostId),
+			}},
+			res: &pbs.CreateAliasResponse{
+				Uri: fmt.Sprintf("aliases/%s_", alias.AliasPrefix),
+				Item: &pb.Alias{
+					ScopeId:           e.proj.GetPublicId(),
+					Scope:             e.scopeInfo(e.proj.GetPublicId()),
+					Name:              wrapperspb.String("name"),
+					Description:       wrapperspb.String("desc"),
+					Version:           1,
+					Value:             wrapperspb.String("web.prod"),
+					DestinationId:     wrapperspb.String(e.targetId),
+					HostId:            wrapperspb.String(e.hostId),
+					AuthorizedActions: testAuthorizedActions,
+				},
+			},
+		},
+		{
+			name: "Create a global alias",
+			req: &pbs.CreateAliasRequest{Item: &pb.Alias{
+				ScopeId:       scope.Global.String(),
+				Value:         wrapperspb.String("global.alias"),
+				DestinationId: wrapperspb.String(e.targetId),
+			}},
+			res: &pbs.CreateAliasResponse{
+				Uri: fmt.Sprintf("aliases/%s_", alias.AliasPrefix),
+				Item: &pb.Alias{
+					ScopeId:           scope.Global.String(),
+					Scope:             e.scopeInfo(scope.Global.String()),
+					Version:           1,
+					Value:             wrapperspb.String("global.alias"),
+					DestinationId:     wrapperspb.String(e.targetId),
+					AuthorizedActions: testAuthorizedActions,
+				},
+			},
+		},
+		{
+			name: "Value is required",
+			req: &pbs.CreateAliasRequest{Item: &pb.Alias{
+				ScopeId:       e.proj.GetPublicId(),
+				DestinationId: wrapperspb.String(e.targetId),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Value must be DNS-style",
+			req: &pbs.CreateAliasRequest{Item: &pb.Alias{
+				ScopeId:       e.proj.GetPublicId(),
+				Value:         wrapperspb.String("Not_A.Name"),
+				DestinationId: wrapperspb.String(e.targetId),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Destination is required",
+			req: &pbs.CreateAliasRequest{Item: &pb.Alias{
+				ScopeId: e.proj.GetPublicId(),
+				Value:   wrapperspb.String("no.destination"),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Destination must be a target",
+			req: &pbs.CreateAliasRequest{Item: &pb.Alias{
+				ScopeId:       e.proj.GetPublicId(),
+				Value:         wrapperspb.String("bad.destination"),
+				DestinationId: wrapperspb.String(e.hostId),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Host must be a host",
+			req: &pbs.CreateAliasRequest{Item: &pb.Alias{
+				ScopeId:       e.proj.GetPublicId(),
+				Value:         wrapperspb.String("bad.host"),
+				DestinationId: wrapperspb.String(e.targetId),
+				HostId:        wrapperspb.String(e.targetId),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't specify id",
+			req: &pbs.CreateAliasRequest{Item: &pb.Alias{
+				Id:            alias.AliasPrefix + "_notallowed",
+				ScopeId:       e.proj.GetPublicId(),
+				Value:         wrapperspb.String("with.id"),
+				DestinationId: wrapperspb.String(e.targetId),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := e.service.CreateAlias(auth.DisabledAuthTestContext(e.iamRepoFn, tc.req.GetItem().GetScopeId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "CreateAlias(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Contains(got.GetUri(), tc.res.Uri)
+			assert.True(strings.HasPrefix(got.GetItem().GetId(), alias.AliasPrefix+"_"))
+			assert.NotNil(got.GetItem().GetCreatedTime())
+			assert.NotNil(got.GetItem().GetUpdatedTime())
+
+			// Clear all values which are hard to compare against.
+			got.Uri, tc.res.Uri = "", ""
+			got.Item.Id = ""
+			got.Item.CreatedTime, got.Item.UpdatedTime = nil, nil
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()))
+		})
+	}
+
+	t.Run("Value must be unique", func(t *testing.T) {
+		req := &pbs.CreateAliasRequest{Item: &pb.Alias{
+			ScopeId:       e.proj.GetPublicId(),
+			Value:         wrapperspb.String(existing.GetValue()),
+			DestinationId: wrapperspb.String(e.targetId),
+		}}
+		_, err := e.service.CreateAlias(auth.DisabledAuthTestContext(e.iamRepoFn, e.proj.GetPublicId()), req)
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	e := newTestEnv(t)
+	ctx := auth.DisabledAuthTestContext(e.iamRepoFn, e.org.GetPublicId())
+
+	cases := []struct {
+		name string
+		req  func(a *alias.Alias) *pbs.UpdateAliasRequest
+		want func(a *alias.Alias) *pb.Alias
+		err  error
+	}{
+		{
+			name: "Update the value and name",
+			req: func(a *alias.Alias) *pbs.UpdateAliasRequest {
+				return &pbs.UpdateAliasRequest{
+					Id:         a.GetPublicId(),
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"name", "value"}},
+					Item: &pb.Alias{
+						Version: a.GetVersion(),
+						Name:    wrapperspb.String("new name"),
+						Value:   wrapperspb.String("updated.alias"),
+					},
+				}
+			},
+			want: func(a *alias.Alias) *pb.Alias {
+				return &pb.Alias{
+					Id:                a.GetPublicId(),
+					ScopeId:           e.org.GetPublicId(),
+					Scope:             e.scopeInfo(e.org.GetPublicId()),
+					Name:              wrapperspb.String("new name"),
+					Version:           2,
+					Value:             wrapperspb.String("updated.alias"),
+					DestinationId:     wrapperspb.String(e.targetId),
+					HostId:            wrapperspb.String(e.hostId),
+					AuthorizedActions: testAuthorizedActions,
+				}
+			},
+		},
+		{
+			name: "Unset the host",
+			req: func(a *alias.Alias) *pbs.UpdateAliasRequest {
+				return &pbs.UpdateAliasRequest{
+					Id:         a.GetPublicId(),
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"host_id"}},
+					Item:       &pb.Alias{Version: a.GetVersion()},
+				}
+			},
+			want: func(a *alias.Alias) *pb.Alias {
+				return &pb.Alias{
+					Id:                a.GetPublicId(),
+					ScopeId:           e.org.GetPublicId(),
+					Scope:             e.scopeInfo(e.org.GetPublicId()),
+					Version:           2,
+					Value:             wrapperspb.String(a.GetValue()),
+					DestinationId:     wrapperspb.String(e.targetId),
+					AuthorizedActions: testAuthorizedActions,
+				}
+			},
+		},
+		{
+			name: "Can't unset the value",
+			req: func(a *alias.Alias) *pbs.UpdateAliasRequest {
+				return &pbs.UpdateAliasRequest{
+					Id:         a.GetPublicId(),
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"value"}},
+					Item:       &pb.Alias{Version: a.GetVersion()},
+				}
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "No update mask",
+			req: func(a *alias.Alias) *pbs.UpdateAliasRequest {
+				return &pbs.UpdateAliasRequest{
+					Id:   a.GetPublicId(),
+					Item: &pb.Alias{Version: a.GetVersion(), Name: wrapperspb.String("name")},
+				}
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Wrong version",
+			req: func(a *alias.Alias) *pbs.UpdateAliasRequest {
+				return &pbs.UpdateAliasRequest{
+					Id:         a.GetPublicId(),
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}},
+					Item:       &pb.Alias{Version: a.GetVersion() + 1, Name: wrapperspb.String("name")},
+				}
+			},
+			err: handlers.ApiErrorWithCode(codes.NotFound),
+		},
+	}
+	for i, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			a := alias.TestAlias(t, e.conn, e.org.GetPublicId(), fmt.Sprintf("alias%d.update", i), e.targetId, alias.WithHostId(e.hostId))
+			req := tc.req(a)
+			got, gErr := e.service.UpdateAlias(ctx, req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "UpdateAlias(%+v) got error %v, wanted %v", req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.True(got.GetItem().GetUpdatedTime().AsTime().After(a.GetUpdateTime().GetTimestamp().AsTime()))
+			got.Item.CreatedTime, got.Item.UpdatedTime = nil, nil
+			assert.Empty(cmp.Diff(got.GetItem(), tc.want(a), protocmp.Transform()))
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	e := newTestEnv(t)
+	ctx := auth.DisabledAuthTestContext(e.iamRepoFn, e.org.GetPublicId())
+	a := alias.TestAlias(t, e.conn, e.org.GetPublicId(), "deleted.alias", e.targetId)
+
+	_, err := e.service.DeleteAlias(ctx, &pbs.DeleteAliasRequest{Id: a.GetPublicId()})
+	require.NoError(t, err)
+
+	_, err = e.service.DeleteAlias(ctx, &pbs.DeleteAliasRequest{Id: a.GetPublicId()})
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "Got %v, wanted not found", err)
+	_, err = e.service.GetAlias(ctx, &pbs.GetAliasRequest{Id: a.GetPublicId()})
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "Got %v, wanted not found", err)
+
+	_, err = e.service.DeleteAlias(ctx, &pbs.DeleteAliasRequest{Id: "j_1234567890"})
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Got %v, wanted invalid argument", err)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/auth/cert"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/authtoken"
@@ -16,6 +17,7 @@ import (
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/scim"
 	requestauth "github.com/hashicorp/boundary/internal/servers/controller/auth"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/common/scopeids"
@@ -23,6 +25,7 @@ import (
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/accounts"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authtokens"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/managed_groups"
+	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
@@ -73,12 +76,20 @@ type Service struct {
 	kms        *kms.Kms
 	pwRepoFn   common.PasswordAuthRepoFactory
 	oidcRepoFn common.OidcAuthRepoFactory
+	certRepoFn common.CertAuthRepoFactory
 	iamRepoFn  common.IamRepoFactory
 	atRepoFn   common.AuthTokenRepoFactory
+
+	// sessionRepoFn and terminations are only used to cancel sessions when
+	// revoking the auth tokens of an auth method.
+	sessionRepoFn common.SessionRepoFactory
+	terminations  *session.TerminationBroadcaster
+
+	scimRepoFn common.ScimRepoFactory
 }
 
 // NewService returns a auth method service which handles auth method related requests to boundary.
-func NewService(kms *kms.Kms, pwRepoFn common.PasswordAuthRepoFactory, oidcRepoFn common.OidcAuthRepoFactory, iamRepoFn common.IamRepoFactory, atRepoFn common.AuthTokenRepoFactory, opt ...handlers.Option) (Service, error) {
+func NewService(kms *kms.Kms, pwRepoFn common.PasswordAuthRepoFactory, oidcRepoFn common.OidcAuthRepoFactory, certRepoFn common.CertAuthRepoFactory, iamRepoFn common.IamRepoFactory, atRepoFn common.AuthTokenRepoFactory, sessionRepoFn common.SessionRepoFactory, terminations *session.TerminationBroadcaster, scimRepoFn common.ScimRepoFactory, opt ...handlers.Option) (Service, error) {
 	const op = "authmethods.NewService"
 	if kms == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing kms")
@@ -89,13 +100,26 @@ func NewService(kms *kms.Kms, pwRepoFn common.PasswordAuthRepoFactory, oidcRepoF
 	if oidcRepoFn == nil {
 		return Service{}, fmt.Errorf("nil oidc repository provided")
 	}
+	if certRepoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing cert repository")
+	}
 	if iamRepoFn == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
 	}
 	if atRepoFn == nil {
 		return Service{}, fmt.Errorf("nil auth token repository provided")
 	}
-	s := Service{kms: kms, pwRepoFn: pwRepoFn, oidcRepoFn: oidcRepoFn, iamRepoFn: iamRepoFn, atRepoFn: atRepoFn}
+	s := Service{
+		kms:           kms,
+		pwRepoFn:      pwRepoFn,
+		oidcRepoFn:    oidcRepoFn,
+		certRepoFn:    certRepoFn,
+		iamRepoFn:     iamRepoFn,
+		atRepoFn:      atRepoFn,
+		sessionRepoFn: sessionRepoFn,
+		terminations:  terminations,
+		scimRepoFn:    scimRepoFn,
+	}
 
 	return s, nil
 }
@@ -328,6 +352,74 @@ func (s Service) UpdateAuthMethod(ctx context.Context, req *pbs.UpdateAuthMethod
 	return &pbs.UpdateAuthMethodResponse{Item: item}, nil
 }
 
+// RevokeAuthMethodTokens implements the interface pbs.AuthMethodServiceServer.
+func (s Service) RevokeAuthMethodTokens(ctx context.Context, req *pbs.RevokeAuthMethodTokensRequest) (*pbs.RevokeAuthMethodTokensResponse, error) {
+	if err := validateRevokeAuthMethodTokensRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RevokeTokens)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	count, sessionIds, err := authtokens.Revoke(ctx, s.atRepoFn, s.sessionRepoFn, s.terminations, req.GetCancelSessions(),
+		func(ctx context.Context, repo *authtoken.Repository) ([]*authtoken.AuthToken, error) {
+			return repo.RevokeAuthTokensForAuthMethod(ctx, req.GetId())
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.RevokeAuthMethodTokensResponse{RevokedTokenCount: count, CanceledSessionIds: sessionIds}, nil
+}
+
+// RotateScimToken implements the interface pbs.AuthMethodServiceServer.
+func (s Service) RotateScimToken(ctx context.Context, req *pbs.RotateScimTokenRequest) (*pbs.RotateScimTokenResponse, error) {
+	if err := validateScimTokenRequest(req.GetId()); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RotateScimToken)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	if s.scimRepoFn == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unimplemented, "SCIM provisioning is not available.")
+	}
+	repo, err := s.scimRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	token, err := repo.RotateToken(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.RotateScimTokenResponse{ScimToken: token, ScimPath: scim.BasePath}, nil
+}
+
+// DeleteScimToken implements the interface pbs.AuthMethodServiceServer.
+func (s Service) DeleteScimToken(ctx context.Context, req *pbs.DeleteScimTokenRequest) (*pbs.DeleteScimTokenResponse, error) {
+	if err := validateScimTokenRequest(req.GetId()); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.DeleteScimToken)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	if s.scimRepoFn == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unimplemented, "SCIM provisioning is not available.")
+	}
+	repo, err := s.scimRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := repo.DeleteToken(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, handlers.NotFoundErrorf("Auth Method %q has no SCIM token.", req.GetId())
+	}
+	return &pbs.DeleteScimTokenResponse{}, nil
+}
+
 // ChangeState implements the interface pbs.AuthMethodServiceServer.
 func (s Service) ChangeState(ctx context.Context, req *pbs.ChangeStateRequest) (*pbs.ChangeStateResponse, error) {
 	const op = "authmethods.(Service).ChangeState"
@@ -410,6 +502,10 @@ func (s Service) Authenticate(ctx context.Context, req *pbs.AuthenticateRequest)
 		if err := validateAuthenticateOidcRequest(req); err != nil {
 			return nil, err
 		}
+	case cert.Subtype:
+		if err := validateAuthenticateCertRequest(req); err != nil {
+			return nil, err
+		}
 	}
 
 	authResults := s.authResult(ctx, req.GetAuthMethodId(), action.Authenticate)
@@ -423,6 +519,9 @@ func (s Service) Authenticate(ctx context.Context, req *pbs.AuthenticateRequest)
 
 	case oidc.Subtype:
 		return s.authenticateOidc(ctx, req, &authResults)
+
+	case cert.Subtype:
+		return s.authenticateCert(ctx, req, &authResults)
 	}
 	return nil, errors.New(ctx, errors.Internal, op, "Invalid auth method subtype not caught in validation function.")
 }
@@ -445,6 +544,17 @@ func (s Service) getFromRepo(ctx context.Context, id string) (auth.AuthMethod, e
 		}
 		am, lookupErr = repo.LookupAuthMethod(ctx, id)
 
+	case cert.Subtype:
+		repo, err := s.certRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		cam, err := repo.LookupAuthMethod(ctx, id)
+		if cam != nil {
+			am = cam
+		}
+		lookupErr = err
+
 	default:
 		return nil, handlers.NotFoundErrorf("Unrecognized id.")
 	}
@@ -494,6 +604,18 @@ func (s Service) listFromRepo(ctx context.Context, scopeIds []string, authResult
 	for _, item := range pl {
 		outUl = append(outUl, item)
 	}
+
+	certRepo, err := s.certRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	cl, err := certRepo.ListAuthMethods(ctx, scopeIds)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	for _, item := range cl {
+		outUl = append(outUl, item)
+	}
 	return outUl, nil
 }
 
@@ -519,6 +641,15 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Auth
 			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create auth method but no error returned from repository.")
 		}
 		out = am
+	case cert.Subtype:
+		am, err := s.createCertInRepo(ctx, scopeId, item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if am == nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create auth method but no error returned from repository.")
+		}
+		out = am
 	}
 	return out, nil
 }
@@ -550,6 +681,16 @@ func (s Service) updateInRepo(ctx context.Context, scopeId string, req *pbs.Upda
 		}
 		am = oam
 		dryRun = dr
+
+	case cert.Subtype:
+		cam, err := s.updateCertInRepo(ctx, scopeId, req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+		if err != nil {
+			return nil, false, errors.Wrap(ctx, err, op)
+		}
+		if cam == nil {
+			return nil, false, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to update auth method but no error returned from repository.")
+		}
+		am = cam
 	}
 
 	return am, dryRun, nil
@@ -573,6 +714,13 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool,
 			return false, errors.Wrap(ctx, err, op)
 		}
 		rows, dErr = repo.DeleteAuthMethod(ctx, id)
+
+	case cert.Subtype:
+		repo, err := s.certRepoFn()
+		if err != nil {
+			return false, errors.Wrap(ctx, err, op)
+		}
+		rows, dErr = repo.DeleteAuthMethod(ctx, scopeId, id)
 	}
 
 	if dErr != nil {
@@ -684,6 +832,22 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) reque
 				return res
 			}
 			authMeth = am
+		case cert.Subtype:
+			repo, err := s.certRepoFn()
+			if err != nil {
+				res.Error = err
+				return res
+			}
+			am, err := repo.LookupAuthMethod(ctx, id)
+			if err != nil {
+				res.Error = err
+				return res
+			}
+			if am == nil {
+				res.Error = handlers.NotFoundError()
+				return res
+			}
+			authMeth = am
 		default:
 			res.Error = errors.New(ctx, errors.InvalidPublicId, op, "unrecognized auth method type")
 			return res
@@ -745,8 +909,19 @@ func toAuthMethodProto(ctx context.Context, in auth.AuthMethod, opt ...handlers.
 			break
 		}
 		st, err := handlers.ProtoToStruct(&pb.PasswordAuthMethodAttributes{
-			MinLoginNameLength: i.GetMinLoginNameLength(),
-			MinPasswordLength:  i.GetMinPasswordLength(),
+			MinLoginNameLength:       i.GetMinLoginNameLength(),
+			MinPasswordLength:        i.GetMinPasswordLength(),
+			PasswordRequireLowercase: i.GetPasswordRequireLowercase(),
+			PasswordRequireUppercase: i.GetPasswordRequireUppercase(),
+			PasswordRequireDigit:     i.GetPasswordRequireDigit(),
+			PasswordRequireSymbol:    i.GetPasswordRequireSymbol(),
+			DenyCommonPasswords:      i.GetDenyCommonPasswords(),
+			LockoutThreshold:         i.GetLockoutThreshold(),
+			LockoutWindowSeconds:     i.GetLockoutWindowSeconds(),
+			LockoutDurationSeconds:   i.GetLockoutDurationSeconds(),
+			PasswordHistoryCount:     i.GetPasswordHistoryCount(),
+			MaxPasswordAgeDays:       i.GetMaxPasswordAgeDays(),
+			TotpMfa:                  i.GetTotpMfa(),
 		})
 		if err != nil {
 			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building password attribute struct: %v", err)
@@ -792,6 +967,22 @@ func toAuthMethodProto(ctx context.Context, in auth.AuthMethod, opt ...handlers.
 			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building oidc attribute struct: %v", err)
 		}
 		out.Attributes = st
+	case *cert.AuthMethod:
+		if outputFields.Has(globals.TypeField) {
+			out.Type = cert.Subtype.String()
+		}
+		if !outputFields.Has(globals.AttributesField) {
+			break
+		}
+		attrs, err := toCertAuthMethodAttributes(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		st, err := handlers.ProtoToStruct(attrs)
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building cert attribute struct: %v", err)
+		}
+		out.Attributes = st
 	}
 	return &out, nil
 }
@@ -820,7 +1011,7 @@ func validateGetRequest(req *pbs.GetAuthMethodRequest) error {
 	if req == nil {
 		return errors.NewDeprecated(errors.InvalidParameter, op, "Missing request")
 	}
-	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, password.AuthMethodPrefix, oidc.AuthMethodPrefix)
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, password.AuthMethodPrefix, oidc.AuthMethodPrefix, cert.AuthMethodPrefix)
 }
 
 func validateCreateRequest(ctx context.Context, req *pbs.CreateAuthMethodRequest) error {
@@ -842,6 +1033,8 @@ func validateCreateRequest(ctx context.Context, req *pbs.CreateAuthMethodRequest
 			attrs := &pb.PasswordAuthMethodAttributes{}
 			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
 				badFields[attributesField] = "Attribute fields do not match the expected format."
+			} else {
+				validatePwAttributes(attrs, true, badFields)
 			}
 		case oidc.Subtype:
 			attrs := &pb.OidcAuthMethodAttributes{}
@@ -918,6 +1111,16 @@ func validateCreateRequest(ctx context.Context, req *pbs.CreateAuthMethodRequest
 					}
 				}
 			}
+		case cert.Subtype:
+			attrs := &pb.CertAuthMethodAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+				badFields[attributesField] = "Attribute fields do not match the expected format."
+			} else {
+				if len(attrs.GetCaCertificates()) == 0 {
+					badFields[caCertificatesField] = "Field required for creating a cert auth method."
+				}
+				validateCertAttributes(ctx, attrs, badFields)
+			}
 		default:
 			badFields[typeField] = fmt.Sprintf("This is a required field and must be %q.", password.Subtype.String())
 		}
@@ -943,6 +1146,11 @@ func validateUpdateRequest(ctx context.Context, req *pbs.UpdateAuthMethodRequest
 			attrs := &pb.PasswordAuthMethodAttributes{}
 			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
 				badFields[attributesField] = "Attribute fields do not match the expected format."
+			} else {
+				validatePwAttributes(attrs,
+					handlers.MaskContains(req.GetUpdateMask().GetPaths(), lockoutThresholdField) &&
+						handlers.MaskContains(req.GetUpdateMask().GetPaths(), lockoutDurationSecondsField),
+					badFields)
 			}
 		case oidc.Subtype:
 			if req.GetItem().GetType() != "" && auth.SubtypeFromType(req.GetItem().GetType()) != oidc.Subtype {
@@ -1049,11 +1257,24 @@ func validateUpdateRequest(ctx context.Context, req *pbs.UpdateAuthMethodRequest
 					}
 				}
 			}
+		case cert.Subtype:
+			if req.GetItem().GetType() != "" && auth.SubtypeFromType(req.GetItem().GetType()) != cert.Subtype {
+				badFields[typeField] = "Cannot modify the resource type."
+			}
+			attrs := &pb.CertAuthMethodAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+				badFields[attributesField] = "Attribute fields do not match the expected format."
+			} else {
+				if handlers.MaskContains(req.GetUpdateMask().GetPaths(), caCertificatesField) && len(attrs.GetCaCertificates()) == 0 {
+					badFields[caCertificatesField] = "Can change but cannot unset this field."
+				}
+				validateCertAttributes(ctx, attrs, badFields)
+			}
 		default:
 			badFields["id"] = "Incorrectly formatted identifier."
 		}
 		return badFields
-	}, password.AuthMethodPrefix, oidc.AuthMethodPrefix)
+	}, password.AuthMethodPrefix, oidc.AuthMethodPrefix, cert.AuthMethodPrefix)
 }
 
 func validateDeleteRequest(req *pbs.DeleteAuthMethodRequest) error {
@@ -1061,7 +1282,33 @@ func validateDeleteRequest(req *pbs.DeleteAuthMethodRequest) error {
 	if req == nil {
 		return errors.NewDeprecated(errors.InvalidParameter, op, "Missing request")
 	}
-	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, password.AuthMethodPrefix, oidc.AuthMethodPrefix)
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, password.AuthMethodPrefix, oidc.AuthMethodPrefix, cert.AuthMethodPrefix)
+}
+
+func validateRevokeAuthMethodTokensRequest(req *pbs.RevokeAuthMethodTokensRequest) error {
+	const op = "authmethod.validateRevokeAuthMethodTokensRequest"
+	if req == nil {
+		return errors.NewDeprecated(errors.InvalidParameter, op, "Missing request")
+	}
+	badFields := make(map[string]string)
+	if !handlers.ValidId(handlers.Id(req.GetId()), password.AuthMethodPrefix, oidc.AuthMethodPrefix, cert.AuthMethodPrefix) {
+		badFields["id"] = "Invalid formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
+	}
+	return nil
+}
+
+func validateScimTokenRequest(id string) error {
+	badFields := make(map[string]string)
+	if !handlers.ValidId(handlers.Id(id), password.AuthMethodPrefix, oidc.AuthMethodPrefix) {
+		badFields["id"] = "Invalid formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
+	}
+	return nil
 }
 
 func validateListRequest(req *pbs.ListAuthMethodsRequest) error {
@@ -1124,7 +1371,7 @@ func validateAuthenticateRequest(req *pbs.AuthenticateRequest) error {
 	} else {
 		st := auth.SubtypeFromId(req.GetAuthMethodId())
 		switch st {
-		case password.Subtype, oidc.Subtype:
+		case password.Subtype, oidc.Subtype, cert.Subtype:
 		default:
 			badFields[authMethodIdField] = "Unknown auth method type."
 		}
@@ -0,0 +1,229 @@
+package authmethods
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/auth/cert"
+	certstore "github.com/hashicorp/boundary/internal/auth/cert/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authmethods"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// cert field names
+	caCertificatesField       = "attributes.ca_certificates"
+	accountAttributeMapsField = "attributes.account_attribute_maps"
+)
+
+var certMaskManager handlers.MaskManager
+
+func init() {
+	var err error
+	if certMaskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&certstore.AuthMethod{}}, handlers.MaskSource{&pb.AuthMethod{}, &pb.CertAuthMethodAttributes{}}); err != nil {
+		panic(err)
+	}
+
+	IdActions[cert.Subtype] = action.ActionSet{
+		action.NoOp,
+		action.Read,
+		action.Update,
+		action.Delete,
+		action.Authenticate,
+		action.RevokeTokens,
+	}
+}
+
+// createCertInRepo creates a cert auth method in a repo and returns the result.
+// This method should never return a nil AuthMethod without returning an error.
+func (s Service) createCertInRepo(ctx context.Context, scopeId string, item *pb.AuthMethod) (*cert.AuthMethod, error) {
+	u, err := toStorageCertAuthMethod(ctx, scopeId, item)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := s.certRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateAuthMethod(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create auth method: %w", err)
+	}
+	return out, nil
+}
+
+func (s Service) updateCertInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.AuthMethod) (*cert.AuthMethod, error) {
+	u, err := toStorageCertAuthMethod(ctx, scopeId, item)
+	if err != nil {
+		return nil, err
+	}
+	version := item.GetVersion()
+	u.PublicId = id
+
+	// The account attribute maps are stored in a column per account field so
+	// they are updated as a set.
+	dbMask := certMaskManager.Translate(mask)
+	if handlers.MaskContains(mask, accountAttributeMapsField) {
+		dbMask = append(dbMask, cert.AttributeMapsField)
+	}
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+
+	repo, err := s.certRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, rowsUpdated, err := repo.UpdateAuthMethod(ctx, u, version, dbMask)
+	if err != nil {
+		return nil, fmt.Errorf("unable to update auth method: %w", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("AuthMethod %q doesn't exist or incorrect version provided.", id)
+	}
+	return out, nil
+}
+
+// authenticateCert authenticates the client certificate presented on the
+// request and returns an auth token for its account.
+func (s Service) authenticateCert(ctx context.Context, req *pbs.AuthenticateRequest, authResults *auth.VerifyResults) (*pbs.AuthenticateResponse, error) {
+	const op = "authmethod_service.(Service).authenticateCert"
+	chain := authResults.ClientCertificates()
+	if len(chain) == 0 {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "No client certificate presented.")
+	}
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	atRepo, err := s.atRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	certRepo, err := s.certRepoFn()
+	if err != nil {
+		return nil, err
+	}
+
+	acct, err := certRepo.Authenticate(ctx, req.GetAuthMethodId(), chain)
+	switch {
+	case errors.Match(errors.T(errors.AccountDisabled), err):
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Account is disabled.")
+	case errors.Match(errors.T(errors.Unauthorized), err):
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+	case err != nil:
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	u, err := iamRepo.LookupUserWithLogin(ctx, acct.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	tok, err := atRepo.CreateAuthToken(ctx, u, acct.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	apiTok, err := s.ConvertInternalAuthTokenToApiAuthToken(ctx, tok)
+	if err != nil {
+		return nil, err
+	}
+	return s.convertToAuthenticateResponse(ctx, req, authResults, apiTok)
+}
+
+func validateAuthenticateCertRequest(req *pbs.AuthenticateRequest) error {
+	badFields := make(map[string]string)
+	if req.GetCommand() == "" {
+		req.Command = loginCommand
+	}
+	if req.GetCommand() != loginCommand {
+		badFields[commandField] = "Invalid command for this auth method type."
+	}
+	tType := strings.ToLower(strings.TrimSpace(req.GetTokenType()))
+	if tType != "" && tType != "token" && tType != "cookie" {
+		badFields[tokenTypeField] = `The only accepted types are "token" and "cookie".`
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
+	}
+	return nil
+}
+
+// validateCertAttributes adds the problems with the CA certificates and
+// account attribute maps in attrs to badFields.
+func validateCertAttributes(ctx context.Context, attrs *pb.CertAuthMethodAttributes, badFields map[string]string) {
+	if len(attrs.GetCaCertificates()) > 0 {
+		if _, err := cert.ParseCertificates(ctx, attrs.GetCaCertificates()...); err != nil {
+			badFields[caCertificatesField] = fmt.Sprintf("Cannot parse CA certificates. %v", err.Error())
+		}
+	}
+	if len(attrs.GetAccountAttributeMaps()) > 0 {
+		if _, err := cert.ParseAttributeMaps(ctx, attrs.GetAccountAttributeMaps()...); err != nil {
+			badFields[accountAttributeMapsField] = fmt.Sprintf("Contains invalid map %q", err.Error())
+		}
+	}
+}
+
+func toStorageCertAuthMethod(ctx context.Context, scopeId string, in *pb.AuthMethod) (*cert.AuthMethod, error) {
+	const op = "authmethod_service.toStorageCertAuthMethod"
+	if in == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil auth method.")
+	}
+	attrs := &pb.CertAuthMethodAttributes{}
+	if err := handlers.StructToProto(in.GetAttributes(), attrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{attributesField: "Attribute fields do not match the expected format."})
+	}
+
+	var opts []cert.Option
+	if in.GetName() != nil {
+		opts = append(opts, cert.WithName(in.GetName().GetValue()))
+	}
+	if in.GetDescription() != nil {
+		opts = append(opts, cert.WithDescription(in.GetDescription().GetValue()))
+	}
+	if len(attrs.GetAccountAttributeMaps()) > 0 {
+		maps, err := cert.ParseAttributeMaps(ctx, attrs.GetAccountAttributeMaps()...)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		opts = append(opts, cert.WithAttributeMaps(maps...))
+	}
+
+	var caCerts []*x509.Certificate
+	if len(attrs.GetCaCertificates()) > 0 {
+		var err error
+		if caCerts, err = cert.ParseCertificates(ctx, attrs.GetCaCertificates()...); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	u, err := cert.NewAuthMethod(ctx, scopeId, caCerts, opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build auth method: %v.", err)
+	}
+	return u, nil
+}
+
+func toCertAuthMethodAttributes(ctx context.Context, in *cert.AuthMethod) (*pb.CertAuthMethodAttributes, error) {
+	certs, err := in.Certificates(ctx)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to read CA certificates: %v.", err)
+	}
+	pems, err := cert.EncodeCertificates(ctx, certs...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to encode CA certificates: %v.", err)
+	}
+	attrs := &pb.CertAuthMethodAttributes{
+		CaCertificates: pems,
+	}
+	for _, m := range in.AttributeMaps() {
+		attrs.AccountAttributeMaps = append(attrs.AccountAttributeMaps, m.String())
+	}
+	return attrs, nil
+}
@@ -0,0 +1,153 @@
+package authmethods_test
+
+import (
+	"context"
+	"crypto/x509"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/cert"
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authmethods"
+	authtokenpb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authtokens"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestAuthenticate_Cert(t *testing.T) {
+	ctx := context.TODO()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrapper), nil
+	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(ctx, rw, rw, kms)
+	}
+	certRepoFn := func() (*cert.Repository, error) {
+		return cert.NewRepository(ctx, rw, rw, kms)
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+
+	ca := cert.NewTestCa(t, "trusted-ca")
+	untrustedCa := cert.NewTestCa(t, "untrusted-ca")
+	am := cert.TestAuthMethod(t, conn, o.GetPublicId(), []*x509.Certificate{ca.Cert})
+	iam.TestSetPrimaryAuthMethod(t, iam.TestRepo(t, conn, wrapper), o, am.PublicId)
+
+	const knownSubject = "spiffe://example.com/known"
+	acct := cert.TestAccount(t, conn, am.PublicId, knownSubject)
+
+	cases := []struct {
+		name        string
+		chain       [][]byte
+		wantAccount string
+		wantSubject string
+		wantErr     error
+	}{
+		{
+			name:        "valid-cert",
+			chain:       ca.Issue(t, cert.TestClientCertificate{Uris: []string{knownSubject}}),
+			wantAccount: acct.GetPublicId(),
+			wantSubject: knownSubject,
+		},
+		{
+			name:        "unknown-subject",
+			chain:       ca.Issue(t, cert.TestClientCertificate{Uris: []string{"spiffe://example.com/unknown"}}),
+			wantSubject: "spiffe://example.com/unknown",
+		},
+		{
+			name:    "untrusted-ca",
+			chain:   untrustedCa.Issue(t, cert.TestClientCertificate{Uris: []string{knownSubject}}),
+			wantErr: handlers.ApiErrorWithCode(codes.Unauthenticated),
+		},
+		{
+			name: "expired-cert",
+			chain: ca.Issue(t, cert.TestClientCertificate{
+				Uris:     []string{knownSubject},
+				NotAfter: time.Now().Add(-time.Minute),
+			}),
+			wantErr: handlers.ApiErrorWithCode(codes.Unauthenticated),
+		},
+		{
+			name:    "no-cert",
+			wantErr: handlers.ApiErrorWithCode(codes.Unauthenticated),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, certRepoFn, iamRepoFn, atRepoFn, nil, nil, nil)
+			require.NoError(err)
+
+			resp, err := s.Authenticate(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId(), auth.WithClientCertificates(tc.chain)),
+				&pbs.AuthenticateRequest{
+					AuthMethodId: am.GetPublicId(),
+					TokenType:    "token",
+				})
+			if tc.wantErr != nil {
+				assert.Error(err)
+				assert.Truef(errors.Is(err, tc.wantErr), "Got %#v, wanted %#v", err, tc.wantErr)
+				return
+			}
+			require.NoError(err)
+			aToken := &authtokenpb.AuthToken{}
+			require.NoError(handlers.StructToProto(resp.GetAttributes(), aToken, handlers.WithDiscardUnknownFields(true)))
+			assert.NotEmpty(aToken.GetId())
+			assert.True(strings.HasPrefix(aToken.GetToken(), aToken.GetId()))
+			assert.Equal(am.GetPublicId(), aToken.GetAuthMethodId())
+			if tc.wantAccount != "" {
+				assert.Equal(tc.wantAccount, aToken.GetAccountId())
+			}
+
+			certRepo, err := certRepoFn()
+			require.NoError(err)
+			got, err := certRepo.LookupAccount(ctx, aToken.GetAccountId())
+			require.NoError(err)
+			require.NotNil(got)
+			assert.Equal(tc.wantSubject, got.GetSubject())
+		})
+	}
+
+	t.Run("disabled-account", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		const subject = "spiffe://example.com/disabled"
+		disabled := cert.TestAccount(t, conn, am.PublicId, subject)
+		certRepo, err := certRepoFn()
+		require.NoError(err)
+		disabled.Disabled = true
+		_, n, err := certRepo.UpdateAccount(ctx, o.GetPublicId(), disabled, disabled.Version, []string{cert.DisabledField})
+		require.NoError(err)
+		require.Equal(1, n)
+
+		s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, certRepoFn, iamRepoFn, atRepoFn, nil, nil, nil)
+		require.NoError(err)
+		chain := ca.Issue(t, cert.TestClientCertificate{Uris: []string{subject}})
+		_, err = s.Authenticate(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId(), auth.WithClientCertificates(chain)),
+			&pbs.AuthenticateRequest{
+				AuthMethodId: am.GetPublicId(),
+				TokenType:    "token",
+			})
+		assert.Truef(errors.Is(err, handlers.ApiErrorWithCode(codes.Unauthenticated)), "Got %#v", err)
+	})
+}
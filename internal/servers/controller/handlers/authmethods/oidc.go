@@ -61,6 +61,9 @@ func init() {
 		action.Delete,
 		action.ChangeState,
 		action.Authenticate,
+		action.RevokeTokens,
+		action.RotateScimToken,
+		action.DeleteScimToken,
 	}
 }
 
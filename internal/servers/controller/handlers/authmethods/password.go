@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/auth/password"
 	pwstore "github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/servers/controller/auth"
@@ -19,9 +20,25 @@ import (
 
 const (
 	// password field names
-	loginNameField = "login_name"
-	passwordField  = "password"
-	loginCommand   = "login"
+	loginNameField   = "login_name"
+	passwordField    = "password"
+	newPasswordField = "new_password"
+	loginCommand     = "login"
+
+	// password totp command field names
+	challengeTokenField = "challenge_token"
+	totpCodeField       = "code"
+	totpCommand         = "totp"
+
+	// password attribute field names
+	lockoutThresholdField       = "attributes.lockout_threshold"
+	lockoutDurationSecondsField = "attributes.lockout_duration_seconds"
+	passwordHistoryCountField   = "attributes.password_history_count"
+	totpMfaField                = "attributes.totp_mfa"
+
+	// maxPasswordHistoryCount is the largest password history an auth method
+	// may keep.
+	maxPasswordHistoryCount = 24
 )
 
 var pwMaskManager handlers.MaskManager
@@ -38,6 +55,9 @@ func init() {
 		action.Update,
 		action.Delete,
 		action.Authenticate,
+		action.RevokeTokens,
+		action.RotateScimToken,
+		action.DeleteScimToken,
 	}
 }
 
@@ -88,42 +108,155 @@ func (s Service) updatePwInRepo(ctx context.Context, scopeId, id string, mask []
 }
 
 func (s Service) authenticatePassword(ctx context.Context, req *pbs.AuthenticateRequest, authResults *auth.VerifyResults) (*pbs.AuthenticateResponse, error) {
+	const op = "authmethod_service.(Service).authenticatePassword"
 	reqAttrs := req.GetAttributes().GetFields()
-	tok, err := s.authenticateWithPwRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), reqAttrs[loginNameField].GetStringValue(), reqAttrs[passwordField].GetStringValue())
+	if req.GetCommand() == totpCommand {
+		tok, err := s.authenticateWithTotp(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), reqAttrs[challengeTokenField].GetStringValue(), reqAttrs[totpCodeField].GetStringValue())
+		if err != nil {
+			return nil, err
+		}
+		return s.convertToAuthenticateResponse(ctx, req, authResults, tok)
+	}
+
+	tok, challenge, err := s.authenticateWithPwRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), reqAttrs[loginNameField].GetStringValue(), reqAttrs[passwordField].GetStringValue(), reqAttrs[newPasswordField].GetStringValue())
 	if err != nil {
 		return nil, err
 	}
+	if challenge != nil {
+		resp := &pbs.AuthenticateResponse{Command: req.GetCommand()}
+		if resp.Attributes, err = handlers.ProtoToStruct(challenge); err != nil {
+			return nil, errors.New(ctx, errors.Internal, op, "Error marshaling parameters.", errors.WithWrap(err))
+		}
+		return resp, nil
+	}
 	return s.convertToAuthenticateResponse(ctx, req, authResults, tok)
 }
 
-func (s Service) authenticateWithPwRepo(ctx context.Context, scopeId, authMethodId, loginName, pw string) (*pba.AuthToken, error) {
+// authenticateWithPwRepo authenticates the account with loginName and pw. If
+// the account must also provide a TOTP code, no token is returned and the
+// returned challenge must be answered with the totp command instead.
+func (s Service) authenticateWithPwRepo(ctx context.Context, scopeId, authMethodId, loginName, pw, newPw string) (*pba.AuthToken, *pb.PasswordAuthMethodAuthenticateMfaChallengeResponse, error) {
 	iamRepo, err := s.iamRepoFn()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	atRepo, err := s.atRepoFn()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	pwRepo, err := s.pwRepoFn()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	acct, err := pwRepo.Authenticate(ctx, scopeId, authMethodId, loginName, pw)
-	if err != nil {
-		return nil, err
+	var opts []password.Option
+	if newPw != "" {
+		opts = append(opts, password.WithNewPassword(newPw))
+	}
+	acct, err := pwRepo.Authenticate(ctx, scopeId, authMethodId, loginName, pw, opts...)
+	switch {
+	case errors.Match(errors.T(errors.AccountLocked), err):
+		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Account is locked.")
+	case errors.Match(errors.T(errors.AccountDisabled), err):
+		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Account is disabled.")
+	case errors.Match(errors.T(errors.PasswordExpired), err):
+		return nil, nil, handlers.InvalidArgumentErrorf("Password has expired.",
+			map[string]string{"attributes.new_password": "The password has expired and must be changed by providing a new password."})
+	case errors.Match(errors.T(errors.PasswordTooShort), err):
+		return nil, nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes.new_password": "Password is too short."})
+	case errors.Match(errors.T(errors.PasswordTooWeak), err):
+		return nil, nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes.new_password": "Password does not meet the password policy of the auth method."})
+	case errors.Match(errors.T(errors.PasswordReused), err):
+		return nil, nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes.new_password": "Password was used recently."})
+	case errors.Match(errors.T(errors.PasswordsEqual), err):
+		return nil, nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes.new_password": "New password equal to current password."})
+	case err != nil:
+		return nil, nil, err
 	}
 	if acct == nil {
-		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+	}
+
+	mfaRequired, err := pwRepo.MfaRequired(ctx, acct.GetPublicId())
+	if err != nil {
+		return nil, nil, err
 	}
 
 	u, err := iamRepo.LookupUserWithLogin(ctx, acct.GetPublicId())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if mfaRequired {
+		// The token stays pending, and is never handed out, until the
+		// challenge is answered.
+		tok, err := atRepo.CreateAuthToken(ctx, u, acct.GetPublicId(), authtoken.WithStatus(authtoken.PendingStatus))
+		if err != nil {
+			return nil, nil, err
+		}
+		challengeToken, enrollment, err := pwRepo.StartMfa(ctx, scopeId, acct.GetPublicId(), tok.GetPublicId())
+		if err != nil {
+			return nil, nil, err
+		}
+		challenge := &pb.PasswordAuthMethodAuthenticateMfaChallengeResponse{
+			ChallengeToken: challengeToken,
+		}
+		if enrollment != nil {
+			challenge.TotpUrl = enrollment.Url
+			challenge.TotpSecret = enrollment.Secret
+			challenge.RecoveryCodes = enrollment.RecoveryCodes
+		}
+		return nil, challenge, nil
 	}
+
 	tok, err := atRepo.CreateAuthToken(ctx, u, acct.GetPublicId())
 	if err != nil {
+		return nil, nil, err
+	}
+
+	apiTok, err := s.ConvertInternalAuthTokenToApiAuthToken(
+		ctx,
+		tok,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return apiTok, nil, nil
+}
+
+// authenticateWithTotp answers the MFA challenge of challengeToken with code
+// and returns the auth token issued for it.
+func (s Service) authenticateWithTotp(ctx context.Context, scopeId, authMethodId, challengeToken, code string) (*pba.AuthToken, error) {
+	atRepo, err := s.atRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	pwRepo, err := s.pwRepoFn()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenId, err := pwRepo.VerifyMfa(ctx, scopeId, authMethodId, challengeToken, code)
+	switch {
+	case errors.Match(errors.T(errors.AuthAttemptExpired), err):
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Challenge has expired.")
+	case errors.Match(errors.T(errors.AccountLocked), err):
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Account is locked.")
+	case err != nil:
+		return nil, err
+	}
+	if tokenId == "" {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+	}
+
+	tok, err := atRepo.IssueAuthToken(ctx, tokenId)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+		}
 		return nil, err
 	}
 
@@ -142,18 +275,27 @@ func validateAuthenticatePasswordRequest(req *pbs.AuthenticateRequest) error {
 		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
 	}
 
-	attrs := req.GetAttributes().GetFields()
-	if _, ok := attrs[loginNameField]; !ok {
-		badFields["attributes.login_name"] = "This is a required field."
-	}
-	if _, ok := attrs[passwordField]; !ok {
-		badFields["attributes.password"] = "This is a required field."
-	}
 	if req.GetCommand() == "" {
 		// TODO: Eventually, require a command. For now, fall back to "login" for backwards compat.
 		req.Command = loginCommand
 	}
-	if req.Command != loginCommand {
+	attrs := req.GetAttributes().GetFields()
+	switch req.GetCommand() {
+	case loginCommand:
+		if _, ok := attrs[loginNameField]; !ok {
+			badFields["attributes.login_name"] = "This is a required field."
+		}
+		if _, ok := attrs[passwordField]; !ok {
+			badFields["attributes.password"] = "This is a required field."
+		}
+	case totpCommand:
+		if attrs[challengeTokenField].GetStringValue() == "" {
+			badFields["attributes.challenge_token"] = "This is a required field."
+		}
+		if attrs[totpCodeField].GetStringValue() == "" {
+			badFields["attributes.code"] = "This is a required field."
+		}
+	default:
 		badFields[commandField] = "Invalid command for this auth method type."
 	}
 	tType := strings.ToLower(strings.TrimSpace(req.GetTokenType()))
@@ -195,5 +337,34 @@ func toStoragePwAuthMethod(scopeId string, item *pb.AuthMethod) (*password.AuthM
 	if pwAttrs.GetMinPasswordLength() != 0 {
 		u.MinPasswordLength = pwAttrs.GetMinPasswordLength()
 	}
+	u.PasswordRequireLowercase = pwAttrs.GetPasswordRequireLowercase()
+	u.PasswordRequireUppercase = pwAttrs.GetPasswordRequireUppercase()
+	u.PasswordRequireDigit = pwAttrs.GetPasswordRequireDigit()
+	u.PasswordRequireSymbol = pwAttrs.GetPasswordRequireSymbol()
+	u.DenyCommonPasswords = pwAttrs.GetDenyCommonPasswords()
+	u.LockoutThreshold = pwAttrs.GetLockoutThreshold()
+	u.LockoutWindowSeconds = pwAttrs.GetLockoutWindowSeconds()
+	u.LockoutDurationSeconds = pwAttrs.GetLockoutDurationSeconds()
+	u.PasswordHistoryCount = pwAttrs.GetPasswordHistoryCount()
+	u.MaxPasswordAgeDays = pwAttrs.GetMaxPasswordAgeDays()
+	u.TotpMfa = pwAttrs.GetTotpMfa()
 	return u, nil
 }
+
+// validatePwAttributes adds the problems with the password policy settings in
+// attrs to badFields. A lockout duration is only required for a lockout
+// threshold if checkLockoutDuration is set since an update may change only one
+// of them.
+func validatePwAttributes(attrs *pb.PasswordAuthMethodAttributes, checkLockoutDuration bool, badFields map[string]string) {
+	if attrs.GetPasswordHistoryCount() > maxPasswordHistoryCount {
+		badFields[passwordHistoryCountField] = fmt.Sprintf("Must not be greater than %d.", maxPasswordHistoryCount)
+	}
+	if checkLockoutDuration && attrs.GetLockoutThreshold() > 0 && attrs.GetLockoutDurationSeconds() == 0 {
+		badFields[lockoutDurationSecondsField] = fmt.Sprintf("Must be set when %s is set.", lockoutThresholdField)
+	}
+	switch attrs.GetTotpMfa() {
+	case "", password.TotpMfaDisabled, password.TotpMfaOptional, password.TotpMfaRequired:
+	default:
+		badFields[totpMfaField] = fmt.Sprintf("Must be one of %q, %q or %q.", password.TotpMfaDisabled, password.TotpMfaOptional, password.TotpMfaRequired)
+	}
+}
@@ -3,11 +3,14 @@ package authtokens
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/servers/controller/auth"
@@ -19,6 +22,7 @@ import (
 	"github.com/hashicorp/boundary/internal/types/scope"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authtokens"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 var (
@@ -35,28 +39,38 @@ var (
 	// CollectionActions contains the set of actions that can be performed on
 	// this collection
 	CollectionActions = action.ActionSet{
+		action.Create,
 		action.List,
 	}
 )
 
+// defaultGrant is used for derived auth tokens which are created without any
+// grant strings. The derived auth token is then only restricted to the grant
+// scopes.
+const defaultGrant = "id=*;type=*;actions=*"
+
 // Service handles request as described by the pbs.AuthTokenServiceServer interface.
 type Service struct {
 	pbs.UnimplementedAuthTokenServiceServer
 
+	kms       *kms.Kms
 	repoFn    common.AuthTokenRepoFactory
 	iamRepoFn common.IamRepoFactory
 }
 
 // NewService returns a user service which handles user related requests to boundary.
-func NewService(repo common.AuthTokenRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+func NewService(kms *kms.Kms, repo common.AuthTokenRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
 	const op = "authtoken.NewService"
+	if kms == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing kms")
+	}
 	if repo == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing auth token repository")
 	}
 	if iamRepoFn == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
 	}
-	return Service{repoFn: repo, iamRepoFn: iamRepoFn}, nil
+	return Service{kms: kms, repoFn: repo, iamRepoFn: iamRepoFn}, nil
 }
 
 var _ pbs.AuthTokenServiceServer = Service{}
@@ -114,7 +128,7 @@ func (s Service) ListAuthTokens(ctx context.Context, req *pbs.ListAuthTokensRequ
 			continue
 		}
 
-		if authorizedActions.OnlySelf() && at.GetIamUserId() != authResults.UserId {
+		if authorizedActions.OnlySelf() && principalId(at) != authResults.UserId {
 			continue
 		}
 
@@ -128,7 +142,7 @@ func (s Service) ListAuthTokens(ctx context.Context, req *pbs.ListAuthTokensRequ
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions.Strings()))
 		}
 
-		item, err := toProto(ctx, at, outputOpts...)
+		item, err := toProto(ctx, at, nil, outputOpts...)
 		if err != nil {
 			return nil, err
 		}
@@ -161,7 +175,7 @@ func (s Service) GetAuthToken(ctx context.Context, req *pbs.GetAuthTokenRequest)
 	authorizedActions := authResults.FetchActionSetForId(ctx, at.GetPublicId(), IdActions)
 
 	// Check to see if we need to verify Read vs. just ReadSelf
-	if at.GetIamUserId() != authResults.UserId {
+	if principalId(at) != authResults.UserId {
 		if !authorizedActions.HasAction(action.Read) {
 			return nil, handlers.ForbiddenError()
 		}
@@ -187,7 +201,15 @@ func (s Service) GetAuthToken(ctx context.Context, req *pbs.GetAuthTokenRequest)
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions.Strings()))
 	}
 
-	item, err := toProto(ctx, at, outputOpts...)
+	var grants []*authtoken.Grant
+	if at.GetParentId() != "" && (outputFields.Has(globals.GrantStringsField) || outputFields.Has(globals.GrantScopeIdsField)) {
+		grants, err = s.listGrantsFromRepo(ctx, at.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item, err := toProto(ctx, at, grants, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -195,6 +217,70 @@ func (s Service) GetAuthToken(ctx context.Context, req *pbs.GetAuthTokenRequest)
 	return &pbs.GetAuthTokenResponse{Item: item}, nil
 }
 
+// CreateAuthToken implements the interface pbs.AuthTokenServiceServer. The
+// created auth token is derived from the auth token used to make the request
+// and is restricted to the grant strings in the grant scopes of the request.
+func (s Service) CreateAuthToken(ctx context.Context, req *pbs.CreateAuthTokenRequest) (*pbs.CreateAuthTokenResponse, error) {
+	const op = "authtokens.(Service).CreateAuthToken"
+
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetScopeId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	if authResults.AuthTokenId == "" || authResults.UserId == auth.AnonymousUserId {
+		return nil, handlers.ForbiddenError()
+	}
+	parent, err := s.getFromRepo(ctx, authResults.AuthTokenId)
+	if err != nil {
+		return nil, err
+	}
+	if principalId(parent) != authResults.UserId {
+		return nil, handlers.ForbiddenError()
+	}
+	if parent.GetParentId() != "" {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"auth_token": "Auth tokens can not be created with a derived auth token."})
+	}
+	if parent.GetScopeId() != req.GetItem().GetScopeId() {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{globals.ScopeIdField: "Must be the scope of the auth token used to make the request."})
+	}
+
+	at, grants, err := s.createInRepo(ctx, parent.GetPublicId(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+	token, err := authtoken.EncryptToken(ctx, s.kms, at.GetScopeId(), at.GetPublicId(), at.GetToken())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	at.Token = at.GetPublicId() + "_" + token
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, at.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, at, grants, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+	// The token value is only ever returned when the auth token is created.
+	item.Token = at.GetToken()
+	return &pbs.CreateAuthTokenResponse{Item: item, Uri: fmt.Sprintf("auth-tokens/%s", item.GetId())}, nil
+}
+
 // DeleteAuthToken implements the interface pbs.AuthTokenServiceServer.
 func (s Service) DeleteAuthToken(ctx context.Context, req *pbs.DeleteAuthTokenRequest) (*pbs.DeleteAuthTokenResponse, error) {
 	if err := validateDeleteRequest(req); err != nil {
@@ -213,7 +299,7 @@ func (s Service) DeleteAuthToken(ctx context.Context, req *pbs.DeleteAuthTokenRe
 	authorizedActions := authResults.FetchActionSetForId(ctx, at.GetPublicId(), IdActions)
 
 	// Check to see if we need to verify Delete vs. just DeleteSelf
-	if at.GetIamUserId() != authResults.UserId {
+	if principalId(at) != authResults.UserId {
 		if !authorizedActions.HasAction(action.Delete) {
 			return nil, handlers.ForbiddenError()
 		}
@@ -242,6 +328,56 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*authtoken.AuthTok
 	return at, nil
 }
 
+func (s Service) createInRepo(ctx context.Context, parentId string, item *pb.AuthToken) (*authtoken.AuthToken, []*authtoken.Grant, error) {
+	const op = "authtokens.(Service).createInRepo"
+	grantStrings := item.GetGrantStrings()
+	if len(grantStrings) == 0 {
+		grantStrings = []string{defaultGrant}
+	}
+	grants := make([]*authtoken.Grant, 0, len(item.GetGrantScopeIds())*len(grantStrings))
+	for _, scopeId := range item.GetGrantScopeIds() {
+		for _, g := range grantStrings {
+			grant, err := authtoken.NewGrant(ctx, scopeId, g)
+			if err != nil {
+				return nil, nil, errors.Wrap(ctx, err, op)
+			}
+			grants = append(grants, grant)
+		}
+	}
+	var opts []authtoken.Option
+	if item.GetDescription() != nil {
+		opts = append(opts, authtoken.WithDescription(item.GetDescription().GetValue()))
+	}
+	if item.GetTimeToLiveSeconds() > 0 {
+		opts = append(opts, authtoken.WithTokenTimeToLiveDuration(time.Duration(item.GetTimeToLiveSeconds())*time.Second))
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	at, err := repo.CreateDerivedAuthToken(ctx, parentId, grants, opts...)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create auth token"))
+	}
+	if at == nil {
+		return nil, nil, errors.New(ctx, errors.Internal, op, "unable to create auth token but no error returned from repository")
+	}
+	return at, grants, nil
+}
+
+func (s Service) listGrantsFromRepo(ctx context.Context, id string) ([]*authtoken.Grant, error) {
+	const op = "authtokens.(Service).listGrantsFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	grants, err := repo.ListAuthTokenGrants(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return grants, nil
+}
+
 func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	const op = "authtokens.(Service).deleteFromRepo"
 	repo, err := s.repoFn()
@@ -315,7 +451,16 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
-func toProto(ctx context.Context, in *authtoken.AuthToken, opt ...handlers.Option) (*pb.AuthToken, error) {
+// principalId returns the id of the principal the auth token was issued to,
+// which is either a user or a service account.
+func principalId(at *authtoken.AuthToken) string {
+	if at.GetServiceAccountId() != "" {
+		return at.GetServiceAccountId()
+	}
+	return at.GetIamUserId()
+}
+
+func toProto(ctx context.Context, in *authtoken.AuthToken, grants []*authtoken.Grant, opt ...handlers.Option) (*pb.AuthToken, error) {
 	opts := handlers.GetOpts(opt...)
 	if opts.WithOutputFields == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building auth token proto")
@@ -332,6 +477,9 @@ func toProto(ctx context.Context, in *authtoken.AuthToken, opt ...handlers.Optio
 	if outputFields.Has(globals.UserIdField) {
 		out.UserId = in.GetIamUserId()
 	}
+	if outputFields.Has(globals.ServiceAccountIdField) {
+		out.ServiceAccountId = in.GetServiceAccountId()
+	}
 	if outputFields.Has(globals.AuthMethodIdField) {
 		out.AuthMethodId = in.GetAuthMethodId()
 	}
@@ -350,6 +498,28 @@ func toProto(ctx context.Context, in *authtoken.AuthToken, opt ...handlers.Optio
 	if outputFields.Has(globals.ExpirationTimeField) {
 		out.ExpirationTime = in.GetExpirationTime().GetTimestamp()
 	}
+	if outputFields.Has(globals.DescriptionField) && in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if outputFields.Has(globals.ParentIdField) {
+		out.ParentId = in.GetParentId()
+	}
+	if len(grants) > 0 {
+		// Grants are stored per grant scope, so they are reassembled into the
+		// grant strings and grant scopes they were created from.
+		seenScopes := make(map[string]bool, len(grants))
+		seenGrants := make(map[string]bool, len(grants))
+		for _, g := range grants {
+			if outputFields.Has(globals.GrantScopeIdsField) && !seenScopes[g.GetScopeId()] {
+				seenScopes[g.GetScopeId()] = true
+				out.GrantScopeIds = append(out.GrantScopeIds, g.GetScopeId())
+			}
+			if outputFields.Has(globals.GrantStringsField) && !seenGrants[g.GetRawGrant()] {
+				seenGrants[g.GetRawGrant()] = true
+				out.GrantStrings = append(out.GrantStrings, g.GetRawGrant())
+			}
+		}
+	}
 	if outputFields.Has(globals.ScopeField) {
 		out.Scope = opts.WithScope
 	}
@@ -373,6 +543,88 @@ func validateDeleteRequest(req *pbs.DeleteAuthTokenRequest) error {
 	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, authtoken.AuthTokenPrefix)
 }
 
+func validateCreateRequest(req *pbs.CreateAuthTokenRequest) error {
+	const maxTimeToLive = 7 * 24 * time.Hour
+	item := req.GetItem()
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(item.GetScopeId()), scope.Org.Prefix()) &&
+		item.GetScopeId() != scope.Global.String() {
+		badFields[globals.ScopeIdField] = "This field must be 'global' or a valid org scope id."
+	}
+	if item.GetId() != "" {
+		badFields[globals.IdField] = "This is a read only field."
+	}
+	if item.GetToken() != "" {
+		badFields["token"] = "This is a read only field."
+	}
+	if item.GetUserId() != "" {
+		badFields[globals.UserIdField] = "This is a read only field."
+	}
+	if item.GetAuthMethodId() != "" {
+		badFields[globals.AuthMethodIdField] = "This is a read only field."
+	}
+	if item.GetAccountId() != "" {
+		badFields[globals.AccountIdField] = "This is a read only field."
+	}
+	if item.GetServiceAccountId() != "" {
+		badFields[globals.ServiceAccountIdField] = "This is a read only field."
+	}
+	if item.GetParentId() != "" {
+		badFields[globals.ParentIdField] = "This is a read only field."
+	}
+	if item.GetCreatedTime() != nil {
+		badFields[globals.CreatedTimeField] = "This is a read only field."
+	}
+	if item.GetUpdatedTime() != nil {
+		badFields[globals.UpdatedTimeField] = "This is a read only field."
+	}
+	if item.GetApproximateLastUsedTime() != nil {
+		badFields[globals.ApproximateLastUsedTimeField] = "This is a read only field."
+	}
+	if item.GetExpirationTime() != nil {
+		badFields[globals.ExpirationTimeField] = "This is a read only field."
+	}
+	if item.GetDescription() != nil {
+		trimmed := strings.TrimSpace(item.GetDescription().GetValue())
+		switch {
+		case trimmed == "":
+			badFields[globals.DescriptionField] = "Cannot set empty string as description"
+		case !handlers.ValidNameDescription(trimmed):
+			badFields[globals.DescriptionField] = "Description contains unprintable characters"
+		default:
+			item.GetDescription().Value = trimmed
+		}
+	}
+	if time.Duration(item.GetTimeToLiveSeconds())*time.Second > maxTimeToLive {
+		badFields[globals.TimeToLiveSecondsField] = fmt.Sprintf("This field must not be greater than %d.", int(maxTimeToLive.Seconds()))
+	}
+	if len(item.GetGrantScopeIds()) == 0 {
+		badFields[globals.GrantScopeIdsField] = "At least one grant scope id must be provided."
+	}
+	for _, id := range item.GetGrantScopeIds() {
+		if id != scope.Global.String() && !handlers.ValidId(handlers.Id(id), scope.Org.Prefix(), scope.Project.Prefix()) {
+			badFields[globals.GrantScopeIdsField] = fmt.Sprintf("Improperly formatted scope id %q.", id)
+			break
+		}
+	}
+	for _, g := range item.GetGrantStrings() {
+		if len(g) == 0 {
+			badFields[globals.GrantStringsField] = "Grant strings must not be empty."
+			break
+		}
+		// The grant scope does not change whether the grant is valid so the global
+		// scope is used to parse it.
+		if _, err := perms.Parse(scope.Global.String(), g); err != nil {
+			badFields[globals.GrantStringsField] = fmt.Sprintf("Improperly formatted grant %q.", g)
+			break
+		}
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
 func validateListRequest(req *pbs.ListAuthTokensRequest) error {
 	badFields := map[string]string{}
 	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) &&
@@ -24,6 +24,7 @@ import (
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -47,7 +48,7 @@ func TestGetSelf(t *testing.T) {
 		return servers.NewRepository(rw, rw, kms)
 	}
 
-	a, err := authtokens.NewService(tokenRepoFn, iamRepoFn)
+	a, err := authtokens.NewService(kms, tokenRepoFn, iamRepoFn)
 	require.NoError(t, err, "Couldn't create new auth token service.")
 
 	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
@@ -125,7 +126,7 @@ func TestGet(t *testing.T) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
 
-	s, err := authtokens.NewService(repoFn, iamRepoFn)
+	s, err := authtokens.NewService(kms, repoFn, iamRepoFn)
 	require.NoError(t, err, "Couldn't create new auth token service.")
 
 	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
@@ -229,7 +230,7 @@ func TestList_Self(t *testing.T) {
 		},
 	}
 
-	a, err := authtokens.NewService(tokenRepoFn, iamRepoFn)
+	a, err := authtokens.NewService(kms, tokenRepoFn, iamRepoFn)
 	require.NoError(t, err)
 
 	for _, tc := range cases {
@@ -383,7 +384,7 @@ func TestList(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			s, err := authtokens.NewService(repoFn, iamRepoFn)
+			s, err := authtokens.NewService(kms, repoFn, iamRepoFn)
 			assert, require := assert.New(t), require.New(t)
 			require.NoError(err, "Couldn't create new user service.")
 
@@ -430,7 +431,7 @@ func TestDeleteSelf(t *testing.T) {
 		return servers.NewRepository(rw, rw, kms)
 	}
 
-	a, err := authtokens.NewService(tokenRepoFn, iamRepoFn)
+	a, err := authtokens.NewService(kms, tokenRepoFn, iamRepoFn)
 	require.NoError(t, err, "Couldn't create new auth token service.")
 
 	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
@@ -519,7 +520,7 @@ func TestDelete(t *testing.T) {
 	org, _ := iam.TestScopes(t, iamRepo)
 	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
 
-	s, err := authtokens.NewService(repoFn, iamRepoFn)
+	s, err := authtokens.NewService(kms, repoFn, iamRepoFn)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	cases := []struct {
@@ -583,7 +584,7 @@ func TestDelete_twice(t *testing.T) {
 	org, _ := iam.TestScopes(t, iamRepo)
 	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
 
-	s, err := authtokens.NewService(repoFn, iamRepoFn)
+	s, err := authtokens.NewService(kms, repoFn, iamRepoFn)
 	require.NoError(err, "Error when getting new user service")
 	req := &pbs.DeleteAuthTokenRequest{
 		Id: at.GetPublicId(),
@@ -594,3 +595,146 @@ func TestDelete_twice(t *testing.T) {
 	assert.Error(gErr, "Second attempt")
 	assert.True(errors.Is(gErr, handlers.ApiErrorWithCode(codes.NotFound)), "Expected permission denied for the second delete.")
 }
+
+func TestCreate(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrap), nil
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+
+	a, err := authtokens.NewService(kms, tokenRepoFn, iamRepoFn)
+	require.NoError(t, err, "Couldn't create new auth token service.")
+
+	o, p := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
+	at := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	r := iam.TestRole(t, conn, o.GetPublicId())
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=auth-token;actions=create,list")
+	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+
+	ctxFor := func(tok *authtoken.AuthToken) context.Context {
+		requestInfo := authpb.RequestInfo{
+			Path:        "/v1/auth-tokens",
+			Method:      "POST",
+			TokenFormat: uint32(auth.AuthTokenTypeBearer),
+			PublicId:    tok.GetPublicId(),
+			Token:       tok.GetToken(),
+		}
+		ctx := auth.NewVerifierContext(context.Background(), iamRepoFn, tokenRepoFn, serversRepoFn, kms, &requestInfo)
+		return context.WithValue(ctx, requests.ContextRequestInformationKey, &requests.RequestContext{})
+	}
+
+	cases := []struct {
+		name    string
+		item    *pb.AuthToken
+		errCode codes.Code
+	}{
+		{
+			name: "global-scope-of-other-token",
+			item: &pb.AuthToken{
+				ScopeId:       scope.Global.String(),
+				GrantScopeIds: []string{o.GetPublicId()},
+			},
+			errCode: codes.PermissionDenied,
+		},
+		{
+			name: "project-scope",
+			item: &pb.AuthToken{
+				ScopeId:       p.GetPublicId(),
+				GrantScopeIds: []string{o.GetPublicId()},
+			},
+			errCode: codes.InvalidArgument,
+		},
+		{
+			name: "missing-grant-scope-ids",
+			item: &pb.AuthToken{
+				ScopeId: o.GetPublicId(),
+			},
+			errCode: codes.InvalidArgument,
+		},
+		{
+			name: "invalid-grant",
+			item: &pb.AuthToken{
+				ScopeId:       o.GetPublicId(),
+				GrantScopeIds: []string{o.GetPublicId()},
+				GrantStrings:  []string{"id=*;type=nothing;actions=read"},
+			},
+			errCode: codes.InvalidArgument,
+		},
+		{
+			name: "read-only-field",
+			item: &pb.AuthToken{
+				ScopeId:       o.GetPublicId(),
+				GrantScopeIds: []string{o.GetPublicId()},
+				UserId:        at.GetIamUserId(),
+			},
+			errCode: codes.InvalidArgument,
+		},
+		{
+			name: "ttl-too-long",
+			item: &pb.AuthToken{
+				ScopeId:           o.GetPublicId(),
+				GrantScopeIds:     []string{o.GetPublicId()},
+				TimeToLiveSeconds: 8 * 24 * 60 * 60,
+			},
+			errCode: codes.InvalidArgument,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := a.CreateAuthToken(ctxFor(at), &pbs.CreateAuthTokenRequest{Item: tc.item})
+			require.Error(t, err)
+			assert.Nil(t, got)
+			assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(tc.errCode)), "got error %v, wanted %v", err, tc.errCode)
+		})
+	}
+
+	t.Run("restricted", func(t *testing.T) {
+		require, assert := require.New(t), assert.New(t)
+		got, err := a.CreateAuthToken(ctxFor(at), &pbs.CreateAuthTokenRequest{Item: &pb.AuthToken{
+			ScopeId:       o.GetPublicId(),
+			Description:   wrapperspb.String("automation"),
+			GrantScopeIds: []string{o.GetPublicId()},
+			GrantStrings:  []string{"id=*;type=target;actions=list"},
+		}})
+		require.NoError(err)
+		item := got.GetItem()
+		assert.Equal(fmt.Sprintf("auth-tokens/%s", item.GetId()), got.GetUri())
+		assert.Equal(at.GetPublicId(), item.GetParentId())
+		assert.Equal(at.GetIamUserId(), item.GetUserId())
+		assert.Equal("automation", item.GetDescription().GetValue())
+		assert.Equal([]string{o.GetPublicId()}, item.GetGrantScopeIds())
+		assert.Equal([]string{"id=*;type=target;actions=list"}, item.GetGrantStrings())
+		require.NotEmpty(item.GetToken())
+
+		derived, err := authtoken.NewRepository(rw, rw, kms)
+		require.NoError(err)
+		derivedTok, err := derived.LookupAuthToken(context.Background(), item.GetId())
+		require.NoError(err)
+		derivedTok.Token = item.GetToken()[len(item.GetId())+1:]
+
+		// The derived token is not allowed to list auth tokens although its
+		// user is.
+		_, err = a.ListAuthTokens(ctxFor(at), &pbs.ListAuthTokensRequest{ScopeId: o.GetPublicId()})
+		require.NoError(err)
+		_, err = a.ListAuthTokens(ctxFor(derivedTok), &pbs.ListAuthTokensRequest{ScopeId: o.GetPublicId()})
+		require.Error(err)
+
+		// Derived tokens can not create further tokens.
+		_, err = a.CreateAuthToken(ctxFor(derivedTok), &pbs.CreateAuthTokenRequest{Item: &pb.AuthToken{
+			ScopeId:       o.GetPublicId(),
+			GrantScopeIds: []string{o.GetPublicId()},
+		}})
+		require.Error(err)
+	})
+}
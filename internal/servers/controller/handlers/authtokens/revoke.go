@@ -0,0 +1,121 @@
+package authtokens
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/session"
+)
+
+// RevokedCancelReason is the cancel reason of sessions canceled because the
+// auth tokens of their user were revoked.
+const RevokedCancelReason = "auth tokens revoked"
+
+// DisabledCancelReason is the cancel reason of sessions canceled because
+// their user was disabled.
+const DisabledCancelReason = "user disabled"
+
+// RevokeFn deletes a set of auth tokens and returns them.
+type RevokeFn func(context.Context, *authtoken.Repository) ([]*authtoken.AuthToken, error)
+
+// Revoke revokes the auth tokens deleted by revokeFn. It is used by the
+// services which provide a revoke-tokens action on the resources auth tokens
+// are issued for.
+//
+// The database cancels the sessions created with a revoked token. If
+// cancelSessions is true, all other non-terminated sessions of the users of
+// the revoked tokens are canceled as well, and the terminations of all their
+// sessions are published so workers close the connections immediately.
+// Revoke returns the number of revoked tokens and the ids of the canceled
+// sessions.
+func Revoke(ctx context.Context, atRepoFn common.AuthTokenRepoFactory, sessionRepoFn common.SessionRepoFactory, terminations *session.TerminationBroadcaster, cancelSessions bool, revokeFn RevokeFn) (uint32, []string, error) {
+	const op = "authtokens.Revoke"
+	if atRepoFn == nil {
+		return 0, nil, errors.New(ctx, errors.Internal, op, "missing auth token repository")
+	}
+	if cancelSessions && sessionRepoFn == nil {
+		return 0, nil, errors.New(ctx, errors.Internal, op, "missing session repository")
+	}
+	atRepo, err := atRepoFn()
+	if err != nil {
+		return 0, nil, errors.Wrap(ctx, err, op)
+	}
+	revoked, err := revokeFn(ctx, atRepo)
+	if err != nil {
+		return 0, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to revoke auth tokens"))
+	}
+	if !cancelSessions || len(revoked) == 0 {
+		return uint32(len(revoked)), nil, nil
+	}
+
+	seen := make(map[string]bool, len(revoked))
+	userIds := make([]string, 0, len(revoked))
+	for _, at := range revoked {
+		if !seen[at.GetIamUserId()] {
+			seen[at.GetIamUserId()] = true
+			userIds = append(userIds, at.GetIamUserId())
+		}
+	}
+	sessionIds, err := cancelUserSessions(ctx, sessionRepoFn, terminations, userIds, RevokedCancelReason)
+	if err != nil {
+		return 0, nil, errors.Wrap(ctx, err, op, errors.WithMsg("auth tokens were revoked but sessions could not be canceled"))
+	}
+	return uint32(len(revoked)), sessionIds, nil
+}
+
+// RevokeDisabled revokes the auth tokens deleted by revokeFn and cancels all
+// non-terminated sessions of userIds, whether or not any token was revoked.
+// It is used when users are disabled. RevokeDisabled returns the number of
+// revoked tokens and the ids of the canceled sessions.
+func RevokeDisabled(ctx context.Context, atRepoFn common.AuthTokenRepoFactory, sessionRepoFn common.SessionRepoFactory, terminations *session.TerminationBroadcaster, userIds []string, revokeFn RevokeFn) (uint32, []string, error) {
+	const op = "authtokens.RevokeDisabled"
+	if atRepoFn == nil {
+		return 0, nil, errors.New(ctx, errors.Internal, op, "missing auth token repository")
+	}
+	if sessionRepoFn == nil {
+		return 0, nil, errors.New(ctx, errors.Internal, op, "missing session repository")
+	}
+	atRepo, err := atRepoFn()
+	if err != nil {
+		return 0, nil, errors.Wrap(ctx, err, op)
+	}
+	revoked, err := revokeFn(ctx, atRepo)
+	if err != nil {
+		return 0, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to revoke auth tokens"))
+	}
+	if len(userIds) == 0 {
+		return uint32(len(revoked)), nil, nil
+	}
+	sessionIds, err := cancelUserSessions(ctx, sessionRepoFn, terminations, userIds, DisabledCancelReason)
+	if err != nil {
+		return 0, nil, errors.Wrap(ctx, err, op, errors.WithMsg("auth tokens were revoked but sessions could not be canceled"))
+	}
+	return uint32(len(revoked)), sessionIds, nil
+}
+
+// cancelUserSessions cancels the non-terminated sessions of userIds and
+// publishes their terminations so workers close the connections
+// immediately. It returns the ids of the canceled sessions.
+func cancelUserSessions(ctx context.Context, sessionRepoFn common.SessionRepoFactory, terminations *session.TerminationBroadcaster, userIds []string, reason string) ([]string, error) {
+	const op = "authtokens.cancelUserSessions"
+	sessRepo, err := sessionRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	canceled, err := sessRepo.CancelUserSessions(ctx, userIds, session.WithCancelReason(reason))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	sessionIds := make([]string, 0, len(canceled))
+	for _, s := range canceled {
+		terminations.Publish(session.Termination{
+			SessionId: s.PublicId,
+			ServerId:  s.ServerId,
+			Reason:    reason,
+		})
+		sessionIds = append(sessionIds, s.PublicId)
+	}
+	return sessionIds, nil
+}
@@ -21,6 +21,9 @@ import (
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/credentiallibraries"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -28,10 +31,18 @@ const (
 	vaultPathField       = "attributes.path"
 	httpMethodField      = "attributes.http_method"
 	httpRequestBodyField = "attributes.http_request_body"
+	validPrincipalsField = "attributes.valid_principals"
+	ttlField             = "attributes.ttl"
+	extensionsField      = "attributes.extensions"
+
+	usernameAttributeField   = "credential_mapping_overrides.username_attribute"
+	passwordAttributeField   = "credential_mapping_overrides.password_attribute"
+	privateKeyAttributeField = "credential_mapping_overrides.private_key_attribute"
 )
 
 var (
-	maskManager handlers.MaskManager
+	maskManager              handlers.MaskManager
+	sshSignedCertMaskManager handlers.MaskManager
 
 	// IdActions contains the set of actions that can be performed on
 	// individual resources
@@ -53,7 +64,11 @@ var (
 func init() {
 	var err error
 	if maskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&store.CredentialLibrary{}},
-		handlers.MaskSource{&pb.CredentialLibrary{}, &pb.VaultCredentialLibraryAttributes{}}); err != nil {
+		handlers.MaskSource{&pb.CredentialLibrary{}, &pb.VaultCredentialLibraryAttributes{}, &pb.CredentialMappingOverrides{}}); err != nil {
+		panic(err)
+	}
+	if sshSignedCertMaskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&store.SshSignedCertCredentialLibrary{}},
+		handlers.MaskSource{&pb.CredentialLibrary{}, &pb.VaultSshSignedCertCredentialLibraryAttributes{}}); err != nil {
 		panic(err)
 	}
 }
@@ -270,7 +285,7 @@ func (s Service) DeleteCredentialLibrary(ctx context.Context, req *pbs.DeleteCre
 	return nil, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, storeId string) ([]*vault.CredentialLibrary, error) {
+func (s Service) listFromRepo(ctx context.Context, storeId string) ([]credential.Library, error) {
 	const op = "credentiallibraries.(Service).listFromRepo"
 	repo, err := s.repoFn()
 	if err != nil {
@@ -280,7 +295,18 @@ func (s Service) listFromRepo(ctx context.Context, storeId string) ([]*vault.Cre
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	return csl, nil
+	var res []credential.Library
+	for _, l := range csl {
+		res = append(res, l)
+	}
+	sshl, err := repo.ListSshSignedCertCredentialLibraries(ctx, storeId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	for _, l := range sshl {
+		res = append(res, l)
+	}
+	return res, nil
 }
 
 func (s Service) getFromRepo(ctx context.Context, id string) (credential.Library, error) {
@@ -289,18 +315,40 @@ func (s Service) getFromRepo(ctx context.Context, id string) (credential.Library
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	cs, err := repo.LookupCredentialLibrary(ctx, id)
-	if err != nil && !errors.IsNotFoundError(err) {
-		return nil, errors.Wrap(ctx, err, op)
+	var cs credential.Library
+	switch credential.SubtypeFromId(id) {
+	case vault.Subtype:
+		l, err := repo.LookupCredentialLibrary(ctx, id)
+		if err != nil && !errors.IsNotFoundError(err) {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if l != nil {
+			cs = l
+		}
+	case vault.SshSignedCertSubtype:
+		l, err := repo.LookupSshSignedCertCredentialLibrary(ctx, id)
+		if err != nil && !errors.IsNotFoundError(err) {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if l != nil {
+			cs = l
+		}
 	}
 	if cs == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("credential library %q not found", id))
 	}
-	return cs, err
+	return cs, nil
 }
 
 func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.CredentialLibrary) (credential.Library, error) {
-	const op = "credentiallibraries.(Service).createInRepo"
+	if credential.SubtypeFromType(item.GetType()) == vault.SshSignedCertSubtype {
+		return s.createSshSignedCertInRepo(ctx, scopeId, item)
+	}
+	return s.createVaultInRepo(ctx, scopeId, item)
+}
+
+func (s Service) createVaultInRepo(ctx context.Context, scopeId string, item *pb.CredentialLibrary) (*vault.CredentialLibrary, error) {
+	const op = "credentiallibraries.(Service).createVaultInRepo"
 	cl, err := toStorageVaultLibrary(item.GetCredentialStoreId(), item)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
@@ -319,8 +367,35 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Cred
 	return out, nil
 }
 
+func (s Service) createSshSignedCertInRepo(ctx context.Context, scopeId string, item *pb.CredentialLibrary) (*vault.SshSignedCertCredentialLibrary, error) {
+	const op = "credentiallibraries.(Service).createSshSignedCertInRepo"
+	cl, err := toStorageSshSignedCertLibrary(item.GetCredentialStoreId(), item)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, err := repo.CreateSshSignedCertCredentialLibrary(ctx, scopeId, cl)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create credential library"))
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create credential library but no error returned from repository.")
+	}
+	return out, nil
+}
+
 func (s Service) updateInRepo(ctx context.Context, projId, id string, mask []string, item *pb.CredentialLibrary) (credential.Library, error) {
-	const op = "credentiallibraries.(Service).updateInRepo"
+	if credential.SubtypeFromId(id) == vault.SshSignedCertSubtype {
+		return s.updateSshSignedCertInRepo(ctx, projId, id, mask, item)
+	}
+	return s.updateVaultInRepo(ctx, projId, id, mask, item)
+}
+
+func (s Service) updateVaultInRepo(ctx context.Context, projId, id string, mask []string, item *pb.CredentialLibrary) (*vault.CredentialLibrary, error) {
+	const op = "credentiallibraries.(Service).updateVaultInRepo"
 	cl, err := toStorageVaultLibrary(item.GetCredentialStoreId(), item)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
@@ -345,13 +420,45 @@ func (s Service) updateInRepo(ctx context.Context, projId, id string, mask []str
 	return out, nil
 }
 
+func (s Service) updateSshSignedCertInRepo(ctx context.Context, projId, id string, mask []string, item *pb.CredentialLibrary) (*vault.SshSignedCertCredentialLibrary, error) {
+	const op = "credentiallibraries.(Service).updateSshSignedCertInRepo"
+	cl, err := toStorageSshSignedCertLibrary(item.GetCredentialStoreId(), item)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	cl.PublicId = id
+
+	dbMask := sshSignedCertMaskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, rowsUpdated, err := repo.UpdateSshSignedCertCredentialLibrary(ctx, projId, cl, item.GetVersion(), dbMask)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update credential library"))
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Credential Library %q doesn't exist or incorrect version provided.", id)
+	}
+	return out, nil
+}
+
 func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool, error) {
 	const op = "credentiallibraries.(Service).deleteFromRepo"
 	repo, err := s.repoFn()
 	if err != nil {
 		return false, err
 	}
-	rows, err := repo.DeleteCredentialLibrary(ctx, scopeId, id)
+	var rows int
+	switch credential.SubtypeFromId(id) {
+	case vault.SshSignedCertSubtype:
+		rows, err = repo.DeleteSshSignedCertCredentialLibrary(ctx, scopeId, id)
+	default:
+		rows, err = repo.DeleteCredentialLibrary(ctx, scopeId, id)
+	}
 	if err != nil {
 		if errors.IsNotFoundError(err) {
 			return false, nil
@@ -390,6 +497,17 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 				return res
 			}
 			parentId = cl.GetStoreId()
+		case vault.SshSignedCertSubtype:
+			cl, err := repo.LookupSshSignedCertCredentialLibrary(ctx, id)
+			if err != nil {
+				res.Error = err
+				return res
+			}
+			if cl == nil {
+				res.Error = handlers.NotFoundError()
+				return res
+			}
+			parentId = cl.GetStoreId()
 		default:
 			res.Error = errors.New(ctx, errors.InvalidParameter, op, "unrecognized credential library subtype from id")
 			return res
@@ -463,6 +581,30 @@ func toProto(in credential.Library, opt ...handlers.Option) (*pb.CredentialLibra
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		out.AuthorizedActions = opts.WithAuthorizedActions
 	}
+	if outputFields.Has(globals.CredentialTypeField) && in.CredentialType() != credential.UnspecifiedType {
+		out.CredentialType = in.CredentialType().String()
+	}
+	if outputFields.Has(globals.CredentialMappingOverridesField) {
+		if vaultIn, ok := in.(*vault.CredentialLibrary); ok {
+			overrides := &pb.CredentialMappingOverrides{}
+			if a := vaultIn.GetUsernameAttribute(); a != "" {
+				overrides.UsernameAttribute = wrapperspb.String(a)
+			}
+			if a := vaultIn.GetPasswordAttribute(); a != "" {
+				overrides.PasswordAttribute = wrapperspb.String(a)
+			}
+			if a := vaultIn.GetPrivateKeyAttribute(); a != "" {
+				overrides.PrivateKeyAttribute = wrapperspb.String(a)
+			}
+			if proto.Size(overrides) > 0 {
+				var err error
+				out.CredentialMappingOverrides, err = handlers.ProtoToStruct(overrides)
+				if err != nil {
+					return nil, errors.WrapDeprecated(err, op, errors.WithMsg("failed to convert resource from storage to api"))
+				}
+			}
+		}
+	}
 	if outputFields.Has(globals.AttributesField) {
 		switch credential.SubtypeFromId(in.GetPublicId()) {
 		case vault.Subtype:
@@ -484,6 +626,30 @@ func toProto(in credential.Library, opt ...handlers.Option) (*pb.CredentialLibra
 			if err != nil {
 				return nil, errors.WrapDeprecated(err, op, errors.WithMsg("failed to convert resource from storage to api"))
 			}
+		case vault.SshSignedCertSubtype:
+			sshIn, ok := in.(*vault.SshSignedCertCredentialLibrary)
+			if !ok {
+				return nil, errors.NewDeprecated(errors.Internal, op, "unable to cast to vault ssh signed cert credential library")
+			}
+			attrs := &pb.VaultSshSignedCertCredentialLibraryAttributes{
+				Path:            wrapperspb.String(sshIn.GetVaultPath()),
+				ValidPrincipals: sshIn.Principals(),
+			}
+			if sshIn.GetTtl() != "" {
+				attrs.Ttl = wrapperspb.String(sshIn.GetTtl())
+			}
+			if sshIn.GetExtensions() != "" {
+				ext := &structpb.Struct{}
+				if err := protojson.Unmarshal([]byte(sshIn.GetExtensions()), ext); err != nil {
+					return nil, errors.WrapDeprecated(err, op, errors.WithMsg("failed to convert extensions from storage to api"))
+				}
+				attrs.Extensions = ext
+			}
+			var err error
+			out.Attributes, err = handlers.ProtoToStruct(attrs)
+			if err != nil {
+				return nil, errors.WrapDeprecated(err, op, errors.WithMsg("failed to convert resource from storage to api"))
+			}
 		}
 	}
 	return &out, nil
@@ -511,6 +677,23 @@ func toStorageVaultLibrary(storeId string, in *pb.CredentialLibrary) (out *vault
 		opts = append(opts, vault.WithRequestBody([]byte(attrs.GetHttpRequestBody().GetValue())))
 	}
 
+	if ct := in.GetCredentialType(); ct != "" {
+		opts = append(opts, vault.WithCredentialType(credential.Type(ct)))
+	}
+	overrides := &pb.CredentialMappingOverrides{}
+	if err := handlers.StructToProto(in.GetCredentialMappingOverrides(), overrides); err != nil {
+		return nil, errors.WrapDeprecated(err, op, errors.WithMsg("unable to parse the credential mapping overrides"))
+	}
+	if overrides.GetUsernameAttribute() != nil {
+		opts = append(opts, vault.WithUsernameAttribute(overrides.GetUsernameAttribute().GetValue()))
+	}
+	if overrides.GetPasswordAttribute() != nil {
+		opts = append(opts, vault.WithPasswordAttribute(overrides.GetPasswordAttribute().GetValue()))
+	}
+	if overrides.GetPrivateKeyAttribute() != nil {
+		opts = append(opts, vault.WithPrivateKeyAttribute(overrides.GetPrivateKeyAttribute().GetValue()))
+	}
+
 	cs, err := vault.NewCredentialLibrary(storeId, attrs.GetPath().GetValue(), opts...)
 	if err != nil {
 		return nil, errors.WrapDeprecated(err, op, errors.WithMsg("unable to build credential library"))
@@ -518,13 +701,45 @@ func toStorageVaultLibrary(storeId string, in *pb.CredentialLibrary) (out *vault
 	return cs, err
 }
 
+func toStorageSshSignedCertLibrary(storeId string, in *pb.CredentialLibrary) (out *vault.SshSignedCertCredentialLibrary, err error) {
+	const op = "credentiallibraries.toStorageSshSignedCertLibrary"
+	var opts []vault.Option
+	if in.GetName() != nil {
+		opts = append(opts, vault.WithName(in.GetName().GetValue()))
+	}
+	if in.GetDescription() != nil {
+		opts = append(opts, vault.WithDescription(in.GetDescription().GetValue()))
+	}
+
+	attrs := &pb.VaultSshSignedCertCredentialLibraryAttributes{}
+	if err := handlers.StructToProto(in.GetAttributes(), attrs); err != nil {
+		return nil, errors.WrapDeprecated(err, op, errors.WithMsg("unable to parse the attributes"))
+	}
+	if attrs.GetTtl() != nil {
+		opts = append(opts, vault.WithTtl(attrs.GetTtl().GetValue()))
+	}
+	if attrs.GetExtensions() != nil {
+		ext := make(map[string]string, len(attrs.GetExtensions().GetFields()))
+		for k, v := range attrs.GetExtensions().GetFields() {
+			ext[k] = v.GetStringValue()
+		}
+		opts = append(opts, vault.WithExtensions(ext))
+	}
+
+	cl, err := vault.NewSshSignedCertCredentialLibrary(storeId, attrs.GetPath().GetValue(), attrs.GetValidPrincipals(), opts...)
+	if err != nil {
+		return nil, errors.WrapDeprecated(err, op, errors.WithMsg("unable to build credential library"))
+	}
+	return cl, nil
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
 //  * The path passed in is correctly formatted
 //  * All required parameters are set
 //  * There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetCredentialLibraryRequest) error {
-	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, vault.CredentialLibraryPrefix)
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, vault.CredentialLibraryPrefix, vault.SshSignedCertCredentialLibraryPrefix)
 }
 
 func validateCreateRequest(req *pbs.CreateCredentialLibraryRequest) error {
@@ -532,6 +747,10 @@ func validateCreateRequest(req *pbs.CreateCredentialLibraryRequest) error {
 		badFields := map[string]string{}
 		switch credential.SubtypeFromId(req.GetItem().GetCredentialStoreId()) {
 		case vault.Subtype:
+			if credential.SubtypeFromType(req.GetItem().GetType()) == vault.SshSignedCertSubtype {
+				validateSshSignedCertCreateRequest(req.GetItem(), badFields)
+				break
+			}
 			if t := req.GetItem().GetType(); t != "" && credential.SubtypeFromType(t) != vault.Subtype {
 				badFields[globals.CredentialStoreIdField] = "If included, type must match that of the credential store."
 			}
@@ -549,6 +768,7 @@ func validateCreateRequest(req *pbs.CreateCredentialLibraryRequest) error {
 			if b := attrs.GetHttpRequestBody(); b != nil && strings.ToUpper(attrs.GetHttpMethod().GetValue()) != "POST" {
 				badFields[httpRequestBodyField] = fmt.Sprintf("Field can only be set if %q is set to the value 'POST'.", httpMethodField)
 			}
+			validateCredentialMapping(req.GetItem(), badFields)
 		default:
 			badFields[globals.CredentialStoreIdField] = "This field must be a valid credential store id."
 		}
@@ -556,6 +776,113 @@ func validateCreateRequest(req *pbs.CreateCredentialLibraryRequest) error {
 	})
 }
 
+// validateSshSignedCertCreateRequest checks the attributes of a
+// vault-ssh-signed-cert credential library being created.
+func validateSshSignedCertCreateRequest(item *pb.CredentialLibrary, badFields map[string]string) {
+	attrs := &pb.VaultSshSignedCertCredentialLibraryAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), attrs); err != nil {
+		badFields[globals.AttributesField] = "Attribute fields do not match the expected format."
+		return
+	}
+	if attrs.GetPath().GetValue() == "" {
+		badFields[vaultPathField] = "This is a required field."
+	}
+	validateSshSignedCertAttributes(attrs, nil, badFields)
+	if ct := item.GetCredentialType(); ct != "" && credential.Type(ct) != credential.SshCertificateType {
+		badFields[globals.CredentialTypeField] = fmt.Sprintf("If set, value must be %q.", credential.SshCertificateType)
+	}
+	if item.GetCredentialMappingOverrides() != nil {
+		badFields[globals.CredentialMappingOverridesField] = "This field is not supported for this credential library type."
+	}
+}
+
+// validateSshSignedCertAttributes checks the principals, ttl, and
+// extensions of attrs. If mask is not nil, only the fields in mask are
+// checked.
+func validateSshSignedCertAttributes(attrs *pb.VaultSshSignedCertCredentialLibraryAttributes, mask []string, badFields map[string]string) {
+	if mask == nil || handlers.MaskContains(mask, validPrincipalsField) {
+		if len(attrs.GetValidPrincipals()) == 0 {
+			badFields[validPrincipalsField] = "This is a required field."
+		}
+		for _, p := range attrs.GetValidPrincipals() {
+			if strings.TrimSpace(p) == "" || strings.Contains(p, ",") {
+				badFields[validPrincipalsField] = "Principals cannot be empty or contain commas."
+				break
+			}
+		}
+	}
+	if t := attrs.GetTtl(); t != nil && strings.TrimSpace(t.GetValue()) == "" {
+		badFields[ttlField] = "If set, this field cannot be empty."
+	}
+	for k, v := range attrs.GetExtensions().GetFields() {
+		if _, ok := v.GetKind().(*structpb.Value_StringValue); !ok {
+			badFields[extensionsField] = fmt.Sprintf("The value of extension %q must be a string.", k)
+			break
+		}
+	}
+}
+
+// validateCredentialMapping checks the credential type and the credential
+// mapping overrides of a credential library being created.
+func validateCredentialMapping(item *pb.CredentialLibrary, badFields map[string]string) {
+	overrides := validateCredentialMappingOverrides(item, badFields)
+	if overrides == nil {
+		return
+	}
+
+	username := defaultIfEmpty(overrides.GetUsernameAttribute().GetValue(), "username")
+	switch ct := credential.Type(item.GetCredentialType()); ct {
+	case "", credential.UnspecifiedType:
+		if proto.Size(overrides) > 0 {
+			badFields[globals.CredentialMappingOverridesField] = fmt.Sprintf("Field can only be set if %q is set.", globals.CredentialTypeField)
+		}
+	case credential.UsernamePasswordType:
+		if overrides.GetPrivateKeyAttribute() != nil {
+			badFields[privateKeyAttributeField] = fmt.Sprintf("Field can only be set if %q is set to the value %q.", globals.CredentialTypeField, credential.SshPrivateKeyType)
+		}
+		if password := defaultIfEmpty(overrides.GetPasswordAttribute().GetValue(), "password"); password == username {
+			badFields[passwordAttributeField] = fmt.Sprintf("Field cannot map to the same attribute as %q.", usernameAttributeField)
+		}
+	case credential.SshPrivateKeyType:
+		if overrides.GetPasswordAttribute() != nil {
+			badFields[passwordAttributeField] = fmt.Sprintf("Field can only be set if %q is set to the value %q.", globals.CredentialTypeField, credential.UsernamePasswordType)
+		}
+		if privateKey := defaultIfEmpty(overrides.GetPrivateKeyAttribute().GetValue(), "private_key"); privateKey == username {
+			badFields[privateKeyAttributeField] = fmt.Sprintf("Field cannot map to the same attribute as %q.", usernameAttributeField)
+		}
+	default:
+		badFields[globals.CredentialTypeField] = fmt.Sprintf("If set, value must be %q or %q.", credential.UsernamePasswordType, credential.SshPrivateKeyType)
+	}
+}
+
+// validateCredentialMappingOverrides checks that the credential mapping
+// overrides of item can be parsed and that none of them are empty. It
+// returns nil if the overrides cannot be parsed.
+func validateCredentialMappingOverrides(item *pb.CredentialLibrary, badFields map[string]string) *pb.CredentialMappingOverrides {
+	overrides := &pb.CredentialMappingOverrides{}
+	if err := handlers.StructToProto(item.GetCredentialMappingOverrides(), overrides); err != nil {
+		badFields[globals.CredentialMappingOverridesField] = "Credential mapping override fields do not match the expected format."
+		return nil
+	}
+	for f, v := range map[string]*wrapperspb.StringValue{
+		usernameAttributeField:   overrides.GetUsernameAttribute(),
+		passwordAttributeField:   overrides.GetPasswordAttribute(),
+		privateKeyAttributeField: overrides.GetPrivateKeyAttribute(),
+	} {
+		if v != nil && strings.TrimSpace(v.GetValue()) == "" {
+			badFields[f] = "If set, this field cannot be empty."
+		}
+	}
+	return overrides
+}
+
+func defaultIfEmpty(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
+
 func validateUpdateRequest(req *pbs.UpdateCredentialLibraryRequest) error {
 	return handlers.ValidateUpdateRequest(req, req.GetItem(), func() map[string]string {
 		badFields := map[string]string{}
@@ -578,13 +905,36 @@ func validateUpdateRequest(req *pbs.UpdateCredentialLibraryRequest) error {
 			if b := attrs.GetHttpRequestBody(); b != nil && strings.ToUpper(attrs.GetHttpMethod().GetValue()) == "GET" {
 				badFields[httpRequestBodyField] = fmt.Sprintf("Field can only be set if %q is set to the value 'POST'.", httpMethodField)
 			}
+			if req.GetItem().GetCredentialType() != "" || handlers.MaskContains(req.GetUpdateMask().GetPaths(), globals.CredentialTypeField) {
+				badFields[globals.CredentialTypeField] = "This field cannot be modified."
+			}
+			validateCredentialMappingOverrides(req.GetItem(), badFields)
+		case vault.SshSignedCertSubtype:
+			if req.GetItem().GetType() != "" && credential.SubtypeFromType(req.GetItem().GetType()) != vault.SshSignedCertSubtype {
+				badFields[globals.TypeField] = "Cannot modify resource type."
+			}
+			attrs := &pb.VaultSshSignedCertCredentialLibraryAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+				badFields[globals.AttributesField] = "Attribute fields do not match the expected format."
+				break
+			}
+			if handlers.MaskContains(req.GetUpdateMask().GetPaths(), vaultPathField) && attrs.GetPath().GetValue() == "" {
+				badFields[vaultPathField] = "This is a required field and cannot be set to empty."
+			}
+			validateSshSignedCertAttributes(attrs, req.GetUpdateMask().GetPaths(), badFields)
+			if req.GetItem().GetCredentialType() != "" || handlers.MaskContains(req.GetUpdateMask().GetPaths(), globals.CredentialTypeField) {
+				badFields[globals.CredentialTypeField] = "This field cannot be modified."
+			}
+			if req.GetItem().GetCredentialMappingOverrides() != nil {
+				badFields[globals.CredentialMappingOverridesField] = "This field is not supported for this credential library type."
+			}
 		}
 		return badFields
-	}, vault.CredentialLibraryPrefix)
+	}, vault.CredentialLibraryPrefix, vault.SshSignedCertCredentialLibraryPrefix)
 }
 
 func validateDeleteRequest(req *pbs.DeleteCredentialLibraryRequest) error {
-	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, vault.CredentialLibraryPrefix)
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, vault.CredentialLibraryPrefix, vault.SshSignedCertCredentialLibraryPrefix)
 }
 
 func validateListRequest(req *pbs.ListCredentialLibrariesRequest) error {
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/credential"
 	"github.com/hashicorp/boundary/internal/credential/vault"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
@@ -364,6 +365,143 @@ func TestCreate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Invalid credential type",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				CredentialType:    "certificate",
+				Attributes: func() *structpb.Struct {
+					attrs, err := handlers.ProtoToStruct(&pb.VaultCredentialLibraryAttributes{
+						Path: wrapperspb.String("something"),
+					})
+					require.NoError(t, err)
+					return attrs
+				}(),
+			}},
+			res: nil,
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Mapping overrides without credential type",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Attributes: func() *structpb.Struct {
+					attrs, err := handlers.ProtoToStruct(&pb.VaultCredentialLibraryAttributes{
+						Path: wrapperspb.String("something"),
+					})
+					require.NoError(t, err)
+					return attrs
+				}(),
+				CredentialMappingOverrides: func() *structpb.Struct {
+					overrides, err := handlers.ProtoToStruct(&pb.CredentialMappingOverrides{
+						UsernameAttribute: wrapperspb.String("user"),
+					})
+					require.NoError(t, err)
+					return overrides
+				}(),
+			}},
+			res: nil,
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Private key attribute with username password type",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				CredentialType:    string(credential.UsernamePasswordType),
+				Attributes: func() *structpb.Struct {
+					attrs, err := handlers.ProtoToStruct(&pb.VaultCredentialLibraryAttributes{
+						Path: wrapperspb.String("something"),
+					})
+					require.NoError(t, err)
+					return attrs
+				}(),
+				CredentialMappingOverrides: func() *structpb.Struct {
+					overrides, err := handlers.ProtoToStruct(&pb.CredentialMappingOverrides{
+						PrivateKeyAttribute: wrapperspb.String("key"),
+					})
+					require.NoError(t, err)
+					return overrides
+				}(),
+			}},
+			res: nil,
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Username and password mapped to the same attribute",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				CredentialType:    string(credential.UsernamePasswordType),
+				Attributes: func() *structpb.Struct {
+					attrs, err := handlers.ProtoToStruct(&pb.VaultCredentialLibraryAttributes{
+						Path: wrapperspb.String("something"),
+					})
+					require.NoError(t, err)
+					return attrs
+				}(),
+				CredentialMappingOverrides: func() *structpb.Struct {
+					overrides, err := handlers.ProtoToStruct(&pb.CredentialMappingOverrides{
+						UsernameAttribute: wrapperspb.String("password"),
+					})
+					require.NoError(t, err)
+					return overrides
+				}(),
+			}},
+			res: nil,
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Create a username password CredentialLibrary",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				CredentialType:    string(credential.UsernamePasswordType),
+				Attributes: func() *structpb.Struct {
+					attrs, err := handlers.ProtoToStruct(&pb.VaultCredentialLibraryAttributes{
+						Path: wrapperspb.String("something"),
+					})
+					require.NoError(t, err)
+					return attrs
+				}(),
+				CredentialMappingOverrides: func() *structpb.Struct {
+					overrides, err := handlers.ProtoToStruct(&pb.CredentialMappingOverrides{
+						UsernameAttribute: wrapperspb.String("user"),
+						PasswordAttribute: wrapperspb.String("pass"),
+					})
+					require.NoError(t, err)
+					return overrides
+				}(),
+			}},
+			idPrefix: vault.CredentialLibraryPrefix + "_",
+			res: &pbs.CreateCredentialLibraryResponse{
+				Uri: fmt.Sprintf("credential-libraries/%s_", vault.CredentialLibraryPrefix),
+				Item: &pb.CredentialLibrary{
+					Id:                store.GetPublicId(),
+					CredentialStoreId: store.GetPublicId(),
+					CreatedTime:       store.GetCreateTime().GetTimestamp(),
+					UpdatedTime:       store.GetUpdateTime().GetTimestamp(),
+					Scope:             &scopepb.ScopeInfo{Id: prj.GetPublicId(), Type: prj.GetType(), ParentScopeId: prj.GetParentId()},
+					Version:           1,
+					Type:              vault.Subtype.String(),
+					CredentialType:    string(credential.UsernamePasswordType),
+					Attributes: func() *structpb.Struct {
+						attrs, err := handlers.ProtoToStruct(&pb.VaultCredentialLibraryAttributes{
+							Path:       wrapperspb.String("something"),
+							HttpMethod: wrapperspb.String("GET"),
+						})
+						require.NoError(t, err)
+						return attrs
+					}(),
+					CredentialMappingOverrides: func() *structpb.Struct {
+						overrides, err := handlers.ProtoToStruct(&pb.CredentialMappingOverrides{
+							UsernameAttribute: wrapperspb.String("user"),
+							PasswordAttribute: wrapperspb.String("pass"),
+						})
+						require.NoError(t, err)
+						return overrides
+					}(),
+					AuthorizedActions: testAuthorizedActions,
+				},
+			},
+		},
 		{
 			name: "Create a valid vault CredentialLibrary",
 			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
@@ -782,6 +920,11 @@ func TestUpdate(t *testing.T) {
 			path: "authorized actions",
 			item: &pb.CredentialLibrary{AuthorizedActions: append(testAuthorizedActions, "another")},
 		},
+		{
+			name: "read only credential type",
+			path: "credential_type",
+			item: &pb.CredentialLibrary{CredentialType: string(credential.SshPrivateKeyType)},
+		},
 	}
 	for _, tc := range errCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -879,3 +1022,559 @@ func TestUpdate(t *testing.T) {
 		assert.Nil(t, cl.GetItem().GetAttributes().GetFields()["http_request_body"])
 	})
 }
+
+func TestList_SshSignedCert(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*vault.Repository, error) {
+		return vault.NewRepository(rw, rw, kms, sche)
+	}
+
+	_, prj := iam.TestScopes(t, iamRepo)
+	store := vault.TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 1)[0]
+
+	var wantLibraries []*pb.CredentialLibrary
+	for _, l := range vault.TestCredentialLibraries(t, conn, wrapper, store.GetPublicId(), 2) {
+		wantLibraries = append(wantLibraries, &pb.CredentialLibrary{
+			Id:                l.GetPublicId(),
+			CredentialStoreId: l.GetStoreId(),
+			Scope:             &scopepb.ScopeInfo{Id: prj.GetPublicId(), Type: scope.Project.String(), ParentScopeId: prj.GetParentId()},
+			CreatedTime:       l.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       l.GetUpdateTime().GetTimestamp(),
+			Version:           l.GetVersion(),
+			Type:              vault.Subtype.String(),
+			AuthorizedActions: testAuthorizedActions,
+			Attributes: func() *structpb.Struct {
+				attrs, err := handlers.ProtoToStruct(&pb.VaultCredentialLibraryAttributes{
+					Path:       wrapperspb.String(l.GetVaultPath()),
+					HttpMethod: wrapperspb.String(l.GetHttpMethod()),
+				})
+				require.NoError(t, err)
+				return attrs
+			}(),
+		})
+	}
+	var wantSshLibraries []*pb.CredentialLibrary
+	for _, l := range vault.TestSshSignedCertCredentialLibraries(t, conn, wrapper, store.GetPublicId(), 2) {
+		wantSshLibraries = append(wantSshLibraries, &pb.CredentialLibrary{
+			Id:                l.GetPublicId(),
+			CredentialStoreId: l.GetStoreId(),
+			Scope:             &scopepb.ScopeInfo{Id: prj.GetPublicId(), Type: scope.Project.String(), ParentScopeId: prj.GetParentId()},
+			CreatedTime:       l.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       l.GetUpdateTime().GetTimestamp(),
+			Version:           l.GetVersion(),
+			Type:              vault.SshSignedCertSubtype.String(),
+			CredentialType:    string(credential.SshCertificateType),
+			AuthorizedActions: testAuthorizedActions,
+			Attributes: func() *structpb.Struct {
+				attrs, err := handlers.ProtoToStruct(&pb.VaultSshSignedCertCredentialLibraryAttributes{
+					Path:            wrapperspb.String(l.GetVaultPath()),
+					ValidPrincipals: l.Principals(),
+				})
+				require.NoError(t, err)
+				return attrs
+			}(),
+		})
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.ListCredentialLibrariesRequest
+		res  *pbs.ListCredentialLibrariesResponse
+	}{
+		{
+			name: "List All Library Types",
+			req:  &pbs.ListCredentialLibrariesRequest{CredentialStoreId: store.GetPublicId()},
+			res:  &pbs.ListCredentialLibrariesResponse{Items: append(append([]*pb.CredentialLibrary{}, wantLibraries...), wantSshLibraries...)},
+		},
+		{
+			name: "Filter to SSH Signed Cert Libraries",
+			req:  &pbs.ListCredentialLibrariesRequest{CredentialStoreId: store.GetPublicId(), Filter: fmt.Sprintf(`"/item/type"==%q`, vault.SshSignedCertSubtype.String())},
+			res:  &pbs.ListCredentialLibrariesResponse{Items: wantSshLibraries},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewService(repoFn, iamRepoFn)
+			require.NoError(t, err)
+
+			got, gErr := s.ListCredentialLibraries(auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId()), tc.req)
+			require.NoError(t, gErr)
+			sort.Slice(got.Items, func(i, j int) bool {
+				return got.Items[i].GetId() < got.Items[j].GetId()
+			})
+			want := proto.Clone(tc.res).(*pbs.ListCredentialLibrariesResponse)
+			sort.Slice(want.Items, func(i, j int) bool {
+				return want.Items[i].GetId() < want.Items[j].GetId()
+			})
+			assert.Empty(t, cmp.Diff(got, want, protocmp.Transform()))
+		})
+	}
+}
+
+func TestCreate_SshSignedCert(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*vault.Repository, error) {
+		return vault.NewRepository(rw, rw, kms, sche)
+	}
+
+	_, prj := iam.TestScopes(t, iamRepo)
+	store := vault.TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 1)[0]
+
+	attrsFn := func(attrs *pb.VaultSshSignedCertCredentialLibraryAttributes) *structpb.Struct {
+		st, err := handlers.ProtoToStruct(attrs)
+		require.NoError(t, err)
+		return st
+	}
+	extensions, err := structpb.NewStruct(map[string]interface{}{"permit-pty": ""})
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		req  *pbs.CreateCredentialLibraryRequest
+		res  *pbs.CreateCredentialLibraryResponse
+		err  error
+	}{
+		{
+			name: "missing vault path",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.SshSignedCertSubtype.String(),
+				Attributes: attrsFn(&pb.VaultSshSignedCertCredentialLibraryAttributes{
+					ValidPrincipals: []string{"ubuntu"},
+				}),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "missing valid principals",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.SshSignedCertSubtype.String(),
+				Attributes: attrsFn(&pb.VaultSshSignedCertCredentialLibraryAttributes{
+					Path: wrapperspb.String("ssh/sign/role"),
+				}),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "principal with comma",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.SshSignedCertSubtype.String(),
+				Attributes: attrsFn(&pb.VaultSshSignedCertCredentialLibraryAttributes{
+					Path:            wrapperspb.String("ssh/sign/role"),
+					ValidPrincipals: []string{"ubuntu,admin"},
+				}),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "empty ttl",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.SshSignedCertSubtype.String(),
+				Attributes: attrsFn(&pb.VaultSshSignedCertCredentialLibraryAttributes{
+					Path:            wrapperspb.String("ssh/sign/role"),
+					ValidPrincipals: []string{"ubuntu"},
+					Ttl:             wrapperspb.String(" "),
+				}),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "non string extension",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.SshSignedCertSubtype.String(),
+				Attributes: attrsFn(&pb.VaultSshSignedCertCredentialLibraryAttributes{
+					Path:            wrapperspb.String("ssh/sign/role"),
+					ValidPrincipals: []string{"ubuntu"},
+					Extensions: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"permit-pty": structpb.NewBoolValue(true),
+					}},
+				}),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "wrong credential type",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.SshSignedCertSubtype.String(),
+				CredentialType:    string(credential.SshPrivateKeyType),
+				Attributes: attrsFn(&pb.VaultSshSignedCertCredentialLibraryAttributes{
+					Path:            wrapperspb.String("ssh/sign/role"),
+					ValidPrincipals: []string{"ubuntu"},
+				}),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "credential mapping overrides",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.SshSignedCertSubtype.String(),
+				Attributes: attrsFn(&pb.VaultSshSignedCertCredentialLibraryAttributes{
+					Path:            wrapperspb.String("ssh/sign/role"),
+					ValidPrincipals: []string{"ubuntu"},
+				}),
+				CredentialMappingOverrides: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"username_attribute": structpb.NewStringValue("user"),
+				}},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Create a valid ssh signed cert library",
+			req: &pbs.CreateCredentialLibraryRequest{Item: &pb.CredentialLibrary{
+				CredentialStoreId: store.GetPublicId(),
+				Type:              vault.SshSignedCertSubtype.String(),
+				Name:              wrapperspb.String("name"),
+				Description:       wrapperspb.String("desc"),
+				Attributes: attrsFn(&pb.VaultSshSignedCertCredentialLibraryAttributes{
+					Path:            wrapperspb.String("ssh/sign/role"),
+					ValidPrincipals: []string{"ubuntu", "admin"},
+					Ttl:             wrapperspb.String("10m"),
+					Extensions:      extensions,
+				}),
+			}},
+			res: &pbs.CreateCredentialLibraryResponse{
+				Item: &pb.CredentialLibrary{
+					CredentialStoreId: store.GetPublicId(),
+					Name:              wrapperspb.String("name"),
+					Description:       wrapperspb.String("desc"),
+					Scope:             &scopepb.ScopeInfo{Id: prj.GetPublicId(), Type: prj.GetType(), ParentScopeId: prj.GetParentId()},
+					Version:           1,
+					Type:              vault.SshSignedCertSubtype.String(),
+					CredentialType:    string(credential.SshCertificateType),
+					Attributes: attrsFn(&pb.VaultSshSignedCertCredentialLibraryAttributes{
+						Path:            wrapperspb.String("ssh/sign/role"),
+						ValidPrincipals: []string{"ubuntu", "admin"},
+						Ttl:             wrapperspb.String("10m"),
+						Extensions:      extensions,
+					}),
+					AuthorizedActions: testAuthorizedActions,
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			s, err := NewService(repoFn, iamRepoFn)
+			require.NoError(err)
+
+			got, gErr := s.CreateCredentialLibrary(auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "CreateCredentialLibrary(...) got error %v, wanted %v", gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			require.NotNil(got)
+			assert.True(strings.HasPrefix(got.GetItem().GetId(), vault.SshSignedCertCredentialLibraryPrefix+"_"))
+			assert.Equal(fmt.Sprintf("credential-libraries/%s", got.GetItem().GetId()), got.GetUri())
+
+			// Clear all values which are hard to compare against.
+			got.Uri = ""
+			got.Item.Id = ""
+			got.Item.CreatedTime, got.Item.UpdatedTime = nil, nil
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()))
+		})
+	}
+}
+
+func TestGet_SshSignedCert(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*vault.Repository, error) {
+		return vault.NewRepository(rw, rw, kms, sche)
+	}
+
+	_, prj := iam.TestScopes(t, iamRepo)
+
+	store := vault.TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 1)[0]
+	sl := vault.TestSshSignedCertCredentialLibraries(t, conn, wrapper, store.GetPublicId(), 1)[0]
+	s, err := NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		id   string
+		res  *pbs.GetCredentialLibraryResponse
+		err  error
+	}{
+		{
+			name: "success",
+			id:   sl.GetPublicId(),
+			res: &pbs.GetCredentialLibraryResponse{
+				Item: &pb.CredentialLibrary{
+					Id:                sl.GetPublicId(),
+					CredentialStoreId: sl.GetStoreId(),
+					Scope:             &scopepb.ScopeInfo{Id: store.GetScopeId(), Type: scope.Project.String(), ParentScopeId: prj.GetParentId()},
+					Type:              vault.SshSignedCertSubtype.String(),
+					CredentialType:    string(credential.SshCertificateType),
+					AuthorizedActions: testAuthorizedActions,
+					CreatedTime:       sl.CreateTime.GetTimestamp(),
+					UpdatedTime:       sl.UpdateTime.GetTimestamp(),
+					Version:           1,
+					Attributes: func() *structpb.Struct {
+						attrs, err := handlers.ProtoToStruct(&pb.VaultSshSignedCertCredentialLibraryAttributes{
+							Path:            wrapperspb.String(sl.GetVaultPath()),
+							ValidPrincipals: sl.Principals(),
+						})
+						require.NoError(t, err)
+						return attrs
+					}(),
+				},
+			},
+		},
+		{
+			name: "not found error",
+			id:   fmt.Sprintf("%s_1234567890", vault.SshSignedCertCredentialLibraryPrefix),
+			err:  handlers.NotFoundError(),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gErr := s.GetCredentialLibrary(auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId()), &pbs.GetCredentialLibraryRequest{Id: tc.id})
+			if tc.err != nil {
+				require.Error(t, gErr)
+				assert.True(t, errors.Is(gErr, tc.err))
+				return
+			}
+			require.NoError(t, gErr)
+			assert.Empty(t, cmp.Diff(got, tc.res, protocmp.Transform()))
+		})
+	}
+}
+
+func TestUpdate_SshSignedCert(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*vault.Repository, error) {
+		return vault.NewRepository(rw, rw, kms, sche)
+	}
+
+	_, prj := iam.TestScopes(t, iamRepo)
+	ctx := auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId())
+
+	s, err := NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+	store := vault.TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 1)[0]
+
+	freshLibrary := func() (*vault.SshSignedCertCredentialLibrary, func()) {
+		sl := vault.TestSshSignedCertCredentialLibraries(t, conn, wrapper, store.GetPublicId(), 1)[0]
+		clean := func() {
+			_, err := s.DeleteCredentialLibrary(ctx, &pbs.DeleteCredentialLibraryRequest{Id: sl.GetPublicId()})
+			require.NoError(t, err)
+		}
+		return sl, clean
+	}
+
+	fieldmask := func(paths ...string) *fieldmaskpb.FieldMask {
+		return &fieldmaskpb.FieldMask{Paths: paths}
+	}
+	attrsFn := func(attrs *pb.VaultSshSignedCertCredentialLibraryAttributes) *structpb.Struct {
+		st, err := handlers.ProtoToStruct(attrs)
+		require.NoError(t, err)
+		return st
+	}
+	extensions, err := structpb.NewStruct(map[string]interface{}{"permit-pty": ""})
+	require.NoError(t, err)
+
+	successCases := []struct {
+		name string
+		req  *pbs.UpdateCredentialLibraryRequest
+		res  func(*pb.CredentialLibrary) *pb.CredentialLibrary
+	}{
+		{
+			name: "name and description",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: fieldmask("name", "description"),
+				Item: &pb.CredentialLibrary{
+					Name:        wrapperspb.String("basic"),
+					Description: wrapperspb.String("basic"),
+				},
+			},
+			res: func(in *pb.CredentialLibrary) *pb.CredentialLibrary {
+				out := proto.Clone(in).(*pb.CredentialLibrary)
+				out.Name = wrapperspb.String("basic")
+				out.Description = wrapperspb.String("basic")
+				return out
+			},
+		},
+		{
+			name: "path and principals",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: fieldmask(vaultPathField, validPrincipalsField),
+				Item: &pb.CredentialLibrary{
+					Attributes: attrsFn(&pb.VaultSshSignedCertCredentialLibraryAttributes{
+						Path:            wrapperspb.String("ssh/sign/other"),
+						ValidPrincipals: []string{"admin", "ubuntu"},
+					}),
+				},
+			},
+			res: func(in *pb.CredentialLibrary) *pb.CredentialLibrary {
+				out := proto.Clone(in).(*pb.CredentialLibrary)
+				out.Attributes = attrsFn(&pb.VaultSshSignedCertCredentialLibraryAttributes{
+					Path:            wrapperspb.String("ssh/sign/other"),
+					ValidPrincipals: []string{"admin", "ubuntu"},
+				})
+				return out
+			},
+		},
+		{
+			name: "ttl and extensions",
+			req: &pbs.UpdateCredentialLibraryRequest{
+				UpdateMask: fieldmask(ttlField, extensionsField),
+				Item: &pb.CredentialLibrary{
+					Attributes: attrsFn(&pb.VaultSshSignedCertCredentialLibraryAttributes{
+						Ttl:        wrapperspb.String("1h"),
+						Extensions: extensions,
+					}),
+				},
+			},
+			res: func(in *pb.CredentialLibrary) *pb.CredentialLibrary {
+				out := proto.Clone(in).(*pb.CredentialLibrary)
+				out.Attributes.Fields["ttl"] = structpb.NewStringValue("1h")
+				out.Attributes.Fields["extensions"] = structpb.NewStructValue(extensions)
+				return out
+			},
+		},
+	}
+	for _, tc := range successCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			sl, cleanup := freshLibrary()
+			defer cleanup()
+
+			tc.req.Id = sl.GetPublicId()
+			tc.req.Item.Version = 1
+			resToChange, err := s.GetCredentialLibrary(ctx, &pbs.GetCredentialLibraryRequest{Id: sl.GetPublicId()})
+			require.NoError(err)
+			want := &pbs.UpdateCredentialLibraryResponse{Item: tc.res(resToChange.GetItem())}
+
+			got, gErr := s.UpdateCredentialLibrary(ctx, tc.req)
+			require.NoError(gErr)
+			require.NotNil(got)
+
+			want.Item.UpdatedTime = got.Item.UpdatedTime
+			assert.EqualValues(2, got.Item.Version)
+			want.Item.Version = 2
+
+			assert.Empty(cmp.Diff(got, want, protocmp.Transform()))
+		})
+	}
+
+	sl, cleanup := freshLibrary()
+	defer cleanup()
+
+	errCases := []struct {
+		name string
+		mask []string
+		item *pb.CredentialLibrary
+	}{
+		{
+			name: "change type",
+			mask: []string{"name"},
+			item: &pb.CredentialLibrary{Type: vault.Subtype.String(), Name: wrapperspb.String("name")},
+		},
+		{
+			name: "unset path",
+			mask: []string{vaultPathField},
+			item: &pb.CredentialLibrary{},
+		},
+		{
+			name: "unset valid principals",
+			mask: []string{validPrincipalsField},
+			item: &pb.CredentialLibrary{},
+		},
+		{
+			name: "principal with comma",
+			mask: []string{validPrincipalsField},
+			item: &pb.CredentialLibrary{
+				Attributes: attrsFn(&pb.VaultSshSignedCertCredentialLibraryAttributes{
+					ValidPrincipals: []string{"ubuntu,admin"},
+				}),
+			},
+		},
+		{
+			name: "read only credential type",
+			mask: []string{"credential_type"},
+			item: &pb.CredentialLibrary{CredentialType: string(credential.SshPrivateKeyType)},
+		},
+		{
+			name: "credential mapping overrides",
+			mask: []string{usernameAttributeField},
+			item: &pb.CredentialLibrary{
+				CredentialMappingOverrides: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"username_attribute": structpb.NewStringValue("user"),
+				}},
+			},
+		},
+	}
+	for _, tc := range errCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &pbs.UpdateCredentialLibraryRequest{
+				Id:         sl.GetPublicId(),
+				Item:       tc.item,
+				UpdateMask: fieldmask(tc.mask...),
+			}
+			req.Item.Version = sl.Version
+
+			got, gErr := s.UpdateCredentialLibrary(ctx, req)
+			assert.Error(t, gErr)
+			assert.Truef(t, errors.Is(gErr, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v, wanted invalid argument", gErr)
+			assert.Nil(t, got)
+		})
+	}
+
+	t.Run("wrong version", func(t *testing.T) {
+		got, gErr := s.UpdateCredentialLibrary(ctx, &pbs.UpdateCredentialLibraryRequest{
+			Id:         sl.GetPublicId(),
+			UpdateMask: fieldmask("name"),
+			Item: &pb.CredentialLibrary{
+				Version: sl.Version + 1,
+				Name:    wrapperspb.String("name"),
+			},
+		})
+		assert.Truef(t, errors.Is(gErr, handlers.NotFoundError()), "got error %v, wanted not found", gErr)
+		assert.Nil(t, got)
+	})
+}
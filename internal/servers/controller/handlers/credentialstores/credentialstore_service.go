@@ -34,6 +34,21 @@ const (
 	caCertsField        = "attributes.ca_cert"
 	clientCertField     = "attributes.client_certificate"
 	clientCertKeyField  = "attributes.certificate_key"
+
+	authMethodField       = "attributes.auth_method"
+	authMountPathField    = "attributes.auth_mount_path"
+	approleRoleIdField    = "attributes.approle_role_id"
+	approleSecretIdField  = "attributes.approle_secret_id"
+	approleSecretIdHmac   = "attributes.approle_secret_id_hmac"
+	kubernetesRoleField   = "attributes.kubernetes_role"
+	operationalStateField = "attributes.operational_state"
+
+	healthStatusField         = "attributes.health_status"
+	healthCheckTimeField      = "attributes.health_check_time"
+	healthFailedCheckField    = "attributes.health_failed_check"
+	healthMessageField        = "attributes.health_message"
+	tokenExpirationTimeField  = "attributes.token_expiration_time"
+	caCertExpirationTimeField = "attributes.ca_cert_expiration_time"
 )
 
 var (
@@ -62,7 +77,7 @@ var (
 
 func init() {
 	var err error
-	if maskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&store.CredentialStore{}, &store.Token{}, &store.ClientCertificate{}},
+	if maskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&store.CredentialStore{}, &store.Token{}, &store.ClientCertificate{}, &store.AuthMethod{}},
 		handlers.MaskSource{&pb.CredentialStore{}, &pb.VaultCredentialStoreAttributes{}}); err != nil {
 		panic(err)
 	}
@@ -535,6 +550,30 @@ func toProto(in credential.Store, opt ...handlers.Option) (*pb.CredentialStore,
 				}
 				attrs.ClientCertificateKeyHmac = base64.RawURLEncoding.EncodeToString(cc.GetCertificateKeyHmac())
 			}
+			if am := vaultIn.AuthMethod(); am != nil {
+				attrs.AuthMethod = wrapperspb.String(am.GetAuthMethod())
+				attrs.AuthMountPath = wrapperspb.String(am.GetMountPath())
+				if am.GetRoleId() != "" {
+					attrs.ApproleRoleId = wrapperspb.String(am.GetRoleId())
+				}
+				if len(am.GetSecretIdHmac()) != 0 {
+					attrs.ApproleSecretIdHmac = base64.RawURLEncoding.EncodeToString(am.GetSecretIdHmac())
+				}
+				if am.GetKubernetesRole() != "" {
+					attrs.KubernetesRole = wrapperspb.String(am.GetKubernetesRole())
+				}
+			}
+			if vaultIn.OperationalState() != "" {
+				attrs.OperationalState = string(vaultIn.OperationalState())
+			}
+			if h := vaultIn.HealthCheck(); h != nil {
+				attrs.HealthStatus = string(h.Status)
+				attrs.HealthCheckTime = h.CheckTime.GetTimestamp()
+				attrs.HealthFailedCheck = string(h.FailedCheck)
+				attrs.HealthMessage = h.Message
+				attrs.TokenExpirationTime = h.TokenExpirationTime.GetTimestamp()
+				attrs.CaCertExpirationTime = h.CaCertExpirationTime.GetTimestamp()
+			}
 
 			var err error
 			if out.Attributes, err = handlers.ProtoToStruct(attrs); err != nil {
@@ -593,6 +632,16 @@ func toStorageVaultStore(scopeId string, in *pb.CredentialStore) (out *vault.Cre
 		}
 		opts = append(opts, vault.WithClientCert(cc))
 	}
+	switch vault.AuthMethodType(attrs.GetAuthMethod().GetValue()) {
+	case "", vault.TokenAuthMethod:
+		if attrs.GetAuthMountPath() != nil || attrs.GetApproleRoleId() != nil ||
+			attrs.GetApproleSecretId() != nil || attrs.GetKubernetesRole() != nil {
+			// Updating the values of an existing auth method.
+			opts = append(opts, vault.WithAuthMethod(authMethodFromAttrs(attrs)))
+		}
+	default:
+		opts = append(opts, vault.WithAuthMethod(authMethodFromAttrs(attrs)))
+	}
 
 	cs, err := vault.NewCredentialStore(scopeId, attrs.GetAddress().GetValue(), []byte(attrs.GetToken().GetValue()), opts...)
 	if err != nil {
@@ -601,6 +650,21 @@ func toStorageVaultStore(scopeId string, in *pb.CredentialStore) (out *vault.Cre
 	return cs, err
 }
 
+// authMethodFromAttrs returns the possibly incomplete auth method set in
+// attrs. The repository validates the auth method after the update mask
+// is applied.
+func authMethodFromAttrs(attrs *pb.VaultCredentialStoreAttributes) *vault.AuthMethod {
+	return &vault.AuthMethod{
+		AuthMethod: &store.AuthMethod{
+			AuthMethod:     attrs.GetAuthMethod().GetValue(),
+			MountPath:      attrs.GetAuthMountPath().GetValue(),
+			RoleId:         attrs.GetApproleRoleId().GetValue(),
+			SecretId:       []byte(attrs.GetApproleSecretId().GetValue()),
+			KubernetesRole: attrs.GetKubernetesRole().GetValue(),
+		},
+	}
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
 //  * The path passed in is correctly formatted
@@ -626,12 +690,63 @@ func validateCreateRequest(req *pbs.CreateCredentialStoreRequest) error {
 			if attrs.GetAddress().GetValue() == "" {
 				badFields[addressField] = "Field required for creating a vault credential store."
 			}
-			if attrs.GetToken().GetValue() == "" {
-				badFields[vaultTokenField] = "Field required for creating a vault credential store."
+			switch vault.AuthMethodType(attrs.GetAuthMethod().GetValue()) {
+			case "", vault.TokenAuthMethod:
+				if attrs.GetToken().GetValue() == "" {
+					badFields[vaultTokenField] = "Field required for creating a vault credential store."
+				}
+				if attrs.GetAuthMountPath() != nil {
+					badFields[authMountPathField] = "Field only allowed with the approle or kubernetes auth method."
+				}
+			case vault.AppRoleAuthMethod:
+				if attrs.GetApproleRoleId().GetValue() == "" {
+					badFields[approleRoleIdField] = "Field required for the approle auth method."
+				}
+				if attrs.GetApproleSecretId().GetValue() == "" {
+					badFields[approleSecretIdField] = "Field required for the approle auth method."
+				}
+			case vault.KubernetesAuthMethod:
+				if attrs.GetKubernetesRole().GetValue() == "" {
+					badFields[kubernetesRoleField] = "Field required for the kubernetes auth method."
+				}
+			default:
+				badFields[authMethodField] = "Must be one of token, approle, or kubernetes."
+			}
+			if attrs.GetToken() != nil && attrs.GetAuthMethod().GetValue() != "" && attrs.GetAuthMethod().GetValue() != string(vault.TokenAuthMethod) {
+				badFields[vaultTokenField] = "Field only allowed with the token auth method."
+			}
+			if attrs.GetAuthMethod().GetValue() != string(vault.AppRoleAuthMethod) {
+				if attrs.GetApproleRoleId() != nil {
+					badFields[approleRoleIdField] = "Field only allowed with the approle auth method."
+				}
+				if attrs.GetApproleSecretId() != nil {
+					badFields[approleSecretIdField] = "Field only allowed with the approle auth method."
+				}
+			}
+			if attrs.GetAuthMethod().GetValue() != string(vault.KubernetesAuthMethod) && attrs.GetKubernetesRole() != nil {
+				badFields[kubernetesRoleField] = "Field only allowed with the kubernetes auth method."
 			}
 			if attrs.GetTokenHmac() != "" {
 				badFields[vaultTokenHmacField] = "This is a read only field."
 			}
+			if attrs.GetApproleSecretIdHmac() != "" {
+				badFields[approleSecretIdHmac] = "This is a read only field."
+			}
+			if attrs.GetOperationalState() != "" {
+				badFields[operationalStateField] = "This is a read only field."
+			}
+			for f, set := range map[string]bool{
+				healthStatusField:         attrs.GetHealthStatus() != "",
+				healthCheckTimeField:      attrs.GetHealthCheckTime() != nil,
+				healthFailedCheckField:    attrs.GetHealthFailedCheck() != "",
+				healthMessageField:        attrs.GetHealthMessage() != "",
+				tokenExpirationTimeField:  attrs.GetTokenExpirationTime() != nil,
+				caCertExpirationTimeField: attrs.GetCaCertExpirationTime() != nil,
+			} {
+				if set {
+					badFields[f] = "This is a read only field."
+				}
+			}
 
 			// TODO(ICU-1478 and ICU-1479): Validate client and CA certificate payloads
 			_, err := decodePemBlocks(attrs.GetCaCert().GetValue())
@@ -677,9 +792,36 @@ func validateUpdateRequest(req *pbs.UpdateCredentialStoreRequest) error {
 				attrs.GetToken().GetValue() == "" {
 				badFields[vaultTokenField] = "This is a required field and cannot be unset."
 			}
+			switch vault.AuthMethodType(attrs.GetAuthMethod().GetValue()) {
+			case "", vault.TokenAuthMethod, vault.AppRoleAuthMethod, vault.KubernetesAuthMethod:
+			default:
+				badFields[authMethodField] = "Must be one of token, approle, or kubernetes."
+			}
+			if handlers.MaskContains(req.GetUpdateMask().GetPaths(), approleSecretIdField) &&
+				attrs.GetApproleSecretId().GetValue() == "" {
+				badFields[approleSecretIdField] = "This field cannot be unset, unset the auth method instead."
+			}
 			if attrs.GetTokenHmac() != "" {
 				badFields[vaultTokenHmacField] = "This is a read only field."
 			}
+			if attrs.GetApproleSecretIdHmac() != "" {
+				badFields[approleSecretIdHmac] = "This is a read only field."
+			}
+			if attrs.GetOperationalState() != "" {
+				badFields[operationalStateField] = "This is a read only field."
+			}
+			for f, set := range map[string]bool{
+				healthStatusField:         attrs.GetHealthStatus() != "",
+				healthCheckTimeField:      attrs.GetHealthCheckTime() != nil,
+				healthFailedCheckField:    attrs.GetHealthFailedCheck() != "",
+				healthMessageField:        attrs.GetHealthMessage() != "",
+				tokenExpirationTimeField:  attrs.GetTokenExpirationTime() != nil,
+				caCertExpirationTimeField: attrs.GetCaCertExpirationTime() != nil,
+			} {
+				if set {
+					badFields[f] = "This is a read only field."
+				}
+			}
 
 			// TODO(ICU-1478 and ICU-1479): Validate client and CA certificate payloads
 			_, err := decodePemBlocks(attrs.GetCaCert().GetValue())
@@ -83,6 +83,7 @@ func TestList(t *testing.T) {
 				attrs, err := handlers.ProtoToStruct(&pb.VaultCredentialStoreAttributes{
 					Address:                  wrapperspb.String(s.GetVaultAddress()),
 					TokenHmac:                base64.RawURLEncoding.EncodeToString(s.Token().GetTokenHmac()),
+					OperationalState:         string(vault.ActiveState),
 					ClientCertificate:        wrapperspb.String(string(s.ClientCertificate().GetCertificate())),
 					ClientCertificateKeyHmac: base64.RawURLEncoding.EncodeToString(s.ClientCertificate().GetCertificateKeyHmac()),
 					// TODO: Add all fields including tls related fields, namespace, etc...
@@ -461,6 +462,7 @@ func TestCreate(t *testing.T) {
 							CaCert:                   wrapperspb.String(string(v.CaCert)),
 							Address:                  wrapperspb.String(v.Addr),
 							TokenHmac:                "<hmac>",
+							OperationalState:         string(vault.ActiveState),
 							ClientCertificate:        wrapperspb.String(string(v.ClientCert)),
 							ClientCertificateKeyHmac: "<hmac>",
 						})
@@ -506,6 +508,7 @@ func TestCreate(t *testing.T) {
 							CaCert:                   wrapperspb.String(string(v.CaCert)),
 							Address:                  wrapperspb.String(v.Addr),
 							TokenHmac:                "<hmac>",
+							OperationalState:         string(vault.ActiveState),
 							ClientCertificate:        wrapperspb.String(string(v.ClientCert)),
 							ClientCertificateKeyHmac: "<hmac>",
 						})
@@ -611,6 +614,7 @@ func TestGet(t *testing.T) {
 						attrs, err := handlers.ProtoToStruct(&pb.VaultCredentialStoreAttributes{
 							Address:                  wrapperspb.String(store.GetVaultAddress()),
 							TokenHmac:                base64.RawURLEncoding.EncodeToString(store.Token().GetTokenHmac()),
+							OperationalState:         string(vault.ActiveState),
 							ClientCertificate:        wrapperspb.String(string(store.ClientCertificate().GetCertificate())),
 							ClientCertificateKeyHmac: base64.RawURLEncoding.EncodeToString(store.ClientCertificate().GetCertificateKeyHmac()),
 						})
@@ -12,6 +12,7 @@ import (
 	plugstore "github.com/hashicorp/boundary/internal/host/plugin/store"
 	"github.com/hashicorp/boundary/internal/host/static"
 	staticstore "github.com/hashicorp/boundary/internal/host/static/store"
+	"github.com/hashicorp/boundary/internal/label"
 	"github.com/hashicorp/boundary/internal/libs/endpoint"
 	"github.com/hashicorp/boundary/internal/perms"
 	hostplugin "github.com/hashicorp/boundary/internal/plugin/host"
@@ -77,13 +78,14 @@ type Service struct {
 
 	staticRepoFn common.StaticRepoFactory
 	pluginRepoFn common.PluginHostRepoFactory
+	labelRepoFn  common.LabelRepoFactory
 }
 
 var _ pbs.HostSetServiceServer = Service{}
 
 // NewService returns a host set Service which handles host set related requests to boundary and uses the provided
 // repositories for storage and retrieval.
-func NewService(staticRepoFn common.StaticRepoFactory, pluginRepoFn common.PluginHostRepoFactory) (Service, error) {
+func NewService(staticRepoFn common.StaticRepoFactory, pluginRepoFn common.PluginHostRepoFactory, labelRepoFn common.LabelRepoFactory) (Service, error) {
 	const op = "host_sets.NewService"
 	if staticRepoFn == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing static repository")
@@ -91,7 +93,10 @@ func NewService(staticRepoFn common.StaticRepoFactory, pluginRepoFn common.Plugi
 	if pluginRepoFn == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing plugin repository")
 	}
-	return Service{staticRepoFn: staticRepoFn, pluginRepoFn: pluginRepoFn}, nil
+	if labelRepoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing label repository")
+	}
+	return Service{staticRepoFn: staticRepoFn, pluginRepoFn: pluginRepoFn, labelRepoFn: labelRepoFn}, nil
 }
 
 func (s Service) ListHostSets(ctx context.Context, req *pbs.ListHostSetsRequest) (*pbs.ListHostSetsResponse, error) {
@@ -113,6 +118,10 @@ func (s Service) ListHostSetsWithOptions(ctx context.Context, req *pbs.ListHostS
 	if len(hl) == 0 {
 		return &pbs.ListHostSetsResponse{}, nil
 	}
+	labels, err := s.listLabels(ctx, hl...)
+	if err != nil {
+		return nil, err
+	}
 
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
@@ -134,7 +143,7 @@ func (s Service) ListHostSetsWithOptions(ctx context.Context, req *pbs.ListHostS
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
-		outputOpts := make([]handlers.Option, 0, 3)
+		outputOpts := make([]handlers.Option, 0, 4)
 		outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 		if outputFields.Has(globals.ScopeField) {
 			outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -142,6 +151,9 @@ func (s Service) ListHostSetsWithOptions(ctx context.Context, req *pbs.ListHostS
 		if outputFields.Has(globals.AuthorizedActionsField) {
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
+		if outputFields.Has(globals.LabelsField) {
+			outputOpts = append(outputOpts, handlers.WithLabels(labels[item.GetPublicId()]))
+		}
 		if plg != nil {
 			outputOpts = append(outputOpts, handlers.WithPlugin(plg))
 		}
@@ -179,7 +191,7 @@ func (s Service) GetHostSet(ctx context.Context, req *pbs.GetHostSetRequest) (*p
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 4)
+	outputOpts := make([]handlers.Option, 0, 5)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -188,6 +200,13 @@ func (s Service) GetHostSet(ctx context.Context, req *pbs.GetHostSetRequest) (*p
 		idActions := idActionsTypeMap[host.SubtypeFromId(req.GetId())]
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hs.GetPublicId(), idActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, hs)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[hs.GetPublicId()]))
+	}
 	if plg != nil {
 		outputOpts = append(outputOpts, handlers.WithPlugin(plg))
 	}
@@ -215,13 +234,18 @@ func (s Service) CreateHostSet(ctx context.Context, req *pbs.CreateHostSetReques
 	if err != nil {
 		return nil, err
 	}
+	if len(req.GetItem().GetLabels()) > 0 {
+		if err := s.setLabels(ctx, hs.GetPublicId(), req.GetItem().GetLabels()); err != nil {
+			return nil, err
+		}
+	}
 
 	outputFields, ok := requests.OutputFields(ctx)
 	if !ok {
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 5)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -230,6 +254,13 @@ func (s Service) CreateHostSet(ctx context.Context, req *pbs.CreateHostSetReques
 		idActions := idActionsTypeMap[host.SubtypeFromId(req.GetItem().GetHostCatalogId())]
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hs.GetPublicId(), idActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, hs)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[hs.GetPublicId()]))
+	}
 	if plg != nil {
 		outputOpts = append(outputOpts, handlers.WithPlugin(plg))
 	}
@@ -256,17 +287,41 @@ func (s Service) UpdateHostSet(ctx context.Context, req *pbs.UpdateHostSetReques
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	hs, hosts, plg, err := s.updateInRepo(ctx, authResults.Scope.GetId(), cat.GetPublicId(), req)
+	// Labels are not stored on the host set itself, so they are set apart
+	// from the other fields.
+	paths, updateLabels := handlers.MaskRemove(req.GetUpdateMask().GetPaths(), globals.LabelsField)
+	var hs host.Set
+	var hosts []host.Host
+	var plg *plugins.PluginInfo
+	var err error
+	switch {
+	case len(paths) > 0 && updateLabels:
+		repoReq := proto.Clone(req).(*pbs.UpdateHostSetRequest)
+		repoReq.GetUpdateMask().Paths = paths
+		hs, hosts, plg, err = s.updateInRepo(ctx, authResults.Scope.GetId(), cat.GetPublicId(), repoReq)
+	case updateLabels:
+		hs, hosts, plg, err = s.getFromRepo(ctx, req.GetId())
+		if err == nil && hs.GetVersion() != req.GetItem().GetVersion() {
+			err = handlers.NotFoundErrorf("Host Set %q doesn't exist or incorrect version provided.", req.GetId())
+		}
+	default:
+		hs, hosts, plg, err = s.updateInRepo(ctx, authResults.Scope.GetId(), cat.GetPublicId(), req)
+	}
 	if err != nil {
 		return nil, err
 	}
+	if updateLabels {
+		if err := s.setLabels(ctx, hs.GetPublicId(), req.GetItem().GetLabels()); err != nil {
+			return nil, err
+		}
+	}
 
 	outputFields, ok := requests.OutputFields(ctx)
 	if !ok {
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 5)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if plg != nil {
 		outputOpts = append(outputOpts, handlers.WithPlugin(plg))
@@ -278,6 +333,13 @@ func (s Service) UpdateHostSet(ctx context.Context, req *pbs.UpdateHostSetReques
 		idActions := idActionsTypeMap[host.SubtypeFromId(req.GetId())]
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hs.GetPublicId(), idActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, hs)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[hs.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, hs, hosts, outputOpts...)
 	if err != nil {
@@ -324,7 +386,7 @@ func (s Service) AddHostSetHosts(ctx context.Context, req *pbs.AddHostSetHostsRe
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -333,6 +395,13 @@ func (s Service) AddHostSetHosts(ctx context.Context, req *pbs.AddHostSetHostsRe
 		idActions := idActionsTypeMap[host.SubtypeFromId(req.GetId())]
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hs.GetPublicId(), idActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, hs)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[hs.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, hs, hosts, outputOpts...)
 	if err != nil {
@@ -362,7 +431,7 @@ func (s Service) SetHostSetHosts(ctx context.Context, req *pbs.SetHostSetHostsRe
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -371,6 +440,13 @@ func (s Service) SetHostSetHosts(ctx context.Context, req *pbs.SetHostSetHostsRe
 		idActions := idActionsTypeMap[host.SubtypeFromId(req.GetId())]
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hs.GetPublicId(), idActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, hs)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[hs.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, hs, hosts, outputOpts...)
 	if err != nil {
@@ -401,7 +477,7 @@ func (s Service) RemoveHostSetHosts(ctx context.Context, req *pbs.RemoveHostSetH
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -410,6 +486,13 @@ func (s Service) RemoveHostSetHosts(ctx context.Context, req *pbs.RemoveHostSetH
 		idActions := idActionsTypeMap[host.SubtypeFromId(req.GetId())]
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hs.GetPublicId(), idActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, hs)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[hs.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, hs, hosts, outputOpts...)
 	if err != nil {
@@ -719,6 +802,32 @@ func (s Service) removeInRepo(ctx context.Context, scopeId, setId string, hostId
 	return out, hl, nil
 }
 
+// listLabels returns the labels of host sets keyed by host set id.
+func (s Service) listLabels(ctx context.Context, sets ...host.Set) (map[string]map[string]string, error) {
+	repo, err := s.labelRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(sets))
+	for _, hs := range sets {
+		ids = append(ids, hs.GetPublicId())
+	}
+	return repo.ListLabels(ctx, resource.HostSet, ids)
+}
+
+// setLabels replaces the labels of the host set id.
+func (s Service) setLabels(ctx context.Context, id string, labels map[string]string) error {
+	const op = "host_sets.(Service).setLabels"
+	repo, err := s.labelRepoFn()
+	if err != nil {
+		return err
+	}
+	if err := repo.SetLabels(ctx, resource.HostSet, id, labels); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to set host set labels"))
+	}
+	return nil
+}
+
 func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Type) (host.Catalog, auth.VerifyResults) {
 	res := auth.VerifyResults{}
 
@@ -855,6 +964,9 @@ func toProto(ctx context.Context, in host.Set, hosts []host.Host, opt ...handler
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		out.AuthorizedActions = opts.WithAuthorizedActions
 	}
+	if outputFields.Has(globals.LabelsField) {
+		out.Labels = opts.WithLabels
+	}
 	if outputFields.Has(globals.HostIdsField) {
 		for _, h := range hosts {
 			out.HostIds = append(out.HostIds, h.GetPublicId())
@@ -966,6 +1078,9 @@ func validateCreateRequest(ctx context.Context, req *pbs.CreateHostSetRequest) e
 				}
 			}
 		}
+		if err := label.Validate(ctx, req.GetItem().GetLabels()); err != nil {
+			badFields[globals.LabelsField] = "Label keys and values must be non-empty, lowercase and free of ',', and keys must not contain '='."
+		}
 		return badFields
 	})
 }
@@ -991,6 +1106,9 @@ func validateUpdateRequest(ctx context.Context, req *pbs.UpdateHostSetRequest) e
 				}
 			}
 		}
+		if err := label.Validate(ctx, req.GetItem().GetLabels()); err != nil {
+			badFields[globals.LabelsField] = "Label keys and values must be non-empty, lowercase and free of ',', and keys must not contain '='."
+		}
 		return badFields
 	}, static.HostSetPrefix, plugin.HostSetPrefix)
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/label"
 	hostplugin "github.com/hashicorp/boundary/internal/plugin/host"
 	"github.com/hashicorp/boundary/internal/scheduler"
 	"github.com/hashicorp/boundary/internal/servers/controller/auth"
@@ -60,6 +61,9 @@ func TestGet_Static(t *testing.T) {
 	org, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -122,7 +126,7 @@ func TestGet_Static(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetHostSetRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := host_sets.NewService(repoFn, pluginRepoFn)
+			s, err := host_sets.NewService(repoFn, pluginRepoFn, labelRepoFn)
 			require.NoError(err, "Couldn't create a new host set service.")
 
 			got, gErr := s.GetHostSet(auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId()), req)
@@ -154,6 +158,9 @@ func TestGet_Plugin(t *testing.T) {
 	org, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -226,7 +233,7 @@ func TestGet_Plugin(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetHostSetRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := host_sets.NewService(repoFn, pluginRepoFn)
+			s, err := host_sets.NewService(repoFn, pluginRepoFn, labelRepoFn)
 			require.NoError(err, "Couldn't create a new host set service.")
 
 			got, gErr := s.GetHostSet(auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId()), req)
@@ -259,6 +266,9 @@ func TestList_Static(t *testing.T) {
 	org, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -317,7 +327,7 @@ func TestList_Static(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := host_sets.NewService(repoFn, pluginRepoFn)
+			s, err := host_sets.NewService(repoFn, pluginRepoFn, labelRepoFn)
 			require.NoError(err, "Couldn't create new host set service.")
 
 			// Test with non-anon user
@@ -358,6 +368,9 @@ func TestList_Plugin(t *testing.T) {
 	org, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -430,7 +443,7 @@ func TestList_Plugin(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := host_sets.NewService(repoFn, pluginRepoFn)
+			s, err := host_sets.NewService(repoFn, pluginRepoFn, labelRepoFn)
 			require.NoError(err, "Couldn't create new host set service.")
 
 			// Test with non-anon user
@@ -472,6 +485,9 @@ func TestDelete_Static(t *testing.T) {
 	_, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -481,7 +497,7 @@ func TestDelete_Static(t *testing.T) {
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
 	h := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
 
-	s, err := host_sets.NewService(repoFn, pluginRepoFn)
+	s, err := host_sets.NewService(repoFn, pluginRepoFn, labelRepoFn)
 	require.NoError(t, err, "Couldn't create a new host set service.")
 
 	cases := []struct {
@@ -543,6 +559,9 @@ func TestDelete_Plugin(t *testing.T) {
 	_, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -558,7 +577,7 @@ func TestDelete_Plugin(t *testing.T) {
 	hc := plugin.TestCatalog(t, conn, proj.GetPublicId(), plg.GetPublicId())
 	h := plugin.TestSet(t, conn, kms, sche, hc, plgm)
 
-	s, err := host_sets.NewService(repoFn, pluginRepoFn)
+	s, err := host_sets.NewService(repoFn, pluginRepoFn, labelRepoFn)
 	require.NoError(t, err, "Couldn't create a new host set service.")
 
 	cases := []struct {
@@ -621,6 +640,9 @@ func TestDelete_twice(t *testing.T) {
 	_, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -630,7 +652,7 @@ func TestDelete_twice(t *testing.T) {
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
 	h := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
 
-	s, err := host_sets.NewService(repoFn, plgRepoFn)
+	s, err := host_sets.NewService(repoFn, plgRepoFn, labelRepoFn)
 	require.NoError(err, "Couldn't create a new host set service.")
 	req := &pbs.DeleteHostSetRequest{
 		Id: h.GetPublicId(),
@@ -658,6 +680,9 @@ func TestCreate_Static(t *testing.T) {
 	org, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -767,7 +792,7 @@ func TestCreate_Static(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
-			s, err := host_sets.NewService(repoFn, plgRepoFn)
+			s, err := host_sets.NewService(repoFn, plgRepoFn, labelRepoFn)
 			require.NoError(err, "Failed to create a new host set service.")
 
 			got, gErr := s.CreateHostSet(auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId()), tc.req)
@@ -814,6 +839,9 @@ func TestCreate_Plugin(t *testing.T) {
 	org, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -1028,7 +1056,7 @@ func TestCreate_Plugin(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
-			s, err := host_sets.NewService(repoFn, plgRepoFn)
+			s, err := host_sets.NewService(repoFn, plgRepoFn, labelRepoFn)
 			require.NoError(err, "Failed to create a new host set service.")
 
 			got, gErr := s.CreateHostSet(auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId()), tc.req)
@@ -1077,6 +1105,9 @@ func TestUpdate_Static(t *testing.T) {
 	org, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
@@ -1112,7 +1143,7 @@ func TestUpdate_Static(t *testing.T) {
 	plgRepoFn := func() (*plugin.Repository, error) {
 		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
 	}
-	tested, err := host_sets.NewService(repoFn, plgRepoFn)
+	tested, err := host_sets.NewService(repoFn, plgRepoFn, labelRepoFn)
 	require.NoError(t, err, "Failed to create a new host set service.")
 
 	cases := []struct {
@@ -1452,6 +1483,9 @@ func TestUpdate_Plugin(t *testing.T) {
 	iamRepo := iam.TestRepo(t, conn, wrapper)
 	_, proj := iam.TestScopes(t, iamRepo)
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 
 	name := "test"
 	plg := hostplugin.TestPlugin(t, conn, name)
@@ -1468,7 +1502,7 @@ func TestUpdate_Plugin(t *testing.T) {
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	tested, err := host_sets.NewService(repoFn, pluginHostRepo)
+	tested, err := host_sets.NewService(repoFn, pluginHostRepo, labelRepoFn)
 	require.NoError(t, err, "Failed to create a new host catalog service.")
 
 	hc := plugin.TestCatalog(t, conn, proj.GetPublicId(), plg.GetPublicId())
@@ -1792,13 +1826,16 @@ func TestAddHostSetHosts(t *testing.T) {
 	_, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
 	plgRepoFn := func() (*plugin.Repository, error) {
 		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
 	}
-	s, err := host_sets.NewService(repoFn, plgRepoFn)
+	s, err := host_sets.NewService(repoFn, plgRepoFn, labelRepoFn)
 	require.NoError(t, err, "Error when getting new host set service.")
 
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
@@ -1913,13 +1950,16 @@ func TestSetHostSetHosts(t *testing.T) {
 	_, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
 	plgRepoFn := func() (*plugin.Repository, error) {
 		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
 	}
-	s, err := host_sets.NewService(repoFn, plgRepoFn)
+	s, err := host_sets.NewService(repoFn, plgRepoFn, labelRepoFn)
 	require.NoError(t, err, "Error when getting new host set service.")
 
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
@@ -2030,13 +2070,16 @@ func TestRemoveHostSetHosts(t *testing.T) {
 	_, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
 	plgRepoFn := func() (*plugin.Repository, error) {
 		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
 	}
-	s, err := host_sets.NewService(repoFn, plgRepoFn)
+	s, err := host_sets.NewService(repoFn, plgRepoFn, labelRepoFn)
 	require.NoError(t, err, "Error when getting new host set service.")
 
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
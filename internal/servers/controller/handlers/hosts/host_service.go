@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/host/static/store"
+	"github.com/hashicorp/boundary/internal/label"
 	"github.com/hashicorp/boundary/internal/perms"
 	hostplugin "github.com/hashicorp/boundary/internal/plugin/host"
 	"github.com/hashicorp/boundary/internal/requests"
@@ -66,13 +67,14 @@ type Service struct {
 
 	staticRepoFn common.StaticRepoFactory
 	pluginRepoFn common.PluginHostRepoFactory
+	labelRepoFn  common.LabelRepoFactory
 }
 
 var _ pbs.HostServiceServer = Service{}
 
 // NewService returns a host Service which handles host related requests to boundary and uses the provided
 // repositories for storage and retrieval.
-func NewService(repoFn common.StaticRepoFactory, pluginRepoFn common.PluginHostRepoFactory) (Service, error) {
+func NewService(repoFn common.StaticRepoFactory, pluginRepoFn common.PluginHostRepoFactory, labelRepoFn common.LabelRepoFactory) (Service, error) {
 	const op = "hosts.NewService"
 	if repoFn == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing static repository")
@@ -80,7 +82,10 @@ func NewService(repoFn common.StaticRepoFactory, pluginRepoFn common.PluginHostR
 	if pluginRepoFn == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing plugin host repository")
 	}
-	return Service{staticRepoFn: repoFn, pluginRepoFn: pluginRepoFn}, nil
+	if labelRepoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing label repository")
+	}
+	return Service{staticRepoFn: repoFn, pluginRepoFn: pluginRepoFn, labelRepoFn: labelRepoFn}, nil
 }
 
 func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs.ListHostsResponse, error) {
@@ -98,6 +103,10 @@ func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs
 	if len(hl) == 0 {
 		return &pbs.ListHostsResponse{}, nil
 	}
+	labels, err := s.listLabels(ctx, hl...)
+	if err != nil {
+		return nil, err
+	}
 
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
@@ -119,7 +128,7 @@ func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
-		outputOpts := make([]handlers.Option, 0, 3)
+		outputOpts := make([]handlers.Option, 0, 4)
 		outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 		if plg != nil {
 			outputOpts = append(outputOpts, handlers.WithPlugin(plg))
@@ -130,6 +139,9 @@ func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs
 		if outputFields.Has(globals.AuthorizedActionsField) {
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
+		if outputFields.Has(globals.LabelsField) {
+			outputOpts = append(outputOpts, handlers.WithLabels(labels[item.GetPublicId()]))
+		}
 
 		switch h := item.(type) {
 		case *plugin.Host:
@@ -168,7 +180,7 @@ func (s Service) GetHost(ctx context.Context, req *pbs.GetHostRequest) (*pbs.Get
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if plg != nil {
 		outputOpts = append(outputOpts, handlers.WithPlugin(plg))
@@ -180,6 +192,13 @@ func (s Service) GetHost(ctx context.Context, req *pbs.GetHostRequest) (*pbs.Get
 		idActions := idActionsTypeMap[host.SubtypeFromId(req.GetId())]
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, h.GetPublicId(), idActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, h)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[h.GetPublicId()]))
+	}
 
 	switch h := h.(type) {
 	case *plugin.Host:
@@ -208,13 +227,18 @@ func (s Service) CreateHost(ctx context.Context, req *pbs.CreateHostRequest) (*p
 	if err != nil {
 		return nil, err
 	}
+	if len(req.GetItem().GetLabels()) > 0 {
+		if err := s.setLabels(ctx, h.GetPublicId(), req.GetItem().GetLabels()); err != nil {
+			return nil, err
+		}
+	}
 
 	outputFields, ok := requests.OutputFields(ctx)
 	if !ok {
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -223,6 +247,13 @@ func (s Service) CreateHost(ctx context.Context, req *pbs.CreateHostRequest) (*p
 		idActions := idActionsTypeMap[host.SubtypeFromId(req.GetItem().GetHostCatalogId())]
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, h.GetPublicId(), idActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, h)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[h.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, h, outputOpts...)
 	if err != nil {
@@ -246,17 +277,34 @@ func (s Service) UpdateHost(ctx context.Context, req *pbs.UpdateHostRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	h, err := s.updateInRepo(ctx, authResults.Scope.GetId(), cat.GetPublicId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	// Labels are not stored on the host itself, so they are set apart from
+	// the other fields.
+	paths, updateLabels := handlers.MaskRemove(req.GetUpdateMask().GetPaths(), globals.LabelsField)
+	var h host.Host
+	var err error
+	if len(paths) > 0 || !updateLabels {
+		h, err = s.updateInRepo(ctx, authResults.Scope.GetId(), cat.GetPublicId(), req.GetId(), paths, req.GetItem())
+	} else {
+		h, _, err = s.getFromRepo(ctx, req.GetId())
+		if err == nil && h.GetVersion() != req.GetItem().GetVersion() {
+			err = handlers.NotFoundErrorf("Host %q doesn't exist or incorrect version provided.", req.GetId())
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
+	if updateLabels {
+		if err := s.setLabels(ctx, h.GetPublicId(), req.GetItem().GetLabels()); err != nil {
+			return nil, err
+		}
+	}
 
 	outputFields, ok := requests.OutputFields(ctx)
 	if !ok {
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -265,6 +313,13 @@ func (s Service) UpdateHost(ctx context.Context, req *pbs.UpdateHostRequest) (*p
 		idActions := idActionsTypeMap[host.SubtypeFromId(req.GetId())]
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, h.GetPublicId(), idActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, h)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[h.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, h, outputOpts...)
 	if err != nil {
@@ -444,6 +499,32 @@ func (s Service) listFromRepo(ctx context.Context, catalogId string) ([]host.Hos
 	return hosts, plg, nil
 }
 
+// listLabels returns the labels of hosts keyed by host id.
+func (s Service) listLabels(ctx context.Context, hosts ...host.Host) (map[string]map[string]string, error) {
+	repo, err := s.labelRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		ids = append(ids, h.GetPublicId())
+	}
+	return repo.ListLabels(ctx, resource.Host, ids)
+}
+
+// setLabels replaces the labels of the host id.
+func (s Service) setLabels(ctx context.Context, id string, labels map[string]string) error {
+	const op = "hosts.(Service).setLabels"
+	repo, err := s.labelRepoFn()
+	if err != nil {
+		return err
+	}
+	if err := repo.SetLabels(ctx, resource.Host, id, labels); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to set host labels"))
+	}
+	return nil
+}
+
 func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Type) (host.Catalog, auth.VerifyResults) {
 	res := auth.VerifyResults{}
 	staticRepo, err := s.staticRepoFn()
@@ -573,6 +654,9 @@ func toProto(ctx context.Context, in host.Host, opt ...handlers.Option) (*pb.Hos
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		out.AuthorizedActions = opts.WithAuthorizedActions
 	}
+	if outputFields.Has(globals.LabelsField) {
+		out.Labels = opts.WithLabels
+	}
 	if outputFields.Has(globals.HostSetIdsField) && len(opts.WithHostSetIds) > 0 {
 		out.HostSetIds = opts.WithHostSetIds
 	}
@@ -660,6 +744,9 @@ func validateCreateRequest(req *pbs.CreateHostRequest) error {
 		case plugin.Subtype:
 			badFields[globals.HostCatalogIdField] = "Cannot manually create hosts for this type of catalog."
 		}
+		if err := label.Validate(context.Background(), req.GetItem().GetLabels()); err != nil {
+			badFields[globals.LabelsField] = "Label keys and values must be non-empty, lowercase and free of ',', and keys must not contain '='."
+		}
 		return badFields
 	})
 }
@@ -689,6 +776,9 @@ func validateUpdateRequest(req *pbs.UpdateHostRequest) error {
 		default:
 			badFields["id"] = "Improperly formatted identifier used."
 		}
+		if err := label.Validate(context.Background(), req.GetItem().GetLabels()); err != nil {
+			badFields[globals.LabelsField] = "Label keys and values must be non-empty, lowercase and free of ',', and keys must not contain '='."
+		}
 		return badFields
 	}, static.HostPrefix)
 }
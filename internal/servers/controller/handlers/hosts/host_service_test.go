@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/label"
 	hostplugin "github.com/hashicorp/boundary/internal/plugin/host"
 	"github.com/hashicorp/boundary/internal/scheduler"
 	"github.com/hashicorp/boundary/internal/servers/controller/auth"
@@ -57,6 +58,9 @@ func TestGet_Static(t *testing.T) {
 	org, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	sche := scheduler.TestScheduler(t, conn, wrapper)
 	pluginRepoFn := func() (*plugin.Repository, error) {
 		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
@@ -113,7 +117,7 @@ func TestGet_Static(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := hosts.NewService(repoFn, pluginRepoFn)
+			s, err := hosts.NewService(repoFn, pluginRepoFn, labelRepoFn)
 			require.NoError(err, "Couldn't create a new host service.")
 
 			got, gErr := s.GetHost(auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId()), tc.req)
@@ -149,6 +153,9 @@ func TestGet_Plugin(t *testing.T) {
 	}
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	sche := scheduler.TestScheduler(t, conn, wrapper)
 	pluginRepoFn := func() (*plugin.Repository, error) {
 		return plugin.NewRepository(rw, rw, kms, sche, plgm)
@@ -211,7 +218,7 @@ func TestGet_Plugin(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := hosts.NewService(repoFn, pluginRepoFn)
+			s, err := hosts.NewService(repoFn, pluginRepoFn, labelRepoFn)
 			require.NoError(err, "Couldn't create a new host service.")
 
 			got, gErr := s.GetHost(auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId()), tc.req)
@@ -243,6 +250,9 @@ func TestList_Static(t *testing.T) {
 	org, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	sche := scheduler.TestScheduler(t, conn, wrapper)
 	pluginRepoFn := func() (*plugin.Repository, error) {
 		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
@@ -309,7 +319,7 @@ func TestList_Static(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := hosts.NewService(repoFn, pluginRepoFn)
+			s, err := hosts.NewService(repoFn, pluginRepoFn, labelRepoFn)
 			require.NoError(err, "Couldn't create new host set service.")
 
 			// Test non-anonymous listing
@@ -352,6 +362,9 @@ func TestList_Plugin(t *testing.T) {
 	}
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	sche := scheduler.TestScheduler(t, conn, wrapper)
 	pluginRepoFn := func() (*plugin.Repository, error) {
 		return plugin.NewRepository(rw, rw, kms, sche, plgm)
@@ -430,7 +443,7 @@ func TestList_Plugin(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := hosts.NewService(repoFn, pluginRepoFn)
+			s, err := hosts.NewService(repoFn, pluginRepoFn, labelRepoFn)
 			require.NoError(err, "Couldn't create new host set service.")
 
 			// Test non-anonymous listing
@@ -474,6 +487,9 @@ func TestDelete(t *testing.T) {
 	_, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	sche := scheduler.TestScheduler(t, conn, wrapper)
 	pluginRepoFn := func() (*plugin.Repository, error) {
 		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
@@ -488,7 +504,7 @@ func TestDelete(t *testing.T) {
 	pluginHc := plugin.TestCatalog(t, conn, proj.GetPublicId(), plg.GetPublicId())
 	pluginH := plugin.TestHost(t, conn, pluginHc.GetPublicId(), "test")
 
-	s, err := hosts.NewService(repoFn, pluginRepoFn)
+	s, err := hosts.NewService(repoFn, pluginRepoFn, labelRepoFn)
 	require.NoError(t, err, "Couldn't create a new host set service.")
 
 	cases := []struct {
@@ -558,6 +574,9 @@ func TestDelete_twice(t *testing.T) {
 	_, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	sche := scheduler.TestScheduler(t, conn, wrapper)
 	pluginRepoFn := func() (*plugin.Repository, error) {
 		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
@@ -568,7 +587,7 @@ func TestDelete_twice(t *testing.T) {
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
 	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
 
-	s, err := hosts.NewService(repoFn, pluginRepoFn)
+	s, err := hosts.NewService(repoFn, pluginRepoFn, labelRepoFn)
 	require.NoError(err, "Couldn't create a new host set service.")
 	req := &pbs.DeleteHostRequest{
 		Id: h.GetPublicId(),
@@ -595,6 +614,9 @@ func TestCreate(t *testing.T) {
 	org, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	sche := scheduler.TestScheduler(t, conn, wrapper)
 	pluginRepoFn := func() (*plugin.Repository, error) {
 		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
@@ -738,7 +760,7 @@ func TestCreate(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := hosts.NewService(repoFn, pluginRepoFn)
+			s, err := hosts.NewService(repoFn, pluginRepoFn, labelRepoFn)
 			require.NoError(err, "Failed to create a new host set service.")
 
 			got, gErr := s.CreateHost(auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId()), tc.req)
@@ -782,6 +804,9 @@ func TestUpdate_Static(t *testing.T) {
 	org, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	sche := scheduler.TestScheduler(t, conn, wrapper)
 	pluginRepoFn := func() (*plugin.Repository, error) {
 		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
@@ -813,7 +838,7 @@ func TestUpdate_Static(t *testing.T) {
 		Id: h.GetPublicId(),
 	}
 
-	tested, err := hosts.NewService(repoFn, pluginRepoFn)
+	tested, err := hosts.NewService(repoFn, pluginRepoFn, labelRepoFn)
 	require.NoError(t, err, "Failed to create a new host set service.")
 
 	cases := []struct {
@@ -1190,6 +1215,9 @@ func TestUpdate_Plugin(t *testing.T) {
 	_, proj := iam.TestScopes(t, iamRepo)
 
 	rw := db.New(conn)
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
 	sche := scheduler.TestScheduler(t, conn, wrapper)
 	pluginRepoFn := func() (*plugin.Repository, error) {
 		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
@@ -1202,7 +1230,7 @@ func TestUpdate_Plugin(t *testing.T) {
 	hc := plugin.TestCatalog(t, conn, proj.GetPublicId(), plg.GetPublicId())
 	h := plugin.TestHost(t, conn, hc.GetPublicId(), "test")
 
-	tested, err := hosts.NewService(repoFn, pluginRepoFn)
+	tested, err := hosts.NewService(repoFn, pluginRepoFn, labelRepoFn)
 	require.NoError(t, err)
 
 	got, err := tested.UpdateHost(auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId()), &pbs.UpdateHostRequest{
@@ -117,3 +117,22 @@ func MaskContains(paths []string, s string) bool {
 	}
 	return false
 }
+
+// MaskRemove returns the paths with every occurrence of s removed, and
+// whether s was found. Paths holding several comma separated fields are
+// split so that each field is checked.
+func MaskRemove(paths []string, s string) ([]string, bool) {
+	var found bool
+	result := make([]string, 0, len(paths))
+	for _, p := range paths {
+		for _, v := range strings.Split(p, ",") {
+			candidate := strings.TrimSpace(v)
+			if candidate == s {
+				found = true
+				continue
+			}
+			result = append(result, candidate)
+		}
+	}
+	return result, found
+}
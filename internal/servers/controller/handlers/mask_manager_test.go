@@ -43,3 +43,21 @@ func TestMaskManager_errors(t *testing.T) {
 	_, err = NewMaskManager(MaskDestination{&pb.TestBase{}}, MaskSource{&pb.TestNotEnoughFields{}})
 	assert.Error(t, err)
 }
+
+func TestMaskRemove(t *testing.T) {
+	paths, found := MaskRemove([]string{"name", "labels"}, "labels")
+	assert.True(t, found)
+	assert.Equal(t, []string{"name"}, paths)
+
+	paths, found = MaskRemove([]string{"name, labels,description"}, "labels")
+	assert.True(t, found)
+	assert.Equal(t, []string{"name", "description"}, paths)
+
+	paths, found = MaskRemove([]string{"labels"}, "labels")
+	assert.True(t, found)
+	assert.Empty(t, paths)
+
+	paths, found = MaskRemove([]string{"name"}, "labels")
+	assert.False(t, found)
+	assert.Equal(t, []string{"name"}, paths)
+}
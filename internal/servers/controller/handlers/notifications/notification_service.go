@@ -0,0 +1,584 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/notification"
+	"github.com/hashicorp/boundary/internal/notification/store"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/common/scopeids"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/notifications"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	maskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.NoOp,
+		action.Read,
+		action.Update,
+		action.Delete,
+	}
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.ActionSet{
+		action.Create,
+		action.List,
+	}
+)
+
+func init() {
+	var err error
+	if maskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&store.Notification{}}, handlers.MaskSource{&pb.Notification{}}); err != nil {
+		panic(err)
+	}
+}
+
+// Service handles request as described by the pbs.NotificationServiceServer interface.
+type Service struct {
+	pbs.UnimplementedNotificationServiceServer
+
+	repoFn    common.NotificationRepoFactory
+	iamRepoFn common.IamRepoFactory
+}
+
+// NewService returns a notification service which handles notification
+// related requests to boundary.
+func NewService(repoFn common.NotificationRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+	const op = "notifications.NewService"
+	if repoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing notification repository")
+	}
+	if iamRepoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
+	}
+	return Service{repoFn: repoFn, iamRepoFn: iamRepoFn}, nil
+}
+
+var _ pbs.NotificationServiceServer = Service{}
+
+// ListNotifications implements the interface pbs.NotificationServiceServer.
+func (s Service) ListNotifications(ctx context.Context, req *pbs.ListNotificationsRequest) (*pbs.ListNotificationsResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		// If it's forbidden, and it's a recursive request, and they're
+		// successfully authenticated but just not authorized, keep going as we
+		// may have authorization on downstream scopes. Or, if they've not
+		// authenticated, still process in case u_anon has permissions.
+		if (authResults.Error == handlers.ForbiddenError() || authResults.Error == handlers.UnauthenticatedError()) &&
+			req.GetRecursive() &&
+			authResults.AuthenticationFinished {
+		} else {
+			return nil, authResults.Error
+		}
+	}
+
+	scopeIds, scopeInfoMap, err := scopeids.GetListingScopeIds(
+		ctx, s.iamRepoFn, authResults, req.GetScopeId(), resource.Notification, req.GetRecursive(), false)
+	if err != nil {
+		return nil, err
+	}
+	// If no scopes match, return an empty response
+	if len(scopeIds) == 0 {
+		return &pbs.ListNotificationsResponse{}, nil
+	}
+
+	nl, err := s.listFromRepo(ctx, scopeIds)
+	if err != nil {
+		return nil, err
+	}
+	if len(nl) == 0 {
+		return &pbs.ListNotificationsResponse{}, nil
+	}
+
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.Notification, 0, len(nl))
+	res := perms.Resource{
+		Type: resource.Notification,
+	}
+	for _, item := range nl {
+		res.Id = item.GetPublicId()
+		res.ScopeId = item.GetScopeId()
+		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+		if len(authorizedActions) == 0 {
+			continue
+		}
+
+		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+		outputOpts := make([]handlers.Option, 0, 3)
+		outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+		if outputFields.Has(globals.ScopeField) {
+			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetScopeId()]))
+		}
+		if outputFields.Has(globals.AuthorizedActionsField) {
+			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+		}
+
+		item, err := toProto(ctx, item, outputOpts...)
+		if err != nil {
+			return nil, err
+		}
+
+		if filter.Match(item) {
+			finalItems = append(finalItems, item)
+		}
+	}
+	return &pbs.ListNotificationsResponse{Items: finalItems}, nil
+}
+
+// GetNotification implements the interface pbs.NotificationServiceServer.
+func (s Service) GetNotification(ctx context.Context, req *pbs.GetNotificationRequest) (*pbs.GetNotificationResponse, error) {
+	const op = "notifications.(Service).GetNotification"
+
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	n, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputOpts, err := s.outputOpts(ctx, op, &authResults, n.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	item, err := toProto(ctx, n, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.GetNotificationResponse{Item: item}, nil
+}
+
+// CreateNotification implements the interface pbs.NotificationServiceServer.
+// If no secret is provided in the request the generated secret is returned,
+// this is the only time it can be retrieved.
+func (s Service) CreateNotification(ctx context.Context, req *pbs.CreateNotificationRequest) (*pbs.CreateNotificationResponse, error) {
+	const op = "notifications.(Service).CreateNotification"
+
+	if err := validateCreateRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetScopeId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	n, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputOpts, err := s.outputOpts(ctx, op, &authResults, n.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	item, err := toProto(ctx, n, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetItem().GetSecret() == nil {
+		item.Secret = wrapperspb.String(string(n.GetSecret()))
+	}
+
+	return &pbs.CreateNotificationResponse{Item: item, Uri: fmt.Sprintf("notifications/%s", item.GetId())}, nil
+}
+
+// UpdateNotification implements the interface pbs.NotificationServiceServer.
+func (s Service) UpdateNotification(ctx context.Context, req *pbs.UpdateNotificationRequest) (*pbs.UpdateNotificationResponse, error) {
+	const op = "notifications.(Service).UpdateNotification"
+
+	if err := validateUpdateRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Update)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	n, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputOpts, err := s.outputOpts(ctx, op, &authResults, n.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	item, err := toProto(ctx, n, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.UpdateNotificationResponse{Item: item}, nil
+}
+
+// DeleteNotification implements the interface pbs.NotificationServiceServer.
+func (s Service) DeleteNotification(ctx context.Context, req *pbs.DeleteNotificationRequest) (*pbs.DeleteNotificationResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	_, err := s.deleteFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s Service) outputOpts(ctx context.Context, op errors.Op, authResults *auth.VerifyResults, id string) ([]handlers.Option, error) {
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, id, IdActions).Strings()))
+	}
+	return outputOpts, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*notification.Notification, error) {
+	const op = "notifications.(Service).getFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	n, err := repo.LookupNotification(ctx, id)
+	if err != nil && !errors.IsNotFoundError(err) {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if n == nil {
+		return nil, handlers.NotFoundErrorf("Notification %q doesn't exist.", id)
+	}
+	return n, nil
+}
+
+func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Notification) (*notification.Notification, error) {
+	const op = "notifications.(Service).createInRepo"
+	var opts []notification.Option
+	if item.GetName() != nil {
+		opts = append(opts, notification.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, notification.WithDescription(item.GetDescription().GetValue()))
+	}
+	if item.GetEventFilter() != nil {
+		opts = append(opts, notification.WithFilter(item.GetEventFilter().GetValue()))
+	}
+	if item.GetSecret() != nil {
+		opts = append(opts, notification.WithSecret([]byte(item.GetSecret().GetValue())))
+	}
+	n, err := notification.NewNotification(scopeId, item.GetUrl().GetValue(), item.GetEventTypes(), opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build notification for creation: %v.", err)
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, err := repo.CreateNotification(ctx, n)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create notification"))
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create notification but no error returned from repository.")
+	}
+	return out, nil
+}
+
+func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Notification) (*notification.Notification, error) {
+	const op = "notifications.(Service).updateInRepo"
+	n := &notification.Notification{
+		Notification: &store.Notification{
+			PublicId:    id,
+			ScopeId:     scopeId,
+			Name:        item.GetName().GetValue(),
+			Description: item.GetDescription().GetValue(),
+			Url:         item.GetUrl().GetValue(),
+			Filter:      item.GetEventFilter().GetValue(),
+			EventTypes:  item.GetEventTypes(),
+		},
+	}
+	if item.GetSecret() != nil {
+		n.Secret = []byte(item.GetSecret().GetValue())
+	}
+	version := item.GetVersion()
+	dbMask := maskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, rowsUpdated, err := repo.UpdateNotification(ctx, n, version, dbMask)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update notification"))
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Notification %q doesn't exist or incorrect version provided.", id)
+	}
+	return out, nil
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
+	const op = "notifications.(Service).deleteFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return false, err
+	}
+	rows, err := repo.DeleteNotification(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete notification"))
+	}
+	return rows > 0, nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*notification.Notification, error) {
+	const op = "notifications.(Service).listFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	nl, err := repo.ListNotifications(ctx, scopeIds)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return nl, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.Notification), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+		iamRepo, err := s.iamRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		scp, err := iamRepo.LookupScope(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if scp == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	default:
+		repo, err := s.repoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		n, err := repo.LookupNotification(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if n == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = n.GetScopeId()
+		opts = append(opts, auth.WithId(id))
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+// toProto converts a notification to its API representation. The secret is
+// never set by toProto.
+func toProto(ctx context.Context, in *notification.Notification, opt ...handlers.Option) (*pb.Notification, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building notification proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.Notification{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has(globals.ScopeIdField) {
+		out.ScopeId = in.GetScopeId()
+	}
+	if outputFields.Has(globals.DescriptionField) && in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if outputFields.Has(globals.NameField) && in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	if outputFields.Has(globals.CreatedTimeField) {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.UpdatedTimeField) {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.VersionField) {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has(globals.UrlField) {
+		out.Url = wrapperspb.String(in.GetUrl())
+	}
+	if outputFields.Has(globals.EventTypesField) {
+		out.EventTypes = in.GetEventTypes()
+	}
+	if outputFields.Has(globals.EventFilterField) && in.GetFilter() != "" {
+		out.EventFilter = wrapperspb.String(in.GetFilter())
+	}
+	if outputFields.Has(globals.DeliveryStatusField) && in.DeliveryStatus != nil {
+		ds := in.DeliveryStatus
+		out.DeliveryStatus = &pb.DeliveryStatus{
+			PendingCount:     uint32(ds.PendingCount),
+			DeliveredCount:   uint32(ds.DeliveredCount),
+			FailedCount:      uint32(ds.FailedCount),
+			LastAttemptTime:  ds.LastAttemptTime.GetTimestamp(),
+			LastStatus:       string(ds.LastStatus),
+			LastResponseCode: uint32(ds.LastResponseCode),
+			LastError:        ds.LastError,
+		}
+	}
+	if outputFields.Has(globals.ScopeField) {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	return &out, nil
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//  * The path passed in is correctly formatted
+//  * All required parameters are set
+//  * There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetNotificationRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, notification.NotificationPrefix)
+}
+
+func validateCreateRequest(ctx context.Context, req *pbs.CreateNotificationRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		item := req.GetItem()
+		if !handlers.ValidId(handlers.Id(item.GetScopeId()), scope.Org.Prefix()) &&
+			scope.Global.String() != item.GetScopeId() {
+			badFields[globals.ScopeIdField] = "This field must be 'global' or a valid org scope id."
+		}
+		if item.GetUrl() == nil {
+			badFields[globals.UrlField] = "This field is required."
+		}
+		if len(item.GetEventTypes()) == 0 {
+			badFields[globals.EventTypesField] = "At least one event type is required."
+		}
+		if item.GetSecret() != nil && item.GetSecret().GetValue() == "" {
+			badFields[globals.SecretField] = "This field cannot be set to empty."
+		}
+		if item.GetDeliveryStatus() != nil {
+			badFields[globals.DeliveryStatusField] = "This is a read only field."
+		}
+		validateFields(ctx, item, badFields)
+		return badFields
+	})
+}
+
+func validateUpdateRequest(ctx context.Context, req *pbs.UpdateNotificationRequest) error {
+	return handlers.ValidateUpdateRequest(req, req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		item := req.GetItem()
+		if handlers.MaskContains(req.GetUpdateMask().GetPaths(), globals.UrlField) && item.GetUrl() == nil {
+			badFields[globals.UrlField] = "This field cannot be unset."
+		}
+		if handlers.MaskContains(req.GetUpdateMask().GetPaths(), globals.EventTypesField) && len(item.GetEventTypes()) == 0 {
+			badFields[globals.EventTypesField] = "At least one event type is required."
+		}
+		if handlers.MaskContains(req.GetUpdateMask().GetPaths(), globals.SecretField) && item.GetSecret().GetValue() == "" {
+			badFields[globals.SecretField] = "This field cannot be unset."
+		}
+		if item.GetDeliveryStatus() != nil {
+			badFields[globals.DeliveryStatusField] = "This is a read only field."
+		}
+		validateFields(ctx, item, badFields)
+		return badFields
+	}, notification.NotificationPrefix)
+}
+
+// validateFields adds an entry to badFields for each of the url, event
+// types and filter of item which is set but not valid.
+func validateFields(ctx context.Context, item *pb.Notification, badFields map[string]string) {
+	if item.GetUrl() != nil {
+		if u, err := url.Parse(item.GetUrl().GetValue()); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			badFields[globals.UrlField] = "Must be an absolute http or https URL."
+		}
+	}
+	if len(item.GetEventTypes()) > 0 {
+		if err := notification.ValidateEventTypes(ctx, item.GetEventTypes()); err != nil {
+			badFields[globals.EventTypesField] = fmt.Sprintf("Unsupported event type, must be one of %v.", notification.SupportedEventTypes())
+		}
+	}
+	if item.GetEventFilter() != nil {
+		if err := notification.ValidateFilter(ctx, item.GetEventFilter().GetValue()); err != nil {
+			badFields[globals.EventFilterField] = "Unable to parse the filter."
+		}
+	}
+}
+
+func validateDeleteRequest(req *pbs.DeleteNotificationRequest) error {
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, notification.NotificationPrefix)
+}
+
+func validateListRequest(req *pbs.ListNotificationsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields[globals.ScopeIdField] = "Incorrectly formatted identifier."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
@@ -0,0 +1,760 @@
+package notifications_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	authpb "github.com/hashicorp/boundary/internal/gen/controller/auth"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/notification"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/notifications"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/notifications"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	testAuthorizedActions = []string{"no-op", "read", "update", "delete"}
+	testEventTypes        = []string{string(notification.RoleCreated), string(notification.RoleDeleted)}
+)
+
+func testNotification(t *testing.T, repo *notification.Repository, scopeId, url string, opt ...notification.Option) *notification.Notification {
+	t.Helper()
+	n, err := notification.NewNotification(scopeId, url, testEventTypes, opt...)
+	require.NoError(t, err)
+	n, err = repo.CreateNotification(context.Background(), n)
+	require.NoError(t, err)
+	n, err = repo.LookupNotification(context.Background(), n.GetPublicId())
+	require.NoError(t, err)
+	return n
+}
+
+func toWantProto(n *notification.Notification, si *scopes.ScopeInfo) *pb.Notification {
+	out := &pb.Notification{
+		Id:                n.GetPublicId(),
+		ScopeId:           n.GetScopeId(),
+		Scope:             si,
+		CreatedTime:       n.GetCreateTime().GetTimestamp(),
+		UpdatedTime:       n.GetUpdateTime().GetTimestamp(),
+		Version:           n.GetVersion(),
+		Url:               wrapperspb.String(n.GetUrl()),
+		EventTypes:        n.GetEventTypes(),
+		DeliveryStatus:    &pb.DeliveryStatus{},
+		AuthorizedActions: testAuthorizedActions,
+	}
+	if n.GetName() != "" {
+		out.Name = wrapperspb.String(n.GetName())
+	}
+	if n.GetDescription() != "" {
+		out.Description = wrapperspb.String(n.GetDescription())
+	}
+	if n.GetFilter() != "" {
+		out.EventFilter = wrapperspb.String(n.GetFilter())
+	}
+	return out
+}
+
+func TestGet(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repo, err := notification.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	repoFn := func() (*notification.Repository, error) {
+		return repo, nil
+	}
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	n := testNotification(t, repo, o.GetPublicId(), "https://hooks.example.com/get",
+		notification.WithName("name"), notification.WithDescription("desc"), notification.WithFilter(`"/data/target_name" == "prod"`))
+
+	s, err := notifications.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		req  *pbs.GetNotificationRequest
+		res  *pbs.GetNotificationResponse
+		err  error
+	}{
+		{
+			name: "Get an existing notification",
+			req:  &pbs.GetNotificationRequest{Id: n.GetPublicId()},
+			res: &pbs.GetNotificationResponse{
+				Item: toWantProto(n, &scopes.ScopeInfo{Id: o.GetPublicId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()}),
+			},
+		},
+		{
+			name: "Get a non existing notification",
+			req:  &pbs.GetNotificationRequest{Id: notification.NotificationPrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Wrong id prefix",
+			req:  &pbs.GetNotificationRequest{Id: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "space in id",
+			req:  &pbs.GetNotificationRequest{Id: notification.NotificationPrefix + "_1 23456789"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.GetNotification(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "GetNotification(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Nil(got.GetItem().GetSecret(), "the secret must never be returned by a read")
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "GetNotification(%q) got response\n%q, wanted\n%q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repo, err := notification.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	repoFn := func() (*notification.Repository, error) {
+		return repo, nil
+	}
+
+	oNoNotifications, _ := iam.TestScopes(t, iamRepo)
+	oWithNotifications, _ := iam.TestScopes(t, iamRepo)
+	globalScopeInfo := &scopes.ScopeInfo{Id: scope.Global.String(), Type: scope.Global.String(), Name: scope.Global.String(), Description: "Global Scope"}
+	orgScopeInfo := &scopes.ScopeInfo{Id: oWithNotifications.GetPublicId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()}
+
+	var wantOrg, wantGlobal, wantAll []*pb.Notification
+	for i := 0; i < 5; i++ {
+		n := testNotification(t, repo, oWithNotifications.GetPublicId(), fmt.Sprintf("https://hooks.example.com/org/%d", i))
+		wantOrg = append(wantOrg, toWantProto(n, orgScopeInfo))
+		n = testNotification(t, repo, scope.Global.String(), fmt.Sprintf("https://hooks.example.com/global/%d", i))
+		wantGlobal = append(wantGlobal, toWantProto(n, globalScopeInfo))
+	}
+	wantAll = append(wantAll, wantOrg...)
+	wantAll = append(wantAll, wantGlobal...)
+
+	s, err := notifications.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		req  *pbs.ListNotificationsRequest
+		res  *pbs.ListNotificationsResponse
+		err  error
+	}{
+		{
+			name: "List many org notifications",
+			req:  &pbs.ListNotificationsRequest{ScopeId: oWithNotifications.GetPublicId()},
+			res:  &pbs.ListNotificationsResponse{Items: wantOrg},
+		},
+		{
+			name: "List global notifications",
+			req:  &pbs.ListNotificationsRequest{ScopeId: scope.Global.String()},
+			res:  &pbs.ListNotificationsResponse{Items: wantGlobal},
+		},
+		{
+			name: "List no notifications",
+			req:  &pbs.ListNotificationsRequest{ScopeId: oNoNotifications.GetPublicId()},
+			res:  &pbs.ListNotificationsResponse{},
+		},
+		{
+			name: "List recursively",
+			req:  &pbs.ListNotificationsRequest{ScopeId: scope.Global.String(), Recursive: true},
+			res:  &pbs.ListNotificationsResponse{Items: wantAll},
+		},
+		{
+			name: "Filter to org notifications",
+			req:  &pbs.ListNotificationsRequest{ScopeId: scope.Global.String(), Recursive: true, Filter: fmt.Sprintf(`"/item/scope/id"==%q`, oWithNotifications.GetPublicId())},
+			res:  &pbs.ListNotificationsResponse{Items: wantOrg},
+		},
+		{
+			name: "Filter by url",
+			req:  &pbs.ListNotificationsRequest{ScopeId: scope.Global.String(), Recursive: true, Filter: fmt.Sprintf(`"/item/url"==%q`, wantGlobal[2].GetUrl().GetValue())},
+			res:  &pbs.ListNotificationsResponse{Items: wantGlobal[2:3]},
+		},
+		{
+			name: "Filter to no notifications",
+			req:  &pbs.ListNotificationsRequest{ScopeId: scope.Global.String(), Recursive: true, Filter: `"/item/id"=="doesntmatch"`},
+			res:  &pbs.ListNotificationsResponse{},
+		},
+		{
+			name: "Filter bad format",
+			req:  &pbs.ListNotificationsRequest{ScopeId: scope.Global.String(), Filter: `"//id/"=="bad"`},
+			err:  handlers.InvalidArgumentErrorf("bad format", nil),
+		},
+		{
+			name: "Bad scope id",
+			req:  &pbs.ListNotificationsRequest{ScopeId: "p_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.ListNotifications(auth.DisabledAuthTestContext(iamRepoFn, tc.req.GetScopeId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "ListNotifications(%q) got error %v, wanted %v", tc.req.GetScopeId(), gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform(), protocmp.SortRepeated(func(x, y *pb.Notification) bool { return x.GetId() < y.GetId() })),
+				"ListNotifications(%q) got response %q, wanted %q", tc.req.GetScopeId(), got, tc.res)
+		})
+	}
+}
+
+func TestList_Authorization(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrap), nil
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+	repo, err := notification.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	repoFn := func() (*notification.Repository, error) {
+		return repo, nil
+	}
+
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
+	readable := testNotification(t, repo, o.GetPublicId(), "https://hooks.example.com/readable")
+	hidden := testNotification(t, repo, o.GetPublicId(), "https://hooks.example.com/hidden")
+
+	at := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	noGrantsAt := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	role := iam.TestRole(t, conn, o.GetPublicId())
+	iam.TestRoleGrant(t, conn, role.GetPublicId(), "id=*;type=notification;actions=list")
+	iam.TestRoleGrant(t, conn, role.GetPublicId(), fmt.Sprintf("id=%s;actions=read", readable.GetPublicId()))
+	iam.TestUserRole(t, conn, role.GetPublicId(), at.GetIamUserId())
+
+	s, err := notifications.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	verifierCtx := func(t *testing.T, method, path string, at *authtoken.AuthToken) context.Context {
+		t.Helper()
+		req := httptest.NewRequest(method, fmt.Sprintf("http://127.0.0.1%s", path), nil)
+		requestInfo := authpb.RequestInfo{
+			Path:        req.URL.Path,
+			Method:      req.Method,
+			TokenFormat: uint32(auth.AuthTokenTypeUnknown),
+		}
+		if at != nil {
+			requestInfo.TokenFormat = uint32(auth.AuthTokenTypeBearer)
+			requestInfo.PublicId = at.GetPublicId()
+			requestInfo.Token = at.GetToken()
+		}
+		ctx := auth.NewVerifierContext(context.Background(), iamRepoFn, tokenRepoFn, serversRepoFn, kms, &requestInfo)
+		return context.WithValue(ctx, requests.ContextRequestInformationKey, &requests.RequestContext{})
+	}
+
+	t.Run("list only authorized", func(t *testing.T) {
+		got, err := s.ListNotifications(verifierCtx(t, "GET", "/v1/notifications", at), &pbs.ListNotificationsRequest{ScopeId: o.GetPublicId()})
+		require.NoError(t, err)
+		require.Len(t, got.GetItems(), 1)
+		assert.Equal(t, readable.GetPublicId(), got.GetItems()[0].GetId())
+		assert.Equal(t, []string{"read"}, got.GetItems()[0].GetAuthorizedActions())
+	})
+	t.Run("list without grants", func(t *testing.T) {
+		_, err := s.ListNotifications(verifierCtx(t, "GET", "/v1/notifications", noGrantsAt), &pbs.ListNotificationsRequest{ScopeId: o.GetPublicId()})
+		assert.True(t, errors.Is(err, handlers.ForbiddenError()), "got error %v, wanted forbidden", err)
+	})
+	t.Run("list anonymous", func(t *testing.T) {
+		_, err := s.ListNotifications(verifierCtx(t, "GET", "/v1/notifications", nil), &pbs.ListNotificationsRequest{ScopeId: o.GetPublicId()})
+		assert.True(t, errors.Is(err, handlers.UnauthenticatedError()), "got error %v, wanted unauthenticated", err)
+	})
+	t.Run("get authorized", func(t *testing.T) {
+		got, err := s.GetNotification(verifierCtx(t, "GET", "/v1/notifications/"+readable.GetPublicId(), at), &pbs.GetNotificationRequest{Id: readable.GetPublicId()})
+		require.NoError(t, err)
+		assert.Equal(t, readable.GetPublicId(), got.GetItem().GetId())
+	})
+	t.Run("get unauthorized", func(t *testing.T) {
+		_, err := s.GetNotification(verifierCtx(t, "GET", "/v1/notifications/"+hidden.GetPublicId(), at), &pbs.GetNotificationRequest{Id: hidden.GetPublicId()})
+		assert.True(t, errors.Is(err, handlers.ForbiddenError()), "got error %v, wanted forbidden", err)
+	})
+	t.Run("delete unauthorized", func(t *testing.T) {
+		_, err := s.DeleteNotification(verifierCtx(t, "DELETE", "/v1/notifications/"+readable.GetPublicId(), at), &pbs.DeleteNotificationRequest{Id: readable.GetPublicId()})
+		assert.True(t, errors.Is(err, handlers.ForbiddenError()), "got error %v, wanted forbidden", err)
+		got, err := repo.LookupNotification(context.Background(), readable.GetPublicId())
+		require.NoError(t, err)
+		assert.NotNil(t, got)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repo, err := notification.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	repoFn := func() (*notification.Repository, error) {
+		return repo, nil
+	}
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	n := testNotification(t, repo, o.GetPublicId(), "https://hooks.example.com/delete")
+
+	s, err := notifications.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		req  *pbs.DeleteNotificationRequest
+		err  error
+	}{
+		{
+			name: "Delete an existing notification",
+			req:  &pbs.DeleteNotificationRequest{Id: n.GetPublicId()},
+		},
+		{
+			name: "Delete it again",
+			req:  &pbs.DeleteNotificationRequest{Id: n.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Delete bad id",
+			req:  &pbs.DeleteNotificationRequest{Id: notification.NotificationPrefix + "_doesntexis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad id formatting",
+			req:  &pbs.DeleteNotificationRequest{Id: "bad_format"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.DeleteNotification(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "DeleteNotification(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Nil(got)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repo, err := notification.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	repoFn := func() (*notification.Repository, error) {
+		return repo, nil
+	}
+
+	o, p := iam.TestScopes(t, iamRepo)
+
+	s, err := notifications.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name          string
+		req           *pbs.CreateNotificationRequest
+		res           *pbs.CreateNotificationResponse
+		wantGenSecret bool
+		err           error
+	}{
+		{
+			name: "Create a valid notification",
+			req: &pbs.CreateNotificationRequest{Item: &pb.Notification{
+				ScopeId:     o.GetPublicId(),
+				Name:        wrapperspb.String("name"),
+				Description: wrapperspb.String("desc"),
+				Url:         wrapperspb.String("https://hooks.example.com/create"),
+				EventTypes:  testEventTypes,
+				EventFilter: wrapperspb.String(`"/data/target_name" == "prod"`),
+			}},
+			res: &pbs.CreateNotificationResponse{
+				Uri: fmt.Sprintf("notifications/%s_", notification.NotificationPrefix),
+				Item: &pb.Notification{
+					ScopeId:           o.GetPublicId(),
+					Scope:             &scopes.ScopeInfo{Id: o.GetPublicId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
+					Name:              wrapperspb.String("name"),
+					Description:       wrapperspb.String("desc"),
+					Version:           1,
+					Url:               wrapperspb.String("https://hooks.example.com/create"),
+					EventTypes:        testEventTypes,
+					EventFilter:       wrapperspb.String(`"/data/target_name" == "prod"`),
+					AuthorizedActions: testAuthorizedActions,
+				},
+			},
+			wantGenSecret: true,
+		},
+		{
+			name: "Create a global notification with a secret",
+			req: &pbs.CreateNotificationRequest{Item: &pb.Notification{
+				ScopeId:    scope.Global.String(),
+				Url:        wrapperspb.String("https://hooks.example.com/global"),
+				EventTypes: testEventTypes,
+				Secret:     wrapperspb.String("secret"),
+			}},
+			res: &pbs.CreateNotificationResponse{
+				Uri: fmt.Sprintf("notifications/%s_", notification.NotificationPrefix),
+				Item: &pb.Notification{
+					ScopeId:           scope.Global.String(),
+					Scope:             &scopes.ScopeInfo{Id: scope.Global.String(), Type: scope.Global.String(), Name: scope.Global.String(), Description: "Global Scope"},
+					Version:           1,
+					Url:               wrapperspb.String("https://hooks.example.com/global"),
+					EventTypes:        testEventTypes,
+					AuthorizedActions: testAuthorizedActions,
+				},
+			},
+		},
+		{
+			name: "Project scope",
+			req: &pbs.CreateNotificationRequest{Item: &pb.Notification{
+				ScopeId:    p.GetPublicId(),
+				Url:        wrapperspb.String("https://hooks.example.com/project"),
+				EventTypes: testEventTypes,
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Missing url",
+			req: &pbs.CreateNotificationRequest{Item: &pb.Notification{
+				ScopeId:    o.GetPublicId(),
+				EventTypes: testEventTypes,
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Relative url",
+			req: &pbs.CreateNotificationRequest{Item: &pb.Notification{
+				ScopeId:    o.GetPublicId(),
+				Url:        wrapperspb.String("/hooks"),
+				EventTypes: testEventTypes,
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Non http url",
+			req: &pbs.CreateNotificationRequest{Item: &pb.Notification{
+				ScopeId:    o.GetPublicId(),
+				Url:        wrapperspb.String("ftp://hooks.example.com"),
+				EventTypes: testEventTypes,
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Missing event types",
+			req: &pbs.CreateNotificationRequest{Item: &pb.Notification{
+				ScopeId: o.GetPublicId(),
+				Url:     wrapperspb.String("https://hooks.example.com/create"),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Unsupported event type",
+			req: &pbs.CreateNotificationRequest{Item: &pb.Notification{
+				ScopeId:    o.GetPublicId(),
+				Url:        wrapperspb.String("https://hooks.example.com/create"),
+				EventTypes: []string{"user.created"},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Bad filter",
+			req: &pbs.CreateNotificationRequest{Item: &pb.Notification{
+				ScopeId:     o.GetPublicId(),
+				Url:         wrapperspb.String("https://hooks.example.com/create"),
+				EventTypes:  testEventTypes,
+				EventFilter: wrapperspb.String(`"/type" ==`),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Empty secret",
+			req: &pbs.CreateNotificationRequest{Item: &pb.Notification{
+				ScopeId:    o.GetPublicId(),
+				Url:        wrapperspb.String("https://hooks.example.com/create"),
+				EventTypes: testEventTypes,
+				Secret:     wrapperspb.String(""),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't specify delivery status",
+			req: &pbs.CreateNotificationRequest{Item: &pb.Notification{
+				ScopeId:        o.GetPublicId(),
+				Url:            wrapperspb.String("https://hooks.example.com/create"),
+				EventTypes:     testEventTypes,
+				DeliveryStatus: &pb.DeliveryStatus{FailedCount: 1},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't specify Id",
+			req: &pbs.CreateNotificationRequest{Item: &pb.Notification{
+				Id:         notification.NotificationPrefix + "_notallowed",
+				ScopeId:    o.GetPublicId(),
+				Url:        wrapperspb.String("https://hooks.example.com/create"),
+				EventTypes: testEventTypes,
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't specify Created Time",
+			req: &pbs.CreateNotificationRequest{Item: &pb.Notification{
+				ScopeId:     o.GetPublicId(),
+				Url:         wrapperspb.String("https://hooks.example.com/create"),
+				EventTypes:  testEventTypes,
+				CreatedTime: timestamppb.Now(),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.CreateNotification(auth.DisabledAuthTestContext(iamRepoFn, tc.req.GetItem().GetScopeId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "CreateNotification(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Contains(got.GetUri(), tc.res.Uri)
+			assert.True(strings.HasPrefix(got.GetItem().GetId(), notification.NotificationPrefix+"_"))
+			assert.NotNil(got.GetItem().GetCreatedTime())
+			assert.NotNil(got.GetItem().GetUpdatedTime())
+			if tc.wantGenSecret {
+				assert.NotEmpty(got.GetItem().GetSecret().GetValue(), "a generated secret must be returned on create")
+			} else {
+				assert.Nil(got.GetItem().GetSecret(), "a provided secret must not be echoed back")
+			}
+
+			// Clear all values which are hard to compare against.
+			got.Uri, tc.res.Uri = "", ""
+			got.Item.Id = ""
+			got.Item.CreatedTime, got.Item.UpdatedTime = nil, nil
+			got.Item.Secret = nil
+			got.Item.DeliveryStatus = nil
+			sort.Strings(got.Item.EventTypes)
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "CreateNotification(%q) got response %q, wanted %q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repo, err := notification.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	repoFn := func() (*notification.Repository, error) {
+		return repo, nil
+	}
+
+	o, _ := iam.TestScopes(t, iamRepo)
+
+	s, err := notifications.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name  string
+		paths []string
+		item  *pb.Notification
+		// version overrides the version of the notification being updated
+		// when non-zero.
+		version uint32
+		want    func(*testing.T, *pb.Notification)
+		err     error
+	}{
+		{
+			name:  "Update name and description",
+			paths: []string{"name", "description"},
+			item: &pb.Notification{
+				Name:        wrapperspb.String("new"),
+				Description: wrapperspb.String("new desc"),
+			},
+			want: func(t *testing.T, got *pb.Notification) {
+				assert.Equal(t, "new", got.GetName().GetValue())
+				assert.Equal(t, "new desc", got.GetDescription().GetValue())
+				assert.Equal(t, "https://hooks.example.com/update", got.GetUrl().GetValue())
+			},
+		},
+		{
+			name:  "Update url",
+			paths: []string{"url"},
+			item:  &pb.Notification{Url: wrapperspb.String("https://hooks.example.com/updated")},
+			want: func(t *testing.T, got *pb.Notification) {
+				assert.Equal(t, "https://hooks.example.com/updated", got.GetUrl().GetValue())
+				assert.Equal(t, "Update url", got.GetName().GetValue())
+			},
+		},
+		{
+			name:  "Update event types",
+			paths: []string{"event_types"},
+			item:  &pb.Notification{EventTypes: []string{string(notification.SessionAuthorized)}},
+			want: func(t *testing.T, got *pb.Notification) {
+				assert.Equal(t, []string{string(notification.SessionAuthorized)}, got.GetEventTypes())
+			},
+		},
+		{
+			name:  "Update filter",
+			paths: []string{"event_filter"},
+			item:  &pb.Notification{EventFilter: wrapperspb.String(`"/data/target_name" == "dev"`)},
+			want: func(t *testing.T, got *pb.Notification) {
+				assert.Equal(t, `"/data/target_name" == "dev"`, got.GetEventFilter().GetValue())
+			},
+		},
+		{
+			name:  "Update secret",
+			paths: []string{"secret"},
+			item:  &pb.Notification{Secret: wrapperspb.String("rotated")},
+			want: func(t *testing.T, got *pb.Notification) {
+				assert.Nil(t, got.GetSecret(), "the secret must not be echoed back")
+			},
+		},
+		{
+			name:    "Wrong version",
+			paths:   []string{"name"},
+			item:    &pb.Notification{Name: wrapperspb.String("new")},
+			version: 100,
+			err:     handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "No update mask",
+			item: &pb.Notification{Name: wrapperspb.String("new")},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:  "Only non existent paths in mask",
+			paths: []string{"nonexistent_field"},
+			item:  &pb.Notification{Name: wrapperspb.String("new")},
+			err:   handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:  "Unset url",
+			paths: []string{"url"},
+			item:  &pb.Notification{},
+			err:   handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:  "Bad url",
+			paths: []string{"url"},
+			item:  &pb.Notification{Url: wrapperspb.String("not a url")},
+			err:   handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:  "Unset event types",
+			paths: []string{"event_types"},
+			item:  &pb.Notification{},
+			err:   handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:  "Unsupported event type",
+			paths: []string{"event_types"},
+			item:  &pb.Notification{EventTypes: []string{"user.created"}},
+			err:   handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:  "Unset secret",
+			paths: []string{"secret"},
+			item:  &pb.Notification{},
+			err:   handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:  "Can't update delivery status",
+			paths: []string{"name"},
+			item:  &pb.Notification{Name: wrapperspb.String("new"), DeliveryStatus: &pb.DeliveryStatus{FailedCount: 1}},
+			err:   handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			n := testNotification(t, repo, o.GetPublicId(), "https://hooks.example.com/update", notification.WithName(tc.name))
+			item := tc.item
+			item.Version = n.GetVersion()
+			if tc.version != 0 {
+				item.Version = tc.version
+			}
+			req := &pbs.UpdateNotificationRequest{
+				Id:   n.GetPublicId(),
+				Item: item,
+			}
+			if tc.paths != nil {
+				req.UpdateMask = &field_mask.FieldMask{Paths: tc.paths}
+			}
+
+			got, gErr := s.UpdateNotification(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "UpdateNotification(%+v) got error %v, wanted %v", req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal(n.GetPublicId(), got.GetItem().GetId())
+			assert.Equal(n.GetVersion()+1, got.GetItem().GetVersion())
+			assert.Equal(testAuthorizedActions, got.GetItem().GetAuthorizedActions())
+			tc.want(t, got.GetItem())
+		})
+	}
+}
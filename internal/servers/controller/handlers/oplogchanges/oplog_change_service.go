@@ -0,0 +1,211 @@
+package oplogchanges
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/changefeed"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/oplogchanges"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// CollectionActions contains the set of actions that can be performed on
+// this collection
+var CollectionActions = action.ActionSet{
+	action.List,
+}
+
+// Service handles request as described by the pbs.OplogChangeServiceServer interface.
+type Service struct {
+	pbs.UnimplementedOplogChangeServiceServer
+
+	repoFn    common.OplogChangeRepoFactory
+	iamRepoFn common.IamRepoFactory
+}
+
+// NewService returns an oplog change service which handles oplog change
+// related requests to boundary.
+func NewService(repoFn common.OplogChangeRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+	const op = "oplogchanges.NewService"
+	if repoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing oplog change repository")
+	}
+	if iamRepoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
+	}
+	return Service{repoFn: repoFn, iamRepoFn: iamRepoFn}, nil
+}
+
+var _ pbs.OplogChangeServiceServer = Service{}
+
+// ListOplogChanges implements the interface pbs.OplogChangeServiceServer.
+func (s Service) ListOplogChanges(ctx context.Context, req *pbs.ListOplogChangesRequest) (*pbs.ListOplogChangesResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+
+	cl, err := s.listFromRepo(ctx, req.GetSinceId(), req.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+	if len(cl) == 0 {
+		return &pbs.ListOplogChangesResponse{}, nil
+	}
+
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	outputFields := authResults.FetchOutputFields(perms.Resource{
+		ScopeId: req.GetScopeId(),
+		Type:    resource.OplogChange,
+	}, action.List).SelfOrDefaults(authResults.UserId)
+
+	finalItems := make([]*pb.OplogChange, 0, len(cl))
+	for _, c := range cl {
+		item, err := toProto(ctx, c, handlers.WithOutputFields(&outputFields))
+		if err != nil {
+			return nil, err
+		}
+		if filter.Match(item) {
+			finalItems = append(finalItems, item)
+		}
+	}
+	return &pbs.ListOplogChangesResponse{Items: finalItems}, nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, sinceId, limit uint32) ([]*changefeed.Change, error) {
+	const op = "oplogchanges.(Service).listFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	var opts []changefeed.Option
+	if limit > 0 {
+		opts = append(opts, changefeed.WithLimit(int(limit)))
+	}
+	cl, err := repo.ListChanges(ctx, sinceId, opts...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return cl, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		res.Error = err
+		return res
+	}
+	scp, err := iamRepo.LookupScope(ctx, id)
+	if err != nil {
+		res.Error = err
+		return res
+	}
+	if scp == nil {
+		res.Error = handlers.NotFoundError()
+		return res
+	}
+	return auth.Verify(ctx, auth.WithType(resource.OplogChange), auth.WithAction(a), auth.WithScopeId(id))
+}
+
+func toProto(ctx context.Context, in *changefeed.Change, opt ...handlers.Option) (*pb.OplogChange, error) {
+	const op = "oplogchanges.toProto"
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building oplog change proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.OplogChange{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.EntryId
+	}
+	if outputFields.Has(globals.ScopeIdField) {
+		out.ScopeId = in.ScopeId
+	}
+	if outputFields.Has(globals.AggregateNameField) {
+		out.AggregateName = in.AggregateName
+	}
+	if outputFields.Has(globals.CreatedTimeField) {
+		out.CreatedTime = in.CreateTime.GetTimestamp()
+	}
+	if outputFields.Has(globals.MetadataField) && len(in.Metadata) > 0 {
+		md := make(map[string]interface{}, len(in.Metadata))
+		for k, vals := range in.Metadata {
+			l := make([]interface{}, 0, len(vals))
+			for _, v := range vals {
+				l = append(l, v)
+			}
+			md[k] = l
+		}
+		st, err := structpb.NewStruct(md)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to convert metadata"))
+		}
+		out.Metadata = st
+	}
+	if outputFields.Has(globals.OperationsField) {
+		for _, o := range in.Operations {
+			po := &pb.OplogOperation{
+				TypeName:       o.TypeName,
+				OpType:         opTypeString(o.OpType),
+				FieldMaskPaths: o.FieldMaskPaths,
+				SetToNullPaths: o.SetToNullPaths,
+			}
+			if o.Message != nil {
+				st, err := handlers.ProtoToStruct(o.Message)
+				if err != nil {
+					return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to convert %s value", o.TypeName)))
+				}
+				po.Value = st
+			}
+			out.Operations = append(out.Operations, po)
+		}
+	}
+	return &out, nil
+}
+
+// opTypeString returns the lower case name of the oplog operation type without
+// its enum prefix, for example "create" for OpType_OP_TYPE_CREATE.
+func opTypeString(t oplog.OpType) string {
+	return strings.ToLower(strings.TrimPrefix(t.String(), "OP_TYPE_"))
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//  * The path passed in is correctly formatted
+//  * All required parameters are set
+//  * There are no conflicting parameters provided
+func validateListRequest(req *pbs.ListOplogChangesRequest) error {
+	badFields := map[string]string{}
+	if req.GetScopeId() != scope.Global.String() {
+		badFields["scope_id"] = "This field must be 'global'."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
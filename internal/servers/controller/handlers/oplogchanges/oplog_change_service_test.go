@@ -0,0 +1,100 @@
+package oplogchanges_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog/changefeed"
+	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/oplogchanges"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestNewService(t *testing.T) {
+	iamRepoFn := func() (*iam.Repository, error) { return nil, nil }
+	repoFn := func() (*changefeed.Repository, error) { return nil, nil }
+
+	_, err := oplogchanges.NewService(nil, iamRepoFn)
+	assert.Error(t, err)
+	_, err = oplogchanges.NewService(repoFn, nil)
+	assert.Error(t, err)
+	_, err = oplogchanges.NewService(repoFn, iamRepoFn)
+	assert.NoError(t, err)
+}
+
+func TestList(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*changefeed.Repository, error) {
+		return changefeed.NewRepository(rw, kms)
+	}
+
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	s, err := oplogchanges.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+	ctx := auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String())
+
+	t.Run("list-all", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ListOplogChanges(ctx, &pbs.ListOplogChangesRequest{ScopeId: scope.Global.String()})
+		require.NoError(err)
+		require.NotEmpty(got.GetItems())
+
+		var found bool
+		for _, c := range got.GetItems() {
+			for _, o := range c.GetOperations() {
+				if o.GetTypeName() == "iam_scope" && o.GetOpType() == "create" &&
+					o.GetValue().GetFields()["public_id"].GetStringValue() == org.GetPublicId() {
+					found = true
+				}
+			}
+		}
+		assert.True(found, "missing create of org scope")
+	})
+	t.Run("since-and-limit", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		first, err := s.ListOplogChanges(ctx, &pbs.ListOplogChangesRequest{ScopeId: scope.Global.String(), Limit: 1})
+		require.NoError(err)
+		require.Len(first.GetItems(), 1)
+
+		next, err := s.ListOplogChanges(ctx, &pbs.ListOplogChangesRequest{ScopeId: scope.Global.String(), SinceId: first.GetItems()[0].GetId(), Limit: 1})
+		require.NoError(err)
+		require.Len(next.GetItems(), 1)
+		assert.Greater(next.GetItems()[0].GetId(), first.GetItems()[0].GetId())
+	})
+	t.Run("filter", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ListOplogChanges(ctx, &pbs.ListOplogChangesRequest{
+			ScopeId: scope.Global.String(),
+			Filter:  fmt.Sprintf(`"/item/aggregate_name"==%q`, "thisdoesntmatch"),
+		})
+		require.NoError(err)
+		assert.Empty(got.GetItems())
+	})
+	t.Run("non-global-scope", func(t *testing.T) {
+		_, err := s.ListOplogChanges(ctx, &pbs.ListOplogChangesRequest{ScopeId: org.GetPublicId()})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+	})
+	t.Run("bad-filter", func(t *testing.T) {
+		_, err := s.ListOplogChanges(ctx, &pbs.ListOplogChangesRequest{ScopeId: scope.Global.String(), Filter: `"//id/"=="bad"`})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+	})
+}
@@ -86,4 +86,16 @@ func Test_GetOpts(t *testing.T) {
 		opts = GetOpts(WithHostSetIds(out))
 		require.Equal(out, opts.WithHostSetIds)
 	})
+	t.Run("WithLabels", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		opts := GetOpts()
+		assert.Nil(opts.WithLabels)
+
+		out := map[string]string{"env": "prod"}
+
+		opts = GetOpts(WithLabels(out))
+		require.Equal(out, opts.WithLabels)
+	})
 }
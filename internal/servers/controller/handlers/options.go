@@ -33,6 +33,7 @@ type options struct {
 	WithManagedGroupIds             []string
 	WithMemberIds                   []string
 	WithHostSetIds                  []string
+	WithLabels                      map[string]string
 }
 
 func getDefaultOptions() options {
@@ -119,3 +120,11 @@ func WithHostSetIds(ids []string) Option {
 		o.WithHostSetIds = ids
 	}
 }
+
+// WithLabels provides an option when creating responses to include the given
+// labels if allowed
+func WithLabels(labels map[string]string) Option {
+	return func(o *options) {
+		o.WithLabels = labels
+	}
+}
@@ -0,0 +1,274 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/report"
+	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/reports"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var (
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.Run,
+	}
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.ActionSet{
+		action.List,
+	}
+)
+
+// Service handles request as described by the pbs.ReportServiceServer interface.
+type Service struct {
+	pbs.UnimplementedReportServiceServer
+
+	repoFn    common.ReportRepoFactory
+	iamRepoFn common.IamRepoFactory
+}
+
+// NewService returns a report service which handles report related requests
+// to boundary.
+func NewService(repoFn common.ReportRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+	const op = "reports.NewService"
+	if repoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing report repository")
+	}
+	if iamRepoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
+	}
+	return Service{repoFn: repoFn, iamRepoFn: iamRepoFn}, nil
+}
+
+var _ pbs.ReportServiceServer = Service{}
+
+// ListReports implements the interface pbs.ReportServiceServer.
+func (s Service) ListReports(ctx context.Context, req *pbs.ListReportsRequest) (*pbs.ListReportsResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), "", action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	rl := report.List()
+	finalItems := make([]*pb.Report, 0, len(rl))
+	res := perms.Resource{
+		ScopeId: req.GetScopeId(),
+		Type:    resource.Report,
+	}
+	for _, r := range rl {
+		res.Id = r.Name
+		authorizedActions := authResults.FetchActionSetForId(ctx, r.Name, IdActions, auth.WithResource(&res)).Strings()
+		if len(authorizedActions) == 0 {
+			continue
+		}
+
+		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+		outputOpts := make([]handlers.Option, 0, 3)
+		outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+		if outputFields.Has(globals.AuthorizedActionsField) {
+			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+		}
+
+		item, err := toProto(r, req.GetScopeId(), outputOpts...)
+		if err != nil {
+			return nil, err
+		}
+		if filter.Match(item) {
+			finalItems = append(finalItems, item)
+		}
+	}
+	return &pbs.ListReportsResponse{Items: finalItems}, nil
+}
+
+// RunReport implements the interface pbs.ReportServiceServer.
+func (s Service) RunReport(ctx context.Context, req *pbs.RunReportRequest) (*pbs.RunReportResponse, error) {
+	const op = "reports.(Service).RunReport"
+	if err := validateRunRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), req.GetId(), action.Run)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	var opts []report.Option
+	if req.GetScopeId() != scope.Global.String() {
+		opts = append(opts, report.WithScopeIds([]string{req.GetScopeId()}))
+	}
+	if req.GetStartTime() != nil {
+		opts = append(opts, report.WithStartTime(req.GetStartTime().AsTime()))
+	}
+	if req.GetEndTime() != nil {
+		opts = append(opts, report.WithEndTime(req.GetEndTime().AsTime()))
+	}
+	if req.GetLimit() > 0 {
+		opts = append(opts, report.WithLimit(int(req.GetLimit())))
+	}
+	res, err := repo.RunReport(ctx, req.GetId(), opts...)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("Report %q not found.", req.GetId())
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	outputFields := authResults.FetchOutputFields(perms.Resource{
+		Id:      req.GetId(),
+		ScopeId: req.GetScopeId(),
+		Type:    resource.Report,
+	}, action.Run).SelfOrDefaults(authResults.UserId)
+
+	item, err := toResultProto(ctx, res, req.GetScopeId(), handlers.WithOutputFields(&outputFields))
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.RunReportResponse{Item: item}, nil
+}
+
+func (s Service) authResult(ctx context.Context, scopeId, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		res.Error = err
+		return res
+	}
+	scp, err := iamRepo.LookupScope(ctx, scopeId)
+	if err != nil {
+		res.Error = err
+		return res
+	}
+	if scp == nil {
+		res.Error = handlers.NotFoundError()
+		return res
+	}
+	opts := []auth.Option{auth.WithType(resource.Report), auth.WithAction(a), auth.WithScopeId(scopeId)}
+	if id != "" {
+		if report.Lookup(id) == nil {
+			res.Error = handlers.NotFoundErrorf("Report %q not found.", id)
+			return res
+		}
+		opts = append(opts, auth.WithId(id))
+	}
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(in *report.Report, scopeId string, opt ...handlers.Option) (*pb.Report, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building report proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.Report{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.Name
+	}
+	if outputFields.Has(globals.ScopeIdField) {
+		out.ScopeId = scopeId
+	}
+	if outputFields.Has(globals.DescriptionField) {
+		out.Description = in.Description
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	return &out, nil
+}
+
+func toResultProto(ctx context.Context, in *report.Result, scopeId string, opt ...handlers.Option) (*pb.ReportResult, error) {
+	const op = "reports.toResultProto"
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building report result proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.ReportResult{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.Name
+	}
+	if outputFields.Has(globals.ScopeIdField) {
+		out.ScopeId = scopeId
+	}
+	if outputFields.Has(globals.ColumnsField) {
+		out.Columns = in.Columns
+	}
+	if outputFields.Has(globals.RowsField) {
+		out.Rows = make([]*structpb.ListValue, 0, len(in.Rows))
+		for _, r := range in.Rows {
+			lv, err := structpb.NewList(r)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to convert report row"))
+			}
+			out.Rows = append(out.Rows, lv)
+		}
+	}
+	return &out, nil
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//  * The path passed in is correctly formatted
+//  * All required parameters are set
+//  * There are no conflicting parameters provided
+func validateListRequest(req *pbs.ListReportsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix(), scope.Project.Prefix()) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields["scope_id"] = "Improperly formatted field."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
+
+func validateRunRequest(req *pbs.RunReportRequest) error {
+	badFields := map[string]string{}
+	if req.GetId() == "" {
+		badFields["id"] = "This field is required."
+	}
+	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix(), scope.Project.Prefix()) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields["scope_id"] = "Improperly formatted field."
+	}
+	if req.GetStartTime() != nil && req.GetEndTime() != nil &&
+		!req.GetStartTime().AsTime().Before(req.GetEndTime().AsTime()) {
+		badFields["end_time"] = "This field must be after the start time."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package reports_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/report"
+	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/reports"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestNewService(t *testing.T) {
+	iamRepoFn := func() (*iam.Repository, error) { return nil, nil }
+	repoFn := func() (*report.Repository, error) { return nil, nil }
+
+	_, err := reports.NewService(nil, iamRepoFn)
+	assert.Error(t, err)
+	_, err = reports.NewService(repoFn, nil)
+	assert.Error(t, err)
+	_, err = reports.NewService(repoFn, iamRepoFn)
+	assert.NoError(t, err)
+}
+
+func TestList(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*report.Repository, error) {
+		return report.NewRepository(rw, rw)
+	}
+	org, proj := iam.TestScopes(t, iamRepo)
+
+	s, err := reports.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	for _, scopeId := range []string{scope.Global.String(), org.GetPublicId(), proj.GetPublicId()} {
+		t.Run(scopeId, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := auth.DisabledAuthTestContext(iamRepoFn, scopeId)
+			got, err := s.ListReports(ctx, &pbs.ListReportsRequest{ScopeId: scopeId})
+			require.NoError(err)
+			require.Len(got.GetItems(), len(report.List()))
+			for i, r := range report.List() {
+				assert.Equal(r.Name, got.GetItems()[i].GetId())
+				assert.Equal(scopeId, got.GetItems()[i].GetScopeId())
+				assert.Equal(r.Description, got.GetItems()[i].GetDescription())
+				assert.Equal([]string{"run"}, got.GetItems()[i].GetAuthorizedActions())
+			}
+		})
+	}
+	t.Run("filter", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ctx := auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String())
+		got, err := s.ListReports(ctx, &pbs.ListReportsRequest{
+			ScopeId: scope.Global.String(),
+			Filter:  fmt.Sprintf(`"/item/id"==%q`, report.TopHosts),
+		})
+		require.NoError(err)
+		require.Len(got.GetItems(), 1)
+		assert.Equal(report.TopHosts, got.GetItems()[0].GetId())
+	})
+	t.Run("bad-scope", func(t *testing.T) {
+		ctx := auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String())
+		_, err := s.ListReports(ctx, &pbs.ListReportsRequest{ScopeId: "u_1234567890"})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+	})
+}
+
+func TestRun(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*report.Repository, error) {
+		return report.NewRepository(rw, rw)
+	}
+	sess := session.TestDefaultSession(t, conn, wrap, iamRepo)
+	otherOrg, _ := iam.TestScopes(t, iamRepo)
+
+	s, err := reports.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	t.Run("project", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ctx := auth.DisabledAuthTestContext(iamRepoFn, sess.ScopeId)
+		got, err := s.RunReport(ctx, &pbs.RunReportRequest{Id: report.SessionsPerUser, ScopeId: sess.ScopeId})
+		require.NoError(err)
+		item := got.GetItem()
+		assert.Equal(report.SessionsPerUser, item.GetId())
+		assert.Equal(sess.ScopeId, item.GetScopeId())
+		assert.Equal("user_id", item.GetColumns()[0])
+		require.Len(item.GetRows(), 1)
+		assert.Equal(sess.UserId, item.GetRows()[0].GetValues()[0].GetStringValue())
+	})
+	t.Run("other-org", func(t *testing.T) {
+		ctx := auth.DisabledAuthTestContext(iamRepoFn, otherOrg.GetPublicId())
+		got, err := s.RunReport(ctx, &pbs.RunReportRequest{Id: report.SessionsPerUser, ScopeId: otherOrg.GetPublicId()})
+		require.NoError(t, err)
+		assert.Empty(t, got.GetItem().GetRows())
+	})
+	t.Run("time-range", func(t *testing.T) {
+		ctx := auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String())
+		got, err := s.RunReport(ctx, &pbs.RunReportRequest{
+			Id:        report.SessionsPerDay,
+			ScopeId:   scope.Global.String(),
+			StartTime: timestamppb.New(time.Now().Add(time.Hour)),
+		})
+		require.NoError(t, err)
+		assert.Empty(t, got.GetItem().GetRows())
+	})
+	t.Run("unknown-report", func(t *testing.T) {
+		ctx := auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String())
+		_, err := s.RunReport(ctx, &pbs.RunReportRequest{Id: "not-a-report", ScopeId: scope.Global.String()})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.NotFoundError()))
+	})
+	t.Run("bad-time-range", func(t *testing.T) {
+		ctx := auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String())
+		now := time.Now()
+		_, err := s.RunReport(ctx, &pbs.RunReportRequest{
+			Id:        report.SessionsPerDay,
+			ScopeId:   scope.Global.String(),
+			StartTime: timestamppb.New(now),
+			EndTime:   timestamppb.New(now.Add(-time.Hour)),
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+	})
+}
@@ -971,8 +971,9 @@ func validateAddRolePrincipalsRequest(req *pbs.AddRolePrincipalsRequest) error {
 	for _, id := range req.GetPrincipalIds() {
 		if !handlers.ValidId(handlers.Id(id), iam.GroupPrefix) &&
 			!handlers.ValidId(handlers.Id(id), iam.UserPrefix) &&
-			!handlers.ValidId(handlers.Id(id), intglobals.OidcManagedGroupPrefix) {
-			badFields["principal_ids"] = "Must only have valid user, group, and/or managed group ids."
+			!handlers.ValidId(handlers.Id(id), intglobals.OidcManagedGroupPrefix) &&
+			!handlers.ValidId(handlers.Id(id), iam.ServiceAccountPrefix) {
+			badFields["principal_ids"] = "Must only have valid user, group, managed group, and/or service account ids."
 			break
 		}
 		if id == "u_recovery" {
@@ -997,8 +998,9 @@ func validateSetRolePrincipalsRequest(req *pbs.SetRolePrincipalsRequest) error {
 	for _, id := range req.GetPrincipalIds() {
 		if !handlers.ValidId(handlers.Id(id), iam.GroupPrefix) &&
 			!handlers.ValidId(handlers.Id(id), iam.UserPrefix) &&
-			!handlers.ValidId(handlers.Id(id), intglobals.OidcManagedGroupPrefix) {
-			badFields["principal_ids"] = "Must only have valid user, group, and/or managed group ids."
+			!handlers.ValidId(handlers.Id(id), intglobals.OidcManagedGroupPrefix) &&
+			!handlers.ValidId(handlers.Id(id), iam.ServiceAccountPrefix) {
+			badFields["principal_ids"] = "Must only have valid user, group, managed group, and/or service account ids."
 			break
 		}
 		if id == "u_recovery" {
@@ -1026,8 +1028,9 @@ func validateRemoveRolePrincipalsRequest(req *pbs.RemoveRolePrincipalsRequest) e
 	for _, id := range req.GetPrincipalIds() {
 		if !handlers.ValidId(handlers.Id(id), iam.GroupPrefix) &&
 			!handlers.ValidId(handlers.Id(id), iam.UserPrefix) &&
-			!handlers.ValidId(handlers.Id(id), intglobals.OidcManagedGroupPrefix) {
-			badFields["principal_ids"] = "Must only have valid user, group, and/or managed group ids."
+			!handlers.ValidId(handlers.Id(id), intglobals.OidcManagedGroupPrefix) &&
+			!handlers.ValidId(handlers.Id(id), iam.ServiceAccountPrefix) {
+			badFields["principal_ids"] = "Must only have valid user, group, managed group, and/or service account ids."
 			break
 		}
 	}
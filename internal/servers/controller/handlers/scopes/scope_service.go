@@ -7,8 +7,10 @@ import (
 	"strings"
 
 	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/auth/cert"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
@@ -19,15 +21,21 @@ import (
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/aliases"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authmethods"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authtokens"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/credentialstores"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/groups"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/host_catalogs"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/notifications"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/oplogchanges"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/reports"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/roles"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/serviceaccounts"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/sessions"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/targets"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/users"
+	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
@@ -46,6 +54,8 @@ var (
 		action.Read,
 		action.Update,
 		action.Delete,
+		action.RevokeTokens,
+		action.CancelSessions,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -57,27 +67,38 @@ var (
 
 	scopeCollectionTypeMapMap = map[string]map[resource.Type]action.ActionSet{
 		scope.Global.String(): {
-			resource.AuthMethod: authmethods.CollectionActions,
-			resource.AuthToken:  authtokens.CollectionActions,
-			resource.Group:      groups.CollectionActions,
-			resource.Role:       roles.CollectionActions,
-			resource.Scope:      CollectionActions,
-			resource.User:       users.CollectionActions,
+			resource.Alias:          aliases.CollectionActions,
+			resource.AuthMethod:     authmethods.CollectionActions,
+			resource.AuthToken:      authtokens.CollectionActions,
+			resource.Group:          groups.CollectionActions,
+			resource.Notification:   notifications.CollectionActions,
+			resource.OplogChange:    oplogchanges.CollectionActions,
+			resource.Report:         reports.CollectionActions,
+			resource.Role:           roles.CollectionActions,
+			resource.Scope:          CollectionActions,
+			resource.ServiceAccount: serviceaccounts.CollectionActions,
+			resource.User:           users.CollectionActions,
 		},
 
 		scope.Org.String(): {
-			resource.AuthMethod: authmethods.CollectionActions,
-			resource.AuthToken:  authtokens.CollectionActions,
-			resource.Group:      groups.CollectionActions,
-			resource.Role:       roles.CollectionActions,
-			resource.Scope:      CollectionActions,
-			resource.User:       users.CollectionActions,
+			resource.Alias:          aliases.CollectionActions,
+			resource.AuthMethod:     authmethods.CollectionActions,
+			resource.AuthToken:      authtokens.CollectionActions,
+			resource.Group:          groups.CollectionActions,
+			resource.Notification:   notifications.CollectionActions,
+			resource.Report:         reports.CollectionActions,
+			resource.Role:           roles.CollectionActions,
+			resource.Scope:          CollectionActions,
+			resource.ServiceAccount: serviceaccounts.CollectionActions,
+			resource.User:           users.CollectionActions,
 		},
 
 		scope.Project.String(): {
+			resource.Alias:           aliases.CollectionActions,
 			resource.CredentialStore: credentialstores.CollectionActions,
 			resource.Group:           groups.CollectionActions,
 			resource.HostCatalog:     host_catalogs.CollectionActions,
+			resource.Report:          reports.CollectionActions,
 			resource.Role:            roles.CollectionActions,
 			resource.Session:         sessions.CollectionActions,
 			resource.Target:          targets.CollectionActions,
@@ -96,16 +117,26 @@ func init() {
 type Service struct {
 	pbs.UnimplementedScopeServiceServer
 
-	repoFn common.IamRepoFactory
+	repoFn        common.IamRepoFactory
+	atRepoFn      common.AuthTokenRepoFactory
+	sessionRepoFn common.SessionRepoFactory
+	terminations  *session.TerminationBroadcaster
 }
 
-// NewService returns a project service which handles project related requests to boundary.
-func NewService(repo common.IamRepoFactory) (Service, error) {
+// NewService returns a project service which handles project related requests
+// to boundary. The auth token and session repositories and the termination
+// broadcaster are only used to revoke the auth tokens of the users of a scope.
+func NewService(repo common.IamRepoFactory, atRepoFn common.AuthTokenRepoFactory, sessionRepoFn common.SessionRepoFactory, terminations *session.TerminationBroadcaster) (Service, error) {
 	const op = "scopes.(Service).NewService"
 	if repo == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
 	}
-	return Service{repoFn: repo}, nil
+	return Service{
+		repoFn:        repo,
+		atRepoFn:      atRepoFn,
+		sessionRepoFn: sessionRepoFn,
+		terminations:  terminations,
+	}, nil
 }
 
 var _ pbs.ScopeServiceServer = Service{}
@@ -353,6 +384,66 @@ func (s Service) DeleteScope(ctx context.Context, req *pbs.DeleteScopeRequest) (
 	return nil, nil
 }
 
+// RevokeScopeTokens implements the interface pbs.ScopeServiceServer.
+func (s Service) RevokeScopeTokens(ctx context.Context, req *pbs.RevokeScopeTokensRequest) (*pbs.RevokeScopeTokensResponse, error) {
+	if err := validateRevokeScopeTokensRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RevokeTokens)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	count, sessionIds, err := authtokens.Revoke(ctx, s.atRepoFn, s.sessionRepoFn, s.terminations, req.GetCancelSessions(),
+		func(ctx context.Context, repo *authtoken.Repository) ([]*authtoken.AuthToken, error) {
+			return repo.RevokeAuthTokensForScope(ctx, req.GetId())
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.RevokeScopeTokensResponse{RevokedTokenCount: count, CanceledSessionIds: sessionIds}, nil
+}
+
+// CancelScopeSessions implements the interface pbs.ScopeServiceServer.
+func (s Service) CancelScopeSessions(ctx context.Context, req *pbs.CancelScopeSessionsRequest) (*pbs.CancelScopeSessionsResponse, error) {
+	if err := validateCancelScopeSessionsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.CancelSessions)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	var opts []session.Option
+	if req.GetId() != scope.Global.String() {
+		scopeIds, err := s.listScopeIdsRecursively(ctx, req.GetId())
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, session.WithScopeIds(scopeIds))
+	}
+	sessionIds, err := sessions.CancelMatching(ctx, s.sessionRepoFn, s.terminations, req.GetFilter(), req.GetReason(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.CancelScopeSessionsResponse{CanceledSessionCount: uint32(len(sessionIds)), CanceledSessionIds: sessionIds}, nil
+}
+
+// listScopeIdsRecursively returns id and the ids of the scopes below it.
+func (s Service) listScopeIdsRecursively(ctx context.Context, id string) ([]string, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	scps, err := repo.ListScopesRecursively(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	scopeIds := make([]string, 0, len(scps))
+	for _, scp := range scps {
+		scopeIds = append(scopeIds, scp.GetPublicId())
+	}
+	return scopeIds, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*iam.Scope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -420,7 +511,7 @@ func (s Service) updateInRepo(ctx context.Context, parentScope *pb.ScopeInfo, sc
 		opts = append(opts, iam.WithName(scopeName))
 	}
 	if primaryAuthMethodId := item.GetPrimaryAuthMethodId(); primaryAuthMethodId != nil {
-		if !handlers.ValidId(handlers.Id(primaryAuthMethodId.GetValue()), password.AuthMethodPrefix, oidc.AuthMethodPrefix) {
+		if !handlers.ValidId(handlers.Id(primaryAuthMethodId.GetValue()), password.AuthMethodPrefix, oidc.AuthMethodPrefix, cert.AuthMethodPrefix) {
 			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"primary_auth_method_id": "Improperly formatted identifier"})
 		}
 		scopePrimaryAuthMethodId = primaryAuthMethodId.GetValue()
@@ -697,7 +788,7 @@ func validateUpdateRequest(req *pbs.UpdateScopeRequest) error {
 	if item.GetUpdatedTime() != nil {
 		badFields["updated_time"] = "This is a read only field and cannot be specified in an update request."
 	}
-	if item.GetPrimaryAuthMethodId().GetValue() != "" && !handlers.ValidId(handlers.Id(item.GetPrimaryAuthMethodId().GetValue()), password.AuthMethodPrefix, oidc.AuthMethodPrefix) {
+	if item.GetPrimaryAuthMethodId().GetValue() != "" && !handlers.ValidId(handlers.Id(item.GetPrimaryAuthMethodId().GetValue()), password.AuthMethodPrefix, oidc.AuthMethodPrefix, cert.AuthMethodPrefix) {
 		badFields["primary_auth_method_id"] = "Improperly formatted identifier."
 	}
 	if len(badFields) > 0 {
@@ -730,6 +821,49 @@ func validateDeleteRequest(req *pbs.DeleteScopeRequest) error {
 	return nil
 }
 
+func validateRevokeScopeTokensRequest(req *pbs.RevokeScopeTokensRequest) error {
+	badFields := map[string]string{}
+	id := req.GetId()
+	switch {
+	case id == scope.Global.String():
+	case strings.HasPrefix(id, scope.Org.Prefix()):
+		if !handlers.ValidId(handlers.Id(id), scope.Org.Prefix()) {
+			badFields["id"] = "Invalidly formatted scope id."
+		}
+	case strings.HasPrefix(id, scope.Project.Prefix()):
+		badFields["id"] = "Projects do not contain users."
+	default:
+		badFields["id"] = "Invalidly formatted scope id."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateCancelScopeSessionsRequest(req *pbs.CancelScopeSessionsRequest) error {
+	badFields := map[string]string{}
+	id := req.GetId()
+	switch {
+	case id == scope.Global.String():
+	case strings.HasPrefix(id, scope.Org.Prefix()):
+		if !handlers.ValidId(handlers.Id(id), scope.Org.Prefix()) {
+			badFields["id"] = "Invalidly formatted scope id."
+		}
+	case strings.HasPrefix(id, scope.Project.Prefix()):
+		if !handlers.ValidId(handlers.Id(id), scope.Project.Prefix()) {
+			badFields["id"] = "Invalidly formatted scope id."
+		}
+	default:
+		badFields["id"] = "Invalidly formatted scope id."
+	}
+	sessions.ValidateCancelMatching(badFields, req.GetFilter(), req.GetReason())
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
 func validateListRequest(req *pbs.ListScopesRequest) error {
 	badFields := map[string]string{}
 	if req.GetScopeId() != scope.Global.String() && !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) {
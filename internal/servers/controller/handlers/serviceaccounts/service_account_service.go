@@ -0,0 +1,793 @@
+package serviceaccounts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/common/scopeids"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authtokens"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pba "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authtokens"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/serviceaccounts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	maskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.NoOp,
+		action.Read,
+		action.Update,
+		action.Delete,
+		action.AddCredential,
+		action.RemoveCredential,
+		action.Authenticate,
+	}
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.ActionSet{
+		action.Create,
+		action.List,
+	}
+)
+
+func init() {
+	var err error
+	if maskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&store.ServiceAccount{}}, handlers.MaskSource{&pb.ServiceAccount{}}); err != nil {
+		panic(err)
+	}
+}
+
+// Service handles request as described by the pbs.ServiceAccountServiceServer interface.
+type Service struct {
+	pbs.UnimplementedServiceAccountServiceServer
+
+	kms      *kms.Kms
+	repoFn   common.IamRepoFactory
+	atRepoFn common.AuthTokenRepoFactory
+}
+
+// NewService returns a service account service which handles service account
+// related requests to boundary.
+func NewService(kms *kms.Kms, repo common.IamRepoFactory, atRepo common.AuthTokenRepoFactory) (Service, error) {
+	const op = "serviceaccounts.NewService"
+	if kms == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing kms")
+	}
+	if repo == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
+	}
+	if atRepo == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing auth token repository")
+	}
+	return Service{kms: kms, repoFn: repo, atRepoFn: atRepo}, nil
+}
+
+var _ pbs.ServiceAccountServiceServer = Service{}
+
+// ListServiceAccounts implements the interface pbs.ServiceAccountServiceServer.
+func (s Service) ListServiceAccounts(ctx context.Context, req *pbs.ListServiceAccountsRequest) (*pbs.ListServiceAccountsResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		// If it's forbidden, and it's a recursive request, and they're
+		// successfully authenticated but just not authorized, keep going as we
+		// may have authorization on downstream scopes. Or, if they've not
+		// authenticated, still process in case u_anon has permissions.
+		if (authResults.Error == handlers.ForbiddenError() || authResults.Error == handlers.UnauthenticatedError()) &&
+			req.GetRecursive() &&
+			authResults.AuthenticationFinished {
+		} else {
+			return nil, authResults.Error
+		}
+	}
+
+	scopeIds, scopeInfoMap, err := scopeids.GetListingScopeIds(
+		ctx, s.repoFn, authResults, req.GetScopeId(), resource.ServiceAccount, req.GetRecursive(), false)
+	if err != nil {
+		return nil, err
+	}
+	// If no scopes match, return an empty response
+	if len(scopeIds) == 0 {
+		return &pbs.ListServiceAccountsResponse{}, nil
+	}
+
+	sl, err := s.listFromRepo(ctx, scopeIds)
+	if err != nil {
+		return nil, err
+	}
+	if len(sl) == 0 {
+		return &pbs.ListServiceAccountsResponse{}, nil
+	}
+
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.ServiceAccount, 0, len(sl))
+	res := perms.Resource{
+		Type: resource.ServiceAccount,
+	}
+	for _, item := range sl {
+		res.Id = item.GetPublicId()
+		res.ScopeId = item.GetScopeId()
+		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+		if len(authorizedActions) == 0 {
+			continue
+		}
+
+		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+		outputOpts := make([]handlers.Option, 0, 3)
+		outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+		if outputFields.Has(globals.ScopeField) {
+			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetScopeId()]))
+		}
+		if outputFields.Has(globals.AuthorizedActionsField) {
+			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+		}
+
+		item, err := toProto(ctx, item, nil, outputOpts...)
+		if err != nil {
+			return nil, err
+		}
+
+		if filter.Match(item) {
+			finalItems = append(finalItems, item)
+		}
+	}
+	return &pbs.ListServiceAccountsResponse{Items: finalItems}, nil
+}
+
+// GetServiceAccount implements the interface pbs.ServiceAccountServiceServer.
+func (s Service) GetServiceAccount(ctx context.Context, req *pbs.GetServiceAccountRequest) (*pbs.GetServiceAccountResponse, error) {
+	const op = "serviceaccounts.(Service).GetServiceAccount"
+
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	sa, creds, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputOpts, err := s.outputOpts(ctx, op, &authResults, sa.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	item, err := toProto(ctx, sa, creds, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.GetServiceAccountResponse{Item: item}, nil
+}
+
+// CreateServiceAccount implements the interface pbs.ServiceAccountServiceServer.
+func (s Service) CreateServiceAccount(ctx context.Context, req *pbs.CreateServiceAccountRequest) (*pbs.CreateServiceAccountResponse, error) {
+	const op = "serviceaccounts.(Service).CreateServiceAccount"
+
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetScopeId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	sa, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputOpts, err := s.outputOpts(ctx, op, &authResults, sa.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	item, err := toProto(ctx, sa, nil, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.CreateServiceAccountResponse{Item: item, Uri: fmt.Sprintf("service-accounts/%s", item.GetId())}, nil
+}
+
+// UpdateServiceAccount implements the interface pbs.ServiceAccountServiceServer.
+func (s Service) UpdateServiceAccount(ctx context.Context, req *pbs.UpdateServiceAccountRequest) (*pbs.UpdateServiceAccountResponse, error) {
+	const op = "serviceaccounts.(Service).UpdateServiceAccount"
+
+	if err := validateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Update)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	sa, creds, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputOpts, err := s.outputOpts(ctx, op, &authResults, sa.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	item, err := toProto(ctx, sa, creds, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.UpdateServiceAccountResponse{Item: item}, nil
+}
+
+// DeleteServiceAccount implements the interface pbs.ServiceAccountServiceServer.
+func (s Service) DeleteServiceAccount(ctx context.Context, req *pbs.DeleteServiceAccountRequest) (*pbs.DeleteServiceAccountResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	_, err := s.deleteFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// AddServiceAccountCredential implements the interface
+// pbs.ServiceAccountServiceServer. The secret of a client-secret credential is
+// only ever returned in this response.
+func (s Service) AddServiceAccountCredential(ctx context.Context, req *pbs.AddServiceAccountCredentialRequest) (*pbs.AddServiceAccountCredentialResponse, error) {
+	const op = "serviceaccounts.(Service).AddServiceAccountCredential"
+
+	if err := validateAddCredentialRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.AddCredential)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	sa, creds, cred, secret, err := s.addCredentialInRepo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	outputOpts, err := s.outputOpts(ctx, op, &authResults, sa.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	item, err := toProto(ctx, sa, creds, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+	outCred := toCredentialProto(cred)
+	outCred.ClientSecret = secret
+
+	return &pbs.AddServiceAccountCredentialResponse{Item: item, Credential: outCred}, nil
+}
+
+// RemoveServiceAccountCredential implements the interface
+// pbs.ServiceAccountServiceServer.
+func (s Service) RemoveServiceAccountCredential(ctx context.Context, req *pbs.RemoveServiceAccountCredentialRequest) (*pbs.RemoveServiceAccountCredentialResponse, error) {
+	const op = "serviceaccounts.(Service).RemoveServiceAccountCredential"
+
+	if err := validateRemoveCredentialRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RemoveCredential)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	sa, creds, err := s.removeCredentialInRepo(ctx, req.GetId(), req.GetVersion(), req.GetCredentialId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputOpts, err := s.outputOpts(ctx, op, &authResults, sa.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	item, err := toProto(ctx, sa, creds, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.RemoveServiceAccountCredentialResponse{Item: item}, nil
+}
+
+// AuthenticateServiceAccount implements the interface
+// pbs.ServiceAccountServiceServer. It issues an auth token for the service
+// account when either a client id and secret or a signed JWT is provided.
+func (s Service) AuthenticateServiceAccount(ctx context.Context, req *pbs.AuthenticateServiceAccountRequest) (*pbs.AuthenticateServiceAccountResponse, error) {
+	const op = "serviceaccounts.(Service).AuthenticateServiceAccount"
+
+	if err := validateAuthenticateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Authenticate)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	var sa *iam.ServiceAccount
+	switch {
+	case req.GetJwt() != "":
+		sa, err = repo.AuthenticateServiceAccountJwt(ctx, req.GetId(), req.GetJwt())
+	default:
+		sa, err = repo.AuthenticateServiceAccountSecret(ctx, req.GetId(), req.GetClientId(), req.GetClientSecret())
+	}
+	switch {
+	case errors.Match(errors.T(errors.Unauthorized), err):
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+	case err != nil:
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	atRepo, err := s.atRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	tok, err := atRepo.CreateServiceAccountAuthToken(ctx, sa)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	token, err := authtoken.EncryptToken(ctx, s.kms, tok.GetScopeId(), tok.GetPublicId(), tok.GetToken())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	res := &perms.Resource{
+		ScopeId: tok.GetScopeId(),
+		Type:    resource.AuthToken,
+	}
+	return &pbs.AuthenticateServiceAccountResponse{Item: &pba.AuthToken{
+		Id:                tok.GetPublicId(),
+		ScopeId:           tok.GetScopeId(),
+		Scope:             authResults.Scope,
+		Token:             tok.GetPublicId() + "_" + token,
+		ServiceAccountId:  tok.GetServiceAccountId(),
+		CreatedTime:       tok.GetCreateTime().GetTimestamp(),
+		UpdatedTime:       tok.GetUpdateTime().GetTimestamp(),
+		ExpirationTime:    tok.GetExpirationTime().GetTimestamp(),
+		AuthorizedActions: authResults.FetchActionSetForId(ctx, tok.GetPublicId(), authtokens.IdActions, auth.WithResource(res)).Strings(),
+	}}, nil
+}
+
+func (s Service) outputOpts(ctx context.Context, op errors.Op, authResults *auth.VerifyResults, id string) ([]handlers.Option, error) {
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, id, IdActions).Strings()))
+	}
+	return outputOpts, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*iam.ServiceAccount, []*iam.ServiceAccountCredential, error) {
+	const op = "serviceaccounts.(Service).getFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	sa, creds, err := repo.LookupServiceAccount(ctx, id)
+	if err != nil && !errors.IsNotFoundError(err) {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	if sa == nil {
+		return nil, nil, handlers.NotFoundErrorf("Service account %q doesn't exist.", id)
+	}
+	return sa, creds, nil
+}
+
+func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.ServiceAccount) (*iam.ServiceAccount, error) {
+	const op = "serviceaccounts.(Service).createInRepo"
+	var opts []iam.Option
+	if item.GetName() != nil {
+		opts = append(opts, iam.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, iam.WithDescription(item.GetDescription().GetValue()))
+	}
+	sa, err := iam.NewServiceAccount(scopeId, opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build service account for creation: %v.", err)
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, err := repo.CreateServiceAccount(ctx, sa)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create service account but no error returned from repository.")
+	}
+	return out, nil
+}
+
+func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.ServiceAccount) (*iam.ServiceAccount, []*iam.ServiceAccountCredential, error) {
+	const op = "serviceaccounts.(Service).updateInRepo"
+	var opts []iam.Option
+	if desc := item.GetDescription(); desc != nil {
+		opts = append(opts, iam.WithDescription(desc.GetValue()))
+	}
+	if name := item.GetName(); name != nil {
+		opts = append(opts, iam.WithName(name.GetValue()))
+	}
+	version := item.GetVersion()
+	sa, err := iam.NewServiceAccount(scopeId, opts...)
+	if err != nil {
+		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build service account for update: %v.", err)
+	}
+	sa.PublicId = id
+	dbMask := maskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	out, creds, rowsUpdated, err := repo.UpdateServiceAccount(ctx, sa, version, dbMask)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	if rowsUpdated == 0 {
+		return nil, nil, handlers.NotFoundErrorf("Service account %q doesn't exist or incorrect version provided.", id)
+	}
+	return out, creds, nil
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
+	const op = "serviceaccounts.(Service).deleteFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return false, err
+	}
+	rows, err := repo.DeleteServiceAccount(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete service account"))
+	}
+	return rows > 0, nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*iam.ServiceAccount, error) {
+	const op = "serviceaccounts.(Service).listFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	sl, err := repo.ListServiceAccounts(ctx, scopeIds)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return sl, nil
+}
+
+func (s Service) addCredentialInRepo(ctx context.Context, req *pbs.AddServiceAccountCredentialRequest) (*iam.ServiceAccount, []*iam.ServiceAccountCredential, *iam.ServiceAccountCredential, string, error) {
+	const op = "serviceaccounts.(Service).addCredentialInRepo"
+	var opts []iam.Option
+	if req.GetExpirationTime() != nil {
+		opts = append(opts, iam.WithExpirationTime(req.GetExpirationTime().AsTime()))
+	}
+	var cred *iam.ServiceAccountCredential
+	var secret string
+	var err error
+	switch iam.ServiceAccountCredentialType(req.GetType()) {
+	case iam.JwtPublicKeyCredentialType:
+		cred, err = iam.NewServiceAccountPublicKey(req.GetId(), req.GetPublicKey(), opts...)
+		if err != nil {
+			return nil, nil, nil, "", handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{publicKeyField: "Unable to parse the public key."})
+		}
+	default:
+		cred, secret, err = iam.NewServiceAccountClientSecret(req.GetId(), opts...)
+		if err != nil {
+			return nil, nil, nil, "", handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build service account credential: %v.", err)
+		}
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, nil, "", errors.Wrap(ctx, err, op)
+	}
+	out, err := repo.AddServiceAccountCredential(ctx, cred, req.GetVersion())
+	if err != nil {
+		return nil, nil, nil, "", errors.Wrap(ctx, err, op, errors.WithMsg("unable to add credential to service account"))
+	}
+	sa, creds, err := repo.LookupServiceAccount(ctx, req.GetId())
+	if err != nil {
+		return nil, nil, nil, "", errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up service account after adding credential"))
+	}
+	if sa == nil {
+		return nil, nil, nil, "", handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup service account after adding credential to it.")
+	}
+	return sa, creds, out, secret, nil
+}
+
+func (s Service) removeCredentialInRepo(ctx context.Context, id string, version uint32, credentialId string) (*iam.ServiceAccount, []*iam.ServiceAccountCredential, error) {
+	const op = "serviceaccounts.(Service).removeCredentialInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	_, err = repo.RemoveServiceAccountCredential(ctx, id, version, credentialId)
+	switch {
+	case errors.IsNotFoundError(err):
+		return nil, nil, handlers.NotFoundErrorf("Credential %q doesn't exist on service account %q.", credentialId, id)
+	case err != nil:
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to remove credential from service account"))
+	}
+	sa, creds, err := repo.LookupServiceAccount(ctx, id)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up service account after removing credential"))
+	}
+	if sa == nil {
+		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup service account after removing credential from it.")
+	}
+	return sa, creds, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+	repo, err := s.repoFn()
+	if err != nil {
+		res.Error = err
+		return res
+	}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.ServiceAccount), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+		scp, err := repo.LookupScope(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if scp == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	default:
+		sa, _, err := repo.LookupServiceAccount(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if sa == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = sa.GetScopeId()
+		opts = append(opts, auth.WithId(id))
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(ctx context.Context, in *iam.ServiceAccount, creds []*iam.ServiceAccountCredential, opt ...handlers.Option) (*pb.ServiceAccount, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building service account proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.ServiceAccount{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has(globals.ScopeIdField) {
+		out.ScopeId = in.GetScopeId()
+	}
+	if outputFields.Has(globals.DescriptionField) && in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if outputFields.Has(globals.NameField) && in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	if outputFields.Has(globals.CreatedTimeField) {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.UpdatedTimeField) {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.VersionField) {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has(globals.ScopeField) {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	if outputFields.Has(globals.CredentialsField) {
+		for _, c := range creds {
+			out.Credentials = append(out.Credentials, toCredentialProto(c))
+		}
+	}
+	return &out, nil
+}
+
+// toCredentialProto converts a credential to its API representation. Secret
+// hashes are never returned.
+func toCredentialProto(in *iam.ServiceAccountCredential) *pb.Credential {
+	return &pb.Credential{
+		Id:             in.GetPublicId(),
+		Type:           in.GetType(),
+		PublicKey:      in.GetPublicKey(),
+		CreatedTime:    in.GetCreateTime().GetTimestamp(),
+		ExpirationTime: in.GetExpirationTime().GetTimestamp(),
+	}
+}
+
+const (
+	typeField         = "type"
+	publicKeyField    = "public_key"
+	credentialIdField = "credential_id"
+	clientIdField     = "client_id"
+	clientSecretField = "client_secret"
+	jwtField          = "jwt"
+)
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//  * The path passed in is correctly formatted
+//  * All required parameters are set
+//  * There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetServiceAccountRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, iam.ServiceAccountPrefix)
+}
+
+func validateCreateRequest(req *pbs.CreateServiceAccountRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		if !handlers.ValidId(handlers.Id(req.GetItem().GetScopeId()), scope.Org.Prefix()) &&
+			scope.Global.String() != req.GetItem().GetScopeId() {
+			badFields[globals.ScopeIdField] = "This field must be 'global' or a valid org scope id."
+		}
+		if len(req.GetItem().GetCredentials()) > 0 {
+			badFields[globals.CredentialsField] = "This is a read only field."
+		}
+		return badFields
+	})
+}
+
+func validateUpdateRequest(req *pbs.UpdateServiceAccountRequest) error {
+	return handlers.ValidateUpdateRequest(req, req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		if len(req.GetItem().GetCredentials()) > 0 {
+			badFields[globals.CredentialsField] = "This is a read only field."
+		}
+		return badFields
+	}, iam.ServiceAccountPrefix)
+}
+
+func validateDeleteRequest(req *pbs.DeleteServiceAccountRequest) error {
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, iam.ServiceAccountPrefix)
+}
+
+func validateListRequest(req *pbs.ListServiceAccountsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields[globals.ScopeIdField] = "Incorrectly formatted identifier."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
+
+func validateAddCredentialRequest(req *pbs.AddServiceAccountCredentialRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), iam.ServiceAccountPrefix) {
+		badFields[globals.IdField] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields[globals.VersionField] = "Required field."
+	}
+	switch iam.ServiceAccountCredentialType(req.GetType()) {
+	case iam.JwtPublicKeyCredentialType:
+		if req.GetPublicKey() == "" {
+			badFields[publicKeyField] = "This field is required for jwt-public-key credentials."
+		}
+	case iam.ClientSecretCredentialType:
+		if req.GetPublicKey() != "" {
+			badFields[publicKeyField] = "This field can only be set for jwt-public-key credentials."
+		}
+	default:
+		badFields[typeField] = fmt.Sprintf("Must be either %q or %q.", iam.JwtPublicKeyCredentialType, iam.ClientSecretCredentialType)
+	}
+	if req.GetExpirationTime() != nil && !req.GetExpirationTime().IsValid() {
+		badFields[globals.ExpirationTimeField] = "Invalid timestamp."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateRemoveCredentialRequest(req *pbs.RemoveServiceAccountCredentialRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), iam.ServiceAccountPrefix) {
+		badFields[globals.IdField] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields[globals.VersionField] = "Required field."
+	}
+	if !handlers.ValidId(handlers.Id(req.GetCredentialId()), iam.ServiceAccountCredentialPrefix) {
+		badFields[credentialIdField] = "Incorrectly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateAuthenticateRequest(req *pbs.AuthenticateServiceAccountRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), iam.ServiceAccountPrefix) {
+		badFields[globals.IdField] = "Incorrectly formatted identifier."
+	}
+	hasSecret := req.GetClientId() != "" || req.GetClientSecret() != ""
+	switch {
+	case hasSecret && req.GetJwt() != "":
+		badFields[jwtField] = "Cannot be set along with a client id and secret."
+	case hasSecret:
+		if !handlers.ValidId(handlers.Id(req.GetClientId()), iam.ServiceAccountCredentialPrefix) {
+			badFields[clientIdField] = "Incorrectly formatted identifier."
+		}
+		if req.GetClientSecret() == "" {
+			badFields[clientSecretField] = "This field is required when a client id is provided."
+		}
+	case req.GetJwt() == "":
+		badFields[jwtField] = "Either a jwt or a client id and secret are required."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
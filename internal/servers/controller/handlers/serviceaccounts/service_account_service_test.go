@@ -0,0 +1,288 @@
+package serviceaccounts_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	authpb "github.com/hashicorp/boundary/internal/gen/controller/auth"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/servers/controller/auth"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/serviceaccounts"
+	capoidc "github.com/hashicorp/cap/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrap), nil
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+
+	s, err := serviceaccounts.NewService(kms, iamRepoFn, tokenRepoFn)
+	require.NoError(t, err)
+
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	o, _ := iam.TestScopes(t, iamRepo)
+	sa := iam.TestServiceAccount(t, conn, o.GetPublicId())
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(priv.Public())
+	require.NoError(t, err)
+	keyCred, err := iam.NewServiceAccountPublicKey(sa.GetPublicId(), string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+	require.NoError(t, err)
+	_, err = iamRepo.AddServiceAccountCredential(ctx, keyCred, sa.GetVersion())
+	require.NoError(t, err)
+	secretCred, secret, err := iam.NewServiceAccountClientSecret(sa.GetPublicId())
+	require.NoError(t, err)
+	gotSecret, err := iamRepo.AddServiceAccountCredential(ctx, secretCred, sa.GetVersion()+1)
+	require.NoError(t, err)
+
+	// Service accounts authenticate as the anonymous user, which is granted
+	// the authenticate action by the default login role.
+	role := iam.TestRole(t, conn, o.GetPublicId())
+	iam.TestRoleGrant(t, conn, role.GetPublicId(), "id=*;type=service-account;actions=authenticate")
+	iam.TestUserRole(t, conn, role.GetPublicId(), auth.AnonymousUserId)
+
+	var jtis int
+	claims := func(aud ...string) map[string]interface{} {
+		jtis++
+		c := map[string]interface{}{
+			"sub": sa.GetPublicId(),
+			"jti": fmt.Sprintf("jti-%d", jtis),
+			"iat": time.Now().Unix(),
+			"exp": time.Now().Add(5 * time.Minute).Unix(),
+		}
+		if len(aud) > 0 {
+			c["aud"] = aud
+		}
+		return c
+	}
+	sign := func(c map[string]interface{}) string {
+		return capoidc.TestSignJWT(t, priv, string(capoidc.ES256), c, nil)
+	}
+	reusedJwt := sign(claims(iam.ServiceAccountJwtAudience(sa.GetPublicId())))
+
+	anonCtx := func(t *testing.T, id string) context.Context {
+		t.Helper()
+		req := httptest.NewRequest("POST", fmt.Sprintf("http://127.0.0.1/v1/service-accounts/%s:authenticate", id), nil)
+		requestInfo := authpb.RequestInfo{
+			Path:        req.URL.Path,
+			Method:      req.Method,
+			TokenFormat: uint32(auth.AuthTokenTypeUnknown),
+		}
+		ctx := auth.NewVerifierContext(context.Background(), iamRepoFn, tokenRepoFn, serversRepoFn, kms, &requestInfo)
+		return context.WithValue(ctx, requests.ContextRequestInformationKey, &requests.RequestContext{})
+	}
+
+	cases := []struct {
+		name    string
+		req     *pbs.AuthenticateServiceAccountRequest
+		wantErr error
+	}{
+		{
+			name: "client secret",
+			req: &pbs.AuthenticateServiceAccountRequest{
+				Id:           sa.GetPublicId(),
+				ClientId:     gotSecret.GetPublicId(),
+				ClientSecret: secret,
+			},
+		},
+		{
+			name: "wrong client secret",
+			req: &pbs.AuthenticateServiceAccountRequest{
+				Id:           sa.GetPublicId(),
+				ClientId:     gotSecret.GetPublicId(),
+				ClientSecret: secret + "x",
+			},
+			wantErr: handlers.ApiErrorWithCode(codes.Unauthenticated),
+		},
+		{
+			name: "jwt",
+			req: &pbs.AuthenticateServiceAccountRequest{
+				Id:  sa.GetPublicId(),
+				Jwt: reusedJwt,
+			},
+		},
+		{
+			name: "reused jwt",
+			req: &pbs.AuthenticateServiceAccountRequest{
+				Id:  sa.GetPublicId(),
+				Jwt: reusedJwt,
+			},
+			wantErr: handlers.ApiErrorWithCode(codes.Unauthenticated),
+		},
+		{
+			name: "jwt without audience",
+			req: &pbs.AuthenticateServiceAccountRequest{
+				Id:  sa.GetPublicId(),
+				Jwt: sign(claims()),
+			},
+			wantErr: handlers.ApiErrorWithCode(codes.Unauthenticated),
+		},
+		{
+			name: "jwt with wrong audience",
+			req: &pbs.AuthenticateServiceAccountRequest{
+				Id:  sa.GetPublicId(),
+				Jwt: sign(claims("https://boundary.example.com")),
+			},
+			wantErr: handlers.ApiErrorWithCode(codes.Unauthenticated),
+		},
+		{
+			name: "jwt and client secret",
+			req: &pbs.AuthenticateServiceAccountRequest{
+				Id:           sa.GetPublicId(),
+				Jwt:          sign(claims(iam.ServiceAccountJwtAudience(sa.GetPublicId()))),
+				ClientId:     gotSecret.GetPublicId(),
+				ClientSecret: secret,
+			},
+			wantErr: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "no credentials",
+			req:     &pbs.AuthenticateServiceAccountRequest{Id: sa.GetPublicId()},
+			wantErr: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "unknown service account",
+			req: &pbs.AuthenticateServiceAccountRequest{
+				Id:           iam.ServiceAccountPrefix + "_1234567890",
+				ClientId:     gotSecret.GetPublicId(),
+				ClientSecret: secret,
+			},
+			wantErr: handlers.NotFoundError(),
+		},
+	}
+	// The cases run in order: "reused jwt" relies on "jwt" having used the
+	// token first.
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := s.AuthenticateServiceAccount(anonCtx(t, tc.req.GetId()), tc.req)
+			if tc.wantErr != nil {
+				require.Error(err)
+				assert.Truef(errors.Is(err, tc.wantErr), "got error %v, wanted %v", err, tc.wantErr)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(sa.GetPublicId(), got.GetItem().GetServiceAccountId())
+			assert.NotEmpty(got.GetItem().GetToken())
+		})
+	}
+}
+
+func TestAuthenticate_Authorization(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrap), nil
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+
+	s, err := serviceaccounts.NewService(kms, iamRepoFn, tokenRepoFn)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	o, _ := iam.TestScopes(t, iamRepo)
+	sa := iam.TestServiceAccount(t, conn, o.GetPublicId())
+	secretCred, secret, err := iam.NewServiceAccountClientSecret(sa.GetPublicId())
+	require.NoError(t, err)
+	gotSecret, err := iamRepo.AddServiceAccountCredential(ctx, secretCred, sa.GetVersion())
+	require.NoError(t, err)
+
+	at := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+
+	req := &pbs.AuthenticateServiceAccountRequest{
+		Id:           sa.GetPublicId(),
+		ClientId:     gotSecret.GetPublicId(),
+		ClientSecret: secret,
+	}
+	verifierCtx := func(t *testing.T, at *authtoken.AuthToken) context.Context {
+		t.Helper()
+		r := httptest.NewRequest("POST", fmt.Sprintf("http://127.0.0.1/v1/service-accounts/%s:authenticate", sa.GetPublicId()), nil)
+		requestInfo := authpb.RequestInfo{
+			Path:        r.URL.Path,
+			Method:      r.Method,
+			TokenFormat: uint32(auth.AuthTokenTypeUnknown),
+		}
+		if at != nil {
+			requestInfo.TokenFormat = uint32(auth.AuthTokenTypeBearer)
+			requestInfo.PublicId = at.GetPublicId()
+			requestInfo.Token = at.GetToken()
+		}
+		ctx := auth.NewVerifierContext(context.Background(), iamRepoFn, tokenRepoFn, serversRepoFn, kms, &requestInfo)
+		return context.WithValue(ctx, requests.ContextRequestInformationKey, &requests.RequestContext{})
+	}
+
+	t.Run("anonymous without grant", func(t *testing.T) {
+		got, err := s.AuthenticateServiceAccount(verifierCtx(t, nil), req)
+		assert.Truef(t, errors.Is(err, handlers.UnauthenticatedError()), "got error %v, wanted unauthenticated", err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("user without grant", func(t *testing.T) {
+		got, err := s.AuthenticateServiceAccount(verifierCtx(t, at), req)
+		assert.Truef(t, errors.Is(err, handlers.ForbiddenError()), "got error %v, wanted forbidden", err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("anonymous with grant on other scope", func(t *testing.T) {
+		other, _ := iam.TestScopes(t, iamRepo)
+		role := iam.TestRole(t, conn, other.GetPublicId())
+		iam.TestRoleGrant(t, conn, role.GetPublicId(), "id=*;type=service-account;actions=authenticate")
+		iam.TestUserRole(t, conn, role.GetPublicId(), auth.AnonymousUserId)
+
+		got, err := s.AuthenticateServiceAccount(verifierCtx(t, nil), req)
+		assert.Truef(t, errors.Is(err, handlers.UnauthenticatedError()), "got error %v, wanted unauthenticated", err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("anonymous with grant", func(t *testing.T) {
+		role := iam.TestRole(t, conn, o.GetPublicId())
+		iam.TestRoleGrant(t, conn, role.GetPublicId(), "id=*;type=service-account;actions=authenticate")
+		iam.TestUserRole(t, conn, role.GetPublicId(), auth.AnonymousUserId)
+
+		got, err := s.AuthenticateServiceAccount(verifierCtx(t, nil), req)
+		require.NoError(t, err)
+		assert.Equal(t, sa.GetPublicId(), got.GetItem().GetServiceAccountId())
+	})
+}
@@ -0,0 +1,99 @@
+package sessions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/session"
+)
+
+// CancelMatching cancels the pending and active sessions returned by listing
+// the session repository with opt which match filter. The filter is evaluated
+// against session.Session. It is used by the services which provide a
+// cancel-sessions action on the resources sessions are created for.
+//
+// The matching sessions are canceled in a single transaction and their
+// terminations are published so workers close the connections immediately.
+// CancelMatching returns the ids of the canceled sessions.
+func CancelMatching(ctx context.Context, repoFn common.SessionRepoFactory, terminations *session.TerminationBroadcaster, filter, reason string, opt ...session.Option) ([]string, error) {
+	const op = "sessions.CancelMatching"
+	if repoFn == nil {
+		return nil, errors.New(ctx, errors.Internal, op, "missing session repository")
+	}
+	f, err := handlers.NewFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	opt = append(opt, session.WithStates(session.StatusPending, session.StatusActive), session.WithLimit(-1))
+	sesList, err := repo.ListSessions(ctx, opt...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	toCancel := make([]string, 0, len(sesList))
+	for _, s := range sesList {
+		if f.Match(s) {
+			toCancel = append(toCancel, s.PublicId)
+		}
+	}
+	if len(toCancel) == 0 {
+		return nil, nil
+	}
+
+	canceled, err := repo.CancelSessions(ctx, toCancel, session.WithCancelReason(reason))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to cancel sessions"))
+	}
+	sessionIds := make([]string, 0, len(canceled))
+	for _, s := range canceled {
+		terminations.Publish(session.Termination{
+			SessionId: s.PublicId,
+			ServerId:  s.ServerId,
+			Reason:    reason,
+		})
+		sessionIds = append(sessionIds, s.PublicId)
+	}
+	return sessionIds, nil
+}
+
+// ValidateCancelMatching adds the problems with the filter and reason of a
+// cancel-sessions request to badFields.
+func ValidateCancelMatching(badFields map[string]string, filter, reason string) {
+	if _, err := handlers.NewFilter(filter); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if msg := validateReason(reason); msg != "" {
+		badFields["reason"] = msg
+	}
+}
+
+// validateReason returns why the reason supplied when canceling sessions is
+// invalid, or an empty string if it is valid.
+func validateReason(reason string) string {
+	switch {
+	case reason == "":
+		return ""
+	case strings.TrimSpace(reason) == "":
+		return "Must not be only whitespace."
+	case len(reason) > maxCancelReasonLength:
+		return fmt.Sprintf("Must not be longer than %d bytes.", maxCancelReasonLength)
+	}
+	return ""
+}
+
+func isCanceled(s *session.Session) bool {
+	for _, state := range s.States {
+		switch state.Status {
+		case session.StatusCanceling, session.StatusTerminated:
+			return true
+		}
+	}
+	return false
+}
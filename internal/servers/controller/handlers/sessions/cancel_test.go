@@ -0,0 +1,52 @@
+package sessions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCancelMatching(t *testing.T) {
+	cases := []struct {
+		name   string
+		filter string
+		reason string
+		want   []string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:   "valid",
+			filter: `"/item/target_id" == "ttcp_1234567890"`,
+			reason: "incident",
+		},
+		{
+			name:   "bad filter",
+			filter: `//badformat/`,
+			want:   []string{"filter"},
+		},
+		{
+			name:   "whitespace reason",
+			reason: "  ",
+			want:   []string{"reason"},
+		},
+		{
+			name:   "long reason",
+			reason: strings.Repeat("a", maxCancelReasonLength+1),
+			want:   []string{"reason"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			badFields := map[string]string{}
+			ValidateCancelMatching(badFields, tc.filter, tc.reason)
+			var got []string
+			for k := range badFields {
+				got = append(got, k)
+			}
+			assert.ElementsMatch(t, tc.want, got)
+		})
+	}
+}
@@ -23,6 +23,11 @@ import (
 	"google.golang.org/grpc/codes"
 )
 
+// maxCancelReasonLength is the maximum length in bytes of the reason supplied
+// when canceling a session. The reason is sent to the client in a websocket
+// close frame, which limits its size.
+const maxCancelReasonLength = 120
+
 var (
 	// IdActions contains the set of actions that can be performed on
 	// individual resources
@@ -45,12 +50,14 @@ var (
 type Service struct {
 	pbs.UnimplementedSessionServiceServer
 
-	repoFn    common.SessionRepoFactory
-	iamRepoFn common.IamRepoFactory
+	repoFn       common.SessionRepoFactory
+	iamRepoFn    common.IamRepoFactory
+	terminations *session.TerminationBroadcaster
 }
 
 // NewService returns a session service which handles session related requests to boundary.
-func NewService(repoFn common.SessionRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+// Canceled sessions are published to terminations, which may be nil.
+func NewService(repoFn common.SessionRepoFactory, iamRepoFn common.IamRepoFactory, terminations *session.TerminationBroadcaster) (Service, error) {
 	const op = "sessions.NewService"
 	if repoFn == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing session repository")
@@ -58,7 +65,7 @@ func NewService(repoFn common.SessionRepoFactory, iamRepoFn common.IamRepoFactor
 	if iamRepoFn == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
 	}
-	return Service{repoFn: repoFn, iamRepoFn: iamRepoFn}, nil
+	return Service{repoFn: repoFn, iamRepoFn: iamRepoFn, terminations: terminations}, nil
 }
 
 var _ pbs.SessionServiceServer = Service{}
@@ -238,19 +245,16 @@ func (s Service) CancelSession(ctx context.Context, req *pbs.CancelSessionReques
 		}
 	}
 
-	var skipCancel bool
-	for _, state := range ses.States {
-		switch state.Status {
-		case session.StatusCanceling, session.StatusTerminated:
-			skipCancel = true
-		}
-	}
-
-	if !skipCancel {
-		ses, err = s.cancelInRepo(ctx, req.GetId(), req.GetVersion())
+	if !isCanceled(ses) {
+		ses, err = s.cancelInRepo(ctx, req.GetId(), req.GetVersion(), req.GetReason())
 		if err != nil {
 			return nil, err
 		}
+		s.terminations.Publish(session.Termination{
+			SessionId: ses.GetPublicId(),
+			ServerId:  ses.ServerId,
+			Reason:    req.GetReason(),
+		})
 	}
 
 	outputOpts := make([]handlers.Option, 0, 3)
@@ -299,13 +303,13 @@ func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*sessio
 	return sesList, nil
 }
 
-func (s Service) cancelInRepo(ctx context.Context, id string, version uint32) (*session.Session, error) {
+func (s Service) cancelInRepo(ctx context.Context, id string, version uint32, reason string) (*session.Session, error) {
 	const op = "sessions.(Service).cancelInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	out, err := repo.CancelSession(ctx, id, version)
+	out, err := repo.CancelSession(ctx, id, version, session.WithCancelReason(reason))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update session"))
 	}
@@ -495,6 +499,9 @@ func validateCancelRequest(req *pbs.CancelSessionRequest) error {
 	if req.GetVersion() == 0 {
 		badFields["version"] = "Required field."
 	}
+	if msg := validateReason(req.GetReason()); msg != "" {
+		badFields["reason"] = msg
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
 	}
@@ -128,7 +128,7 @@ func TestGetSession(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
-			s, err := sessions.NewService(sessRepoFn, iamRepoFn)
+			s, err := sessions.NewService(sessRepoFn, iamRepoFn, nil)
 			require.NoError(err, "Couldn't create new session service.")
 
 			got, gErr := s.GetSession(auth.DisabledAuthTestContext(iamRepoFn, tc.scopeId), tc.req)
@@ -189,7 +189,7 @@ func TestList_Self(t *testing.T) {
 		Endpoint:    "tcp://127.0.0.1:22",
 	})
 
-	s, err := sessions.NewService(sessRepoFn, iamRepoFn)
+	s, err := sessions.NewService(sessRepoFn, iamRepoFn, nil)
 	require.NoError(t, err, "Couldn't create new session service.")
 
 	cases := []struct {
@@ -408,7 +408,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			require, assert := require.New(t), assert.New(t)
-			s, err := sessions.NewService(sessRepoFn, iamRepoFn)
+			s, err := sessions.NewService(sessRepoFn, iamRepoFn, nil)
 			require.NoError(err, "Couldn't create new session service.")
 
 			// Test without anon user
@@ -573,7 +573,7 @@ func TestCancel(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
-			s, err := sessions.NewService(sessRepoFn, iamRepoFn)
+			s, err := sessions.NewService(sessRepoFn, iamRepoFn, nil)
 			require.NoError(err, "Couldn't create new session service.")
 
 			tc.req.Version = version
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/alias"
 	"github.com/hashicorp/boundary/internal/credential"
 	"github.com/hashicorp/boundary/internal/credential/vault"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
@@ -19,7 +20,10 @@ import (
 	"github.com/hashicorp/boundary/internal/host"
 	"github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/label"
+	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/servers"
@@ -27,11 +31,13 @@ import (
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/sessions"
 	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/target"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
 	"github.com/hashicorp/go-bexpr"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
@@ -62,6 +68,7 @@ var (
 		action.SetCredentialSources,
 		action.RemoveCredentialSources,
 		action.AuthorizeSession,
+		action.CancelSessions,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -83,10 +90,16 @@ type Service struct {
 	pluginHostRepoFn common.PluginHostRepoFactory
 	staticHostRepoFn common.StaticRepoFactory
 	vaultCredRepoFn  common.VaultCredentialRepoFactory
+	reportRepoFn     common.ReportRepoFactory
+	aliasRepoFn      common.AliasRepoFactory
+	labelRepoFn      common.LabelRepoFactory
+	activityRepoFn   common.ActivityRepoFactory
 	kmsCache         *kms.Kms
+	terminations     *session.TerminationBroadcaster
 }
 
 // NewService returns a target service which handles target related requests to boundary.
+// Sessions canceled through a target are published to terminations, which may be nil.
 func NewService(
 	ctx context.Context,
 	kmsCache *kms.Kms,
@@ -96,7 +109,12 @@ func NewService(
 	sessionRepoFn common.SessionRepoFactory,
 	pluginHostRepoFn common.PluginHostRepoFactory,
 	staticHostRepoFn common.StaticRepoFactory,
-	vaultCredRepoFn common.VaultCredentialRepoFactory) (Service, error) {
+	vaultCredRepoFn common.VaultCredentialRepoFactory,
+	reportRepoFn common.ReportRepoFactory,
+	aliasRepoFn common.AliasRepoFactory,
+	labelRepoFn common.LabelRepoFactory,
+	activityRepoFn common.ActivityRepoFactory,
+	terminations *session.TerminationBroadcaster) (Service, error) {
 	const op = "targets.NewService"
 	if repoFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing target repository")
@@ -119,6 +137,18 @@ func NewService(
 	if vaultCredRepoFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing vault credential repository")
 	}
+	if reportRepoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing report repository")
+	}
+	if aliasRepoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing alias repository")
+	}
+	if labelRepoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing label repository")
+	}
+	if activityRepoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing activity repository")
+	}
 	return Service{
 		repoFn:           repoFn,
 		iamRepoFn:        iamRepoFn,
@@ -127,7 +157,12 @@ func NewService(
 		pluginHostRepoFn: pluginHostRepoFn,
 		staticHostRepoFn: staticHostRepoFn,
 		vaultCredRepoFn:  vaultCredRepoFn,
+		reportRepoFn:     reportRepoFn,
+		aliasRepoFn:      aliasRepoFn,
+		labelRepoFn:      labelRepoFn,
+		activityRepoFn:   activityRepoFn,
 		kmsCache:         kmsCache,
+		terminations:     terminations,
 	}, nil
 }
 
@@ -138,37 +173,83 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 	if err := validateListRequest(req); err != nil {
 		return nil, err
 	}
-	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	scopeId := req.GetScopeId()
+	if req.GetAuthorized() {
+		scopeId = scope.Global.String()
+	}
+	authResults := s.authResult(ctx, scopeId, action.List)
 	if authResults.Error != nil {
-		// If it's forbidden, and it's a recursive request, and they're
-		// successfully authenticated but just not authorized, keep going as we
-		// may have authorization on downstream scopes. Or, if they've not
-		// authenticated, still process in case u_anon has permissions.
+		// If it's forbidden, and it's a recursive or authorized request, and
+		// they're successfully authenticated but just not authorized, keep
+		// going as we may have authorization on downstream scopes or on
+		// individual targets. Or, if they've not authenticated, still process
+		// in case u_anon has permissions.
 		if (authResults.Error == handlers.ForbiddenError() || authResults.Error == handlers.UnauthenticatedError()) &&
-			req.GetRecursive() &&
+			(req.GetRecursive() || req.GetAuthorized()) &&
 			authResults.AuthenticationFinished {
 		} else {
 			return nil, authResults.Error
 		}
 	}
 
-	scopeIds, scopeInfoMap, err := scopeids.GetListingScopeIds(
-		ctx, s.iamRepoFn, authResults, req.GetScopeId(), resource.Target, req.GetRecursive(), false)
-	if err != nil {
-		return nil, err
+	var opts []target.Option
+	if req.GetLabelSelector() != "" {
+		labels, err := label.ParseSelector(ctx, req.GetLabelSelector())
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, target.WithLabels(labels))
 	}
-	// If no scopes match, return an empty response
-	if len(scopeIds) == 0 {
-		return &pbs.ListTargetsResponse{}, nil
+
+	var scopeInfoMap map[string]*scopes.ScopeInfo
+	var err error
+	switch {
+	case req.GetAuthorized():
+		// The targets are found from the grants rather than by checking
+		// every scope, so that targets the caller may only connect to are
+		// found as well.
+		permissions, unrestricted := authResults.ListPermissions(resource.Target, action.AuthorizeSession)
+		if !unrestricted && len(permissions) == 0 {
+			return &pbs.ListTargetsResponse{}, nil
+		}
+		scopeInfoMap, err = s.listScopeInfo(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if unrestricted {
+			scopeIds := make([]string, 0, len(scopeInfoMap))
+			for id := range scopeInfoMap {
+				scopeIds = append(scopeIds, id)
+			}
+			opts = append(opts, target.WithScopeIds(scopeIds))
+		} else {
+			opts = append(opts, target.WithPermissions(permissions))
+		}
+	default:
+		var scopeIds []string
+		scopeIds, scopeInfoMap, err = scopeids.GetListingScopeIds(
+			ctx, s.iamRepoFn, authResults, req.GetScopeId(), resource.Target, req.GetRecursive(), false)
+		if err != nil {
+			return nil, err
+		}
+		// If no scopes match, return an empty response
+		if len(scopeIds) == 0 {
+			return &pbs.ListTargetsResponse{}, nil
+		}
+		opts = append(opts, target.WithScopeIds(scopeIds))
 	}
 
-	tl, err := s.listFromRepo(ctx, scopeIds)
+	tl, err := s.listFromRepo(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
 	if len(tl) == 0 {
 		return &pbs.ListTargetsResponse{}, nil
 	}
+	labels, err := s.listLabels(ctx, tl...)
+	if err != nil {
+		return nil, err
+	}
 
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
@@ -181,13 +262,17 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 	for _, item := range tl {
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetScopeId()
-		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
-		if len(authorizedActions) == 0 {
+		actionSet := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res))
+		if len(actionSet) == 0 {
 			continue
 		}
+		if req.GetAuthorized() && !actionSet.HasAction(action.AuthorizeSession) {
+			continue
+		}
+		authorizedActions := actionSet.Strings()
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
-		outputOpts := make([]handlers.Option, 0, 3)
+		outputOpts := make([]handlers.Option, 0, 4)
 		outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 		if outputFields.Has(globals.ScopeField) {
 			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetScopeId()]))
@@ -195,6 +280,9 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 		if outputFields.Has(globals.AuthorizedActionsField) {
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
+		if outputFields.Has(globals.LabelsField) {
+			outputOpts = append(outputOpts, handlers.WithLabels(labels[item.GetPublicId()]))
+		}
 
 		item, err := toProto(ctx, item, nil, nil, outputOpts...)
 		if err != nil {
@@ -229,7 +317,7 @@ func (s Service) GetTarget(ctx context.Context, req *pbs.GetTargetRequest) (*pbs
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -237,6 +325,13 @@ func (s Service) GetTarget(ctx context.Context, req *pbs.GetTargetRequest) (*pbs
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, cl, outputOpts...)
 	if err != nil {
@@ -261,13 +356,18 @@ func (s Service) CreateTarget(ctx context.Context, req *pbs.CreateTargetRequest)
 	if err != nil {
 		return nil, err
 	}
+	if len(req.GetItem().GetLabels()) > 0 {
+		if err := s.setLabels(ctx, t.GetPublicId(), req.GetItem().GetLabels()); err != nil {
+			return nil, err
+		}
+	}
 
 	outputFields, ok := requests.OutputFields(ctx)
 	if !ok {
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -275,6 +375,13 @@ func (s Service) CreateTarget(ctx context.Context, req *pbs.CreateTargetRequest)
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, cl, outputOpts...)
 	if err != nil {
@@ -295,17 +402,36 @@ func (s Service) UpdateTarget(ctx context.Context, req *pbs.UpdateTargetRequest)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	t, ts, cl, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	// Labels are not stored on the target itself, so they are set apart from
+	// the other fields.
+	paths, updateLabels := handlers.MaskRemove(req.GetUpdateMask().GetPaths(), globals.LabelsField)
+	var t target.Target
+	var ts []target.HostSource
+	var cl []target.CredentialSource
+	var err error
+	if len(paths) > 0 || !updateLabels {
+		t, ts, cl, err = s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), paths, req.GetItem())
+	} else {
+		t, ts, cl, err = s.getFromRepo(ctx, req.GetId())
+		if err == nil && t.GetVersion() != req.GetItem().GetVersion() {
+			err = handlers.NotFoundErrorf("Target %q not found or incorrect version provided.", req.GetId())
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
+	if updateLabels {
+		if err := s.setLabels(ctx, t.GetPublicId(), req.GetItem().GetLabels()); err != nil {
+			return nil, err
+		}
+	}
 
 	outputFields, ok := requests.OutputFields(ctx)
 	if !ok {
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -313,6 +439,13 @@ func (s Service) UpdateTarget(ctx context.Context, req *pbs.UpdateTargetRequest)
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, cl, outputOpts...)
 	if err != nil {
@@ -359,7 +492,7 @@ func (s Service) AddTargetHostSets(ctx context.Context, req *pbs.AddTargetHostSe
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -367,6 +500,13 @@ func (s Service) AddTargetHostSets(ctx context.Context, req *pbs.AddTargetHostSe
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, cl, outputOpts...)
 	if err != nil {
@@ -397,7 +537,7 @@ func (s Service) SetTargetHostSets(ctx context.Context, req *pbs.SetTargetHostSe
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -405,6 +545,13 @@ func (s Service) SetTargetHostSets(ctx context.Context, req *pbs.SetTargetHostSe
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, cl, outputOpts...)
 	if err != nil {
@@ -435,7 +582,7 @@ func (s Service) RemoveTargetHostSets(ctx context.Context, req *pbs.RemoveTarget
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -443,6 +590,13 @@ func (s Service) RemoveTargetHostSets(ctx context.Context, req *pbs.RemoveTarget
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, cl, outputOpts...)
 	if err != nil {
@@ -473,7 +627,7 @@ func (s Service) AddTargetHostSources(ctx context.Context, req *pbs.AddTargetHos
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -481,6 +635,13 @@ func (s Service) AddTargetHostSources(ctx context.Context, req *pbs.AddTargetHos
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, cl, outputOpts...)
 	if err != nil {
@@ -511,7 +672,7 @@ func (s Service) SetTargetHostSources(ctx context.Context, req *pbs.SetTargetHos
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -519,6 +680,13 @@ func (s Service) SetTargetHostSources(ctx context.Context, req *pbs.SetTargetHos
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, cl, outputOpts...)
 	if err != nil {
@@ -549,7 +717,7 @@ func (s Service) RemoveTargetHostSources(ctx context.Context, req *pbs.RemoveTar
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -557,6 +725,13 @@ func (s Service) RemoveTargetHostSources(ctx context.Context, req *pbs.RemoveTar
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, cl, outputOpts...)
 	if err != nil {
@@ -587,7 +762,7 @@ func (s Service) AddTargetCredentialLibraries(ctx context.Context, req *pbs.AddT
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -595,6 +770,13 @@ func (s Service) AddTargetCredentialLibraries(ctx context.Context, req *pbs.AddT
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, cl, outputOpts...)
 	if err != nil {
@@ -625,7 +807,7 @@ func (s Service) SetTargetCredentialLibraries(ctx context.Context, req *pbs.SetT
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -633,6 +815,13 @@ func (s Service) SetTargetCredentialLibraries(ctx context.Context, req *pbs.SetT
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, cl, outputOpts...)
 	if err != nil {
@@ -663,7 +852,7 @@ func (s Service) RemoveTargetCredentialLibraries(ctx context.Context, req *pbs.R
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -671,6 +860,13 @@ func (s Service) RemoveTargetCredentialLibraries(ctx context.Context, req *pbs.R
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, cl, outputOpts...)
 	if err != nil {
@@ -701,7 +897,7 @@ func (s Service) AddTargetCredentialSources(ctx context.Context, req *pbs.AddTar
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -709,6 +905,13 @@ func (s Service) AddTargetCredentialSources(ctx context.Context, req *pbs.AddTar
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, cl, outputOpts...)
 	if err != nil {
@@ -739,7 +942,7 @@ func (s Service) SetTargetCredentialSources(ctx context.Context, req *pbs.SetTar
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -747,6 +950,13 @@ func (s Service) SetTargetCredentialSources(ctx context.Context, req *pbs.SetTar
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, cl, outputOpts...)
 	if err != nil {
@@ -777,7 +987,7 @@ func (s Service) RemoveTargetCredentialSources(ctx context.Context, req *pbs.Rem
 		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
 	}
 
-	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts := make([]handlers.Option, 0, 4)
 	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
 	if outputFields.Has(globals.ScopeField) {
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
@@ -785,6 +995,13 @@ func (s Service) RemoveTargetCredentialSources(ctx context.Context, req *pbs.Rem
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabels(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, cl, outputOpts...)
 	if err != nil {
@@ -799,12 +1016,49 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	if err := validateAuthorizeSessionRequest(req); err != nil {
 		return nil, err
 	}
-	authResults := s.authResult(ctx, req.GetId(), action.AuthorizeSession,
+	// An alias can be used in place of the target id. It is resolved to its
+	// target, and to its host if no host id was requested, before the
+	// request is authorized against the target. Unknown aliases and aliases
+	// of targets the caller is not authorized on fail with the same error so
+	// the existence of an alias is not disclosed.
+	id, requestedId := req.GetId(), req.GetHostId()
+	viaAlias := req.GetName() == "" && alias.IsValue(id)
+	if viaAlias {
+		a, err := s.resolveAlias(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if a == nil {
+			return nil, handlers.ForbiddenError()
+		}
+		id = a.GetDestinationId()
+		if requestedId == "" {
+			requestedId = a.GetHostId()
+		}
+	}
+	authResults := s.authResult(ctx, id, action.AuthorizeSession,
 		target.WithName(req.GetName()),
 		target.WithScopeId(req.GetScopeId()),
 		target.WithScopeName(req.GetScopeName()),
 	)
 	if authResults.Error != nil {
+		if t, ok := authResults.RoundTripValue.(target.Target); ok && t != nil {
+			var reason string
+			switch {
+			case errors.Is(authResults.Error, handlers.ForbiddenError()):
+				reason = "forbidden"
+			case errors.Is(authResults.Error, handlers.UnauthenticatedError()):
+				reason = "unauthenticated"
+			}
+			if reason != "" {
+				s.recordAuthorizationFailure(ctx, authResults.UserId, t, reason)
+			}
+		}
+		if viaAlias && (errors.Is(authResults.Error, handlers.ForbiddenError()) ||
+			errors.Is(authResults.Error, handlers.UnauthenticatedError()) ||
+			errors.Is(authResults.Error, handlers.NotFoundError())) {
+			return nil, handlers.ForbiddenError()
+		}
 		return nil, authResults.Error
 	}
 
@@ -834,6 +1088,14 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	// * u_recovery access (which is fine, recovery is meant for recovering
 	// system state, no real reason to allow it to then connect to systems)
 	if authResults.AuthTokenId == "" {
+		s.recordAuthorizationFailure(ctx, authResults.UserId, t, "no auth token")
+		return nil, handlers.ForbiddenError()
+	}
+
+	// Sessions belong to users, so service accounts can manage Boundary
+	// resources but can not connect to targets.
+	if strings.HasPrefix(authResults.UserId, iam.ServiceAccountPrefix+"_") {
+		s.recordAuthorizationFailure(ctx, authResults.UserId, t, "service account")
 		return nil, handlers.ForbiddenError()
 	}
 
@@ -864,26 +1126,21 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	}
 
 	// First ensure we can actually service a request, that is, we have workers
-	// available (after any filtering). WorkerInfo only contains the address;
-	// worker IDs below is used to contain their IDs in the same order. This is
-	// used to fetch tags for filtering. But we avoid allocation unless we
-	// actually need it.
-	var workers []*pb.WorkerInfo
-	var workerIds []string
+	// available (after any filtering). Tags are only fetched when the worker
+	// filter or the worker selection strategy needs them.
 	hasWorkerFilter := len(t.GetWorkerFilter()) > 0
-	servers, err := serversRepo.ListServers(ctx, servers.ServerTypeWorker)
+	strategy := t.GetWorkerSelectionStrategy()
+	workerServers, err := serversRepo.ListServers(ctx, servers.ServerTypeWorker)
 	if err != nil {
 		return nil, err
 	}
-	for _, v := range servers {
-		if hasWorkerFilter {
+
+	var tagMap map[string]map[string][]string
+	if (hasWorkerFilter || strategy == target.WorkerSelectionLowestLatency) && len(workerServers) > 0 {
+		workerIds := make([]string, 0, len(workerServers))
+		for _, v := range workerServers {
 			workerIds = append(workerIds, v.GetPrivateId())
 		}
-		workers = append(workers, &pb.WorkerInfo{Address: v.Address})
-	}
-
-	if hasWorkerFilter && len(workerIds) > 0 {
-		finalWorkers := make([]*pb.WorkerInfo, 0, len(workers))
 		// Fetch the tags for the given worker IDs
 		tags, err := serversRepo.ListTagsForServers(ctx, workerIds)
 		if err != nil {
@@ -892,7 +1149,7 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 		// Build the map for filtering. This is similar to the filter map we
 		// built from the worker config, but with one extra level: a map of the
 		// worker's ID to its filter map.
-		tagMap := make(map[string]map[string][]string)
+		tagMap = make(map[string]map[string][]string)
 		for _, tag := range tags {
 			currWorkerMap := tagMap[tag.ServerId]
 			if currWorkerMap == nil {
@@ -903,6 +1160,10 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 			// We don't need to reinsert after the fact because maps are
 			// reference types, so we don't need to re-insert into tagMap
 		}
+	}
+
+	if hasWorkerFilter && len(workerServers) > 0 {
+		finalWorkers := make([]*servers.Server, 0, len(workerServers))
 
 		// Create the evaluator
 		eval, err := bexpr.CreateEvaluator(t.GetWorkerFilter())
@@ -910,13 +1171,13 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 			return nil, err
 		}
 
-		// Iterate through the known worker IDs, and evaluate. If evaluation
+		// Iterate through the known workers, and evaluate. If evaluation
 		// returns true, add to the final worker slice, which is assigned back
-		// to workers after this.
-		for i, worker := range workerIds {
+		// to workerServers after this.
+		for _, worker := range workerServers {
 			filterInput := map[string]interface{}{
-				"name": worker,
-				"tags": tagMap[worker],
+				"name": worker.GetPrivateId(),
+				"tags": tagMap[worker.GetPrivateId()],
 			}
 			ok, err := eval.Evaluate(filterInput)
 			if err != nil && !stderrors.Is(err, pointerstructure.ErrNotFound) {
@@ -925,18 +1186,25 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 					fmt.Sprintf("Worker filter expression evaluation resulted in error: %s", err))
 			}
 			if ok {
-				finalWorkers = append(finalWorkers, workers[i])
+				finalWorkers = append(finalWorkers, worker)
 			}
 		}
-		workers = finalWorkers
+		workerServers = finalWorkers
 	}
-	if len(workers) == 0 {
+	if len(workerServers) == 0 {
 		return nil, handlers.ApiErrorWithCodeAndMessage(
 			codes.FailedPrecondition,
 			"No workers are available to handle this session, or all have been filtered.")
 	}
 
-	requestedId := req.GetHostId()
+	// The client tries the workers in order, so order them by the target's
+	// worker selection strategy. WorkerInfo only contains the address.
+	workerServers = orderWorkers(strategy, authResults.UserId, workerServers, tagMap)
+	workers := make([]*pb.WorkerInfo, 0, len(workerServers))
+	for _, v := range workerServers {
+		workers = append(workers, &pb.WorkerInfo{Address: v.Address})
+	}
+
 	staticHostRepo, err := s.staticHostRepoFn()
 	if err != nil {
 		return nil, err
@@ -1011,10 +1279,15 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	var reqs []credential.Request
 	var dynCreds []*session.DynamicCredential
 	for _, l := range libs {
-		reqs = append(reqs, credential.Request{
-			SourceId: l.Id(),
-			Purpose:  l.CredentialPurpose(),
-		})
+		// Egress credentials are used by the worker to connect to the
+		// endpoint and are issued when the worker looks up the session, so
+		// they are never returned to the client.
+		if l.CredentialPurpose() != credential.EgressPurpose {
+			reqs = append(reqs, credential.Request{
+				SourceId: l.Id(),
+				Purpose:  l.CredentialPurpose(),
+			})
+		}
 		dynCreds = append(dynCreds, session.NewDynamicCredential(l.Id(), l.CredentialPurpose()))
 	}
 
@@ -1032,6 +1305,10 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 		ConnectionLimit:    t.GetSessionConnectionLimit(),
 		WorkerFilter:       t.GetWorkerFilter(),
 		DynamicCredentials: dynCreds,
+
+		ConnectionIdleTimeoutSeconds: t.GetConnectionIdleTimeoutSeconds(),
+		MaxConcurrentConnections:     t.GetMaxConcurrentConnections(),
+		MaxBytesPerSession:           t.GetMaxBytesPerSession(),
 	}
 
 	sess, err := session.New(sessionComposition)
@@ -1059,53 +1336,9 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 		}
 	}
 
-	var creds []*pb.SessionCredential
-	for _, c := range cs {
-		l := c.Library()
-		secret := c.Secret()
-		// TODO: Access the json directly from the vault response instead of re-marshalling it.
-		jSecret, err := json.Marshal(secret)
-		if err != nil {
-			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("marshalling secret to json"))
-		}
-		var sSecret *structpb.Struct
-		switch secret.(type) {
-		case map[string]interface{}:
-			// In this case we actually have to re-decode it. The proto wrappers
-			// choke on json.Number and at the time I'm writing this I don't
-			// have time to write a walk function to dig through with reflect
-			// and find all json.Numbers and replace them. So we eat the
-			// inefficiency. So note that we are specifically _not_ using a
-			// decoder with UseNumber here.
-			var dSecret map[string]interface{}
-			if err := json.Unmarshal(jSecret, &dSecret); err != nil {
-				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("decoding json for proto marshaling"))
-			}
-			sSecret, err = structpb.NewStruct(dSecret)
-			if err != nil {
-				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("creating proto struct for secret"))
-			}
-		}
-		creds = append(creds, &pb.SessionCredential{
-			CredentialLibrary: &pb.CredentialLibrary{
-				Id:                l.GetPublicId(),
-				Name:              l.GetName(),
-				Description:       l.GetDescription(),
-				CredentialStoreId: l.GetStoreId(),
-				Type:              credential.SubtypeFromId(l.GetPublicId()).String(),
-			},
-			CredentialSource: &pb.CredentialSource{
-				Id:                l.GetPublicId(),
-				Name:              l.GetName(),
-				Description:       l.GetDescription(),
-				CredentialStoreId: l.GetStoreId(),
-				Type:              credential.SubtypeFromId(l.GetPublicId()).String(),
-			},
-			Secret: &pb.SessionSecret{
-				Raw:     base64.StdEncoding.EncodeToString(jSecret),
-				Decoded: sSecret,
-			},
-		})
+	creds, err := DynamicCredentialsToProto(ctx, cs)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
 	}
 
 	sad := &pb.SessionAuthorizationData{
@@ -1143,6 +1376,22 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	return &pbs.AuthorizeSessionResponse{Item: ret}, nil
 }
 
+// CancelTargetSessions implements the interface pbs.TargetServiceServer.
+func (s Service) CancelTargetSessions(ctx context.Context, req *pbs.CancelTargetSessionsRequest) (*pbs.CancelTargetSessionsResponse, error) {
+	if err := validateCancelTargetSessionsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.CancelSessions)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	sessionIds, err := sessions.CancelMatching(ctx, s.sessionRepoFn, s.terminations, req.GetFilter(), req.GetReason(), session.WithTargetId(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.CancelTargetSessionsResponse{CanceledSessionCount: uint32(len(sessionIds)), CanceledSessionIds: sessionIds}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (target.Target, []target.HostSource, []target.CredentialSource, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -1176,6 +1425,18 @@ func (s Service) createInRepo(ctx context.Context, item *pb.Target) (target.Targ
 	if item.GetWorkerFilter() != nil {
 		opts = append(opts, target.WithWorkerFilter(item.GetWorkerFilter().GetValue()))
 	}
+	if item.GetConnectionIdleTimeoutSeconds() != nil {
+		opts = append(opts, target.WithConnectionIdleTimeoutSeconds(item.GetConnectionIdleTimeoutSeconds().GetValue()))
+	}
+	if item.GetMaxConcurrentConnections() != nil {
+		opts = append(opts, target.WithMaxConcurrentConnections(item.GetMaxConcurrentConnections().GetValue()))
+	}
+	if item.GetMaxBytesPerSession() != nil {
+		opts = append(opts, target.WithMaxBytesPerSession(item.GetMaxBytesPerSession().GetValue()))
+	}
+	if item.GetWorkerSelectionStrategy() != nil {
+		opts = append(opts, target.WithWorkerSelectionStrategy(item.GetWorkerSelectionStrategy().GetValue()))
+	}
 
 	attr, err := subtypeRegistry.newAttribute(target.SubtypeFromType(item.GetType()), withStruct(item.GetAttributes()))
 	if err != nil {
@@ -1219,6 +1480,18 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if filter := item.GetWorkerFilter(); filter != nil {
 		opts = append(opts, target.WithWorkerFilter(item.GetWorkerFilter().GetValue()))
 	}
+	if item.GetConnectionIdleTimeoutSeconds() != nil {
+		opts = append(opts, target.WithConnectionIdleTimeoutSeconds(item.GetConnectionIdleTimeoutSeconds().GetValue()))
+	}
+	if item.GetMaxConcurrentConnections() != nil {
+		opts = append(opts, target.WithMaxConcurrentConnections(item.GetMaxConcurrentConnections().GetValue()))
+	}
+	if item.GetMaxBytesPerSession() != nil {
+		opts = append(opts, target.WithMaxBytesPerSession(item.GetMaxBytesPerSession().GetValue()))
+	}
+	if item.GetWorkerSelectionStrategy() != nil {
+		opts = append(opts, target.WithWorkerSelectionStrategy(item.GetWorkerSelectionStrategy().GetValue()))
+	}
 	subtype := target.SubtypeFromId(id)
 
 	attr, err := subtypeRegistry.newAttribute(subtype, withStruct(item.GetAttributes()))
@@ -1275,18 +1548,67 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]target.Target, error) {
+func (s Service) listFromRepo(ctx context.Context, opt ...target.Option) ([]target.Target, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListTargets(ctx, target.WithScopeIds(scopeIds))
+	ul, err := repo.ListTargets(ctx, opt...)
 	if err != nil {
 		return nil, err
 	}
 	return ul, nil
 }
 
+// listLabels returns the labels of targets keyed by target id.
+func (s Service) listLabels(ctx context.Context, targets ...target.Target) (map[string]map[string]string, error) {
+	repo, err := s.labelRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(targets))
+	for _, t := range targets {
+		ids = append(ids, t.GetPublicId())
+	}
+	return repo.ListLabels(ctx, resource.Target, ids)
+}
+
+// setLabels replaces the labels of the target id.
+func (s Service) setLabels(ctx context.Context, id string, labels map[string]string) error {
+	const op = "targets.(Service).setLabels"
+	repo, err := s.labelRepoFn()
+	if err != nil {
+		return err
+	}
+	if err := repo.SetLabels(ctx, resource.Target, id, labels); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to set target labels"))
+	}
+	return nil
+}
+
+// listScopeInfo returns the info of every scope keyed by scope id.
+func (s Service) listScopeInfo(ctx context.Context) (map[string]*scopes.ScopeInfo, error) {
+	repo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	scps, err := repo.ListScopesRecursively(ctx, scope.Global.String())
+	if err != nil {
+		return nil, err
+	}
+	scopeInfoMap := make(map[string]*scopes.ScopeInfo, len(scps))
+	for _, scp := range scps {
+		scopeInfoMap[scp.GetPublicId()] = &scopes.ScopeInfo{
+			Id:            scp.GetPublicId(),
+			Type:          scp.GetType(),
+			Name:          scp.GetName(),
+			Description:   scp.GetDescription(),
+			ParentScopeId: scp.GetParentId(),
+		}
+	}
+	return scopeInfoMap, nil
+}
+
 func (s Service) addHostSourcesInRepo(ctx context.Context, targetId string, hostSourceIds []string, version uint32) (target.Target, []target.HostSource, []target.CredentialSource, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -1421,6 +1743,40 @@ func (s Service) removeCredentialSourcesInRepo(ctx context.Context, targetId str
 	return out, hs, credSources, nil
 }
 
+// recordAuthorizationFailure records a denied session authorization in the
+// warehouse and in the activity history of the user. Failing to record it is
+// logged but does not change the response to the request.
+func (s Service) recordAuthorizationFailure(ctx context.Context, userId string, t target.Target, reason string) {
+	const op = "targets.(Service).recordAuthorizationFailure"
+	if userId == "" {
+		return
+	}
+	if repo, err := s.reportRepoFn(); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to get report repository"))
+	} else if err := repo.RecordAuthorizationFailure(ctx, userId, t.GetPublicId(), t.GetScopeId(), reason); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to record authorization failure"))
+	}
+	if repo, err := s.activityRepoFn(); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to get activity repository"))
+	} else if err := repo.RecordSessionAuthorizationDenied(ctx, userId, t.GetPublicId(), t.GetScopeId(), reason); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to record session authorization denied activity"))
+	}
+}
+
+// resolveAlias returns the alias with the value or nil if there is none.
+func (s Service) resolveAlias(ctx context.Context, value string) (*alias.Alias, error) {
+	const op = "targets.(Service).resolveAlias"
+	repo, err := s.aliasRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	a, err := repo.LookupAliasByValue(ctx, value)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return a, nil
+}
+
 func (s Service) authResult(ctx context.Context, id string, a action.Type, lookupOpt ...target.Option) auth.VerifyResults {
 	res := auth.VerifyResults{}
 
@@ -1516,12 +1872,27 @@ func toProto(ctx context.Context, in target.Target, hostSources []target.HostSou
 	if outputFields.Has(globals.WorkerFilterField) && in.GetWorkerFilter() != "" {
 		out.WorkerFilter = wrapperspb.String(in.GetWorkerFilter())
 	}
+	if outputFields.Has(globals.ConnectionIdleTimeoutSecondsField) && in.GetConnectionIdleTimeoutSeconds() != 0 {
+		out.ConnectionIdleTimeoutSeconds = wrapperspb.UInt32(in.GetConnectionIdleTimeoutSeconds())
+	}
+	if outputFields.Has(globals.MaxConcurrentConnectionsField) && in.GetMaxConcurrentConnections() != 0 {
+		out.MaxConcurrentConnections = wrapperspb.UInt32(in.GetMaxConcurrentConnections())
+	}
+	if outputFields.Has(globals.MaxBytesPerSessionField) && in.GetMaxBytesPerSession() != 0 {
+		out.MaxBytesPerSession = wrapperspb.UInt64(in.GetMaxBytesPerSession())
+	}
+	if outputFields.Has(globals.WorkerSelectionStrategyField) && in.GetWorkerSelectionStrategy() != "" {
+		out.WorkerSelectionStrategy = wrapperspb.String(in.GetWorkerSelectionStrategy())
+	}
 	if outputFields.Has(globals.ScopeField) {
 		out.Scope = opts.WithScope
 	}
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		out.AuthorizedActions = opts.WithAuthorizedActions
 	}
+	if outputFields.Has(globals.LabelsField) {
+		out.Labels = opts.WithLabels
+	}
 	if outputFields.Has(globals.HostSetIdsField) {
 		for _, hs := range hostSources {
 			out.HostSetIds = append(out.HostSetIds, hs.Id())
@@ -1550,12 +1921,23 @@ func toProto(ctx context.Context, in target.Target, hostSources []target.HostSou
 	}
 	if outputFields.Has(globals.ApplicationCredentialLibraryIdsField) {
 		for _, cs := range credSources {
-			out.ApplicationCredentialLibraryIds = append(out.ApplicationCredentialLibraryIds, cs.Id())
+			if credential.Purpose(cs.CredentialPurpose()) == credential.ApplicationPurpose {
+				out.ApplicationCredentialLibraryIds = append(out.ApplicationCredentialLibraryIds, cs.Id())
+			}
 		}
 	}
 	if outputFields.Has(globals.ApplicationCredentialSourceIdsField) {
 		for _, cs := range credSources {
-			out.ApplicationCredentialSourceIds = append(out.ApplicationCredentialSourceIds, cs.Id())
+			if credential.Purpose(cs.CredentialPurpose()) == credential.ApplicationPurpose {
+				out.ApplicationCredentialSourceIds = append(out.ApplicationCredentialSourceIds, cs.Id())
+			}
+		}
+	}
+	if outputFields.Has(globals.EgressCredentialSourceIdsField) {
+		for _, cs := range credSources {
+			if credential.Purpose(cs.CredentialPurpose()) == credential.EgressPurpose {
+				out.EgressCredentialSourceIds = append(out.EgressCredentialSourceIds, cs.Id())
+			}
 		}
 	}
 	if outputFields.Has(globals.ApplicationCredentialLibrariesField) {
@@ -1588,6 +1970,16 @@ func toProto(ctx context.Context, in target.Target, hostSources []target.HostSou
 			}
 		}
 	}
+	if outputFields.Has(globals.EgressCredentialSourcesField) {
+		for _, cs := range credSources {
+			if credential.Purpose(cs.CredentialPurpose()) == credential.EgressPurpose {
+				out.EgressCredentialSources = append(out.EgressCredentialSources, &pb.CredentialSource{
+					Id:                cs.Id(),
+					CredentialStoreId: cs.CredentialStoreId(),
+				})
+			}
+		}
+	}
 	if outputFields.Has(globals.AttributesField) {
 		attr, err := subtypeRegistry.newAttribute(in.GetType(), withTarget(in))
 		if err != nil {
@@ -1633,6 +2025,9 @@ func validateCreateRequest(req *pbs.CreateTargetRequest) error {
 		if req.GetItem().GetSessionMaxSeconds() != nil && req.GetItem().GetSessionMaxSeconds().GetValue() == 0 {
 			badFields[globals.SessionMaxSecondsField] = "This must be greater than zero."
 		}
+		if err := label.Validate(context.Background(), req.GetItem().GetLabels()); err != nil {
+			badFields[globals.LabelsField] = "Label keys and values must be non-empty, lowercase and free of ',', and keys must not contain '='."
+		}
 		if req.GetItem().GetType() == "" {
 			badFields[globals.TypeField] = "This is a required field."
 		} else if target.SubtypeFromType(req.GetItem().GetType()) == "" {
@@ -1643,6 +2038,11 @@ func validateCreateRequest(req *pbs.CreateTargetRequest) error {
 				badFields[globals.WorkerFilterField] = "Unable to successfully parse filter expression."
 			}
 		}
+		if strategy := req.GetItem().GetWorkerSelectionStrategy(); strategy != nil {
+			if strategy.GetValue() == "" || !target.ValidWorkerSelectionStrategy(strategy.GetValue()) {
+				badFields[globals.WorkerSelectionStrategyField] = workerSelectionStrategyError
+			}
+		}
 
 		subtype := target.SubtypeFromType(req.GetItem().GetType())
 		_, err := subtypeRegistry.get(subtype)
@@ -1685,6 +2085,12 @@ func validateUpdateRequest(req *pbs.UpdateTargetRequest) error {
 				badFields[globals.WorkerFilterField] = "Unable to successfully parse filter expression."
 			}
 		}
+		if strategy := req.GetItem().GetWorkerSelectionStrategy(); strategy != nil && !target.ValidWorkerSelectionStrategy(strategy.GetValue()) {
+			badFields[globals.WorkerSelectionStrategyField] = workerSelectionStrategyError
+		}
+		if err := label.Validate(context.Background(), req.GetItem().GetLabels()); err != nil {
+			badFields[globals.LabelsField] = "Label keys and values must be non-empty, lowercase and free of ',', and keys must not contain '='."
+		}
 		subtype := target.SubtypeFromId(req.GetId())
 		_, err := subtypeRegistry.get(subtype)
 		if err != nil {
@@ -1713,10 +2119,23 @@ func validateDeleteRequest(req *pbs.DeleteTargetRequest) error {
 
 func validateListRequest(req *pbs.ListTargetsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Project.Prefix()) &&
-		!req.GetRecursive() {
+	switch {
+	case req.GetAuthorized():
+		if req.GetScopeId() != "" && req.GetScopeId() != scope.Global.String() {
+			badFields[globals.ScopeIdField] = "This field must be empty or global when listing authorized targets."
+		}
+		if req.GetRecursive() {
+			badFields["recursive"] = "Authorized targets are always listed across all scopes."
+		}
+	case !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Project.Prefix()) &&
+		!req.GetRecursive():
 		badFields[globals.ScopeIdField] = "This field must be a valid project scope ID or the list operation must be recursive."
 	}
+	if req.GetLabelSelector() != "" {
+		if _, err := label.ParseSelector(context.Background(), req.GetLabelSelector()); err != nil {
+			badFields[globals.LabelSelectorField] = "This field must be comma separated key=value pairs, each key given once."
+		}
+	}
 	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
 		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
 	}
@@ -1876,7 +2295,7 @@ func validateAddLibrariesRequest(req *pbs.AddTargetCredentialLibrariesRequest) e
 		badFields[globals.ApplicationCredentialLibraryIdsField] = "Must be non-empty."
 	}
 	for _, cl := range req.GetApplicationCredentialLibraryIds() {
-		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix) {
+		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix, vault.SshSignedCertCredentialLibraryPrefix) {
 			badFields[globals.ApplicationCredentialLibraryIdsField] = fmt.Sprintf("Incorrectly formatted credential library identifier %q.", cl)
 			break
 		}
@@ -1896,7 +2315,7 @@ func validateSetLibrariesRequest(req *pbs.SetTargetCredentialLibrariesRequest) e
 		badFields[globals.VersionField] = "Required field."
 	}
 	for _, cl := range req.GetApplicationCredentialLibraryIds() {
-		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix) {
+		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix, vault.SshSignedCertCredentialLibraryPrefix) {
 			badFields[globals.ApplicationCredentialLibraryIdsField] = fmt.Sprintf("Incorrectly formatted credential library identifier %q.", cl)
 			break
 		}
@@ -1919,7 +2338,7 @@ func validateRemoveLibrariesRequest(req *pbs.RemoveTargetCredentialLibrariesRequ
 		badFields[globals.ApplicationCredentialLibraryIdsField] = "Must be non-empty."
 	}
 	for _, cl := range req.GetApplicationCredentialLibraryIds() {
-		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix) {
+		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix, vault.SshSignedCertCredentialLibraryPrefix) {
 			badFields[globals.ApplicationCredentialLibraryIdsField] = fmt.Sprintf("Incorrectly formatted credential library identifier %q.", cl)
 			break
 		}
@@ -1943,13 +2362,13 @@ func validateAddCredentialSourcesRequest(req *pbs.AddTargetCredentialSourcesRequ
 		badFields[globals.EgressCredentialSourceIdsField] = "Application or Egress Credential Source IDs must be provided."
 	}
 	for _, cl := range req.GetApplicationCredentialSourceIds() {
-		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix) {
+		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix, vault.SshSignedCertCredentialLibraryPrefix) {
 			badFields[globals.ApplicationCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
 		}
 	}
 	for _, cl := range req.GetEgressCredentialSourceIds() {
-		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix) {
+		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix, vault.SshSignedCertCredentialLibraryPrefix) {
 			badFields[globals.EgressCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
 		}
@@ -1969,13 +2388,13 @@ func validateSetCredentialSourcesRequest(req *pbs.SetTargetCredentialSourcesRequ
 		badFields[globals.VersionField] = "Required field."
 	}
 	for _, cl := range req.GetApplicationCredentialSourceIds() {
-		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix) {
+		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix, vault.SshSignedCertCredentialLibraryPrefix) {
 			badFields[globals.ApplicationCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
 		}
 	}
 	for _, cl := range req.GetEgressCredentialSourceIds() {
-		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix) {
+		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix, vault.SshSignedCertCredentialLibraryPrefix) {
 			badFields[globals.EgressCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
 		}
@@ -1999,13 +2418,13 @@ func validateRemoveCredentialSourcesRequest(req *pbs.RemoveTargetCredentialSourc
 		badFields[globals.EgressCredentialSourceIdsField] = "Application or Egress Credential Source IDs must be provided."
 	}
 	for _, cl := range req.GetApplicationCredentialSourceIds() {
-		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix) {
+		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix, vault.SshSignedCertCredentialLibraryPrefix) {
 			badFields[globals.ApplicationCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
 		}
 	}
 	for _, cl := range req.GetEgressCredentialSourceIds() {
-		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix) {
+		if !handlers.ValidId(handlers.Id(cl), vault.CredentialLibraryPrefix, vault.SshSignedCertCredentialLibraryPrefix) {
 			badFields[globals.EgressCredentialSourceIdsField] = fmt.Sprintf("Incorrectly formatted credential source identifier %q.", cl)
 			break
 		}
@@ -2016,13 +2435,25 @@ func validateRemoveCredentialSourcesRequest(req *pbs.RemoveTargetCredentialSourc
 	return nil
 }
 
+func validateCancelTargetSessionsRequest(req *pbs.CancelTargetSessionsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), target.Prefixes()...) {
+		badFields[globals.IdField] = "Incorrectly formatted identifier."
+	}
+	sessions.ValidateCancelMatching(badFields, req.GetFilter(), req.GetReason())
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
 func validateAuthorizeSessionRequest(req *pbs.AuthorizeSessionRequest) error {
 	badFields := map[string]string{}
 	nameEmpty := req.GetName() == ""
 	scopeIdEmpty := req.GetScopeId() == ""
 	scopeNameEmpty := req.GetScopeName() == ""
 	if nameEmpty {
-		if !handlers.ValidId(handlers.Id(req.GetId()), target.Prefixes()...) {
+		if !handlers.ValidId(handlers.Id(req.GetId()), target.Prefixes()...) && !alias.IsValue(req.GetId()) {
 			badFields[globals.IdField] = "Incorrectly formatted identifier."
 		}
 		if !scopeIdEmpty {
@@ -2076,3 +2507,92 @@ func createCredLibs(targetId string, applicationIds, ingressIds, egressIds []str
 	}
 	return credLibs, nil
 }
+
+// DynamicCredentialsToProto converts the issued dynamic credentials into the
+// SessionCredential protos returned to clients and workers.
+func DynamicCredentialsToProto(ctx context.Context, cs []credential.Dynamic) ([]*pb.SessionCredential, error) {
+	const op = "targets.DynamicCredentialsToProto"
+	var creds []*pb.SessionCredential
+	for _, c := range cs {
+		l := c.Library()
+		secret := c.Secret()
+		// TODO: Access the json directly from the vault response instead of re-marshalling it.
+		jSecret, err := json.Marshal(secret)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("marshalling secret to json"))
+		}
+		var sSecret *structpb.Struct
+		switch secret.(type) {
+		case map[string]interface{}:
+			// In this case we actually have to re-decode it. The proto wrappers
+			// choke on json.Number and at the time I'm writing this I don't
+			// have time to write a walk function to dig through with reflect
+			// and find all json.Numbers and replace them. So we eat the
+			// inefficiency. So note that we are specifically _not_ using a
+			// decoder with UseNumber here.
+			var dSecret map[string]interface{}
+			if err := json.Unmarshal(jSecret, &dSecret); err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("decoding json for proto marshaling"))
+			}
+			sSecret, err = structpb.NewStruct(dSecret)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("creating proto struct for secret"))
+			}
+		}
+		var typedCred map[string]interface{}
+		switch tc := c.(type) {
+		case credential.UserPassword:
+			typedCred = map[string]interface{}{
+				"username": tc.Username(),
+				"password": string(tc.Password()),
+			}
+		case credential.SshCertificate:
+			// SshCertificate also satisfies KeyPair so it must be checked
+			// first.
+			typedCred = map[string]interface{}{
+				"username":    tc.Username(),
+				"private_key": string(tc.Private()),
+				"certificate": string(tc.Certificate()),
+			}
+		case credential.KeyPair:
+			typedCred = map[string]interface{}{
+				"username":    tc.Username(),
+				"private_key": string(tc.Private()),
+			}
+		}
+		var sCred *structpb.Struct
+		if typedCred != nil {
+			sCred, err = structpb.NewStruct(typedCred)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("creating proto struct for credential"))
+			}
+		}
+		var credType string
+		if ct := l.CredentialType(); ct != credential.UnspecifiedType {
+			credType = ct.String()
+		}
+		creds = append(creds, &pb.SessionCredential{
+			CredentialLibrary: &pb.CredentialLibrary{
+				Id:                l.GetPublicId(),
+				Name:              l.GetName(),
+				Description:       l.GetDescription(),
+				CredentialStoreId: l.GetStoreId(),
+				Type:              credential.SubtypeFromId(l.GetPublicId()).String(),
+			},
+			CredentialSource: &pb.CredentialSource{
+				Id:                l.GetPublicId(),
+				Name:              l.GetName(),
+				Description:       l.GetDescription(),
+				CredentialStoreId: l.GetStoreId(),
+				Type:              credential.SubtypeFromId(l.GetPublicId()).String(),
+				CredentialType:    credType,
+			},
+			Secret: &pb.SessionSecret{
+				Raw:     base64.StdEncoding.EncodeToString(jSecret),
+				Decoded: sSecret,
+			},
+			Credential: sCred,
+		})
+	}
+	return creds, nil
+}
@@ -13,6 +13,8 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/activity"
+	"github.com/hashicorp/boundary/internal/alias"
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/credential"
 	"github.com/hashicorp/boundary/internal/credential/vault"
@@ -24,7 +26,9 @@ import (
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/label"
 	"github.com/hashicorp/boundary/internal/plugin/host"
+	"github.com/hashicorp/boundary/internal/report"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/scheduler"
 	"github.com/hashicorp/boundary/internal/servers"
@@ -37,6 +41,7 @@ import (
 	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/target"
 	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	credpb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/credentiallibraries"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
@@ -98,7 +103,19 @@ func testService(t *testing.T, conn *db.DB, kms *kms.Kms, wrapper wrapping.Wrapp
 	credentialRepoFn := func() (*vault.Repository, error) {
 		return vault.NewRepository(rw, rw, kms, sche)
 	}
-	return targets.NewService(context.Background(), kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, credentialRepoFn)
+	reportRepoFn := func() (*report.Repository, error) {
+		return report.NewRepository(rw, rw)
+	}
+	aliasRepoFn := func() (*alias.Repository, error) {
+		return alias.NewRepository(rw, rw, kms)
+	}
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
+	activityRepoFn := func() (*activity.Repository, error) {
+		return activity.NewRepository(rw, rw)
+	}
+	return targets.NewService(context.Background(), kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, credentialRepoFn, reportRepoFn, aliasRepoFn, labelRepoFn, activityRepoFn, nil)
 }
 
 func TestGet(t *testing.T) {
@@ -326,6 +343,198 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestList_Labels(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	labelRepo, err := label.NewRepository(rw, rw)
+	require.NoError(t, err)
+
+	_, proj := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+	prod := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "prod")
+	require.NoError(t, labelRepo.SetLabels(ctx, resource.Target, prod.GetPublicId(), map[string]string{"env": "prod", "team": "payments"}))
+	dev := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "dev")
+	require.NoError(t, labelRepo.SetLabels(ctx, resource.Target, dev.GetPublicId(), map[string]string{"env": "dev", "team": "payments"}))
+	_ = tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "unlabeled")
+
+	cases := []struct {
+		name    string
+		req     *pbs.ListTargetsRequest
+		wantIds []string
+		err     error
+	}{
+		{
+			name:    "Label Matches",
+			req:     &pbs.ListTargetsRequest{ScopeId: proj.GetPublicId(), LabelSelector: "env=prod"},
+			wantIds: []string{prod.GetPublicId()},
+		},
+		{
+			name:    "All Labels Match",
+			req:     &pbs.ListTargetsRequest{ScopeId: proj.GetPublicId(), LabelSelector: "team=payments,env=dev"},
+			wantIds: []string{dev.GetPublicId()},
+		},
+		{
+			name:    "Shared Label Matches",
+			req:     &pbs.ListTargetsRequest{ScopeId: proj.GetPublicId(), LabelSelector: "team=payments"},
+			wantIds: []string{prod.GetPublicId(), dev.GetPublicId()},
+		},
+		{
+			name: "Label Doesn't Match",
+			req:  &pbs.ListTargetsRequest{ScopeId: proj.GetPublicId(), LabelSelector: "env=staging"},
+		},
+		{
+			name: "Not All Labels Match",
+			req:  &pbs.ListTargetsRequest{ScopeId: proj.GetPublicId(), LabelSelector: "env=prod,team=search"},
+		},
+		{
+			name: "Selector Without Value",
+			req:  &pbs.ListTargetsRequest{ScopeId: proj.GetPublicId(), LabelSelector: "env"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Selector With Duplicate Key",
+			req:  &pbs.ListTargetsRequest{ScopeId: proj.GetPublicId(), LabelSelector: "env=prod,env=dev"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s, err := testService(t, conn, kms, wrapper)
+			require.NoError(err, "Couldn't create new target service.")
+
+			got, gErr := s.ListTargets(auth.DisabledAuthTestContext(iamRepoFn, tc.req.GetScopeId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "ListTargets(%q) got error %v, wanted %v", tc.req.GetLabelSelector(), gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			var gotIds []string
+			for _, item := range got.GetItems() {
+				gotIds = append(gotIds, item.GetId())
+			}
+			assert.ElementsMatch(tc.wantIds, gotIds)
+		})
+	}
+}
+
+func TestList_Authorized(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+	labelRepo, err := label.NewRepository(rw, rw)
+	require.NoError(t, err)
+
+	org, proj := iam.TestScopes(t, iamRepo)
+	_, otherProj := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	ctx = auth.NewVerifierContext(requests.NewRequestContext(ctx),
+		iamRepoFn,
+		atRepoFn,
+		serversRepoFn,
+		kms,
+		&authpb.RequestInfo{
+			Token:       at.GetToken(),
+			TokenFormat: uint32(auth.AuthTokenTypeBearer),
+			PublicId:    at.GetPublicId(),
+		})
+
+	connectable := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "connectable")
+	require.NoError(t, labelRepo.SetLabels(ctx, resource.Target, connectable.GetPublicId(), map[string]string{"env": "prod"}))
+	readable := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "readable")
+	require.NoError(t, labelRepo.SetLabels(ctx, resource.Target, readable.GetPublicId(), map[string]string{"env": "prod"}))
+	_ = tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "no grants")
+	otherConnectable := tcp.TestTarget(ctx, t, conn, otherProj.GetPublicId(), "other connectable")
+	require.NoError(t, labelRepo.SetLabels(ctx, resource.Target, otherConnectable.GetPublicId(), map[string]string{"env": "dev"}))
+
+	r := iam.TestRole(t, conn, proj.GetPublicId())
+	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), fmt.Sprintf("id=%s;actions=authorize-session", connectable.GetPublicId()))
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), fmt.Sprintf("id=%s;actions=read", readable.GetPublicId()))
+	otherR := iam.TestRole(t, conn, otherProj.GetPublicId())
+	_ = iam.TestUserRole(t, conn, otherR.GetPublicId(), at.GetIamUserId())
+	_ = iam.TestRoleGrant(t, conn, otherR.GetPublicId(), "id=*;type=target;actions=authorize-session")
+
+	s, err := testService(t, conn, kms, wrapper)
+	require.NoError(t, err, "Couldn't create new target service.")
+
+	cases := []struct {
+		name    string
+		req     *pbs.ListTargetsRequest
+		wantIds []string
+		err     error
+	}{
+		{
+			name:    "Only Targets Allowing Authorize Session",
+			req:     &pbs.ListTargetsRequest{Authorized: true},
+			wantIds: []string{connectable.GetPublicId(), otherConnectable.GetPublicId()},
+		},
+		{
+			name:    "Global Scope",
+			req:     &pbs.ListTargetsRequest{ScopeId: scope.Global.String(), Authorized: true},
+			wantIds: []string{connectable.GetPublicId(), otherConnectable.GetPublicId()},
+		},
+		{
+			name:    "With Label Selector",
+			req:     &pbs.ListTargetsRequest{Authorized: true, LabelSelector: "env=prod"},
+			wantIds: []string{connectable.GetPublicId()},
+		},
+		{
+			name:    "With Filter",
+			req:     &pbs.ListTargetsRequest{Authorized: true, Filter: fmt.Sprintf(`"/item/id"==%q`, otherConnectable.GetPublicId())},
+			wantIds: []string{otherConnectable.GetPublicId()},
+		},
+		{
+			name: "Project Scope",
+			req:  &pbs.ListTargetsRequest{ScopeId: proj.GetPublicId(), Authorized: true},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Recursive",
+			req:  &pbs.ListTargetsRequest{Authorized: true, Recursive: true},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.ListTargets(ctx, tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "ListTargets(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			var gotIds []string
+			for _, item := range got.GetItems() {
+				gotIds = append(gotIds, item.GetId())
+			}
+			assert.ElementsMatch(tc.wantIds, gotIds)
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -2679,7 +2888,19 @@ func TestAuthorizeSession(t *testing.T) {
 	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
 	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=*;actions=*")
 
-	s, err := targets.NewService(ctx, kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, credentialRepoFn)
+	reportRepoFn := func() (*report.Repository, error) {
+		return report.NewRepository(rw, rw)
+	}
+	aliasRepoFn := func() (*alias.Repository, error) {
+		return alias.NewRepository(rw, rw, kms)
+	}
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
+	activityRepoFn := func() (*activity.Repository, error) {
+		return activity.NewRepository(rw, rw)
+	}
+	s, err := targets.NewService(ctx, kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, credentialRepoFn, reportRepoFn, aliasRepoFn, labelRepoFn, activityRepoFn, nil)
 	require.NoError(t, err)
 
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
@@ -2753,7 +2974,7 @@ func TestAuthorizeSession(t *testing.T) {
 			require.NoError(t, err)
 
 			// Tell our DB that there is a worker ready to serve the data
-			workerService := workers.NewWorkerServiceServer(serversRepoFn, sessionRepoFn, &sync.Map{}, kms)
+			workerService := workers.NewWorkerServiceServer(serversRepoFn, sessionRepoFn, iamRepoFn, credentialRepoFn, &sync.Map{}, kms, nil)
 			_, err = workerService.Status(ctx, &spbs.StatusRequest{
 				Worker: &spb.Server{
 					PrivateId: "testworker",
@@ -2839,6 +3060,208 @@ func TestAuthorizeSession(t *testing.T) {
 	}
 }
 
+func TestAuthorizeSession_Alias(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	err := vault.RegisterJobs(context.Background(), sche, rw, rw, kms)
+	require.NoError(t, err)
+
+	repoFn := func() (*target.Repository, error) {
+		return target.NewRepository(rw, rw, kms)
+	}
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kms)
+	}
+	staticHostRepoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	pluginHostRepoFn := func() (*plugin.Repository, error) {
+		return plugin.NewRepository(rw, rw, kms, sche, map[string]plgpb.HostPluginServiceClient{})
+	}
+	credentialRepoFn := func() (*vault.Repository, error) {
+		return vault.NewRepository(rw, rw, kms, sche)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	reportRepoFn := func() (*report.Repository, error) {
+		return report.NewRepository(rw, rw)
+	}
+	aliasRepoFn := func() (*alias.Repository, error) {
+		return alias.NewRepository(rw, rw, kms)
+	}
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
+	activityRepoFn := func() (*activity.Repository, error) {
+		return activity.NewRepository(rw, rw)
+	}
+
+	org, proj := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	ctx = auth.NewVerifierContext(requests.NewRequestContext(ctx),
+		iamRepoFn,
+		atRepoFn,
+		serversRepoFn,
+		kms,
+		&authpb.RequestInfo{
+			Token:       at.GetToken(),
+			TokenFormat: uint32(auth.AuthTokenTypeBearer),
+			PublicId:    at.GetPublicId(),
+		})
+	r := iam.TestRole(t, conn, proj.GetPublicId())
+	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=*;actions=*")
+
+	s, err := targets.NewService(ctx, kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, credentialRepoFn, reportRepoFn, aliasRepoFn, labelRepoFn, activityRepoFn, nil)
+	require.NoError(t, err)
+
+	// Tell our DB that there is a worker ready to serve the data
+	workerService := workers.NewWorkerServiceServer(serversRepoFn, sessionRepoFn, iamRepoFn, credentialRepoFn, &sync.Map{}, kms, nil)
+	_, err = workerService.Status(ctx, &spbs.StatusRequest{
+		Worker: &spb.Server{
+			PrivateId: "testworker",
+			Address:   "localhost:8457",
+		},
+	})
+	require.NoError(t, err)
+
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	hs := static.TestHosts(t, conn, hc.GetPublicId(), 2)
+	shs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+	_ = static.TestSetMembers(t, conn, shs.GetPublicId(), hs)
+
+	tar := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "aliased")
+	_, err = s.AddTargetHostSets(ctx, &pbs.AddTargetHostSetsRequest{
+		Id:         tar.GetPublicId(),
+		Version:    tar.GetVersion(),
+		HostSetIds: []string{shs.GetPublicId()},
+	})
+	require.NoError(t, err)
+
+	targetAlias := alias.TestAlias(t, conn, proj.GetPublicId(), "target.alias", tar.GetPublicId())
+	hostAlias := alias.TestAlias(t, conn, proj.GetPublicId(), "host.alias", tar.GetPublicId(), alias.WithHostId(hs[1].GetPublicId()))
+
+	_, otherProj := iam.TestScopes(t, iamRepo)
+	otherTar := tcp.TestTarget(ctx, t, conn, otherProj.GetPublicId(), "not granted")
+	otherAlias := alias.TestAlias(t, conn, otherProj.GetPublicId(), "other.alias", otherTar.GetPublicId())
+
+	cases := []struct {
+		name         string
+		req          *pbs.AuthorizeSessionRequest
+		wantedHostId string
+		err          error
+	}{
+		{
+			name: "alias of the target",
+			req:  &pbs.AuthorizeSessionRequest{Id: targetAlias.GetValue()},
+		},
+		{
+			name:         "alias with a host",
+			req:          &pbs.AuthorizeSessionRequest{Id: hostAlias.GetValue()},
+			wantedHostId: hs[1].GetPublicId(),
+		},
+		{
+			name:         "requested host overrides the alias host",
+			req:          &pbs.AuthorizeSessionRequest{Id: hostAlias.GetValue(), HostId: hs[0].GetPublicId()},
+			wantedHostId: hs[0].GetPublicId(),
+		},
+		{
+			name: "unknown alias",
+			req:  &pbs.AuthorizeSessionRequest{Id: "unknown.alias"},
+			err:  handlers.ForbiddenError(),
+		},
+		{
+			// Fails with the same error as an unknown alias.
+			name: "alias of a target without grants",
+			req:  &pbs.AuthorizeSessionRequest{Id: otherAlias.GetValue()},
+			err:  handlers.ForbiddenError(),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			res, err := s.AuthorizeSession(ctx, tc.req)
+			if tc.err != nil {
+				require.Error(err)
+				assert.True(errors.Is(err, tc.err), "AuthorizeSession(%+v) got error %v, wanted %v", tc.req, err, tc.err)
+				assert.Nil(res)
+				return
+			}
+			require.NoError(err)
+			got := res.GetItem()
+			assert.Equal(tar.GetPublicId(), got.GetTargetId())
+			assert.Equal(shs.GetPublicId(), got.GetHostSetId())
+			if tc.wantedHostId != "" {
+				assert.Equal(tc.wantedHostId, got.GetHostId())
+			}
+		})
+	}
+}
+
+func TestAuthorizeSession_DeniedActivity(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+	activityRepo, err := activity.NewRepository(rw, rw)
+	require.NoError(t, err)
+
+	org, proj := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	ctx := auth.NewVerifierContext(requests.NewRequestContext(context.Background()),
+		iamRepoFn,
+		atRepoFn,
+		serversRepoFn,
+		kms,
+		&authpb.RequestInfo{
+			Token:       at.GetToken(),
+			TokenFormat: uint32(auth.AuthTokenTypeBearer),
+			PublicId:    at.GetPublicId(),
+		})
+	tar := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "no grants")
+
+	s, err := testService(t, conn, kms, wrapper)
+	require.NoError(t, err)
+
+	// The user has no grants on the target
+	_, err = s.AuthorizeSession(ctx, &pbs.AuthorizeSessionRequest{Id: tar.GetPublicId()})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, handlers.ForbiddenError()), "AuthorizeSession got error %v, wanted %v", err, handlers.ForbiddenError())
+
+	got, err := activityRepo.ListUserActivity(ctx, at.GetIamUserId())
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, activity.SessionAuthorizationDenied, got[0].GetType())
+	assert.Equal(t, proj.GetPublicId(), got[0].ScopeId)
+	assert.Equal(t, tar.GetPublicId(), got[0].TargetId)
+	assert.Empty(t, got[0].SessionId)
+	assert.Equal(t, "forbidden", got[0].Detail)
+}
+
 func TestAuthorizeSession_Errors(t *testing.T) {
 	ctx := context.Background()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -2877,7 +3300,19 @@ func TestAuthorizeSession_Errors(t *testing.T) {
 	}
 	org, proj := iam.TestScopes(t, iamRepo)
 
-	s, err := targets.NewService(ctx, kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, credentialRepoFn)
+	reportRepoFn := func() (*report.Repository, error) {
+		return report.NewRepository(rw, rw)
+	}
+	aliasRepoFn := func() (*alias.Repository, error) {
+		return alias.NewRepository(rw, rw, kms)
+	}
+	labelRepoFn := func() (*label.Repository, error) {
+		return label.NewRepository(rw, rw)
+	}
+	activityRepoFn := func() (*activity.Repository, error) {
+		return activity.NewRepository(rw, rw)
+	}
+	s, err := targets.NewService(ctx, kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, credentialRepoFn, reportRepoFn, aliasRepoFn, labelRepoFn, activityRepoFn, nil)
 	require.NoError(t, err)
 
 	// Authorized user gets full permissions
@@ -2902,7 +3337,7 @@ func TestAuthorizeSession_Errors(t *testing.T) {
 	store := vault.TestCredentialStore(t, conn, wrapper, proj.GetPublicId(), v.Addr, tok, sec.Auth.Accessor)
 
 	workerExists := func(tar target.Target) (version uint32) {
-		workerService := workers.NewWorkerServiceServer(serversRepoFn, sessionRepoFn, &sync.Map{}, kms)
+		workerService := workers.NewWorkerServiceServer(serversRepoFn, sessionRepoFn, iamRepoFn, credentialRepoFn, &sync.Map{}, kms, nil)
 		_, err := workerService.Status(context.Background(), &spbs.StatusRequest{
 			Worker: &spb.Server{
 				PrivateId: "testworker",
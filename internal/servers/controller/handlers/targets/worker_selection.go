@@ -0,0 +1,90 @@
+package targets
+
+import (
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/target"
+)
+
+const workerSelectionStrategyError = `Must be one of "random", "least-connections", "lowest-latency" or "user-affinity".`
+
+// orderWorkers returns workers ordered by the worker selection strategy of a
+// target. The client tries the workers in the returned order, moving to the
+// next one when it can't connect. tags holds the tags of each worker keyed by
+// its private id; it is only read by the lowest-latency strategy. userId is
+// only read by the user-affinity strategy. An empty or unknown strategy
+// leaves workers as they are.
+func orderWorkers(strategy, userId string, workers []*servers.Server, tags map[string]map[string][]string) []*servers.Server {
+	ordered := make([]*servers.Server, len(workers))
+	copy(ordered, workers)
+
+	switch strategy {
+	case target.WorkerSelectionRandom:
+		rand.Shuffle(len(ordered), func(i, j int) {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		})
+
+	case target.WorkerSelectionLeastConnections:
+		// Shuffle first so that sessions are spread between the workers with
+		// the same number of connections, as the counts are only updated when
+		// the workers report their status.
+		rand.Shuffle(len(ordered), func(i, j int) {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		})
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return ordered[i].GetActiveConnectionCount() < ordered[j].GetActiveConnectionCount()
+		})
+
+	case target.WorkerSelectionLowestLatency:
+		latencies := make(map[string]time.Duration, len(ordered))
+		for _, w := range ordered {
+			latencies[w.GetPrivateId()] = workerLatency(tags[w.GetPrivateId()])
+		}
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return latencies[ordered[i].GetPrivateId()] < latencies[ordered[j].GetPrivateId()]
+		})
+
+	case target.WorkerSelectionUserAffinity:
+		// Rendezvous hashing: each user prefers the worker with the highest
+		// score, and only the users of a worker which goes away move.
+		scores := make(map[string]uint64, len(ordered))
+		for _, w := range ordered {
+			h := fnv.New64a()
+			h.Write([]byte(userId))
+			h.Write([]byte{0})
+			h.Write([]byte(w.GetPrivateId()))
+			scores[w.GetPrivateId()] = h.Sum64()
+		}
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return scores[ordered[i].GetPrivateId()] > scores[ordered[j].GetPrivateId()]
+		})
+	}
+	return ordered
+}
+
+// workerLatency returns the latency a worker advertises in its
+// target.WorkerLatencyTagKey tag. Workers without a valid tag sort after all
+// others.
+func workerLatency(tags map[string][]string) time.Duration {
+	latency := time.Duration(math.MaxInt64)
+	for _, v := range tags[target.WorkerLatencyTagKey] {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			ms, err := strconv.ParseUint(v, 10, 32)
+			if err != nil {
+				continue
+			}
+			d = time.Duration(ms) * time.Millisecond
+		}
+		if d >= 0 && d < latency {
+			latency = d
+		}
+	}
+	return latency
+}
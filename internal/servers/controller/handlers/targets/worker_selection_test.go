@@ -0,0 +1,60 @@
+package targets
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderWorkers(t *testing.T) {
+	newWorker := func(id string, conns uint32) *servers.Server {
+		return &servers.Server{PrivateId: id, Address: id + ":9202", ActiveConnectionCount: conns}
+	}
+	w1, w2, w3 := newWorker("w1", 5), newWorker("w2", 0), newWorker("w3", 2)
+	workers := []*servers.Server{w1, w2, w3}
+	ids := func(in []*servers.Server) []string {
+		var out []string
+		for _, w := range in {
+			out = append(out, w.PrivateId)
+		}
+		return out
+	}
+
+	t.Run("unset", func(t *testing.T) {
+		assert.Equal(t, []string{"w1", "w2", "w3"}, ids(orderWorkers("", "u_1", workers, nil)))
+	})
+	t.Run("random", func(t *testing.T) {
+		got := orderWorkers(target.WorkerSelectionRandom, "u_1", workers, nil)
+		assert.ElementsMatch(t, []string{"w1", "w2", "w3"}, ids(got))
+		// The input is not reordered
+		assert.Equal(t, []string{"w1", "w2", "w3"}, ids(workers))
+	})
+	t.Run("least-connections", func(t *testing.T) {
+		assert.Equal(t, []string{"w2", "w3", "w1"}, ids(orderWorkers(target.WorkerSelectionLeastConnections, "u_1", workers, nil)))
+	})
+	t.Run("lowest-latency", func(t *testing.T) {
+		tags := map[string]map[string][]string{
+			"w1": {target.WorkerLatencyTagKey: {"40ms"}},
+			"w2": {"region": {"us-east-1"}},
+			"w3": {target.WorkerLatencyTagKey: {"15"}},
+		}
+		assert.Equal(t, []string{"w3", "w1", "w2"}, ids(orderWorkers(target.WorkerSelectionLowestLatency, "u_1", workers, tags)))
+	})
+	t.Run("user-affinity", func(t *testing.T) {
+		first := orderWorkers(target.WorkerSelectionUserAffinity, "u_1", workers, nil)
+		assert.ElementsMatch(t, []string{"w1", "w2", "w3"}, ids(first))
+		// The order for a user does not depend on the order of the input
+		reversed := []*servers.Server{w3, w2, w1}
+		assert.Equal(t, ids(first), ids(orderWorkers(target.WorkerSelectionUserAffinity, "u_1", reversed, nil)))
+		// A worker going away does not move the user if it was not preferred
+		var remaining []*servers.Server
+		for _, w := range workers {
+			if w.PrivateId != first[len(first)-1].PrivateId {
+				remaining = append(remaining, w)
+			}
+		}
+		assert.Equal(t, first[0].PrivateId, orderWorkers(target.WorkerSelectionUserAffinity, "u_1", remaining, nil)[0].PrivateId)
+	})
+}
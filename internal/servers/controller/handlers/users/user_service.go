@@ -5,24 +5,32 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/activity"
+	"github.com/hashicorp/boundary/internal/auth/cert"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/iam/store"
 	"github.com/hashicorp/boundary/internal/intglobals"
+	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/servers/controller/auth"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authtokens"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/sessions"
+	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/users"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -39,6 +47,9 @@ var (
 		action.AddAccounts,
 		action.SetAccounts,
 		action.RemoveAccounts,
+		action.RevokeTokens,
+		action.ReadActivity,
+		action.CancelSessions,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -60,16 +71,30 @@ func init() {
 type Service struct {
 	pbs.UnimplementedUserServiceServer
 
-	repoFn common.IamRepoFactory
+	repoFn         common.IamRepoFactory
+	atRepoFn       common.AuthTokenRepoFactory
+	sessionRepoFn  common.SessionRepoFactory
+	terminations   *session.TerminationBroadcaster
+	activityRepoFn common.ActivityRepoFactory
 }
 
-// NewService returns a user service which handles user related requests to boundary.
-func NewService(repo common.IamRepoFactory) (Service, error) {
+// NewService returns a user service which handles user related requests to
+// boundary. The auth token and session repositories and the termination
+// broadcaster are only used to revoke the auth tokens and to cancel the
+// sessions of users. The activity
+// repository is only used to read the activity history of users.
+func NewService(repo common.IamRepoFactory, atRepoFn common.AuthTokenRepoFactory, sessionRepoFn common.SessionRepoFactory, terminations *session.TerminationBroadcaster, activityRepoFn common.ActivityRepoFactory) (Service, error) {
 	const op = "users.NewService"
 	if repo == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
 	}
-	return Service{repoFn: repo}, nil
+	return Service{
+		repoFn:         repo,
+		atRepoFn:       atRepoFn,
+		sessionRepoFn:  sessionRepoFn,
+		terminations:   terminations,
+		activityRepoFn: activityRepoFn,
+	}, nil
 }
 
 var _ pbs.UserServiceServer = Service{}
@@ -239,6 +264,11 @@ func (s Service) UpdateUser(ctx context.Context, req *pbs.UpdateUserRequest) (*p
 	if err != nil {
 		return nil, err
 	}
+	if u.GetDisabled() && handlers.MaskContains(req.GetUpdateMask().GetPaths(), globals.DisabledField) {
+		if err := s.revokeDisabled(ctx, u.GetPublicId()); err != nil {
+			return nil, err
+		}
+	}
 
 	outputFields, ok := requests.OutputFields(ctx)
 	if !ok {
@@ -392,6 +422,111 @@ func (s Service) RemoveUserAccounts(ctx context.Context, req *pbs.RemoveUserAcco
 	return &pbs.RemoveUserAccountsResponse{Item: item}, nil
 }
 
+// RevokeUserTokens implements the interface pbs.UserServiceServer.
+func (s Service) RevokeUserTokens(ctx context.Context, req *pbs.RevokeUserTokensRequest) (*pbs.RevokeUserTokensResponse, error) {
+	if err := validateRevokeUserTokensRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RevokeTokens)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	count, sessionIds, err := authtokens.Revoke(ctx, s.atRepoFn, s.sessionRepoFn, s.terminations, req.GetCancelSessions(),
+		func(ctx context.Context, repo *authtoken.Repository) ([]*authtoken.AuthToken, error) {
+			return repo.RevokeAuthTokensForUser(ctx, req.GetId())
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.RevokeUserTokensResponse{RevokedTokenCount: count, CanceledSessionIds: sessionIds}, nil
+}
+
+// ReadUserActivity implements the interface pbs.UserServiceServer.
+func (s Service) ReadUserActivity(ctx context.Context, req *pbs.ReadUserActivityRequest) (*pbs.ReadUserActivityResponse, error) {
+	const op = "users.(Service).ReadUserActivity"
+	if err := validateReadUserActivityRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ReadActivity)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+
+	var opts []activity.Option
+	if req.GetScopeId() != "" && req.GetScopeId() != scope.Global.String() {
+		iamRepo, err := s.repoFn()
+		if err != nil {
+			return nil, err
+		}
+		scps, err := iamRepo.ListScopesRecursively(ctx, req.GetScopeId())
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if len(scps) == 0 {
+			return nil, handlers.NotFoundErrorf("Scope %q doesn't exist.", req.GetScopeId())
+		}
+		scopeIds := make([]string, 0, len(scps))
+		for _, scp := range scps {
+			scopeIds = append(scopeIds, scp.GetPublicId())
+		}
+		opts = append(opts, activity.WithScopeIds(scopeIds))
+	}
+	if req.GetStartTime() != nil {
+		opts = append(opts, activity.WithStartTime(req.GetStartTime().AsTime()))
+	}
+	if req.GetEndTime() != nil {
+		opts = append(opts, activity.WithEndTime(req.GetEndTime().AsTime()))
+	}
+	if req.GetLimit() > 0 {
+		opts = append(opts, activity.WithLimit(int(req.GetLimit())))
+	}
+
+	repo, err := s.activityRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	activities, err := repo.ListUserActivity(ctx, req.GetId(), opts...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	items := make([]*pb.UserActivity, 0, len(activities))
+	for _, a := range activities {
+		items = append(items, toActivityProto(a))
+	}
+	return &pbs.ReadUserActivityResponse{Items: items}, nil
+}
+
+// CancelUserSessions implements the interface pbs.UserServiceServer.
+func (s Service) CancelUserSessions(ctx context.Context, req *pbs.CancelUserSessionsRequest) (*pbs.CancelUserSessionsResponse, error) {
+	if err := validateCancelUserSessionsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.CancelSessions)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	sessionIds, err := sessions.CancelMatching(ctx, s.sessionRepoFn, s.terminations, req.GetFilter(), req.GetReason(), session.WithUserId(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.CancelUserSessionsResponse{CanceledSessionCount: uint32(len(sessionIds)), CanceledSessionIds: sessionIds}, nil
+}
+
+// revokeDisabled revokes the auth tokens and cancels the sessions of the
+// disabled user userId.
+func (s Service) revokeDisabled(ctx context.Context, userId string) error {
+	const op = "users.(Service).revokeDisabled"
+	count, sessionIds, err := authtokens.RevokeDisabled(ctx, s.atRepoFn, s.sessionRepoFn, s.terminations, []string{userId},
+		func(ctx context.Context, repo *authtoken.Repository) ([]*authtoken.AuthToken, error) {
+			return repo.RevokeAuthTokensForUser(ctx, userId)
+		})
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("user was disabled but its auth tokens could not be revoked"))
+	}
+	event.WriteSysEvent(ctx, op, "user disabled", "user_id", userId, "revoked_token_count", count, "canceled_session_ids", sessionIds)
+	return nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*iam.User, []string, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -423,6 +558,7 @@ func (s Service) createInRepo(ctx context.Context, orgId string, item *pb.User)
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build user for creation: %v.", err)
 	}
+	u.Disabled = item.GetDisabled()
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -452,6 +588,7 @@ func (s Service) updateInRepo(ctx context.Context, orgId, id string, mask []stri
 		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build user for update: %v.", err)
 	}
 	u.PublicId = id
+	u.Disabled = item.GetDisabled()
 	dbMask := maskManager.Translate(mask)
 	if len(dbMask) == 0 {
 		return nil, nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
@@ -641,6 +778,9 @@ func toProto(ctx context.Context, in *iam.User, accts []string, opt ...handlers.
 	if outputFields.Has(globals.EmailField) {
 		out.Email = in.GetEmail()
 	}
+	if outputFields.Has(globals.DisabledField) {
+		out.Disabled = in.GetDisabled()
+	}
 	if outputFields.Has(globals.ScopeField) {
 		out.Scope = opts.WithScope
 	}
@@ -659,6 +799,20 @@ func toProto(ctx context.Context, in *iam.User, accts []string, opt ...handlers.
 	return &out, nil
 }
 
+func toActivityProto(in *activity.Activity) *pb.UserActivity {
+	return &pb.UserActivity{
+		Id:           uint64(in.Id),
+		Type:         in.ActivityType,
+		ScopeId:      in.ScopeId,
+		AuthMethodId: in.AuthMethodId,
+		AccountId:    in.AccountId,
+		TargetId:     in.TargetId,
+		SessionId:    in.SessionId,
+		Detail:       in.Detail,
+		CreatedTime:  timestamppb.New(in.CreateTime),
+	}
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
 //  * The path passed in is correctly formatted
@@ -719,6 +873,7 @@ func validateAddUserAccountsRequest(req *pbs.AddUserAccountsRequest) error {
 			intglobals.OldPasswordAccountPrefix,
 			intglobals.NewPasswordAccountPrefix,
 			oidc.AccountPrefix,
+			cert.AccountPrefix,
 		) {
 			badFields["account_ids"] = "Values must be valid account ids."
 			break
@@ -744,6 +899,7 @@ func validateSetUserAccountsRequest(req *pbs.SetUserAccountsRequest) error {
 			intglobals.OldPasswordAccountPrefix,
 			intglobals.NewPasswordAccountPrefix,
 			oidc.AccountPrefix,
+			cert.AccountPrefix,
 		) {
 			badFields["account_ids"] = "Values must be valid account ids."
 			break
@@ -772,6 +928,7 @@ func validateRemoveUserAccountsRequest(req *pbs.RemoveUserAccountsRequest) error
 			intglobals.OldPasswordAccountPrefix,
 			intglobals.NewPasswordAccountPrefix,
 			oidc.AccountPrefix,
+			cert.AccountPrefix,
 		) {
 			badFields["account_ids"] = "Values must be valid account ids."
 			break
@@ -782,3 +939,45 @@ func validateRemoveUserAccountsRequest(req *pbs.RemoveUserAccountsRequest) error
 	}
 	return nil
 }
+
+func validateRevokeUserTokensRequest(req *pbs.RevokeUserTokensRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), iam.UserPrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateReadUserActivityRequest(req *pbs.ReadUserActivityRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), iam.UserPrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetScopeId() != "" && req.GetScopeId() != scope.Global.String() &&
+		!handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix(), scope.Project.Prefix()) {
+		badFields["scope_id"] = "Improperly formatted field."
+	}
+	if req.GetStartTime() != nil && req.GetEndTime() != nil &&
+		!req.GetStartTime().AsTime().Before(req.GetEndTime().AsTime()) {
+		badFields["end_time"] = "This field must be after the start time."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateCancelUserSessionsRequest(req *pbs.CancelUserSessionsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), iam.UserPrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	sessions.ValidateCancelMatching(badFields, req.GetFilter(), req.GetReason())
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
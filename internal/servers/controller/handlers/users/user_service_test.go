@@ -7,10 +7,14 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/activity"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
+	pwstore "github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/db"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
@@ -18,6 +22,7 @@ import (
 	"github.com/hashicorp/boundary/internal/servers/controller/auth"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/users"
+	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/users"
@@ -32,7 +37,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts", "revoke-tokens", "read-activity"}
 
 func createDefaultUserAndRepo(t *testing.T, withAccts bool) (*iam.User, []string, func() (*iam.Repository, error)) {
 	t.Helper()
@@ -139,7 +144,7 @@ func TestGet(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetUserRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := users.NewService(repoFn)
+			s, err := users.NewService(repoFn, nil, nil, nil, nil)
 			require.NoError(err, "Couldn't create new user service.")
 
 			got, gErr := s.GetUser(auth.DisabledAuthTestContext(repoFn, u.GetScopeId()), req)
@@ -178,7 +183,7 @@ func TestList(t *testing.T) {
 	secondaryAm := password.TestAuthMethods(t, conn, oWithUsers.PublicId, 1)
 	require.Len(t, secondaryAm, 1)
 
-	s, err := users.NewService(repoFn)
+	s, err := users.NewService(repoFn, nil, nil, nil, nil)
 	require.NoError(t, err)
 
 	var wantUsers []*pb.User
@@ -327,7 +332,7 @@ func (s sortableUsers) Swap(i, j int)      { s.users[i], s.users[j] = s.users[j]
 func TestDelete(t *testing.T) {
 	u, _, repoFn := createDefaultUserAndRepo(t, false)
 
-	s, err := users.NewService(repoFn)
+	s, err := users.NewService(repoFn, nil, nil, nil, nil)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	cases := []struct {
@@ -374,7 +379,7 @@ func TestDelete_twice(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 	u, _, repoFn := createDefaultUserAndRepo(t, false)
 
-	s, err := users.NewService(repoFn)
+	s, err := users.NewService(repoFn, nil, nil, nil, nil)
 	require.NoError(err, "Error when getting new user service")
 	req := &pbs.DeleteUserRequest{
 		Id: u.GetPublicId(),
@@ -466,7 +471,7 @@ func TestCreate(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := users.NewService(repoFn)
+			s, err := users.NewService(repoFn, nil, nil, nil, nil)
 			require.NoError(err, "Error when getting new user service.")
 
 			got, gErr := s.CreateUser(auth.DisabledAuthTestContext(repoFn, tc.req.GetItem().GetScopeId()), tc.req)
@@ -495,7 +500,7 @@ func TestCreate(t *testing.T) {
 
 func TestUpdate(t *testing.T) {
 	u, _, repoFn := createDefaultUserAndRepo(t, false)
-	tested, err := users.NewService(repoFn)
+	tested, err := users.NewService(repoFn, nil, nil, nil, nil)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	created := u.GetCreateTime().GetTimestamp().AsTime()
@@ -771,7 +776,7 @@ func TestAddAccount(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	s, err := users.NewService(repoFn)
+	s, err := users.NewService(repoFn, nil, nil, nil, nil)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	o, _ := iam.TestScopes(t, iamRepo)
@@ -916,7 +921,7 @@ func TestSetAccount(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	s, err := users.NewService(repoFn)
+	s, err := users.NewService(repoFn, nil, nil, nil, nil)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	o, _ := iam.TestScopes(t, iamRepo)
@@ -1063,7 +1068,7 @@ func TestRemoveAccount(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	s, err := users.NewService(repoFn)
+	s, err := users.NewService(repoFn, nil, nil, nil, nil)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	o, _ := iam.TestScopes(t, iamRepo)
@@ -1216,3 +1221,269 @@ func TestRemoveAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestRevokeTokens(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	kmsCache := kms.TestKms(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kmsCache)
+	}
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	at1 := authtoken.TestAuthToken(t, conn, kmsCache, o.GetPublicId())
+	at2 := authtoken.TestAuthToken(t, conn, kmsCache, o.GetPublicId())
+
+	s, err := users.NewService(repoFn, atRepoFn, sessionRepoFn, nil, nil)
+	require.NoError(t, err, "Error when getting new user service.")
+
+	cases := []struct {
+		name string
+		req  *pbs.RevokeUserTokensRequest
+		res  *pbs.RevokeUserTokensResponse
+		err  error
+	}{
+		{
+			name: "Revoke tokens of a user",
+			req:  &pbs.RevokeUserTokensRequest{Id: at1.GetIamUserId(), CancelSessions: true},
+			res:  &pbs.RevokeUserTokensResponse{RevokedTokenCount: 1},
+		},
+		{
+			name: "Revoke again",
+			req:  &pbs.RevokeUserTokensRequest{Id: at1.GetIamUserId()},
+			res:  &pbs.RevokeUserTokensResponse{},
+		},
+		{
+			name: "Bad user id formatting",
+			req:  &pbs.RevokeUserTokensRequest{Id: "bad_format"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.RevokeUserTokens(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "RevokeUserTokens(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(tc.res, got, protocmp.Transform()))
+		})
+	}
+
+	atRepo, err := atRepoFn()
+	require.NoError(t, err)
+	got, err := atRepo.LookupAuthToken(ctx, at1.GetPublicId())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	got, err = atRepo.LookupAuthToken(ctx, at2.GetPublicId())
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+}
+
+func TestCancelSessions(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	kmsCache := kms.TestKms(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kmsCache)
+	}
+
+	s1 := session.TestDefaultSession(t, conn, wrap, iamRepo)
+	other := session.TestDefaultSession(t, conn, wrap, iamRepo)
+
+	s, err := users.NewService(repoFn, nil, sessionRepoFn, nil, nil)
+	require.NoError(t, err, "Error when getting new user service.")
+
+	cases := []struct {
+		name string
+		req  *pbs.CancelUserSessionsRequest
+		res  *pbs.CancelUserSessionsResponse
+		err  error
+	}{
+		{
+			name: "Filter matches no sessions",
+			req:  &pbs.CancelUserSessionsRequest{Id: s1.UserId, Filter: `"/item/target_id" == "ttcp_1234567890"`},
+			res:  &pbs.CancelUserSessionsResponse{},
+		},
+		{
+			name: "Cancel sessions of a user",
+			req:  &pbs.CancelUserSessionsRequest{Id: s1.UserId, Filter: fmt.Sprintf(`"/item/target_id" == %q`, s1.TargetId), Reason: "incident"},
+			res:  &pbs.CancelUserSessionsResponse{CanceledSessionCount: 1, CanceledSessionIds: []string{s1.PublicId}},
+		},
+		{
+			name: "Cancel again",
+			req:  &pbs.CancelUserSessionsRequest{Id: s1.UserId},
+			res:  &pbs.CancelUserSessionsResponse{},
+		},
+		{
+			name: "Bad user id formatting",
+			req:  &pbs.CancelUserSessionsRequest{Id: "bad_format"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Bad filter",
+			req:  &pbs.CancelUserSessionsRequest{Id: s1.UserId, Filter: `//badformat/`},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.CancelUserSessions(auth.DisabledAuthTestContext(repoFn, s1.ScopeId), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "CancelUserSessions(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(tc.res, got, protocmp.Transform()))
+		})
+	}
+
+	sessionRepo, err := sessionRepoFn()
+	require.NoError(t, err)
+	got, _, err := sessionRepo.LookupSession(ctx, s1.PublicId)
+	require.NoError(t, err)
+	assert.Equal(t, session.StatusCanceling, got.States[0].Status)
+	assert.Equal(t, "incident", got.CancelReason)
+	got, _, err = sessionRepo.LookupSession(ctx, other.PublicId)
+	require.NoError(t, err)
+	assert.Equal(t, session.StatusPending, got.States[0].Status)
+}
+
+func TestReadActivity(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	kmsCache := kms.TestKms(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	activityRepoFn := func() (*activity.Repository, error) {
+		return activity.NewRepository(rw, rw)
+	}
+
+	o, p := iam.TestScopes(t, iamRepo)
+	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	pwRepo, err := password.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	acct, err := pwRepo.CreateAccount(ctx, o.GetPublicId(), &password.Account{
+		Account: &pwstore.Account{
+			AuthMethodId: am.GetPublicId(),
+			LoginName:    "alice",
+		},
+	}, password.WithPassword("12345678"))
+	require.NoError(t, err)
+	u := iam.TestUser(t, iamRepo, o.GetPublicId(), iam.WithAccountIds(acct.GetPublicId()))
+
+	_, err = pwRepo.Authenticate(ctx, o.GetPublicId(), am.GetPublicId(), "alice", "wrong-password")
+	require.NoError(t, err)
+	_, err = pwRepo.Authenticate(ctx, o.GetPublicId(), am.GetPublicId(), "alice", "12345678")
+	require.NoError(t, err)
+
+	s, err := users.NewService(repoFn, nil, nil, nil, activityRepoFn)
+	require.NoError(t, err, "Error when getting new user service.")
+
+	succeeded := &pb.UserActivity{
+		Type:         "authentication-succeeded",
+		ScopeId:      o.GetPublicId(),
+		AuthMethodId: am.GetPublicId(),
+		AccountId:    acct.GetPublicId(),
+	}
+	failed := &pb.UserActivity{
+		Type:         "authentication-failed",
+		ScopeId:      o.GetPublicId(),
+		AuthMethodId: am.GetPublicId(),
+		AccountId:    acct.GetPublicId(),
+		Detail:       "invalid password",
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.ReadUserActivityRequest
+		res  []*pb.UserActivity
+		err  error
+	}{
+		{
+			name: "Read all",
+			req:  &pbs.ReadUserActivityRequest{Id: u.GetPublicId()},
+			res:  []*pb.UserActivity{succeeded, failed},
+		},
+		{
+			name: "With limit",
+			req:  &pbs.ReadUserActivityRequest{Id: u.GetPublicId(), Limit: 1},
+			res:  []*pb.UserActivity{succeeded},
+		},
+		{
+			name: "In org scope",
+			req:  &pbs.ReadUserActivityRequest{Id: u.GetPublicId(), ScopeId: o.GetPublicId()},
+			res:  []*pb.UserActivity{succeeded, failed},
+		},
+		{
+			name: "In project scope",
+			req:  &pbs.ReadUserActivityRequest{Id: u.GetPublicId(), ScopeId: p.GetPublicId()},
+		},
+		{
+			name: "Before start time",
+			req:  &pbs.ReadUserActivityRequest{Id: u.GetPublicId(), EndTime: timestamppb.New(time.Now().Add(-time.Hour))},
+		},
+		{
+			name: "Bad user id formatting",
+			req:  &pbs.ReadUserActivityRequest{Id: "bad_format"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Bad scope id formatting",
+			req:  &pbs.ReadUserActivityRequest{Id: u.GetPublicId(), ScopeId: "bad_format"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "End time before start time",
+			req: &pbs.ReadUserActivityRequest{
+				Id:        u.GetPublicId(),
+				StartTime: timestamppb.Now(),
+				EndTime:   timestamppb.New(time.Now().Add(-time.Hour)),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.ReadUserActivity(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "ReadUserActivity(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			require.Len(got.GetItems(), len(tc.res))
+			for i, item := range got.GetItems() {
+				assert.NotZero(item.GetId())
+				assert.NotNil(item.GetCreatedTime())
+				item.Id, item.CreatedTime = 0, nil
+				assert.Empty(cmp.Diff(tc.res[i], item, protocmp.Transform()))
+			}
+		})
+	}
+}
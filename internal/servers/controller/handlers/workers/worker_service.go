@@ -2,45 +2,61 @@ package workers
 
 import (
 	"context"
-	"errors"
+	stderrors "errors"
 	"fmt"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	targetshandler "github.com/hashicorp/boundary/internal/servers/controller/handlers/targets"
 	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
 	"github.com/hashicorp/go-bexpr"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 type workerServiceServer struct {
 	pbs.UnimplementedServerCoordinationServiceServer
 	pbs.UnimplementedSessionServiceServer
 
-	serversRepoFn common.ServersRepoFactory
-	sessionRepoFn common.SessionRepoFactory
-	updateTimes   *sync.Map
-	kms           *kms.Kms
+	serversRepoFn         common.ServersRepoFactory
+	sessionRepoFn         common.SessionRepoFactory
+	iamRepoFn             common.IamRepoFactory
+	vaultCredentialRepoFn common.VaultCredentialRepoFactory
+	updateTimes           *sync.Map
+	kms                   *kms.Kms
+	terminations          *session.TerminationBroadcaster
 }
 
+// NewWorkerServiceServer creates the service workers use to coordinate with the
+// controller. Sessions published to terminations are streamed to the workers
+// handling them; terminations may be nil.
 func NewWorkerServiceServer(
 	serversRepoFn common.ServersRepoFactory,
 	sessionRepoFn common.SessionRepoFactory,
+	iamRepoFn common.IamRepoFactory,
+	vaultCredentialRepoFn common.VaultCredentialRepoFactory,
 	updateTimes *sync.Map,
-	kms *kms.Kms) *workerServiceServer {
+	kms *kms.Kms,
+	terminations *session.TerminationBroadcaster) *workerServiceServer {
 	return &workerServiceServer{
-		serversRepoFn: serversRepoFn,
-		sessionRepoFn: sessionRepoFn,
-		updateTimes:   updateTimes,
-		kms:           kms,
+		serversRepoFn:         serversRepoFn,
+		sessionRepoFn:         sessionRepoFn,
+		iamRepoFn:             iamRepoFn,
+		vaultCredentialRepoFn: vaultCredentialRepoFn,
+		updateTimes:           updateTimes,
+		kms:                   kms,
+		terminations:          terminations,
 	}
 }
 
@@ -65,6 +81,7 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 		return &pbs.StatusResponse{}, status.Errorf(codes.Internal, "Error acquiring repo to query session status: %v", err)
 	}
 	req.Worker.Type = resource.Worker.String()
+	req.Worker.ActiveConnectionCount = activeConnectionCount(req.GetJobs())
 	controllers, _, err := serverRepo.UpsertServer(ctx, req.Worker, servers.WithUpdateTags(req.GetUpdateTags()))
 	if err != nil {
 		event.WriteError(ctx, op, err, event.WithInfoMsg("error storing worker status"))
@@ -149,8 +166,9 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 							Type: pbs.JOBTYPE_JOBTYPE_SESSION,
 							JobInfo: &pbs.Job_SessionInfo{
 								SessionInfo: &pbs.SessionJobInfo{
-									SessionId: sessionId,
-									Status:    currState.ProtoVal(),
+									SessionId:    sessionId,
+									Status:       currState.ProtoVal(),
+									CancelReason: sessionInfo.CancelReason,
 								},
 							},
 						},
@@ -213,6 +231,26 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 	return ret, nil
 }
 
+// activeConnectionCount returns the number of authorized or connected
+// connections in the session jobs a worker reported. It is stored with the
+// worker so that sessions can be balanced between workers.
+func activeConnectionCount(jobs []*pbs.JobStatus) uint32 {
+	var count uint32
+	for _, jobStatus := range jobs {
+		if jobStatus.GetJob().GetType() != pbs.JOBTYPE_JOBTYPE_SESSION {
+			continue
+		}
+		for _, conn := range jobStatus.GetJob().GetSessionInfo().GetConnections() {
+			switch conn.GetStatus() {
+			case pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_AUTHORIZED,
+				pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CONNECTED:
+				count++
+			}
+		}
+	}
+	return count
+}
+
 func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.LookupSessionRequest) (*pbs.LookupSessionResponse, error) {
 	const op = "workers.(workerServiceServer).LookupSession"
 
@@ -234,7 +272,7 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 
 	if sessionInfo.WorkerFilter != "" {
 		if req.ServerId == "" {
-			event.WriteError(ctx, op, errors.New("worker filter enabled for session but got no server ID from worker"))
+			event.WriteError(ctx, op, stderrors.New("worker filter enabled for session but got no server ID from worker"))
 			return &pbs.LookupSessionResponse{}, status.Errorf(codes.Internal, "Did not receive server ID when looking up session but filtering is enabled: %v", err)
 		}
 		serversRepo, err := ws.serversRepoFn()
@@ -293,11 +331,24 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 		HostSetId:       sessionInfo.HostSetId,
 		TargetId:        sessionInfo.TargetId,
 		UserId:          sessionInfo.UserId,
+
+		ConnectionIdleTimeoutSeconds: sessionInfo.ConnectionIdleTimeoutSeconds,
+		MaxConcurrentConnections:     sessionInfo.MaxConcurrentConnections,
+		MaxBytesPerSession:           sessionInfo.MaxBytesPerSession,
 	}
 	if resp.ConnectionsLeft != -1 {
 		resp.ConnectionsLeft -= int32(authzSummary.CurrentConnectionCount)
 	}
 
+	if err := ws.lookupSessionUser(ctx, sessionInfo, resp); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error looking up session user", "session_id", sessionInfo.GetPublicId()))
+		return nil, status.Errorf(codes.Internal, "Error looking up session user: %v", err)
+	}
+	if err := ws.issueEgressCredentials(ctx, sessionInfo, resp); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error issuing egress credentials", "session_id", sessionInfo.GetPublicId()))
+		return nil, err
+	}
+
 	wrapper, err := ws.kms.GetWrapper(ctx, sessionInfo.ScopeId, kms.KeyPurposeSessions, kms.WithKeyId(sessionInfo.KeyId))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Error getting sessions wrapper: %v", err)
@@ -313,6 +364,167 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 	return resp, nil
 }
 
+// lookupSessionUser populates the name of the session's user and the ids of
+// the managed groups the user is a member of, which workers use to identify the
+// user to the endpoint.
+func (ws *workerServiceServer) lookupSessionUser(ctx context.Context, sessionInfo *session.Session, resp *pbs.LookupSessionResponse) error {
+	if sessionInfo.UserId == "" {
+		return nil
+	}
+	iamRepo, err := ws.iamRepoFn()
+	if err != nil {
+		return err
+	}
+	u, _, err := iamRepo.LookupUser(ctx, sessionInfo.UserId)
+	if err != nil {
+		return err
+	}
+	if u != nil {
+		resp.UserName = u.GetName()
+	}
+	resp.ManagedGroupIds, err = iamRepo.ListUserManagedGroups(ctx, sessionInfo.UserId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// issueEgressCredentials adds the session's egress credentials to the
+// response. Dynamic credentials can only be issued once per session, so the
+// first lookup issues them and stores them with the session, and later lookups,
+// for other connections or from other workers, are given the stored
+// credentials.
+func (ws *workerServiceServer) issueEgressCredentials(ctx context.Context, sessionInfo *session.Session, resp *pbs.LookupSessionResponse) error {
+	const op = "workers.(workerServiceServer).issueEgressCredentials"
+	var reqs []credential.Request
+	var issued bool
+	for _, c := range sessionInfo.DynamicCredentials {
+		if credential.Purpose(c.CredentialPurpose) != credential.EgressPurpose {
+			continue
+		}
+		if c.CredentialId != "" {
+			issued = true
+			continue
+		}
+		reqs = append(reqs, credential.Request{
+			SourceId: c.LibraryId,
+			Purpose:  credential.EgressPurpose,
+		})
+	}
+	if len(reqs) == 0 && !issued {
+		return nil
+	}
+
+	sessRepo, err := ws.sessionRepoFn()
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error getting session repo: %v", err)
+	}
+	found, err := ws.lookupEgressCredentials(ctx, sessRepo, sessionInfo, resp)
+	switch {
+	case err != nil:
+		return err
+	case found:
+		return nil
+	case len(reqs) == 0:
+		// Another lookup issued the credentials but has not stored them yet.
+		return status.Error(codes.Unavailable, "Egress credentials for this session are being issued.")
+	}
+
+	credRepo, err := ws.vaultCredentialRepoFn()
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error getting credential repo: %v", err)
+	}
+	cs, err := credRepo.Issue(ctx, sessionInfo.GetPublicId(), reqs)
+	if err != nil {
+		// A concurrent lookup may have issued the credentials first.
+		if found, lookupErr := ws.lookupEgressCredentials(ctx, sessRepo, sessionInfo, resp); lookupErr == nil && found {
+			return nil
+		}
+		return status.Errorf(codes.Internal, "Error issuing egress credentials: %v", err)
+	}
+	resp.EgressCredentials, err = targetshandler.DynamicCredentialsToProto(ctx, cs)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error converting egress credentials: %v", err)
+	}
+	stored, err := proto.Marshal(&pbs.LookupSessionResponse{EgressCredentials: resp.EgressCredentials})
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error marshaling egress credentials: %v", err)
+	}
+	if err := sessRepo.AddEgressCredentials(ctx, sessionInfo.GetPublicId(), sessionInfo.ScopeId, stored); err != nil {
+		// Credentials which are not stored in Vault's lease tables, such as
+		// signed SSH certificates, can be issued by concurrent lookups. Hand
+		// out the ones which were stored first.
+		if errors.Match(errors.T(errors.NotUnique), err) {
+			if _, err := ws.lookupEgressCredentials(ctx, sessRepo, sessionInfo, resp); err != nil {
+				return err
+			}
+			return nil
+		}
+		// Release the issued credentials so the next lookup can issue them
+		// again instead of waiting for credentials which will never be
+		// stored.
+		if releaseErr := credRepo.Release(ctx, sessionInfo.GetPublicId(), credential.EgressPurpose); releaseErr != nil {
+			event.WriteError(ctx, op, releaseErr, event.WithInfoMsg("error releasing egress credentials", "session_id", sessionInfo.GetPublicId()))
+		}
+		return status.Errorf(codes.Internal, "Error storing egress credentials: %v", err)
+	}
+	return nil
+}
+
+// lookupEgressCredentials adds the egress credentials stored for the session
+// to the response and reports whether there were any.
+func (ws *workerServiceServer) lookupEgressCredentials(ctx context.Context, sessRepo *session.Repository, sessionInfo *session.Session, resp *pbs.LookupSessionResponse) (bool, error) {
+	stored, err := sessRepo.LookupEgressCredentials(ctx, sessionInfo.GetPublicId(), sessionInfo.ScopeId)
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "Error looking up egress credentials: %v", err)
+	}
+	if stored == nil {
+		return false, nil
+	}
+	var creds pbs.LookupSessionResponse
+	if err := proto.Unmarshal(stored, &creds); err != nil {
+		return false, status.Errorf(codes.Internal, "Error unmarshaling egress credentials: %v", err)
+	}
+	resp.EgressCredentials = creds.GetEgressCredentials()
+	return true, nil
+}
+
+// SessionTerminations streams the canceled sessions which are handled by the
+// requesting worker until the stream's context is done. Sessions canceled on
+// this controller are sent right away and sessions canceled on other
+// controllers once the controller polls the database for them. Workers still
+// learn of every cancellation in their status responses.
+func (ws *workerServiceServer) SessionTerminations(req *pbs.SessionTerminationsRequest, stream pbs.ServerCoordinationService_SessionTerminationsServer) error {
+	const op = "workers.(workerServiceServer).SessionTerminations"
+	if req.GetWorkerId() == "" {
+		return status.Error(codes.InvalidArgument, "Missing worker id.")
+	}
+	if ws.terminations == nil {
+		return status.Error(codes.Unimplemented, "Session terminations are not available on this controller.")
+	}
+
+	ctx := stream.Context()
+	terms, unsubscribe := ws.terminations.Subscribe()
+	defer unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case t := <-terms:
+			if t.ServerId != "" && t.ServerId != req.GetWorkerId() {
+				continue
+			}
+			if err := stream.Send(&pbs.SessionTerminationsResponse{
+				SessionId: t.SessionId,
+				Reason:    t.Reason,
+			}); err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("error sending session termination", "worker_id", req.GetWorkerId(), "session_id", t.SessionId))
+				return err
+			}
+		}
+	}
+}
+
 func (ws *workerServiceServer) CancelSession(ctx context.Context, req *pbs.CancelSessionRequest) (*pbs.CancelSessionResponse, error) {
 	const op = "workers.(workerServiceServer).CancelSession"
 
@@ -0,0 +1,242 @@
+package workers_test
+
+import (
+	"context"
+	"path"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/credential/vault"
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/workers"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/target"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// testEgressSession creates a session of a target with a Vault PKI credential
+// library as an egress credential source. c, if not nil, can change the
+// session before it is created.
+func testEgressSession(t *testing.T, conn *db.DB, wrapper wrapping.Wrapper, kms *kms.Kms, c func(*session.ComposedOf)) (*session.Session, *vault.Repository) {
+	t.Helper()
+	ctx := context.Background()
+	rw := db.New(conn)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	require.NoError(t, vault.RegisterJobs(ctx, sche, rw, rw, kms))
+	credRepo, err := vault.NewRepository(rw, rw, kms, sche)
+	require.NoError(t, err)
+
+	v := vault.NewTestVaultServer(t)
+	v.MountPKI(t)
+	sec, tok := v.CreateToken(t, vault.WithPolicies([]string{"default", "boundary-controller", "pki"}))
+
+	params := session.TestSessionParams(t, conn, wrapper, iamRepo)
+	store := vault.TestCredentialStore(t, conn, wrapper, params.ScopeId, v.Addr, tok, sec.Auth.Accessor)
+	lib, err := vault.NewCredentialLibrary(store.GetPublicId(), path.Join("pki", "issue", "boundary"),
+		vault.WithMethod(vault.MethodPost),
+		vault.WithRequestBody([]byte(`{"common_name":"boundary.com"}`)))
+	require.NoError(t, err)
+	lib, err = credRepo.CreateCredentialLibrary(ctx, params.ScopeId, lib)
+	require.NoError(t, err)
+
+	targetRepo, err := target.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	tar, _, _, err := targetRepo.LookupTarget(ctx, params.TargetId)
+	require.NoError(t, err)
+	_, _, _, err = targetRepo.AddTargetCredentialSources(ctx, tar.GetPublicId(), tar.GetVersion(), []*target.CredentialLibrary{
+		target.TestNewCredentialLibrary(tar.GetPublicId(), lib.GetPublicId(), credential.EgressPurpose),
+	})
+	require.NoError(t, err)
+
+	params.DynamicCredentials = []*session.DynamicCredential{
+		session.NewDynamicCredential(lib.GetPublicId(), credential.EgressPurpose),
+	}
+	if c != nil {
+		c(&params)
+	}
+	return session.TestSession(t, conn, wrapper, params), credRepo
+}
+
+// testWorkerService returns a worker service which issues credentials from
+// credRepo.
+func testWorkerService(t *testing.T, conn *db.DB, kms *kms.Kms, credRepo *vault.Repository) pbs.SessionServiceServer {
+	t.Helper()
+	rw := db.New(conn)
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kms)
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.NewRepository(rw, rw, kms)
+	}
+	credentialRepoFn := func() (*vault.Repository, error) {
+		return credRepo, nil
+	}
+	return workers.NewWorkerServiceServer(serversRepoFn, sessionRepoFn, iamRepoFn, credentialRepoFn, &sync.Map{}, kms, nil)
+}
+
+func TestLookupSession_EgressCredentials(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	sess, credRepo := testEgressSession(t, conn, wrapper, kms, nil)
+	s := testWorkerService(t, conn, kms, credRepo)
+
+	// The worker looks the session up for every connection, and each lookup
+	// is given the credentials issued for the first one.
+	var first *pbs.LookupSessionResponse
+	for i := 0; i < 2; i++ {
+		resp, err := s.LookupSession(ctx, &pbs.LookupSessionRequest{
+			ServerId:  "worker1",
+			SessionId: sess.GetPublicId(),
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.GetEgressCredentials(), 1)
+		assert.NotNil(t, resp.GetEgressCredentials()[0].GetSecret())
+		if first == nil {
+			first = resp
+			continue
+		}
+		assert.Empty(t, cmp.Diff(first.GetEgressCredentials(), resp.GetEgressCredentials(), protocmp.Transform()))
+	}
+}
+
+func TestLookupSession_EgressCredentialsMultipleWorkers(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	serversRepo, err := servers.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	for _, w := range []struct {
+		name string
+		role string
+	}{
+		{"worker1", "egress"},
+		{"worker2", "egress"},
+		{"worker3", "other"},
+	} {
+		_, _, err := serversRepo.UpsertServer(ctx, &servers.Server{
+			PrivateId: w.name,
+			Type:      string(servers.ServerTypeWorker),
+			Address:   "127.0.0.1",
+			Tags: map[string]*servers.TagValues{
+				"role": {Values: []string{w.role}},
+			},
+		}, servers.WithUpdateTags(true))
+		require.NoError(t, err)
+	}
+
+	sess, credRepo := testEgressSession(t, conn, wrapper, kms, func(c *session.ComposedOf) {
+		c.WorkerFilter = `"egress" in "/tags/role"`
+	})
+	s := testWorkerService(t, conn, kms, credRepo)
+
+	// Connections of a session can be proxied by any worker the session's
+	// worker filter allows, and every one of them is given the credentials
+	// issued for the first lookup.
+	var first *pbs.LookupSessionResponse
+	for _, serverId := range []string{"worker1", "worker2", "worker1", "worker2"} {
+		resp, err := s.LookupSession(ctx, &pbs.LookupSessionRequest{
+			ServerId:  serverId,
+			SessionId: sess.GetPublicId(),
+		})
+		require.NoError(t, err, serverId)
+		require.Len(t, resp.GetEgressCredentials(), 1, serverId)
+		assert.NotNil(t, resp.GetEgressCredentials()[0].GetSecret(), serverId)
+		if first == nil {
+			first = resp
+			continue
+		}
+		assert.Empty(t, cmp.Diff(first.GetEgressCredentials(), resp.GetEgressCredentials(), protocmp.Transform()), serverId)
+	}
+
+	// A worker the filter excludes is not given the credentials.
+	resp, err := s.LookupSession(ctx, &pbs.LookupSessionRequest{
+		ServerId:  "worker3",
+		SessionId: sess.GetPublicId(),
+	})
+	assert.Error(t, err)
+	assert.Empty(t, resp.GetEgressCredentials())
+}
+
+func TestLookupSession_EgressCredentialsStoreFailure(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	sess, credRepo := testEgressSession(t, conn, wrapper, kms, nil)
+	s := testWorkerService(t, conn, kms, credRepo)
+
+	// Make storing the issued credentials fail.
+	_, err := rw.Exec(ctx, `
+create function test_fail_egress_credential()
+  returns trigger
+as $$
+begin
+  raise exception 'test failure';
+end;
+$$ language plpgsql;
+create trigger test_fail_egress_credential before insert on session_egress_credential
+  for each row execute procedure test_fail_egress_credential();
+`, nil)
+	require.NoError(t, err)
+
+	req := &pbs.LookupSessionRequest{
+		ServerId:  "worker1",
+		SessionId: sess.GetPublicId(),
+	}
+	_, err = s.LookupSession(ctx, req)
+	require.Error(t, err)
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	_, err = rw.Exec(ctx, `drop trigger test_fail_egress_credential on session_egress_credential;`, nil)
+	require.NoError(t, err)
+
+	// The credentials which could not be stored were released, so the retry
+	// issues new ones instead of waiting for them.
+	resp, err := s.LookupSession(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, resp.GetEgressCredentials(), 1)
+	assert.NotNil(t, resp.GetEgressCredentials()[0].GetSecret())
+
+	rows, err := rw.Query(ctx, `
+select status, count(*)
+  from credential_vault_credential
+ where session_id = ?
+ group by status;
+`, []interface{}{sess.GetPublicId()})
+	require.NoError(t, err)
+	defer rows.Close()
+	got := make(map[string]int)
+	for rows.Next() {
+		var st string
+		var n int
+		require.NoError(t, rows.Scan(&st, &n))
+		got[st] = n
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, map[string]int{"active": 1, "revoke": 1}, got)
+}
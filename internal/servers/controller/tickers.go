@@ -19,6 +19,19 @@ const (
 // This is exported so it can be tweaked in tests
 var NonceCleanupInterval = 2 * time.Minute
 
+// SessionTerminationsPollInterval is how often the database is polled for
+// sessions canceled on any controller, so their terminations can be pushed to
+// the workers connected to this one. This is exported so it can be tweaked in
+// tests.
+var SessionTerminationsPollInterval = 2 * time.Second
+
+// sessionTerminationsLookback is how far back each poll for canceled sessions
+// looks. It is longer than the poll interval so sessions canceled by
+// transactions which take a while to commit are not missed, and shorter than
+// session.TerminationPublishedWindow so the terminations found by
+// overlapping polls are only delivered once.
+const sessionTerminationsLookback = 30 * time.Second
+
 func (c *Controller) startStatusTicking(cancelCtx context.Context) {
 	const op = "controller.(Controller).startStatusTicking"
 	timer := time.NewTimer(0)
@@ -149,3 +162,34 @@ func (c *Controller) startCloseExpiredPendingTokens(cancelCtx context.Context) {
 		}
 	}
 }
+
+// startSessionTerminationsPolling publishes the terminations of sessions
+// canceled on any controller to the workers connected to this controller.
+// Sessions canceled on this controller are also published when they are
+// canceled; the broadcaster only delivers each termination once.
+func (c *Controller) startSessionTerminationsPolling(cancelCtx context.Context) {
+	const op = "controller.(Controller).startSessionTerminationsPolling"
+	timer := time.NewTimer(0)
+	for {
+		select {
+		case <-cancelCtx.Done():
+			event.WriteSysEvent(cancelCtx, op, "session terminations polling shutting down")
+			return
+
+		case <-timer.C:
+			repo, err := c.SessionRepoFn()
+			if err != nil {
+				event.WriteError(cancelCtx, op, err, event.WithInfoMsg("error fetching repository for session terminations"))
+			} else {
+				terms, err := repo.ListRecentTerminations(cancelCtx, sessionTerminationsLookback)
+				if err != nil {
+					event.WriteError(cancelCtx, op, err, event.WithInfoMsg("error listing session terminations"))
+				}
+				for _, t := range terms {
+					c.sessionTerminations.Publish(t)
+				}
+			}
+			timer.Reset(SessionTerminationsPollInterval)
+		}
+	}
+}
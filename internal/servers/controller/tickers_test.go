@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionTerminationsMulti(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+
+	c1 := NewTestController(t, nil)
+	defer c1.Shutdown()
+	c2 := c1.AddClusterControllerMember(t, nil)
+	defer c2.Shutdown()
+
+	terms1, unsub1 := c1.Controller().sessionTerminations.Subscribe()
+	defer unsub1()
+	terms2, unsub2 := c2.Controller().sessionTerminations.Subscribe()
+	defer unsub2()
+
+	sess := session.TestDefaultSession(t, c1.DbConn(), c1.Controller().conf.RootKms, c1.IamRepo())
+	repo, err := c1.Controller().SessionRepoFn()
+	require.NoError(err)
+	_, err = repo.CancelSession(ctx, sess.PublicId, sess.Version, session.WithCancelReason("maintenance"))
+	require.NoError(err)
+
+	// The handlers publish the termination on the controller the session was
+	// canceled on.
+	want := session.Termination{
+		SessionId: sess.PublicId,
+		ServerId:  sess.ServerId,
+		Reason:    "maintenance",
+	}
+	c1.Controller().sessionTerminations.Publish(want)
+
+	// The other controller finds it by polling the database.
+	select {
+	case got := <-terms2:
+		assert.Equal(want, got)
+	case <-time.After(5 * SessionTerminationsPollInterval):
+		t.Fatal("termination was not published on the second controller")
+	}
+
+	// Polling does not deliver the termination a second time.
+	assert.Equal(want, <-terms1)
+	time.Sleep(2 * SessionTerminationsPollInterval)
+	assert.Len(terms1, 0)
+	assert.Len(terms2, 0)
+}
@@ -261,3 +261,43 @@ func TestListServersWithLiveness(t *testing.T) {
 	require.Len(result, 3)
 	requireIds([]string{server1.PrivateId, server2.PrivateId, server3.PrivateId}, result)
 }
+
+func TestUpsertServerActiveConnectionCount(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	serversRepo, err := servers.NewRepository(rw, rw, kms)
+	require.NoError(err)
+	ctx := context.Background()
+
+	worker := &servers.Server{
+		PrivateId:             "test-active-conns",
+		Type:                  "worker",
+		Address:               "127.0.0.1",
+		ActiveConnectionCount: 3,
+	}
+	_, _, err = serversRepo.UpsertServer(ctx, worker)
+	require.NoError(err)
+
+	lookup := func() *servers.Server {
+		workers, err := serversRepo.ListServers(ctx, servers.ServerTypeWorker)
+		require.NoError(err)
+		for _, w := range workers {
+			if w.PrivateId == worker.PrivateId {
+				return w
+			}
+		}
+		require.FailNow("worker not listed")
+		return nil
+	}
+	require.Equal(uint32(3), lookup().ActiveConnectionCount)
+
+	// A later status report replaces the count
+	worker.ActiveConnectionCount = 0
+	_, _, err = serversRepo.UpsertServer(ctx, worker)
+	require.NoError(err)
+	require.Equal(uint32(0), lookup().ActiveConnectionCount)
+}
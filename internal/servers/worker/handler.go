@@ -212,7 +212,23 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig) (http.Han
 
 		si.Lock()
 		ci.ConnCtx = connCtx
-		ci.ConnCancel = connCancel
+		// ConnCancel is called with the session lock held. When the session
+		// was canceled the client is told why before the connection is torn
+		// down; closing waits for the client's close frame, so it must not
+		// block the caller.
+		ci.ConnCancel = func() {
+			if si.TerminationReason == "" {
+				connCancel()
+				return
+			}
+			reason := si.TerminationReason
+			go func() {
+				defer connCancel()
+				if err := conn.Close(globals.SessionTerminatedWsCloseCode, reason); err != nil && !errors.Is(err, io.EOF) {
+					event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
+				}
+			}()
+		}
 		si.ConnInfoMap[ci.Id] = ci
 		si.Status = sessStatus
 		connectionLimit := si.LookupSessionResponse.GetConnectionLimit()
@@ -249,7 +265,20 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig) (http.Han
 			return
 		}
 
-		if err = handleProxyFn(connCtx, conf); err != nil {
+		var proxyOpts []proxyHandlers.Option
+		egressCreds, err := proxyHandlers.ConvertSessionCredentials(si.LookupSessionResponse.GetEgressCredentials())
+		if err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error converting egress credentials"))
+			if err = conn.Close(websocket.StatusInternalError, "unable to convert egress credentials"); err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
+			}
+			return
+		}
+		if len(egressCreds) > 0 {
+			proxyOpts = append(proxyOpts, proxyHandlers.WithEgressCredentials(egressCreds))
+		}
+
+		if err = handleProxyFn(connCtx, conf, proxyOpts...); err != nil {
 			event.WriteError(ctx, op, err, event.WithInfoMsg("error handling proxy", "session_id", sessionId, "endpoint", endpoint))
 			if err = conn.Close(websocket.StatusInternalError, "unable to establish proxy"); err != nil {
 				event.WriteError(ctx, op, err, event.WithInfoMsg("error closing client connection"))
@@ -1,5 +1,6 @@
 package worker
 
 import (
+	_ "github.com/hashicorp/boundary/internal/servers/worker/proxy/kubernetes"
 	_ "github.com/hashicorp/boundary/internal/servers/worker/proxy/tcp"
 )
@@ -0,0 +1,59 @@
+package proxy
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+)
+
+// sessionCredential is a credential.Credential built from a session credential
+// sent to the worker by the controller.
+type sessionCredential struct {
+	id     string
+	secret map[string]interface{}
+}
+
+var _ credential.Credential = (*sessionCredential)(nil)
+
+// GetPublicId returns the id of the credential library the credential was
+// issued from.
+func (c *sessionCredential) GetPublicId() string { return c.id }
+
+// Secret returns the decoded secret of the credential as a
+// map[string]interface{}.
+func (c *sessionCredential) Secret() credential.SecretData { return c.secret }
+
+// ConvertSessionCredentials converts the session credentials returned by the
+// controller when looking up a session into credential.Credentials which can be
+// passed to a proxy handler using WithEgressCredentials.
+func ConvertSessionCredentials(creds []*targets.SessionCredential) ([]credential.Credential, error) {
+	if len(creds) == 0 {
+		return nil, nil
+	}
+	ret := make([]credential.Credential, 0, len(creds))
+	for _, c := range creds {
+		sc := &sessionCredential{
+			id: c.GetCredentialSource().GetId(),
+		}
+		switch {
+		case c.GetSecret().GetDecoded() != nil:
+			sc.secret = c.GetSecret().GetDecoded().AsMap()
+		case c.GetSecret().GetRaw() != "":
+			raw, err := base64.StdEncoding.DecodeString(c.GetSecret().GetRaw())
+			if err != nil {
+				return nil, fmt.Errorf("error decoding secret for credential source %q: %w", sc.id, err)
+			}
+			if err := json.Unmarshal(raw, &sc.secret); err != nil {
+				return nil, fmt.Errorf("error unmarshaling secret for credential source %q: %w", sc.id, err)
+			}
+		default:
+			return nil, errors.New("missing secret for session credential")
+		}
+		ret = append(ret, sc)
+	}
+	return ret, nil
+}
@@ -0,0 +1,92 @@
+package proxy
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestConvertSessionCredentials(t *testing.T) {
+	t.Parallel()
+
+	decoded, err := structpb.NewStruct(map[string]interface{}{"token": "decoded-token"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		creds   []*targets.SessionCredential
+		want    map[string]map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "decoded",
+			creds: []*targets.SessionCredential{
+				{
+					CredentialSource: &targets.CredentialSource{Id: "clvlt_decoded"},
+					Secret:           &targets.SessionSecret{Decoded: decoded},
+				},
+			},
+			want: map[string]map[string]interface{}{
+				"clvlt_decoded": {"token": "decoded-token"},
+			},
+		},
+		{
+			name: "raw",
+			creds: []*targets.SessionCredential{
+				{
+					CredentialSource: &targets.CredentialSource{Id: "clvlt_raw"},
+					Secret: &targets.SessionSecret{
+						Raw: base64.StdEncoding.EncodeToString([]byte(`{"token":"raw-token"}`)),
+					},
+				},
+			},
+			want: map[string]map[string]interface{}{
+				"clvlt_raw": {"token": "raw-token"},
+			},
+		},
+		{
+			name: "invalid-raw",
+			creds: []*targets.SessionCredential{
+				{
+					CredentialSource: &targets.CredentialSource{Id: "clvlt_invalid"},
+					Secret:           &targets.SessionSecret{Raw: "not base64!"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing-secret",
+			creds: []*targets.SessionCredential{
+				{
+					CredentialSource: &targets.CredentialSource{Id: "clvlt_missing"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := ConvertSessionCredentials(tt.creds)
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.Len(got, len(tt.want))
+			for _, c := range got {
+				want, ok := tt.want[c.GetPublicId()]
+				require.True(ok)
+				assert.Equal(want, c.Secret())
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+// Package kubernetes provides the worker proxy handler for kubernetes targets.
+// The handler terminates the HTTP connection from the client and forwards
+// requests to the Kubernetes API server, authenticating with the session's
+// egress credential and impersonating the Boundary user.
+package kubernetes
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/servers/worker/proxy"
+	"github.com/hashicorp/boundary/internal/servers/worker/session"
+	"nhooyr.io/websocket"
+)
+
+const (
+	defaultPort = "443"
+
+	impersonateUserHeader  = "Impersonate-User"
+	impersonateGroupHeader = "Impersonate-Group"
+	impersonateExtraPrefix = "Impersonate-Extra-"
+	sessionIdExtraHeader   = impersonateExtraPrefix + "Boundary-Session-Id"
+	userNameExtraHeader    = impersonateExtraPrefix + "Boundary-User-Name"
+)
+
+var (
+	// tokenKeys are the secret keys checked, in order, for the bearer token
+	// used to authenticate to the API server.
+	tokenKeys = []string{"service_account_token", "token"}
+
+	// caKeys are the secret keys checked, in order, for the PEM encoded CA
+	// certificate of the API server.
+	caKeys = []string{"ca.crt", "ca_crt"}
+)
+
+func init() {
+	err := proxy.RegisterHandler("kubernetes", handleProxy)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// handleProxy creates an HTTP reverse proxy between the incoming websocket conn
+// and the Kubernetes API server of the remote endpoint. handleProxy sets the
+// connectionId as connected in the repository.
+//
+// Requests from the client are sent to the API server with the bearer token from
+// the egress credentials and with impersonation headers for the session's user
+// and the user's managed groups. Any authorization or impersonation headers
+// sent by the client are removed.
+//
+// handleProxy blocks until the client connection is closed or ctx is done.
+//
+// Supported options: WithEgressCredentials (required).
+func handleProxy(ctx context.Context, conf proxy.Config, opt ...proxy.Option) error {
+	opts := proxy.GetOpts(opt...)
+	sessionUrl, err := url.Parse(conf.RemoteEndpoint)
+	if err != nil {
+		return fmt.Errorf("error parsing endpoint information: %w", err)
+	}
+	if sessionUrl.Scheme != "kubernetes" {
+		return fmt.Errorf("invalid scheme for kubernetes proxy: %v", sessionUrl.Scheme)
+	}
+	host := sessionUrl.Host
+	if sessionUrl.Port() == "" {
+		host = net.JoinHostPort(sessionUrl.Hostname(), defaultPort)
+	}
+
+	token, caPem, err := egressAuth(opts.WithEgressCredentials)
+	if err != nil {
+		return err
+	}
+	tlsConf := &tls.Config{
+		ServerName: sessionUrl.Hostname(),
+		MinVersion: tls.VersionTLS12,
+	}
+	if caPem != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(caPem)) {
+			return errors.New("error parsing ca certificate from egress credentials")
+		}
+		tlsConf.RootCAs = pool
+	}
+
+	remoteConn, err := tls.Dial("tcp", host, tlsConf)
+	if err != nil {
+		return fmt.Errorf("error dialing endpoint: %w", err)
+	}
+	endpointAddr := remoteConn.RemoteAddr().(*net.TCPAddr)
+	_ = remoteConn.Close()
+
+	connectionInfo := &pbs.ConnectConnectionRequest{
+		ConnectionId:       conf.ConnectionId,
+		ClientTcpAddress:   conf.ClientAddress.IP.String(),
+		ClientTcpPort:      uint32(conf.ClientAddress.Port),
+		EndpointTcpAddress: endpointAddr.IP.String(),
+		EndpointTcpPort:    uint32(endpointAddr.Port),
+		Type:               "kubernetes",
+		UserClientIp:       conf.UserClientIp.String(),
+	}
+
+	connStatus, err := session.ConnectConnection(ctx, conf.SessionClient, connectionInfo)
+	if err != nil {
+		return fmt.Errorf("error marking connection as connected: %w", err)
+	}
+
+	// Update connection info to set connection status
+	conf.SessionInfo.Lock()
+	conf.SessionInfo.ConnInfoMap[conf.ConnectionId].Status = connStatus
+	lsr := conf.SessionInfo.LookupSessionResponse
+	conf.SessionInfo.Unlock()
+
+	target := &url.URL{
+		Scheme: "https",
+		Host:   host,
+	}
+	rp := &httputil.ReverseProxy{
+		Director: func(r *http.Request) {
+			r.URL.Scheme = target.Scheme
+			r.URL.Host = target.Host
+			r.Host = target.Host
+
+			r.Header.Del("Authorization")
+			for k := range r.Header {
+				if strings.HasPrefix(http.CanonicalHeaderKey(k), "Impersonate-") {
+					r.Header.Del(k)
+				}
+			}
+			r.Header.Set("Authorization", "Bearer "+token)
+			r.Header.Set(impersonateUserHeader, lsr.GetUserId())
+			for _, g := range lsr.GetManagedGroupIds() {
+				r.Header.Add(impersonateGroupHeader, g)
+			}
+			r.Header.Set(sessionIdExtraHeader, lsr.GetAuthorization().GetSessionId())
+			if lsr.GetUserName() != "" {
+				r.Header.Set(userNameExtraHeader, lsr.GetUserName())
+			}
+		},
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConf,
+		},
+	}
+
+	// Get a wrapped net.Conn so the HTTP server can read requests from the
+	// client connection.
+	netConn := websocket.NetConn(ctx, conf.ClientConn, websocket.MessageBinary)
+	l := newConnListener(netConn)
+	srv := &http.Server{Handler: rp}
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = srv.Close()
+		case <-l.done:
+		}
+	}()
+	err = srv.Serve(l)
+	_ = netConn.Close()
+	if err != nil && !errors.Is(err, net.ErrClosed) && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("error serving kubernetes proxy: %w", err)
+	}
+	return nil
+}
+
+// egressAuth returns the bearer token and the optional PEM encoded CA
+// certificate found in the egress credentials.
+func egressAuth(creds []credential.Credential) (token string, caPem string, err error) {
+	for _, c := range creds {
+		secret, ok := c.Secret().(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if token == "" {
+			token = secretValue(secret, tokenKeys)
+		}
+		if caPem == "" {
+			caPem = secretValue(secret, caKeys)
+		}
+	}
+	if token == "" {
+		return "", "", errors.New("no bearer token found in egress credentials")
+	}
+	return token, caPem, nil
+}
+
+func secretValue(secret map[string]interface{}, keys []string) string {
+	for _, k := range keys {
+		if v, ok := secret[k].(string); ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// connListener is a net.Listener which returns a single connection. Once that
+// connection has been accepted, Accept blocks until either the connection or
+// the listener is closed.
+type connListener struct {
+	conn      net.Conn
+	accepted  bool
+	mu        sync.Mutex
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newConnListener(conn net.Conn) *connListener {
+	return &connListener{
+		conn: conn,
+		done: make(chan struct{}),
+	}
+}
+
+func (l *connListener) Accept() (net.Conn, error) {
+	l.mu.Lock()
+	if !l.accepted {
+		l.accepted = true
+		l.mu.Unlock()
+		return &notifyConn{Conn: l.conn, l: l}, nil
+	}
+	l.mu.Unlock()
+	<-l.done
+	return nil, net.ErrClosed
+}
+
+func (l *connListener) Close() error {
+	l.closeOnce.Do(func() { close(l.done) })
+	return nil
+}
+
+func (l *connListener) Addr() net.Addr {
+	return l.conn.LocalAddr()
+}
+
+// notifyConn closes the listener it was accepted from when it is closed.
+type notifyConn struct {
+	net.Conn
+	l *connListener
+}
+
+func (c *notifyConn) Close() error {
+	err := c.Conn.Close()
+	_ = c.l.Close()
+	return err
+}
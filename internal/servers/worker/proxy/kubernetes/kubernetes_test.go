@@ -0,0 +1,164 @@
+package kubernetes
+
+import (
+	"bufio"
+	"context"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/servers/worker/proxy"
+	"github.com/hashicorp/boundary/internal/servers/worker/session"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"nhooyr.io/websocket"
+)
+
+type cred struct {
+	id     string
+	secret map[string]interface{}
+}
+
+func (c cred) GetPublicId() string           { return c.id }
+func (c cred) Secret() credential.SecretData { return c.secret }
+
+func TestHandleProxy(t *testing.T) {
+	t.Parallel()
+	require, assert := require.New(t), assert.New(t)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	clientConn, proxyConn := proxy.TestWsConn(t, ctx)
+	require.NotNil(clientConn)
+	require.NotNil(proxyConn)
+
+	gotHeaders := make(chan http.Header, 1)
+	apiServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeaders <- r.Header.Clone()
+		_, _ = io.WriteString(w, "api server response")
+	}))
+	defer apiServer.Close()
+	apiUrl, err := url.Parse(apiServer.URL)
+	require.NoError(err)
+
+	caPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: apiServer.Certificate().Raw})
+
+	clientAddr := &net.TCPAddr{
+		IP:   net.ParseIP("127.0.0.1"),
+		Port: 50000,
+	}
+	sessClient := pbs.NewMockSessionServiceClient()
+	si := &session.Info{
+		Id: "one",
+		LookupSessionResponse: &pbs.LookupSessionResponse{
+			Authorization: &targets.SessionAuthorizationData{
+				SessionId: "mock-session",
+			},
+			UserId:          "u_1234567890",
+			UserName:        "alice",
+			ManagedGroupIds: []string{"mgoidc_1", "mgoidc_2"},
+		},
+		ConnInfoMap: map[string]*session.ConnInfo{
+			"mock-connection": {},
+		},
+	}
+
+	conf := proxy.Config{
+		ClientAddress:  clientAddr,
+		ClientConn:     proxyConn,
+		RemoteEndpoint: "kubernetes://" + apiUrl.Host,
+		SessionClient:  sessClient,
+		SessionInfo:    si,
+		ConnectionId:   "mock-connection",
+		UserClientIp:   net.ParseIP("127.0.0.1"),
+	}
+	creds := []credential.Credential{
+		cred{id: "clvlt_1", secret: map[string]interface{}{
+			"service_account_token": "egress-token",
+			"ca.crt":                string(caPem),
+		}},
+	}
+
+	proxyErr := make(chan error, 1)
+	go func() {
+		proxyErr <- handleProxy(ctx, conf, proxy.WithEgressCredentials(creds))
+	}()
+
+	netConn := websocket.NetConn(ctx, clientConn, websocket.MessageBinary)
+	req, err := http.NewRequest(http.MethodGet, "http://localhost/api/v1/namespaces", nil)
+	require.NoError(err)
+	req.Header.Set("Authorization", "Bearer client-token")
+	req.Header.Set("Impersonate-User", "admin")
+	req.Header.Set("Impersonate-Extra-Scopes", "all")
+	require.NoError(req.Write(netConn))
+
+	resp, err := http.ReadResponse(bufio.NewReader(netConn), req)
+	require.NoError(err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(err)
+	require.NoError(resp.Body.Close())
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal("api server response", string(body))
+
+	h := <-gotHeaders
+	assert.Equal("Bearer egress-token", h.Get("Authorization"))
+	assert.Equal("u_1234567890", h.Get(impersonateUserHeader))
+	assert.Equal([]string{"mgoidc_1", "mgoidc_2"}, h.Values(impersonateGroupHeader))
+	assert.Equal("mock-session", h.Get(sessionIdExtraHeader))
+	assert.Equal("alice", h.Get(userNameExtraHeader))
+	assert.Empty(h.Get("Impersonate-Extra-Scopes"))
+
+	assert.Equal(pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CONNECTED, si.ConnInfoMap["mock-connection"].Status)
+
+	require.NoError(netConn.Close())
+	assert.NoError(<-proxyErr)
+}
+
+func TestHandleProxy_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		endpoint string
+		creds    []credential.Credential
+		wantErr  string
+	}{
+		{
+			name:     "invalid-scheme",
+			endpoint: "tcp://localhost:6443",
+			wantErr:  "invalid scheme for kubernetes proxy: tcp",
+		},
+		{
+			name:     "missing-token",
+			endpoint: "kubernetes://localhost:6443",
+			creds: []credential.Credential{
+				cred{id: "clvlt_1", secret: map[string]interface{}{"username": "user"}},
+			},
+			wantErr: "no bearer token found in egress credentials",
+		},
+		{
+			name:     "invalid-ca",
+			endpoint: "kubernetes://localhost:6443",
+			creds: []credential.Credential{
+				cred{id: "clvlt_1", secret: map[string]interface{}{"token": "t", "ca_crt": "not a cert"}},
+			},
+			wantErr: "error parsing ca certificate from egress credentials",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			conf := proxy.Config{RemoteEndpoint: tt.endpoint}
+			err := handleProxy(context.Background(), conf, proxy.WithEgressCredentials(tt.creds))
+			require.Error(t, err)
+			assert.Equal(t, tt.wantErr, err.Error())
+		})
+	}
+}
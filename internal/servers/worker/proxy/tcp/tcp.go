@@ -2,18 +2,29 @@ package tcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/url"
 	"sync"
+	"time"
 
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/servers/worker/proxy"
 	"github.com/hashicorp/boundary/internal/servers/worker/session"
+	isession "github.com/hashicorp/boundary/internal/session"
 	"nhooyr.io/websocket"
 )
 
+// copyBufferSize is the size of the buffer used when copying data between the
+// connections while enforcing the session's connection limits.
+const copyBufferSize = 32 * 1024
+
+// errMaxConcurrentConnections is returned when a connection would exceed the
+// maximum number of concurrent connections of its session.
+var errMaxConcurrentConnections = errors.New("session has reached its maximum number of concurrent connections")
+
 func init() {
 	err := proxy.RegisterHandler("tcp", handleProxy)
 	if err != nil {
@@ -28,6 +39,11 @@ func init() {
 // handleProxy blocks until an error (EOF on happy path) is received on either
 // connection.
 //
+// handleProxy enforces the connection idle timeout, the maximum number of
+// concurrent connections and the maximum number of bytes per session sent in
+// the session's LookupSessionResponse. When a limit is reached the connection
+// is closed and its ClosedReason is set to ConnectionLimitReached.
+//
 // All options are ignored.
 func handleProxy(ctx context.Context, conf proxy.Config, _ ...proxy.Option) error {
 	conn := conf.ClientConn
@@ -61,28 +77,164 @@ func handleProxy(ctx context.Context, conf proxy.Config, _ ...proxy.Option) erro
 		return fmt.Errorf("error marking connection as connected: %w", err)
 	}
 
-	// Update connection info to set connection status
+	// Update connection info to set connection status, unless the connection
+	// would exceed the session's maximum number of concurrent connections.
 	conf.SessionInfo.Lock()
-	conf.SessionInfo.ConnInfoMap[conf.ConnectionId].Status = connStatus
+	lsr := conf.SessionInfo.LookupSessionResponse
+	ci := conf.SessionInfo.ConnInfoMap[conf.ConnectionId]
+	if max := lsr.GetMaxConcurrentConnections(); max > 0 && connectedCount(conf.SessionInfo) >= int(max) {
+		ci.ClosedReason = isession.ConnectionLimitReached
+		conf.SessionInfo.Unlock()
+		_ = tcpRemoteConn.Close()
+		return errMaxConcurrentConnections
+	}
+	ci.Status = connStatus
 	conf.SessionInfo.Unlock()
 
 	// Get a wrapped net.Conn so we can use io.Copy
 	netConn := websocket.NetConn(ctx, conn, websocket.MessageBinary)
 
+	if lsr.GetConnectionIdleTimeoutSeconds() == 0 && lsr.GetMaxBytesPerSession() == 0 {
+		connWg := new(sync.WaitGroup)
+		connWg.Add(2)
+		go func() {
+			defer connWg.Done()
+			_, _ = io.Copy(netConn, tcpRemoteConn)
+			_ = netConn.Close()
+			_ = tcpRemoteConn.Close()
+		}()
+		go func() {
+			defer connWg.Done()
+			_, _ = io.Copy(tcpRemoteConn, netConn)
+			_ = tcpRemoteConn.Close()
+			_ = netConn.Close()
+		}()
+		connWg.Wait()
+		return nil
+	}
+
+	l := newLimiter(conf.SessionInfo, ci, lsr, func() {
+		_ = netConn.Close()
+		_ = tcpRemoteConn.Close()
+	})
+	defer l.stop()
+
 	connWg := new(sync.WaitGroup)
 	connWg.Add(2)
 	go func() {
 		defer connWg.Done()
-		_, _ = io.Copy(netConn, tcpRemoteConn)
-		_ = netConn.Close()
-		_ = tcpRemoteConn.Close()
+		l.copy(netConn, tcpRemoteConn)
 	}()
 	go func() {
 		defer connWg.Done()
-		_, _ = io.Copy(tcpRemoteConn, netConn)
-		_ = tcpRemoteConn.Close()
-		_ = netConn.Close()
+		l.copy(tcpRemoteConn, netConn)
 	}()
 	connWg.Wait()
 	return nil
 }
+
+// connectedCount returns the number of connections of the session which are
+// connected. It must be called with the session lock held.
+func connectedCount(si *session.Info) int {
+	var n int
+	for _, ci := range si.ConnInfoMap {
+		if ci.Status == pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CONNECTED {
+			n++
+		}
+	}
+	return n
+}
+
+// limiter enforces the connection idle timeout and the maximum number of bytes
+// per session on a proxied connection.
+type limiter struct {
+	si       *session.Info
+	ci       *session.ConnInfo
+	maxBytes uint64
+
+	idleTimeout time.Duration
+	idleTimer   *time.Timer
+
+	closeOnce sync.Once
+	closeFn   func()
+}
+
+func newLimiter(si *session.Info, ci *session.ConnInfo, lsr *pbs.LookupSessionResponse, closeFn func()) *limiter {
+	l := &limiter{
+		si:          si,
+		ci:          ci,
+		maxBytes:    lsr.GetMaxBytesPerSession(),
+		idleTimeout: time.Duration(lsr.GetConnectionIdleTimeoutSeconds()) * time.Second,
+		closeFn:     closeFn,
+	}
+	if l.idleTimeout > 0 {
+		l.idleTimer = time.AfterFunc(l.idleTimeout, func() {
+			l.limitReached()
+		})
+	}
+	return l
+}
+
+// copy copies from src to dst until either returns an error or a limit is
+// reached, then closes both connections.
+func (l *limiter) copy(dst io.Writer, src io.Reader) {
+	defer l.close()
+	buf := make([]byte, copyBufferSize)
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			if l.idleTimer != nil {
+				l.idleTimer.Reset(l.idleTimeout)
+			}
+			allowed, ok := l.addBytes(uint64(n))
+			if _, werr := dst.Write(buf[:allowed]); werr != nil {
+				return
+			}
+			if !ok {
+				l.limitReached()
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// addBytes adds n to the bytes transferred by the session. It returns the
+// number of bytes which may be written without exceeding the maximum number of
+// bytes per session, and false if the maximum has been exceeded.
+func (l *limiter) addBytes(n uint64) (uint64, bool) {
+	l.si.Lock()
+	total := l.si.AddBytesTransferred(n)
+	l.si.Unlock()
+	if l.maxBytes == 0 || total <= l.maxBytes {
+		return n, true
+	}
+	over := total - l.maxBytes
+	if over >= n {
+		return 0, false
+	}
+	return n - over, false
+}
+
+// limitReached records ConnectionLimitReached as the reason the connection is
+// closed and closes it.
+func (l *limiter) limitReached() {
+	l.si.Lock()
+	if l.ci.ClosedReason == "" {
+		l.ci.ClosedReason = isession.ConnectionLimitReached
+	}
+	l.si.Unlock()
+	l.close()
+}
+
+func (l *limiter) close() {
+	l.closeOnce.Do(l.closeFn)
+}
+
+func (l *limiter) stop() {
+	if l.idleTimer != nil {
+		l.idleTimer.Stop()
+	}
+}
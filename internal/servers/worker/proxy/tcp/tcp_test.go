@@ -3,12 +3,15 @@ package tcp
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
 	"testing"
+	"time"
 
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/servers/worker/proxy"
 	"github.com/hashicorp/boundary/internal/servers/worker/session"
+	isession "github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
 	"github.com/hashicorp/boundary/sdk/testutil"
 	"github.com/stretchr/testify/assert"
@@ -102,3 +105,120 @@ func TestHandleTcpProxyV1(t *testing.T) {
 
 	cancelCtx()
 }
+
+func TestHandleTcpProxyV1_Limits(t *testing.T) {
+	t.Parallel()
+
+	// setup starts an endpoint which writes endpointData to the first
+	// connection it accepts and returns a proxy config for a session with the
+	// provided lookup response.
+	setup := func(t *testing.T, ctx context.Context, lsr *pbs.LookupSessionResponse, endpointData []byte) (proxy.Config, *websocket.Conn) {
+		t.Helper()
+		clientConn, proxyConn := proxy.TestWsConn(t, ctx)
+		require.NotNil(t, clientConn)
+		require.NotNil(t, proxyConn)
+
+		port := testutil.TestFreePort(t)
+		l, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = l.Close() })
+		go func() {
+			endpointConn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer endpointConn.Close()
+			if len(endpointData) > 0 {
+				_, _ = endpointConn.Write(endpointData)
+			}
+			<-ctx.Done()
+		}()
+
+		lsr.Authorization = &targets.SessionAuthorizationData{SessionId: "mock-session"}
+		si := &session.Info{
+			Id:                    "one",
+			LookupSessionResponse: lsr,
+			ConnInfoMap: map[string]*session.ConnInfo{
+				"mock-connection": {},
+			},
+		}
+		return proxy.Config{
+			ClientAddress: &net.TCPAddr{
+				IP:   net.ParseIP("127.0.0.1"),
+				Port: 50000,
+			},
+			ClientConn:     proxyConn,
+			RemoteEndpoint: fmt.Sprintf("tcp://localhost:%d", port),
+			SessionClient:  pbs.NewMockSessionServiceClient(),
+			SessionInfo:    si,
+			ConnectionId:   "mock-connection",
+			UserClientIp:   net.ParseIP("127.0.0.1"),
+		}, clientConn
+	}
+
+	t.Run("max-concurrent-connections", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ctx, cancelCtx := context.WithCancel(context.Background())
+		defer cancelCtx()
+
+		conf, _ := setup(t, ctx, &pbs.LookupSessionResponse{MaxConcurrentConnections: 1}, nil)
+		conf.SessionInfo.ConnInfoMap["other-connection"] = &session.ConnInfo{
+			Status: pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CONNECTED,
+		}
+
+		err := handleProxy(ctx, conf)
+		require.ErrorIs(err, errMaxConcurrentConnections)
+		ci := conf.SessionInfo.ConnInfoMap["mock-connection"]
+		assert.Equal(isession.ConnectionLimitReached, ci.ClosedReason)
+		assert.NotEqual(pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CONNECTED, ci.Status)
+	})
+
+	t.Run("max-bytes-per-session", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ctx, cancelCtx := context.WithCancel(context.Background())
+		defer cancelCtx()
+
+		conf, clientConn := setup(t, ctx, &pbs.LookupSessionResponse{MaxBytesPerSession: 8}, []byte("endpoint write to client via proxy"))
+
+		proxyErr := make(chan error, 1)
+		go func() {
+			proxyErr <- handleProxy(ctx, conf)
+		}()
+
+		netConn := websocket.NetConn(ctx, clientConn, websocket.MessageBinary)
+		got, _ := io.ReadAll(netConn)
+		assert.Equal("endpoint", string(got))
+
+		require.NoError(<-proxyErr)
+		conf.SessionInfo.RLock()
+		defer conf.SessionInfo.RUnlock()
+		assert.Equal(isession.ConnectionLimitReached, conf.SessionInfo.ConnInfoMap["mock-connection"].ClosedReason)
+	})
+
+	t.Run("connection-idle-timeout", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ctx, cancelCtx := context.WithCancel(context.Background())
+		defer cancelCtx()
+
+		conf, clientConn := setup(t, ctx, &pbs.LookupSessionResponse{ConnectionIdleTimeoutSeconds: 1}, nil)
+
+		proxyErr := make(chan error, 1)
+		go func() {
+			proxyErr <- handleProxy(ctx, conf)
+		}()
+		// Read on the client so the websocket close handshake completes.
+		go func() {
+			_, _ = io.ReadAll(websocket.NetConn(ctx, clientConn, websocket.MessageBinary))
+		}()
+
+		select {
+		case err := <-proxyErr:
+			require.NoError(err)
+		case <-time.After(10 * time.Second):
+			require.FailNow("idle connection was not closed")
+		}
+		conf.SessionInfo.RLock()
+		defer conf.SessionInfo.RUnlock()
+		assert.Equal(isession.ConnectionLimitReached, conf.SessionInfo.ConnInfoMap["mock-connection"].ClosedReason)
+	})
+}
@@ -27,6 +27,9 @@ type ConnInfo struct {
 	ConnCancel context.CancelFunc
 	Status     pbs.CONNECTIONSTATUS
 	CloseTime  time.Time
+	// ClosedReason is the reason sent to the controller when the connection
+	// is closed. If empty, session.UnknownReason is sent.
+	ClosedReason session.ClosedReason
 }
 
 // Info defines the information about a session
@@ -37,6 +40,21 @@ type Info struct {
 	Status                pbs.SESSIONSTATUS
 	LookupSessionResponse *pbs.LookupSessionResponse
 	ConnInfoMap           map[string]*ConnInfo
+	// TerminationReason is sent to the client when the session's connections
+	// are closed because the session was canceled.
+	TerminationReason string
+
+	// bytesTransferred is the number of bytes proxied over all connections of
+	// the session. It is guarded by the embedded lock.
+	bytesTransferred uint64
+}
+
+// AddBytesTransferred adds n to the number of bytes proxied over all
+// connections of the session and returns the new total. It must be called
+// with the lock held.
+func (i *Info) AddBytesTransferred(n uint64) uint64 {
+	i.bytesTransferred += n
+	return i.bytesTransferred
 }
 
 // Activate is a helper worker function that sends session activation request to the
@@ -139,7 +157,7 @@ func CloseConnections(ctx context.Context, sessClient pbs.SessionServiceClient,
 	// within an adequate period of time.
 	closeConnCtx, closeConnCancel := context.WithTimeout(ctx, common.StatusTimeout)
 	defer closeConnCancel()
-	response, err := closeConnection(closeConnCtx, sessClient, makeCloseConnectionRequest(closeInfo))
+	response, err := closeConnection(closeConnCtx, sessClient, makeCloseConnectionRequest(sessionInfo, closeInfo))
 	if err != nil {
 		event.WriteError(ctx, op, err, event.WithInfoMsg("error marking connections closed",
 			"warning", "error contacting controller, connections will be closed only on worker",
@@ -172,15 +190,24 @@ func CloseConnections(ctx context.Context, sessClient pbs.SessionServiceClient,
 // use with closing connections.
 //
 // closeInfo is a map, indexed by connection ID, to the individual
-// sessions IDs that those connections belong to. The values are
-// ignored; the parameter is expected as such just for convenience of
-// its caller.
-func makeCloseConnectionRequest(closeInfo map[string]string) *pbs.CloseConnectionRequest {
+// sessions IDs that those connections belong to. The reason sent for
+// each connection is the ClosedReason set on its ConnInfo in
+// sessionInfo, or session.UnknownReason if none is set.
+func makeCloseConnectionRequest(sessionInfo *sync.Map, closeInfo map[string]string) *pbs.CloseConnectionRequest {
 	closeData := make([]*pbs.CloseConnectionRequestData, 0, len(closeInfo))
-	for connId := range closeInfo {
+	for connId, sessionId := range closeInfo {
+		reason := session.UnknownReason
+		if siRaw, ok := sessionInfo.Load(sessionId); ok {
+			si := siRaw.(*Info)
+			si.RLock()
+			if ci, ok := si.ConnInfoMap[connId]; ok && ci.ClosedReason != "" {
+				reason = ci.ClosedReason
+			}
+			si.RUnlock()
+		}
 		closeData = append(closeData, &pbs.CloseConnectionRequestData{
 			ConnectionId: connId,
-			Reason:       session.UnknownReason.String(),
+			Reason:       reason.String(),
 		})
 	}
 
@@ -13,14 +13,23 @@ import (
 
 func TestWorkerMakeCloseConnectionRequest(t *testing.T) {
 	require := require.New(t)
-	in := map[string]string{"foo": "one", "bar": "two"}
+	in := map[string]string{"foo": "one", "bar": "two", "baz": "two"}
+	sessionInfo := new(sync.Map)
+	sessionInfo.Store("two", &Info{
+		Id: "two",
+		ConnInfoMap: map[string]*ConnInfo{
+			"bar": {Id: "bar", ClosedReason: session.ConnectionLimitReached},
+			"baz": {Id: "baz"},
+		},
+	})
 	expected := &pbs.CloseConnectionRequest{
 		CloseRequestData: []*pbs.CloseConnectionRequestData{
 			{ConnectionId: "foo", Reason: session.UnknownReason.String()},
-			{ConnectionId: "bar", Reason: session.UnknownReason.String()},
+			{ConnectionId: "bar", Reason: session.ConnectionLimitReached.String()},
+			{ConnectionId: "baz", Reason: session.UnknownReason.String()},
 		},
 	}
-	actual := makeCloseConnectionRequest(in)
+	actual := makeCloseConnectionRequest(sessionInfo, in)
 	require.ElementsMatch(expected.GetCloseRequestData(), actual.GetCloseRequestData())
 }
 
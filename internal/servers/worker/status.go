@@ -198,6 +198,9 @@ func (w *Worker) sendWorkerStatus(cancelCtx context.Context) {
 					si := siRaw.(*session.Info)
 					si.Lock()
 					si.Status = sessInfo.GetStatus()
+					if si.Status == pbs.SESSIONSTATUS_SESSIONSTATUS_CANCELING && si.TerminationReason == "" {
+						si.TerminationReason = terminationReason(sessInfo.GetCancelReason())
+					}
 					// Update connection state if there are any connections in
 					// the request.
 					for _, conn := range sessInfo.GetConnections() {
@@ -0,0 +1,98 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/servers/worker/common"
+	"github.com/hashicorp/boundary/internal/servers/worker/session"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultTerminationReason is sent to clients when a session is canceled
+// without a reason.
+const defaultTerminationReason = "Session canceled"
+
+// terminationReason returns the reason sent to clients for a session canceled
+// with the given reason.
+func terminationReason(reason string) string {
+	if reason == "" {
+		return defaultTerminationReason
+	}
+	return reason
+}
+
+// startTerminationsStream receives the canceled sessions from the controller
+// and closes their connections immediately instead of waiting for the next
+// status request, which remains the fallback. The stream is reopened until
+// cancelCtx is done.
+func (w *Worker) startTerminationsStream(cancelCtx context.Context) {
+	const op = "worker.(Worker).startTerminationsStream"
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-cancelCtx.Done():
+			event.WriteSysEvent(w.baseContext, op, "session terminations stream shutting down")
+			return
+
+		case <-timer.C:
+			retry := common.StatusInterval
+			if err := w.receiveTerminations(cancelCtx); err != nil {
+				if status.Code(err) == codes.Unimplemented {
+					// Controllers predating the stream still send
+					// cancellations in status responses; check back rarely.
+					retry = 30 * common.StatusInterval
+				} else if cancelCtx.Err() == nil {
+					event.WriteError(cancelCtx, op, err, event.WithInfoMsg("error receiving session terminations from controller"))
+				}
+			}
+			timer.Reset(retry)
+		}
+	}
+}
+
+// receiveTerminations opens a session terminations stream to the controller
+// and handles the terminations received on it until the stream ends.
+func (w *Worker) receiveTerminations(cancelCtx context.Context) error {
+	client := w.controllerStatusConn.Load().(pbs.ServerCoordinationServiceClient)
+	stream, err := client.SessionTerminations(cancelCtx, &pbs.SessionTerminationsRequest{
+		WorkerId: w.conf.RawConfig.Worker.Name,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := stream.Recv()
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			return err
+		}
+		w.terminateSession(cancelCtx, resp.GetSessionId(), resp.GetReason())
+	}
+}
+
+// terminateSession marks the session as canceling and closes its connections.
+// Sessions not handled by this worker are ignored.
+func (w *Worker) terminateSession(cancelCtx context.Context, sessionId, reason string) {
+	const op = "worker.(Worker).terminateSession"
+	siRaw, ok := w.sessionInfoMap.Load(sessionId)
+	if !ok {
+		return
+	}
+	si := siRaw.(*session.Info)
+	si.Lock()
+	si.Status = pbs.SESSIONSTATUS_SESSIONSTATUS_CANCELING
+	si.TerminationReason = terminationReason(reason)
+	si.Unlock()
+	event.WriteSysEvent(cancelCtx, op, "received session termination", "session_id", sessionId)
+
+	w.cleanupConnections(cancelCtx, false)
+}
@@ -0,0 +1,75 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/servers/worker/session"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestWorkerTerminateSession(t *testing.T) {
+	// do not run using t.Parallel() since it relies on the sys eventer
+	event.TestEnableEventing(t, true)
+	testConfig := event.DefaultEventerConfig()
+	testLock := &sync.Mutex{}
+	testLogger := hclog.New(&hclog.LoggerOptions{
+		Mutex: testLock,
+	})
+	err := event.InitSysEventer(testLogger, testLock, "TestWorkerTerminateSession", event.WithEventerConfig(testConfig))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		reason     string
+		wantReason string
+	}{
+		{
+			name:       "with-reason",
+			reason:     "Scheduled maintenance",
+			wantReason: "Scheduled maintenance",
+		},
+		{
+			name:       "without-reason",
+			wantReason: defaultTerminationReason,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			w := &Worker{
+				baseContext:    context.Background(),
+				sessionInfoMap: new(sync.Map),
+			}
+			si := &session.Info{
+				Id:          "s_1234567890",
+				Status:      pbs.SESSIONSTATUS_SESSIONSTATUS_ACTIVE,
+				ConnInfoMap: map[string]*session.ConnInfo{},
+				LookupSessionResponse: &pbs.LookupSessionResponse{
+					Expiration: timestamppb.Now(),
+				},
+			}
+			w.sessionInfoMap.Store(si.Id, si)
+
+			// Unknown sessions are ignored
+			w.terminateSession(context.Background(), "s_unknown", tt.reason)
+			_, ok := w.sessionInfoMap.Load(si.Id)
+			assert.True(ok)
+
+			w.terminateSession(context.Background(), si.Id, tt.reason)
+			assert.Equal(pbs.SESSIONSTATUS_SESSIONSTATUS_CANCELING, si.Status)
+			assert.Equal(tt.wantReason, si.TerminationReason)
+			// The session has no open connections so it is cleaned up
+			// immediately
+			_, ok = w.sessionInfoMap.Load(si.Id)
+			assert.False(ok)
+		})
+	}
+}
@@ -133,6 +133,11 @@ func (w *Worker) Start() error {
 		defer w.tickerWg.Done()
 		w.startStatusTicking(w.baseContext)
 	}()
+	w.tickerWg.Add(1)
+	go func() {
+		defer w.tickerWg.Done()
+		w.startTerminationsStream(w.baseContext)
+	}()
 
 	w.workerStartTime = time.Now()
 	w.started.Store(true)
@@ -312,6 +317,11 @@ func (w *Worker) getSessionTls(hello *tls.ClientHelloInfo) (*tls.Config, error)
 		// Update the response to the latest
 		actualSi := actualSiRaw.(*session.Info)
 		actualSi.Lock()
+		// Keep the egress credentials handed out by an earlier lookup, which
+		// existing connections may still be using.
+		if len(resp.GetEgressCredentials()) == 0 {
+			resp.EgressCredentials = actualSi.LookupSessionResponse.GetEgressCredentials()
+		}
 		actualSi.LookupSessionResponse = resp
 		actualSi.Unlock()
 	}
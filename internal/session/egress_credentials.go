@@ -0,0 +1,57 @@
+package session
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+)
+
+// egressCredentials are the egress credentials issued for a session. Dynamic
+// credentials are only issued once per session, so they are kept to hand to
+// every worker which looks up the session. Credentials is opaque to the
+// session package and is encrypted with the database key of the session's
+// scope.
+type egressCredentials struct {
+	SessionId     string `json:"session_id,omitempty" gorm:"primary_key"`
+	CtCredentials []byte `json:"ct_credentials,omitempty" gorm:"column:credentials;not_null" wrapping:"ct,credentials"`
+	Credentials   []byte `json:"credentials,omitempty" gorm:"-" wrapping:"pt,credentials"`
+	KeyId         string `json:"key_id,omitempty" gorm:"not_null"`
+
+	tableName string `gorm:"-"`
+}
+
+func allocEgressCredentials() *egressCredentials {
+	return &egressCredentials{}
+}
+
+// TableName returns the table name.
+func (c *egressCredentials) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return "session_egress_credential"
+}
+
+// SetTableName sets the table name.
+func (c *egressCredentials) SetTableName(n string) {
+	c.tableName = n
+}
+
+func (c *egressCredentials) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "session.(egressCredentials).encrypt"
+	if err := structwrapping.WrapStruct(ctx, cipher, c, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	c.KeyId = cipher.KeyID()
+	return nil
+}
+
+func (c *egressCredentials) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "session.(egressCredentials).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, c, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
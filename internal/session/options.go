@@ -23,12 +23,15 @@ type options struct {
 	withOrderByCreateTime db.OrderBy
 	withScopeIds          []string
 	withUserId            string
+	withTargetId          string
 	withExpirationTime    *timestamp.Timestamp
 	withTestTofu          []byte
 	withListingConvert    bool
 	withSessionIds        []string
 	withServerId          string
 	withDbOpts            []db.Option
+	withCancelReason      string
+	withStates            []Status
 }
 
 func getDefaultOptions() options {
@@ -66,6 +69,13 @@ func WithUserId(userId string) Option {
 	}
 }
 
+// WithTargetId allows specifying a target ID criteria for the function.
+func WithTargetId(targetId string) Option {
+	return func(o *options) {
+		o.withTargetId = targetId
+	}
+}
+
 // WithExpirationTime allows specifying an expiration time for the session
 func WithExpirationTime(exp *timestamp.Timestamp) Option {
 	return func(o *options) {
@@ -108,3 +118,18 @@ func WithDbOpts(opts ...db.Option) Option {
 		o.withDbOpts = opts
 	}
 }
+
+// WithCancelReason allows specifying a message describing why a session was
+// canceled.
+func WithCancelReason(reason string) Option {
+	return func(o *options) {
+		o.withCancelReason = reason
+	}
+}
+
+// WithStates allows specifying the states sessions must currently be in.
+func WithStates(states ...Status) Option {
+	return func(o *options) {
+		o.withStates = states
+	}
+}
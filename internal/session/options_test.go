@@ -51,6 +51,13 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withUserId = "u_1234"
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithTargetId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithTargetId("ttcp_1234"))
+		testOpts := getDefaultOptions()
+		testOpts.withTargetId = "ttcp_1234"
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithExpirationTime", func(t *testing.T) {
 		assert := assert.New(t)
 		now := timestamppb.Now()
@@ -82,4 +89,11 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withServerId = "worker1"
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithCancelReason", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithCancelReason("maintenance"))
+		testOpts := getDefaultOptions()
+		testOpts.withCancelReason = "maintenance"
+		assert.Equal(opts, testOpts)
+	})
 }
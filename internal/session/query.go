@@ -352,6 +352,42 @@ with
   `
 )
 
+const (
+	// cancelableSessions returns and locks the id and version of each of the
+	// given sessions which is pending or active.
+	cancelableSessions = `
+select s.public_id, s.version
+  from session s
+  join session_state ss on ss.session_id = s.public_id
+ where s.public_id in (?)
+   and ss.end_time is null
+   and ss.state in ('pending', 'active')
+   for update of s;
+`
+
+	// cancelableUserSessions returns and locks the id and version of each
+	// session of the given users which has not been terminated.
+	cancelableUserSessions = `
+select s.public_id, s.version
+  from session s
+  join session_state ss on ss.session_id = s.public_id
+ where s.user_id in (?)
+   and ss.end_time is null
+   and ss.state in ('pending', 'active', 'canceling')
+   for update of s;
+`
+
+	// recentTerminations returns the id, worker and cancel reason of each
+	// session which was canceled within the given number of seconds.
+	recentTerminations = `
+select s.public_id, s.server_id, s.cancel_reason
+  from session s
+  join session_state ss on ss.session_id = s.public_id
+ where ss.state = 'canceling'
+   and ss.start_time > now() - ? * interval '1 second';
+`
+)
+
 const (
 	sessionCredentialDynamicBatchInsertBase = `
 insert into session_credential_dynamic
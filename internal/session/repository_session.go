@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
@@ -201,7 +202,7 @@ func (r *Repository) LookupSession(ctx context.Context, sessionId string, _ ...O
 	return &session, authzSummary, nil
 }
 
-// ListSessions will sessions.  Supports the WithLimit, WithScopeId, WithSessionIds, and WithServerId options.
+// ListSessions will sessions.  Supports the WithLimit, WithScopeId, WithUserId, WithTargetId, WithSessionIds, and WithServerId options.
 func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Session, error) {
 	const op = "session.(Repository).ListSessions"
 	opts := getOpts(opt...)
@@ -227,6 +228,10 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 		inClauseCnt += 1
 		where, args = append(where, fmt.Sprintf("user_id = @%d", inClauseCnt)), append(args, sql.Named(fmt.Sprintf("%d", inClauseCnt), opts.withUserId))
 	}
+	if opts.withTargetId != "" {
+		inClauseCnt += 1
+		where, args = append(where, fmt.Sprintf("target_id = @%d", inClauseCnt)), append(args, sql.Named(fmt.Sprintf("%d", inClauseCnt), opts.withTargetId))
+	}
 	if len(opts.withSessionIds) > 0 {
 		idsInClause := make([]string, 0, len(opts.withSessionIds))
 		for _, id := range opts.withSessionIds {
@@ -239,6 +244,14 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 		inClauseCnt += 1
 		where, args = append(where, fmt.Sprintf("server_id = @%d", inClauseCnt)), append(args, sql.Named(fmt.Sprintf("%d", inClauseCnt), opts.withServerId))
 	}
+	if len(opts.withStates) > 0 {
+		statesInClause := make([]string, 0, len(opts.withStates))
+		for _, st := range opts.withStates {
+			inClauseCnt += 1
+			statesInClause, args = append(statesInClause, fmt.Sprintf("@%d", inClauseCnt)), append(args, sql.Named(fmt.Sprintf("%d", inClauseCnt), st.String()))
+		}
+		where = append(where, fmt.Sprintf("s.public_id in (select session_id from session_state where end_time is null and state in (%s))", strings.Join(statesInClause, ",")))
+	}
 
 	var limit string
 	switch {
@@ -333,7 +346,10 @@ func (r *Repository) DeleteSession(ctx context.Context, publicId string, _ ...Op
 // session state to "canceling" for the given reason, so the workers can get the
 // "canceling signal" during their next status heartbeat. CancelSession is
 // idempotent.
-func (r *Repository) CancelSession(ctx context.Context, sessionId string, sessionVersion uint32) (*Session, error) {
+//
+// Supported options: WithCancelReason, which is stored with the session and
+// sent to the worker so it can be passed on to the client.
+func (r *Repository) CancelSession(ctx context.Context, sessionId string, sessionVersion uint32, opt ...Option) (*Session, error) {
 	const op = "session.(Repository).CancelSession"
 	if sessionId == "" {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
@@ -341,7 +357,7 @@ func (r *Repository) CancelSession(ctx context.Context, sessionId string, sessio
 	if sessionVersion == 0 {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session version")
 	}
-	s, ss, err := r.updateState(ctx, sessionId, sessionVersion, StatusCanceling)
+	s, ss, err := r.updateState(ctx, sessionId, sessionVersion, StatusCanceling, opt...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -349,6 +365,109 @@ func (r *Repository) CancelSession(ctx context.Context, sessionId string, sessio
 	return s, nil
 }
 
+// CancelUserSessions cancels all sessions of the given users which have not
+// been terminated in a single transaction and returns them. Sessions which are
+// already canceling are canceled again so the returned sessions can be passed
+// on to their workers.
+//
+// Supported options: WithCancelReason.
+func (r *Repository) CancelUserSessions(ctx context.Context, userIds []string, opt ...Option) ([]*Session, error) {
+	const op = "session.(Repository).CancelUserSessions"
+	if len(userIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user ids")
+	}
+	var canceled []*Session
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			var err error
+			canceled, err = r.cancelSessionsTx(ctx, reader, w, cancelableUserSessions, []interface{}{userIds}, opt...)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return canceled, nil
+}
+
+// CancelSessions cancels the pending and active sessions with the given ids in
+// a single transaction and returns them. The sessions are locked and their
+// versions are read within the transaction, so sessions which are updated
+// concurrently are still canceled. Sessions which are canceling or terminated
+// by the time the transaction starts are skipped.
+//
+// Supported options: WithCancelReason.
+func (r *Repository) CancelSessions(ctx context.Context, sessionIds []string, opt ...Option) ([]*Session, error) {
+	const op = "session.(Repository).CancelSessions"
+	if len(sessionIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session ids")
+	}
+	var canceled []*Session
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			var err error
+			canceled, err = r.cancelSessionsTx(ctx, reader, w, cancelableSessions, []interface{}{sessionIds}, opt...)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return canceled, nil
+}
+
+// cancelSessionsTx cancels the sessions selected by query using the reader
+// and writer of an open transaction and returns them. query must select the
+// public id and version of each session and lock the sessions for update.
+func (r *Repository) cancelSessionsTx(ctx context.Context, reader db.Reader, w db.Writer, query string, args []interface{}, opt ...Option) ([]*Session, error) {
+	const op = "session.(Repository).cancelSessionsTx"
+	rows, err := reader.Query(ctx, query, args)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	type sessionVersion struct {
+		id      string
+		version uint32
+	}
+	var toCancel []sessionVersion
+	for rows.Next() {
+		var sv sessionVersion
+		if err := rows.Scan(&sv.id, &sv.version); err != nil {
+			rows.Close()
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		toCancel = append(toCancel, sv)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	rows.Close()
+
+	canceled := make([]*Session, 0, len(toCancel))
+	for _, sv := range toCancel {
+		s, ss, err := r.updateStateTx(ctx, reader, w, sv.id, sv.version, StatusCanceling, opt...)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to cancel session %s", sv.id)))
+		}
+		s.States = ss
+		canceled = append(canceled, s)
+	}
+	return canceled, nil
+}
+
 // TerminateSession sets a session's termination reason and it's state to
 // "terminated" Sessions cannot be terminated which still have connections that
 // are not closed.
@@ -716,8 +835,8 @@ func (r *Repository) ActivateSession(ctx context.Context, sessionId string, sess
 
 // updateState will update the session's state using the session id and its
 // version. updateState is idempotent. States are ordered by start time
-// descending. No options are currently supported.
-func (r *Repository) updateState(ctx context.Context, sessionId string, sessionVersion uint32, s Status, _ ...Option) (*Session, []*State, error) {
+// descending. Supported options: WithCancelReason.
+func (r *Repository) updateState(ctx context.Context, sessionId string, sessionVersion uint32, s Status, opt ...Option) (*Session, []*State, error) {
 	const op = "session.(Repository).updateState"
 	if sessionId == "" {
 		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
@@ -732,8 +851,7 @@ func (r *Repository) updateState(ctx context.Context, sessionId string, sessionV
 		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "you must call ActivateSession to update a session's state to active")
 	}
 
-	var rowsAffected int
-	updatedSession := AllocSession()
+	var updatedSession *Session
 	var returnedStates []*State
 	_, err := r.writer.DoTx(
 		ctx,
@@ -741,54 +859,161 @@ func (r *Repository) updateState(ctx context.Context, sessionId string, sessionV
 		db.ExpBackoff{},
 		func(reader db.Reader, w db.Writer) error {
 			var err error
-			// We need to update the session version as that's the aggregate
-			updatedSession.PublicId = sessionId
-			updatedSession.Version = uint32(sessionVersion) + 1
-			rowsUpdated, err := w.Update(ctx, &updatedSession, []string{"Version"}, nil, db.WithVersion(&sessionVersion))
-			if err != nil {
-				return errors.Wrap(ctx, err, op)
-			}
-			if rowsUpdated != 1 {
-				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated session and %d rows updated", rowsUpdated))
-			}
-			if len(updatedSession.CtTofuToken) > 0 {
-				databaseWrapper, err := r.kms.GetWrapper(ctx, updatedSession.ScopeId, kms.KeyPurposeDatabase)
-				if err != nil {
-					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
-				}
-				if err := updatedSession.decrypt(ctx, databaseWrapper); err != nil {
-					return errors.Wrap(ctx, err, op, errors.WithMsg("cannot decrypt session value"))
-				}
-			} else {
-				updatedSession.CtTofuToken = nil
-			}
-
-			rowsAffected, err = w.Exec(ctx, updateSessionState, []interface{}{
-				sql.Named("session_id", sessionId),
-				sql.Named("status", s.String()),
-			})
-			if err != nil {
-				return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to update session %s state to %s", sessionId, s.String())))
-			}
-			if rowsAffected != 0 && rowsAffected != 1 {
-				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated session %s to state %s and %d rows inserted (should be 0 or 1)", sessionId, s.String(), rowsAffected))
-			}
-			returnedStates, err = fetchStates(ctx, reader, sessionId, db.WithOrder("start_time desc"))
-			if err != nil {
-				return errors.Wrap(ctx, err, op)
-			}
-			if len(returnedStates) < 1 && returnedStates[0].Status != s {
-				return errors.New(ctx, errors.InvalidSessionState, op, fmt.Sprintf("failed to update %s to a state of %s", sessionId, s.String()))
-			}
-			return nil
+			updatedSession, returnedStates, err = r.updateStateTx(ctx, reader, w, sessionId, sessionVersion, s, opt...)
+			return err
 		},
 	)
 	if err != nil {
 		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("error creating new state"))
 	}
+	return updatedSession, returnedStates, nil
+}
+
+// updateStateTx updates the state of a session using the reader and writer
+// of an open transaction. Callers must validate the parameters.
+func (r *Repository) updateStateTx(ctx context.Context, reader db.Reader, w db.Writer, sessionId string, sessionVersion uint32, s Status, opt ...Option) (*Session, []*State, error) {
+	const op = "session.(Repository).updateStateTx"
+	opts := getOpts(opt...)
+	fieldMask := []string{"Version"}
+	updatedSession := AllocSession()
+	if opts.withCancelReason != "" {
+		updatedSession.CancelReason = opts.withCancelReason
+		fieldMask = append(fieldMask, "CancelReason")
+	}
+	// We need to update the session version as that's the aggregate
+	updatedSession.PublicId = sessionId
+	updatedSession.Version = uint32(sessionVersion) + 1
+	rowsUpdated, err := w.Update(ctx, &updatedSession, fieldMask, nil, db.WithVersion(&sessionVersion))
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	if rowsUpdated != 1 {
+		return nil, nil, errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated session and %d rows updated", rowsUpdated))
+	}
+	if len(updatedSession.CtTofuToken) > 0 {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, updatedSession.ScopeId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+		}
+		if err := updatedSession.decrypt(ctx, databaseWrapper); err != nil {
+			return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("cannot decrypt session value"))
+		}
+	} else {
+		updatedSession.CtTofuToken = nil
+	}
+
+	rowsAffected, err := w.Exec(ctx, updateSessionState, []interface{}{
+		sql.Named("session_id", sessionId),
+		sql.Named("status", s.String()),
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to update session %s state to %s", sessionId, s.String())))
+	}
+	if rowsAffected != 0 && rowsAffected != 1 {
+		return nil, nil, errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated session %s to state %s and %d rows inserted (should be 0 or 1)", sessionId, s.String(), rowsAffected))
+	}
+	returnedStates, err := fetchStates(ctx, reader, sessionId, db.WithOrder("start_time desc"))
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	if len(returnedStates) < 1 && returnedStates[0].Status != s {
+		return nil, nil, errors.New(ctx, errors.InvalidSessionState, op, fmt.Sprintf("failed to update %s to a state of %s", sessionId, s.String()))
+	}
 	return &updatedSession, returnedStates, nil
 }
 
+// ListRecentTerminations returns a termination for each session which was
+// canceled within window. It is used to push terminations to the workers
+// connected to every controller, not just the controller the session was
+// canceled on.
+func (r *Repository) ListRecentTerminations(ctx context.Context, window time.Duration) ([]Termination, error) {
+	const op = "session.(Repository).ListRecentTerminations"
+	if window <= 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing window")
+	}
+	rows, err := r.reader.Query(ctx, recentTerminations, []interface{}{window.Seconds()})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var terms []Termination
+	for rows.Next() {
+		var sessionId string
+		var serverId, reason sql.NullString
+		if err := rows.Scan(&sessionId, &serverId, &reason); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		terms = append(terms, Termination{
+			SessionId: sessionId,
+			ServerId:  serverId.String,
+			Reason:    reason.String,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return terms, nil
+}
+
+// AddEgressCredentials stores the egress credentials issued for the session
+// so they can be handed to every worker which looks up the session. creds is
+// encrypted with the database key of scopeId. An error with the code
+// errors.NotUnique is returned if credentials were already stored for the
+// session.
+func (r *Repository) AddEgressCredentials(ctx context.Context, sessionId, scopeId string, creds []byte) error {
+	const op = "session.(Repository).AddEgressCredentials"
+	switch {
+	case sessionId == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	case scopeId == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	case len(creds) == 0:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing credentials")
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	c := allocEgressCredentials()
+	c.SessionId = sessionId
+	c.Credentials = creds
+	if err := c.encrypt(ctx, databaseWrapper); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err := r.writer.Create(ctx, c); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// LookupEgressCredentials returns the egress credentials stored for the
+// session by AddEgressCredentials. If none were stored, it returns nil, nil.
+func (r *Repository) LookupEgressCredentials(ctx context.Context, sessionId, scopeId string) ([]byte, error) {
+	const op = "session.(Repository).LookupEgressCredentials"
+	switch {
+	case sessionId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	case scopeId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	c := allocEgressCredentials()
+	c.SessionId = sessionId
+	if err := r.reader.LookupById(ctx, c); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(c.KeyId))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := c.decrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return c.Credentials, nil
+}
+
 func fetchStates(ctx context.Context, r db.Reader, sessionId string, opt ...db.Option) ([]*State, error) {
 	const op = "session.fetchStates"
 	var states []*State
@@ -1163,6 +1163,38 @@ func TestRepository_CloseConnections(t *testing.T) {
 	}
 }
 
+func TestRepository_ListRecentTerminations(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+
+	_, err = repo.ListRecentTerminations(ctx, 0)
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+
+	canceled := TestDefaultSession(t, conn, wrapper, iamRepo)
+	_ = TestDefaultSession(t, conn, wrapper, iamRepo)
+	terms, err := repo.ListRecentTerminations(ctx, time.Minute)
+	require.NoError(err)
+	assert.Empty(terms)
+
+	_, err = repo.CancelSession(ctx, canceled.PublicId, canceled.Version, WithCancelReason("maintenance"))
+	require.NoError(err)
+	terms, err = repo.ListRecentTerminations(ctx, time.Minute)
+	require.NoError(err)
+	assert.Equal([]Termination{{
+		SessionId: canceled.PublicId,
+		ServerId:  canceled.ServerId,
+		Reason:    "maintenance",
+	}}, terms)
+}
+
 func TestRepository_CancelSession(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -1297,6 +1329,134 @@ func TestRepository_CancelSession(t *testing.T) {
 	}
 }
 
+func TestRepository_CancelUserSessions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	s1 := TestDefaultSession(t, conn, wrapper, iamRepo)
+	other := TestDefaultSession(t, conn, wrapper, iamRepo)
+
+	_, err = repo.CancelUserSessions(ctx, nil)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+
+	// The version is read when the session is canceled.
+	_, err = rw.Exec(ctx, "update session set version = version + 1 where public_id = ?", []interface{}{s1.PublicId})
+	require.NoError(t, err)
+
+	got, err := repo.CancelUserSessions(ctx, []string{s1.UserId}, WithCancelReason("auth tokens revoked"))
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, s1.PublicId, got[0].PublicId)
+	assert.Equal(t, "auth tokens revoked", got[0].CancelReason)
+	assert.Equal(t, StatusCanceling, got[0].States[0].Status)
+
+	// Canceling sessions are returned again.
+	got, err = repo.CancelUserSessions(ctx, []string{s1.UserId})
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+
+	found, _, err := repo.LookupSession(ctx, other.PublicId)
+	require.NoError(t, err)
+	assert.Equal(t, StatusPending, found.States[0].Status)
+}
+
+func TestRepository_CancelSessions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	s1 := TestDefaultSession(t, conn, wrapper, iamRepo)
+	s2 := TestDefaultSession(t, conn, wrapper, iamRepo)
+	canceling := TestDefaultSession(t, conn, wrapper, iamRepo)
+	other := TestDefaultSession(t, conn, wrapper, iamRepo)
+
+	_, err = repo.CancelSessions(ctx, nil)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+
+	// The versions are read when the sessions are canceled, so sessions
+	// updated since they were listed are canceled, and sessions which were
+	// canceled in the meantime are skipped.
+	_, err = repo.CancelSession(ctx, canceling.PublicId, canceling.Version, WithCancelReason("earlier"))
+	require.NoError(t, err)
+	_, err = rw.Exec(ctx, "update session set version = version + 1 where public_id = ?", []interface{}{s1.PublicId})
+	require.NoError(t, err)
+
+	got, err := repo.CancelSessions(ctx, []string{s1.PublicId, s2.PublicId, canceling.PublicId}, WithCancelReason("incident"))
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.ElementsMatch(t, []string{s1.PublicId, s2.PublicId}, []string{got[0].PublicId, got[1].PublicId})
+	for _, s := range got {
+		assert.Equal(t, "incident", s.CancelReason)
+		assert.Equal(t, StatusCanceling, s.States[0].Status)
+	}
+
+	found, _, err := repo.LookupSession(ctx, canceling.PublicId)
+	require.NoError(t, err)
+	assert.Equal(t, "earlier", found.CancelReason)
+	found, _, err = repo.LookupSession(ctx, other.PublicId)
+	require.NoError(t, err)
+	assert.Equal(t, StatusPending, found.States[0].Status)
+
+	// Only the sessions which are still pending are listed.
+	listed, err := repo.ListSessions(ctx, WithStates(StatusPending, StatusActive), WithLimit(-1))
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, other.PublicId, listed[0].PublicId)
+}
+
+func TestRepository_EgressCredentials(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	s := TestDefaultSession(t, conn, wrapper, iamRepo)
+
+	err = repo.AddEgressCredentials(ctx, s.PublicId, s.ScopeId, nil)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+
+	got, err := repo.LookupEgressCredentials(ctx, s.PublicId, s.ScopeId)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	require.NoError(t, repo.AddEgressCredentials(ctx, s.PublicId, s.ScopeId, []byte("creds")))
+	err = repo.AddEgressCredentials(ctx, s.PublicId, s.ScopeId, []byte("other creds"))
+	assert.True(t, errors.Match(errors.T(errors.NotUnique), err))
+
+	// Every lookup returns the credentials which were stored first.
+	for i := 0; i < 2; i++ {
+		got, err = repo.LookupEgressCredentials(ctx, s.PublicId, s.ScopeId)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("creds"), got)
+	}
+
+	// The credentials are deleted when the session is terminated.
+	_, err = repo.CancelSession(ctx, s.PublicId, s.Version)
+	require.NoError(t, err)
+	TestState(t, conn, s.PublicId, StatusTerminated)
+	got, err = repo.LookupEgressCredentials(ctx, s.PublicId, s.ScopeId)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
 func TestRepository_CancelSessionViaFKNull(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
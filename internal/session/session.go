@@ -49,6 +49,15 @@ type ComposedOf struct {
 	// existed at creation time. Round tripping it through here saves a lookup
 	// in the DB. It is not stored in the warehouse.
 	WorkerFilter string
+	// ConnectionIdleTimeoutSeconds is the number of seconds a connection may
+	// be idle before the worker closes it. 0 means no timeout.
+	ConnectionIdleTimeoutSeconds uint32
+	// MaxConcurrentConnections is the maximum number of connections which may
+	// be open at the same time. 0 means no limit.
+	MaxConcurrentConnections uint32
+	// MaxBytesPerSession is the maximum number of bytes which may be
+	// transferred over all connections of the session. 0 means no limit.
+	MaxBytesPerSession uint64
 	// DynamicCredentials are dynamic credentials that will be retrieved
 	// for the session. DynamicCredentials optional.
 	DynamicCredentials []*DynamicCredential
@@ -98,6 +107,15 @@ type Session struct {
 	ConnectionLimit int32 `json:"connection_limit,omitempty" gorm:"default:null"`
 	// Worker filter
 	WorkerFilter string `json:"-" gorm:"default:null"`
+	// Seconds a connection may be idle before it is closed
+	ConnectionIdleTimeoutSeconds uint32 `json:"connection_idle_timeout_seconds,omitempty" gorm:"default:null"`
+	// Maximum number of connections open at the same time
+	MaxConcurrentConnections uint32 `json:"max_concurrent_connections,omitempty" gorm:"default:null"`
+	// Maximum number of bytes transferred over all connections
+	MaxBytesPerSession uint64 `json:"max_bytes_per_session,omitempty" gorm:"default:null"`
+	// CancelReason is an optional message supplied when the session was
+	// canceled
+	CancelReason string `json:"cancel_reason,omitempty" gorm:"default:null"`
 
 	// key_id is the key ID that was used for the encryption operation. It can be
 	// used to identify a specific version of the key needed to decrypt the value,
@@ -142,6 +160,10 @@ func New(c ComposedOf, _ ...Option) (*Session, error) {
 		ConnectionLimit:    c.ConnectionLimit,
 		WorkerFilter:       c.WorkerFilter,
 		DynamicCredentials: c.DynamicCredentials,
+
+		ConnectionIdleTimeoutSeconds: c.ConnectionIdleTimeoutSeconds,
+		MaxConcurrentConnections:     c.MaxConcurrentConnections,
+		MaxBytesPerSession:           c.MaxBytesPerSession,
 	}
 	if err := s.validateNewSession(); err != nil {
 		return nil, errors.WrapDeprecated(err, op)
@@ -172,6 +194,11 @@ func (s *Session) Clone() interface{} {
 		ConnectionLimit:   s.ConnectionLimit,
 		WorkerFilter:      s.WorkerFilter,
 		KeyId:             s.KeyId,
+
+		ConnectionIdleTimeoutSeconds: s.ConnectionIdleTimeoutSeconds,
+		MaxConcurrentConnections:     s.MaxConcurrentConnections,
+		MaxBytesPerSession:           s.MaxBytesPerSession,
+		CancelReason:                 s.CancelReason,
 	}
 	if len(s.States) > 0 {
 		clone.States = make([]*State, 0, len(s.States))
@@ -270,6 +297,12 @@ func (s *Session) VetForWrite(ctx context.Context, _ db.Reader, opType db.OpType
 			return errors.New(ctx, errors.InvalidParameter, op, "connection limit is immutable")
 		case contains(opts.WithFieldMaskPaths, "WorkerFilter"):
 			return errors.New(ctx, errors.InvalidParameter, op, "worker filter is immutable")
+		case contains(opts.WithFieldMaskPaths, "ConnectionIdleTimeoutSeconds"):
+			return errors.New(ctx, errors.InvalidParameter, op, "connection idle timeout seconds is immutable")
+		case contains(opts.WithFieldMaskPaths, "MaxConcurrentConnections"):
+			return errors.New(ctx, errors.InvalidParameter, op, "max concurrent connections is immutable")
+		case contains(opts.WithFieldMaskPaths, "MaxBytesPerSession"):
+			return errors.New(ctx, errors.InvalidParameter, op, "max bytes per session is immutable")
 		case contains(opts.WithFieldMaskPaths, "DynamicCredentials"):
 			return errors.New(ctx, errors.InvalidParameter, op, "dynamic credentials are immutable")
 		case contains(opts.WithFieldMaskPaths, "TerminationReason"):
@@ -0,0 +1,97 @@
+package session
+
+import (
+	"sync"
+	"time"
+)
+
+// terminationBufferSize is the number of terminations buffered for each
+// subscriber. Terminations published to a subscriber whose buffer is full are
+// dropped; the worker will still learn of the cancellation during its next
+// status request.
+const terminationBufferSize = 100
+
+// TerminationPublishedWindow is how long a published termination is
+// remembered. Terminations of the same session published again within the
+// window are not delivered a second time, so a termination can be published
+// both when the session is canceled and when it is found by polling the
+// database.
+const TerminationPublishedWindow = time.Minute
+
+// Termination describes a session which was canceled and whose connections
+// should be closed immediately by the worker handling them.
+type Termination struct {
+	// SessionId of the canceled session
+	SessionId string
+	// ServerId of the worker handling the session. If empty the termination
+	// is sent to all workers.
+	ServerId string
+	// Reason supplied when the session was canceled, if any
+	Reason string
+}
+
+// TerminationBroadcaster delivers session terminations published on a
+// controller to the workers connected to that controller. Terminations of
+// sessions canceled on other controllers are only delivered if they are
+// published here too, e.g. by polling the database with
+// Repository.ListRecentTerminations.
+type TerminationBroadcaster struct {
+	mu          sync.Mutex
+	subscribers map[chan Termination]struct{}
+	published   map[string]time.Time
+}
+
+// NewTerminationBroadcaster creates a new TerminationBroadcaster.
+func NewTerminationBroadcaster() *TerminationBroadcaster {
+	return &TerminationBroadcaster{
+		subscribers: make(map[chan Termination]struct{}),
+		published:   make(map[string]time.Time),
+	}
+}
+
+// Subscribe returns a channel on which published terminations are received
+// and a function which must be called to unsubscribe once the caller is no
+// longer reading from the channel.
+func (b *TerminationBroadcaster) Subscribe() (<-chan Termination, func()) {
+	ch := make(chan Termination, terminationBufferSize)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+		})
+	}
+}
+
+// Publish sends t to all current subscribers without blocking, unless a
+// termination of the same session was published within
+// TerminationPublishedWindow. Publish is a no-op on a nil
+// TerminationBroadcaster.
+func (b *TerminationBroadcaster) Publish(t Termination) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	for id, at := range b.published {
+		if now.Sub(at) > TerminationPublishedWindow {
+			delete(b.published, id)
+		}
+	}
+	if _, ok := b.published[t.SessionId]; ok {
+		return
+	}
+	b.published[t.SessionId] = now
+	for ch := range b.subscribers {
+		select {
+		case ch <- t:
+		default:
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package session
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerminationBroadcaster(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+
+	b := NewTerminationBroadcaster()
+	ch1, unsub1 := b.Subscribe()
+	ch2, unsub2 := b.Subscribe()
+	defer unsub2()
+
+	term := Termination{SessionId: "s_1234567890", ServerId: "w_1", Reason: "maintenance"}
+	b.Publish(term)
+	assert.Equal(term, <-ch1)
+	assert.Equal(term, <-ch2)
+
+	unsub1()
+	unsub1() // unsubscribing more than once is a no-op
+	b.Publish(Termination{SessionId: "s_0987654321"})
+	assert.Len(ch1, 0)
+	assert.Equal("s_0987654321", (<-ch2).SessionId)
+
+	// A session published again within the window is not delivered twice
+	b.Publish(term)
+	assert.Len(ch2, 0)
+
+	// Publishing to a full subscriber does not block
+	for i := 0; i < terminationBufferSize+1; i++ {
+		b.Publish(Termination{SessionId: fmt.Sprintf("s_%010d", i)})
+	}
+	require.Len(ch2, terminationBufferSize)
+
+	var nilBroadcaster *TerminationBroadcaster
+	assert.NotPanics(func() { nilBroadcaster.Publish(term) })
+}
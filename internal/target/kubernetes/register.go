@@ -0,0 +1,55 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/target"
+)
+
+func init() {
+	target.Register(Subtype, newTarget, allocTarget, vet, vetCredentialLibraries, TargetPrefix)
+}
+
+const (
+	// TargetPrefix is the prefix for public ids of a kubernetes.Target.
+	TargetPrefix = "tkube"
+)
+
+// vet validates that the given target.Target is a kubernetes.Target and that it
+// has a Target store.
+func vet(ctx context.Context, t target.Target) error {
+	const op = "kubernetes.vet"
+
+	tt, ok := t.(*Target)
+	if !ok {
+		return errors.New(ctx, errors.InvalidParameter, op, "target is not a kubernetes.Target")
+	}
+
+	if tt == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target")
+	}
+
+	if tt.Target == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target store")
+	}
+	return nil
+}
+
+// vetCredentialLibraries checks that all of the provided credential libraries
+// have a CredentialPurpose of EgressPurpose. The worker uses egress credentials
+// to authenticate to the Kubernetes API server on behalf of the user, so they
+// are never returned to the client. Any other CredentialPurpose will result in
+// an error.
+func vetCredentialLibraries(ctx context.Context, cls []*target.CredentialLibrary) error {
+	const op = "kubernetes.vetCredentialLibraries"
+
+	for _, cl := range cls {
+		if cl.CredentialPurpose != string(credential.EgressPurpose) {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("kubernetes.Target only supports credential purpose: %q", credential.EgressPurpose))
+		}
+	}
+	return nil
+}
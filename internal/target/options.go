@@ -3,6 +3,7 @@ package target
 import (
 	"time"
 
+	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/types/subtypes"
 )
 
@@ -35,6 +36,13 @@ type options struct {
 	WithSessionConnectionLimit int32
 	WithPublicId               string
 	WithWorkerFilter           string
+	WithLabels                 map[string]string
+	WithPermissions            []perms.Permission
+
+	WithConnectionIdleTimeoutSeconds uint32
+	WithMaxConcurrentConnections     uint32
+	WithMaxBytesPerSession           uint64
+	WithWorkerSelectionStrategy      string
 }
 
 func getDefaultOptions() options {
@@ -54,6 +62,13 @@ func getDefaultOptions() options {
 		WithSessionConnectionLimit: 1,
 		WithPublicId:               "",
 		WithWorkerFilter:           "",
+		WithLabels:                 nil,
+		WithPermissions:            nil,
+
+		WithConnectionIdleTimeoutSeconds: 0,
+		WithMaxConcurrentConnections:     0,
+		WithMaxBytesPerSession:           0,
+		WithWorkerSelectionStrategy:      "",
 	}
 }
 
@@ -161,3 +176,51 @@ func WithWorkerFilter(filter string) Option {
 		o.WithWorkerFilter = filter
 	}
 }
+
+// WithLabels provides an option to search for targets carrying all of the
+// labels.
+func WithLabels(labels map[string]string) Option {
+	return func(o *options) {
+		o.WithLabels = labels
+	}
+}
+
+// WithPermissions provides an option to search for the targets allowed by
+// the permissions.
+func WithPermissions(p []perms.Permission) Option {
+	return func(o *options) {
+		o.WithPermissions = p
+	}
+}
+
+// WithConnectionIdleTimeoutSeconds provides an optional number of seconds a
+// session connection may be idle before it is closed.
+func WithConnectionIdleTimeoutSeconds(s uint32) Option {
+	return func(o *options) {
+		o.WithConnectionIdleTimeoutSeconds = s
+	}
+}
+
+// WithMaxConcurrentConnections provides an optional maximum number of
+// connections of a session that may be open at the same time.
+func WithMaxConcurrentConnections(n uint32) Option {
+	return func(o *options) {
+		o.WithMaxConcurrentConnections = n
+	}
+}
+
+// WithMaxBytesPerSession provides an optional maximum number of bytes that may
+// be transferred over all connections of a session.
+func WithMaxBytesPerSession(n uint64) Option {
+	return func(o *options) {
+		o.WithMaxBytesPerSession = n
+	}
+}
+
+// WithWorkerSelectionStrategy provides an optional strategy used to order the
+// workers which can handle a session.
+func WithWorkerSelectionStrategy(s string) Option {
+	return func(o *options) {
+		o.WithWorkerSelectionStrategy = s
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/target/store"
 	"github.com/hashicorp/boundary/internal/types/subtypes"
 	"github.com/stretchr/testify/assert"
@@ -108,6 +109,34 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.WithWorkerFilter = `"/foo" == "bar"`
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithConnectionIdleTimeoutSeconds", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithConnectionIdleTimeoutSeconds(300))
+		testOpts := getDefaultOptions()
+		testOpts.WithConnectionIdleTimeoutSeconds = 300
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithMaxConcurrentConnections", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithMaxConcurrentConnections(5))
+		testOpts := getDefaultOptions()
+		testOpts.WithMaxConcurrentConnections = 5
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithMaxBytesPerSession", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithMaxBytesPerSession(1 << 30))
+		testOpts := getDefaultOptions()
+		testOpts.WithMaxBytesPerSession = 1 << 30
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithWorkerSelectionStrategy", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithWorkerSelectionStrategy(WorkerSelectionLeastConnections))
+		testOpts := getDefaultOptions()
+		testOpts.WithWorkerSelectionStrategy = WorkerSelectionLeastConnections
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithCredentialLibraries", func(t *testing.T) {
 		assert := assert.New(t)
 		opts := GetOpts(WithCredentialLibraries([]*CredentialLibrary{
@@ -141,4 +170,18 @@ func Test_GetOpts(t *testing.T) {
 		}
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithLabels", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithLabels(map[string]string{"env": "prod"}))
+		testOpts := getDefaultOptions()
+		testOpts.WithLabels = map[string]string{"env": "prod"}
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithPermissions", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithPermissions([]perms.Permission{{ScopeId: "p_1234567890", All: true}}))
+		testOpts := getDefaultOptions()
+		testOpts.WithPermissions = []perms.Permission{{ScopeId: "p_1234567890", All: true}}
+		assert.Equal(opts, testOpts)
+	})
 }
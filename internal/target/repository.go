@@ -9,7 +9,10 @@ import (
 	dbcommon "github.com/hashicorp/boundary/internal/db/common"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/label"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/resource"
 )
 
 // Cloneable provides a cloning interface
@@ -138,12 +141,13 @@ func (r *Repository) LookupTarget(ctx context.Context, publicIdOrName string, op
 	return subtype, hostSources, credSources, nil
 }
 
-// ListTargets in targets in a scope.  Supports the WithScopeId, WithLimit, WithType options.
+// ListTargets in targets in a scope.  Supports the WithScopeIds, WithLimit,
+// WithType, WithLabels and WithPermissions options.
 func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target, error) {
 	const op = "target.(Repository).ListTargets"
 	opts := GetOpts(opt...)
-	if len(opts.WithScopeIds) == 0 && opts.WithUserId == "" {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "must specify either scope id or user id")
+	if len(opts.WithScopeIds) == 0 && opts.WithUserId == "" && len(opts.WithPermissions) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "must specify either scope id, user id or permissions")
 	}
 	// TODO (jimlambrt 8/2020) - implement WithUserId() optional filtering.
 	var where []string
@@ -151,9 +155,20 @@ func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target,
 	if len(opts.WithScopeIds) != 0 {
 		where, args = append(where, "scope_id in (?)"), append(args, opts.WithScopeIds)
 	}
+	if len(opts.WithPermissions) != 0 {
+		permWhere, permArgs := permissionsWhere(opts.WithPermissions)
+		where, args = append(where, permWhere), append(args, permArgs...)
+	}
 	if opts.WithType != "" {
 		where, args = append(where, "type = ?"), append(args, opts.WithType.String())
 	}
+	if len(opts.WithLabels) != 0 {
+		labelWhere, labelArgs, err := label.Where(ctx, resource.Target, opts.WithLabels)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		where, args = append(where, labelWhere), append(args, labelArgs...)
+	}
 
 	var foundTargets []*targetView
 	err := r.list(ctx, &foundTargets, strings.Join(where, " and "), args, opt...)
@@ -173,6 +188,30 @@ func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target,
 	return targets, nil
 }
 
+// permissionsWhere returns a condition, and its arguments, matching the
+// targets allowed by permissions. Grants on specific ids only apply within
+// the scope of the grant.
+func permissionsWhere(permissions []perms.Permission) (string, []interface{}) {
+	var where []string
+	var args []interface{}
+	var allScopeIds []string
+	for _, p := range permissions {
+		switch {
+		case p.All:
+			allScopeIds = append(allScopeIds, p.ScopeId)
+		case len(p.ResourceIds) > 0:
+			where, args = append(where, "(scope_id = ? and public_id in (?))"), append(args, p.ScopeId, p.ResourceIds)
+		}
+	}
+	if len(allScopeIds) > 0 {
+		where, args = append([]string{"scope_id in (?)"}, where...), append([]interface{}{allScopeIds}, args...)
+	}
+	if len(where) == 0 {
+		return "false", nil
+	}
+	return "(" + strings.Join(where, " or ") + ")", args
+}
+
 // list will return a listing of resources and honor the WithLimit option or the
 // repo defaultLimit
 func (r *Repository) list(ctx context.Context, resources interface{}, where string, args []interface{}, opt ...Option) error {
@@ -407,8 +446,9 @@ func (r *Repository) CreateTarget(ctx context.Context, target Target, opt ...Opt
 // UpdateTarget will update a target in the repository and return the written
 // target. fieldMaskPaths provides field_mask.proto paths for fields that should
 // be updated.  Fields will be set to NULL if the field is a zero value and
-// included in fieldMask. Name, Description, and WorkerFilter are the only
-// updatable fields. If no updatable fields are included in the fieldMaskPaths,
+// included in fieldMask. Name, Description, DefaultPort, SessionMaxSeconds,
+// SessionConnectionLimit, WorkerFilter, ConnectionIdleTimeoutSeconds,
+// MaxConcurrentConnections, MaxBytesPerSession and WorkerSelectionStrategy are the only updatable fields. If no updatable fields are included in the fieldMaskPaths,
 // then an error is returned.
 func (r *Repository) UpdateTarget(ctx context.Context, target Target, version uint32, fieldMaskPaths []string, _ ...Option) (Target, []HostSource, []CredentialSource, int, error) {
 	const op = "target.(Repository).UpdateTarget"
@@ -435,6 +475,10 @@ func (r *Repository) UpdateTarget(ctx context.Context, target Target, version ui
 		case strings.EqualFold("sessionmaxseconds", f):
 		case strings.EqualFold("sessionconnectionlimit", f):
 		case strings.EqualFold("workerfilter", f):
+		case strings.EqualFold("connectionidletimeoutseconds", f):
+		case strings.EqualFold("maxconcurrentconnections", f):
+		case strings.EqualFold("maxbytespersession", f):
+		case strings.EqualFold("workerselectionstrategy", f):
 		default:
 			return nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
 		}
@@ -448,9 +492,14 @@ func (r *Repository) UpdateTarget(ctx context.Context, target Target, version ui
 			"SessionMaxSeconds":      target.GetSessionMaxSeconds(),
 			"SessionConnectionLimit": target.GetSessionConnectionLimit(),
 			"WorkerFilter":           target.GetWorkerFilter(),
+
+			"ConnectionIdleTimeoutSeconds": target.GetConnectionIdleTimeoutSeconds(),
+			"MaxConcurrentConnections":     target.GetMaxConcurrentConnections(),
+			"MaxBytesPerSession":           target.GetMaxBytesPerSession(),
+			"WorkerSelectionStrategy":      target.GetWorkerSelectionStrategy(),
 		},
 		fieldMaskPaths,
-		[]string{"SessionMaxSeconds", "SessionConnectionLimit"},
+		[]string{"SessionMaxSeconds", "SessionConnectionLimit", "ConnectionIdleTimeoutSeconds", "MaxConcurrentConnections", "MaxBytesPerSession"},
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
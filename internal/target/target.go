@@ -25,6 +25,10 @@ type Target interface {
 	GetSessionMaxSeconds() uint32
 	GetSessionConnectionLimit() int32
 	GetWorkerFilter() string
+	GetConnectionIdleTimeoutSeconds() uint32
+	GetMaxConcurrentConnections() uint32
+	GetMaxBytesPerSession() uint64
+	GetWorkerSelectionStrategy() string
 	Clone() Target
 	SetPublicId(context.Context, string) error
 	SetScopeId(string)
@@ -37,6 +41,10 @@ type Target interface {
 	SetSessionMaxSeconds(uint32)
 	SetSessionConnectionLimit(int32)
 	SetWorkerFilter(string)
+	SetConnectionIdleTimeoutSeconds(uint32)
+	SetMaxConcurrentConnections(uint32)
+	SetMaxBytesPerSession(uint64)
+	SetWorkerSelectionStrategy(string)
 	Oplog(op oplog.OpType) oplog.Metadata
 }
 
@@ -104,5 +112,9 @@ func (t *targetView) targetSubtype(ctx context.Context) (Target, error) {
 	tt.SetSessionMaxSeconds(t.SessionMaxSeconds)
 	tt.SetSessionConnectionLimit(t.SessionConnectionLimit)
 	tt.SetWorkerFilter(t.WorkerFilter)
+	tt.SetConnectionIdleTimeoutSeconds(t.ConnectionIdleTimeoutSeconds)
+	tt.SetMaxConcurrentConnections(t.MaxConcurrentConnections)
+	tt.SetMaxBytesPerSession(t.MaxBytesPerSession)
+	tt.SetWorkerSelectionStrategy(t.WorkerSelectionStrategy)
 	return tt, nil
 }
@@ -116,6 +116,22 @@ func (t *Target) GetWorkerFilter() string {
 	return t.WorkerFilter
 }
 
+func (t *Target) GetConnectionIdleTimeoutSeconds() uint32 {
+	return t.ConnectionIdleTimeoutSeconds
+}
+
+func (t *Target) GetMaxConcurrentConnections() uint32 {
+	return t.MaxConcurrentConnections
+}
+
+func (t *Target) GetMaxBytesPerSession() uint64 {
+	return t.MaxBytesPerSession
+}
+
+func (t *Target) GetWorkerSelectionStrategy() string {
+	return t.WorkerSelectionStrategy
+}
+
 func (t *Target) Clone() target.Target {
 	cp := proto.Clone(t.Target)
 	return &Target{
@@ -168,6 +184,22 @@ func (t *Target) SetWorkerFilter(f string) {
 	t.WorkerFilter = f
 }
 
+func (t *Target) SetConnectionIdleTimeoutSeconds(s uint32) {
+	t.ConnectionIdleTimeoutSeconds = s
+}
+
+func (t *Target) SetMaxConcurrentConnections(n uint32) {
+	t.MaxConcurrentConnections = n
+}
+
+func (t *Target) SetMaxBytesPerSession(n uint64) {
+	t.MaxBytesPerSession = n
+}
+
+func (t *Target) SetWorkerSelectionStrategy(s string) {
+	t.WorkerSelectionStrategy = s
+}
+
 func (t *Target) Oplog(op oplog.OpType) oplog.Metadata {
 	return oplog.Metadata{
 		"resource-public-id": []string{t.PublicId},
@@ -224,6 +256,11 @@ func New(scopeId string, opt ...target.Option) (target.Target, error) {
 			SessionConnectionLimit: opts.WithSessionConnectionLimit,
 			SessionMaxSeconds:      opts.WithSessionMaxSeconds,
 			WorkerFilter:           opts.WithWorkerFilter,
+
+			ConnectionIdleTimeoutSeconds: opts.WithConnectionIdleTimeoutSeconds,
+			MaxConcurrentConnections:     opts.WithMaxConcurrentConnections,
+			MaxBytesPerSession:           opts.WithMaxBytesPerSession,
+			WorkerSelectionStrategy:      opts.WithWorkerSelectionStrategy,
 		},
 	}
 	return t, nil
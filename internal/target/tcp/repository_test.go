@@ -11,9 +11,12 @@ import (
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/label"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/target"
 	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -266,6 +269,85 @@ func TestRepository_ListRoles_Multiple_Scopes(t *testing.T) {
 	assert.Equal(t, total, len(got))
 }
 
+func TestRepository_ListTargets_LabelsAndPermissions(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, proj1 := iam.TestScopes(t, iamRepo)
+	_, proj2 := iam.TestScopes(t, iamRepo)
+	rw := db.New(conn)
+	repo, err := target.NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	labelRepo, err := label.NewRepository(rw, rw)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	prod1 := tcp.TestTarget(ctx, t, conn, proj1.GetPublicId(), "prod1")
+	dev1 := tcp.TestTarget(ctx, t, conn, proj1.GetPublicId(), "dev1")
+	prod2 := tcp.TestTarget(ctx, t, conn, proj2.GetPublicId(), "prod2")
+	require.NoError(t, labelRepo.SetLabels(ctx, resource.Target, prod1.GetPublicId(), map[string]string{"env": "prod", "team": "payments"}))
+	require.NoError(t, labelRepo.SetLabels(ctx, resource.Target, dev1.GetPublicId(), map[string]string{"env": "dev", "team": "payments"}))
+	require.NoError(t, labelRepo.SetLabels(ctx, resource.Target, prod2.GetPublicId(), map[string]string{"env": "prod"}))
+
+	ids := func(targets []target.Target) []string {
+		var ret []string
+		for _, t := range targets {
+			ret = append(ret, t.GetPublicId())
+		}
+		return ret
+	}
+	tests := []struct {
+		name string
+		opt  []target.Option
+		want []string
+	}{
+		{
+			name: "label",
+			opt: []target.Option{
+				target.WithScopeIds([]string{proj1.GetPublicId(), proj2.GetPublicId()}),
+				target.WithLabels(map[string]string{"env": "prod"}),
+			},
+			want: []string{prod1.GetPublicId(), prod2.GetPublicId()},
+		},
+		{
+			name: "all-labels",
+			opt: []target.Option{
+				target.WithScopeIds([]string{proj1.GetPublicId(), proj2.GetPublicId()}),
+				target.WithLabels(map[string]string{"env": "prod", "team": "payments"}),
+			},
+			want: []string{prod1.GetPublicId()},
+		},
+		{
+			name: "permissions",
+			opt: []target.Option{
+				target.WithPermissions([]perms.Permission{
+					{ScopeId: proj1.GetPublicId(), ResourceIds: []string{dev1.GetPublicId(), prod2.GetPublicId()}},
+					{ScopeId: proj2.GetPublicId(), All: true},
+				}),
+			},
+			want: []string{dev1.GetPublicId(), prod2.GetPublicId()},
+		},
+		{
+			name: "permissions-and-label",
+			opt: []target.Option{
+				target.WithPermissions([]perms.Permission{{ScopeId: proj1.GetPublicId(), All: true}}),
+				target.WithLabels(map[string]string{"env": "prod"}),
+			},
+			want: []string{prod1.GetPublicId()},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.ListTargets(ctx, tt.opt...)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, ids(got))
+		})
+	}
+}
+
 func TestRepository_DeleteTarget(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -54,6 +54,11 @@ func newTarget(scopeId string, opt ...target.Option) (target.Target, error) {
 			SessionConnectionLimit: opts.WithSessionConnectionLimit,
 			SessionMaxSeconds:      opts.WithSessionMaxSeconds,
 			WorkerFilter:           opts.WithWorkerFilter,
+
+			ConnectionIdleTimeoutSeconds: opts.WithConnectionIdleTimeoutSeconds,
+			MaxConcurrentConnections:     opts.WithMaxConcurrentConnections,
+			MaxBytesPerSession:           opts.WithMaxBytesPerSession,
+			WorkerSelectionStrategy:      opts.WithWorkerSelectionStrategy,
 		},
 	}
 	return t, nil
@@ -171,3 +176,19 @@ func (t *Target) SetSessionConnectionLimit(limit int32) {
 func (t *Target) SetWorkerFilter(filter string) {
 	t.WorkerFilter = filter
 }
+
+func (t *Target) SetConnectionIdleTimeoutSeconds(s uint32) {
+	t.ConnectionIdleTimeoutSeconds = s
+}
+
+func (t *Target) SetMaxConcurrentConnections(n uint32) {
+	t.MaxConcurrentConnections = n
+}
+
+func (t *Target) SetMaxBytesPerSession(n uint64) {
+	t.MaxBytesPerSession = n
+}
+
+func (t *Target) SetWorkerSelectionStrategy(s string) {
+	t.WorkerSelectionStrategy = s
+}
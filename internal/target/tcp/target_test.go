@@ -61,6 +61,33 @@ func TestTarget_Create(t *testing.T) {
 			}(),
 			create: true,
 		},
+		{
+			name: "valid-connection-limits",
+			args: args{
+				scopeId: prj.PublicId,
+				opt: []target.Option{
+					target.WithName("valid-connection-limits"),
+					target.WithConnectionIdleTimeoutSeconds(300),
+					target.WithMaxConcurrentConnections(2),
+					target.WithMaxBytesPerSession(1 << 32),
+				},
+			},
+			want: func() target.Target {
+				t, _ := target.New(
+					ctx,
+					tcp.Subtype,
+					prj.PublicId,
+					target.WithName("valid-connection-limits"),
+					target.WithSessionMaxSeconds(uint32((8 * time.Hour).Seconds())),
+					target.WithSessionConnectionLimit(1),
+					target.WithConnectionIdleTimeoutSeconds(300),
+					target.WithMaxConcurrentConnections(2),
+					target.WithMaxBytesPerSession(1<<32),
+				)
+				return t
+			}(),
+			create: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,42 @@
+package target
+
+// The strategies used to order the workers which can handle a session of a
+// target. The client tries the workers in the order they are returned, so the
+// first worker is the one the strategy prefers.
+const (
+	// WorkerSelectionRandom shuffles the workers.
+	WorkerSelectionRandom = "random"
+
+	// WorkerSelectionLeastConnections prefers the workers with the fewest
+	// active connections, as of their last status report.
+	WorkerSelectionLeastConnections = "least-connections"
+
+	// WorkerSelectionLowestLatency prefers the workers with the lowest value
+	// of the WorkerLatencyTagKey tag.
+	WorkerSelectionLowestLatency = "lowest-latency"
+
+	// WorkerSelectionUserAffinity orders the workers by a hash of the user and
+	// worker ids, so sessions of a user land on the same worker while it is
+	// available.
+	WorkerSelectionUserAffinity = "user-affinity"
+)
+
+// WorkerLatencyTagKey is the worker tag read by the lowest-latency strategy.
+// Its value is either a duration, such as "25ms", or an integer number of
+// milliseconds.
+const WorkerLatencyTagKey = "latency"
+
+// ValidWorkerSelectionStrategy reports whether s is a supported worker
+// selection strategy. The empty string is valid and leaves the workers
+// unordered.
+func ValidWorkerSelectionStrategy(s string) bool {
+	switch s {
+	case "",
+		WorkerSelectionRandom,
+		WorkerSelectionLeastConnections,
+		WorkerSelectionLowestLatency,
+		WorkerSelectionUserAffinity:
+		return true
+	}
+	return false
+}
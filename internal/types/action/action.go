@@ -53,6 +53,16 @@ const (
 	AddHostSources            Type = 42
 	SetHostSources            Type = 43
 	RemoveHostSources         Type = 44
+	EnrollTotp                Type = 45
+	RemoveTotp                Type = 46
+	Run                       Type = 47
+	RevokeTokens              Type = 48
+	AddCredential             Type = 49
+	RemoveCredential          Type = 50
+	RotateScimToken           Type = 51
+	DeleteScimToken           Type = 52
+	ReadActivity              Type = 53
+	CancelSessions            Type = 54
 )
 
 var Map = map[string]Type{
@@ -100,6 +110,16 @@ var Map = map[string]Type{
 	AddHostSources.String():            AddHostSources,
 	SetHostSources.String():            SetHostSources,
 	RemoveHostSources.String():         RemoveHostSources,
+	EnrollTotp.String():                EnrollTotp,
+	RemoveTotp.String():                RemoveTotp,
+	Run.String():                       Run,
+	RevokeTokens.String():              RevokeTokens,
+	AddCredential.String():             AddCredential,
+	RemoveCredential.String():          RemoveCredential,
+	RotateScimToken.String():           RotateScimToken,
+	DeleteScimToken.String():           DeleteScimToken,
+	ReadActivity.String():              ReadActivity,
+	CancelSessions.String():            CancelSessions,
 }
 
 func (a Type) String() string {
@@ -149,6 +169,16 @@ func (a Type) String() string {
 		"add-host-sources",
 		"set-host-sources",
 		"remove-host-sources",
+		"enroll-totp",
+		"remove-totp",
+		"run",
+		"revoke-tokens",
+		"add-credential",
+		"remove-credential",
+		"rotate-scim-token",
+		"delete-scim-token",
+		"read-activity",
+		"cancel-sessions",
 	}[a]
 }
 
@@ -95,6 +95,46 @@ func TestAction(t *testing.T) {
 			action: NoOp,
 			want:   "no-op",
 		},
+		{
+			action: EnrollTotp,
+			want:   "enroll-totp",
+		},
+		{
+			action: RemoveTotp,
+			want:   "remove-totp",
+		},
+		{
+			action: Run,
+			want:   "run",
+		},
+		{
+			action: RevokeTokens,
+			want:   "revoke-tokens",
+		},
+		{
+			action: AddCredential,
+			want:   "add-credential",
+		},
+		{
+			action: RemoveCredential,
+			want:   "remove-credential",
+		},
+		{
+			action: RotateScimToken,
+			want:   "rotate-scim-token",
+		},
+		{
+			action: DeleteScimToken,
+			want:   "delete-scim-token",
+		},
+		{
+			action: ReadActivity,
+			want:   "read-activity",
+		},
+		{
+			action: CancelSessions,
+			want:   "cancel-sessions",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.want, func(t *testing.T) {
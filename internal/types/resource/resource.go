@@ -25,6 +25,11 @@ const (
 	ManagedGroup
 	CredentialStore
 	CredentialLibrary
+	OplogChange
+	Report
+	ServiceAccount
+	Notification
+	Alias
 	// NOTE: When adding a new type, be sure to update:
 	//
 	// * The Grant.validateType function and test
@@ -57,6 +62,11 @@ func (r Type) String() string {
 		"managed-group",
 		"credential-store",
 		"credential-library",
+		"oplog-change",
+		"report",
+		"service-account",
+		"notification",
+		"alias",
 	}[r]
 }
 
@@ -64,6 +74,8 @@ func (r Type) PluralString() string {
 	switch r {
 	case CredentialLibrary:
 		return "credential-libraries"
+	case Alias:
+		return "aliases"
 	default:
 		return r.String() + "s"
 	}
@@ -89,4 +101,9 @@ var Map = map[string]Type{
 	ManagedGroup.String():      ManagedGroup,
 	CredentialStore.String():   CredentialStore,
 	CredentialLibrary.String(): CredentialLibrary,
+	OplogChange.String():       OplogChange,
+	Report.String():            Report,
+	ServiceAccount.String():    ServiceAccount,
+	Notification.String():      Notification,
+	Alias.String():             Alias,
 }
@@ -87,6 +87,26 @@ func Test_Resource(t *testing.T) {
 			typeString: "credential-library",
 			want:       CredentialLibrary,
 		},
+		{
+			typeString: "oplog-change",
+			want:       OplogChange,
+		},
+		{
+			typeString: "report",
+			want:       Report,
+		},
+		{
+			typeString: "service-account",
+			want:       ServiceAccount,
+		},
+		{
+			typeString: "notification",
+			want:       Notification,
+		},
+		{
+			typeString: "alias",
+			want:       Alias,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.typeString, func(t *testing.T) {
@@ -0,0 +1,147 @@
+package warehouse
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// A Destination stores exported files.
+type Destination interface {
+	// Put stores data under name, replacing any existing file with the
+	// same name. Names are slash separated relative paths.
+	Put(ctx context.Context, name string, data []byte) error
+}
+
+// LocalDirectory is a Destination which stores files in a directory of the
+// local file system.
+type LocalDirectory struct {
+	dir string
+}
+
+// NewLocalDirectory creates a LocalDirectory for dir. The directory is
+// created if it does not exist.
+func NewLocalDirectory(ctx context.Context, dir string) (*LocalDirectory, error) {
+	const op = "warehouse.NewLocalDirectory"
+	if dir == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing directory")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create export directory"))
+	}
+	return &LocalDirectory{dir: dir}, nil
+}
+
+// Put implements Destination. The file is written to a temporary file which
+// is renamed once complete so partially written files are never visible.
+func (d *LocalDirectory) Put(ctx context.Context, name string, data []byte) error {
+	const op = "warehouse.(LocalDirectory).Put"
+	if name == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing name")
+	}
+	p := filepath.Join(d.dir, filepath.FromSlash(path.Clean("/"+name)))
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".export-*")
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrap(ctx, err, op)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// S3Config configures an S3Bucket.
+type S3Config struct {
+	// Endpoint is the URL of an S3 compatible service. If empty, AWS S3 is
+	// used.
+	Endpoint string
+	// Region defaults to us-east-1.
+	Region string
+	Bucket string
+	// Prefix is prepended to the names of the exported files.
+	Prefix string
+	// AccessKeyId and SecretAccessKey are the static credentials to use. If
+	// they are empty, the default AWS credential chain is used.
+	AccessKeyId     string
+	SecretAccessKey string
+	// ForcePathStyle puts the bucket name in the path of requests rather
+	// than in the host name, which most S3 compatible services require.
+	ForcePathStyle bool
+}
+
+// S3Bucket is a Destination which stores files in a bucket of AWS S3 or an S3
+// compatible service.
+type S3Bucket struct {
+	client *s3.S3
+	bucket string
+	prefix string
+}
+
+// NewS3Bucket creates an S3Bucket from the config.
+func NewS3Bucket(ctx context.Context, c S3Config) (*S3Bucket, error) {
+	const op = "warehouse.NewS3Bucket"
+	if c.Bucket == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing bucket")
+	}
+	if (c.AccessKeyId == "") != (c.SecretAccessKey == "") {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "access key id and secret access key must be set together")
+	}
+	region := c.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	awsConf := aws.NewConfig().
+		WithRegion(region).
+		WithS3ForcePathStyle(c.ForcePathStyle)
+	if c.Endpoint != "" {
+		awsConf = awsConf.WithEndpoint(c.Endpoint)
+	}
+	if c.AccessKeyId != "" {
+		awsConf = awsConf.WithCredentials(credentials.NewStaticCredentials(c.AccessKeyId, c.SecretAccessKey, ""))
+	}
+	sess, err := session.NewSession(awsConf)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create aws session"))
+	}
+	return &S3Bucket{
+		client: s3.New(sess),
+		bucket: c.Bucket,
+		prefix: c.Prefix,
+	}, nil
+}
+
+// Put implements Destination.
+func (b *S3Bucket) Put(ctx context.Context, name string, data []byte) error {
+	const op = "warehouse.(S3Bucket).Put"
+	if name == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing name")
+	}
+	_, err := b.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(path.Join(b.prefix, name)),
+		Body:   bytes.NewReader(data),
+	})
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to put object"))
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package warehouse
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalDirectory_Put(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "export")
+
+	_, err := NewLocalDirectory(ctx, "")
+	require.Error(t, err)
+
+	d, err := NewLocalDirectory(ctx, dir)
+	require.NoError(t, err)
+	require.Error(t, d.Put(ctx, "", []byte("x")))
+
+	require.NoError(t, d.Put(ctx, "t/t_1.csv", []byte("one")))
+	require.NoError(t, d.Put(ctx, "t/t_1.csv", []byte("two")))
+	got, err := os.ReadFile(filepath.Join(dir, "t", "t_1.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "two", string(got))
+
+	// Names can not escape the directory.
+	require.NoError(t, d.Put(ctx, "../escape.csv", []byte("three")))
+	_, err = os.Stat(filepath.Join(dir, "escape.csv"))
+	assert.NoError(t, err)
+
+	entries, err := os.ReadDir(filepath.Join(dir, "t"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files should be removed")
+}
+
+// testS3Server is a minimal stand-in for an S3 compatible service which
+// stores the bodies of PUT requests by path.
+type testS3Server struct {
+	sync.Mutex
+	objects map[string]string
+	auth    []string
+}
+
+func (s *testS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.objects[r.URL.Path] = string(body)
+	s.auth = append(s.auth, r.Header.Get("Authorization"))
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestS3Bucket_Put(t *testing.T) {
+	ctx := context.Background()
+	s := &testS3Server{objects: map[string]string{}}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	_, err := NewS3Bucket(ctx, S3Config{Endpoint: ts.URL})
+	require.Error(t, err, "missing bucket")
+	_, err = NewS3Bucket(ctx, S3Config{Endpoint: ts.URL, Bucket: "b", AccessKeyId: "id"})
+	require.Error(t, err, "missing secret access key")
+
+	b, err := NewS3Bucket(ctx, S3Config{
+		Endpoint:        ts.URL,
+		Bucket:          "warehouse",
+		Prefix:          "boundary",
+		AccessKeyId:     "id",
+		SecretAccessKey: "secret",
+		ForcePathStyle:  true,
+	})
+	require.NoError(t, err)
+	require.Error(t, b.Put(ctx, "", []byte("x")))
+	require.NoError(t, b.Put(ctx, "t/t_1.parquet", []byte("data")))
+
+	s.Lock()
+	defer s.Unlock()
+	assert.Equal(t, map[string]string{"/warehouse/boundary/t/t_1.parquet": "data"}, s.objects)
+	require.Len(t, s.auth, 1)
+	assert.Contains(t, s.auth[0], "Credential=id/")
+}
@@ -0,0 +1,238 @@
+package warehouse
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// columnKind is the kind of value held by a column of an exported table.
+type columnKind int
+
+const (
+	stringColumn columnKind = iota
+	intColumn
+	floatColumn
+	boolColumn
+	timeColumn
+)
+
+type column struct {
+	name string
+	kind columnKind
+}
+
+// columnsFromRows returns the columns of rows with the kind of each column
+// derived from its database type. Columns with unknown types are exported as
+// strings.
+func columnsFromRows(rows *sql.Rows) ([]column, error) {
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	cols := make([]column, 0, len(types))
+	for _, t := range types {
+		c := column{name: t.Name(), kind: stringColumn}
+		switch strings.ToUpper(t.DatabaseTypeName()) {
+		case "INT2", "INT4", "INT8":
+			c.kind = intColumn
+		case "FLOAT4", "FLOAT8":
+			c.kind = floatColumn
+		case "BOOL":
+			c.kind = boolColumn
+		case "TIMESTAMP", "TIMESTAMPTZ":
+			c.kind = timeColumn
+		}
+		cols = append(cols, c)
+	}
+	return cols, nil
+}
+
+// An encoder writes rows of a table to a file in one of the export formats.
+type encoder interface {
+	// write adds a row to the file. The row holds one value, as returned by
+	// the database driver, for each column.
+	write(row []interface{}) error
+	// close finishes the file and returns its contents.
+	close() ([]byte, error)
+}
+
+func newEncoder(ctx context.Context, f Format, cols []column) (encoder, error) {
+	const op = "warehouse.newEncoder"
+	switch f {
+	case CsvFormat:
+		return newCsvEncoder(cols)
+	case ParquetFormat:
+		return newParquetEncoder(cols)
+	default:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unknown format %q", f))
+	}
+}
+
+// csvEncoder writes a header line with the column names followed by one line
+// for each row. Times are written in RFC 3339 format in UTC and null values
+// are written as empty fields.
+type csvEncoder struct {
+	cols []column
+	buf  bytes.Buffer
+	w    *csv.Writer
+}
+
+func newCsvEncoder(cols []column) (*csvEncoder, error) {
+	e := &csvEncoder{cols: cols}
+	e.w = csv.NewWriter(&e.buf)
+	header := make([]string, 0, len(cols))
+	for _, c := range cols {
+		header = append(header, c.name)
+	}
+	if err := e.w.Write(header); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *csvEncoder) write(row []interface{}) error {
+	rec := make([]string, 0, len(row))
+	for _, v := range row {
+		switch t := v.(type) {
+		case nil:
+			rec = append(rec, "")
+		case []byte:
+			rec = append(rec, string(t))
+		case time.Time:
+			rec = append(rec, t.UTC().Format(time.RFC3339Nano))
+		case float64:
+			rec = append(rec, strconv.FormatFloat(t, 'f', -1, 64))
+		case float32:
+			rec = append(rec, strconv.FormatFloat(float64(t), 'f', -1, 32))
+		default:
+			rec = append(rec, fmt.Sprint(t))
+		}
+	}
+	return e.w.Write(rec)
+}
+
+func (e *csvEncoder) close() ([]byte, error) {
+	e.w.Flush()
+	if err := e.w.Error(); err != nil {
+		return nil, err
+	}
+	return e.buf.Bytes(), nil
+}
+
+// parquetEncoder writes a snappy compressed Parquet file with one optional
+// field for each column. Times are stored as microseconds since the Unix
+// epoch. Infinite times, which the warehouse uses for events which have not
+// happened yet, are stored as null.
+type parquetEncoder struct {
+	cols []column
+	buf  bytes.Buffer
+	w    *writer.CSVWriter
+}
+
+func newParquetEncoder(cols []column) (*parquetEncoder, error) {
+	md := make([]string, 0, len(cols))
+	for _, c := range cols {
+		var typ string
+		switch c.kind {
+		case intColumn:
+			typ = "type=INT64"
+		case floatColumn:
+			typ = "type=DOUBLE"
+		case boolColumn:
+			typ = "type=BOOLEAN"
+		case timeColumn:
+			typ = "type=INT64, convertedtype=TIMESTAMP_MICROS"
+		default:
+			typ = "type=BYTE_ARRAY, convertedtype=UTF8"
+		}
+		md = append(md, fmt.Sprintf("name=%s, %s, repetitiontype=OPTIONAL", c.name, typ))
+	}
+	e := &parquetEncoder{cols: cols}
+	w, err := writer.NewCSVWriterFromWriter(md, &e.buf, 1)
+	if err != nil {
+		return nil, err
+	}
+	e.w = w
+	return e, nil
+}
+
+func (e *parquetEncoder) write(row []interface{}) error {
+	if len(row) != len(e.cols) {
+		return fmt.Errorf("row has %d values for %d columns", len(row), len(e.cols))
+	}
+	rec := make([]interface{}, len(row))
+	for i, v := range row {
+		pv, err := parquetValue(e.cols[i], v)
+		if err != nil {
+			return fmt.Errorf("column %s: %w", e.cols[i].name, err)
+		}
+		rec[i] = pv
+	}
+	return e.w.Write(rec)
+}
+
+func (e *parquetEncoder) close() ([]byte, error) {
+	if err := e.w.WriteStop(); err != nil {
+		return nil, err
+	}
+	return e.buf.Bytes(), nil
+}
+
+// parquetValue converts v, as returned by the database driver, into the Go
+// type the parquet writer expects for the column.
+func parquetValue(c column, v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch c.kind {
+	case intColumn:
+		switch t := v.(type) {
+		case int64:
+			return t, nil
+		case int32:
+			return int64(t), nil
+		case int16:
+			return int64(t), nil
+		}
+	case floatColumn:
+		switch t := v.(type) {
+		case float64:
+			return t, nil
+		case float32:
+			return float64(t), nil
+		}
+	case boolColumn:
+		if t, ok := v.(bool); ok {
+			return t, nil
+		}
+	case timeColumn:
+		switch t := v.(type) {
+		case time.Time:
+			return t.UnixNano() / int64(time.Microsecond), nil
+		case string:
+			// infinity and -infinity
+			return nil, nil
+		}
+	default:
+		switch t := v.(type) {
+		case string:
+			return t, nil
+		case []byte:
+			return string(t), nil
+		case time.Time:
+			return t.UTC().Format(time.RFC3339Nano), nil
+		default:
+			return fmt.Sprint(t), nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected value type %T", v)
+}
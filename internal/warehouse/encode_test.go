@@ -0,0 +1,105 @@
+package warehouse
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func testColumns() []column {
+	return []column{
+		{name: "key", kind: stringColumn},
+		{name: "count", kind: intColumn},
+		{name: "ratio", kind: floatColumn},
+		{name: "active", kind: boolColumn},
+		{name: "start_time", kind: timeColumn},
+	}
+}
+
+func TestCsvEncoder(t *testing.T) {
+	ctx := context.Background()
+	ts := time.Date(2021, 10, 4, 13, 5, 6, 7000, time.FixedZone("x", 3600))
+
+	enc, err := newEncoder(ctx, CsvFormat, testColumns())
+	require.NoError(t, err)
+	require.NoError(t, enc.write([]interface{}{"a", int64(1), 1.5, true, ts}))
+	require.NoError(t, enc.write([]interface{}{[]byte("b,c"), nil, nil, false, "infinity"}))
+	got, err := enc.close()
+	require.NoError(t, err)
+
+	want := "key,count,ratio,active,start_time\n" +
+		"a,1,1.5,true,2021-10-04T12:05:06.000007Z\n" +
+		"\"b,c\",,,false,infinity\n"
+	assert.Equal(t, want, string(got))
+}
+
+func TestParquetEncoder(t *testing.T) {
+	ctx := context.Background()
+	ts := time.Date(2021, 10, 4, 13, 5, 6, 7000, time.UTC)
+
+	enc, err := newEncoder(ctx, ParquetFormat, testColumns())
+	require.NoError(t, err)
+	require.NoError(t, enc.write([]interface{}{"a", int64(1), 1.5, true, ts}))
+	require.NoError(t, enc.write([]interface{}{[]byte("b"), int32(2), nil, nil, "infinity"}))
+	got, err := enc.close()
+	require.NoError(t, err)
+
+	f, err := buffer.NewBufferFile(got)
+	require.NoError(t, err)
+	pr, err := reader.NewParquetColumnReader(f, 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+	assert.Equal(t, int64(2), pr.GetNumRows())
+
+	read := func(i int64) []interface{} {
+		t.Helper()
+		vals, _, _, err := pr.ReadColumnByIndex(i, 2)
+		require.NoError(t, err)
+		return vals
+	}
+	assert.Equal(t, []interface{}{"a", "b"}, read(0))
+	assert.Equal(t, []interface{}{int64(1), int64(2)}, read(1))
+	assert.Equal(t, []interface{}{1.5, nil}, read(2))
+	assert.Equal(t, []interface{}{true, nil}, read(3))
+	assert.Equal(t, []interface{}{ts.UnixNano() / 1000, nil}, read(4))
+}
+
+func TestParquetValue(t *testing.T) {
+	_, err := parquetValue(column{name: "count", kind: intColumn}, "one")
+	assert.Error(t, err)
+
+	got, err := parquetValue(column{name: "count", kind: intColumn}, int16(3))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), got)
+}
+
+func TestParseFormat(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		in      string
+		want    Format
+		wantErr bool
+	}{
+		{in: "", want: ParquetFormat},
+		{in: "parquet", want: ParquetFormat},
+		{in: " CSV ", want: CsvFormat},
+		{in: "json", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseFormat(ctx, tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
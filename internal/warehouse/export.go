@@ -0,0 +1,56 @@
+// Package warehouse exports the session data warehouse to files for use by
+// systems which cannot query the Boundary database directly.
+//
+// The export job runs on the scheduler. Each run writes the rows of each
+// exported table which were inserted or updated since the previous run to a
+// new file in the configured Destination. The update time up to which rows
+// have been exported is stored for each table in the wh_export_watermark
+// table, so a run which fails is retried from the same point by the next run.
+package warehouse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// Format is the file format of exported tables.
+type Format string
+
+const (
+	ParquetFormat Format = "parquet"
+	CsvFormat     Format = "csv"
+)
+
+// ParseFormat returns the Format for s. The empty string returns the default
+// format, ParquetFormat.
+func ParseFormat(ctx context.Context, s string) (Format, error) {
+	const op = "warehouse.ParseFormat"
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case "":
+		return ParquetFormat, nil
+	case ParquetFormat, CsvFormat:
+		return f, nil
+	default:
+		return "", errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unknown export format %q", s))
+	}
+}
+
+// extension returns the file name extension for files in the format.
+func (f Format) extension() string {
+	return "." + string(f)
+}
+
+// exportedTables are the warehouse tables written by the export job. Each
+// table has an update_time column which is set when a row is inserted or
+// updated.
+var exportedTables = []string{
+	"wh_host_dimension",
+	"wh_user_dimension",
+	"wh_credential_dimension",
+	"wh_credential_group_membership",
+	"wh_session_accumulating_fact",
+	"wh_session_connection_accumulating_fact",
+}
@@ -0,0 +1,211 @@
+package warehouse
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	ua "go.uber.org/atomic"
+)
+
+const (
+	exportJobName         = "warehouse_export"
+	defaultExportInterval = time.Hour
+
+	// exportLag is how far behind the current time the high watermark of a
+	// run is set.
+	exportLag = time.Minute
+)
+
+// exportJob is the recurring job which exports new and changed rows of the
+// warehouse tables to a Destination. The exportJob is not thread safe, an
+// attempt to Run the job concurrently will result in a JobAlreadyRunning
+// error.
+type exportJob struct {
+	reader   db.Reader
+	writer   db.Writer
+	dest     Destination
+	format   Format
+	interval time.Duration
+	lag      time.Duration
+
+	running      ua.Bool
+	numTables    int
+	numProcessed int
+}
+
+// newExportJob creates a new in-memory exportJob.
+//
+// WithFormat and WithInterval are the only supported options.
+func newExportJob(ctx context.Context, r db.Reader, w db.Writer, dest Destination, opt ...Option) (*exportJob, error) {
+	const op = "warehouse.newExportJob"
+	switch {
+	case r == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Reader")
+	case w == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Writer")
+	case dest == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing destination")
+	}
+	opts := getOpts(opt...)
+	if _, err := ParseFormat(ctx, string(opts.withFormat)); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return &exportJob{
+		reader:   r,
+		writer:   w,
+		dest:     dest,
+		format:   opts.withFormat,
+		interval: opts.withInterval,
+		lag:      exportLag,
+	}, nil
+}
+
+// Status returns the current status of the export job. Total is the number of
+// exported tables. Completed is the number of tables already exported in the
+// current run.
+func (j *exportJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: j.numProcessed,
+		Total:     j.numTables,
+	}
+}
+
+// Run exports the rows of each warehouse table which were inserted or updated
+// since the previous run. The watermark of a table is only advanced once its
+// file has been stored, so if Run fails the rows are exported again by the
+// next run. Can not be run in parallel, if Run is invoked while already
+// running an error with code JobAlreadyRunning will be returned.
+func (j *exportJob) Run(ctx context.Context) error {
+	const op = "warehouse.(exportJob).Run"
+	if !j.running.CAS(j.running.Load(), true) {
+		return errors.New(ctx, errors.JobAlreadyRunning, op, "job already running")
+	}
+	defer j.running.Store(false)
+
+	// Verify context is not done before running
+	if err := ctx.Err(); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	hw, err := j.highWatermark(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	j.numProcessed, j.numTables = 0, len(exportedTables)
+	for _, table := range exportedTables {
+		if err := j.exportTable(ctx, table, hw); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg(table))
+		}
+		j.numProcessed++
+	}
+	return nil
+}
+
+func (j *exportJob) highWatermark(ctx context.Context) (time.Time, error) {
+	const op = "warehouse.(exportJob).highWatermark"
+	rows, err := j.reader.Query(ctx, exportHighWatermarkQuery, []interface{}{j.lag.Seconds()})
+	if err != nil {
+		return time.Time{}, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var hw time.Time
+	for rows.Next() {
+		if err := rows.Scan(&hw); err != nil {
+			return time.Time{}, errors.Wrap(ctx, err, op)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return time.Time{}, errors.Wrap(ctx, err, op)
+	}
+	if hw.IsZero() {
+		return time.Time{}, errors.New(ctx, errors.NotSpecificIntegrity, op, "unable to read current time from database")
+	}
+	return hw, nil
+}
+
+// exportTable writes the rows of table with an update_time after the stored
+// watermark of the table and at or before hw to a new file, then sets the
+// watermark of the table to hw. No file is written if there are no such rows.
+func (j *exportJob) exportTable(ctx context.Context, table string, hw time.Time) error {
+	const op = "warehouse.(exportJob).exportTable"
+	args := []interface{}{
+		sql.Named("table_name", table),
+		sql.Named("high_watermark", hw),
+	}
+	rows, err := j.reader.Query(ctx, fmt.Sprintf(exportRowsQuery, table), args)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+
+	cols, err := columnsFromRows(rows)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	enc, err := newEncoder(ctx, j.format, cols)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	var n int
+	for rows.Next() {
+		row := make([]interface{}, len(cols))
+		ptrs := make([]interface{}, len(cols))
+		for i := range row {
+			ptrs[i] = &row[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := enc.write(row); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to encode row"))
+		}
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	rows.Close()
+
+	if n > 0 {
+		data, err := enc.close()
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to encode file"))
+		}
+		if err := j.dest.Put(ctx, exportFileName(table, hw, j.format), data); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+	}
+
+	if _, err := j.writer.Exec(ctx, upsertExportWatermarkQuery, args); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update watermark"))
+	}
+	return nil
+}
+
+// exportFileName returns the name of the file holding the rows of table up to
+// the high watermark hw.
+func exportFileName(table string, hw time.Time, f Format) string {
+	return path.Join(table, table+"_"+hw.UTC().Format("20060102T150405.000000Z")+f.extension())
+}
+
+// NextRunIn returns the configured export interval.
+func (j *exportJob) NextRunIn() (time.Duration, error) {
+	return j.interval, nil
+}
+
+// Name is the unique name of the job.
+func (j *exportJob) Name() string {
+	return exportJobName
+}
+
+// Description is the human readable description of the job.
+func (j *exportJob) Description() string {
+	return "Periodically exports new and changed warehouse rows to files."
+}
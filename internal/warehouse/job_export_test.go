@@ -0,0 +1,90 @@
+package warehouse
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExportJob(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	dest, err := NewLocalDirectory(ctx, t.TempDir())
+	require.NoError(t, err)
+
+	_, err = newExportJob(ctx, nil, rw, dest)
+	assert.Error(t, err)
+	_, err = newExportJob(ctx, rw, nil, dest)
+	assert.Error(t, err)
+	_, err = newExportJob(ctx, rw, rw, nil)
+	assert.Error(t, err)
+	_, err = newExportJob(ctx, rw, rw, dest, WithFormat("json"))
+	assert.Error(t, err)
+
+	j, err := newExportJob(ctx, rw, rw, dest, WithFormat(CsvFormat), WithInterval(0))
+	require.NoError(t, err)
+	assert.Equal(t, CsvFormat, j.format)
+	assert.Equal(t, defaultExportInterval, j.interval)
+	assert.Equal(t, exportJobName, j.Name())
+	next, err := j.NextRunIn()
+	require.NoError(t, err)
+	assert.Equal(t, defaultExportInterval, next)
+}
+
+func TestExportJob_Run(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	dir := t.TempDir()
+	dest, err := NewLocalDirectory(ctx, dir)
+	require.NoError(t, err)
+
+	j, err := newExportJob(ctx, rw, rw, dest, WithFormat(CsvFormat))
+	require.NoError(t, err)
+	j.lag = 0
+
+	countFiles := func(table string) int {
+		t.Helper()
+		entries, err := os.ReadDir(filepath.Join(dir, table))
+		if os.IsNotExist(err) {
+			return 0
+		}
+		require.NoError(t, err)
+		return len(entries)
+	}
+
+	_ = session.TestDefaultSession(t, conn, wrapper, iamRepo)
+	require.NoError(t, j.Run(ctx))
+	assert.Equal(t, len(exportedTables), j.Status().Total)
+	assert.Equal(t, len(exportedTables), j.Status().Completed)
+	assert.Equal(t, 1, countFiles("wh_session_accumulating_fact"))
+
+	var watermarks int
+	rows, err := rw.Query(ctx, "select count(*) from wh_export_watermark", nil)
+	require.NoError(t, err)
+	for rows.Next() {
+		require.NoError(t, rows.Scan(&watermarks))
+	}
+	require.NoError(t, rows.Close())
+	assert.Equal(t, len(exportedTables), watermarks)
+
+	// Nothing changed, so no new files are written.
+	require.NoError(t, j.Run(ctx))
+	assert.Equal(t, 1, countFiles("wh_session_accumulating_fact"))
+
+	_ = session.TestDefaultSession(t, conn, wrapper, iamRepo)
+	require.NoError(t, j.Run(ctx))
+	assert.Equal(t, 2, countFiles("wh_session_accumulating_fact"))
+}
@@ -0,0 +1,24 @@
+package warehouse
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/scheduler"
+)
+
+// RegisterJobs registers the warehouse export job with the provided scheduler.
+//
+// WithFormat and WithInterval are the only supported options.
+func RegisterJobs(ctx context.Context, scheduler *scheduler.Scheduler, r db.Reader, w db.Writer, dest Destination, opt ...Option) error {
+	const op = "warehouse.RegisterJobs"
+	exportJob, err := newExportJob(ctx, r, w, dest, opt...)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err = scheduler.RegisterJob(ctx, exportJob); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("export job"))
+	}
+	return nil
+}
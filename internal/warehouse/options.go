@@ -0,0 +1,48 @@
+package warehouse
+
+import "time"
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withFormat   Format
+	withInterval time.Duration
+}
+
+func getDefaultOptions() options {
+	return options{
+		withFormat:   ParquetFormat,
+		withInterval: defaultExportInterval,
+	}
+}
+
+// WithFormat provides the format of the exported files. The default is
+// ParquetFormat.
+func WithFormat(f Format) Option {
+	return func(o *options) {
+		if f != "" {
+			o.withFormat = f
+		}
+	}
+}
+
+// WithInterval provides the time between runs of the export job. Zero or
+// negative values use the default of one hour.
+func WithInterval(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.withInterval = d
+		}
+	}
+}
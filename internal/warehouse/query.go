@@ -0,0 +1,32 @@
+package warehouse
+
+const (
+	// exportHighWatermarkQuery returns the update time up to which rows are
+	// exported by a run. It lags behind the current time so rows written by
+	// transactions which are still in progress, whose update_time is the start
+	// time of their transaction, are exported by a later run.
+	exportHighWatermarkQuery = `
+select current_timestamp - make_interval(secs => ?);
+`
+
+	// exportRowsQuery is formatted with the name of an exported table.
+	exportRowsQuery = `
+select *
+  from %s
+ where update_time > coalesce((select high_watermark
+                                  from wh_export_watermark
+                                 where table_name = @table_name),
+                              '-infinity')
+   and update_time <= @high_watermark
+ order by update_time;
+`
+
+	upsertExportWatermarkQuery = `
+insert into wh_export_watermark
+  (table_name, high_watermark)
+values
+  (@table_name, @high_watermark)
+on conflict (table_name) do update
+  set high_watermark = excluded.high_watermark;
+`
+)
@@ -64,6 +64,7 @@ var table = &Table{
 func main() {
 	table.Body.Resources = append(table.Body.Resources,
 		account,
+		alias,
 		authMethod,
 		authToken,
 		group,
@@ -71,8 +72,12 @@ func main() {
 		hostCatalog,
 		hostSet,
 		managedGroup,
+		notification,
+		oplogChange,
+		report,
 		role,
 		scope,
+		serviceAccount,
 		session,
 		target,
 		user,
@@ -255,6 +260,10 @@ func sortedKeys(in map[string]string) []string {
 
 func clActions(typ string) []*Action {
 	listVersion := strings.TrimPrefix(strings.TrimPrefix(typ, "an "), "a ")
+	plural := listVersion + "s"
+	if strings.HasSuffix(listVersion, "s") {
+		plural = listVersion + "es"
+	}
 	return []*Action{
 		{
 			Name:        "create",
@@ -265,7 +274,7 @@ func clActions(typ string) []*Action {
 		},
 		{
 			Name:        "list",
-			Description: fmt.Sprintf("List %ss", listVersion),
+			Description: fmt.Sprintf("List %s", plural),
 			Examples: []string{
 				"type=<type>;actions=list",
 			},
@@ -342,11 +351,57 @@ var account = &Resource{
 						"id=<pin>;type=<type>;actions=change-password",
 					},
 				},
+				&Action{
+					Name:        "enroll-totp",
+					Description: "Enroll a password account in TOTP multi-factor authentication",
+					Examples: []string{
+						"id=<id>;actions=enroll-totp",
+						"id=<pin>;type=<type>;actions=enroll-totp",
+					},
+				},
+				&Action{
+					Name:        "remove-totp",
+					Description: "Remove the TOTP enrollment of a password account",
+					Examples: []string{
+						"id=<id>;actions=remove-totp",
+						"id=<pin>;type=<type>;actions=remove-totp",
+					},
+				},
+				&Action{
+					Name:        "revoke-tokens",
+					Description: "Revoke all auth tokens issued to an account",
+					Examples: []string{
+						"id=<id>;actions=revoke-tokens",
+						"id=<pin>;type=<type>;actions=revoke-tokens",
+					},
+				},
 			),
 		},
 	},
 }
 
+var alias = &Resource{
+	Type:   "Alias",
+	Scopes: append(iamScopes, infraScope...),
+	Endpoints: []*Endpoint{
+		{
+			Path: "/aliases",
+			Params: map[string]string{
+				"Type": "alias",
+			},
+			Actions: clActions("an alias"),
+		},
+		{
+			Path: "/aliases/<id>",
+			Params: map[string]string{
+				"ID":   "<id>",
+				"Type": "alias",
+			},
+			Actions: rudActions("an alias", false),
+		},
+	},
+}
+
 var authMethod = &Resource{
 	Type:   "Auth Method",
 	Scopes: iamScopes,
@@ -373,6 +428,27 @@ var authMethod = &Resource{
 						"id=<id>;actions=authenticate",
 					},
 				},
+				&Action{
+					Name:        "revoke-tokens",
+					Description: "Revoke all auth tokens issued by an auth method",
+					Examples: []string{
+						"id=<id>;actions=revoke-tokens",
+					},
+				},
+				&Action{
+					Name:        "rotate-scim-token",
+					Description: "Generate a new SCIM provisioning token for an auth method, replacing any existing one",
+					Examples: []string{
+						"id=<id>;actions=rotate-scim-token",
+					},
+				},
+				&Action{
+					Name:        "delete-scim-token",
+					Description: "Delete the SCIM provisioning token of an auth method",
+					Examples: []string{
+						"id=<id>;actions=delete-scim-token",
+					},
+				},
 			),
 		},
 	},
@@ -388,6 +464,13 @@ var authToken = &Resource{
 				"Type": "auth-token",
 			},
 			Actions: []*Action{
+				{
+					Name:        "create",
+					Description: "Create a derived auth token from the auth token of the request",
+					Examples: []string{
+						"type=<type>;actions=create",
+					},
+				},
 				{
 					Name:        "list",
 					Description: "List auth tokens",
@@ -423,6 +506,67 @@ var authToken = &Resource{
 	},
 }
 
+var oplogChange = &Resource{
+	Type:   "Oplog Change",
+	Scopes: []string{"Global"},
+	Endpoints: []*Endpoint{
+		{
+			Path: "/oplog-changes",
+			Params: map[string]string{
+				"Type": "oplog-change",
+			},
+			Actions: []*Action{
+				{
+					Name:        "list",
+					Description: "List decrypted oplog changes",
+					Examples: []string{
+						"type=<type>;actions=list",
+					},
+				},
+			},
+		},
+	},
+}
+
+var report = &Resource{
+	Type:   "Report",
+	Scopes: append(iamScopes, infraScope...),
+	Endpoints: []*Endpoint{
+		{
+			Path: "/reports",
+			Params: map[string]string{
+				"Type": "report",
+			},
+			Actions: []*Action{
+				{
+					Name:        "list",
+					Description: "List reports",
+					Examples: []string{
+						"type=<type>;actions=list",
+					},
+				},
+			},
+		},
+		{
+			Path: "/reports/<id>",
+			Params: map[string]string{
+				"ID":   "<id>",
+				"Type": "report",
+			},
+			Actions: []*Action{
+				{
+					Name:        "run",
+					Description: "Run a report over the warehouse data of the scope and its child scopes",
+					Examples: []string{
+						"id=<id>;actions=run",
+						"id=*;type=report;actions=run",
+					},
+				},
+			},
+		},
+	},
+}
+
 var group = &Resource{
 	Type:   "Group",
 	Scopes: append(iamScopes, infraScope...),
@@ -585,6 +729,28 @@ var managedGroup = &Resource{
 	},
 }
 
+var notification = &Resource{
+	Type:   "Notification",
+	Scopes: iamScopes,
+	Endpoints: []*Endpoint{
+		{
+			Path: "/notifications",
+			Params: map[string]string{
+				"Type": "notification",
+			},
+			Actions: clActions("a notification"),
+		},
+		{
+			Path: "/notifications/<id>",
+			Params: map[string]string{
+				"ID":   "<id>",
+				"Type": "notification",
+			},
+			Actions: rudActions("a notification", false),
+		},
+	},
+}
+
 var role = &Resource{
 	Type:   "Role",
 	Scopes: append(iamScopes, infraScope...),
@@ -668,7 +834,68 @@ var scope = &Resource{
 				"ID":   "<id>",
 				"Type": "scope",
 			},
-			Actions: rudActions("a scope", false),
+			Actions: append(
+				rudActions("a scope", false),
+				&Action{
+					Name:        "revoke-tokens",
+					Description: "Revoke all auth tokens of the users in a scope",
+					Examples: []string{
+						"id=<id>;actions=revoke-tokens",
+					},
+				},
+				&Action{
+					Name:        "cancel-sessions",
+					Description: "Cancel the sessions in a scope and its child scopes",
+					Examples: []string{
+						"id=<id>;actions=cancel-sessions",
+					},
+				},
+			),
+		},
+	},
+}
+
+var serviceAccount = &Resource{
+	Type:   "Service Account",
+	Scopes: iamScopes,
+	Endpoints: []*Endpoint{
+		{
+			Path: "/service-accounts",
+			Params: map[string]string{
+				"Type": "service-account",
+			},
+			Actions: clActions("a service account"),
+		},
+		{
+			Path: "/service-accounts/<id>",
+			Params: map[string]string{
+				"ID":   "<id>",
+				"Type": "service-account",
+			},
+			Actions: append(
+				rudActions("a service account", false),
+				&Action{
+					Name:        "add-credential",
+					Description: "Add a public key or client secret credential to a service account",
+					Examples: []string{
+						"id=<id>;actions=add-credential",
+					},
+				},
+				&Action{
+					Name:        "remove-credential",
+					Description: "Remove a credential from a service account",
+					Examples: []string{
+						"id=<id>;actions=remove-credential",
+					},
+				},
+				&Action{
+					Name:        "authenticate",
+					Description: "Authenticate as a service account to receive an auth token",
+					Examples: []string{
+						"id=<id>;actions=authenticate",
+					},
+				},
+			),
 		},
 	},
 }
@@ -779,6 +1006,13 @@ var target = &Resource{
 						"id=<id>;actions=authorize-session",
 					},
 				},
+				&Action{
+					Name:        "cancel-sessions",
+					Description: "Cancel the sessions of a target",
+					Examples: []string{
+						"id=<id>;actions=cancel-sessions",
+					},
+				},
 			),
 		},
 	},
@@ -824,6 +1058,27 @@ var user = &Resource{
 						"id=<id>;actions=remove-accounts",
 					},
 				},
+				&Action{
+					Name:        "revoke-tokens",
+					Description: "Revoke all auth tokens of a user",
+					Examples: []string{
+						"id=<id>;actions=revoke-tokens",
+					},
+				},
+				&Action{
+					Name:        "read-activity",
+					Description: "Read the authentication and session authorization history of a user",
+					Examples: []string{
+						"id=<id>;actions=read-activity",
+					},
+				},
+				&Action{
+					Name:        "cancel-sessions",
+					Description: "Cancel the sessions of a user",
+					Examples: []string{
+						"id=<id>;actions=cancel-sessions",
+					},
+				},
 			),
 		},
 	},
@@ -0,0 +1,339 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.17.3
+// source: controller/api/resources/oplogchanges/v1/oplog_change.proto
+
+package oplogchanges
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// OplogChange contains a single decrypted entry of the operations log.
+type OplogChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Output only. The ID of the oplog entry. IDs are monotonically increasing and can be used as the since_id of a subsequent list request.
+	Id uint32 `protobuf:"varint,10,opt,name=id,proto3" json:"id,omitempty"`
+	// Output only. The ID of the Scope whose oplog key encrypted the entry.
+	ScopeId string `protobuf:"bytes,20,opt,name=scope_id,proto3" json:"scope_id,omitempty"`
+	// Output only. The name of the aggregate the entry was written for.
+	AggregateName string `protobuf:"bytes,30,opt,name=aggregate_name,proto3" json:"aggregate_name,omitempty"`
+	// Output only. The time the entry was written.
+	CreatedTime *timestamppb.Timestamp `protobuf:"bytes,40,opt,name=created_time,proto3" json:"created_time,omitempty"`
+	// Output only. The metadata written with the entry. Each key maps to a list of values.
+	Metadata *structpb.Struct `protobuf:"bytes,50,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Output only. The operations contained in the entry, in the order they were applied.
+	Operations []*OplogOperation `protobuf:"bytes,60,rep,name=operations,proto3" json:"operations,omitempty"`
+}
+
+func (x *OplogChange) Reset() {
+	*x = OplogChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_api_resources_oplogchanges_v1_oplog_change_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OplogChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OplogChange) ProtoMessage() {}
+
+func (x *OplogChange) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_api_resources_oplogchanges_v1_oplog_change_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OplogChange.ProtoReflect.Descriptor instead.
+func (*OplogChange) Descriptor() ([]byte, []int) {
+	return file_controller_api_resources_oplogchanges_v1_oplog_change_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OplogChange) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *OplogChange) GetScopeId() string {
+	if x != nil {
+		return x.ScopeId
+	}
+	return ""
+}
+
+func (x *OplogChange) GetAggregateName() string {
+	if x != nil {
+		return x.AggregateName
+	}
+	return ""
+}
+
+func (x *OplogChange) GetCreatedTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTime
+	}
+	return nil
+}
+
+func (x *OplogChange) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *OplogChange) GetOperations() []*OplogOperation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+// OplogOperation is a single operation contained in an oplog entry.
+type OplogOperation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Output only. The name of the type of the operation's value, which is the name of the modified table.
+	TypeName string `protobuf:"bytes,10,opt,name=type_name,proto3" json:"type_name,omitempty"`
+	// Output only. The type of the operation, for example create, update or delete.
+	OpType string `protobuf:"bytes,20,opt,name=op_type,proto3" json:"op_type,omitempty"`
+	// Output only. The fields that were set by an update operation.
+	FieldMaskPaths []string `protobuf:"bytes,30,rep,name=field_mask_paths,proto3" json:"field_mask_paths,omitempty"`
+	// Output only. The fields that were set to null by an update operation.
+	SetToNullPaths []string `protobuf:"bytes,40,rep,name=set_to_null_paths,proto3" json:"set_to_null_paths,omitempty"`
+	// Output only. The value of the operation with secret fields removed. It is not set if the type of the operation can not be decoded.
+	Value *structpb.Struct `protobuf:"bytes,50,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *OplogOperation) Reset() {
+	*x = OplogOperation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_api_resources_oplogchanges_v1_oplog_change_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OplogOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OplogOperation) ProtoMessage() {}
+
+func (x *OplogOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_api_resources_oplogchanges_v1_oplog_change_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OplogOperation.ProtoReflect.Descriptor instead.
+func (*OplogOperation) Descriptor() ([]byte, []int) {
+	return file_controller_api_resources_oplogchanges_v1_oplog_change_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OplogOperation) GetTypeName() string {
+	if x != nil {
+		return x.TypeName
+	}
+	return ""
+}
+
+func (x *OplogOperation) GetOpType() string {
+	if x != nil {
+		return x.OpType
+	}
+	return ""
+}
+
+func (x *OplogOperation) GetFieldMaskPaths() []string {
+	if x != nil {
+		return x.FieldMaskPaths
+	}
+	return nil
+}
+
+func (x *OplogOperation) GetSetToNullPaths() []string {
+	if x != nil {
+		return x.SetToNullPaths
+	}
+	return nil
+}
+
+func (x *OplogOperation) GetValue() *structpb.Struct {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+var File_controller_api_resources_oplogchanges_v1_oplog_change_proto protoreflect.FileDescriptor
+
+var file_controller_api_resources_oplogchanges_v1_oplog_change_proto_rawDesc = []byte{
+	0x0a, 0x3b, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2f, 0x6f, 0x70, 0x6c, 0x6f, 0x67,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x70, 0x6c, 0x6f, 0x67,
+	0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x28, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x6f, 0x70, 0x6c, 0x6f, 0x67, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb0, 0x02, 0x0a, 0x0b, 0x4f, 0x70, 0x6c, 0x6f, 0x67,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x5f,
+	0x69, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x0c, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x28, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x32, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x58, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x3c, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x6f,
+	0x70, 0x6c, 0x6f, 0x67, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f,
+	0x70, 0x6c, 0x6f, 0x67, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xd1, 0x01, 0x0a, 0x0e, 0x4f, 0x70,
+	0x6c, 0x6f, 0x67, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09,
+	0x74, 0x79, 0x70, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x70,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x70, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61,
+	0x73, 0x6b, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x1e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73,
+	0x12, 0x2c, 0x0a, 0x11, 0x73, 0x65, 0x74, 0x5f, 0x74, 0x6f, 0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x5f,
+	0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x28, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x73, 0x65, 0x74,
+	0x5f, 0x74, 0x6f, 0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x12, 0x2d,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x32, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x5a, 0x5a,
+	0x58, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x61, 0x73, 0x68,
+	0x69, 0x63, 0x6f, 0x72, 0x70, 0x2f, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x2f, 0x73,
+	0x64, 0x6b, 0x2f, 0x70, 0x62, 0x73, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
+	0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2f,
+	0x6f, 0x70, 0x6c, 0x6f, 0x67, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x3b, 0x6f, 0x70, 0x6c,
+	0x6f, 0x67, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_controller_api_resources_oplogchanges_v1_oplog_change_proto_rawDescOnce sync.Once
+	file_controller_api_resources_oplogchanges_v1_oplog_change_proto_rawDescData = file_controller_api_resources_oplogchanges_v1_oplog_change_proto_rawDesc
+)
+
+func file_controller_api_resources_oplogchanges_v1_oplog_change_proto_rawDescGZIP() []byte {
+	file_controller_api_resources_oplogchanges_v1_oplog_change_proto_rawDescOnce.Do(func() {
+		file_controller_api_resources_oplogchanges_v1_oplog_change_proto_rawDescData = protoimpl.X.CompressGZIP(file_controller_api_resources_oplogchanges_v1_oplog_change_proto_rawDescData)
+	})
+	return file_controller_api_resources_oplogchanges_v1_oplog_change_proto_rawDescData
+}
+
+var file_controller_api_resources_oplogchanges_v1_oplog_change_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_controller_api_resources_oplogchanges_v1_oplog_change_proto_goTypes = []interface{}{
+	(*OplogChange)(nil),           // 0: controller.api.resources.oplogchanges.v1.OplogChange
+	(*OplogOperation)(nil),        // 1: controller.api.resources.oplogchanges.v1.OplogOperation
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),       // 3: google.protobuf.Struct
+}
+var file_controller_api_resources_oplogchanges_v1_oplog_change_proto_depIdxs = []int32{
+	2, // 0: controller.api.resources.oplogchanges.v1.OplogChange.created_time:type_name -> google.protobuf.Timestamp
+	3, // 1: controller.api.resources.oplogchanges.v1.OplogChange.metadata:type_name -> google.protobuf.Struct
+	1, // 2: controller.api.resources.oplogchanges.v1.OplogChange.operations:type_name -> controller.api.resources.oplogchanges.v1.OplogOperation
+	3, // 3: controller.api.resources.oplogchanges.v1.OplogOperation.value:type_name -> google.protobuf.Struct
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_controller_api_resources_oplogchanges_v1_oplog_change_proto_init() }
+func file_controller_api_resources_oplogchanges_v1_oplog_change_proto_init() {
+	if File_controller_api_resources_oplogchanges_v1_oplog_change_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_controller_api_resources_oplogchanges_v1_oplog_change_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OplogChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_api_resources_oplogchanges_v1_oplog_change_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OplogOperation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_controller_api_resources_oplogchanges_v1_oplog_change_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_controller_api_resources_oplogchanges_v1_oplog_change_proto_goTypes,
+		DependencyIndexes: file_controller_api_resources_oplogchanges_v1_oplog_change_proto_depIdxs,
+		MessageInfos:      file_controller_api_resources_oplogchanges_v1_oplog_change_proto_msgTypes,
+	}.Build()
+	File_controller_api_resources_oplogchanges_v1_oplog_change_proto = out.File
+	file_controller_api_resources_oplogchanges_v1_oplog_change_proto_rawDesc = nil
+	file_controller_api_resources_oplogchanges_v1_oplog_change_proto_goTypes = nil
+	file_controller_api_resources_oplogchanges_v1_oplog_change_proto_depIdxs = nil
+}